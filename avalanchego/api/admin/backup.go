@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package admin
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	// Name of the file, in the backup directory, that the manifest is written
+	// to
+	backupManifestFile = "manifest.json"
+
+	// Number of bytes written to the backup per batch
+	backupBatchSize = 4 * units.MiB
+)
+
+// BackupManifest describes a complete database backup
+type BackupManifest struct {
+	// Time at which the snapshot that was backed up was taken
+	CreatedAt time.Time `json:"createdAt"`
+	// Version of the database that was backed up. The backup is stored in a
+	// sub-directory of the same name, so that the backup directory can be
+	// used as the node's database directory.
+	DatabaseVersion string      `json:"databaseVersion"`
+	Keys            json.Uint64 `json:"keys"`
+	Bytes           json.Uint64 `json:"bytes"`
+}
+
+// backupDB copies [snapshot] into a new database in [dir] and then writes the
+// manifest of the backup to [dir]. The manifest is only written if the backup
+// completes.
+func backupDB(
+	log logging.Logger,
+	snapshot database.Snapshot,
+	createdAt time.Time,
+	dbVersion string,
+	dir string,
+) (BackupManifest, error) {
+	manifest := BackupManifest{
+		CreatedAt:       createdAt,
+		DatabaseVersion: dbVersion,
+	}
+
+	dbPath := filepath.Join(dir, dbVersion)
+	db, err := leveldb.New(dbPath, nil, log, "", prometheus.NewRegistry())
+	if err != nil {
+		return manifest, fmt.Errorf("couldn't create backup database at %s: %w", dbPath, err)
+	}
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		key, value := it.Key(), it.Value()
+		if err := batch.Put(key, value); err != nil {
+			_ = db.Close()
+			return manifest, err
+		}
+		manifest.Keys++
+		manifest.Bytes += json.Uint64(len(key) + len(value))
+
+		if batch.Size() < backupBatchSize {
+			continue
+		}
+		if err := batch.Write(); err != nil {
+			_ = db.Close()
+			return manifest, err
+		}
+		batch.Reset()
+	}
+	if err := it.Error(); err != nil {
+		_ = db.Close()
+		return manifest, fmt.Errorf("couldn't iterate over snapshot: %w", err)
+	}
+	if err := batch.Write(); err != nil {
+		_ = db.Close()
+		return manifest, err
+	}
+	if err := db.Close(); err != nil {
+		return manifest, err
+	}
+
+	manifestBytes, err := stdjson.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return manifest, err
+	}
+	return manifest, perms.WriteFile(filepath.Join(dir, backupManifestFile), manifestBytes, perms.ReadWrite)
+}
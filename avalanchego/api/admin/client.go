@@ -6,10 +6,20 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
+
+	stdjson "encoding/json"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maintenance"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -21,14 +31,35 @@ type Client interface {
 	StopCPUProfiler(context.Context, ...rpc.Option) error
 	MemoryProfile(context.Context, ...rpc.Option) error
 	LockProfile(context.Context, ...rpc.Option) error
+	StartTrace(context.Context, ...rpc.Option) error
+	StopTrace(context.Context, ...rpc.Option) error
+	CaptureProfile(ctx context.Context, cpu, heap, trace bool, duration time.Duration, options ...rpc.Option) (string, error)
 	Alias(ctx context.Context, endpoint string, alias string, options ...rpc.Option) error
 	AliasChain(ctx context.Context, chainID string, alias string, options ...rpc.Option) error
 	GetChainAliases(ctx context.Context, chainID string, options ...rpc.Option) ([]string, error)
 	Stacktrace(context.Context, ...rpc.Option) error
 	LoadVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, map[ids.ID]string, error)
+	TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error
 	SetLoggerLevel(ctx context.Context, loggerName, logLevel, displayLevel string, options ...rpc.Option) error
 	GetLoggerLevel(ctx context.Context, loggerName string, options ...rpc.Option) (map[string]LogAndDisplayLevels, error)
 	GetConfig(ctx context.Context, options ...rpc.Option) (interface{}, error)
+	GetGossipConfig(ctx context.Context, chain string, options ...rpc.Option) (sender.GossipConfig, error)
+	SetGossipConfig(ctx context.Context, chain string, config sender.GossipConfig, options ...rpc.Option) (sender.GossipConfig, error)
+	GetSubnetConfig(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (chains.SubnetConfig, error)
+	CompactDB(ctx context.Context, chain string, options ...rpc.Option) error
+	GetDBStats(ctx context.Context, chains []string, options ...rpc.Option) (*GetDBStatsReply, error)
+	BackupDB(ctx context.Context, directory string, options ...rpc.Option) error
+	GetCapturedMessages(ctx context.Context, chain string, options ...rpc.Option) ([]router.CapturedMessage, error)
+	ExportUptimes(ctx context.Context, options ...rpc.Option) (*uptime.Attestation, error)
+	ImportUptimes(ctx context.Context, attestation *uptime.Attestation, options ...rpc.Option) (uint32, error)
+	GetObservedUptimes(ctx context.Context, options ...rpc.Option) ([]ObservedUptime, error)
+	GetConnectionGater(ctx context.Context, options ...rpc.Option) (*network.GaterConfig, error)
+	SetConnectionGater(ctx context.Context, config network.GaterConfig, options ...rpc.Option) error
+	GetDiskWatchdog(ctx context.Context, options ...rpc.Option) (*diskwatchdog.Status, error)
+	SetDiskWatchdogOverride(ctx context.Context, action diskwatchdog.Action, override diskwatchdog.Override, options ...rpc.Option) (*diskwatchdog.Status, error)
+	EnterMaintenance(ctx context.Context, drainTimeout time.Duration, options ...rpc.Option) (*maintenance.Status, error)
+	ExitMaintenance(ctx context.Context, options ...rpc.Option) (*maintenance.Status, error)
+	GetMaintenance(ctx context.Context, options ...rpc.Option) (*maintenance.Status, error)
 }
 
 // Client implementation for the Avalanche Platform Info API Endpoint
@@ -60,6 +91,32 @@ func (c *client) LockProfile(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "lockProfile", struct{}{}, &api.EmptyReply{}, options...)
 }
 
+func (c *client) StartTrace(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "startTrace", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) StopTrace(ctx context.Context, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "stopTrace", struct{}{}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) CaptureProfile(
+	ctx context.Context,
+	cpu bool,
+	heap bool,
+	trace bool,
+	duration time.Duration,
+	options ...rpc.Option,
+) (string, error) {
+	res := &CaptureProfileReply{}
+	err := c.requester.SendRequest(ctx, "captureProfile", &CaptureProfileArgs{
+		CPU:      cpu,
+		Heap:     heap,
+		Trace:    trace,
+		Duration: duration.String(),
+	}, res, options...)
+	return res.Dir, err
+}
+
 func (c *client) Alias(ctx context.Context, endpoint, alias string, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "alias", &AliasArgs{
 		Endpoint: endpoint,
@@ -92,6 +149,12 @@ func (c *client) LoadVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID
 	return res.NewVMs, res.FailedVMs, err
 }
 
+func (c *client) TrackSubnet(ctx context.Context, subnetID ids.ID, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "trackSubnet", &TrackSubnetArgs{
+		SubnetID: subnetID,
+	}, &api.EmptyReply{}, options...)
+}
+
 func (c *client) SetLoggerLevel(
 	ctx context.Context,
 	loggerName,
@@ -140,3 +203,133 @@ func (c *client) GetConfig(ctx context.Context, options ...rpc.Option) (interfac
 	err := c.requester.SendRequest(ctx, "getConfig", struct{}{}, &res, options...)
 	return res, err
 }
+
+func (c *client) GetGossipConfig(ctx context.Context, chain string, options ...rpc.Option) (sender.GossipConfig, error) {
+	res := &GossipConfigReply{}
+	err := c.requester.SendRequest(ctx, "getGossipConfig", &GetGossipConfigArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.GossipConfig, err
+}
+
+func (c *client) SetGossipConfig(
+	ctx context.Context,
+	chain string,
+	config sender.GossipConfig,
+	options ...rpc.Option,
+) (sender.GossipConfig, error) {
+	configBytes, err := stdjson.Marshal(config)
+	if err != nil {
+		return sender.GossipConfig{}, err
+	}
+	res := &GossipConfigReply{}
+	err = c.requester.SendRequest(ctx, "setGossipConfig", &SetGossipConfigArgs{
+		Chain:        chain,
+		GossipConfig: configBytes,
+	}, res, options...)
+	return res.GossipConfig, err
+}
+
+func (c *client) GetSubnetConfig(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (chains.SubnetConfig, error) {
+	res := &GetSubnetConfigReply{}
+	err := c.requester.SendRequest(ctx, "getSubnetConfig", &GetSubnetConfigArgs{
+		SubnetID: subnetID,
+	}, res, options...)
+	return res.SubnetConfig, err
+}
+
+func (c *client) CompactDB(ctx context.Context, chain string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "compactDB", &CompactDBArgs{
+		Chain: chain,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetDBStats(ctx context.Context, chains []string, options ...rpc.Option) (*GetDBStatsReply, error) {
+	res := &GetDBStatsReply{}
+	err := c.requester.SendRequest(ctx, "getDBStats", &GetDBStatsArgs{
+		Chains: chains,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) BackupDB(ctx context.Context, directory string, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "backupDB", &BackupDBArgs{
+		Directory: directory,
+	}, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetCapturedMessages(ctx context.Context, chain string, options ...rpc.Option) ([]router.CapturedMessage, error) {
+	res := &GetCapturedMessagesReply{}
+	err := c.requester.SendRequest(ctx, "getCapturedMessages", &GetCapturedMessagesArgs{
+		Chain: chain,
+	}, res, options...)
+	return res.Messages, err
+}
+
+func (c *client) ExportUptimes(ctx context.Context, options ...rpc.Option) (*uptime.Attestation, error) {
+	res := &ExportUptimesReply{}
+	err := c.requester.SendRequest(ctx, "exportUptimes", struct{}{}, res, options...)
+	return res.Attestation, err
+}
+
+func (c *client) ImportUptimes(ctx context.Context, attestation *uptime.Attestation, options ...rpc.Option) (uint32, error) {
+	res := &ImportUptimesReply{}
+	err := c.requester.SendRequest(ctx, "importUptimes", &ImportUptimesArgs{
+		Attestation: *attestation,
+	}, res, options...)
+	return uint32(res.Imported), err
+}
+
+func (c *client) GetObservedUptimes(ctx context.Context, options ...rpc.Option) ([]ObservedUptime, error) {
+	res := &GetObservedUptimesReply{}
+	err := c.requester.SendRequest(ctx, "getObservedUptimes", struct{}{}, res, options...)
+	return res.Peers, err
+}
+
+func (c *client) GetConnectionGater(ctx context.Context, options ...rpc.Option) (*network.GaterConfig, error) {
+	res := &network.GaterConfig{}
+	err := c.requester.SendRequest(ctx, "getConnectionGater", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SetConnectionGater(ctx context.Context, config network.GaterConfig, options ...rpc.Option) error {
+	return c.requester.SendRequest(ctx, "setConnectionGater", &config, &api.EmptyReply{}, options...)
+}
+
+func (c *client) GetDiskWatchdog(ctx context.Context, options ...rpc.Option) (*diskwatchdog.Status, error) {
+	res := &diskwatchdog.Status{}
+	err := c.requester.SendRequest(ctx, "getDiskWatchdog", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) SetDiskWatchdogOverride(ctx context.Context, action diskwatchdog.Action, override diskwatchdog.Override, options ...rpc.Option) (*diskwatchdog.Status, error) {
+	res := &diskwatchdog.Status{}
+	err := c.requester.SendRequest(ctx, "setDiskWatchdogOverride", &SetDiskWatchdogOverrideArgs{
+		Action:   action,
+		Override: override,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) EnterMaintenance(ctx context.Context, drainTimeout time.Duration, options ...rpc.Option) (*maintenance.Status, error) {
+	// A drain timeout of 0 uses the node's default.
+	args := &EnterMaintenanceArgs{}
+	if drainTimeout > 0 {
+		args.DrainTimeout = drainTimeout.String()
+	}
+	res := &maintenance.Status{}
+	err := c.requester.SendRequest(ctx, "enterMaintenance", args, res, options...)
+	return res, err
+}
+
+func (c *client) ExitMaintenance(ctx context.Context, options ...rpc.Option) (*maintenance.Status, error) {
+	res := &maintenance.Status{}
+	err := c.requester.SendRequest(ctx, "exitMaintenance", struct{}{}, res, options...)
+	return res, err
+}
+
+func (c *client) GetMaintenance(ctx context.Context, options ...rpc.Option) (*maintenance.Status, error) {
+	res := &maintenance.Status{}
+	err := c.requester.SendRequest(ctx, "getMaintenance", struct{}{}, res, options...)
+	return res, err
+}
@@ -7,12 +7,20 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maintenance"
 	"github.com/ava-labs/avalanchego/utils/rpc"
 )
 
@@ -64,6 +72,39 @@ func (mc *mockClient) SendRequest(ctx context.Context, method string, params int
 	case *GetLoggerLevelReply:
 		response := mc.response.(*GetLoggerLevelReply)
 		*p = *response
+	case *GossipConfigReply:
+		response := mc.response.(*GossipConfigReply)
+		*p = *response
+	case *GetSubnetConfigReply:
+		response := mc.response.(*GetSubnetConfigReply)
+		*p = *response
+	case *GetDBStatsReply:
+		response := mc.response.(*GetDBStatsReply)
+		*p = *response
+	case *CaptureProfileReply:
+		response := mc.response.(*CaptureProfileReply)
+		*p = *response
+	case *GetCapturedMessagesReply:
+		response := mc.response.(*GetCapturedMessagesReply)
+		*p = *response
+	case *ExportUptimesReply:
+		response := mc.response.(*ExportUptimesReply)
+		*p = *response
+	case *ImportUptimesReply:
+		response := mc.response.(*ImportUptimesReply)
+		*p = *response
+	case *GetObservedUptimesReply:
+		response := mc.response.(*GetObservedUptimesReply)
+		*p = *response
+	case *network.GaterConfig:
+		response := mc.response.(*network.GaterConfig)
+		*p = *response
+	case *diskwatchdog.Status:
+		response := mc.response.(*diskwatchdog.Status)
+		*p = *response
+	case *maintenance.Status:
+		response := mc.response.(*maintenance.Status)
+		*p = *response
 	case *interface{}:
 		response := mc.response.(*interface{})
 		*p = *response
@@ -137,6 +178,26 @@ func TestLockProfile(t *testing.T) {
 	}
 }
 
+func TestStartTrace(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.StartTrace(context.Background())
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
+func TestStopTrace(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.StopTrace(context.Background())
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
 func TestAlias(t *testing.T) {
 	tests := GetSuccessResponseTests()
 
@@ -206,6 +267,22 @@ func TestStacktrace(t *testing.T) {
 	}
 }
 
+func TestTrackSubnet(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.TrackSubnet(context.Background(), ids.GenerateTestID())
+		// if there is error as expected, the test passes
+		if err != nil && test.Err != nil {
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	}
+}
+
 func TestReloadInstalledVMs(t *testing.T) {
 	t.Run("successful", func(t *testing.T) {
 		expectedNewVMs := map[ids.ID][]string{
@@ -385,3 +462,302 @@ func TestGetConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestGetGossipConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedConfig := sender.GossipConfig{OnAcceptPeerSize: 10}
+		mockClient := client{requester: NewMockClient(&GossipConfigReply{
+			GossipConfig: expectedConfig,
+		}, nil)}
+
+		config, err := mockClient.GetGossipConfig(context.Background(), "C")
+		require.NoError(t, err)
+		require.Equal(t, expectedConfig, config)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GossipConfigReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetGossipConfig(context.Background(), "C")
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestSetGossipConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedConfig := sender.GossipConfig{AppGossipValidatorSize: 5}
+		mockClient := client{requester: NewMockClient(&GossipConfigReply{
+			GossipConfig: expectedConfig,
+		}, nil)}
+
+		config, err := mockClient.SetGossipConfig(context.Background(), "C", expectedConfig)
+		require.NoError(t, err)
+		require.Equal(t, expectedConfig, config)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GossipConfigReply{}, errors.New("some error"))}
+
+		_, err := mockClient.SetGossipConfig(context.Background(), "C", sender.GossipConfig{})
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetSubnetConfig(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedConfig := chains.SubnetConfig{ValidatorOnly: true}
+		mockClient := client{requester: NewMockClient(&GetSubnetConfigReply{
+			SubnetConfig: expectedConfig,
+		}, nil)}
+
+		config, err := mockClient.GetSubnetConfig(context.Background(), ids.GenerateTestID())
+		require.NoError(t, err)
+		require.Equal(t, expectedConfig, config)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetSubnetConfigReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetSubnetConfig(context.Background(), ids.GenerateTestID())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetDBStats(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &GetDBStatsReply{
+			Compacting: true,
+			Levels:     []LevelStats{{Tables: 2, Size: 100}},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetDBStats(context.Background(), []string{"C"})
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetDBStatsReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetDBStats(context.Background(), nil)
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestBackupDB(t *testing.T) {
+	tests := GetSuccessResponseTests()
+
+	for _, test := range tests {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, test.Err)}
+		err := mockClient.BackupDB(context.Background(), "/tmp/backup")
+		require.ErrorIs(t, err, test.Err)
+	}
+}
+
+func TestCaptureProfile(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &CaptureProfileReply{Dir: "/tmp/logs/profiles/20220101T000000Z"}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		dir, err := mockClient.CaptureProfile(context.Background(), true, false, true, time.Minute)
+		require.NoError(t, err)
+		require.Equal(t, expectedReply.Dir, dir)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&CaptureProfileReply{}, errors.New("some error"))}
+
+		_, err := mockClient.CaptureProfile(context.Background(), true, false, false, time.Minute)
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetCapturedMessages(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &GetCapturedMessagesReply{
+			Messages: []router.CapturedMessage{{
+				Op:      "put",
+				ChainID: ids.GenerateTestID(),
+			}},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		messages, err := mockClient.GetCapturedMessages(context.Background(), "C")
+		require.NoError(t, err)
+		require.Equal(t, expectedReply.Messages, messages)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetCapturedMessagesReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetCapturedMessages(context.Background(), "")
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestExportUptimes(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &ExportUptimesReply{
+			Attestation: &uptime.Attestation{
+				Records: []uptime.Record{{
+					NodeID:     ids.GenerateTestNodeID(),
+					UpDuration: time.Hour,
+				}},
+				Signature: []byte{1},
+			},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		attestation, err := mockClient.ExportUptimes(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedReply.Attestation, attestation)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ExportUptimesReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ExportUptimes(context.Background())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestImportUptimes(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ImportUptimesReply{Imported: 2}, nil)}
+
+		imported, err := mockClient.ImportUptimes(context.Background(), &uptime.Attestation{})
+		require.NoError(t, err)
+		require.EqualValues(t, 2, imported)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&ImportUptimesReply{}, errors.New("some error"))}
+
+		_, err := mockClient.ImportUptimes(context.Background(), &uptime.Attestation{})
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetObservedUptimes(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &GetObservedUptimesReply{
+			Peers: []ObservedUptime{{
+				NodeID:         ids.GenerateTestNodeID(),
+				Weight:         10,
+				ObservedUptime: 95,
+			}},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		peers, err := mockClient.GetObservedUptimes(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, expectedReply.Peers, peers)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&GetObservedUptimesReply{}, errors.New("some error"))}
+
+		_, err := mockClient.GetObservedUptimes(context.Background())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestGetConnectionGater(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &network.GaterConfig{
+			DeniedCIDRs:   []string{"10.0.0.0/8"},
+			MaxConnsPerIP: 2,
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.GetConnectionGater(context.Background())
+
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&network.GaterConfig{}, errors.New("some error"))}
+
+		_, err := mockClient.GetConnectionGater(context.Background())
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestSetConnectionGater(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, nil)}
+
+		err := mockClient.SetConnectionGater(context.Background(), network.GaterConfig{MaxConnsPerIP: 2})
+
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&api.EmptyReply{}, errors.New("some error"))}
+
+		err := mockClient.SetConnectionGater(context.Background(), network.GaterConfig{})
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestSetDiskWatchdogOverride(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &diskwatchdog.Status{
+			AvailableDiskBytes: 1024,
+			Actions: []diskwatchdog.ActionStatus{{
+				Action:   diskwatchdog.RejectAPITxs,
+				Override: diskwatchdog.OverrideForce,
+				Active:   true,
+			}},
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.SetDiskWatchdogOverride(context.Background(), diskwatchdog.RejectAPITxs, diskwatchdog.OverrideForce)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&diskwatchdog.Status{}, errors.New("some error"))}
+
+		_, err := mockClient.SetDiskWatchdogOverride(context.Background(), diskwatchdog.Halt, diskwatchdog.OverrideForce)
+
+		require.EqualError(t, err, "some error")
+	})
+}
+
+func TestEnterMaintenance(t *testing.T) {
+	t.Run("successful", func(t *testing.T) {
+		expectedReply := &maintenance.Status{
+			State:            maintenance.Draining,
+			InFlightAPICalls: 2,
+		}
+		mockClient := client{requester: NewMockClient(expectedReply, nil)}
+
+		reply, err := mockClient.EnterMaintenance(context.Background(), time.Minute)
+
+		require.NoError(t, err)
+		require.Equal(t, expectedReply, reply)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		mockClient := client{requester: NewMockClient(&maintenance.Status{}, errors.New("some error"))}
+
+		_, err := mockClient.EnterMaintenance(context.Background(), 0)
+
+		require.EqualError(t, err, "some error")
+	})
+}
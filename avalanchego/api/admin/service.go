@@ -4,9 +4,18 @@
 package admin
 
 import (
+	"crypto"
+	"crypto/x509"
 	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"path"
+	"path/filepath"
+	"sync"
+	"time"
+
+	stdjson "encoding/json"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -15,14 +24,25 @@ import (
 	"github.com/ava-labs/avalanchego/api"
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maintenance"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -32,28 +52,71 @@ const (
 
 	// Name of file that stacktraces are written to
 	stacktraceFile = "stacktrace.txt"
+
+	// Directory under the log directory that captured profiles are written to
+	captureProfileDir = "profiles"
+	// Longest window a profile can be captured for
+	maxCaptureDuration = 10 * time.Minute
+
+	// Drain timeout used when entering maintenance without specifying one
+	defaultDrainTimeout = time.Minute
 )
 
 var (
-	errAliasTooLong = errors.New("alias length is too long")
-	errNoLogLevel   = errors.New("need to specify either displayLevel or logLevel")
+	errAliasTooLong         = errors.New("alias length is too long")
+	errNoLogLevel           = errors.New("need to specify either displayLevel or logLevel")
+	errCompactionInProgress = errors.New("a database compaction is already in progress")
+	errStatsNotSupported    = errors.New("database doesn't report storage stats")
+	errBackupInProgress     = errors.New("a database backup is already in progress")
+	errSnapshotsUnsupported = errors.New("database doesn't support snapshots")
+	errNoBackupDir          = errors.New("backup directory must be specified")
+	errBackupDirExists      = errors.New("backup directory already exists")
+	errCaptureInProgress    = errors.New("a profile capture is already in progress")
+	errNothingToCapture     = errors.New("need to capture at least one of cpu, heap or trace")
+	errCaptureTooLong       = fmt.Errorf("capture duration must be positive and at most %s", maxCaptureDuration)
+	errMessageCaptureOff    = errors.New("consensus message capture is disabled")
+	errForeignAttestation   = errors.New("uptime attestation wasn't signed by this node")
+	errInvalidDrainTimeout  = errors.New("drain timeout must be positive")
 )
 
 type Config struct {
 	Log          logging.Logger
 	ProfileDir   string
+	LogDir       string
 	LogFactory   logging.Factory
 	NodeConfig   interface{}
 	ChainManager chains.Manager
 	HTTPServer   server.PathAdderWithReadLock
 	VMRegistry   registry.VMRegistry
 	VMManager    vms.Manager
+	DB           database.Database
+	DBVersion    *version.Semantic
+	// Nil if the consensus message capture is disabled
+	MessageCapture *router.MessageCapture
+	// Staking certificate and key of this node, used to sign uptime exports
+	StakingCert   *x509.Certificate
+	StakingSigner crypto.Signer
+	// Primary network validators
+	Validators       validators.Set
+	UptimeCalculator uptime.LockedCalculator
+	Network          network.Network
+	DiskWatchdog     *diskwatchdog.Watchdog
+	Maintenance      *maintenance.Mode
 }
 
 // Admin is the API service for node admin management
 type Admin struct {
 	Config
 	profiler profiler.Profiler
+
+	compactionLock sync.Mutex
+	compacting     bool
+
+	backupLock sync.Mutex
+	backingUp  bool
+
+	captureLock sync.Mutex
+	capturing   bool
 }
 
 // NewService returns a new admin API service.
@@ -100,6 +163,124 @@ func (service *Admin) LockProfile(_ *http.Request, _ *struct{}, _ *api.EmptyRepl
 	return service.profiler.LockProfile()
 }
 
+// StartTrace starts an execution trace writing to the profile directory
+func (service *Admin) StartTrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: StartTrace called")
+
+	return service.profiler.StartTrace()
+}
+
+// StopTrace stops the execution trace
+func (service *Admin) StopTrace(_ *http.Request, _ *struct{}, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: StopTrace called")
+
+	return service.profiler.StopTrace()
+}
+
+// CaptureProfileArgs are the arguments for calling CaptureProfile
+type CaptureProfileArgs struct {
+	// Record a CPU profile over the window
+	CPU bool `json:"cpu"`
+	// Take a heap snapshot at the end of the window
+	Heap bool `json:"heap"`
+	// Record an execution trace over the window
+	Trace bool `json:"trace"`
+	// Length of the window, e.g. "30s"
+	Duration string `json:"duration"`
+}
+
+// CaptureProfileReply is the response from calling CaptureProfile
+type CaptureProfileReply struct {
+	// Directory the profiles are written to
+	Dir string `json:"dir"`
+}
+
+// CaptureProfile profiles the node for the given window in the background.
+// The profiles are written to a new directory under the log directory once
+// the window ends. Only one capture may run at a time.
+func (service *Admin) CaptureProfile(_ *http.Request, args *CaptureProfileArgs, reply *CaptureProfileReply) error {
+	service.Log.Debug("Admin: CaptureProfile called",
+		zap.Bool("cpu", args.CPU),
+		zap.Bool("heap", args.Heap),
+		zap.Bool("trace", args.Trace),
+		logging.UserString("duration", args.Duration),
+	)
+
+	if !args.CPU && !args.Heap && !args.Trace {
+		return errNothingToCapture
+	}
+	duration, err := time.ParseDuration(args.Duration)
+	if err != nil {
+		return err
+	}
+	if duration <= 0 || duration > maxCaptureDuration {
+		return errCaptureTooLong
+	}
+
+	service.captureLock.Lock()
+	defer service.captureLock.Unlock()
+
+	if service.capturing {
+		return errCaptureInProgress
+	}
+
+	dir := filepath.Join(
+		service.LogDir,
+		captureProfileDir,
+		time.Now().UTC().Format("20060102T150405Z"),
+	)
+	p := profiler.New(dir)
+	if args.CPU {
+		if err := p.StartCPUProfiler(); err != nil {
+			return err
+		}
+	}
+	if args.Trace {
+		if err := p.StartTrace(); err != nil {
+			if args.CPU {
+				_ = p.StopCPUProfiler() // Return the original error
+			}
+			return err
+		}
+	}
+	service.capturing = true
+
+	service.Log.Info("starting profile capture",
+		zap.String("dir", dir),
+		zap.Duration("duration", duration),
+	)
+	time.AfterFunc(duration, func() {
+		errs := wrappers.Errs{}
+		if args.CPU {
+			errs.Add(p.StopCPUProfiler())
+		}
+		if args.Trace {
+			errs.Add(p.StopTrace())
+		}
+		if args.Heap {
+			errs.Add(p.MemoryProfile())
+		}
+
+		service.captureLock.Lock()
+		service.capturing = false
+		service.captureLock.Unlock()
+
+		if errs.Errored() {
+			service.Log.Error("profile capture failed",
+				zap.String("dir", dir),
+				zap.Error(errs.Err),
+			)
+			return
+		}
+		service.Log.Info("finished profile capture",
+			zap.String("dir", dir),
+		)
+	})
+
+	reply.Dir = dir
+	return nil
+}
+
 // AliasArgs are the arguments for calling Alias
 type AliasArgs struct {
 	Endpoint string `json:"endpoint"`
@@ -286,6 +467,22 @@ func (service *Admin) GetConfig(_ *http.Request, args *struct{}, reply *interfac
 	return nil
 }
 
+// TrackSubnetArgs are the arguments for calling TrackSubnet
+type TrackSubnetArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// TrackSubnet starts validating the given subnet without restarting the node.
+// The subnet's chains are created and connected peers are notified that this
+// node now tracks the subnet.
+func (service *Admin) TrackSubnet(_ *http.Request, args *TrackSubnetArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: TrackSubnet called",
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	return service.ChainManager.TrackSubnet(args.SubnetID)
+}
+
 // LoadVMsReply contains the response metadata for LoadVMs
 type LoadVMsReply struct {
 	// VMs and their aliases which were successfully loaded
@@ -313,3 +510,568 @@ func (service *Admin) LoadVMs(_ *http.Request, _ *struct{}, reply *LoadVMsReply)
 	reply.NewVMs, err = ids.GetRelevantAliases(service.VMManager, loadedVMs)
 	return err
 }
+
+// GetGossipConfigArgs are the arguments for calling GetGossipConfig
+type GetGossipConfigArgs struct {
+	Chain string `json:"chain"`
+}
+
+// GossipConfigReply is the gossip config currently used by a chain
+type GossipConfigReply struct {
+	GossipConfig sender.GossipConfig `json:"gossipConfig"`
+}
+
+// GetGossipConfig returns the gossip config currently used by the given chain
+func (service *Admin) GetGossipConfig(_ *http.Request, args *GetGossipConfigArgs, reply *GossipConfigReply) error {
+	service.Log.Debug("Admin: GetGossipConfig called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	reply.GossipConfig, err = service.ChainManager.GossipConfig(chainID)
+	return err
+}
+
+// SetGossipConfigArgs are the arguments for calling SetGossipConfig
+type SetGossipConfigArgs struct {
+	Chain string `json:"chain"`
+	// GossipConfig holds the fields of the gossip config to change. Omitted
+	// fields keep their current value.
+	GossipConfig stdjson.RawMessage `json:"gossipConfig"`
+}
+
+// SetGossipConfig changes the gossip config of the given chain until the node
+// is restarted, and returns the resulting config
+func (service *Admin) SetGossipConfig(_ *http.Request, args *SetGossipConfigArgs, reply *GossipConfigReply) error {
+	service.Log.Debug("Admin: SetGossipConfig called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	chainID, err := service.ChainManager.Lookup(args.Chain)
+	if err != nil {
+		return err
+	}
+
+	gossipConfig, err := service.ChainManager.GossipConfig(chainID)
+	if err != nil {
+		return err
+	}
+	if len(args.GossipConfig) != 0 {
+		if err := stdjson.Unmarshal(args.GossipConfig, &gossipConfig); err != nil {
+			return err
+		}
+	}
+	if err := service.ChainManager.SetGossipConfig(chainID, gossipConfig); err != nil {
+		return err
+	}
+
+	service.Log.Info("changed gossip config",
+		zap.Stringer("chainID", chainID),
+		zap.Reflect("gossipConfig", gossipConfig),
+	)
+	reply.GossipConfig = gossipConfig
+	return nil
+}
+
+// GetSubnetConfigArgs are the arguments for calling GetSubnetConfig
+type GetSubnetConfigArgs struct {
+	SubnetID ids.ID `json:"subnetID"`
+}
+
+// GetSubnetConfigReply is the config of a subnet
+type GetSubnetConfigReply struct {
+	SubnetConfig chains.SubnetConfig `json:"subnetConfig"`
+}
+
+// GetSubnetConfig returns the config that the chains of the given tracked
+// subnet are created with, which is the subnet's config file or flag content
+// merged with the node's defaults. Gossip config changes made with
+// SetGossipConfig aren't reflected.
+func (service *Admin) GetSubnetConfig(_ *http.Request, args *GetSubnetConfigArgs, reply *GetSubnetConfigReply) error {
+	service.Log.Debug("Admin: GetSubnetConfig called",
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	var err error
+	reply.SubnetConfig, err = service.ChainManager.SubnetConfig(args.SubnetID)
+	return err
+}
+
+// CompactDBArgs are the arguments for calling CompactDB
+type CompactDBArgs struct {
+	// Chain whose data is compacted. If empty, the whole database is
+	// compacted.
+	Chain string `json:"chain"`
+}
+
+// CompactDB starts compacting the node's database in the background. Only one
+// compaction may run at a time. Use GetDBStats to find out when it finishes.
+func (service *Admin) CompactDB(_ *http.Request, args *CompactDBArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: CompactDB called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	var start, limit []byte
+	if args.Chain != "" {
+		chainID, err := service.ChainManager.Lookup(args.Chain)
+		if err != nil {
+			return err
+		}
+		start = prefixdb.MakePrefix(chainID[:])
+		limit = prefixLimit(start)
+	}
+
+	service.compactionLock.Lock()
+	defer service.compactionLock.Unlock()
+
+	if service.compacting {
+		return errCompactionInProgress
+	}
+	service.compacting = true
+
+	go func() {
+		service.Log.Info("starting database compaction",
+			logging.UserString("chain", args.Chain),
+		)
+		startTime := time.Now()
+		err := service.DB.Compact(start, limit)
+
+		service.compactionLock.Lock()
+		service.compacting = false
+		service.compactionLock.Unlock()
+
+		if err != nil {
+			service.Log.Error("database compaction failed",
+				logging.UserString("chain", args.Chain),
+				zap.Error(err),
+			)
+			return
+		}
+		service.Log.Info("finished database compaction",
+			logging.UserString("chain", args.Chain),
+			zap.Duration("duration", time.Since(startTime)),
+		)
+	}()
+	return nil
+}
+
+// GetDBStatsArgs are the arguments for calling GetDBStats
+type GetDBStatsArgs struct {
+	// Chains whose approximate size is reported
+	Chains []string `json:"chains"`
+}
+
+// LevelStats describes one level of the database
+type LevelStats struct {
+	Tables json.Uint64 `json:"tables"`
+	Size   json.Uint64 `json:"size"`
+}
+
+// GetDBStatsReply describes how the node's database is laid out on disk
+type GetDBStatsReply struct {
+	Compacting     bool         `json:"compacting"`
+	BackingUp      bool         `json:"backingUp"`
+	Compactions    json.Uint64  `json:"compactions"`
+	WritePaused    bool         `json:"writePaused"`
+	Levels         []LevelStats `json:"levels"`
+	OpenTables     json.Uint64  `json:"openTables"`
+	AliveSnapshots json.Uint64  `json:"aliveSnapshots"`
+	AliveIterators json.Uint64  `json:"aliveIterators"`
+	// ChainSizes is the approximate number of bytes used on disk by each of
+	// the requested chains
+	ChainSizes map[ids.ID]json.Uint64 `json:"chainSizes"`
+}
+
+// GetDBStats returns statistics about the node's database
+func (service *Admin) GetDBStats(_ *http.Request, args *GetDBStatsArgs, reply *GetDBStatsReply) error {
+	service.Log.Debug("Admin: GetDBStats called")
+
+	db, ok := service.DB.(database.StatsReporter)
+	if !ok {
+		return errStatsNotSupported
+	}
+	stats, err := db.StorageStats()
+	if err != nil {
+		return err
+	}
+
+	service.compactionLock.Lock()
+	reply.Compacting = service.compacting
+	service.compactionLock.Unlock()
+
+	service.backupLock.Lock()
+	reply.BackingUp = service.backingUp
+	service.backupLock.Unlock()
+
+	reply.Compactions = json.Uint64(stats.Compactions)
+	reply.WritePaused = stats.WritePaused
+	reply.Levels = make([]LevelStats, len(stats.LevelSizes))
+	for level, size := range stats.LevelSizes {
+		reply.Levels[level].Size = json.Uint64(size)
+	}
+	for level, tables := range stats.LevelTables {
+		if level < len(reply.Levels) {
+			reply.Levels[level].Tables = json.Uint64(tables)
+		}
+	}
+	reply.OpenTables = json.Uint64(stats.OpenTables)
+	reply.AliveSnapshots = json.Uint64(stats.AliveSnapshots)
+	reply.AliveIterators = json.Uint64(stats.AliveIterators)
+
+	reply.ChainSizes = make(map[ids.ID]json.Uint64, len(args.Chains))
+	for _, chain := range args.Chains {
+		chainID, err := service.ChainManager.Lookup(chain)
+		if err != nil {
+			return err
+		}
+		size, err := db.ApproximatePrefixSize(prefixdb.MakePrefix(chainID[:]))
+		if err != nil {
+			return err
+		}
+		reply.ChainSizes[chainID] = json.Uint64(size)
+	}
+	return nil
+}
+
+// BackupDBArgs are the arguments for calling BackupDB
+type BackupDBArgs struct {
+	// Directory that the backup is written to. It must not exist yet.
+	Directory string `json:"directory"`
+}
+
+// BackupDB takes a snapshot of the node's database, which holds the data of
+// every chain, and copies it to a new database in [args.Directory] in the
+// background. Once the copy completes, a manifest describing the backup is
+// written to the directory. Only one backup may run at a time.
+func (service *Admin) BackupDB(_ *http.Request, args *BackupDBArgs, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: BackupDB called",
+		logging.UserString("directory", args.Directory),
+	)
+
+	if args.Directory == "" {
+		return errNoBackupDir
+	}
+	dir, err := filepath.Abs(args.Directory)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%w: %s", errBackupDirExists, dir)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	snapshotter, ok := service.DB.(database.Snapshotter)
+	if !ok {
+		return errSnapshotsUnsupported
+	}
+
+	service.backupLock.Lock()
+	defer service.backupLock.Unlock()
+
+	if service.backingUp {
+		return errBackupInProgress
+	}
+
+	createdAt := time.Now()
+	snapshot, err := snapshotter.NewSnapshot()
+	if err != nil {
+		return fmt.Errorf("couldn't take database snapshot: %w", err)
+	}
+	service.backingUp = true
+
+	go func() {
+		defer snapshot.Release()
+
+		service.Log.Info("starting database backup",
+			zap.String("directory", dir),
+		)
+		manifest, err := backupDB(service.Log, snapshot, createdAt, service.DBVersion.String(), dir)
+
+		service.backupLock.Lock()
+		service.backingUp = false
+		service.backupLock.Unlock()
+
+		if err != nil {
+			service.Log.Error("database backup failed",
+				zap.String("directory", dir),
+				zap.Error(err),
+			)
+			return
+		}
+		service.Log.Info("finished database backup",
+			zap.String("directory", dir),
+			zap.Uint64("keys", uint64(manifest.Keys)),
+			zap.Uint64("bytes", uint64(manifest.Bytes)),
+			zap.Duration("duration", time.Since(createdAt)),
+		)
+	}()
+	return nil
+}
+
+// prefixLimit returns the smallest key that is larger than every key that
+// starts with [prefix], or nil if there is no such key.
+func prefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
+
+// GetCapturedMessagesArgs are the arguments for calling GetCapturedMessages
+type GetCapturedMessagesArgs struct {
+	// Chain whose messages are returned. If empty, the messages of every chain
+	// are returned.
+	Chain string `json:"chain"`
+}
+
+// GetCapturedMessagesReply is the response from calling GetCapturedMessages
+type GetCapturedMessagesReply struct {
+	// Captured messages, oldest first
+	Messages []router.CapturedMessage `json:"messages"`
+}
+
+// GetCapturedMessages returns the most recent inbound consensus messages
+// recorded by the consensus message capture
+func (service *Admin) GetCapturedMessages(_ *http.Request, args *GetCapturedMessagesArgs, reply *GetCapturedMessagesReply) error {
+	service.Log.Debug("Admin: GetCapturedMessages called",
+		logging.UserString("chain", args.Chain),
+	)
+
+	if service.MessageCapture == nil {
+		return errMessageCaptureOff
+	}
+
+	var chainID ids.ID
+	if args.Chain != "" {
+		var err error
+		chainID, err = service.ChainManager.Lookup(args.Chain)
+		if err != nil {
+			return err
+		}
+	}
+	reply.Messages = service.MessageCapture.Messages(chainID)
+	return nil
+}
+
+// ExportUptimesReply is the response from calling ExportUptimes
+type ExportUptimesReply struct {
+	Attestation *uptime.Attestation `json:"attestation"`
+}
+
+// ExportUptimes returns the uptimes this node tracked of the current primary
+// network validators, signed with this node's staking key. The export can be
+// imported with ImportUptimes after moving the node to a new host.
+func (service *Admin) ExportUptimes(_ *http.Request, _ *struct{}, reply *ExportUptimesReply) error {
+	service.Log.Debug("Admin: ExportUptimes called")
+
+	vdrs := service.Validators.List()
+	records := make([]uptime.Record, 0, len(vdrs))
+	for _, vdr := range vdrs {
+		nodeID := vdr.ID()
+		upDuration, lastUpdated, err := service.UptimeCalculator.CalculateUptime(nodeID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		records = append(records, uptime.Record{
+			NodeID:      nodeID,
+			UpDuration:  upDuration,
+			LastUpdated: lastUpdated,
+		})
+	}
+
+	attestation, err := uptime.NewAttestation(records, service.StakingCert, service.StakingSigner)
+	if err != nil {
+		return err
+	}
+	reply.Attestation = attestation
+	return nil
+}
+
+// ImportUptimesArgs are the arguments for calling ImportUptimes
+type ImportUptimesArgs struct {
+	Attestation uptime.Attestation `json:"attestation"`
+}
+
+// ImportUptimesReply is the response from calling ImportUptimes
+type ImportUptimesReply struct {
+	// Number of local uptime records that were increased by the import
+	Imported json.Uint32 `json:"imported"`
+}
+
+// ImportUptimes merges the uptimes of an attestation created by ExportUptimes
+// into the uptimes tracked by this node. The attestation must have been signed
+// by this node's staking key. Local uptimes are never decreased.
+func (service *Admin) ImportUptimes(_ *http.Request, args *ImportUptimesArgs, reply *ImportUptimesReply) error {
+	service.Log.Debug("Admin: ImportUptimes called",
+		zap.Int("numRecords", len(args.Attestation.Records)),
+	)
+
+	signerID, err := args.Attestation.Verify()
+	if err != nil {
+		return fmt.Errorf("couldn't verify uptime attestation: %w", err)
+	}
+	if signerID != ids.NodeIDFromCert(service.StakingCert) {
+		return errForeignAttestation
+	}
+
+	for _, record := range args.Attestation.Records {
+		imported, err := service.UptimeCalculator.ImportUptime(record.NodeID, record.UpDuration, record.LastUpdated)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if imported {
+			reply.Imported++
+		}
+	}
+	return nil
+}
+
+// ObservedUptime is the uptime of this node as observed by a connected peer
+type ObservedUptime struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// Stake weight of the peer, or 0 if the peer isn't a validator
+	Weight         json.Uint64 `json:"weight"`
+	ObservedUptime json.Uint8  `json:"observedUptime"`
+}
+
+// GetObservedUptimesReply is the response from calling GetObservedUptimes
+type GetObservedUptimesReply struct {
+	Peers []ObservedUptime `json:"peers"`
+}
+
+// GetObservedUptimes returns the uptime of this node as reported by each of
+// its connected peers
+func (service *Admin) GetObservedUptimes(_ *http.Request, _ *struct{}, reply *GetObservedUptimesReply) error {
+	service.Log.Debug("Admin: GetObservedUptimes called")
+
+	peers := service.Network.PeerInfo(nil)
+	reply.Peers = make([]ObservedUptime, len(peers))
+	for i, peer := range peers {
+		weight, _ := service.Validators.GetWeight(peer.ID)
+		reply.Peers[i] = ObservedUptime{
+			NodeID:         peer.ID,
+			Weight:         json.Uint64(weight),
+			ObservedUptime: peer.ObservedUptime,
+		}
+	}
+	return nil
+}
+
+// GetConnectionGater returns the config of the gater that decides which
+// connections this node accepts and makes
+func (service *Admin) GetConnectionGater(_ *http.Request, _ *struct{}, reply *network.GaterConfig) error {
+	service.Log.Debug("Admin: GetConnectionGater called")
+
+	*reply = service.Network.GaterConfig()
+	return nil
+}
+
+// SetConnectionGater replaces the config of the connection gater. Existing
+// connections aren't closed.
+func (service *Admin) SetConnectionGater(_ *http.Request, args *network.GaterConfig, _ *api.EmptyReply) error {
+	service.Log.Debug("Admin: SetConnectionGater called",
+		zap.Strings("allowedCIDRs", args.AllowedCIDRs),
+		zap.Strings("deniedCIDRs", args.DeniedCIDRs),
+		zap.Strings("deniedDialCIDRs", args.DeniedDialCIDRs),
+		zap.Int("maxConnsPerIP", args.MaxConnsPerIP),
+	)
+
+	return service.Network.SetGaterConfig(*args)
+}
+
+// GetDiskWatchdog returns the available disk space and the state of the actions
+// the node takes as the disk fills up
+func (service *Admin) GetDiskWatchdog(_ *http.Request, _ *struct{}, reply *diskwatchdog.Status) error {
+	service.Log.Debug("Admin: GetDiskWatchdog called")
+
+	*reply = service.DiskWatchdog.Status()
+	return nil
+}
+
+type SetDiskWatchdogOverrideArgs struct {
+	// Action to override. One of pauseIndexing, rejectAPITxs or halt.
+	Action diskwatchdog.Action `json:"action"`
+	// Override of the action. One of auto, force or suppress. Halting can't
+	// be forced.
+	Override diskwatchdog.Override `json:"override"`
+}
+
+// SetDiskWatchdogOverride forces or suppresses one of the actions the node
+// takes as the disk fills up, or returns it to being driven by the available
+// disk space
+func (service *Admin) SetDiskWatchdogOverride(_ *http.Request, args *SetDiskWatchdogOverrideArgs, reply *diskwatchdog.Status) error {
+	service.Log.Debug("Admin: SetDiskWatchdogOverride called",
+		zap.String("action", string(args.Action)),
+		zap.String("override", string(args.Override)),
+	)
+
+	status, err := service.DiskWatchdog.SetOverride(args.Action, args.Override)
+	if err != nil {
+		return err
+	}
+	*reply = status
+	return nil
+}
+
+type EnterMaintenanceArgs struct {
+	// Longest time to wait for the outstanding requests to finish before the
+	// databases are flushed, e.g. "2m". Defaults to 1m.
+	DrainTimeout string `json:"drainTimeout"`
+}
+
+// EnterMaintenance stops the node from starting consensus polls, waits for
+// its outstanding requests to finish and flushes its databases, so that it
+// can be stopped for an upgrade. The node drains in the background and
+// reports its progress through GetMaintenance and the health API.
+func (service *Admin) EnterMaintenance(_ *http.Request, args *EnterMaintenanceArgs, reply *maintenance.Status) error {
+	service.Log.Debug("Admin: EnterMaintenance called",
+		logging.UserString("drainTimeout", args.DrainTimeout),
+	)
+
+	drainTimeout := defaultDrainTimeout
+	if args.DrainTimeout != "" {
+		var err error
+		drainTimeout, err = time.ParseDuration(args.DrainTimeout)
+		if err != nil {
+			return err
+		}
+		if drainTimeout <= 0 {
+			return errInvalidDrainTimeout
+		}
+	}
+
+	*reply = service.Maintenance.Enter(drainTimeout)
+	return nil
+}
+
+// ExitMaintenance resumes the consensus polls of a node in maintenance
+func (service *Admin) ExitMaintenance(_ *http.Request, _ *struct{}, reply *maintenance.Status) error {
+	service.Log.Debug("Admin: ExitMaintenance called")
+
+	*reply = service.Maintenance.Exit()
+	return nil
+}
+
+// GetMaintenance returns whether the node is active, draining or drained
+func (service *Admin) GetMaintenance(_ *http.Request, _ *struct{}, reply *maintenance.Status) error {
+	service.Log.Debug("Admin: GetMaintenance called")
+
+	*reply = service.Maintenance.Status()
+	return nil
+}
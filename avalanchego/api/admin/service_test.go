@@ -4,15 +4,36 @@
 package admin
 
 import (
+	"crypto"
+	"crypto/tls"
 	"errors"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
+
+	stdjson "encoding/json"
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/registry"
 )
@@ -120,3 +141,288 @@ func TestLoadVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errOops)
 }
+
+// statsDB is a database that reports fixed storage stats and blocks
+// compactions until [compacted] is read from.
+type statsDB struct {
+	*memdb.Database
+	stats     database.StorageStats
+	sizes     map[string]uint64
+	compacted chan [2][]byte
+}
+
+func (db *statsDB) Compact(start, limit []byte) error {
+	db.compacted <- [2][]byte{start, limit}
+	return nil
+}
+
+func (db *statsDB) StorageStats() (database.StorageStats, error) {
+	return db.stats, nil
+}
+
+func (db *statsDB) ApproximatePrefixSize(prefix []byte) (uint64, error) {
+	return db.sizes[string(prefix)], nil
+}
+
+func TestCompactDB(t *testing.T) {
+	require := require.New(t)
+
+	db := &statsDB{
+		Database:  memdb.New(),
+		compacted: make(chan [2][]byte),
+	}
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.MockManager{},
+		DB:           db,
+	}}
+
+	chainID := ids.GenerateTestID()
+	require.NoError(admin.CompactDB(nil, &CompactDBArgs{Chain: chainID.String()}, nil))
+
+	// Only one compaction may run at a time.
+	err := admin.CompactDB(nil, &CompactDBArgs{}, nil)
+	require.ErrorIs(err, errCompactionInProgress)
+
+	prefix := prefixdb.MakePrefix(chainID[:])
+	compactedRange := <-db.compacted
+	require.Equal(prefix, compactedRange[0])
+	require.Equal(prefixLimit(prefix), compactedRange[1])
+
+	require.Eventually(func() bool {
+		reply := GetDBStatsReply{}
+		require.NoError(admin.GetDBStats(nil, &GetDBStatsArgs{}, &reply))
+		return !reply.Compacting
+	}, time.Second, 10*time.Millisecond)
+
+	// The whole database is compacted if no chain is given.
+	require.NoError(admin.CompactDB(nil, &CompactDBArgs{}, nil))
+	require.Equal([2][]byte{nil, nil}, <-db.compacted)
+}
+
+func TestCaptureProfileWindow(t *testing.T) {
+	require := require.New(t)
+
+	admin := &Admin{Config: Config{
+		Log:    logging.NoLog{},
+		LogDir: t.TempDir(),
+	}}
+
+	err := admin.CaptureProfile(nil, &CaptureProfileArgs{Duration: "1s"}, &CaptureProfileReply{})
+	require.ErrorIs(err, errNothingToCapture)
+
+	err = admin.CaptureProfile(nil, &CaptureProfileArgs{Heap: true, Duration: "1h"}, &CaptureProfileReply{})
+	require.ErrorIs(err, errCaptureTooLong)
+
+	reply := CaptureProfileReply{}
+	require.NoError(admin.CaptureProfile(nil, &CaptureProfileArgs{
+		CPU:      true,
+		Heap:     true,
+		Trace:    true,
+		Duration: "50ms",
+	}, &reply))
+	require.Equal(filepath.Join(admin.LogDir, captureProfileDir), filepath.Dir(reply.Dir))
+
+	// Only one capture may run at a time.
+	err = admin.CaptureProfile(nil, &CaptureProfileArgs{Heap: true, Duration: "1s"}, &CaptureProfileReply{})
+	require.ErrorIs(err, errCaptureInProgress)
+
+	require.Eventually(func() bool {
+		admin.captureLock.Lock()
+		defer admin.captureLock.Unlock()
+		return !admin.capturing
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for _, file := range []string{"cpu.profile", "mem.profile", "trace.out"} {
+		_, err := os.Stat(filepath.Join(reply.Dir, file))
+		require.NoError(err)
+	}
+}
+
+// newUptimeAdmin returns an admin service whose uptime calculator tracks
+// [state]
+func newUptimeAdmin(tlsCert *tls.Certificate, vdrs validators.Set, state uptime.State, now time.Time) *Admin {
+	manager := uptime.NewManager(state).(uptime.TestManager)
+	manager.SetTime(now)
+
+	isBootstrapped := &utils.AtomicBool{}
+	isBootstrapped.SetValue(true)
+	calculator := uptime.NewLockedCalculator()
+	calculator.SetCalculator(isBootstrapped, &sync.Mutex{}, manager)
+
+	return &Admin{Config: Config{
+		Log:              logging.NoLog{},
+		StakingCert:      tlsCert.Leaf,
+		StakingSigner:    tlsCert.PrivateKey.(crypto.Signer),
+		Validators:       vdrs,
+		UptimeCalculator: calculator,
+	}}
+}
+
+func TestExportImportUptimes(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	nodeID := ids.GenerateTestNodeID()
+	vdrs := validators.NewSet()
+	require.NoError(vdrs.AddWeight(nodeID, 1))
+
+	startTime := time.Unix(time.Now().Unix(), 0)
+	now := startTime.Add(10 * time.Second)
+
+	// The previous host saw the validator online for 8 of its first 9 seconds
+	oldState := uptime.NewTestState()
+	oldState.AddNode(nodeID, startTime)
+	require.NoError(oldState.SetUptime(nodeID, 8*time.Second, startTime.Add(9*time.Second)))
+	oldAdmin := newUptimeAdmin(tlsCert, vdrs, oldState, startTime.Add(9*time.Second))
+
+	exportReply := ExportUptimesReply{}
+	require.NoError(oldAdmin.ExportUptimes(nil, nil, &exportReply))
+	require.Len(exportReply.Attestation.Records, 1)
+
+	// The new host started tracking from scratch
+	newState := uptime.NewTestState()
+	newState.AddNode(nodeID, startTime)
+	require.NoError(newState.SetUptime(nodeID, 0, now))
+	newAdmin := newUptimeAdmin(tlsCert, vdrs, newState, now)
+
+	importReply := ImportUptimesReply{}
+	require.NoError(newAdmin.ImportUptimes(nil, &ImportUptimesArgs{
+		Attestation: *exportReply.Attestation,
+	}, &importReply))
+	require.EqualValues(1, importReply.Imported)
+
+	upDuration, _, err := newState.GetUptime(nodeID)
+	require.NoError(err)
+	require.Equal(9*time.Second, upDuration)
+
+	// Attestations signed by another node are rejected
+	otherCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	otherAdmin := newUptimeAdmin(otherCert, vdrs, newState, now)
+	err = otherAdmin.ImportUptimes(nil, &ImportUptimesArgs{
+		Attestation: *exportReply.Attestation,
+	}, &ImportUptimesReply{})
+	require.ErrorIs(err, errForeignAttestation)
+}
+
+func TestGetDBStatsSuccess(t *testing.T) {
+	require := require.New(t)
+
+	chainID := ids.GenerateTestID()
+	db := &statsDB{
+		Database: memdb.New(),
+		stats: database.StorageStats{
+			LevelSizes:     []uint64{10, 200},
+			LevelTables:    []int{1, 4},
+			OpenTables:     3,
+			Compactions:    7,
+			AliveIterators: 2,
+		},
+		sizes: map[string]uint64{
+			string(prefixdb.MakePrefix(chainID[:])): 150,
+		},
+	}
+	admin := &Admin{Config: Config{
+		Log:          logging.NoLog{},
+		ChainManager: chains.MockManager{},
+		DB:           db,
+	}}
+
+	reply := GetDBStatsReply{}
+	require.NoError(admin.GetDBStats(nil, &GetDBStatsArgs{Chains: []string{chainID.String()}}, &reply))
+	require.Equal(GetDBStatsReply{
+		Compactions:    7,
+		Levels:         []LevelStats{{Tables: 1, Size: 10}, {Tables: 4, Size: 200}},
+		OpenTables:     3,
+		AliveIterators: 2,
+		ChainSizes:     map[ids.ID]json.Uint64{chainID: 150},
+	}, reply)
+
+	// Databases that don't report stats are rejected.
+	admin.DB = memdb.New()
+	err := admin.GetDBStats(nil, &GetDBStatsArgs{}, &GetDBStatsReply{})
+	require.ErrorIs(err, errStatsNotSupported)
+}
+
+func TestPrefixLimit(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal([]byte{0x01, 0x03}, prefixLimit([]byte{0x01, 0x02}))
+	require.Equal([]byte{0x02}, prefixLimit([]byte{0x01, 0xff}))
+	require.Nil(prefixLimit([]byte{0xff, 0xff}))
+}
+
+func TestBackupDBSuccess(t *testing.T) {
+	require := require.New(t)
+
+	db, err := leveldb.New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	require.NoError(db.Put([]byte("key1"), []byte("value1")))
+	require.NoError(db.Put([]byte("key2"), []byte("value2")))
+
+	admin := &Admin{Config: Config{
+		Log:       logging.NoLog{},
+		DB:        db,
+		DBVersion: version.CurrentDatabase,
+	}}
+
+	dir := filepath.Join(t.TempDir(), "backup")
+	require.NoError(admin.BackupDB(nil, &BackupDBArgs{Directory: dir}, nil))
+
+	// Writes after the snapshot was taken aren't backed up.
+	require.NoError(db.Put([]byte("key3"), []byte("value3")))
+
+	require.Eventually(func() bool {
+		reply := GetDBStatsReply{}
+		require.NoError(admin.GetDBStats(nil, &GetDBStatsArgs{}, &reply))
+		return !reply.BackingUp
+	}, 5*time.Second, 10*time.Millisecond)
+
+	manifestBytes, err := os.ReadFile(filepath.Join(dir, backupManifestFile))
+	require.NoError(err)
+	manifest := BackupManifest{}
+	require.NoError(stdjson.Unmarshal(manifestBytes, &manifest))
+	require.Equal(version.CurrentDatabase.String(), manifest.DatabaseVersion)
+	require.EqualValues(2, manifest.Keys)
+	require.EqualValues(20, manifest.Bytes)
+
+	backup, err := leveldb.New(filepath.Join(dir, manifest.DatabaseVersion), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer backup.Close()
+
+	value, err := backup.Get([]byte("key1"))
+	require.NoError(err)
+	require.Equal([]byte("value1"), value)
+	value, err = backup.Get([]byte("key2"))
+	require.NoError(err)
+	require.Equal([]byte("value2"), value)
+	_, err = backup.Get([]byte("key3"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The backup directory may not be reused.
+	err = admin.BackupDB(nil, &BackupDBArgs{Directory: dir}, nil)
+	require.ErrorIs(err, errBackupDirExists)
+}
+
+func TestBackupDBInvalid(t *testing.T) {
+	require := require.New(t)
+
+	admin := &Admin{Config: Config{
+		Log: logging.NoLog{},
+		DB:  memdb.New(),
+	}}
+
+	err := admin.BackupDB(nil, &BackupDBArgs{}, nil)
+	require.ErrorIs(err, errNoBackupDir)
+
+	err = admin.BackupDB(nil, &BackupDBArgs{Directory: t.TempDir()}, nil)
+	require.ErrorIs(err, errBackupDirExists)
+
+	err = admin.BackupDB(nil, &BackupDBArgs{Directory: filepath.Join(t.TempDir(), "backup")}, nil)
+	require.ErrorIs(err, errSnapshotsUnsupported)
+}
@@ -52,6 +52,7 @@ var (
 	errNoPassword                  = errors.New("no password")
 	errNoEndpoints                 = errors.New("must name at least one endpoint")
 	errTooManyEndpoints            = fmt.Errorf("can only name at most %d endpoints", maxEndpoints)
+	errNoScopes                    = errors.New("must name at least one scope")
 
 	_ Auth = &auth{}
 )
@@ -62,6 +63,10 @@ type Auth interface {
 	// If one of the elements of [endpoints] is "*", all APIs are accessible.
 	NewToken(pw string, duration time.Duration, endpoints []string) (string, error)
 
+	// Create and return a new token that allows access to each API endpoint
+	// granted by one of [scopes] for [duration].
+	NewScopedToken(pw string, duration time.Duration, scopes []Scope) (string, error)
+
 	// Revokes [token]; it will not be accepted as authorization for future API
 	// calls. If the token is invalid, this is a no-op.  If a token is revoked
 	// and then the password is changed, and then changed back to the current
@@ -100,23 +105,31 @@ type auth struct {
 	password password.Hash
 	// Set of token IDs that have been revoked
 	revoked map[string]struct{}
+	// APIs granted by these scopes may be accessed without a token
+	publicScopes []Scope
 }
 
-func New(log logging.Logger, endpoint, pw string) (Auth, error) {
+// New returns a new Auth. The APIs granted by [publicScopes] may be accessed
+// without a token.
+func New(log logging.Logger, endpoint, pw string, publicScopes ...Scope) (Auth, error) {
 	a := &auth{
-		log:      log,
-		endpoint: endpoint,
-		revoked:  make(map[string]struct{}),
+		log:          log,
+		endpoint:     endpoint,
+		revoked:      make(map[string]struct{}),
+		publicScopes: publicScopes,
 	}
 	return a, a.password.Set(pw)
 }
 
-func NewFromHash(log logging.Logger, endpoint string, pw password.Hash) Auth {
+// NewFromHash returns a new Auth. The APIs granted by [publicScopes] may be
+// accessed without a token.
+func NewFromHash(log logging.Logger, endpoint string, pw password.Hash, publicScopes ...Scope) Auth {
 	return &auth{
-		log:      log,
-		endpoint: endpoint,
-		password: pw,
-		revoked:  make(map[string]struct{}),
+		log:          log,
+		endpoint:     endpoint,
+		password:     pw,
+		revoked:      make(map[string]struct{}),
+		publicScopes: publicScopes,
 	}
 }
 
@@ -130,13 +143,6 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		return "", errTooManyEndpoints
 	}
 
-	a.lock.RLock()
-	defer a.lock.RUnlock()
-
-	if !a.password.Check(pw) {
-		return "", errWrongPassword
-	}
-
 	canAccessAll := false
 	for _, endpoint := range endpoints {
 		if endpoint == "*" {
@@ -145,24 +151,50 @@ func (a *auth) NewToken(pw string, duration time.Duration, endpoints []string) (
 		}
 	}
 
-	idBytes := [tokenIDByteLen]byte{}
-	if _, err := rand.Read(idBytes[:]); err != nil {
-		return "", fmt.Errorf("failed to generate the unique token ID due to %w", err)
-	}
-	id := base64.URLEncoding.EncodeToString(idBytes[:])
-
-	claims := endpointClaims{
-		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: a.clock.Time().Add(duration).Unix(),
-			Id:        id,
-		},
-	}
+	claims := endpointClaims{}
 	if canAccessAll {
 		claims.Endpoints = []string{"*"}
 	} else {
 		claims.Endpoints = endpoints
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
+	return a.newToken(pw, duration, &claims)
+}
+
+func (a *auth) NewScopedToken(pw string, duration time.Duration, scopes []Scope) (string, error) {
+	if pw == "" {
+		return "", errNoPassword
+	}
+	if len(scopes) == 0 {
+		return "", errNoScopes
+	}
+	for _, scope := range scopes {
+		if _, err := ParseScope(string(scope)); err != nil {
+			return "", err
+		}
+	}
+	return a.newToken(pw, duration, &endpointClaims{
+		Scopes: scopes,
+	})
+}
+
+// newToken signs [claims] after giving them a unique ID and an expiry.
+func (a *auth) newToken(pw string, duration time.Duration, claims *endpointClaims) (string, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if !a.password.Check(pw) {
+		return "", errWrongPassword
+	}
+
+	idBytes := [tokenIDByteLen]byte{}
+	if _, err := rand.Read(idBytes[:]); err != nil {
+		return "", fmt.Errorf("failed to generate the unique token ID due to %w", err)
+	}
+	claims.StandardClaims = jwt.StandardClaims{
+		ExpiresAt: a.clock.Time().Add(duration).Unix(),
+		Id:        base64.URLEncoding.EncodeToString(idBytes[:]),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(a.password.Password[:]) // Sign the token and return its string repr.
 }
 
@@ -227,6 +259,11 @@ func (a *auth) AuthenticateToken(tokenStr, url string) error {
 			return nil
 		}
 	}
+	for _, scope := range claims.Scopes {
+		if scope.Allows(url) {
+			return nil
+		}
+	}
 	return errTokenInsufficientPermission
 }
 
@@ -272,6 +309,13 @@ func (a *auth) WrapHandler(h http.Handler) http.Handler {
 			h.ServeHTTP(w, r)
 			return
 		}
+		// Don't require auth token to hit public endpoints
+		for _, scope := range a.publicScopes {
+			if scope.Allows(r.URL.Path) {
+				h.ServeHTTP(w, r)
+				return
+			}
+		}
 
 		// Should be "Bearer AUTH.TOKEN.HERE"
 		rawHeader := r.Header.Get(headerKey)
@@ -344,3 +344,64 @@ func TestWrapHandlerInvalidSigningMethod(t *testing.T) {
 		require.Regexp(t, unAuthorizedResponseRegex, rr.Body.String())
 	}
 }
+
+func TestWrapHandlerScopedToken(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword)
+
+	_, err := auth.NewScopedToken(testPassword, defaultTokenLifespan, nil)
+	require.ErrorIs(t, err, errNoScopes)
+	_, err = auth.NewScopedToken(testPassword, defaultTokenLifespan, []Scope{"unknown"})
+	require.Error(t, err)
+	_, err = auth.NewScopedToken("notThePassword", defaultTokenLifespan, []Scope{ScopeReadOnly})
+	require.ErrorIs(t, err, errWrongPassword)
+
+	tokenStr, err := auth.NewScopedToken(testPassword, defaultTokenLifespan, []Scope{ScopeReadOnly})
+	require.NoError(t, err)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	for endpoint, expectedCode := range map[string]int{
+		"/ext/info":          http.StatusOK,
+		"/ext/bc/X/events":   http.StatusOK,
+		"/ext/bc/C/rpc":      http.StatusUnauthorized,
+		"/ext/admin":         http.StatusUnauthorized,
+		"/ext/bc/C/admin":    http.StatusUnauthorized,
+		"/ext/index/X/tx":    http.StatusUnauthorized,
+		"/ext/keystore":      http.StatusUnauthorized,
+		"/ext/administrator": http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://127.0.0.1:9650%s", endpoint), strings.NewReader(""))
+		req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		require.Equal(t, expectedCode, rr.Code, endpoint)
+	}
+
+	// Revoking a scoped token works the same as revoking any other token.
+	require.NoError(t, auth.RevokeToken(tokenStr, testPassword))
+	require.ErrorIs(t, auth.AuthenticateToken(tokenStr, "/ext/info"), errTokenRevoked)
+}
+
+func TestWrapHandlerPublicScopes(t *testing.T) {
+	auth := NewFromHash(logging.NoLog{}, "auth", hashedPassword, ScopeReadOnly)
+
+	wrappedHandler := auth.WrapHandler(dummyHandler)
+	for endpoint, expectedCode := range map[string]int{
+		"/ext/info":       http.StatusOK,
+		"/ext/health":     http.StatusOK,
+		"/ext/bc/X":       http.StatusUnauthorized,
+		"/ext/admin":      http.StatusUnauthorized,
+		"/ext/index/X/tx": http.StatusUnauthorized,
+	} {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://127.0.0.1:9650%s", endpoint), strings.NewReader(""))
+		rr := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(rr, req)
+		require.Equal(t, expectedCode, rr.Code, endpoint)
+	}
+
+	// An admin token can still access everything.
+	tokenStr, err := auth.NewScopedToken(testPassword, defaultTokenLifespan, []Scope{ScopeAdmin})
+	require.NoError(t, err)
+	for _, endpoint := range []string{"/ext/admin", "/ext/index/X/tx", "/ext/info"} {
+		require.NoError(t, auth.AuthenticateToken(tokenStr, endpoint))
+	}
+}
@@ -15,4 +15,7 @@ type endpointClaims struct {
 	// If endpoints has an element "*", allows access to all API endpoints
 	// In this case, "*" should be the only element of [endpoints]
 	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Each element is a scope whose APIs the token allows access to
+	Scopes []Scope `json:"scopes,omitempty"`
 }
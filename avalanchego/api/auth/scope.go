@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// ScopeAdmin grants access to every API, including the APIs that manage
+	// the node or hold its keys.
+	ScopeAdmin Scope = "admin"
+	// ScopeIndexing grants access to the index APIs.
+	ScopeIndexing Scope = "indexing"
+	// ScopeReadOnly grants access to the APIs listed in [readOnlyPaths], which
+	// neither manage the node nor hold its keys.
+	ScopeReadOnly Scope = "read-only"
+
+	indexPathPrefix = "/ext/index"
+)
+
+var (
+	// readOnlyPaths are the APIs granted by [ScopeReadOnly]. Any other API,
+	// including the chain APIs that can use keystore users, is only granted by
+	// [ScopeAdmin]. Chain APIs are listed under the aliases of their chain.
+	readOnlyPaths = []string{
+		"/ext/health",
+		"/ext/info",
+		"/ext/metrics",
+		"/ext/bc/P/validators",
+		"/ext/bc/platform/validators",
+		"/ext/bc/X/events",
+		"/ext/bc/avm/events",
+	}
+
+	scopes = []Scope{ScopeAdmin, ScopeIndexing, ScopeReadOnly}
+)
+
+// Scope is a class of APIs that a token may grant access to.
+type Scope string
+
+// ParseScope returns the scope named [s].
+func ParseScope(s string) (Scope, error) {
+	for _, scope := range scopes {
+		if string(scope) == s {
+			return scope, nil
+		}
+	}
+	return "", fmt.Errorf("unknown API scope %q", s)
+}
+
+// ScopeOf returns the most specific scope that grants access to [url].
+func ScopeOf(url string) Scope {
+	if hasPathPrefix(url, indexPathPrefix) {
+		return ScopeIndexing
+	}
+	for _, path := range readOnlyPaths {
+		if hasPathPrefix(url, path) {
+			return ScopeReadOnly
+		}
+	}
+	return ScopeAdmin
+}
+
+// Allows returns true if [s] grants access to [url].
+func (s Scope) Allows(url string) bool {
+	return s == ScopeAdmin || ScopeOf(url) == s
+}
+
+// hasPathPrefix returns true if [url] is [prefix] or is nested under it.
+func hasPathPrefix(url, prefix string) bool {
+	return url == prefix || strings.HasPrefix(url, prefix+"/")
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScopeOf(t *testing.T) {
+	tests := map[string]Scope{
+		"/ext/admin":                  ScopeAdmin,
+		"/ext/keystore":               ScopeAdmin,
+		"/ext/ipcs":                   ScopeAdmin,
+		"/ext/bc/C/admin":             ScopeAdmin,
+		"/ext/bc/C/avax":              ScopeAdmin,
+		"/ext/bc/C/rpc":               ScopeAdmin,
+		"/ext/bc/X":                   ScopeAdmin,
+		"/ext/bc/X/wallet":            ScopeAdmin,
+		"/ext/bc/P":                   ScopeAdmin,
+		"/ext/index/X/tx":             ScopeIndexing,
+		"/ext/index":                  ScopeIndexing,
+		"/ext/indexer":                ScopeAdmin,
+		"/ext/info":                   ScopeReadOnly,
+		"/ext/health":                 ScopeReadOnly,
+		"/ext/health/readiness":       ScopeReadOnly,
+		"/ext/healthy":                ScopeAdmin,
+		"/ext/metrics":                ScopeReadOnly,
+		"/ext/bc/X/events":            ScopeReadOnly,
+		"/ext/bc/platform/validators": ScopeReadOnly,
+		"/ext/administrator":          ScopeAdmin,
+	}
+	for url, expectedScope := range tests {
+		require.Equal(t, expectedScope, ScopeOf(url), url)
+	}
+}
+
+func TestParseScope(t *testing.T) {
+	require := require.New(t)
+
+	for _, scope := range []Scope{ScopeAdmin, ScopeIndexing, ScopeReadOnly} {
+		parsed, err := ParseScope(string(scope))
+		require.NoError(err)
+		require.Equal(scope, parsed)
+	}
+
+	_, err := ParseScope("everything")
+	require.Error(err)
+}
@@ -4,11 +4,14 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/ava-labs/avalanchego/api"
 )
 
+var errEndpointsAndScopes = errors.New("can't name both endpoints and scopes")
+
 // Service that serves the Auth API functionality.
 type Service struct {
 	auth *auth
@@ -26,6 +29,12 @@ type NewTokenArgs struct {
 	// allows access to all API endpoints. [Endpoints] must have between 1 and
 	// [maxEndpoints] elements
 	Endpoints []string `json:"endpoints"`
+	// Scopes whose APIs may be accessed with this token e.g. if scopes is
+	// ["read-only"] then the token holder can hit the info, health and metrics
+	// APIs and the chains' streams, but not the admin API or the chain APIs
+	// that can use keys. Exactly one of [Endpoints] and [Scopes] must
+	// be provided.
+	Scopes []Scope `json:"scopes"`
 }
 
 type Token struct {
@@ -36,7 +45,14 @@ func (s *Service) NewToken(_ *http.Request, args *NewTokenArgs, reply *Token) er
 	s.auth.log.Debug("Auth: NewToken called")
 
 	var err error
-	reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	switch {
+	case len(args.Endpoints) > 0 && len(args.Scopes) > 0:
+		return errEndpointsAndScopes
+	case len(args.Scopes) > 0:
+		reply.Token, err = s.auth.NewScopedToken(args.Password.Password, defaultTokenLifespan, args.Scopes)
+	default:
+		reply.Token, err = s.auth.NewToken(args.Password.Password, defaultTokenLifespan, args.Endpoints)
+	}
 	return err
 }
 
@@ -46,6 +46,36 @@ type JSONTxIDChangeAddr struct {
 	JSONChangeAddr
 }
 
+// SigningDescriptor describes the signatures that must be provided to spend
+// an input of an unsigned tx
+type SigningDescriptor struct {
+	// Index of the input in the tx
+	InputIndex json.Uint32 `json:"inputIndex"`
+	// ID of the UTXO consumed by the input
+	UTXOID string `json:"utxoID"`
+	// Addresses that must sign, in the order of the signatures. The signing
+	// wallet maps them to its key derivation paths.
+	Addresses []string `json:"addresses"`
+	// Hash of the unsigned tx bytes that must be signed
+	SigHash string `json:"sigHash"`
+}
+
+// JSONUnsignedTx is an unsigned tx returned instead of issuing the tx, along
+// with the signatures needed to complete it
+type JSONUnsignedTx struct {
+	// Hex encoded unsigned tx bytes
+	UnsignedTx string              `json:"unsignedTx,omitempty"`
+	Signers    []SigningDescriptor `json:"signers,omitempty"`
+}
+
+// JSONTxIDChangeAddrUnsignedTx is the reply of tx-building endpoints that may
+// return an unsigned tx instead of issuing it. [TxID] is only set if the tx
+// was issued.
+type JSONTxIDChangeAddrUnsignedTx struct {
+	JSONTxIDChangeAddr
+	JSONUnsignedTx
+}
+
 // JSONFromAddrs is a list of addresses to send funds from
 type JSONFromAddrs struct {
 	From []string `json:"from"`
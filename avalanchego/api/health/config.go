@@ -0,0 +1,34 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+// Config describes how individual checks are treated. Checks are identified
+// by the name they were registered with, regardless of whether they are
+// readiness, health or liveness checks.
+type Config struct {
+	// DisabledChecks are never run or reported.
+	DisabledChecks []string `json:"disabledChecks"`
+
+	// WarningChecks are run and reported, but failing them doesn't cause the
+	// node to be reported as unhealthy.
+	WarningChecks []string `json:"warningChecks"`
+
+	// ReadinessChecks are health checks that are also reported as readiness
+	// checks. Unlike checks registered as readiness checks, they may become
+	// unready again after having passed.
+	ReadinessChecks []string `json:"readinessChecks"`
+
+	// LivenessChecks are health checks that are also reported as liveness
+	// checks.
+	LivenessChecks []string `json:"livenessChecks"`
+}
+
+// toSet returns the set of [names].
+func toSet(names []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		set[name] = struct{}{}
+	}
+	return set
+}
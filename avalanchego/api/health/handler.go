@@ -4,6 +4,7 @@
 package health
 
 import (
+	"fmt"
 	"net/http"
 
 	stdjson "encoding/json"
@@ -14,6 +15,19 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
+const (
+	// VerbosityParam is the query parameter of GET requests that selects how
+	// much detail is reported.
+	VerbosityParam = "verbosity"
+
+	// VerbosityFull reports the full result of every check. This is the
+	// default.
+	VerbosityFull = "full"
+	// VerbositySummary only reports the failing checks, without their
+	// details.
+	VerbositySummary = "summary"
+)
+
 // NewGetAndPostHandler returns a health handler that supports GET and jsonrpc
 // POST requests.
 func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler, error) {
@@ -46,13 +60,27 @@ func NewGetAndPostHandler(log logging.Logger, reporter Reporter) (http.Handler,
 }
 
 // NewGetHandler return a health handler that supports GET requests reporting
-// the result of the provided [reporter].
+// the result of the provided [reporter]. The amount of detail reported is
+// selected by the optional [VerbosityParam] query parameter.
 func NewGetHandler(reporter func() (map[string]Result, bool)) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		verbosity := r.URL.Query().Get(VerbosityParam)
+		if verbosity != "" && verbosity != VerbosityFull && verbosity != VerbositySummary {
+			http.Error(
+				w,
+				fmt.Sprintf("unknown %s %q, expected %q or %q", VerbosityParam, verbosity, VerbosityFull, VerbositySummary),
+				http.StatusBadRequest,
+			)
+			return
+		}
+
 		// Make sure the content type is set before writing the header.
 		w.Header().Set("Content-Type", "application/json")
 
 		checks, healthy := reporter()
+		if verbosity == VerbositySummary {
+			checks = summarize(checks)
+		}
 		if !healthy {
 			// If a health check has failed, we should return a 503.
 			w.WriteHeader(http.StatusServiceUnavailable)
@@ -65,3 +93,19 @@ func NewGetHandler(reporter func() (map[string]Result, bool)) http.Handler {
 		})
 	})
 }
+
+// summarize returns the failing checks of [checks] without their details.
+func summarize(checks map[string]Result) map[string]Result {
+	summary := make(map[string]Result)
+	for name, result := range checks {
+		if result.Error == nil {
+			continue
+		}
+		summary[name] = Result{
+			Error:              result.Error,
+			ContiguousFailures: result.ContiguousFailures,
+			Warning:            result.Warning,
+		}
+	}
+	return summary
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetHandlerVerbosity(t *testing.T) {
+	checkErr := "unhealthy"
+	checks := map[string]Result{
+		"passing": {
+			Details: "details",
+		},
+		"failing": {
+			Details:            "details",
+			Error:              &checkErr,
+			ContiguousFailures: 2,
+		},
+	}
+	handler := NewGetHandler(func() (map[string]Result, bool) {
+		return checks, false
+	})
+
+	tests := []struct {
+		verbosity      string
+		expectedStatus int
+		expectedChecks map[string]Result
+	}{
+		{
+			verbosity:      "",
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: checks,
+		},
+		{
+			verbosity:      VerbosityFull,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: checks,
+		},
+		{
+			verbosity:      VerbositySummary,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedChecks: map[string]Result{
+				"failing": {
+					Error:              &checkErr,
+					ContiguousFailures: 2,
+				},
+			},
+		},
+		{
+			verbosity:      "everything",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.verbosity, func(t *testing.T) {
+			require := require.New(t)
+
+			r := httptest.NewRequest(http.MethodGet, "/ext/health", nil)
+			if test.verbosity != "" {
+				q := r.URL.Query()
+				q.Set(VerbosityParam, test.verbosity)
+				r.URL.RawQuery = q.Encode()
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(test.expectedStatus, w.Code)
+			if test.expectedChecks == nil {
+				return
+			}
+
+			reply := APIHealthReply{}
+			require.NoError(json.Unmarshal(w.Body.Bytes(), &reply))
+			require.False(reply.Healthy)
+			require.Equal(test.expectedChecks, reply.Checks)
+		})
+	}
+}
@@ -4,6 +4,7 @@
 package health
 
 import (
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -44,37 +45,79 @@ type health struct {
 	readiness *worker
 	health    *worker
 	liveness  *worker
+
+	// Names of the checks that should be ignored when registered
+	disabledChecks map[string]struct{}
+	// Names of the health checks that should also be reported as readiness
+	// checks
+	readinessChecks map[string]struct{}
+	// Names of the health checks that should also be reported as liveness
+	// checks
+	livenessChecks map[string]struct{}
 }
 
-func New(log logging.Logger, registerer prometheus.Registerer) (Health, error) {
-	readinessWorker, err := newWorker("readiness", registerer)
+func New(log logging.Logger, config Config, registerer prometheus.Registerer) (Health, error) {
+	warningChecks := toSet(config.WarningChecks)
+
+	readinessWorker, err := newWorker("readiness", registerer, warningChecks)
 	if err != nil {
 		return nil, err
 	}
 
-	healthWorker, err := newWorker("health", registerer)
+	healthWorker, err := newWorker("health", registerer, warningChecks)
 	if err != nil {
 		return nil, err
 	}
 
-	livenessWorker, err := newWorker("liveness", registerer)
+	livenessWorker, err := newWorker("liveness", registerer, warningChecks)
 	return &health{
-		log:       log,
-		readiness: readinessWorker,
-		health:    healthWorker,
-		liveness:  livenessWorker,
+		log:             log,
+		readiness:       readinessWorker,
+		health:          healthWorker,
+		liveness:        livenessWorker,
+		disabledChecks:  toSet(config.DisabledChecks),
+		readinessChecks: toSet(config.ReadinessChecks),
+		livenessChecks:  toSet(config.LivenessChecks),
 	}, err
 }
 
 func (h *health) RegisterReadinessCheck(name string, checker Checker) error {
+	if h.isDisabled(name) {
+		return nil
+	}
 	return h.readiness.RegisterMonotonicCheck(name, checker)
 }
 
+// RegisterHealthCheck registers [checker] as a health check. If configured,
+// it is also registered as a readiness and/or liveness check. A check that was
+// explicitly registered as a readiness or liveness check with the same name
+// takes precedence.
 func (h *health) RegisterHealthCheck(name string, checker Checker) error {
-	return h.health.RegisterCheck(name, checker)
+	if h.isDisabled(name) {
+		return nil
+	}
+	if err := h.health.RegisterCheck(name, checker); err != nil {
+		return err
+	}
+	if _, ok := h.readinessChecks[name]; ok {
+		err := h.readiness.RegisterCheck(name, checker)
+		if err != nil && !errors.Is(err, errDuplicateCheck) {
+			return err
+		}
+	}
+	if _, ok := h.livenessChecks[name]; ok {
+		err := h.liveness.RegisterCheck(name, checker)
+		if err != nil && !errors.Is(err, errDuplicateCheck) {
+			return err
+		}
+	}
+	return nil
 }
 
 func (h *health) RegisterLivenessCheck(name string, checker Checker) error {
+	if h.isDisabled(name) {
+		return nil
+	}
 	return h.liveness.RegisterCheck(name, checker)
 }
 
@@ -108,6 +151,18 @@ func (h *health) Liveness() (map[string]Result, bool) {
 	return results, healthy
 }
 
+// isDisabled returns true, and logs that the check is skipped, if [name] was
+// disabled.
+func (h *health) isDisabled(name string) bool {
+	if _, ok := h.disabledChecks[name]; !ok {
+		return false
+	}
+	h.log.Info("skipping disabled health check",
+		zap.String("name", name),
+	)
+	return true
+}
+
 func (h *health) Start(freq time.Duration) {
 	h.readiness.Start(freq)
 	h.health.Start(freq)
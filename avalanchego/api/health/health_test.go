@@ -60,7 +60,7 @@ func TestDuplicatedRegistations(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, Config{}, prometheus.NewRegistry())
 	require.NoError(err)
 
 	err = h.RegisterReadinessCheck("check", check)
@@ -86,7 +86,7 @@ func TestDefaultFailing(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, Config{}, prometheus.NewRegistry())
 	require.NoError(err)
 
 	{
@@ -130,7 +130,7 @@ func TestPassingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, Config{}, prometheus.NewRegistry())
 	require.NoError(err)
 
 	err = h.RegisterReadinessCheck("check", check)
@@ -200,7 +200,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 		return "", nil
 	})
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, Config{}, prometheus.NewRegistry())
 	require.NoError(err)
 
 	err = h.RegisterReadinessCheck("check", check)
@@ -250,7 +250,7 @@ func TestPassingThenFailingChecks(t *testing.T) {
 func TestDeadlockRegression(t *testing.T) {
 	require := require.New(t)
 
-	h, err := New(logging.NoLog{}, prometheus.NewRegistry())
+	h, err := New(logging.NoLog{}, Config{}, prometheus.NewRegistry())
 	require.NoError(err)
 
 	var lock sync.Mutex
@@ -273,3 +273,101 @@ func TestDeadlockRegression(t *testing.T) {
 
 	awaitHealthy(h, true)
 }
+
+func TestDisabledAndWarningChecks(t *testing.T) {
+	require := require.New(t)
+
+	checkErr := errors.New("unhealthy")
+	check := CheckerFunc(func() (interface{}, error) {
+		return checkErr.Error(), checkErr
+	})
+
+	config := Config{
+		DisabledChecks: []string{"disabled"},
+		WarningChecks:  []string{"warning"},
+	}
+	h, err := New(logging.NoLog{}, config, prometheus.NewRegistry())
+	require.NoError(err)
+
+	err = h.RegisterHealthCheck("disabled", check)
+	require.NoError(err)
+	err = h.RegisterHealthCheck("warning", check)
+	require.NoError(err)
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	for {
+		results, healthy := h.Health()
+		require.True(healthy)
+		require.NotContains(results, "disabled")
+		require.Contains(results, "warning")
+
+		result := results["warning"]
+		require.True(result.Warning)
+		if result.ContiguousFailures > 0 {
+			require.Equal(checkErr.Error(), *result.Error)
+			break
+		}
+		time.Sleep(awaitFreq)
+	}
+}
+
+func TestReadinessAndLivenessChecks(t *testing.T) {
+	require := require.New(t)
+
+	var shouldCheckErr utils.AtomicBool
+	checkErr := errors.New("unhealthy")
+	check := CheckerFunc(func() (interface{}, error) {
+		if shouldCheckErr.GetValue() {
+			return checkErr.Error(), checkErr
+		}
+		return "", nil
+	})
+
+	config := Config{
+		ReadinessChecks: []string{"ready", "explicit"},
+		LivenessChecks:  []string{"live"},
+	}
+	h, err := New(logging.NoLog{}, config, prometheus.NewRegistry())
+	require.NoError(err)
+
+	err = h.RegisterReadinessCheck("explicit", check)
+	require.NoError(err)
+	err = h.RegisterHealthCheck("explicit", check)
+	require.NoError(err)
+	err = h.RegisterHealthCheck("ready", check)
+	require.NoError(err)
+	err = h.RegisterHealthCheck("live", check)
+	require.NoError(err)
+
+	h.Start(checkFreq)
+	defer h.Stop()
+
+	awaitReadiness(h)
+	awaitLiveness(h, true)
+
+	readinessResults, _ := h.Readiness()
+	require.Len(readinessResults, 2)
+	require.Contains(readinessResults, "ready")
+	require.Contains(readinessResults, "explicit")
+
+	livenessResults, _ := h.Liveness()
+	require.Len(livenessResults, 1)
+	require.Contains(livenessResults, "live")
+
+	shouldCheckErr.SetValue(true)
+
+	awaitLiveness(h, false)
+	for {
+		results, ready := h.Readiness()
+		if !ready {
+			// The explicitly registered readiness check is monotonic, so only
+			// the configured one reports a failure.
+			require.Nil(results["explicit"].Error)
+			require.NotNil(results["ready"].Error)
+			break
+		}
+		time.Sleep(awaitFreq)
+	}
+}
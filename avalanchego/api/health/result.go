@@ -37,4 +37,8 @@ type Result struct {
 
 	// TimeOfFirstFailure of the HealthCheck,
 	TimeOfFirstFailure *time.Time `json:"timeOfFirstFailure,omitempty"`
+
+	// Warning is true if a failure of the HealthCheck doesn't cause the node
+	// to be reported as unhealthy.
+	Warning bool `json:"warning,omitempty"`
 }
@@ -24,18 +24,23 @@ type worker struct {
 	resultsLock sync.RWMutex
 	results     map[string]Result
 
+	// Names of the checks whose failures don't cause the worker to be
+	// unhealthy
+	warningChecks map[string]struct{}
+
 	startOnce sync.Once
 	closeOnce sync.Once
 	closer    chan struct{}
 }
 
-func newWorker(namespace string, registerer prometheus.Registerer) (*worker, error) {
+func newWorker(namespace string, registerer prometheus.Registerer, warningChecks map[string]struct{}) (*worker, error) {
 	metrics, err := newMetrics(namespace, registerer)
 	return &worker{
-		metrics: metrics,
-		checks:  make(map[string]Checker),
-		results: make(map[string]Result),
-		closer:  make(chan struct{}),
+		metrics:       metrics,
+		checks:        make(map[string]Checker),
+		results:       make(map[string]Result),
+		warningChecks: warningChecks,
+		closer:        make(chan struct{}),
 	}, err
 }
 
@@ -81,8 +86,11 @@ func (w *worker) Results() (map[string]Result, bool) {
 	results := make(map[string]Result, len(w.results))
 	healthy := true
 	for name, result := range w.results {
+		if _, ok := w.warningChecks[name]; ok {
+			result.Warning = true
+		}
 		results[name] = result
-		healthy = healthy && result.Error == nil
+		healthy = healthy && (result.Error == nil || result.Warning)
 	}
 	return results, healthy
 }
@@ -17,15 +17,19 @@ var _ Client = &client{}
 type Client interface {
 	GetNodeVersion(context.Context, ...rpc.Option) (*GetNodeVersionReply, error)
 	GetNodeID(context.Context, ...rpc.Option) (ids.NodeID, *signer.ProofOfPossession, error)
+	GetNodeIdentity(context.Context, ...rpc.Option) (*GetNodeIdentityReply, error)
 	GetNodeIP(context.Context, ...rpc.Option) (string, error)
 	GetNetworkID(context.Context, ...rpc.Option) (uint32, error)
 	GetNetworkName(context.Context, ...rpc.Option) (string, error)
 	GetBlockchainID(context.Context, string, ...rpc.Option) (ids.ID, error)
 	Peers(context.Context, ...rpc.Option) ([]Peer, error)
+	PeersDetailed(context.Context, ...rpc.Option) ([]PeerDetailed, error)
 	IsBootstrapped(context.Context, string, ...rpc.Option) (bool, error)
 	GetTxFee(context.Context, ...rpc.Option) (*GetTxFeeResponse, error)
 	Uptime(context.Context, ...rpc.Option) (*UptimeResponse, error)
 	GetVMs(context.Context, ...rpc.Option) (map[ids.ID][]string, error)
+	GetNodeConfig(context.Context, ...rpc.Option) (interface{}, error)
+	GetUpgradeSchedule(context.Context, ...rpc.Option) ([]UpgradeStatus, error)
 }
 
 // Client implementation for an Info API Client
@@ -53,6 +57,12 @@ func (c *client) GetNodeID(ctx context.Context, options ...rpc.Option) (ids.Node
 	return res.NodeID, res.NodePOP, err
 }
 
+func (c *client) GetNodeIdentity(ctx context.Context, options ...rpc.Option) (*GetNodeIdentityReply, error) {
+	res := &GetNodeIdentityReply{}
+	err := c.requester.SendRequest(ctx, "getNodeIdentity", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetNodeIP(ctx context.Context, options ...rpc.Option) (string, error) {
 	res := &GetNodeIPReply{}
 	err := c.requester.SendRequest(ctx, "getNodeIP", struct{}{}, res, options...)
@@ -85,6 +95,12 @@ func (c *client) Peers(ctx context.Context, options ...rpc.Option) ([]Peer, erro
 	return res.Peers, err
 }
 
+func (c *client) PeersDetailed(ctx context.Context, options ...rpc.Option) ([]PeerDetailed, error) {
+	res := &PeersDetailedReply{}
+	err := c.requester.SendRequest(ctx, "peersDetailed", struct{}{}, res, options...)
+	return res.Peers, err
+}
+
 func (c *client) IsBootstrapped(ctx context.Context, chainID string, options ...rpc.Option) (bool, error) {
 	res := &IsBootstrappedResponse{}
 	err := c.requester.SendRequest(ctx, "isBootstrapped", &IsBootstrappedArgs{
@@ -110,3 +126,15 @@ func (c *client) GetVMs(ctx context.Context, options ...rpc.Option) (map[ids.ID]
 	err := c.requester.SendRequest(ctx, "getVMs", struct{}{}, res, options...)
 	return res.VMs, err
 }
+
+func (c *client) GetNodeConfig(ctx context.Context, options ...rpc.Option) (interface{}, error) {
+	var res interface{}
+	err := c.requester.SendRequest(ctx, "getNodeConfig", struct{}{}, &res, options...)
+	return res, err
+}
+
+func (c *client) GetUpgradeSchedule(ctx context.Context, options ...rpc.Option) ([]UpgradeStatus, error) {
+	res := &GetUpgradeScheduleReply{}
+	err := c.requester.SendRequest(ctx, "getUpgradeSchedule", struct{}{}, res, options...)
+	return res.Upgrades, err
+}
@@ -104,6 +104,36 @@ func (_m *Client) GetNetworkName(_a0 context.Context, _a1 ...rpc.Option) (string
 	return r0, r1
 }
 
+// GetNodeConfig provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetNodeConfig(_a0 context.Context, _a1 ...rpc.Option) (interface{}, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 interface{}
+	if rf, ok := ret.Get(0).(func(context.Context, ...rpc.Option) interface{}); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNodeID provides a mock function with given fields: _a0, _a1
 func (_m *Client) GetNodeID(_a0 context.Context, _a1 ...rpc.Option) (string, error) {
 	_va := make([]interface{}, len(_a1))
@@ -160,6 +190,36 @@ func (_m *Client) GetNodeIP(_a0 context.Context, _a1 ...rpc.Option) (string, err
 	return r0, r1
 }
 
+// GetNodeIdentity provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetNodeIdentity(_a0 context.Context, _a1 ...rpc.Option) (*info.GetNodeIdentityReply, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *info.GetNodeIdentityReply
+	if rf, ok := ret.Get(0).(func(context.Context, ...rpc.Option) *info.GetNodeIdentityReply); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*info.GetNodeIdentityReply)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNodeVersion provides a mock function with given fields: _a0, _a1
 func (_m *Client) GetNodeVersion(_a0 context.Context, _a1 ...rpc.Option) (*info.GetNodeVersionReply, error) {
 	_va := make([]interface{}, len(_a1))
@@ -220,6 +280,36 @@ func (_m *Client) GetTxFee(_a0 context.Context, _a1 ...rpc.Option) (*info.GetTxF
 	return r0, r1
 }
 
+// GetUpgradeSchedule provides a mock function with given fields: _a0, _a1
+func (_m *Client) GetUpgradeSchedule(_a0 context.Context, _a1 ...rpc.Option) ([]info.UpgradeStatus, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []info.UpgradeStatus
+	if rf, ok := ret.Get(0).(func(context.Context, ...rpc.Option) []info.UpgradeStatus); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]info.UpgradeStatus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVMs provides a mock function with given fields: _a0, _a1
 func (_m *Client) GetVMs(_a0 context.Context, _a1 ...rpc.Option) (map[ids.ID][]string, error) {
 	_va := make([]interface{}, len(_a1))
@@ -308,6 +398,36 @@ func (_m *Client) Peers(_a0 context.Context, _a1 ...rpc.Option) ([]info.Peer, er
 	return r0, r1
 }
 
+// PeersDetailed provides a mock function with given fields: _a0, _a1
+func (_m *Client) PeersDetailed(_a0 context.Context, _a1 ...rpc.Option) ([]info.PeerDetailed, error) {
+	_va := make([]interface{}, len(_a1))
+	for _i := range _a1 {
+		_va[_i] = _a1[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []info.PeerDetailed
+	if rf, ok := ret.Get(0).(func(context.Context, ...rpc.Option) []info.PeerDetailed); ok {
+		r0 = rf(_a0, _a1...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]info.PeerDetailed)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...rpc.Option) error); ok {
+		r1 = rf(_a0, _a1...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Uptime provides a mock function with given fields: _a0, _a1
 func (_m *Client) Uptime(_a0 context.Context, _a1 ...rpc.Option) (*info.UptimeResponse, error) {
 	_va := make([]interface{}, len(_a1))
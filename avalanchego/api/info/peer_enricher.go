@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+var _ PeerEnricher = &cidrPeerEnricher{}
+
+// PeerEnricher annotates the peers reported by info.peersDetailed with
+// operator provided metadata, such as the ASN or location of a peer's IP.
+//
+// Implementations are called while serving API requests, so they must not
+// perform blocking lookups against remote services.
+type PeerEnricher interface {
+	Enrich(peer.Info) map[string]string
+}
+
+type cidrLabels struct {
+	network *net.IPNet
+	labels  map[string]string
+}
+
+type cidrPeerEnricher struct {
+	ranges []cidrLabels
+}
+
+// NewCIDRPeerEnricher returns a PeerEnricher that labels a peer with the
+// labels of the most specific CIDR range in [labels] that contains the peer's
+// IP.
+func NewCIDRPeerEnricher(labels map[string]map[string]string) (PeerEnricher, error) {
+	ranges := make([]cidrLabels, 0, len(labels))
+	for cidr, cidrLabel := range labels {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse CIDR %q: %w", cidr, err)
+		}
+		ranges = append(ranges, cidrLabels{
+			network: network,
+			labels:  cidrLabel,
+		})
+	}
+	return &cidrPeerEnricher{ranges: ranges}, nil
+}
+
+func (e *cidrPeerEnricher) Enrich(info peer.Info) map[string]string {
+	ip := peerIP(info)
+	if ip == nil {
+		return nil
+	}
+
+	var (
+		labels  map[string]string
+		maxOnes = -1
+	)
+	for _, r := range e.ranges {
+		if !r.network.Contains(ip) {
+			continue
+		}
+		if ones, _ := r.network.Mask.Size(); ones > maxOnes {
+			labels = r.labels
+			maxOnes = ones
+		}
+	}
+	return labels
+}
+
+// peerIP returns the IP the peer claims to be reachable at, falling back to
+// the address of the connection if the peer didn't report one.
+func peerIP(info peer.Info) net.IP {
+	if info.PublicIP != "" {
+		return net.ParseIP(info.PublicIP)
+	}
+	host, _, err := net.SplitHostPort(info.IP)
+	if err != nil {
+		return nil
+	}
+	return net.ParseIP(host)
+}
@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+)
+
+func TestCIDRPeerEnricher(t *testing.T) {
+	require := require.New(t)
+
+	enricher, err := NewCIDRPeerEnricher(map[string]map[string]string{
+		"10.0.0.0/8":  {"asn": "AS1"},
+		"10.1.0.0/16": {"asn": "AS2"},
+	})
+	require.NoError(err)
+
+	// The most specific range is used
+	require.Equal(map[string]string{"asn": "AS2"}, enricher.Enrich(peer.Info{PublicIP: "10.1.2.3"}))
+	require.Equal(map[string]string{"asn": "AS1"}, enricher.Enrich(peer.Info{PublicIP: "10.2.2.3"}))
+
+	// The connection address is used if there is no public IP
+	require.Equal(map[string]string{"asn": "AS1"}, enricher.Enrich(peer.Info{IP: "10.2.2.3:9651"}))
+
+	require.Nil(enricher.Enrich(peer.Info{PublicIP: "192.168.0.1"}))
+	require.Nil(enricher.Enrich(peer.Info{}))
+}
+
+func TestCIDRPeerEnricherInvalidCIDR(t *testing.T) {
+	_, err := NewCIDRPeerEnricher(map[string]map[string]string{
+		"10.0.0.0": {"asn": "AS1"},
+	})
+	require.Error(t, err)
+}
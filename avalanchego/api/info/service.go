@@ -4,9 +4,11 @@
 package info
 
 import (
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/rpc/v2"
 
@@ -18,6 +20,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -47,6 +51,7 @@ type Parameters struct {
 	Version                       *version.Application
 	NodeID                        ids.NodeID
 	NodePOP                       *signer.ProofOfPossession
+	StakingCert                   *x509.Certificate
 	NetworkID                     uint32
 	TxFee                         uint64
 	CreateAssetTxFee              uint64
@@ -58,6 +63,14 @@ type Parameters struct {
 	AddSubnetValidatorFee         uint64
 	AddSubnetDelegatorFee         uint64
 	VMManager                     vms.Manager
+	// NodeConfig is the effective config of the node. Secrets must already be
+	// omitted from its JSON encoding.
+	NodeConfig interface{}
+	// PeerEnricher, if non-nil, annotates the peers returned by PeersDetailed
+	PeerEnricher PeerEnricher
+	// Upgrades is the upgrade schedule of the network, including the Flare
+	// specific upgrades
+	Upgrades []version.Upgrade
 }
 
 // NewService returns a new admin API service
@@ -129,6 +142,47 @@ func (service *Info) GetNodeID(_ *http.Request, _ *struct{}, reply *GetNodeIDRep
 	return nil
 }
 
+// GetNodeIdentityReply are the results from calling GetNodeIdentity
+type GetNodeIdentityReply struct {
+	NodeID ids.NodeID `json:"nodeID"`
+	// NodeIDHex is the hex encoding of [NodeID], as used by the validator
+	// registration contracts
+	NodeIDHex string `json:"nodeIDHex"`
+	// Certificate is the hex encoding of the DER staking certificate
+	Certificate string `json:"certificate"`
+	// CertificateFingerprint is the hex encoding of the SHA-256 hash of the
+	// DER staking certificate
+	CertificateFingerprint string `json:"certificateFingerprint"`
+	// NodePOP is the BLS public key and its proof of possession
+	NodePOP *signer.ProofOfPossession `json:"nodePOP"`
+}
+
+// GetNodeIdentity returns everything needed to register this node as a
+// validator, in the encodings the registration expects
+func (service *Info) GetNodeIdentity(_ *http.Request, _ *struct{}, reply *GetNodeIdentityReply) error {
+	service.log.Debug("Info: GetNodeIdentity called")
+
+	nodeIDHex, err := formatting.Encode(formatting.HexNC, service.NodeID[:])
+	if err != nil {
+		return err
+	}
+	cert, err := formatting.Encode(formatting.HexNC, service.StakingCert.Raw)
+	if err != nil {
+		return err
+	}
+	fingerprint, err := formatting.Encode(formatting.HexNC, hashing.ComputeHash256(service.StakingCert.Raw))
+	if err != nil {
+		return err
+	}
+
+	reply.NodeID = service.NodeID
+	reply.NodeIDHex = nodeIDHex
+	reply.Certificate = cert
+	reply.CertificateFingerprint = fingerprint
+	reply.NodePOP = service.NodePOP
+	return nil
+}
+
 // GetNetworkIDReply are the results from calling GetNetworkID
 type GetNetworkIDReply struct {
 	NetworkID json.Uint32 `json:"networkID"`
@@ -224,6 +278,60 @@ func (service *Info) Peers(_ *http.Request, args *PeersArgs, reply *PeersReply)
 	return nil
 }
 
+type PeerDetailed struct {
+	Peer
+
+	Messages peer.MessageCounts `json:"messages"`
+	// SendFailRateContribution is the fraction of this node's failed sends,
+	// across all connected peers, that were to this peer.
+	SendFailRateContribution json.Float64 `json:"sendFailRateContribution"`
+	// Enrichment is the metadata provided by the configured PeerEnricher
+	Enrichment map[string]string `json:"enrichment,omitempty"`
+}
+
+// PeersDetailedReply are the results from calling PeersDetailed
+type PeersDetailedReply struct {
+	// Number of elements in [Peers]
+	NumPeers json.Uint64 `json:"numPeers"`
+	// Each element is a peer
+	Peers []PeerDetailed `json:"peers"`
+}
+
+// PeersDetailed returns the same peers as Peers along with the messages
+// exchanged with each of them
+func (service *Info) PeersDetailed(_ *http.Request, args *PeersArgs, reply *PeersDetailedReply) error {
+	service.log.Debug("Info: PeersDetailed called")
+
+	peers := service.networking.PeerInfo(args.NodeIDs)
+	messageCounts := service.networking.PeerMessageCounts()
+
+	totalSendFailures := uint64(0)
+	for _, counts := range messageCounts {
+		totalSendFailures += uint64(counts.SendFailures)
+	}
+
+	reply.Peers = make([]PeerDetailed, len(peers))
+	for i, peerInfo := range peers {
+		counts := messageCounts[peerInfo.ID]
+		detailed := PeerDetailed{
+			Peer: Peer{
+				Info:    peerInfo,
+				Benched: service.benchlist.GetBenched(peerInfo.ID),
+			},
+			Messages: counts,
+		}
+		if totalSendFailures > 0 {
+			detailed.SendFailRateContribution = json.Float64(float64(counts.SendFailures) / float64(totalSendFailures))
+		}
+		if service.PeerEnricher != nil {
+			detailed.Enrichment = service.PeerEnricher.Enrich(peerInfo)
+		}
+		reply.Peers[i] = detailed
+	}
+	reply.NumPeers = json.Uint64(len(reply.Peers))
+	return nil
+}
+
 // IsBootstrappedArgs are the arguments for calling IsBootstrapped
 type IsBootstrappedArgs struct {
 	// Alias of the chain
@@ -330,3 +438,41 @@ func (service *Info) GetVMs(_ *http.Request, _ *struct{}, reply *GetVMsReply) er
 	reply.VMs, err = ids.GetRelevantAliases(service.VMManager, vmIDs)
 	return err
 }
+
+// GetNodeConfig returns the effective config of the node, after its flags,
+// environment variables and config files are merged, with secrets redacted.
+func (service *Info) GetNodeConfig(_ *http.Request, _ *struct{}, reply *interface{}) error {
+	service.log.Debug("Info: GetNodeConfig called")
+
+	*reply = service.NodeConfig
+	return nil
+}
+
+// UpgradeStatus is a network upgrade and whether it is active
+type UpgradeStatus struct {
+	version.Upgrade
+	Active bool `json:"active"`
+}
+
+// GetUpgradeScheduleReply is the response from GetUpgradeSchedule
+type GetUpgradeScheduleReply struct {
+	NetworkID json.Uint32     `json:"networkID"`
+	Upgrades  []UpgradeStatus `json:"upgrades"`
+}
+
+// GetUpgradeSchedule returns the activation times of the network upgrades of
+// this network and which of them are currently active
+func (service *Info) GetUpgradeSchedule(_ *http.Request, _ *struct{}, reply *GetUpgradeScheduleReply) error {
+	service.log.Debug("Info: GetUpgradeSchedule called")
+
+	now := time.Now()
+	reply.NetworkID = json.Uint32(service.NetworkID)
+	reply.Upgrades = make([]UpgradeStatus, len(service.Upgrades))
+	for i, upgrade := range service.Upgrades {
+		reply.Upgrades[i] = UpgradeStatus{
+			Upgrade: upgrade,
+			Active:  !now.Before(upgrade.Time),
+		}
+	}
+	return nil
+}
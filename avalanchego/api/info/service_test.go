@@ -4,16 +4,24 @@
 package info
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
 )
 
 var errOops = errors.New("oops")
@@ -107,3 +115,75 @@ func TestGetVMsGetAliasesFails(t *testing.T) {
 
 	require.Equal(t, err, errOops)
 }
+
+func TestGetNodeConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLog := logging.NewMockLogger(ctrl)
+	mockLog.EXPECT().Debug(gomock.Any()).Times(1)
+
+	nodeConfig := map[string]interface{}{"networkID": 14}
+	service := Info{
+		Parameters: Parameters{NodeConfig: nodeConfig},
+		log:        mockLog,
+	}
+
+	var reply interface{}
+	require.NoError(t, service.GetNodeConfig(nil, nil, &reply))
+	require.Equal(t, nodeConfig, reply)
+}
+
+func TestGetUpgradeSchedule(t *testing.T) {
+	require := require.New(t)
+
+	past := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	future := time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+	service := Info{
+		Parameters: Parameters{
+			NetworkID: constants.FlareID,
+			Upgrades: []version.Upgrade{
+				{Name: "apricotPhase3", Time: past},
+				{Name: "xChainMigration", Time: future},
+			},
+		},
+		log: logging.NoLog{},
+	}
+
+	reply := GetUpgradeScheduleReply{}
+	require.NoError(service.GetUpgradeSchedule(nil, nil, &reply))
+	require.EqualValues(constants.FlareID, reply.NetworkID)
+	require.Equal([]UpgradeStatus{
+		{Upgrade: version.Upgrade{Name: "apricotPhase3", Time: past}, Active: true},
+		{Upgrade: version.Upgrade{Name: "xChainMigration", Time: future}, Active: false},
+	}, reply.Upgrades)
+}
+
+func TestGetNodeIdentity(t *testing.T) {
+	require := require.New(t)
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(err)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	nodeID := ids.NodeIDFromCert(cert.Leaf)
+	pop := signer.NewProofOfPossession(sk)
+	service := Info{
+		Parameters: Parameters{
+			NodeID:      nodeID,
+			NodePOP:     pop,
+			StakingCert: cert.Leaf,
+		},
+		log: logging.NoLog{},
+	}
+
+	reply := GetNodeIdentityReply{}
+	require.NoError(service.GetNodeIdentity(nil, nil, &reply))
+	require.Equal(nodeID, reply.NodeID)
+	require.Equal("0x"+hex.EncodeToString(nodeID[:]), reply.NodeIDHex)
+	require.Equal("0x"+hex.EncodeToString(cert.Leaf.Raw), reply.Certificate)
+	fingerprint := sha256.Sum256(cert.Leaf.Raw)
+	require.Equal("0x"+hex.EncodeToString(fingerprint[:]), reply.CertificateFingerprint)
+	require.Equal(pop, reply.NodePOP)
+	require.NoError(reply.NodePOP.Verify())
+}
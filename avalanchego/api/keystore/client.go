@@ -22,6 +22,11 @@ type Client interface {
 	ExportUser(context.Context, api.UserPass, ...rpc.Option) ([]byte, error)
 	// Import [exportedUser] to [importTo]
 	ImportUser(ctx context.Context, importTo api.UserPass, exportedUser []byte, options ...rpc.Option) error
+	// Returns the byte representation of the given user, encrypted with its
+	// password
+	ExportEncryptedUser(context.Context, api.UserPass, ...rpc.Option) ([]byte, error)
+	// Import the [exportedUser] returned by ExportEncryptedUser to [importTo]
+	ImportEncryptedUser(ctx context.Context, importTo api.UserPass, exportedUser []byte, options ...rpc.Option) error
 	// Delete the given user
 	DeleteUser(context.Context, api.UserPass, ...rpc.Option) error
 }
@@ -49,26 +54,47 @@ func (c *client) ListUsers(ctx context.Context, options ...rpc.Option) ([]string
 }
 
 func (c *client) ExportUser(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]byte, error) {
+	return c.exportUser(ctx, user, false, options...)
+}
+
+func (c *client) ImportUser(ctx context.Context, user api.UserPass, account []byte, options ...rpc.Option) error {
+	return c.importUser(ctx, user, account, false, options...)
+}
+
+func (c *client) ExportEncryptedUser(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]byte, error) {
+	return c.exportUser(ctx, user, true, options...)
+}
+
+func (c *client) ImportEncryptedUser(ctx context.Context, user api.UserPass, account []byte, options ...rpc.Option) error {
+	return c.importUser(ctx, user, account, true, options...)
+}
+
+func (c *client) exportUser(ctx context.Context, user api.UserPass, encrypted bool, options ...rpc.Option) ([]byte, error) {
 	res := &ExportUserReply{
 		Encoding: formatting.Hex,
 	}
-	err := c.requester.SendRequest(ctx, "exportUser", &user, res, options...)
+	err := c.requester.SendRequest(ctx, "exportUser", &ExportUserArgs{
+		UserPass:  user,
+		Encoding:  formatting.Hex,
+		Encrypted: encrypted,
+	}, res, options...)
 	if err != nil {
 		return nil, err
 	}
 	return formatting.Decode(res.Encoding, res.User)
 }
 
-func (c *client) ImportUser(ctx context.Context, user api.UserPass, account []byte, options ...rpc.Option) error {
+func (c *client) importUser(ctx context.Context, user api.UserPass, account []byte, encrypted bool, options ...rpc.Option) error {
 	accountStr, err := formatting.Encode(formatting.Hex, account)
 	if err != nil {
 		return err
 	}
 
 	return c.requester.SendRequest(ctx, "importUser", &ImportUserArgs{
-		UserPass: user,
-		User:     accountStr,
-		Encoding: formatting.Hex,
+		UserPass:  user,
+		User:      accountStr,
+		Encoding:  formatting.Hex,
+		Encrypted: encrypted,
 	}, &api.EmptyReply{}, options...)
 }
 
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keystore
+
+import (
+	"crypto/rand"
+	"errors"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const (
+	// argon2id parameters used to derive the export key from the password
+	exportKeyTime    = 3
+	exportKeyMemory  = 64 * 1024
+	exportKeyThreads = 4
+	exportKeyLen     = chacha20poly1305.KeySize
+
+	exportSaltLen = 16
+)
+
+var (
+	errIncorrectExportPassword = errors.New("incorrect password or corrupted export")
+	errEncryptedUserTooShort   = errors.New("encrypted user is too short")
+)
+
+// encryptUser seals the serialized [userBytes] with XChaCha20-Poly1305 under a
+// key derived from [pw] with argon2id. The result is laid out as:
+//
+//	salt || nonce || ciphertext
+func encryptUser(userBytes []byte, pw string) ([]byte, error) {
+	header := make([]byte, exportSaltLen+chacha20poly1305.NonceSizeX)
+	if _, err := rand.Read(header); err != nil {
+		return nil, err
+	}
+	salt, nonce := header[:exportSaltLen], header[exportSaltLen:]
+
+	aead, err := chacha20poly1305.NewX(exportKey(pw, salt))
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, userBytes, nil), nil
+}
+
+// decryptUser opens the [encryptedBytes] produced by encryptUser with [pw].
+func decryptUser(encryptedBytes []byte, pw string) ([]byte, error) {
+	if len(encryptedBytes) < exportSaltLen+chacha20poly1305.NonceSizeX {
+		return nil, errEncryptedUserTooShort
+	}
+	salt := encryptedBytes[:exportSaltLen]
+	nonce := encryptedBytes[exportSaltLen : exportSaltLen+chacha20poly1305.NonceSizeX]
+	ciphertext := encryptedBytes[exportSaltLen+chacha20poly1305.NonceSizeX:]
+
+	aead, err := chacha20poly1305.NewX(exportKey(pw, salt))
+	if err != nil {
+		return nil, err
+	}
+	userBytes, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errIncorrectExportPassword
+	}
+	return userBytes, nil
+}
+
+func exportKey(pw string, salt []byte) []byte {
+	return argon2.IDKey([]byte(pw), salt, exportKeyTime, exportKeyMemory, exportKeyThreads, exportKeyLen)
+}
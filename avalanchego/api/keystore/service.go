@@ -54,6 +54,8 @@ type ImportUserArgs struct {
 	User string `json:"user"`
 	// The encoding of [User] ("hex")
 	Encoding formatting.Encoding `json:"encoding"`
+	// True if [User] was exported with [ExportUserArgs.Encrypted] set
+	Encrypted bool `json:"encrypted"`
 }
 
 func (s *service) ImportUser(r *http.Request, args *ImportUserArgs, _ *api.EmptyReply) error {
@@ -66,6 +68,12 @@ func (s *service) ImportUser(r *http.Request, args *ImportUserArgs, _ *api.Empty
 	if err != nil {
 		return fmt.Errorf("couldn't decode 'user' to bytes: %w", err)
 	}
+	if args.Encrypted {
+		user, err = decryptUser(user, args.Password)
+		if err != nil {
+			return fmt.Errorf("couldn't decrypt 'user': %w", err)
+		}
+	}
 
 	return s.ks.ImportUser(args.Username, args.Password, user)
 }
@@ -75,6 +83,9 @@ type ExportUserArgs struct {
 	api.UserPass
 	// The encoding for the exported user ("hex")
 	Encoding formatting.Encoding `json:"encoding"`
+	// If true, the exported user is encrypted with a key derived from the
+	// password using argon2id
+	Encrypted bool `json:"encrypted"`
 }
 
 type ExportUserReply struct {
@@ -82,6 +93,8 @@ type ExportUserReply struct {
 	User string `json:"user"`
 	// The encoding for the exported user ("hex")
 	Encoding formatting.Encoding `json:"encoding"`
+	// True if the exported user is encrypted
+	Encrypted bool `json:"encrypted"`
 }
 
 func (s *service) ExportUser(_ *http.Request, args *ExportUserArgs, reply *ExportUserReply) error {
@@ -93,6 +106,12 @@ func (s *service) ExportUser(_ *http.Request, args *ExportUserArgs, reply *Expor
 	if err != nil {
 		return err
 	}
+	if args.Encrypted {
+		userBytes, err = encryptUser(userBytes, args.Password)
+		if err != nil {
+			return fmt.Errorf("couldn't encrypt user: %w", err)
+		}
+	}
 
 	// Encode the user from bytes to string
 	reply.User, err = formatting.Encode(args.Encoding, userBytes)
@@ -100,6 +119,7 @@ func (s *service) ExportUser(_ *http.Request, args *ExportUserArgs, reply *Expor
 		return fmt.Errorf("couldn't encode user to string: %w", err)
 	}
 	reply.Encoding = args.Encoding
+	reply.Encrypted = args.Encrypted
 	return nil
 }
 
@@ -324,6 +324,78 @@ func TestServiceExportImport(t *testing.T) {
 	}
 }
 
+func TestServiceExportImportEncrypted(t *testing.T) {
+	ks, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := service{ks: ks.(*keystore)}
+
+	userPass := api.UserPass{
+		Username: "bob",
+		Password: strongPassword,
+	}
+	if err := s.CreateUser(nil, &userPass, &api.EmptyReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	exportReply := ExportUserReply{}
+	if err := s.ExportUser(nil, &ExportUserArgs{
+		UserPass:  userPass,
+		Encoding:  formatting.Hex,
+		Encrypted: true,
+	}, &exportReply); err != nil {
+		t.Fatal(err)
+	}
+	if !exportReply.Encrypted {
+		t.Fatal("export should have been encrypted")
+	}
+
+	newKS, err := CreateTestKeystore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	newS := service{ks: newKS.(*keystore)}
+
+	// The encrypted export can't be imported as a plaintext export
+	if err := newS.ImportUser(nil, &ImportUserArgs{
+		UserPass: userPass,
+		User:     exportReply.User,
+		Encoding: formatting.Hex,
+	}, &api.EmptyReply{}); err == nil {
+		t.Fatal("Should have errored due to the export being encrypted")
+	}
+
+	if err := newS.ImportUser(nil, &ImportUserArgs{
+		UserPass: api.UserPass{
+			Username: "bob",
+			Password: "wrong" + strongPassword,
+		},
+		User:      exportReply.User,
+		Encoding:  formatting.Hex,
+		Encrypted: true,
+	}, &api.EmptyReply{}); err == nil {
+		t.Fatal("Should have errored due to incorrect password")
+	}
+
+	if err := newS.ImportUser(nil, &ImportUserArgs{
+		UserPass:  userPass,
+		User:      exportReply.User,
+		Encoding:  formatting.Hex,
+		Encrypted: true,
+	}, &api.EmptyReply{}); err != nil {
+		t.Fatal(err)
+	}
+
+	users, err := newKS.ListUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0] != "bob" {
+		t.Fatalf("expected imported user bob but got %v", users)
+	}
+}
+
 func TestServiceDeleteUser(t *testing.T) {
 	testUser := "testUser"
 	password := "passwTest@fake01ord"
@@ -0,0 +1,311 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// archiveUnavailableCode is the JSON-RPC error code returned to clients
+	// whose request couldn't be forwarded to the archive node.
+	archiveUnavailableCode = -32004
+
+	getLogsMethod = "eth_getLogs"
+	chainLabel    = "chain"
+)
+
+var (
+	errInvalidArchiveURL  = errors.New("archive URL must be an absolute http or https URL")
+	errNothingToForward   = errors.New("no methods to forward")
+	errInvalidBlockNumber = errors.New("invalid block number")
+
+	_ Wrapper = &ArchiveProxy{}
+)
+
+// ArchiveProxyConfig describes the calls to a chain's RPC endpoint that are
+// forwarded to an archive node rather than served locally.
+type ArchiveProxyConfig struct {
+	// URL of the archive node's RPC endpoint of the chain, e.g.
+	// http://archive:9650/ext/bc/C/rpc
+	URL string `json:"url"`
+	// Methods are always forwarded, keyed by either the full method name (e.g.
+	// debug_traceTransaction) or its namespace (e.g. debug).
+	Methods []string `json:"methods"`
+	// eth_getLogs calls that span more than [GetLogsMaxBlockRange] blocks are
+	// forwarded. If 0, eth_getLogs calls are only forwarded if listed in
+	// [Methods].
+	GetLogsMaxBlockRange uint64 `json:"getLogsMaxBlockRange"`
+}
+
+func (c *ArchiveProxyConfig) Verify() error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidArchiveURL, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%w: %q", errInvalidArchiveURL, c.URL)
+	}
+	if len(c.Methods) == 0 && c.GetLogsMaxBlockRange == 0 {
+		return errNothingToForward
+	}
+	return nil
+}
+
+// ArchiveProxy is a Wrapper that forwards heavy calls to a chain's RPC
+// endpoint, such as tracing or eth_getLogs over large block ranges, to an
+// archive node. Other calls are served locally. A batch is forwarded as a
+// whole if any of its calls is forwarded.
+type ArchiveProxy struct {
+	lookup func(alias string) (ids.ID, error)
+
+	proxied *prometheus.CounterVec
+	errors  *prometheus.CounterVec
+
+	lock sync.Mutex
+	// configs keyed by chainID or chain alias that haven't been resolved to a
+	// chain yet. Chains, and their aliases, are registered after the API
+	// server starts, so configs are resolved when requests arrive.
+	unresolved map[string]ArchiveProxyConfig
+	chains     map[ids.ID]*chainProxy
+}
+
+type chainProxy struct {
+	label   string
+	config  ArchiveProxyConfig
+	methods map[string]struct{}
+	proxy   *httputil.ReverseProxy
+}
+
+// NewArchiveProxy returns an ArchiveProxy that enforces [configs], which are
+// keyed by chainID or chain alias. [lookup] resolves a chain alias to the ID
+// of the chain.
+func NewArchiveProxy(
+	configs map[string]ArchiveProxyConfig,
+	lookup func(alias string) (ids.ID, error),
+	namespace string,
+	registerer prometheus.Registerer,
+) (*ArchiveProxy, error) {
+	unresolved := make(map[string]ArchiveProxyConfig, len(configs))
+	for chain, config := range configs {
+		if err := config.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid archive proxy config for chain %s: %w", chain, err)
+		}
+		unresolved[chain] = config
+	}
+
+	p := &ArchiveProxy{
+		lookup: lookup,
+		proxied: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_proxied",
+				Help:      "Number of requests forwarded to an archive node",
+			},
+			[]string{chainLabel},
+		),
+		errors: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_failed",
+				Help:      "Number of requests that couldn't be forwarded to an archive node",
+			},
+			[]string{chainLabel},
+		),
+		unresolved: unresolved,
+		chains:     make(map[ids.ID]*chainProxy),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(p.proxied),
+		registerer.Register(p.errors),
+	)
+	return p, errs.Err
+}
+
+func (p *ArchiveProxy) WrapHandler(h http.Handler) http.Handler {
+	if len(p.unresolved) == 0 {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			h.ServeHTTP(w, r)
+			return
+		}
+		chain, ok := rpcChain(r.URL.Path)
+		if !ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		cp := p.chainProxy(chain)
+		if cp == nil {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		calls, err := inspectCalls(r, maxInspectedBodySize)
+		if err != nil {
+			writeInspectionError(w, err)
+			return
+		}
+		for _, c := range calls {
+			if cp.forward(c) {
+				p.proxied.WithLabelValues(cp.label).Inc()
+				cp.proxy.ServeHTTP(w, r)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// chainProxy returns the proxy of the chain with alias [chain], or nil if the
+// chain's calls aren't forwarded.
+func (p *ArchiveProxy) chainProxy(chain string) *chainProxy {
+	chainID, err := p.lookup(chain)
+	if err != nil {
+		return nil
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if cp, ok := p.chains[chainID]; ok {
+		return cp
+	}
+	for alias, config := range p.unresolved {
+		id, err := p.lookup(alias)
+		if err != nil {
+			continue
+		}
+		delete(p.unresolved, alias)
+		p.chains[id] = p.newChainProxy(alias, config)
+	}
+	return p.chains[chainID]
+}
+
+func (p *ArchiveProxy) newChainProxy(label string, config ArchiveProxyConfig) *chainProxy {
+	// The URL was checked by [config.Verify].
+	target, _ := url.Parse(config.URL)
+	cp := &chainProxy{
+		label:   label,
+		config:  config,
+		methods: make(map[string]struct{}, len(config.Methods)),
+		proxy: &httputil.ReverseProxy{
+			Director: func(r *http.Request) {
+				r.URL.Scheme = target.Scheme
+				r.URL.Host = target.Host
+				r.URL.Path = target.Path
+				r.URL.RawPath = target.RawPath
+				r.URL.RawQuery = target.RawQuery
+				r.Host = target.Host
+				// The auth token of this node must not leak to the archive
+				// node.
+				r.Header.Del("Authorization")
+			},
+			ErrorHandler: func(w http.ResponseWriter, _ *http.Request, _ error) {
+				p.errors.WithLabelValues(label).Inc()
+				writeArchiveUnavailable(w)
+			},
+		},
+	}
+	for _, method := range config.Methods {
+		cp.methods[method] = struct{}{}
+	}
+	return cp
+}
+
+// forward returns true if [c] should be served by the archive node.
+func (cp *chainProxy) forward(c call) bool {
+	if _, ok := cp.methods[c.Method]; ok {
+		return true
+	}
+	// Ethereum style APIs separate the namespace with an underscore while the
+	// native APIs use a period.
+	if i := strings.IndexAny(c.Method, "_."); i > 0 {
+		if _, ok := cp.methods[c.Method[:i]]; ok {
+			return true
+		}
+	}
+	if c.Method != getLogsMethod || cp.config.GetLogsMaxBlockRange == 0 {
+		return false
+	}
+	blockRange, ok := getLogsBlockRange(c.Params)
+	return ok && blockRange > cp.config.GetLogsMaxBlockRange
+}
+
+// rpcChain returns the chain alias of [path] if [path] is the RPC endpoint of
+// a chain.
+func rpcChain(path string) (string, bool) {
+	path = strings.TrimSuffix(path, "/")
+	prefix := fmt.Sprintf("%s/%s/", baseURL, constants.ChainAliasPrefix)
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+	parts := strings.Split(strings.TrimPrefix(path, prefix), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] != "rpc" {
+		return "", false
+	}
+	return parts[0], true
+}
+
+// getLogsBlockRange returns the number of blocks an eth_getLogs call with
+// [params] spans. If the span can't be known without the current height, as
+// is the case for filters ending at the latest block, false is returned.
+func getLogsBlockRange(params json.RawMessage) (uint64, bool) {
+	var filters []struct {
+		BlockHash *string `json:"blockHash"`
+		FromBlock *string `json:"fromBlock"`
+		ToBlock   *string `json:"toBlock"`
+	}
+	if err := json.Unmarshal(params, &filters); err != nil || len(filters) == 0 {
+		return 0, false
+	}
+	filter := filters[0]
+	if filter.BlockHash != nil || filter.FromBlock == nil || filter.ToBlock == nil {
+		return 0, false
+	}
+	from, err := parseBlockNumber(*filter.FromBlock)
+	if err != nil {
+		return 0, false
+	}
+	to, err := parseBlockNumber(*filter.ToBlock)
+	if err != nil || to < from {
+		return 0, false
+	}
+	return to - from + 1, true
+}
+
+// parseBlockNumber parses a hex encoded block number or the earliest tag.
+// Tags relative to the current height aren't supported.
+func parseBlockNumber(s string) (uint64, error) {
+	if s == "earliest" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(s, "0x") {
+		return 0, fmt.Errorf("%w: %q", errInvalidBlockNumber, s)
+	}
+	return strconv.ParseUint(s[2:], 16, 64)
+}
+
+func writeArchiveUnavailable(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadGateway)
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":%d,"message":"archive node unavailable"},"id":null}`, archiveUnavailableCode)
+}
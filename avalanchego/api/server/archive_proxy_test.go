@@ -0,0 +1,200 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestArchiveProxy(t *testing.T) {
+	assert := assert.New(t)
+
+	var archiveBody, archiveAuth string
+	archive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		archiveBody = string(body)
+		archiveAuth = r.Header.Get("Authorization")
+		assert.Equal("/ext/bc/C/rpc", r.URL.Path)
+		_, _ = w.Write([]byte("archive"))
+	}))
+	defer archive.Close()
+
+	chainID := ids.GenerateTestID()
+	lookup := func(alias string) (ids.ID, error) {
+		if alias == "C" || alias == chainID.String() {
+			return chainID, nil
+		}
+		return ids.Empty, errors.New("unknown alias")
+	}
+	p, err := NewArchiveProxy(
+		map[string]ArchiveProxyConfig{
+			"C": {
+				URL:                  archive.URL + "/ext/bc/C/rpc",
+				Methods:              []string{"debug", "eth_getProof"},
+				GetLogsMaxBlockRange: 100,
+			},
+		},
+		lookup,
+		"",
+		prometheus.NewRegistry(),
+	)
+	assert.NoError(err)
+	h := p.WrapHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("local"))
+	}))
+
+	serve := func(path, body string) string {
+		r := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer token")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Body.String()
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "namespace",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"debug_traceTransaction","params":["0x01"]}`,
+			expected: "archive",
+		},
+		{
+			name:     "full method name",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getProof"}`,
+			expected: "archive",
+		},
+		{
+			name:     "chainID path",
+			path:     "/ext/bc/" + chainID.String() + "/rpc",
+			body:     `{"method":"debug_traceBlockByNumber"}`,
+			expected: "archive",
+		},
+		{
+			name:     "light call",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_blockNumber"}`,
+			expected: "local",
+		},
+		{
+			name:     "batch with a heavy call",
+			path:     "/ext/bc/C/rpc",
+			body:     `[{"method":"eth_blockNumber"},{"method":"debug_traceCall"}]`,
+			expected: "archive",
+		},
+		{
+			name:     "large getLogs range",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getLogs","params":[{"fromBlock":"0x0","toBlock":"0x64"}]}`,
+			expected: "archive",
+		},
+		{
+			name:     "large getLogs range from earliest",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getLogs","params":[{"fromBlock":"earliest","toBlock":"0x1000"}]}`,
+			expected: "archive",
+		},
+		{
+			name:     "small getLogs range",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getLogs","params":[{"fromBlock":"0x1","toBlock":"0x64"}]}`,
+			expected: "local",
+		},
+		{
+			name:     "getLogs up to latest",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getLogs","params":[{"fromBlock":"0x0","toBlock":"latest"}]}`,
+			expected: "local",
+		},
+		{
+			name:     "getLogs by block hash",
+			path:     "/ext/bc/C/rpc",
+			body:     `{"method":"eth_getLogs","params":[{"blockHash":"0x01"}]}`,
+			expected: "local",
+		},
+		{
+			name:     "other endpoint",
+			path:     "/ext/bc/C/avax",
+			body:     `{"method":"debug_traceTransaction"}`,
+			expected: "local",
+		},
+		{
+			name:     "unconfigured chain",
+			path:     "/ext/bc/X",
+			body:     `{"method":"debug_traceTransaction"}`,
+			expected: "local",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			archiveBody = ""
+			assert.Equal(test.expected, serve(test.path, test.body))
+			if test.expected == "archive" {
+				// The request is forwarded untouched, except for its auth
+				// token.
+				assert.Equal(test.body, archiveBody)
+				assert.Empty(archiveAuth)
+			}
+		})
+	}
+	assert.Equal(6.0, testutil.ToFloat64(p.proxied.WithLabelValues("C")))
+
+	// Requests are answered even if the archive node is unavailable.
+	archive.Close()
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/C/rpc", strings.NewReader(`{"method":"debug_traceCall"}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(http.StatusBadGateway, w.Code)
+	assert.Equal(1.0, testutil.ToFloat64(p.errors.WithLabelValues("C")))
+}
+
+func TestArchiveProxyConfigVerify(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      ArchiveProxyConfig
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: ArchiveProxyConfig{URL: "https://archive:9650/ext/bc/C/rpc", Methods: []string{"debug"}},
+		},
+		{
+			name:        "relative URL",
+			config:      ArchiveProxyConfig{URL: "/ext/bc/C/rpc", Methods: []string{"debug"}},
+			expectedErr: errInvalidArchiveURL,
+		},
+		{
+			name:        "unsupported scheme",
+			config:      ArchiveProxyConfig{URL: "ws://archive:9650/ext/bc/C/ws", Methods: []string{"debug"}},
+			expectedErr: errInvalidArchiveURL,
+		},
+		{
+			name:        "nothing to forward",
+			config:      ArchiveProxyConfig{URL: "http://archive:9650/ext/bc/C/rpc"},
+			expectedErr: errNothingToForward,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ErrorIs(t, test.config.Verify(), test.expectedErr)
+		})
+	}
+}
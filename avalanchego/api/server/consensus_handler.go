@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// Endpoints, relative to the base of a chain's routes, that serve the state of
+// the chain's consensus
+const (
+	consensusParamsEndpoint     = "/consensus/params"
+	consensusProcessingEndpoint = "/consensus/processing"
+)
+
+// newConsensusHandler returns a handler that responds to GET requests with the
+// JSON encoding of the result of [report]. The handler must be called with the
+// chain's context lock held.
+func newConsensusHandler(report func() interface{}) *common.HTTPHandler {
+	return &common.HTTPHandler{
+		LockOptions: common.ReadLock,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet {
+				http.Error(w, "only GET requests are supported", http.StatusMethodNotAllowed)
+				return
+			}
+
+			// Make sure the content type is set before writing the header.
+			w.Header().Set("Content-Type", "application/json")
+			_ = stdjson.NewEncoder(w).Encode(report())
+		}),
+	}
+}
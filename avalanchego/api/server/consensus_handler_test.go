@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+func TestConsensusHandler(t *testing.T) {
+	require := require.New(t)
+
+	info := common.ConsensusInfo{
+		Engine: "snowman",
+		Params: snowball.Parameters{
+			K:            20,
+			Alpha:        15,
+			BetaVirtuous: 15,
+			BetaRogue:    20,
+		},
+		OutstandingPolls: 4,
+		Processing:       2,
+		LastRequestID:    100,
+	}
+	handler := newConsensusHandler(func() interface{} {
+		return info
+	})
+	require.EqualValues(common.ReadLock, handler.LockOptions)
+
+	w := httptest.NewRecorder()
+	handler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, consensusParamsEndpoint, nil))
+	require.Equal(http.StatusOK, w.Code)
+	require.Equal("application/json", w.Header().Get("Content-Type"))
+
+	var reply struct {
+		Engine string `json:"engine"`
+		Params struct {
+			K            int `json:"k"`
+			Alpha        int `json:"alpha"`
+			BetaVirtuous int `json:"betaVirtuous"`
+			BetaRogue    int `json:"betaRogue"`
+		} `json:"params"`
+		OutstandingPolls int    `json:"outstandingPolls"`
+		Processing       int    `json:"processing"`
+		LastRequestID    uint32 `json:"lastRequestID"`
+	}
+	require.NoError(stdjson.Unmarshal(w.Body.Bytes(), &reply))
+	require.Equal("snowman", reply.Engine)
+	require.Equal(20, reply.Params.K)
+	require.Equal(15, reply.Params.Alpha)
+	require.Equal(15, reply.Params.BetaVirtuous)
+	require.Equal(20, reply.Params.BetaRogue)
+	require.Equal(4, reply.OutstandingPolls)
+	require.Equal(2, reply.Processing)
+	require.EqualValues(100, reply.LastRequestID)
+
+	w = httptest.NewRecorder()
+	handler.Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, consensusParamsEndpoint, nil))
+	require.Equal(http.StatusMethodNotAllowed, w.Code)
+}
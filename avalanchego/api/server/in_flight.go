@@ -0,0 +1,36 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+var _ Wrapper = &InFlight{}
+
+// InFlight is a Wrapper that counts the API calls being served. Websocket
+// connections are served for as long as they're open, so they aren't counted.
+type InFlight struct {
+	count int64
+}
+
+// Len returns the number of API calls being served.
+func (f *InFlight) Len() int {
+	return int(atomic.LoadInt64(&f.count))
+}
+
+func (f *InFlight) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		atomic.AddInt64(&f.count, 1)
+		defer atomic.AddInt64(&f.count, -1)
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlight(t *testing.T) {
+	assert := assert.New(t)
+
+	f := &InFlight{}
+	var served []int
+	h := f.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		served = append(served, f.Len())
+	}))
+
+	h.ServeHTTP(httptest.NewRecorder(), newRateLimitedRequest("1.2.3.4:5000", `{"method":"platform.getHeight"}`))
+	assert.Zero(f.Len())
+
+	// Websocket connections aren't counted.
+	r := newRateLimitedRequest("1.2.3.4:5000", "")
+	r.Header.Set("Upgrade", "websocket")
+	h.ServeHTTP(httptest.NewRecorder(), r)
+	assert.Zero(f.Len())
+
+	assert.Equal([]int{1, 0}, served)
+}
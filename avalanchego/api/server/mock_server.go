@@ -6,6 +6,7 @@ package server
 
 import (
 	io "io"
+	os "os"
 	reflect "reflect"
 	sync "sync"
 	time "time"
@@ -260,6 +261,20 @@ func (mr *MockServerMockRecorder) DispatchTLS(certBytes, keyBytes interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchTLS", reflect.TypeOf((*MockServer)(nil).DispatchTLS), certBytes, keyBytes)
 }
 
+// DispatchUnix mocks base method.
+func (m *MockServer) DispatchUnix(socketPath string, perms os.FileMode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchUnix", socketPath, perms)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DispatchUnix indicates an expected call of DispatchUnix.
+func (mr *MockServerMockRecorder) DispatchUnix(socketPath, perms interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchUnix", reflect.TypeOf((*MockServer)(nil).DispatchUnix), socketPath, perms)
+}
+
 // Initialize mocks base method.
 func (m *MockServer) Initialize(log logging.Logger, factory logging.Factory, host string, port uint16, allowedOrigins []string, shutdownTimeout time.Duration, nodeID ids.NodeID, wrappers ...Wrapper) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,423 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// maxInspectedBodySize is the largest request body that is inspected for
+	// JSON-RPC methods by the wrappers other than the rate limiter, which has
+	// its own setting. Larger bodies are rejected by the wrappers that inspect
+	// them, so that they can't bypass what the wrappers enforce.
+	maxInspectedBodySize = 1 << 20 // 1 MiB
+
+	// forwardedForHeader lists the addresses a request was forwarded for, as
+	// appended by each proxy it went through.
+	forwardedForHeader = "X-Forwarded-For"
+
+	// ipLimitLabel is the [limitLabel] value of requests rejected by the
+	// per-IP limit.
+	ipLimitLabel = "ip"
+	limitLabel   = "limit"
+
+	// rateLimitedCode is the JSON-RPC error code returned to rate limited
+	// clients.
+	rateLimitedCode = -32005
+)
+
+var (
+	errInvalidRateLimit   = errors.New("rate limit must have a positive rate and burst")
+	errInvalidMaxBodySize = errors.New("max body size must be positive")
+	errBodyTooLarge       = errors.New("request body is too large")
+
+	_ Wrapper = &RateLimiter{}
+)
+
+// RateLimit is a token bucket that refills at [Rate] tokens per second and
+// holds at most [Burst] tokens.
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst int     `json:"burst"`
+}
+
+func (l RateLimit) verify() error {
+	if l.Rate <= 0 || l.Burst <= 0 {
+		return fmt.Errorf("%w: rate %f, burst %d", errInvalidRateLimit, l.Rate, l.Burst)
+	}
+	return nil
+}
+
+// refillTime returns how long it takes an empty bucket to fill up.
+func (l RateLimit) refillTime() time.Duration {
+	return time.Duration(float64(l.Burst) / l.Rate * float64(time.Second))
+}
+
+type RateLimiterConfig struct {
+	// PerIP limits the requests each client IP may make. If the rate is <= 0,
+	// requests are not limited per IP.
+	PerIP RateLimit `json:"perIP"`
+	// Methods limits the JSON-RPC calls each client IP may make, keyed by
+	// either the full method name (e.g. eth_getLogs) or its namespace (e.g.
+	// debug). A full method name takes precedence over its namespace.
+	Methods map[string]RateLimit `json:"methods"`
+	// MaxBodySize is the largest request body, in bytes, that is inspected for
+	// the JSON-RPC calls it makes when [Methods] is set. Larger bodies are
+	// rejected so that they can't bypass the method limits.
+	MaxBodySize int64 `json:"maxBodySize"`
+	// TrustedProxies are the CIDRs of the proxies whose X-Forwarded-For
+	// header is trusted. Requests received from a trusted proxy are limited by
+	// the address it forwarded them for. If empty, requests are always limited
+	// by the address they were received from.
+	TrustedProxies []string `json:"trustedProxies"`
+}
+
+// Enabled returns true if any limit is configured.
+func (c *RateLimiterConfig) Enabled() bool {
+	return c.PerIP.Rate > 0 || len(c.Methods) > 0
+}
+
+func (c *RateLimiterConfig) Verify() error {
+	if c.PerIP.Rate > 0 {
+		if err := c.PerIP.verify(); err != nil {
+			return fmt.Errorf("invalid per IP limit: %w", err)
+		}
+	}
+	for method, limit := range c.Methods {
+		if err := limit.verify(); err != nil {
+			return fmt.Errorf("invalid limit for %q: %w", method, err)
+		}
+	}
+	if len(c.Methods) > 0 && c.MaxBodySize <= 0 {
+		return fmt.Errorf("%w: %d", errInvalidMaxBodySize, c.MaxBodySize)
+	}
+	_, err := parseTrustedProxies(c.TrustedProxies)
+	return err
+}
+
+func parseTrustedProxies(cidrs []string) ([]*net.IPNet, error) {
+	proxies := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, proxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", cidr, err)
+		}
+		proxies[i] = proxy
+	}
+	return proxies, nil
+}
+
+// RateLimiter is a Wrapper that rejects requests from clients that exceed
+// their per-IP or per-method limits with 429 Too Many Requests.
+type RateLimiter struct {
+	config         RateLimiterConfig
+	trustedProxies []*net.IPNet
+	clock          mockable.Clock
+
+	allowed prometheus.Counter
+	limited *prometheus.CounterVec
+
+	lock    sync.Mutex
+	clients map[string]*clientLimiter
+	// a client that has been idle for [staleAfter] has full buckets, so its
+	// limiters can be dropped.
+	staleAfter time.Duration
+	lastPrune  time.Time
+}
+
+type clientLimiter struct {
+	lastSeen time.Time
+	ip       *rate.Limiter
+	methods  map[string]*rate.Limiter
+}
+
+// NewRateLimiter returns a RateLimiter that enforces [config] and registers
+// its metrics with [registerer].
+func NewRateLimiter(config RateLimiterConfig, namespace string, registerer prometheus.Registerer) (*RateLimiter, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	trustedProxies, err := parseTrustedProxies(config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &RateLimiter{
+		config:         config,
+		trustedProxies: trustedProxies,
+		allowed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_allowed",
+			Help:      "Number of requests that were within their rate limits",
+		}),
+		limited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_limited",
+				Help:      "Number of requests rejected for exceeding a rate limit",
+			},
+			[]string{limitLabel},
+		),
+		clients: make(map[string]*clientLimiter),
+	}
+	if config.PerIP.Rate > 0 {
+		l.staleAfter = config.PerIP.refillTime()
+	}
+	for _, limit := range config.Methods {
+		if refillTime := limit.refillTime(); refillTime > l.staleAfter {
+			l.staleAfter = refillTime
+		}
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(l.allowed),
+		registerer.Register(l.limited),
+	)
+	return l, errs.Err
+}
+
+func (l *RateLimiter) WrapHandler(h http.Handler) http.Handler {
+	if !l.config.Enabled() {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var methods []string
+		if len(l.config.Methods) > 0 && r.Method == http.MethodPost {
+			var err error
+			methods, err = inspectMethods(r, l.config.MaxBodySize)
+			if err != nil {
+				writeInspectionError(w, err)
+				return
+			}
+		}
+
+		if limit, ok := l.allow(l.clientIP(r), methods); !ok {
+			l.limited.WithLabelValues(limit).Inc()
+			writeRateLimited(w)
+			return
+		}
+		l.allowed.Inc()
+		h.ServeHTTP(w, r)
+	})
+}
+
+// allow consumes a token for the request and for each of its [methods]. If
+// any limit is exceeded, the name of that limit is returned along with false.
+func (l *RateLimiter) allow(ip string, methods []string) (string, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.clock.Time()
+	l.prune(now)
+
+	client, ok := l.clients[ip]
+	if !ok {
+		client = &clientLimiter{
+			methods: make(map[string]*rate.Limiter),
+		}
+		if l.config.PerIP.Rate > 0 {
+			client.ip = newLimiter(l.config.PerIP)
+		}
+		l.clients[ip] = client
+	}
+	client.lastSeen = now
+
+	if client.ip != nil && !client.ip.AllowN(now, 1) {
+		return ipLimitLabel, false
+	}
+	for _, method := range methods {
+		key, limit, ok := l.methodLimit(method)
+		if !ok {
+			continue
+		}
+		limiter, ok := client.methods[key]
+		if !ok {
+			limiter = newLimiter(limit)
+			client.methods[key] = limiter
+		}
+		if !limiter.AllowN(now, 1) {
+			return key, false
+		}
+	}
+	return "", true
+}
+
+// methodLimit returns the configured limit that applies to [method] and the
+// key it was configured under.
+func (l *RateLimiter) methodLimit(method string) (string, RateLimit, bool) {
+	if limit, ok := l.config.Methods[method]; ok {
+		return method, limit, true
+	}
+	// Ethereum style APIs separate the namespace with an underscore while the
+	// native APIs use a period.
+	if i := strings.IndexAny(method, "_."); i > 0 {
+		namespace := method[:i]
+		if limit, ok := l.config.Methods[namespace]; ok {
+			return namespace, limit, true
+		}
+	}
+	return "", RateLimit{}, false
+}
+
+// prune drops the limiters of clients that have been idle long enough for
+// their buckets to refill.
+// Assumes [l.lock] is held.
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < l.staleAfter {
+		return
+	}
+	l.lastPrune = now
+	for ip, client := range l.clients {
+		if now.Sub(client.lastSeen) >= l.staleAfter {
+			delete(l.clients, ip)
+		}
+	}
+}
+
+func newLimiter(limit RateLimit) *rate.Limiter {
+	return rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+}
+
+// call is a JSON-RPC call.
+type call struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+}
+
+// inspectMethods returns the JSON-RPC methods called by [r], which may be a
+// single or a batch request. The body of [r] is left unconsumed. Bodies larger
+// than [maxBodySize] are rejected with [errBodyTooLarge].
+func inspectMethods(r *http.Request, maxBodySize int64) ([]string, error) {
+	calls, err := inspectCalls(r, maxBodySize)
+	if err != nil {
+		return nil, err
+	}
+	methods := make([]string, len(calls))
+	for i, c := range calls {
+		methods[i] = c.Method
+	}
+	return methods, nil
+}
+
+// inspectCalls returns the JSON-RPC calls of [r], which may be a single or a
+// batch request. The body of [r] is left unconsumed. Bodies larger than
+// [maxBodySize] are rejected with [errBodyTooLarge].
+func inspectCalls(r *http.Request, maxBodySize int64) ([]call, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read request body: %w", err)
+	}
+	if int64(len(body)) > maxBodySize {
+		return nil, fmt.Errorf("%w: more than %d bytes", errBodyTooLarge, maxBodySize)
+	}
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: bytes.NewReader(body),
+		Closer: r.Body,
+	}
+
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var msgs []json.RawMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			// Malformed requests are rejected by the handler.
+			return nil, nil
+		}
+		// The handler answers each call of a batch on its own, so a malformed
+		// call doesn't hide the others.
+		calls := make([]call, 0, len(msgs))
+		for _, msg := range msgs {
+			var c call
+			if err := json.Unmarshal(msg, &c); err == nil {
+				calls = append(calls, c)
+			}
+		}
+		return calls, nil
+	}
+	var c call
+	if err := json.Unmarshal(body, &c); err != nil {
+		return nil, nil
+	}
+	return []call{c}, nil
+}
+
+// writeInspectionError rejects a request whose body couldn't be inspected.
+func writeInspectionError(w http.ResponseWriter, err error) {
+	code := http.StatusBadRequest
+	if errors.Is(err, errBodyTooLarge) {
+		code = http.StatusRequestEntityTooLarge
+	}
+	http.Error(w, err.Error(), code)
+}
+
+// clientIP returns the IP that [r] is limited by. This is the address [r] was
+// received from, unless that address is a trusted proxy. Then, the addresses
+// the proxies forwarded [r] for are followed back to the first one that isn't
+// a trusted proxy.
+func (l *RateLimiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if !l.isTrustedProxy(net.ParseIP(host)) {
+		return host
+	}
+
+	var hops []string
+	for _, value := range r.Header.Values(forwardedForHeader) {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	// Each proxy appends the address it received the request from, so the
+	// addresses are followed from the last one.
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// The addresses before a malformed one can't be trusted.
+			break
+		}
+		host = ip.String()
+		if !l.isTrustedProxy(ip) {
+			break
+		}
+	}
+	return host
+}
+
+func (l *RateLimiter) isTrustedProxy(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range l.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func writeRateLimited(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusTooManyRequests)
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":%d,"message":"rate limit exceeded"},"id":null}`, rateLimitedCode)
+}
@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedRequest(remoteAddr, body string) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/ext/bc/C/rpc", strings.NewReader(body))
+	r.RemoteAddr = remoteAddr
+	return r
+}
+
+func TestRateLimiterPerIP(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := NewRateLimiter(RateLimiterConfig{
+		PerIP: RateLimit{Rate: 1, Burst: 2},
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	now := time.Now()
+	l.clock.Set(now)
+
+	h := l.WrapHandler(&testHandler{})
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", ""))
+		assert.Equal(http.StatusOK, w.Code)
+	}
+
+	// The burst is exhausted.
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5001", ""))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Contains(w.Body.String(), "rate limit exceeded")
+
+	// Other clients have their own bucket.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("5.6.7.8:5000", ""))
+	assert.Equal(http.StatusOK, w.Code)
+
+	// The bucket refills over time.
+	l.clock.Set(now.Add(time.Second))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", ""))
+	assert.Equal(http.StatusOK, w.Code)
+
+	assert.Equal(4.0, testutil.ToFloat64(l.allowed))
+	assert.Equal(1.0, testutil.ToFloat64(l.limited.WithLabelValues(ipLimitLabel)))
+}
+
+func TestRateLimiterMethods(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := NewRateLimiter(RateLimiterConfig{
+		Methods: map[string]RateLimit{
+			"eth_getLogs": {Rate: 1, Burst: 1},
+			"debug":       {Rate: 1, Burst: 2},
+		},
+		MaxBodySize: maxInspectedBodySize,
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	l.clock.Set(time.Now())
+
+	var bodies []string
+	h := l.WrapHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		bodies = append(bodies, string(body))
+	}))
+
+	getLogs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs","params":[]}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", getLogs))
+	assert.Equal(http.StatusOK, w.Code)
+	// The handler receives the whole body.
+	assert.Equal([]string{getLogs}, bodies)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", getLogs))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	// Methods without a limit aren't affected.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `{"method":"eth_blockNumber"}`))
+	assert.Equal(http.StatusOK, w.Code)
+
+	// Every call in a batch consumes a token of its namespace.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `[{"method":"debug_traceTransaction"},{"method":"debug_traceCall"}]`))
+	assert.Equal(http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `{"method":"debug_traceBlockByNumber"}`))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+
+	assert.Equal(1.0, testutil.ToFloat64(l.limited.WithLabelValues("eth_getLogs")))
+	assert.Equal(1.0, testutil.ToFloat64(l.limited.WithLabelValues("debug")))
+}
+
+func TestRateLimiterUninspectableBodies(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := NewRateLimiter(RateLimiterConfig{
+		Methods: map[string]RateLimit{
+			"eth_getLogs": {Rate: 1, Burst: 1},
+		},
+		MaxBodySize: 1024,
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	l.clock.Set(time.Now())
+
+	handled := 0
+	h := l.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		handled++
+	}))
+
+	// Bodies too large to be inspected can't bypass the method limits.
+	padding := strings.Repeat(" ", 1024)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `{"method":"eth_getLogs"}`+padding))
+	assert.Equal(http.StatusRequestEntityTooLarge, w.Code)
+	assert.Zero(handled)
+
+	// A malformed call doesn't hide the other calls of its batch.
+	batch := `[1,{"method":"eth_getLogs"}]`
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", batch))
+	assert.Equal(http.StatusOK, w.Code)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", batch))
+	assert.Equal(http.StatusTooManyRequests, w.Code)
+	assert.Equal(1, handled)
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := NewRateLimiter(RateLimiterConfig{
+		PerIP: RateLimit{Rate: 1, Burst: 10},
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+	now := time.Now()
+	l.clock.Set(now)
+
+	_, ok := l.allow("1.2.3.4", nil)
+	assert.True(ok)
+	assert.Len(l.clients, 1)
+
+	// The client's bucket hasn't refilled yet.
+	l.clock.Set(now.Add(5 * time.Second))
+	_, ok = l.allow("5.6.7.8", nil)
+	assert.True(ok)
+	assert.Len(l.clients, 2)
+
+	l.clock.Set(now.Add(12 * time.Second))
+	_, ok = l.allow("5.6.7.8", nil)
+	assert.True(ok)
+	assert.Len(l.clients, 1)
+	assert.Contains(l.clients, "5.6.7.8")
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	l, err := NewRateLimiter(RateLimiterConfig{}, "", prometheus.NewRegistry())
+	assert.NoError(t, err)
+
+	h := &testHandler{}
+	assert.Equal(t, h, l.WrapHandler(h))
+}
+
+func TestRateLimiterTrustedProxies(t *testing.T) {
+	assert := assert.New(t)
+
+	l, err := NewRateLimiter(RateLimiterConfig{
+		PerIP:          RateLimit{Rate: 1, Burst: 1},
+		TrustedProxies: []string{"10.0.0.0/8", "192.168.1.1/32"},
+	}, "", prometheus.NewRegistry())
+	assert.NoError(err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor []string
+		expectedIP   string
+	}{
+		{
+			name:       "not forwarded",
+			remoteAddr: "10.0.0.1:5000",
+			expectedIP: "10.0.0.1",
+		},
+		{
+			name:         "untrusted sender",
+			remoteAddr:   "1.2.3.4:5000",
+			forwardedFor: []string{"5.6.7.8"},
+			expectedIP:   "1.2.3.4",
+		},
+		{
+			name:         "trusted proxy",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: []string{"5.6.7.8"},
+			expectedIP:   "5.6.7.8",
+		},
+		{
+			name:         "chain of trusted proxies",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: []string{"9.9.9.9, 5.6.7.8", "192.168.1.1"},
+			expectedIP:   "5.6.7.8",
+		},
+		{
+			name:         "malformed address",
+			remoteAddr:   "10.0.0.1:5000",
+			forwardedFor: []string{"5.6.7.8, bad, 10.0.0.2"},
+			expectedIP:   "10.0.0.2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := newRateLimitedRequest(test.remoteAddr, "")
+			for _, value := range test.forwardedFor {
+				r.Header.Add(forwardedForHeader, value)
+			}
+			assert.Equal(test.expectedIP, l.clientIP(r))
+		})
+	}
+}
+
+func TestRateLimiterInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      RateLimiterConfig
+		expectedErr error
+	}{
+		{
+			name: "invalid method limit",
+			config: RateLimiterConfig{
+				Methods: map[string]RateLimit{
+					"eth_getLogs": {Rate: 1},
+				},
+				MaxBodySize: maxInspectedBodySize,
+			},
+			expectedErr: errInvalidRateLimit,
+		},
+		{
+			name: "no max body size",
+			config: RateLimiterConfig{
+				Methods: map[string]RateLimit{
+					"eth_getLogs": {Rate: 1, Burst: 1},
+				},
+			},
+			expectedErr: errInvalidMaxBodySize,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewRateLimiter(test.config, "", prometheus.NewRegistry())
+			assert.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+
+	_, err := NewRateLimiter(RateLimiterConfig{
+		TrustedProxies: []string{"10.0.0.1"},
+	}, "", prometheus.NewRegistry())
+	assert.Error(t, err)
+}
@@ -11,6 +11,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"sync"
 	"time"
@@ -76,6 +77,10 @@ type Server interface {
 	Dispatch() error
 	// DispatchTLS starts the API server with the provided TLS certificate
 	DispatchTLS(certBytes, keyBytes []byte) error
+	// DispatchUnix starts the API server on a Unix socket at [socketPath],
+	// which only the users allowed by [perms] may connect to. It may be called
+	// in addition to Dispatch or DispatchTLS.
+	DispatchUnix(socketPath string, perms os.FileMode) error
 	// RegisterChain registers the API endpoints associated with this chain. That is,
 	// add <route, handler> pairs to server so that API calls can be made to the VM.
 	// This method runs in a goroutine to avoid a deadlock in the event that the caller
@@ -111,6 +116,8 @@ type server struct {
 	router *router
 
 	srv *http.Server
+	// Serves the API on a Unix socket, if dispatched
+	unixSrv *http.Server
 }
 
 // New returns an instance of a Server.
@@ -155,6 +162,13 @@ func (s *server) Initialize(
 	for _, wrapper := range wrappers {
 		s.handler = wrapper.WrapHandler(s.handler)
 	}
+
+	// Created up front so that a Unix socket that is dispatched concurrently
+	// with Shutdown is always closed.
+	s.unixSrv = &http.Server{
+		Handler:           s.handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
 }
 
 func (s *server) Dispatch() error {
@@ -219,6 +233,29 @@ func (s *server) DispatchTLS(certBytes, keyBytes []byte) error {
 	return s.srv.Serve(listener)
 }
 
+func (s *server) DispatchUnix(socketPath string, perms os.FileMode) error {
+	// A socket left behind by an unclean shutdown would prevent listening.
+	if info, err := os.Stat(socketPath); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(socketPath); err != nil {
+			return err
+		}
+	}
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return err
+	}
+	if err := os.Chmod(socketPath, perms); err != nil {
+		_ = listener.Close()
+		return err
+	}
+
+	s.log.Info("HTTP API server listening",
+		zap.String("path", socketPath),
+		zap.Stringer("perms", perms),
+	)
+	return s.unixSrv.Serve(listener)
+}
+
 func (s *server) RegisterChain(chainName string, engine common.Engine) {
 	go s.registerChain(chainName, engine)
 }
@@ -265,6 +302,28 @@ func (s *server) registerChain(chainName string, engine common.Engine) {
 			)
 		}
 	}
+
+	// Expose the parameters that consensus actually runs with, which may come
+	// from the subnet config
+	if inspector, ok := engine.(common.ConsensusInspector); ok {
+		handler := newConsensusHandler(func() interface{} {
+			return inspector.ConsensusInfo()
+		})
+		if err := s.AddChainRoute(handler, ctx, defaultEndpoint, consensusParamsEndpoint); err != nil {
+			s.log.Error("error adding route",
+				zap.Error(err),
+			)
+		}
+	}
+	// Expose the undecided frontier, to debug consensus that isn't advancing
+	if inspector, ok := engine.(common.ProcessingInspector); ok {
+		handler := newConsensusHandler(inspector.ProcessingSnapshot)
+		if err := s.AddChainRoute(handler, ctx, defaultEndpoint, consensusProcessingEndpoint); err != nil {
+			s.log.Error("error adding route",
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 func (s *server) AddChainRoute(handler *common.HTTPHandler, ctx *snow.ConsensusContext, base, endpoint string) error {
@@ -363,16 +422,21 @@ func (s *server) AddAliasesWithReadLock(endpoint string, aliases ...string) erro
 }
 
 func (s *server) Shutdown() error {
-	if s.srv == nil {
-		return nil
-	}
-
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
-	err := s.srv.Shutdown(ctx)
-	cancel()
+	defer cancel()
+
+	var err error
+	for _, srv := range []*http.Server{s.srv, s.unixSrv} {
+		if srv == nil {
+			continue
+		}
+		if shutdownErr := srv.Shutdown(ctx); err == nil {
+			err = shutdownErr
+		}
 
-	// If shutdown times out, make sure the server is still shutdown.
-	_ = s.srv.Close()
+		// If shutdown times out, make sure the server is still shutdown.
+		_ = srv.Close()
+	}
 	return err
 }
 
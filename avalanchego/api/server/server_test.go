@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func TestDispatchUnix(t *testing.T) {
+	require := require.New(t)
+
+	socketPath := filepath.Join(t.TempDir(), "http.sock")
+	// A socket left behind by an unclean shutdown is replaced.
+	staleListener, err := net.Listen("unix", socketPath)
+	require.NoError(err)
+	staleListener.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(staleListener.Close())
+
+	nodeID := ids.GenerateTestNodeID()
+	s := New()
+	s.Initialize(logging.NoLog{}, nil, "127.0.0.1", 0, []string{"*"}, time.Second, nodeID)
+
+	dispatchErr := make(chan error, 1)
+	go func() {
+		dispatchErr <- s.DispatchUnix(socketPath, 0o600)
+	}()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var dialer net.Dialer
+				return dialer.DialContext(ctx, "unix", socketPath)
+			},
+		},
+	}
+	var res *http.Response
+	require.Eventually(func() bool {
+		res, err = client.Get("http://unix/ext/info")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(res.Body.Close())
+	require.Equal(nodeID.String(), res.Header.Get("node-id"))
+
+	info, err := os.Stat(socketPath)
+	require.NoError(err)
+	require.Equal(os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(s.Shutdown())
+	require.ErrorIs(<-dispatchErr, http.ErrServerClosed)
+	_, err = os.Stat(socketPath)
+	require.ErrorIs(err, os.ErrNotExist)
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// txsRejectedCode is the JSON-RPC error code returned to clients whose txs are
+// rejected by the TxGate.
+const txsRejectedCode = -32003
+
+var (
+	// txMethods are the JSON-RPC methods that may issue a tx.
+	txMethods = map[string]struct{}{
+		// X-chain
+		"avm.issueTx":                {},
+		"avm.issueTxs":               {},
+		"avm.issueStopVertex":        {},
+		"avm.createAsset":            {},
+		"avm.createFixedCapAsset":    {},
+		"avm.createVariableCapAsset": {},
+		"avm.createNFTAsset":         {},
+		"avm.send":                   {},
+		"avm.sendMultiple":           {},
+		"avm.consolidateUTXOs":       {},
+		"avm.mint":                   {},
+		"avm.sendNFT":                {},
+		"avm.mintNFT":                {},
+		"avm.import":                 {},
+		"avm.export":                 {},
+		"wallet.issueTx":             {},
+		"wallet.send":                {},
+		"wallet.sendMultiple":        {},
+		// P-chain
+		"platform.issueTx":            {},
+		"platform.addValidator":       {},
+		"platform.addDelegator":       {},
+		"platform.addSubnetValidator": {},
+		"platform.createSubnet":       {},
+		"platform.exportAVAX":         {},
+		"platform.importAVAX":         {},
+		"platform.createBlockchain":   {},
+		// C-chain
+		"avax.issueTx":           {},
+		"avax.import":            {},
+		"avax.importAVAX":        {},
+		"avax.export":            {},
+		"avax.exportAVAX":        {},
+		"eth_sendRawTransaction": {},
+		"eth_sendTransaction":    {},
+		// Cross-chain transfers
+		"transfer.transfer":       {},
+		"transfer.resumeTransfer": {},
+	}
+
+	_ Wrapper = &TxGate{}
+)
+
+// TxGate is a Wrapper that, while closed, rejects the JSON-RPC requests that
+// may issue a tx with 503 Service Unavailable. Txs gossiped by peers aren't
+// affected.
+type TxGate struct {
+	closed utils.AtomicBool
+}
+
+// SetClosed sets whether requests that may issue a tx are rejected.
+func (g *TxGate) SetClosed(closed bool) {
+	g.closed.SetValue(closed)
+}
+
+// Closed returns true if requests that may issue a tx are rejected.
+func (g *TxGate) Closed() bool {
+	return g.closed.GetValue()
+}
+
+func (g *TxGate) WrapHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !g.Closed() || r.Method != http.MethodPost {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		methods, err := inspectMethods(r, maxInspectedBodySize)
+		if err != nil {
+			writeInspectionError(w, err)
+			return
+		}
+		for _, method := range methods {
+			if _, ok := txMethods[method]; ok {
+				writeTxsRejected(w)
+				return
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func writeTxsRejected(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","error":{"code":%d,"message":"node isn't accepting txs"},"id":null}`, txsRejectedCode)
+}
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxGate(t *testing.T) {
+	assert := assert.New(t)
+
+	g := &TxGate{}
+	h := g.WrapHandler(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	sendTx := `{"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0x00"]}`
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", sendTx))
+	assert.Equal(http.StatusOK, w.Code)
+
+	g.SetClosed(true)
+	assert.True(g.Closed())
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", sendTx))
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+
+	// A batch is rejected if any of its calls may issue a tx.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `[{"method":"avm.getTx"},{"method":"avm.issueTx"}]`))
+	assert.Equal(http.StatusServiceUnavailable, w.Code)
+
+	// Other calls aren't affected.
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", `{"method":"platform.getHeight"}`))
+	assert.Equal(http.StatusOK, w.Code)
+
+	g.SetClosed(false)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, newRateLimitedRequest("1.2.3.4:5000", sendTx))
+	assert.Equal(http.StatusOK, w.Code)
+}
@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Only the user running the node may connect to the socket
+	socketPerms = 0o600
+
+	readHeaderTimeout = 10 * time.Second
+)
+
+// Server serves the signer service over a local Unix socket, so that txs can
+// be signed on the node even if the keystore API is disabled.
+type Server struct {
+	log      logging.Logger
+	path     string
+	listener net.Listener
+	srv      *http.Server
+}
+
+// NewServer starts listening on a Unix socket at [path].
+func NewServer(log logging.Logger, ks keystore.Keystore, path string) (*Server, error) {
+	handler, err := NewHandler(log, ks)
+	if err != nil {
+		return nil, err
+	}
+
+	// A socket left behind by an unclean shutdown would prevent listening.
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, socketPerms); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+	return &Server{
+		log:      log,
+		path:     path,
+		listener: listener,
+		srv: &http.Server{
+			Handler:           handler,
+			ReadHeaderTimeout: readHeaderTimeout,
+		},
+	}, nil
+}
+
+// Dispatch serves requests until the server is shut down.
+func (s *Server) Dispatch() error {
+	s.log.Info("signer listening",
+		zap.String("path", s.path),
+	)
+	err := s.srv.Serve(s.listener)
+	if err == http.ErrServerClosed {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops serving requests, waiting at most [timeout] for the ones in
+// flight.
+func (s *Server) Shutdown(timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	err := s.srv.Shutdown(ctx)
+	cancel()
+
+	// If shutdown times out, make sure the server is still shutdown. The
+	// listener is closed explicitly in case it was never served.
+	_ = s.srv.Close()
+	_ = s.listener.Close()
+	return err
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+
+	vmkeystore "github.com/ava-labs/avalanchego/vms/components/keystore"
+)
+
+var errInvalidHashLength = fmt.Errorf("hash must be %d bytes", hashing.HashLen)
+
+// Service is the API service for signing with the keys held in the keystore
+// without exposing the keystore API.
+type Service struct {
+	log logging.Logger
+	ks  keystore.Keystore
+}
+
+// NewHandler returns a handler that serves the signer service.
+func NewHandler(log logging.Logger, ks keystore.Keystore) (http.Handler, error) {
+	newServer := rpc.NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	return newServer, newServer.RegisterService(&Service{
+		log: log,
+		ks:  ks,
+	}, "signer")
+}
+
+// SignHashArgs are the arguments to SignHash
+type SignHashArgs struct {
+	api.UserPass
+	// ID of the chain whose keystore database holds the key
+	BlockchainID ids.ID `json:"blockchainID"`
+	// Address of the key to sign with
+	Address string `json:"address"`
+	// Hex encoded hash to sign, such as the sigHash of a signing descriptor
+	Hash string `json:"hash"`
+}
+
+// SignHashReply is the response from SignHash
+type SignHashReply struct {
+	// Hex encoded recoverable signature
+	Signature string `json:"signature"`
+}
+
+// SignHash signs [args.Hash] with the key of [args.Address] held by the
+// keystore user.
+func (s *Service) SignHash(_ *http.Request, args *SignHashArgs, reply *SignHashReply) error {
+	s.log.Debug("Signer: SignHash called",
+		logging.UserString("username", args.Username),
+		logging.UserString("address", args.Address),
+	)
+
+	addr, err := address.ParseToID(args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+	hash, err := formatting.Decode(formatting.Hex, args.Hash)
+	if err != nil {
+		return fmt.Errorf("couldn't decode hash: %w", err)
+	}
+	if len(hash) != hashing.HashLen {
+		return errInvalidHashLength
+	}
+
+	db, err := s.ks.GetDatabase(args.BlockchainID, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	user := vmkeystore.NewUserFromDB(db)
+	defer user.Close()
+
+	key, err := user.GetKey(addr)
+	if err != nil {
+		return fmt.Errorf("couldn't get key of %s: %w", args.Address, err)
+	}
+	sig, err := key.SignHash(hash)
+	if err != nil {
+		return err
+	}
+	reply.Signature, err = formatting.Encode(formatting.Hex, sig)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		user.Close(),
+	)
+	return errs.Err
+}
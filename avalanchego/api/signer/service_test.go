@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package signer
+
+import (
+	"bytes"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+
+	vmkeystore "github.com/ava-labs/avalanchego/vms/components/keystore"
+)
+
+const (
+	testUsername = "ScoobyUser"
+	testPassword = "ShaggyPassword1Zoinks!"
+)
+
+// newTestKeystore returns a keystore with a user holding a key on
+// [blockchainID], along with the formatted address of that key.
+func newTestKeystore(t *testing.T, blockchainID ids.ID) (keystore.Keystore, *crypto.PrivateKeySECP256K1R, string) {
+	require := require.New(t)
+
+	ks := keystore.New(logging.NoLog{}, manager.NewMemDB(version.Semantic1_0_0))
+	require.NoError(ks.CreateUser(testUsername, testPassword))
+
+	user, err := vmkeystore.NewUserFromKeystore(ks.NewBlockchainKeyStore(blockchainID), testUsername, testPassword)
+	require.NoError(err)
+	key, err := vmkeystore.NewKey(user)
+	require.NoError(err)
+	require.NoError(user.Close())
+
+	addr, err := address.Format("X", "local", key.PublicKey().Address().Bytes())
+	require.NoError(err)
+	return ks, key, addr
+}
+
+func TestSignHash(t *testing.T) {
+	require := require.New(t)
+
+	blockchainID := ids.GenerateTestID()
+	ks, key, addr := newTestKeystore(t, blockchainID)
+	s := &Service{
+		log: logging.NoLog{},
+		ks:  ks,
+	}
+
+	hash := hashing.ComputeHash256([]byte("unsigned tx"))
+	hashStr, err := formatting.Encode(formatting.Hex, hash)
+	require.NoError(err)
+	args := SignHashArgs{
+		UserPass: api.UserPass{
+			Username: testUsername,
+			Password: testPassword,
+		},
+		BlockchainID: blockchainID,
+		Address:      addr,
+		Hash:         hashStr,
+	}
+	reply := SignHashReply{}
+	require.NoError(s.SignHash(nil, &args, &reply))
+
+	sig, err := formatting.Decode(formatting.Hex, reply.Signature)
+	require.NoError(err)
+	factory := crypto.FactorySECP256K1R{}
+	pk, err := factory.RecoverHashPublicKey(hash, sig)
+	require.NoError(err)
+	require.Equal(key.PublicKey().Address(), pk.Address())
+
+	// The key is only held on [blockchainID]
+	args.BlockchainID = ids.GenerateTestID()
+	require.Error(s.SignHash(nil, &args, &reply))
+
+	args.BlockchainID = blockchainID
+	args.Password = "wrong password"
+	require.Error(s.SignHash(nil, &args, &reply))
+
+	args.Password = testPassword
+	args.Hash, err = formatting.Encode(formatting.Hex, hash[1:])
+	require.NoError(err)
+	require.ErrorIs(s.SignHash(nil, &args, &reply), errInvalidHashLength)
+}
+
+func TestServerSocket(t *testing.T) {
+	require := require.New(t)
+
+	blockchainID := ids.GenerateTestID()
+	ks, _, addr := newTestKeystore(t, blockchainID)
+
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	server, err := NewServer(logging.NoLog{}, ks, path)
+	require.NoError(err)
+	dispatchErr := make(chan error, 1)
+	go func() {
+		dispatchErr <- server.Dispatch()
+	}()
+
+	hashStr, err := formatting.Encode(formatting.Hex, hashing.ComputeHash256(nil))
+	require.NoError(err)
+	body, err := stdjson.Marshal(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "signer.signHash",
+		"params": SignHashArgs{
+			UserPass: api.UserPass{
+				Username: testUsername,
+				Password: testPassword,
+			},
+			BlockchainID: blockchainID,
+			Address:      addr,
+			Hash:         hashStr,
+		},
+	})
+	require.NoError(err)
+
+	client := http.Client{Transport: &http.Transport{
+		Dial: func(string, string) (net.Conn, error) {
+			return net.Dial("unix", path)
+		},
+	}}
+	resp, err := client.Post("http://signer", "application/json", bytes.NewReader(body))
+	require.NoError(err)
+	defer resp.Body.Close()
+
+	res := struct {
+		Result SignHashReply `json:"result"`
+	}{}
+	require.NoError(stdjson.NewDecoder(resp.Body).Decode(&res))
+	require.NotEmpty(res.Result.Signature)
+
+	require.NoError(server.Shutdown(time.Second))
+	require.NoError(<-dispatchErr)
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transfer
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+)
+
+var _ Client = &client{}
+
+// Client interface for the Avalanche Transfer API Endpoint
+type Client interface {
+	// Transfer moves [amount] of [assetID] from [sourceChain] to [to] on
+	// [destinationChain]. An empty [assetID] transfers AVAX.
+	Transfer(
+		ctx context.Context,
+		user api.UserPass,
+		sourceChain string,
+		destinationChain string,
+		assetID string,
+		amount uint64,
+		to string,
+		options ...rpc.Option,
+	) (*TransferReply, error)
+	// ResumeTransfer continues the interrupted transfer [transferID]
+	ResumeTransfer(ctx context.Context, user api.UserPass, transferID ids.ID, options ...rpc.Option) (*TransferReply, error)
+	// ListTransfers returns the transfers made by [user]
+	ListTransfers(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]TransferReply, error)
+}
+
+// Client implementation for the Avalanche Transfer API Endpoint
+type client struct {
+	requester rpc.EndpointRequester
+}
+
+// NewClient returns a new Transfer API Client
+func NewClient(uri string) Client {
+	return &client{requester: rpc.NewEndpointRequester(
+		uri+"/ext/transfer",
+		"transfer",
+	)}
+}
+
+func (c *client) Transfer(
+	ctx context.Context,
+	user api.UserPass,
+	sourceChain string,
+	destinationChain string,
+	assetID string,
+	amount uint64,
+	to string,
+	options ...rpc.Option,
+) (*TransferReply, error) {
+	res := &TransferReply{}
+	err := c.requester.SendRequest(ctx, "transfer", &TransferArgs{
+		UserPass:         user,
+		SourceChain:      sourceChain,
+		DestinationChain: destinationChain,
+		AssetID:          assetID,
+		Amount:           json.Uint64(amount),
+		To:               to,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ResumeTransfer(ctx context.Context, user api.UserPass, transferID ids.ID, options ...rpc.Option) (*TransferReply, error) {
+	res := &TransferReply{}
+	err := c.requester.SendRequest(ctx, "resumeTransfer", &ResumeTransferArgs{
+		UserPass:   user,
+		TransferID: transferID,
+	}, res, options...)
+	return res, err
+}
+
+func (c *client) ListTransfers(ctx context.Context, user api.UserPass, options ...rpc.Option) ([]TransferReply, error) {
+	res := &ListTransfersReply{}
+	err := c.requester.SendRequest(ctx, "listTransfers", &user, res, options...)
+	return res.Transfers, err
+}
@@ -0,0 +1,314 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	stdjson "encoding/json"
+
+	"github.com/gorilla/rpc/v2"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+
+	vmkeystore "github.com/ava-labs/avalanchego/vms/components/keystore"
+)
+
+var (
+	errNoAmount = errors.New("amount must be positive")
+	errNoKeys   = errors.New("user has no keys")
+)
+
+type Config struct {
+	Log          logging.Logger
+	Keystore     keystore.Keystore
+	DB           database.Database
+	ChainAliaser ids.AliaserReader
+	AVAXAssetID  ids.ID
+	// URI is the address of this node's HTTP server
+	URI string
+}
+
+// Service is the API service for moving funds between the chains of the
+// primary network using the keys held in the keystore.
+type Service struct {
+	Config
+}
+
+// NewService returns a new transfer API service.
+// All of the fields in [config] must be set.
+func NewService(config Config) (*common.HTTPHandler, error) {
+	newServer := rpc.NewServer()
+	codec := json.NewCodec()
+	newServer.RegisterCodec(codec, "application/json")
+	newServer.RegisterCodec(codec, "application/json;charset=UTF-8")
+	if err := newServer.RegisterService(&Service{Config: config}, "transfer"); err != nil {
+		return nil, err
+	}
+	return &common.HTTPHandler{Handler: newServer}, nil
+}
+
+// record is what is persisted for every transfer so that it can be resumed.
+type record struct {
+	To    ids.ShortID            `json:"to"`
+	State *primary.TransferState `json:"state"`
+}
+
+// TransferArgs are the arguments to Transfer
+type TransferArgs struct {
+	api.UserPass
+	SourceChain      string `json:"sourceChain"`
+	DestinationChain string `json:"destinationChain"`
+	// AssetID defaults to AVAX if empty
+	AssetID string      `json:"assetID"`
+	Amount  json.Uint64 `json:"amount"`
+	// To is the address on the destination chain to send the funds to
+	To string `json:"to"`
+}
+
+// TransferReply is the progress of a transfer
+type TransferReply struct {
+	// TransferID is the ID of the export tx of the transfer
+	TransferID         ids.ID `json:"transferID"`
+	SourceChainID      ids.ID `json:"sourceChainID"`
+	DestinationChainID ids.ID `json:"destinationChainID"`
+	ExportTxID         ids.ID `json:"exportTxID"`
+	ExportAccepted     bool   `json:"exportAccepted"`
+	ImportTxID         ids.ID `json:"importTxID"`
+	ImportAccepted     bool   `json:"importAccepted"`
+}
+
+func (r *TransferReply) fill(state *primary.TransferState) {
+	r.TransferID = state.ExportTxID
+	r.SourceChainID = state.SourceChainID
+	r.DestinationChainID = state.DestinationChainID
+	r.ExportTxID = state.ExportTxID
+	r.ExportAccepted = state.ExportAccepted
+	r.ImportTxID = state.ImportTxID
+	r.ImportAccepted = state.ImportAccepted
+}
+
+// Transfer exports [args.Amount] of [args.AssetID] from the source chain and
+// imports it into the destination chain. If the transfer is interrupted after
+// the export tx was created, it can be continued with ResumeTransfer.
+func (s *Service) Transfer(r *http.Request, args *TransferArgs, reply *TransferReply) error {
+	s.Log.Debug("Transfer: Transfer called",
+		logging.UserString("username", args.Username),
+		zap.String("sourceChain", args.SourceChain),
+		zap.String("destinationChain", args.DestinationChain),
+	)
+
+	if args.Amount == 0 {
+		return errNoAmount
+	}
+	sourceChainID, err := s.chainID(args.SourceChain)
+	if err != nil {
+		return fmt.Errorf("couldn't parse source chain: %w", err)
+	}
+	destinationChainID, err := s.chainID(args.DestinationChain)
+	if err != nil {
+		return fmt.Errorf("couldn't parse destination chain: %w", err)
+	}
+	assetID := s.AVAXAssetID
+	if args.AssetID != "" {
+		assetID, err = ids.FromString(args.AssetID)
+		if err != nil {
+			return fmt.Errorf("couldn't parse assetID: %w", err)
+		}
+	}
+	to, err := address.ParseToID(args.To)
+	if err != nil {
+		return fmt.Errorf("couldn't parse to: %w", err)
+	}
+
+	kc, err := s.keychain(args.UserPass, sourceChainID, destinationChainID)
+	if err != nil {
+		return err
+	}
+	// The exported funds are owned by one of the user's keys so that the
+	// import tx can spend them.
+	owner := kc.Addrs.List()[0]
+	outputs := []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: uint64(args.Amount),
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{owner},
+			},
+		},
+	}}
+
+	rec := &record{
+		To: to,
+		State: &primary.TransferState{
+			SourceChainID:      sourceChainID,
+			DestinationChainID: destinationChainID,
+		},
+	}
+	return s.transfer(r, args.UserPass, kc, rec, outputs, reply)
+}
+
+// ResumeTransferArgs are the arguments to ResumeTransfer
+type ResumeTransferArgs struct {
+	api.UserPass
+	TransferID ids.ID `json:"transferID"`
+}
+
+// ResumeTransfer continues an interrupted transfer from its last recorded
+// step.
+func (s *Service) ResumeTransfer(r *http.Request, args *ResumeTransferArgs, reply *TransferReply) error {
+	s.Log.Debug("Transfer: ResumeTransfer called",
+		logging.UserString("username", args.Username),
+		zap.Stringer("transferID", args.TransferID),
+	)
+
+	rec, err := s.getRecord(args.Username, args.TransferID)
+	if err != nil {
+		return err
+	}
+	kc, err := s.keychain(args.UserPass, rec.State.SourceChainID, rec.State.DestinationChainID)
+	if err != nil {
+		return err
+	}
+	return s.transfer(r, args.UserPass, kc, rec, nil, reply)
+}
+
+// ListTransfersReply are the transfers of a user
+type ListTransfersReply struct {
+	Transfers []TransferReply `json:"transfers"`
+}
+
+// ListTransfers returns the progress of all the transfers made by a user,
+// including those whose reply was lost.
+func (s *Service) ListTransfers(_ *http.Request, args *api.UserPass, reply *ListTransfersReply) error {
+	s.Log.Debug("Transfer: ListTransfers called",
+		logging.UserString("username", args.Username),
+	)
+
+	// Only the user that knows the password may list its transfers.
+	db, err := s.Keystore.GetDatabase(constants.PlatformChainID, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	if err := db.Close(); err != nil {
+		return err
+	}
+
+	it := s.userDB(args.Username).NewIterator()
+	defer it.Release()
+
+	reply.Transfers = []TransferReply{}
+	for it.Next() {
+		rec := &record{}
+		if err := stdjson.Unmarshal(it.Value(), rec); err != nil {
+			return err
+		}
+		transfer := TransferReply{}
+		transfer.fill(rec.State)
+		reply.Transfers = append(reply.Transfers, transfer)
+	}
+	return it.Error()
+}
+
+func (s *Service) transfer(
+	r *http.Request,
+	user api.UserPass,
+	kc *secp256k1fx.Keychain,
+	rec *record,
+	outputs []*avax.TransferableOutput,
+	reply *TransferReply,
+) error {
+	to := &secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rec.To},
+	}
+	err := primary.Transfer(
+		r.Context(),
+		s.URI,
+		kc,
+		rec.State,
+		outputs,
+		to,
+		func(*primary.TransferState) error {
+			return s.putRecord(user.Username, rec)
+		},
+	)
+	reply.fill(rec.State)
+	if err != nil && rec.State.ExportTx != nil {
+		return fmt.Errorf("transfer %s interrupted: %w", rec.State.ExportTxID, err)
+	}
+	return err
+}
+
+// chainID returns the ID of the chain that is either named or aliased by
+// [chain].
+func (s *Service) chainID(chain string) (ids.ID, error) {
+	if chainID, err := ids.FromString(chain); err == nil {
+		return chainID, nil
+	}
+	return s.ChainAliaser.Lookup(chain)
+}
+
+// keychain returns the keys [user] holds on the given chains.
+func (s *Service) keychain(user api.UserPass, chainIDs ...ids.ID) (*secp256k1fx.Keychain, error) {
+	kc := secp256k1fx.NewKeychain()
+	for _, chainID := range chainIDs {
+		u, err := vmkeystore.NewUserFromKeystore(s.Keystore.NewBlockchainKeyStore(chainID), user.Username, user.Password)
+		if err != nil {
+			return nil, err
+		}
+		chainKC, err := vmkeystore.GetKeychain(u, nil)
+		if closeErr := u.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range chainKC.Keys {
+			kc.Add(key)
+		}
+	}
+	if kc.Addrs.Len() == 0 {
+		return nil, errNoKeys
+	}
+	return kc, nil
+}
+
+func (s *Service) userDB(username string) database.Database {
+	return prefixdb.New([]byte(username), s.DB)
+}
+
+func (s *Service) getRecord(username string, transferID ids.ID) (*record, error) {
+	recBytes, err := s.userDB(username).Get(transferID[:])
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get transfer %s: %w", transferID, err)
+	}
+	rec := &record{}
+	return rec, stdjson.Unmarshal(recBytes, rec)
+}
+
+func (s *Service) putRecord(username string, rec *record) error {
+	recBytes, err := stdjson.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	return s.userDB(username).Put(rec.State.ExportTxID[:], recBytes)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api"
+	"github.com/ava-labs/avalanchego/api/keystore"
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary"
+)
+
+const (
+	testUsername = "ScoobyUser"
+	testPassword = "ShaggyPassword1Zoinks!"
+)
+
+func TestListTransfers(t *testing.T) {
+	require := require.New(t)
+
+	ks := keystore.New(logging.NoLog{}, manager.NewMemDB(version.Semantic1_0_0))
+	require.NoError(ks.CreateUser(testUsername, testPassword))
+	s := &Service{Config: Config{
+		Log:      logging.NoLog{},
+		Keystore: ks,
+		DB:       memdb.New(),
+	}}
+
+	rec := &record{
+		To: ids.GenerateTestShortID(),
+		State: &primary.TransferState{
+			SourceChainID:      ids.GenerateTestID(),
+			DestinationChainID: ids.GenerateTestID(),
+			ExportTxID:         ids.GenerateTestID(),
+			ExportTx:           []byte{1},
+			ExportAccepted:     true,
+		},
+	}
+	require.NoError(s.putRecord(testUsername, rec))
+
+	user := api.UserPass{
+		Username: testUsername,
+		Password: testPassword,
+	}
+	reply := ListTransfersReply{}
+	require.NoError(s.ListTransfers(nil, &user, &reply))
+	require.Len(reply.Transfers, 1)
+	transfer := reply.Transfers[0]
+	require.Equal(rec.State.ExportTxID, transfer.TransferID)
+	require.Equal(rec.State.SourceChainID, transfer.SourceChainID)
+	require.True(transfer.ExportAccepted)
+	require.False(transfer.ImportAccepted)
+
+	gotRec, err := s.getRecord(testUsername, transfer.TransferID)
+	require.NoError(err)
+	require.Equal(rec, gotRec)
+
+	// Records are only visible to the user that created them.
+	_, err = s.getRecord("otherUser", transfer.TransferID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	user.Password = "wrong password"
+	require.Error(s.ListTransfers(nil, &user, &reply))
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"context"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// MaxUTXOsPageSize is the max number of UTXOs returned by a single getUTXOs
+// call
+const MaxUTXOsPageSize = 1024
+
+// UTXOPageFetcher returns the page of at most [limit] UTXOs that follows
+// [startAddress] and [startUTXOID], along with the index of the last returned
+// UTXO.
+type UTXOPageFetcher func(
+	ctx context.Context,
+	limit uint32,
+	startAddress ids.ShortID,
+	startUTXOID ids.ID,
+) ([][]byte, ids.ShortID, ids.ID, error)
+
+// UTXOBatch is a page of UTXOs sent by StreamUTXOs. If [Err] is non-nil, it is
+// the last batch sent.
+type UTXOBatch struct {
+	UTXOs [][]byte
+	Err   error
+}
+
+// GetAllUTXOs pages through [fetch] with pages of [limit] UTXOs until all the
+// UTXOs have been fetched. If [limit] is 0 or exceeds MaxUTXOsPageSize,
+// MaxUTXOsPageSize is used.
+func GetAllUTXOs(ctx context.Context, fetch UTXOPageFetcher, limit uint32) ([][]byte, error) {
+	var utxos [][]byte
+	err := pageUTXOs(ctx, fetch, limit, func(page [][]byte) bool {
+		utxos = append(utxos, page...)
+		return true
+	})
+	return utxos, err
+}
+
+// StreamUTXOs pages through [fetch] in the background and sends every fetched
+// page on the returned channel. The channel is closed once all the UTXOs have
+// been sent, an error occurred, or [ctx] is done.
+func StreamUTXOs(ctx context.Context, fetch UTXOPageFetcher, limit uint32) <-chan UTXOBatch {
+	batches := make(chan UTXOBatch)
+	go func() {
+		defer close(batches)
+
+		err := pageUTXOs(ctx, fetch, limit, func(page [][]byte) bool {
+			select {
+			case batches <- UTXOBatch{UTXOs: page}:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+		if err == nil {
+			return
+		}
+		select {
+		case batches <- UTXOBatch{Err: err}:
+		case <-ctx.Done():
+		}
+	}()
+	return batches
+}
+
+// pageUTXOs calls [onPage] with every non-empty page returned by [fetch] until
+// the UTXOs are exhausted or [onPage] returns false.
+func pageUTXOs(
+	ctx context.Context,
+	fetch UTXOPageFetcher,
+	limit uint32,
+	onPage func([][]byte) bool,
+) error {
+	if limit == 0 || limit > MaxUTXOsPageSize {
+		limit = MaxUTXOsPageSize
+	}
+
+	var (
+		startAddr ids.ShortID
+		startUTXO ids.ID
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		page, endAddr, endUTXO, err := fetch(ctx, limit, startAddr, startUTXO)
+		if err != nil {
+			return err
+		}
+		if len(page) > 0 && !onPage(page) {
+			return ctx.Err()
+		}
+		if len(page) < int(limit) {
+			return nil
+		}
+
+		// Update the vars to query the next page of UTXOs.
+		startAddr = endAddr
+		startUTXO = endUTXO
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// newTestFetcher returns a fetcher that serves [numUTXOs] UTXOs, where the
+// i-th UTXO is the single byte i.
+func newTestFetcher(numUTXOs int) UTXOPageFetcher {
+	return func(_ context.Context, limit uint32, _ ids.ShortID, startUTXOID ids.ID) ([][]byte, ids.ShortID, ids.ID, error) {
+		start := 0
+		if startUTXOID != ids.Empty {
+			start = int(startUTXOID[0]) + 1
+		}
+		end := start + int(limit)
+		if end > numUTXOs {
+			end = numUTXOs
+		}
+
+		var (
+			page    [][]byte
+			endUTXO ids.ID
+		)
+		for i := start; i < end; i++ {
+			page = append(page, []byte{byte(i)})
+			endUTXO = ids.ID{byte(i)}
+		}
+		return page, ids.ShortEmpty, endUTXO, nil
+	}
+}
+
+func TestGetAllUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	utxos, err := GetAllUTXOs(context.Background(), newTestFetcher(10), 3)
+	require.NoError(err)
+	require.Len(utxos, 10)
+	for i, utxo := range utxos {
+		require.Equal([]byte{byte(i)}, utxo)
+	}
+
+	utxos, err = GetAllUTXOs(context.Background(), newTestFetcher(0), 3)
+	require.NoError(err)
+	require.Empty(utxos)
+}
+
+func TestGetAllUTXOsError(t *testing.T) {
+	require := require.New(t)
+
+	errFetch := errors.New("fetch failed")
+	fetch := func(context.Context, uint32, ids.ShortID, ids.ID) ([][]byte, ids.ShortID, ids.ID, error) {
+		return nil, ids.ShortEmpty, ids.Empty, errFetch
+	}
+	_, err := GetAllUTXOs(context.Background(), fetch, 3)
+	require.ErrorIs(err, errFetch)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = GetAllUTXOs(ctx, newTestFetcher(10), 3)
+	require.ErrorIs(err, context.Canceled)
+}
+
+func TestStreamUTXOs(t *testing.T) {
+	require := require.New(t)
+
+	numBatches := 0
+	numUTXOs := 0
+	for batch := range StreamUTXOs(context.Background(), newTestFetcher(10), 4) {
+		require.NoError(batch.Err)
+		numBatches++
+		numUTXOs += len(batch.UTXOs)
+	}
+	require.Equal(3, numBatches)
+	require.Equal(10, numUTXOs)
+}
@@ -0,0 +1,231 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package keys implements the "keys" subcommand, which generates and inspects
+// the staking TLS certificate and BLS signing key of a node without running
+// it.
+//
+// Generate a new staking certificate and signing key:
+//
+//	avalanchego keys generate --staking-tls-key-file=staker.key --staking-tls-cert-file=staker.crt --staking-signer-key-file=signer.key
+//
+// Print the nodeID and proof of possession of existing keys:
+//
+//	avalanchego keys inspect --staking-tls-cert-file=staker.crt --staking-signer-key-file=signer.key
+package keys
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	stdjson "encoding/json"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+)
+
+const (
+	// Command is the argument that selects the keys subcommand
+	Command = "keys"
+
+	generateCommand = "generate"
+	inspectCommand  = "inspect"
+
+	// The flag names match the node's flags, so that the same paths can be
+	// passed to both.
+	stakingTLSKeyPathKey    = "staking-tls-key-file"
+	stakingCertPathKey      = "staking-tls-cert-file"
+	stakingSignerKeyPathKey = "staking-signer-key-file"
+)
+
+var (
+	errUnknownCommand = errors.New("unknown command")
+	errNoKeyFiles     = errors.New("no key files provided")
+	errFileExists     = errors.New("file already exists")
+	errMissingTLSPath = errors.New("both the staking TLS key and certificate files must be provided")
+	errInvalidCert    = errors.New("staking certificate isn't PEM encoded")
+)
+
+// Info describes the keys of a node in the formats used to register it as a
+// validator.
+type Info struct {
+	// NodeID is derived from the staking certificate
+	NodeID *ids.NodeID `json:"nodeID,omitempty"`
+	// NodeIDHex is the hex encoding of [NodeID], as used by the validator
+	// registration contracts
+	NodeIDHex string `json:"nodeIDHex,omitempty"`
+	// NodePOP is the BLS public key and its proof of possession
+	NodePOP *signer.ProofOfPossession `json:"nodePOP,omitempty"`
+}
+
+type paths struct {
+	stakingKey    string
+	stakingCert   string
+	stakingSigner string
+}
+
+// Run executes the keys subcommand with [args], which don't include
+// [Command], and writes the resulting Info to [out].
+func Run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: expected %q or %q", errUnknownCommand, generateCommand, inspectCommand)
+	}
+
+	command := args[0]
+	fs := pflag.NewFlagSet(Command+" "+command, pflag.ContinueOnError)
+	fs.SetOutput(out)
+	var p paths
+	fs.StringVar(&p.stakingKey, stakingTLSKeyPathKey, "", "Path to the TLS private key for staking")
+	fs.StringVar(&p.stakingCert, stakingCertPathKey, "", "Path to the TLS certificate for staking")
+	fs.StringVar(&p.stakingSigner, stakingSignerKeyPathKey, "", "Path to the signer private key for staking")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	var (
+		info Info
+		err  error
+	)
+	switch command {
+	case generateCommand:
+		info, err = generate(p)
+	case inspectCommand:
+		info, err = inspect(p)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownCommand, command)
+	}
+	if err != nil {
+		return err
+	}
+
+	infoBytes, err := stdjson.MarshalIndent(info, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(infoBytes))
+	return err
+}
+
+// generate creates the keys whose paths are provided and returns their Info.
+// Existing files are never overwritten.
+func generate(p paths) (Info, error) {
+	generateTLS := p.stakingKey != "" || p.stakingCert != ""
+	if !generateTLS && p.stakingSigner == "" {
+		return Info{}, errNoKeyFiles
+	}
+	if generateTLS && (p.stakingKey == "" || p.stakingCert == "") {
+		return Info{}, errMissingTLSPath
+	}
+	for _, path := range []string{p.stakingKey, p.stakingCert, p.stakingSigner} {
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			return Info{}, fmt.Errorf("%w: %s", errFileExists, path)
+		}
+	}
+
+	if generateTLS {
+		if err := staking.InitNodeStakingKeyPair(p.stakingKey, p.stakingCert); err != nil {
+			return Info{}, fmt.Errorf("couldn't generate staking key pair: %w", err)
+		}
+	}
+	if p.stakingSigner != "" {
+		key, err := bls.NewSecretKey()
+		if err != nil {
+			return Info{}, fmt.Errorf("couldn't generate signing key: %w", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(p.stakingSigner), perms.ReadWriteExecute); err != nil {
+			return Info{}, fmt.Errorf("couldn't create path for signing key: %w", err)
+		}
+		if err := perms.WriteFile(p.stakingSigner, bls.SecretKeyToBytes(key), perms.ReadOnly); err != nil {
+			return Info{}, fmt.Errorf("couldn't write signing key to %s: %w", p.stakingSigner, err)
+		}
+	}
+	return inspect(p)
+}
+
+// inspect returns the Info of the keys whose paths are provided. If both the
+// staking key and certificate are provided, they must match.
+func inspect(p paths) (Info, error) {
+	if p.stakingKey == "" && p.stakingCert == "" && p.stakingSigner == "" {
+		return Info{}, errNoKeyFiles
+	}
+
+	var info Info
+	switch {
+	case p.stakingKey != "" && p.stakingCert != "":
+		cert, err := staking.LoadTLSCertFromFiles(p.stakingKey, p.stakingCert)
+		if err != nil {
+			return Info{}, fmt.Errorf("couldn't load staking key pair: %w", err)
+		}
+		if err := info.setNodeID(ids.NodeIDFromCert(cert.Leaf)); err != nil {
+			return Info{}, err
+		}
+	case p.stakingCert != "":
+		certBytes, err := os.ReadFile(p.stakingCert)
+		if err != nil {
+			return Info{}, err
+		}
+		nodeID, err := nodeIDFromCertBytes(certBytes)
+		if err != nil {
+			return Info{}, err
+		}
+		if err := info.setNodeID(nodeID); err != nil {
+			return Info{}, err
+		}
+	case p.stakingKey != "":
+		return Info{}, errMissingTLSPath
+	}
+
+	if p.stakingSigner != "" {
+		keyBytes, err := os.ReadFile(p.stakingSigner)
+		if err != nil {
+			return Info{}, err
+		}
+		key, err := bls.SecretKeyFromBytes(keyBytes)
+		if err != nil {
+			return Info{}, fmt.Errorf("couldn't parse signing key: %w", err)
+		}
+		info.NodePOP = signer.NewProofOfPossession(key)
+	}
+	return info, nil
+}
+
+func (i *Info) setNodeID(nodeID ids.NodeID) error {
+	nodeIDHex, err := formatting.Encode(formatting.HexNC, nodeID[:])
+	if err != nil {
+		return err
+	}
+	i.NodeID = &nodeID
+	i.NodeIDHex = nodeIDHex
+	return nil
+}
+
+// nodeIDFromCertBytes returns the nodeID of the PEM encoded certificate
+// [certBytes].
+func nodeIDFromCertBytes(certBytes []byte) (ids.NodeID, error) {
+	block, _ := pem.Decode(certBytes)
+	if block == nil {
+		return ids.EmptyNodeID, errInvalidCert
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return ids.EmptyNodeID, fmt.Errorf("couldn't parse staking certificate: %w", err)
+	}
+	if err := staking.VerifyCertificate(cert); err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return ids.NodeIDFromCert(cert), nil
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package keys
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	stdjson "encoding/json"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAndInspect(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "staking", "staker.key")
+	certPath := filepath.Join(dir, "staking", "staker.crt")
+	signerPath := filepath.Join(dir, "staking", "signer.key")
+	args := []string{
+		"--" + stakingTLSKeyPathKey, keyPath,
+		"--" + stakingCertPathKey, certPath,
+		"--" + stakingSignerKeyPathKey, signerPath,
+	}
+
+	out := &bytes.Buffer{}
+	require.NoError(Run(append([]string{generateCommand}, args...), out))
+	var generated Info
+	require.NoError(stdjson.Unmarshal(out.Bytes(), &generated))
+	require.NotNil(generated.NodeID)
+	require.NotEmpty(generated.NodeIDHex)
+	require.NotNil(generated.NodePOP)
+	require.NoError(generated.NodePOP.Verify())
+
+	// Inspecting the generated keys reports the same info.
+	inspected, err := inspect(paths{
+		stakingKey:    keyPath,
+		stakingCert:   certPath,
+		stakingSigner: signerPath,
+	})
+	require.NoError(err)
+	require.Equal(*generated.NodeID, *inspected.NodeID)
+	require.Equal(generated.NodeIDHex, inspected.NodeIDHex)
+	require.Equal(generated.NodePOP.PublicKey, inspected.NodePOP.PublicKey)
+
+	// The nodeID can be derived from the certificate alone.
+	inspected, err = inspect(paths{stakingCert: certPath})
+	require.NoError(err)
+	require.Equal(*generated.NodeID, *inspected.NodeID)
+	require.Nil(inspected.NodePOP)
+
+	// Existing keys are never overwritten.
+	err = Run(append([]string{generateCommand}, args...), &bytes.Buffer{})
+	require.ErrorIs(err, errFileExists)
+}
+
+func TestInvalidArgs(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		expectedErr error
+	}{
+		"no command": {
+			args:        nil,
+			expectedErr: errUnknownCommand,
+		},
+		"unknown command": {
+			args:        []string{"rotate"},
+			expectedErr: errUnknownCommand,
+		},
+		"no files": {
+			args:        []string{inspectCommand},
+			expectedErr: errNoKeyFiles,
+		},
+		"key without cert": {
+			args:        []string{generateCommand, "--" + stakingTLSKeyPathKey, filepath.Join(t.TempDir(), "staker.key")},
+			expectedErr: errMissingTLSPath,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Run(test.args, &bytes.Buffer{})
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
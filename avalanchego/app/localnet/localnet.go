@@ -0,0 +1,264 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package localnet implements the "localnet" subcommand, which runs a five
+// node localflare network on this machine. The nodes use the staking keys of
+// the genesis stakers and the localflare upgrade schedule, on which every
+// Flare upgrade is already activated. The key that funds the genesis
+// allocations is printed on start up.
+//
+// Start a network whose nodes listen on ports 9650 to 9659:
+//
+//	avalanchego localnet --data-dir=$HOME/.avalanchego/localnet --base-port=9650
+//
+// Flags after "--" are passed to every node:
+//
+//	avalanchego localnet -- --log-level=debug
+//
+// The logs of each node are written to the logs directory of its data
+// directory, and its output to the output.log file of its data directory. The network stops once the command is interrupted. Starting it
+// again with the same data directory resumes it.
+package localnet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	_ "embed"
+
+	stdjson "encoding/json"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/staking/local"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+const (
+	// Command is the argument that selects the localnet subcommand
+	Command = "localnet"
+
+	dataDirKey  = "data-dir"
+	basePortKey = "base-port"
+	cleanKey    = "clean"
+
+	// Name of the file, in the data directory of a node, that the output of
+	// the node is written to
+	outputFile = "output.log"
+
+	// The key that controls the genesis allocations of the localflare network
+	// on the P-chain and the C-chain
+	fundedPrivateKey = "PrivateKey-ewoqjP7PxY4yr3iLTpLisriqt94hdyDFNgchSxGGztUrTXtNN"
+	fundedPAddress   = "P-localflare18jma8ppw3nhx5r4ap8clazz0dps7rv5uj3gy4v"
+	fundedCAddress   = "0x8db97C7cEcE249c2b98bDC0226Cc4C2A57BF52FC"
+)
+
+var (
+	//go:embed c_chain_config.json
+	cChainConfig []byte
+
+	errInvalidBasePort = errors.New("base port leaves no room for the node ports")
+)
+
+// Node describes a node of the local network
+type Node struct {
+	NodeID  ids.NodeID `json:"nodeID"`
+	URI     string     `json:"uri"`
+	DataDir string     `json:"dataDir"`
+
+	args []string
+}
+
+// Info describes the local network
+type Info struct {
+	NetworkID      string `json:"networkID"`
+	Nodes          []Node `json:"nodes"`
+	FundedKey      string `json:"fundedKey"`
+	FundedPAddress string `json:"fundedPAddress"`
+	FundedCAddress string `json:"fundedCAddress"`
+}
+
+// Run executes the localnet subcommand with [args], which don't include
+// [Command]. The Info of the network is written to [out] once its nodes are
+// started. Run returns once the command is interrupted or a node exits.
+func Run(args []string, out io.Writer) error {
+	fs := pflag.NewFlagSet(Command, pflag.ContinueOnError)
+	fs.SetOutput(out)
+	dataDir := fs.String(dataDirKey, filepath.Join("$HOME", ".avalanchego", Command), "Directory that the data of the nodes is placed in")
+	basePort := fs.Uint16(basePortKey, 9650, "HTTP port of the first node. Each node uses the next two ports.")
+	clean := fs.Bool(cleanKey, false, "If true, the data of a previous run is deleted before the network is started")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	binaryPath, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	dir := os.ExpandEnv(*dataDir)
+	if *clean {
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+	}
+	info, err := prepare(dir, *basePort)
+	if err != nil {
+		return err
+	}
+	for i := range info.Nodes {
+		info.Nodes[i].args = append(info.Nodes[i].args, fs.Args()...)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	exited := make(chan error, len(info.Nodes))
+	cmds := make([]*exec.Cmd, 0, len(info.Nodes))
+	defer func() {
+		for _, cmd := range cmds {
+			_ = cmd.Process.Signal(os.Interrupt)
+		}
+		for range cmds {
+			<-exited
+		}
+	}()
+	for _, node := range info.Nodes {
+		output, err := perms.Create(filepath.Join(node.DataDir, outputFile), perms.ReadWrite)
+		if err != nil {
+			return err
+		}
+		defer output.Close()
+
+		cmd := exec.Command(binaryPath, node.args...)
+		cmd.Stdout = output
+		cmd.Stderr = output
+		if err := cmd.Start(); err != nil {
+			return fmt.Errorf("couldn't start node %s: %w", node.NodeID, err)
+		}
+		cmds = append(cmds, cmd)
+		go func(node Node) {
+			if err := cmd.Wait(); err != nil {
+				exited <- fmt.Errorf("node %s exited: %w", node.NodeID, err)
+				return
+			}
+			exited <- fmt.Errorf("node %s exited", node.NodeID)
+		}(node)
+	}
+
+	infoBytes, err := stdjson.MarshalIndent(info, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, string(infoBytes)); err != nil {
+		return err
+	}
+
+	select {
+	case <-signals:
+		return nil
+	case err := <-exited:
+		// The deferred shutdown waits for every node, including this one.
+		exited <- err
+		return err
+	}
+}
+
+// prepare writes the staking keys and chain configs of the nodes into [dir]
+// and returns the network they form. Files of a previous run are reused.
+func prepare(dir string, basePort uint16) (Info, error) {
+	if uint32(basePort)+2*local.NumStakers > 1<<16 {
+		return Info{}, errInvalidBasePort
+	}
+
+	info := Info{
+		NetworkID:      constants.LocalFlareName,
+		Nodes:          make([]Node, local.NumStakers),
+		FundedKey:      fundedPrivateKey,
+		FundedPAddress: fundedPAddress,
+		FundedCAddress: fundedCAddress,
+	}
+	for i := range info.Nodes {
+		nodeDir := filepath.Join(dir, fmt.Sprintf("node%d", i+1))
+		nodeID, err := writeNodeFiles(nodeDir, i+1)
+		if err != nil {
+			return Info{}, err
+		}
+
+		httpPort := basePort + uint16(2*i)
+		stakingPort := httpPort + 1
+		args := []string{
+			fmt.Sprintf("--%s=%s", config.DataDirKey, nodeDir),
+			fmt.Sprintf("--%s=%s", config.NetworkNameKey, constants.LocalFlareName),
+			fmt.Sprintf("--%s=127.0.0.1", config.PublicIPKey),
+			fmt.Sprintf("--%s=%d", config.HTTPPortKey, httpPort),
+			fmt.Sprintf("--%s=%d", config.StakingPortKey, stakingPort),
+		}
+		// Every node bootstraps from the first node, which is the only
+		// validator of the localflare genesis.
+		if i > 0 {
+			args = append(args,
+				fmt.Sprintf("--%s=127.0.0.1:%d", config.BootstrapIPsKey, basePort+1),
+				fmt.Sprintf("--%s=%s", config.BootstrapIDsKey, info.Nodes[0].NodeID),
+			)
+		}
+		info.Nodes[i] = Node{
+			NodeID:  nodeID,
+			URI:     fmt.Sprintf("http://127.0.0.1:%d", httpPort),
+			DataDir: nodeDir,
+			args:    args,
+		}
+	}
+	return info, nil
+}
+
+// writeNodeFiles writes the keys of the staker with index [staker] and the
+// C-chain config into the default locations of the data directory [nodeDir].
+// Existing files are kept.
+func writeNodeFiles(nodeDir string, staker int) (ids.NodeID, error) {
+	key, cert, err := local.StakerKeys(staker)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	signingKey, err := bls.NewSecretKey()
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+
+	stakingDir := filepath.Join(nodeDir, "staking")
+	files := map[string][]byte{
+		filepath.Join(stakingDir, "staker.key"):                         key,
+		filepath.Join(stakingDir, "staker.crt"):                         cert,
+		filepath.Join(stakingDir, "signer.key"):                         bls.SecretKeyToBytes(signingKey),
+		filepath.Join(nodeDir, "configs", "chains", "C", "config.json"): cChainConfig,
+	}
+	for path, content := range files {
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), perms.ReadWriteExecute); err != nil {
+			return ids.EmptyNodeID, err
+		}
+		if err := perms.WriteFile(path, content, perms.ReadOnly); err != nil {
+			return ids.EmptyNodeID, err
+		}
+	}
+
+	tlsCert, err := staking.LoadTLSCertFromBytes(key, cert)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return ids.NodeIDFromCert(tlsCert.Leaf), nil
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package localnet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestPrepare(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	info, err := prepare(dir, 9650)
+	require.NoError(err)
+	require.Len(info.Nodes, 5)
+
+	// The first node is the validator of the localflare genesis
+	genesisValidator, err := ids.NodeIDFromString("NodeID-7Xhw2mDxuDS44j42TCB6U5579esbSt3Lg")
+	require.NoError(err)
+	require.Equal(genesisValidator, info.Nodes[0].NodeID)
+	require.Equal("http://127.0.0.1:9650", info.Nodes[0].URI)
+	require.NotContains(info.Nodes[0].args, "--"+config.BootstrapIDsKey+"="+genesisValidator.String())
+
+	nodeIDs := ids.NodeIDSet{}
+	for _, node := range info.Nodes[1:] {
+		require.Contains(node.args, "--"+config.BootstrapIPsKey+"=127.0.0.1:9651")
+		require.Contains(node.args, "--"+config.BootstrapIDsKey+"="+genesisValidator.String())
+		nodeIDs.Add(node.NodeID)
+	}
+	require.Equal(4, nodeIDs.Len())
+	require.Equal("http://127.0.0.1:9658", info.Nodes[4].URI)
+
+	// Files of a previous run are reused, so the nodes keep their signing keys.
+	signerPath := filepath.Join(info.Nodes[0].DataDir, "staking", "signer.key")
+	signerKey, err := os.ReadFile(signerPath)
+	require.NoError(err)
+	_, err = os.Stat(filepath.Join(info.Nodes[0].DataDir, "configs", "chains", "C", "config.json"))
+	require.NoError(err)
+
+	_, err = prepare(dir, 9650)
+	require.NoError(err)
+	reusedKey, err := os.ReadFile(signerPath)
+	require.NoError(err)
+	require.Equal(signerKey, reusedKey)
+
+	_, err = prepare(dir, 65530)
+	require.ErrorIs(err, errInvalidBasePort)
+}
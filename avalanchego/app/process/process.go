@@ -93,7 +93,8 @@ func (p *process) Start() error {
 			"Please confirm the settings in your router")
 	}
 
-	mapper := nat.NewPortMapper(log, p.config.Nat)
+	mapper := nat.NewPortMapper(log, p.config.Nat, p.config.NATReachabilityCheckEnabled)
+	p.config.PortMapper = mapper
 
 	// Open staking port we want for NAT traversal to have the external port
 	// (config.IP.Port) to connect to our internal listening port
@@ -109,8 +110,9 @@ func (p *process) Start() error {
 		)
 	}
 
-	// Open the HTTP port iff the HTTP server is not listening on localhost
-	if p.config.HTTPHost != "127.0.0.1" && p.config.HTTPHost != "localhost" && p.config.HTTPPort != 0 {
+	// Open the HTTP port iff the HTTP server is listening on TCP, but not on
+	// localhost
+	if p.config.HTTPTCPEnabled && p.config.HTTPHost != "127.0.0.1" && p.config.HTTPHost != "localhost" && p.config.HTTPPort != 0 {
 		// For NAT traversal we want to route from the external port
 		// (config.ExternalHTTPPort) to our internal port (config.HTTPPort)
 		mapper.Map(
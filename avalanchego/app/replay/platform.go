@@ -0,0 +1,198 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/chains/atomic"
+	"github.com/ava-labs/avalanchego/database/leveldb"
+	"github.com/ava-labs/avalanchego/database/manager"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/database/versiondb"
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/node"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/uptime"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/version"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+var (
+	errUnsupportedDatabase = errors.New("only leveldb databases can be replayed")
+	errUnknownChain        = errors.New("unknown chain")
+)
+
+// replayPlatformChain replays the P-chain stored in the database of the node
+// configured by [nodeConfig]. The blocks of the P-chain are verified by the
+// platformvm, not by the proposervm that wraps them.
+func replayPlatformChain(nodeConfig *node.Config, report *Report) error {
+	if nodeConfig.DatabaseConfig.Name != leveldb.Name {
+		return errUnsupportedDatabase
+	}
+	// The database is locked while the node runs.
+	dbManager, err := manager.NewLevelDB(
+		nodeConfig.DatabaseConfig.Path,
+		nodeConfig.DatabaseConfig.Config,
+		logging.NoLog{},
+		version.CurrentDatabase,
+		"db_internal",
+		prometheus.NewRegistry(),
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't open database: %w", err)
+	}
+	defer dbManager.Close()
+
+	// The changes the source VM makes while it initializes are never written
+	// to the database.
+	chainDB := dbManager.
+		NewPrefixDBManager(constants.PlatformChainID[:]).
+		NewPrefixDBManager([]byte("vm")).
+		Current().
+		Database
+	sourceDBManager, err := manager.NewManagerFromDBs([]*manager.VersionedDatabase{{
+		Database: versiondb.New(chainDB),
+		Version:  version.CurrentDatabase,
+	}})
+	if err != nil {
+		return err
+	}
+
+	// The target VM is initialized first, as the default validators are set
+	// from the timestamp of the first P-chain state that is loaded.
+	target, err := newPlatformVM(nodeConfig, manager.NewMemDB(version.CurrentDatabase))
+	if err != nil {
+		return fmt.Errorf("couldn't initialize replaying VM: %w", err)
+	}
+	defer target.Shutdown()
+
+	source, err := newPlatformVM(nodeConfig, sourceDBManager)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize VM from database: %w", err)
+	}
+	defer source.Shutdown()
+
+	return replayChain(source, target, report)
+}
+
+// platformVM is a platformvm whose context lock is held until it's shut down,
+// as it is by the consensus engine.
+type platformVM struct {
+	*platformvm.VM
+	ctx *snow.Context
+}
+
+func (vm *platformVM) Shutdown() error {
+	defer vm.ctx.Lock.Unlock()
+	return vm.VM.Shutdown()
+}
+
+// newPlatformVM returns a bootstrapping platformvm that stores its state in
+// [dbManager]. Imports aren't checked against the shared memory while
+// bootstrapping, so the shared memory is left empty.
+func newPlatformVM(nodeConfig *node.Config, dbManager manager.Manager) (*platformVM, error) {
+	cfg := node.PlatformVMConfig(nodeConfig)
+	// The replayed chain doesn't create the chains it registers.
+	cfg.Chains = chains.MockManager{}
+	cfg.Validators = validators.NewManager()
+	cfg.UptimeLockedCalculator = uptime.NewLockedCalculator()
+	cfg.Pruning = config.PruningConfig{}
+
+	ctx, err := newContext(nodeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := &platformVM{
+		VM:  &platformvm.VM{Factory: platformvm.Factory{Config: cfg}},
+		ctx: ctx,
+	}
+	ctx.Lock.Lock()
+	if err := vm.Initialize(
+		ctx,
+		dbManager,
+		nodeConfig.GenesisBytes,
+		nil,
+		nil,
+		make(chan common.Message, 1),
+		nil,
+		nil,
+	); err != nil {
+		ctx.Lock.Unlock()
+		return nil, err
+	}
+	if err := vm.SetState(snow.Bootstrapping); err != nil {
+		_ = vm.Shutdown()
+		return nil, err
+	}
+	return vm, nil
+}
+
+func newContext(nodeConfig *node.Config) (*snow.Context, error) {
+	createAVMTx, err := genesis.VMGenesis(nodeConfig.GenesisBytes, constants.AVMID)
+	if err != nil {
+		return nil, err
+	}
+	xChainID := createAVMTx.ID()
+
+	createEVMTx, err := genesis.VMGenesis(nodeConfig.GenesisBytes, constants.EVMID)
+	if err != nil {
+		return nil, err
+	}
+	cChainID := createEVMTx.ID()
+
+	aliaser := ids.NewAliaser()
+	errs := wrappers.Errs{}
+	errs.Add(
+		aliaser.Alias(constants.PlatformChainID, "P"),
+		aliaser.Alias(constants.PlatformChainID, constants.PlatformChainID.String()),
+		aliaser.Alias(xChainID, "X"),
+		aliaser.Alias(xChainID, xChainID.String()),
+		aliaser.Alias(cChainID, "C"),
+		aliaser.Alias(cChainID, cChainID.String()),
+	)
+	if errs.Errored() {
+		return nil, errs.Err
+	}
+
+	return &snow.Context{
+		NetworkID:    nodeConfig.NetworkID,
+		SubnetID:     constants.PrimaryNetworkID,
+		ChainID:      constants.PlatformChainID,
+		XChainID:     xChainID,
+		AVAXAssetID:  nodeConfig.AvaxAssetID,
+		Log:          logging.NoLog{},
+		SharedMemory: atomic.NewMemory(memdb.New()).NewSharedMemory(constants.PlatformChainID),
+		BCLookup:     aliaser,
+		SNLookup: primaryNetworkLookup{
+			constants.PlatformChainID: struct{}{},
+			xChainID:                  struct{}{},
+			cChainID:                  struct{}{},
+		},
+		Metrics: metrics.NewOptionalGatherer(),
+	}, nil
+}
+
+// primaryNetworkLookup is the set of the chains of the primary network
+type primaryNetworkLookup map[ids.ID]struct{}
+
+func (l primaryNetworkLookup) SubnetID(chainID ids.ID) (ids.ID, error) {
+	if _, ok := l[chainID]; !ok {
+		return ids.Empty, fmt.Errorf("%w: %s", errUnknownChain, chainID)
+	}
+	return constants.PrimaryNetworkID, nil
+}
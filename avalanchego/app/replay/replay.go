@@ -0,0 +1,271 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package replay implements the "replay" subcommand, which re-verifies the
+// accepted blocks of a chain from the local database of a stopped node. The
+// blocks are parsed, verified and accepted in order by a VM whose state starts
+// from genesis, without any networking, and the first block that can't be
+// replayed is reported. Only the P-chain can be replayed.
+//
+// The node flags, which select the database and the network, are passed after
+// "--":
+//
+//	avalanchego replay --chain=P -- --data-dir=$HOME/.avalanchego --network-id=flare
+//
+// The Report is written as JSON once the replay ends. The command fails if a
+// block couldn't be replayed. A "corruption" failure means that the local
+// database is inconsistent, and a "divergence" failure that the VM doesn't
+// reach the state that the local database recorded.
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	stdjson "encoding/json"
+
+	"github.com/spf13/pflag"
+
+	"github.com/ava-labs/avalanchego/config"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// Command is the argument that selects the replay subcommand
+	Command = "replay"
+
+	chainKey = "chain"
+
+	// Corruption is the kind of the failures caused by an inconsistent local
+	// database
+	Corruption = "corruption"
+	// Divergence is the kind of the failures caused by the replayed state not
+	// matching the local database
+	Divergence = "divergence"
+)
+
+var (
+	errUnsupportedChain = errors.New("unsupported chain")
+	errReplayFailed     = errors.New("replay failed")
+)
+
+// Failure describes the first block that couldn't be replayed
+type Failure struct {
+	Kind    string `json:"kind"`
+	Height  uint64 `json:"height"`
+	BlockID ids.ID `json:"blockID"`
+	Error   string `json:"error"`
+}
+
+// Report is the result of a replay
+type Report struct {
+	Chain              string `json:"chain"`
+	LastAcceptedID     ids.ID `json:"lastAcceptedID"`
+	LastAcceptedHeight uint64 `json:"lastAcceptedHeight"`
+	// Height of the last block that was replayed successfully
+	ReplayedHeight uint64   `json:"replayedHeight"`
+	Failure        *Failure `json:"failure,omitempty"`
+}
+
+func (r *Report) fail(kind string, height uint64, blkID ids.ID, err error) {
+	r.Failure = &Failure{
+		Kind:    kind,
+		Height:  height,
+		BlockID: blkID,
+		Error:   err.Error(),
+	}
+}
+
+// Run executes the replay subcommand with [args], which don't include
+// [Command]. The Report is written to [out].
+func Run(args []string, out io.Writer) error {
+	fs := pflag.NewFlagSet(Command, pflag.ContinueOnError)
+	fs.SetOutput(out)
+	chain := fs.String(chainKey, "P", "Alias of the chain to replay")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *chain != "P" && *chain != "platform" && *chain != constants.PlatformChainID.String() {
+		return fmt.Errorf("%w %q: only the P-chain can be replayed", errUnsupportedChain, *chain)
+	}
+
+	v, err := config.BuildViper(config.BuildFlagSet(), fs.Args())
+	if err != nil {
+		return fmt.Errorf("couldn't configure flags: %w", err)
+	}
+	runnerConfig, err := config.GetRunnerConfig(v)
+	if err != nil {
+		return fmt.Errorf("couldn't load process config: %w", err)
+	}
+	nodeConfig, err := config.GetNodeConfig(v, runnerConfig.BuildDir)
+	if err != nil {
+		return fmt.Errorf("couldn't load node config: %w", err)
+	}
+	version.InitApplicationPrefix(nodeConfig.NetworkID)
+
+	report := &Report{Chain: "P"}
+	if err := replayPlatformChain(&nodeConfig, report); err != nil {
+		return err
+	}
+
+	reportBytes, err := stdjson.MarshalIndent(report, "", "\t")
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(out, string(reportBytes)); err != nil {
+		return err
+	}
+	if report.Failure != nil {
+		return fmt.Errorf("%w at height %d", errReplayFailed, report.Failure.Height)
+	}
+	return nil
+}
+
+// replayChain replays the accepted blocks of [source] on [target], whose last
+// accepted block must be the genesis block. The first block that can't be
+// replayed is recorded in [report].
+func replayChain(source, target block.ChainVM, report *Report) error {
+	blkIDs, ok, err := acceptedBlockIDs(source, report)
+	if err != nil || !ok {
+		return err
+	}
+
+	genesisID, err := target.LastAccepted()
+	if err != nil {
+		return err
+	}
+	if genesisID != blkIDs[0] {
+		report.fail(Divergence, 0, blkIDs[0], fmt.Errorf("expected genesis block %s", genesisID))
+		return nil
+	}
+
+	// A block is only accepted once its child is verified, as the options of a
+	// proposal block are verified before the proposal block is accepted.
+	var verified snowman.Block
+	for height := uint64(1); height < uint64(len(blkIDs)); height++ {
+		blkID := blkIDs[height]
+		blk, ok := parse(source, target, height, blkID, report)
+		if !ok {
+			return nil
+		}
+		if err := blk.Verify(); err != nil {
+			report.fail(Divergence, height, blkID, fmt.Errorf("failed to verify block: %w", err))
+			return nil
+		}
+		if verified != nil && !accept(verified, report) {
+			return nil
+		}
+		verified = blk
+	}
+	if verified != nil && !accept(verified, report) {
+		return nil
+	}
+
+	sourceState, ok := source.(validators.State)
+	if !ok {
+		return nil
+	}
+	targetState, ok := target.(validators.State)
+	if !ok {
+		return nil
+	}
+	sourceVdrs, err := sourceState.GetValidatorSet(report.LastAcceptedHeight, constants.PrimaryNetworkID)
+	if err != nil {
+		report.fail(Corruption, report.LastAcceptedHeight, report.LastAcceptedID, fmt.Errorf("failed to get validator set: %w", err))
+		return nil
+	}
+	targetVdrs, err := targetState.GetValidatorSet(report.LastAcceptedHeight, constants.PrimaryNetworkID)
+	if err != nil {
+		report.fail(Divergence, report.LastAcceptedHeight, report.LastAcceptedID, fmt.Errorf("failed to get validator set: %w", err))
+		return nil
+	}
+	if !equalValidatorSets(sourceVdrs, targetVdrs) {
+		report.fail(Divergence, report.LastAcceptedHeight, report.LastAcceptedID, errors.New("validator sets differ"))
+	}
+	return nil
+}
+
+// acceptedBlockIDs returns the IDs of the accepted blocks of [vm], indexed by
+// height. False is returned if the chain is corrupted.
+func acceptedBlockIDs(vm block.ChainVM, report *Report) ([]ids.ID, bool, error) {
+	lastAcceptedID, err := vm.LastAccepted()
+	if err != nil {
+		return nil, false, err
+	}
+	lastAccepted, err := vm.GetBlock(lastAcceptedID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get last accepted block %s: %w", lastAcceptedID, err)
+	}
+	report.LastAcceptedID = lastAcceptedID
+	report.LastAcceptedHeight = lastAccepted.Height()
+
+	blkIDs := make([]ids.ID, report.LastAcceptedHeight+1)
+	blkID := lastAcceptedID
+	for height := report.LastAcceptedHeight; ; height-- {
+		blk, err := vm.GetBlock(blkID)
+		if err != nil {
+			report.fail(Corruption, height, blkID, fmt.Errorf("failed to get block: %w", err))
+			return nil, false, nil
+		}
+		if status := blk.Status(); status != choices.Accepted {
+			report.fail(Corruption, height, blkID, fmt.Errorf("expected accepted block but was %s", status))
+			return nil, false, nil
+		}
+		if blk.Height() != height {
+			report.fail(Corruption, height, blkID, fmt.Errorf("expected height %d but was %d", height, blk.Height()))
+			return nil, false, nil
+		}
+		blkIDs[height] = blkID
+		if height == 0 {
+			return blkIDs, true, nil
+		}
+		blkID = blk.Parent()
+	}
+}
+
+// parse returns the block [blkID] of [source] parsed by [target].
+func parse(source, target block.ChainVM, height uint64, blkID ids.ID, report *Report) (snowman.Block, bool) {
+	sourceBlk, err := source.GetBlock(blkID)
+	if err != nil {
+		report.fail(Corruption, height, blkID, fmt.Errorf("failed to get block: %w", err))
+		return nil, false
+	}
+	blk, err := target.ParseBlock(sourceBlk.Bytes())
+	if err != nil {
+		report.fail(Corruption, height, blkID, fmt.Errorf("failed to parse block: %w", err))
+		return nil, false
+	}
+	if blk.ID() != blkID {
+		report.fail(Corruption, height, blkID, fmt.Errorf("block bytes have ID %s", blk.ID()))
+		return nil, false
+	}
+	return blk, true
+}
+
+func accept(blk snowman.Block, report *Report) bool {
+	if err := blk.Accept(); err != nil {
+		report.fail(Divergence, blk.Height(), blk.ID(), fmt.Errorf("failed to accept block: %w", err))
+		return false
+	}
+	report.ReplayedHeight = blk.Height()
+	return true
+}
+
+func equalValidatorSets(a, b map[ids.NodeID]uint64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for nodeID, weight := range a {
+		if otherWeight, ok := b[nodeID]; !ok || otherWeight != weight {
+			return false
+		}
+	}
+	return true
+}
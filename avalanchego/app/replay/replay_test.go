@@ -0,0 +1,169 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package replay
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+var errInvalidBlock = errors.New("invalid block")
+
+// testChain is a chain of [numBlocks] accepted blocks. [source] returns the
+// accepted blocks, and [target] parses them from genesis. The blocks parsed by
+// [target] fail to verify with the errors in [verifyErrs].
+type testChain struct {
+	blks       []*snowman.TestBlock
+	verifyErrs map[uint64]error
+	// Heights of the blocks accepted by [target], in order
+	accepted []uint64
+}
+
+func newTestChain(numBlocks int) *testChain {
+	c := &testChain{verifyErrs: make(map[uint64]error)}
+	parentID := ids.Empty
+	for height := 0; height < numBlocks; height++ {
+		blkID := ids.GenerateTestID()
+		c.blks = append(c.blks, &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     blkID,
+				StatusV: choices.Accepted,
+			},
+			ParentV: parentID,
+			HeightV: uint64(height),
+			BytesV:  blkID[:],
+		})
+		parentID = blkID
+	}
+	return c
+}
+
+func (c *testChain) source() block.ChainVM {
+	return &block.TestVM{
+		LastAcceptedF: func() (ids.ID, error) {
+			return c.blks[len(c.blks)-1].ID(), nil
+		},
+		GetBlockF: func(blkID ids.ID) (snowman.Block, error) {
+			for _, blk := range c.blks {
+				if blk.ID() == blkID {
+					return blk, nil
+				}
+			}
+			return nil, database.ErrNotFound
+		},
+	}
+}
+
+func (c *testChain) target() block.ChainVM {
+	return &block.TestVM{
+		LastAcceptedF: func() (ids.ID, error) {
+			return c.blks[0].ID(), nil
+		},
+		ParseBlockF: func(b []byte) (snowman.Block, error) {
+			for _, blk := range c.blks {
+				if string(blk.Bytes()) != string(b) {
+					continue
+				}
+				return &testBlock{
+					TestBlock: &snowman.TestBlock{
+						TestDecidable: choices.TestDecidable{
+							IDV:     blk.ID(),
+							StatusV: choices.Processing,
+						},
+						ParentV: blk.Parent(),
+						HeightV: blk.Height(),
+						BytesV:  blk.Bytes(),
+						VerifyV: c.verifyErrs[blk.Height()],
+					},
+					chain: c,
+				}, nil
+			}
+			return nil, errInvalidBlock
+		},
+	}
+}
+
+type testBlock struct {
+	*snowman.TestBlock
+	chain *testChain
+}
+
+func (b *testBlock) Accept() error {
+	b.chain.accepted = append(b.chain.accepted, b.Height())
+	return b.TestBlock.Accept()
+}
+
+func TestReplayChain(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestChain(4)
+	report := &Report{}
+	require.NoError(replayChain(c.source(), c.target(), report))
+	require.Nil(report.Failure)
+	require.Equal(c.blks[3].ID(), report.LastAcceptedID)
+	require.EqualValues(3, report.LastAcceptedHeight)
+	require.EqualValues(3, report.ReplayedHeight)
+	require.Equal([]uint64{1, 2, 3}, c.accepted)
+}
+
+func TestReplayChainDivergence(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestChain(5)
+	c.verifyErrs[3] = errInvalidBlock
+	report := &Report{}
+	require.NoError(replayChain(c.source(), c.target(), report))
+	require.Equal(&Failure{
+		Kind:    Divergence,
+		Height:  3,
+		BlockID: c.blks[3].ID(),
+		Error:   "failed to verify block: invalid block",
+	}, report.Failure)
+	// The parent of the block that failed to verify isn't accepted.
+	require.EqualValues(1, report.ReplayedHeight)
+	require.Equal([]uint64{1}, c.accepted)
+}
+
+func TestReplayChainCorruption(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestChain(5)
+	missingBlk := c.blks[2]
+	c.blks = append(c.blks[:2], c.blks[3:]...)
+	report := &Report{}
+	require.NoError(replayChain(c.source(), c.target(), report))
+	require.Equal(Corruption, report.Failure.Kind)
+	require.EqualValues(2, report.Failure.Height)
+	require.Equal(missingBlk.ID(), report.Failure.BlockID)
+	require.Empty(c.accepted)
+}
+
+func TestReplayChainWrongGenesis(t *testing.T) {
+	require := require.New(t)
+
+	c := newTestChain(3)
+	target := c.target().(*block.TestVM)
+	target.LastAcceptedF = func() (ids.ID, error) {
+		return ids.GenerateTestID(), nil
+	}
+	report := &Report{}
+	require.NoError(replayChain(c.source(), target, report))
+	require.Equal(Divergence, report.Failure.Kind)
+	require.Zero(report.Failure.Height)
+	require.Empty(c.accepted)
+}
+
+func TestRunUnsupportedChain(t *testing.T) {
+	err := Run([]string{"--" + chainKey, "X"}, io.Discard)
+	require.ErrorIs(t, err, errUnsupportedChain)
+}
@@ -7,6 +7,9 @@ type Config struct {
 	// If true, displays version and exits during startup
 	DisplayVersionAndExit bool
 
+	// If true, prints the effective node config and exits during startup
+	PrintConfigAndExit bool
+
 	// Path to the build directory
 	BuildDir string
 
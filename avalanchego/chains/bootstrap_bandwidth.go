@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var _ common.BandwidthLimiter = &chainBandwidth{}
+
+// bootstrapBandwidth splits the bandwidth used to fetch containers while
+// bootstrapping among the bootstrapping chains, in proportion to their shares.
+type bootstrapBandwidth struct {
+	// Cancelled when the node shuts down, to stop waiting for bandwidth
+	ctx context.Context
+	// Bytes per second shared by the bootstrapping chains
+	bandwidth float64
+
+	lock   sync.Mutex
+	shares map[ids.ID]float64
+	chains map[ids.ID]*rate.Limiter
+}
+
+func newBootstrapBandwidth(ctx context.Context, bandwidth uint64) *bootstrapBandwidth {
+	return &bootstrapBandwidth{
+		ctx:       ctx,
+		bandwidth: float64(bandwidth),
+		shares:    make(map[ids.ID]float64),
+		chains:    make(map[ids.ID]*rate.Limiter),
+	}
+}
+
+// add returns the limiter of [chainID], which receives [share] of the
+// bandwidth relative to the shares of the other bootstrapping chains.
+func (b *bootstrapBandwidth) add(chainID ids.ID, share float64) common.BandwidthLimiter {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// A whole Ancestors message must fit in the burst, or it could never be
+	// waited for.
+	limiter := rate.NewLimiter(0, constants.DefaultMaxMessageSize)
+	b.shares[chainID] = share
+	b.chains[chainID] = limiter
+	b.rebalance()
+	return &chainBandwidth{
+		ctx:     b.ctx,
+		limiter: limiter,
+	}
+}
+
+// remove stops limiting [chainID] and gives its share of the bandwidth to the
+// remaining bootstrapping chains.
+func (b *bootstrapBandwidth) remove(chainID ids.ID) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	limiter, ok := b.chains[chainID]
+	if !ok {
+		return
+	}
+	limiter.SetLimit(rate.Inf)
+	delete(b.shares, chainID)
+	delete(b.chains, chainID)
+	b.rebalance()
+}
+
+// limit returns the bytes per second currently given to [chainID].
+func (b *bootstrapBandwidth) limit(chainID ids.ID) rate.Limit {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	limiter, ok := b.chains[chainID]
+	if !ok {
+		return rate.Inf
+	}
+	return limiter.Limit()
+}
+
+// Assumes [b.lock] is held.
+func (b *bootstrapBandwidth) rebalance() {
+	totalShares := 0.
+	for _, share := range b.shares {
+		totalShares += share
+	}
+	for chainID, limiter := range b.chains {
+		limiter.SetLimit(rate.Limit(b.bandwidth * b.shares[chainID] / totalShares))
+	}
+}
+
+type chainBandwidth struct {
+	ctx     context.Context
+	limiter *rate.Limiter
+}
+
+func (c *chainBandwidth) Wait(numBytes int) {
+	if burst := c.limiter.Burst(); numBytes > burst {
+		numBytes = burst
+	}
+	// An error is only returned once the node is shutting down, in which case
+	// the chain no longer needs to be limited.
+	_ = c.limiter.WaitN(c.ctx, numBytes)
+}
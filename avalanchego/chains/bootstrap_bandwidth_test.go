@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"golang.org/x/time/rate"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestBootstrapBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		xChainID = ids.GenerateTestID()
+		cChainID = ids.GenerateTestID()
+	)
+
+	b := newBootstrapBandwidth(context.Background(), 1200)
+	b.add(xChainID, 1)
+	require.Equal(rate.Limit(1200), b.limit(xChainID))
+
+	b.add(cChainID, 2)
+	require.Equal(rate.Limit(400), b.limit(xChainID))
+	require.Equal(rate.Limit(800), b.limit(cChainID))
+
+	// The bandwidth of a bootstrapped chain is given to the remaining chains.
+	b.remove(xChainID)
+	require.Equal(rate.Inf, b.limit(xChainID))
+	require.Equal(rate.Limit(1200), b.limit(cChainID))
+	b.remove(xChainID)
+}
+
+func TestBootstrapBandwidthWaitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	b := newBootstrapBandwidth(ctx, 1)
+	limiter := b.add(ids.GenerateTestID(), 1)
+
+	// Waiting for more than the burst must not block forever once the node
+	// shuts down.
+	cancel()
+	limiter.Wait(1 << 30)
+}
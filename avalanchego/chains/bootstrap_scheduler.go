@@ -0,0 +1,105 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+type queuedChain struct {
+	params   ChainParameters
+	priority int
+}
+
+// bootstrapScheduler limits the number of chains that bootstrap at once and
+// orders the chains that are waiting to start bootstrapping.
+type bootstrapScheduler struct {
+	// Max number of chains that may bootstrap at once. If 0, the number of
+	// chains isn't limited.
+	maxConcurrent int
+
+	lock sync.Mutex
+	// Chains waiting to be started, sorted by priority
+	queued []queuedChain
+	// Chains that were started and haven't finished bootstrapping
+	bootstrapping ids.Set
+}
+
+func newBootstrapScheduler(maxConcurrent int) *bootstrapScheduler {
+	return &bootstrapScheduler{
+		maxConcurrent: maxConcurrent,
+	}
+}
+
+// add queues [params] to be started. Chains with a lower [priority] are
+// started first. Chains with the same priority are started in the order they
+// were added.
+func (s *bootstrapScheduler) add(params ChainParameters, priority int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	i := sort.Search(len(s.queued), func(i int) bool {
+		return s.queued[i].priority > priority
+	})
+	s.queued = append(s.queued, queuedChain{})
+	copy(s.queued[i+1:], s.queued[i:])
+	s.queued[i] = queuedChain{
+		params:   params,
+		priority: priority,
+	}
+}
+
+// next removes the chains that may start bootstrapping now from the queue and
+// returns them in the order they should be started.
+func (s *bootstrapScheduler) next() []ChainParameters {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	numReady := len(s.queued)
+	if s.maxConcurrent > 0 {
+		available := s.maxConcurrent - s.bootstrapping.Len()
+		if available < 0 {
+			available = 0
+		}
+		if available < numReady {
+			numReady = available
+		}
+	}
+
+	ready := make([]ChainParameters, numReady)
+	for i, chain := range s.queued[:numReady] {
+		ready[i] = chain.params
+		s.bootstrapping.Add(chain.params.ID)
+	}
+	s.queued = s.queued[numReady:]
+	return ready
+}
+
+// done marks [chainID] as no longer bootstrapping. Returns true if this freed
+// a slot for a queued chain.
+func (s *bootstrapScheduler) done(chainID ids.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.bootstrapping.Contains(chainID) {
+		return false
+	}
+	s.bootstrapping.Remove(chainID)
+	return len(s.queued) > 0
+}
+
+// queuedChains returns the IDs of the chains that haven't been started.
+func (s *bootstrapScheduler) queuedChains() []ids.ID {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	chainIDs := make([]ids.ID, len(s.queued))
+	for i, chain := range s.queued {
+		chainIDs[i] = chain.params.ID
+	}
+	return chainIDs
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestBootstrapScheduler(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		x       = ChainParameters{ID: ids.GenerateTestID()}
+		c       = ChainParameters{ID: ids.GenerateTestID()}
+		subnet0 = ChainParameters{ID: ids.GenerateTestID()}
+		subnet1 = ChainParameters{ID: ids.GenerateTestID()}
+	)
+
+	s := newBootstrapScheduler(1)
+	s.add(subnet0, 2)
+	s.add(c, 1)
+	s.add(subnet1, 2)
+	s.add(x, 0)
+	require.Equal([]ids.ID{x.ID, c.ID, subnet0.ID, subnet1.ID}, s.queuedChains())
+
+	require.Equal([]ChainParameters{x}, s.next())
+	require.Empty(s.next(), "only one chain may bootstrap at once")
+
+	// A chain that isn't bootstrapping doesn't free a slot.
+	require.False(s.done(c.ID))
+	require.Empty(s.next())
+
+	require.True(s.done(x.ID))
+	require.False(s.done(x.ID), "the slot was already freed")
+	require.Equal([]ChainParameters{c}, s.next())
+
+	require.True(s.done(c.ID))
+	require.Equal([]ChainParameters{subnet0}, s.next())
+	require.True(s.done(subnet0.ID))
+	require.Equal([]ChainParameters{subnet1}, s.next())
+	require.Empty(s.queuedChains())
+
+	require.False(s.done(subnet1.ID), "no chain is waiting for the slot")
+}
+
+func TestBootstrapSchedulerUnlimited(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		x = ChainParameters{ID: ids.GenerateTestID()}
+		c = ChainParameters{ID: ids.GenerateTestID()}
+	)
+
+	s := newBootstrapScheduler(0)
+	s.add(c, 1)
+	s.add(x, 0)
+	require.Equal([]ChainParameters{x, c}, s.next())
+	require.Empty(s.next())
+}
@@ -4,6 +4,7 @@
 package chains
 
 import (
+	"context"
 	"crypto"
 	"crypto/tls"
 	"errors"
@@ -21,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network"
@@ -33,17 +35,23 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/syncer"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/metervm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/proposervm"
+	"github.com/ava-labs/avalanchego/vms/tracedvm"
 
 	dbManager "github.com/ava-labs/avalanchego/database/manager"
 	timetracker "github.com/ava-labs/avalanchego/snow/networking/tracker"
@@ -63,6 +71,7 @@ const defaultChannelSize = 1
 
 var (
 	errUnknownChainID   = errors.New("unknown chain ID")
+	errUnknownSubnetID  = errors.New("unknown subnet ID")
 	errUnknownVMType    = errors.New("the vm should have type avalanche.DAGVM or snowman.ChainVM")
 	errCreatePlatformVM = errors.New("attempted to create a chain running the PlatformVM")
 	errNotBootstrapped  = errors.New("chains not bootstrapped")
@@ -92,6 +101,13 @@ type Manager interface {
 	// created, [r].RegisterChain([new chain]) is called.
 	AddRegistrant(Registrant)
 
+	// Add a subnet registrant [r]. Every time a subnet is tracked with
+	// [TrackSubnet], [r].RegisterSubnet([subnetID]) is called.
+	AddSubnetRegistrant(SubnetRegistrant)
+
+	// Start validating the subnet [subnetID] without restarting the node
+	TrackSubnet(subnetID ids.ID) error
+
 	// Given an alias, return the ID of the chain associated with that alias
 	Lookup(string) (ids.ID, error)
 
@@ -104,6 +120,16 @@ type Manager interface {
 	// Returns true iff the chain with the given ID exists and is finished bootstrapping
 	IsBootstrapped(ids.ID) bool
 
+	// Returns the gossip config currently used by the chain with the given ID
+	GossipConfig(chainID ids.ID) (sender.GossipConfig, error)
+
+	// Replaces the gossip config used by the chain with the given ID
+	SetGossipConfig(chainID ids.ID, config sender.GossipConfig) error
+
+	// Returns the config that the chains of the tracked subnet with the given
+	// ID are created with, after the node's defaults are applied
+	SubnetConfig(subnetID ids.ID) (SubnetConfig, error)
+
 	Shutdown()
 }
 
@@ -127,6 +153,7 @@ type chain struct {
 	Name    string
 	Engine  common.Engine
 	Handler handler.Handler
+	Sender  common.Sender
 	Beacons validators.Set
 }
 
@@ -141,6 +168,7 @@ type ChainConfig struct {
 type ManagerConfig struct {
 	StakingEnabled              bool            // True iff the network has staking enabled
 	StakingCert                 tls.Certificate // needed to sign snowman++ blocks
+	StakingBLSSigner            bls.Signer      // needed to sign warp messages
 	Log                         logging.Logger
 	LogFactory                  logging.Factory
 	VMManager                   vms.Manager // Manage mappings from vm ID --> vm
@@ -170,12 +198,38 @@ type ManagerConfig struct {
 	ChainConfigs                map[string]ChainConfig  // alias -> ChainConfig
 	// ShutdownNodeFunc allows the chain manager to issue a request to shutdown the node
 	ShutdownNodeFunc func(exitCode int)
+	// ChainStoppedFunc is called when a chain stops, including when the node
+	// shuts down. May be nil.
+	ChainStoppedFunc func(chainAlias string, chainID ids.ID)
 	MeterVMEnabled   bool // Should each VM be wrapped with a MeterVM
 	Metrics          metrics.MultiGatherer
 
+	// Starts the spans of the messages handled by the chains
+	Tracer trace.Tracer
+	// Should each snowman VM be wrapped with a TracedVM
+	TracingEnabled bool
+
+	// Stops the consensus engines of the chains from starting new polls while
+	// the node is in maintenance
+	QueryGate *snow.QueryGate
+
 	ConsensusGossipFrequency time.Duration
+	// Accepted containers received via gossip in the last
+	// [GossipedContainerTTL] aren't gossiped again. If 0, every accepted
+	// container is gossiped.
+	GossipedContainerTTL time.Duration
+
+	// Default bounds of each chain's inbound message queues
+	MessageQueueConfig handler.MessageQueueConfig
+	// alias -> inbound message queue bounds overriding [MessageQueueConfig]
+	ChainMessageQueueConfigs map[string]handler.MessageQueueConfig
 
 	GossipConfig sender.GossipConfig
+	// Benchlist parameters of the chains whose subnet doesn't override them
+	BenchlistParameters benchlist.Parameters
+	// Max number of requests of a chain waiting for a response from a single
+	// peer. If 0, the requests aren't limited.
+	MaxOutstandingRequestsPerPeer int
 
 	// Max Time to spend fetching a container and its
 	// ancestors when responding to a GetAncestors
@@ -190,10 +244,39 @@ type ManagerConfig struct {
 	ApricotPhase4MinPChainHeight uint64
 	BanffTime                    time.Time
 
+	// Number of times the time spent repairing each height of the proposervm
+	// height index that the repair sleeps for
+	ProposerVMHeightIndexRepairThrottle uint64
+
 	// Tracks CPU/disk usage caused by each peer.
 	ResourceTracker timetracker.ResourceTracker
+	// Tracks CPU usage caused by each chain.
+	ChainTracker timetracker.ChainTracker
 
 	StateSyncBeacons []ids.NodeID
+
+	// Limits the state summaries and blocks served to syncing peers. Shared
+	// by all the snowman chains.
+	StateSyncServingBudget syncserving.Budget
+
+	// Chain alias or ID -> checkpoint that the block fetched at its height
+	// must match while bootstrapping
+	TrustedCheckpoints map[string]genesis.Checkpoint
+
+	// Aliases or IDs of the chains in the order they start bootstrapping.
+	// Chains that aren't listed start after the listed chains. The P-chain
+	// always bootstraps before the other chains are started.
+	BootstrapOrder []string
+	// Max number of chains, excluding the P-chain, that bootstrap at once. If
+	// 0, the number of chains isn't limited.
+	BootstrapMaxConcurrentChains int
+	// Bytes per second of containers fetched by all the bootstrapping chains.
+	// If 0, the bandwidth isn't limited.
+	BootstrapMaxBandwidth uint64
+	// alias -> share of [BootstrapMaxBandwidth] given to the chain while it
+	// bootstraps, relative to the shares of the other bootstrapping chains.
+	// Chains that aren't listed have a share of 1.
+	BootstrapBandwidthShares map[string]float64
 }
 
 type manager struct {
@@ -205,9 +288,29 @@ type manager struct {
 	// Those notified when a chain is created
 	registrants []Registrant
 
+	whitelistLock sync.RWMutex
+	// [whitelistLock] must be held while accessing [WhitelistedSubnets] or
+	// [subnetRegistrants]
+	// Those notified when a subnet is tracked at runtime
+	subnetRegistrants []SubnetRegistrant
+
 	unblocked     bool
 	blockedChains []ChainParameters
 
+	// Orders the chains created after the P-chain. If nil, the chains are
+	// created as soon as the P-chain is bootstrapped.
+	bootstrapScheduler *bootstrapScheduler
+	// [createLock] is held while the chains queued in [bootstrapScheduler]
+	// are created
+	createLock sync.Mutex
+	// Limits the bandwidth of the bootstrapping chains. If nil, the
+	// bandwidth isn't limited.
+	bootstrapBandwidth *bootstrapBandwidth
+	// Cancelled on shutdown to stop the chains waiting for bandwidth
+	bootstrapCtx    context.Context
+	bootstrapCancel context.CancelFunc
+
+	subnetsLock sync.Mutex
 	// Key: Subnet's ID
 	// Value: Subnet description
 	subnets map[ids.ID]Subnet
@@ -216,19 +319,38 @@ type manager struct {
 	// Key: Chain's ID
 	// Value: The chain
 	chains map[ids.ID]handler.Handler
+	// Key: Chain's ID
+	// Value: The chain's gossip config, which may be changed at runtime
+	gossipConfigs map[ids.ID]sender.GossipConfigurer
 
 	// snowman++ related interface to allow validators retrival
 	validatorState validators.State
+	// verifies warp messages against the P-chain's validator sets
+	warpVerifier warp.Verifier
 }
 
 // New returns a new Manager
 func New(config *ManagerConfig) Manager {
-	return &manager{
+	m := &manager{
 		Aliaser:       ids.NewAliaser(),
 		ManagerConfig: *config,
 		subnets:       make(map[ids.ID]Subnet),
 		chains:        make(map[ids.ID]handler.Handler),
+		gossipConfigs: make(map[ids.ID]sender.GossipConfigurer),
 	}
+	// The whitelist can grow at runtime, so it must not be shared with the
+	// rest of the node.
+	m.WhitelistedSubnets = ids.NewSet(config.WhitelistedSubnets.Len())
+	m.WhitelistedSubnets.Union(config.WhitelistedSubnets)
+
+	m.bootstrapCtx, m.bootstrapCancel = context.WithCancel(context.Background())
+	if len(config.BootstrapOrder) != 0 || config.BootstrapMaxConcurrentChains > 0 {
+		m.bootstrapScheduler = newBootstrapScheduler(config.BootstrapMaxConcurrentChains)
+	}
+	if config.BootstrapMaxBandwidth > 0 {
+		m.bootstrapBandwidth = newBootstrapBandwidth(m.bootstrapCtx, config.BootstrapMaxBandwidth)
+	}
+	return m
 }
 
 // Router that this chain manager is using to route consensus messages to chains
@@ -236,9 +358,13 @@ func (m *manager) Router() router.Router { return m.ManagerConfig.Router }
 
 // Create a chain
 func (m *manager) CreateChain(chain ChainParameters) {
-	if !m.unblocked {
+	switch {
+	case !m.unblocked:
 		m.blockedChains = append(m.blockedChains, chain)
-	} else {
+	case m.bootstrapScheduler != nil:
+		m.queueChain(chain)
+		go m.createQueuedChains()
+	default:
 		m.ForceCreateChain(chain)
 	}
 }
@@ -246,7 +372,7 @@ func (m *manager) CreateChain(chain ChainParameters) {
 // Create a chain, this is only called from the P-chain thread, except for
 // creating the P-chain.
 func (m *manager) ForceCreateChain(chainParams ChainParameters) {
-	if m.StakingEnabled && chainParams.SubnetID != constants.PrimaryNetworkID && !m.WhitelistedSubnets.Contains(chainParams.SubnetID) {
+	if m.StakingEnabled && chainParams.SubnetID != constants.PrimaryNetworkID && !m.isWhitelisted(chainParams.SubnetID) {
 		m.Log.Debug("skipped creating non-whitelisted chain",
 			zap.Stringer("chainID", chainParams.ID),
 			zap.Stringer("vmID", chainParams.VMID),
@@ -268,13 +394,12 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 		zap.Stringer("vmID", chainParams.VMID),
 	)
 
-	sb, exists := m.subnets[chainParams.SubnetID]
-	if !exists {
-		sb = newSubnet()
-		m.subnets[chainParams.SubnetID] = sb
-	}
-
+	sb := m.getSubnet(chainParams.SubnetID)
 	sb.addChain(chainParams.ID)
+	sb = &bootstrapNotifyingSubnet{
+		Subnet:         sb,
+		onBootstrapped: m.bootstrapFinished,
+	}
 
 	// Note: buildChain builds all chain's relevant objects (notably engine and handler)
 	// but does not start their operations. Starting of the handler (which could potentially
@@ -284,6 +409,7 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	chain, err := m.buildChain(chainParams, sb)
 	if err != nil {
 		sb.removeChain(chainParams.ID)
+		m.bootstrapFinished(chainParams.ID)
 		if m.CriticalChains.Contains(chainParams.ID) {
 			// Shut down if we fail to create a required chain (i.e. X, P or C)
 			m.Log.Fatal("error creating required chain",
@@ -318,6 +444,9 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 
 	m.chainsLock.Lock()
 	m.chains[chainParams.ID] = chain.Handler
+	if gossipConfig, ok := chain.Sender.(sender.GossipConfigurer); ok {
+		m.gossipConfigs[chainParams.ID] = gossipConfig
+	}
 	m.chainsLock.Unlock()
 
 	// Associate the newly created chain with its default alias
@@ -351,6 +480,17 @@ func (m *manager) ForceCreateChain(chainParams ChainParameters) {
 	// Tell the chain to start processing messages.
 	// If the X, P, or C Chain panics, do not attempt to recover
 	chain.Handler.Start(!m.CriticalChains.Contains(chainParams.ID))
+
+	go func() {
+		<-chain.Handler.Stopped()
+		m.TimeoutManager.UnregisterChain(chainParams.ID)
+		// A chain that stopped before it finished bootstrapping no longer
+		// holds a bootstrap slot or bandwidth.
+		m.bootstrapFinished(chainParams.ID)
+		if m.ChainStoppedFunc != nil {
+			m.ChainStoppedFunc(chain.Name, chainParams.ID)
+		}
+	}()
 }
 
 // Create a chain
@@ -398,10 +538,20 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 			ValidatorState:    m.validatorState,
 			StakingCertLeaf:   m.StakingCert.Leaf,
 			StakingLeafSigner: m.StakingCert.PrivateKey.(crypto.Signer),
+
+			WarpVerifier: m.warpVerifier,
 		},
 		DecisionAcceptor:  m.DecisionAcceptorGroup,
 		ConsensusAcceptor: m.ConsensusAcceptorGroup,
 		Registerer:        consensusMetrics,
+		Tracer:            m.Tracer,
+		QueryGate:         m.QueryGate,
+	}
+	if m.GossipedContainerTTL > 0 {
+		ctx.GossipedContainers = snow.NewGossipedContainers(m.GossipedContainerTTL)
+	}
+	if m.StakingBLSSigner != nil {
+		ctx.WarpSigner = warp.NewSigner(m.StakingBLSSigner, chainParams.ID)
 	}
 	// We set the state to Initializing here because failing to set the state
 	// before it's first access would cause a panic.
@@ -410,6 +560,7 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 	if sbConfigs, ok := m.SubnetConfigs[chainParams.SubnetID]; ok {
 		if sbConfigs.ValidatorOnly {
 			ctx.SetValidatorOnly()
+			ctx.AllowNodes(sbConfigs.AllowedNodes...)
 		}
 	}
 
@@ -516,13 +667,135 @@ func (m *manager) buildChain(chainParams ChainParameters, sb Subnet) (*chain, er
 
 func (m *manager) AddRegistrant(r Registrant) { m.registrants = append(m.registrants, r) }
 
+func (m *manager) AddSubnetRegistrant(r SubnetRegistrant) {
+	m.whitelistLock.Lock()
+	defer m.whitelistLock.Unlock()
+
+	m.subnetRegistrants = append(m.subnetRegistrants, r)
+}
+
+func (m *manager) TrackSubnet(subnetID ids.ID) error {
+	if subnetID == constants.PrimaryNetworkID {
+		return nil
+	}
+
+	m.whitelistLock.Lock()
+	if m.WhitelistedSubnets.Contains(subnetID) {
+		m.whitelistLock.Unlock()
+		return nil
+	}
+	m.WhitelistedSubnets.Add(subnetID)
+	registrants := m.subnetRegistrants
+	m.whitelistLock.Unlock()
+
+	m.Log.Info("tracking subnet",
+		zap.Stringer("subnetID", subnetID),
+	)
+
+	// Mark this node as tracking the subnet so that the subnet's chains are
+	// notified that this node is connected.
+	m.ManagerConfig.Router.Connected(m.NodeID, version.CurrentApp, subnetID)
+
+	for _, r := range registrants {
+		if err := r.RegisterSubnet(subnetID); err != nil {
+			return fmt.Errorf("failed to register subnet %s: %w", subnetID, err)
+		}
+	}
+	return m.Net.TrackSubnet(subnetID)
+}
+
+func (m *manager) isWhitelisted(subnetID ids.ID) bool {
+	m.whitelistLock.RLock()
+	defer m.whitelistLock.RUnlock()
+
+	return m.WhitelistedSubnets.Contains(subnetID)
+}
+
 func (m *manager) unblockChains() {
 	m.unblocked = true
 	blocked := m.blockedChains
 	m.blockedChains = nil
+	if m.bootstrapScheduler == nil {
+		for _, chainParams := range blocked {
+			m.ForceCreateChain(chainParams)
+		}
+		return
+	}
+
 	for _, chainParams := range blocked {
-		m.ForceCreateChain(chainParams)
+		m.queueChain(chainParams)
+	}
+	// The chains are created on another goroutine, as the P-chain may still
+	// be holding its lock.
+	go m.createQueuedChains()
+}
+
+// getSubnet returns the subnet with ID [subnetID], creating it if it doesn't
+// exist yet.
+func (m *manager) getSubnet(subnetID ids.ID) Subnet {
+	m.subnetsLock.Lock()
+	defer m.subnetsLock.Unlock()
+
+	sb, exists := m.subnets[subnetID]
+	if !exists {
+		sb = newSubnet()
+		m.subnets[subnetID] = sb
+	}
+	return sb
+}
+
+// queueChain queues [chainParams] in the bootstrap scheduler.
+func (m *manager) queueChain(chainParams ChainParameters) {
+	// The chain is added to its subnet right away so that the other chains of
+	// the subnet wait for it to bootstrap before they finish bootstrapping.
+	m.getSubnet(chainParams.SubnetID).addChain(chainParams.ID)
+	m.bootstrapScheduler.add(chainParams, m.getBootstrapPriority(chainParams.ID))
+}
+
+// createQueuedChains creates the queued chains that may start bootstrapping.
+func (m *manager) createQueuedChains() {
+	m.createLock.Lock()
+	defer m.createLock.Unlock()
+
+	for {
+		ready := m.bootstrapScheduler.next()
+		if len(ready) == 0 {
+			return
+		}
+		for _, chainParams := range ready {
+			m.ForceCreateChain(chainParams)
+
+			m.chainsLock.Lock()
+			_, created := m.chains[chainParams.ID]
+			m.chainsLock.Unlock()
+			if !created {
+				// The chain was skipped, so it must not block its subnet or
+				// hold a bootstrap slot.
+				m.getSubnet(chainParams.SubnetID).removeChain(chainParams.ID)
+				m.bootstrapFinished(chainParams.ID)
+			}
+		}
+	}
+}
+
+// bootstrapFinished releases the bootstrap slot and bandwidth of [chainID].
+// It may be called multiple times for the same chain.
+func (m *manager) bootstrapFinished(chainID ids.ID) {
+	if m.bootstrapBandwidth != nil {
+		m.bootstrapBandwidth.remove(chainID)
+	}
+	if m.bootstrapScheduler != nil && m.bootstrapScheduler.done(chainID) {
+		go m.createQueuedChains()
+	}
+}
+
+// bootstrapLimiter returns the limiter of the bandwidth used by the chain
+// with ID [id] to bootstrap, or nil if the bandwidth isn't limited.
+func (m *manager) bootstrapLimiter(id ids.ID) common.BandwidthLimiter {
+	if m.bootstrapBandwidth == nil {
+		return nil
 	}
+	return m.bootstrapBandwidth.add(id, m.getBootstrapBandwidthShare(id))
 }
 
 // Create a DAG-based blockchain that uses Avalanche
@@ -565,7 +838,7 @@ func (m *manager) createAvalancheChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
-	gossipConfig := m.GossipConfig
+	gossipConfig := m.ManagerConfig.GossipConfig
 	if sbConfigs, ok := m.SubnetConfigs[ctx.SubnetID]; ok && ctx.SubnetID != constants.PrimaryNetworkID {
 		gossipConfig = sbConfigs.GossipConfig
 	}
@@ -580,6 +853,7 @@ func (m *manager) createAvalancheChain(
 		m.ManagerConfig.Router,
 		m.TimeoutManager,
 		gossipConfig,
+		m.MaxOutstandingRequestsPerPeer,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
@@ -636,6 +910,8 @@ func (m *manager) createAvalancheChain(
 		sb.afterBootstrapped(),
 		m.ConsensusGossipFrequency,
 		m.ResourceTracker,
+		m.ChainTracker,
+		m.getMessageQueueConfig(ctx.ChainID),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing network handler: %w", err)
@@ -660,6 +936,7 @@ func (m *manager) createAvalancheChain(
 		MaxTimeGetAncestors:            m.BootstrapMaxTimeGetAncestors,
 		AncestorsMaxContainersSent:     m.BootstrapAncestorsMaxContainersSent,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		BootstrapBandwidth:             m.bootstrapLimiter(ctx.ChainID),
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
@@ -716,6 +993,7 @@ func (m *manager) createAvalancheChain(
 		Name:    chainAlias,
 		Engine:  engine,
 		Handler: handler,
+		Sender:  sender,
 	}, nil
 }
 
@@ -753,7 +1031,7 @@ func (m *manager) createSnowmanChain(
 	// VM uses this channel to notify engine that a block is ready to be made
 	msgChan := make(chan common.Message, defaultChannelSize)
 
-	gossipConfig := m.GossipConfig
+	gossipConfig := m.ManagerConfig.GossipConfig
 	if sbConfigs, ok := m.SubnetConfigs[ctx.SubnetID]; ok && ctx.SubnetID != constants.PrimaryNetworkID {
 		gossipConfig = sbConfigs.GossipConfig
 	}
@@ -768,6 +1046,7 @@ func (m *manager) createSnowmanChain(
 		m.ManagerConfig.Router,
 		m.TimeoutManager,
 		gossipConfig,
+		m.MaxOutstandingRequestsPerPeer,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize sender: %w", err)
@@ -798,6 +1077,13 @@ func (m *manager) createSnowmanChain(
 			m.validatorState = validators.NewNoValidatorsState(m.validatorState)
 			ctx.ValidatorState = validators.NewNoValidatorsState(ctx.ValidatorState)
 		}
+
+		// Warp messages are verified against the P-chain's validator sets,
+		// which requires the P-chain to also provide the validators' BLS keys.
+		if warpState, ok := vm.(warp.ValidatorState); ok {
+			m.warpVerifier = warp.NewVerifier(warp.NewLockedValidatorState(&ctx.Lock, warpState), m)
+			ctx.WarpVerifier = warp.NewVerifier(warpState, m)
+		}
 	}
 
 	// Initialize the ProposerVM and the vm wrapped inside it
@@ -811,11 +1097,15 @@ func (m *manager) createSnowmanChain(
 		m.ApricotPhase4Time,
 		m.ApricotPhase4MinPChainHeight,
 		m.BanffTime,
+		m.ProposerVMHeightIndexRepairThrottle,
 	)
 
 	if m.MeterVMEnabled {
 		vm = metervm.NewBlockVM(vm)
 	}
+	if m.TracingEnabled {
+		vm = tracedvm.NewBlockVM(vm, ctx.Tracer, &ctx.TraceScope)
+	}
 	if err := vm.Initialize(
 		ctx.Context,
 		vmDBManager,
@@ -843,6 +1133,8 @@ func (m *manager) createSnowmanChain(
 		sb.afterBootstrapped(),
 		m.ConsensusGossipFrequency,
 		m.ResourceTracker,
+		m.ChainTracker,
+		m.getMessageQueueConfig(ctx.ChainID),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize message handler: %w", err)
@@ -867,10 +1159,11 @@ func (m *manager) createSnowmanChain(
 		MaxTimeGetAncestors:            m.BootstrapMaxTimeGetAncestors,
 		AncestorsMaxContainersSent:     m.BootstrapAncestorsMaxContainersSent,
 		AncestorsMaxContainersReceived: m.BootstrapAncestorsMaxContainersReceived,
+		BootstrapBandwidth:             m.bootstrapLimiter(ctx.ChainID),
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
-	snowGetHandler, err := snowgetter.New(vm, commonCfg)
+	snowGetHandler, err := snowgetter.New(vm, commonCfg, m.StateSyncServingBudget)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't initialize snow base message handler: %w", err)
 	}
@@ -900,6 +1193,14 @@ func (m *manager) createSnowmanChain(
 		VM:            vm,
 		Bootstrapped:  m.unblockChains,
 	}
+	if checkpoint, ok := m.getTrustedCheckpoint(ctx.ChainID); ok {
+		ctx.Log.Info("bootstrapping against a trusted checkpoint",
+			zap.Stringer("blkID", checkpoint.ID),
+			zap.Uint64("height", checkpoint.Height),
+		)
+		bootstrapCfg.TrustedCheckpointID = checkpoint.ID
+		bootstrapCfg.TrustedCheckpointHeight = checkpoint.Height
+	}
 	bootstrapper, err := smbootstrap.New(
 		bootstrapCfg,
 		engine.Start,
@@ -936,6 +1237,7 @@ func (m *manager) createSnowmanChain(
 		Name:    chainAlias,
 		Engine:  engine,
 		Handler: handler,
+		Sender:  sender,
 	}, nil
 }
 
@@ -950,6 +1252,51 @@ func (m *manager) SubnetID(chainID ids.ID) (ids.ID, error) {
 	return chain.Context().SubnetID, nil
 }
 
+func (m *manager) GossipConfig(chainID ids.ID) (sender.GossipConfig, error) {
+	m.chainsLock.Lock()
+	gossipConfig, exists := m.gossipConfigs[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return sender.GossipConfig{}, errUnknownChainID
+	}
+	return gossipConfig.GossipConfig(), nil
+}
+
+func (m *manager) SetGossipConfig(chainID ids.ID, config sender.GossipConfig) error {
+	m.chainsLock.Lock()
+	gossipConfig, exists := m.gossipConfigs[chainID]
+	m.chainsLock.Unlock()
+	if !exists {
+		return errUnknownChainID
+	}
+	return gossipConfig.SetGossipConfig(config)
+}
+
+func (m *manager) SubnetConfig(subnetID ids.ID) (SubnetConfig, error) {
+	if subnetID != constants.PrimaryNetworkID && !m.isWhitelisted(subnetID) {
+		return SubnetConfig{}, errUnknownSubnetID
+	}
+
+	config := SubnetConfig{
+		GossipConfig:        m.ManagerConfig.GossipConfig,
+		ConsensusParameters: m.ConsensusParams,
+		BenchlistParameters: m.BenchlistParameters,
+	}
+	sbConfig, ok := m.SubnetConfigs[subnetID]
+	if !ok {
+		return config, nil
+	}
+	if subnetID != constants.PrimaryNetworkID {
+		return sbConfig, nil
+	}
+
+	// The primary network always uses the node's consensus, gossip and
+	// benchlist flags.
+	config.ValidatorOnly = sbConfig.ValidatorOnly
+	config.AllowedNodes = sbConfig.AllowedNodes
+	return config, nil
+}
+
 func (m *manager) IsBootstrapped(id ids.ID) bool {
 	m.chainsLock.Lock()
 	chain, exists := m.chains[id]
@@ -972,6 +1319,9 @@ func (m *manager) chainsNotBootstrapped() []ids.ID {
 		}
 		chainsBootstrapping = append(chainsBootstrapping, chainID)
 	}
+	if m.bootstrapScheduler != nil {
+		chainsBootstrapping = append(chainsBootstrapping, m.bootstrapScheduler.queuedChains()...)
+	}
 	return chainsBootstrapping
 }
 
@@ -1000,6 +1350,7 @@ func (m *manager) registerBootstrappedHealthChecks() error {
 // Shutdown stops all the chains
 func (m *manager) Shutdown() {
 	m.Log.Info("shutting down chain manager")
+	m.bootstrapCancel()
 	m.ManagerConfig.Router.Shutdown()
 }
 
@@ -1044,3 +1395,73 @@ func (m *manager) getChainConfig(id ids.ID) (ChainConfig, error) {
 
 	return ChainConfig{}, nil
 }
+
+// getTrustedCheckpoint returns the trusted checkpoint of the chain with ID
+// [id], if any.
+func (m *manager) getTrustedCheckpoint(id ids.ID) (genesis.Checkpoint, bool) {
+	if val, ok := m.TrustedCheckpoints[id.String()]; ok {
+		return val, true
+	}
+	aliases, err := m.Aliases(id)
+	if err != nil {
+		return genesis.Checkpoint{}, false
+	}
+	for _, alias := range aliases {
+		if val, ok := m.TrustedCheckpoints[alias]; ok {
+			return val, true
+		}
+	}
+	return genesis.Checkpoint{}, false
+}
+
+// getMessageQueueConfig returns the inbound message queue bounds of the chain
+// with ID [id], falling back to the default bounds if none were specified.
+func (m *manager) getMessageQueueConfig(id ids.ID) handler.MessageQueueConfig {
+	if val, ok := m.ChainMessageQueueConfigs[id.String()]; ok {
+		return val
+	}
+	aliases, err := m.Aliases(id)
+	if err != nil {
+		return m.MessageQueueConfig
+	}
+	for _, alias := range aliases {
+		if val, ok := m.ChainMessageQueueConfigs[alias]; ok {
+			return val
+		}
+	}
+	return m.MessageQueueConfig
+}
+
+// getBootstrapPriority returns the position of the chain with ID [id] in
+// [BootstrapOrder]. Chains that aren't listed are placed after the listed
+// chains.
+func (m *manager) getBootstrapPriority(id ids.ID) int {
+	aliases, _ := m.Aliases(id)
+	aliases = append(aliases, id.String())
+	for i, chain := range m.BootstrapOrder {
+		for _, alias := range aliases {
+			if chain == alias {
+				return i
+			}
+		}
+	}
+	return len(m.BootstrapOrder)
+}
+
+// getBootstrapBandwidthShare returns the share of the bootstrap bandwidth of
+// the chain with ID [id], which defaults to 1.
+func (m *manager) getBootstrapBandwidthShare(id ids.ID) float64 {
+	if val, ok := m.BootstrapBandwidthShares[id.String()]; ok {
+		return val
+	}
+	aliases, err := m.Aliases(id)
+	if err != nil {
+		return 1
+	}
+	for _, alias := range aliases {
+		if val, ok := m.BootstrapBandwidthShares[alias]; ok {
+			return val
+		}
+	}
+	return 1
+}
@@ -6,6 +6,7 @@ package chains
 import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
 )
 
 var _ Manager = MockManager{}
@@ -14,18 +15,28 @@ var _ Manager = MockManager{}
 // To be used only in tests
 type MockManager struct{}
 
-func (mm MockManager) Router() router.Router               { return nil }
-func (mm MockManager) CreateChain(ChainParameters)         {}
-func (mm MockManager) ForceCreateChain(ChainParameters)    {}
-func (mm MockManager) AddRegistrant(Registrant)            {}
-func (mm MockManager) Aliases(ids.ID) ([]string, error)    { return nil, nil }
-func (mm MockManager) PrimaryAlias(ids.ID) (string, error) { return "", nil }
-func (mm MockManager) PrimaryAliasOrDefault(ids.ID) string { return "" }
-func (mm MockManager) Alias(ids.ID, string) error          { return nil }
-func (mm MockManager) RemoveAliases(ids.ID)                {}
-func (mm MockManager) Shutdown()                           {}
-func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)     { return ids.ID{}, nil }
-func (mm MockManager) IsBootstrapped(ids.ID) bool          { return false }
+func (mm MockManager) Router() router.Router                { return nil }
+func (mm MockManager) CreateChain(ChainParameters)          {}
+func (mm MockManager) ForceCreateChain(ChainParameters)     {}
+func (mm MockManager) AddRegistrant(Registrant)             {}
+func (mm MockManager) AddSubnetRegistrant(SubnetRegistrant) {}
+func (mm MockManager) TrackSubnet(ids.ID) error             { return nil }
+func (mm MockManager) Aliases(ids.ID) ([]string, error)     { return nil, nil }
+func (mm MockManager) PrimaryAlias(ids.ID) (string, error)  { return "", nil }
+func (mm MockManager) PrimaryAliasOrDefault(ids.ID) string  { return "" }
+func (mm MockManager) Alias(ids.ID, string) error           { return nil }
+func (mm MockManager) RemoveAliases(ids.ID)                 {}
+func (mm MockManager) Shutdown()                            {}
+func (mm MockManager) SubnetID(ids.ID) (ids.ID, error)      { return ids.ID{}, nil }
+func (mm MockManager) IsBootstrapped(ids.ID) bool           { return false }
+
+func (mm MockManager) GossipConfig(ids.ID) (sender.GossipConfig, error) {
+	return sender.GossipConfig{}, nil
+}
+
+func (mm MockManager) SetGossipConfig(ids.ID, sender.GossipConfig) error { return nil }
+
+func (mm MockManager) SubnetConfig(ids.ID) (SubnetConfig, error) { return SubnetConfig{}, nil }
 
 func (mm MockManager) Lookup(s string) (ids.ID, error) {
 	id, err := ids.FromString(s)
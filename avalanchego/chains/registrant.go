@@ -4,6 +4,7 @@
 package chains
 
 import (
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 )
 
@@ -14,3 +15,11 @@ type Registrant interface {
 	// [engine] should be an avalanche.Engine or snowman.Engine
 	RegisterChain(name string, engine common.Engine)
 }
+
+// SubnetRegistrant can register the existence of a subnet that this node
+// started tracking after startup
+type SubnetRegistrant interface {
+	// Called when this node starts tracking [subnetID]. The registrant is
+	// expected to create the chains validated by the subnet.
+	RegisterSubnet(subnetID ids.ID) error
+}
@@ -9,10 +9,14 @@ import (
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
 )
 
-var _ Subnet = &subnet{}
+var (
+	_ Subnet = &subnet{}
+	_ Subnet = &bootstrapNotifyingSubnet{}
+)
 
 // Subnet keeps track of the currently bootstrapping chains in a subnet. If no
 // chains in the subnet are currently bootstrapping, the subnet is considered
@@ -30,8 +34,15 @@ type SubnetConfig struct {
 	sender.GossipConfig
 
 	// ValidatorOnly indicates that this Subnet's Chains are available to only subnet validators.
-	ValidatorOnly       bool                 `json:"validatorOnly" yaml:"validatorOnly"`
+	ValidatorOnly bool `json:"validatorOnly" yaml:"validatorOnly"`
+	// AllowedNodes are non-validator nodes, such as trusted RPC or archive
+	// nodes, that may still exchange consensus messages with this Subnet's
+	// Chains when ValidatorOnly is set.
+	AllowedNodes        []ids.NodeID         `json:"allowedNodes" yaml:"allowedNodes"`
 	ConsensusParameters avalanche.Parameters `json:"consensusParameters" yaml:"consensusParameters"`
+	// BenchlistParameters define when validators of this Subnet's Chains are
+	// benched. They default to the benchlist flags of the node.
+	BenchlistParameters benchlist.Parameters `json:"benchlistParameters" yaml:"benchlistParameters"`
 }
 
 type subnet struct {
@@ -85,3 +96,15 @@ func (s *subnet) removeChain(chainID ids.ID) {
 
 	s.bootstrapping.Remove(chainID)
 }
+
+// bootstrapNotifyingSubnet calls [onBootstrapped] whenever a chain of the
+// subnet is marked as bootstrapped.
+type bootstrapNotifyingSubnet struct {
+	Subnet
+	onBootstrapped func(chainID ids.ID)
+}
+
+func (s *bootstrapNotifyingSubnet) Bootstrapped(chainID ids.ID) {
+	s.onBootstrapped(chainID)
+	s.Subnet.Bootstrapped(chainID)
+}
@@ -9,6 +9,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
 func TestSubnet(t *testing.T) {
@@ -37,3 +41,52 @@ func TestSubnet(t *testing.T) {
 	s.Bootstrapped(chainID2)
 	require.True(s.IsBootstrapped(), "A subnet with only bootstrapped chains should be considered bootstrapped")
 }
+
+func TestManagerSubnetConfig(t *testing.T) {
+	require := require.New(t)
+
+	configuredSubnetID := ids.GenerateTestID()
+	defaultSubnetID := ids.GenerateTestID()
+	configuredSubnet := SubnetConfig{
+		GossipConfig:        sender.GossipConfig{OnAcceptPeerSize: 1},
+		ConsensusParameters: avalanche.Parameters{Parents: 1},
+		BenchlistParameters: benchlist.Parameters{Threshold: 1},
+	}
+	m := &manager{ManagerConfig: ManagerConfig{
+		GossipConfig:        sender.GossipConfig{OnAcceptPeerSize: 2},
+		ConsensusParams:     avalanche.Parameters{Parents: 2},
+		BenchlistParameters: benchlist.Parameters{Threshold: 2},
+		WhitelistedSubnets:  ids.Set{},
+		SubnetConfigs: map[ids.ID]SubnetConfig{
+			configuredSubnetID: configuredSubnet,
+			constants.PrimaryNetworkID: {
+				ConsensusParameters: avalanche.Parameters{Parents: 3},
+				ValidatorOnly:       true,
+			},
+		},
+	}}
+	m.WhitelistedSubnets.Add(configuredSubnetID, defaultSubnetID)
+
+	config, err := m.SubnetConfig(configuredSubnetID)
+	require.NoError(err)
+	require.Equal(configuredSubnet, config)
+
+	defaultConfig := SubnetConfig{
+		GossipConfig:        m.ManagerConfig.GossipConfig,
+		ConsensusParameters: m.ConsensusParams,
+		BenchlistParameters: m.BenchlistParameters,
+	}
+	config, err = m.SubnetConfig(defaultSubnetID)
+	require.NoError(err)
+	require.Equal(defaultConfig, config)
+
+	// Only the chain access settings of the primary network may be configured.
+	primaryConfig := defaultConfig
+	primaryConfig.ValidatorOnly = true
+	config, err = m.SubnetConfig(constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(primaryConfig, config)
+
+	_, err = m.SubnetConfig(ids.GenerateTestID())
+	require.ErrorIs(err, errUnknownSubnetID)
+}
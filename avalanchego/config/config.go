@@ -5,6 +5,7 @@ package config
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"errors"
@@ -12,13 +13,22 @@ import (
 	"io/fs"
 	"math"
 	"net"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/app/runner"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
@@ -27,27 +37,39 @@ import (
 	"github.com/ava-labs/avalanchego/nat"
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/node"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/staking/remote"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/notifier"
 	"github.com/ava-labs/avalanchego/utils/password"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+	platformconfig "github.com/ava-labs/avalanchego/vms/platformvm/config"
 )
 
 const (
@@ -55,17 +77,40 @@ const (
 	chainConfigFileName  = "config"
 	chainUpgradeFileName = "upgrade"
 	subnetConfigFileExt  = ".json"
+
+	legacyMessageFormatAuto     = "auto"
+	legacyMessageFormatEnabled  = "enabled"
+	legacyMessageFormatDisabled = "disabled"
+	// legacyMessageFormatGracePeriod is how long after Banff activates that
+	// the legacy message format remains enabled by default
+	legacyMessageFormatGracePeriod = 30 * 24 * time.Hour
+
+	// validatorModeNonValidatorShedThreshold is the largest share of the peer
+	// connections that non-validators may use in validator mode
+	validatorModeNonValidatorShedThreshold = 0.5
 )
 
 var (
+	// deprecatedKeys maps each deprecated key to the key that replaces it
 	deprecatedKeys = map[string]string{
-		DynamicUpdateDurationKey:   fmt.Sprintf("replaced by %q", PublicIPResolutionFreqKey),
-		DynamicPublicIPResolverKey: fmt.Sprintf("replaced by %q", PublicIPResolutionServiceKey),
+		DynamicUpdateDurationKey:   PublicIPResolutionFreqKey,
+		DynamicPublicIPResolverKey: PublicIPResolutionServiceKey,
+	}
+
+	// validatorModeDisabledAPIKeys are the keys of the APIs that are disabled
+	// in validator mode
+	validatorModeDisabledAPIKeys = []string{
+		AdminAPIEnabledKey,
+		KeystoreAPIEnabledKey,
+		IndexEnabledKey,
+		TransferAPIEnabledKey,
+		IpcAPIEnabledKey,
 	}
 
 	errInvalidStakerWeights          = errors.New("staking weights must be positive")
 	errStakingDisableOnPublicNetwork = errors.New("staking disabled on public network")
 	errAuthPasswordTooWeak           = errors.New("API auth password is not strong enough")
+	errPublicAdminScope              = errors.New("the admin scope can't be public")
 	errInvalidUptimeRequirement      = errors.New("uptime requirement must be in the range [0, 1]")
 	errMinValidatorStakeAboveMax     = errors.New("minimum validator stake can't be greater than maximum validator stake")
 	errInvalidDelegationFee          = errors.New("delegation fee must be in the range [0, 1,000,000]")
@@ -77,11 +122,13 @@ var (
 	errCannotWhitelistPrimaryNetwork = errors.New("cannot whitelist primary network")
 	errStakingKeyContentUnset        = fmt.Errorf("%s key not set but %s set", StakingTLSKeyContentKey, StakingCertContentKey)
 	errStakingCertContentUnset       = fmt.Errorf("%s key set but %s not set", StakingTLSKeyContentKey, StakingCertContentKey)
+	errUnknownConfigKeys             = errors.New("unknown config keys")
 )
 
 func GetRunnerConfig(v *viper.Viper) (runner.Config, error) {
 	config := runner.Config{
 		DisplayVersionAndExit: v.GetBool(VersionKey),
+		PrintConfigAndExit:    v.GetBool(PrintConfigKey),
 		BuildDir:              GetExpandedArg(v, BuildDirKey),
 		PluginMode:            v.GetBool(PluginModeKey),
 	}
@@ -160,13 +207,27 @@ func getLoggingConfig(v *viper.Viper) (logging.Config, error) {
 		return loggingConfig, err
 	}
 	loggingConfig.LogFormat, err = logging.ToFormat(v.GetString(LogFormatKey), os.Stdout.Fd())
+	if err != nil {
+		return loggingConfig, err
+	}
 	loggingConfig.DisableWriterDisplaying = v.GetBool(LogDisableDisplayPluginLogsKey)
 	loggingConfig.MaxSize = int(v.GetUint(LogRotaterMaxSizeKey))
 	loggingConfig.MaxFiles = int(v.GetUint(LogRotaterMaxFilesKey))
 	loggingConfig.MaxAge = int(v.GetUint(LogRotaterMaxAgeKey))
 	loggingConfig.Compress = v.GetBool(LogRotaterCompressEnabledKey)
-
-	return loggingConfig, err
+	loggingConfig.CompressionType, err = compression.TypeFromString(v.GetString(LogRotaterCompressionTypeKey))
+	if err != nil {
+		return loggingConfig, fmt.Errorf("couldn't parse %s: %w", LogRotaterCompressionTypeKey, err)
+	}
+	if loggingConfig.CompressionType != compression.TypeGzip && loggingConfig.CompressionType != compression.TypeZstd {
+		return loggingConfig, fmt.Errorf("%s must be one of {%s, %s}", LogRotaterCompressionTypeKey, compression.TypeGzip, compression.TypeZstd)
+	}
+	loggingConfig.RotationInterval = v.GetDuration(LogRotaterRotationIntervalKey)
+	if loggingConfig.RotationInterval < 0 {
+		return loggingConfig, fmt.Errorf("%q must be >= 0", LogRotaterRotationIntervalKey)
+	}
+	loggingConfig.DirectoryMaxSize = int(v.GetUint(LogDirMaxSizeKey))
+	return loggingConfig, nil
 }
 
 func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
@@ -191,6 +252,20 @@ func getAPIAuthConfig(v *viper.Viper) (node.APIAuthConfig, error) {
 	if !password.SufficientlyStrong(config.APIAuthPassword, password.OK) {
 		return node.APIAuthConfig{}, errAuthPasswordTooWeak
 	}
+
+	for _, scopeStr := range strings.Split(v.GetString(APIAuthPublicScopesKey), ",") {
+		if scopeStr == "" {
+			continue
+		}
+		scope, err := auth.ParseScope(scopeStr)
+		if err != nil {
+			return node.APIAuthConfig{}, fmt.Errorf("couldn't parse %s: %w", APIAuthPublicScopesKey, err)
+		}
+		if scope == auth.ScopeAdmin {
+			return node.APIAuthConfig{}, fmt.Errorf("invalid %s: %w", APIAuthPublicScopesKey, errPublicAdminScope)
+		}
+		config.APIAuthPublicScopes = append(config.APIAuthPublicScopes, scope)
+	}
 	return config, nil
 }
 
@@ -245,17 +320,23 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 	config := node.HTTPConfig{
 		APIConfig: node.APIConfig{
 			APIIndexerConfig: node.APIIndexerConfig{
-				IndexAPIEnabled:      v.GetBool(IndexEnabledKey),
-				IndexAllowIncomplete: v.GetBool(IndexAllowIncompleteKey),
+				IndexAPIEnabled:             v.GetBool(IndexEnabledKey),
+				IndexAllowIncomplete:        v.GetBool(IndexAllowIncompleteKey),
+				PlatformHistoryIndexEnabled: v.GetBool(IndexPlatformHistoryEnabledKey),
 			},
 			AdminAPIEnabled:    v.GetBool(AdminAPIEnabledKey),
 			InfoAPIEnabled:     v.GetBool(InfoAPIEnabledKey),
 			KeystoreAPIEnabled: v.GetBool(KeystoreAPIEnabledKey),
+			SignerSocketPath:   GetExpandedArg(v, SignerSocketPathKey),
 			MetricsAPIEnabled:  v.GetBool(MetricsAPIEnabledKey),
 			HealthAPIEnabled:   v.GetBool(HealthAPIEnabledKey),
+			TransferAPIEnabled: v.GetBool(TransferAPIEnabledKey),
+			ChainAPIsEnabled:   true,
 		},
 		HTTPHost:          v.GetString(HTTPHostKey),
 		HTTPPort:          uint16(v.GetUint(HTTPPortKey)),
+		HTTPTCPEnabled:    v.GetBool(HTTPTCPEnabledKey),
+		HTTPSocketPath:    GetExpandedArg(v, HTTPSocketPathKey),
 		HTTPSEnabled:      v.GetBool(HTTPSEnabledKey),
 		HTTPSKey:          httpsKey,
 		HTTPSCert:         httpsCert,
@@ -270,9 +351,195 @@ func getHTTPConfig(v *viper.Viper) (node.HTTPConfig, error) {
 		return node.HTTPConfig{}, err
 	}
 	config.IPCConfig = getIPCConfig(v)
+	config.RateLimiterConfig, err = getHTTPRateLimiterConfig(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.ArchiveProxyConfigs, err = getArchiveProxyConfigs(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	config.InfoAPIPeerEnrichment, err = getInfoAPIPeerEnrichment(v)
+	if err != nil {
+		return node.HTTPConfig{}, err
+	}
+	if v.GetBool(ValidatorModeKey) {
+		if err := applyValidatorMode(v, &config); err != nil {
+			return node.HTTPConfig{}, err
+		}
+	}
+
+	socketPerms, err := strconv.ParseUint(v.GetString(HTTPSocketPermsKey), 8, 32)
+	switch {
+	case err != nil || socketPerms > 0o777:
+		return node.HTTPConfig{}, fmt.Errorf("%s must be octal permission bits", HTTPSocketPermsKey)
+	case !config.HTTPTCPEnabled && config.HTTPSocketPath == "":
+		return node.HTTPConfig{}, fmt.Errorf("%s must be set if %s is false", HTTPSocketPathKey, HTTPTCPEnabledKey)
+	case !config.HTTPTCPEnabled && config.TransferAPIEnabled:
+		// The transfer API issues txs through the HTTP server's TCP address.
+		return node.HTTPConfig{}, fmt.Errorf("%s requires %s", TransferAPIEnabledKey, HTTPTCPEnabledKey)
+	}
+	config.HTTPSocketPerms = os.FileMode(socketPerms)
+	return config, nil
+}
+
+// applyValidatorMode disables the APIs of [config] that a validator doesn't
+// need, leaving only the health, info and metrics APIs, and requires the API
+// server to only listen locally. An error is returned if one of the disabled
+// APIs was explicitly enabled.
+func applyValidatorMode(v *viper.Viper, config *node.HTTPConfig) error {
+	for _, key := range validatorModeDisabledAPIKeys {
+		if v.IsSet(key) && v.GetBool(key) {
+			return fmt.Errorf("%s can't be enabled with %s", key, ValidatorModeKey)
+		}
+	}
+	if len(config.ArchiveProxyConfigs) != 0 {
+		return fmt.Errorf("%s can't be set with %s", HTTPArchiveProxyConfigKey, ValidatorModeKey)
+	}
+	if !isLoopbackHost(config.HTTPHost) {
+		return fmt.Errorf("%s must be a loopback address with %s but is %q", HTTPHostKey, ValidatorModeKey, config.HTTPHost)
+	}
+
+	config.AdminAPIEnabled = false
+	config.KeystoreAPIEnabled = false
+	config.IndexAPIEnabled = false
+	config.TransferAPIEnabled = false
+	config.IPCAPIEnabled = false
+	config.ChainAPIsEnabled = false
+	return nil
+}
+
+// isLoopbackHost returns true if [host] only resolves to this machine.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func getInfoAPIPeerEnrichment(v *viper.Viper) (map[string]map[string]string, error) {
+	if !v.IsSet(InfoAPIPeerEnrichmentFileKey) {
+		return nil, nil
+	}
+	filePath := GetExpandedArg(v, InfoAPIPeerEnrichmentFileKey)
+	fileBytes, err := os.ReadFile(filepath.Clean(filePath))
+	if err != nil {
+		return nil, err
+	}
+	enrichment := make(map[string]map[string]string)
+	if err := json.Unmarshal(fileBytes, &enrichment); err != nil {
+		return nil, fmt.Errorf("problem unmarshaling %s: %w", InfoAPIPeerEnrichmentFileKey, err)
+	}
+	return enrichment, nil
+}
+
+func getHTTPRateLimiterConfig(v *viper.Viper) (server.RateLimiterConfig, error) {
+	config := server.RateLimiterConfig{
+		PerIP: server.RateLimit{
+			Rate:  v.GetFloat64(HTTPRateLimitPerIPKey),
+			Burst: v.GetInt(HTTPRateLimitPerIPBurstKey),
+		},
+		Methods:     make(map[string]server.RateLimit),
+		MaxBodySize: v.GetInt64(HTTPRateLimitMaxBodySizeKey),
+	}
+	for _, proxy := range strings.Split(v.GetString(HTTPRateLimitTrustedProxiesKey), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			config.TrustedProxies = append(config.TrustedProxies, proxy)
+		}
+	}
+	for _, limitStr := range strings.Split(v.GetString(HTTPRateLimitMethodsKey), ",") {
+		if limitStr == "" {
+			continue
+		}
+		method, limit, err := parseMethodRateLimit(limitStr)
+		if err != nil {
+			return server.RateLimiterConfig{}, fmt.Errorf("couldn't parse %s: %w", HTTPRateLimitMethodsKey, err)
+		}
+		config.Methods[method] = limit
+	}
+	return config, config.Verify()
+}
+
+// getArchiveProxyConfigs returns the archive proxy configs of the chains that
+// forward calls to an archive node, keyed by chainID or chain alias.
+func getArchiveProxyConfigs(v *viper.Viper) (map[string]server.ArchiveProxyConfig, error) {
+	configs := make(map[string]server.ArchiveProxyConfig)
+	configsStr := v.GetString(HTTPArchiveProxyConfigKey)
+	if configsStr == "" {
+		return configs, nil
+	}
+
+	if err := json.Unmarshal([]byte(configsStr), &configs); err != nil {
+		return nil, fmt.Errorf("couldn't parse %q: %w", HTTPArchiveProxyConfigKey, err)
+	}
+	for chain, config := range configs {
+		if err := config.Verify(); err != nil {
+			return nil, fmt.Errorf("invalid %q for chain %s: %w", HTTPArchiveProxyConfigKey, chain, err)
+		}
+	}
+	return configs, nil
+}
+
+// parseMethodRateLimit parses a limit formatted as
+// <method or namespace>=<calls per second>:<burst>.
+func parseMethodRateLimit(limitStr string) (string, server.RateLimit, error) {
+	methodAndLimit := strings.Split(limitStr, "=")
+	if len(methodAndLimit) != 2 || methodAndLimit[0] == "" {
+		return "", server.RateLimit{}, fmt.Errorf("expected <method>=<rate>:<burst> but got %q", limitStr)
+	}
+	rateAndBurst := strings.Split(methodAndLimit[1], ":")
+	if len(rateAndBurst) != 2 {
+		return "", server.RateLimit{}, fmt.Errorf("expected <method>=<rate>:<burst> but got %q", limitStr)
+	}
+	rate, err := strconv.ParseFloat(rateAndBurst[0], 64)
+	if err != nil {
+		return "", server.RateLimit{}, fmt.Errorf("invalid rate in %q: %w", limitStr, err)
+	}
+	burst, err := strconv.Atoi(rateAndBurst[1])
+	if err != nil {
+		return "", server.RateLimit{}, fmt.Errorf("invalid burst in %q: %w", limitStr, err)
+	}
+	return methodAndLimit[0], server.RateLimit{
+		Rate:  rate,
+		Burst: burst,
+	}, nil
+}
+
+func getHealthChecksConfig(v *viper.Viper) (health.Config, error) {
+	config := health.Config{
+		DisabledChecks:  getHealthCheckNames(v, HealthCheckDisabledKey),
+		WarningChecks:   getHealthCheckNames(v, HealthCheckWarningKey),
+		ReadinessChecks: getHealthCheckNames(v, HealthCheckReadinessKey),
+		LivenessChecks:  getHealthCheckNames(v, HealthCheckLivenessKey),
+	}
+	disabled := make(map[string]struct{}, len(config.DisabledChecks))
+	for _, name := range config.DisabledChecks {
+		disabled[name] = struct{}{}
+	}
+	for _, key := range []string{HealthCheckWarningKey, HealthCheckReadinessKey, HealthCheckLivenessKey} {
+		for _, name := range getHealthCheckNames(v, key) {
+			if _, ok := disabled[name]; ok {
+				return health.Config{}, fmt.Errorf("health check %q can't be both in %q and %q", name, HealthCheckDisabledKey, key)
+			}
+		}
+	}
 	return config, nil
 }
 
+// getHealthCheckNames returns the comma separated health check names of [key].
+func getHealthCheckNames(v *viper.Viper, key string) []string {
+	checks := v.GetString(key)
+	if checks == "" {
+		return nil
+	}
+	names := strings.Split(checks, ",")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+	}
+	return names
+}
+
 func getRouterHealthConfig(v *viper.Viper, halflife time.Duration) (router.HealthConfig, error) {
 	config := router.HealthConfig{
 		MaxDropRate:            v.GetFloat64(RouterHealthMaxDropRateKey),
@@ -316,6 +583,121 @@ func getAdaptiveTimeoutConfig(v *viper.Viper) (timer.AdaptiveTimeoutConfig, erro
 	return config, nil
 }
 
+func getMessageQueueConfig(v *viper.Viper) (handler.MessageQueueConfig, error) {
+	dropPolicy, err := handler.DropPolicyFromString(v.GetString(ConsensusQueueDropPolicyKey))
+	if err != nil {
+		return handler.MessageQueueConfig{}, fmt.Errorf("couldn't parse %q: %w", ConsensusQueueDropPolicyKey, err)
+	}
+	cpuSoftLimit := v.GetFloat64(ConsensusQueueCPUSoftLimitKey)
+	if cpuSoftLimit < 0 {
+		return handler.MessageQueueConfig{}, fmt.Errorf("%q must be >= 0", ConsensusQueueCPUSoftLimitKey)
+	}
+	return handler.MessageQueueConfig{
+		MaxSize:      int(v.GetUint(ConsensusQueueMaxSizeKey)),
+		DropPolicy:   dropPolicy,
+		CPUSoftLimit: cpuSoftLimit,
+	}, nil
+}
+
+// getLegacyMessagesDisabled returns true if the legacy message format isn't
+// supported by the node. If the format is set to auto, it is disabled once
+// Banff has been active on [networkID] for [legacyMessageFormatGracePeriod] at
+// [now].
+func getLegacyMessagesDisabled(v *viper.Viper, networkID uint32, now time.Time) (bool, error) {
+	switch format := v.GetString(NetworkLegacyMessageFormatKey); format {
+	case legacyMessageFormatAuto:
+		return !now.Before(version.GetBanffTime(networkID).Add(legacyMessageFormatGracePeriod)), nil
+	case legacyMessageFormatEnabled:
+		return false, nil
+	case legacyMessageFormatDisabled:
+		return true, nil
+	default:
+		return false, fmt.Errorf("%q must be one of {%s, %s, %s} but got %q", NetworkLegacyMessageFormatKey, legacyMessageFormatAuto, legacyMessageFormatEnabled, legacyMessageFormatDisabled, format)
+	}
+}
+
+// getChainMessageQueueConfigs returns the inbound message queue configs of the
+// chains that override [defaultConfig]. Fields that a chain doesn't specify are
+// inherited from [defaultConfig]. The returned map is keyed by chainID or chain
+// alias.
+func getChainMessageQueueConfigs(v *viper.Viper, defaultConfig handler.MessageQueueConfig) (map[string]handler.MessageQueueConfig, error) {
+	configs := make(map[string]handler.MessageQueueConfig)
+	configsStr := v.GetString(ConsensusChainQueueConfigKey)
+	if configsStr == "" {
+		return configs, nil
+	}
+
+	rawConfigs := make(map[string]json.RawMessage)
+	if err := json.Unmarshal([]byte(configsStr), &rawConfigs); err != nil {
+		return nil, fmt.Errorf("couldn't parse %q: %w", ConsensusChainQueueConfigKey, err)
+	}
+	for chain, rawConfig := range rawConfigs {
+		config := defaultConfig
+		if err := json.Unmarshal(rawConfig, &config); err != nil {
+			return nil, fmt.Errorf("couldn't parse %q for chain %s: %w", ConsensusChainQueueConfigKey, chain, err)
+		}
+		if config.MaxSize < 0 {
+			return nil, fmt.Errorf("%q maxSize must be >= 0 for chain %s", ConsensusChainQueueConfigKey, chain)
+		}
+		if config.CPUSoftLimit < 0 {
+			return nil, fmt.Errorf("%q cpuSoftLimit must be >= 0 for chain %s", ConsensusChainQueueConfigKey, chain)
+		}
+		configs[chain] = config
+	}
+	return configs, nil
+}
+
+// getChainAdaptiveTimeoutConfigs returns the adaptive timeout configs of the
+// chains that override the minimum or maximum timeout of [defaultConfig]. The
+// returned map is keyed by chainID or chain alias.
+func getChainAdaptiveTimeoutConfigs(v *viper.Viper, defaultConfig timer.AdaptiveTimeoutConfig) (map[string]timer.AdaptiveTimeoutConfig, error) {
+	configs := make(map[string]timer.AdaptiveTimeoutConfig)
+	getConfig := func(chain string) timer.AdaptiveTimeoutConfig {
+		if config, ok := configs[chain]; ok {
+			return config
+		}
+		return defaultConfig
+	}
+
+	for chain, minimumTimeoutStr := range v.GetStringMapString(NetworkChainMinimumTimeoutKey) {
+		minimumTimeout, err := time.ParseDuration(minimumTimeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q for chain %s: %w", NetworkChainMinimumTimeoutKey, chain, err)
+		}
+		config := getConfig(chain)
+		config.MinimumTimeout = minimumTimeout
+		configs[chain] = config
+	}
+	for chain, maximumTimeoutStr := range v.GetStringMapString(NetworkChainMaximumTimeoutKey) {
+		maximumTimeout, err := time.ParseDuration(maximumTimeoutStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q for chain %s: %w", NetworkChainMaximumTimeoutKey, chain, err)
+		}
+		config := getConfig(chain)
+		config.MaximumTimeout = maximumTimeout
+		configs[chain] = config
+	}
+
+	for chain, config := range configs {
+		// The initial timeout is kept within the overridden bounds.
+		if config.InitialTimeout < config.MinimumTimeout {
+			config.InitialTimeout = config.MinimumTimeout
+		}
+		if config.InitialTimeout > config.MaximumTimeout {
+			config.InitialTimeout = config.MaximumTimeout
+		}
+		configs[chain] = config
+
+		switch {
+		case config.MinimumTimeout < 1:
+			return nil, fmt.Errorf("%q must be positive for chain %s", NetworkChainMinimumTimeoutKey, chain)
+		case config.MinimumTimeout > config.MaximumTimeout:
+			return nil, fmt.Errorf("maximum timeout must be >= minimum timeout for chain %s", chain)
+		}
+	}
+	return configs, nil
+}
+
 func getGossipConfig(v *viper.Viper) sender.GossipConfig {
 	return sender.GossipConfig{
 		AcceptedFrontierValidatorSize:    uint(v.GetUint32(ConsensusGossipAcceptedFrontierValidatorSizeKey)),
@@ -327,6 +709,7 @@ func getGossipConfig(v *viper.Viper) sender.GossipConfig {
 		AppGossipValidatorSize:           uint(v.GetUint32(AppGossipValidatorSizeKey)),
 		AppGossipNonValidatorSize:        uint(v.GetUint32(AppGossipNonValidatorSizeKey)),
 		AppGossipPeerSize:                uint(v.GetUint32(AppGossipPeerSizeKey)),
+		AppGossipStakeWeighted:           v.GetBool(AppGossipStakeWeightedKey),
 	}
 }
 
@@ -337,6 +720,12 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 	upgradeCooldown := v.GetDuration(InboundConnUpgradeThrottlerCooldownKey)
 	upgradeCooldownInSeconds := upgradeCooldown.Seconds()
 	maxRecentConnsUpgraded := int(math.Ceil(maxInboundConnsPerSec * upgradeCooldownInSeconds))
+
+	compressionType, err := compression.TypeFromString(v.GetString(NetworkCompressionTypeKey))
+	if err != nil {
+		return network.Config{}, fmt.Errorf("couldn't parse %s: %w", NetworkCompressionTypeKey, err)
+	}
+
 	config := network.Config{
 		// Throttling
 		ThrottlerConfig: network.ThrottlerConfig{
@@ -387,6 +776,7 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		},
 
 		TLSKeyLogFile: v.GetString(NetworkTLSKeyLogFileKey),
+		QUICEnabled:   v.GetBool(NetworkQUICEnabledKey),
 
 		TimeoutConfig: network.TimeoutConfig{
 			PingPongTimeout:      v.GetDuration(NetworkPingTimeoutKey),
@@ -399,6 +789,7 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 			PeerListNonValidatorGossipSize: v.GetUint32(NetworkPeerListNonValidatorGossipSizeKey),
 			PeerListPeersGossipSize:        v.GetUint32(NetworkPeerListPeersGossipSizeKey),
 			PeerListGossipFreq:             v.GetDuration(NetworkPeerListGossipFreqKey),
+			PeerListRecoveryStakeThreshold: v.GetFloat64(NetworkPeerListRecoveryStakeThresholdKey),
 		},
 
 		DelayConfig: network.DelayConfig{
@@ -408,6 +799,7 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 
 		MaxClockDifference:           v.GetDuration(NetworkMaxClockDifferenceKey),
 		CompressionEnabled:           v.GetBool(NetworkCompressionEnabledKey),
+		CompressionType:              compressionType,
 		PingFrequency:                v.GetDuration(NetworkPingFrequencyKey),
 		AllowPrivateIPs:              v.GetBool(NetworkAllowPrivateIPsKey),
 		UptimeMetricFreq:             v.GetDuration(UptimeMetricFreqKey),
@@ -416,11 +808,44 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		RequireValidatorToConnect: v.GetBool(NetworkRequireValidatorToConnectKey),
 		PeerReadBufferSize:        int(v.GetUint(NetworkPeerReadBufferSizeKey)),
 		PeerWriteBufferSize:       int(v.GetUint(NetworkPeerWriteBufferSizeKey)),
+		PeerMaxQueuedBulkBytes:    int(v.GetUint(NetworkPeerMaxQueuedBulkBytesKey)),
+		GaterConfig: network.GaterConfig{
+			AllowedCIDRs:    getCommaSeparated(v, NetworkGaterAllowedCIDRsKey),
+			DeniedCIDRs:     getCommaSeparated(v, NetworkGaterDeniedCIDRsKey),
+			DeniedDialCIDRs: getCommaSeparated(v, NetworkGaterDeniedDialCIDRsKey),
+			MaxConnsPerIP:   v.GetInt(NetworkGaterMaxConnsPerIPKey),
+		},
+		PeerBudgetConfig: network.PeerBudgetConfig{
+			MaxPeerConns:              v.GetInt(NetworkMaxPeerConnsKey),
+			NonValidatorShedThreshold: v.GetFloat64(NetworkNonValidatorShedThresholdKey),
+		},
+	}
+	if config.PeerBudgetConfig.MaxPeerConns == 0 {
+		config.PeerBudgetConfig.MaxPeerConns = int(v.GetUint64(FdLimitKey) / 2)
+	}
+
+	config.BlocklistedNodeIDs, err = getBlocklistedNodeIDs(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.BlocklistedIPs, err = getBlocklistedIPs(v)
+	if err != nil {
+		return network.Config{}, err
+	}
+	config.PeerCertPolicy, err = getPeerCertPolicy(v)
+	if err != nil {
+		return network.Config{}, err
 	}
 
 	switch {
 	case config.HealthConfig.MaxTimeSinceMsgSent < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceMsgSentKey)
+	case config.GaterConfig.MaxConnsPerIP < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkGaterMaxConnsPerIPKey)
+	case config.PeerBudgetConfig.MaxPeerConns < 0:
+		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxPeerConnsKey)
+	case config.PeerBudgetConfig.NonValidatorShedThreshold <= 0 || config.PeerBudgetConfig.NonValidatorShedThreshold > 1:
+		return network.Config{}, fmt.Errorf("%s must be in (0,1]", NetworkNonValidatorShedThresholdKey)
 	case config.HealthConfig.MaxTimeSinceMsgReceived < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkHealthMaxTimeSinceMsgReceivedKey)
 	case config.HealthConfig.MaxSendFailRate < 0 || config.HealthConfig.MaxSendFailRate > 1:
@@ -431,6 +856,10 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 		return network.Config{}, fmt.Errorf("%q must be >= 0", OutboundConnectionTimeout)
 	case config.PeerListGossipFreq < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkPeerListGossipFreqKey)
+	case config.PeerListRecoveryStakeThreshold < 0 || config.PeerListRecoveryStakeThreshold > 1:
+		return network.Config{}, fmt.Errorf("%s must be in [0,1]", NetworkPeerListRecoveryStakeThresholdKey)
+	case config.CompressionType != compression.TypeGzip && config.CompressionType != compression.TypeZstd:
+		return network.Config{}, fmt.Errorf("%s must be one of {%s, %s}", NetworkCompressionTypeKey, compression.TypeGzip, compression.TypeZstd)
 	case config.MaxReconnectDelay < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxReconnectDelayKey)
 	case config.InitialReconnectDelay < 0:
@@ -448,16 +877,98 @@ func getNetworkConfig(v *viper.Viper, halflife time.Duration) (network.Config, e
 	case config.MaxClockDifference < 0:
 		return network.Config{}, fmt.Errorf("%s must be >= 0", NetworkMaxClockDifferenceKey)
 	}
+
+	if v.GetBool(ValidatorModeKey) {
+		// Keep more sockets available to validators
+		config.PeerBudgetConfig.NonValidatorShedThreshold = math.Min(config.PeerBudgetConfig.NonValidatorShedThreshold, validatorModeNonValidatorShedThreshold)
+	}
 	return config, nil
 }
 
-func getBenchlistConfig(v *viper.Viper, alpha, k int) (benchlist.Config, error) {
-	config := benchlist.Config{
+func getBlocklistedNodeIDs(v *viper.Viper) (ids.NodeIDSet, error) {
+	nodeIDs := ids.NodeIDSet{}
+	for _, id := range strings.Split(v.GetString(NetworkBlocklistNodeIDsKey), ",") {
+		if id == "" {
+			continue
+		}
+		nodeID, err := ids.NodeIDFromString(id)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse blocklisted node id %s: %w", id, err)
+		}
+		nodeIDs.Add(nodeID)
+	}
+	return nodeIDs, nil
+}
+
+// getCommaSeparated returns the non-empty elements of the comma separated list
+// at [key]
+func getCommaSeparated(v *viper.Viper, key string) []string {
+	var elements []string
+	for _, element := range strings.Split(v.GetString(key), ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+func getBlocklistedIPs(v *viper.Viper) ([]net.IP, error) {
+	var blocklistedIPs []net.IP
+	for _, ipStr := range strings.Split(v.GetString(NetworkBlocklistIPsKey), ",") {
+		if ipStr == "" {
+			continue
+		}
+		ip := net.ParseIP(ipStr)
+		if ip == nil {
+			return nil, fmt.Errorf("couldn't parse blocklisted ip %s", ipStr)
+		}
+		blocklistedIPs = append(blocklistedIPs, ip)
+	}
+	return blocklistedIPs, nil
+}
+
+func getPeerCertPolicy(v *viper.Viper) (peer.CertPolicy, error) {
+	policy := peer.CertPolicy{
+		MinRSAKeySize: int(v.GetUint(NetworkTLSMinRSAKeySizeKey)),
+		MaxValidity:   v.GetDuration(NetworkTLSMaxCertValidityKey),
+	}
+	if policy.MaxValidity < 0 {
+		return peer.CertPolicy{}, fmt.Errorf("%s must be >= 0", NetworkTLSMaxCertValidityKey)
+	}
+	for _, name := range getCommaSeparated(v, NetworkTLSAllowedSignatureAlgorithmsKey) {
+		algorithm, ok := signatureAlgorithmFromString(name)
+		if !ok {
+			return peer.CertPolicy{}, fmt.Errorf("%s contains unknown signature algorithm %q", NetworkTLSAllowedSignatureAlgorithmsKey, name)
+		}
+		policy.AllowedSignatureAlgorithms = append(policy.AllowedSignatureAlgorithms, algorithm)
+	}
+	return policy, nil
+}
+
+// signatureAlgorithmFromString returns the signature algorithm named [name],
+// as it is printed by x509.SignatureAlgorithm.String.
+func signatureAlgorithmFromString(name string) (x509.SignatureAlgorithm, bool) {
+	for algorithm := x509.MD2WithRSA; algorithm <= x509.PureEd25519; algorithm++ {
+		if strings.EqualFold(algorithm.String(), name) {
+			return algorithm, true
+		}
+	}
+	return x509.UnknownSignatureAlgorithm, false
+}
+
+func getBenchlistParameters(v *viper.Viper, alpha, k int) benchlist.Parameters {
+	return benchlist.Parameters{
 		Threshold:              v.GetInt(BenchlistFailThresholdKey),
 		Duration:               v.GetDuration(BenchlistDurationKey),
 		MinimumFailingDuration: v.GetDuration(BenchlistMinFailingDurationKey),
 		MaxPortion:             (1.0 - (float64(alpha) / float64(k))) / 3.0,
 	}
+}
+
+func getBenchlistConfig(v *viper.Viper, alpha, k int) (benchlist.Config, error) {
+	config := benchlist.Config{
+		Parameters: getBenchlistParameters(v, alpha, k),
+	}
 	switch {
 	case config.Duration < 0:
 		return benchlist.Config{}, fmt.Errorf("%q must be >= 0", BenchlistDurationKey)
@@ -496,6 +1007,16 @@ func getStateSyncConfig(v *viper.Viper) (node.StateSyncConfig, error) {
 		config.StateSyncIDs = append(config.StateSyncIDs, nodeID)
 	}
 
+	config.StateSyncServingConfig = syncserving.Config{
+		Enabled:               v.GetBool(StateSyncServingLimitsEnabledKey),
+		MaxConcurrentPerPeer:  int(v.GetUint(StateSyncServingMaxConcurrentPerPeerKey)),
+		BandwidthPerPeer:      float64(v.GetUint64(StateSyncServingBandwidthPerPeerKey)),
+		BandwidthBurstPerPeer: float64(v.GetUint64(StateSyncServingBandwidthBurstPerPeerKey)),
+	}
+	if err := config.StateSyncServingConfig.Verify(); err != nil {
+		return node.StateSyncConfig{}, fmt.Errorf("invalid state sync serving limits: %w", err)
+	}
+
 	lenIPs := len(config.StateSyncIPs)
 	lenIDs := len(config.StateSyncIDs)
 	if lenIPs != lenIDs {
@@ -513,7 +1034,20 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		BootstrapMaxTimeGetAncestors:            v.GetDuration(BootstrapMaxTimeGetAncestorsKey),
 		BootstrapAncestorsMaxContainersSent:     int(v.GetUint(BootstrapAncestorsMaxContainersSentKey)),
 		BootstrapAncestorsMaxContainersReceived: int(v.GetUint(BootstrapAncestorsMaxContainersReceivedKey)),
+		BootstrapMaxConcurrentChains:            int(v.GetUint(BootstrapMaxConcurrentChainsKey)),
+		BootstrapMaxBandwidth:                   v.GetUint64(BootstrapMaxBandwidthKey),
 	}
+	for _, chain := range strings.Split(v.GetString(BootstrapOrderKey), ",") {
+		if chain == "" {
+			continue
+		}
+		config.BootstrapOrder = append(config.BootstrapOrder, chain)
+	}
+	shares, err := getBootstrapBandwidthShares(v)
+	if err != nil {
+		return node.BootstrapConfig{}, err
+	}
+	config.BootstrapBandwidthShares = shares
 
 	ipsSet := v.IsSet(BootstrapIPsKey)
 	idsSet := v.IsSet(BootstrapIDsKey)
@@ -559,10 +1093,118 @@ func getBootstrapConfig(v *viper.Viper, networkID uint32) (node.BootstrapConfig,
 		return node.BootstrapConfig{}, fmt.Errorf("expected the number of bootstrapIPs (%d) to match the number of bootstrapIDs (%d)", lenIPs, lenIDs)
 	}
 
+	config.TrustedCheckpoints, err = getTrustedCheckpoints(v, networkID)
+	if err != nil {
+		return node.BootstrapConfig{}, err
+	}
 	return config, nil
 }
 
+// getTrustedCheckpoints returns the built-in checkpoints of [networkID],
+// overridden by the checkpoints given by the operator.
+func getTrustedCheckpoints(v *viper.Viper, networkID uint32) (map[string]genesis.Checkpoint, error) {
+	checkpoints, err := genesis.GetCheckpoints(networkID)
+	if err != nil {
+		return nil, err
+	}
+	for _, checkpointStr := range strings.Split(v.GetString(BootstrapTrustedCheckpointsKey), ",") {
+		if checkpointStr == "" {
+			continue
+		}
+		chain, checkpoint, err := parseCheckpoint(checkpointStr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q: %w", BootstrapTrustedCheckpointsKey, err)
+		}
+		checkpoints[chain] = checkpoint
+	}
+	return checkpoints, nil
+}
+
+// getBootstrapBandwidthShares returns the bootstrap bandwidth shares of the
+// chains, keyed by chain alias or ID.
+func getBootstrapBandwidthShares(v *viper.Viper) (map[string]float64, error) {
+	shares := make(map[string]float64)
+	for _, shareStr := range strings.Split(v.GetString(BootstrapBandwidthSharesKey), ",") {
+		if shareStr == "" {
+			continue
+		}
+		chainAndShare := strings.Split(shareStr, "=")
+		if len(chainAndShare) != 2 || chainAndShare[0] == "" {
+			return nil, fmt.Errorf("couldn't parse %q: expected <chain>=<share> but got %q", BootstrapBandwidthSharesKey, shareStr)
+		}
+		share, err := strconv.ParseFloat(chainAndShare[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse %q: invalid share in %q: %w", BootstrapBandwidthSharesKey, shareStr, err)
+		}
+		if math.IsNaN(share) || share <= 0 || math.IsInf(share, 0) {
+			return nil, fmt.Errorf("%q share of chain %s must be positive and finite", BootstrapBandwidthSharesKey, chainAndShare[0])
+		}
+		shares[chainAndShare[0]] = share
+	}
+	return shares, nil
+}
+
+// parseCheckpoint parses a checkpoint formatted as
+// <chain alias or ID>=<block ID>:<height>.
+func parseCheckpoint(checkpointStr string) (string, genesis.Checkpoint, error) {
+	chainAndCheckpoint := strings.Split(checkpointStr, "=")
+	if len(chainAndCheckpoint) != 2 || chainAndCheckpoint[0] == "" {
+		return "", genesis.Checkpoint{}, fmt.Errorf("expected <chain>=<block ID>:<height> but got %q", checkpointStr)
+	}
+	idAndHeight := strings.Split(chainAndCheckpoint[1], ":")
+	if len(idAndHeight) != 2 {
+		return "", genesis.Checkpoint{}, fmt.Errorf("expected <chain>=<block ID>:<height> but got %q", checkpointStr)
+	}
+	blkID, err := ids.FromString(idAndHeight[0])
+	if err != nil {
+		return "", genesis.Checkpoint{}, fmt.Errorf("invalid block ID in %q: %w", checkpointStr, err)
+	}
+	height, err := strconv.ParseUint(idAndHeight[1], 10, 64)
+	if err != nil {
+		return "", genesis.Checkpoint{}, fmt.Errorf("invalid height in %q: %w", checkpointStr, err)
+	}
+	return chainAndCheckpoint[0], genesis.Checkpoint{
+		ID:     blkID,
+		Height: height,
+	}, nil
+}
+
 func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
+	config, err := getPublicIPConfig(v)
+	if err != nil {
+		return node.IPConfig{}, err
+	}
+
+	stakingPort := uint16(v.GetUint(StakingPortKey))
+	additionalIPs := getCommaSeparated(v, AdditionalPublicIPsKey)
+	if len(additionalIPs) > peer.MaxAdditionalIPs {
+		return node.IPConfig{}, fmt.Errorf("at most %d %s may be given", peer.MaxAdditionalIPs, AdditionalPublicIPsKey)
+	}
+	for _, ipStr := range additionalIPs {
+		ipPort, err := ips.ToIPPort(ipStr)
+		if err != nil {
+			ip := net.ParseIP(ipStr)
+			if ip == nil {
+				return node.IPConfig{}, fmt.Errorf("invalid %s %q", AdditionalPublicIPsKey, ipStr)
+			}
+			ipPort = ips.IPPort{
+				IP:   ip,
+				Port: stakingPort,
+			}
+		}
+		config.AdditionalIPs = append(config.AdditionalIPs, ipPort)
+	}
+
+	config.ListenAddresses = getCommaSeparated(v, StakingListenAddressesKey)
+	for _, address := range config.ListenAddresses {
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return node.IPConfig{}, fmt.Errorf("invalid %s %q: %w", StakingListenAddressesKey, address, err)
+		}
+	}
+	return config, nil
+}
+
+func getPublicIPConfig(v *viper.Viper) (node.IPConfig, error) {
 	// If both deprecated and current flag are given,
 	// override deprecated flag value with new flag value.
 	ipResolutionService := v.GetString(DynamicPublicIPResolverKey)
@@ -632,11 +1274,12 @@ func getIPConfig(v *viper.Viper) (node.IPConfig, error) {
 		return node.IPConfig{}, fmt.Errorf("public IP / IP resolution service not given and failed to resolve IP with NAT: %w", err)
 	}
 	return node.IPConfig{
-		Nat:                   nat,
-		AttemptedNATTraversal: true,
-		IPPort:                ips.NewDynamicIPPort(ip, stakingPort),
-		IPUpdater:             dynamicip.NewNoUpdater(),
-		IPResolutionFreq:      ipResolutionFreq,
+		Nat:                         nat,
+		AttemptedNATTraversal:       true,
+		NATReachabilityCheckEnabled: v.GetBool(NATReachabilityCheckEnabledKey),
+		IPPort:                      ips.NewDynamicIPPort(ip, stakingPort),
+		IPUpdater:                   dynamicip.NewNoUpdater(),
+		IPResolutionFreq:            ipResolutionFreq,
 	}, nil
 }
 
@@ -653,6 +1296,76 @@ func getProfilerConfig(v *viper.Viper) (profiler.Config, error) {
 	return config, nil
 }
 
+func getTraceConfig(v *viper.Viper) (trace.Config, error) {
+	enabled := v.GetBool(TracingEnabledKey)
+	if !enabled {
+		return trace.Config{}, nil
+	}
+
+	exporterType, err := trace.ExporterTypeFromString(v.GetString(TracingExporterTypeKey))
+	if err != nil {
+		return trace.Config{}, fmt.Errorf("couldn't parse %q: %w", TracingExporterTypeKey, err)
+	}
+	sampleRate := v.GetFloat64(TracingSampleRateKey)
+	if sampleRate < 0 || sampleRate > 1 {
+		return trace.Config{}, fmt.Errorf("%q must be in [0, 1]", TracingSampleRateKey)
+	}
+	return trace.Config{
+		ExporterConfig: trace.ExporterConfig{
+			Type:     exporterType,
+			Endpoint: v.GetString(TracingEndpointKey),
+			Headers:  v.GetStringMapString(TracingHeadersKey),
+			Insecure: v.GetBool(TracingInsecureKey),
+		},
+		Enabled:         true,
+		TraceSampleRate: sampleRate,
+	}, nil
+}
+
+func getNotifierConfig(v *viper.Viper) (notifier.Config, error) {
+	config := notifier.Config{
+		WebhookURL:       v.GetString(NotifierWebhookURLKey),
+		SocketPath:       v.GetString(NotifierSocketPathKey),
+		CheckFrequency:   v.GetDuration(NotifierCheckFrequencyKey),
+		BehindThreshold:  v.GetUint64(NotifierBehindThresholdKey),
+		BenchedThreshold: v.GetFloat64(NotifierBenchedThresholdKey),
+		BenchedWindow:    v.GetDuration(NotifierBenchedWindowKey),
+	}
+	if !config.Enabled() {
+		return config, nil
+	}
+	if config.WebhookURL != "" {
+		if _, err := url.ParseRequestURI(config.WebhookURL); err != nil {
+			return notifier.Config{}, fmt.Errorf("couldn't parse %q: %w", NotifierWebhookURLKey, err)
+		}
+	}
+	switch {
+	case config.CheckFrequency <= 0:
+		return notifier.Config{}, fmt.Errorf("%q must be positive", NotifierCheckFrequencyKey)
+	case config.BehindThreshold == 0:
+		return notifier.Config{}, fmt.Errorf("%q must be positive", NotifierBehindThresholdKey)
+	case config.BenchedThreshold <= 0 || config.BenchedThreshold > 1:
+		return notifier.Config{}, fmt.Errorf("%q must be in (0, 1]", NotifierBenchedThresholdKey)
+	case config.BenchedWindow <= 0:
+		return notifier.Config{}, fmt.Errorf("%q must be positive", NotifierBenchedWindowKey)
+	}
+	return config, nil
+}
+
+func getPlatformPruningConfig(v *viper.Viper) (platformconfig.PruningConfig, error) {
+	config := platformconfig.PruningConfig{
+		RewardUTXOs:    v.GetBool(PlatformPruneRewardUTXOsKey),
+		Txs:            v.GetBool(PlatformPruneTxsKey),
+		RetainedBlocks: v.GetUint64(PlatformPruningRetainedBlocksKey),
+		RetainedAge:    v.GetDuration(PlatformPruningRetainedAgeKey),
+		DryRun:         v.GetBool(PlatformPruningDryRunKey),
+	}
+	if config.RetainedAge < 0 {
+		return platformconfig.PruningConfig{}, fmt.Errorf("%q must be non-negative", PlatformPruningRetainedAgeKey)
+	}
+	return config, nil
+}
+
 func getStakingTLSCertFromFlag(v *viper.Viper) (tls.Certificate, error) {
 	stakingKeyRawContent := v.GetString(StakingTLSKeyContentKey)
 	stakingKeyContent, err := base64.StdEncoding.DecodeString(stakingKeyRawContent)
@@ -782,13 +1495,35 @@ func getStakingSigner(v *viper.Viper) (*bls.SecretKey, error) {
 	return key, nil
 }
 
+func getStakingRemoteSigner(v *viper.Viper) (*remote.Client, error) {
+	creds := insecure.NewCredentials()
+	if v.IsSet(StakingRemoteSignerCAFileKey) {
+		var err error
+		creds, err = credentials.NewClientTLSFromFile(GetExpandedArg(v, StakingRemoteSignerCAFileKey), "")
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load remote signer CA: %w", err)
+		}
+	}
+
+	// The connection is kept open for the lifetime of the node.
+	conn, err := grpc.Dial(v.GetString(StakingRemoteSignerAddressKey), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't dial remote signer: %w", err)
+	}
+	return remote.NewClient(
+		signerpb.NewSignerClient(conn),
+		v.GetDuration(StakingRemoteSignerTimeoutKey),
+	), nil
+}
+
 func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, error) {
 	config := node.StakingConfig{
-		EnableStaking:         v.GetBool(StakingEnabledKey),
-		DisabledStakingWeight: v.GetUint64(StakingDisabledWeightKey),
-		StakingKeyPath:        GetExpandedArg(v, StakingTLSKeyPathKey),
-		StakingCertPath:       GetExpandedArg(v, StakingCertPathKey),
-		StakingSignerPath:     GetExpandedArg(v, StakingSignerKeyPathKey),
+		EnableStaking:              v.GetBool(StakingEnabledKey),
+		DisabledStakingWeight:      v.GetUint64(StakingDisabledWeightKey),
+		StakingKeyPath:             GetExpandedArg(v, StakingTLSKeyPathKey),
+		StakingCertPath:            GetExpandedArg(v, StakingCertPathKey),
+		StakingSignerPath:          GetExpandedArg(v, StakingSignerKeyPathKey),
+		StakingRemoteSignerAddress: v.GetString(StakingRemoteSignerAddressKey),
 	}
 	if !config.EnableStaking && config.DisabledStakingWeight == 0 {
 		return node.StakingConfig{}, errInvalidStakerWeights
@@ -798,14 +1533,31 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 		return node.StakingConfig{}, errStakingDisableOnPublicNetwork
 	}
 
-	var err error
-	config.StakingTLSCert, err = getStakingTLSCert(v)
-	if err != nil {
-		return node.StakingConfig{}, err
-	}
-	config.StakingSigningKey, err = getStakingSigner(v)
-	if err != nil {
-		return node.StakingConfig{}, err
+	if config.StakingRemoteSignerAddress != "" {
+		remoteSigner, err := getStakingRemoteSigner(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		stakingTLSCert, err := remoteSigner.TLSCertificate()
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		config.StakingTLSCert = *stakingTLSCert
+		config.StakingSigner, err = remoteSigner.BLSSigner()
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+	} else {
+		var err error
+		config.StakingTLSCert, err = getStakingTLSCert(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		stakingSigningKey, err := getStakingSigner(v)
+		if err != nil {
+			return node.StakingConfig{}, err
+		}
+		config.StakingSigner = bls.NewSigner(stakingSigningKey)
 	}
 	if networkID != constants.MainnetID {
 		config.UptimeRequirement = v.GetFloat64(UptimeRequirementKey)
@@ -840,9 +1592,42 @@ func getStakingConfig(v *viper.Viper, networkID uint32) (node.StakingConfig, err
 	} else {
 		config.StakingConfig = genesis.GetStakingConfig(networkID)
 	}
+
+	var err error
+	config.NetworkParams, err = getNetworkParams(v)
+	if err != nil {
+		return node.StakingConfig{}, err
+	}
 	return config, nil
 }
 
+func getNetworkParams(v *viper.Viper) (map[uint32]platformconfig.NetworkParams, error) {
+	var paramsBytes []byte
+	switch {
+	case v.IsSet(NetworkParamsContentKey):
+		var err error
+		paramsContent := v.GetString(NetworkParamsContentKey)
+		paramsBytes, err = base64.StdEncoding.DecodeString(paramsContent)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode base64 content: %w", err)
+		}
+	case v.IsSet(NetworkParamsFileKey):
+		var err error
+		paramsBytes, err = os.ReadFile(GetExpandedArg(v, NetworkParamsFileKey))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, nil
+	}
+
+	params, err := platformconfig.ParseNetworkParams(paramsBytes)
+	if err != nil {
+		return nil, fmt.Errorf("problem parsing network params: %w", err)
+	}
+	return params, nil
+}
+
 func getTxFeeConfig(v *viper.Viper, networkID uint32) genesis.TxFeeConfig {
 	if networkID != constants.MainnetID {
 		return genesis.TxFeeConfig{
@@ -1096,6 +1881,9 @@ func getSubnetConfigsFromFlags(v *viper.Viper, subnetIDs []ids.ID) (map[ids.ID]c
 			if err := subnetConfig.ConsensusParameters.Valid(); err != nil {
 				return nil, err
 			}
+			if err := subnetConfig.BenchlistParameters.Valid(); err != nil {
+				return nil, fmt.Errorf("invalid benchlist parameters for subnet %s: %w", subnetID, err)
+			}
 			res[subnetID] = subnetConfig
 		}
 	}
@@ -1156,6 +1944,9 @@ func readSubnetConfigs(subnetConfigPath string, subnetIDs []ids.ID, defaultSubne
 		if err := configData.ConsensusParameters.Valid(); err != nil {
 			return nil, err
 		}
+		if err := configData.BenchlistParameters.Valid(); err != nil {
+			return nil, fmt.Errorf("invalid benchlist parameters for subnet %s: %w", subnetID, err)
+		}
 		subnetConfigs[subnetID] = configData
 	}
 
@@ -1163,10 +1954,12 @@ func readSubnetConfigs(subnetConfigPath string, subnetIDs []ids.ID, defaultSubne
 }
 
 func defaultSubnetConfig(v *viper.Viper) chains.SubnetConfig {
+	consensusParams := getConsensusConfig(v)
 	return chains.SubnetConfig{
-		ConsensusParameters: getConsensusConfig(v),
+		ConsensusParameters: consensusParams,
 		ValidatorOnly:       false,
 		GossipConfig:        getGossipConfig(v),
+		BenchlistParameters: getBenchlistParameters(v, consensusParams.Alpha, consensusParams.K),
 	}
 }
 
@@ -1190,14 +1983,24 @@ func getCPUTargeterConfig(v *viper.Viper) (tracker.TargeterConfig, error) {
 	}
 }
 
-func getDiskSpaceConfig(v *viper.Viper) (requiredAvailableDiskSpace uint64, warningThresholdAvailableDiskSpace uint64, err error) {
-	requiredAvailableDiskSpace = v.GetUint64(SystemTrackerRequiredAvailableDiskSpaceKey)
-	warningThresholdAvailableDiskSpace = v.GetUint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey)
+func getDiskSpaceConfig(v *viper.Viper) (diskwatchdog.Config, error) {
+	config := diskwatchdog.Config{
+		IndexingPauseThreshold:             v.GetUint64(SystemTrackerIndexingPauseAvailableDiskSpaceKey),
+		APITxsRejectThreshold:              v.GetUint64(SystemTrackerAPITxsRejectAvailableDiskSpaceKey),
+		RequiredAvailableDiskSpace:         v.GetUint64(SystemTrackerRequiredAvailableDiskSpaceKey),
+		WarningThresholdAvailableDiskSpace: v.GetUint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey),
+	}
 	switch {
-	case warningThresholdAvailableDiskSpace < requiredAvailableDiskSpace:
-		return 0, 0, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerWarningThresholdAvailableDiskSpaceKey, warningThresholdAvailableDiskSpace, SystemTrackerRequiredAvailableDiskSpaceKey, requiredAvailableDiskSpace)
+	case config.WarningThresholdAvailableDiskSpace < config.RequiredAvailableDiskSpace:
+		return diskwatchdog.Config{}, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerWarningThresholdAvailableDiskSpaceKey, config.WarningThresholdAvailableDiskSpace, SystemTrackerRequiredAvailableDiskSpaceKey, config.RequiredAvailableDiskSpace)
+	case config.APITxsRejectThreshold != 0 && config.APITxsRejectThreshold < config.RequiredAvailableDiskSpace:
+		return diskwatchdog.Config{}, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerAPITxsRejectAvailableDiskSpaceKey, config.APITxsRejectThreshold, SystemTrackerRequiredAvailableDiskSpaceKey, config.RequiredAvailableDiskSpace)
+	case config.IndexingPauseThreshold != 0 && config.IndexingPauseThreshold < config.RequiredAvailableDiskSpace:
+		return diskwatchdog.Config{}, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerIndexingPauseAvailableDiskSpaceKey, config.IndexingPauseThreshold, SystemTrackerRequiredAvailableDiskSpaceKey, config.RequiredAvailableDiskSpace)
+	case config.IndexingPauseThreshold != 0 && config.IndexingPauseThreshold < config.APITxsRejectThreshold:
+		return diskwatchdog.Config{}, fmt.Errorf("%q (%d) < %q (%d)", SystemTrackerIndexingPauseAvailableDiskSpaceKey, config.IndexingPauseThreshold, SystemTrackerAPITxsRejectAvailableDiskSpaceKey, config.APITxsRejectThreshold)
 	default:
-		return requiredAvailableDiskSpace, warningThresholdAvailableDiskSpace, nil
+		return config, nil
 	}
 }
 
@@ -1226,6 +2029,11 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 
 	// Plugin directory defaults to [buildDir]/[pluginsDirName]
 	nodeConfig.PluginDir = filepath.Join(buildDir, pluginsDirName)
+	nodeConfig.CorethPluginEnabled = v.GetBool(CorethPluginEnabledKey)
+	nodeConfig.PluginMaxRestarts = v.GetInt(PluginMaxRestartsKey)
+	if nodeConfig.PluginMaxRestarts < 0 {
+		return node.Config{}, fmt.Errorf("%q must be >= 0", PluginMaxRestartsKey)
+	}
 
 	// Consensus Parameters
 	nodeConfig.ConsensusParams = getConsensusConfig(v)
@@ -1242,8 +2050,27 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if nodeConfig.ConsensusGossipFrequency < 0 {
 		return node.Config{}, fmt.Errorf("%s must be >= 0", ConsensusGossipFrequencyKey)
 	}
+	nodeConfig.GossipedContainerTTL = v.GetDuration(ConsensusGossipedContainerTTLKey)
+	if nodeConfig.GossipedContainerTTL < 0 {
+		return node.Config{}, fmt.Errorf("%s must be >= 0", ConsensusGossipedContainerTTLKey)
+	}
 
 	var err error
+	// Inbound message queues
+	nodeConfig.MessageQueueConfig, err = getMessageQueueConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.ChainMessageQueueConfigs, err = getChainMessageQueueConfigs(v, nodeConfig.MessageQueueConfig)
+	if err != nil {
+		return node.Config{}, err
+	}
+	nodeConfig.MessageCaptureConfig = router.CaptureConfig{
+		Size:     int(v.GetUint(ConsensusMessageCaptureSizeKey)),
+		Payloads: v.GetBool(ConsensusMessageCapturePayloadsKey),
+	}
+	nodeConfig.MaxOutstandingRequestsPerPeer = int(v.GetUint(ConsensusMaxOutstandingRequestsPerPeerKey))
+
 	// Logging
 	nodeConfig.LoggingConfig, err = getLoggingConfig(v)
 	if err != nil {
@@ -1291,6 +2118,10 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	if nodeConfig.HealthCheckFreq < 0 {
 		return node.Config{}, fmt.Errorf("%s must be positive", HealthCheckFreqKey)
 	}
+	nodeConfig.HealthChecksConfig, err = getHealthChecksConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
 	// Halflife of continuous averager used in health checks
 	healthCheckAveragerHalflife := v.GetDuration(HealthCheckAveragerHalflifeKey)
 	if healthCheckAveragerHalflife <= 0 {
@@ -1307,17 +2138,28 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	// Metrics
 	nodeConfig.MeterVMEnabled = v.GetBool(MeterVMsEnabledKey)
 
+	// ProposerVM
+	nodeConfig.ProposerVMHeightIndexRepairThrottle = v.GetUint64(ProposerVMHeightIndexRepairThrottleKey)
+
 	// Adaptive Timeout Config
 	nodeConfig.AdaptiveTimeoutConfig, err = getAdaptiveTimeoutConfig(v)
 	if err != nil {
 		return node.Config{}, err
 	}
+	nodeConfig.ChainAdaptiveTimeoutConfigs, err = getChainAdaptiveTimeoutConfigs(v, nodeConfig.AdaptiveTimeoutConfig)
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	// Network Config
 	nodeConfig.NetworkConfig, err = getNetworkConfig(v, healthCheckAveragerHalflife)
 	if err != nil {
 		return node.Config{}, err
 	}
+	nodeConfig.NetworkConfig.LegacyMessagesDisabled, err = getLegacyMessagesDisabled(v, nodeConfig.NetworkID, time.Now())
+	if err != nil {
+		return node.Config{}, err
+	}
 
 	nodeConfig.GossipConfig = getGossipConfig(v)
 
@@ -1370,6 +2212,22 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 		return node.Config{}, err
 	}
 
+	// Tracing
+	nodeConfig.TraceConfig, err = getTraceConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.NotifierConfig, err = getNotifierConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
+	nodeConfig.PlatformPruningConfig, err = getPlatformPruningConfig(v)
+	if err != nil {
+		return node.Config{}, err
+	}
+
 	// VM Aliases
 	nodeConfig.VMManager, err = getVMManager(v)
 	if err != nil {
@@ -1381,7 +2239,7 @@ func GetNodeConfig(v *viper.Viper, buildDir string) (node.Config, error) {
 	nodeConfig.SystemTrackerCPUHalflife = v.GetDuration(SystemTrackerCPUHalflifeKey)
 	nodeConfig.SystemTrackerDiskHalflife = v.GetDuration(SystemTrackerDiskHalflifeKey)
 
-	nodeConfig.RequiredAvailableDiskSpace, nodeConfig.WarningThresholdAvailableDiskSpace, err = getDiskSpaceConfig(v)
+	nodeConfig.DiskWatchdogConfig, err = getDiskSpaceConfig(v)
 	if err != nil {
 		return node.Config{}, err
 	}
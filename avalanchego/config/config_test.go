@@ -4,6 +4,7 @@
 package config
 
 import (
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -11,14 +12,24 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
+	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/timer"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/version"
 )
 
 func TestGetChainConfigsFromFiles(t *testing.T) {
@@ -459,6 +470,40 @@ func TestGetSubnetConfigsFromFile(t *testing.T) {
 			},
 			errMessage: "",
 		},
+		"stake weighted app gossip": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"appGossipStakeWeighted": true}`,
+			testF: func(require *require.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+				require.True(config.AppGossipStakeWeighted)
+				require.Equal(uint(10), config.AppGossipValidatorSize)
+			},
+			errMessage: "",
+		},
+		"benchlist parameters": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"benchlistParameters":{"threshold": 50, "maxPortion": 0}}`,
+			testF: func(require *require.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				id, _ := ids.FromString("2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i")
+				config, ok := given[id]
+				require.True(ok)
+				require.Equal(50, config.BenchlistParameters.Threshold)
+				require.Zero(config.BenchlistParameters.MaxPortion)
+				// must still respect defaults
+				require.Equal(15*time.Minute, config.BenchlistParameters.Duration)
+			},
+			errMessage: "",
+		},
+		"invalid benchlist parameters": {
+			fileName:  "2Ctt6eGAeo4MLqTmGa7AdRecuVMPGWEX9wSsCLBYrLhX4a394i.json",
+			givenJSON: `{"benchlistParameters":{"maxPortion": 1}}`,
+			testF: func(require *require.Assertions, given map[ids.ID]chains.SubnetConfig) {
+				require.Nil(given)
+			},
+			errMessage: "invalid benchlist parameters",
+		},
 	}
 
 	for name, test := range tests {
@@ -592,6 +637,376 @@ func setupFile(t *testing.T, path string, fileName string, value string) {
 	require.NoError(t, os.WriteFile(filePath, []byte(value), 0o600))
 }
 
+func TestGetChainAdaptiveTimeoutConfigs(t *testing.T) {
+	defaultConfig := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     5 * time.Second,
+		MinimumTimeout:     2 * time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 2,
+		TimeoutHalflife:    5 * time.Minute,
+	}
+
+	tests := map[string]struct {
+		minimumTimeouts string
+		maximumTimeouts string
+		errMessage      string
+		expected        map[string]timer.AdaptiveTimeoutConfig
+	}{
+		"no overrides": {
+			expected: map[string]timer.AdaptiveTimeoutConfig{},
+		},
+		"minimum and maximum overrides": {
+			minimumTimeouts: `{"C":"1s","P":"6s"}`,
+			maximumTimeouts: `{"C":"3s"}`,
+			expected: map[string]timer.AdaptiveTimeoutConfig{
+				"C": {
+					InitialTimeout:     3 * time.Second,
+					MinimumTimeout:     time.Second,
+					MaximumTimeout:     3 * time.Second,
+					TimeoutCoefficient: 2,
+					TimeoutHalflife:    5 * time.Minute,
+				},
+				"P": {
+					InitialTimeout:     6 * time.Second,
+					MinimumTimeout:     6 * time.Second,
+					MaximumTimeout:     10 * time.Second,
+					TimeoutCoefficient: 2,
+					TimeoutHalflife:    5 * time.Minute,
+				},
+			},
+		},
+		"invalid duration": {
+			minimumTimeouts: `{"C":"one second"}`,
+			errMessage:      "couldn't parse",
+		},
+		"minimum greater than maximum": {
+			minimumTimeouts: `{"C":"20s"}`,
+			errMessage:      "maximum timeout must be >= minimum timeout",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(NetworkChainMinimumTimeoutKey, test.minimumTimeouts)
+			v.Set(NetworkChainMaximumTimeoutKey, test.maximumTimeouts)
+
+			configs, err := getChainAdaptiveTimeoutConfigs(v, defaultConfig)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, configs)
+		})
+	}
+}
+
+func TestGetChainMessageQueueConfigs(t *testing.T) {
+	defaultConfig := handler.MessageQueueConfig{
+		MaxSize:    100,
+		DropPolicy: handler.DropPolicyDrop,
+	}
+
+	tests := map[string]struct {
+		chainConfigs string
+		errMessage   string
+		expected     map[string]handler.MessageQueueConfig
+	}{
+		"no overrides": {
+			expected: map[string]handler.MessageQueueConfig{},
+		},
+		"partial overrides": {
+			chainConfigs: `{"C":{"maxSize":10,"dropPolicy":"deprioritize"},"P":{"dropPolicy":"deprioritize"}}`,
+			expected: map[string]handler.MessageQueueConfig{
+				"C": {
+					MaxSize:    10,
+					DropPolicy: handler.DropPolicyDeprioritize,
+				},
+				"P": {
+					MaxSize:    100,
+					DropPolicy: handler.DropPolicyDeprioritize,
+				},
+			},
+		},
+		"unknown drop policy": {
+			chainConfigs: `{"C":{"dropPolicy":"ignore"}}`,
+			errMessage:   "unknown drop policy",
+		},
+		"negative max size": {
+			chainConfigs: `{"C":{"maxSize":-1}}`,
+			errMessage:   "maxSize must be >= 0",
+		},
+		"cpu soft limit": {
+			chainConfigs: `{"X":{"cpuSoftLimit":1.5}}`,
+			expected: map[string]handler.MessageQueueConfig{
+				"X": {
+					MaxSize:      100,
+					DropPolicy:   handler.DropPolicyDrop,
+					CPUSoftLimit: 1.5,
+				},
+			},
+		},
+		"negative cpu soft limit": {
+			chainConfigs: `{"C":{"cpuSoftLimit":-1}}`,
+			errMessage:   "cpuSoftLimit must be >= 0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(ConsensusChainQueueConfigKey, test.chainConfigs)
+
+			configs, err := getChainMessageQueueConfigs(v, defaultConfig)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, configs)
+		})
+	}
+}
+
+func TestGetArchiveProxyConfigs(t *testing.T) {
+	tests := map[string]struct {
+		configs    string
+		errMessage string
+		expected   map[string]server.ArchiveProxyConfig
+	}{
+		"no configs": {
+			expected: map[string]server.ArchiveProxyConfig{},
+		},
+		"valid": {
+			configs: `{"C":{"url":"http://archive:9650/ext/bc/C/rpc","methods":["debug"],"getLogsMaxBlockRange":2048}}`,
+			expected: map[string]server.ArchiveProxyConfig{
+				"C": {
+					URL:                  "http://archive:9650/ext/bc/C/rpc",
+					Methods:              []string{"debug"},
+					GetLogsMaxBlockRange: 2048,
+				},
+			},
+		},
+		"invalid url": {
+			configs:    `{"C":{"url":"archive","methods":["debug"]}}`,
+			errMessage: "archive URL must be an absolute http or https URL",
+		},
+		"nothing to forward": {
+			configs:    `{"C":{"url":"http://archive:9650/ext/bc/C/rpc"}}`,
+			errMessage: "no methods to forward",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(HTTPArchiveProxyConfigKey, test.configs)
+
+			configs, err := getArchiveProxyConfigs(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, configs)
+		})
+	}
+}
+
+func TestGetDiskSpaceConfig(t *testing.T) {
+	tests := map[string]struct {
+		indexingPause uint64
+		apiTxsReject  uint64
+		errMessage    string
+	}{
+		"actions disabled": {},
+		"progressive thresholds": {
+			indexingPause: 4 * units.GiB,
+			apiTxsReject:  2 * units.GiB,
+		},
+		"reject txs below halting": {
+			apiTxsReject: units.MiB,
+			errMessage:   SystemTrackerRequiredAvailableDiskSpaceKey,
+		},
+		"pause indexing after rejecting txs": {
+			indexingPause: units.GiB,
+			apiTxsReject:  2 * units.GiB,
+			errMessage:    SystemTrackerAPITxsRejectAvailableDiskSpaceKey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(SystemTrackerIndexingPauseAvailableDiskSpaceKey, test.indexingPause)
+			v.Set(SystemTrackerAPITxsRejectAvailableDiskSpaceKey, test.apiTxsReject)
+
+			config, err := getDiskSpaceConfig(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.indexingPause, config.IndexingPauseThreshold)
+			require.Equal(test.apiTxsReject, config.APITxsRejectThreshold)
+			require.Equal(uint64(units.GiB/2), config.RequiredAvailableDiskSpace)
+		})
+	}
+}
+
+func TestGetPeerCertPolicy(t *testing.T) {
+	tests := map[string]struct {
+		algorithms         string
+		maxValidity        time.Duration
+		expectedAlgorithms []x509.SignatureAlgorithm
+		errMessage         string
+	}{
+		"permissive": {},
+		"restricted algorithms": {
+			algorithms:         "SHA256-RSA, ecdsa-sha256",
+			maxValidity:        24 * time.Hour,
+			expectedAlgorithms: []x509.SignatureAlgorithm{x509.SHA256WithRSA, x509.ECDSAWithSHA256},
+		},
+		"unknown algorithm": {
+			algorithms: "SHA256-RSA,SHA3-RSA",
+			errMessage: NetworkTLSAllowedSignatureAlgorithmsKey,
+		},
+		"negative validity": {
+			maxValidity: -time.Hour,
+			errMessage:  NetworkTLSMaxCertValidityKey,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(NetworkTLSMinRSAKeySizeKey, 2048)
+			v.Set(NetworkTLSAllowedSignatureAlgorithmsKey, test.algorithms)
+			v.Set(NetworkTLSMaxCertValidityKey, test.maxValidity)
+
+			policy, err := getPeerCertPolicy(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(2048, policy.MinRSAKeySize)
+			require.Equal(test.expectedAlgorithms, policy.AllowedSignatureAlgorithms)
+			require.Equal(test.maxValidity, policy.MaxValidity)
+		})
+	}
+}
+
+func TestGetHealthChecksConfig(t *testing.T) {
+	tests := map[string]struct {
+		disabled   string
+		warning    string
+		readiness  string
+		liveness   string
+		errMessage string
+		expected   health.Config
+	}{
+		"no checks": {
+			expected: health.Config{},
+		},
+		"all check sets": {
+			disabled:  "network, diskspace",
+			warning:   "router",
+			readiness: "router",
+			liveness:  "database,router",
+			expected: health.Config{
+				DisabledChecks:  []string{"network", "diskspace"},
+				WarningChecks:   []string{"router"},
+				ReadinessChecks: []string{"router"},
+				LivenessChecks:  []string{"database", "router"},
+			},
+		},
+		"disabled and warning": {
+			disabled:   "network",
+			warning:    "router,network",
+			errMessage: "can't be both",
+		},
+		"disabled and liveness": {
+			disabled:   "database",
+			liveness:   "database",
+			errMessage: "can't be both",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(HealthCheckDisabledKey, test.disabled)
+			v.Set(HealthCheckWarningKey, test.warning)
+			v.Set(HealthCheckReadinessKey, test.readiness)
+			v.Set(HealthCheckLivenessKey, test.liveness)
+
+			config, err := getHealthChecksConfig(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, config)
+		})
+	}
+}
+
+func TestGetAPIAuthPublicScopes(t *testing.T) {
+	tests := map[string]struct {
+		scopes      string
+		expectedErr error
+		expected    []auth.Scope
+	}{
+		"no scopes": {},
+		"read-only and indexing": {
+			scopes:   "read-only,indexing",
+			expected: []auth.Scope{auth.ScopeReadOnly, auth.ScopeIndexing},
+		},
+		"admin": {
+			scopes:      "read-only,admin",
+			expectedErr: errPublicAdminScope,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(APIAuthRequiredKey, true)
+			v.Set(APIAuthPasswordKey, "ynwtmE2WM7gUr6ae")
+			v.Set(APIAuthPublicScopesKey, test.scopes)
+
+			config, err := getAPIAuthConfig(v)
+			require.ErrorIs(err, test.expectedErr)
+			if test.expectedErr != nil {
+				return
+			}
+			require.Equal(test.expected, config.APIAuthPublicScopes)
+		})
+	}
+}
+
 func setupViperFlags() *viper.Viper {
 	v := viper.New()
 	fs := BuildFlagSet()
@@ -621,3 +1036,272 @@ func setupViper(configFilePath string) *viper.Viper {
 	}
 	return v
 }
+
+func TestGetTrustedCheckpoints(t *testing.T) {
+	blkID, err := ids.FromString("2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm")
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		checkpoints string
+		errMessage  string
+		expected    map[string]genesis.Checkpoint
+	}{
+		"built-in": {
+			expected: map[string]genesis.Checkpoint{},
+		},
+		"valid": {
+			checkpoints: "C=2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm:1000,,P=2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm:5",
+			expected: map[string]genesis.Checkpoint{
+				"C": {ID: blkID, Height: 1000},
+				"P": {ID: blkID, Height: 5},
+			},
+		},
+		"missing height": {
+			checkpoints: "C=2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm",
+			errMessage:  "expected <chain>=<block ID>:<height>",
+		},
+		"invalid block ID": {
+			checkpoints: "C=notAnID:1000",
+			errMessage:  "invalid block ID",
+		},
+		"invalid height": {
+			checkpoints: "C=2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm:-1",
+			errMessage:  "invalid height",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(BootstrapTrustedCheckpointsKey, test.checkpoints)
+
+			checkpoints, err := getTrustedCheckpoints(v, constants.FlareID)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, checkpoints)
+		})
+	}
+}
+
+func TestGetBootstrapBandwidthShares(t *testing.T) {
+	tests := map[string]struct {
+		shares     string
+		errMessage string
+		expected   map[string]float64
+	}{
+		"default": {
+			expected: map[string]float64{},
+		},
+		"valid": {
+			shares: "X=1,,C=2.5",
+			expected: map[string]float64{
+				"X": 1,
+				"C": 2.5,
+			},
+		},
+		"missing share": {
+			shares:     "C",
+			errMessage: "expected <chain>=<share>",
+		},
+		"invalid share": {
+			shares:     "C=many",
+			errMessage: "invalid share",
+		},
+		"zero share": {
+			shares:     "C=0",
+			errMessage: "must be positive",
+		},
+		"NaN share": {
+			shares:     "C=NaN",
+			errMessage: "must be positive",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(BootstrapBandwidthSharesKey, test.shares)
+
+			shares, err := getBootstrapBandwidthShares(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, shares)
+		})
+	}
+}
+
+func TestGetLegacyMessagesDisabled(t *testing.T) {
+	banffTime := version.GetBanffTime(constants.MainnetID)
+	tests := map[string]struct {
+		format     string
+		now        time.Time
+		errMessage string
+		expected   bool
+	}{
+		"auto before grace period ends": {
+			format:   legacyMessageFormatAuto,
+			now:      banffTime.Add(legacyMessageFormatGracePeriod - time.Second),
+			expected: false,
+		},
+		"auto after grace period ends": {
+			format:   legacyMessageFormatAuto,
+			now:      banffTime.Add(legacyMessageFormatGracePeriod),
+			expected: true,
+		},
+		"enabled": {
+			format:   legacyMessageFormatEnabled,
+			now:      banffTime.Add(legacyMessageFormatGracePeriod),
+			expected: false,
+		},
+		"disabled": {
+			format:   legacyMessageFormatDisabled,
+			now:      banffTime,
+			expected: true,
+		},
+		"invalid": {
+			format:     "sometimes",
+			errMessage: "must be one of",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(NetworkLegacyMessageFormatKey, test.format)
+
+			disabled, err := getLegacyMessagesDisabled(v, constants.MainnetID, test.now)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.Equal(test.expected, disabled)
+		})
+	}
+}
+
+func TestValidatorMode(t *testing.T) {
+	tests := map[string]struct {
+		settings   map[string]interface{}
+		errMessage string
+	}{
+		"defaults": {
+			settings: map[string]interface{}{},
+		},
+		"localhost": {
+			settings: map[string]interface{}{HTTPHostKey: "localhost"},
+		},
+		"public host": {
+			settings:   map[string]interface{}{HTTPHostKey: "0.0.0.0"},
+			errMessage: "must be a loopback address",
+		},
+		"admin API enabled": {
+			settings:   map[string]interface{}{AdminAPIEnabledKey: true},
+			errMessage: "can't be enabled",
+		},
+		"index explicitly disabled": {
+			settings: map[string]interface{}{IndexEnabledKey: false},
+		},
+		"archive proxy": {
+			settings:   map[string]interface{}{HTTPArchiveProxyConfigKey: `{"C":{"url":"http://archive:9650/ext/bc/C/rpc","methods":["debug"]}}`},
+			errMessage: "can't be set",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			v := setupViperFlags()
+			v.Set(ValidatorModeKey, true)
+			for key, value := range test.settings {
+				v.Set(key, value)
+			}
+
+			httpConfig, err := getHTTPConfig(v)
+			if len(test.errMessage) > 0 {
+				require.Error(err)
+				require.Contains(err.Error(), test.errMessage)
+				return
+			}
+			require.NoError(err)
+			require.False(httpConfig.AdminAPIEnabled)
+			require.False(httpConfig.KeystoreAPIEnabled)
+			require.False(httpConfig.IndexAPIEnabled)
+			require.False(httpConfig.TransferAPIEnabled)
+			require.False(httpConfig.IPCAPIEnabled)
+			require.False(httpConfig.ChainAPIsEnabled)
+			require.True(httpConfig.InfoAPIEnabled)
+			require.True(httpConfig.HealthAPIEnabled)
+			require.True(httpConfig.MetricsAPIEnabled)
+
+			networkConfig, err := getNetworkConfig(v, time.Second)
+			require.NoError(err)
+			require.Equal(validatorModeNonValidatorShedThreshold, networkConfig.PeerBudgetConfig.NonValidatorShedThreshold)
+		})
+	}
+}
+
+func TestBuildViperUnknownConfigKeys(t *testing.T) {
+	tests := map[string]struct {
+		args        []string
+		config      string
+		errContains string
+	}{
+		"known keys": {
+			config: `{"network-id": "local"}`,
+		},
+		"unknown key with suggestion": {
+			config:      `{"netwrok-id": "local"}`,
+			errContains: `"netwrok-id" (did you mean "network-id"?)`,
+		},
+		"unknown key without suggestion": {
+			config:      `{"not-a-real-flag-at-all": true}`,
+			errContains: `"not-a-real-flag-at-all"`,
+		},
+		"strict mode disabled": {
+			args:   []string{fmt.Sprintf("--%s=false", ConfigFileStrictKey)},
+			config: `{"netwrok-id": "local"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require := require.New(t)
+
+			configContent := base64.StdEncoding.EncodeToString([]byte(test.config))
+			args := append(test.args, fmt.Sprintf("--%s=%s", ConfigContentKey, configContent))
+			_, err := BuildViper(BuildFlagSet(), args)
+			if test.errContains == "" {
+				require.NoError(err)
+				return
+			}
+			require.ErrorIs(err, errUnknownConfigKeys)
+			require.Contains(err.Error(), test.errContains)
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(0, editDistance("network-id", "network-id"))
+	require.Equal(2, editDistance("netwrok-id", "network-id"))
+	require.Equal(3, editDistance("", "abc"))
+	require.Equal(3, editDistance("kitten", "sitting"))
+}
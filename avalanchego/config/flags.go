@@ -19,9 +19,14 @@ import (
 	"github.com/ava-labs/avalanchego/database/leveldb"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/trace"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ulimit"
 	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/proposervm/indexer"
 )
 
 const (
@@ -72,6 +77,7 @@ func init() {
 func addProcessFlags(fs *flag.FlagSet) {
 	// If true, print the version and quit.
 	fs.Bool(VersionKey, false, "If true, print version and quit")
+	fs.Bool(PrintConfigKey, false, "If true, print the effective config, with secrets redacted, and quit")
 
 	// Build directory
 	fs.String(BuildDirKey, defaultBuildDirs[0], "Path to the build directory")
@@ -90,12 +96,17 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(ConfigFileKey, "", fmt.Sprintf("Specifies a config file. Ignored if %s is specified", ConfigContentKey))
 	fs.String(ConfigContentKey, "", "Specifies base64 encoded config content")
 	fs.String(ConfigContentTypeKey, "json", "Specifies the format of the base64 encoded config content. Available values: 'json', 'yaml', 'toml'")
+	fs.Bool(ConfigFileStrictKey, true, "If true, unknown keys in the config file or config content cause an error rather than being ignored")
 
 	// Genesis
 	fs.String(GenesisConfigFileKey, "", fmt.Sprintf("Specifies a genesis config file (ignored when running standard networks or if %s is specified)",
 		GenesisConfigContentKey))
 	fs.String(GenesisConfigContentKey, "", "Specifies base64 encoded genesis content")
 
+	// Network parameters
+	fs.String(NetworkParamsFileKey, "", fmt.Sprintf("Specifies a JSON file that overrides the network specific P-chain parameters, keyed by network ID. Networks with built in parameters can't be overridden. Ignored if %s is specified", NetworkParamsContentKey))
+	fs.String(NetworkParamsContentKey, "", "Specifies base64 encoded network specific P-chain parameter overrides")
+
 	// Network ID
 	fs.String(NetworkNameKey, constants.MainnetName, "Network ID this node will connect to")
 
@@ -124,7 +135,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(LogRotaterMaxSizeKey, 8, "The maximum file size in megabytes of the log file before it gets rotated.")
 	fs.Uint(LogRotaterMaxFilesKey, 7, "The maximum number of old log files to retain. 0 means retain all old log files.")
 	fs.Uint(LogRotaterMaxAgeKey, 0, "The maximum number of days to retain old log files based on the timestamp encoded in their filename. 0 means retain all old log files.")
-	fs.Bool(LogRotaterCompressEnabledKey, false, "Enables the compression of rotated log files through gzip.")
+	fs.Bool(LogRotaterCompressEnabledKey, false, fmt.Sprintf("Enables the compression of rotated log files through the algorithm set by %s.", LogRotaterCompressionTypeKey))
+	fs.String(LogRotaterCompressionTypeKey, compression.TypeGzip.String(), fmt.Sprintf("Compression algorithm of rotated log files. Must be one of {%s, %s}", compression.TypeGzip, compression.TypeZstd))
+	fs.Duration(LogRotaterRotationIntervalKey, 0, "Rotate a log file once it has been written to for this long, even if it is smaller than the maximum size. 0 means log files are only rotated by size.")
+	fs.Uint(LogDirMaxSizeKey, 0, "The maximum size in megabytes of the log directory. The oldest rotated log files are removed to stay under it. 0 means no limit.")
 	fs.Bool(LogDisableDisplayPluginLogsKey, false, "Disables displaying plugin logs in stdout.")
 
 	// Peer List Gossip
@@ -141,13 +155,16 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(NetworkPeerListNonValidatorGossipSizeKey, 0, gossipHelpMsg)
 	fs.Uint(NetworkPeerListPeersGossipSizeKey, 10, gossipHelpMsg)
 	fs.Duration(NetworkPeerListGossipFreqKey, time.Minute, gossipHelpMsg)
+	fs.Float64(NetworkPeerListRecoveryStakeThresholdKey, .8, fmt.Sprintf("While this node is connected to less than this portion of the Primary Network's stake, it requests the IPs of the validators it isn't connected to from its validator peers every [%s] and dials them without backoff. Must be in [0,1]. 0 disables the recovery", NetworkPeerListGossipFreqKey))
 
 	// Public IP Resolution
 	fs.String(PublicIPKey, "", "Public IP of this node for P2P communication. If empty, try to discover with NAT. Ignored if dynamic-public-ip is non-empty")
 	fs.Duration(DynamicUpdateDurationKey, 5*time.Minute, "Dynamic IP and NAT traversal update duration")                                                        // Deprecated
 	fs.String(DynamicPublicIPResolverKey, "", "'ifconfigco' (alias 'ifconfig') or 'opendns' or 'ifconfigme'. By default does not do dynamic public IP updates") // Deprecated
 	fs.Duration(PublicIPResolutionFreqKey, 5*time.Minute, "Frequency at which this node resolves/updates its public IP and renew NAT mappings, if applicable")
+	fs.String(AdditionalPublicIPsKey, "", fmt.Sprintf("Comma separated list of other public IPs of this node for P2P communication, such as its IPv6 address, in order of preference. They are advertised to peers along with the public IP. Each is an IP, which uses %s, or an IP:port. At most %d may be given. Example: 2001:db8::1,[2001:db8::2]:9651", StakingPortKey, peer.MaxAdditionalIPs))
 	fs.String(PublicIPResolutionServiceKey, "", "Only acceptable values are 'ifconfigco', 'opendns' or 'ifconfigme'. When provided, the node will use that service to periodically resolve/update its public IP")
+	fs.Bool(NATReachabilityCheckEnabledKey, false, "If true, after each NAT mapping renewal the node dials its own public IP and staking port to verify it is reachable, and reports the result in the nat health check. Requires a router that supports hairpin NAT")
 
 	// Inbound Connection Throttling
 	fs.Duration(InboundConnUpgradeThrottlerCooldownKey, 10*time.Second, "Upgrade an inbound connection from a given IP at most once per this duration. If 0, don't rate-limit inbound connection upgrades")
@@ -159,6 +176,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkInitialTimeoutKey, 5*time.Second, "Initial timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMinimumTimeoutKey, 2*time.Second, "Minimum timeout value of the adaptive timeout manager")
 	fs.Duration(NetworkMaximumTimeoutKey, 10*time.Second, "Maximum timeout value of the adaptive timeout manager")
+	fs.String(NetworkChainMinimumTimeoutKey, "", fmt.Sprintf("JSON map of per chain overrides of %s. Keyed by chainID or chain alias, e.g. {\"C\":\"1s\",\"P\":\"3s\"}", NetworkMinimumTimeoutKey))
+	fs.String(NetworkChainMaximumTimeoutKey, "", fmt.Sprintf("JSON map of per chain overrides of %s. Keyed by chainID or chain alias, e.g. {\"C\":\"5s\",\"P\":\"20s\"}", NetworkMaximumTimeoutKey))
 	fs.Duration(NetworkMaximumInboundTimeoutKey, 10*time.Second, "Maximum timeout value of an inbound message. Defines duration within which an incoming message must be fulfilled. Incoming messages containing deadline higher than this value will be overridden with this value.")
 	fs.Duration(NetworkTimeoutHalflifeKey, 5*time.Minute, "Halflife of average network response time. Higher value --> network timeout is less volatile. Can't be 0")
 	fs.Float64(NetworkTimeoutCoefficientKey, 2, "Multiplied by average network response time to get the network timeout. Must be >= 1")
@@ -167,13 +186,28 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(NetworkPingFrequencyKey, constants.DefaultPingFrequency, "Frequency of pinging other peers")
 
 	fs.Bool(NetworkCompressionEnabledKey, true, "If true, compress certain outbound messages. This node will be able to parse compressed inbound messages regardless of this flag's value")
+	fs.String(NetworkCompressionTypeKey, compression.TypeGzip.String(), fmt.Sprintf("Compression algorithm to use for outbound messages when compression is enabled. Must be one of {%s, %s}. Peers that don't support the algorithm are sent %s compressed messages", compression.TypeGzip, compression.TypeZstd, compression.TypeGzip))
+	fs.String(NetworkLegacyMessageFormatKey, legacyMessageFormatAuto, fmt.Sprintf("Whether the legacy, pre-Banff, message format is supported. Must be one of {%s, %s, %s}. If %s, the legacy format is disabled once Banff has been active on the network for %s. When disabled, messages sent by peers in the legacy format are dropped", legacyMessageFormatAuto, legacyMessageFormatEnabled, legacyMessageFormatDisabled, legacyMessageFormatAuto, legacyMessageFormatGracePeriod))
 	fs.Duration(NetworkMaxClockDifferenceKey, time.Minute, "Max allowed clock difference value between this node and peers")
 	fs.Bool(NetworkAllowPrivateIPsKey, true, "Allows the node to initiate outbound connection attempts to peers with private IPs")
 	fs.Bool(NetworkRequireValidatorToConnectKey, false, "If true, this node will only maintain a connection with another node if this node is a validator, the other node is a validator, or the other node is a beacon")
+	fs.String(NetworkBlocklistNodeIDsKey, "", "Comma separated list of node IDs this node will never connect to or accept connections from. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	fs.String(NetworkBlocklistIPsKey, "", "Comma separated list of IPs this node will never connect to or accept connections from. Example: 127.0.0.1,::1")
+	fs.String(NetworkGaterAllowedCIDRsKey, "", "Comma separated list of CIDRs. If non-empty, inbound connections are only accepted from these CIDRs. Example: 10.0.0.0/8,192.168.0.0/16")
+	fs.String(NetworkGaterDeniedCIDRsKey, "", "Comma separated list of CIDRs that inbound connections are rejected from, before their TLS handshake")
+	fs.String(NetworkGaterDeniedDialCIDRsKey, "", "Comma separated list of CIDRs that this node will never attempt outbound connections to")
+	fs.Int(NetworkGaterMaxConnsPerIPKey, 0, "Maximum number of inbound connections accepted from a single IP. 0 means there is no maximum")
+	fs.Int(NetworkMaxPeerConnsKey, 0, fmt.Sprintf("Number of peer connections this node has sockets for. Non-validators are shed as it's approached, so that this node stays connected to the validators. If 0, half of --%s", FdLimitKey))
+	fs.Float64(NetworkNonValidatorShedThresholdKey, 0.9, fmt.Sprintf("Share of --%s above which connections from non-validators are refused and connected non-validators are disconnected. Must be in (0, 1]", NetworkMaxPeerConnsKey))
 	fs.Uint(NetworkPeerReadBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we read peer messages into (there is one buffer per peer)")
 	fs.Uint(NetworkPeerWriteBufferSizeKey, 8*units.KiB, "Size, in bytes, of the buffer that we write peer messages into (there is one buffer per peer)")
+	fs.Uint(NetworkPeerMaxQueuedBulkBytesKey, 16*units.MiB, "Maximum number of bytes of bulk messages, such as Ancestors, queued to be sent to a peer. Further bulk messages are dropped until the queue drains. 0 means there is no maximum")
+	fs.Bool(NetworkQUICEnabledKey, false, "Experimental. If true, peer connections are also accepted over QUIC on the UDP ports matching the staking ports, and peers that advertise QUIC support are dialed over it")
 
 	fs.String(NetworkTLSKeyLogFileKey, "", "TLS key log file path. Should only be specified for debugging")
+	fs.Uint(NetworkTLSMinRSAKeySizeKey, 0, "Minimum size, in bits, of the RSA key of a peer's staking certificate. Handshakes with peers using smaller keys fail. 0 means there is no minimum")
+	fs.String(NetworkTLSAllowedSignatureAlgorithmsKey, "", "Comma separated list of the signature algorithms a peer's staking certificate may be signed with. Handshakes with peers using other algorithms fail. If empty, every algorithm is allowed. Example: SHA256-RSA,ECDSA-SHA256")
+	fs.Duration(NetworkTLSMaxCertValidityKey, 0, "Maximum validity period of a peer's staking certificate. Handshakes with peers whose certificate is valid for longer, or ends before it starts, fail. 0 means there is no maximum")
 
 	// Benchlist
 	fs.Int(BenchlistFailThresholdKey, 10, "Number of consecutive failed queries before benchlisting a node")
@@ -182,7 +216,15 @@ func addNodeFlags(fs *flag.FlagSet) {
 
 	// Router
 	fs.Duration(ConsensusGossipFrequencyKey, 10*time.Second, "Frequency of gossiping accepted frontiers")
+	fs.Duration(ConsensusGossipedContainerTTLKey, time.Minute, "Duration for which a container received via gossip isn't gossiped again once it's accepted. If 0, every accepted container is gossiped")
 	fs.Duration(ConsensusShutdownTimeoutKey, 30*time.Second, "Timeout before killing an unresponsive chain")
+	fs.Uint(ConsensusQueueMaxSizeKey, 0, "Number of queued inbound messages per chain after which gossip messages are dropped or deprioritized. If 0, the queues are unbounded")
+	fs.String(ConsensusQueueDropPolicyKey, handler.DropPolicyDrop.String(), fmt.Sprintf("Policy applied to gossip messages received while a chain's inbound queue is full. Must be one of {%s, %s}. At most %s messages are deprioritized, further ones are dropped", handler.DropPolicyDrop, handler.DropPolicyDeprioritize, ConsensusQueueMaxSizeKey))
+	fs.Float64(ConsensusQueueCPUSoftLimitKey, 0, "CPU usage, in cores, attributed to a chain above which handling the chain's inbound messages is delayed. If 0, message handling is never delayed")
+	fs.Uint(ConsensusMessageCaptureSizeKey, 0, "Number of most recent inbound consensus messages to keep for debugging. They can be exported through the admin API. 0 disables the capture")
+	fs.Bool(ConsensusMessageCapturePayloadsKey, false, fmt.Sprintf("If true, the container and application bytes of the messages kept by %s are also kept", ConsensusMessageCaptureSizeKey))
+	fs.Uint(ConsensusMaxOutstandingRequestsPerPeerKey, 0, "Number of requests of a chain that may wait for a response from a single peer. Further requests to the peer fail immediately, so that they can be sent to other peers. If 0, the requests aren't limited")
+	fs.String(ConsensusChainQueueConfigKey, "", fmt.Sprintf("JSON map of per chain overrides of %s, %s and %s. Keyed by chainID or chain alias, e.g. {\"C\":{\"maxSize\":1024,\"dropPolicy\":\"deprioritize\",\"cpuSoftLimit\":2}}", ConsensusQueueMaxSizeKey, ConsensusQueueDropPolicyKey, ConsensusQueueCPUSoftLimitKey))
 	fs.Uint(ConsensusGossipAcceptedFrontierValidatorSizeKey, 0, "Number of validators to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipAcceptedFrontierNonValidatorSizeKey, 0, "Number of non-validators to gossip to when gossiping accepted frontier")
 	fs.Uint(ConsensusGossipAcceptedFrontierPeerSizeKey, 15, "Number of peers to gossip to when gossiping accepted frontier")
@@ -192,6 +234,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Uint(AppGossipValidatorSizeKey, 10, "Number of validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipNonValidatorSizeKey, 0, "Number of non-validators to gossip an AppGossip message to")
 	fs.Uint(AppGossipPeerSizeKey, 0, "Number of peers (which may be validators or non-validators) to gossip an AppGossip message to")
+	fs.Bool(AppGossipStakeWeightedKey, false, "If true, the validators that an AppGossip message is gossiped to are sampled with probability proportional to their stake")
 
 	// Inbound Throttling
 	fs.Uint64(InboundThrottlerAtLargeAllocSizeKey, 6*units.MiB, "Size, in bytes, of at-large byte allocation in inbound message throttler")
@@ -212,6 +255,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(HTTPHostKey, "127.0.0.1", "Address of the HTTP server")
 	fs.Uint(HTTPPortKey, DefaultHTTPPort, "Port of the HTTP server")
 	fs.Bool(HTTPSEnabledKey, false, "Upgrade the HTTP server to HTTPs")
+	fs.Bool(HTTPTCPEnabledKey, true, fmt.Sprintf("If false, the HTTP server doesn't listen on %s:%s. Requires %s to be set", HTTPHostKey, HTTPPortKey, HTTPSocketPathKey))
+	fs.String(HTTPSocketPathKey, "", "If non-empty, path of a Unix socket that the HTTP server also listens on. The socket is never upgraded to HTTPs")
+	fs.String(HTTPSocketPermsKey, "0660", fmt.Sprintf("Permissions, in octal, of the Unix socket at %s. Only the users with write permission may connect to the socket", HTTPSocketPathKey))
 	fs.String(HTTPSKeyFileKey, "", fmt.Sprintf("TLS private key file for the HTTPs server. Ignored if %s is specified", HTTPSKeyContentKey))
 	fs.String(HTTPSKeyContentKey, "", "Specifies base64 encoded TLS private key for the HTTPs server")
 	fs.String(HTTPSCertFileKey, "", fmt.Sprintf("TLS certificate file for the HTTPs server. Ignored if %s is specified", HTTPSCertContentKey))
@@ -219,23 +265,38 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.String(HTTPAllowedOrigins, "*", "Origins to allow on the HTTP port. Defaults to * which allows all origins. Example: https://*.avax.network https://*.avax-test.network")
 	fs.Duration(HTTPShutdownWaitKey, 0, "Duration to wait after receiving SIGTERM or SIGINT before initiating shutdown. The /health endpoint will return unhealthy during this duration")
 	fs.Duration(HTTPShutdownTimeoutKey, 10*time.Second, "Maximum duration to wait for existing connections to complete during node shutdown")
+	fs.Float64(HTTPRateLimitPerIPKey, 0, "Number of HTTP requests per second each client IP may make. If 0, requests are not limited per IP")
+	fs.Int(HTTPRateLimitPerIPBurstKey, 100, fmt.Sprintf("Maximum number of HTTP requests a client IP may make in a burst. Ignored if %s is 0", HTTPRateLimitPerIPKey))
+	fs.String(HTTPRateLimitMethodsKey, "", "Comma separated list of per client IP limits on JSON-RPC calls, formatted as <method or namespace>=<calls per second>:<burst>. Example: eth_getLogs=5:10,debug=1:2")
+	fs.Int64(HTTPRateLimitMaxBodySizeKey, units.MiB, fmt.Sprintf("Largest HTTP request body, in bytes, that is inspected for the JSON-RPC calls it makes when %s is set. Larger bodies are rejected so they can't bypass the limits", HTTPRateLimitMethodsKey))
+	fs.String(HTTPRateLimitTrustedProxiesKey, "", "Comma separated list of the CIDRs of proxies whose X-Forwarded-For header is trusted. Requests received from a trusted proxy are limited by the client IP it forwarded them for. Example: 10.0.0.0/8,127.0.0.1/32")
+	fs.String(HTTPArchiveProxyConfigKey, "", "JSON map of the JSON-RPC calls to a chain's RPC endpoint that are forwarded to an archive node. Keyed by chainID or chain alias, e.g. {\"C\":{\"url\":\"http://archive:9650/ext/bc/C/rpc\",\"methods\":[\"debug\"],\"getLogsMaxBlockRange\":2048}}")
 	fs.Bool(APIAuthRequiredKey, false, "Require authorization token to call HTTP APIs")
 	fs.String(APIAuthPasswordFileKey, "",
 		fmt.Sprintf("Password file used to initially create/validate API authorization tokens. Ignored if %s is specified. Leading and trailing whitespace is removed from the password. Can be changed via API call",
 			APIAuthPasswordKey))
 	fs.String(APIAuthPasswordKey, "", "Specifies password for API authorization tokens")
+	fs.String(APIAuthPublicScopesKey, "", "Comma separated list of API scopes that may be called without an authorization token. One of {indexing, read-only}. The read-only scope covers the info, health and metrics APIs and the chains' event and validator streams. Example: read-only")
 
 	// Enable/Disable APIs
 	fs.Bool(AdminAPIEnabledKey, false, "If true, this node exposes the Admin API")
 	fs.Bool(InfoAPIEnabledKey, true, "If true, this node exposes the Info API")
+	fs.String(InfoAPIPeerEnrichmentFileKey, "", "Path to a JSON file mapping CIDR ranges to labels, such as ASN or location, that info.peersDetailed attaches to peers in that range. Example: {\"1.2.3.0/24\": {\"asn\": \"AS64496\"}}")
 	fs.Bool(KeystoreAPIEnabledKey, true, "If true, this node exposes the Keystore API")
+	fs.String(SignerSocketPathKey, "", "If non-empty, path of a Unix socket that serves signatures made with the keys of keystore users. The socket is available even if the Keystore API is disabled")
 	fs.Bool(MetricsAPIEnabledKey, true, "If true, this node exposes the Metrics API")
 	fs.Bool(HealthAPIEnabledKey, true, "If true, this node exposes the Health API")
+	fs.Bool(TransferAPIEnabledKey, false, "If true, this node exposes the Transfer API, which moves funds held in the keystore between the chains of the primary network")
 	fs.Bool(IpcAPIEnabledKey, false, "If true, IPCs can be opened")
+	fs.Bool(ValidatorModeKey, false, fmt.Sprintf("If true, only the Health, Info and Metrics APIs are exposed, the chains' APIs aren't, %s must be a loopback address and fewer connections are kept for non-validators. The APIs disabled by this flag can't be explicitly enabled", HTTPHostKey))
 
 	// Health Checks
 	fs.Duration(HealthCheckFreqKey, 30*time.Second, "Time between health checks")
 	fs.Duration(HealthCheckAveragerHalflifeKey, 10*time.Second, "Halflife of averager when calculating a running average in a health check")
+	fs.String(HealthCheckDisabledKey, "", "Comma separated list of names of health checks that are never run or reported, e.g. network,diskspace")
+	fs.String(HealthCheckWarningKey, "", "Comma separated list of names of health checks whose failures are reported but don't cause the node to be unhealthy")
+	fs.String(HealthCheckReadinessKey, "", "Comma separated list of names of health checks that are also reported by /ext/health/readiness, e.g. network")
+	fs.String(HealthCheckLivenessKey, "", "Comma separated list of names of health checks that are also reported by /ext/health/liveness, e.g. database,diskspace")
 	// Network Layer Health
 	fs.Duration(NetworkHealthMaxTimeSinceMsgSentKey, time.Minute, "Network layer returns unhealthy if haven't sent a message for at least this much time")
 	fs.Duration(NetworkHealthMaxTimeSinceMsgReceivedKey, time.Minute, "Network layer returns unhealthy if haven't received a message for at least this much time")
@@ -249,6 +310,7 @@ func addNodeFlags(fs *flag.FlagSet) {
 
 	// Staking
 	fs.Uint(StakingPortKey, DefaultStakingPort, "Port of the consensus server")
+	fs.String(StakingListenAddressesKey, "", fmt.Sprintf("Comma separated list of host:port addresses that the consensus server listens on, such as the addresses of several interfaces or address families. If empty, listens on all interfaces at %s. Example: 0.0.0.0:9651,[::]:9651", StakingPortKey))
 	fs.Bool(StakingEnabledKey, true, "Enable staking. If enabled, Network TLS is required")
 	fs.Bool(StakingEphemeralCertEnabledKey, false, "If true, the node uses an ephemeral staking TLS key and certificate, and has an ephemeral node ID")
 	fs.String(StakingTLSKeyPathKey, defaultStakingTLSKeyPath, fmt.Sprintf("Path to the TLS private key for staking. Ignored if %s is specified", StakingTLSKeyContentKey))
@@ -258,6 +320,9 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(StakingEphemeralSignerEnabledKey, false, "If true, the node uses an ephemeral staking signer key")
 	fs.String(StakingSignerKeyPathKey, defaultStakingSignerKeyPath, fmt.Sprintf("Path to the signer private key for staking. Ignored if %s is specified", StakingSignerKeyContentKey))
 	fs.String(StakingSignerKeyContentKey, "", "Specifies base64 encoded signer private key for staking")
+	fs.String(StakingRemoteSignerAddressKey, "", "Address of a gRPC signer holding the staking TLS and signer keys. If specified, the staking key files and contents are ignored")
+	fs.String(StakingRemoteSignerCAFileKey, "", fmt.Sprintf("Path to the PEM encoded CA certificate the certificate of %s must be signed by. If empty, the connection to the signer is not encrypted", StakingRemoteSignerAddressKey))
+	fs.Duration(StakingRemoteSignerTimeoutKey, 10*time.Second, "Maximum duration of a request to the remote signer")
 
 	fs.Uint64(StakingDisabledWeightKey, 100, "Weight to provide to each peer when staking is disabled")
 	// Uptime Requirement
@@ -284,6 +349,10 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// State syncing
 	fs.String(StateSyncIPsKey, "", "Comma separated list of state sync peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
 	fs.String(StateSyncIDsKey, "", "Comma separated list of state sync peer ids to connect to. Example: NodeID-JR4dVmy6ffUGAKCBDkyCbeZbyHQBeDsET,NodeID-8CrVPQZ4VSqgL8zTdvL14G8HqAfrBr4z")
+	fs.Bool(StateSyncServingLimitsEnabledKey, false, "If true, limits the state summaries and blocks served to each peer that is syncing from this node")
+	fs.Uint(StateSyncServingMaxConcurrentPerPeerKey, 4, "Max number of state sync requests from a peer that are served at once")
+	fs.Uint64(StateSyncServingBandwidthPerPeerKey, 2*units.MiB, "Bytes per second of state summaries and blocks that may be served to each syncing peer")
+	fs.Uint64(StateSyncServingBandwidthBurstPerPeerKey, 16*units.MiB, "Max number of bytes of state summaries and blocks that may be served to a syncing peer at once")
 
 	// Bootstrapping
 	fs.String(BootstrapIPsKey, "", "Comma separated list of bootstrap peer ips to connect to. Example: 127.0.0.1:9630,127.0.0.1:9631")
@@ -294,6 +363,11 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(BootstrapMaxTimeGetAncestorsKey, 50*time.Millisecond, "Max Time to spend fetching a container and its ancestors when responding to a GetAncestors")
 	fs.Uint(BootstrapAncestorsMaxContainersSentKey, 2000, "Max number of containers in an Ancestors message sent by this node")
 	fs.Uint(BootstrapAncestorsMaxContainersReceivedKey, 2000, "This node reads at most this many containers from an incoming Ancestors message")
+	fs.String(BootstrapTrustedCheckpointsKey, "", "Comma separated list of checkpoints to trust, overriding the built-in checkpoints of the chain. Bootstrapping fails if the block fetched at the height of a checkpoint isn't the checkpoint. Example: C=2JVSBoinj9C2J33VntvzYtVJNZdN2NKiwwKjcumHUWEb5DbBrm:1000000")
+	fs.String(BootstrapOrderKey, "", "Comma separated list of chain aliases or IDs, in the order the chains start bootstrapping. Chains that aren't listed start after the listed chains. The P-chain always bootstraps first. Example: X,C")
+	fs.Uint(BootstrapMaxConcurrentChainsKey, 0, "Max number of chains, excluding the P-chain, that bootstrap at once. If 0, the number of chains isn't limited")
+	fs.Uint64(BootstrapMaxBandwidthKey, 0, "Bytes per second of containers fetched by all the bootstrapping chains. If 0, the bandwidth isn't limited")
+	fs.String(BootstrapBandwidthSharesKey, "", fmt.Sprintf("Comma separated list of the shares of %s given to each chain while it bootstraps, relative to the shares of the other bootstrapping chains. Chains that aren't listed have a share of 1. Example: X=1,C=3", BootstrapMaxBandwidthKey))
 
 	// Consensus
 	fs.Int(SnowSampleSizeKey, 20, "Number of nodes to query for each network poll")
@@ -320,6 +394,21 @@ func addNodeFlags(fs *flag.FlagSet) {
 	// Indexer
 	fs.Bool(IndexEnabledKey, false, "If true, index all accepted containers and transactions and expose them via an API")
 	fs.Bool(IndexAllowIncompleteKey, false, "If true, allow running the node in such a way that could cause an index to miss transactions. Ignored if index is disabled")
+	fs.Bool(IndexPlatformHistoryEnabledKey, false, "If true, index the balance and stake of every P-chain address by height and expose them via the platform API. Can only be enabled on a database that is bootstrapped with it")
+
+	// P-chain pruning
+	fs.Bool(PlatformPruneRewardUTXOsKey, false, "If true, delete the reward UTXOs of the P-chain stakers rewarded before the retention bounds when the node starts. They are no longer returned by the platform API")
+	fs.Bool(PlatformPruneTxsKey, false, "If true, delete the P-chain txs accepted before the retention bounds that aren't needed to verify new blocks when the node starts. They are no longer returned by the platform API")
+	fs.Uint64(PlatformPruningRetainedBlocksKey, 0, "Number of most recently accepted P-chain blocks whose data isn't pruned")
+	fs.Duration(PlatformPruningRetainedAgeKey, 30*24*time.Hour, "P-chain data accepted less than this long before the last accepted block isn't pruned")
+	fs.Bool(PlatformPruningDryRunKey, false, fmt.Sprintf("If true, the P-chain data that would be pruned is only reported in the logs, not deleted. Ignored if neither %s nor %s is set", PlatformPruneRewardUTXOsKey, PlatformPruneTxsKey))
+
+	// ProposerVM
+	fs.Uint64(ProposerVMHeightIndexRepairThrottleKey, indexer.DefaultSleepDurationMultiplier, "Number of times the time spent repairing each height of the proposervm height index to sleep for, so that the repair doesn't starve block verification. 0 disables the throttling")
+
+	// VM Plugins
+	fs.Bool(CorethPluginEnabledKey, false, "If true, the C-chain runs the EVM plugin in the plugin directory in its own process instead of the EVM built into the node. A crash of the plugin then stops the C-chain without shutting down the node")
+	fs.Int(PluginMaxRestartsKey, 3, "Number of times a VM plugin process that exits unexpectedly is restarted. 0 disables the restarts")
 
 	// Config Directories
 	fs.String(ChainConfigDirKey, defaultChainConfigDir, fmt.Sprintf("Chain specific configurations parent directory. Ignored if %s is specified", ChainConfigContentKey))
@@ -332,6 +421,23 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Bool(ProfileContinuousEnabledKey, false, "Whether the app should continuously produce performance profiles")
 	fs.Duration(ProfileContinuousFreqKey, 15*time.Minute, "How frequently to rotate performance profiles")
 	fs.Int(ProfileContinuousMaxFilesKey, 5, "Maximum number of historical profiles to keep")
+
+	// Tracing
+	fs.Bool(TracingEnabledKey, false, "If true, the handling of inbound messages is traced and the spans are exported to an OTLP collector")
+	fs.String(TracingExporterTypeKey, trace.GRPC.String(), fmt.Sprintf("Protocol used to export spans. Must be one of {%s, %s}", trace.GRPC, trace.HTTP))
+	fs.String(TracingEndpointKey, "", "The OTLP collector to export spans to. If empty, the exporter's default endpoint is used")
+	fs.Bool(TracingInsecureKey, false, "If true, spans are exported without TLS")
+	fs.Float64(TracingSampleRateKey, 0.1, "The fraction of inbound messages that are traced")
+	fs.String(TracingHeadersKey, "", "JSON map of headers sent with exported spans, e.g. {\"authorization\":\"Bearer ...\"}")
+
+	// Notifier
+	fs.String(NotifierWebhookURLKey, "", "URL that notifications about significant events, such as the node becoming unhealthy, are POSTed to as JSON. If empty, no webhooks are sent")
+	fs.String(NotifierSocketPathKey, "", "Path of a Unix socket that notifications about significant events are written to as JSON lines. If empty, nothing is written")
+	fs.Duration(NotifierCheckFrequencyKey, 30*time.Second, "How often the notifier checks the state of the node")
+	fs.Uint64(NotifierBehindThresholdKey, 100, "Number of blocks a chain must be behind the blocks sent by its peers to send a notification")
+	fs.Float64(NotifierBenchedThresholdKey, 0.5, "Share of the stake of the connected validators that must have stopped querying the node to send a notification")
+	fs.Duration(NotifierBenchedWindowKey, 5*time.Minute, "Time a connected validator must not have queried the node for to be considered to have benched it")
+
 	fs.String(VMAliasesFileKey, defaultVMAliasFilePath, fmt.Sprintf("Specifies a JSON file that maps vmIDs with custom aliases. Ignored if %s is specified", VMAliasesContentKey))
 	fs.String(VMAliasesContentKey, "", "Specifies base64 encoded maps vmIDs with custom aliases")
 
@@ -346,6 +452,8 @@ func addNodeFlags(fs *flag.FlagSet) {
 	fs.Duration(SystemTrackerDiskHalflifeKey, time.Minute, "Halflife to use for the disk tracker. Larger halflife --> disk usage metrics change more slowly")
 	fs.Uint64(SystemTrackerRequiredAvailableDiskSpaceKey, units.GiB/2, "Minimum number of available bytes on disk, under which the node will shutdown.")
 	fs.Uint64(SystemTrackerWarningThresholdAvailableDiskSpaceKey, units.GiB, fmt.Sprintf("Warning threshold for the number of available bytes on disk, under which the node will be considered unhealthy.  Must be >= [%s]", SystemTrackerRequiredAvailableDiskSpaceKey))
+	fs.Uint64(SystemTrackerIndexingPauseAvailableDiskSpaceKey, 0, fmt.Sprintf("Number of available bytes on disk under which the node stops indexing accepted containers. Pausing marks the indices as incomplete, so restarting with indexing enabled requires %s. If 0, indexing is never paused. Must be >= [%s] and [%s]", IndexAllowIncompleteKey, SystemTrackerAPITxsRejectAvailableDiskSpaceKey, SystemTrackerRequiredAvailableDiskSpaceKey))
+	fs.Uint64(SystemTrackerAPITxsRejectAvailableDiskSpaceKey, 0, fmt.Sprintf("Number of available bytes on disk under which the node rejects API calls that issue txs. If 0, API txs are never rejected. Must be >= [%s]", SystemTrackerRequiredAvailableDiskSpaceKey))
 
 	// CPU management
 	fs.Float64(CPUVdrAllocKey, float64(runtime.NumCPU()), "Maximum number of CPUs to allocate for use by validators. Value should be in range [0, total core count]")
@@ -9,7 +9,9 @@ const (
 	ConfigFileKey                                      = "config-file"
 	ConfigContentKey                                   = "config-file-content"
 	ConfigContentTypeKey                               = "config-file-content-type"
+	ConfigFileStrictKey                                = "config-file-strict"
 	VersionKey                                         = "version"
+	PrintConfigKey                                     = "print-config"
 	GenesisConfigFileKey                               = "genesis"
 	GenesisConfigContentKey                            = "genesis-content"
 	NetworkNameKey                                     = "network-id"
@@ -42,6 +44,8 @@ const (
 	DynamicPublicIPResolverKey                         = "dynamic-public-ip"
 	PublicIPResolutionFreqKey                          = "public-ip-resolution-frequency"
 	PublicIPResolutionServiceKey                       = "public-ip-resolution-service"
+	AdditionalPublicIPsKey                             = "additional-public-ips"
+	NATReachabilityCheckEnabledKey                     = "nat-reachability-check-enabled"
 	InboundConnUpgradeThrottlerCooldownKey             = "inbound-connection-throttling-cooldown"
 	InboundThrottlerMaxConnsPerSecKey                  = "inbound-connection-throttling-max-conns-per-sec"
 	OutboundConnectionThrottlingRps                    = "outbound-connection-throttling-rps"
@@ -49,6 +53,9 @@ const (
 	HTTPHostKey                                        = "http-host"
 	HTTPPortKey                                        = "http-port"
 	HTTPSEnabledKey                                    = "http-tls-enabled"
+	HTTPTCPEnabledKey                                  = "http-tcp-enabled"
+	HTTPSocketPathKey                                  = "http-socket-path"
+	HTTPSocketPermsKey                                 = "http-socket-perms"
 	HTTPSKeyFileKey                                    = "http-tls-key-file"
 	HTTPSKeyContentKey                                 = "http-tls-key-file-content"
 	HTTPSCertFileKey                                   = "http-tls-cert-file"
@@ -56,14 +63,26 @@ const (
 	HTTPAllowedOrigins                                 = "http-allowed-origins"
 	HTTPShutdownTimeoutKey                             = "http-shutdown-timeout"
 	HTTPShutdownWaitKey                                = "http-shutdown-wait"
+	HTTPRateLimitPerIPKey                              = "http-rate-limit-per-ip"
+	HTTPRateLimitPerIPBurstKey                         = "http-rate-limit-per-ip-burst"
+	HTTPRateLimitMethodsKey                            = "http-rate-limit-methods"
+	HTTPRateLimitMaxBodySizeKey                        = "http-rate-limit-max-body-size"
+	HTTPRateLimitTrustedProxiesKey                     = "http-rate-limit-trusted-proxies"
+	HTTPArchiveProxyConfigKey                          = "http-archive-proxy-config"
 	APIAuthRequiredKey                                 = "api-auth-required"
 	APIAuthPasswordKey                                 = "api-auth-password"
 	APIAuthPasswordFileKey                             = "api-auth-password-file"
+	APIAuthPublicScopesKey                             = "api-auth-public-scopes"
 	StateSyncIPsKey                                    = "state-sync-ips"
 	StateSyncIDsKey                                    = "state-sync-ids"
+	StateSyncServingLimitsEnabledKey                   = "state-sync-serving-limits-enabled"
+	StateSyncServingMaxConcurrentPerPeerKey            = "state-sync-serving-max-concurrent-per-peer"
+	StateSyncServingBandwidthPerPeerKey                = "state-sync-serving-bandwidth-per-peer"
+	StateSyncServingBandwidthBurstPerPeerKey           = "state-sync-serving-bandwidth-burst-per-peer"
 	BootstrapIPsKey                                    = "bootstrap-ips"
 	BootstrapIDsKey                                    = "bootstrap-ids"
 	StakingPortKey                                     = "staking-port"
+	StakingListenAddressesKey                          = "staking-listen-addresses"
 	StakingEnabledKey                                  = "staking-enabled"
 	StakingEphemeralCertEnabledKey                     = "staking-ephemeral-cert-enabled"
 	StakingTLSKeyPathKey                               = "staking-tls-key-file"
@@ -73,10 +92,15 @@ const (
 	StakingEphemeralSignerEnabledKey                   = "staking-ephemeral-signer-enabled"
 	StakingSignerKeyPathKey                            = "staking-signer-key-file"
 	StakingSignerKeyContentKey                         = "staking-signer-key-file-content"
+	StakingRemoteSignerAddressKey                      = "staking-remote-signer-address"
+	StakingRemoteSignerCAFileKey                       = "staking-remote-signer-ca-file"
+	StakingRemoteSignerTimeoutKey                      = "staking-remote-signer-timeout"
 	StakingDisabledWeightKey                           = "staking-disabled-weight"
 	NetworkInitialTimeoutKey                           = "network-initial-timeout"
 	NetworkMinimumTimeoutKey                           = "network-minimum-timeout"
 	NetworkMaximumTimeoutKey                           = "network-maximum-timeout"
+	NetworkChainMinimumTimeoutKey                      = "network-chain-minimum-timeout"
+	NetworkChainMaximumTimeoutKey                      = "network-chain-maximum-timeout"
 	NetworkMaximumInboundTimeoutKey                    = "network-maximum-inbound-timeout"
 	NetworkTimeoutHalflifeKey                          = "network-timeout-halflife"
 	NetworkTimeoutCoefficientKey                       = "network-timeout-coefficient"
@@ -91,18 +115,34 @@ const (
 	NetworkPeerListNonValidatorGossipSizeKey           = "network-peer-list-non-validator-gossip-size"
 	NetworkPeerListPeersGossipSizeKey                  = "network-peer-list-peers-gossip-size"
 	NetworkPeerListGossipFreqKey                       = "network-peer-list-gossip-frequency"
+	NetworkPeerListRecoveryStakeThresholdKey           = "network-peer-list-recovery-stake-threshold"
 	NetworkInitialReconnectDelayKey                    = "network-initial-reconnect-delay"
 	NetworkReadHandshakeTimeoutKey                     = "network-read-handshake-timeout"
 	NetworkPingTimeoutKey                              = "network-ping-timeout"
 	NetworkPingFrequencyKey                            = "network-ping-frequency"
 	NetworkMaxReconnectDelayKey                        = "network-max-reconnect-delay"
 	NetworkCompressionEnabledKey                       = "network-compression-enabled"
+	NetworkCompressionTypeKey                          = "network-compression-type"
+	NetworkLegacyMessageFormatKey                      = "network-legacy-message-format"
 	NetworkMaxClockDifferenceKey                       = "network-max-clock-difference"
 	NetworkAllowPrivateIPsKey                          = "network-allow-private-ips"
 	NetworkRequireValidatorToConnectKey                = "network-require-validator-to-connect"
+	NetworkBlocklistNodeIDsKey                         = "network-blocklist-node-ids"
+	NetworkBlocklistIPsKey                             = "network-blocklist-ips"
+	NetworkGaterAllowedCIDRsKey                        = "network-gater-allowed-cidrs"
+	NetworkGaterDeniedCIDRsKey                         = "network-gater-denied-cidrs"
+	NetworkGaterDeniedDialCIDRsKey                     = "network-gater-denied-dial-cidrs"
+	NetworkGaterMaxConnsPerIPKey                       = "network-gater-max-conns-per-ip"
+	NetworkMaxPeerConnsKey                             = "network-max-peer-conns"
+	NetworkNonValidatorShedThresholdKey                = "network-non-validator-shed-threshold"
 	NetworkPeerReadBufferSizeKey                       = "network-peer-read-buffer-size"
 	NetworkPeerWriteBufferSizeKey                      = "network-peer-write-buffer-size"
+	NetworkPeerMaxQueuedBulkBytesKey                   = "network-peer-max-queued-bulk-bytes"
+	NetworkQUICEnabledKey                              = "network-quic-enabled"
 	NetworkTLSKeyLogFileKey                            = "network-tls-key-log-file-unsafe"
+	NetworkTLSMinRSAKeySizeKey                         = "network-tls-min-rsa-key-size"
+	NetworkTLSAllowedSignatureAlgorithmsKey            = "network-tls-allowed-signature-algorithms"
+	NetworkTLSMaxCertValidityKey                       = "network-tls-max-cert-validity"
 	BenchlistFailThresholdKey                          = "benchlist-fail-threshold"
 	BenchlistDurationKey                               = "benchlist-duration"
 	BenchlistMinFailingDurationKey                     = "benchlist-min-failing-duration"
@@ -115,6 +155,9 @@ const (
 	LogRotaterMaxFilesKey                              = "log-rotater-max-files"
 	LogRotaterMaxAgeKey                                = "log-rotater-max-age"
 	LogRotaterCompressEnabledKey                       = "log-rotater-compress-enabled"
+	LogRotaterCompressionTypeKey                       = "log-rotater-compression-type"
+	LogRotaterRotationIntervalKey                      = "log-rotater-rotation-interval"
+	LogDirMaxSizeKey                                   = "log-dir-max-size"
 	LogDisableDisplayPluginLogsKey                     = "log-disable-display-plugin-logs"
 	SnowSampleSizeKey                                  = "snow-sample-size"
 	SnowQuorumSizeKey                                  = "snow-quorum-size"
@@ -131,14 +174,27 @@ const (
 	WhitelistedSubnetsKey                              = "whitelisted-subnets"
 	AdminAPIEnabledKey                                 = "api-admin-enabled"
 	InfoAPIEnabledKey                                  = "api-info-enabled"
+	InfoAPIPeerEnrichmentFileKey                       = "api-info-peer-enrichment-file"
 	KeystoreAPIEnabledKey                              = "api-keystore-enabled"
+	SignerSocketPathKey                                = "signer-socket-path"
 	MetricsAPIEnabledKey                               = "api-metrics-enabled"
 	HealthAPIEnabledKey                                = "api-health-enabled"
+	TransferAPIEnabledKey                              = "api-transfer-enabled"
 	IpcAPIEnabledKey                                   = "api-ipcs-enabled"
+	ValidatorModeKey                                   = "validator-mode"
 	IpcsChainIDsKey                                    = "ipcs-chain-ids"
 	IpcsPathKey                                        = "ipcs-path"
 	MeterVMsEnabledKey                                 = "meter-vms-enabled"
+	ProposerVMHeightIndexRepairThrottleKey             = "proposervm-height-index-repair-throttle"
 	ConsensusGossipFrequencyKey                        = "consensus-gossip-frequency"
+	ConsensusGossipedContainerTTLKey                   = "consensus-gossiped-container-ttl"
+	ConsensusQueueMaxSizeKey                           = "consensus-queue-max-size"
+	ConsensusQueueDropPolicyKey                        = "consensus-queue-drop-policy"
+	ConsensusQueueCPUSoftLimitKey                      = "consensus-queue-cpu-soft-limit"
+	ConsensusChainQueueConfigKey                       = "consensus-chain-queue-config"
+	ConsensusMessageCaptureSizeKey                     = "consensus-message-capture-size"
+	ConsensusMessageCapturePayloadsKey                 = "consensus-message-capture-payloads"
+	ConsensusMaxOutstandingRequestsPerPeerKey          = "consensus-max-outstanding-requests-per-peer"
 	ConsensusGossipAcceptedFrontierValidatorSizeKey    = "consensus-accepted-frontier-gossip-validator-size"
 	ConsensusGossipAcceptedFrontierNonValidatorSizeKey = "consensus-accepted-frontier-gossip-non-validator-size"
 	ConsensusGossipAcceptedFrontierPeerSizeKey         = "consensus-accepted-frontier-gossip-peer-size"
@@ -148,21 +204,39 @@ const (
 	AppGossipValidatorSizeKey                          = "consensus-app-gossip-validator-size"
 	AppGossipNonValidatorSizeKey                       = "consensus-app-gossip-non-validator-size"
 	AppGossipPeerSizeKey                               = "consensus-app-gossip-peer-size"
+	AppGossipStakeWeightedKey                          = "consensus-app-gossip-stake-weighted"
 	ConsensusShutdownTimeoutKey                        = "consensus-shutdown-timeout"
 	FdLimitKey                                         = "fd-limit"
 	IndexEnabledKey                                    = "index-enabled"
 	IndexAllowIncompleteKey                            = "index-allow-incomplete"
+	IndexPlatformHistoryEnabledKey                     = "index-platform-history-enabled"
+	PlatformPruneRewardUTXOsKey                        = "platform-prune-reward-utxos"
+	PlatformPruneTxsKey                                = "platform-prune-txs"
+	PlatformPruningRetainedBlocksKey                   = "platform-pruning-retained-blocks"
+	PlatformPruningRetainedAgeKey                      = "platform-pruning-retained-age"
+	PlatformPruningDryRunKey                           = "platform-pruning-dry-run"
 	RouterHealthMaxDropRateKey                         = "router-health-max-drop-rate"
 	RouterHealthMaxOutstandingRequestsKey              = "router-health-max-outstanding-requests"
 	HealthCheckFreqKey                                 = "health-check-frequency"
 	HealthCheckAveragerHalflifeKey                     = "health-check-averager-halflife"
+	HealthCheckDisabledKey                             = "health-check-disabled"
+	HealthCheckWarningKey                              = "health-check-warning"
+	HealthCheckReadinessKey                            = "health-check-readiness"
+	HealthCheckLivenessKey                             = "health-check-liveness"
 	RetryBootstrapKey                                  = "bootstrap-retry-enabled"
 	RetryBootstrapWarnFrequencyKey                     = "bootstrap-retry-warn-frequency"
 	PluginModeKey                                      = "plugin-mode-enabled"
+	CorethPluginEnabledKey                             = "coreth-plugin-enabled"
+	PluginMaxRestartsKey                               = "plugin-max-restarts"
 	BootstrapBeaconConnectionTimeoutKey                = "bootstrap-beacon-connection-timeout"
 	BootstrapMaxTimeGetAncestorsKey                    = "bootstrap-max-time-get-ancestors"
 	BootstrapAncestorsMaxContainersSentKey             = "bootstrap-ancestors-max-containers-sent"
 	BootstrapAncestorsMaxContainersReceivedKey         = "bootstrap-ancestors-max-containers-received"
+	BootstrapTrustedCheckpointsKey                     = "bootstrap-trusted-checkpoints"
+	BootstrapOrderKey                                  = "bootstrap-order"
+	BootstrapMaxConcurrentChainsKey                    = "bootstrap-max-concurrent-chains"
+	BootstrapMaxBandwidthKey                           = "bootstrap-max-bandwidth"
+	BootstrapBandwidthSharesKey                        = "bootstrap-bandwidth-shares"
 	ChainConfigDirKey                                  = "chain-config-dir"
 	ChainConfigContentKey                              = "chain-config-content"
 	SubnetConfigDirKey                                 = "subnet-config-dir"
@@ -171,6 +245,18 @@ const (
 	ProfileContinuousEnabledKey                        = "profile-continuous-enabled"
 	ProfileContinuousFreqKey                           = "profile-continuous-freq"
 	ProfileContinuousMaxFilesKey                       = "profile-continuous-max-files"
+	TracingEnabledKey                                  = "tracing-enabled"
+	TracingExporterTypeKey                             = "tracing-exporter-type"
+	TracingEndpointKey                                 = "tracing-endpoint"
+	TracingInsecureKey                                 = "tracing-insecure"
+	TracingSampleRateKey                               = "tracing-sample-rate"
+	TracingHeadersKey                                  = "tracing-headers"
+	NotifierWebhookURLKey                              = "notifier-webhook-url"
+	NotifierSocketPathKey                              = "notifier-socket-path"
+	NotifierCheckFrequencyKey                          = "notifier-check-frequency"
+	NotifierBehindThresholdKey                         = "notifier-behind-threshold"
+	NotifierBenchedThresholdKey                        = "notifier-benched-threshold"
+	NotifierBenchedWindowKey                           = "notifier-benched-window"
 	InboundThrottlerAtLargeAllocSizeKey                = "throttler-inbound-at-large-alloc-size"
 	InboundThrottlerVdrAllocSizeKey                    = "throttler-inbound-validator-alloc-size"
 	InboundThrottlerNodeMaxAtLargeBytesKey             = "throttler-inbound-node-max-at-large-bytes"
@@ -188,6 +274,8 @@ const (
 	SystemTrackerDiskHalflifeKey                       = "system-tracker-disk-halflife"
 	SystemTrackerRequiredAvailableDiskSpaceKey         = "system-tracker-disk-required-available-space"
 	SystemTrackerWarningThresholdAvailableDiskSpaceKey = "system-tracker-disk-warning-threshold-available-space"
+	SystemTrackerIndexingPauseAvailableDiskSpaceKey    = "system-tracker-disk-indexing-pause-available-space"
+	SystemTrackerAPITxsRejectAvailableDiskSpaceKey     = "system-tracker-disk-api-txs-reject-available-space"
 	DiskVdrAllocKey                                    = "throttler-inbound-disk-validator-alloc"
 	DiskMaxNonVdrUsageKey                              = "throttler-inbound-disk-max-non-validator-usage"
 	DiskMaxNonVdrNodeUsageKey                          = "throttler-inbound-disk-max-non-validator-node-usage"
@@ -197,4 +285,6 @@ const (
 	UptimeMetricFreqKey                                = "uptime-metric-freq"
 	VMAliasesFileKey                                   = "vm-aliases-file"
 	VMAliasesContentKey                                = "vm-aliases-file-content"
+	NetworkParamsFileKey                               = "network-params-file"
+	NetworkParamsContentKey                            = "network-params-file-content"
 )
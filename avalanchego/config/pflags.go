@@ -5,13 +5,14 @@ package config
 
 import (
 	"flag"
+	"fmt"
 
 	"github.com/spf13/pflag"
 )
 
 func deprecateFlags(fs *pflag.FlagSet) error {
-	for key, message := range deprecatedKeys {
-		if err := fs.MarkDeprecated(key, message); err != nil {
+	for key, replacement := range deprecatedKeys {
+		if err := fs.MarkDeprecated(key, fmt.Sprintf("use %s instead", replacement)); err != nil {
 			return err
 		}
 	}
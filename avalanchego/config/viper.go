@@ -9,9 +9,12 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/spf13/viper"
+
+	"github.com/ava-labs/avalanchego/utils/math"
 )
 
 // BuildViper returns the viper environment from parsing config file from
@@ -57,13 +60,99 @@ func BuildViper(fs *flag.FlagSet, args []string) (*viper.Viper, error) {
 
 	// Config deprecations must be after v.ReadInConfig
 	deprecateConfigs(v, fs.Output())
+
+	if v.GetBool(ConfigFileStrictKey) {
+		if err := verifyConfigKeys(v, fs); err != nil {
+			return nil, err
+		}
+	}
 	return v, nil
 }
 
 func deprecateConfigs(v *viper.Viper, output io.Writer) {
-	for key, message := range deprecatedKeys {
+	for key, replacement := range deprecatedKeys {
 		if v.InConfig(key) {
-			fmt.Fprintf(output, "Config %s has been deprecated, %s\n", key, message)
+			fmt.Fprintf(output, "Config %s has been deprecated, use %s instead\n", key, replacement)
+		}
+	}
+}
+
+// verifyConfigKeys returns an error if the loaded config contains a key that
+// doesn't correspond to any flag. The error suggests the closest known key.
+func verifyConfigKeys(v *viper.Viper, fs *flag.FlagSet) error {
+	var unknownKeys []string
+	for _, key := range v.AllKeys() {
+		if !v.InConfig(key) {
+			continue
+		}
+		// Nested values are flattened by viper, only the top level key has to
+		// be a flag.
+		topLevelKey := strings.SplitN(key, ".", 2)[0]
+		if fs.Lookup(topLevelKey) == nil {
+			unknownKeys = append(unknownKeys, topLevelKey)
+		}
+	}
+	if len(unknownKeys) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknownKeys)
+	descriptions := make([]string, 0, len(unknownKeys))
+	for i, key := range unknownKeys {
+		if i > 0 && unknownKeys[i-1] == key {
+			continue
+		}
+		description := fmt.Sprintf("%q", key)
+		if suggestion := closestFlagName(fs, key); suggestion != "" {
+			description += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+		descriptions = append(descriptions, description)
+	}
+	return fmt.Errorf(
+		"%w: %s. Set --%s=false to ignore unknown keys",
+		errUnknownConfigKeys,
+		strings.Join(descriptions, ", "),
+		ConfigFileStrictKey,
+	)
+}
+
+// closestFlagName returns the name of the flag in [fs] with the smallest edit
+// distance to [key], or the empty string if no flag is reasonably close.
+func closestFlagName(fs *flag.FlagSet, key string) string {
+	var (
+		closest      string
+		bestDistance = len(key)/3 + 1
+	)
+	fs.VisitAll(func(f *flag.Flag) {
+		if distance := editDistance(key, f.Name); distance < bestDistance {
+			closest = f.Name
+			bestDistance = distance
+		}
+	})
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between [a] and [b].
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = math.Min(
+				previous[j]+1,
+				current[j-1]+1,
+				previous[j-1]+cost,
+			)
 		}
+		previous, current = current, previous
 	}
+	return previous[len(b)]
 }
@@ -4,6 +4,7 @@
 package corruptabledb
 
 import (
+	"errors"
 	"fmt"
 	"sync"
 
@@ -11,8 +12,15 @@ import (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
+	errStatsNotSupported     = errors.New("underlying database doesn't report storage stats")
+	errSnapshotsNotSupported = errors.New("underlying database doesn't support snapshots")
+	errFlushNotSupported     = errors.New("underlying database doesn't support flushing")
+
+	_ database.Database      = &Database{}
+	_ database.StatsReporter = &Database{}
+	_ database.Snapshotter   = &Database{}
+	_ database.Flusher       = &Database{}
+	_ database.Batch         = &batch{}
 )
 
 // CorruptableDB is a wrapper around Database
@@ -72,6 +80,51 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 
 func (db *Database) Close() error { return db.handleError(db.Database.Close()) }
 
+// StorageStats returns the storage stats of the underlying database, if it
+// reports them. Failing to report stats doesn't indicate corruption.
+func (db *Database) StorageStats() (database.StorageStats, error) {
+	reporter, ok := db.Database.(database.StatsReporter)
+	if !ok {
+		return database.StorageStats{}, errStatsNotSupported
+	}
+	return reporter.StorageStats()
+}
+
+// ApproximatePrefixSize returns the approximate size of [prefix] in the
+// underlying database, if it reports it.
+func (db *Database) ApproximatePrefixSize(prefix []byte) (uint64, error) {
+	reporter, ok := db.Database.(database.StatsReporter)
+	if !ok {
+		return 0, errStatsNotSupported
+	}
+	return reporter.ApproximatePrefixSize(prefix)
+}
+
+// NewSnapshot returns a snapshot of the underlying database, if it supports
+// them.
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	if err := db.corrupted(); err != nil {
+		return nil, err
+	}
+	snapshotter, ok := db.Database.(database.Snapshotter)
+	if !ok {
+		return nil, errSnapshotsNotSupported
+	}
+	return snapshotter.NewSnapshot()
+}
+
+// Flush flushes the underlying database, if it supports it.
+func (db *Database) Flush() error {
+	if err := db.corrupted(); err != nil {
+		return err
+	}
+	flusher, ok := db.Database.(database.Flusher)
+	if !ok {
+		return errFlushNotSupported
+	}
+	return db.handleError(flusher.Flush())
+}
+
 func (db *Database) HealthCheck() (interface{}, error) {
 	if err := db.corrupted(); err != nil {
 		return nil, err
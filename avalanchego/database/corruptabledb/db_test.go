@@ -66,3 +66,28 @@ func TestCorruption(t *testing.T) {
 		})
 	}
 }
+
+func TestStorageStatsNotSupported(t *testing.T) {
+	db := New(memdb.New())
+
+	_, err := db.StorageStats()
+	require.ErrorIs(t, err, errStatsNotSupported)
+	_, err = db.ApproximatePrefixSize(nil)
+	require.ErrorIs(t, err, errStatsNotSupported)
+
+	// Not reporting stats isn't treated as corruption.
+	require.NoError(t, db.Put([]byte("key"), []byte("value")))
+}
+
+func TestSnapshotsNotSupported(t *testing.T) {
+	db := New(memdb.New())
+
+	_, err := db.NewSnapshot()
+	require.ErrorIs(t, err, errSnapshotsNotSupported)
+}
+
+func TestFlushNotSupported(t *testing.T) {
+	db := New(memdb.New())
+
+	require.ErrorIs(t, db.Flush(), errFlushNotSupported)
+}
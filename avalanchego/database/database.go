@@ -78,6 +78,61 @@ type Compacter interface {
 	Compact(start []byte, limit []byte) error
 }
 
+// StatsReporter is implemented by backing data stores that can report how
+// their data is laid out on disk.
+type StatsReporter interface {
+	// StorageStats returns statistics about the whole data store.
+	StorageStats() (StorageStats, error)
+
+	// ApproximatePrefixSize returns the approximate number of bytes used on
+	// disk by the keys that start with [prefix].
+	ApproximatePrefixSize(prefix []byte) (uint64, error)
+}
+
+// Snapshotter is implemented by backing data stores that can provide a
+// consistent view of their contents while they keep being written to.
+type Snapshotter interface {
+	// NewSnapshot returns a read-only view of the data store's current
+	// contents. Writes made after NewSnapshot returns aren't visible in the
+	// snapshot.
+	NewSnapshot() (Snapshot, error)
+}
+
+// Flusher is implemented by backing data stores that buffer writes in memory.
+type Flusher interface {
+	// Flush writes the buffered writes to their on-disk tables, so that
+	// reopening the data store doesn't have to replay them.
+	Flush() error
+}
+
+// Snapshot is a read-only view of a data store at a point in time.
+// Release must be called once the snapshot is no longer needed.
+type Snapshot interface {
+	KeyValueReader
+	Iteratee
+
+	// Release the resources held by the snapshot. The snapshot must not be
+	// used after it is released.
+	Release()
+}
+
+// StorageStats describes how a data store's data is laid out on disk.
+type StorageStats struct {
+	// Number of bytes stored at each level of the data store
+	LevelSizes []uint64
+	// Number of tables at each level of the data store
+	LevelTables []int
+	// Number of tables that are currently open
+	OpenTables int
+	// Number of compactions run since the data store was opened
+	Compactions uint64
+	// Number of snapshots and iterators currently held open
+	AliveSnapshots int
+	AliveIterators int
+	// True if writes are paused until a compaction finishes
+	WritePaused bool
+}
+
 // Database contains all the methods required to allow handling different
 // key-value data stores backing the database.
 type Database interface {
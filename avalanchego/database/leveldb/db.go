@@ -63,9 +63,12 @@ const (
 )
 
 var (
-	_ database.Database = &Database{}
-	_ database.Batch    = &batch{}
-	_ database.Iterator = &iter{}
+	_ database.Database      = &Database{}
+	_ database.StatsReporter = &Database{}
+	_ database.Snapshotter   = &Database{}
+	_ database.Flusher       = &Database{}
+	_ database.Batch         = &batch{}
+	_ database.Iterator      = &iter{}
 )
 
 // Database is a persistent key-value store. Apart from basic data storage
@@ -347,6 +350,48 @@ func (db *Database) Compact(start []byte, limit []byte) error {
 	return updateError(db.DB.CompactRange(util.Range{Start: start, Limit: limit}))
 }
 
+func (db *Database) StorageStats() (database.StorageStats, error) {
+	stats := leveldb.DBStats{}
+	if err := db.DB.Stats(&stats); err != nil {
+		return database.StorageStats{}, updateError(err)
+	}
+	levelSizes := make([]uint64, len(stats.LevelSizes))
+	for level, size := range stats.LevelSizes {
+		levelSizes[level] = uint64(size)
+	}
+	return database.StorageStats{
+		LevelSizes:  levelSizes,
+		LevelTables: stats.LevelTablesCounts,
+		OpenTables:  stats.OpenedTablesCount,
+		Compactions: uint64(stats.MemComp) +
+			uint64(stats.Level0Comp) +
+			uint64(stats.NonLevel0Comp) +
+			uint64(stats.SeekComp),
+		AliveSnapshots: int(stats.AliveSnapshots),
+		AliveIterators: int(stats.AliveIterators),
+		WritePaused:    stats.WritePaused,
+	}, nil
+}
+
+func (db *Database) ApproximatePrefixSize(prefix []byte) (uint64, error) {
+	sizes, err := db.DB.SizeOf([]util.Range{*util.BytesPrefix(prefix)})
+	if err != nil {
+		return 0, updateError(err)
+	}
+	return uint64(sizes.Sum()), nil
+}
+
+// Flush writes the memtable to a table on disk. Opening a transaction waits
+// for the memtable to be compacted, and discarding it writes nothing.
+func (db *Database) Flush() error {
+	tx, err := db.DB.OpenTransaction()
+	if err != nil {
+		return updateError(err)
+	}
+	tx.Discard()
+	return nil
+}
+
 func (db *Database) Close() error {
 	db.closed.SetValue(true)
 	db.closeOnce.Do(func() {
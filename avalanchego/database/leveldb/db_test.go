@@ -8,6 +8,8 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
@@ -49,3 +51,99 @@ func BenchmarkInterface(b *testing.B) {
 		}
 	}
 }
+
+func TestStorageStats(t *testing.T) {
+	require := require.New(t)
+
+	dbIntf, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	db := dbIntf.(*Database)
+	defer db.Close()
+
+	value := make([]byte, 1024)
+	for i := 0; i < 1024; i++ {
+		require.NoError(db.Put([]byte{0x01, byte(i >> 8), byte(i)}, value))
+	}
+	// Flush the memtable to tables on disk.
+	require.NoError(db.Compact(nil, nil))
+
+	stats, err := db.StorageStats()
+	require.NoError(err)
+	require.NotZero(stats.Compactions)
+	var size uint64
+	for _, levelSize := range stats.LevelSizes {
+		size += levelSize
+	}
+	require.NotZero(size)
+
+	prefixSize, err := db.ApproximatePrefixSize([]byte{0x01})
+	require.NoError(err)
+	require.NotZero(prefixSize)
+
+	prefixSize, err = db.ApproximatePrefixSize([]byte{0x02})
+	require.NoError(err)
+	require.Zero(prefixSize)
+}
+
+func TestFlush(t *testing.T) {
+	require := require.New(t)
+
+	dbIntf, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	db := dbIntf.(*Database)
+	defer db.Close()
+
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+
+	// The write is only in the memtable until it's flushed.
+	stats, err := db.StorageStats()
+	require.NoError(err)
+	require.Zero(stats.Compactions)
+
+	require.NoError(db.Flush())
+
+	stats, err = db.StorageStats()
+	require.NoError(err)
+	require.NotZero(stats.Compactions)
+	value, err := db.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("1"), value)
+}
+
+func TestSnapshot(t *testing.T) {
+	require := require.New(t)
+
+	db, err := New(t.TempDir(), nil, logging.NoLog{}, "", prometheus.NewRegistry())
+	require.NoError(err)
+	defer db.Close()
+
+	require.NoError(db.Put([]byte("a"), []byte("1")))
+	require.NoError(db.Put([]byte("b"), []byte("2")))
+
+	snapshot, err := db.(database.Snapshotter).NewSnapshot()
+	require.NoError(err)
+	defer snapshot.Release()
+
+	// Writes after the snapshot is taken aren't visible in it.
+	require.NoError(db.Put([]byte("a"), []byte("3")))
+	require.NoError(db.Put([]byte("c"), []byte("4")))
+	require.NoError(db.Delete([]byte("b")))
+
+	value, err := snapshot.Get([]byte("a"))
+	require.NoError(err)
+	require.Equal([]byte("1"), value)
+	has, err := snapshot.Has([]byte("c"))
+	require.NoError(err)
+	require.False(has)
+	_, err = snapshot.Get([]byte("c"))
+	require.ErrorIs(err, database.ErrNotFound)
+
+	it := snapshot.NewIterator()
+	defer it.Release()
+	var keys []string
+	for it.Next() {
+		keys = append(keys, string(it.Key()))
+	}
+	require.NoError(it.Error())
+	require.Equal([]string{"a", "b"}, keys)
+}
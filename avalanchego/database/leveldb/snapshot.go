@@ -0,0 +1,76 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package leveldb
+
+import (
+	"bytes"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ava-labs/avalanchego/database"
+)
+
+var _ database.Snapshot = &snapshot{}
+
+// snapshot is a consistent, read-only view of a Database
+type snapshot struct {
+	db       *Database
+	snapshot *leveldb.Snapshot
+}
+
+// NewSnapshot returns a view of the database's current contents
+func (db *Database) NewSnapshot() (database.Snapshot, error) {
+	s, err := db.DB.GetSnapshot()
+	if err != nil {
+		return nil, updateError(err)
+	}
+	return &snapshot{
+		db:       db,
+		snapshot: s,
+	}, nil
+}
+
+// Has returns if the key was set in the database when the snapshot was taken
+func (s *snapshot) Has(key []byte) (bool, error) {
+	has, err := s.snapshot.Has(key, nil)
+	return has, updateError(err)
+}
+
+// Get returns the value the key mapped to when the snapshot was taken
+func (s *snapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snapshot.Get(key, nil)
+	return value, updateError(err)
+}
+
+func (s *snapshot) NewIterator() database.Iterator {
+	return s.newIterator(new(util.Range))
+}
+
+func (s *snapshot) NewIteratorWithStart(start []byte) database.Iterator {
+	return s.newIterator(&util.Range{Start: start})
+}
+
+func (s *snapshot) NewIteratorWithPrefix(prefix []byte) database.Iterator {
+	return s.newIterator(util.BytesPrefix(prefix))
+}
+
+func (s *snapshot) NewIteratorWithStartAndPrefix(start, prefix []byte) database.Iterator {
+	iterRange := util.BytesPrefix(prefix)
+	if bytes.Compare(start, prefix) == 1 {
+		iterRange.Start = start
+	}
+	return s.newIterator(iterRange)
+}
+
+func (s *snapshot) newIterator(iterRange *util.Range) database.Iterator {
+	return &iter{
+		db:       s.db,
+		Iterator: s.snapshot.NewIterator(iterRange, nil),
+	}
+}
+
+func (s *snapshot) Release() {
+	s.snapshot.Release()
+}
@@ -48,11 +48,17 @@ func New(prefix []byte, db database.Database) *Database {
 	return NewNested(prefix, db)
 }
 
+// MakePrefix returns the prefix that NewNested([prefix], db) prepends to the
+// keys it stores in [db].
+func MakePrefix(prefix []byte) []byte {
+	return hashing.ComputeHash256(prefix)
+}
+
 // NewNested returns a new prefixed database without attempting to compress
 // prefixes.
 func NewNested(prefix []byte, db database.Database) *Database {
 	return &Database{
-		dbPrefix: hashing.ComputeHash256(prefix),
+		dbPrefix: MakePrefix(prefix),
 		db:       db,
 		bufferPool: sync.Pool{
 			New: func() interface{} {
@@ -0,0 +1,252 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	stdjson "encoding/json"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var errInvalidCChainBalance = errors.New("invalid C-chain balance")
+
+// Audit summarizes the funds that a genesis config allocates. The totals are
+// recomputed from the allocations rather than read from the config, so that
+// the genesis can be verified without reading the code that builds it.
+type Audit struct {
+	NetworkID   uint32      `json:"networkID"`
+	NetworkName string      `json:"networkName"`
+	StartTime   json.Uint64 `json:"startTime"`
+	// Supply that the P-chain is created with
+	InitialSupply json.Uint64 `json:"initialSupply"`
+
+	// Sum of the initial amounts and unlock schedules of the allocations
+	TotalAllocated json.Uint64 `json:"totalAllocated"`
+	// Sum of the initial amounts, which are sent to the X-chain
+	TotalInitialAmount json.Uint64 `json:"totalInitialAmount"`
+	// Sum of the unlock schedules, which are sent to the P-chain
+	TotalUnlockSchedule json.Uint64 `json:"totalUnlockSchedule"`
+	// Part of [TotalUnlockSchedule] that is still locked at [StartTime]
+	TotalLocked json.Uint64 `json:"totalLocked"`
+	// Part of [TotalUnlockSchedule] that is staked by the initial stakers
+	TotalStaked json.Uint64 `json:"totalStaked"`
+
+	Allocations []AllocationAudit `json:"allocations"`
+	// Amounts of the unlock schedules grouped by locktime, by increasing
+	// locktime
+	UnlockSchedule    []UnlockAudit `json:"unlockSchedule"`
+	NumInitialStakers int           `json:"numInitialStakers"`
+
+	// Nil if the config doesn't have a C-chain genesis
+	CChain *CChainAudit `json:"cChain,omitempty"`
+}
+
+// AllocationAudit summarizes the funds allocated to an address.
+type AllocationAudit struct {
+	AVAXAddr             string      `json:"avaxAddr"`
+	ETHAddr              string      `json:"ethAddr"`
+	InitialAmount        json.Uint64 `json:"initialAmount"`
+	UnlockScheduleAmount json.Uint64 `json:"unlockScheduleAmount"`
+	// Part of [UnlockScheduleAmount] that is still locked at the start time
+	LockedAmount json.Uint64 `json:"lockedAmount"`
+	// True if [UnlockScheduleAmount] is staked by the initial stakers
+	Staked bool        `json:"staked"`
+	Total  json.Uint64 `json:"total"`
+}
+
+// UnlockAudit is the amount of the unlock schedules that unlocks at
+// [Locktime].
+type UnlockAudit struct {
+	Locktime json.Uint64 `json:"locktime"`
+	Amount   json.Uint64 `json:"amount"`
+	// Sum of the amounts that unlock at or before [Locktime]
+	CumulativeAmount json.Uint64 `json:"cumulativeAmount"`
+}
+
+// CChainAudit summarizes the accounts of a C-chain genesis. Balances are in
+// wei and formatted as decimal strings, as they may exceed 64 bits.
+type CChainAudit struct {
+	ChainID      uint64 `json:"chainID"`
+	TotalBalance string `json:"totalBalance"`
+	// Accounts with a balance or code, sorted by address
+	Accounts     []CChainAccountAudit `json:"accounts"`
+	NumContracts int                  `json:"numContracts"`
+}
+
+// CChainAccountAudit is an account of a C-chain genesis.
+type CChainAccountAudit struct {
+	Address  string `json:"address"`
+	Balance  string `json:"balance"`
+	Contract bool   `json:"contract"`
+}
+
+// cChainGenesis holds the fields of a C-chain genesis that are audited.
+type cChainGenesis struct {
+	Config struct {
+		ChainID uint64 `json:"chainId"`
+	} `json:"config"`
+	Alloc map[string]struct {
+		Balance string `json:"balance"`
+		Code    string `json:"code"`
+	} `json:"alloc"`
+}
+
+// NewAudit returns the audit of [config].
+func NewAudit(config *Config) (*Audit, error) {
+	initialSupply, err := config.InitialSupply()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't calculate the initial supply: %w", err)
+	}
+
+	audit := &Audit{
+		NetworkID:         config.NetworkID,
+		NetworkName:       constants.NetworkName(config.NetworkID),
+		StartTime:         json.Uint64(config.StartTime),
+		InitialSupply:     json.Uint64(initialSupply),
+		Allocations:       make([]AllocationAudit, len(config.Allocations)),
+		NumInitialStakers: len(config.InitialStakers),
+	}
+
+	initiallyStaked := ids.ShortSet{}
+	initiallyStaked.Add(config.InitialStakedFunds...)
+	unlocks := make(map[uint64]uint64)
+	for i, allocation := range config.Allocations {
+		unparsed, err := allocation.Unparse(config.NetworkID)
+		if err != nil {
+			return nil, err
+		}
+		allocationAudit := AllocationAudit{
+			AVAXAddr:      unparsed.AVAXAddr,
+			ETHAddr:       unparsed.ETHAddr,
+			InitialAmount: json.Uint64(allocation.InitialAmount),
+			Staked:        initiallyStaked.Contains(allocation.AVAXAddr),
+		}
+
+		var unlockScheduleAmount, lockedAmount uint64
+		for _, unlock := range allocation.UnlockSchedule {
+			unlockScheduleAmount, err = safemath.Add64(unlockScheduleAmount, unlock.Amount)
+			if err != nil {
+				return nil, err
+			}
+			if unlock.Locktime > config.StartTime {
+				lockedAmount += unlock.Amount
+			}
+			unlocks[unlock.Locktime], err = safemath.Add64(unlocks[unlock.Locktime], unlock.Amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+		total, err := safemath.Add64(allocation.InitialAmount, unlockScheduleAmount)
+		if err != nil {
+			return nil, err
+		}
+		allocationAudit.UnlockScheduleAmount = json.Uint64(unlockScheduleAmount)
+		allocationAudit.LockedAmount = json.Uint64(lockedAmount)
+		allocationAudit.Total = json.Uint64(total)
+		audit.Allocations[i] = allocationAudit
+
+		// The totals can't overflow as they're bounded by the sum of the
+		// allocations' totals, which is checked below.
+		audit.TotalInitialAmount += allocationAudit.InitialAmount
+		audit.TotalUnlockSchedule += allocationAudit.UnlockScheduleAmount
+		audit.TotalLocked += allocationAudit.LockedAmount
+		if allocationAudit.Staked {
+			audit.TotalStaked += allocationAudit.UnlockScheduleAmount
+		}
+		totalAllocated, err := safemath.Add64(uint64(audit.TotalAllocated), total)
+		if err != nil {
+			return nil, err
+		}
+		audit.TotalAllocated = json.Uint64(totalAllocated)
+	}
+
+	locktimes := make([]uint64, 0, len(unlocks))
+	for locktime := range unlocks {
+		locktimes = append(locktimes, locktime)
+	}
+	sort.Slice(locktimes, func(i, j int) bool { return locktimes[i] < locktimes[j] })
+	audit.UnlockSchedule = make([]UnlockAudit, len(locktimes))
+	var cumulativeAmount uint64
+	for i, locktime := range locktimes {
+		cumulativeAmount += unlocks[locktime]
+		audit.UnlockSchedule[i] = UnlockAudit{
+			Locktime:         json.Uint64(locktime),
+			Amount:           json.Uint64(unlocks[locktime]),
+			CumulativeAmount: json.Uint64(cumulativeAmount),
+		}
+	}
+
+	if config.CChainGenesis != "" {
+		audit.CChain, err = newCChainAudit(config.CChainGenesis)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't audit the C-chain genesis: %w", err)
+		}
+	}
+	return audit, nil
+}
+
+func newCChainAudit(genesisJSON string) (*CChainAudit, error) {
+	var genesis cChainGenesis
+	if err := stdjson.Unmarshal([]byte(genesisJSON), &genesis); err != nil {
+		return nil, err
+	}
+
+	audit := &CChainAudit{
+		ChainID: genesis.Config.ChainID,
+	}
+	totalBalance := new(big.Int)
+	for addr, account := range genesis.Alloc {
+		balance, err := parseCChainBalance(account.Balance)
+		if err != nil {
+			return nil, fmt.Errorf("%w of %s: %s", errInvalidCChainBalance, addr, err)
+		}
+		isContract := strings.TrimPrefix(account.Code, "0x") != ""
+		if balance.Sign() == 0 && !isContract {
+			continue
+		}
+
+		totalBalance.Add(totalBalance, balance)
+		if isContract {
+			audit.NumContracts++
+		}
+		audit.Accounts = append(audit.Accounts, CChainAccountAudit{
+			Address:  "0x" + strings.ToLower(strings.TrimPrefix(addr, "0x")),
+			Balance:  balance.String(),
+			Contract: isContract,
+		})
+	}
+	sort.Slice(audit.Accounts, func(i, j int) bool {
+		return audit.Accounts[i].Address < audit.Accounts[j].Address
+	})
+	audit.TotalBalance = totalBalance.String()
+	return audit, nil
+}
+
+// parseCChainBalance parses a hexadecimal balance prefixed with 0x, or a
+// decimal balance.
+func parseCChainBalance(balanceStr string) (*big.Int, error) {
+	base := 10
+	if strings.HasPrefix(balanceStr, "0x") {
+		balanceStr = strings.TrimPrefix(balanceStr, "0x")
+		base = 16
+	}
+	if balanceStr == "" {
+		return new(big.Int), nil
+	}
+	balance, ok := new(big.Int).SetString(balanceStr, base)
+	if !ok || balance.Sign() < 0 {
+		return nil, fmt.Errorf("%q isn't a non-negative integer", balanceStr)
+	}
+	return balance, nil
+}
@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestNewAudit(t *testing.T) {
+	require := require.New(t)
+
+	stakedAddr := ids.GenerateTestShortID()
+	config := &Config{
+		NetworkID: constants.FlareID,
+		StartTime: 100,
+		Allocations: []Allocation{
+			{
+				AVAXAddr:      ids.GenerateTestShortID(),
+				InitialAmount: 10,
+				UnlockSchedule: []LockedAmount{
+					{Amount: 1, Locktime: 100},
+					{Amount: 2, Locktime: 200},
+				},
+			},
+			{
+				AVAXAddr: stakedAddr,
+				UnlockSchedule: []LockedAmount{
+					{Amount: 4, Locktime: 200},
+				},
+			},
+		},
+		InitialStakedFunds: []ids.ShortID{stakedAddr},
+		CChainGenesis: `{
+			"config": {"chainId": 14},
+			"alloc": {
+				"0x00000000000000000000000000000000000000AA": {"balance": "0x10"},
+				"0000000000000000000000000000000000000001": {"balance": "0x0", "code": "0x6080"},
+				"0000000000000000000000000000000000000002": {"balance": "0x0"},
+				"0000000000000000000000000000000000000003": {"balance": "5"}
+			}
+		}`,
+	}
+
+	audit, err := NewAudit(config)
+	require.NoError(err)
+	require.Equal("flare", audit.NetworkName)
+	require.EqualValues(17, audit.InitialSupply)
+	require.EqualValues(17, audit.TotalAllocated)
+	require.EqualValues(10, audit.TotalInitialAmount)
+	require.EqualValues(7, audit.TotalUnlockSchedule)
+	require.EqualValues(6, audit.TotalLocked)
+	require.EqualValues(4, audit.TotalStaked)
+
+	require.Len(audit.Allocations, 2)
+	require.EqualValues(13, audit.Allocations[0].Total)
+	require.EqualValues(2, audit.Allocations[0].LockedAmount)
+	require.False(audit.Allocations[0].Staked)
+	require.True(audit.Allocations[1].Staked)
+
+	require.Equal([]UnlockAudit{
+		{Locktime: 100, Amount: 1, CumulativeAmount: 1},
+		{Locktime: 200, Amount: 6, CumulativeAmount: 7},
+	}, audit.UnlockSchedule)
+
+	require.Equal(&CChainAudit{
+		ChainID:      14,
+		TotalBalance: "21",
+		Accounts: []CChainAccountAudit{
+			{Address: "0x0000000000000000000000000000000000000001", Balance: "0", Contract: true},
+			{Address: "0x0000000000000000000000000000000000000003", Balance: "5"},
+			{Address: "0x00000000000000000000000000000000000000aa", Balance: "16"},
+		},
+		NumContracts: 1,
+	}, audit.CChain)
+}
+
+func TestNewAuditInvalidCChainBalance(t *testing.T) {
+	config := &Config{
+		NetworkID:     constants.FlareID,
+		CChainGenesis: `{"alloc": {"0000000000000000000000000000000000000001": {"balance": "0xzz"}}}`,
+	}
+	_, err := NewAudit(config)
+	require.ErrorIs(t, err, errInvalidCChainBalance)
+}
+
+func TestNewAuditEmbeddedGenesis(t *testing.T) {
+	for _, networkID := range []uint32{constants.FlareID, constants.CostwoID, constants.SongbirdID, constants.CostonID} {
+		t.Run(constants.NetworkName(networkID), func(t *testing.T) {
+			require := require.New(t)
+
+			audit, err := NewAudit(GetConfig(networkID))
+			require.NoError(err)
+			require.NotNil(audit.CChain)
+			if len(audit.Allocations) > 0 {
+				require.Equal(audit.InitialSupply, audit.TotalAllocated)
+				require.Equal(audit.TotalUnlockSchedule, audit.UnlockSchedule[len(audit.UnlockSchedule)-1].CumulativeAmount)
+			}
+		})
+	}
+}
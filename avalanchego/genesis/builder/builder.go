@@ -0,0 +1,143 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package builder builds genesis configs from a declarative YAML spec. The
+// same spec always produces the same genesis, so the genesis of a network can
+// be reviewed and reproduced from its spec.
+package builder
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Genesis is a genesis built from a spec
+type Genesis struct {
+	// Config is the genesis config in the format of the genesis file that
+	// nodes are started with
+	Config []byte
+	// ID of the genesis, which is the hash of the genesis bytes of the
+	// P-chain
+	ID          ids.ID
+	AVAXAssetID ids.ID
+}
+
+// Build validates [spec] and returns the genesis it describes
+func Build(spec *Spec) (*Genesis, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+	cChainGenesis, err := buildCChainGenesis(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	unparsedConfig := genesis.UnparsedConfig{
+		NetworkID:                  spec.NetworkID,
+		Allocations:                make([]genesis.UnparsedAllocation, len(spec.Allocations)),
+		StartTime:                  unixTime(spec.StartTime),
+		InitialStakeDuration:       uint64(spec.InitialStakeDuration.Seconds()),
+		InitialStakeDurationOffset: uint64(spec.InitialStakeDurationOffset.Seconds()),
+		InitialStakedFunds:         spec.InitialStakedFunds,
+		InitialStakers:             make([]genesis.UnparsedStaker, len(spec.InitialStakers)),
+		CChainGenesis:              cChainGenesis,
+		Message:                    spec.Message,
+	}
+	for i, allocation := range spec.Allocations {
+		unlockSchedule := make([]genesis.LockedAmount, len(allocation.UnlockSchedule))
+		for j, unlock := range allocation.UnlockSchedule {
+			unlockSchedule[j] = genesis.LockedAmount{
+				Amount:   unlock.Amount,
+				Locktime: unixTime(unlock.Locktime),
+			}
+		}
+		unparsedConfig.Allocations[i] = genesis.UnparsedAllocation{
+			ETHAddr:        allocation.ETHAddr,
+			AVAXAddr:       allocation.AVAXAddr,
+			InitialAmount:  allocation.InitialAmount,
+			UnlockSchedule: unlockSchedule,
+		}
+	}
+	for i, staker := range spec.InitialStakers {
+		// The node ID was checked by Validate
+		nodeID, _ := ids.NodeIDFromString(staker.NodeID)
+		unparsedConfig.InitialStakers[i] = genesis.UnparsedStaker{
+			NodeID:        nodeID,
+			RewardAddress: staker.RewardAddress,
+			DelegationFee: staker.DelegationFee,
+		}
+	}
+
+	config, err := unparsedConfig.Parse()
+	if err != nil {
+		return nil, err
+	}
+	if err := genesis.ValidateConfig(&config); err != nil {
+		return nil, fmt.Errorf("genesis config validation failed: %w", err)
+	}
+	genesisBytes, avaxAssetID, err := genesis.FromConfig(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	configBytes, err := json.MarshalIndent(unparsedConfig, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	return &Genesis{
+		Config:      append(configBytes, '\n'),
+		ID:          hashing.ComputeHash256Array(genesisBytes),
+		AVAXAssetID: avaxAssetID,
+	}, nil
+}
+
+// buildCChainGenesis returns the compact JSON of the C-chain genesis of
+// [spec]
+func buildCChainGenesis(spec *Spec) (string, error) {
+	var (
+		cChainGenesis []byte
+		err           error
+	)
+	if spec.CChainGenesisFile != "" {
+		cChainGenesis, err = os.ReadFile(spec.CChainGenesisFile)
+	} else {
+		// Map keys are sorted when marshalled, so the output is deterministic
+		cChainGenesis, err = json.Marshal(spec.CChainGenesis)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cChainGenesis: %w", err)
+	}
+
+	var parsed struct {
+		Config struct {
+			ChainID *json.Number `json:"chainId"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(cChainGenesis, &parsed); err != nil {
+		return "", fmt.Errorf("cChainGenesis: %w", err)
+	}
+	if parsed.Config.ChainID == nil {
+		return "", fmt.Errorf("cChainGenesis: %w", errCChainGenesisConfig)
+	}
+
+	compacted := &bytes.Buffer{}
+	if err := json.Compact(compacted, cChainGenesis); err != nil {
+		return "", fmt.Errorf("cChainGenesis: %w", err)
+	}
+	return compacted.String(), nil
+}
+
+// unixTime returns [t] in seconds since the unix epoch, or 0 if [t] is unset
+func unixTime(t time.Time) uint64 {
+	if t.IsZero() {
+		return 0
+	}
+	return uint64(t.Unix())
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"encoding/base64"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/perms"
+)
+
+var updateGolden = flag.Bool("update", false, "overwrite the golden files in testdata")
+
+func TestBuildGolden(t *testing.T) {
+	require := require.New(t)
+
+	spec, err := Load(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(err)
+	built, err := Build(spec)
+	require.NoError(err)
+
+	goldenPath := filepath.Join("testdata", "genesis.json")
+	idPath := filepath.Join("testdata", "genesis.id")
+	if *updateGolden {
+		require.NoError(os.WriteFile(goldenPath, built.Config, perms.ReadWrite))
+		require.NoError(os.WriteFile(idPath, []byte(built.ID.String()+"\n"), perms.ReadWrite))
+	}
+
+	expectedConfig, err := os.ReadFile(goldenPath)
+	require.NoError(err)
+	require.Equal(string(expectedConfig), string(built.Config))
+	expectedID, err := os.ReadFile(idPath)
+	require.NoError(err)
+	require.Equal(string(expectedID), built.ID.String()+"\n")
+
+	// The built config is accepted by nodes and describes the same genesis
+	genesisBytes, avaxAssetID, err := genesis.FromFlag(spec.NetworkID, base64.StdEncoding.EncodeToString(built.Config))
+	require.NoError(err)
+	require.Equal(built.AVAXAssetID, avaxAssetID)
+	require.EqualValues(built.ID, hashing.ComputeHash256Array(genesisBytes))
+
+	// Building again yields the same genesis
+	rebuilt, err := Build(spec)
+	require.NoError(err)
+	require.Equal(built, rebuilt)
+}
+
+func TestBuildInlineCChainGenesis(t *testing.T) {
+	require := require.New(t)
+
+	fromFile, err := Load(filepath.Join("testdata", "spec.yaml"))
+	require.NoError(err)
+	cChainGenesis, err := buildCChainGenesis(fromFile)
+	require.NoError(err)
+
+	inline := *fromFile
+	inline.CChainGenesisFile = ""
+	inline.CChainGenesis = map[string]interface{}{
+		"config": map[string]interface{}{"chainId": 43112},
+	}
+	_, err = Build(&inline)
+	require.NoError(err)
+
+	inlineCChainGenesis, err := buildCChainGenesis(&inline)
+	require.NoError(err)
+	require.Equal(`{"config":{"chainId":43112}}`, inlineCChainGenesis)
+	require.NotEqual(cChainGenesis, inlineCChainGenesis)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expectedErr string
+	}{
+		{
+			name:        "unknown field",
+			spec:        "networkID: 1337\nstartTim: 2022-09-01T00:00:00Z\n",
+			expectedErr: "field startTim not found",
+		},
+		{
+			name:        "invalid duration",
+			spec:        "initialStakeDuration: a year\n",
+			expectedErr: "cannot unmarshal",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Parse([]byte(test.spec))
+			require.ErrorContains(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(*Spec)
+		expectedErr error
+		field       string
+	}{
+		{
+			name:        "standard network",
+			modify:      func(s *Spec) { s.NetworkID = 14 },
+			expectedErr: errStandardNetwork,
+			field:       "networkID",
+		},
+		{
+			name:        "start time before epoch",
+			modify:      func(s *Spec) { s.StartTime = s.StartTime.AddDate(-2000, 0, 0) },
+			expectedErr: errBeforeEpoch,
+			field:       "startTime",
+		},
+		{
+			name:        "short eth address",
+			modify:      func(s *Spec) { s.Allocations[0].ETHAddr = "0xb3d8" },
+			expectedErr: errInvalidETHAddress,
+			field:       "allocations[0].ethAddr",
+		},
+		{
+			name: "address of another network",
+			modify: func(s *Spec) {
+				s.InitialStakers[1].RewardAddress = "X-localflare18jma8ppw3nhx5r4ap8clazz0dps7rv5uj3gy4v"
+			},
+			expectedErr: errWrongHRP,
+			field:       "initialStakers[1].rewardAddress",
+		},
+		{
+			name: "unsorted unlock schedule",
+			modify: func(s *Spec) {
+				schedule := s.Allocations[0].UnlockSchedule
+				schedule[0], schedule[1] = schedule[1], schedule[0]
+			},
+			expectedErr: errUnsortedUnlocks,
+			field:       "allocations[0].unlockSchedule[1].locktime",
+		},
+		{
+			name: "two C-chain genesis sources",
+			modify: func(s *Spec) {
+				s.CChainGenesis = map[string]interface{}{"config": map[string]interface{}{}}
+			},
+			expectedErr: errBothCChainGenesis,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			spec, err := Load(filepath.Join("testdata", "spec.yaml"))
+			require.NoError(err)
+			test.modify(spec)
+
+			_, err = Build(spec)
+			require.ErrorIs(err, test.expectedErr)
+			require.ErrorContains(err, test.field)
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/formatting/address"
+)
+
+var (
+	errMissingField        = errors.New("missing required field")
+	errInvalidETHAddress   = errors.New("expected a 0x prefixed 20 byte hex address")
+	errWrongHRP            = errors.New("address doesn't belong to the network")
+	errUnsortedUnlocks     = errors.New("unlock schedule isn't sorted by locktime")
+	errZeroUnlock          = errors.New("unlock amount must be > 0")
+	errBeforeEpoch         = errors.New("time is before the unix epoch")
+	errBothCChainGenesis   = errors.New("cChainGenesis and cChainGenesisFile are mutually exclusive")
+	errStandardNetwork     = errors.New("cannot build the genesis of a standard network")
+	errCChainGenesisConfig = errors.New("C-chain genesis must contain config.chainId")
+)
+
+// Spec is the declarative description of a genesis. Amounts are in nAVAX and
+// addresses use the HRP of the network.
+type Spec struct {
+	NetworkID uint32 `yaml:"networkID"`
+	// Time of the genesis. It mustn't be in the future when the genesis is
+	// built, as nodes refuse to start from such a genesis.
+	StartTime time.Time `yaml:"startTime"`
+	// Duration that the initial stakers validate for, such as "8760h"
+	InitialStakeDuration time.Duration `yaml:"initialStakeDuration"`
+	// Difference between the end times of consecutive initial stakers
+	InitialStakeDurationOffset time.Duration `yaml:"initialStakeDurationOffset"`
+
+	Allocations []Allocation `yaml:"allocations"`
+	// Addresses whose locked funds back the initial stakers
+	InitialStakedFunds []string `yaml:"initialStakedFunds"`
+	InitialStakers     []Staker `yaml:"initialStakers"`
+
+	// Genesis of the C-chain, either inline or as a path to a JSON file.
+	// Load resolves a relative path against the directory of the spec.
+	CChainGenesis     map[string]interface{} `yaml:"cChainGenesis"`
+	CChainGenesisFile string                 `yaml:"cChainGenesisFile"`
+
+	Message string `yaml:"message"`
+}
+
+// Allocation is the initial balance of an address
+type Allocation struct {
+	ETHAddr  string `yaml:"ethAddr"`
+	AVAXAddr string `yaml:"avaxAddr"`
+	// Amount that is spendable on the X-chain at genesis
+	InitialAmount  uint64   `yaml:"initialAmount"`
+	UnlockSchedule []Unlock `yaml:"unlockSchedule"`
+}
+
+// Unlock is an amount that is locked on the P-chain until [Locktime]. An
+// unset locktime leaves the amount unlocked, which is how funds that are only
+// staked are described.
+type Unlock struct {
+	Amount   uint64    `yaml:"amount"`
+	Locktime time.Time `yaml:"locktime"`
+}
+
+// Staker is a validator of the primary network at genesis
+type Staker struct {
+	NodeID        string `yaml:"nodeID"`
+	RewardAddress string `yaml:"rewardAddress"`
+	// Fee charged to delegators, in units of 1/10,000th of a percent
+	DelegationFee uint32 `yaml:"delegationFee"`
+}
+
+// Parse decodes a YAML spec. Unknown fields are rejected so that a mistyped
+// field doesn't silently fall back to its zero value.
+func Parse(specBytes []byte) (*Spec, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(specBytes))
+	decoder.KnownFields(true)
+
+	spec := &Spec{}
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("couldn't decode spec: %w", err)
+	}
+	return spec, nil
+}
+
+// Load reads and decodes the spec at [path]
+func Load(path string) (*Spec, error) {
+	specBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := Parse(specBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if spec.CChainGenesisFile != "" && !filepath.IsAbs(spec.CChainGenesisFile) {
+		spec.CChainGenesisFile = filepath.Join(filepath.Dir(path), spec.CChainGenesisFile)
+	}
+	return spec, nil
+}
+
+// Validate checks the fields of the spec that the genesis config itself
+// doesn't check. Errors name the offending field.
+func (s *Spec) Validate() error {
+	switch s.NetworkID {
+	case constants.FlareID, constants.SongbirdID, constants.CostwoID, constants.CostonID, constants.LocalFlareID, constants.LocalID:
+		return fmt.Errorf("networkID: %w %s (%d)", errStandardNetwork, constants.NetworkName(s.NetworkID), s.NetworkID)
+	}
+	switch {
+	case s.StartTime.IsZero():
+		return fmt.Errorf("startTime: %w", errMissingField)
+	case s.StartTime.Unix() < 0:
+		return fmt.Errorf("startTime: %w", errBeforeEpoch)
+	}
+	if s.InitialStakeDuration <= 0 {
+		return fmt.Errorf("initialStakeDuration: %w", errMissingField)
+	}
+
+	hrp := constants.GetHRP(s.NetworkID)
+	for i, allocation := range s.Allocations {
+		field := fmt.Sprintf("allocations[%d]", i)
+		if err := validateETHAddress(allocation.ETHAddr); err != nil {
+			return fmt.Errorf("%s.ethAddr: %w", field, err)
+		}
+		if err := validateAddress(hrp, allocation.AVAXAddr); err != nil {
+			return fmt.Errorf("%s.avaxAddr: %w", field, err)
+		}
+		for j, unlock := range allocation.UnlockSchedule {
+			switch {
+			case unlock.Amount == 0:
+				return fmt.Errorf("%s.unlockSchedule[%d].amount: %w", field, j, errZeroUnlock)
+			case !unlock.Locktime.IsZero() && unlock.Locktime.Unix() < 0:
+				return fmt.Errorf("%s.unlockSchedule[%d].locktime: %w", field, j, errBeforeEpoch)
+			case j > 0 && unlock.Locktime.Before(allocation.UnlockSchedule[j-1].Locktime):
+				return fmt.Errorf("%s.unlockSchedule[%d].locktime: %w", field, j, errUnsortedUnlocks)
+			}
+		}
+	}
+	for i, addr := range s.InitialStakedFunds {
+		if err := validateAddress(hrp, addr); err != nil {
+			return fmt.Errorf("initialStakedFunds[%d]: %w", i, err)
+		}
+	}
+	for i, staker := range s.InitialStakers {
+		field := fmt.Sprintf("initialStakers[%d]", i)
+		if _, err := ids.NodeIDFromString(staker.NodeID); err != nil {
+			return fmt.Errorf("%s.nodeID: %w", field, err)
+		}
+		if err := validateAddress(hrp, staker.RewardAddress); err != nil {
+			return fmt.Errorf("%s.rewardAddress: %w", field, err)
+		}
+	}
+
+	switch {
+	case len(s.CChainGenesis) != 0 && s.CChainGenesisFile != "":
+		return errBothCChainGenesis
+	case len(s.CChainGenesis) == 0 && s.CChainGenesisFile == "":
+		return fmt.Errorf("cChainGenesis: %w", errMissingField)
+	}
+	return nil
+}
+
+func validateETHAddress(addr string) error {
+	if len(addr) != 42 || !strings.HasPrefix(addr, "0x") {
+		return errInvalidETHAddress
+	}
+	if _, err := hex.DecodeString(addr[2:]); err != nil {
+		return errInvalidETHAddress
+	}
+	return nil
+}
+
+func validateAddress(hrp string, addr string) error {
+	_, addrHRP, _, err := address.Parse(addr)
+	if err != nil {
+		return err
+	}
+	if addrHRP != hrp {
+		return fmt.Errorf("%w: expected HRP %q but got %q", errWrongHRP, hrp, addrHRP)
+	}
+	return nil
+}
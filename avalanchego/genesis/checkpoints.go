@@ -0,0 +1,44 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package genesis
+
+import (
+	"encoding/json"
+	"fmt"
+
+	_ "embed"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+var (
+	// checkpointsJSON maps a network name to the checkpoints of its chains,
+	// keyed by chain alias.
+	//go:embed checkpoints.json
+	checkpointsJSON []byte
+)
+
+// Checkpoint is an accepted block that a node may trust when bootstrapping.
+// Bootstrapping fails if the block fetched at the height of a trusted
+// checkpoint isn't the checkpoint.
+type Checkpoint struct {
+	ID     ids.ID `json:"id"`
+	Height uint64 `json:"height"`
+}
+
+// GetCheckpoints returns the built-in checkpoints of the chains of the
+// network with ID [networkID], keyed by chain alias.
+func GetCheckpoints(networkID uint32) (map[string]Checkpoint, error) {
+	networkCheckpoints := make(map[string]map[string]Checkpoint)
+	if err := json.Unmarshal(checkpointsJSON, &networkCheckpoints); err != nil {
+		return nil, fmt.Errorf("couldn't parse built-in checkpoints: %w", err)
+	}
+
+	checkpoints := networkCheckpoints[constants.NetworkName(networkID)]
+	if checkpoints == nil {
+		checkpoints = make(map[string]Checkpoint)
+	}
+	return checkpoints, nil
+}
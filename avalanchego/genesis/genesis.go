@@ -104,6 +104,12 @@ func validateInitialStakedFunds(config *Config) error {
 	return nil
 }
 
+// ValidateConfig returns an error if the provided
+// *Config is not considered valid for its own network ID.
+func ValidateConfig(config *Config) error {
+	return validateConfig(config.NetworkID, config)
+}
+
 // validateConfig returns an error if the provided
 // *Config is not considered valid.
 func validateConfig(networkID uint32, config *Config) error {
@@ -467,3 +467,13 @@ func TestAVAXAssetID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCheckpoints(t *testing.T) {
+	require := require.New(t)
+
+	for _, networkID := range []uint32{constants.FlareID, constants.SongbirdID, constants.CostwoID, constants.CostonID, constants.LocalID} {
+		checkpoints, err := GetCheckpoints(networkID)
+		require.NoError(err)
+		require.NotNil(checkpoints)
+	}
+}
@@ -6,6 +6,7 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/formatting"
@@ -22,6 +23,10 @@ type Client interface {
 	// If [startIndex] > the last accepted index, returns an error (unless the above apply.)
 	// If we run out of transactions, returns the ones fetched before running out.
 	GetContainerRange(ctx context.Context, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, error)
+	// GetContainersByTimeRange returns up to [numToFetch] containers, in order
+	// of acceptance, that were accepted in [startTime, endTime] at or after
+	// [startIndex], and the index to continue the search from.
+	GetContainersByTimeRange(ctx context.Context, startTime, endTime time.Time, startIndex uint64, numToFetch int, options ...rpc.Option) ([]Container, uint64, error)
 	// Get a container by its index
 	GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error)
 	// Get the most recently accepted container
@@ -32,6 +37,8 @@ type Client interface {
 	IsAccepted(ctx context.Context, containerID ids.ID, options ...rpc.Option) (bool, error)
 	// Get a container by its index
 	GetContainerByID(ctx context.Context, containerID ids.ID, options ...rpc.Option) (Container, error)
+	// Get a block by its height. Only supported by block indices.
+	GetContainerByHeight(ctx context.Context, height uint64, options ...rpc.Option) (Container, error)
 }
 
 // Client implementation for Avalanche Indexer API Endpoint
@@ -77,6 +84,41 @@ func (c *client) GetContainerRange(ctx context.Context, startIndex uint64, numTo
 	return response, nil
 }
 
+func (c *client) GetContainersByTimeRange(
+	ctx context.Context,
+	startTime time.Time,
+	endTime time.Time,
+	startIndex uint64,
+	numToFetch int,
+	options ...rpc.Option,
+) ([]Container, uint64, error) {
+	var fcs GetContainersByTimeRangeResponse
+	err := c.requester.SendRequest(ctx, "getContainersByTimeRange", &GetContainersByTimeRangeArgs{
+		StartTime:  startTime,
+		EndTime:    endTime,
+		StartIndex: json.Uint64(startIndex),
+		NumToFetch: json.Uint64(numToFetch),
+		Encoding:   formatting.Hex,
+	}, &fcs, options...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	response := make([]Container, len(fcs.Containers))
+	for i, resp := range fcs.Containers {
+		containerBytes, err := formatting.Decode(resp.Encoding, resp.Bytes)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't decode container %s: %w", resp.ID, err)
+		}
+		response[i] = Container{
+			ID:        resp.ID,
+			Timestamp: resp.Timestamp.Unix(),
+			Bytes:     containerBytes,
+		}
+	}
+	return response, uint64(fcs.NextIndex), nil
+}
+
 func (c *client) GetContainerByIndex(ctx context.Context, index uint64, options ...rpc.Option) (Container, error) {
 	var fc FormattedContainer
 	err := c.requester.SendRequest(ctx, "getContainerByIndex", &GetContainerByIndexArgs{
@@ -154,3 +196,24 @@ func (c *client) GetContainerByID(ctx context.Context, id ids.ID, options ...rpc
 		Bytes:     containerBytes,
 	}, nil
 }
+
+func (c *client) GetContainerByHeight(ctx context.Context, height uint64, options ...rpc.Option) (Container, error) {
+	var fc FormattedContainer
+	err := c.requester.SendRequest(ctx, "getContainerByHeight", &GetContainerByHeightArgs{
+		Height:   json.Uint64(height),
+		Encoding: formatting.Hex,
+	}, &fc, options...)
+	if err != nil {
+		return Container{}, err
+	}
+
+	containerBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+	if err != nil {
+		return Container{}, fmt.Errorf("couldn't decode container %s: %w", fc.ID, err)
+	}
+	return Container{
+		ID:        fc.ID,
+		Timestamp: fc.Timestamp.Unix(),
+		Bytes:     containerBytes,
+	}, nil
+}
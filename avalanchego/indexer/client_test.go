@@ -6,6 +6,7 @@ package indexer
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -88,6 +89,33 @@ func TestIndexClient(t *testing.T) {
 		require.EqualValues(id, containers[0].ID)
 		require.EqualValues(bytes, containers[0].Bytes)
 	}
+	{
+		// Test GetContainersByTimeRange
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "getContainersByTimeRange",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*GetContainersByTimeRangeResponse)) = GetContainersByTimeRangeResponse{
+					Containers: []FormattedContainer{{
+						ID:    id,
+						Bytes: bytesStr,
+					}},
+					NextIndex: 2,
+				}
+				return nil
+			},
+		}
+		containers, next, err := client.GetContainersByTimeRange(context.Background(), time.Unix(0, 0), time.Unix(10, 0), 1, 10)
+		require.NoError(err)
+		require.Len(containers, 1)
+		require.EqualValues(2, next)
+		require.EqualValues(id, containers[0].ID)
+		require.EqualValues(bytes, containers[0].Bytes)
+	}
 	{
 		// Test IsAccepted
 		client.requester = &mockClient{
@@ -124,4 +152,26 @@ func TestIndexClient(t *testing.T) {
 		require.EqualValues(id, container.ID)
 		require.EqualValues(bytes, container.Bytes)
 	}
+	{
+		// Test GetContainerByHeight
+		id := ids.GenerateTestID()
+		bytes := utils.RandomBytes(10)
+		bytesStr, err := formatting.Encode(formatting.Hex, bytes)
+		require.NoError(err)
+		client.requester = &mockClient{
+			require:        require,
+			expectedMethod: "getContainerByHeight",
+			onSendRequestF: func(reply interface{}) error {
+				*(reply.(*FormattedContainer)) = FormattedContainer{
+					ID:    id,
+					Bytes: bytesStr,
+				}
+				return nil
+			},
+		}
+		container, err := client.GetContainerByHeight(context.Background(), 5)
+		require.NoError(err)
+		require.EqualValues(id, container.ID)
+		require.EqualValues(bytes, container.Bytes)
+	}
 }
@@ -12,6 +12,8 @@ type Container struct {
 	ID ids.ID `serialize:"true"`
 	// Byte representation of this container
 	Bytes []byte `serialize:"true"`
-	// Unix time, in nanoseconds, at which this container was accepted by this node
+	// Unix time, in nanoseconds, at which this container was accepted by this
+	// node. This is local time rather than the container's own timestamp, so
+	// containers that were bootstrapped have the time they were bootstrapped.
 	Timestamp int64 `serialize:"true"`
 }
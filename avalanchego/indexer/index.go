@@ -34,12 +34,20 @@ var (
 	nextAcceptedIndexKey   = []byte{0x00}
 	indexToContainerPrefix = []byte{0x01}
 	containerToIDPrefix    = []byte{0x02}
+	streamCursorPrefix     = []byte{0x03}
+	heightToIndexPrefix    = []byte{0x04}
 	errNoneAccepted        = errors.New("no containers have been accepted")
+	errHeightsNotIndexed   = errors.New("containers aren't indexed by height")
 	errNumToFetchZero      = fmt.Errorf("numToFetch must be in [1,%d]", MaxFetchedByRange)
+	errInvalidTimeRange    = errors.New("start time is after end time")
 
 	_ Index = &index{}
 )
 
+// heightFunc returns the height of the container whose bytes are
+// [containerBytes].
+type heightFunc func(containerBytes []byte) (uint64, error)
+
 // Index indexes containers in their order of acceptance
 // Index is thread-safe.
 // Index assumes that Accept is called before the container is committed to the
@@ -48,9 +56,11 @@ type Index interface {
 	snow.Acceptor
 	GetContainerByIndex(index uint64) (Container, error)
 	GetContainerRange(startIndex uint64, numToFetch uint64) ([]Container, error)
+	GetContainersByTimeRange(startTime, endTime int64, startIndex, numToFetch uint64) ([]Container, uint64, error)
 	GetLastAccepted() (Container, error)
 	GetIndex(id ids.ID) (uint64, error)
 	GetContainerByID(id ids.ID) (Container, error)
+	GetContainerByHeight(height uint64) (Container, error)
 	io.Closer
 }
 
@@ -61,6 +71,9 @@ type index struct {
 	lock  sync.RWMutex
 	// The index of the next accepted transaction
 	nextAcceptedIndex uint64
+	// The timestamp of the last accepted transaction. Accepted transactions are
+	// never given an earlier timestamp, even if the local clock steps back.
+	lastAcceptedTimestamp int64
 	// When [baseDB] is committed, writes to [baseDB]
 	vDB    *versiondb.Database
 	baseDB database.Database
@@ -69,20 +82,33 @@ type index struct {
 	indexToContainer database.Database
 	// Container ID --> Index
 	containerToIndex database.Database
-	log              logging.Logger
+	// Stream cursor name --> Index of the next container to stream
+	streamCursors database.Database
+	// Container height --> Index
+	heightToIndex database.Database
+	// Returns the height of an accepted container. Nil if the containers don't
+	// have heights.
+	heightOf heightFunc
+	log      logging.Logger
+	// Pushes accepted containers to websocket subscribers
+	stream *stream
 }
 
 // Returns a new, thread-safe Index.
 // Closes [baseDB] on close.
+// If [heightOf] isn't nil, accepted containers are also indexed by height.
 func newIndex(
 	baseDB database.Database,
 	log logging.Logger,
 	codec codec.Manager,
 	clock mockable.Clock,
-) (Index, error) {
+	heightOf heightFunc,
+) (*index, error) {
 	vDB := versiondb.New(baseDB)
 	indexToContainer := prefixdb.New(indexToContainerPrefix, vDB)
 	containerToIndex := prefixdb.New(containerToIDPrefix, vDB)
+	streamCursors := prefixdb.New(streamCursorPrefix, vDB)
+	heightToIndex := prefixdb.New(heightToIndexPrefix, vDB)
 
 	i := &index{
 		clock:            clock,
@@ -91,8 +117,12 @@ func newIndex(
 		vDB:              vDB,
 		indexToContainer: indexToContainer,
 		containerToIndex: containerToIndex,
+		streamCursors:    streamCursors,
+		heightToIndex:    heightToIndex,
+		heightOf:         heightOf,
 		log:              log,
 	}
+	i.stream = newStream(log, i)
 
 	// Get next accepted index from db
 	nextAcceptedIndex, err := database.GetUInt64(i.vDB, nextAcceptedIndexKey)
@@ -107,6 +137,12 @@ func newIndex(
 		return nil, fmt.Errorf("couldn't get next accepted index from database: %w", err)
 	}
 	i.nextAcceptedIndex = nextAcceptedIndex
+
+	lastAccepted, err := i.getContainerByIndex(nextAcceptedIndex - 1)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get last accepted container: %w", err)
+	}
+	i.lastAcceptedTimestamp = lastAccepted.Timestamp
 	i.log.Info("created new index",
 		zap.Uint64("nextAcceptedIndex", i.nextAcceptedIndex),
 	)
@@ -115,10 +151,14 @@ func newIndex(
 
 // Close this index
 func (i *index) Close() error {
+	i.stream.Close()
+
 	errs := wrappers.Errs{}
 	errs.Add(
 		i.indexToContainer.Close(),
 		i.containerToIndex.Close(),
+		i.streamCursors.Close(),
+		i.heightToIndex.Close(),
 		i.vDB.Close(),
 		i.baseDB.Close(),
 	)
@@ -152,12 +192,19 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		zap.Stringer("containerID", containerID),
 	)
 	// Persist index --> Container
-	nextAcceptedIndexBytes := database.PackUInt64(i.nextAcceptedIndex)
-	bytes, err := i.codec.Marshal(codecVersion, Container{
+	acceptedIndex := i.nextAcceptedIndex
+	nextAcceptedIndexBytes := database.PackUInt64(acceptedIndex)
+	timestamp := i.clock.Time().UnixNano()
+	if timestamp < i.lastAcceptedTimestamp {
+		// Keep timestamps non-decreasing so they can be searched.
+		timestamp = i.lastAcceptedTimestamp
+	}
+	container := Container{
 		ID:        containerID,
 		Bytes:     containerBytes,
-		Timestamp: i.clock.Time().UnixNano(),
-	})
+		Timestamp: timestamp,
+	}
+	bytes, err := i.codec.Marshal(codecVersion, container)
 	if err != nil {
 		return fmt.Errorf("couldn't serialize container %s: %w", containerID, err)
 	}
@@ -170,6 +217,21 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 		return fmt.Errorf("couldn't map container %s to index: %w", containerID, err)
 	}
 
+	// Persist height --> index
+	if i.heightOf != nil {
+		height, err := i.heightOf(containerBytes)
+		if err != nil {
+			// The container is still indexed, it just can't be looked up by
+			// height.
+			ctx.Log.Warn("couldn't get height of container",
+				zap.Stringer("containerID", containerID),
+				zap.Error(err),
+			)
+		} else if err := i.heightToIndex.Put(database.PackUInt64(height), nextAcceptedIndexBytes); err != nil {
+			return fmt.Errorf("couldn't map height %d to container %s: %w", height, containerID, err)
+		}
+	}
+
 	// Persist next accepted index
 	i.nextAcceptedIndex++
 	if err := database.PutUInt64(i.vDB, nextAcceptedIndexKey, i.nextAcceptedIndex); err != nil {
@@ -177,7 +239,13 @@ func (i *index) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container
 	}
 
 	// Atomically commit [i.vDB], [i.indexToContainer], [i.containerToIndex] to [i.baseDB]
-	return i.vDB.Commit()
+	if err := i.vDB.Commit(); err != nil {
+		return err
+	}
+	i.lastAcceptedTimestamp = timestamp
+
+	i.stream.publish(container, acceptedIndex)
+	return nil
 }
 
 // Returns the ID of the [index]th accepted container and the container itself.
@@ -258,6 +326,73 @@ func (i *index) GetContainerRange(startIndex, numToFetch uint64) ([]Container, e
 	return containers, nil
 }
 
+// GetContainersByTimeRange returns up to [numToFetch] containers, in order of
+// acceptance, that were accepted by this node in [startTime, endTime], along
+// with the index to pass as [startIndex] to fetch the containers that follow.
+// Fewer than [numToFetch] containers are returned only if there are no more.
+// [startTime] and [endTime] are Unix times in nanoseconds.
+// [startIndex] is the index to start the search at.
+// [numToFetch] should be in [0, MaxFetchedByRange]
+//
+// Containers are timestamped with the local time at which they were accepted,
+// which is not the block timestamp; containers accepted while bootstrapping
+// have the time at which they were bootstrapped. Timestamps are kept
+// non-decreasing when the local clock steps back. Containers indexed before
+// this was the case may be out of order around a clock step; the ones that
+// aren't in the range are skipped, and the ones that are may be missed.
+func (i *index) GetContainersByTimeRange(startTime, endTime int64, startIndex, numToFetch uint64) ([]Container, uint64, error) {
+	// Check arguments for validity
+	if numToFetch == 0 {
+		return nil, 0, errNumToFetchZero
+	} else if numToFetch > MaxFetchedByRange {
+		return nil, 0, fmt.Errorf("requested %d but maximum page size is %d", numToFetch, MaxFetchedByRange)
+	} else if startTime > endTime {
+		return nil, 0, errInvalidTimeRange
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	if _, ok := i.lastAcceptedIndex(); !ok {
+		return nil, 0, errNoneAccepted
+	}
+	if startIndex >= i.nextAcceptedIndex {
+		return []Container{}, startIndex, nil
+	}
+
+	// Binary search for the first container accepted at or after [startTime]
+	low, high := startIndex, i.nextAcceptedIndex
+	for low < high {
+		mid := low + (high-low)/2
+		container, err := i.getContainerByIndex(mid)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get container at index %d: %w", mid, err)
+		}
+		if container.Timestamp < startTime {
+			low = mid + 1
+		} else {
+			high = mid
+		}
+	}
+
+	containers := []Container{}
+	next := low
+	for ; next < i.nextAcceptedIndex && uint64(len(containers)) < numToFetch; next++ {
+		container, err := i.getContainerByIndex(next)
+		if err != nil {
+			return nil, 0, fmt.Errorf("couldn't get container at index %d: %w", next, err)
+		}
+		if container.Timestamp > endTime {
+			break
+		}
+		if container.Timestamp < startTime {
+			continue
+		}
+		containers = append(containers, container)
+	}
+	return containers, next, nil
+}
+
 // Returns database.ErrNotFound if the container is not indexed as accepted
 func (i *index) GetIndex(id ids.ID) (uint64, error) {
 	i.lock.RLock()
@@ -278,6 +413,24 @@ func (i *index) GetContainerByID(id ids.ID) (Container, error) {
 	return i.getContainerByIndexBytes(indexBytes)
 }
 
+// GetContainerByHeight returns the accepted container at [height].
+// Returns database.ErrNotFound if no container at [height] was indexed.
+// Containers accepted before heights were indexed can't be looked up by height.
+func (i *index) GetContainerByHeight(height uint64) (Container, error) {
+	if i.heightOf == nil {
+		return Container{}, errHeightsNotIndexed
+	}
+
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	indexBytes, err := i.heightToIndex.Get(database.PackUInt64(height))
+	if err != nil {
+		return Container{}, err
+	}
+	return i.getContainerByIndexBytes(indexBytes)
+}
+
 // GetLastAccepted returns the last accepted container.
 // Returns an error if no containers have been accepted.
 func (i *index) GetLastAccepted() (Container, error) {
@@ -292,6 +445,45 @@ func (i *index) GetLastAccepted() (Container, error) {
 }
 
 // Assumes i.lock is held
+// containersFrom returns up to [MaxFetchedByRange] containers, in order of
+// acceptance, starting at [startIndex]. No containers are returned if
+// [startIndex] wasn't accepted yet.
+func (i *index) containersFrom(startIndex uint64) ([]Container, error) {
+	i.lock.RLock()
+	nextAcceptedIndex := i.nextAcceptedIndex
+	i.lock.RUnlock()
+
+	if startIndex >= nextAcceptedIndex {
+		return nil, nil
+	}
+	return i.GetContainerRange(startIndex, MaxFetchedByRange)
+}
+
+// getStreamCursor returns the index of the next container to stream to the
+// subscriber named [name], or false if it never acknowledged a container.
+func (i *index) getStreamCursor(name string) (uint64, bool, error) {
+	i.lock.RLock()
+	defer i.lock.RUnlock()
+
+	next, err := database.GetUInt64(i.streamCursors, []byte(name))
+	if err == database.ErrNotFound {
+		return 0, false, nil
+	}
+	return next, err == nil, err
+}
+
+// putStreamCursor persists that [next] is the index of the next container to
+// stream to the subscriber named [name].
+func (i *index) putStreamCursor(name string, next uint64) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if err := database.PutUInt64(i.streamCursors, []byte(name), next); err != nil {
+		return err
+	}
+	return i.vDB.Commit()
+}
+
 // Returns:
 // 1) The index of the most recently accepted transaction,
 //    or 0 if no transactions have been accepted
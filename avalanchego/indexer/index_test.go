@@ -4,12 +4,15 @@
 package indexer
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/codec"
 	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
@@ -30,9 +33,8 @@ func TestIndex(t *testing.T) {
 	db := versiondb.New(baseDB)
 	ctx := snow.DefaultConsensusContextTest()
 
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
 	require.NoError(err)
-	idx := indexIntf.(*index)
 
 	// Populate "containers" with random IDs/bytes
 	containers := map[ids.ID][]byte{}
@@ -84,9 +86,8 @@ func TestIndex(t *testing.T) {
 	require.NoError(db.Commit())
 	require.NoError(idx.Close())
 	db = versiondb.New(baseDB)
-	indexIntf, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
 	require.NoError(err)
-	idx = indexIntf.(*index)
 
 	// Get all of the containers
 	containersList, err := idx.GetContainerRange(0, pageSize)
@@ -119,9 +120,8 @@ func TestIndexGetContainerByRangeMaxPageSize(t *testing.T) {
 	require.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	indexIntf, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
 	require.NoError(err)
-	idx := indexIntf.(*index)
 
 	// Insert [MaxFetchedByRange] + 1 containers
 	for i := uint64(0); i < MaxFetchedByRange+1; i++ {
@@ -161,7 +161,7 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	db := memdb.New()
 	ctx := snow.DefaultConsensusContextTest()
-	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{})
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
 	require.NoError(err)
 
 	// Accept the same container twice
@@ -174,3 +174,171 @@ func TestDontIndexSameContainerTwice(t *testing.T) {
 	require.NoError(err)
 	require.EqualValues(gotContainer.Bytes, []byte{1, 2, 3}, "should not have accepted same container twice")
 }
+
+func TestIndexGetContainersByTimeRange(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	require.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
+	require.NoError(err)
+
+	_, _, err = idx.GetContainersByTimeRange(0, 1, 0, 1)
+	require.ErrorIs(err, errNoneAccepted)
+
+	// Accept 2 containers at each of the times 10, 20, ..., 100
+	containerIDs := []ids.ID{}
+	for i := 1; i <= 10; i++ {
+		idx.clock.Set(time.Unix(0, int64(10*i)))
+		for j := 0; j < 2; j++ {
+			containerID := ids.GenerateTestID()
+			require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+			containerIDs = append(containerIDs, containerID)
+		}
+	}
+
+	containers, next, err := idx.GetContainersByTimeRange(15, 40, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 6)
+	for i, container := range containers {
+		require.Equal(containerIDs[i+2], container.ID)
+	}
+	require.EqualValues(8, next)
+
+	// Bounds are inclusive
+	containers, _, err = idx.GetContainersByTimeRange(20, 20, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 2)
+	require.Equal(containerIDs[2], containers[0].ID)
+
+	// Page size is respected and the search continues from the returned index
+	containers, next, err = idx.GetContainersByTimeRange(0, 100, 0, 3)
+	require.NoError(err)
+	require.Len(containers, 3)
+	require.Equal(containerIDs[0], containers[0].ID)
+	require.EqualValues(3, next)
+
+	containers, next, err = idx.GetContainersByTimeRange(0, 100, next, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 17)
+	require.Equal(containerIDs[3], containers[0].ID)
+	require.EqualValues(20, next)
+
+	// No containers in range
+	containers, _, err = idx.GetContainersByTimeRange(101, 200, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Empty(containers)
+
+	containers, next, err = idx.GetContainersByTimeRange(0, 100, 20, MaxFetchedByRange)
+	require.NoError(err)
+	require.Empty(containers)
+	require.EqualValues(20, next)
+
+	_, _, err = idx.GetContainersByTimeRange(40, 15, 0, MaxFetchedByRange)
+	require.ErrorIs(err, errInvalidTimeRange)
+
+	_, _, err = idx.GetContainersByTimeRange(0, 100, 0, 0)
+	require.ErrorIs(err, errNumToFetchZero)
+
+	_, _, err = idx.GetContainersByTimeRange(0, 100, 0, MaxFetchedByRange+1)
+	require.Error(err)
+}
+
+func TestIndexTimestampsAfterClockStep(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	require.NoError(err)
+	baseDB := memdb.New()
+	db := versiondb.New(baseDB)
+	ctx := snow.DefaultConsensusContextTest()
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
+	require.NoError(err)
+
+	// The clock steps back after the first container is accepted
+	idx.clock.Set(time.Unix(0, 100))
+	firstID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, firstID, utils.RandomBytes(32)))
+	idx.clock.Set(time.Unix(0, 50))
+	secondID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, secondID, utils.RandomBytes(32)))
+
+	second, err := idx.GetContainerByID(secondID)
+	require.NoError(err)
+	require.EqualValues(100, second.Timestamp)
+
+	// The last timestamp is restored after a restart
+	require.NoError(db.Commit())
+	require.NoError(idx.Close())
+	db = versiondb.New(baseDB)
+	idx, err = newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, nil)
+	require.NoError(err)
+	idx.clock.Set(time.Unix(0, 75))
+	thirdID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, thirdID, utils.RandomBytes(32)))
+
+	containers, next, err := idx.GetContainersByTimeRange(100, 100, 0, MaxFetchedByRange)
+	require.NoError(err)
+	require.Len(containers, 3)
+	require.Equal(firstID, containers[0].ID)
+	require.Equal(secondID, containers[1].ID)
+	require.Equal(thirdID, containers[2].ID)
+	require.EqualValues(3, next)
+}
+
+func TestIndexGetContainerByHeight(t *testing.T) {
+	// Setup
+	require := require.New(t)
+	codec := codec.NewDefaultManager()
+	err := codec.RegisterCodec(codecVersion, linearcodec.NewDefault())
+	require.NoError(err)
+	db := memdb.New()
+	ctx := snow.DefaultConsensusContextTest()
+
+	// The first byte of each container is its height.
+	errNoHeight := errors.New("no height")
+	heightOf := func(containerBytes []byte) (uint64, error) {
+		if len(containerBytes) == 0 {
+			return 0, errNoHeight
+		}
+		return uint64(containerBytes[0]), nil
+	}
+	idx, err := newIndex(db, logging.NoLog{}, codec, mockable.Clock{}, heightOf)
+	require.NoError(err)
+
+	// Heights don't need to match the indices.
+	containerIDs := []ids.ID{}
+	for height := byte(10); height < 15; height++ {
+		containerID := ids.GenerateTestID()
+		require.NoError(idx.Accept(ctx, containerID, []byte{height}))
+		containerIDs = append(containerIDs, containerID)
+	}
+
+	for i, containerID := range containerIDs {
+		container, err := idx.GetContainerByHeight(uint64(10 + i))
+		require.NoError(err)
+		require.Equal(containerID, container.ID)
+	}
+
+	_, err = idx.GetContainerByHeight(15)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// Containers without a height are indexed, but can't be looked up by
+	// height.
+	containerID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, containerID, nil))
+	index, err := idx.GetIndex(containerID)
+	require.NoError(err)
+	require.EqualValues(len(containerIDs), index)
+
+	// Indices without heights can't be looked up by height.
+	idx, err = newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, nil)
+	require.NoError(err)
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), []byte{10}))
+	_, err = idx.GetContainerByHeight(10)
+	require.ErrorIs(err, errHeightsNotIndexed)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/avalanche"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -73,6 +75,10 @@ type Config struct {
 // Indexer is threadsafe.
 type Indexer interface {
 	chains.Registrant
+	// SetPaused stops or resumes indexing newly accepted containers. Pausing
+	// marks the indices of all chains as incomplete, as the containers
+	// accepted while paused are never indexed.
+	SetPaused(paused bool) error
 	// Close will do nothing and return nil after the first call
 	io.Closer
 }
@@ -132,6 +138,9 @@ type indexer struct {
 	// If false, don't create index for a chain when RegisterChain is called
 	indexingEnabled bool
 
+	// If true, newly accepted containers aren't indexed
+	paused utils.AtomicBool
+
 	// Chain ID --> index of blocks of that chain (if applicable)
 	blockIndices map[ids.ID]Index
 	// Chain ID --> index of vertices of that chain (if applicable)
@@ -263,7 +272,11 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 
 	switch engine.(type) {
 	case snowman.Engine:
-		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup)
+		var heightOf heightFunc
+		if vm, ok := engine.GetVM().(block.ChainVM); ok {
+			heightOf = blockHeightFunc(vm)
+		}
+		index, err := i.registerChainHelper(chainID, blockPrefix, name, "block", i.consensusAcceptorGroup, heightOf)
 		if err != nil {
 			i.log.Fatal("failed to create block index",
 				zap.String("chainName", name),
@@ -278,7 +291,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.blockIndices[chainID] = index
 	case avalanche.Engine:
-		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup)
+		vtxIndex, err := i.registerChainHelper(chainID, vtxPrefix, name, "vtx", i.consensusAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create vertex index",
 				zap.String("chainName", name),
@@ -293,7 +306,7 @@ func (i *indexer) RegisterChain(name string, engine common.Engine) {
 		}
 		i.vtxIndices[chainID] = vtxIndex
 
-		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup)
+		txIndex, err := i.registerChainHelper(chainID, txPrefix, name, "tx", i.decisionAcceptorGroup, nil)
 		if err != nil {
 			i.log.Fatal("couldn't create tx index for",
 				zap.String("chainName", name),
@@ -326,19 +339,24 @@ func (i *indexer) registerChainHelper(
 	prefixEnd byte,
 	name, endpoint string,
 	acceptorGroup snow.AcceptorGroup,
+	heightOf heightFunc,
 ) (Index, error) {
 	prefix := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(prefix, chainID[:])
 	prefix[hashing.HashLen] = prefixEnd
 	indexDB := prefixdb.New(prefix, i.db)
-	index, err := newIndex(indexDB, i.log, i.codec, i.clock)
+	index, err := newIndex(indexDB, i.log, i.codec, i.clock, heightOf)
 	if err != nil {
 		_ = indexDB.Close()
 		return nil, err
 	}
 
 	// Register index to learn about new accepted vertices
-	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), index, true); err != nil {
+	acceptor := &pausableAcceptor{
+		Acceptor: index,
+		paused:   &i.paused,
+	}
+	if err := acceptorGroup.RegisterAcceptor(chainID, fmt.Sprintf("%s%s", indexNamePrefix, chainID), acceptor, true); err != nil {
 		_ = index.Close()
 		return nil, err
 	}
@@ -357,6 +375,13 @@ func (i *indexer) registerChainHelper(
 		_ = index.Close()
 		return nil, err
 	}
+
+	// Create a websocket endpoint that pushes newly accepted containers
+	streamHandler := &common.HTTPHandler{LockOptions: common.NoLock, Handler: index.stream}
+	if err := i.pathAdder.AddRoute(streamHandler, &sync.RWMutex{}, "index/"+name, "/"+endpoint+streamEndpoint); err != nil {
+		_ = index.Close()
+		return nil, err
+	}
 	return index, nil
 }
 
@@ -402,6 +427,35 @@ func (i *indexer) close() error {
 	return errs.Err
 }
 
+func (i *indexer) SetPaused(paused bool) error {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	if i.closed || i.paused.GetValue() == paused {
+		return nil
+	}
+	if paused {
+		errs := wrappers.Errs{}
+		for chainID := range i.txIndices {
+			errs.Add(i.markIncomplete(chainID))
+		}
+		for chainID := range i.vtxIndices {
+			errs.Add(i.markIncomplete(chainID))
+		}
+		for chainID := range i.blockIndices {
+			errs.Add(i.markIncomplete(chainID))
+		}
+		if errs.Errored() {
+			return fmt.Errorf("couldn't mark indices as incomplete: %w", errs.Err)
+		}
+		i.log.Warn("pausing indexing. Indices will be incomplete")
+	} else {
+		i.log.Info("resuming indexing")
+	}
+	i.paused.SetValue(paused)
+	return nil
+}
+
 func (i *indexer) markIncomplete(chainID ids.ID) error {
 	key := make([]byte, hashing.HashLen+wrappers.ByteLen)
 	copy(key, chainID[:])
@@ -441,3 +495,30 @@ func (i *indexer) markHasRun() error {
 func (i *indexer) hasRun() (bool, error) {
 	return i.db.Has(hasRunKey)
 }
+
+// blockHeightFunc returns the heights of blocks parsed by [vm].
+// Blocks are only parsed when they are accepted, while the chain's context lock
+// is held.
+func blockHeightFunc(vm block.ChainVM) heightFunc {
+	return func(blkBytes []byte) (uint64, error) {
+		blk, err := vm.ParseBlock(blkBytes)
+		if err != nil {
+			return 0, err
+		}
+		return blk.Height(), nil
+	}
+}
+
+// pausableAcceptor passes accepted containers to [Acceptor] unless indexing is
+// paused.
+type pausableAcceptor struct {
+	snow.Acceptor
+	paused *utils.AtomicBool
+}
+
+func (a *pausableAcceptor) Accept(ctx *snow.ConsensusContext, containerID ids.ID, container []byte) error {
+	if a.paused.GetValue() {
+		return nil
+	}
+	return a.Acceptor.Accept(ctx, containerID, container)
+}
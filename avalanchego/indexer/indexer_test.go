@@ -14,12 +14,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/database/versiondb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/utils"
@@ -174,9 +176,11 @@ func TestIndexer(t *testing.T) {
 	require.NoError(err)
 	require.True(previouslyIndexed)
 	server := config.APIServer.(*apiServerMock)
-	require.EqualValues(1, server.timesCalled)
+	require.EqualValues(2, server.timesCalled)
 	require.EqualValues("index/chain1", server.bases[0])
 	require.EqualValues("/block", server.endpoints[0])
+	require.EqualValues("index/chain1", server.bases[1])
+	require.EqualValues("/block/stream", server.endpoints[1])
 	require.Len(idxr.blockIndices, 1)
 	require.Len(idxr.txIndices, 0)
 	require.Len(idxr.vtxIndices, 0)
@@ -189,6 +193,8 @@ func TestIndexer(t *testing.T) {
 		Timestamp: now.UnixNano(),
 	}
 
+	// The block is parsed to index it by height
+	chainVM.EXPECT().ParseBlock(blkBytes).Return(&snowman.TestBlock{HeightV: 5}, nil)
 	require.NoError(config.ConsensusAcceptorGroup.Accept(chain1Ctx, blkID, blkBytes))
 
 	blkIdx := idxr.blockIndices[chain1Ctx.ChainID]
@@ -220,6 +226,11 @@ func TestIndexer(t *testing.T) {
 	require.Len(containers, 1)
 	require.Equal(expectedContainer, containers[0])
 
+	// Verify GetContainerByHeight is right
+	container, err = blkIdx.GetContainerByHeight(5)
+	require.NoError(err)
+	require.Equal(expectedContainer, container)
+
 	// Close the indexer
 	require.NoError(db.Commit())
 	require.NoError(idxr.Close())
@@ -274,10 +285,12 @@ func TestIndexer(t *testing.T) {
 	idxr.RegisterChain("chain2", dagEngine)
 	require.NoError(err)
 	server = config.APIServer.(*apiServerMock)
-	require.EqualValues(3, server.timesCalled) // block index, vtx index, tx index
+	require.EqualValues(6, server.timesCalled) // block index, vtx index, tx index and their streams
 	require.Contains(server.bases, "index/chain2")
 	require.Contains(server.endpoints, "/vtx")
 	require.Contains(server.endpoints, "/tx")
+	require.Contains(server.endpoints, "/vtx/stream")
+	require.Contains(server.endpoints, "/tx/stream")
 	require.Len(idxr.blockIndices, 1)
 	require.Len(idxr.txIndices, 1)
 	require.Len(idxr.vtxIndices, 1)
@@ -450,8 +463,10 @@ func TestIncompleteIndex(t *testing.T) {
 	previouslyIndexed, err := idxr.previouslyIndexed(chain1Ctx.ChainID)
 	require.NoError(err)
 	require.False(previouslyIndexed)
+	chainVM := smblockmocks.NewMockChainVM(ctrl)
 	chainEngine := &smengmocks.Engine{}
 	chainEngine.On("Context").Return(chain1Ctx)
+	chainEngine.On("GetVM").Return(chainVM)
 	idxr.RegisterChain("chain1", chainEngine)
 	isIncomplete, err = idxr.isIncomplete(chain1Ctx.ChainID)
 	require.NoError(err)
@@ -539,3 +554,55 @@ func TestIgnoreNonDefaultChains(t *testing.T) {
 	idxr.RegisterChain("chain1", chainEngine)
 	require.Len(idxr.blockIndices, 0)
 }
+
+// Test that containers accepted while indexing is paused aren't indexed and
+// that pausing marks the index as incomplete
+func TestIndexerPause(t *testing.T) {
+	require := require.New(t)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	config := Config{
+		IndexingEnabled:        true,
+		AllowIncompleteIndex:   false,
+		Log:                    logging.NoLog{},
+		DB:                     memdb.New(),
+		DecisionAcceptorGroup:  snow.NewAcceptorGroup(logging.NoLog{}),
+		ConsensusAcceptorGroup: snow.NewAcceptorGroup(logging.NoLog{}),
+		APIServer:              &apiServerMock{},
+		ShutdownF:              func() {},
+	}
+
+	idxrIntf, err := NewIndexer(config)
+	require.NoError(err)
+	idxr, ok := idxrIntf.(*indexer)
+	require.True(ok)
+
+	chainCtx := snow.DefaultConsensusContextTest()
+	chainCtx.ChainID = ids.GenerateTestID()
+	chainVM := smblockmocks.NewMockChainVM(ctrl)
+	chainEngine := &smengmocks.Engine{}
+	chainEngine.On("Context").Return(chainCtx)
+	chainEngine.On("GetVM").Return(chainVM)
+	idxr.RegisterChain("chain", chainEngine)
+	blkIdx := idxr.blockIndices[chainCtx.ChainID]
+	require.NotNil(blkIdx)
+
+	require.NoError(idxr.SetPaused(true))
+	isIncomplete, err := idxr.isIncomplete(chainCtx.ChainID)
+	require.NoError(err)
+	require.True(isIncomplete)
+
+	skippedID := ids.GenerateTestID()
+	require.NoError(config.ConsensusAcceptorGroup.Accept(chainCtx, skippedID, utils.RandomBytes(32)))
+	_, err = blkIdx.GetIndex(skippedID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	require.NoError(idxr.SetPaused(false))
+	blkID := ids.GenerateTestID()
+	chainVM.EXPECT().ParseBlock(gomock.Any()).Return(&snowman.TestBlock{}, nil)
+	require.NoError(config.ConsensusAcceptorGroup.Accept(chainCtx, blkID, utils.RandomBytes(32)))
+	index, err := blkIdx.GetIndex(blkID)
+	require.NoError(err)
+	require.EqualValues(0, index)
+}
@@ -111,6 +111,53 @@ func (s *service) GetContainerRange(r *http.Request, args *GetContainerRangeArgs
 	return nil
 }
 
+type GetContainersByTimeRangeArgs struct {
+	StartTime  time.Time           `json:"startTime"`
+	EndTime    time.Time           `json:"endTime"`
+	StartIndex json.Uint64         `json:"startIndex"`
+	NumToFetch json.Uint64         `json:"numToFetch"`
+	Encoding   formatting.Encoding `json:"encoding"`
+}
+
+type GetContainersByTimeRangeResponse struct {
+	Containers []FormattedContainer `json:"containers"`
+	NextIndex  json.Uint64          `json:"nextIndex"`
+}
+
+// GetContainersByTimeRange returns, in order of acceptance, up to [numToFetch]
+// containers that were accepted by this node in [startTime, endTime], searching
+// from [startIndex]. Containers are timestamped with the local time at which
+// this node accepted them, not with their block timestamps.
+// If [n] > [MaxFetchedByRange], returns an error.
+// To fetch more containers than fit in a single response, call again with
+// [startIndex] set to the returned [nextIndex] until fewer than [numToFetch]
+// containers are returned.
+func (s *service) GetContainersByTimeRange(_ *http.Request, args *GetContainersByTimeRangeArgs, reply *GetContainersByTimeRangeResponse) error {
+	containers, nextIndex, err := s.Index.GetContainersByTimeRange(
+		args.StartTime.UnixNano(),
+		args.EndTime.UnixNano(),
+		uint64(args.StartIndex),
+		uint64(args.NumToFetch),
+	)
+	if err != nil {
+		return err
+	}
+	reply.NextIndex = json.Uint64(nextIndex)
+
+	reply.Containers = make([]FormattedContainer, len(containers))
+	for i, container := range containers {
+		index, err := s.Index.GetIndex(container.ID)
+		if err != nil {
+			return fmt.Errorf("couldn't get index: %w", err)
+		}
+		reply.Containers[i], err = newFormattedContainer(container, index, args.Encoding)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type GetIndexArgs struct {
 	ID ids.ID `json:"id"`
 }
@@ -163,3 +210,23 @@ func (s *service) GetContainerByID(r *http.Request, args *GetContainerByIDArgs,
 	*reply, err = newFormattedContainer(container, index, args.Encoding)
 	return err
 }
+
+type GetContainerByHeightArgs struct {
+	Height   json.Uint64         `json:"height"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// GetContainerByHeight returns the accepted container at [height]. Only
+// supported by block indices.
+func (s *service) GetContainerByHeight(_ *http.Request, args *GetContainerByHeightArgs, reply *FormattedContainer) error {
+	container, err := s.Index.GetContainerByHeight(uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	index, err := s.Index.GetIndex(container.ID)
+	if err != nil {
+		return fmt.Errorf("couldn't get index: %w", err)
+	}
+	*reply, err = newFormattedContainer(container, index, args.Encoding)
+	return err
+}
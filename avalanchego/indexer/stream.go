@@ -0,0 +1,544 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	stdjson "encoding/json"
+
+	"github.com/gorilla/websocket"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	streamEndpoint = "/stream"
+
+	// Query parameters of a stream request
+	encodingParam     = "encoding"
+	includeBytesParam = "includeBytes"
+	startIndexParam   = "startIndex"
+	cursorParam       = "cursor"
+
+	// Maximum length of the name of a cursor
+	maxCursorLen = 64
+
+	// Size of the ws read buffer
+	streamReadBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	streamWriteBufferSize = units.KiB
+
+	// Time allowed to write a message to the subscriber.
+	streamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	streamPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// [streamPongWait].
+	streamPingPeriod = (streamPongWait * 9) / 10
+
+	// Maximum size of a message read from a subscriber. Subscribers aren't
+	// expected to send anything other than control messages and acks.
+	streamMaxMessageSize = units.KiB
+
+	// Maximum number of containers pending to be sent to a subscriber. A
+	// subscriber that falls further behind is disconnected.
+	streamMaxPendingContainers = 1024
+)
+
+var (
+	streamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  streamReadBufferSize,
+		WriteBufferSize: streamWriteBufferSize,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+
+	errInvalidAck = errors.New("acknowledged a container that wasn't sent")
+)
+
+// streamSource is the index that a stream reads containers and persists the
+// cursors of its subscribers from.
+type streamSource interface {
+	containersFrom(startIndex uint64) ([]Container, error)
+	getStreamCursor(name string) (uint64, bool, error)
+	putStreamCursor(name string, next uint64) error
+}
+
+// stream pushes the containers accepted by an index to its websocket
+// subscribers, in order of acceptance.
+//
+// By default, subscribers only receive containers accepted after they
+// subscribed, and a subscriber that can't keep up is disconnected rather than
+// silently skipped, so that it can resume from the last index it received
+// using GetContainerRange.
+//
+// A subscriber that sets [startIndexParam] or [cursorParam] is instead sent
+// every container from that index onwards, read from the index at its own
+// pace. A subscriber with a cursor acknowledges the containers it processed by
+// sending {"ack": index}, which is persisted. When it resubscribes with the
+// same cursor, it's sent the containers after the last one it acknowledged, so
+// every container is delivered at least once.
+type stream struct {
+	log    logging.Logger
+	source streamSource
+
+	lock        sync.Mutex
+	closed      bool
+	subscribers map[*subscriber]struct{}
+	// Name of a cursor --> The subscriber using it
+	cursors map[string]*subscriber
+}
+
+type subscriber struct {
+	s    *stream
+	conn *websocket.Conn
+
+	encoding     formatting.Encoding
+	includeBytes bool
+
+	// Buffered channel of containers to send. Closed when the subscriber is
+	// removed from the stream. Only used if the subscriber doesn't replay
+	// containers from the index.
+	send chan FormattedContainer
+
+	// Signaled when a container is accepted. Closed when the subscriber is
+	// removed from the stream. Only used if the subscriber replays containers
+	// from the index.
+	notify chan struct{}
+	// Name of the cursor that the subscriber acknowledges containers to, if
+	// any
+	cursor string
+
+	// Index of the next container to send. Only used if the subscriber
+	// replays containers from the index.
+	nextLock sync.Mutex
+	next     uint64
+	// Index of the next container that wasn't acknowledged
+	acked uint64
+}
+
+func newStream(log logging.Logger, source streamSource) *stream {
+	return &stream{
+		log:         log,
+		source:      source,
+		subscribers: make(map[*subscriber]struct{}),
+		cursors:     make(map[string]*subscriber),
+	}
+}
+
+// ServeHTTP upgrades the request to a websocket connection that the accepted
+// containers are pushed to. The container bytes are only sent if the
+// [includeBytesParam] query parameter is true, in which case they're encoded
+// with [encodingParam], which defaults to hex. Containers are replayed from
+// [startIndexParam] if it's set, or else from the position of the
+// [cursorParam] cursor, which starts at index 0.
+func (s *stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	encoding := formatting.Hex
+	if encodingStr := query.Get(encodingParam); encodingStr != "" {
+		if err := encoding.UnmarshalJSON([]byte(strconv.Quote(encodingStr))); err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", encodingParam, encodingStr, err), http.StatusBadRequest)
+			return
+		}
+	}
+	includeBytes := false
+	if includeBytesStr := query.Get(includeBytesParam); includeBytesStr != "" {
+		var err error
+		includeBytes, err = strconv.ParseBool(includeBytesStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", includeBytesParam, includeBytesStr, err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	cursor := query.Get(cursorParam)
+	if len(cursor) > maxCursorLen {
+		http.Error(w, fmt.Sprintf("%s is longer than %d characters", cursorParam, maxCursorLen), http.StatusBadRequest)
+		return
+	}
+	replay := cursor != ""
+	startIndexStr := query.Get(startIndexParam)
+	var startIndex uint64
+	if startIndexStr != "" {
+		var err error
+		startIndex, err = strconv.ParseUint(startIndexStr, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", startIndexParam, startIndexStr, err), http.StatusBadRequest)
+			return
+		}
+		replay = true
+	}
+
+	sub := &subscriber{
+		s:            s,
+		encoding:     encoding,
+		includeBytes: includeBytes,
+		cursor:       cursor,
+	}
+	if replay {
+		sub.notify = make(chan struct{}, 1)
+	} else {
+		sub.send = make(chan FormattedContainer, streamMaxPendingContainers)
+	}
+
+	if cursor != "" {
+		// The cursor is reserved before it's read so that it isn't moved by
+		// a previous subscriber.
+		if !s.reserveCursor(sub) {
+			http.Error(w, fmt.Sprintf("cursor %q is already in use", cursor), http.StatusConflict)
+			return
+		}
+		if startIndexStr == "" {
+			var err error
+			startIndex, _, err = s.source.getStreamCursor(cursor)
+			if err != nil {
+				s.releaseCursor(sub)
+				http.Error(w, fmt.Sprintf("couldn't get cursor %q: %s", cursor, err), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	sub.next = startIndex
+	sub.acked = startIndex
+
+	conn, err := streamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.releaseCursor(sub)
+		s.log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+	sub.conn = conn
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		if cursor != "" {
+			delete(s.cursors, cursor)
+		}
+		_ = conn.Close()
+		return
+	}
+	s.subscribers[sub] = struct{}{}
+	if replay {
+		go sub.replayPump()
+	} else {
+		go sub.writePump()
+	}
+	go sub.readPump()
+}
+
+// reserveCursor returns true if no other subscriber uses the cursor of [sub],
+// in which case [sub] now uses it.
+func (s *stream) reserveCursor(sub *subscriber) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.cursors[sub.cursor]; ok {
+		return false
+	}
+	s.cursors[sub.cursor] = sub
+	return true
+}
+
+// releaseCursor frees the cursor reserved by [sub], which didn't subscribe.
+func (s *stream) releaseCursor(sub *subscriber) {
+	if sub.cursor == "" {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.cursors, sub.cursor)
+}
+
+// publish sends [container], accepted at [index], to every subscriber.
+// publish never blocks on a subscriber.
+func (s *stream) publish(container Container, index uint64) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.notify != nil {
+			select {
+			case sub.notify <- struct{}{}:
+			default:
+				// The subscriber was already notified.
+			}
+			continue
+		}
+
+		fc, err := sub.format(container, index)
+		if err != nil {
+			s.log.Debug("dropping subscriber",
+				zap.String("reason", "failed to format container"),
+				zap.Error(err),
+			)
+			s.remove(sub)
+			continue
+		}
+		select {
+		case sub.send <- fc:
+		default:
+			s.log.Debug("dropping subscriber",
+				zap.String("reason", "too many pending containers"),
+			)
+			s.remove(sub)
+		}
+	}
+}
+
+// Close disconnects all subscribers. Containers published after Close are
+// dropped.
+func (s *stream) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+// removeSubscriber removes [sub] from the stream if it hasn't been already.
+func (s *stream) removeSubscriber(sub *subscriber) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remove(sub)
+}
+
+// remove closes [sub.send] or [sub.notify], which causes the write pump to
+// close the connection.
+// Assumes [s.lock] is held.
+func (s *stream) remove(sub *subscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	if sub.cursor != "" {
+		delete(s.cursors, sub.cursor)
+	}
+	if sub.notify != nil {
+		close(sub.notify)
+	} else {
+		close(sub.send)
+	}
+}
+
+func (sub *subscriber) format(container Container, index uint64) (FormattedContainer, error) {
+	if sub.includeBytes {
+		return newFormattedContainer(container, index, sub.encoding)
+	}
+	return FormattedContainer{
+		ID:        container.ID,
+		Timestamp: time.Unix(0, container.Timestamp),
+		Encoding:  sub.encoding,
+		Index:     json.Uint64(index),
+	}, nil
+}
+
+// readPump processes the acks and control messages sent by the subscriber
+// until the connection is closed. Other messages are discarded.
+func (sub *subscriber) readPump() {
+	defer func() {
+		sub.s.removeSubscriber(sub)
+		_ = sub.conn.Close()
+	}()
+
+	sub.conn.SetReadLimit(streamMaxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := sub.conn.SetReadDeadline(time.Now().Add(streamPongWait)); err != nil {
+		return
+	}
+	sub.conn.SetPongHandler(func(string) error {
+		return sub.conn.SetReadDeadline(time.Now().Add(streamPongWait))
+	})
+
+	for {
+		_, msg, err := sub.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				sub.s.log.Debug("unexpected close in websockets",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+		if sub.cursor == "" {
+			continue
+		}
+		if err := sub.ack(msg); err != nil {
+			sub.s.log.Debug("dropping subscriber",
+				zap.String("reason", "failed to acknowledge container"),
+				zap.String("cursor", sub.cursor),
+				zap.Error(err),
+			)
+			return
+		}
+	}
+}
+
+// streamAck is sent by a subscriber with a cursor once it processed the
+// containers up to and including [Ack].
+type streamAck struct {
+	Ack *json.Uint64 `json:"ack"`
+}
+
+// ack persists the cursor of the subscriber after the container acknowledged
+// by [msg]. Messages that aren't acks are ignored.
+func (sub *subscriber) ack(msg []byte) error {
+	var a streamAck
+	if err := stdjson.Unmarshal(msg, &a); err != nil || a.Ack == nil {
+		return nil
+	}
+
+	sub.nextLock.Lock()
+	defer sub.nextLock.Unlock()
+
+	index := uint64(*a.Ack)
+	if index >= sub.next {
+		return fmt.Errorf("%w: %d", errInvalidAck, index)
+	}
+	if index < sub.acked {
+		// Already acknowledged
+		return nil
+	}
+	sub.acked = index + 1
+	return sub.s.source.putStreamCursor(sub.cursor, sub.acked)
+}
+
+// replayPump sends the containers of the index to the subscriber, starting at
+// [sub.next], and pings it periodically, until the subscriber is removed or
+// the connection fails.
+func (sub *subscriber) replayPump() {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		sub.s.removeSubscriber(sub)
+		_ = sub.conn.Close()
+	}()
+
+	for {
+		sub.nextLock.Lock()
+		next := sub.next
+		sub.nextLock.Unlock()
+
+		containers, err := sub.s.source.containersFrom(next)
+		if err != nil {
+			sub.s.log.Debug("dropping subscriber",
+				zap.String("reason", "failed to read containers"),
+				zap.Error(err),
+			)
+			return
+		}
+		for i, container := range containers {
+			fc, err := sub.format(container, next+uint64(i))
+			if err != nil {
+				sub.s.log.Debug("dropping subscriber",
+					zap.String("reason", "failed to format container"),
+					zap.Error(err),
+				)
+				return
+			}
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteJSON(fc); err != nil {
+				return
+			}
+
+			sub.nextLock.Lock()
+			sub.next++
+			sub.nextLock.Unlock()
+		}
+
+		if len(containers) > 0 {
+			// Stop replaying if the subscriber was removed.
+			select {
+			case _, ok := <-sub.notify:
+				if !ok {
+					sub.closeGracefully()
+					return
+				}
+			default:
+			}
+			continue
+		}
+
+		select {
+		case _, ok := <-sub.notify:
+			if !ok {
+				sub.closeGracefully()
+				return
+			}
+		case <-ticker.C:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeGracefully attempts to close the connection with a close message.
+func (sub *subscriber) closeGracefully() {
+	if err := sub.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+		return
+	}
+	_ = sub.conn.WriteMessage(websocket.CloseMessage, []byte{})
+}
+
+// writePump sends the containers published to the subscriber, and pings it
+// periodically, until the subscriber is removed or the connection fails.
+func (sub *subscriber) writePump() {
+	ticker := time.NewTicker(streamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		sub.s.removeSubscriber(sub)
+		_ = sub.conn.Close()
+	}()
+
+	for {
+		select {
+		case fc, ok := <-sub.send:
+			if !ok {
+				// The subscriber was removed.
+				sub.closeGracefully()
+				return
+			}
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteJSON(fc); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
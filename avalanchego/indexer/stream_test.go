@@ -0,0 +1,214 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package indexer
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+func newTestStreamIndex(t *testing.T) *index {
+	codec := codec.NewDefaultManager()
+	require.NoError(t, codec.RegisterCodec(codecVersion, linearcodec.NewDefault()))
+	idx, err := newIndex(memdb.New(), logging.NoLog{}, codec, mockable.Clock{}, nil)
+	require.NoError(t, err)
+	return idx
+}
+
+// subscribe connects to [idx]'s stream and waits until the subscription is
+// registered.
+func subscribe(t *testing.T, idx *index, query string) *websocket.Conn {
+	srv := httptest.NewServer(idx.stream)
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.Eventually(t, func() bool {
+		idx.stream.lock.Lock()
+		defer idx.stream.lock.Unlock()
+		return len(idx.stream.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+	return conn
+}
+
+func TestStream(t *testing.T) {
+	require := require.New(t)
+
+	idx := newTestStreamIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	// Containers accepted before subscribing aren't sent.
+	require.NoError(idx.Accept(ctx, ids.GenerateTestID(), utils.RandomBytes(32)))
+
+	conn := subscribe(t, idx, "includeBytes=true&encoding=hex")
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID()}
+	containerBytes := [][]byte{utils.RandomBytes(32), utils.RandomBytes(32)}
+	for i, containerID := range containerIDs {
+		require.NoError(idx.Accept(ctx, containerID, containerBytes[i]))
+	}
+
+	for i, containerID := range containerIDs {
+		var fc FormattedContainer
+		require.NoError(conn.ReadJSON(&fc))
+		require.Equal(containerID, fc.ID)
+		require.EqualValues(i+1, fc.Index)
+		require.Equal(formatting.Hex, fc.Encoding)
+		gotBytes, err := formatting.Decode(fc.Encoding, fc.Bytes)
+		require.NoError(err)
+		require.Equal(containerBytes[i], gotBytes)
+	}
+
+	// Closing the index disconnects the subscriber.
+	require.NoError(idx.Close())
+	_, _, err := conn.ReadMessage()
+	require.True(websocket.IsCloseError(err, websocket.CloseNoStatusReceived))
+}
+
+func TestStreamWithoutBytes(t *testing.T) {
+	require := require.New(t)
+
+	idx := newTestStreamIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+	conn := subscribe(t, idx, "")
+
+	containerID := ids.GenerateTestID()
+	require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+
+	var fc FormattedContainer
+	require.NoError(conn.ReadJSON(&fc))
+	require.Equal(containerID, fc.ID)
+	require.EqualValues(0, fc.Index)
+	require.Empty(fc.Bytes)
+}
+
+func TestStreamSlowSubscriber(t *testing.T) {
+	require := require.New(t)
+
+	s := newStream(logging.NoLog{}, nil)
+	sub := &subscriber{
+		s:    s,
+		send: make(chan FormattedContainer, 1),
+	}
+	s.subscribers[sub] = struct{}{}
+
+	s.publish(Container{ID: ids.GenerateTestID()}, 0)
+	require.Len(s.subscribers, 1)
+
+	// The subscriber isn't reading so its buffer is full.
+	s.publish(Container{ID: ids.GenerateTestID()}, 1)
+	require.Empty(s.subscribers)
+
+	// The pending container is still delivered before the channel is closed.
+	_, ok := <-sub.send
+	require.True(ok)
+	_, ok = <-sub.send
+	require.False(ok)
+}
+
+func TestStreamReplay(t *testing.T) {
+	require := require.New(t)
+
+	idx := newTestStreamIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, containerID := range containerIDs[:2] {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	// The containers accepted before subscribing are sent from the start
+	// index, followed by the containers accepted after subscribing.
+	conn := subscribe(t, idx, "startIndex=1")
+	require.NoError(idx.Accept(ctx, containerIDs[2], utils.RandomBytes(32)))
+	for i, containerID := range containerIDs[1:] {
+		var fc FormattedContainer
+		require.NoError(conn.ReadJSON(&fc))
+		require.Equal(containerID, fc.ID)
+		require.EqualValues(i+1, fc.Index)
+	}
+}
+
+func TestStreamCursor(t *testing.T) {
+	require := require.New(t)
+
+	idx := newTestStreamIndex(t)
+	ctx := snow.DefaultConsensusContextTest()
+
+	containerIDs := []ids.ID{ids.GenerateTestID(), ids.GenerateTestID(), ids.GenerateTestID()}
+	for _, containerID := range containerIDs {
+		require.NoError(idx.Accept(ctx, containerID, utils.RandomBytes(32)))
+	}
+
+	// A new cursor starts at the first container.
+	conn := subscribe(t, idx, "cursor=indexer")
+	for i, containerID := range containerIDs {
+		var fc FormattedContainer
+		require.NoError(conn.ReadJSON(&fc))
+		require.Equal(containerID, fc.ID)
+		require.EqualValues(i, fc.Index)
+	}
+	require.NoError(conn.WriteJSON(map[string]string{"ack": "1"}))
+	require.Eventually(func() bool {
+		next, ok, err := idx.getStreamCursor("indexer")
+		return err == nil && ok && next == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// A cursor can only be used by one subscriber at a time.
+	w := httptest.NewRecorder()
+	idx.stream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/block/stream?cursor=indexer", nil))
+	require.Equal(http.StatusConflict, w.Code)
+
+	// The containers that weren't acknowledged are sent again after
+	// resubscribing.
+	require.NoError(conn.Close())
+	require.Eventually(func() bool {
+		idx.stream.lock.Lock()
+		defer idx.stream.lock.Unlock()
+		return len(idx.stream.subscribers) == 0
+	}, time.Second, 10*time.Millisecond)
+	conn = subscribe(t, idx, "cursor=indexer")
+	var fc FormattedContainer
+	require.NoError(conn.ReadJSON(&fc))
+	require.Equal(containerIDs[2], fc.ID)
+	require.EqualValues(2, fc.Index)
+
+	// Acknowledging a container that wasn't sent drops the subscriber.
+	require.NoError(conn.WriteJSON(map[string]string{"ack": "3"}))
+	_, _, err := conn.ReadMessage()
+	require.Error(err)
+	next, _, err := idx.getStreamCursor("indexer")
+	require.NoError(err)
+	require.EqualValues(2, next)
+}
+
+func TestStreamInvalidQuery(t *testing.T) {
+	idx := newTestStreamIndex(t)
+
+	for _, query := range []string{"encoding=base58", "includeBytes=maybe", "startIndex=-1", "cursor=" + strings.Repeat("a", maxCursorLen+1)} {
+		w := httptest.NewRecorder()
+		idx.stream.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/block/stream?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
@@ -4,18 +4,43 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/pflag"
 
+	"github.com/ava-labs/avalanchego/app/keys"
+	"github.com/ava-labs/avalanchego/app/localnet"
+	"github.com/ava-labs/avalanchego/app/replay"
 	"github.com/ava-labs/avalanchego/app/runner"
 	"github.com/ava-labs/avalanchego/config"
 	"github.com/ava-labs/avalanchego/version"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == keys.Command {
+		if err := keys.Run(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, pflag.ErrHelp) {
+			fmt.Printf("couldn't run %s command: %s\n", keys.Command, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == localnet.Command {
+		if err := localnet.Run(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, pflag.ErrHelp) {
+			fmt.Printf("couldn't run %s command: %s\n", localnet.Command, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	if len(os.Args) > 1 && os.Args[1] == replay.Command {
+		if err := replay.Run(os.Args[2:], os.Stdout); err != nil && !errors.Is(err, pflag.ErrHelp) {
+			fmt.Printf("couldn't run %s command: %s\n", replay.Command, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
 
 	fs := config.BuildFlagSet()
 	v, err := config.BuildViper(fs, os.Args[1:])
@@ -46,6 +71,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	if runnerConfig.PrintConfigAndExit {
+		configBytes, err := json.MarshalIndent(nodeConfig, "", "\t")
+		if err != nil {
+			fmt.Printf("couldn't marshal node config: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(configBytes))
+		os.Exit(0)
+	}
+
 	// Flare specific: set the application prefix (flare for songbird and avalanche for flare)
 	version.InitApplicationPrefix(nodeConfig.NetworkID)
 
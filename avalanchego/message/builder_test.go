@@ -62,6 +62,9 @@ func TestBuildVersion(t *testing.T) {
 		myVersionTime,
 		sig,
 		[]ids.ID{subnetID},
+		nil,
+		nil,
+		0,
 	)
 	require.NoError(t, err)
 	require.NotNil(t, msg)
@@ -478,6 +481,60 @@ func TestBuildAppResponseMsg(t *testing.T) {
 	}
 }
 
+func TestBuildAppErrorMsg(t *testing.T) {
+	chainID := ids.GenerateTestID()
+
+	for _, compress := range []bool{false, true} {
+		builder := NewOutboundBuilderWithPacker(TestCodec, compress)
+		msg, err := builder.AppError(chainID, 1, -2, "rate limited")
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		require.Equal(t, AppError, msg.Op())
+
+		parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		require.Equal(t, AppError, msg.Op())
+
+		requestIDIntf, err := parsedMsg.Get(RequestID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, requestIDIntf)
+
+		errorCodeIntf, err := parsedMsg.Get(ErrorCode)
+		require.NoError(t, err)
+		require.Equal(t, int32(-2), errorCodeIntf)
+
+		errorMessageIntf, err := parsedMsg.Get(ErrorMessage)
+		require.NoError(t, err)
+		require.Equal(t, "rate limited", errorMessageIntf)
+
+		chainIDIntf, err := parsedMsg.Get(ChainID)
+		require.NoError(t, err)
+		require.Equal(t, chainID[:], chainIDIntf)
+	}
+}
+
+func TestBuildGetPeerListMsg(t *testing.T) {
+	validatorIDs := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+
+	for _, compress := range []bool{false, true} {
+		builder := NewOutboundBuilderWithPacker(TestCodec, compress)
+		msg, err := builder.GetPeerList(validatorIDs)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		require.Equal(t, GetPeerList, msg.Op())
+
+		parsedMsg, err := TestCodec.Parse(msg.Bytes(), dummyNodeID, dummyOnFinishedHandling)
+		require.NoError(t, err)
+		require.NotNil(t, msg)
+		require.Equal(t, GetPeerList, msg.Op())
+
+		validatorIDsIntf, err := parsedMsg.Get(ValidatorIDs)
+		require.NoError(t, err)
+		require.Equal(t, [][]byte{validatorIDs[0][:], validatorIDs[1][:]}, validatorIDsIntf)
+	}
+}
+
 func TestBuildAppGossipMsg(t *testing.T) {
 	chainID := ids.GenerateTestID()
 	appGossipBytes := make([]byte, 1024)
@@ -7,12 +7,12 @@ import (
 	"errors"
 	"fmt"
 	"math"
-	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/buffer"
 	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/metric"
@@ -23,6 +23,7 @@ import (
 var (
 	errMissingField = errors.New("message missing field")
 	errBadOp        = errors.New("input field has invalid operation")
+	errBadType      = errors.New("input field has wrong type")
 
 	_ Codec = &codec{}
 )
@@ -52,9 +53,9 @@ type Codec interface {
 // codec defines the serialization and deserialization of network messages.
 // It's safe for multiple goroutines to call Pack and Parse concurrently.
 type codec struct {
-	// Contains []byte. Used as an optimization.
+	// Buffers of outbound messages. Used as an optimization.
 	// Can be accessed by multiple goroutines concurrently.
-	byteSlicePool sync.Pool
+	byteSlicePool *buffer.Pool
 
 	clock mockable.Clock
 
@@ -70,11 +71,7 @@ func NewCodecWithMemoryPool(namespace string, metrics prometheus.Registerer, max
 		return nil, err
 	}
 	c := &codec{
-		byteSlicePool: sync.Pool{
-			New: func() interface{} {
-				return make([]byte, 0, constants.DefaultByteSliceCap)
-			},
-		},
+		byteSlicePool:         buffer.NewPool(constants.DefaultByteSliceCap, int(maxMessageSize)),
 		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
 		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
 		compressor:            cpr,
@@ -127,10 +124,9 @@ func (c *codec) Pack(
 		return nil, errBadOp
 	}
 
-	buffer := c.byteSlicePool.Get().([]byte)
 	p := wrappers.Packer{
 		MaxSize: math.MaxInt32,
-		Bytes:   buffer[:0],
+		Bytes:   c.byteSlicePool.Get(0),
 	}
 	// Pack the op code (message type)
 	p.PackByte(byte(op))
@@ -176,6 +172,7 @@ func (c *codec) Pack(
 	}
 	c.compressTimeMetrics[op].Observe(float64(time.Since(startTime)))
 	msg.bytesSavedCompression = len(payloadBytes) - len(compressedPayloadBytes) // may be negative
+	msg.compressionType = compression.TypeGzip
 	// Remove the uncompressed payload (keep just the message type and isCompressed)
 	msg.bytes = msg.bytes[:wrappers.BoolLen+wrappers.ByteLen]
 	// Attach the compressed payload
@@ -186,6 +183,8 @@ func (c *codec) Pack(
 // Parse attempts to convert bytes into a message.
 // The first byte of the message is the opcode of the message.
 // Overrides client specified deadline in a message to maxDeadlineDuration
+// The returned message references [bytes], so they must not be modified or
+// reused afterwards.
 func (c *codec) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()) (InboundMessage, error) {
 	p := wrappers.Packer{Bytes: bytes}
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 )
 
@@ -39,7 +40,7 @@ func NewCreator(metrics prometheus.Registerer, parentNamespace string, compressi
 	}, nil
 }
 
-func NewCreatorWithProto(metrics prometheus.Registerer, parentNamespace string, compressionEnabled bool, maxInboundMessageTimeout time.Duration) (Creator, error) {
+func NewCreatorWithProto(metrics prometheus.Registerer, parentNamespace string, compressionType compression.Type, maxInboundMessageTimeout time.Duration) (Creator, error) {
 	// different namespace, not to be in conflict with packer
 	namespace := fmt.Sprintf("%s_proto_codec", parentNamespace)
 	builder, err := newMsgBuilderProtobuf(namespace, metrics, int64(constants.DefaultMaxMessageSize), maxInboundMessageTimeout)
@@ -47,7 +48,7 @@ func NewCreatorWithProto(metrics prometheus.Registerer, parentNamespace string,
 		return nil, err
 	}
 	return &creator{
-		OutboundMsgBuilder: newOutboundBuilderWithProto(compressionEnabled, builder),
+		OutboundMsgBuilder: newOutboundBuilderWithProto(compressionType, builder),
 		InboundMsgBuilder:  newInboundBuilderWithProto(builder),
 		InternalMsgBuilder: NewInternalBuilder(),
 	}, nil
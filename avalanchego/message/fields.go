@@ -35,12 +35,19 @@ const (
 	SummaryHeights                   // Used for state sync
 	SummaryIDs                       // Used for state sync
 	VersionStruct                    // Used internally
+	CompressionTypes                 // Used in handshake
+	ErrorCode                        // Used at application level
+	ErrorMessage                     // Used at application level
+	AdditionalIPs                    // Used in handshake
+	AdditionalIPsSig                 // Used in handshake
+	ValidatorIDs                     // Used in peer gossiping
+	Capabilities                     // Used in handshake
 )
 
 // Packer returns the packer function that can be used to pack this field.
 func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 	switch f {
-	case VersionStr:
+	case VersionStr, ErrorMessage:
 		return wrappers.TryPackStr
 	case NetworkID, NodeID, RequestID:
 		return wrappers.TryPackInt
@@ -54,12 +61,14 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 		return wrappers.TryPackBytes
 	case ContainerIDs, TrackedSubnets, SummaryIDs:
 		return wrappers.TryPackHashes
-	case MultiContainerBytes:
+	case MultiContainerBytes, ValidatorIDs:
 		return wrappers.TryPack2DBytes
 	case Peers:
 		return wrappers.TryPackClaimedIPPortList
 	case Uptime:
 		return wrappers.TryPackByte
+	case ErrorCode:
+		return tryPackInt32
 	case SummaryHeights:
 		return wrappers.TryPackUint64Slice
 	default:
@@ -70,7 +79,7 @@ func (f Field) Packer() func(*wrappers.Packer, interface{}) {
 // Unpacker returns the unpacker function that can be used to unpack this field.
 func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 	switch f {
-	case VersionStr:
+	case VersionStr, ErrorMessage:
 		return wrappers.TryUnpackStr
 	case NetworkID, NodeID, RequestID:
 		return wrappers.TryUnpackInt
@@ -84,12 +93,14 @@ func (f Field) Unpacker() func(*wrappers.Packer) interface{} {
 		return wrappers.TryUnpackBytes
 	case ContainerIDs, TrackedSubnets, SummaryIDs:
 		return wrappers.TryUnpackHashes
-	case MultiContainerBytes:
+	case MultiContainerBytes, ValidatorIDs:
 		return wrappers.TryUnpack2DBytes
 	case Peers:
 		return wrappers.TryUnpackClaimedIPPortList
 	case Uptime:
 		return wrappers.TryUnpackByte
+	case ErrorCode:
+		return tryUnpackInt32
 	case SummaryHeights:
 		return wrappers.TryUnpackUint64Slice
 	default:
@@ -145,7 +156,35 @@ func (f Field) String() string {
 		return "SummaryIDs"
 	case VersionStruct:
 		return "VersionStruct"
+	case CompressionTypes:
+		return "CompressionTypes"
+	case ErrorCode:
+		return "ErrorCode"
+	case ErrorMessage:
+		return "ErrorMessage"
+	case AdditionalIPs:
+		return "AdditionalIPs"
+	case AdditionalIPsSig:
+		return "AdditionalIPsSig"
+	case ValidatorIDs:
+		return "ValidatorIDs"
+	case Capabilities:
+		return "Capabilities"
 	default:
 		return "Unknown Field"
 	}
 }
+
+// tryPackInt32 packs an int32 as its two's complement uint32
+func tryPackInt32(p *wrappers.Packer, valIntf interface{}) {
+	if val, ok := valIntf.(int32); ok {
+		p.PackInt(uint32(val))
+	} else {
+		p.Add(errBadType)
+	}
+}
+
+// tryUnpackInt32 unpacks an int32 packed by tryPackInt32
+func tryUnpackInt32(p *wrappers.Packer) interface{} {
+	return int32(p.UnpackInt())
+}
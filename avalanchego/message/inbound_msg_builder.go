@@ -118,6 +118,14 @@ type InboundMsgBuilder interface {
 		nodeID ids.NodeID,
 	) InboundMessage
 
+	InboundAppError(
+		chainID ids.ID,
+		requestID uint32,
+		errorCode int32,
+		errorMessage string,
+		nodeID ids.NodeID,
+	) InboundMessage
+
 	InboundGet(
 		chainID ids.ID,
 		requestID uint32,
@@ -431,6 +439,27 @@ func (b *inMsgBuilderWithPacker) InboundAppResponse(
 	}
 }
 
+func (b *inMsgBuilderWithPacker) InboundAppError(
+	chainID ids.ID,
+	requestID uint32,
+	errorCode int32,
+	errorMessage string,
+	nodeID ids.NodeID,
+) InboundMessage {
+	return &inboundMessageWithPacker{
+		inboundMessage: inboundMessage{
+			op:     AppError,
+			nodeID: nodeID,
+		},
+		fields: map[Field]interface{}{
+			ChainID:      chainID[:],
+			RequestID:    requestID,
+			ErrorCode:    errorCode,
+			ErrorMessage: errorMessage,
+		},
+	}
+}
+
 func (b *inMsgBuilderWithPacker) InboundGet(
 	chainID ids.ID,
 	requestID uint32,
@@ -441,7 +470,7 @@ func (b *inMsgBuilderWithPacker) InboundGet(
 	received := b.clock.Time()
 	return &inboundMessageWithPacker{
 		inboundMessage: inboundMessage{
-			op:             Put,
+			op:             Get,
 			nodeID:         nodeID,
 			expirationTime: received.Add(deadline),
 		},
@@ -498,3 +527,10 @@ func encodeIDs(ids []ids.ID, result [][]byte) {
 		result[i] = copy[:]
 	}
 }
+
+func encodeNodeIDs(nodeIDs []ids.NodeID, result [][]byte) {
+	for i, nodeID := range nodeIDs {
+		copy := nodeID
+		result[i] = copy[:]
+	}
+}
@@ -28,6 +28,8 @@ func (b *inMsgBuilderWithProto) SetTime(t time.Time) {
 	b.protoBuilder.clock.Set(t)
 }
 
+// Parse copies the fields of the message, so [bytes] can be reused once it
+// returns.
 func (b *inMsgBuilderWithProto) Parse(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()) (InboundMessage, error) {
 	return b.protoBuilder.parseInbound(bytes, nodeID, onFinishedHandling)
 }
@@ -365,6 +367,31 @@ func (b *inMsgBuilderWithProto) InboundAppResponse(
 	}
 }
 
+func (b *inMsgBuilderWithProto) InboundAppError(
+	chainID ids.ID,
+	requestID uint32,
+	errorCode int32,
+	errorMessage string,
+	nodeID ids.NodeID,
+) InboundMessage {
+	return &inboundMessageWithProto{
+		inboundMessage: inboundMessage{
+			op:     AppError,
+			nodeID: nodeID,
+		},
+		msg: &p2p.Message{
+			Message: &p2p.Message_AppError{
+				AppError: &p2p.AppError{
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					ErrorCode:    errorCode,
+					ErrorMessage: errorMessage,
+				},
+			},
+		},
+	}
+}
+
 func (b *inMsgBuilderWithProto) InboundGet(
 	chainID ids.ID,
 	requestID uint32,
@@ -34,9 +34,17 @@ var (
 
 	errUnknownMessageTypeForOp = errors.New("unknown message type for Op")
 	errUnexpectedCompressedOp  = errors.New("unexpected compressed Op")
+	errUnknownCompressionType  = errors.New("unknown compression type")
 
 	errInvalidIPAddrLen = errors.New("invalid IP address field length (expected 16-byte)")
 	errInvalidCert      = errors.New("invalid TLS certificate field")
+
+	// compressionTypes are the compression algorithms supported by the
+	// protobuf message builder.
+	compressionTypes = []compression.Type{
+		compression.TypeGzip,
+		compression.TypeZstd,
+	}
 )
 
 // InboundMessage represents a set of fields for an inbound message that can be serialized into a byte stream
@@ -185,6 +193,45 @@ func getField(m *p2ppb.Message, field Field) (interface{}, error) {
 			return msg.Sig, nil
 		case TrackedSubnets:
 			return msg.TrackedSubnets, nil
+		case CompressionTypes:
+			// Unknown algorithms are ignored, as they may have been added by a
+			// newer version of the remote peer.
+			compressionTypes := make([]compression.Type, 0, len(msg.SupportedCompressions))
+			for _, c := range msg.SupportedCompressions {
+				switch c {
+				case p2ppb.Compression_COMPRESSION_GZIP:
+					compressionTypes = append(compressionTypes, compression.TypeGzip)
+				case p2ppb.Compression_COMPRESSION_ZSTD:
+					compressionTypes = append(compressionTypes, compression.TypeZstd)
+				}
+			}
+			return compressionTypes, nil
+		case AdditionalIPs:
+			additionalIPs := make([]ips.IPPort, len(msg.AdditionalIps))
+			for i, ip := range msg.AdditionalIps {
+				if len(ip.IpAddr) != net.IPv6len {
+					return nil, fmt.Errorf(
+						"%w: invalid IP address length %d in version message",
+						errInvalidIPAddrLen,
+						len(ip.IpAddr),
+					)
+				}
+				additionalIPs[i] = ips.IPPort{
+					IP:   net.IP(ip.IpAddr),
+					Port: uint16(ip.IpPort),
+				}
+			}
+			return additionalIPs, nil
+		case AdditionalIPsSig:
+			return msg.AdditionalIpsSig, nil
+		case Capabilities:
+			return msg.Capabilities, nil
+		}
+
+	case *p2ppb.Message_GetPeerList_:
+		msg := m.GetGetPeerList_()
+		if field == ValidatorIDs {
+			return msg.ValidatorIds, nil
 		}
 
 	case *p2ppb.Message_PeerList:
@@ -433,6 +480,19 @@ func getField(m *p2ppb.Message, field Field) (interface{}, error) {
 		case AppBytes:
 			return msg.AppBytes, nil
 		}
+
+	case *p2ppb.Message_AppError:
+		msg := m.GetAppError()
+		switch field {
+		case ChainID:
+			return msg.ChainId, nil
+		case RequestID:
+			return msg.RequestId, nil
+		case ErrorCode:
+			return msg.ErrorCode, nil
+		case ErrorMessage:
+			return msg.ErrorMessage, nil
+		}
 	}
 	return nil, fmt.Errorf("%w: %s", errMissingField, field)
 }
@@ -444,6 +504,7 @@ type OutboundMessage interface {
 	Bytes() []byte
 	Op() Op
 	BypassThrottling() bool
+	CompressionType() compression.Type
 
 	AddRef()
 	DecRef()
@@ -456,6 +517,7 @@ type outboundMessage struct {
 	bytes                 []byte
 	bytesSavedCompression int
 	bypassThrottling      bool
+	compressionType       compression.Type
 }
 
 // Op returns the value of the specified operation in this message
@@ -475,6 +537,12 @@ func (outMsg *outboundMessage) BytesSavedCompression() int {
 // BypassThrottling when attempting to send this message
 func (outMsg *outboundMessage) BypassThrottling() bool { return outMsg.bypassThrottling }
 
+// CompressionType returns the algorithm used to compress this message, or
+// [compression.TypeNone] if it wasn't compressed.
+func (outMsg *outboundMessage) CompressionType() compression.Type {
+	return outMsg.compressionType
+}
+
 type outboundMessageWithPacker struct {
 	outboundMessage
 
@@ -514,13 +582,14 @@ func (outMsg *outboundMessageWithProto) AddRef()       {}
 func (outMsg *outboundMessageWithProto) DecRef()       {}
 func (outMsg *outboundMessageWithProto) IsProto() bool { return true }
 
-// TODO: add other compression algorithms with extended interface
 type msgBuilderProtobuf struct {
 	gzipCompressor compression.Compressor
+	zstdCompressor compression.Compressor
 	clock          mockable.Clock
 
-	compressTimeMetrics   map[Op]metric.Averager
-	decompressTimeMetrics map[Op]metric.Averager
+	compressTimeMetrics     map[compression.Type]map[Op]metric.Averager
+	decompressTimeMetrics   map[compression.Type]map[Op]metric.Averager
+	compressionRatioMetrics map[compression.Type]map[Op]metric.Averager
 
 	maxMessageTimeout time.Duration
 }
@@ -528,56 +597,100 @@ type msgBuilderProtobuf struct {
 // NOTE: the metrics registration paths are the same as "NewCodecWithMemoryPool"!
 // To avoid conflicts, use the different namespace if created at the same time.
 func newMsgBuilderProtobuf(namespace string, metrics prometheus.Registerer, maxMessageSize int64, maxMessageTimeout time.Duration) (*msgBuilderProtobuf, error) {
-	cpr, err := compression.NewGzipCompressor(maxMessageSize)
+	gzipCompressor, err := compression.NewGzipCompressor(maxMessageSize)
+	if err != nil {
+		return nil, err
+	}
+	zstdCompressor, err := compression.NewZstdCompressor(maxMessageSize)
 	if err != nil {
 		return nil, err
 	}
 
 	mb := &msgBuilderProtobuf{
-		gzipCompressor: cpr,
+		gzipCompressor: gzipCompressor,
+		zstdCompressor: zstdCompressor,
 
-		compressTimeMetrics:   make(map[Op]metric.Averager, len(ExternalOps)),
-		decompressTimeMetrics: make(map[Op]metric.Averager, len(ExternalOps)),
+		compressTimeMetrics:     make(map[compression.Type]map[Op]metric.Averager, len(compressionTypes)),
+		decompressTimeMetrics:   make(map[compression.Type]map[Op]metric.Averager, len(compressionTypes)),
+		compressionRatioMetrics: make(map[compression.Type]map[Op]metric.Averager, len(compressionTypes)),
 
 		maxMessageTimeout: maxMessageTimeout,
 	}
 
 	errs := wrappers.Errs{}
-	for _, op := range ExternalOps {
-		if !op.Compressible() {
-			continue
+	for _, compressionType := range compressionTypes {
+		// gzip metrics keep their original names so that existing dashboards
+		// continue to work.
+		prefix := ""
+		if compressionType != compression.TypeGzip {
+			prefix = fmt.Sprintf("%s_", compressionType)
 		}
 
-		mb.compressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_compress_time", op),
-			fmt.Sprintf("time (in ns) to compress %s messages", op),
-			metrics,
-			&errs,
-		)
-		mb.decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
-			namespace,
-			fmt.Sprintf("%s_decompress_time", op),
-			fmt.Sprintf("time (in ns) to decompress %s messages", op),
-			metrics,
-			&errs,
-		)
+		compressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		decompressTimeMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		compressionRatioMetrics := make(map[Op]metric.Averager, len(ExternalOps))
+		for _, op := range ExternalOps {
+			if !op.Compressible() {
+				continue
+			}
+
+			compressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%scompress_time", op, prefix),
+				fmt.Sprintf("time (in ns) to %s compress %s messages", compressionType, op),
+				metrics,
+				&errs,
+			)
+			decompressTimeMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%sdecompress_time", op, prefix),
+				fmt.Sprintf("time (in ns) to %s decompress %s messages", compressionType, op),
+				metrics,
+				&errs,
+			)
+			compressionRatioMetrics[op] = metric.NewAveragerWithErrs(
+				namespace,
+				fmt.Sprintf("%s_%s_compression_ratio", op, compressionType),
+				fmt.Sprintf("ratio of uncompressed to %s compressed size of %s messages", compressionType, op),
+				metrics,
+				&errs,
+			)
+		}
+		mb.compressTimeMetrics[compressionType] = compressTimeMetrics
+		mb.decompressTimeMetrics[compressionType] = decompressTimeMetrics
+		mb.compressionRatioMetrics[compressionType] = compressionRatioMetrics
 	}
 	return mb, errs.Err
 }
 
+// compressor returns the compressor for [compressionType].
+func (mb *msgBuilderProtobuf) compressor(compressionType compression.Type) (compression.Compressor, error) {
+	switch compressionType {
+	case compression.TypeGzip:
+		return mb.gzipCompressor, nil
+	case compression.TypeZstd:
+		return mb.zstdCompressor, nil
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownCompressionType, compressionType)
+	}
+}
+
 // NOTE THAT the passed message must be verified beforehand.
 // NOTE THAT the passed message will be modified if compression is enabled.
 // TODO: find a way to not in-place modify the message
-func (mb *msgBuilderProtobuf) marshal(m *p2ppb.Message, gzipCompress bool) ([]byte, int, time.Duration, error) {
+func (mb *msgBuilderProtobuf) marshal(m *p2ppb.Message, compressionType compression.Type) ([]byte, int, time.Duration, error) {
 	uncompressedMsgBytes, err := proto.Marshal(m)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 
-	if !gzipCompress {
+	if compressionType == compression.TypeNone {
 		return uncompressedMsgBytes, 0, 0, nil
 	}
+	compressor, err := mb.compressor(compressionType)
+	if err != nil {
+		return nil, 0, 0, err
+	}
 
 	// If compression is enabled, we marshal twice:
 	// 1. the original message
@@ -586,15 +699,22 @@ func (mb *msgBuilderProtobuf) marshal(m *p2ppb.Message, gzipCompress bool) ([]by
 	// This recursive packing allows us to avoid an extra compression on/off
 	// field in the message.
 	startTime := time.Now()
-	compressedBytes, err := mb.gzipCompressor.Compress(uncompressedMsgBytes)
+	compressedBytes, err := compressor.Compress(uncompressedMsgBytes)
 	if err != nil {
 		return nil, 0, 0, err
 	}
 	compressTook := time.Since(startTime)
 
 	// Original message can be discarded for the compressed message.
-	m.Message = &p2ppb.Message_CompressedGzip{
-		CompressedGzip: compressedBytes,
+	switch compressionType {
+	case compression.TypeGzip:
+		m.Message = &p2ppb.Message_CompressedGzip{
+			CompressedGzip: compressedBytes,
+		}
+	case compression.TypeZstd:
+		m.Message = &p2ppb.Message_CompressedZstd{
+			CompressedZstd: compressedBytes,
+		}
 	}
 	compressedMsgBytes, err := proto.Marshal(m)
 	if err != nil {
@@ -605,40 +725,54 @@ func (mb *msgBuilderProtobuf) marshal(m *p2ppb.Message, gzipCompress bool) ([]by
 	return compressedMsgBytes, bytesSaved, compressTook, nil
 }
 
-func (mb *msgBuilderProtobuf) unmarshal(b []byte) (Op, *p2ppb.Message, bool, int, time.Duration, error) {
+func (mb *msgBuilderProtobuf) unmarshal(b []byte) (Op, *p2ppb.Message, compression.Type, int, time.Duration, error) {
 	m := new(p2ppb.Message)
 	if err := proto.Unmarshal(b, m); err != nil {
-		return 0, nil, false, 0, 0, err
+		return 0, nil, compression.TypeNone, 0, 0, err
 	}
 
-	compressed := m.GetCompressedGzip()
-	if len(compressed) == 0 {
+	var (
+		compressionType compression.Type
+		compressed      []byte
+	)
+	switch {
+	case len(m.GetCompressedGzip()) != 0:
+		compressionType = compression.TypeGzip
+		compressed = m.GetCompressedGzip()
+	case len(m.GetCompressedZstd()) != 0:
+		compressionType = compression.TypeZstd
+		compressed = m.GetCompressedZstd()
+	default:
 		// The message wasn't compressed
 		op, err := msgToOp(m)
-		return op, m, false, 0, 0, err
+		return op, m, compression.TypeNone, 0, 0, err
+	}
+	compressor, err := mb.compressor(compressionType)
+	if err != nil {
+		return 0, nil, compressionType, 0, 0, err
 	}
 
 	startTime := time.Now()
-	decompressed, err := mb.gzipCompressor.Decompress(compressed)
+	decompressed, err := compressor.Decompress(compressed)
 	if err != nil {
-		return 0, nil, true, 0, 0, err
+		return 0, nil, compressionType, 0, 0, err
 	}
 	decompressTook := time.Since(startTime)
 
 	if err := proto.Unmarshal(decompressed, m); err != nil {
-		return 0, nil, true, 0, 0, err
+		return 0, nil, compressionType, 0, 0, err
 	}
 
 	op, err := msgToOp(m)
 	if err != nil {
-		return 0, nil, true, 0, 0, err
+		return 0, nil, compressionType, 0, 0, err
 	}
 	if !op.Compressible() {
-		return 0, nil, true, 0, 0, errUnexpectedCompressedOp
+		return 0, nil, compressionType, 0, 0, errUnexpectedCompressedOp
 	}
 
 	bytesSavedCompression := len(decompressed) - len(compressed)
-	return op, m, true, bytesSavedCompression, decompressTook, nil
+	return op, m, compressionType, bytesSavedCompression, decompressTook, nil
 }
 
 func msgToOp(m *p2ppb.Message) (Op, error) {
@@ -649,6 +783,8 @@ func msgToOp(m *p2ppb.Message) (Op, error) {
 		return Pong, nil
 	case *p2ppb.Message_Version:
 		return Version, nil
+	case *p2ppb.Message_GetPeerList_:
+		return GetPeerList, nil
 	case *p2ppb.Message_PeerList:
 		return PeerList, nil
 	case *p2ppb.Message_GetStateSummaryFrontier:
@@ -687,6 +823,8 @@ func msgToOp(m *p2ppb.Message) (Op, error) {
 		return AppResponse, nil
 	case *p2ppb.Message_AppGossip:
 		return AppGossip, nil
+	case *p2ppb.Message_AppError:
+		return AppError, nil
 	default:
 		return 0, fmt.Errorf("%w: unknown message %T", errUnknownMessageTypeForOp, m.GetMessage())
 	}
@@ -694,13 +832,14 @@ func msgToOp(m *p2ppb.Message) (Op, error) {
 
 // NOTE THAT the passed message will be updated if compression is enabled.
 // TODO: find a way to not in-place modify the message
-func (mb *msgBuilderProtobuf) createOutbound(op Op, msg *p2ppb.Message, gzipCompress bool, bypassThrottling bool) (*outboundMessageWithProto, error) {
-	b, saved, compressTook, err := mb.marshal(msg, gzipCompress)
+func (mb *msgBuilderProtobuf) createOutbound(op Op, msg *p2ppb.Message, compressionType compression.Type, bypassThrottling bool) (*outboundMessageWithProto, error) {
+	b, saved, compressTook, err := mb.marshal(msg, compressionType)
 	if err != nil {
 		return nil, err
 	}
-	if gzipCompress {
-		mb.compressTimeMetrics[op].Observe(float64(compressTook))
+	if compressionType != compression.TypeNone {
+		mb.compressTimeMetrics[compressionType][op].Observe(float64(compressTook))
+		mb.compressionRatioMetrics[compressionType][op].Observe(float64(len(b)+saved) / float64(len(b)))
 	}
 
 	return &outboundMessageWithProto{
@@ -709,18 +848,33 @@ func (mb *msgBuilderProtobuf) createOutbound(op Op, msg *p2ppb.Message, gzipComp
 			bytes:                 b,
 			bytesSavedCompression: saved,
 			bypassThrottling:      bypassThrottling,
+			compressionType:       compressionType,
 		},
 		msg: msg,
 	}, nil
 }
 
+// recompress returns [msg] compressed with [compressionType]. If [msg] is
+// already compressed with [compressionType], [msg] is returned.
+func (mb *msgBuilderProtobuf) recompress(msg *outboundMessageWithProto, compressionType compression.Type) (*outboundMessageWithProto, error) {
+	if msg.compressionType == compressionType {
+		return msg, nil
+	}
+
+	op, m, _, _, _, err := mb.unmarshal(msg.bytes)
+	if err != nil {
+		return nil, err
+	}
+	return mb.createOutbound(op, m, compressionType, msg.bypassThrottling)
+}
+
 func (mb *msgBuilderProtobuf) parseInbound(bytes []byte, nodeID ids.NodeID, onFinishedHandling func()) (*inboundMessageWithProto, error) {
-	op, m, wasCompressed, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
+	op, m, compressionType, bytesSavedCompression, decompressTook, err := mb.unmarshal(bytes)
 	if err != nil {
 		return nil, err
 	}
-	if wasCompressed {
-		mb.decompressTimeMetrics[op].Observe(float64(decompressTook))
+	if compressionType != compression.TypeNone {
+		mb.decompressTimeMetrics[compressionType][op].Observe(float64(decompressTook))
 	}
 
 	var expirationTime time.Time
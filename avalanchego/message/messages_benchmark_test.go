@@ -16,6 +16,7 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/units"
 
@@ -109,7 +110,7 @@ func BenchmarkMarshalVersion(b *testing.B) {
 		}
 
 		if useProtoBuilder {
-			_, err = protoCodec.createOutbound(inboundMsg.op, &protoMsg, false, false)
+			_, err = protoCodec.createOutbound(inboundMsg.op, &protoMsg, compression.TypeNone, false)
 		} else {
 			_, err = proto.Marshal(&protoMsg)
 		}
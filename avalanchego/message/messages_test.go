@@ -18,6 +18,7 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/units"
 
@@ -146,7 +147,7 @@ func TestProtoMarshalSizeAncestors(t *testing.T) {
 	)
 	require.NoError(err)
 
-	b, _, _, err := mb.marshal(&protoMsg, compressible)
+	b, _, _, err := mb.marshal(&protoMsg, compression.TypeGzip)
 	require.NoError(err)
 
 	protoMsgN := len(b)
@@ -169,6 +170,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 	require.NoError(err)
 
 	testID := ids.GenerateTestID()
+	testNodeID := ids.GenerateTestNodeID()
 	compressibleContainers := [][]byte{
 		bytes.Repeat([]byte{0}, 100),
 		bytes.Repeat([]byte{0}, 32),
@@ -187,7 +189,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 		desc                string
 		op                  Op
 		msg                 *p2ppb.Message
-		gzipCompress        bool
+		compressionType     compression.Type
 		bypassThrottling    bool
 		bytesSaved          bool                  // if true, outbound message saved bytes must be non-zero
 		expectedOutboundErr error                 // expected error for creating outbound message
@@ -204,7 +206,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -221,7 +223,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					Ping: &p2ppb.Ping{},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -240,7 +242,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -263,7 +265,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -287,7 +289,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -311,7 +313,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -335,7 +337,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -359,7 +361,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -382,7 +384,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -406,7 +408,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -430,7 +432,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -453,7 +455,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -477,7 +479,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -502,7 +504,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -527,7 +529,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -551,7 +553,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -562,6 +564,25 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 			},
 			expectedGetFieldErr: nil,
 		},
+		{
+			desc: "valid get_peer_list outbound message",
+			op:   GetPeerList,
+			msg: &p2ppb.Message{
+				Message: &p2ppb.Message_GetPeerList_{
+					GetPeerList_: &p2ppb.GetPeerList{
+						ValidatorIds: [][]byte{testNodeID[:]},
+					},
+				},
+			},
+			compressionType:     compression.TypeNone,
+			bypassThrottling:    false,
+			bytesSaved:          false,
+			expectedOutboundErr: nil,
+			fields: map[Field]interface{}{
+				ValidatorIDs: [][]byte{testNodeID[:]},
+			},
+			expectedGetFieldErr: nil,
+		},
 		{
 			desc: "valid peer_list outbound message with no compression",
 			op:   PeerList,
@@ -580,7 +601,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -614,7 +635,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -641,7 +662,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -668,7 +689,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -702,7 +723,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -729,7 +750,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -813,7 +834,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -838,7 +859,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -862,7 +883,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -885,7 +906,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -907,7 +928,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -928,7 +949,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -938,6 +959,31 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 			},
 			expectedGetFieldErr: nil,
 		},
+		{
+			desc: "valid app_error outbound message",
+			op:   AppError,
+			msg: &p2ppb.Message{
+				Message: &p2ppb.Message_AppError{
+					AppError: &p2ppb.AppError{
+						ChainId:      testID[:],
+						RequestId:    1,
+						ErrorCode:    2,
+						ErrorMessage: "rate limited",
+					},
+				},
+			},
+			compressionType:     compression.TypeNone,
+			bypassThrottling:    true,
+			bytesSaved:          false,
+			expectedOutboundErr: nil,
+			fields: map[Field]interface{}{
+				ChainID:      testID[:],
+				RequestID:    uint32(1),
+				ErrorCode:    int32(2),
+				ErrorMessage: "rate limited",
+			},
+			expectedGetFieldErr: nil,
+		},
 		{
 			desc: "valid get_state_summary_frontier outbound message with no compression",
 			op:   GetStateSummaryFrontier,
@@ -950,7 +996,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -973,7 +1019,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -996,7 +1042,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -1020,7 +1066,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -1045,7 +1091,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -1069,7 +1115,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        false,
+			compressionType:     compression.TypeNone,
 			bypassThrottling:    true,
 			bytesSaved:          false,
 			expectedOutboundErr: nil,
@@ -1092,7 +1138,29 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 					},
 				},
 			},
-			gzipCompress:        true,
+			compressionType:     compression.TypeGzip,
+			bypassThrottling:    true,
+			bytesSaved:          true,
+			expectedOutboundErr: nil,
+			fields: map[Field]interface{}{
+				ChainID:    testID[:],
+				RequestID:  uint32(1),
+				SummaryIDs: [][]byte{testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:]},
+			},
+		},
+		{
+			desc: "valid accepted_state_summary_frontier outbound message with zstd compression",
+			op:   AcceptedStateSummary,
+			msg: &p2ppb.Message{
+				Message: &p2ppb.Message_AcceptedStateSummary_{
+					AcceptedStateSummary_: &p2ppb.AcceptedStateSummary{
+						ChainId:    testID[:],
+						RequestId:  1,
+						SummaryIds: [][]byte{testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:], testID[:]},
+					},
+				},
+			},
+			compressionType:     compression.TypeZstd,
 			bypassThrottling:    true,
 			bytesSaved:          true,
 			expectedOutboundErr: nil,
@@ -1109,7 +1177,7 @@ func TestNewOutboundInboundMessageWithProto(t *testing.T) {
 			// copy before we in-place update via marshal
 			oldProtoMsgS := tv.msg.String()
 
-			encodedMsg, err := mb.createOutbound(tv.op, tv.msg, tv.gzipCompress, tv.bypassThrottling)
+			encodedMsg, err := mb.createOutbound(tv.op, tv.msg, tv.compressionType, tv.bypassThrottling)
 			require.ErrorIs(err, tv.expectedOutboundErr, fmt.Errorf("unexpected error %v (%T)", err, err))
 			if tv.expectedOutboundErr != nil {
 				return
@@ -17,7 +17,7 @@ const (
 	// Handshake:
 	_ Op = iota // Used to be a GetVersion message
 	_           // Used to be a Version message
-	_           // Used to be a GetPeerList message
+	GetPeerList
 	Pong
 	Ping
 	_ // Used to be a Pong message
@@ -47,6 +47,8 @@ const (
 	StateSummaryFrontier
 	GetAcceptedStateSummary
 	AcceptedStateSummary
+	// Application level:
+	AppError
 
 	// Internal messages (External messages should be added above these):
 	GetAcceptedFrontierFailed
@@ -67,6 +69,7 @@ const (
 var (
 	HandshakeOps = []Op{
 		Version,
+		GetPeerList,
 		PeerList,
 		Ping,
 		Pong,
@@ -91,6 +94,7 @@ var (
 		Put,
 		Chits,
 		AppResponse,
+		AppError,
 		StateSummaryFrontier,
 		AcceptedStateSummary,
 	}
@@ -156,6 +160,7 @@ var (
 		AppGossip,
 		AppRequestFailed,
 		AppResponse,
+		AppError,
 	}
 
 	RequestToResponseOps = map[Op]Op{
@@ -189,6 +194,11 @@ var (
 		QueryFailed:                   Chits,
 		AppRequestFailed:              AppResponse,
 	}
+	// ErrorToResponseOps maps the messages that may be sent instead of a
+	// response, to report that the request was refused, to that response
+	ErrorToResponseOps = map[Op]Op{
+		AppError: AppResponse,
+	}
 	UnrequestedOps = map[Op]struct{}{
 		GetAcceptedFrontier:     {},
 		GetAccepted:             {},
@@ -206,10 +216,11 @@ var (
 	messages = map[Op][]Field{
 		// Handshake:
 		// TODO: remove NodeID from the Version message
-		Version:  {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
-		PeerList: {Peers},
-		Ping:     {},
-		Pong:     {Uptime},
+		Version:     {NetworkID, NodeID, MyTime, IP, VersionStr, VersionTime, SigBytes, TrackedSubnets},
+		GetPeerList: {ValidatorIDs},
+		PeerList:    {Peers},
+		Ping:        {},
+		Pong:        {Uptime},
 		// Bootstrapping:
 		GetAcceptedFrontier: {ChainID, RequestID, Deadline},
 		AcceptedFrontier:    {ChainID, RequestID, ContainerIDs},
@@ -228,6 +239,7 @@ var (
 		AppRequest:  {ChainID, RequestID, Deadline, AppBytes},
 		AppResponse: {ChainID, RequestID, AppBytes},
 		AppGossip:   {ChainID, AppBytes},
+		AppError:    {ChainID, RequestID, ErrorCode, ErrorMessage},
 		// State Sync
 		GetStateSummaryFrontier: {ChainID, RequestID, Deadline},
 		StateSummaryFrontier:    {ChainID, RequestID, SummaryBytes},
@@ -251,6 +263,8 @@ func (op Op) String() string {
 	switch op {
 	case Version:
 		return "version"
+	case GetPeerList:
+		return "get_peerlist"
 	case PeerList:
 		return "peerlist"
 	case Ping:
@@ -285,6 +299,8 @@ func (op Op) String() string {
 		return "app_response"
 	case AppGossip:
 		return "app_gossip"
+	case AppError:
+		return "app_error"
 	case GetStateSummaryFrontier:
 		return "get_state_summary_frontier"
 	case StateSummaryFrontier:
@@ -4,13 +4,20 @@
 package message
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
-var _ OutboundMsgBuilder = &outMsgBuilderWithPacker{}
+var (
+	_ OutboundMsgBuilder = &outMsgBuilderWithPacker{}
+
+	errRecompressUnsupported = errors.New("recompression is not supported")
+)
 
 // OutboundMsgBuilder builds outbound messages. Outbound messages are returned
 // with a reference count of 1. Once the reference count hits 0, the message
@@ -24,8 +31,13 @@ type OutboundMsgBuilder interface {
 		myVersionTime uint64,
 		sig []byte,
 		trackedSubnets []ids.ID,
+		additionalIPs []ips.IPPort,
+		additionalIPsSig []byte,
+		capabilities uint64,
 	) (OutboundMessage, error)
 
+	GetPeerList(validatorIDs []ids.NodeID) (OutboundMessage, error)
+
 	PeerList(
 		peers []ips.ClaimedIPPort,
 		bypassThrottling bool,
@@ -148,6 +160,20 @@ type OutboundMsgBuilder interface {
 		chainID ids.ID,
 		msg []byte,
 	) (OutboundMessage, error)
+
+	AppError(
+		chainID ids.ID,
+		requestID uint32,
+		errorCode int32,
+		errorMessage string,
+	) (OutboundMessage, error)
+
+	// Recompress returns a copy of [msg] compressed with [compressionType]. If
+	// [msg] is already compressed with [compressionType], [msg] is returned.
+	Recompress(
+		msg OutboundMessage,
+		compressionType compression.Type,
+	) (OutboundMessage, error)
 }
 
 type outMsgBuilderWithPacker struct {
@@ -170,6 +196,13 @@ func (b *outMsgBuilderWithPacker) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	// Packer based Version messages can't carry additional IPs, so peers
+	// that receive them only learn [ip].
+	_ []ips.IPPort,
+	_ []byte,
+	// Nor can they carry capabilities, so peers that receive them assume that
+	// the local node supports no optional features.
+	_ uint64,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	for i, containerID := range trackedSubnets {
@@ -193,6 +226,19 @@ func (b *outMsgBuilderWithPacker) Version(
 	)
 }
 
+func (b *outMsgBuilderWithPacker) GetPeerList(validatorIDs []ids.NodeID) (OutboundMessage, error) {
+	validatorIDBytes := make([][]byte, len(validatorIDs))
+	encodeNodeIDs(validatorIDs, validatorIDBytes)
+	return b.c.Pack(
+		GetPeerList,
+		map[Field]interface{}{
+			ValidatorIDs: validatorIDBytes,
+		},
+		b.compress && GetPeerList.Compressible(),
+		false,
+	)
+}
+
 func (b *outMsgBuilderWithPacker) PeerList(peers []ips.ClaimedIPPort, bypassThrottling bool) (OutboundMessage, error) {
 	return b.c.Pack(
 		PeerList,
@@ -537,6 +583,21 @@ func (b *outMsgBuilderWithPacker) AppResponse(chainID ids.ID, requestID uint32,
 	)
 }
 
+// Application level error sent instead of a response
+func (b *outMsgBuilderWithPacker) AppError(chainID ids.ID, requestID uint32, errorCode int32, errorMessage string) (OutboundMessage, error) {
+	return b.c.Pack(
+		AppError,
+		map[Field]interface{}{
+			ChainID:      chainID[:],
+			RequestID:    requestID,
+			ErrorCode:    errorCode,
+			ErrorMessage: errorMessage,
+		},
+		b.compress && AppError.Compressible(),
+		false,
+	)
+}
+
 // Application level gossiped message
 func (b *outMsgBuilderWithPacker) AppGossip(chainID ids.ID, msg []byte) (OutboundMessage, error) {
 	return b.c.Pack(
@@ -549,3 +610,12 @@ func (b *outMsgBuilderWithPacker) AppGossip(chainID ids.ID, msg []byte) (Outboun
 		false,
 	)
 }
+
+// Recompress is only supported for protobuf messages, so [msg] is returned
+// only if it is already compressed with [compressionType].
+func (*outMsgBuilderWithPacker) Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	if msg.CompressionType() == compressionType {
+		return msg, nil
+	}
+	return nil, fmt.Errorf("%w: %s message from %s to %s", errRecompressUnsupported, msg.Op(), msg.CompressionType(), compressionType)
+}
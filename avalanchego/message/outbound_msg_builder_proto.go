@@ -4,9 +4,11 @@
 package message
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 
 	p2ppb "github.com/ava-labs/avalanchego/proto/pb/p2p"
@@ -15,20 +17,28 @@ import (
 var _ OutboundMsgBuilder = &outMsgBuilderWithProto{}
 
 type outMsgBuilderWithProto struct {
-	compress bool // set to "true" if compression is enabled
+	compressionType compression.Type // set to "TypeNone" if compression is disabled
 
 	protoBuilder *msgBuilderProtobuf
 }
 
 // Use "message.NewCreatorWithProto" to import this function
 // since we do not expose "msgBuilderProtobuf" yet
-func newOutboundBuilderWithProto(enableCompression bool, protoBuilder *msgBuilderProtobuf) OutboundMsgBuilder {
+func newOutboundBuilderWithProto(compressionType compression.Type, protoBuilder *msgBuilderProtobuf) OutboundMsgBuilder {
 	return &outMsgBuilderWithProto{
-		compress:     enableCompression,
-		protoBuilder: protoBuilder,
+		compressionType: compressionType,
+		protoBuilder:    protoBuilder,
 	}
 }
 
+// compressionTypeFor returns the compression type to use for messages of [op].
+func (b *outMsgBuilderWithProto) compressionTypeFor(op Op) compression.Type {
+	if !op.Compressible() {
+		return compression.TypeNone
+	}
+	return b.compressionType
+}
+
 func (b *outMsgBuilderWithProto) Version(
 	networkID uint32,
 	myTime uint64,
@@ -37,12 +47,22 @@ func (b *outMsgBuilderWithProto) Version(
 	myVersionTime uint64,
 	sig []byte,
 	trackedSubnets []ids.ID,
+	additionalIPs []ips.IPPort,
+	additionalIPsSig []byte,
+	capabilities uint64,
 ) (OutboundMessage, error) {
 	subnetIDBytes := make([][]byte, len(trackedSubnets))
 	for i, containerID := range trackedSubnets {
 		copy := containerID
 		subnetIDBytes[i] = copy[:]
 	}
+	additionalIPPorts := make([]*p2ppb.IpPort, len(additionalIPs))
+	for i, ip := range additionalIPs {
+		additionalIPPorts[i] = &p2ppb.IpPort{
+			IpAddr: []byte(ip.IP.To16()), // ref. "wrappers.TryPackIP"
+			IpPort: uint32(ip.Port),
+		}
+	}
 	return b.protoBuilder.createOutbound(
 		Version,
 		&p2ppb.Message{
@@ -56,14 +76,40 @@ func (b *outMsgBuilderWithProto) Version(
 					MyVersionTime:  myVersionTime,
 					Sig:            sig,
 					TrackedSubnets: subnetIDBytes,
+					// Every node that supports protobuf messages is able to
+					// decompress gzip, so only the additional algorithms
+					// are advertised.
+					SupportedCompressions: []p2ppb.Compression{
+						p2ppb.Compression_COMPRESSION_ZSTD,
+					},
+					AdditionalIps:    additionalIPPorts,
+					AdditionalIpsSig: additionalIPsSig,
+					Capabilities:     capabilities,
 				},
 			},
 		},
-		b.compress && Version.Compressible(),
+		b.compressionTypeFor(Version),
 		true,
 	)
 }
 
+func (b *outMsgBuilderWithProto) GetPeerList(validatorIDs []ids.NodeID) (OutboundMessage, error) {
+	validatorIDBytes := make([][]byte, len(validatorIDs))
+	encodeNodeIDs(validatorIDs, validatorIDBytes)
+	return b.protoBuilder.createOutbound(
+		GetPeerList,
+		&p2ppb.Message{
+			Message: &p2ppb.Message_GetPeerList_{
+				GetPeerList_: &p2ppb.GetPeerList{
+					ValidatorIds: validatorIDBytes,
+				},
+			},
+		},
+		b.compressionTypeFor(GetPeerList),
+		false,
+	)
+}
+
 func (b *outMsgBuilderWithProto) PeerList(peers []ips.ClaimedIPPort, bypassThrottling bool) (OutboundMessage, error) {
 	claimIPPorts := make([]*p2ppb.ClaimedIpPort, len(peers))
 	for i, p := range peers {
@@ -87,7 +133,7 @@ func (b *outMsgBuilderWithProto) PeerList(peers []ips.ClaimedIPPort, bypassThrot
 				},
 			},
 		},
-		b.compress && PeerList.Compressible(),
+		b.compressionTypeFor(PeerList),
 		bypassThrottling,
 	)
 }
@@ -100,7 +146,7 @@ func (b *outMsgBuilderWithProto) Ping() (OutboundMessage, error) {
 				Ping: &p2ppb.Ping{},
 			},
 		},
-		b.compress && Ping.Compressible(),
+		b.compressionTypeFor(Ping),
 		false,
 	)
 }
@@ -115,7 +161,7 @@ func (b *outMsgBuilderWithProto) Pong(uptimePercentage uint8) (OutboundMessage,
 				},
 			},
 		},
-		b.compress && Pong.Compressible(),
+		b.compressionTypeFor(Pong),
 		false,
 	)
 }
@@ -136,7 +182,7 @@ func (b *outMsgBuilderWithProto) GetStateSummaryFrontier(
 				},
 			},
 		},
-		b.compress && GetStateSummaryFrontier.Compressible(),
+		b.compressionTypeFor(GetStateSummaryFrontier),
 		false,
 	)
 }
@@ -157,7 +203,7 @@ func (b *outMsgBuilderWithProto) StateSummaryFrontier(
 				},
 			},
 		},
-		b.compress && StateSummaryFrontier.Compressible(),
+		b.compressionTypeFor(StateSummaryFrontier),
 		false,
 	)
 }
@@ -180,7 +226,7 @@ func (b *outMsgBuilderWithProto) GetAcceptedStateSummary(
 				},
 			},
 		},
-		b.compress && GetAcceptedStateSummary.Compressible(),
+		b.compressionTypeFor(GetAcceptedStateSummary),
 		false,
 	)
 }
@@ -203,7 +249,7 @@ func (b *outMsgBuilderWithProto) AcceptedStateSummary(
 				},
 			},
 		},
-		b.compress && AcceptedStateSummary.Compressible(),
+		b.compressionTypeFor(AcceptedStateSummary),
 		false,
 	)
 }
@@ -224,7 +270,7 @@ func (b *outMsgBuilderWithProto) GetAcceptedFrontier(
 				},
 			},
 		},
-		b.compress && GetAcceptedFrontier.Compressible(),
+		b.compressionTypeFor(GetAcceptedFrontier),
 		false,
 	)
 }
@@ -247,7 +293,7 @@ func (b *outMsgBuilderWithProto) AcceptedFrontier(
 				},
 			},
 		},
-		b.compress && AcceptedFrontier.Compressible(),
+		b.compressionTypeFor(AcceptedFrontier),
 		false,
 	)
 }
@@ -272,7 +318,7 @@ func (b *outMsgBuilderWithProto) GetAccepted(
 				},
 			},
 		},
-		b.compress && GetAccepted.Compressible(),
+		b.compressionTypeFor(GetAccepted),
 		false,
 	)
 }
@@ -295,7 +341,7 @@ func (b *outMsgBuilderWithProto) Accepted(
 				},
 			},
 		},
-		b.compress && Accepted.Compressible(),
+		b.compressionTypeFor(Accepted),
 		false,
 	)
 }
@@ -318,7 +364,7 @@ func (b *outMsgBuilderWithProto) GetAncestors(
 				},
 			},
 		},
-		b.compress && GetAncestors.Compressible(),
+		b.compressionTypeFor(GetAncestors),
 		false,
 	)
 }
@@ -339,7 +385,7 @@ func (b *outMsgBuilderWithProto) Ancestors(
 				},
 			},
 		},
-		b.compress && Ancestors.Compressible(),
+		b.compressionTypeFor(Ancestors),
 		false,
 	)
 }
@@ -362,7 +408,7 @@ func (b *outMsgBuilderWithProto) Get(
 				},
 			},
 		},
-		b.compress && Get.Compressible(),
+		b.compressionTypeFor(Get),
 		false,
 	)
 }
@@ -383,7 +429,7 @@ func (b *outMsgBuilderWithProto) Put(
 				},
 			},
 		},
-		b.compress && Put.Compressible(),
+		b.compressionTypeFor(Put),
 		false,
 	)
 }
@@ -406,7 +452,7 @@ func (b *outMsgBuilderWithProto) PushQuery(
 				},
 			},
 		},
-		b.compress && PushQuery.Compressible(),
+		b.compressionTypeFor(PushQuery),
 		false,
 	)
 }
@@ -429,7 +475,7 @@ func (b *outMsgBuilderWithProto) PullQuery(
 				},
 			},
 		},
-		b.compress && PullQuery.Compressible(),
+		b.compressionTypeFor(PullQuery),
 		false,
 	)
 }
@@ -452,7 +498,7 @@ func (b *outMsgBuilderWithProto) Chits(
 				},
 			},
 		},
-		b.compress && Chits.Compressible(),
+		b.compressionTypeFor(Chits),
 		false,
 	)
 }
@@ -476,7 +522,7 @@ func (b *outMsgBuilderWithProto) AppRequest(
 				},
 			},
 		},
-		b.compress && AppRequest.Compressible(),
+		b.compressionTypeFor(AppRequest),
 		false,
 	)
 }
@@ -494,7 +540,26 @@ func (b *outMsgBuilderWithProto) AppResponse(chainID ids.ID, requestID uint32, m
 				},
 			},
 		},
-		b.compress && AppResponse.Compressible(),
+		b.compressionTypeFor(AppResponse),
+		false,
+	)
+}
+
+// Application level error sent instead of a response
+func (b *outMsgBuilderWithProto) AppError(chainID ids.ID, requestID uint32, errorCode int32, errorMessage string) (OutboundMessage, error) {
+	return b.protoBuilder.createOutbound(
+		AppError,
+		&p2ppb.Message{
+			Message: &p2ppb.Message_AppError{
+				AppError: &p2ppb.AppError{
+					ChainId:      chainID[:],
+					RequestId:    requestID,
+					ErrorCode:    errorCode,
+					ErrorMessage: errorMessage,
+				},
+			},
+		},
+		b.compressionTypeFor(AppError),
 		false,
 	)
 }
@@ -511,7 +576,15 @@ func (b *outMsgBuilderWithProto) AppGossip(chainID ids.ID, msg []byte) (Outbound
 				},
 			},
 		},
-		b.compress && AppGossip.Compressible(),
+		b.compressionTypeFor(AppGossip),
 		false,
 	)
 }
+
+func (b *outMsgBuilderWithProto) Recompress(msg OutboundMessage, compressionType compression.Type) (OutboundMessage, error) {
+	protoMsg, ok := msg.(*outboundMessageWithProto)
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected message type %T", errRecompressUnsupported, msg)
+	}
+	return b.protoBuilder.recompress(protoMsg, compressionType)
+}
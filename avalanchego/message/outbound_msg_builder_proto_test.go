@@ -4,6 +4,7 @@
 package message
 
 import (
+	"net"
 	"testing"
 	"time"
 
@@ -12,7 +13,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 func Test_newOutboundBuilderWithProto(t *testing.T) {
@@ -22,10 +25,148 @@ func Test_newOutboundBuilderWithProto(t *testing.T) {
 	mb, err := newMsgBuilderProtobuf("test", prometheus.NewRegistry(), int64(constants.DefaultMaxMessageSize), 5*time.Second)
 	require.NoError(err)
 
-	builder := newOutboundBuilderWithProto(true /*compress*/, mb)
+	builder := newOutboundBuilderWithProto(compression.TypeGzip, mb)
 
 	outMsg, err := builder.GetAcceptedStateSummary(ids.GenerateTestID(), uint32(12345), time.Hour, []uint64{1000, 2000})
 	require.NoError(err)
 
 	t.Logf("outbound message built %q with size %d", outMsg.Op().String(), len(outMsg.Bytes()))
 }
+
+func TestRecompressWithProto(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	mb, err := newMsgBuilderProtobuf("test", prometheus.NewRegistry(), int64(constants.DefaultMaxMessageSize), 5*time.Second)
+	require.NoError(err)
+
+	builder := newOutboundBuilderWithProto(compression.TypeZstd, mb)
+
+	chainID := ids.GenerateTestID()
+	container := make([]byte, 1024)
+	zstdMsg, err := builder.Put(chainID, 12345, container)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, zstdMsg.CompressionType())
+
+	sameMsg, err := builder.Recompress(zstdMsg, compression.TypeZstd)
+	require.NoError(err)
+	require.Equal(zstdMsg, sameMsg)
+
+	gzipMsg, err := builder.Recompress(zstdMsg, compression.TypeGzip)
+	require.NoError(err)
+	require.Equal(compression.TypeGzip, gzipMsg.CompressionType())
+	require.Equal(zstdMsg.Op(), gzipMsg.Op())
+	require.Equal(zstdMsg.BypassThrottling(), gzipMsg.BypassThrottling())
+
+	parsedMsg, err := mb.parseInbound(gzipMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+	require.Equal(Put, parsedMsg.Op())
+
+	parsedContainer, err := parsedMsg.Get(ContainerBytes)
+	require.NoError(err)
+	require.Equal(container, parsedContainer)
+}
+
+func TestVersionCompressionTypesWithProto(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	mb, err := newMsgBuilderProtobuf("test", prometheus.NewRegistry(), int64(constants.DefaultMaxMessageSize), 5*time.Second)
+	require.NoError(err)
+
+	builder := newOutboundBuilderWithProto(compression.TypeNone, mb)
+
+	outMsg, err := builder.Version(
+		1,
+		2,
+		ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 5},
+		"version",
+		3,
+		[]byte{'s', 'i', 'g'},
+		nil,
+		nil,
+		nil,
+		0,
+	)
+	require.NoError(err)
+
+	parsedMsg, err := mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+
+	compressionTypes, err := parsedMsg.Get(CompressionTypes)
+	require.NoError(err)
+	require.Equal([]compression.Type{compression.TypeZstd}, compressionTypes)
+}
+
+func TestVersionAdditionalIPsWithProto(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	mb, err := newMsgBuilderProtobuf("test", prometheus.NewRegistry(), int64(constants.DefaultMaxMessageSize), 5*time.Second)
+	require.NoError(err)
+
+	builder := newOutboundBuilderWithProto(compression.TypeNone, mb)
+
+	additionalIPs := []ips.IPPort{
+		{IP: net.ParseIP("2001:db8::1"), Port: 5},
+		{IP: net.IPv4(5, 6, 7, 8), Port: 6},
+	}
+	outMsg, err := builder.Version(
+		1,
+		2,
+		ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 5},
+		"version",
+		3,
+		[]byte{'s', 'i', 'g'},
+		nil,
+		additionalIPs,
+		[]byte{'s', 'i', 'g', '2'},
+		0,
+	)
+	require.NoError(err)
+
+	parsedMsg, err := mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+
+	parsedIPs, err := parsedMsg.Get(AdditionalIPs)
+	require.NoError(err)
+	require.Len(parsedIPs, 2)
+	for i, ip := range parsedIPs.([]ips.IPPort) {
+		require.True(additionalIPs[i].Equal(ip))
+	}
+
+	sig, err := parsedMsg.Get(AdditionalIPsSig)
+	require.NoError(err)
+	require.Equal([]byte{'s', 'i', 'g', '2'}, sig)
+}
+
+func TestVersionCapabilitiesWithProto(t *testing.T) {
+	t.Parallel()
+	require := require.New(t)
+
+	mb, err := newMsgBuilderProtobuf("test", prometheus.NewRegistry(), int64(constants.DefaultMaxMessageSize), 5*time.Second)
+	require.NoError(err)
+
+	builder := newOutboundBuilderWithProto(compression.TypeNone, mb)
+
+	outMsg, err := builder.Version(
+		1,
+		2,
+		ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 5},
+		"version",
+		3,
+		[]byte{'s', 'i', 'g'},
+		nil,
+		nil,
+		nil,
+		5,
+	)
+	require.NoError(err)
+
+	parsedMsg, err := mb.parseInbound(outMsg.Bytes(), ids.EmptyNodeID, func() {})
+	require.NoError(err)
+
+	capabilities, err := parsedMsg.Get(Capabilities)
+	require.NoError(err)
+	require.Equal(uint64(5), capabilities)
+}
@@ -3,6 +3,10 @@
 
 package message
 
+import (
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
 type TestMsg struct {
 	op               Op
 	bytes            []byte
@@ -25,3 +29,6 @@ func (*TestMsg) AddRef()                    {}
 func (*TestMsg) DecRef()                    {}
 func (*TestMsg) IsProto() bool              { return false }
 func (m *TestMsg) BypassThrottling() bool   { return m.bypassThrottling }
+func (*TestMsg) CompressionType() compression.Type {
+	return compression.TypeNone
+}
@@ -4,7 +4,10 @@
 package nat
 
 import (
+	"errors"
+	"fmt"
 	"net"
+	"sort"
 	"sync"
 	"time"
 
@@ -15,10 +18,13 @@ import (
 )
 
 const (
-	mapTimeout        = 30 * time.Minute
-	maxRefreshRetries = 3
+	mapTimeout          = 30 * time.Minute
+	maxRefreshRetries   = 3
+	reachabilityTimeout = 10 * time.Second
 )
 
+var errUnhealthyMappings = errors.New("port mappings are unhealthy")
+
 // Router describes the functionality that a network device must support to be
 // able to open ports to an external IP.
 type Router interface {
@@ -44,23 +50,59 @@ func GetRouter() Router {
 	return NewNoRouter()
 }
 
+// MappingStatus is the result of the most recent attempt to map a port
+type MappingStatus struct {
+	Protocol      string    `json:"protocol"`
+	InternalPort  uint16    `json:"internalPort"`
+	ExternalPort  uint16    `json:"externalPort"`
+	LastAttempt   time.Time `json:"lastAttempt"`
+	LastMapped    time.Time `json:"lastMapped"`
+	MappingError  string    `json:"mappingError,omitempty"`
+	Reachable     *bool     `json:"reachable,omitempty"`
+	ReachableAddr string    `json:"reachableAddr,omitempty"`
+	// ReachabilityError is set if the external address of the mapping was
+	// verified to be unreachable.
+	ReachabilityError string `json:"reachabilityError,omitempty"`
+}
+
 // Mapper attempts to open a set of ports on a router
 type Mapper struct {
 	log    logging.Logger
 	r      Router
 	closer chan struct{}
 	wg     sync.WaitGroup
+
+	// If true, after mapping a port whose external IP is known, the mapper
+	// dials the external address to verify that it is reachable.
+	verifyReachability bool
+	// dial is used to verify the reachability of a mapped port
+	dial func(addr string) error
+
+	statusLock sync.RWMutex
+	// external port -> status of the mapping
+	status map[uint16]*MappingStatus
 }
 
 // NewPortMapper returns an initialized mapper
-func NewPortMapper(log logging.Logger, r Router) Mapper {
-	return Mapper{
-		log:    log,
-		r:      r,
-		closer: make(chan struct{}),
+func NewPortMapper(log logging.Logger, r Router, verifyReachability bool) *Mapper {
+	return &Mapper{
+		log:                log,
+		r:                  r,
+		closer:             make(chan struct{}),
+		verifyReachability: verifyReachability,
+		dial:               dialTCP,
+		status:             make(map[uint16]*MappingStatus),
 	}
 }
 
+func dialTCP(addr string) error {
+	conn, err := net.DialTimeout("tcp", addr, reachabilityTimeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 // Map external port [extPort] (exposed to the internet) to internal port [intPort] (where our process is listening)
 // and set [ip]. Does this every [updateTime]. [ip] may be nil.
 func (m *Mapper) Map(protocol string, intPort, extPort uint16, desc string, ip ips.DynamicIPPort, updateTime time.Duration) {
@@ -70,6 +112,7 @@ func (m *Mapper) Map(protocol string, intPort, extPort uint16, desc string, ip i
 
 	// we attempt a port map, and log an Error if it fails.
 	err := m.retryMapPort(protocol, intPort, extPort, desc, mapTimeout)
+	m.recordMapping(protocol, intPort, extPort, ip, err)
 	if err != nil {
 		m.log.Error("NAT traversal failed",
 			zap.Uint16("externalPort", extPort),
@@ -145,6 +188,7 @@ func (m *Mapper) keepPortMapping(protocol string, intPort, extPort uint16, desc
 				)
 			}
 			m.updateIP(ip)
+			m.recordMapping(protocol, intPort, extPort, ip, err)
 			updateTimer.Reset(updateTime)
 		case <-m.closer:
 			return
@@ -172,6 +216,79 @@ func (m *Mapper) updateIP(ip ips.DynamicIPPort) {
 	}
 }
 
+// recordMapping stores the result of mapping [extPort]. If the mapping
+// succeeded and reachability verification is enabled, the external address is
+// dialed to confirm that the mapping is effective.
+func (m *Mapper) recordMapping(protocol string, intPort, extPort uint16, ip ips.DynamicIPPort, mapErr error) {
+	now := time.Now()
+	status := MappingStatus{
+		Protocol:     protocol,
+		InternalPort: intPort,
+		ExternalPort: extPort,
+		LastAttempt:  now,
+	}
+
+	m.statusLock.RLock()
+	if previous, ok := m.status[extPort]; ok {
+		status.LastMapped = previous.LastMapped
+	}
+	m.statusLock.RUnlock()
+
+	if mapErr != nil {
+		status.MappingError = mapErr.Error()
+	} else {
+		status.LastMapped = now
+		if m.verifyReachability && ip != nil {
+			addr := ips.IPPort{
+				IP:   ip.IPPort().IP,
+				Port: extPort,
+			}.String()
+			reachable := true
+			if err := m.dial(addr); err != nil {
+				reachable = false
+				status.ReachabilityError = err.Error()
+				m.log.Warn("mapped port is unreachable",
+					zap.String("address", addr),
+					zap.Error(err),
+				)
+			}
+			status.Reachable = &reachable
+			status.ReachableAddr = addr
+		}
+	}
+
+	m.statusLock.Lock()
+	m.status[extPort] = &status
+	m.statusLock.Unlock()
+}
+
+// HealthCheck reports an error if the most recent attempt to map, or to verify
+// the reachability of, any port failed.
+func (m *Mapper) HealthCheck() (interface{}, error) {
+	m.statusLock.RLock()
+	defer m.statusLock.RUnlock()
+
+	mappings := make([]MappingStatus, 0, len(m.status))
+	unhealthy := 0
+	for _, status := range m.status {
+		mappings = append(mappings, *status)
+		if status.MappingError != "" || status.ReachabilityError != "" {
+			unhealthy++
+		}
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].ExternalPort < mappings[j].ExternalPort
+	})
+	details := map[string]interface{}{
+		"supportsNAT": m.r.SupportsNAT(),
+		"mappings":    mappings,
+	}
+	if unhealthy > 0 {
+		return details, fmt.Errorf("%w: %d of %d failed", errUnhealthyMappings, unhealthy, len(mappings))
+	}
+	return details, nil
+}
+
 // UnmapAllPorts stops mapping all ports from this mapper and attempts to unmap
 // them.
 func (m *Mapper) UnmapAllPorts() {
@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package nat
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errTest = errors.New("non-nil error")
+
+type testRouter struct {
+	mapErr error
+}
+
+func (*testRouter) SupportsNAT() bool { return true }
+
+func (r *testRouter) MapPort(string, uint16, uint16, string, time.Duration) error {
+	return r.mapErr
+}
+
+func (*testRouter) UnmapPort(string, uint16, uint16) error { return nil }
+
+func (*testRouter) ExternalIP() (net.IP, error) { return net.IPv4(1, 2, 3, 4), nil }
+
+func TestMapperHealthCheck(t *testing.T) {
+	require := require.New(t)
+
+	router := &testRouter{}
+	mapper := NewPortMapper(logging.NoLog{}, router, true)
+	var dialed string
+	mapper.dial = func(addr string) error {
+		dialed = addr
+		return nil
+	}
+	ip := ips.NewDynamicIPPort(net.IPv4(1, 2, 3, 4), 9651)
+
+	mapper.recordMapping("TCP", 9651, 9651, ip, nil)
+	_, err := mapper.HealthCheck()
+	require.NoError(err)
+	require.Equal("1.2.3.4:9651", dialed)
+
+	// Unreachable external address
+	mapper.dial = func(string) error { return errTest }
+	mapper.recordMapping("TCP", 9651, 9651, ip, nil)
+	_, err = mapper.HealthCheck()
+	require.ErrorIs(err, errUnhealthyMappings)
+
+	// Failed renewal keeps the last successful mapping time
+	mapper.recordMapping("TCP", 9651, 9651, ip, errTest)
+	details, err := mapper.HealthCheck()
+	require.ErrorIs(err, errUnhealthyMappings)
+	mappings := details.(map[string]interface{})["mappings"].([]MappingStatus)
+	require.Len(mappings, 1)
+	require.False(mappings[0].LastMapped.IsZero())
+	require.Equal(errTest.Error(), mappings[0].MappingError)
+
+	// Successful renewal without reachability verification is healthy
+	mapper.verifyReachability = false
+	mapper.recordMapping("TCP", 9651, 9651, ip, nil)
+	_, err = mapper.HealthCheck()
+	require.NoError(err)
+}
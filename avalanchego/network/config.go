@@ -6,14 +6,17 @@ package network
 import (
 	"crypto"
 	"crypto/tls"
+	"net"
 	"time"
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
@@ -67,6 +70,13 @@ type PeerListGossipConfig struct {
 	// PeerListGossipFreq is the frequency that this node will attempt to gossip
 	// signed IPs to its peers.
 	PeerListGossipFreq time.Duration `json:"peerListGossipFreq"`
+
+	// PeerListRecoveryStakeThreshold is the portion of the Primary Network's
+	// stake that this node must be connected to. Below it, every gossip event
+	// requests the IPs of up to [PeerListNumValidatorIPs] disconnected
+	// validators from [PeerListValidatorGossipSize] validators, and the
+	// validators are dialed without backoff. Should be in [0,1].
+	PeerListRecoveryStakeThreshold float64 `json:"peerListRecoveryStakeThreshold"`
 }
 
 type TimeoutConfig struct {
@@ -109,6 +119,16 @@ type Config struct {
 
 	TLSKeyLogFile string `json:"tlsKeyLogFile"`
 
+	// QUICEnabled accepts peer connections over QUIC, on the UDP ports that
+	// match the staking ports, and dials the peers that advertise QUIC support
+	// over it. Bulk messages are exchanged over streams of their own on QUIC
+	// connections, with their own inbound throttling. It is experimental.
+	QUICEnabled bool `json:"quicEnabled"`
+
+	// PeerCertPolicy restricts the staking certificates peers may present
+	// during the TLS handshake.
+	PeerCertPolicy peer.CertPolicy `json:"peerCertPolicy"`
+
 	Namespace          string            `json:"namespace"`
 	MyNodeID           ids.NodeID        `json:"myNodeID"`
 	MyIPPort           ips.DynamicIPPort `json:"myIP"`
@@ -117,10 +137,23 @@ type Config struct {
 	PingFrequency      time.Duration     `json:"pingFrequency"`
 	AllowPrivateIPs    bool              `json:"allowPrivateIPs"`
 
+	// Other IPs this node is reachable at, which are advertised to peers in
+	// the handshake along with [MyIPPort]
+	MyAdditionalIPs []ips.IPPort `json:"myAdditionalIPs"`
+
 	// CompressionEnabled will compress available outbound messages when set to
 	// true.
 	CompressionEnabled bool `json:"compressionEnabled"`
 
+	// CompressionType is the algorithm used to compress outbound messages
+	// when CompressionEnabled is true.
+	CompressionType compression.Type `json:"compressionType"`
+
+	// LegacyMessagesDisabled drops the legacy, pre-Banff, message format.
+	// Messages received in the legacy format are dropped and all messages are
+	// sent in the proto format.
+	LegacyMessagesDisabled bool `json:"legacyMessagesDisabled"`
+
 	// TLSKey is this node's TLS key that is used to sign IPs.
 	TLSKey crypto.Signer `json:"-"`
 
@@ -128,6 +161,10 @@ type Config struct {
 	WhitelistedSubnets ids.Set        `json:"whitelistedSubnets"`
 	Beacons            validators.Set `json:"beacons"`
 
+	// ValidatorOnlyAllowedNodes are the non-validator nodes, per subnet, that
+	// are still sent messages of validator only chains.
+	ValidatorOnlyAllowedNodes map[ids.ID]ids.NodeIDSet `json:"validatorOnlyAllowedNodes"`
+
 	// Validators are the current validators in the Avalanche network
 	Validators validators.Manager `json:"validators"`
 
@@ -147,6 +184,22 @@ type Config struct {
 	// the network negatively.
 	RequireValidatorToConnect bool `json:"requireValidatorToConnect"`
 
+	// BlocklistedNodeIDs are the nodes this node will never connect to or
+	// accept connections from.
+	BlocklistedNodeIDs ids.NodeIDSet `json:"blocklistedNodeIDs"`
+
+	// BlocklistedIPs are the IPs this node will never connect to or accept
+	// connections from.
+	BlocklistedIPs []net.IP `json:"blocklistedIPs"`
+
+	// GaterConfig describes the connections this node accepts and makes. It
+	// can be changed at runtime through the admin API.
+	GaterConfig GaterConfig `json:"gaterConfig"`
+
+	// PeerBudgetConfig describes how connections to non-validators are shed
+	// as the node approaches its socket budget.
+	PeerBudgetConfig PeerBudgetConfig `json:"peerBudgetConfig"`
+
 	// MaximumInboundMessageTimeout is the maximum deadline duration in a
 	// message. Messages sent by clients setting values higher than this value
 	// will be reset to this value.
@@ -160,6 +213,11 @@ type Config struct {
 	// (there is one buffer per peer)
 	PeerWriteBufferSize int `json:"peerWriteBufferSize"`
 
+	// Maximum number of bytes of bulk messages, such as Ancestors, that are
+	// queued for a peer. Bulk messages are dropped beyond it. 0 means there is
+	// no maximum.
+	PeerMaxQueuedBulkBytes int `json:"peerMaxQueuedBulkBytes"`
+
 	// Tracks the CPU/disk usage caused by processing messages of each peer.
 	ResourceTracker tracker.ResourceTracker `json:"-"`
 
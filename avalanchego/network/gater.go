@@ -0,0 +1,161 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// GaterConfig describes the connections that the network accepts and makes.
+// It is applied before the TLS handshake, so gated connections cost no more
+// than accepting them.
+type GaterConfig struct {
+	// If non-empty, inbound connections are only accepted from these ranges
+	AllowedCIDRs []string `json:"allowedCIDRs"`
+	// Inbound connections from these ranges are rejected
+	DeniedCIDRs []string `json:"deniedCIDRs"`
+	// Outbound connections to these ranges aren't attempted
+	DeniedDialCIDRs []string `json:"deniedDialCIDRs"`
+	// Maximum number of inbound connections from a single IP. 0 means there
+	// is no maximum.
+	MaxConnsPerIP int `json:"maxConnsPerIP"`
+}
+
+// connGater enforces a GaterConfig, which can be replaced at runtime
+type connGater struct {
+	lock          sync.Mutex
+	config        GaterConfig
+	allowed       []*net.IPNet
+	denied        []*net.IPNet
+	deniedDial    []*net.IPNet
+	maxConnsPerIP int
+	// IP -> number of open inbound connections from the IP
+	conns map[string]int
+}
+
+func newConnGater(config GaterConfig) (*connGater, error) {
+	g := &connGater{
+		conns: make(map[string]int),
+	}
+	return g, g.setConfig(config)
+}
+
+// setConfig replaces the config of the gater. Open connections aren't
+// affected.
+func (g *connGater) setConfig(config GaterConfig) error {
+	allowed, err := parseCIDRs(config.AllowedCIDRs)
+	if err != nil {
+		return err
+	}
+	denied, err := parseCIDRs(config.DeniedCIDRs)
+	if err != nil {
+		return err
+	}
+	deniedDial, err := parseCIDRs(config.DeniedDialCIDRs)
+	if err != nil {
+		return err
+	}
+	if config.MaxConnsPerIP < 0 {
+		return fmt.Errorf("max connections per IP must be >= 0 but got %d", config.MaxConnsPerIP)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.config = config
+	g.allowed = allowed
+	g.denied = denied
+	g.deniedDial = deniedDial
+	g.maxConnsPerIP = config.MaxConnsPerIP
+	return nil
+}
+
+func (g *connGater) getConfig() GaterConfig {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return g.config
+}
+
+// admit returns [conn], which was accepted from [ip], wrapped so that closing
+// it frees its slot. If [conn] isn't allowed, the reason is returned instead.
+func (g *connGater) admit(conn net.Conn, ip net.IP) (net.Conn, string, bool) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	switch {
+	case len(g.allowed) > 0 && !containsIP(g.allowed, ip):
+		return nil, "not in allowed CIDRs", false
+	case containsIP(g.denied, ip):
+		return nil, "in denied CIDRs", false
+	}
+
+	key := ip.String()
+	if g.maxConnsPerIP > 0 && g.conns[key] >= g.maxConnsPerIP {
+		return nil, "too many connections from IP", false
+	}
+	g.conns[key]++
+	return &gatedConn{
+		Conn:    conn,
+		release: func() { g.release(key) },
+	}, "", true
+}
+
+func (g *connGater) release(key string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.conns[key]--
+	if g.conns[key] <= 0 {
+		delete(g.conns, key)
+	}
+}
+
+// allowDial returns true if outbound connections to [ip] may be attempted
+func (g *connGater) allowDial(ip net.IP) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	return !containsIP(g.deniedDial, ip)
+}
+
+// gatedConn frees its slot in the gater once it is closed
+type gatedConn struct {
+	net.Conn
+	once    sync.Once
+	release func()
+}
+
+func (c *gatedConn) Close() error {
+	c.once.Do(c.release)
+	return c.Conn.Close()
+}
+
+// NetConn returns the wrapped connection
+func (c *gatedConn) NetConn() net.Conn {
+	return c.Conn
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	ipNets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse CIDR %q: %w", cidr, err)
+		}
+		ipNets[i] = ipNet
+	}
+	return ipNets, nil
+}
+
+func containsIP(ipNets []*net.IPNet, ip net.IP) bool {
+	for _, ipNet := range ipNets {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnGater(t *testing.T) {
+	require := require.New(t)
+
+	_, err := newConnGater(GaterConfig{DeniedCIDRs: []string{"10.0.0.1"}})
+	require.Error(err)
+
+	g, err := newConnGater(GaterConfig{
+		DeniedCIDRs:     []string{"10.0.0.0/8"},
+		DeniedDialCIDRs: []string{"192.168.0.0/16"},
+		MaxConnsPerIP:   1,
+	})
+	require.NoError(err)
+
+	_, reason, ok := g.admit(pipeConn(), net.ParseIP("10.1.2.3"))
+	require.False(ok)
+	require.Equal("in denied CIDRs", reason)
+
+	ip := net.ParseIP("1.2.3.4")
+	conn, _, ok := g.admit(pipeConn(), ip)
+	require.True(ok)
+	_, reason, ok = g.admit(pipeConn(), ip)
+	require.False(ok)
+	require.Equal("too many connections from IP", reason)
+
+	// Closing the connection frees its slot, even if closed repeatedly
+	require.NoError(conn.Close())
+	require.NoError(conn.Close())
+	conn, _, ok = g.admit(pipeConn(), ip)
+	require.True(ok)
+	_, _, ok = g.admit(pipeConn(), ip)
+	require.False(ok)
+	require.NoError(conn.Close())
+
+	require.False(g.allowDial(net.ParseIP("192.168.1.1")))
+	require.True(g.allowDial(net.ParseIP("10.1.2.3")))
+
+	// The config can be replaced at runtime
+	config := GaterConfig{AllowedCIDRs: []string{"172.16.0.0/12"}}
+	require.NoError(g.setConfig(config))
+	require.Equal(config, g.getConfig())
+	_, reason, ok = g.admit(pipeConn(), ip)
+	require.False(ok)
+	require.Equal("not in allowed CIDRs", reason)
+	conn, _, ok = g.admit(pipeConn(), net.ParseIP("172.16.0.1"))
+	require.True(ok)
+	require.NoError(conn.Close())
+	require.True(g.allowDial(net.ParseIP("192.168.1.1")))
+
+	// An invalid config leaves the current config in place
+	require.Error(g.setConfig(GaterConfig{MaxConnsPerIP: -1}))
+	require.Equal(config, g.getConfig())
+}
+
+func pipeConn() net.Conn {
+	conn, _ := net.Pipe()
+	return conn
+}
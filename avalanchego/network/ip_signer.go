@@ -14,9 +14,11 @@ import (
 
 // ipSigner will return a signedIP for the current value of our dynamic IP.
 type ipSigner struct {
-	ip     ips.DynamicIPPort
-	clock  *mockable.Clock
-	signer crypto.Signer
+	ip ips.DynamicIPPort
+	// Signed along with [ip], in order of preference
+	additionalIPs []ips.IPPort
+	clock         *mockable.Clock
+	signer        crypto.Signer
 
 	// Must be held while accessing [signedIP]
 	signedIPLock sync.RWMutex
@@ -27,13 +29,15 @@ type ipSigner struct {
 
 func newIPSigner(
 	ip ips.DynamicIPPort,
+	additionalIPs []ips.IPPort,
 	clock *mockable.Clock,
 	signer crypto.Signer,
 ) *ipSigner {
 	return &ipSigner{
-		ip:     ip,
-		clock:  clock,
-		signer: signer,
+		ip:            ip,
+		additionalIPs: additionalIPs,
+		clock:         clock,
+		signer:        signer,
 	}
 }
 
@@ -67,8 +71,9 @@ func (s *ipSigner) getSignedIP() (*peer.SignedIP, error) {
 
 	// We should now sign our new IP at the current timestamp.
 	unsignedIP := peer.UnsignedIP{
-		IP:        ip,
-		Timestamp: s.clock.Unix(),
+		IP:            ip,
+		Timestamp:     s.clock.Unix(),
+		AdditionalIPs: s.additionalIPs,
 	}
 	signedIP, err := unsignedIP.Sign(s.signer)
 	if err != nil {
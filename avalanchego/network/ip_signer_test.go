@@ -31,7 +31,7 @@ func TestIPSigner(t *testing.T) {
 
 	key := tlsCert.PrivateKey.(crypto.Signer)
 
-	s := newIPSigner(dynIP, &clock, key)
+	s := newIPSigner(dynIP, nil, &clock, key)
 
 	signedIP1, err := s.getSignedIP()
 	require.NoError(err)
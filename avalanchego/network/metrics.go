@@ -24,9 +24,15 @@ type metrics struct {
 	disconnected              prometheus.Counter
 	acceptFailed              *prometheus.CounterVec
 	inboundConnRateLimited    prometheus.Counter
+	inboundConnGated          prometheus.Counter
 	inboundConnAllowed        prometheus.Counter
+	nonValidatorsRefused      prometheus.Counter
+	nonValidatorsShed         prometheus.Counter
 	nodeUptimeWeightedAverage prometheus.Gauge
 	nodeUptimeRewardingStake  prometheus.Gauge
+	connectedStake            prometheus.Gauge
+	stakeRecoveries           prometheus.Counter
+	stakeRecoveryDuration     prometheus.Histogram
 }
 
 func newMetrics(namespace string, registerer prometheus.Registerer, initialSubnetIDs ids.Set) (*metrics, error) {
@@ -94,6 +100,21 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "inbound_conn_throttler_rate_limited",
 			Help:      "Times this node rejected an inbound connection due to rate-limiting",
 		}),
+		inboundConnGated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "inbound_conn_gated",
+			Help:      "Times this node rejected an inbound connection due to the connection gater",
+		}),
+		nonValidatorsRefused: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "non_validators_refused",
+			Help:      "Times this node refused a connection from a non-validator to keep its socket budget for validators",
+		}),
+		nonValidatorsShed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "non_validators_shed",
+			Help:      "Times this node disconnected from a non-validator to keep its socket budget for validators",
+		}),
 		nodeUptimeWeightedAverage: prometheus.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "node_uptime_weighted_average",
@@ -104,6 +125,22 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 			Name:      "node_uptime_rewarding_stake",
 			Help:      "The percentage of total stake which thinks this node is eligible for rewards",
 		}),
+		connectedStake: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "connected_stake",
+			Help:      "Portion of the Primary Network's stake this node is connected to, including its own stake",
+		}),
+		stakeRecoveries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "stake_recoveries",
+			Help:      "Times the connected stake fell below the recovery threshold, starting a recovery",
+		}),
+		stakeRecoveryDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "stake_recovery_duration",
+			Help:      "Time (in s) it took the connected stake to get back above the recovery threshold",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 10),
+		}),
 	}
 
 	errs := wrappers.Errs{}
@@ -120,8 +157,14 @@ func newMetrics(namespace string, registerer prometheus.Registerer, initialSubne
 		registerer.Register(m.acceptFailed),
 		registerer.Register(m.inboundConnAllowed),
 		registerer.Register(m.inboundConnRateLimited),
+		registerer.Register(m.inboundConnGated),
+		registerer.Register(m.nonValidatorsRefused),
+		registerer.Register(m.nonValidatorsShed),
 		registerer.Register(m.nodeUptimeWeightedAverage),
 		registerer.Register(m.nodeUptimeRewardingStake),
+		registerer.Register(m.connectedStake),
+		registerer.Register(m.stakeRecoveries),
+		registerer.Register(m.stakeRecoveryDuration),
 	)
 
 	// init subnet tracker metrics with whitelisted subnets
@@ -150,6 +193,10 @@ func (m *metrics) markConnected(peer peer.Peer) {
 	}
 }
 
+func (m *metrics) markSubnetConnected(subnetID ids.ID) {
+	m.numSubnetPeers.WithLabelValues(subnetID.String()).Inc()
+}
+
 func (m *metrics) markDisconnected(peer peer.Peer) {
 	m.numPeers.Dec()
 	m.disconnected.Inc()
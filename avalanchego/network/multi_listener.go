@@ -0,0 +1,92 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+var (
+	errListenerClosed = errors.New("listener closed")
+
+	_ net.Listener = &multiListener{}
+)
+
+// NewMultiListener returns a listener that accepts the connections of all of
+// [listeners], such as the listeners of the IPv4 and IPv6 addresses of a dual
+// stack node. Its address is the address of the first listener.
+func NewMultiListener(listeners ...net.Listener) net.Listener {
+	if len(listeners) == 1 {
+		return listeners[0]
+	}
+	l := &multiListener{
+		listeners: listeners,
+		accepted:  make(chan acceptResult),
+		closed:    make(chan struct{}),
+	}
+	for _, listener := range listeners {
+		go l.accept(listener)
+	}
+	return l
+}
+
+type acceptResult struct {
+	conn net.Conn
+	err  error
+}
+
+type multiListener struct {
+	listeners []net.Listener
+	accepted  chan acceptResult
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// accept forwards the connections of [listener] until it fails with a
+// non-temporary error, which is forwarded as well.
+func (l *multiListener) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		select {
+		case l.accepted <- acceptResult{conn: conn, err: err}:
+		case <-l.closed:
+			if conn != nil {
+				_ = conn.Close()
+			}
+			return
+		}
+		if netErr, ok := err.(net.Error); err != nil && (!ok || !netErr.Temporary()) {
+			return
+		}
+	}
+}
+
+func (l *multiListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.accepted:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, errListenerClosed
+	}
+}
+
+func (l *multiListener) Close() error {
+	errs := wrappers.Errs{}
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		for _, listener := range l.listeners {
+			errs.Add(listener.Close())
+		}
+	})
+	return errs.Err
+}
+
+func (l *multiListener) Addr() net.Addr {
+	return l.listeners[0].Addr()
+}
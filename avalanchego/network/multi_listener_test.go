@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiListener(t *testing.T) {
+	require := require.New(t)
+
+	listener0, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	listener1, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+
+	listener := NewMultiListener(listener0, listener1)
+	require.Equal(listener0.Addr(), listener.Addr())
+
+	// Connections to every address are accepted.
+	for _, l := range []net.Listener{listener0, listener1} {
+		dialed, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(err)
+
+		accepted, err := listener.Accept()
+		require.NoError(err)
+		require.Equal(dialed.LocalAddr().String(), accepted.RemoteAddr().String())
+		require.Equal(l.Addr().String(), accepted.LocalAddr().String())
+
+		require.NoError(dialed.Close())
+		require.NoError(accepted.Close())
+	}
+
+	require.NoError(listener.Close())
+	_, err = listener.Accept()
+	require.ErrorIs(err, errListenerClosed)
+
+	// The underlying listeners are closed.
+	_, err = net.Dial("tcp", listener1.Addr().String())
+	require.Error(err)
+}
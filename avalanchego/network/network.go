@@ -22,16 +22,17 @@ import (
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
-	"github.com/ava-labs/avalanchego/network/dialer"
 	"github.com/ava-labs/avalanchego/network/peer"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 )
@@ -84,7 +85,22 @@ type Network interface {
 	// info about the peers in [nodeIDs] that have finished the handshake.
 	PeerInfo(nodeIDs []ids.NodeID) []peer.Info
 
+	// PeerMessageCounts returns the number of messages exchanged with each
+	// peer that has finished the handshake.
+	PeerMessageCounts() map[ids.NodeID]peer.MessageCounts
+
+	// TrackSubnet starts tracking [subnetID]. Peers are notified by re-sending
+	// them this node's Version message, which now includes [subnetID].
+	TrackSubnet(subnetID ids.ID) error
+
 	NodeUptime() (UptimeResult, bool)
+
+	// GaterConfig returns the config of the connection gater
+	GaterConfig() GaterConfig
+
+	// SetGaterConfig replaces the config of the connection gater. Existing
+	// connections aren't closed.
+	SetGaterConfig(GaterConfig) error
 }
 
 type UptimeResult struct {
@@ -104,14 +120,12 @@ type network struct {
 
 	// Limits the number of connection attempts based on IP.
 	inboundConnUpgradeThrottler throttling.InboundConnUpgradeThrottler
-	// Listens for and accepts new inbound connections
-	listener net.Listener
-	// Makes new outbound connections
-	dialer dialer.Dialer
-	// Does TLS handshakes for inbound connections
-	serverUpgrader peer.Upgrader
-	// Does TLS handshakes for outbound connections
-	clientUpgrader peer.Upgrader
+	// Accepts new inbound connections, makes new outbound connections and
+	// authenticates both
+	transport Transport
+	// Decides which connections are accepted and made, before they are
+	// upgraded
+	gater *connGater
 
 	// ensures the close of the network only happens once.
 	closeOnce sync.Once
@@ -131,7 +145,14 @@ type network struct {
 	manuallyTrackedIDs ids.NodeIDSet
 	connectingPeers    peer.Set
 	connectedPeers     peer.Set
-	closing            bool
+	// shedPeers are the connected peers that were disconnected to stay within
+	// the peer budget but haven't finished closing yet.
+	shedPeers ids.NodeIDSet
+	closing   bool
+	// recoveryStart is when the portion of the Primary Network's stake this
+	// node is connected to fell below [PeerListRecoveryStakeThreshold]. It is
+	// zero while the connected stake is above the threshold.
+	recoveryStart time.Time
 
 	// router is notified about all peer [Connected] and [Disconnected] events
 	// as well as all non-handshake peer messages.
@@ -157,8 +178,7 @@ func NewNetwork(
 	banffTime time.Time, // TODO: remove this once we complete banff migration
 	metricsRegisterer prometheus.Registerer,
 	log logging.Logger,
-	listener net.Listener,
-	dialer dialer.Dialer,
+	transport Transport,
 	router router.ExternalHandler,
 ) (Network, error) {
 	primaryNetworkValidators, ok := config.Validators.GetValidators(constants.PrimaryNetworkID)
@@ -180,6 +200,25 @@ func NewNetwork(
 		return nil, fmt.Errorf("initializing inbound message throttler failed with: %w", err)
 	}
 
+	// Bulk messages received over multiplexed connections are throttled
+	// separately, so that they don't delay the other messages.
+	var bulkInboundMsgThrottler throttling.InboundMsgThrottler
+	if config.QUICEnabled {
+		bulkInboundMsgThrottler, err = throttling.NewInboundMsgThrottler(
+			log,
+			fmt.Sprintf("%s_bulk", config.Namespace),
+			metricsRegisterer,
+			primaryNetworkValidators,
+			config.ThrottlerConfig.InboundMsgThrottlerConfig,
+			config.ResourceTracker,
+			config.CPUTargeter,
+			config.DiskTargeter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("initializing bulk inbound message throttler failed with: %w", err)
+		}
+	}
+
 	outboundMsgThrottler, err := throttling.NewSybilOutboundMsgThrottler(
 		log,
 		config.Namespace,
@@ -209,20 +248,27 @@ func NewNetwork(
 		MessageCreatorWithProto: msgCreatorWithProto,
 
 		// TODO: remove this once we complete banff migration
-		BanffTime: banffTime,
-
-		Log:                  log,
-		InboundMsgThrottler:  inboundMsgThrottler,
-		Network:              nil, // This is set below.
-		Router:               router,
-		VersionCompatibility: version.GetCompatibility(config.NetworkID),
-		MySubnets:            config.WhitelistedSubnets,
-		Beacons:              config.Beacons,
-		NetworkID:            config.NetworkID,
-		PingFrequency:        config.PingFrequency,
-		PongTimeout:          config.PingPongTimeout,
-		MaxClockDifference:   config.MaxClockDifference,
-		ResourceTracker:      config.ResourceTracker,
+		BanffTime:              banffTime,
+		LegacyMessagesDisabled: config.LegacyMessagesDisabled,
+
+		Log:                     log,
+		InboundMsgThrottler:     inboundMsgThrottler,
+		BulkInboundMsgThrottler: bulkInboundMsgThrottler,
+		Network:                 nil, // This is set below.
+		Router:                  router,
+		VersionCompatibility:    version.GetCompatibility(config.NetworkID),
+		MySubnets:               peer.NewSubnetSet(config.WhitelistedSubnets),
+		Beacons:                 config.Beacons,
+		NetworkID:               config.NetworkID,
+		PingFrequency:           config.PingFrequency,
+		PongTimeout:             config.PingPongTimeout,
+		MaxClockDifference:      config.MaxClockDifference,
+		ResourceTracker:         config.ResourceTracker,
+	}
+
+	gater, err := newConnGater(config.GaterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection gater config: %w", err)
 	}
 
 	onCloseCtx, cancel := context.WithCancel(context.Background())
@@ -230,14 +276,12 @@ func NewNetwork(
 		config:               config,
 		peerConfig:           peerConfig,
 		metrics:              metrics,
-		ipSigner:             newIPSigner(config.MyIPPort, &peerConfig.Clock, config.TLSKey),
+		ipSigner:             newIPSigner(config.MyIPPort, config.MyAdditionalIPs, &peerConfig.Clock, config.TLSKey),
 		outboundMsgThrottler: outboundMsgThrottler,
 
 		inboundConnUpgradeThrottler: throttling.NewInboundConnUpgradeThrottler(log, config.ThrottlerConfig.InboundConnUpgradeThrottlerConfig),
-		listener:                    listener,
-		dialer:                      dialer,
-		serverUpgrader:              peer.NewTLSServerUpgrader(config.TLSConfig),
-		clientUpgrader:              peer.NewTLSClientUpgrader(config.TLSConfig),
+		transport:                   transport,
+		gater:                       gater,
 
 		onCloseCtx:       onCloseCtx,
 		onCloseCtxCancel: cancel,
@@ -278,6 +322,30 @@ func (n *network) Gossip(
 	return n.send(msg, peers)
 }
 
+// StakeWeightedGossip samples [numValidatorsToSend] validators by stake. The
+// remaining peers are sampled as in Gossip, skipping any validator that was
+// already sampled.
+func (n *network) StakeWeightedGossip(
+	msg message.OutboundMessage,
+	subnetID ids.ID,
+	validatorOnly bool,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+) ids.NodeIDSet {
+	peers := n.sampleValidatorsByStake(subnetID, numValidatorsToSend)
+	sampled := ids.NewNodeIDSet(len(peers))
+	for _, p := range peers {
+		sampled.Add(p.ID())
+	}
+	for _, p := range n.samplePeers(subnetID, validatorOnly, 0, numNonValidatorsToSend, numPeersToSend) {
+		if !sampled.Contains(p.ID()) {
+			peers = append(peers, p)
+		}
+	}
+	return n.send(msg, peers)
+}
+
 // HealthCheck returns information about several network layer health checks.
 // 1) Information about health check results
 // 2) An error if the health check reports unhealthy
@@ -372,11 +440,29 @@ func (n *network) Connected(nodeID ids.NodeID) {
 	}
 }
 
+// ConnectedSubnet is called after a connected peer reports that it started
+// tracking [subnetID].
+func (n *network) ConnectedSubnet(nodeID ids.NodeID, subnetID ids.ID) {
+	n.peersLock.Lock()
+	peer, connected := n.connectedPeers.GetByID(nodeID)
+	if !connected {
+		n.peersLock.Unlock()
+		return
+	}
+	n.metrics.markSubnetConnected(subnetID)
+	n.peersLock.Unlock()
+
+	n.router.Connected(nodeID, peer.Version(), subnetID)
+}
+
 // AllowConnection returns true if this node should have a connection to the
-// provided nodeID. If the node is attempting to connect to the minimum number
+// provided nodeID. Blocklisted nodes are never allowed. If the node is attempting to connect to the minimum number
 // of peers, then it should only connect if this node is a validator, or the
 // peer is a validator/beacon.
 func (n *network) AllowConnection(nodeID ids.NodeID) bool {
+	if n.config.BlocklistedNodeIDs.Contains(nodeID) {
+		return false
+	}
 	return !n.config.RequireValidatorToConnect ||
 		n.config.Validators.Contains(constants.PrimaryNetworkID, n.config.MyNodeID) ||
 		n.WantsConnection(nodeID)
@@ -426,11 +512,21 @@ func (n *network) Track(claimedIPPort ips.ClaimedIPPort) bool {
 		if tracked.ip.Timestamp >= claimedIPPort.Timestamp {
 			return false
 		}
-		// Stop tracking the old IP and instead start tracking new one.
-		tracked := tracked.trackNewIP(&peer.UnsignedIP{
+		newIP := &peer.UnsignedIP{
 			IP:        claimedIPPort.IPPort,
 			Timestamp: claimedIPPort.Timestamp,
-		})
+		}
+		// Stop tracking the old IP and instead start tracking new one. While
+		// the connected stake is recovering, validators are dialed without the
+		// backoff of the old IP.
+		if !n.recoveryStart.IsZero() && n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+			tracked.stopTracking()
+			capabilities := tracked.capabilities
+			tracked = newTrackedIP(newIP)
+			tracked.capabilities = capabilities
+		} else {
+			tracked = tracked.trackNewIP(newIP)
+		}
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 		return true
@@ -480,6 +576,9 @@ func (n *network) Version() (message.OutboundMessage, error) {
 		mySignedIP.IP.Timestamp,
 		mySignedIP.Signature,
 		n.peerConfig.MySubnets.List(),
+		mySignedIP.IP.AdditionalIPs,
+		mySignedIP.AdditionalIPsSignature,
+		uint64(n.transport.Capabilities()),
 	)
 }
 
@@ -488,6 +587,30 @@ func (n *network) Peers() (message.OutboundMessage, error) {
 	return n.peerConfig.GetMessageCreator().PeerList(peers, true)
 }
 
+func (n *network) ValidatorPeers(validatorIDs []ids.NodeID) (message.OutboundMessage, error) {
+	var (
+		requested = ids.NewNodeIDSet(len(validatorIDs))
+		peers     = make([]peer.Peer, 0, len(validatorIDs))
+	)
+	n.peersLock.RLock()
+	for _, nodeID := range validatorIDs {
+		if len(peers) >= int(n.config.PeerListNumValidatorIPs) {
+			break
+		}
+		if requested.Contains(nodeID) || !n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+			continue
+		}
+		requested.Add(nodeID)
+
+		if peer, ok := n.connectedPeers.GetByID(nodeID); ok {
+			peers = append(peers, peer)
+		}
+	}
+	n.peersLock.RUnlock()
+
+	return n.peerConfig.GetMessageCreator().PeerList(claimedIPs(peers), false)
+}
+
 func (n *network) Pong(nodeID ids.NodeID) (message.OutboundMessage, error) {
 	uptimePercentFloat, err := n.config.UptimeCalculator.CalculateUptimePercent(nodeID)
 	if err != nil {
@@ -505,7 +628,7 @@ func (n *network) Dispatch() error {
 	go n.inboundConnUpgradeThrottler.Dispatch()
 	errs := wrappers.Errs{}
 	for { // Continuously accept new connections
-		conn, err := n.listener.Accept() // Returns error when n.Close() is called
+		conn, err := n.transport.Accept() // Returns error when n.Close() is called
 		if err != nil {
 			if netErr, ok := err.(net.Error); ok {
 				if netErr.Timeout() {
@@ -543,6 +666,27 @@ func (n *network) Dispatch() error {
 			break
 		}
 
+		if n.isBlocklistedIP(ip.IP) {
+			n.peerConfig.Log.Debug("dropping inbound connection",
+				zap.String("reason", "blocklisted IP"),
+				zap.Stringer("peerIP", ip),
+			)
+			_ = conn.Close()
+			continue
+		}
+
+		gatedConn, reason, ok := n.gater.admit(conn, ip.IP)
+		if !ok {
+			n.peerConfig.Log.Debug("dropping inbound connection",
+				zap.String("reason", reason),
+				zap.Stringer("peerIP", ip),
+			)
+			n.metrics.inboundConnGated.Inc()
+			_ = conn.Close()
+			continue
+		}
+		conn = gatedConn
+
 		if !n.inboundConnUpgradeThrottler.ShouldUpgrade(ip) {
 			n.peerConfig.Log.Debug("failed to upgrade connection",
 				zap.String("reason", "rate-limiting"),
@@ -555,7 +699,7 @@ func (n *network) Dispatch() error {
 		n.metrics.inboundConnAllowed.Inc()
 
 		go func() {
-			if err := n.upgrade(conn, n.serverUpgrader); err != nil {
+			if err := n.upgrade(conn, n.transport.ServerUpgrader()); err != nil {
 				n.peerConfig.Log.Verbo("failed to upgrade inbound connection",
 					zap.Error(err),
 				)
@@ -584,6 +728,9 @@ func (n *network) WantsConnection(nodeID ids.NodeID) bool {
 }
 
 func (n *network) wantsConnection(nodeID ids.NodeID) bool {
+	if n.config.BlocklistedNodeIDs.Contains(nodeID) {
+		return false
+	}
 	return n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) ||
 		n.manuallyTrackedIDs.Contains(nodeID)
 }
@@ -615,7 +762,7 @@ func (n *network) ManuallyTrack(nodeID ids.NodeID, ip ips.IPPort) {
 
 func (n *network) TracksSubnet(nodeID ids.NodeID, subnetID ids.ID) bool {
 	if n.config.MyNodeID == nodeID {
-		return subnetID == constants.PrimaryNetworkID || n.config.WhitelistedSubnets.Contains(subnetID)
+		return subnetID == constants.PrimaryNetworkID || n.peerConfig.MySubnets.Contains(subnetID)
 	}
 
 	n.peersLock.RLock()
@@ -629,6 +776,76 @@ func (n *network) TracksSubnet(nodeID ids.NodeID, subnetID ids.ID) bool {
 	return subnetID == constants.PrimaryNetworkID || trackedSubnets.Contains(subnetID)
 }
 
+func (n *network) TrackSubnet(subnetID ids.ID) error {
+	if !n.peerConfig.MySubnets.Add(subnetID) {
+		return nil
+	}
+
+	msg, err := n.Version()
+	if err != nil {
+		return fmt.Errorf("failed to create version message: %w", err)
+	}
+
+	n.peersLock.Lock()
+	connectingPeers := n.connectingPeers.Sample(n.connectingPeers.Len(), peer.NoPrecondition)
+	connectedPeers := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	// Peers that haven't finished the handshake will report their tracked
+	// subnets to the router once they are connected.
+	for _, p := range connectingPeers {
+		p.TrackSubnet(subnetID)
+	}
+	newlyTracking := make([]peer.Peer, 0, len(connectedPeers))
+	for _, p := range connectedPeers {
+		if p.TrackSubnet(subnetID) {
+			n.metrics.markSubnetConnected(subnetID)
+			newlyTracking = append(newlyTracking, p)
+		}
+	}
+	n.peersLock.Unlock()
+
+	n.send(msg, append(connectingPeers, connectedPeers...))
+	for _, p := range newlyTracking {
+		n.router.Connected(p.ID(), p.Version(), subnetID)
+	}
+	return nil
+}
+
+// reannounceIP sends a Version message with a newly signed IP to all connected
+// peers if our IP has changed since [announcedIP] was signed. Returns the IP
+// that peers were last told about.
+func (n *network) reannounceIP(announcedIP *peer.SignedIP) *peer.SignedIP {
+	signedIP, err := n.ipSigner.getSignedIP()
+	if err != nil {
+		n.peerConfig.Log.Error("failed to sign IP",
+			zap.Error(err),
+		)
+		return announcedIP
+	}
+	if signedIP == announcedIP {
+		return announcedIP
+	}
+
+	msg, err := n.Version()
+	if err != nil {
+		n.peerConfig.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.Version),
+			zap.Error(err),
+		)
+		return announcedIP
+	}
+
+	n.peersLock.RLock()
+	peers := n.connectedPeers.Sample(n.connectedPeers.Len(), peer.NoPrecondition)
+	n.peersLock.RUnlock()
+
+	n.peerConfig.Log.Info("announcing updated IP to peers",
+		zap.Stringer("ip", signedIP.IP.IP),
+		zap.Int("numPeers", len(peers)),
+	)
+	n.send(msg, peers)
+	return signedIP
+}
+
 func (n *network) sampleValidatorIPs() []ips.ClaimedIPPort {
 	n.peersLock.RLock()
 	peers := n.connectedPeers.Sample(
@@ -640,17 +857,22 @@ func (n *network) sampleValidatorIPs() []ips.ClaimedIPPort {
 	)
 	n.peersLock.RUnlock()
 
-	sampledIPs := make([]ips.ClaimedIPPort, len(peers))
+	return claimedIPs(peers)
+}
+
+// claimedIPs returns the signed IPs of [peers].
+func claimedIPs(peers []peer.Peer) []ips.ClaimedIPPort {
+	claimedIPs := make([]ips.ClaimedIPPort, len(peers))
 	for i, peer := range peers {
 		peerIP := peer.IP()
-		sampledIPs[i] = ips.ClaimedIPPort{
+		claimedIPs[i] = ips.ClaimedIPPort{
 			Cert:      peer.Cert(),
 			IPPort:    peerIP.IP.IP,
 			Timestamp: peerIP.IP.Timestamp,
 			Signature: peerIP.Signature,
 		}
 	}
-	return sampledIPs
+	return claimedIPs
 }
 
 // getPeers returns a slice of connected peers from a set of [nodeIDs].
@@ -682,7 +904,7 @@ func (n *network) getPeers(
 			continue
 		}
 
-		if validatorOnly && !n.config.Validators.Contains(subnetID, nodeID) {
+		if validatorOnly && !n.isValidatorOrAllowed(subnetID, nodeID) {
 			continue
 		}
 
@@ -722,7 +944,13 @@ func (n *network) samplePeers(
 				return true
 			}
 
-			if n.config.Validators.Contains(subnetID, p.ID()) {
+			// When only validators are sampled, allowed non-validators are
+			// sampled as if they were validators.
+			isValidator := n.config.Validators.Contains(subnetID, p.ID())
+			if validatorOnly {
+				isValidator = n.isValidatorOrAllowed(subnetID, p.ID())
+			}
+			if isValidator {
 				numValidatorsToSample--
 				return numValidatorsToSample >= 0
 			}
@@ -733,6 +961,234 @@ func (n *network) samplePeers(
 	)
 }
 
+// sampleValidatorsByStake returns up to [numValidatorsToSample] distinct
+// connected validators of [subnetID]. Each validator is sampled with
+// probability proportional to its stake among the validators that haven't been
+// sampled yet.
+func (n *network) sampleValidatorsByStake(subnetID ids.ID, numValidatorsToSample int) []peer.Peer {
+	if numValidatorsToSample <= 0 {
+		return nil
+	}
+	vdrs, ok := n.config.Validators.GetValidators(subnetID)
+	if !ok {
+		return nil
+	}
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	var (
+		peers   []peer.Peer
+		weights []uint64
+	)
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		p, _ := n.connectedPeers.GetByIndex(i)
+		// Only return peers that are tracking [subnetID]
+		trackedSubnets := p.TrackedSubnets()
+		if subnetID != constants.PrimaryNetworkID && !trackedSubnets.Contains(subnetID) {
+			continue
+		}
+		weight, ok := vdrs.GetWeight(p.ID())
+		if !ok || weight == 0 {
+			continue
+		}
+		peers = append(peers, p)
+		weights = append(weights, weight)
+	}
+
+	indices := sampleByWeight(weights, numValidatorsToSample)
+	sampled := make([]peer.Peer, len(indices))
+	for i, index := range indices {
+		sampled[i] = peers[index]
+	}
+	return sampled
+}
+
+// sampleByWeight returns the indices of up to [numToSample] distinct elements
+// of [weights]. Each element is sampled with probability proportional to its
+// weight among the elements that haven't been sampled yet. If there are at
+// most [numToSample] elements, all of them are returned.
+func sampleByWeight(weights []uint64, numToSample int) []int {
+	if numToSample >= len(weights) {
+		indices := make([]int, len(weights))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	// Sampled elements have their weight zeroed so that they can't be sampled
+	// again.
+	weights = append([]uint64(nil), weights...)
+	totalWeight := uint64(0)
+	for _, weight := range weights {
+		totalWeight += weight
+	}
+
+	var (
+		uniform  = sampler.NewUniform()
+		weighted = sampler.NewDeterministicWeighted()
+		sampled  = make([]int, 0, numToSample)
+	)
+	for len(sampled) < numToSample {
+		if err := uniform.Initialize(totalWeight); err != nil {
+			break
+		}
+		if err := weighted.Initialize(weights); err != nil {
+			break
+		}
+		sampleValue, err := uniform.Next()
+		if err != nil {
+			break
+		}
+		index, err := weighted.Sample(sampleValue)
+		if err != nil {
+			break
+		}
+		sampled = append(sampled, index)
+		totalWeight -= weights[index]
+		weights[index] = 0
+	}
+	return sampled
+}
+
+// connectedStake returns the portion of the Primary Network's stake that this
+// node is connected to, including its own stake, and the validators it isn't
+// connected to. False is returned if the Primary Network has no stake.
+func (n *network) connectedStake() (float64, []validators.Validator, bool) {
+	primaryValidators, ok := n.config.Validators.GetValidators(constants.PrimaryNetworkID)
+	if !ok {
+		return 0, nil, false
+	}
+	totalWeight := primaryValidators.Weight()
+	if totalWeight == 0 {
+		return 0, nil, false
+	}
+
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	var (
+		connectedWeight uint64
+		disconnected    []validators.Validator
+	)
+	for _, vdr := range primaryValidators.List() {
+		nodeID := vdr.ID()
+		if _, connected := n.connectedPeers.GetByID(nodeID); connected || nodeID == n.config.MyNodeID {
+			connectedWeight += vdr.Weight()
+			continue
+		}
+		disconnected = append(disconnected, vdr)
+	}
+	return float64(connectedWeight) / float64(totalWeight), disconnected, true
+}
+
+// recoverStake requests the IPs of disconnected Primary Network validators
+// from connected validators while the portion of stake this node is connected
+// to is below [PeerListRecoveryStakeThreshold]. The validators are sampled by
+// stake, so that the stake is recovered with as few connections as possible.
+func (n *network) recoverStake() {
+	connectedStake, disconnected, ok := n.connectedStake()
+	if !ok {
+		return
+	}
+	n.metrics.connectedStake.Set(connectedStake)
+
+	var (
+		now          = n.peerConfig.Clock.Time()
+		isRecovering = connectedStake < n.config.PeerListRecoveryStakeThreshold
+	)
+	n.peersLock.Lock()
+	recoveryStart := n.recoveryStart
+	wasRecovering := !recoveryStart.IsZero()
+	switch {
+	case isRecovering && !wasRecovering:
+		n.recoveryStart = now
+		n.redialValidators()
+	case !isRecovering && wasRecovering:
+		n.recoveryStart = time.Time{}
+	}
+	n.peersLock.Unlock()
+
+	switch {
+	case isRecovering && !wasRecovering:
+		n.metrics.stakeRecoveries.Inc()
+		n.peerConfig.Log.Info("connected stake is below the recovery threshold",
+			zap.Float64("connectedStake", connectedStake),
+			zap.Float64("threshold", n.config.PeerListRecoveryStakeThreshold),
+			zap.Int("numDisconnectedValidators", len(disconnected)),
+		)
+	case !isRecovering && wasRecovering:
+		duration := now.Sub(recoveryStart)
+		n.metrics.stakeRecoveryDuration.Observe(duration.Seconds())
+		n.peerConfig.Log.Info("connected stake recovered",
+			zap.Float64("connectedStake", connectedStake),
+			zap.Duration("duration", duration),
+		)
+		return
+	case !isRecovering:
+		return
+	}
+
+	weights := make([]uint64, len(disconnected))
+	for i, vdr := range disconnected {
+		weights[i] = vdr.Weight()
+	}
+	indices := sampleByWeight(weights, int(n.config.PeerListNumValidatorIPs))
+	if len(indices) == 0 {
+		return
+	}
+	validatorIDs := make([]ids.NodeID, len(indices))
+	for i, index := range indices {
+		validatorIDs[i] = disconnected[index].ID()
+	}
+
+	msg, err := n.peerConfig.GetMessageCreator().GetPeerList(validatorIDs)
+	if err != nil {
+		n.peerConfig.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.GetPeerList),
+			zap.Error(err),
+		)
+		return
+	}
+	n.Gossip(
+		msg,
+		constants.PrimaryNetworkID,
+		true,
+		int(n.config.PeerListValidatorGossipSize),
+		0,
+		0,
+	)
+}
+
+// redialValidators restarts dialing the tracked Primary Network validators
+// without the backoff of their previous connection attempts.
+//
+// Assumes [n.peersLock] is held.
+func (n *network) redialValidators() {
+	for nodeID, tracked := range n.trackedIPs {
+		if tracked.getDelay() == 0 || !n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) {
+			continue
+		}
+		tracked.stopTracking()
+		redialed := newTrackedIP(tracked.ip)
+		redialed.capabilities = tracked.capabilities
+		n.trackedIPs[nodeID] = redialed
+		n.dial(n.onCloseCtx, nodeID, redialed)
+	}
+}
+
+// isValidatorOrAllowed returns true if [nodeID] is a validator of [subnetID]
+// or is explicitly allowed to receive messages of [subnetID]'s validator only
+// chains.
+func (n *network) isValidatorOrAllowed(subnetID ids.ID, nodeID ids.NodeID) bool {
+	if n.config.Validators.Contains(subnetID, nodeID) {
+		return true
+	}
+	allowedNodes := n.config.ValidatorOnlyAllowedNodes[subnetID]
+	return allowedNodes.Contains(nodeID)
+}
+
 // send the message to the provided peers.
 //
 // send takes ownership of the provided message reference. So, the provided
@@ -794,10 +1250,12 @@ func (n *network) disconnectedFromConnected(peer peer.Peer, nodeID ids.NodeID) {
 	defer n.peersLock.Unlock()
 
 	n.connectedPeers.Remove(nodeID)
+	n.shedPeers.Remove(nodeID)
 
 	// The peer that is disconnecting from us finished the handshake
 	if n.wantsConnection(nodeID) {
 		tracked := newTrackedIP(&peer.IP().IP)
+		tracked.capabilities = peer.Capabilities()
 		n.trackedIPs[nodeID] = tracked
 		n.dial(n.onCloseCtx, nodeID, tracked)
 	} else {
@@ -818,6 +1276,16 @@ func (n *network) shouldTrack(nodeID ids.NodeID, ip ips.ClaimedIPPort) bool {
 		return false
 	}
 
+	if n.isBlocklistedIP(ip.IPPort.IP) {
+		n.peerConfig.Log.Verbo(
+			"not connecting to suggested peer",
+			zap.String("reason", "peer IP is blocklisted"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("peerIPPort", ip.IPPort),
+		)
+		return false
+	}
+
 	n.peersLock.RLock()
 	defer n.peersLock.RUnlock()
 
@@ -901,6 +1369,23 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				return
 			}
 
+			dialIP, ok := n.dialableIP(ip)
+			if !ok {
+				n.peerConfig.Log.Verbo(
+					"exiting attempt to dial peer",
+					zap.String("reason", "blocklisted IP"),
+					zap.Stringer("nodeID", nodeID),
+					zap.Stringer("peerIP", ip.ip.IP),
+				)
+				n.peersLock.Lock()
+				if tracked, exists := n.trackedIPs[nodeID]; exists && tracked == ip {
+					ip.stopTracking()
+					delete(n.trackedIPs, nodeID)
+				}
+				n.peersLock.Unlock()
+				return
+			}
+
 			// Increase the delay that we will use for a future connection
 			// attempt.
 			ip.increaseDelay(
@@ -908,21 +1393,32 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 				n.config.MaxReconnectDelay,
 			)
 
-			conn, err := n.dialer.Dial(ctx, ip.ip.IP)
+			if !n.gater.allowDial(dialIP.IP) {
+				n.peerConfig.Log.Verbo(
+					"not dialing peer in denied CIDRs, attempting again",
+					zap.Stringer("peerIP", dialIP),
+					zap.Duration("delay", ip.delay),
+				)
+				ip.rotateIP()
+				continue
+			}
+
+			conn, err := n.transport.Dial(ctx, dialIP, ip.capabilities)
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to reach peer, attempting again",
-					zap.Stringer("peerIP", ip.ip.IP),
+					zap.Stringer("peerIP", dialIP),
 					zap.Duration("delay", ip.delay),
 				)
+				ip.rotateIP()
 				continue
 			}
 
-			err = n.upgrade(conn, n.clientUpgrader)
+			err = n.upgrade(conn, n.transport.ClientUpgrader())
 			if err != nil {
 				n.peerConfig.Log.Verbo(
 					"failed to upgrade, attempting again",
-					zap.Stringer("peerIP", ip.ip.IP),
+					zap.Stringer("peerIP", dialIP),
 					zap.Duration("delay", ip.delay),
 				)
 				continue
@@ -932,6 +1428,31 @@ func (n *network) dial(ctx context.Context, nodeID ids.NodeID, ip *trackedIP) {
 	}()
 }
 
+// dialableIP returns the first IP of [ip], starting from the one that is
+// dialed next, that isn't blocklisted. False is returned if all the IPs of [ip]
+// are blocklisted.
+func (n *network) dialableIP(ip *trackedIP) (ips.IPPort, bool) {
+	for i := 0; i <= len(ip.ip.AdditionalIPs); i++ {
+		dialIP := ip.dialIP()
+		if !n.isBlocklistedIP(dialIP.IP) {
+			return dialIP, true
+		}
+		ip.rotateIP()
+	}
+	return ips.IPPort{}, false
+}
+
+// isBlocklistedIP returns true if the operator configured this node to never
+// connect to [ip].
+func (n *network) isBlocklistedIP(ip net.IP) bool {
+	for _, blocklistedIP := range n.config.BlocklistedIPs {
+		if blocklistedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // upgrade the provided connection, which may be an inbound connection or an
 // outbound connection, with the provided [upgrader].
 //
@@ -1028,6 +1549,17 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 		return nil
 	}
 
+	if !n.withinPeerBudget(nodeID) {
+		_ = tlsConn.Close()
+		n.peerConfig.Log.Verbo(
+			"dropping connection",
+			zap.String("reason", "socket budget reserved for validators"),
+			zap.Stringer("nodeID", nodeID),
+		)
+		n.metrics.nonValidatorsRefused.Inc()
+		return nil
+	}
+
 	n.peerConfig.Log.Verbo("starting handshake",
 		zap.Stringer("nodeID", nodeID),
 	)
@@ -1045,9 +1577,12 @@ func (n *network) upgrade(conn net.Conn, upgrader peer.Upgrader) error {
 			nodeID,
 			n.peerConfig.Log,
 			n.outboundMsgThrottler,
+			n.config.PeerMaxQueuedBulkBytes,
+			n.peerConfig.Metrics.BulkMessagesDropped,
 		),
 	)
 	n.connectingPeers.Add(peer)
+	n.shedNonValidators()
 	return nil
 }
 
@@ -1061,12 +1596,24 @@ func (n *network) PeerInfo(nodeIDs []ids.NodeID) []peer.Info {
 	return n.connectedPeers.Info(nodeIDs)
 }
 
+func (n *network) PeerMessageCounts() map[ids.NodeID]peer.MessageCounts {
+	n.peersLock.RLock()
+	defer n.peersLock.RUnlock()
+
+	counts := make(map[ids.NodeID]peer.MessageCounts, n.connectedPeers.Len())
+	for i := 0; i < n.connectedPeers.Len(); i++ {
+		peer, _ := n.connectedPeers.GetByIndex(i)
+		counts[peer.ID()] = peer.MessageCounts()
+	}
+	return counts
+}
+
 func (n *network) StartClose() {
 	n.closeOnce.Do(func() {
 		n.peerConfig.Log.Info("shutting down the p2p networking")
 
-		if err := n.listener.Close(); err != nil {
-			n.peerConfig.Log.Debug("closing the network listener",
+		if err := n.transport.Close(); err != nil {
+			n.peerConfig.Log.Debug("closing the network transport",
 				zap.Error(err),
 			)
 		}
@@ -1149,11 +1696,23 @@ func (n *network) runTimers() {
 		updateUptimes.Stop()
 	}()
 
+	// The IP that connected peers were told about. New peers are always told
+	// about the current IP during the handshake.
+	announcedIP, err := n.ipSigner.getSignedIP()
+	if err != nil {
+		n.peerConfig.Log.Error("failed to sign IP",
+			zap.Error(err),
+		)
+	}
+
 	for {
 		select {
 		case <-n.onCloseCtx.Done():
 			return
 		case <-gossipPeerlists.C:
+			announcedIP = n.reannounceIP(announcedIP)
+			n.recoverStake()
+
 			validatorIPs := n.sampleValidatorIPs()
 			if len(validatorIPs) == 0 {
 				n.peerConfig.Log.Debug("skipping validator IP gossiping as no IPs are connected")
@@ -1187,3 +1746,11 @@ func (n *network) runTimers() {
 		}
 	}
 }
+
+func (n *network) GaterConfig() GaterConfig {
+	return n.gater.getConfig()
+}
+
+func (n *network) SetGaterConfig(config GaterConfig) error {
+	return n.gater.setConfig(config)
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -184,7 +186,7 @@ func newMessageCreator(t *testing.T) (message.Creator, message.Creator) {
 	mcProto, err := message.NewCreatorWithProto(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -231,8 +233,7 @@ func newFullyConnectedTestNetwork(t *testing.T, handlers []router.InboundHandler
 			time.Now().Add(time.Hour), // TODO: test proto with banff activated
 			prometheus.NewRegistry(),
 			logging.NoLog{},
-			listeners[i],
-			dialer,
+			NewTLSTransport(listeners[i], dialer, config.TLSConfig),
 			&testHandler{
 				InboundHandler: handlers[i],
 				ConnectedF: func(nodeID ids.NodeID, _ *version.Application, _ ids.ID) {
@@ -348,6 +349,166 @@ func TestSend(t *testing.T) {
 	}
 }
 
+func TestSendValidatorOnlyAllowedNodes(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan message.InboundMessage)
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			router.InboundHandlerFunc(func(message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(msg message.InboundMessage) {
+				received <- msg
+			}),
+		},
+	)
+
+	net0 := networks[0].(*network)
+	err := net0.config.Validators.RemoveWeight(constants.PrimaryNetworkID, nodeIDs[1], 1)
+	require.NoError(err)
+
+	mc, _ := newMessageCreator(t)
+	toSend := ids.NodeIDSet{}
+	toSend.Add(nodeIDs[1])
+
+	// nodeIDs[1] isn't a validator, so it shouldn't be sent validator only
+	// messages.
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	sentTo := net0.Send(outboundGetMsg, toSend, constants.PrimaryNetworkID, true)
+	require.Zero(sentTo.Len())
+
+	// Once allowed, nodeIDs[1] should be sent validator only messages.
+	net0.config.ValidatorOnlyAllowedNodes = map[ids.ID]ids.NodeIDSet{
+		constants.PrimaryNetworkID: toSend,
+	}
+	outboundGetMsg, err = mc.Get(ids.Empty, 2, time.Second, ids.Empty)
+	require.NoError(err)
+	sentTo = net0.Send(outboundGetMsg, toSend, constants.PrimaryNetworkID, true)
+	require.EqualValues(toSend, sentTo)
+
+	inboundGetMsg := <-received
+	require.Equal(message.Get, inboundGetMsg.Op())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestStakeWeightedGossip(t *testing.T) {
+	require := require.New(t)
+
+	received := make(chan message.InboundMessage)
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(
+		t,
+		[]router.InboundHandler{
+			router.InboundHandlerFunc(func(message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(message.InboundMessage) {
+				t.Fatal("unexpected message received")
+			}),
+			router.InboundHandlerFunc(func(msg message.InboundMessage) {
+				received <- msg
+			}),
+		},
+	)
+
+	// nodeIDs[2] holds nearly all of the stake, so it should be sampled first.
+	net0 := networks[0].(*network)
+	err := net0.config.Validators.AddWeight(constants.PrimaryNetworkID, nodeIDs[2], 1<<40)
+	require.NoError(err)
+
+	for i := 0; i < 10; i++ {
+		peers := net0.sampleValidatorsByStake(constants.PrimaryNetworkID, 1)
+		require.Len(peers, 1)
+		require.Equal(nodeIDs[2], peers[0].ID())
+	}
+
+	// Validators are never sampled more than once.
+	peers := net0.sampleValidatorsByStake(constants.PrimaryNetworkID, 3)
+	require.Len(peers, 2)
+	require.NotEqual(peers[0].ID(), peers[1].ID())
+
+	// Peers without stake aren't sampled.
+	err = net0.config.Validators.RemoveWeight(constants.PrimaryNetworkID, nodeIDs[1], 1)
+	require.NoError(err)
+	peers = net0.sampleValidatorsByStake(constants.PrimaryNetworkID, 2)
+	require.Len(peers, 1)
+	require.Equal(nodeIDs[2], peers[0].ID())
+
+	mc, _ := newMessageCreator(t)
+	outboundGetMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	sentTo := net0.StakeWeightedGossip(outboundGetMsg, constants.PrimaryNetworkID, false, 1, 0, 0)
+	require.Equal(1, sentTo.Len())
+	require.True(sentTo.Contains(nodeIDs[2]))
+
+	inboundGetMsg := <-received
+	require.Equal(message.Get, inboundGetMsg.Op())
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
+func TestStakeRecovery(t *testing.T) {
+	require := require.New(t)
+
+	nodeIDs, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil, nil, nil})
+
+	net0 := networks[0].(*network)
+	net0.config.PeerListRecoveryStakeThreshold = .8
+
+	// The disconnected validator holds most of the stake.
+	disconnectedID, _, _ := getTLS(t, 3)
+	err := net0.config.Validators.AddWeight(constants.PrimaryNetworkID, disconnectedID, 7)
+	require.NoError(err)
+
+	connectedStake, disconnected, ok := net0.connectedStake()
+	require.True(ok)
+	require.Equal(.3, connectedStake)
+	require.Len(disconnected, 1)
+	require.Equal(disconnectedID, disconnected[0].ID())
+
+	net0.recoverStake()
+	net0.peersLock.RLock()
+	require.False(net0.recoveryStart.IsZero())
+	net0.peersLock.RUnlock()
+	require.Equal(1., testutil.ToFloat64(net0.metrics.stakeRecoveries))
+
+	// Only the IPs of connected validators are returned.
+	msg, err := net0.ValidatorPeers([]ids.NodeID{nodeIDs[1], disconnectedID, nodeIDs[1]})
+	require.NoError(err)
+	mc, _ := newMessageCreator(t)
+	inboundMsg, err := mc.Parse(msg.Bytes(), nodeIDs[1], func() {})
+	require.NoError(err)
+	peersIntf, err := inboundMsg.Get(message.Peers)
+	require.NoError(err)
+	peers := peersIntf.([]ips.ClaimedIPPort)
+	require.Len(peers, 1)
+	require.Equal(nodeIDs[1], ids.NodeIDFromCert(peers[0].Cert))
+
+	// The recovery ends once the connected stake is above the threshold.
+	err = net0.config.Validators.RemoveWeight(constants.PrimaryNetworkID, disconnectedID, 7)
+	require.NoError(err)
+	net0.recoverStake()
+	net0.peersLock.RLock()
+	require.True(net0.recoveryStart.IsZero())
+	net0.peersLock.RUnlock()
+	require.Equal(1., testutil.ToFloat64(net0.metrics.connectedStake))
+	require.Equal(1, testutil.CollectAndCount(net0.metrics.stakeRecoveryDuration))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
+
 func TestTrackVerifiesSignatures(t *testing.T) {
 	require := require.New(t)
 
@@ -379,3 +540,33 @@ func TestTrackVerifiesSignatures(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestBlocklist(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+
+	network := networks[0].(*network)
+	nodeID, _, _ := getTLS(t, 1)
+	err := network.config.Validators.AddWeight(constants.PrimaryNetworkID, nodeID, 1)
+	require.NoError(err)
+	require.True(network.WantsConnection(nodeID))
+	require.True(network.AllowConnection(nodeID))
+
+	blocklistedIP := net.IPv4(123, 132, 123, 123)
+	network.config.BlocklistedNodeIDs = ids.NodeIDSet{}
+	network.config.BlocklistedNodeIDs.Add(nodeID)
+	network.config.BlocklistedIPs = []net.IP{blocklistedIP}
+
+	// Validators are still dropped if they are blocklisted.
+	require.False(network.WantsConnection(nodeID))
+	require.False(network.AllowConnection(nodeID))
+
+	require.True(network.isBlocklistedIP(net.ParseIP("123.132.123.123")))
+	require.False(network.isBlocklistedIP(net.IPv4(123, 132, 123, 124)))
+
+	for _, net := range networks {
+		net.StartClose()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+// Capabilities is a bit set of the optional features that a node supports.
+// Nodes advertise their capabilities in their Version message. A feature must
+// only be used with a peer if both nodes support it.
+type Capabilities uint64
+
+const (
+	// CapabilityQUIC is set by nodes that accept peer connections over QUIC on
+	// the UDP port that matches their staking port.
+	//
+	// This transport is experimental.
+	CapabilityQUIC Capabilities = 1 << iota
+)
+
+// Contains returns true if [c] includes every capability in [other].
+func (c Capabilities) Contains(other Capabilities) bool {
+	return c&other == other
+}
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	errRSAKeyTooSmall               = errors.New("RSA key is too small")
+	errDisallowedSignatureAlgorithm = errors.New("signature algorithm isn't allowed")
+	errInvalidValidityPeriod        = errors.New("invalid validity period")
+)
+
+// CertPolicy restricts the staking certificates that peers may present during
+// the TLS handshake. The zero value accepts every certificate.
+type CertPolicy struct {
+	// MinRSAKeySize is the minimum size, in bits, of the RSA key of a
+	// certificate. Certificates with other key types aren't restricted. 0
+	// means there is no minimum.
+	MinRSAKeySize int `json:"minRSAKeySize"`
+
+	// AllowedSignatureAlgorithms are the algorithms a certificate may be
+	// signed with. If empty, every algorithm is allowed.
+	AllowedSignatureAlgorithms []x509.SignatureAlgorithm `json:"allowedSignatureAlgorithms"`
+
+	// MaxValidity is the maximum duration between the start and the end of
+	// the validity period of a certificate. 0 means there is no maximum.
+	MaxValidity time.Duration `json:"maxValidity"`
+}
+
+// Verify returns an error if [cert] doesn't satisfy the policy.
+func (p *CertPolicy) Verify(cert *x509.Certificate) error {
+	if p.MinRSAKeySize > 0 {
+		if key, ok := cert.PublicKey.(*rsa.PublicKey); ok && key.N.BitLen() < p.MinRSAKeySize {
+			return fmt.Errorf("%w: %d bits < %d bits", errRSAKeyTooSmall, key.N.BitLen(), p.MinRSAKeySize)
+		}
+	}
+
+	if len(p.AllowedSignatureAlgorithms) > 0 && !p.allowsSignatureAlgorithm(cert.SignatureAlgorithm) {
+		return fmt.Errorf("%w: %s", errDisallowedSignatureAlgorithm, cert.SignatureAlgorithm)
+	}
+
+	if p.MaxValidity > 0 {
+		if cert.NotAfter.Before(cert.NotBefore) {
+			return fmt.Errorf("%w: ends at %s before starting at %s", errInvalidValidityPeriod, cert.NotAfter, cert.NotBefore)
+		}
+		// Sub saturates, so validity periods longer than the maximum duration
+		// are still rejected.
+		if validity := cert.NotAfter.Sub(cert.NotBefore); validity > p.MaxValidity {
+			return fmt.Errorf("%w: %s > %s", errInvalidValidityPeriod, validity, p.MaxValidity)
+		}
+	}
+	return nil
+}
+
+func (p *CertPolicy) allowsSignatureAlgorithm(algorithm x509.SignatureAlgorithm) bool {
+	for _, allowed := range p.AllowedSignatureAlgorithms {
+		if algorithm == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// verifyConnection is called once the TLS handshake verified that the peer
+// holds the key of its certificate. Returning an error aborts the handshake.
+func (p *CertPolicy) verifyConnection(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		// The upgrader reports the missing certificate.
+		return nil
+	}
+	return p.Verify(state.PeerCertificates[0])
+}
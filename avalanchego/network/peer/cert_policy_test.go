@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func newPolicyTestCert(t *testing.T, key crypto.Signer, notBefore, notAfter time.Time) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(0),
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(certBytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCertPolicyVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var (
+		now      = time.Now()
+		rsaCert  = newPolicyTestCert(t, rsaKey, now, now.Add(time.Hour))
+		ecCert   = newPolicyTestCert(t, ecdsaKey, now, now.Add(time.Hour))
+		backward = newPolicyTestCert(t, ecdsaKey, now, now.Add(-time.Hour))
+		forever  = newPolicyTestCert(t, ecdsaKey, time.Date(2000, time.January, 0, 0, 0, 0, 0, time.UTC), time.Date(9999, time.January, 0, 0, 0, 0, 0, time.UTC))
+	)
+
+	tests := []struct {
+		name        string
+		policy      CertPolicy
+		cert        *x509.Certificate
+		expectedErr error
+	}{
+		{
+			name:   "permissive",
+			policy: CertPolicy{},
+			cert:   forever,
+		},
+		{
+			name:        "RSA key too small",
+			policy:      CertPolicy{MinRSAKeySize: 2048},
+			cert:        rsaCert,
+			expectedErr: errRSAKeyTooSmall,
+		},
+		{
+			name:   "non-RSA key isn't restricted by size",
+			policy: CertPolicy{MinRSAKeySize: 2048},
+			cert:   ecCert,
+		},
+		{
+			name:        "disallowed signature algorithm",
+			policy:      CertPolicy{AllowedSignatureAlgorithms: []x509.SignatureAlgorithm{x509.SHA256WithRSA}},
+			cert:        ecCert,
+			expectedErr: errDisallowedSignatureAlgorithm,
+		},
+		{
+			name:   "allowed signature algorithm",
+			policy: CertPolicy{AllowedSignatureAlgorithms: []x509.SignatureAlgorithm{x509.SHA256WithRSA, x509.ECDSAWithSHA256}},
+			cert:   ecCert,
+		},
+		{
+			name:        "validity too long",
+			policy:      CertPolicy{MaxValidity: 200 * 365 * 24 * time.Hour},
+			cert:        forever,
+			expectedErr: errInvalidValidityPeriod,
+		},
+		{
+			name:        "validity ends before it starts",
+			policy:      CertPolicy{MaxValidity: time.Hour},
+			cert:        backward,
+			expectedErr: errInvalidValidityPeriod,
+		},
+		{
+			name:   "validity within maximum",
+			policy: CertPolicy{MaxValidity: time.Hour},
+			cert:   ecCert,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.policy.Verify(test.cert)
+			require.ErrorIs(t, err, test.expectedErr)
+		})
+	}
+}
+
+func TestTLSConfigRejectsCertOutsidePolicy(t *testing.T) {
+	require := require.New(t)
+
+	serverCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	clientCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	// Staking certificates are valid for about a century.
+	policy := CertPolicy{MaxValidity: 365 * 24 * time.Hour}
+
+	// A pipe isn't used as the alert sent by the server would block until the
+	// client reads it.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	defer listener.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			serverErr <- err
+			return
+		}
+		defer conn.Close()
+		serverErr <- tls.Server(conn, TLSConfig(*serverCert, nil, policy)).Handshake()
+	}()
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(err)
+	defer conn.Close()
+
+	// The client finishes its side of the handshake before the server
+	// verifies the client's certificate.
+	_ = tls.Client(conn, TLSConfig(*clientCert, nil, CertPolicy{})).Handshake()
+	require.ErrorIs(<-serverErr, errInvalidValidityPeriod)
+}
@@ -6,7 +6,6 @@ package peer
 import (
 	"time"
 
-	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
@@ -29,13 +28,20 @@ type Config struct {
 
 	// TODO: remove this once we complete banff migration
 	BanffTime time.Time
+	// If true, messages aren't sent or parsed in the legacy format
+	LegacyMessagesDisabled bool
+
+	Log                 logging.Logger
+	InboundMsgThrottler throttling.InboundMsgThrottler
+	// BulkInboundMsgThrottler throttles the messages read from the bulk
+	// stream of a [MultiplexedConn]. If nil, bulk messages are exchanged with
+	// the other messages, even over multiplexed connections.
+	BulkInboundMsgThrottler throttling.InboundMsgThrottler
 
-	Log                  logging.Logger
-	InboundMsgThrottler  throttling.InboundMsgThrottler
 	Network              Network
 	Router               router.InboundHandler
 	VersionCompatibility version.Compatibility
-	MySubnets            ids.Set
+	MySubnets            *SubnetSet
 	Beacons              validators.Set
 	NetworkID            uint32
 	PingFrequency        time.Duration
@@ -52,7 +58,7 @@ type Config struct {
 
 func (c *Config) GetMessageCreator() message.Creator {
 	now := c.Clock.Time()
-	if c.IsBanffActivated(now) {
+	if c.LegacyMessagesDisabled || c.IsBanffActivated(now) {
 		return c.MessageCreatorWithProto
 	}
 	return c.MessageCreator
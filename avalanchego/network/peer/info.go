@@ -20,3 +20,14 @@ type Info struct {
 	ObservedUptime json.Uint8 `json:"observedUptime"`
 	TrackedSubnets []ids.ID   `json:"trackedSubnets"`
 }
+
+// MessageCounts are the number of messages exchanged with a peer since the
+// connection was established.
+type MessageCounts struct {
+	Sent     json.Uint64 `json:"sent"`
+	Received json.Uint64 `json:"received"`
+	// SendAttempts is the number of messages that were attempted to be queued
+	// for sending to the peer. SendFailures of them were dropped.
+	SendAttempts json.Uint64 `json:"sendAttempts"`
+	SendFailures json.Uint64 `json:"sendFailures"`
+}
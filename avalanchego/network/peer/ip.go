@@ -7,31 +7,54 @@ import (
 	"crypto"
 	"crypto/rand"
 	"crypto/x509"
+	"fmt"
 
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+// MaxAdditionalIPs is the maximum number of additional IPs that a validator
+// may claim.
+const MaxAdditionalIPs = 8
+
+var errTooManyAdditionalIPs = fmt.Errorf("more than %d additional IPs", MaxAdditionalIPs)
+
 // UnsignedIP is used for a validator to claim an IP. The [Timestamp] is used to
 // ensure that the most updated IP claim is tracked by peers for a given
 // validator.
 type UnsignedIP struct {
 	IP        ips.IPPort
 	Timestamp uint64
+	// Other IPs the validator is reachable at, such as the IPs of its other
+	// address families. They are signed separately from [IP], so that peers
+	// that don't know about them can still verify the claim of [IP].
+	AdditionalIPs []ips.IPPort
 }
 
 // Sign this IP with the provided signer and return the signed IP.
 func (ip *UnsignedIP) Sign(signer crypto.Signer) (*SignedIP, error) {
-	sig, err := signer.Sign(
+	sig, err := sign(signer, ip.bytes())
+	if err != nil || len(ip.AdditionalIPs) == 0 {
+		return &SignedIP{
+			IP:        *ip,
+			Signature: sig,
+		}, err
+	}
+	additionalIPsSig, err := sign(signer, ip.additionalIPsBytes())
+	return &SignedIP{
+		IP:                     *ip,
+		Signature:              sig,
+		AdditionalIPsSignature: additionalIPsSig,
+	}, err
+}
+
+func sign(signer crypto.Signer, msg []byte) ([]byte, error) {
+	return signer.Sign(
 		rand.Reader,
-		hashing.ComputeHash256(ip.bytes()),
+		hashing.ComputeHash256(msg),
 		crypto.SHA256,
 	)
-	return &SignedIP{
-		IP:        *ip,
-		Signature: sig,
-	}, err
 }
 
 func (ip *UnsignedIP) bytes() []byte {
@@ -43,16 +66,45 @@ func (ip *UnsignedIP) bytes() []byte {
 	return p.Bytes
 }
 
+func (ip *UnsignedIP) additionalIPsBytes() []byte {
+	p := wrappers.Packer{
+		Bytes: make([]byte, wrappers.IPLen+wrappers.LongLen+wrappers.IntLen+len(ip.AdditionalIPs)*wrappers.IPLen),
+	}
+	p.PackIP(ip.IP)
+	p.PackLong(ip.Timestamp)
+	p.PackInt(uint32(len(ip.AdditionalIPs)))
+	for _, additionalIP := range ip.AdditionalIPs {
+		p.PackIP(additionalIP)
+	}
+	return p.Bytes
+}
+
 // SignedIP is a wrapper of an UnsignedIP with the signature from a signer.
 type SignedIP struct {
 	IP        UnsignedIP
 	Signature []byte
+	// Signature of [IP] including its additional IPs. Empty if there are no
+	// additional IPs.
+	AdditionalIPsSignature []byte
 }
 
 func (ip *SignedIP) Verify(cert *x509.Certificate) error {
-	return cert.CheckSignature(
+	if len(ip.IP.AdditionalIPs) > MaxAdditionalIPs {
+		return errTooManyAdditionalIPs
+	}
+	if err := cert.CheckSignature(
 		cert.SignatureAlgorithm,
 		ip.IP.bytes(),
 		ip.Signature,
+	); err != nil {
+		return err
+	}
+	if len(ip.IP.AdditionalIPs) == 0 {
+		return nil
+	}
+	return cert.CheckSignature(
+		cert.SignatureAlgorithm,
+		ip.IP.additionalIPsBytes(),
+		ip.AdditionalIPsSignature,
 	)
 }
@@ -0,0 +1,59 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"crypto"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+func TestSignedIPAdditionalIPs(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	signer := tlsCert.PrivateKey.(crypto.Signer)
+
+	unsignedIP := UnsignedIP{
+		IP:        ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		Timestamp: 10,
+		AdditionalIPs: []ips.IPPort{
+			{IP: net.ParseIP("2001:db8::1"), Port: 9651},
+		},
+	}
+	signedIP, err := unsignedIP.Sign(signer)
+	require.NoError(err)
+	require.NoError(signedIP.Verify(tlsCert.Leaf))
+
+	// Peers that ignore the additional IPs can still verify the IP.
+	withoutAdditionalIPs := &SignedIP{
+		IP: UnsignedIP{
+			IP:        unsignedIP.IP,
+			Timestamp: unsignedIP.Timestamp,
+		},
+		Signature: signedIP.Signature,
+	}
+	require.NoError(withoutAdditionalIPs.Verify(tlsCert.Leaf))
+
+	// The additional IPs can't be replaced.
+	forged := *signedIP
+	forged.IP.AdditionalIPs = []ips.IPPort{
+		{IP: net.ParseIP("2001:db8::2"), Port: 9651},
+	}
+	require.Error(forged.Verify(tlsCert.Leaf))
+
+	// Additional IPs can't be claimed without a signature.
+	forged.AdditionalIPsSignature = nil
+	require.Error(forged.Verify(tlsCert.Leaf))
+
+	forged = *signedIP
+	forged.IP.AdditionalIPs = make([]ips.IPPort, MaxAdditionalIPs+1)
+	require.ErrorIs(forged.Verify(tlsCert.Leaf), errTooManyAdditionalIPs)
+}
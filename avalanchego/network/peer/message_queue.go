@@ -7,6 +7,8 @@ import (
 	"context"
 	"sync"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -16,10 +18,24 @@ import (
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
-const initialQueueSize = 64
+const (
+	initialQueueSize = 64
+
+	// Number of consensus messages that are sent in a row while bulk messages
+	// are waiting, before a bulk message is sent
+	maxConsecutiveConsensusMsgs = 8
+)
 
 var (
-	_ MessageQueue = &throttledMessageQueue{}
+	// bulkOps are the operations of the messages that carry large amounts of
+	// data, such as the containers that a bootstrapping peer requested
+	bulkOps = map[message.Op]struct{}{
+		message.Ancestors:            {},
+		message.StateSummaryFrontier: {},
+		message.AppResponse:          {},
+	}
+
+	_ ClassQueue   = &throttledMessageQueue{}
 	_ MessageQueue = &blockingMessageQueue{}
 )
 
@@ -50,6 +66,22 @@ type MessageQueue interface {
 	Close()
 }
 
+// ClassQueue is a MessageQueue whose bulk and consensus messages can also be
+// popped separately, so that each class can be sent over its own stream.
+type ClassQueue interface {
+	MessageQueue
+
+	// PopClass blocks until a bulk message, if [bulk] is true, or a consensus
+	// message otherwise, is available and then returns the message. If the
+	// queue is closed, then `false` is returned.
+	PopClass(bulk bool) (message.OutboundMessage, bool)
+
+	// PopClassNow attempts to return a message of the class selected by [bulk]
+	// without blocking. If such a message is not available or the queue is
+	// closed, then `false` is returned.
+	PopClassNow(bulk bool) (message.OutboundMessage, bool)
+}
+
 type throttledMessageQueue struct {
 	onFailed SendFailedCallback
 	// [id] of the peer we're sending messages to
@@ -57,32 +89,60 @@ type throttledMessageQueue struct {
 	log                  logging.Logger
 	outboundMsgThrottler throttling.OutboundMsgThrottler
 
-	// Signalled when a message is added to the queue and when Close() is
-	// called.
+	// Broadcast when a message is added to the queue and when Close() is
+	// called. Popping a message of a single class may wait on it while
+	// another message class is being popped.
 	cond *sync.Cond
 
 	// closed flags whether the send queue has been closed.
 	// [cond.L] must be held while accessing [closed].
 	closed bool
 
-	// queue of the messages
-	// [cond.L] must be held while accessing [queue].
-	queue buffer.UnboundedQueue[message.OutboundMessage]
+	// Consensus and bulk messages are queued separately so that a bulk
+	// transfer doesn't delay the consensus messages sent after it.
+	// [cond.L] must be held while accessing the queues and the counters.
+	queue     buffer.UnboundedQueue[message.OutboundMessage]
+	bulkQueue buffer.UnboundedQueue[message.OutboundMessage]
+	// Number of bytes of the messages in [bulkQueue]
+	bulkBytes int
+	// Number of consensus messages popped since the last bulk message
+	consecutiveConsensusMsgs int
+
+	// Number of bytes of bulk messages that can be queued. Bulk messages are
+	// dropped beyond it, so that bulk transfers can't use up the outbound
+	// allocation that consensus messages depend on. 0 means there is no
+	// maximum.
+	maxBulkBytes int
+	// Incremented when a bulk message is dropped because of [maxBulkBytes]
+	bulkDropped prometheus.Counter
 }
 
+// NewThrottledMessageQueue returns a queue that sends consensus messages ahead
+// of bulk messages, while still sending a bulk message after every few
+// consensus messages. Bulk messages are dropped, and counted by [bulkDropped],
+// once [maxBulkBytes] of them are queued.
+//
+// Over a single stream, such as a TCP connection, a bulk message that is being
+// written still delays the messages after it. Peers with multiplexed
+// connections pop each class separately to send it over its own stream.
 func NewThrottledMessageQueue(
 	onFailed SendFailedCallback,
 	id ids.NodeID,
 	log logging.Logger,
 	outboundMsgThrottler throttling.OutboundMsgThrottler,
-) MessageQueue {
+	maxBulkBytes int,
+	bulkDropped prometheus.Counter,
+) ClassQueue {
 	return &throttledMessageQueue{
 		onFailed:             onFailed,
 		id:                   id,
 		log:                  log,
 		outboundMsgThrottler: outboundMsgThrottler,
+		maxBulkBytes:         maxBulkBytes,
+		bulkDropped:          bulkDropped,
 		cond:                 sync.NewCond(&sync.Mutex{}),
 		queue:                buffer.NewUnboundedSliceQueue[message.OutboundMessage](initialQueueSize),
+		bulkQueue:            buffer.NewUnboundedSliceQueue[message.OutboundMessage](initialQueueSize),
 	}
 }
 
@@ -129,8 +189,26 @@ func (q *throttledMessageQueue) Push(ctx context.Context, msg message.OutboundMe
 		return false
 	}
 
-	q.queue.Enqueue(msg)
-	q.cond.Signal()
+	if _, isBulk := bulkOps[msg.Op()]; isBulk {
+		msgLen := len(msg.Bytes())
+		if q.maxBulkBytes > 0 && q.bulkBytes+msgLen > q.maxBulkBytes {
+			q.bulkDropped.Inc()
+			q.log.Debug(
+				"dropping outgoing message",
+				zap.String("reason", "bulk queue full"),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Stringer("nodeID", q.id),
+			)
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+			return false
+		}
+		q.bulkBytes += msgLen
+		q.bulkQueue.Enqueue(msg)
+	} else {
+		q.queue.Enqueue(msg)
+	}
+	q.cond.Broadcast()
 	return true
 }
 
@@ -142,7 +220,7 @@ func (q *throttledMessageQueue) Pop() (message.OutboundMessage, bool) {
 		if q.closed {
 			return nil, false
 		}
-		if q.queue.Len() > 0 {
+		if q.len() > 0 {
 			// There is a message
 			break
 		}
@@ -157,7 +235,7 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	q.cond.L.Lock()
 	defer q.cond.L.Unlock()
 
-	if q.closed || q.queue.Len() == 0 {
+	if q.closed || q.len() == 0 {
 		// There isn't a message
 		return nil, false
 	}
@@ -165,8 +243,71 @@ func (q *throttledMessageQueue) PopNow() (message.OutboundMessage, bool) {
 	return q.pop(), true
 }
 
+func (q *throttledMessageQueue) PopClass(bulk bool) (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	for {
+		if q.closed {
+			return nil, false
+		}
+		if q.classQueue(bulk).Len() > 0 {
+			// There is a message
+			break
+		}
+		// Wait until there is a message
+		q.cond.Wait()
+	}
+
+	return q.popClass(bulk), true
+}
+
+func (q *throttledMessageQueue) PopClassNow(bulk bool) (message.OutboundMessage, bool) {
+	q.cond.L.Lock()
+	defer q.cond.L.Unlock()
+
+	if q.closed || q.classQueue(bulk).Len() == 0 {
+		// There isn't a message
+		return nil, false
+	}
+
+	return q.popClass(bulk), true
+}
+
+// len returns the number of queued messages. Assumes [cond.L] is held.
+func (q *throttledMessageQueue) len() int {
+	return q.queue.Len() + q.bulkQueue.Len()
+}
+
+// classQueue returns [bulkQueue] if [bulk] is true and [queue] otherwise.
+// Assumes [cond.L] is held.
+func (q *throttledMessageQueue) classQueue(bulk bool) buffer.UnboundedQueue[message.OutboundMessage] {
+	if bulk {
+		return q.bulkQueue
+	}
+	return q.queue
+}
+
+// pop returns the next message to send, of which there must be at least one.
+// Assumes [cond.L] is held.
 func (q *throttledMessageQueue) pop() message.OutboundMessage {
-	msg, _ := q.queue.Dequeue()
+	bulk := q.queue.Len() == 0 ||
+		(q.bulkQueue.Len() > 0 && q.consecutiveConsensusMsgs >= maxConsecutiveConsensusMsgs)
+	return q.popClass(bulk)
+}
+
+// popClass returns the next message of the class selected by [bulk], of which
+// there must be at least one. Assumes [cond.L] is held.
+func (q *throttledMessageQueue) popClass(bulk bool) message.OutboundMessage {
+	var msg message.OutboundMessage
+	if bulk {
+		msg, _ = q.bulkQueue.Dequeue()
+		q.bulkBytes -= len(msg.Bytes())
+		q.consecutiveConsensusMsgs = 0
+	} else {
+		msg, _ = q.queue.Dequeue()
+		q.consecutiveConsensusMsgs++
+	}
 
 	q.outboundMsgThrottler.Release(msg, q.id)
 	return msg
@@ -182,12 +323,16 @@ func (q *throttledMessageQueue) Close() {
 
 	q.closed = true
 
-	for q.queue.Len() > 0 {
-		msg, _ := q.queue.Dequeue()
-		q.outboundMsgThrottler.Release(msg, q.id)
-		q.onFailed.SendFailed(msg)
+	for _, queue := range []buffer.UnboundedQueue[message.OutboundMessage]{q.queue, q.bulkQueue} {
+		for queue.Len() > 0 {
+			msg, _ := queue.Dequeue()
+			q.outboundMsgThrottler.Release(msg, q.id)
+			q.onFailed.SendFailed(msg)
+		}
 	}
 	q.queue = nil
+	q.bulkQueue = nil
+	q.bulkBytes = 0
 
 	q.cond.Broadcast()
 }
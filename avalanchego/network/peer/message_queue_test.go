@@ -8,10 +8,16 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/units"
 )
 
 func TestBlockingMessageQueue(t *testing.T) {
@@ -62,3 +68,115 @@ func TestBlockingMessageQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestThrottledMessageQueueBulkMessages(t *testing.T) {
+	require := require.New(t)
+
+	var failed []message.OutboundMessage
+	maxBulkBytes := 16 * units.MiB
+	bulkDropped := prometheus.NewCounter(prometheus.CounterOpts{})
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(msg message.OutboundMessage) {
+			failed = append(failed, msg)
+		}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+		maxBulkBytes,
+		bulkDropped,
+	)
+
+	mc, _ := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+	bulkMsg, err := mc.Ancestors(chainID, 1, [][]byte{make([]byte, units.MiB)})
+	require.NoError(err)
+	consensusMsg, err := mc.Chits(chainID, 2, []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+
+	require.True(q.Push(context.Background(), bulkMsg))
+	numConsensusMsgs := maxConsecutiveConsensusMsgs + 1
+	for i := 0; i < numConsensusMsgs; i++ {
+		require.True(q.Push(context.Background(), consensusMsg))
+	}
+
+	// Consensus messages are sent ahead of the bulk message, which is sent
+	// once enough consensus messages were sent in a row
+	for i := 0; i < maxConsecutiveConsensusMsgs; i++ {
+		msg, ok := q.PopNow()
+		require.True(ok)
+		require.Equal(message.Chits, msg.Op())
+	}
+	msg, ok := q.PopNow()
+	require.True(ok)
+	require.Equal(message.Ancestors, msg.Op())
+	msg, ok = q.PopNow()
+	require.True(ok)
+	require.Equal(message.Chits, msg.Op())
+	_, ok = q.PopNow()
+	require.False(ok)
+
+	// Bulk messages beyond the bulk allocation are dropped
+	numBulkMsgs := maxBulkBytes / len(bulkMsg.Bytes())
+	for i := 0; i < numBulkMsgs; i++ {
+		require.True(q.Push(context.Background(), bulkMsg))
+	}
+	require.False(q.Push(context.Background(), bulkMsg))
+	require.Len(failed, 1)
+	require.Equal(float64(1), testutil.ToFloat64(bulkDropped))
+
+	// Consensus messages are still accepted
+	require.True(q.Push(context.Background(), consensusMsg))
+
+	q.Close()
+	require.Len(failed, numBulkMsgs+2)
+}
+
+func TestThrottledMessageQueuePopClass(t *testing.T) {
+	require := require.New(t)
+
+	q := NewThrottledMessageQueue(
+		SendFailedFunc(func(message.OutboundMessage) {}),
+		ids.GenerateTestNodeID(),
+		logging.NoLog{},
+		throttling.NewNoOutboundThrottler(),
+		0,
+		prometheus.NewCounter(prometheus.CounterOpts{}),
+	)
+
+	mc, _ := newMessageCreator(t)
+	chainID := ids.GenerateTestID()
+	bulkMsg, err := mc.Ancestors(chainID, 1, [][]byte{make([]byte, units.MiB)})
+	require.NoError(err)
+	consensusMsg, err := mc.Chits(chainID, 2, []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+
+	// A bulk message that is waiting doesn't wake up the consensus stream
+	consensusPopped := make(chan message.OutboundMessage)
+	go func() {
+		msg, _ := q.PopClass(false)
+		consensusPopped <- msg
+	}()
+	require.True(q.Push(context.Background(), bulkMsg))
+
+	msg, ok := q.PopClass(true)
+	require.True(ok)
+	require.Equal(message.Ancestors, msg.Op())
+	_, ok = q.PopClassNow(true)
+	require.False(ok)
+
+	require.True(q.Push(context.Background(), consensusMsg))
+	msg = <-consensusPopped
+	require.NotNil(msg)
+	require.Equal(message.Chits, msg.Op())
+
+	// Closing the queue wakes up every class
+	bulkClosed := make(chan bool)
+	go func() {
+		_, ok := q.PopClass(true)
+		bulkClosed <- ok
+	}()
+	q.Close()
+	require.False(<-bulkClosed)
+	_, ok = q.PopClass(false)
+	require.False(ok)
+}
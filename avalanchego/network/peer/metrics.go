@@ -87,6 +87,8 @@ func NewMessageMetrics(
 type Metrics struct {
 	Log                     logging.Logger
 	FailedToParse           prometheus.Counter
+	LegacyMessagesDropped   prometheus.Counter
+	BulkMessagesDropped     prometheus.Counter
 	NumUselessPeerListBytes prometheus.Counter
 	MessageMetrics          map[message.Op]*MessageMetrics
 }
@@ -103,6 +105,16 @@ func NewMetrics(
 			Name:      "msgs_failed_to_parse",
 			Help:      "Number of messages that could not be parsed or were invalidly formed",
 		}),
+		LegacyMessagesDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "legacy_msgs_dropped",
+			Help:      "Number of messages dropped because they were sent in the disabled legacy format",
+		}),
+		BulkMessagesDropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "bulk_msgs_dropped",
+			Help:      "Number of outbound bulk messages dropped because the bulk messages queued for their peer were at the maximum size",
+		}),
 		NumUselessPeerListBytes: prometheus.NewCounter(prometheus.CounterOpts{
 			Namespace: namespace,
 			Name:      "num_useless_peerlist_bytes",
@@ -114,6 +126,8 @@ func NewMetrics(
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(m.FailedToParse),
+		registerer.Register(m.LegacyMessagesDropped),
+		registerer.Register(m.BulkMessagesDropped),
 		registerer.Register(m.NumUselessPeerListBytes),
 	)
 	for _, op := range message.ExternalOps {
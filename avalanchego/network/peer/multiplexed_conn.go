@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"context"
+	"io"
+	"net"
+	"time"
+)
+
+// MultiplexedConn is a connection that carries independent streams, such as a
+// QUIC connection. Peers connected over one exchange bulk messages over a
+// stream of their own, so that bulk messages are flow controlled separately
+// from consensus messages and can't delay them.
+type MultiplexedConn interface {
+	net.Conn
+
+	// OpenSendStream opens a stream that only the local node writes to.
+	OpenSendStream(ctx context.Context) (SendStream, error)
+
+	// AcceptReceiveStream waits for the remote peer to open a stream that
+	// only it writes to.
+	AcceptReceiveStream(ctx context.Context) (ReceiveStream, error)
+}
+
+// SendStream is the writing half of a stream
+type SendStream interface {
+	io.Writer
+	SetWriteDeadline(t time.Time) error
+}
+
+// ReceiveStream is the reading half of a stream
+type ReceiveStream interface {
+	io.Reader
+	SetReadDeadline(t time.Time) error
+}
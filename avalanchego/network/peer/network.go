@@ -15,6 +15,10 @@ type Network interface {
 	// Connected is called by the peer once the handshake is finished.
 	Connected(ids.NodeID)
 
+	// ConnectedSubnet is called by the peer when, after the handshake, it
+	// starts tracking a subnet that this node is also tracking.
+	ConnectedSubnet(nodeID ids.NodeID, subnetID ids.ID)
+
 	// AllowConnection enables the network is signal to the peer that its
 	// connection is no longer desired and should be terminated.
 	AllowConnection(ids.NodeID) bool
@@ -41,6 +45,12 @@ type Network interface {
 	// during the handshake.
 	Peers() (message.OutboundMessage, error)
 
+	// ValidatorPeers provides the peer with the PeerList message to send to
+	// the peer in response to a GetPeerList message. The message only
+	// includes the IPs of the requested validators that this node is
+	// connected to.
+	ValidatorPeers(validatorIDs []ids.NodeID) (message.OutboundMessage, error)
+
 	// Pong provides the peer with a Pong message to send to the peer in
 	// response to a Ping message.
 	Pong(ids.NodeID) (message.OutboundMessage, error)
@@ -19,7 +19,10 @@ import (
 
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/network/throttling"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -30,6 +33,9 @@ import (
 var (
 	errClosed = errors.New("closed")
 
+	// Buffers that inbound messages are read into. Shared by all peers.
+	msgBufferPool = buffer.NewPool(constants.DefaultByteSliceCap, constants.DefaultMaxMessageSize)
+
 	_ Peer = &peer{}
 )
 
@@ -62,6 +68,9 @@ type Peer interface {
 	// called after [Ready] returns true.
 	Info() Info
 
+	// MessageCounts returns the number of messages exchanged with this peer.
+	MessageCounts() MessageCounts
+
 	// IP returns the claimed IP and signature provided by this peer during the
 	// handshake. It should only be called after [Ready] returns true.
 	IP() *SignedIP
@@ -74,6 +83,16 @@ type Peer interface {
 	// be called after [Ready] returns true.
 	TrackedSubnets() ids.Set
 
+	// Capabilities returns the optional features this peer reported supporting
+	// in its Version message. It should only be called after [Ready] returns
+	// true.
+	Capabilities() Capabilities
+
+	// TrackSubnet marks [subnetID] as tracked by this peer if the peer reported
+	// it in its most recent Version message. Returns true if the subnet wasn't
+	// already tracked.
+	TrackSubnet(subnetID ids.ID) bool
+
 	// ObservedUptime returns the local node's uptime according to the peer. The
 	// value ranges from [0, 100]. It should only be called after [Ready]
 	// returns true.
@@ -113,13 +132,29 @@ type peer struct {
 	// queue of messages to send to this peer.
 	messageQueue MessageQueue
 
-	// ip is the claimed IP the peer gave us in the Version message.
+	// If the connection is multiplexed, [multiplexedConn] is the connection
+	// and bulk messages are popped from [classQueue], which is
+	// [messageQueue], to be sent over a stream of their own. Both are nil
+	// otherwise.
+	multiplexedConn MultiplexedConn
+	classQueue      ClassQueue
+
+	ipLock sync.RWMutex
+	// ip is the claimed IP the peer gave us in the most recent Version
+	// message.
 	ip *SignedIP
 	// version is the claimed version the peer is running that we received in
 	// the Version message.
 	version *version.Application
-	// trackedSubnets is the subset of subnetIDs the peer sent us in the Version
-	// message that we are also tracking.
+
+	trackedSubnetsLock sync.RWMutex
+	// [trackedSubnetsLock] must be held while accessing [claimedSubnets] or
+	// [trackedSubnets]
+	// claimedSubnets is the set of subnetIDs the peer sent us in its most
+	// recent Version message.
+	claimedSubnets ids.Set
+	// trackedSubnets is the subset of [claimedSubnets] that we are also
+	// tracking.
 	trackedSubnets ids.Set
 
 	observedUptimeLock sync.RWMutex
@@ -131,6 +166,16 @@ type peer struct {
 	// Only modified on the connection's reader routine.
 	gotVersion utils.AtomicBool
 
+	// True if this peer reported in its Version message that it is able to
+	// decompress zstd compressed messages.
+	// Only modified on the connection's reader routine.
+	supportsZstd utils.AtomicBool
+
+	// capabilities the peer reported in its Version message.
+	// Only modified on the connection's reader routine.
+	// Must only be accessed atomically
+	capabilities uint64
+
 	// True if the peer:
 	// * Has sent us a Version message
 	// * Has sent us a PeerList message
@@ -155,12 +200,21 @@ type peer struct {
 	// Unix time of the last message sent and received respectively
 	// Must only be accessed atomically
 	lastSent, lastReceived int64
+
+	// Number of messages sent, received, attempted to be sent and failed to
+	// be sent respectively
+	// Must only be accessed atomically
+	numSent, numReceived, numSendAttempts, numSendFailures uint64
 }
 
 // Start a new peer instance.
 //
+// If [conn] is a [MultiplexedConn], [messageQueue] is a [ClassQueue] and
+// [config.BulkInboundMsgThrottler] is set, bulk messages are exchanged over
+// streams of their own.
+//
 // Invariant: There must only be one peer running at a time with a reference to
-// the same [config.InboundMsgThrottler].
+// the same [config.InboundMsgThrottler] or [config.BulkInboundMsgThrottler].
 func Start(
 	config *Config,
 	conn net.Conn,
@@ -182,6 +236,17 @@ func Start(
 		onClosed:           make(chan struct{}),
 	}
 
+	multiplexedConn, isMultiplexed := conn.(MultiplexedConn)
+	classQueue, isClassQueue := messageQueue.(ClassQueue)
+	if isMultiplexed && isClassQueue && config.BulkInboundMsgThrottler != nil {
+		p.multiplexedConn = multiplexedConn
+		p.classQueue = classQueue
+		p.numExecuting += 2
+
+		go p.readBulkMessages()
+		go p.writeBulkMessages()
+	}
+
 	go p.readMessages()
 	go p.writeMessages()
 	go p.sendPings()
@@ -222,8 +287,8 @@ func (p *peer) AwaitReady(ctx context.Context) error {
 
 func (p *peer) Info() Info {
 	publicIPStr := ""
-	if !p.ip.IP.IP.IsZero() {
-		publicIPStr = p.ip.IP.IP.String()
+	if ip := p.IP(); !ip.IP.IP.IsZero() {
+		publicIPStr = ip.IP.IP.String()
 	}
 	return Info{
 		IP:             p.conn.RemoteAddr().String(),
@@ -233,15 +298,51 @@ func (p *peer) Info() Info {
 		LastSent:       time.Unix(atomic.LoadInt64(&p.lastSent), 0),
 		LastReceived:   time.Unix(atomic.LoadInt64(&p.lastReceived), 0),
 		ObservedUptime: json.Uint8(p.ObservedUptime()),
-		TrackedSubnets: p.trackedSubnets.List(),
+		TrackedSubnets: p.TrackedSubnets().List(),
 	}
 }
 
-func (p *peer) IP() *SignedIP { return p.ip }
+func (p *peer) MessageCounts() MessageCounts {
+	return MessageCounts{
+		Sent:         json.Uint64(atomic.LoadUint64(&p.numSent)),
+		Received:     json.Uint64(atomic.LoadUint64(&p.numReceived)),
+		SendAttempts: json.Uint64(atomic.LoadUint64(&p.numSendAttempts)),
+		SendFailures: json.Uint64(atomic.LoadUint64(&p.numSendFailures)),
+	}
+}
+
+func (p *peer) IP() *SignedIP {
+	p.ipLock.RLock()
+	defer p.ipLock.RUnlock()
+
+	return p.ip
+}
 
 func (p *peer) Version() *version.Application { return p.version }
 
-func (p *peer) TrackedSubnets() ids.Set { return p.trackedSubnets }
+func (p *peer) TrackedSubnets() ids.Set {
+	p.trackedSubnetsLock.RLock()
+	defer p.trackedSubnetsLock.RUnlock()
+
+	trackedSubnets := ids.NewSet(p.trackedSubnets.Len())
+	trackedSubnets.Union(p.trackedSubnets)
+	return trackedSubnets
+}
+
+func (p *peer) Capabilities() Capabilities {
+	return Capabilities(atomic.LoadUint64(&p.capabilities))
+}
+
+func (p *peer) TrackSubnet(subnetID ids.ID) bool {
+	p.trackedSubnetsLock.Lock()
+	defer p.trackedSubnetsLock.Unlock()
+
+	if !p.claimedSubnets.Contains(subnetID) || p.trackedSubnets.Contains(subnetID) {
+		return false
+	}
+	p.trackedSubnets.Add(subnetID)
+	return true
+}
 
 func (p *peer) ObservedUptime() uint8 {
 	p.observedUptimeLock.RLock()
@@ -251,7 +352,12 @@ func (p *peer) ObservedUptime() uint8 {
 }
 
 func (p *peer) Send(ctx context.Context, msg message.OutboundMessage) bool {
-	return p.messageQueue.Push(ctx, msg)
+	atomic.AddUint64(&p.numSendAttempts, 1)
+	if !p.messageQueue.Push(ctx, msg) {
+		atomic.AddUint64(&p.numSendFailures, 1)
+		return false
+	}
+	return true
 }
 
 func (p *peer) StartClose() {
@@ -308,12 +414,59 @@ func (p *peer) readMessages() {
 		p.close()
 	}()
 
+	p.readStream(p.conn, p.InboundMsgThrottler, false)
+}
+
+// Read and handle the bulk messages this peer sends over the stream of its
+// multiplexed connection that it opened for them.
+// When this method returns, the connection is closed.
+func (p *peer) readBulkMessages() {
+	// Track this node with the bulk inbound message throttler.
+	p.BulkInboundMsgThrottler.AddNode(p.id)
+	defer func() {
+		p.BulkInboundMsgThrottler.RemoveNode(p.id)
+		p.StartClose()
+		p.close()
+	}()
+
+	stream, err := p.multiplexedConn.AcceptReceiveStream(p.onClosingCtx)
+	if err != nil {
+		p.Log.Verbo("error accepting the bulk stream",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
+
+	// The streams aren't ordered with each other, so a bulk message sent right
+	// after the peer finished the handshake may arrive before the messages
+	// that finish the handshake on this side. It would be dropped if it were
+	// handled before them.
+	select {
+	case <-p.onFinishHandshake:
+	case <-p.onClosingCtx.Done():
+		return
+	}
+
+	p.readStream(stream, p.BulkInboundMsgThrottler, true)
+}
+
+// readStream reads and handles messages from [stream] until it fails. Reading a
+// message waits for [throttler]. If [bulk] is true, only bulk messages are
+// handled.
+func (p *peer) readStream(stream ReceiveStream, throttler throttling.InboundMsgThrottler, bulk bool) {
 	// Continuously read and handle messages from this peer.
-	reader := bufio.NewReaderSize(p.conn, p.Config.ReadBufferSize)
+	reader := bufio.NewReaderSize(stream, p.Config.ReadBufferSize)
 	msgLenBytes := make([]byte, wrappers.IntLen)
 	for {
-		// Time out and close connection if we can't read the message length
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		// Time out and close connection if we can't read the message length.
+		// The bulk stream is idle whenever no bulk message is being sent, so
+		// the liveness of the connection is only checked on the main stream.
+		var lenDeadline time.Time
+		if !bulk {
+			lenDeadline = p.nextTimeout()
+		}
+		if err := stream.SetReadDeadline(lenDeadline); err != nil {
 			p.Log.Verbo("error setting the connection read timeout",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
@@ -348,12 +501,13 @@ func (p *peer) readMessages() {
 		// throttler metrics to verify that there is no leak.
 		//
 		// Invariant: There must only be one call to Acquire at any given time
-		// with the same nodeID. In this package, only this goroutine ever
-		// performs Acquire. Additionally, we ensure that this goroutine has
-		// exited before calling [Network.Disconnected] to guarantee that there
-		// can't be multiple instances of this goroutine running over different
-		// peer instances.
-		onFinishedHandling := p.InboundMsgThrottler.Acquire(
+		// with the same nodeID. In this package, only the goroutine reading
+		// the stream [throttler] is used for ever performs Acquire on it.
+		// Additionally, we ensure that this goroutine has exited before
+		// calling [Network.Disconnected] to guarantee that there can't be
+		// multiple instances of this goroutine running over different peer
+		// instances.
+		onFinishedHandling := throttler.Acquire(
 			p.onClosingCtx,
 			uint64(msgLen),
 			p.id,
@@ -366,7 +520,7 @@ func (p *peer) readMessages() {
 		}
 
 		// Time out and close connection if we can't read message
-		if err := p.conn.SetReadDeadline(p.nextTimeout()); err != nil {
+		if err := stream.SetReadDeadline(p.nextTimeout()); err != nil {
 			p.Log.Verbo("error setting the connection read timeout",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
@@ -375,13 +529,24 @@ func (p *peer) readMessages() {
 			return
 		}
 
-		// Read the message
-		msgBytes := make([]byte, msgLen)
+		// Read the message. Proto messages are read into a buffer of
+		// [msgBufferPool], which is returned to it once the message is parsed.
+		// Legacy messages keep referencing their buffer, so it is allocated
+		// with the exact length of the message.
+		var msgBytes []byte
+		if isProto {
+			msgBytes = msgBufferPool.Get(int(msgLen))
+		} else {
+			msgBytes = make([]byte, msgLen)
+		}
 		if _, err := io.ReadFull(reader, msgBytes); err != nil {
 			p.Log.Verbo("error reading message",
 				zap.Stringer("nodeID", p.id),
 				zap.Error(err),
 			)
+			if isProto {
+				msgBufferPool.Put(msgBytes)
+			}
 			onFinishedHandling()
 			return
 		}
@@ -399,6 +564,19 @@ func (p *peer) readMessages() {
 			zap.Binary("messageBytes", msgBytes),
 		)
 
+		if !isProto && p.LegacyMessagesDisabled {
+			p.Log.Debug("dropping message",
+				zap.String("reason", "legacy message format is disabled"),
+				zap.Stringer("nodeID", p.id),
+			)
+
+			p.Metrics.LegacyMessagesDropped.Inc()
+
+			onFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			continue
+		}
+
 		// Parse the message
 		var msg message.InboundMessage
 		if isProto {
@@ -416,14 +594,37 @@ func (p *peer) readMessages() {
 			p.Metrics.FailedToParse.Inc()
 
 			// Couldn't parse the message. Read the next one.
+			if isProto {
+				msgBufferPool.Put(msgBytes)
+			}
 			onFinishedHandling()
 			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
 			continue
 		}
 
+		// Parsed proto messages copy all of their fields, so the buffer can be
+		// reused.
+		if isProto {
+			msgBufferPool.Put(msgBytes)
+		}
+
+		// The bulk stream is only throttled for bulk messages, so other
+		// messages must not bypass the throttling of the main stream over it.
+		if _, isBulk := bulkOps[msg.Op()]; bulk && !isBulk {
+			p.Log.Debug("dropping message",
+				zap.String("reason", "not a bulk message"),
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+			)
+			msg.OnFinishedHandling()
+			p.ResourceTracker.StopProcessing(p.id, p.Clock.Time())
+			continue
+		}
+
 		now := p.Clock.Time().Unix()
 		atomic.StoreInt64(&p.Config.LastReceived, now)
 		atomic.StoreInt64(&p.lastReceived, now)
+		atomic.AddUint64(&p.numReceived, 1)
 		p.Metrics.Received(msg, msgLen)
 
 		// Handle the message. Note that when we are done handling this message,
@@ -451,12 +652,41 @@ func (p *peer) writeMessages() {
 		return
 	}
 
-	p.writeMessage(writer, msg)
+	p.writeMessage(p.conn, writer, msg)
+	p.writeQueuedMessages(p.conn, writer, false)
+}
+
+// Write the bulk messages queued for this peer over a stream of its multiplexed
+// connection that is opened for them.
+// When this method returns, the connection is closed.
+func (p *peer) writeBulkMessages() {
+	defer func() {
+		p.StartClose()
+		p.close()
+	}()
+
+	stream, err := p.multiplexedConn.OpenSendStream(p.onClosingCtx)
+	if err != nil {
+		p.Log.Verbo("error opening the bulk stream",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		return
+	}
+
+	writer := bufio.NewWriterSize(stream, p.Config.WriteBufferSize)
+	p.writeQueuedMessages(stream, writer, true)
+}
 
+// writeQueuedMessages writes the messages popped from the queue to [writer],
+// which buffers [stream], until the queue is closed. If the connection is
+// multiplexed, only the bulk messages are popped if [bulk] is true, and only
+// the other messages otherwise.
+func (p *peer) writeQueuedMessages(stream SendStream, writer *bufio.Writer, bulk bool) {
 	for {
-		msg, ok := p.messageQueue.PopNow()
+		msg, ok := p.popMessageNow(bulk)
 		if ok {
-			p.writeMessage(writer, msg)
+			p.writeMessage(stream, writer, msg)
 			continue
 		}
 
@@ -470,24 +700,59 @@ func (p *peer) writeMessages() {
 			return
 		}
 
-		msg, ok = p.messageQueue.Pop()
+		msg, ok = p.popMessage(bulk)
 		if !ok {
 			// This peer is closing
 			return
 		}
 
-		p.writeMessage(writer, msg)
+		p.writeMessage(stream, writer, msg)
+	}
+}
+
+// popMessage blocks until a message is available, of the class selected by
+// [bulk] if the connection is multiplexed, and then returns it.
+func (p *peer) popMessage(bulk bool) (message.OutboundMessage, bool) {
+	if p.classQueue == nil {
+		return p.messageQueue.Pop()
 	}
+	return p.classQueue.PopClass(bulk)
 }
 
-func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
+// popMessageNow attempts to return a message, of the class selected by [bulk]
+// if the connection is multiplexed, without blocking.
+func (p *peer) popMessageNow(bulk bool) (message.OutboundMessage, bool) {
+	if p.classQueue == nil {
+		return p.messageQueue.PopNow()
+	}
+	return p.classQueue.PopClassNow(bulk)
+}
+
+// writeMessage writes [msg] to [writer], which buffers [stream].
+func (p *peer) writeMessage(stream SendStream, writer io.Writer, msg message.OutboundMessage) {
+	if msg.CompressionType() == compression.TypeZstd && !p.supportsZstd.GetValue() {
+		// Every peer that can parse protobuf messages is able to decompress
+		// gzip, so fall back to it for peers that didn't advertise zstd.
+		gzipMsg, err := p.MessageCreatorWithProto.Recompress(msg, compression.TypeGzip)
+		if err != nil {
+			p.Log.Error("failed to recompress message",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Error(err),
+			)
+			msg.DecRef()
+			return
+		}
+		msg = gzipMsg
+	}
+
 	msgBytes := msg.Bytes()
 	p.Log.Verbo("sending message",
 		zap.Stringer("nodeID", p.id),
 		zap.Binary("messageBytes", msgBytes),
 	)
 
-	if err := p.conn.SetWriteDeadline(p.nextTimeout()); err != nil {
+	if err := stream.SetWriteDeadline(p.nextTimeout()); err != nil {
 		p.Log.Verbo("error setting write deadline",
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
@@ -521,6 +786,7 @@ func (p *peer) writeMessage(writer io.Writer, msg message.OutboundMessage) {
 	now := p.Clock.Time().Unix()
 	atomic.StoreInt64(&p.Config.LastSent, now)
 	atomic.StoreInt64(&p.lastSent, now)
+	atomic.AddUint64(&p.numSent, 1)
 	p.Metrics.Sent(msg)
 }
 
@@ -603,6 +869,12 @@ func (p *peer) handle(msg message.InboundMessage) {
 		return
 	}
 
+	if op == message.GetPeerList {
+		p.handleGetPeerList(msg)
+		msg.OnFinishedHandling()
+		return
+	}
+
 	// Consensus and app-level messages
 	p.Router.HandleInbound(msg)
 }
@@ -649,10 +921,10 @@ func (p *peer) handlePong(msg message.InboundMessage) {
 
 func (p *peer) handleVersion(msg message.InboundMessage) {
 	if p.gotVersion.GetValue() {
-		// TODO: this should never happen, should we close the connection here?
-		p.Log.Verbo("dropping duplicated version message",
-			zap.Stringer("nodeID", p.id),
-		)
+		// A Version message sent after the handshake announces that the peer
+		// has started tracking additional subnets or that its IP has changed.
+		p.handleTrackedSubnetsUpdate(msg)
+		p.handleIPUpdate(msg)
 		return
 	}
 
@@ -785,34 +1057,170 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 	}
 
 	// handle subnet IDs
-	subnetIDsBytesIntf, err := msg.Get(message.TrackedSubnets)
+	claimedSubnets, ok := p.parseTrackedSubnets(msg)
+	if !ok {
+		return
+	}
+
+	p.trackedSubnetsLock.Lock()
+	p.claimedSubnets = claimedSubnets
+	for subnetID := range claimedSubnets {
+		// add only if we also track this subnet
+		if p.MySubnets.Contains(subnetID) {
+			p.trackedSubnets.Add(subnetID)
+		}
+	}
+	p.trackedSubnetsLock.Unlock()
+
+	// Peers that don't report their supported compression types, such as peers
+	// sending packer based messages, are assumed to only support gzip.
+	if compressionTypesIntf, err := msg.Get(message.CompressionTypes); err == nil {
+		for _, compressionType := range compressionTypesIntf.([]compression.Type) {
+			if compressionType == compression.TypeZstd {
+				p.supportsZstd.SetValue(true)
+			}
+		}
+	}
+
+	// Peers that don't report their capabilities, such as peers sending packer
+	// based messages, are assumed to support no optional features.
+	if capabilitiesIntf, err := msg.Get(message.Capabilities); err == nil {
+		atomic.StoreUint64(&p.capabilities, capabilitiesIntf.(uint64))
+	}
+
+	peerIPIntf, err := msg.Get(message.IP)
 	if err != nil {
 		p.Log.Debug("message with invalid field",
 			zap.Stringer("nodeID", p.id),
 			zap.Stringer("messageOp", message.Version),
-			zap.Stringer("field", message.TrackedSubnets),
+			zap.Stringer("field", message.IP),
 			zap.Error(err),
 		)
 		p.StartClose()
 		return
 	}
-	subnetIDsBytes := subnetIDsBytesIntf.([][]byte)
+	peerIP := peerIPIntf.(ips.IPPort)
 
-	for _, subnetIDBytes := range subnetIDsBytes {
-		subnetID, err := ids.ToID(subnetIDBytes)
+	signatureIntf, err := msg.Get(message.SigBytes)
+	if err != nil {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.Version),
+			zap.Stringer("field", message.SigBytes),
+			zap.Error(err),
+		)
+		p.StartClose()
+		return
+	}
+	signature := signatureIntf.([]byte)
+
+	signedIP := &SignedIP{
+		IP: UnsignedIP{
+			IP:        peerIP,
+			Timestamp: versionTime,
+		},
+		Signature: signature,
+	}
+	// Peers that don't claim additional IPs, such as peers sending packer
+	// based messages, are only dialed at [peerIP].
+	if additionalIPsIntf, err := msg.Get(message.AdditionalIPs); err == nil {
+		signedIP.IP.AdditionalIPs = additionalIPsIntf.([]ips.IPPort)
+	}
+	if len(signedIP.IP.AdditionalIPs) != 0 {
+		additionalIPsSigIntf, err := msg.Get(message.AdditionalIPsSig)
 		if err != nil {
-			p.Log.Debug("failed to parse peer's tracked subnets",
+			p.Log.Debug("message with invalid field",
 				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.Version),
+				zap.Stringer("field", message.AdditionalIPsSig),
 				zap.Error(err),
 			)
 			p.StartClose()
 			return
 		}
-		// add only if we also track this subnet
-		if p.MySubnets.Contains(subnetID) {
-			p.trackedSubnets.Add(subnetID)
+		signedIP.AdditionalIPsSignature = additionalIPsSigIntf.([]byte)
+	}
+	if err := signedIP.Verify(p.cert); err != nil {
+		p.Log.Debug("signature verification failed",
+			zap.Stringer("nodeID", p.id),
+			zap.Error(err),
+		)
+		p.StartClose()
+		return
+	}
+
+	p.ipLock.Lock()
+	p.ip = signedIP
+	p.ipLock.Unlock()
+
+	p.gotVersion.SetValue(true)
+
+	peerlistMsg, err := p.Network.Peers()
+	if err != nil {
+		p.Log.Error("failed to create message",
+			zap.Stringer("messageOp", message.PeerList),
+			zap.Error(err),
+		)
+		return
+	}
+	p.Send(p.onClosingCtx, peerlistMsg)
+}
+
+// handleTrackedSubnetsUpdate records the subnets reported in a Version message
+// received after the handshake. Subnets are never untracked for the lifetime
+// of the connection, so only newly reported subnets are handled.
+func (p *peer) handleTrackedSubnetsUpdate(msg message.InboundMessage) {
+	claimedSubnets, ok := p.parseTrackedSubnets(msg)
+	if !ok {
+		return
+	}
+
+	p.trackedSubnetsLock.Lock()
+	p.claimedSubnets.Union(claimedSubnets)
+	p.trackedSubnetsLock.Unlock()
+
+	for subnetID := range claimedSubnets {
+		if !p.MySubnets.Contains(subnetID) || !p.TrackSubnet(subnetID) {
+			continue
+		}
+		// If the handshake hasn't finished yet, the network will be notified
+		// about this subnet once it has.
+		if p.finishedHandshake.GetValue() {
+			p.Network.ConnectedSubnet(p.id, subnetID)
 		}
 	}
+}
+
+// handleIPUpdate records the IP reported in a Version message received after
+// the handshake if it was signed after the IP we currently have for the peer.
+func (p *peer) handleIPUpdate(msg message.InboundMessage) {
+	versionTimeIntf, err := msg.Get(message.VersionTime)
+	if err != nil {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.Version),
+			zap.Stringer("field", message.VersionTime),
+			zap.Error(err),
+		)
+		p.StartClose()
+		return
+	}
+	versionTime := versionTimeIntf.(uint64)
+
+	currentIP := p.IP()
+	if versionTime <= currentIP.IP.Timestamp {
+		return
+	}
+
+	myTime := p.Clock.Unix()
+	if float64(versionTime)-float64(myTime) > p.MaxClockDifference.Seconds() {
+		p.Log.Debug("peer attempting to update IP with version timestamp too far in the future",
+			zap.Stringer("nodeID", p.id),
+			zap.Uint64("versionTime", versionTime),
+		)
+		p.StartClose()
+		return
+	}
 
 	peerIPIntf, err := msg.Get(message.IP)
 	if err != nil {
@@ -825,7 +1233,6 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		p.StartClose()
 		return
 	}
-	peerIP := peerIPIntf.(ips.IPPort)
 
 	signatureIntf, err := msg.Get(message.SigBytes)
 	if err != nil {
@@ -838,16 +1245,15 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		p.StartClose()
 		return
 	}
-	signature := signatureIntf.([]byte)
 
-	p.ip = &SignedIP{
+	signedIP := &SignedIP{
 		IP: UnsignedIP{
-			IP:        peerIP,
+			IP:        peerIPIntf.(ips.IPPort),
 			Timestamp: versionTime,
 		},
-		Signature: signature,
+		Signature: signatureIntf.([]byte),
 	}
-	if err := p.ip.Verify(p.cert); err != nil {
+	if err := signedIP.Verify(p.cert); err != nil {
 		p.Log.Debug("signature verification failed",
 			zap.Stringer("nodeID", p.id),
 			zap.Error(err),
@@ -856,9 +1262,82 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		return
 	}
 
-	p.gotVersion.SetValue(true)
+	p.ipLock.Lock()
+	p.ip = signedIP
+	p.ipLock.Unlock()
 
-	peerlistMsg, err := p.Network.Peers()
+	if !signedIP.IP.IP.Equal(currentIP.IP.IP) {
+		p.Log.Debug("peer updated its IP",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("oldIP", currentIP.IP.IP),
+			zap.Stringer("newIP", signedIP.IP.IP),
+		)
+	}
+}
+
+// parseTrackedSubnets returns the subnetIDs reported in the Version message
+// [msg]. If the field is invalid, the peer is closed and false is returned.
+func (p *peer) parseTrackedSubnets(msg message.InboundMessage) (ids.Set, bool) {
+	subnetIDsBytesIntf, err := msg.Get(message.TrackedSubnets)
+	if err != nil {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.Version),
+			zap.Stringer("field", message.TrackedSubnets),
+			zap.Error(err),
+		)
+		p.StartClose()
+		return nil, false
+	}
+	subnetIDsBytes := subnetIDsBytesIntf.([][]byte)
+
+	subnetIDs := ids.NewSet(len(subnetIDsBytes))
+	for _, subnetIDBytes := range subnetIDsBytes {
+		subnetID, err := ids.ToID(subnetIDBytes)
+		if err != nil {
+			p.Log.Debug("failed to parse peer's tracked subnets",
+				zap.Stringer("nodeID", p.id),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return nil, false
+		}
+		subnetIDs.Add(subnetID)
+	}
+	return subnetIDs, true
+}
+
+func (p *peer) handleGetPeerList(msg message.InboundMessage) {
+	validatorIDsIntf, err := msg.Get(message.ValidatorIDs)
+	if err != nil {
+		p.Log.Debug("message with invalid field",
+			zap.Stringer("nodeID", p.id),
+			zap.Stringer("messageOp", message.GetPeerList),
+			zap.Stringer("field", message.ValidatorIDs),
+			zap.Error(err),
+		)
+		p.StartClose()
+		return
+	}
+	validatorIDsBytes := validatorIDsIntf.([][]byte)
+
+	validatorIDs := make([]ids.NodeID, len(validatorIDsBytes))
+	for i, validatorIDBytes := range validatorIDsBytes {
+		validatorID, err := ids.ToNodeID(validatorIDBytes)
+		if err != nil {
+			p.Log.Debug("message with invalid field",
+				zap.Stringer("nodeID", p.id),
+				zap.Stringer("messageOp", message.GetPeerList),
+				zap.Stringer("field", message.ValidatorIDs),
+				zap.Error(err),
+			)
+			p.StartClose()
+			return
+		}
+		validatorIDs[i] = validatorID
+	}
+
+	peerListMsg, err := p.Network.ValidatorPeers(validatorIDs)
 	if err != nil {
 		p.Log.Error("failed to create message",
 			zap.Stringer("messageOp", message.PeerList),
@@ -866,7 +1345,7 @@ func (p *peer) handleVersion(msg message.InboundMessage) {
 		)
 		return
 	}
-	p.Send(p.onClosingCtx, peerlistMsg)
+	p.Send(p.onClosingCtx, peerListMsg)
 }
 
 func (p *peer) handlePeerList(msg message.InboundMessage) {
@@ -9,10 +9,12 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -23,6 +25,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -44,6 +47,38 @@ type rawTestPeer struct {
 	inboundMsgChan <-chan message.InboundMessage
 }
 
+// testMultiplexedConn carries each stream over a pipe of its own
+type testMultiplexedConn struct {
+	net.Conn
+	sendStream    *countingConn
+	receiveStream net.Conn
+}
+
+func (c *testMultiplexedConn) OpenSendStream(context.Context) (SendStream, error) {
+	return c.sendStream, nil
+}
+
+func (c *testMultiplexedConn) AcceptReceiveStream(context.Context) (ReceiveStream, error) {
+	return c.receiveStream, nil
+}
+
+func (c *testMultiplexedConn) Close() error {
+	_ = c.sendStream.Close()
+	_ = c.receiveStream.Close()
+	return c.Conn.Close()
+}
+
+// countingConn counts the bytes attempted to be written to it
+type countingConn struct {
+	net.Conn
+	written int64
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	atomic.AddInt64(&c.written, int64(len(b)))
+	return c.Conn.Write(b)
+}
+
 func newMessageCreator(t *testing.T) (message.Creator, message.Creator) {
 	t.Helper()
 
@@ -58,7 +93,7 @@ func newMessageCreator(t *testing.T) (message.Creator, message.Creator) {
 	mcProto, err := message.NewCreatorWithProto(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	require.NoError(t, err)
@@ -99,7 +134,7 @@ func makeRawTestPeers(t *testing.T) (*rawTestPeer, *rawTestPeer) {
 		Log:                     logging.NoLog{},
 		InboundMsgThrottler:     throttling.NewNoInboundThrottler(),
 		VersionCompatibility:    version.GetCompatibility(constants.LocalID),
-		MySubnets:               ids.Set{},
+		MySubnets:               NewSubnetSet(nil),
 		Beacons:                 validators.NewSet(),
 		NetworkID:               constants.LocalID,
 		PingFrequency:           constants.DefaultPingFrequency,
@@ -179,6 +214,8 @@ func makeTestPeers(t *testing.T) (*testPeer, *testPeer) {
 				rawPeer1.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				0,
+				rawPeer0.config.Metrics.BulkMessagesDropped,
 			),
 		),
 		inboundMsgChan: rawPeer0.inboundMsgChan,
@@ -194,6 +231,8 @@ func makeTestPeers(t *testing.T) (*testPeer, *testPeer) {
 				rawPeer0.nodeID,
 				logging.NoLog{},
 				throttling.NewNoOutboundThrottler(),
+				0,
+				rawPeer1.config.Metrics.BulkMessagesDropped,
 			),
 		),
 		inboundMsgChan: rawPeer1.inboundMsgChan,
@@ -238,6 +277,8 @@ func TestReady(t *testing.T) {
 					rawPeer1.nodeID,
 					logging.NoLog{},
 					throttling.NewNoOutboundThrottler(),
+					0,
+					rawPeer0.config.Metrics.BulkMessagesDropped,
 				),
 			)
 
@@ -254,6 +295,8 @@ func TestReady(t *testing.T) {
 					rawPeer0.nodeID,
 					logging.NoLog{},
 					throttling.NewNoOutboundThrottler(),
+					0,
+					rawPeer1.config.Metrics.BulkMessagesDropped,
 				),
 			)
 
@@ -302,3 +345,440 @@ func TestSend(t *testing.T) {
 		})
 	}
 }
+
+func TestLegacyMessagesDisabled(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer1.config.LegacyMessagesDisabled = true
+
+	// peer1 would drop a handshake sent in the legacy format.
+	rawPeer0.config.Network.(*testNetwork).mc = rawPeer0.config.MessageCreatorWithProto
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	mc, mcProto := newMessageCreator(t)
+
+	legacyMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), legacyMsg))
+
+	protoMsg, err := mcProto.Get(ids.Empty, 2, time.Second, ids.Empty)
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), protoMsg))
+
+	// Messages are read in order, so the legacy message was dropped before the
+	// proto message was received.
+	inboundGetMsg := <-rawPeer1.inboundMsgChan
+	require.Equal(message.Get, inboundGetMsg.Op())
+	requestID, err := inboundGetMsg.Get(message.RequestID)
+	require.NoError(err)
+	require.Equal(uint32(2), requestID)
+	require.Equal(1., testutil.ToFloat64(rawPeer1.config.Metrics.LegacyMessagesDropped))
+
+	peer1.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestTrackedSubnetsUpdate(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	// The peers must not share the set of subnets they are tracking.
+	rawPeer0.config.MySubnets = NewSubnetSet(nil)
+	rawPeer1.config.MySubnets = NewSubnetSet(nil)
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	subnetID := ids.GenerateTestID()
+	require.False(peer0.TrackSubnet(subnetID))
+
+	// peer1 starts tracking the subnet and announces it to peer0.
+	require.True(rawPeer1.config.MySubnets.Add(subnetID))
+	network1 := rawPeer1.config.Network.(*testNetwork)
+	network1.subnets.Add(subnetID)
+	versionMsg, err := network1.Version()
+	require.NoError(err)
+	require.True(peer1.Send(context.Background(), versionMsg))
+
+	// Messages are handled in order, so once the Get message is received, the
+	// Version message has been handled.
+	mc, _ := newMessageCreator(t)
+	getMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+	require.NoError(err)
+	require.True(peer1.Send(context.Background(), getMsg))
+	inboundGetMsg := <-rawPeer0.inboundMsgChan
+	require.Equal(message.Get, inboundGetMsg.Op())
+
+	// peer0 doesn't track the subnet yet.
+	trackedSubnets := peer0.TrackedSubnets()
+	require.False(trackedSubnets.Contains(subnetID))
+
+	// Once peer0 starts tracking the subnet, the previously announced subnet is
+	// marked as tracked.
+	require.True(rawPeer0.config.MySubnets.Add(subnetID))
+	require.True(peer0.TrackSubnet(subnetID))
+	require.False(peer0.TrackSubnet(subnetID))
+	trackedSubnets = peer0.TrackedSubnets()
+	require.True(trackedSubnets.Contains(subnetID))
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestIPUpdate(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	network1 := rawPeer1.config.Network.(*testNetwork)
+	oldIP := peer0.IP()
+
+	sendVersion := func(ip ips.IPPort, timestamp uint64) {
+		unsignedIP := UnsignedIP{
+			IP:        ip,
+			Timestamp: timestamp,
+		}
+		signedIP, err := unsignedIP.Sign(network1.signer)
+		require.NoError(err)
+		versionMsg, err := network1.mc.Version(
+			network1.networkID,
+			timestamp,
+			ip,
+			network1.version.String(),
+			timestamp,
+			signedIP.Signature,
+			nil,
+			nil,
+			nil,
+			0,
+		)
+		require.NoError(err)
+		require.True(peer1.Send(context.Background(), versionMsg))
+
+		// Messages are handled in order, so once the Get message is received,
+		// the Version message has been handled.
+		mc, _ := newMessageCreator(t)
+		getMsg, err := mc.Get(ids.Empty, 1, time.Second, ids.Empty)
+		require.NoError(err)
+		require.True(peer1.Send(context.Background(), getMsg))
+		inboundGetMsg := <-rawPeer0.inboundMsgChan
+		require.Equal(message.Get, inboundGetMsg.Op())
+	}
+
+	newIP := ips.IPPort{
+		IP:   net.IPv4(1, 2, 3, 4),
+		Port: 9651,
+	}
+
+	// An IP signed before the current one is ignored.
+	sendVersion(newIP, oldIP.IP.Timestamp-1)
+	require.Equal(oldIP, peer0.IP())
+
+	// A newer IP replaces the current one.
+	sendVersion(newIP, oldIP.IP.Timestamp+1)
+	require.Equal(newIP, peer0.IP().IP.IP)
+	require.Equal(newIP.String(), peer0.Info().PublicIP)
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestSendZstdCompressed(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+
+	mcZstd, err := message.NewCreatorWithProto(
+		prometheus.NewRegistry(),
+		"",
+		compression.TypeZstd,
+		10*time.Second,
+	)
+	require.NoError(err)
+	rawPeer0.config.MessageCreatorWithProto = mcZstd
+	rawPeer1.config.MessageCreatorWithProto = mcZstd
+
+	// Only peer1 advertises zstd support during the handshake.
+	rawPeer1.config.Network.(*testNetwork).mc = mcZstd
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	require.True(peer0.(*peer).supportsZstd.GetValue())
+	require.False(peer1.(*peer).supportsZstd.GetValue())
+
+	container := make([]byte, 1024)
+	putMsg, err := mcZstd.Put(ids.Empty, 1, container)
+	require.NoError(err)
+	require.Equal(compression.TypeZstd, putMsg.CompressionType())
+
+	// peer1 supports zstd, so the message is sent as is.
+	require.True(peer0.Send(context.Background(), putMsg))
+	inboundPutMsg := <-rawPeer1.inboundMsgChan
+	require.Equal(message.Put, inboundPutMsg.Op())
+	inboundContainer, err := inboundPutMsg.Get(message.ContainerBytes)
+	require.NoError(err)
+	require.Equal(container, inboundContainer)
+
+	// peer0 didn't advertise zstd, so the message is recompressed with gzip.
+	require.True(peer1.Send(context.Background(), putMsg))
+	inboundPutMsg = <-rawPeer0.inboundMsgChan
+	require.Equal(message.Put, inboundPutMsg.Op())
+	inboundContainer, err = inboundPutMsg.Get(message.ContainerBytes)
+	require.NoError(err)
+	require.Equal(container, inboundContainer)
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestCapabilities(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+
+	// Only peer1 advertises capabilities during the handshake. Packer based
+	// Version messages can't carry them.
+	network1 := rawPeer1.config.Network.(*testNetwork)
+	network1.mc = rawPeer1.config.MessageCreatorWithProto
+	network1.capabilities = CapabilityQUIC
+
+	peer0 := Start(
+		rawPeer0.config,
+		rawPeer0.conn,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		rawPeer1.conn,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	require.True(peer0.Capabilities().Contains(CapabilityQUIC))
+	require.Equal(Capabilities(0), peer1.Capabilities())
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
+
+func TestMultiplexedBulkMessages(t *testing.T) {
+	require := require.New(t)
+
+	rawPeer0, rawPeer1 := makeRawTestPeers(t)
+	rawPeer0.config.BulkInboundMsgThrottler = throttling.NewNoInboundThrottler()
+	rawPeer1.config.BulkInboundMsgThrottler = throttling.NewNoInboundThrottler()
+
+	bulk01Send, bulk01Receive := net.Pipe()
+	bulk10Send, bulk10Receive := net.Pipe()
+	conn0 := &testMultiplexedConn{
+		Conn:          rawPeer0.conn,
+		sendStream:    &countingConn{Conn: bulk01Send},
+		receiveStream: bulk10Receive,
+	}
+	conn1 := &testMultiplexedConn{
+		Conn:          rawPeer1.conn,
+		sendStream:    &countingConn{Conn: bulk10Send},
+		receiveStream: bulk01Receive,
+	}
+
+	peer0 := Start(
+		rawPeer0.config,
+		conn0,
+		rawPeer1.cert,
+		rawPeer1.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer0.config.Metrics,
+			rawPeer1.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer0.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	peer1 := Start(
+		rawPeer1.config,
+		conn1,
+		rawPeer0.cert,
+		rawPeer0.nodeID,
+		NewThrottledMessageQueue(
+			rawPeer1.config.Metrics,
+			rawPeer0.nodeID,
+			logging.NoLog{},
+			throttling.NewNoOutboundThrottler(),
+			0,
+			rawPeer1.config.Metrics.BulkMessagesDropped,
+		),
+	)
+	require.NoError(peer0.AwaitReady(context.Background()))
+	require.NoError(peer1.AwaitReady(context.Background()))
+
+	// Nothing was sent over the bulk streams during the handshake.
+	require.Zero(atomic.LoadInt64(&conn0.sendStream.written))
+	require.Zero(atomic.LoadInt64(&conn1.sendStream.written))
+
+	mc := rawPeer0.config.GetMessageCreator()
+	chainID := ids.GenerateTestID()
+	ancestorsMsg, err := mc.Ancestors(chainID, 1, [][]byte{make([]byte, 1024)})
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), ancestorsMsg))
+
+	inboundMsg := <-rawPeer1.inboundMsgChan
+	require.Equal(message.Ancestors, inboundMsg.Op())
+	require.Positive(atomic.LoadInt64(&conn0.sendStream.written))
+
+	// Consensus messages are still sent over the main stream.
+	written := atomic.LoadInt64(&conn0.sendStream.written)
+	chitsMsg, err := mc.Chits(chainID, 2, []ids.ID{ids.GenerateTestID()})
+	require.NoError(err)
+	require.True(peer0.Send(context.Background(), chitsMsg))
+
+	inboundMsg = <-rawPeer1.inboundMsgChan
+	require.Equal(message.Chits, inboundMsg.Op())
+	require.Equal(written, atomic.LoadInt64(&conn0.sendStream.written))
+
+	peer0.StartClose()
+	require.NoError(peer0.AwaitClosed(context.Background()))
+	require.NoError(peer1.AwaitClosed(context.Background()))
+}
@@ -0,0 +1,54 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package peer
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+// SubnetSet is a thread-safe set of subnetIDs. It is used to hold the subnets
+// this node is tracking, which may grow while peers are reading it.
+type SubnetSet struct {
+	lock    sync.RWMutex
+	subnets ids.Set
+}
+
+// NewSubnetSet returns a new SubnetSet that initially contains a copy of
+// [subnetIDs].
+func NewSubnetSet(subnetIDs ids.Set) *SubnetSet {
+	s := &SubnetSet{}
+	s.subnets.Union(subnetIDs)
+	return s
+}
+
+// Add [subnetID] to the set. Returns true if [subnetID] wasn't already in the
+// set.
+func (s *SubnetSet) Add(subnetID ids.ID) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.subnets.Contains(subnetID) {
+		return false
+	}
+	s.subnets.Add(subnetID)
+	return true
+}
+
+// Contains returns true if [subnetID] is in the set.
+func (s *SubnetSet) Contains(subnetID ids.ID) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.subnets.Contains(subnetID)
+}
+
+// List returns the subnetIDs in the set.
+func (s *SubnetSet) List() []ids.ID {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.subnets.List()
+}
@@ -25,7 +25,8 @@ type testNetwork struct {
 	signer    crypto.Signer
 	subnets   ids.Set
 
-	uptime uint8
+	uptime       uint8
+	capabilities Capabilities
 }
 
 // NewTestNetwork creates and returns a new TestNetwork
@@ -51,6 +52,8 @@ func NewTestNetwork(
 
 func (n *testNetwork) Connected(ids.NodeID) {}
 
+func (n *testNetwork) ConnectedSubnet(ids.NodeID, ids.ID) {}
+
 func (n *testNetwork) AllowConnection(ids.NodeID) bool { return true }
 
 func (n *testNetwork) Track(ips.ClaimedIPPort) bool { return true }
@@ -75,6 +78,9 @@ func (n *testNetwork) Version() (message.OutboundMessage, error) {
 		now,
 		signedIP.Signature,
 		n.subnets.List(),
+		nil,
+		nil,
+		uint64(n.capabilities),
 	)
 }
 
@@ -82,6 +88,10 @@ func (n *testNetwork) Peers() (message.OutboundMessage, error) {
 	return n.mc.PeerList(nil, true)
 }
 
+func (n *testNetwork) ValidatorPeers([]ids.NodeID) (message.OutboundMessage, error) {
+	return n.mc.PeerList(nil, false)
+}
+
 func (n *testNetwork) Pong(ids.NodeID) (message.OutboundMessage, error) {
 	return n.mc.Pong(n.uptime)
 }
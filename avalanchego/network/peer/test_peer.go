@@ -18,6 +18,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -59,7 +60,7 @@ func StartTestPeer(
 		return nil, err
 	}
 
-	tlsConfg := TLSConfig(*tlsCert, nil)
+	tlsConfg := TLSConfig(*tlsCert, nil, CertPolicy{})
 	clientUpgrader := NewTLSClientUpgrader(tlsConfg)
 
 	peerID, conn, cert, err := clientUpgrader.Upgrade(conn)
@@ -80,7 +81,7 @@ func StartTestPeer(
 	mcWithProto, err := message.NewCreatorWithProto(
 		prometheus.NewRegistry(),
 		"",
-		true,
+		compression.TypeGzip,
 		10*time.Second,
 	)
 	if err != nil {
@@ -124,7 +125,7 @@ func StartTestPeer(
 			),
 			Router:               router,
 			VersionCompatibility: version.GetCompatibility(networkID),
-			MySubnets:            ids.Set{},
+			MySubnets:            NewSubnetSet(nil),
 			Beacons:              validators.NewSet(),
 			NetworkID:            networkID,
 			PingFrequency:        constants.DefaultPingFrequency,
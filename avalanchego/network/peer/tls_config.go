@@ -13,7 +13,9 @@ import (
 //
 // It is safe, and typically expected, for [keyLogWriter] to be [nil].
 // [keyLogWriter] should only be enabled for debugging.
-func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
+//
+// Handshakes with peers whose certificate doesn't satisfy [certPolicy] fail.
+func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer, certPolicy CertPolicy) *tls.Config {
 	// #nosec G402
 	return &tls.Config{
 		Certificates: []tls.Certificate{cert},
@@ -27,5 +29,6 @@ func TLSConfig(cert tls.Certificate, keyLogWriter io.Writer) *tls.Config {
 		InsecureSkipVerify: true,
 		MinVersion:         tls.VersionTLS13,
 		KeyLogWriter:       keyLogWriter,
+		VerifyConnection:   certPolicy.verifyConnection,
 	}
 }
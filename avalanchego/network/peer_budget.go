@@ -0,0 +1,84 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// PeerBudgetConfig describes how connections to peers are shed as the node
+// approaches its socket budget, so that it stays connected to the validators.
+// Validators, beacons and manually tracked nodes are never shed.
+type PeerBudgetConfig struct {
+	// Number of peer connections the node has sockets for. 0 means there is no
+	// budget.
+	MaxPeerConns int `json:"maxPeerConns"`
+	// Share of [MaxPeerConns] above which connections to non-validators are
+	// refused and connected non-validators are disconnected. Should be in
+	// (0, 1].
+	NonValidatorShedThreshold float64 `json:"nonValidatorShedThreshold"`
+}
+
+// shedThreshold returns the number of peer connections above which
+// non-validators are shed, or false if there is no budget.
+func (n *network) shedThreshold() (int, bool) {
+	config := n.config.PeerBudgetConfig
+	if config.MaxPeerConns <= 0 {
+		return 0, false
+	}
+	return int(float64(config.MaxPeerConns) * config.NonValidatorShedThreshold), true
+}
+
+// numPeerConns returns the number of peer connections that aren't being
+// closed by shedNonValidators. Assumes [n.peersLock] is held.
+func (n *network) numPeerConns() int {
+	return n.connectingPeers.Len() + n.connectedPeers.Len() - n.shedPeers.Len()
+}
+
+// isPrioritized returns true if the connection to [nodeID] is kept under
+// socket pressure. Assumes [n.peersLock] is held.
+func (n *network) isPrioritized(nodeID ids.NodeID) bool {
+	return n.config.Validators.Contains(constants.PrimaryNetworkID, nodeID) ||
+		n.config.Beacons.Contains(nodeID) ||
+		n.manuallyTrackedIDs.Contains(nodeID)
+}
+
+// withinPeerBudget returns true if a new connection to [nodeID] may be
+// started. Assumes [n.peersLock] is held.
+func (n *network) withinPeerBudget(nodeID ids.NodeID) bool {
+	threshold, ok := n.shedThreshold()
+	return !ok || n.isPrioritized(nodeID) || n.numPeerConns() < threshold
+}
+
+// shedNonValidators disconnects connected non-validators until the number of
+// peer connections is back at the shed threshold. Assumes [n.peersLock] is
+// held.
+func (n *network) shedNonValidators() {
+	threshold, ok := n.shedThreshold()
+	if !ok {
+		return
+	}
+	excess := n.numPeerConns() - threshold
+	if excess <= 0 {
+		return
+	}
+
+	shed := n.connectedPeers.Sample(excess, func(p peer.Peer) bool {
+		nodeID := p.ID()
+		return !n.shedPeers.Contains(nodeID) && !n.isPrioritized(nodeID)
+	})
+	for _, p := range shed {
+		n.peerConfig.Log.Debug("disconnecting peer",
+			zap.String("reason", "socket budget reserved for validators"),
+			zap.Stringer("nodeID", p.ID()),
+		)
+		n.shedPeers.Add(p.ID())
+		n.metrics.nonValidatorsShed.Inc()
+		p.StartClose()
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+type budgetTestPeer struct {
+	peer.Peer
+	nodeID ids.NodeID
+	closed bool
+}
+
+func (p *budgetTestPeer) ID() ids.NodeID { return p.nodeID }
+
+func (p *budgetTestPeer) StartClose() { p.closed = true }
+
+func TestShedNonValidators(t *testing.T) {
+	require := require.New(t)
+
+	_, networks, wg := newFullyConnectedTestNetwork(t, []router.InboundHandler{nil})
+	defer func() {
+		for _, net := range networks {
+			net.StartClose()
+		}
+		wg.Wait()
+	}()
+
+	n := networks[0].(*network)
+	n.config.PeerBudgetConfig = PeerBudgetConfig{
+		MaxPeerConns:              8,
+		NonValidatorShedThreshold: .5,
+	}
+
+	validator := &budgetTestPeer{nodeID: ids.GenerateTestNodeID()}
+	require.NoError(n.config.Validators.AddWeight(constants.PrimaryNetworkID, validator.nodeID, 1))
+	manuallyTracked := &budgetTestPeer{nodeID: ids.GenerateTestNodeID()}
+	nonValidators := []*budgetTestPeer{
+		{nodeID: ids.GenerateTestNodeID()},
+		{nodeID: ids.GenerateTestNodeID()},
+		{nodeID: ids.GenerateTestNodeID()},
+	}
+
+	n.peersLock.Lock()
+	defer n.peersLock.Unlock()
+
+	n.manuallyTrackedIDs.Add(manuallyTracked.nodeID)
+	n.connectedPeers.Add(validator)
+	n.connectedPeers.Add(manuallyTracked)
+	n.connectedPeers.Add(nonValidators[0])
+	defer func() {
+		// The test peers can't be closed by the network.
+		n.connectedPeers.Remove(validator.nodeID)
+		n.connectedPeers.Remove(manuallyTracked.nodeID)
+		for _, p := range nonValidators {
+			n.connectedPeers.Remove(p.nodeID)
+		}
+	}()
+
+	// Below the threshold, non-validators are still accepted.
+	n.shedNonValidators()
+	require.False(nonValidators[0].closed)
+	require.True(n.withinPeerBudget(nonValidators[1].nodeID))
+	n.connectedPeers.Add(nonValidators[1])
+
+	// At the threshold, only prioritized nodes are accepted.
+	require.False(n.withinPeerBudget(nonValidators[2].nodeID))
+	require.True(n.withinPeerBudget(validator.nodeID))
+	n.connectedPeers.Add(nonValidators[2])
+
+	// Above the threshold, a single non-validator is shed.
+	n.shedNonValidators()
+	require.False(validator.closed)
+	require.False(manuallyTracked.closed)
+	numClosed := 0
+	for _, p := range nonValidators {
+		if p.closed {
+			numClosed++
+		}
+	}
+	require.Equal(1, numClosed)
+	require.Equal(1, n.shedPeers.Len())
+
+	// Peers that are being shed aren't shed again.
+	n.shedNonValidators()
+	require.Equal(1, n.shedPeers.Len())
+}
@@ -0,0 +1,312 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/quic-go/quic-go"
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by QUIC connections
+	// between nodes.
+	quicALPN = "avalanche-p2p"
+
+	// quicStreamTimeout is the amount of time a dialer has to start the
+	// stream that messages are exchanged over after the QUIC handshake.
+	quicStreamTimeout = 10 * time.Second
+
+	// maxPendingQUICConns is the maximum number of inbound QUIC connections
+	// that may be waiting for their stream to be started at once. Further
+	// connections are queued, and eventually refused, by the QUIC listener.
+	maxPendingQUICConns = 64
+)
+
+var (
+	errNoQUICCert = errors.New("quic handshake finished with no peer certificate")
+
+	_ Transport            = &quicTransport{}
+	_ peer.Upgrader        = &quicUpgrader{}
+	_ net.Listener         = &quicListener{}
+	_ peer.MultiplexedConn = &quicConn{}
+
+	// quicConfig is the configuration of every QUIC connection between nodes.
+	// Messages are exchanged over a bidirectional stream that is opened by the
+	// dialer, except for bulk messages, which each node sends over a
+	// unidirectional stream that it opens.
+	quicConfig = &quic.Config{
+		MaxIncomingStreams:    1,
+		MaxIncomingUniStreams: 1,
+		// Peers ping each other less frequently than the default idle timeout,
+		// so the connection is kept alive by QUIC itself.
+		KeepAlivePeriod: 10 * time.Second,
+	}
+)
+
+// quicTLSConfig returns a copy of [config] that negotiates [quicALPN].
+func quicTLSConfig(config *tls.Config) *tls.Config {
+	config = config.Clone()
+	config.NextProtos = []string{quicALPN}
+	return config
+}
+
+// quicConn is a QUIC connection along with the stream that messages are
+// exchanged over. It is closed once the connection is closed.
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+
+	// closer, if set, closes the connection in place of [conn]. It allows the
+	// wrappers of the connection that was upgraded, such as the gater, to
+	// release their resources.
+	closer io.Closer
+}
+
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+func (c *quicConn) Close() error {
+	if c.closer != nil {
+		return c.closer.Close()
+	}
+	return c.conn.CloseWithError(0, "")
+}
+
+func (c *quicConn) OpenSendStream(ctx context.Context) (peer.SendStream, error) {
+	stream, err := c.conn.OpenUniStreamSync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+func (c *quicConn) AcceptReceiveStream(ctx context.Context) (peer.ReceiveStream, error) {
+	stream, err := c.conn.AcceptUniStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return stream, nil
+}
+
+// quicConnOf returns the QUIC connection that [conn] wraps, if any.
+func quicConnOf(conn net.Conn) (*quicConn, bool) {
+	for {
+		switch c := conn.(type) {
+		case *quicConn:
+			return c, true
+		case interface{ NetConn() net.Conn }:
+			conn = c.NetConn()
+		default:
+			return nil, false
+		}
+	}
+}
+
+type quicListener struct {
+	listener *quic.Listener
+	accepted chan acceptResult
+	pending  chan struct{}
+
+	closeOnce sync.Once
+	closed    chan struct{}
+}
+
+// NewQUICListener returns a listener that accepts QUIC connections on the UDP
+// [address]. The connections are authenticated with [config] before they are
+// accepted, and must be upgraded by a transport returned by NewQUICTransport.
+func NewQUICListener(address string, config *tls.Config) (net.Listener, error) {
+	listener, err := quic.ListenAddr(address, quicTLSConfig(config), quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	l := &quicListener{
+		listener: listener,
+		accepted: make(chan acceptResult),
+		pending:  make(chan struct{}, maxPendingQUICConns),
+		closed:   make(chan struct{}),
+	}
+	go l.accept()
+	return l, nil
+}
+
+// accept waits for the stream of each accepted connection in a separate
+// goroutine, so that a dialer that doesn't start its stream doesn't delay the
+// other connections.
+func (l *quicListener) accept() {
+	for {
+		select {
+		case l.pending <- struct{}{}:
+		case <-l.closed:
+			return
+		}
+
+		conn, err := l.listener.Accept(context.Background())
+		if err != nil {
+			<-l.pending
+			select {
+			case l.accepted <- acceptResult{err: err}:
+			case <-l.closed:
+			}
+			return
+		}
+
+		go func() {
+			defer func() { <-l.pending }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), quicStreamTimeout)
+			stream, err := conn.AcceptStream(ctx)
+			cancel()
+			if err != nil {
+				_ = conn.CloseWithError(0, "")
+				return
+			}
+
+			select {
+			case l.accepted <- acceptResult{conn: &quicConn{Stream: stream, conn: conn}}:
+			case <-l.closed:
+				_ = conn.CloseWithError(0, "")
+			}
+		}()
+	}
+}
+
+func (l *quicListener) Accept() (net.Conn, error) {
+	select {
+	case result := <-l.accepted:
+		return result.conn, result.err
+	case <-l.closed:
+		return nil, errListenerClosed
+	}
+}
+
+func (l *quicListener) Close() error {
+	var err error
+	l.closeOnce.Do(func() {
+		close(l.closed)
+		err = l.listener.Close()
+	})
+	return err
+}
+
+func (l *quicListener) Addr() net.Addr {
+	return l.listener.Addr()
+}
+
+type quicTransport struct {
+	Transport
+
+	log            logging.Logger
+	tlsConfig      *tls.Config
+	serverUpgrader peer.Upgrader
+	clientUpgrader peer.Upgrader
+}
+
+// NewQUICTransport returns a transport that advertises [peer.CapabilityQUIC]
+// and dials the peers that advertise it over QUIC. All other peers, and the
+// peers that can't be reached over QUIC, are dialed with [transport]. Because
+// capabilities are learned during the handshake, the first connection to a
+// peer is always made with [transport].
+//
+// Inbound QUIC connections must be accepted by a listener returned by
+// NewQUICListener that [transport] accepts the connections of.
+func NewQUICTransport(transport Transport, config *tls.Config, log logging.Logger) Transport {
+	return &quicTransport{
+		Transport: transport,
+		log:       log,
+		tlsConfig: quicTLSConfig(config),
+		serverUpgrader: &quicUpgrader{
+			upgrader: transport.ServerUpgrader(),
+		},
+		clientUpgrader: &quicUpgrader{
+			upgrader: transport.ClientUpgrader(),
+		},
+	}
+}
+
+func (t *quicTransport) Capabilities() peer.Capabilities {
+	return t.Transport.Capabilities() | peer.CapabilityQUIC
+}
+
+func (t *quicTransport) Dial(ctx context.Context, ip ips.IPPort, peerCapabilities peer.Capabilities) (net.Conn, error) {
+	if !peerCapabilities.Contains(peer.CapabilityQUIC) {
+		return t.Transport.Dial(ctx, ip, peerCapabilities)
+	}
+
+	conn, err := t.dialQUIC(ctx, ip)
+	if err == nil {
+		return conn, nil
+	}
+	t.log.Verbo("failed to reach peer over QUIC, falling back",
+		zap.Stringer("peerIP", ip),
+		zap.Error(err),
+	)
+	return t.Transport.Dial(ctx, ip, peerCapabilities)
+}
+
+func (t *quicTransport) dialQUIC(ctx context.Context, ip ips.IPPort) (net.Conn, error) {
+	conn, err := quic.DialAddr(ctx, ip.String(), t.tlsConfig, quicConfig)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := conn.OpenStreamSync(ctx)
+	if err != nil {
+		_ = conn.CloseWithError(0, "")
+		return nil, err
+	}
+	return &quicConn{
+		Stream: stream,
+		conn:   conn,
+	}, nil
+}
+
+func (t *quicTransport) ServerUpgrader() peer.Upgrader {
+	return t.serverUpgrader
+}
+
+func (t *quicTransport) ClientUpgrader() peer.Upgrader {
+	return t.clientUpgrader
+}
+
+// quicUpgrader authenticates QUIC connections by the certificate the peer
+// presented during the QUIC handshake, and all other connections with
+// [upgrader].
+type quicUpgrader struct {
+	upgrader peer.Upgrader
+}
+
+func (u *quicUpgrader) Upgrade(conn net.Conn) (ids.NodeID, net.Conn, *x509.Certificate, error) {
+	qc, ok := quicConnOf(conn)
+	if !ok {
+		return u.upgrader.Upgrade(conn)
+	}
+
+	state := qc.conn.ConnectionState().TLS
+	if len(state.PeerCertificates) == 0 {
+		return ids.NodeID{}, nil, nil, errNoQUICCert
+	}
+	peerCert := state.PeerCertificates[0]
+
+	upgraded := *qc
+	upgraded.closer = conn
+	return ids.NodeIDFromCert(peerCert), &upgraded, peerCert, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/ips"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+func newTestQUICTransport(t *testing.T) (Transport, ids.NodeID, ips.IPPort) {
+	t.Helper()
+	require := require.New(t)
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(err)
+	tlsConfig := peer.TLSConfig(*cert, nil, peer.CertPolicy{})
+
+	tcpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	quicListener, err := NewQUICListener(tcpListener.Addr().String(), tlsConfig)
+	require.NoError(err)
+
+	ip, err := ips.ToIPPort(tcpListener.Addr().String())
+	require.NoError(err)
+
+	transport := NewQUICTransport(
+		NewTLSTransport(
+			NewMultiListener(tcpListener, quicListener),
+			dialer.NewDialer("tcp", dialer.Config{ConnectionTimeout: time.Second}, logging.NoLog{}),
+			tlsConfig,
+		),
+		tlsConfig,
+		logging.NoLog{},
+	)
+	t.Cleanup(func() {
+		_ = transport.Close()
+	})
+	return transport, ids.NodeIDFromCert(cert.Leaf), ip
+}
+
+func TestQUICTransport(t *testing.T) {
+	tests := []struct {
+		name             string
+		peerCapabilities peer.Capabilities
+		expectedQUIC     bool
+	}{
+		{
+			name:             "peer supports QUIC",
+			peerCapabilities: peer.CapabilityQUIC,
+			expectedQUIC:     true,
+		},
+		{
+			name:             "peer doesn't support QUIC",
+			peerCapabilities: 0,
+			expectedQUIC:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			server, serverID, serverIP := newTestQUICTransport(t)
+			client, clientID, _ := newTestQUICTransport(t)
+			require.True(server.Capabilities().Contains(peer.CapabilityQUIC))
+
+			dialed, err := client.Dial(context.Background(), serverIP, test.peerCapabilities)
+			require.NoError(err)
+			_, isQUIC := quicConnOf(dialed)
+			require.Equal(test.expectedQUIC, isQUIC)
+
+			type upgradeResult struct {
+				nodeID ids.NodeID
+				conn   net.Conn
+				err    error
+			}
+			// The stream of a QUIC connection is only accepted once the dialer
+			// writes to it, so the client writes right after the upgrade.
+			clientUpgraded := make(chan upgradeResult, 1)
+			go func() {
+				nodeID, conn, _, err := client.ClientUpgrader().Upgrade(dialed)
+				if err == nil {
+					_, err = conn.Write([]byte("ping"))
+				}
+				clientUpgraded <- upgradeResult{nodeID: nodeID, conn: conn, err: err}
+			}()
+
+			accepted, err := server.Accept()
+			require.NoError(err)
+			_, isQUIC = quicConnOf(accepted)
+			require.Equal(test.expectedQUIC, isQUIC)
+
+			nodeID, serverConn, _, err := server.ServerUpgrader().Upgrade(accepted)
+			require.NoError(err)
+			require.Equal(clientID, nodeID)
+
+			result := <-clientUpgraded
+			require.NoError(result.err)
+			require.Equal(serverID, result.nodeID)
+			clientConn := result.conn
+
+			buf := make([]byte, 4)
+			_, err = io.ReadFull(serverConn, buf)
+			require.NoError(err)
+			require.Equal([]byte("ping"), buf)
+
+			_, err = serverConn.Write([]byte("pong"))
+			require.NoError(err)
+			_, err = io.ReadFull(clientConn, buf)
+			require.NoError(err)
+			require.Equal([]byte("pong"), buf)
+
+			require.NoError(clientConn.Close())
+			require.NoError(serverConn.Close())
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 func init() { rand.Seed(time.Now().UnixNano()) }
@@ -18,6 +19,15 @@ type trackedIP struct {
 	delay     time.Duration
 
 	ip *peer.UnsignedIP
+	// The IP that is dialed next is [ip.IP] if [dialIndex] is 0, and
+	// [ip.AdditionalIPs][dialIndex-1] otherwise. Only accessed by the dialing
+	// goroutine.
+	dialIndex int
+
+	// capabilities the peer advertised the last time it was connected, which
+	// select the transport that it is dialed over. Peers that were never
+	// connected are dialed over TCP.
+	capabilities peer.Capabilities
 
 	stopTrackingOnce sync.Once
 	onStopTracking   chan struct{}
@@ -35,10 +45,26 @@ func (ip *trackedIP) trackNewIP(newIP *peer.UnsignedIP) *trackedIP {
 	return &trackedIP{
 		delay:          ip.getDelay(),
 		ip:             newIP,
+		capabilities:   ip.capabilities,
 		onStopTracking: make(chan struct{}),
 	}
 }
 
+// dialIP returns the IP that the next connection attempt is made to.
+func (ip *trackedIP) dialIP() ips.IPPort {
+	if ip.dialIndex == 0 {
+		return ip.ip.IP
+	}
+	return ip.ip.AdditionalIPs[ip.dialIndex-1]
+}
+
+// rotateIP makes the next connection attempt dial the next IP claimed by the
+// peer, so that an unreachable IP doesn't prevent connecting to a peer that is
+// reachable at another address family.
+func (ip *trackedIP) rotateIP() {
+	ip.dialIndex = (ip.dialIndex + 1) % (len(ip.ip.AdditionalIPs) + 1)
+}
+
 func (ip *trackedIP) getDelay() time.Duration {
 	ip.delayLock.RLock()
 	delay := ip.delay
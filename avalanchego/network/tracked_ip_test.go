@@ -4,10 +4,14 @@
 package network
 
 import (
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/ips"
 )
 
 func TestTrackedIP(t *testing.T) {
@@ -46,3 +50,21 @@ func TestTrackedIP(t *testing.T) {
 	ip.stopTracking()
 	<-ip.onStopTracking
 }
+
+func TestTrackedIPRotateIP(t *testing.T) {
+	require := require.New(t)
+
+	ip := newTrackedIP(&peer.UnsignedIP{
+		IP: ips.IPPort{IP: net.IPv4(1, 2, 3, 4), Port: 9651},
+		AdditionalIPs: []ips.IPPort{
+			{IP: net.ParseIP("2001:db8::1"), Port: 9651},
+		},
+	})
+	require.Equal(ip.ip.IP, ip.dialIP())
+
+	ip.rotateIP()
+	require.Equal(ip.ip.AdditionalIPs[0], ip.dialIP())
+
+	ip.rotateIP()
+	require.Equal(ip.ip.IP, ip.dialIP())
+}
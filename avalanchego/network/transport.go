@@ -0,0 +1,86 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+
+	"github.com/ava-labs/avalanchego/network/dialer"
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/utils/ips"
+)
+
+var _ Transport = &tlsTransport{}
+
+// Transport establishes the authenticated connections that peers exchange
+// messages over. The network only depends on this interface, so that it can
+// run over transports other than TCP and TLS.
+type Transport interface {
+	// Accept waits for and returns the next inbound connection. It returns an
+	// error once the transport is closed.
+	Accept() (net.Conn, error)
+
+	// Capabilities returns the optional transports, advertised to peers during
+	// the handshake, that this transport is able to accept connections over.
+	Capabilities() peer.Capabilities
+
+	// Dial opens an outbound connection to [ip]. [peerCapabilities] are the
+	// capabilities the peer last advertised, which may select a transport
+	// other than TCP.
+	Dial(ctx context.Context, ip ips.IPPort, peerCapabilities peer.Capabilities) (net.Conn, error)
+
+	// ServerUpgrader authenticates the connections returned by Accept.
+	ServerUpgrader() peer.Upgrader
+
+	// ClientUpgrader authenticates the connections returned by Dial.
+	ClientUpgrader() peer.Upgrader
+
+	// Close stops accepting inbound connections.
+	Close() error
+}
+
+type tlsTransport struct {
+	listener       net.Listener
+	dialer         dialer.Dialer
+	serverUpgrader peer.Upgrader
+	clientUpgrader peer.Upgrader
+}
+
+// NewTLSTransport returns a transport that accepts the connections of
+// [listener], makes connections with [dialer] and authenticates both with a
+// TLS handshake using [config].
+func NewTLSTransport(listener net.Listener, dialer dialer.Dialer, config *tls.Config) Transport {
+	return &tlsTransport{
+		listener:       listener,
+		dialer:         dialer,
+		serverUpgrader: peer.NewTLSServerUpgrader(config),
+		clientUpgrader: peer.NewTLSClientUpgrader(config),
+	}
+}
+
+func (t *tlsTransport) Accept() (net.Conn, error) {
+	return t.listener.Accept()
+}
+
+func (*tlsTransport) Capabilities() peer.Capabilities {
+	return 0
+}
+
+func (t *tlsTransport) Dial(ctx context.Context, ip ips.IPPort, _ peer.Capabilities) (net.Conn, error) {
+	return t.dialer.Dial(ctx, ip)
+}
+
+func (t *tlsTransport) ServerUpgrader() peer.Upgrader {
+	return t.serverUpgrader
+}
+
+func (t *tlsTransport) ClientUpgrader() peer.Upgrader {
+	return t.clientUpgrader
+}
+
+func (t *tlsTransport) Close() error {
+	return t.listener.Close()
+}
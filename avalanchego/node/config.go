@@ -5,8 +5,12 @@ package node
 
 import (
 	"crypto/tls"
+	"os"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/auth"
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/genesis"
 	"github.com/ava-labs/avalanchego/ids"
@@ -14,16 +18,23 @@ import (
 	"github.com/ava-labs/avalanchego/network"
 	"github.com/ava-labs/avalanchego/snow/consensus/avalanche"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/dynamicip"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/notifier"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/vms"
+
+	platformconfig "github.com/ava-labs/avalanchego/vms/platformvm/config"
 )
 
 type IPCConfig struct {
@@ -35,17 +46,27 @@ type IPCConfig struct {
 type APIAuthConfig struct {
 	APIRequireAuthToken bool   `json:"apiRequireAuthToken"`
 	APIAuthPassword     string `json:"-"`
+	// APIs granted by these scopes may be called without a token
+	APIAuthPublicScopes []auth.Scope `json:"apiAuthPublicScopes"`
 }
 
 type APIIndexerConfig struct {
 	IndexAPIEnabled      bool `json:"indexAPIEnabled"`
 	IndexAllowIncomplete bool `json:"indexAllowIncomplete"`
+	// True if the P-chain should index the balance and stake of every address
+	// by height
+	PlatformHistoryIndexEnabled bool `json:"platformHistoryIndexEnabled"`
 }
 
 type HTTPConfig struct {
 	APIConfig `json:"apiConfig"`
 	HTTPHost  string `json:"httpHost"`
 	HTTPPort  uint16 `json:"httpPort"`
+	// False if the HTTP server only listens on [HTTPSocketPath]
+	HTTPTCPEnabled bool `json:"httpTCPEnabled"`
+	// If non-empty, path of a Unix socket that the HTTP server listens on
+	HTTPSocketPath  string      `json:"httpSocketPath"`
+	HTTPSocketPerms os.FileMode `json:"httpSocketPerms"`
 
 	HTTPSEnabled bool   `json:"httpsEnabled"`
 	HTTPSKey     []byte `json:"-"`
@@ -55,6 +76,10 @@ type HTTPConfig struct {
 
 	ShutdownTimeout time.Duration `json:"shutdownTimeout"`
 	ShutdownWait    time.Duration `json:"shutdownWait"`
+
+	RateLimiterConfig server.RateLimiterConfig `json:"rateLimiterConfig"`
+	// Calls forwarded to an archive node, keyed by chainID or chain alias
+	ArchiveProxyConfigs map[string]server.ArchiveProxyConfig `json:"archiveProxyConfigs"`
 }
 
 type APIConfig struct {
@@ -66,34 +91,60 @@ type APIConfig struct {
 	AdminAPIEnabled    bool `json:"adminAPIEnabled"`
 	InfoAPIEnabled     bool `json:"infoAPIEnabled"`
 	KeystoreAPIEnabled bool `json:"keystoreAPIEnabled"`
-	MetricsAPIEnabled  bool `json:"metricsAPIEnabled"`
-	HealthAPIEnabled   bool `json:"healthAPIEnabled"`
+	// If non-empty, path of the Unix socket serving the signer
+	SignerSocketPath   string `json:"signerSocketPath"`
+	MetricsAPIEnabled  bool   `json:"metricsAPIEnabled"`
+	HealthAPIEnabled   bool   `json:"healthAPIEnabled"`
+	TransferAPIEnabled bool   `json:"transferAPIEnabled"`
+	// False if the APIs of the chains aren't exposed
+	ChainAPIsEnabled bool `json:"chainAPIsEnabled"`
+
+	// CIDR range -> labels attached to peers in that range by the Info API
+	InfoAPIPeerEnrichment map[string]map[string]string `json:"infoAPIPeerEnrichment"`
 }
 
 type IPConfig struct {
 	IPPort           ips.DynamicIPPort `json:"ip"`
 	IPUpdater        dynamicip.Updater `json:"-"`
 	IPResolutionFreq time.Duration     `json:"ipResolutionFrequency"`
+	// Other IPs that are advertised to peers along with [IPPort]
+	AdditionalIPs []ips.IPPort `json:"additionalIPs"`
+	// Addresses the staking server listens on. If empty, it listens on all
+	// interfaces at the port of [IPPort].
+	ListenAddresses []string `json:"listenAddresses"`
 	// True if we attempted NAT traversal
 	AttemptedNATTraversal bool `json:"attemptedNATTraversal"`
 	// Tries to perform network address translation
 	Nat nat.Router `json:"-"`
+	// True if NAT mappings should be verified by dialing our public IP
+	NATReachabilityCheckEnabled bool `json:"natReachabilityCheckEnabled"`
+	// Maintains the NAT mappings. It is reported in the nat health check if
+	// set.
+	PortMapper *nat.Mapper `json:"-"`
 }
 
 type StakingConfig struct {
 	genesis.StakingConfig
 	EnableStaking         bool            `json:"enableStaking"`
 	StakingTLSCert        tls.Certificate `json:"-"`
-	StakingSigningKey     *bls.SecretKey  `json:"-"`
+	StakingSigner         bls.Signer      `json:"-"`
 	DisabledStakingWeight uint64          `json:"disabledStakingWeight"`
 	StakingKeyPath        string          `json:"stakingKeyPath"`
 	StakingCertPath       string          `json:"stakingCertPath"`
 	StakingSignerPath     string          `json:"stakingSignerPath"`
+	// If non-empty, the staking keys are held by the signer at this address
+	StakingRemoteSignerAddress string `json:"stakingRemoteSignerAddress"`
+	// Overrides of the network specific P-chain parameters, keyed by network
+	// ID
+	NetworkParams map[uint32]platformconfig.NetworkParams `json:"networkParams"`
 }
 
 type StateSyncConfig struct {
 	StateSyncIDs []ids.NodeID `json:"stateSyncIDs"`
 	StateSyncIPs []ips.IPPort `json:"stateSyncIPs"`
+
+	// Limits on the state summaries and blocks served to syncing peers
+	StateSyncServingConfig syncserving.Config `json:"stateSyncServingConfig"`
 }
 
 type BootstrapConfig struct {
@@ -119,6 +170,24 @@ type BootstrapConfig struct {
 
 	BootstrapIDs []ids.NodeID `json:"bootstrapIDs"`
 	BootstrapIPs []ips.IPPort `json:"bootstrapIPs"`
+
+	// Chain alias or ID -> checkpoint that the block fetched at its height
+	// must match while bootstrapping
+	TrustedCheckpoints map[string]genesis.Checkpoint `json:"trustedCheckpoints"`
+
+	// Aliases or IDs of the chains in the order they start bootstrapping
+	BootstrapOrder []string `json:"bootstrapOrder"`
+
+	// Max number of chains, excluding the P-chain, that bootstrap at once. If
+	// 0, the number of chains isn't limited.
+	BootstrapMaxConcurrentChains int `json:"bootstrapMaxConcurrentChains"`
+
+	// Bytes per second of containers fetched by all the bootstrapping chains.
+	// If 0, the bandwidth isn't limited.
+	BootstrapMaxBandwidth uint64 `json:"bootstrapMaxBandwidth"`
+
+	// Chain alias or ID -> relative share of [BootstrapMaxBandwidth]
+	BootstrapBandwidthShares map[string]float64 `json:"bootstrapBandwidthShares"`
 }
 
 type DatabaseConfig struct {
@@ -150,26 +219,50 @@ type Config struct {
 	NetworkID uint32 `json:"networkID"`
 
 	// Health
-	HealthCheckFreq time.Duration `json:"healthCheckFreq"`
+	HealthCheckFreq    time.Duration `json:"healthCheckFreq"`
+	HealthChecksConfig health.Config `json:"healthChecksConfig"`
 
 	// Network configuration
 	NetworkConfig network.Config `json:"networkConfig"`
 
 	GossipConfig sender.GossipConfig `json:"gossipConfig"`
 
+	// MaxOutstandingRequestsPerPeer is the number of requests of a chain that
+	// may wait for a response from a single peer. If 0, the requests aren't
+	// limited.
+	MaxOutstandingRequestsPerPeer int `json:"maxOutstandingRequestsPerPeer"`
+
 	AdaptiveTimeoutConfig timer.AdaptiveTimeoutConfig `json:"adaptiveTimeoutConfig"`
 
+	// ChainAdaptiveTimeoutConfigs overrides [AdaptiveTimeoutConfig] for
+	// specific chains. Keyed by chainID or chain alias.
+	ChainAdaptiveTimeoutConfigs map[string]timer.AdaptiveTimeoutConfig `json:"chainAdaptiveTimeoutConfigs"`
+
 	// Benchlist Configuration
 	BenchlistConfig benchlist.Config `json:"benchlistConfig"`
 
 	// Profiling configurations
 	ProfilerConfig profiler.Config `json:"profilerConfig"`
 
+	// Tracing configuration
+	TraceConfig trace.Config `json:"traceConfig"`
+
+	// Configuration of the notifications about significant events
+	NotifierConfig notifier.Config `json:"notifierConfig"`
+
+	// Configuration of the deletion of old P-chain data
+	PlatformPruningConfig platformconfig.PruningConfig `json:"platformPruningConfig"`
+
 	// Logging configuration
 	LoggingConfig logging.Config `json:"loggingConfig"`
 
 	// Plugin directory
 	PluginDir string `json:"pluginDir"`
+	// If true, the C-chain runs the EVM plugin from [PluginDir] instead of the
+	// EVM built into the node
+	CorethPluginEnabled bool `json:"corethPluginEnabled"`
+	// Number of times a VM plugin process that exits unexpectedly is restarted
+	PluginMaxRestarts int `json:"pluginMaxRestarts"`
 
 	// File Descriptor Limit
 	FdLimit uint64 `json:"fdLimit"`
@@ -180,12 +273,25 @@ type Config struct {
 	// Metrics
 	MeterVMEnabled bool `json:"meterVMEnabled"`
 
+	// Number of times the time spent repairing each height of the proposervm
+	// height index that the repair sleeps for
+	ProposerVMHeightIndexRepairThrottle uint64 `json:"proposerVMHeightIndexRepairThrottle"`
+
 	// Router that is used to handle incoming consensus messages
 	ConsensusRouter          router.Router       `json:"-"`
 	RouterHealthConfig       router.HealthConfig `json:"routerHealthConfig"`
 	ConsensusShutdownTimeout time.Duration       `json:"consensusShutdownTimeout"`
 	// Gossip a container in the accepted frontier every [ConsensusGossipFrequency]
 	ConsensusGossipFrequency time.Duration `json:"consensusGossipFreq"`
+	// Don't gossip an accepted container that was received via gossip in the
+	// last [GossipedContainerTTL]. If 0, every accepted container is gossiped.
+	GossipedContainerTTL time.Duration `json:"gossipedContainerTTL"`
+	// Bounds of each chain's inbound message queues
+	MessageQueueConfig handler.MessageQueueConfig `json:"messageQueueConfig"`
+	// MessageQueueConfig overrides keyed by chainID or chain alias
+	ChainMessageQueueConfigs map[string]handler.MessageQueueConfig `json:"chainMessageQueueConfigs"`
+	// Capture of the inbound consensus messages for debugging
+	MessageCaptureConfig router.CaptureConfig `json:"messageCaptureConfig"`
 
 	// Subnet Whitelist
 	WhitelistedSubnets ids.Set `json:"whitelistedSubnets"`
@@ -220,6 +326,5 @@ type Config struct {
 
 	DiskTargeterConfig tracker.TargeterConfig `json:"diskTargeterConfig"`
 
-	RequiredAvailableDiskSpace         uint64 `json:"requiredAvailableDiskSpace"`
-	WarningThresholdAvailableDiskSpace uint64 `json:"warningThresholdAvailableDiskSpace"`
+	DiskWatchdogConfig diskwatchdog.Config `json:"diskWatchdogConfig"`
 }
@@ -11,6 +11,8 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/ava-labs/avalanchego/api/keystore"
 	"github.com/ava-labs/avalanchego/api/metrics"
 	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/api/transfer"
 	"github.com/ava-labs/avalanchego/chains"
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/database"
@@ -51,21 +54,28 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/diskwatchdog"
 	"github.com/ava-labs/avalanchego/utils/filesystem"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/ips"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/maintenance"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/notifier"
 	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ava-labs/avalanchego/utils/resource"
+	"github.com/ava-labs/avalanchego/utils/storage"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
@@ -74,19 +84,27 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/propertyfx"
 	"github.com/ava-labs/avalanchego/vms/registry"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	ipcsapi "github.com/ava-labs/avalanchego/api/ipcs"
+	apisigner "github.com/ava-labs/avalanchego/api/signer"
 )
 
 var (
 	genesisHashKey  = []byte("genesisID")
 	indexerDBPrefix = []byte{0x00}
-
-	errInvalidTLSKey = errors.New("invalid TLS key")
-	errShuttingDown  = errors.New("server shutting down")
+	// transferDBPrefix is the prefix of the transfer API's progress records
+	transferDBPrefix = []byte("transfer")
+	// benchlistDBPrefix is the prefix of the benched validators of every
+	// chain
+	benchlistDBPrefix = []byte("benchlist")
+
+	errInvalidTLSKey        = errors.New("invalid TLS key")
+	errShuttingDown         = errors.New("server shutting down")
+	errCorethPluginNotFound = errors.New("coreth plugin not found")
 )
 
 // Node is an instance of an Avalanche node.
@@ -98,6 +116,9 @@ type Node struct {
 	// (in consensus, for example)
 	ID ids.NodeID
 
+	// Proof of possession of this node's BLS key
+	pop *signer.ProofOfPossession
+
 	// Storage for this node
 	DBManager manager.Manager
 	DB        database.Database
@@ -105,12 +126,39 @@ type Node struct {
 	// Profiles the process. Nil if continuous profiling is disabled.
 	profiler profiler.ContinuousProfiler
 
+	// Starts the spans of the messages handled by the chains
+	tracer trace.Tracer
+
 	// Indexes blocks, transactions and blocks
 	indexer indexer.Indexer
 
+	// Progressively restricts the node as the disk fills up
+	diskWatchdog *diskwatchdog.Watchdog
+
+	// Stops the chains from starting consensus polls while the node is in
+	// maintenance
+	queryGate *snow.QueryGate
+
+	// Drains the node before it's stopped
+	maintenance *maintenance.Mode
+
 	// Handles calls to Keystore API
 	keystore keystore.Keystore
 
+	// Signs with the keys of the keystore over a local socket. Nil if the
+	// signer socket is disabled.
+	signer *apisigner.Server
+
+	// Records the inbound consensus messages. Nil if the capture is disabled.
+	messageCapture *router.MessageCapture
+
+	// Records when the peers last queried this node. Nil if the notifier is
+	// disabled.
+	queryTracker *router.QueryTracker
+
+	// Notifies the operator of significant node events. Nil if disabled.
+	notifier *notifier.Notifier
+
 	// Manages shared memory
 	sharedMemory *atomic.Memory
 
@@ -151,6 +199,10 @@ type Node struct {
 
 	// Handles HTTP API calls
 	APIServer server.Server
+	// Rejects API calls that issue txs while the node is low on disk space
+	apiTxGate *server.TxGate
+	// Counts the API calls being served
+	apiInFlight *server.InFlight
 
 	// This node's configuration
 	Config *Config
@@ -180,6 +232,9 @@ type Node struct {
 	// Tracks the CPU/disk usage caused by processing
 	// messages of each peer.
 	resourceTracker tracker.ResourceTracker
+	// Tracks the CPU usage caused by processing
+	// messages of each chain.
+	chainTracker tracker.ChainTracker
 
 	// Specifies how much CPU usage each peer can cause before
 	// we rate-limit them.
@@ -200,14 +255,23 @@ type Node struct {
 // Assumes [n.CPUTracker] and [n.CPUTargeter] have been initialized.
 func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	currentIPPort := n.Config.IPPort.IPPort()
-	listener, err := net.Listen(constants.NetworkType, fmt.Sprintf(":%d", currentIPPort.Port))
-	if err != nil {
-		return err
+	listenAddresses := n.Config.ListenAddresses
+	if len(listenAddresses) == 0 {
+		listenAddresses = []string{fmt.Sprintf(":%d", currentIPPort.Port)}
+	}
+	listeners := make([]net.Listener, 0, len(listenAddresses))
+	for _, address := range listenAddresses {
+		listener, err := net.Listen(constants.NetworkType, address)
+		if err != nil {
+			for _, listener := range listeners {
+				_ = listener.Close()
+			}
+			return err
+		}
+		listeners = append(listeners, listener)
 	}
-	// Wrap listener so it will only accept a certain number of incoming connections per second
-	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
-	ipPort, err := ips.ToIPPort(listener.Addr().String())
+	ipPort, err := ips.ToIPPort(listeners[0].Addr().String())
 	if err != nil {
 		n.Log.Info("initializing networking",
 			zap.Stringer("currentNodeIP", currentIPPort),
@@ -237,17 +301,54 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		)
 	}
 
-	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser)
+	tlsConfig := peer.TLSConfig(n.Config.StakingTLSCert, n.tlsKeyLogWriterCloser, n.Config.NetworkConfig.PeerCertPolicy)
+
+	if n.Config.NetworkConfig.QUICEnabled {
+		// QUIC connections are accepted on the UDP ports that match the TCP
+		// ports being listened on.
+		quicListeners := make([]net.Listener, 0, len(listeners))
+		for _, listener := range listeners {
+			quicListener, err := network.NewQUICListener(listener.Addr().String(), tlsConfig)
+			if err != nil {
+				for _, listener := range append(listeners, quicListeners...) {
+					_ = listener.Close()
+				}
+				return err
+			}
+			quicListeners = append(quicListeners, quicListener)
+		}
+		listeners = append(listeners, quicListeners...)
+		n.Log.Warn("experimental QUIC transport is enabled")
+	}
+	listener := network.NewMultiListener(listeners...)
+	// Wrap listener so it will only accept a certain number of incoming connections per second
+	listener = throttling.NewThrottledListener(listener, n.Config.NetworkConfig.ThrottlerConfig.MaxInboundConnsPerSec)
 
 	// Configure benchlist
 	n.Config.BenchlistConfig.Validators = n.vdrs
 	n.Config.BenchlistConfig.Benchable = n.Config.ConsensusRouter
 	n.Config.BenchlistConfig.StakingEnabled = n.Config.EnableStaking
+	n.Config.BenchlistConfig.DB = prefixdb.New(benchlistDBPrefix, n.DB)
+	n.Config.BenchlistConfig.SubnetParameters = make(map[ids.ID]benchlist.Parameters, len(n.Config.SubnetConfigs))
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		// The primary network always uses the node's benchlist flags
+		if subnetID != constants.PrimaryNetworkID {
+			n.Config.BenchlistConfig.SubnetParameters[subnetID] = subnetConfig.BenchlistParameters
+		}
+	}
 	n.benchlistManager = benchlist.NewManager(&n.Config.BenchlistConfig)
 
 	n.uptimeCalculator = uptime.NewLockedCalculator()
 
 	consensusRouter := n.Config.ConsensusRouter
+	if n.Config.MessageCaptureConfig.Size > 0 {
+		n.messageCapture = router.NewMessageCapture(n.Config.MessageCaptureConfig)
+		consensusRouter = router.NewCapturingRouter(consensusRouter, n.messageCapture)
+	}
+	if n.Config.NotifierConfig.Enabled() {
+		n.queryTracker = router.NewQueryTracker()
+		consensusRouter = router.NewQueryTrackingRouter(consensusRouter, n.queryTracker)
+	}
 	if !n.Config.EnableStaking {
 		if err := primaryNetVdrs.AddWeight(n.ID, n.Config.DisabledStakingWeight); err != nil {
 			return err
@@ -291,18 +392,37 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 	n.Config.NetworkConfig.Namespace = n.networkNamespace
 	n.Config.NetworkConfig.MyNodeID = n.ID
 	n.Config.NetworkConfig.MyIPPort = n.Config.IPPort
+	n.Config.NetworkConfig.MyAdditionalIPs = n.Config.AdditionalIPs
 	n.Config.NetworkConfig.NetworkID = n.Config.NetworkID
 	n.Config.NetworkConfig.Validators = n.vdrs
 	n.Config.NetworkConfig.Beacons = n.beacons
 	n.Config.NetworkConfig.TLSConfig = tlsConfig
 	n.Config.NetworkConfig.TLSKey = tlsKey
 	n.Config.NetworkConfig.WhitelistedSubnets = n.Config.WhitelistedSubnets
+	n.Config.NetworkConfig.ValidatorOnlyAllowedNodes = make(map[ids.ID]ids.NodeIDSet)
+	for subnetID, subnetConfig := range n.Config.SubnetConfigs {
+		if !subnetConfig.ValidatorOnly || len(subnetConfig.AllowedNodes) == 0 {
+			continue
+		}
+		allowedNodes := ids.NewNodeIDSet(len(subnetConfig.AllowedNodes))
+		allowedNodes.Add(subnetConfig.AllowedNodes...)
+		n.Config.NetworkConfig.ValidatorOnlyAllowedNodes[subnetID] = allowedNodes
+	}
 	n.Config.NetworkConfig.UptimeCalculator = n.uptimeCalculator
 	n.Config.NetworkConfig.UptimeRequirement = n.Config.UptimeRequirement
 	n.Config.NetworkConfig.ResourceTracker = n.resourceTracker
 	n.Config.NetworkConfig.CPUTargeter = n.cpuTargeter
 	n.Config.NetworkConfig.DiskTargeter = n.diskTargeter
 
+	transport := network.NewTLSTransport(
+		listener,
+		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		n.Config.NetworkConfig.TLSConfig,
+	)
+	if n.Config.NetworkConfig.QUICEnabled {
+		transport = network.NewQUICTransport(transport, n.Config.NetworkConfig.TLSConfig, n.Log)
+	}
+
 	n.Net, err = network.NewNetwork(
 		&n.Config.NetworkConfig,
 		n.msgCreator,
@@ -310,8 +430,7 @@ func (n *Node) initNetworking(primaryNetVdrs validators.Set) error {
 		version.GetBanffTime(n.Config.NetworkID),
 		n.MetricsRegisterer,
 		n.Log,
-		listener,
-		dialer.NewDialer(constants.NetworkType, n.Config.NetworkConfig.DialerConfig, n.Log),
+		transport,
 		consensusRouter,
 	)
 
@@ -381,31 +500,46 @@ func (b *beaconManager) Disconnected(vdrID ids.NodeID) {
 	b.Router.Disconnected(vdrID)
 }
 
+// apiServerStopped shuts down the node once the API server stopped serving
+// because of [err].
+func (n *Node) apiServerStopped(err error) {
+	// When [n].Shutdown() is called, [n.APIServer].Close() is called.
+	// This causes [n.APIServer].Dispatch() to return an error.
+	// If that happened, don't log/return an error here.
+	if !n.shuttingDown.GetValue() {
+		n.Log.Fatal("API server dispatch failed",
+			zap.Error(err),
+		)
+	}
+	// If the API server isn't running, shut down the node.
+	// If node is already shutting down, this does nothing.
+	n.Shutdown(1)
+}
+
 // Dispatch starts the node's servers.
 // Returns when the node exits.
 func (n *Node) Dispatch() error {
 	// Start the HTTP API server
-	go n.Log.RecoverAndPanic(func() {
-		var err error
-		if n.Config.HTTPSEnabled {
-			n.Log.Debug("initializing API server with TLS")
-			err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey)
-		} else {
-			n.Log.Debug("initializing API server without TLS")
-			err = n.APIServer.Dispatch()
-		}
-		// When [n].Shutdown() is called, [n.APIServer].Close() is called.
-		// This causes [n.APIServer].Dispatch() to return an error.
-		// If that happened, don't log/return an error here.
-		if !n.shuttingDown.GetValue() {
-			n.Log.Fatal("API server dispatch failed",
-				zap.Error(err),
-			)
-		}
-		// If the API server isn't running, shut down the node.
-		// If node is already shutting down, this does nothing.
-		n.Shutdown(1)
-	})
+	if n.Config.HTTPTCPEnabled {
+		go n.Log.RecoverAndPanic(func() {
+			var err error
+			if n.Config.HTTPSEnabled {
+				n.Log.Debug("initializing API server with TLS")
+				err = n.APIServer.DispatchTLS(n.Config.HTTPSCert, n.Config.HTTPSKey)
+			} else {
+				n.Log.Debug("initializing API server without TLS")
+				err = n.APIServer.Dispatch()
+			}
+			n.apiServerStopped(err)
+		})
+	}
+	if n.Config.HTTPSocketPath != "" {
+		go n.Log.RecoverAndPanic(func() {
+			n.Log.Debug("initializing API server on Unix socket")
+			err := n.APIServer.DispatchUnix(n.Config.HTTPSocketPath, n.Config.HTTPSocketPerms)
+			n.apiServerStopped(err)
+		})
+	}
 
 	// Add state sync nodes to the peer network
 	for i, peerIP := range n.Config.StateSyncIPs {
@@ -567,6 +701,117 @@ func (n *Node) initIndexer() error {
 	return nil
 }
 
+// Initialize [n.diskWatchdog] and register it as the diskspace health check.
+// Assumes [n.indexer] and [n.apiTxGate] are already initialized.
+func (n *Node) initDiskWatchdog() error {
+	// Confirm that the node has enough disk space to continue operating. As
+	// the disk fills up, first stop indexing, then stop accepting txs through
+	// the APIs and finally shutdown the node.
+	n.diskWatchdog = diskwatchdog.New(
+		n.Log,
+		n.Config.DiskWatchdogConfig,
+		n.resourceTracker.DiskTracker().AvailableDiskBytes,
+		diskwatchdog.Actions{
+			PauseIndexing: n.indexer.SetPaused,
+			RejectAPITxs:  n.apiTxGate.SetClosed,
+			Halt:          func() { n.Shutdown(1) },
+		},
+	)
+	if err := n.health.RegisterHealthCheck("diskspace", n.diskWatchdog); err != nil {
+		return fmt.Errorf("couldn't register resource health check: %w", err)
+	}
+	return nil
+}
+
+// Initialize [n.maintenance] and register it as the maintenance health check.
+// Assumes [n.apiInFlight] and [n.queryGate] are already initialized.
+func (n *Node) initMaintenance() error {
+	// The polls started before the node entered maintenance finish once
+	// their requests time out at the latest.
+	queryDrainTime := n.Config.AdaptiveTimeoutConfig.MaximumTimeout
+	for _, config := range n.Config.ChainAdaptiveTimeoutConfigs {
+		if config.MaximumTimeout > queryDrainTime {
+			queryDrainTime = config.MaximumTimeout
+		}
+	}
+
+	n.maintenance = maintenance.New(
+		n.Log,
+		queryDrainTime,
+		maintenance.Actions{
+			PauseQueries:     n.queryGate.SetClosed,
+			InFlightAPICalls: n.apiInFlight.Len,
+			FlushDatabases: func() error {
+				flusher, ok := n.DB.(database.Flusher)
+				if !ok {
+					// In-memory databases have nothing to flush.
+					return nil
+				}
+				return flusher.Flush()
+			},
+		},
+	)
+	if err := n.health.RegisterHealthCheck("maintenance", n.maintenance); err != nil {
+		return fmt.Errorf("couldn't register maintenance health check: %w", err)
+	}
+	return nil
+}
+
+// Initialize [n.notifier] if the notifier is enabled.
+// Assumes [n.health], [n.Net] and [n.queryTracker] are already initialized.
+func (n *Node) initNotifier() {
+	if !n.Config.NotifierConfig.Enabled() {
+		return
+	}
+
+	n.notifier = notifier.New(
+		n.Log,
+		n.Config.NotifierConfig,
+		n.ID,
+		n.Config.NetworkID,
+		notifier.Sources{
+			Health:      n.health.Health,
+			SilentStake: n.silentStake,
+		},
+	)
+}
+
+// silentStake returns the share of the stake of the connected primary network
+// validators that haven't queried this node within [window]. Returns false if
+// this node isn't a validator, as validators don't query non-validators, or if
+// no validator queried this node, which is the case while it starts.
+func (n *Node) silentStake(window time.Duration) (float64, bool) {
+	primaryValidators, ok := n.vdrs.GetValidators(constants.PrimaryNetworkID)
+	if !ok || !primaryValidators.Contains(n.ID) {
+		return 0, false
+	}
+
+	var (
+		now         = time.Now()
+		totalWeight uint64
+		silent      uint64
+		queried     bool
+	)
+	for _, peer := range n.Net.PeerInfo(nil) {
+		weight, ok := primaryValidators.GetWeight(peer.ID)
+		if !ok {
+			continue
+		}
+		totalWeight += weight
+
+		lastQueried, ok := n.queryTracker.LastQueried(peer.ID)
+		if ok && now.Sub(lastQueried) < window {
+			queried = true
+		} else {
+			silent += weight
+		}
+	}
+	if totalWeight == 0 || !queried {
+		return 0, false
+	}
+	return float64(silent) / float64(totalWeight), true
+}
+
 // Initializes the Platform chain.
 // Its genesis data specifies the other chains that should be created.
 func (n *Node) initChains(genesisBytes []byte) {
@@ -586,6 +831,31 @@ func (n *Node) initChains(genesisBytes []byte) {
 func (n *Node) initAPIServer() error {
 	n.Log.Info("initializing API server")
 	n.APIServer = server.New()
+	n.apiTxGate = &server.TxGate{}
+	n.apiInFlight = &server.InFlight{}
+
+	rateLimiter, err := server.NewRateLimiter(
+		n.Config.RateLimiterConfig,
+		"api_rate_limiter",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize API rate limiter: %w", err)
+	}
+
+	// Chains are created after the API server, so aliases are resolved when
+	// requests arrive.
+	archiveProxy, err := server.NewArchiveProxy(
+		n.Config.ArchiveProxyConfigs,
+		func(alias string) (ids.ID, error) {
+			return n.chainManager.Lookup(alias)
+		},
+		"api_archive_proxy",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize API archive proxy: %w", err)
+	}
 
 	if !n.Config.APIRequireAuthToken {
 		n.APIServer.Initialize(
@@ -596,15 +866,22 @@ func (n *Node) initAPIServer() error {
 			n.Config.APIAllowedOrigins,
 			n.Config.ShutdownTimeout,
 			n.ID,
+			archiveProxy,
+			n.apiTxGate,
+			rateLimiter,
+			n.apiInFlight,
 		)
 		return nil
 	}
 
-	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword)
+	a, err := auth.New(n.Log, "auth", n.Config.APIAuthPassword, n.Config.APIAuthPublicScopes...)
 	if err != nil {
 		return err
 	}
 
+	// The rate limiter wraps the auth handler so that requests are limited
+	// before their tokens are verified. Txs are only rejected, and calls only
+	// forwarded to the archive node, once the token of the request is verified.
 	n.APIServer.Initialize(
 		n.Log,
 		n.LogFactory,
@@ -613,7 +890,11 @@ func (n *Node) initAPIServer() error {
 		n.Config.APIAllowedOrigins,
 		n.Config.ShutdownTimeout,
 		n.ID,
+		archiveProxy,
+		n.apiTxGate,
 		a,
+		rateLimiter,
+		n.apiInFlight,
 	)
 
 	// only create auth service if token authorization is required
@@ -660,17 +941,24 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 	}
 	cChainID := createEVMTx.ID()
 
+	n.queryGate = &snow.QueryGate{}
+
 	// If any of these chains die, the node shuts down
 	criticalChains := ids.Set{}
 	criticalChains.Add(
 		constants.PlatformChainID,
 		xChainID,
-		cChainID,
 	)
+	// A C-chain running the EVM plugin is isolated from the rest of the node,
+	// so it may stop without shutting down the node.
+	if !n.Config.CorethPluginEnabled {
+		criticalChains.Add(cChainID)
+	}
 
 	// Manages network timeouts
 	timeoutManager, err := timeout.NewManager(
 		&n.Config.AdaptiveTimeoutConfig,
+		n.Config.ChainAdaptiveTimeoutConfigs,
 		n.benchlistManager,
 		"requests",
 		n.MetricsRegisterer,
@@ -698,9 +986,25 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		return fmt.Errorf("couldn't initialize chain router: %w", err)
 	}
 
+	// Limits the state summaries and blocks served to syncing peers
+	stateSyncServingBudget, err := syncserving.NewBudget(
+		n.Config.StateSyncServingConfig,
+		"sync_serving",
+		n.MetricsRegisterer,
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize state sync serving budget: %w", err)
+	}
+
+	var chainStoppedFunc func(string, ids.ID)
+	if n.notifier != nil {
+		chainStoppedFunc = n.notifier.ChainStopped
+	}
+
 	n.chainManager = chains.New(&chains.ManagerConfig{
 		StakingEnabled:                          n.Config.EnableStaking,
 		StakingCert:                             n.Config.StakingTLSCert,
+		StakingBLSSigner:                        n.Config.StakingSigner,
 		Log:                                     n.Log,
 		LogFactory:                              n.LogFactory,
 		VMManager:                               n.Config.VMManager,
@@ -728,11 +1032,20 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		RetryBootstrapWarnFrequency:             n.Config.RetryBootstrapWarnFrequency,
 		ShutdownNodeFunc:                        n.Shutdown,
 		MeterVMEnabled:                          n.Config.MeterVMEnabled,
+		Tracer:                                  n.tracer,
+		TracingEnabled:                          n.Config.TraceConfig.Enabled,
+		QueryGate:                               n.queryGate,
+		ChainStoppedFunc:                        chainStoppedFunc,
 		Metrics:                                 n.MetricsGatherer,
 		SubnetConfigs:                           n.Config.SubnetConfigs,
 		ChainConfigs:                            n.Config.ChainConfigs,
 		ConsensusGossipFrequency:                n.Config.ConsensusGossipFrequency,
+		GossipedContainerTTL:                    n.Config.GossipedContainerTTL,
+		BenchlistParameters:                     n.Config.BenchlistConfig.Parameters,
+		MessageQueueConfig:                      n.Config.MessageQueueConfig,
+		ChainMessageQueueConfigs:                n.Config.ChainMessageQueueConfigs,
 		GossipConfig:                            n.Config.GossipConfig,
+		MaxOutstandingRequestsPerPeer:           n.Config.MaxOutstandingRequestsPerPeer,
 		BootstrapMaxTimeGetAncestors:            n.Config.BootstrapMaxTimeGetAncestors,
 		BootstrapAncestorsMaxContainersSent:     n.Config.BootstrapAncestorsMaxContainersSent,
 		BootstrapAncestorsMaxContainersReceived: n.Config.BootstrapAncestorsMaxContainersReceived,
@@ -740,14 +1053,64 @@ func (n *Node) initChainManager(avaxAssetID ids.ID) error {
 		ApricotPhase4MinPChainHeight:            version.GetApricotPhase4MinPChainHeight(n.Config.NetworkID),
 		BanffTime:                               version.GetBanffTime(n.Config.NetworkID),
 		ResourceTracker:                         n.resourceTracker,
+		ChainTracker:                            n.chainTracker,
 		StateSyncBeacons:                        n.Config.StateSyncIDs,
+		StateSyncServingBudget:                  stateSyncServingBudget,
+		TrustedCheckpoints:                      n.Config.TrustedCheckpoints,
+		BootstrapOrder:                          n.Config.BootstrapOrder,
+		BootstrapMaxConcurrentChains:            n.Config.BootstrapMaxConcurrentChains,
+		BootstrapMaxBandwidth:                   n.Config.BootstrapMaxBandwidth,
+		BootstrapBandwidthShares:                n.Config.BootstrapBandwidthShares,
+		ProposerVMHeightIndexRepairThrottle:     n.Config.ProposerVMHeightIndexRepairThrottle,
 	})
 
 	// Notify the API server when new chains are created
-	n.chainManager.AddRegistrant(n.APIServer)
+	if n.Config.ChainAPIsEnabled {
+		n.chainManager.AddRegistrant(n.APIServer)
+	} else {
+		n.Log.Info("skipping chain API initialization because it has been disabled")
+	}
+	if n.notifier != nil {
+		n.chainManager.AddRegistrant(n.notifier)
+	}
 	return nil
 }
 
+// PlatformVMConfig returns the parameters of the P-chain set by [nodeConfig].
+// The dependencies of the P-chain on the running node, such as the chain
+// manager, aren't set.
+func PlatformVMConfig(nodeConfig *Config) config.Config {
+	return config.Config{
+		StakingEnabled:                nodeConfig.EnableStaking,
+		WhitelistedSubnets:            nodeConfig.WhitelistedSubnets,
+		TxFee:                         nodeConfig.TxFee,
+		CreateAssetTxFee:              nodeConfig.CreateAssetTxFee,
+		CreateSubnetTxFee:             nodeConfig.CreateSubnetTxFee,
+		TransformSubnetTxFee:          nodeConfig.TransformSubnetTxFee,
+		CreateBlockchainTxFee:         nodeConfig.CreateBlockchainTxFee,
+		AddPrimaryNetworkValidatorFee: nodeConfig.AddPrimaryNetworkValidatorFee,
+		AddPrimaryNetworkDelegatorFee: nodeConfig.AddPrimaryNetworkDelegatorFee,
+		AddSubnetValidatorFee:         nodeConfig.AddSubnetValidatorFee,
+		AddSubnetDelegatorFee:         nodeConfig.AddSubnetDelegatorFee,
+		UptimePercentage:              nodeConfig.UptimeRequirement,
+		MinValidatorStake:             nodeConfig.MinValidatorStake,
+		MaxValidatorStake:             nodeConfig.MaxValidatorStake,
+		MinDelegatorStake:             nodeConfig.MinDelegatorStake,
+		MinDelegationFee:              nodeConfig.MinDelegationFee,
+		MinStakeDuration:              nodeConfig.MinStakeDuration,
+		MaxStakeDuration:              nodeConfig.MaxStakeDuration,
+		RewardConfig:                  nodeConfig.RewardConfig,
+		ApricotPhase3Time:             version.GetApricotPhase3Time(nodeConfig.NetworkID),
+		ApricotPhase5Time:             version.GetApricotPhase5Time(nodeConfig.NetworkID),
+		BanffTime:                     version.GetBanffTime(nodeConfig.NetworkID),
+		ContinuousStakingTime:         version.GetContinuousStakingTime(nodeConfig.NetworkID),
+		BatchDelegationTime:           version.GetBatchDelegationTime(nodeConfig.NetworkID),
+		HistoricalStateIndexEnabled:   nodeConfig.PlatformHistoryIndexEnabled,
+		Pruning:                       nodeConfig.PlatformPruningConfig,
+		NetworkParams:                 nodeConfig.NetworkParams,
+	}
+}
+
 // initVMs initializes the VMs Avalanche supports + any additional vms installed as plugins.
 func (n *Node) initVMs() error {
 	n.Log.Info("initializing VMs")
@@ -767,49 +1130,32 @@ func (n *Node) initVMs() error {
 		VMManager: n.Config.VMManager,
 	})
 
+	platformConfig := PlatformVMConfig(n.Config)
+	platformConfig.Chains = n.chainManager
+	platformConfig.Validators = vdrs
+	platformConfig.SubnetTracker = n.Net
+	platformConfig.UptimeLockedCalculator = n.uptimeCalculator
+
 	// Register the VMs that Avalanche supports
 	errs := wrappers.Errs{}
 	errs.Add(
 		vmRegisterer.Register(constants.PlatformVMID, &platformvm.Factory{
-			Config: config.Config{
-				Chains:                        n.chainManager,
-				Validators:                    vdrs,
-				SubnetTracker:                 n.Net,
-				UptimeLockedCalculator:        n.uptimeCalculator,
-				StakingEnabled:                n.Config.EnableStaking,
-				WhitelistedSubnets:            n.Config.WhitelistedSubnets,
-				TxFee:                         n.Config.TxFee,
-				CreateAssetTxFee:              n.Config.CreateAssetTxFee,
-				CreateSubnetTxFee:             n.Config.CreateSubnetTxFee,
-				TransformSubnetTxFee:          n.Config.TransformSubnetTxFee,
-				CreateBlockchainTxFee:         n.Config.CreateBlockchainTxFee,
-				AddPrimaryNetworkValidatorFee: n.Config.AddPrimaryNetworkValidatorFee,
-				AddPrimaryNetworkDelegatorFee: n.Config.AddPrimaryNetworkDelegatorFee,
-				AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
-				AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
-				UptimePercentage:              n.Config.UptimeRequirement,
-				MinValidatorStake:             n.Config.MinValidatorStake,
-				MaxValidatorStake:             n.Config.MaxValidatorStake,
-				MinDelegatorStake:             n.Config.MinDelegatorStake,
-				MinDelegationFee:              n.Config.MinDelegationFee,
-				MinStakeDuration:              n.Config.MinStakeDuration,
-				MaxStakeDuration:              n.Config.MaxStakeDuration,
-				RewardConfig:                  n.Config.RewardConfig,
-				ApricotPhase3Time:             version.GetApricotPhase3Time(n.Config.NetworkID),
-				ApricotPhase5Time:             version.GetApricotPhase5Time(n.Config.NetworkID),
-				BanffTime:                     version.GetBanffTime(n.Config.NetworkID),
-			},
+			Config: platformConfig,
 		}),
 		vmRegisterer.Register(constants.AVMID, &avm.Factory{
 			TxFee:            n.Config.TxFee,
 			CreateAssetTxFee: n.Config.CreateAssetTxFee,
 			BanffTime:        version.GetBanffTime(n.Config.NetworkID),
 		}),
-		vmRegisterer.Register(constants.EVMID, &coreth.Factory{}),
 		n.Config.VMManager.RegisterFactory(secp256k1fx.ID, &secp256k1fx.Factory{}),
 		n.Config.VMManager.RegisterFactory(nftfx.ID, &nftfx.Factory{}),
 		n.Config.VMManager.RegisterFactory(propertyfx.ID, &propertyfx.Factory{}),
 	)
+	// If the EVM plugin is enabled, the EVM is registered when the plugins
+	// are loaded.
+	if !n.Config.CorethPluginEnabled {
+		errs.Add(vmRegisterer.Register(constants.EVMID, &coreth.Factory{}))
+	}
 	if errs.Errored() {
 		return errs.Err
 	}
@@ -817,10 +1163,11 @@ func (n *Node) initVMs() error {
 	// initialize the vm registry
 	n.VMRegistry = registry.NewVMRegistry(registry.VMRegistryConfig{
 		VMGetter: registry.NewVMGetter(registry.VMGetterConfig{
-			FileReader:      filesystem.NewReader(),
-			Manager:         n.Config.VMManager,
-			PluginDirectory: n.Config.PluginDir,
-			CPUTracker:      n.resourceManager,
+			FileReader:        filesystem.NewReader(),
+			Manager:           n.Config.VMManager,
+			PluginDirectory:   n.Config.PluginDir,
+			CPUTracker:        n.resourceManager,
+			MaxPluginRestarts: n.Config.PluginMaxRestarts,
 		}),
 		VMRegisterer: vmRegisterer,
 	})
@@ -833,7 +1180,16 @@ func (n *Node) initVMs() error {
 			zap.Error(err),
 		)
 	}
-	return err
+	if err != nil {
+		return err
+	}
+
+	if n.Config.CorethPluginEnabled {
+		if _, err := n.Config.VMManager.GetFactory(constants.EVMID); err != nil {
+			return fmt.Errorf("%w in %s: %s", errCorethPluginNotFound, n.Config.PluginDir, err)
+		}
+	}
+	return nil
 }
 
 // initSharedMemory initializes the shared memory for cross chain interation
@@ -865,12 +1221,40 @@ func (n *Node) initKeystoreAPI() error {
 	return n.APIServer.AddRoute(handler, &sync.RWMutex{}, "keystore", "")
 }
 
-// initMetricsAPI initializes the Metrics API
-// Assumes n.APIServer is already set
-func (n *Node) initMetricsAPI() error {
+// initSigner starts serving the signer over a local socket, if enabled.
+// Assumes n.keystore is already set
+func (n *Node) initSigner() error {
+	if n.Config.SignerSocketPath == "" {
+		n.Log.Info("skipping signer initialization because it has been disabled")
+		return nil
+	}
+
+	n.Log.Info("initializing signer")
+	var err error
+	n.signer, err = apisigner.NewServer(n.Log, n.keystore, n.Config.SignerSocketPath)
+	if err != nil {
+		return err
+	}
+	go n.Log.RecoverAndPanic(func() {
+		if err := n.signer.Dispatch(); err != nil {
+			n.Log.Error("signer dispatch failed",
+				zap.Error(err),
+			)
+		}
+	})
+	return nil
+}
+
+// initMetrics initializes the registry that the node's metrics are registered
+// with
+func (n *Node) initMetrics() {
 	n.MetricsRegisterer = prometheus.NewRegistry()
 	n.MetricsGatherer = metrics.NewMultiGatherer()
+}
 
+// initMetricsAPI initializes the Metrics API
+// Assumes n.APIServer is already set
+func (n *Node) initMetricsAPI() error {
 	if !n.Config.MetricsAPIEnabled {
 		n.Log.Info("skipping metrics API initialization because it has been disabled")
 		return nil
@@ -916,16 +1300,32 @@ func (n *Node) initAdminAPI() error {
 		return nil
 	}
 	n.Log.Info("initializing admin API")
+	stakingSigner, ok := n.Config.StakingTLSCert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return errInvalidTLSKey
+	}
+	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
 	service, err := admin.NewService(
 		admin.Config{
-			Log:          n.Log,
-			ChainManager: n.chainManager,
-			HTTPServer:   n.APIServer,
-			ProfileDir:   n.Config.ProfilerConfig.Dir,
-			LogFactory:   n.LogFactory,
-			NodeConfig:   n.Config,
-			VMManager:    n.Config.VMManager,
-			VMRegistry:   n.VMRegistry,
+			Log:              n.Log,
+			ChainManager:     n.chainManager,
+			HTTPServer:       n.APIServer,
+			ProfileDir:       n.Config.ProfilerConfig.Dir,
+			LogDir:           n.Config.LoggingConfig.Directory,
+			MessageCapture:   n.messageCapture,
+			StakingCert:      n.Config.StakingTLSCert.Leaf,
+			StakingSigner:    stakingSigner,
+			Validators:       primaryValidators,
+			UptimeCalculator: n.uptimeCalculator,
+			Network:          n.Net,
+			DiskWatchdog:     n.diskWatchdog,
+			Maintenance:      n.maintenance,
+			LogFactory:       n.LogFactory,
+			NodeConfig:       n.Config,
+			VMManager:        n.Config.VMManager,
+			VMRegistry:       n.VMRegistry,
+			DB:               n.DB,
+			DBVersion:        n.DBManager.Current().Version,
 		},
 	)
 	if err != nil {
@@ -934,6 +1334,38 @@ func (n *Node) initAdminAPI() error {
 	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "admin", "")
 }
 
+// initTransferAPI initializes the Transfer API service
+// Assumes n.keystore and n.chainManager already initialized
+func (n *Node) initTransferAPI() error {
+	if !n.Config.TransferAPIEnabled {
+		n.Log.Info("skipping transfer API initialization because it has been disabled")
+		return nil
+	}
+	n.Log.Info("initializing transfer API")
+	scheme := "http"
+	if n.Config.HTTPSEnabled {
+		scheme = "https"
+	}
+	service, err := transfer.NewService(
+		transfer.Config{
+			Log:          n.Log,
+			Keystore:     n.keystore,
+			DB:           prefixdb.New(transferDBPrefix, n.DB),
+			ChainAliaser: n.chainManager,
+			AVAXAssetID:  n.Config.AvaxAssetID,
+			URI: fmt.Sprintf(
+				"%s://%s",
+				scheme,
+				net.JoinHostPort(n.Config.HTTPHost, strconv.Itoa(int(n.Config.HTTPPort))),
+			),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	return n.APIServer.AddRoute(service, &sync.RWMutex{}, "transfer", "")
+}
+
 // initProfiler initializes the continuous profiling
 func (n *Node) initProfiler() {
 	if !n.Config.ProfilerConfig.Enabled {
@@ -958,6 +1390,35 @@ func (n *Node) initProfiler() {
 	})
 }
 
+// unsupportedUpgrades returns the network upgrades of this network that this
+// release doesn't implement
+func (n *Node) unsupportedUpgrades() []version.PendingUpgrade {
+	current := version.GetCompatibility(n.Config.NetworkID).Version()
+	return version.UnsupportedUpgrades(n.Config.NetworkID, current)
+}
+
+// upgradeSchedule returns the network upgrades of this network, including the
+// starts of its staking phases, in the order that they activate in
+func (n *Node) upgradeSchedule() []version.Upgrade {
+	upgrades := version.GetUpgradeSchedule(n.Config.NetworkID)
+	params := executor.GetNetworkParams(n.Config.NetworkID, &config.Config{
+		NetworkParams: n.Config.NetworkParams,
+	})
+	for i, phase := range params.StakingPhases {
+		if phase.Start.IsZero() {
+			continue
+		}
+		upgrades = append(upgrades, version.Upgrade{
+			Name: fmt.Sprintf("stakingPhase%d", i+1),
+			Time: phase.Start,
+		})
+	}
+	sort.SliceStable(upgrades, func(i, j int) bool {
+		return upgrades[i].Time.Before(upgrades[j].Time)
+	})
+	return upgrades
+}
+
 func (n *Node) initInfoAPI() error {
 	if !n.Config.InfoAPIEnabled {
 		n.Log.Info("skipping info API initialization because it has been disabled")
@@ -966,12 +1427,22 @@ func (n *Node) initInfoAPI() error {
 
 	n.Log.Info("initializing info API")
 
+	var peerEnricher info.PeerEnricher
+	if len(n.Config.InfoAPIPeerEnrichment) > 0 {
+		var err error
+		peerEnricher, err = info.NewCIDRPeerEnricher(n.Config.InfoAPIPeerEnrichment)
+		if err != nil {
+			return err
+		}
+	}
+
 	primaryValidators, _ := n.vdrs.GetValidators(constants.PrimaryNetworkID)
 	service, err := info.NewService(
 		info.Parameters{
 			Version:                       version.CurrentApp,
 			NodeID:                        n.ID,
-			NodePOP:                       signer.NewProofOfPossession(n.Config.StakingSigningKey),
+			NodePOP:                       n.pop,
+			StakingCert:                   n.Config.StakingTLSCert.Leaf,
 			NetworkID:                     n.Config.NetworkID,
 			TxFee:                         n.Config.TxFee,
 			CreateAssetTxFee:              n.Config.CreateAssetTxFee,
@@ -983,6 +1454,9 @@ func (n *Node) initInfoAPI() error {
 			AddSubnetValidatorFee:         n.Config.AddSubnetValidatorFee,
 			AddSubnetDelegatorFee:         n.Config.AddSubnetDelegatorFee,
 			VMManager:                     n.Config.VMManager,
+			NodeConfig:                    n.Config,
+			PeerEnricher:                  peerEnricher,
+			Upgrades:                      n.upgradeSchedule(),
 		},
 		n.Log,
 		n.chainManager,
@@ -1001,7 +1475,7 @@ func (n *Node) initInfoAPI() error {
 // initHealthAPI initializes the Health API service
 // Assumes n.Log, n.Net, n.APIServer, n.HTTPLog already initialized
 func (n *Node) initHealthAPI() error {
-	healthChecker, err := health.New(n.Log, n.MetricsRegisterer)
+	healthChecker, err := health.New(n.Log, n.Config.HealthChecksConfig, n.MetricsRegisterer)
 	if err != nil {
 		return err
 	}
@@ -1029,31 +1503,30 @@ func (n *Node) initHealthAPI() error {
 		return fmt.Errorf("couldn't register database health check: %w", err)
 	}
 
-	diskSpaceCheck := health.CheckerFunc(func() (interface{}, error) {
-		// confirm that the node has enough disk space to continue operating
-		// if there is too little disk space remaining, first report unhealthy and then shutdown the node
-
-		availableDiskBytes := n.resourceTracker.DiskTracker().AvailableDiskBytes()
+	if n.Config.PortMapper != nil {
+		err = healthChecker.RegisterHealthCheck("nat", n.Config.PortMapper)
+		if err != nil {
+			return fmt.Errorf("couldn't register nat health check: %w", err)
+		}
+	}
 
-		var err error
-		if availableDiskBytes < n.Config.RequiredAvailableDiskSpace {
-			n.Log.Fatal("low on disk space. Shutting down...",
-				zap.Uint64("remainingDiskBytes", availableDiskBytes),
+	unsupportedUpgrades := n.unsupportedUpgrades()
+	forkReadinessCheck := health.CheckerFunc(func() (interface{}, error) {
+		// report unhealthy while an upgrade that this release doesn't
+		// implement is pending and shutdown once it activates
+		pending, err := version.CheckUpgradeReadiness(unsupportedUpgrades, time.Now())
+		if errors.Is(err, version.ErrUnsupportedUpgradeActive) {
+			n.Log.Fatal("network upgrade isn't implemented by this release. Shutting down...",
+				zap.Error(err),
 			)
 			go n.Shutdown(1)
-			err = fmt.Errorf("remaining available disk space (%d) is below minimum required available space (%d)", availableDiskBytes, n.Config.RequiredAvailableDiskSpace)
-		} else if availableDiskBytes < n.Config.WarningThresholdAvailableDiskSpace {
-			err = fmt.Errorf("remaining available disk space (%d) is below the warning threshold of disk space (%d)", availableDiskBytes, n.Config.WarningThresholdAvailableDiskSpace)
 		}
-
-		return map[string]interface{}{
-			"availableDiskBytes": availableDiskBytes,
-		}, err
+		return pending, err
 	})
 
-	err = n.health.RegisterHealthCheck("diskspace", diskSpaceCheck)
+	err = n.health.RegisterHealthCheck("forkReadiness", forkReadinessCheck)
 	if err != nil {
-		return fmt.Errorf("couldn't register resource health check: %w", err)
+		return fmt.Errorf("couldn't register fork readiness health check: %w", err)
 	}
 
 	handler, err := health.NewGetAndPostHandler(n.Log, healthChecker)
@@ -1144,6 +1617,55 @@ func (n *Node) initChainAliases(genesisBytes []byte) error {
 	return nil
 }
 
+// checkCChainConfig cross-checks the C-chain config before the chains are
+// created, so that an incompatible config fails the node at startup rather than
+// once the chain is running.
+// Assumes n.chainManager has the chain aliases.
+func (n *Node) checkCChainConfig() error {
+	// The EVM plugin may not match this build, so it checks its own config.
+	if n.Config.CorethPluginEnabled {
+		return nil
+	}
+
+	createEVMTx, err := genesis.VMGenesis(n.Config.GenesisBytes, constants.EVMID)
+	if err != nil {
+		return err
+	}
+	cChainID := createEVMTx.ID()
+
+	chainConfig, ok := n.Config.ChainConfigs[cChainID.String()]
+	if !ok {
+		aliases, err := n.chainManager.Aliases(cChainID)
+		if err != nil {
+			return err
+		}
+		for _, alias := range aliases {
+			if chainConfig, ok = n.Config.ChainConfigs[alias]; ok {
+				break
+			}
+		}
+	}
+
+	availableDiskSpace, err := storage.AvailableBytes(n.Config.DatabaseConfig.Path)
+	if err != nil {
+		n.Log.Debug("couldn't read available disk space",
+			zap.String("path", n.Config.DatabaseConfig.Path),
+			zap.Error(err),
+		)
+		availableDiskSpace = 0
+	}
+	warnings, err := coreth.CheckConfig(chainConfig.Config, availableDiskSpace)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		n.Log.Warn("incompatible C-chain config options",
+			zap.String("warning", warning),
+		)
+	}
+	return nil
+}
+
 // APIs aliases as specified by the genesis information
 func (n *Node) initAPIAliases(genesisBytes []byte) error {
 	n.Log.Info("initializing API aliases")
@@ -1182,6 +1704,10 @@ func (n *Node) initResourceManager(reg prometheus.Registerer) error {
 
 	var err error
 	n.resourceTracker, err = tracker.NewResourceTracker(reg, n.resourceManager, &meter.ContinuousFactory{}, n.Config.SystemTrackerProcessingHalflife)
+	if err != nil {
+		return err
+	}
+	n.chainTracker, err = tracker.NewChainTracker(reg, n.resourceManager, &meter.ContinuousFactory{}, n.Config.SystemTrackerProcessingHalflife)
 	return err
 }
 
@@ -1224,18 +1750,40 @@ func (n *Node) Initialize(
 	n.LogFactory = logFactory
 	n.DoneShuttingDown.Add(1)
 
-	pop := signer.NewProofOfPossession(n.Config.StakingSigningKey)
+	n.pop, err = signer.NewProofOfPossessionFromSigner(n.Config.StakingSigner)
+	if err != nil {
+		return fmt.Errorf("couldn't create proof of possession: %w", err)
+	}
 	n.Log.Info("initializing node",
 		zap.Stringer("version", version.CurrentApp),
 		zap.Stringer("nodeID", n.ID),
-		zap.Reflect("nodePOP", pop),
+		zap.Reflect("nodePOP", n.pop),
 		zap.Reflect("config", n.Config),
 	)
 
+	// A release that doesn't implement an active network upgrade would stall
+	// once it reaches the upgrade, so it isn't started at all.
+	_, err = version.CheckUpgradeReadiness(n.unsupportedUpgrades(), time.Now())
+	switch {
+	case errors.Is(err, version.ErrUnsupportedUpgradeActive):
+		return err
+	case err != nil:
+		n.Log.Warn("node must be updated before the next network upgrade",
+			zap.Error(err),
+		)
+	}
+
 	if err = n.initBeacons(); err != nil { // Configure the beacons
 		return fmt.Errorf("problem initializing node beacons: %w", err)
 	}
 
+	n.initMetrics()
+
+	n.tracer, err = trace.New(n.Config.TraceConfig)
+	if err != nil {
+		return fmt.Errorf("couldn't initialize tracer: %w", err)
+	}
+
 	if err := n.initAPIServer(); err != nil { // Start the API Server
 		return fmt.Errorf("couldn't initialize API server: %w", err)
 	}
@@ -1252,6 +1800,10 @@ func (n *Node) Initialize(
 		return fmt.Errorf("couldn't initialize keystore API: %w", err)
 	}
 
+	if err := n.initSigner(); err != nil { // Start the signer socket
+		return fmt.Errorf("couldn't initialize signer: %w", err)
+	}
+
 	n.initSharedMemory() // Initialize shared memory
 
 	// message.Creator is shared between networking, chainManager and the engine.
@@ -1259,24 +1811,35 @@ func (n *Node) Initialize(
 	// and the engine (initChains) but after the metrics (initMetricsAPI)
 	// message.Creator currently record metrics under network namespace
 	n.networkNamespace = "network"
-	n.msgCreator, err = message.NewCreator(
-		n.MetricsRegisterer,
-		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionEnabled,
-		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
-	)
-	if err != nil {
-		return fmt.Errorf("problem initializing message creator: %w", err)
+	compressionType := compression.TypeNone
+	if n.Config.NetworkConfig.CompressionEnabled {
+		compressionType = n.Config.NetworkConfig.CompressionType
 	}
 	n.msgCreatorWithProto, err = message.NewCreatorWithProto(
 		n.MetricsRegisterer,
 		n.networkNamespace,
-		n.Config.NetworkConfig.CompressionEnabled,
+		compressionType,
 		n.Config.NetworkConfig.MaximumInboundMessageTimeout,
 	)
 	if err != nil {
 		return fmt.Errorf("problem initializing message creator with proto: %w", err)
 	}
+	if n.Config.NetworkConfig.LegacyMessagesDisabled {
+		// The legacy codec isn't initialized, so every message is created with
+		// the proto creator.
+		n.Log.Info("legacy message format is disabled")
+		n.msgCreator = n.msgCreatorWithProto
+	} else {
+		n.msgCreator, err = message.NewCreator(
+			n.MetricsRegisterer,
+			n.networkNamespace,
+			n.Config.NetworkConfig.CompressionEnabled,
+			n.Config.NetworkConfig.MaximumInboundMessageTimeout,
+		)
+		if err != nil {
+			return fmt.Errorf("problem initializing message creator: %w", err)
+		}
+	}
 
 	primaryNetVdrs, err := n.initVdrs()
 	if err != nil {
@@ -1302,15 +1865,13 @@ func (n *Node) Initialize(
 	if err := n.addDefaultVMAliases(); err != nil {
 		return fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
+	n.initNotifier()
 	if err := n.initChainManager(n.Config.AvaxAssetID); err != nil { // Set up the chain manager
 		return fmt.Errorf("couldn't initialize chain manager: %w", err)
 	}
 	if err := n.initVMs(); err != nil { // Initialize the VM registry.
 		return fmt.Errorf("couldn't initialize VM registry: %w", err)
 	}
-	if err := n.initAdminAPI(); err != nil { // Start the Admin API
-		return fmt.Errorf("couldn't initialize admin API: %w", err)
-	}
 	if err := n.initInfoAPI(); err != nil { // Start the Info API
 		return fmt.Errorf("couldn't initialize info API: %w", err)
 	}
@@ -1326,11 +1887,29 @@ func (n *Node) Initialize(
 	if err := n.initAPIAliases(n.Config.GenesisBytes); err != nil {
 		return fmt.Errorf("couldn't initialize API aliases: %w", err)
 	}
+	if err := n.checkCChainConfig(); err != nil {
+		return fmt.Errorf("invalid C-chain config: %w", err)
+	}
 	if err := n.initIndexer(); err != nil {
 		return fmt.Errorf("couldn't initialize indexer: %w", err)
 	}
+	if err := n.initDiskWatchdog(); err != nil {
+		return fmt.Errorf("couldn't initialize disk space watchdog: %w", err)
+	}
+	if err := n.initMaintenance(); err != nil {
+		return fmt.Errorf("couldn't initialize maintenance mode: %w", err)
+	}
+	if err := n.initAdminAPI(); err != nil { // Start the Admin API
+		return fmt.Errorf("couldn't initialize admin API: %w", err)
+	}
+	if err := n.initTransferAPI(); err != nil { // Start the Transfer API
+		return fmt.Errorf("couldn't initialize transfer API: %w", err)
+	}
 
 	n.health.Start(n.Config.HealthCheckFreq)
+	if n.notifier != nil {
+		n.notifier.Start()
+	}
 	n.initProfiler()
 
 	// Start the Platform chain
@@ -1353,6 +1932,12 @@ func (n *Node) shutdown() {
 		zap.Int("exitCode", n.ExitCode()),
 	)
 
+	// Stopped first, as the node is reported unhealthy and its chains stop
+	// while it shuts down.
+	if n.notifier != nil {
+		n.notifier.Stop()
+	}
+
 	if n.health != nil {
 		// Passes if the node is not shutting down
 		shuttingDownCheck := health.CheckerFunc(func() (interface{}, error) {
@@ -1395,12 +1980,28 @@ func (n *Node) shutdown() {
 			zap.Error(err),
 		)
 	}
+	if n.signer != nil {
+		if err := n.signer.Shutdown(n.Config.ShutdownTimeout); err != nil {
+			n.Log.Debug("error during signer shutdown",
+				zap.Error(err),
+			)
+		}
+	}
 	if err := n.indexer.Close(); err != nil {
 		n.Log.Debug("error closing tx indexer",
 			zap.Error(err),
 		)
 	}
 
+	// Export the spans of the messages handled before the shutdown
+	if n.tracer != nil {
+		if err := n.tracer.Close(); err != nil {
+			n.Log.Debug("error closing tracer",
+				zap.Error(err),
+			)
+		}
+	}
+
 	// Make sure all plugin subprocesses are killed
 	n.Log.Info("cleaning up plugin subprocesses")
 	plugin.CleanupClients()
@@ -0,0 +1,249 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             (unknown)
+// source: signer/signer.proto
+
+package signer
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SignerClient is the client API for Signer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SignerClient interface {
+	TLSCertificate(ctx context.Context, in *TLSCertificateRequest, opts ...grpc.CallOption) (*TLSCertificateResponse, error)
+	TLSSign(ctx context.Context, in *TLSSignRequest, opts ...grpc.CallOption) (*TLSSignResponse, error)
+	BLSPublicKey(ctx context.Context, in *BLSPublicKeyRequest, opts ...grpc.CallOption) (*BLSPublicKeyResponse, error)
+	BLSSign(ctx context.Context, in *BLSSignRequest, opts ...grpc.CallOption) (*BLSSignResponse, error)
+	BLSSignProofOfPossession(ctx context.Context, in *BLSSignProofOfPossessionRequest, opts ...grpc.CallOption) (*BLSSignProofOfPossessionResponse, error)
+}
+
+type signerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSignerClient(cc grpc.ClientConnInterface) SignerClient {
+	return &signerClient{cc}
+}
+
+func (c *signerClient) TLSCertificate(ctx context.Context, in *TLSCertificateRequest, opts ...grpc.CallOption) (*TLSCertificateResponse, error) {
+	out := new(TLSCertificateResponse)
+	err := c.cc.Invoke(ctx, "/signer.Signer/TLSCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) TLSSign(ctx context.Context, in *TLSSignRequest, opts ...grpc.CallOption) (*TLSSignResponse, error) {
+	out := new(TLSSignResponse)
+	err := c.cc.Invoke(ctx, "/signer.Signer/TLSSign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) BLSPublicKey(ctx context.Context, in *BLSPublicKeyRequest, opts ...grpc.CallOption) (*BLSPublicKeyResponse, error) {
+	out := new(BLSPublicKeyResponse)
+	err := c.cc.Invoke(ctx, "/signer.Signer/BLSPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) BLSSign(ctx context.Context, in *BLSSignRequest, opts ...grpc.CallOption) (*BLSSignResponse, error) {
+	out := new(BLSSignResponse)
+	err := c.cc.Invoke(ctx, "/signer.Signer/BLSSign", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *signerClient) BLSSignProofOfPossession(ctx context.Context, in *BLSSignProofOfPossessionRequest, opts ...grpc.CallOption) (*BLSSignProofOfPossessionResponse, error) {
+	out := new(BLSSignProofOfPossessionResponse)
+	err := c.cc.Invoke(ctx, "/signer.Signer/BLSSignProofOfPossession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SignerServer is the server API for Signer service.
+// All implementations must embed UnimplementedSignerServer
+// for forward compatibility
+type SignerServer interface {
+	TLSCertificate(context.Context, *TLSCertificateRequest) (*TLSCertificateResponse, error)
+	TLSSign(context.Context, *TLSSignRequest) (*TLSSignResponse, error)
+	BLSPublicKey(context.Context, *BLSPublicKeyRequest) (*BLSPublicKeyResponse, error)
+	BLSSign(context.Context, *BLSSignRequest) (*BLSSignResponse, error)
+	BLSSignProofOfPossession(context.Context, *BLSSignProofOfPossessionRequest) (*BLSSignProofOfPossessionResponse, error)
+	mustEmbedUnimplementedSignerServer()
+}
+
+// UnimplementedSignerServer must be embedded to have forward compatible implementations.
+type UnimplementedSignerServer struct {
+}
+
+func (UnimplementedSignerServer) TLSCertificate(context.Context, *TLSCertificateRequest) (*TLSCertificateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TLSCertificate not implemented")
+}
+func (UnimplementedSignerServer) TLSSign(context.Context, *TLSSignRequest) (*TLSSignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TLSSign not implemented")
+}
+func (UnimplementedSignerServer) BLSPublicKey(context.Context, *BLSPublicKeyRequest) (*BLSPublicKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLSPublicKey not implemented")
+}
+func (UnimplementedSignerServer) BLSSign(context.Context, *BLSSignRequest) (*BLSSignResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLSSign not implemented")
+}
+func (UnimplementedSignerServer) BLSSignProofOfPossession(context.Context, *BLSSignProofOfPossessionRequest) (*BLSSignProofOfPossessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BLSSignProofOfPossession not implemented")
+}
+func (UnimplementedSignerServer) mustEmbedUnimplementedSignerServer() {}
+
+// UnsafeSignerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SignerServer will
+// result in compilation errors.
+type UnsafeSignerServer interface {
+	mustEmbedUnimplementedSignerServer()
+}
+
+func RegisterSignerServer(s grpc.ServiceRegistrar, srv SignerServer) {
+	s.RegisterService(&Signer_ServiceDesc, srv)
+}
+
+func _Signer_TLSCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TLSCertificateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).TLSCertificate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signer.Signer/TLSCertificate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).TLSCertificate(ctx, req.(*TLSCertificateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_TLSSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TLSSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).TLSSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signer.Signer/TLSSign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).TLSSign(ctx, req.(*TLSSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_BLSPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BLSPublicKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).BLSPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signer.Signer/BLSPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).BLSPublicKey(ctx, req.(*BLSPublicKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_BLSSign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BLSSignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).BLSSign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signer.Signer/BLSSign",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).BLSSign(ctx, req.(*BLSSignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Signer_BLSSignProofOfPossession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BLSSignProofOfPossessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SignerServer).BLSSignProofOfPossession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/signer.Signer/BLSSignProofOfPossession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SignerServer).BLSSignProofOfPossession(ctx, req.(*BLSSignProofOfPossessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Signer_ServiceDesc is the grpc.ServiceDesc for Signer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Signer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "signer.Signer",
+	HandlerType: (*SignerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "TLSCertificate",
+			Handler:    _Signer_TLSCertificate_Handler,
+		},
+		{
+			MethodName: "TLSSign",
+			Handler:    _Signer_TLSSign_Handler,
+		},
+		{
+			MethodName: "BLSPublicKey",
+			Handler:    _Signer_BLSPublicKey_Handler,
+		},
+		{
+			MethodName: "BLSSign",
+			Handler:    _Signer_BLSSign_Handler,
+		},
+		{
+			MethodName: "BLSSignProofOfPossession",
+			Handler:    _Signer_BLSSignProofOfPossession_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "signer/signer.proto",
+}
@@ -31,11 +31,12 @@ type TopologicalFactory struct{}
 
 func (TopologicalFactory) New() Consensus { return &Topological{} }
 
-// TODO: Implement pruning of decisions.
-// To perfectly preserve the protocol, this implementation will need to store
-// the hashes of all accepted decisions. It is possible to add a heuristic that
-// removes sufficiently old decisions. However, that will need to be analyzed to
-// ensure safety. It is doable when adding in a weak synchrony assumption.
+// Decisions aren't retained once they are decided. Accepted and rejected
+// vertices are removed from [nodes] and snowstorm removes decided
+// transactions, so the memory used is bounded by the number of processing
+// decisions. The statuses of decided vertices and transactions are read
+// through the Decidable interface, and it is the responsibility of their
+// storage to persist them.
 
 // Topological performs the avalanche algorithm by utilizing a topological sort
 // of the voting results. Assumes that vertices are inserted in topological
@@ -5,6 +5,76 @@ package avalanche
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowstorm"
 )
 
 func TestTopological(t *testing.T) { runConsensusTests(t, TopologicalFactory{}) }
+
+// Decided vertices must not be retained, so that the memory used by a long
+// running DAG is bounded by the number of processing vertices.
+func TestTopologicalDropsDecidedVertices(t *testing.T) {
+	require := require.New(t)
+
+	params := Parameters{
+		Parameters: snowball.Parameters{
+			K:                     1,
+			Alpha:                 1,
+			BetaVirtuous:          1,
+			BetaRogue:             2,
+			ConcurrentRepolls:     1,
+			OptimalProcessing:     1,
+			MaxOutstandingItems:   1,
+			MaxItemProcessingTime: 1,
+		},
+		Parents:   2,
+		BatchSize: 1,
+	}
+	genesis := &TestVertex{TestDecidable: choices.TestDecidable{
+		IDV:     ids.GenerateTestID(),
+		StatusV: choices.Accepted,
+	}}
+
+	ta := &Topological{}
+	require.NoError(ta.Initialize(snow.DefaultConsensusContextTest(), params, []Vertex{genesis}))
+
+	parent := Vertex(genesis)
+	for i := uint64(1); i <= 100; i++ {
+		tx := &snowstorm.TestTx{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			InputIDsV: []ids.ID{ids.GenerateTestID()},
+		}
+		vtx := &TestVertex{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentsV: []Vertex{parent},
+			HeightV:  i,
+			TxsV:     []snowstorm.Tx{tx},
+		}
+		require.NoError(ta.Add(vtx))
+
+		votes := ids.UniqueBag{}
+		votes.Add(0, vtx.IDV)
+		require.NoError(ta.RecordPoll(votes))
+		require.Equal(choices.Accepted, tx.Status())
+		require.Equal(choices.Accepted, vtx.Status())
+
+		require.Zero(ta.NumProcessing())
+		require.True(ta.Finalized())
+		require.Len(ta.frontier, 1)
+		require.LessOrEqual(len(ta.preferenceCache), 2)
+		require.LessOrEqual(len(ta.virtuousCache), 2)
+		parent = vtx
+	}
+}
@@ -55,4 +55,32 @@ type Consensus interface {
 
 	// HealthCheck returns information about the consensus health.
 	HealthCheck() (interface{}, error)
+
+	// Snapshot returns the blocks that are currently processing, for
+	// debugging consensus that isn't making progress.
+	Snapshot() Snapshot
+}
+
+// Snapshot describes the undecided frontier of a snowman instance
+type Snapshot struct {
+	LastAcceptedID     ids.ID `json:"lastAcceptedID"`
+	LastAcceptedHeight uint64 `json:"lastAcceptedHeight"`
+	// Confidence of the decision between the children of the last accepted
+	// block. Empty if no children have been issued.
+	LastAcceptedConfidence string `json:"lastAcceptedConfidence,omitempty"`
+	// Preference is the tail of the preferred chain
+	Preference ids.ID `json:"preference"`
+	// Blocks that are processing, sorted by height
+	Blocks []ProcessingBlock `json:"blocks"`
+}
+
+// ProcessingBlock describes a block that hasn't been decided yet
+type ProcessingBlock struct {
+	ID        ids.ID `json:"id"`
+	ParentID  ids.ID `json:"parentID"`
+	Height    uint64 `json:"height"`
+	Preferred bool   `json:"preferred"`
+	// Confidence of the decision between the children of this block. Empty if
+	// no children have been issued.
+	Confidence string `json:"confidence,omitempty"`
 }
@@ -63,6 +63,7 @@ var (
 		RandomizedConsistencyTest,
 		ErrorOnAddDecidedBlock,
 		ErrorOnAddDuplicateBlockID,
+		SnapshotTest,
 	}
 )
 
@@ -1811,3 +1812,93 @@ func gatherCounterGauge(t *testing.T, reg *prometheus.Registry) map[string]float
 	}
 	return mss
 }
+
+func SnapshotTest(t *testing.T, factory Factory) {
+	require := require.New(t)
+	sm := factory.New()
+
+	ctx := snow.DefaultConsensusContextTest()
+	params := snowball.Parameters{
+		K:                     1,
+		Alpha:                 1,
+		BetaVirtuous:          3,
+		BetaRogue:             5,
+		ConcurrentRepolls:     1,
+		OptimalProcessing:     1,
+		MaxOutstandingItems:   1,
+		MaxItemProcessingTime: 1,
+	}
+	require.NoError(sm.Initialize(ctx, params, GenesisID, GenesisHeight))
+
+	snapshot := sm.Snapshot()
+	require.Equal(GenesisID, snapshot.LastAcceptedID)
+	require.Equal(GenesisHeight, snapshot.LastAcceptedHeight)
+	require.Empty(snapshot.LastAcceptedConfidence)
+	require.Equal(GenesisID, snapshot.Preference)
+	require.Empty(snapshot.Blocks)
+
+	block0 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(1),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	block1 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(2),
+			StatusV: choices.Processing,
+		},
+		ParentV: block0.IDV,
+		HeightV: block0.HeightV + 1,
+	}
+	block2 := &TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.Empty.Prefix(3),
+			StatusV: choices.Processing,
+		},
+		ParentV: Genesis.IDV,
+		HeightV: Genesis.HeightV + 1,
+	}
+	require.NoError(sm.Add(block0))
+	require.NoError(sm.Add(block1))
+	require.NoError(sm.Add(block2))
+
+	votes := ids.Bag{}
+	votes.Add(block1.ID())
+	require.NoError(sm.RecordPoll(votes))
+
+	snapshot = sm.Snapshot()
+	require.Equal(GenesisID, snapshot.LastAcceptedID)
+	require.NotEmpty(snapshot.LastAcceptedConfidence)
+	require.Equal(block1.ID(), snapshot.Preference)
+	require.Len(snapshot.Blocks, 3)
+
+	blocks := make(map[ids.ID]ProcessingBlock)
+	for i, blk := range snapshot.Blocks {
+		if i > 0 {
+			require.LessOrEqual(snapshot.Blocks[i-1].Height, blk.Height)
+		}
+		blocks[blk.ID] = blk
+	}
+	require.Equal(ProcessingBlock{
+		ID:         block0.ID(),
+		ParentID:   GenesisID,
+		Height:     block0.Height(),
+		Preferred:  true,
+		Confidence: blocks[block0.ID()].Confidence,
+	}, blocks[block0.ID()])
+	require.NotEmpty(blocks[block0.ID()].Confidence)
+	require.Equal(ProcessingBlock{
+		ID:        block1.ID(),
+		ParentID:  block0.ID(),
+		Height:    block1.Height(),
+		Preferred: true,
+	}, blocks[block1.ID()])
+	require.Equal(ProcessingBlock{
+		ID:       block2.ID(),
+		ParentID: GenesisID,
+		Height:   block2.Height(),
+	}, blocks[block2.ID()])
+}
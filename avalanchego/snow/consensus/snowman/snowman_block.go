@@ -56,3 +56,12 @@ func (n *snowmanBlock) Accepted() bool {
 	}
 	return n.blk.Status() == choices.Accepted
 }
+
+// confidence returns the state of the decision between the children of this
+// block, or an empty string if no children have been issued
+func (n *snowmanBlock) confidence() string {
+	if n.sb == nil {
+		return ""
+	}
+	return n.sb.String()
+}
@@ -4,8 +4,10 @@
 package snowman
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"go.uber.org/zap"
@@ -316,6 +318,36 @@ func (ts *Topological) HealthCheck() (interface{}, error) {
 	return details, nil
 }
 
+func (ts *Topological) Snapshot() Snapshot {
+	snapshot := Snapshot{
+		LastAcceptedID:         ts.head,
+		LastAcceptedHeight:     ts.height,
+		LastAcceptedConfidence: ts.blocks[ts.head].confidence(),
+		Preference:             ts.tail,
+		Blocks:                 make([]ProcessingBlock, 0, ts.NumProcessing()),
+	}
+	for blkID, node := range ts.blocks {
+		if blkID == ts.head {
+			continue
+		}
+		snapshot.Blocks = append(snapshot.Blocks, ProcessingBlock{
+			ID:         blkID,
+			ParentID:   node.blk.Parent(),
+			Height:     node.blk.Height(),
+			Preferred:  ts.preferredIDs.Contains(blkID),
+			Confidence: node.confidence(),
+		})
+	}
+	sort.Slice(snapshot.Blocks, func(i, j int) bool {
+		blkI, blkJ := snapshot.Blocks[i], snapshot.Blocks[j]
+		if blkI.Height != blkJ.Height {
+			return blkI.Height < blkJ.Height
+		}
+		return bytes.Compare(blkI.ID[:], blkJ.ID[:]) < 0
+	})
+	return snapshot
+}
+
 // takes in a list of votes and sets up the topological ordering. Returns the
 // reachable section of the graph annotated with the number of inbound edges and
 // the non-transitively applied votes. Also returns the list of leaf blocks.
@@ -15,8 +15,10 @@ import (
 	"github.com/ava-labs/avalanchego/chains/atomic"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 )
 
 type SubnetLookup interface {
@@ -55,6 +57,10 @@ type Context struct {
 	ValidatorState    validators.State  // interface for P-Chain validators
 	StakingLeafSigner crypto.Signer     // block signer
 	StakingCertLeaf   *x509.Certificate // block certificate
+
+	// warp attributes
+	WarpSigner   warp.Signer   // signs messages sent by this chain
+	WarpVerifier warp.Verifier // verifies messages sent by other chains
 }
 
 // Expose gatherer interface for unit testing.
@@ -78,6 +84,21 @@ type ConsensusContext struct {
 	// accepted.
 	ConsensusAcceptor Acceptor
 
+	// Tracer starts the spans of the messages this chain handles
+	Tracer trace.Tracer
+
+	// TraceScope holds the context of the message this chain is handling
+	TraceScope trace.Scope
+
+	// QueryGate stops the consensus engine from starting new polls while it
+	// is closed. May be nil.
+	QueryGate *QueryGate
+
+	// GossipedContainers holds the containers this chain recently received
+	// via gossip, which aren't gossiped again when they're accepted. May be
+	// nil, in which case every accepted container is gossiped.
+	GossipedContainers *GossipedContainers
+
 	// Non-zero iff this chain bootstrapped.
 	state utils.AtomicInterface
 
@@ -86,6 +107,11 @@ type ConsensusContext struct {
 
 	// Indicates this chain is available to only validators.
 	validatorOnly utils.AtomicBool
+
+	// Non-validator nodes that are still allowed to interact with this chain
+	// when it is available to only validators. Must not be modified once the
+	// chain has started.
+	allowedNodes ids.NodeIDSet
 }
 
 func (ctx *ConsensusContext) SetState(newState State) {
@@ -118,6 +144,18 @@ func (ctx *ConsensusContext) SetValidatorOnly() {
 	ctx.validatorOnly.SetValue(true)
 }
 
+// IsAllowedNode returns true iff [nodeID] is allowed to interact with this
+// chain even if it isn't a validator
+func (ctx *ConsensusContext) IsAllowedNode(nodeID ids.NodeID) bool {
+	return ctx.allowedNodes.Contains(nodeID)
+}
+
+// AllowNodes allows [nodeIDs] to interact with this chain even if it is
+// available to only validators
+func (ctx *ConsensusContext) AllowNodes(nodeIDs ...ids.NodeID) {
+	ctx.allowedNodes.Add(nodeIDs...)
+}
+
 func DefaultContextTest() *Context {
 	return &Context{
 		NetworkID: 0,
@@ -136,5 +174,6 @@ func DefaultConsensusContextTest() *ConsensusContext {
 		Registerer:        prometheus.NewRegistry(),
 		DecisionAcceptor:  noOpAcceptor{},
 		ConsensusAcceptor: noOpAcceptor{},
+		Tracer:            trace.Noop,
 	}
 }
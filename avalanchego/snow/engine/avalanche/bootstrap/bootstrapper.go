@@ -145,6 +145,7 @@ func (b *bootstrapper) Ancestors(nodeID ids.NodeID, requestID uint32, vtxs [][]b
 
 		vtxs = vtxs[:b.Config.AncestorsMaxContainersReceived]
 	}
+	b.Config.WaitForBandwidth(vtxs)
 
 	requestedVtxID, requested := b.OutstandingRequests.Remove(nodeID, requestID)
 	vtx, err := b.Manager.ParseVtx(vtxs[0]) // first vertex should be the one we requested in GetAncestors request
@@ -134,13 +134,24 @@ func (i *issuer) Update() {
 		)
 	}
 
+	// The vertex is still added to consensus, so it's polled by the repolls
+	// once the node leaves maintenance.
+	paused := i.t.Ctx.QueryGate.Closed()
+	if paused {
+		i.t.queriesPaused = true
+		i.t.Ctx.Log.Debug("dropped query",
+			zap.String("reason", "the node is in maintenance"),
+			zap.Stringer("vtxID", vtxID),
+		)
+	}
+
 	vdrBag := ids.NodeIDBag{} // Validators to sample repr. as a set
 	for _, vdr := range vdrs {
 		vdrBag.Add(vdr.ID())
 	}
 
 	i.t.RequestID++
-	if err == nil && i.t.polls.Add(i.t.RequestID, vdrBag) {
+	if err == nil && !paused && i.t.polls.Add(i.t.RequestID, vdrBag) {
 		numPushTo := i.t.Params.MixedQueryNumPushVdr
 		if !i.t.Validators.Contains(i.t.Ctx.NodeID) {
 			numPushTo = i.t.Params.MixedQueryNumPushNonVdr
@@ -22,7 +22,11 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
-var _ Engine = &Transitive{}
+var (
+	_ Engine                    = &Transitive{}
+	_ common.ConsensusInspector = &Transitive{}
+	_ common.AppErrorHandler    = &Transitive{}
+)
 
 func New(config Config) (Engine, error) {
 	return newTransitive(config)
@@ -45,6 +49,9 @@ type Transitive struct {
 
 	polls poll.Set // track people I have asked for their preference
 
+	// true if a poll wasn't started because the node is in maintenance
+	queriesPaused bool
+
 	// The set of vertices that have been requested in Get messages but not yet received
 	outstandingVtxReqs common.Requests
 
@@ -250,6 +257,11 @@ func (t *Transitive) AppResponse(nodeID ids.NodeID, requestID uint32, response [
 	return t.VM.AppResponse(nodeID, requestID, response)
 }
 
+func (t *Transitive) AppError(nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	// Notify the VM that a request it made was refused
+	return common.NotifyAppError(t.VM, nodeID, requestID, appErr)
+}
+
 func (t *Transitive) AppGossip(nodeID ids.NodeID, msg []byte) error {
 	// Notify the VM of this message which has been gossiped to it
 	return t.VM.AppGossip(nodeID, msg)
@@ -266,6 +278,15 @@ func (t *Transitive) Disconnected(nodeID ids.NodeID) error {
 func (t *Transitive) Timeout() error { return nil }
 
 func (t *Transitive) Gossip() error {
+	// The processing vertices may have been left without a poll while the
+	// node was in maintenance.
+	if t.queriesPaused && !t.Ctx.QueryGate.Closed() {
+		t.queriesPaused = false
+		if t.Consensus.NumProcessing() > 0 {
+			t.repoll()
+		}
+	}
+
 	edge := t.Manager.Edge()
 	if len(edge) == 0 {
 		t.Ctx.Log.Verbo("dropping gossip request as no vertices have been accepted")
@@ -372,6 +393,16 @@ func (t *Transitive) HealthCheck() (interface{}, error) {
 	return intf, fmt.Errorf("vm: %s ; consensus: %s", vmErr, consensusErr)
 }
 
+func (t *Transitive) ConsensusInfo() common.ConsensusInfo {
+	return common.ConsensusInfo{
+		Engine:           "avalanche",
+		Params:           t.Params,
+		OutstandingPolls: t.polls.Len(),
+		Processing:       t.Consensus.NumProcessing(),
+		LastRequestID:    t.RequestID,
+	}
+}
+
 func (t *Transitive) GetVM() common.VM {
 	return t.VM
 }
@@ -602,6 +633,14 @@ func (t *Transitive) batch(txs []snowstorm.Tx, opt batchOption) ([]snowstorm.Tx,
 
 // Issues a new poll for a preferred vertex in order to move consensus along
 func (t *Transitive) issueRepoll() {
+	if t.Ctx.QueryGate.Closed() {
+		t.queriesPaused = true
+		t.Ctx.Log.Debug("dropped re-query",
+			zap.String("reason", "the node is in maintenance"),
+		)
+		return
+	}
+
 	preferredIDs := t.Consensus.Preferences()
 	if preferredIDs.Len() == 0 {
 		t.Ctx.Log.Error("re-query attempt was dropped due to no pending vertices")
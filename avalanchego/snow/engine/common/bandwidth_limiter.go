@@ -0,0 +1,11 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package common
+
+// BandwidthLimiter limits the rate that a chain fetches containers while
+// bootstrapping.
+type BandwidthLimiter interface {
+	// Wait blocks until [numBytes] more bytes may be fetched.
+	Wait(numBytes int)
+}
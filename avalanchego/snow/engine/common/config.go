@@ -43,6 +43,10 @@ type Config struct {
 	// containers in an ancestors message it receives.
 	AncestorsMaxContainersReceived int
 
+	// Limits the bytes of containers fetched while bootstrapping. If nil,
+	// fetching isn't limited.
+	BootstrapBandwidth BandwidthLimiter
+
 	SharedCfg *SharedConfig
 }
 
@@ -51,6 +55,19 @@ func (c *Config) Context() *snow.ConsensusContext { return c.Ctx }
 // IsBootstrapped returns true iff this chain is done bootstrapping
 func (c *Config) IsBootstrapped() bool { return c.Ctx.GetState() == snow.NormalOp }
 
+// WaitForBandwidth blocks until [containers] may be fetched by
+// [BootstrapBandwidth].
+func (c *Config) WaitForBandwidth(containers [][]byte) {
+	if c.BootstrapBandwidth == nil {
+		return
+	}
+	numBytes := 0
+	for _, container := range containers {
+		numBytes += len(container)
+	}
+	c.BootstrapBandwidth.Wait(numBytes)
+}
+
 // Shared among common.bootstrapper and snowman/avalanche bootstrapper
 type SharedConfig struct {
 	// Tracks the last requestID that was used in a request
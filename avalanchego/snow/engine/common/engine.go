@@ -4,6 +4,7 @@
 package common
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/ava-labs/avalanchego/api/health"
@@ -30,6 +31,47 @@ type Engine interface {
 	GetVM() VM
 }
 
+// ConsensusInfo describes the consensus parameters that an engine runs with
+// and the state of its polls
+type ConsensusInfo struct {
+	// Either "snowman" or "avalanche"
+	Engine string `json:"engine"`
+	// Parameters the engine was configured with. Their fields depend on the
+	// engine.
+	Params interface{} `json:"params"`
+	// Number of polls that were issued but haven't finished yet
+	OutstandingPolls int `json:"outstandingPolls"`
+	// Number of containers that are processing in consensus
+	Processing int `json:"processing"`
+	// ID of the most recent request the engine sent
+	LastRequestID uint32 `json:"lastRequestID"`
+}
+
+// ConsensusInspector is implemented by engines that can report their
+// ConsensusInfo. The chain's context lock must be held while calling it.
+type ConsensusInspector interface {
+	ConsensusInfo() ConsensusInfo
+}
+
+// ProcessingInspector is implemented by engines that can report the
+// containers that are processing in consensus. The chain's context lock must be
+// held while calling it.
+type ProcessingInspector interface {
+	// ProcessingSnapshot returns a JSON serializable description of the
+	// processing containers
+	ProcessingSnapshot() interface{}
+}
+
+// HeightInspector is implemented by engines of linear chains that can report
+// how far the chain is behind its peers. The chain's context lock must be held
+// while calling it.
+type HeightInspector interface {
+	// Heights returns the height of the last accepted block and the highest
+	// height of the blocks sent by peers. Peers can send blocks with any
+	// height, so the highest height is only a hint.
+	Heights() (lastAccepted uint64, highestObserved uint64, err error)
+}
+
 type Handler interface {
 	AllGetsServer
 	StateSummaryFrontierHandler
@@ -452,6 +494,44 @@ type AppHandler interface {
 	AppGossip(nodeID ids.NodeID, msg []byte) error
 }
 
+// AppError is sent by a peer in place of an AppResponse, to report that it
+// understood an AppRequest but refused to serve it
+type AppError struct {
+	// Application defined code of the error. Always > 0.
+	Code int32 `json:"code"`
+	// Human readable description of the error
+	Message string `json:"message"`
+}
+
+func (e *AppError) Error() string {
+	return fmt.Sprintf("app error %d: %s", e.Code, e.Message)
+}
+
+// AppErrorHandler is implemented by engines and VMs that handle AppErrors.
+// AppErrors are reported as AppRequestFailed to those that don't implement it.
+type AppErrorHandler interface {
+	// Notify this engine that [nodeID] refused the AppRequest message it sent
+	// with request ID [requestID].
+	//
+	// It is guaranteed that:
+	// * This engine sent a request to [nodeID] with ID [requestID].
+	// * AppRequestFailed([nodeID], [requestID]) has not already been called.
+	// * AppResponse([nodeID], [requestID]) has not already been called.
+	//
+	// It is not guaranteed that:
+	// * [appErr] is well-formed/valid.
+	AppError(nodeID ids.NodeID, requestID uint32, appErr *AppError) error
+}
+
+// NotifyAppError delivers [appErr] to [handler], falling back to
+// AppRequestFailed if [handler] doesn't handle AppErrors
+func NotifyAppError(handler AppHandler, nodeID ids.NodeID, requestID uint32, appErr *AppError) error {
+	if errHandler, ok := handler.(AppErrorHandler); ok {
+		return errHandler.AppError(nodeID, requestID, appErr)
+	}
+	return handler.AppRequestFailed(nodeID, requestID)
+}
+
 // InternalHandler defines how this consensus engine reacts to messages from
 // other components of this validator. Functions only return fatal errors if
 // they occur.
@@ -143,3 +143,15 @@ type AppSender interface {
 	SendAppGossip(appGossipBytes []byte) error
 	SendAppGossipSpecific(nodeIDs ids.NodeIDSet, appGossipBytes []byte) error
 }
+
+// AppErrorSender is implemented by AppSenders that can refuse an AppRequest
+// with an AppError, rather than overloading the payload of an AppResponse.
+// VMs must check for it, as not every AppSender implements it.
+type AppErrorSender interface {
+	// Send an application-level error in place of the response to a request.
+	// This error must be in response to an AppRequest that the VM
+	// corresponding to this AppSender received from [nodeID] with ID
+	// [requestID]. [errorCode] must be > 0.
+	// A non-nil error should be considered fatal.
+	SendAppError(nodeID ids.NodeID, requestID uint32, errorCode int32, errorMessage string) error
+}
@@ -27,7 +27,8 @@ const bootstrappingDelay = 10 * time.Second
 var (
 	_ common.BootstrapableEngine = &bootstrapper{}
 
-	errUnexpectedTimeout = errors.New("unexpected timeout fired")
+	errUnexpectedTimeout  = errors.New("unexpected timeout fired")
+	errCheckpointMismatch = errors.New("fetched block conflicts with the trusted checkpoint")
 )
 
 type bootstrapper struct {
@@ -177,6 +178,7 @@ func (b *bootstrapper) Ancestors(nodeID ids.NodeID, requestID uint32, blks [][]b
 			zap.Uint32("requestID", requestID),
 		)
 	}
+	b.Config.WaitForBandwidth(blks)
 
 	blocks, err := block.BatchedParseBlock(b.VM, blks)
 	if err != nil { // the provided blocks couldn't be parsed
@@ -424,6 +426,15 @@ func (b *bootstrapper) process(blk snowman.Block, processingBlocks map[ids.ID]sn
 			return b.checkFinish()
 		}
 
+		if b.TrustedCheckpointID != ids.Empty && blkHeight == b.TrustedCheckpointHeight && blkID != b.TrustedCheckpointID {
+			return fmt.Errorf("%w: fetched %s at height %d but the checkpoint is %s",
+				errCheckpointMismatch,
+				blkID,
+				blkHeight,
+				b.TrustedCheckpointID,
+			)
+		}
+
 		// If this block is going to be accepted, make sure to update the
 		// tipHeight for logging
 		if blkHeight > b.tipHeight {
@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common/tracker"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/version"
@@ -81,7 +82,7 @@ func newConfig(t *testing.T) (Config, ids.NodeID, *common.SenderTest, *block.Tes
 		SharedCfg:                      &common.SharedConfig{},
 	}
 
-	snowGetHandler, err := getter.New(vm, commonConfig)
+	snowGetHandler, err := getter.New(vm, commonConfig, syncserving.NoBudget{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -132,7 +133,7 @@ func TestBootstrapperStartsOnlyIfEnoughStakeIsConnected(t *testing.T) {
 	}
 
 	blocker, _ := queue.NewWithMissing(memdb.New(), "", prometheus.NewRegistry())
-	snowGetHandler, err := getter.New(vm, commonCfg)
+	snowGetHandler, err := getter.New(vm, commonCfg, syncserving.NoBudget{})
 	require.NoError(err)
 	cfg := Config{
 		Config:        commonCfg,
@@ -1414,3 +1415,84 @@ func TestBootstrapContinueAfterHalt(t *testing.T) {
 		t.Fatal("Should have left blk1 as missing")
 	}
 }
+
+// newTrustedCheckpointTest returns a bootstrapper whose VM has accepted a
+// genesis block, and knows of two processing blocks built on top of it.
+func newTrustedCheckpointTest(t *testing.T, checkpointID ids.ID) ([]*snowman.TestBlock, common.BootstrapableEngine) {
+	require := require.New(t)
+
+	config, _, _, vm := newConfig(t)
+
+	blks := []*snowman.TestBlock{{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Accepted,
+		},
+		HeightV: 0,
+		BytesV:  []byte{0},
+	}}
+	for i := 1; i < 3; i++ {
+		blks = append(blks, &snowman.TestBlock{
+			TestDecidable: choices.TestDecidable{
+				IDV:     ids.GenerateTestID(),
+				StatusV: choices.Processing,
+			},
+			ParentV: blks[i-1].IDV,
+			HeightV: uint64(i),
+			BytesV:  []byte{byte(i)},
+		})
+	}
+
+	vm.CantSetState = false
+	vm.CantLastAccepted = false
+	vm.LastAcceptedF = func() (ids.ID, error) { return blks[0].ID(), nil }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		for _, blk := range blks {
+			if blk.ID() == blkID {
+				return blk, nil
+			}
+		}
+		return nil, database.ErrNotFound
+	}
+	vm.ParseBlockF = func(blkBytes []byte) (snowman.Block, error) {
+		for _, blk := range blks {
+			if bytes.Equal(blk.Bytes(), blkBytes) {
+				return blk, nil
+			}
+		}
+		return nil, errUnknownBlock
+	}
+
+	config.TrustedCheckpointID = checkpointID
+	config.TrustedCheckpointHeight = 1
+	bs, err := New(
+		config,
+		func(lastReqID uint32) error { config.Ctx.SetState(snow.NormalOp); return nil },
+	)
+	require.NoError(err)
+	require.NoError(bs.Start(0))
+	return blks, bs
+}
+
+func TestBootstrapperTrustedCheckpoint(t *testing.T) {
+	require := require.New(t)
+
+	checkpointID := ids.GenerateTestID()
+	blks, bs := newTrustedCheckpointTest(t, checkpointID)
+	blks[1].IDV = checkpointID
+	blks[2].ParentV = checkpointID
+
+	require.NoError(bs.ForceAccepted([]ids.ID{blks[2].ID()}))
+	require.Equal(choices.Accepted, blks[1].Status())
+	require.Equal(choices.Accepted, blks[2].Status())
+}
+
+func TestBootstrapperTrustedCheckpointMismatch(t *testing.T) {
+	require := require.New(t)
+
+	blks, bs := newTrustedCheckpointTest(t, ids.GenerateTestID())
+
+	err := bs.ForceAccepted([]ids.ID{blks[2].ID()})
+	require.ErrorIs(err, errCheckpointMismatch)
+	require.Equal(choices.Processing, blks[1].Status())
+}
@@ -4,6 +4,7 @@
 package bootstrap
 
 import (
+	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/queue"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
@@ -23,4 +24,9 @@ type Config struct {
 	VM block.ChainVM
 
 	Bootstrapped func()
+
+	// If [TrustedCheckpointID] isn't empty, bootstrapping fails unless the
+	// fetched block at [TrustedCheckpointHeight] is [TrustedCheckpointID].
+	TrustedCheckpointID     ids.ID
+	TrustedCheckpointHeight uint64
 }
@@ -7,10 +7,13 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/metric"
 )
@@ -21,6 +24,7 @@ var _ common.AllGetsServer = &getter{}
 func New(
 	vm block.ChainVM,
 	commonCfg common.Config,
+	budget syncserving.Budget,
 ) (common.AllGetsServer, error) {
 	ssVM, _ := vm.(block.StateSyncableVM)
 	gh := &getter{
@@ -28,6 +32,7 @@ func New(
 		ssVM:   ssVM,
 		sender: commonCfg.Sender,
 		cfg:    commonCfg,
+		budget: budget,
 		log:    commonCfg.Ctx.Log,
 	}
 
@@ -47,6 +52,9 @@ type getter struct {
 	sender common.Sender
 	cfg    common.Config
 
+	// limits the state summaries and blocks served to syncing peers
+	budget syncserving.Budget
+
 	log              logging.Logger
 	getAncestorsBlks metric.Averager
 }
@@ -64,6 +72,19 @@ func (gh *getter) GetStateSummaryFrontier(nodeID ids.NodeID, requestID uint32) e
 		return nil
 	}
 
+	if !gh.budget.Acquire(nodeID, message.GetStateSummaryFrontier) {
+		gh.log.Debug("dropping GetStateSummaryFrontier message",
+			zap.String("reason", "serving budget exceeded"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	bytesServed := 0
+	defer func() {
+		gh.budget.Release(nodeID, message.GetStateSummaryFrontier, bytesServed)
+	}()
+
 	summary, err := gh.ssVM.GetLastStateSummary()
 	if err != nil {
 		gh.log.Debug("dropping GetStateSummaryFrontier message",
@@ -75,7 +96,9 @@ func (gh *getter) GetStateSummaryFrontier(nodeID ids.NodeID, requestID uint32) e
 		return nil
 	}
 
-	gh.sender.SendStateSummaryFrontier(nodeID, requestID, summary.Bytes())
+	summaryBytes := summary.Bytes()
+	bytesServed = len(summaryBytes)
+	gh.sender.SendStateSummaryFrontier(nodeID, requestID, summaryBytes)
 	return nil
 }
 
@@ -99,6 +122,19 @@ func (gh *getter) GetAcceptedStateSummary(nodeID ids.NodeID, requestID uint32, h
 		return nil
 	}
 
+	if !gh.budget.Acquire(nodeID, message.GetAcceptedStateSummary) {
+		gh.log.Debug("dropping GetAcceptedStateSummary message",
+			zap.String("reason", "serving budget exceeded"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+		)
+		return nil
+	}
+	bytesServed := 0
+	defer func() {
+		gh.budget.Release(nodeID, message.GetAcceptedStateSummary, bytesServed)
+	}()
+
 	summaryIDs := make([]ids.ID, 0, len(heights))
 	for _, height := range heights {
 		summary, err := gh.ssVM.GetStateSummary(height)
@@ -120,6 +156,7 @@ func (gh *getter) GetAcceptedStateSummary(nodeID ids.NodeID, requestID uint32, h
 		summaryIDs = append(summaryIDs, summary.ID())
 	}
 
+	bytesServed = len(summaryIDs) * hashing.HashLen
 	gh.sender.SendAcceptedStateSummary(nodeID, requestID, summaryIDs)
 	return nil
 }
@@ -145,6 +182,20 @@ func (gh *getter) GetAccepted(nodeID ids.NodeID, requestID uint32, containerIDs
 }
 
 func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, blkID ids.ID) error {
+	if !gh.budget.Acquire(nodeID, message.GetAncestors) {
+		gh.log.Debug("dropping GetAncestors message",
+			zap.String("reason", "serving budget exceeded"),
+			zap.Stringer("nodeID", nodeID),
+			zap.Uint32("requestID", requestID),
+			zap.Stringer("blkID", blkID),
+		)
+		return nil
+	}
+	bytesServed := 0
+	defer func() {
+		gh.budget.Release(nodeID, message.GetAncestors, bytesServed)
+	}()
+
 	ancestorsBytes, err := block.GetAncestors(
 		gh.vm,
 		blkID,
@@ -164,6 +215,9 @@ func (gh *getter) GetAncestors(nodeID ids.NodeID, requestID uint32, blkID ids.ID
 	}
 
 	gh.getAncestorsBlks.Observe(float64(len(ancestorsBytes)))
+	for _, blkBytes := range ancestorsBytes {
+		bytesServed += len(blkBytes)
+	}
 	gh.sender.SendAncestors(nodeID, requestID, ancestorsBytes)
 	return nil
 }
@@ -9,6 +9,8 @@ import (
 
 	"github.com/golang/mock/gomock"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
@@ -18,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/validators"
 )
 
@@ -99,7 +102,7 @@ func TestAcceptedFrontier(t *testing.T) {
 		return dummyBlk, nil
 	}
 
-	bsIntf, err := New(vm, config)
+	bsIntf, err := New(vm, config, syncserving.NoBudget{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -151,7 +154,7 @@ func TestFilterAccepted(t *testing.T) {
 		return blk1, nil
 	}
 
-	bsIntf, err := New(vm, config)
+	bsIntf, err := New(vm, config, syncserving.NoBudget{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -199,3 +202,46 @@ func TestFilterAccepted(t *testing.T) {
 		t.Fatalf("Blk shouldn't be accepted")
 	}
 }
+
+func TestGetStateSummaryFrontierServingBudget(t *testing.T) {
+	require := require.New(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	vm, sender, config := testSetup(t, ctrl)
+
+	summary := &block.TestStateSummary{
+		IDV:     ids.GenerateTestID(),
+		HeightV: 1,
+		BytesV:  []byte{1, 2, 3},
+	}
+	vm.MockStateSyncableVM.EXPECT().GetLastStateSummary().Return(summary, nil).Times(2)
+
+	budget, err := syncserving.NewBudget(syncserving.Config{
+		Enabled:               true,
+		MaxConcurrentPerPeer:  1,
+		BandwidthPerPeer:      1,
+		BandwidthBurstPerPeer: 2,
+	}, "", prometheus.NewRegistry())
+	require.NoError(err)
+
+	bs, err := New(vm, config, budget)
+	require.NoError(err)
+
+	served := make(map[ids.NodeID]int)
+	sender.SendStateSummaryFrontierF = func(nodeID ids.NodeID, _ uint32, summaryBytes []byte) {
+		require.Equal(summary.Bytes(), summaryBytes)
+		served[nodeID]++
+	}
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+
+	// The first summary exhausts the bandwidth budget of [nodeID0], so its
+	// second request is dropped.
+	require.NoError(bs.GetStateSummaryFrontier(nodeID0, 0))
+	require.NoError(bs.GetStateSummaryFrontier(nodeID0, 1))
+	require.NoError(bs.GetStateSummaryFrontier(nodeID1, 2))
+	require.Equal(map[ids.NodeID]int{nodeID0: 1, nodeID1: 1}, served)
+}
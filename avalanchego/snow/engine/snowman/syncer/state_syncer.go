@@ -520,6 +520,10 @@ func (ss *stateSyncer) AppRequestFailed(nodeID ids.NodeID, requestID uint32) err
 	return ss.VM.AppRequestFailed(nodeID, requestID)
 }
 
+func (ss *stateSyncer) AppError(nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	return common.NotifyAppError(ss.VM, nodeID, requestID, appErr)
+}
+
 func (ss *stateSyncer) Notify(msg common.Message) error {
 	if msg != common.StateSyncDone {
 		ss.Ctx.Log.Warn("received an unexpected message from the VM",
@@ -13,6 +13,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 )
@@ -80,7 +81,7 @@ func buildTestsObjects(t *testing.T, commonCfg *common.Config) (
 			T: t,
 		},
 	}
-	dummyGetter, err := getter.New(fullVM, *commonCfg)
+	dummyGetter, err := getter.New(fullVM, *commonCfg, syncserving.NoBudget{})
 	require.NoError(t, err)
 
 	cfg, err := NewConfig(*commonCfg, nil, dummyGetter, fullVM)
@@ -24,7 +24,13 @@ import (
 
 const nonVerifiedCacheSize = 128
 
-var _ Engine = &Transitive{}
+var (
+	_ Engine                     = &Transitive{}
+	_ common.ConsensusInspector  = &Transitive{}
+	_ common.ProcessingInspector = &Transitive{}
+	_ common.HeightInspector     = &Transitive{}
+	_ common.AppErrorHandler     = &Transitive{}
+)
 
 func New(config Config) (Engine, error) {
 	return newTransitive(config)
@@ -48,6 +54,12 @@ type Transitive struct {
 	// track outstanding preference requests
 	polls poll.Set
 
+	// true if a poll wasn't started because the node is in maintenance
+	queriesPaused bool
+
+	// highest height of the blocks sent by peers
+	highestObservedHeight uint64
+
 	// blocks that have we have sent get requests for but haven't yet received
 	blkReqs common.Requests
 
@@ -127,6 +139,7 @@ func (t *Transitive) Put(nodeID ids.NodeID, requestID uint32, blkBytes []byte) e
 		// abandon the request.
 		return t.GetFailed(nodeID, requestID)
 	}
+	t.observeHeight(blk)
 
 	actualBlkID := blk.ID()
 	expectedBlkID, ok := t.blkReqs.Get(nodeID, requestID)
@@ -209,6 +222,7 @@ func (t *Transitive) PushQuery(nodeID ids.NodeID, requestID uint32, blkBytes []b
 		)
 		return nil
 	}
+	t.observeHeight(blk)
 
 	if t.wasIssued(blk) {
 		t.metrics.numUselessPushQueryBytes.Add(float64(len(blkBytes)))
@@ -294,6 +308,11 @@ func (t *Transitive) AppResponse(nodeID ids.NodeID, requestID uint32, response [
 	return t.VM.AppResponse(nodeID, requestID, response)
 }
 
+func (t *Transitive) AppError(nodeID ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	// Notify the VM that a request it made was refused
+	return common.NotifyAppError(t.VM, nodeID, requestID, appErr)
+}
+
 func (t *Transitive) AppGossip(nodeID ids.NodeID, msg []byte) error {
 	// Notify the VM of this message which has been gossiped to it
 	return t.VM.AppGossip(nodeID, msg)
@@ -310,6 +329,15 @@ func (t *Transitive) Disconnected(nodeID ids.NodeID) error {
 func (t *Transitive) Timeout() error { return nil }
 
 func (t *Transitive) Gossip() error {
+	// The processing blocks may have been left without a poll while the node
+	// was in maintenance.
+	if t.queriesPaused && !t.Ctx.QueryGate.Closed() {
+		t.queriesPaused = false
+		if t.Consensus.NumProcessing() > 0 {
+			t.repoll()
+		}
+	}
+
 	blkID, err := t.VM.LastAccepted()
 	if err != nil {
 		return err
@@ -427,6 +455,40 @@ func (t *Transitive) HealthCheck() (interface{}, error) {
 	return intf, fmt.Errorf("vm: %s ; consensus: %s", vmErr, consensusErr)
 }
 
+func (t *Transitive) ConsensusInfo() common.ConsensusInfo {
+	return common.ConsensusInfo{
+		Engine:           "snowman",
+		Params:           t.Params,
+		OutstandingPolls: t.polls.Len(),
+		Processing:       t.Consensus.NumProcessing(),
+		LastRequestID:    t.RequestID,
+	}
+}
+
+func (t *Transitive) ProcessingSnapshot() interface{} {
+	return t.Consensus.Snapshot()
+}
+
+// Heights implements common.HeightInspector. The highest observed height is
+// the height of the highest block parsed from a peer's Put or PushQuery.
+func (t *Transitive) Heights() (uint64, uint64, error) {
+	lastAcceptedID, err := t.VM.LastAccepted()
+	if err != nil {
+		return 0, 0, err
+	}
+	lastAccepted, err := t.GetBlock(lastAcceptedID)
+	if err != nil {
+		return 0, 0, err
+	}
+	return lastAccepted.Height(), t.highestObservedHeight, nil
+}
+
+func (t *Transitive) observeHeight(blk snowman.Block) {
+	if height := blk.Height(); height > t.highestObservedHeight {
+		t.highestObservedHeight = height
+	}
+}
+
 func (t *Transitive) GetVM() common.VM {
 	return t.VM
 }
@@ -660,6 +722,15 @@ func (t *Transitive) sendRequest(nodeID ids.NodeID, blkID ids.ID) {
 
 // send a pull query for this block ID
 func (t *Transitive) pullQuery(blkID ids.ID) {
+	if t.Ctx.QueryGate.Closed() {
+		t.queriesPaused = true
+		t.Ctx.Log.Debug("dropped query for block",
+			zap.String("reason", "the node is in maintenance"),
+			zap.Stringer("blkID", blkID),
+		)
+		return
+	}
+
 	t.Ctx.Log.Verbo("sampling from validators",
 		zap.Stringer("validators", t.Validators),
 	)
@@ -690,6 +761,15 @@ func (t *Transitive) pullQuery(blkID ids.ID) {
 // Send a query for this block. Some validators will be sent
 // a Push Query and some will be sent a Pull Query.
 func (t *Transitive) sendMixedQuery(blk snowman.Block) {
+	if t.Ctx.QueryGate.Closed() {
+		t.queriesPaused = true
+		t.Ctx.Log.Debug("dropped query for block",
+			zap.String("reason", "the node is in maintenance"),
+			zap.Stringer("blkID", blk.ID()),
+		)
+		return
+	}
+
 	t.Ctx.Log.Verbo("sampling from validators",
 		zap.Stringer("validators", t.Validators),
 	)
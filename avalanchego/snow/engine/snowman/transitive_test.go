@@ -12,12 +12,14 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowball"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/getter"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -47,7 +49,7 @@ func setup(t *testing.T, commonCfg common.Config, engCfg Config) (ids.NodeID, va
 	vm.T = t
 	engCfg.VM = vm
 
-	snowGetHandler, err := getter.New(vm, commonCfg)
+	snowGetHandler, err := getter.New(vm, commonCfg, syncserving.NoBudget{})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -996,6 +998,100 @@ func TestVoteCanceling(t *testing.T) {
 	}
 }
 
+func TestEngineQueryGate(t *testing.T) {
+	require := require.New(t)
+
+	_, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+
+	gate := &snow.QueryGate{}
+	gate.SetClosed(true)
+	te.Ctx.QueryGate = gate
+
+	queried := []ids.ID(nil)
+	sender.SendPushQueryF = func(ids.NodeIDSet, uint32, []byte) {
+		queried = append(queried, ids.Empty)
+	}
+	sender.SendPullQueryF = func(_ ids.NodeIDSet, _ uint32, blkID ids.ID) {
+		queried = append(queried, blkID)
+	}
+	sender.SendGossipF = func([]byte) {}
+	vm.LastAcceptedF = func() (ids.ID, error) { return gBlk.ID(), nil }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == gBlk.ID() {
+			return gBlk, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: gBlk.ID(),
+		HeightV: 1,
+		BytesV:  []byte{1},
+	}
+
+	// The block is issued to consensus, but isn't polled while the gate is
+	// closed.
+	require.NoError(te.issue(blk))
+	require.True(te.Consensus.Processing(blk.ID()))
+	require.NoError(te.Gossip())
+	require.Empty(queried)
+
+	// Once the gate opens, the block is polled on the next gossip.
+	gate.SetClosed(false)
+	require.NoError(te.Gossip())
+	require.Equal([]ids.ID{blk.ID()}, queried)
+
+	require.NoError(te.Gossip())
+	require.Len(queried, 1)
+}
+
+func TestEngineHeights(t *testing.T) {
+	require := require.New(t)
+
+	_, _, sender, vm, te, gBlk := setupDefaultConfig(t)
+	sender.Default(false)
+
+	vm.LastAcceptedF = func() (ids.ID, error) { return gBlk.ID(), nil }
+	vm.GetBlockF = func(blkID ids.ID) (snowman.Block, error) {
+		if blkID == gBlk.ID() {
+			return gBlk, nil
+		}
+		return nil, errUnknownBlock
+	}
+
+	// A block far ahead of the last accepted block, whose ancestors are
+	// unknown.
+	blk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		ParentV: ids.GenerateTestID(),
+		HeightV: 10,
+		BytesV:  []byte{1},
+	}
+	vm.ParseBlockF = func(b []byte) (snowman.Block, error) {
+		require.Equal(blk.Bytes(), b)
+		return blk, nil
+	}
+
+	lastAccepted, highestObserved, err := te.Heights()
+	require.NoError(err)
+	require.Equal(gBlk.Height(), lastAccepted)
+	require.Zero(highestObserved)
+
+	require.NoError(te.PushQuery(ids.GenerateTestNodeID(), 0, blk.Bytes()))
+
+	lastAccepted, highestObserved, err = te.Heights()
+	require.NoError(err)
+	require.Equal(gBlk.Height(), lastAccepted)
+	require.Equal(blk.Height(), highestObserved)
+}
+
 func TestEngineNoQuery(t *testing.T) {
 	engCfg := DefaultConfigs()
 
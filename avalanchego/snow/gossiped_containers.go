@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/buffer"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// GossipedContainers records the containers that a chain recently received
+// via gossip. Such containers were already gossiped by the peer that sent them,
+// so the chain doesn't gossip them again when they're accepted.
+//
+// Containers are keyed by the hash of their bytes, which is the ID of vertices
+// and of snowman++ blocks.
+type GossipedContainers struct {
+	ttl   time.Duration
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// container hash --> time the container was last received
+	received map[ids.ID]time.Time
+	// Containers in the order they were received. May hold stale entries for
+	// containers that were received again since.
+	queue buffer.UnboundedQueue[gossipedContainer]
+}
+
+type gossipedContainer struct {
+	id       ids.ID
+	received time.Time
+}
+
+// NewGossipedContainers returns a set of the containers received via gossip
+// in the last [ttl].
+func NewGossipedContainers(ttl time.Duration) *GossipedContainers {
+	return &GossipedContainers{
+		ttl:      ttl,
+		received: make(map[ids.ID]time.Time),
+		queue:    buffer.NewUnboundedSliceQueue[gossipedContainer](16),
+	}
+}
+
+// Add records that [container] was received via gossip. Add is a no-op on a
+// nil set.
+func (g *GossipedContainers) Add(container []byte) {
+	if g == nil {
+		return
+	}
+
+	id := ids.ID(hashing.ComputeHash256Array(container))
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	now := g.clock.Time()
+	g.prune(now)
+	g.received[id] = now
+	g.queue.Enqueue(gossipedContainer{
+		id:       id,
+		received: now,
+	})
+}
+
+// Contains returns true if [container] was received via gossip in the last
+// [ttl]. A nil set doesn't contain any container.
+func (g *GossipedContainers) Contains(container []byte) bool {
+	if g == nil {
+		return false
+	}
+
+	id := ids.ID(hashing.ComputeHash256Array(container))
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	g.prune(g.clock.Time())
+	_, ok := g.received[id]
+	return ok
+}
+
+// prune removes the containers that were last received more than [ttl] before
+// [now].
+// Assumes [g.lock] is held.
+func (g *GossipedContainers) prune(now time.Time) {
+	for {
+		oldest, ok := g.queue.PeekHead()
+		if !ok || now.Sub(oldest.received) <= g.ttl {
+			return
+		}
+		_, _ = g.queue.Dequeue()
+
+		// The container may have been received again since.
+		if received := g.received[oldest.id]; received.Equal(oldest.received) {
+			delete(g.received, oldest.id)
+		}
+	}
+}
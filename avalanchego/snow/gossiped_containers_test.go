@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGossipedContainers(t *testing.T) {
+	require := require.New(t)
+
+	g := NewGossipedContainers(time.Minute)
+	now := time.Now()
+	g.clock.Set(now)
+
+	container0 := []byte{0}
+	container1 := []byte{1}
+	require.False(g.Contains(container0))
+
+	g.Add(container0)
+	require.True(g.Contains(container0))
+	require.False(g.Contains(container1))
+
+	// Receiving a container again extends its lifetime.
+	g.clock.Set(now.Add(30 * time.Second))
+	g.Add(container1)
+	g.Add(container0)
+
+	g.clock.Set(now.Add(time.Minute + time.Second))
+	require.True(g.Contains(container0))
+	require.True(g.Contains(container1))
+
+	g.clock.Set(now.Add(2 * time.Minute))
+	require.False(g.Contains(container0))
+	require.False(g.Contains(container1))
+	require.Empty(g.received)
+}
+
+func TestGossipedContainersNil(t *testing.T) {
+	require := require.New(t)
+
+	var g *GossipedContainers
+	g.Add([]byte{0})
+	require.False(g.Contains([]byte{0}))
+}
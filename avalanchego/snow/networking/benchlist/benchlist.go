@@ -14,8 +14,10 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/timer"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
@@ -23,7 +25,10 @@ import (
 	safemath "github.com/ava-labs/avalanchego/utils/math"
 )
 
-var _ heap.Interface = &benchedQueue{}
+var (
+	_ heap.Interface                 = &benchedQueue{}
+	_ validators.SetCallbackListener = &benchlist{}
+)
 
 // If a peer consistently does not respond to queries, it will
 // increase latencies on the network whenever that peer is polled.
@@ -105,6 +110,10 @@ type benchlist struct {
 	// Validator set of the network
 	vdrs validators.Set
 
+	// Persists the benched validators so that they remain benched across
+	// restarts
+	db database.Database
+
 	// Validator ID --> Consecutive failure information
 	// [streaklock] must be held when touching [failureStreaks]
 	streaklock     sync.Mutex
@@ -113,6 +122,13 @@ type benchlist struct {
 	// IDs of validators that are currently benched
 	benchlistSet ids.NodeIDSet
 
+	// IDs of benched validators that were restored from [db]. Their stake is
+	// checked against [maxPortion] whenever [vdrsChanged] is set.
+	restored ids.NodeIDSet
+	// Set when [vdrs] changes. Only accessed atomically so that it can be set
+	// while [vdrs] is locked.
+	vdrsChanged utils.AtomicBool
+
 	// Min heap containing benched validators and their endtimes
 	// Pop() returns the next validator to leave
 	benchedQueue benchedQueue
@@ -142,6 +158,7 @@ func NewBenchlist(
 	duration time.Duration,
 	maxPortion float64,
 	registerer prometheus.Registerer,
+	db database.Database,
 ) (Benchlist, error) {
 	if maxPortion < 0 || maxPortion >= 1 {
 		return nil, fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", maxPortion)
@@ -153,14 +170,129 @@ func NewBenchlist(
 		benchlistSet:           ids.NodeIDSet{},
 		benchable:              benchable,
 		vdrs:                   validators,
+		db:                     db,
 		threshold:              threshold,
 		minimumFailingDuration: minimumFailingDuration,
 		duration:               duration,
 		maxPortion:             maxPortion,
 	}
+	if err := benchlist.metrics.Initialize(registerer); err != nil {
+		return nil, err
+	}
 	benchlist.timer = timer.NewTimer(benchlist.update)
+	if err := benchlist.restore(); err != nil {
+		return nil, err
+	}
+	validators.RegisterCallbackListener(benchlist)
 	go benchlist.timer.Dispatch()
-	return benchlist, benchlist.metrics.Initialize(registerer)
+	return benchlist, nil
+}
+
+// restore benches the validators that were still benched when the node was
+// last shut down. The stake limit isn't checked here because the validator set
+// may not be populated yet. It's enforced by [enforceRestoredMaxPortion] once
+// the validator set changes.
+func (b *benchlist) restore() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	it := b.db.NewIterator()
+	defer it.Release()
+
+	now := b.clock.Time()
+	for it.Next() {
+		nodeID, record, err := parseBenched(it.Key(), it.Value())
+		if err != nil {
+			return err
+		}
+		if !now.Before(record.benchedUntil) {
+			if err := deleteBenched(b.db, nodeID); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b.benchlistSet.Add(nodeID)
+		b.restored.Add(nodeID)
+		b.benchable.Benched(b.chainID, nodeID)
+		heap.Push(
+			&b.benchedQueue,
+			&benchData{nodeID: nodeID, benchedUntil: record.benchedUntil},
+		)
+		b.log.Debug("restored benched validator",
+			zap.Stringer("nodeID", nodeID),
+			zap.String("reason", record.reason),
+			zap.Time("benchedUntil", record.benchedUntil),
+		)
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+
+	b.setNextLeaveTime()
+	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
+	return nil
+}
+
+// enforceRestoredMaxPortion unbenches restored validators until the benched
+// stake is at most [maxPortion] of the total stake. Does nothing unless the
+// validator set changed since the last call.
+func (b *benchlist) enforceRestoredMaxPortion() {
+	if !b.vdrsChanged.GetValue() {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	// Any change made to the validator set from now on is checked by the next
+	// call
+	b.vdrsChanged.SetValue(false)
+	if b.restored.Len() == 0 {
+		return
+	}
+
+	maxBenchedStake := float64(b.vdrs.Weight()) * b.maxPortion
+	// [remove] modifies [b.benchedQueue], so iterate over a copy of it
+	benched := make([]*benchData, len(b.benchedQueue))
+	copy(benched, b.benchedQueue)
+	for _, node := range benched {
+		benchedStake, err := b.vdrs.SubsetWeight(b.benchlistSet)
+		if err != nil {
+			// This should never happen
+			b.log.Error("couldn't get benched stake",
+				zap.Error(err),
+			)
+			return
+		}
+		if float64(benchedStake) <= maxBenchedStake {
+			break
+		}
+		if !b.restored.Contains(node.nodeID) {
+			continue
+		}
+
+		b.log.Debug("unbenching restored node",
+			zap.String("reason", "benched stake exceeds max"),
+			zap.Stringer("nodeID", node.nodeID),
+			zap.Float64("benchedStake", float64(benchedStake)),
+			zap.Float64("maxBenchedStake", maxBenchedStake),
+		)
+		b.remove(node)
+	}
+	b.setNextLeaveTime()
+}
+
+func (b *benchlist) OnValidatorAdded(ids.NodeID, uint64) {
+	b.vdrsChanged.SetValue(true)
+}
+
+func (b *benchlist) OnValidatorRemoved(ids.NodeID, uint64) {
+	b.vdrsChanged.SetValue(true)
+}
+
+func (b *benchlist) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {
+	b.vdrsChanged.SetValue(true)
 }
 
 // Update removes benched validators whose time on the bench is over
@@ -192,7 +324,14 @@ func (b *benchlist) remove(node *benchData) {
 	)
 	heap.Remove(&b.benchedQueue, node.index)
 	b.benchlistSet.Remove(id)
+	b.restored.Remove(id)
 	b.benchable.Unbenched(b.chainID, id)
+	if err := deleteBenched(b.db, id); err != nil {
+		b.log.Error("couldn't remove node from persisted benchlist",
+			zap.Stringer("nodeID", id),
+			zap.Error(err),
+		)
+	}
 
 	// Update metrics
 	b.metrics.numBenched.Set(float64(b.benchedQueue.Len()))
@@ -237,6 +376,8 @@ func (b *benchlist) setNextLeaveTime() {
 // IsBenched returns true if messages to [nodeID]
 // should not be sent over the network and should immediately fail.
 func (b *benchlist) IsBenched(nodeID ids.NodeID) bool {
+	b.enforceRestoredMaxPortion()
+
 	b.lock.RLock()
 	defer b.lock.RUnlock()
 	return b.isBenched(nodeID)
@@ -261,6 +402,8 @@ func (b *benchlist) RegisterResponse(nodeID ids.NodeID) {
 
 // RegisterResponse notes that a request to validator [validatorID] timed out
 func (b *benchlist) RegisterFailure(nodeID ids.NodeID) {
+	b.enforceRestoredMaxPortion()
+
 	b.lock.Lock()
 	defer b.lock.Unlock()
 
@@ -348,6 +491,16 @@ func (b *benchlist) bench(nodeID ids.NodeID) {
 		&b.benchedQueue,
 		&benchData{nodeID: nodeID, benchedUntil: benchedUntil},
 	)
+	err = putBenched(b.db, nodeID, benchedRecord{
+		benchedUntil: benchedUntil,
+		reason:       reasonFailedQueries,
+	})
+	if err != nil {
+		b.log.Error("couldn't persist benched node",
+			zap.Stringer("nodeID", nodeID),
+			zap.Error(err),
+		)
+	}
 	b.log.Debug("benching validator after consecutive failed queries",
 		zap.Stringer("nodeID", nodeID),
 		zap.Duration("benchDuration", benchedUntil.Sub(now)),
@@ -11,6 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/logging"
@@ -56,6 +57,7 @@ func TestBenchlistAdd(t *testing.T) {
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		memdb.New(),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -194,6 +196,7 @@ func TestBenchlistMaxStake(t *testing.T) {
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		memdb.New(),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -334,6 +337,7 @@ func TestBenchlistRemove(t *testing.T) {
 		duration,
 		maxPortion,
 		prometheus.NewRegistry(),
+		memdb.New(),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -414,3 +418,142 @@ func TestBenchlistRemove(t *testing.T) {
 
 	require.Equal(t, 3, count)
 }
+
+// Test that benched validators remain benched after a restart
+func TestBenchlistRestore(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewSet()
+	vdr0 := validators.GenerateRandomValidator(50)
+	vdr1 := validators.GenerateRandomValidator(50)
+	require.NoError(vdrs.AddWeight(vdr0.ID(), vdr0.Weight()))
+	require.NoError(vdrs.AddWeight(vdr1.ID(), vdr1.Weight()))
+
+	benchable := &TestBenchable{T: t}
+
+	db := memdb.New()
+	threshold := 1
+	duration := time.Hour
+	maxPortion := 0.5
+	benchIntf, err := NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		threshold,
+		0,
+		duration,
+		maxPortion,
+		prometheus.NewRegistry(),
+		db,
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	b.RegisterFailure(vdr0.ID())
+	b.RegisterFailure(vdr0.ID())
+	require.True(b.IsBenched(vdr0.ID()))
+	b.timer.Stop()
+
+	// An expired entry should be dropped on restart
+	require.NoError(putBenched(db, vdr1.ID(), benchedRecord{
+		benchedUntil: time.Now().Add(-time.Minute),
+		reason:       reasonFailedQueries,
+	}))
+
+	benchIntf, err = NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		threshold,
+		0,
+		duration,
+		maxPortion,
+		prometheus.NewRegistry(),
+		db,
+	)
+	require.NoError(err)
+	b = benchIntf.(*benchlist)
+	defer b.timer.Stop()
+
+	require.True(b.IsBenched(vdr0.ID()))
+	require.False(b.IsBenched(vdr1.ID()))
+	require.Equal(1, b.benchedQueue.Len())
+	has, err := db.Has(vdr1.ID().Bytes())
+	require.NoError(err)
+	require.False(has)
+
+	record, err := db.Get(vdr0.ID().Bytes())
+	require.NoError(err)
+	_, restored, err := parseBenched(vdr0.ID().Bytes(), record)
+	require.NoError(err)
+	require.Equal(reasonFailedQueries, restored.reason)
+}
+
+// Test that restored validators are unbenched if they exceed the max portion
+// of stake once the validator set is populated
+func TestBenchlistRestoreMaxStake(t *testing.T) {
+	require := require.New(t)
+
+	vdr0 := validators.GenerateRandomValidator(50)
+	vdr1 := validators.GenerateRandomValidator(50)
+	vdr2 := validators.GenerateRandomValidator(50)
+
+	db := memdb.New()
+	benchedUntil := time.Now().Add(time.Hour)
+	for _, vdr := range []validators.Validator{vdr0, vdr1} {
+		require.NoError(putBenched(db, vdr.ID(), benchedRecord{
+			benchedUntil: benchedUntil,
+			reason:       reasonFailedQueries,
+		}))
+	}
+
+	// The validator set isn't populated yet when the benchlist is restored
+	vdrs := validators.NewSet()
+	unbenched := ids.NodeIDSet{}
+	benchable := &TestBenchable{
+		T: t,
+		UnbenchedF: func(_ ids.ID, nodeID ids.NodeID) {
+			unbenched.Add(nodeID)
+		},
+	}
+	benchIntf, err := NewBenchlist(
+		ids.Empty,
+		logging.NoLog{},
+		benchable,
+		vdrs,
+		1,
+		0,
+		time.Hour,
+		0.5,
+		prometheus.NewRegistry(),
+		db,
+	)
+	require.NoError(err)
+	b := benchIntf.(*benchlist)
+	defer b.timer.Stop()
+
+	require.True(b.IsBenched(vdr0.ID()))
+	require.True(b.IsBenched(vdr1.ID()))
+
+	// Benching both validators would bench 100 out of 150 stake
+	require.NoError(vdrs.AddWeight(vdr0.ID(), vdr0.Weight()))
+	require.NoError(vdrs.AddWeight(vdr1.ID(), vdr1.Weight()))
+	require.NoError(vdrs.AddWeight(vdr2.ID(), vdr2.Weight()))
+
+	numBenched := 0
+	for _, vdr := range []validators.Validator{vdr0, vdr1} {
+		isBenched := b.IsBenched(vdr.ID())
+		require.Equal(!isBenched, unbenched.Contains(vdr.ID()))
+
+		has, err := db.Has(vdr.ID().Bytes())
+		require.NoError(err)
+		require.Equal(isBenched, has)
+		if isBenched {
+			numBenched++
+		}
+	}
+	require.Equal(1, numBenched)
+	require.Equal(1, b.benchedQueue.Len())
+	require.Equal(1, b.restored.Len())
+}
@@ -5,9 +5,12 @@ package benchlist
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/validators"
@@ -43,15 +46,54 @@ type Manager interface {
 	GetBenched(nodeID ids.NodeID) []ids.ID
 }
 
+// Parameters define when validators are benched
+type Parameters struct {
+	// Number of consecutive failed queries before a validator is benched
+	Threshold int `json:"threshold" yaml:"threshold"`
+	// Minimum amount of time queries must have been failing before a
+	// validator is benched
+	MinimumFailingDuration time.Duration `json:"minimumFailingDuration" yaml:"minimumFailingDuration"`
+	// Maximum amount of time a validator is benched for
+	Duration time.Duration `json:"duration" yaml:"duration"`
+	// Maximum portion of stake that may be benched. 0 disables benching.
+	MaxPortion float64 `json:"maxPortion" yaml:"maxPortion"`
+}
+
+// Valid returns nil if the parameters describe a valid benchlist
+func (p Parameters) Valid() error {
+	switch {
+	case p.Threshold < 0:
+		return fmt.Errorf("threshold must be >= 0 but got %d", p.Threshold)
+	case p.MinimumFailingDuration < 0:
+		return fmt.Errorf("minimum failing duration must be >= 0 but got %s", p.MinimumFailingDuration)
+	case p.Duration < 0:
+		return fmt.Errorf("duration must be >= 0 but got %s", p.Duration)
+	case p.MaxPortion < 0 || p.MaxPortion >= 1:
+		return fmt.Errorf("max portion of benched stake must be in [0,1) but got %f", p.MaxPortion)
+	default:
+		return nil
+	}
+}
+
 // Config defines the configuration for a benchlist
 type Config struct {
-	Benchable              Benchable          `json:"-"`
-	Validators             validators.Manager `json:"-"`
-	StakingEnabled         bool               `json:"-"`
-	Threshold              int                `json:"threshold"`
-	MinimumFailingDuration time.Duration      `json:"minimumFailingDuration"`
-	Duration               time.Duration      `json:"duration"`
-	MaxPortion             float64            `json:"maxPortion"`
+	Benchable      Benchable          `json:"-"`
+	Validators     validators.Manager `json:"-"`
+	StakingEnabled bool               `json:"-"`
+	DB             database.Database  `json:"-"`
+	// Parameters of the benchlists of chains whose subnet isn't in
+	// [SubnetParameters]
+	Parameters
+	// Subnet ID --> parameters of the benchlists of the subnet's chains
+	SubnetParameters map[ids.ID]Parameters `json:"subnetParameters,omitempty"`
+}
+
+// parameters returns the parameters of the benchlists of [subnetID]'s chains
+func (c *Config) parameters(subnetID ids.ID) Parameters {
+	if params, ok := c.SubnetParameters[subnetID]; ok {
+		return params
+	}
+	return c.Parameters
 }
 
 type manager struct {
@@ -66,8 +108,12 @@ type manager struct {
 // NewManager returns a manager for chain-specific query benchlisting
 func NewManager(config *Config) Manager {
 	// If the maximum portion of validators allowed to be benchlisted
-	// is 0, return the no-op benchlist
-	if config.MaxPortion <= 0 {
+	// is 0 for every subnet, return the no-op benchlist
+	enabled := config.MaxPortion > 0
+	for _, params := range config.SubnetParameters {
+		enabled = enabled || params.MaxPortion > 0
+	}
+	if !enabled {
 		return NewNoBenchlist()
 	}
 	return &manager{
@@ -115,6 +161,12 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		return nil
 	}
 
+	// Chains without a benchlist are never benched
+	params := m.config.parameters(ctx.SubnetID)
+	if params.MaxPortion <= 0 {
+		return nil
+	}
+
 	var (
 		vdrs validators.Set
 		ok   bool
@@ -134,11 +186,12 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 		ctx.Log,
 		m.config.Benchable,
 		vdrs,
-		m.config.Threshold,
-		m.config.MinimumFailingDuration,
-		m.config.Duration,
-		m.config.MaxPortion,
+		params.Threshold,
+		params.MinimumFailingDuration,
+		params.Duration,
+		params.MaxPortion,
 		ctx.Registerer,
+		prefixdb.New(ctx.ChainID[:], m.config.DB),
 	)
 	if err != nil {
 		return err
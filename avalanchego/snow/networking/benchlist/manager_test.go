@@ -0,0 +1,147 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestManagerSubnetParameters(t *testing.T) {
+	require := require.New(t)
+
+	lenientSubnetID := ids.GenerateTestID()
+	strictSubnetID := ids.GenerateTestID()
+	vdr := validators.GenerateRandomValidator(50)
+
+	vdrs := validators.NewManager()
+	for _, subnetID := range []ids.ID{constants.PrimaryNetworkID, lenientSubnetID, strictSubnetID} {
+		set := validators.NewSet()
+		require.NoError(set.AddWeight(vdr.ID(), vdr.Weight()))
+		for i := 0; i < 4; i++ {
+			other := validators.GenerateRandomValidator(50)
+			require.NoError(set.AddWeight(other.ID(), other.Weight()))
+		}
+		require.NoError(vdrs.Set(subnetID, set))
+	}
+
+	benchable := &TestBenchable{T: t}
+	benchable.BenchedF = func(ids.ID, ids.NodeID) {}
+
+	params := Parameters{
+		Threshold:  1,
+		Duration:   time.Minute,
+		MaxPortion: 0.5,
+	}
+	m := NewManager(&Config{
+		Benchable:      benchable,
+		Validators:     vdrs,
+		StakingEnabled: true,
+		DB:             memdb.New(),
+		Parameters:     params,
+		SubnetParameters: map[ids.ID]Parameters{
+			// Benching is disabled on the lenient subnet
+			lenientSubnetID: {},
+			// Validators of the strict subnet are benched after more
+			// failures than on the primary network
+			strictSubnetID: {
+				Threshold:  3,
+				Duration:   time.Minute,
+				MaxPortion: 0.5,
+			},
+		},
+	})
+
+	chainIDs := make(map[ids.ID]ids.ID)
+	for _, subnetID := range []ids.ID{constants.PrimaryNetworkID, lenientSubnetID, strictSubnetID} {
+		ctx := snow.DefaultConsensusContextTest()
+		ctx.ChainID = ids.GenerateTestID()
+		ctx.SubnetID = subnetID
+		require.NoError(m.RegisterChain(ctx))
+		chainIDs[subnetID] = ctx.ChainID
+	}
+
+	for i := 0; i < 2; i++ {
+		for _, chainID := range chainIDs {
+			m.RegisterFailure(chainID, vdr.ID())
+		}
+	}
+	require.True(m.IsBenched(vdr.ID(), chainIDs[constants.PrimaryNetworkID]))
+	require.False(m.IsBenched(vdr.ID(), chainIDs[lenientSubnetID]))
+	require.False(m.IsBenched(vdr.ID(), chainIDs[strictSubnetID]))
+
+	m.RegisterFailure(chainIDs[strictSubnetID], vdr.ID())
+	require.True(m.IsBenched(vdr.ID(), chainIDs[strictSubnetID]))
+}
+
+func TestNewManagerDisabled(t *testing.T) {
+	require := require.New(t)
+
+	m := NewManager(&Config{
+		SubnetParameters: map[ids.ID]Parameters{
+			ids.GenerateTestID(): {Threshold: 10},
+		},
+	})
+	require.IsType(&noBenchlist{}, m)
+
+	m = NewManager(&Config{
+		SubnetParameters: map[ids.ID]Parameters{
+			ids.GenerateTestID(): {MaxPortion: 0.1},
+		},
+	})
+	require.IsType(&manager{}, m)
+}
+
+func TestParametersValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		params Parameters
+		valid  bool
+	}{
+		{
+			name:   "valid",
+			params: Parameters{Threshold: 10, MinimumFailingDuration: time.Minute, Duration: time.Minute, MaxPortion: 0.1},
+			valid:  true,
+		},
+		{
+			name:   "disabled",
+			params: Parameters{},
+			valid:  true,
+		},
+		{
+			name:   "negative threshold",
+			params: Parameters{Threshold: -1},
+		},
+		{
+			name:   "negative minimum failing duration",
+			params: Parameters{MinimumFailingDuration: -time.Second},
+		},
+		{
+			name:   "negative duration",
+			params: Parameters{Duration: -time.Second},
+		},
+		{
+			name:   "max portion too large",
+			params: Parameters{MaxPortion: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.params.Valid()
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
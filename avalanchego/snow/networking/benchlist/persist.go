@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package benchlist
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// reasonFailedQueries is recorded for nodes benched after too many
+// consecutive failed queries.
+const reasonFailedQueries = "consecutive failed queries"
+
+// benchedRecord is what is persisted for every benched node so that the node
+// remains benched across restarts.
+type benchedRecord struct {
+	benchedUntil time.Time
+	reason       string
+}
+
+func putBenched(db database.KeyValueWriter, nodeID ids.NodeID, record benchedRecord) error {
+	p := wrappers.Packer{
+		MaxSize: wrappers.LongLen + wrappers.ShortLen + len(record.reason),
+	}
+	p.PackLong(uint64(record.benchedUntil.Unix()))
+	p.PackStr(record.reason)
+	if p.Errored() {
+		return p.Err
+	}
+	return db.Put(nodeID[:], p.Bytes)
+}
+
+func deleteBenched(db database.KeyValueDeleter, nodeID ids.NodeID) error {
+	return db.Delete(nodeID[:])
+}
+
+func parseBenched(key []byte, value []byte) (ids.NodeID, benchedRecord, error) {
+	nodeID, err := ids.ToNodeID(key)
+	if err != nil {
+		return ids.NodeID{}, benchedRecord{}, err
+	}
+	p := wrappers.Packer{Bytes: value}
+	record := benchedRecord{
+		benchedUntil: time.Unix(int64(p.UnpackLong()), 0),
+		reason:       p.UnpackStr(),
+	}
+	return nodeID, record, p.Err
+}
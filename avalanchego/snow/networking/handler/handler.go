@@ -12,6 +12,8 @@ import (
 
 	"go.uber.org/zap"
 
+	oteltrace "go.opentelemetry.io/otel/trace"
+
 	"github.com/ava-labs/avalanchego/api/health"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
@@ -20,6 +22,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/networking/worker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/trace"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/version"
@@ -28,6 +31,9 @@ import (
 const (
 	threadPoolSize        = 2
 	numDispatchersToClose = 3
+	// maxThrottleDelay is the longest a dispatcher waits for the chain's CPU
+	// usage to drop below its soft limit before handling the next message.
+	maxThrottleDelay = time.Second
 )
 
 var _ Handler = &handler{}
@@ -80,6 +86,11 @@ type handler struct {
 
 	// Tracks cpu/disk usage caused by each peer.
 	resourceTracker tracker.ResourceTracker
+	// Tracks cpu usage caused by each chain.
+	chainTracker tracker.ChainTracker
+	// CPU usage of this chain above which messages from the network are
+	// handled more slowly. 0 means the chain isn't throttled.
+	cpuSoftLimit float64
 
 	// Holds messages that [engine] hasn't processed yet.
 	// [unprocessedMsgsCond.L] must be held while accessing [syncMessageQueue].
@@ -108,6 +119,8 @@ func New(
 	preemptTimeouts chan struct{},
 	gossipFrequency time.Duration,
 	resourceTracker tracker.ResourceTracker,
+	chainTracker tracker.ChainTracker,
+	queueConfig MessageQueueConfig,
 ) (Handler, error) {
 	h := &handler{
 		ctx:              ctx,
@@ -121,6 +134,8 @@ func New(
 		closingChan:      make(chan struct{}),
 		closed:           make(chan struct{}),
 		resourceTracker:  resourceTracker,
+		chainTracker:     chainTracker,
+		cpuSoftLimit:     queueConfig.CPUSoftLimit,
 	}
 
 	var err error
@@ -130,11 +145,11 @@ func New(
 		return nil, fmt.Errorf("initializing handler metrics errored with: %w", err)
 	}
 	cpuTracker := resourceTracker.CPUTracker()
-	h.syncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, "handler", h.ctx.Registerer, message.SynchronousOps)
+	h.syncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, queueConfig, "handler", h.ctx.Registerer, message.SynchronousOps)
 	if err != nil {
 		return nil, fmt.Errorf("initializing sync message queue errored with: %w", err)
 	}
-	h.asyncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, "handler_async", h.ctx.Registerer, message.AsynchronousOps)
+	h.asyncMessageQueue, err = NewMessageQueue(h.ctx.Log, h.validators, cpuTracker, queueConfig, "handler_async", h.ctx.Registerer, message.AsynchronousOps)
 	if err != nil {
 		return nil, fmt.Errorf("initializing async message queue errored with: %w", err)
 	}
@@ -146,7 +161,8 @@ func (h *handler) Context() *snow.ConsensusContext { return h.ctx }
 func (h *handler) IsValidator(nodeID ids.NodeID) bool {
 	return !h.ctx.IsValidatorOnly() ||
 		nodeID == h.ctx.NodeID ||
-		h.validators.Contains(nodeID)
+		h.validators.Contains(nodeID) ||
+		h.ctx.IsAllowedNode(nodeID)
 }
 
 func (h *handler) SetStateSyncer(engine common.StateSyncer) { h.stateSyncer = engine }
@@ -225,13 +241,21 @@ func (h *handler) HealthCheck() (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return engine.HealthCheck()
+	engineDetails, err := engine.HealthCheck()
+	details := map[string]interface{}{
+		"engine":   engineDetails,
+		"cpuUsage": h.chainTracker.CPUUsage(h.ctx.ChainID, h.clock.Time()),
+	}
+	if h.cpuSoftLimit > 0 {
+		details["cpuSoftLimit"] = h.cpuSoftLimit
+	}
+	return details, err
 }
 
 // Push the message onto the handler's queue
 func (h *handler) Push(msg message.InboundMessage) {
 	switch msg.Op() {
-	case message.AppRequest, message.AppGossip, message.AppRequestFailed, message.AppResponse:
+	case message.AppRequest, message.AppGossip, message.AppRequestFailed, message.AppResponse, message.AppError:
 		h.asyncMessageQueue.Push(msg)
 	default:
 		h.syncMessageQueue.Push(msg)
@@ -294,6 +318,10 @@ func (h *handler) dispatchSync() {
 
 	// Handle sync messages from the router
 	for {
+		if !h.throttle() {
+			return
+		}
+
 		// Get the next message we should process. If the handler is shutting
 		// down, we may fail to pop a message.
 		msg, ok := h.popUnexpiredMsg(h.syncMessageQueue, h.metrics.expired)
@@ -321,6 +349,10 @@ func (h *handler) dispatchAsync() {
 
 	// Handle async messages from the router
 	for {
+		if !h.throttle() {
+			return
+		}
+
 		// Get the next message we should process. If the handler is shutting
 		// down, we may fail to pop a message.
 		msg, ok := h.popUnexpiredMsg(h.asyncMessageQueue, h.metrics.asyncExpired)
@@ -379,8 +411,12 @@ func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 		startTime = h.clock.Time()
 	)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.chainTracker.StartProcessing(h.ctx.ChainID, startTime)
 	h.ctx.Lock.Lock()
+	span := h.startSpan(msg)
 	defer func() {
+		h.ctx.TraceScope.Set(nil)
+		span.End()
 		h.ctx.Lock.Unlock()
 
 		var (
@@ -388,6 +424,7 @@ func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 			histogram = h.metrics.messages[op]
 		)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.chainTracker.StopProcessing(h.ctx.ChainID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("finished handling sync message",
@@ -727,6 +764,11 @@ func (h *handler) handleSyncMsg(msg message.InboundMessage) error {
 		}
 		container := containerIntf.([]byte)
 
+		if requestID == constants.GossipMsgRequestID {
+			// The peer that gossiped [container] already gossips it, so it
+			// isn't gossiped again once it's accepted.
+			h.ctx.GossipedContainers.Add(container)
+		}
 		return engine.Put(nodeID, requestID, container)
 
 	case message.PushQuery:
@@ -858,12 +900,18 @@ func (h *handler) executeAsyncMsg(msg message.InboundMessage) error {
 		startTime = h.clock.Time()
 	)
 	h.resourceTracker.StartProcessing(nodeID, startTime)
+	h.chainTracker.StartProcessing(h.ctx.ChainID, startTime)
+	// App messages are handled without holding the chain's lock, so the spans
+	// started while handling them can't be parented to this span.
+	_, span := h.ctx.Tracer.Start(trace.ContextOf(msg), fmt.Sprintf("handler.%s", op))
 	defer func() {
+		span.End()
 		var (
 			endTime   = h.clock.Time()
 			histogram = h.metrics.messages[op]
 		)
 		h.resourceTracker.StopProcessing(nodeID, endTime)
+		h.chainTracker.StopProcessing(h.ctx.ChainID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("finished handling async message",
@@ -930,6 +978,53 @@ func (h *handler) executeAsyncMsg(msg message.InboundMessage) error {
 
 		return engine.AppRequestFailed(nodeID, requestID)
 
+	case message.AppError:
+		requestIDIntf, err := msg.Get(message.RequestID)
+		if err != nil {
+			return err
+		}
+		requestID := requestIDIntf.(uint32)
+
+		errorCodeIntf, err := msg.Get(message.ErrorCode)
+		if err != nil {
+			h.ctx.Log.Debug("message with invalid field",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageOp", op),
+				zap.Uint32("requestID", requestID),
+				zap.Stringer("field", message.ErrorCode),
+				zap.Error(err),
+			)
+			return engine.AppRequestFailed(nodeID, requestID)
+		}
+		errorMessageIntf, err := msg.Get(message.ErrorMessage)
+		if err != nil {
+			h.ctx.Log.Debug("message with invalid field",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageOp", op),
+				zap.Uint32("requestID", requestID),
+				zap.Stringer("field", message.ErrorMessage),
+				zap.Error(err),
+			)
+			return engine.AppRequestFailed(nodeID, requestID)
+		}
+		appErr := &common.AppError{
+			Code:    errorCodeIntf.(int32),
+			Message: errorMessageIntf.(string),
+		}
+		if appErr.Code <= 0 {
+			// Codes <= 0 are reserved, so the peer didn't follow the protocol
+			h.ctx.Log.Debug("message with invalid field",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageOp", op),
+				zap.Uint32("requestID", requestID),
+				zap.Stringer("field", message.ErrorCode),
+				zap.Int32("errorCode", appErr.Code),
+			)
+			return engine.AppRequestFailed(nodeID, requestID)
+		}
+
+		return common.NotifyAppError(engine, nodeID, requestID, appErr)
+
 	case message.AppGossip:
 		appBytesIntf, err := msg.Get(message.AppBytes)
 		if err != nil {
@@ -963,14 +1058,19 @@ func (h *handler) handleChanMsg(msg message.InboundMessage) error {
 		op        = msg.Op()
 		startTime = h.clock.Time()
 	)
+	h.chainTracker.StartProcessing(h.ctx.ChainID, startTime)
 	h.ctx.Lock.Lock()
+	span := h.startSpan(msg)
 	defer func() {
+		h.ctx.TraceScope.Set(nil)
+		span.End()
 		h.ctx.Lock.Unlock()
 
 		var (
 			endTime   = h.clock.Time()
 			histogram = h.metrics.messages[op]
 		)
+		h.chainTracker.StopProcessing(h.ctx.ChainID, endTime)
 		histogram.Observe(float64(endTime.Sub(startTime)))
 		msg.OnFinishedHandling()
 		h.ctx.Log.Debug("finished handling chan message",
@@ -1007,6 +1107,15 @@ func (h *handler) handleChanMsg(msg message.InboundMessage) error {
 	}
 }
 
+// startSpan starts the span of handling [msg], parented to the span the
+// router started for it. The spans started by the chain until the scope is
+// cleared are parented to the returned span. Assumes [h.ctx.Lock] is held.
+func (h *handler) startSpan(msg message.InboundMessage) oteltrace.Span {
+	ctx, span := h.ctx.Tracer.Start(trace.ContextOf(msg), fmt.Sprintf("handler.%s", msg.Op()))
+	h.ctx.TraceScope.Set(ctx)
+	return span
+}
+
 func (h *handler) getEngine() (common.Engine, error) {
 	state := h.ctx.GetState()
 	switch state {
@@ -1046,6 +1155,38 @@ func (h *handler) popUnexpiredMsg(queue MessageQueue, expired prometheus.Counter
 	}
 }
 
+// throttle waits until the CPU usage of this chain drops to its soft limit, for
+// at most [maxThrottleDelay]. Messages from the network keep being queued while
+// waiting, so the queue's drop policy applies to an overloaded chain.
+// Returns false if the handler started shutting down while waiting.
+func (h *handler) throttle() bool {
+	now := h.clock.Time()
+	h.metrics.cpuUsage.Set(h.chainTracker.CPUUsage(h.ctx.ChainID, now))
+	if h.cpuSoftLimit <= 0 {
+		return true
+	}
+
+	delay := h.chainTracker.TimeUntilCPUUsage(h.ctx.ChainID, now, h.cpuSoftLimit)
+	if delay <= 0 {
+		return true
+	}
+	if delay > maxThrottleDelay {
+		delay = maxThrottleDelay
+	}
+	h.metrics.throttled.Inc()
+	h.metrics.throttledTime.Add(float64(delay))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-h.closingChan:
+		return false
+	}
+}
+
 func (h *handler) closeDispatcher() {
 	h.ctx.Lock.Lock()
 	defer h.ctx.Lock.Unlock()
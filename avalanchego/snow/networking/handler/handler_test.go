@@ -4,11 +4,20 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
+
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -38,6 +47,8 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handlerIntf, err := New(
 		mc,
 		ctx,
@@ -46,6 +57,8 @@ func TestHandlerDropsTimedOutMessages(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 	handler := handlerIntf.(*handler)
@@ -116,6 +129,8 @@ func TestHandlerClosesOnError(t *testing.T) {
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handlerIntf, err := New(
 		mc,
 		ctx,
@@ -124,6 +139,8 @@ func TestHandlerClosesOnError(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 	handler := handlerIntf.(*handler)
@@ -185,6 +202,8 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 	mc := message.NewInternalBuilder()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handlerIntf, err := New(
 		mc,
 		ctx,
@@ -193,6 +212,8 @@ func TestHandlerDropsGossipDuringBootstrapping(t *testing.T) {
 		nil,
 		1,
 		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 	handler := handlerIntf.(*handler)
@@ -246,6 +267,8 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	mc := message.NewInternalBuilder()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := New(
 		mc,
 		ctx,
@@ -254,6 +277,8 @@ func TestHandlerDispatchInternal(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -289,3 +314,181 @@ func TestHandlerDispatchInternal(t *testing.T) {
 	case <-calledNotify:
 	}
 }
+
+// Test that a chain using more CPU than its soft limit is throttled
+func TestHandlerThrottle(t *testing.T) {
+	require := require.New(t)
+
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	err := vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
+	require.NoError(err)
+
+	ctrl := gomock.NewController(t)
+	mockUser := resource.NewMockUser(ctrl)
+	mockUser.EXPECT().CPUUsage().Return(4.0).AnyTimes()
+
+	mc := message.NewInternalBuilder()
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, time.Second)
+	require.NoError(err)
+	handlerIntf, err := New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{
+			CPUSoftLimit: 1,
+		},
+	)
+	require.NoError(err)
+	handler := handlerIntf.(*handler)
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.HealthF = func() (interface{}, error) { return nil, nil }
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp)
+
+	// The chain is the only one that processed messages, so it is attributed
+	// all of the node's CPU usage.
+	now := time.Now()
+	chainTracker.StartProcessing(ctx.ChainID, now)
+	now = now.Add(time.Second)
+	chainTracker.StopProcessing(ctx.ChainID, now)
+	handler.clock.Set(now)
+
+	detailsIntf, err := handler.HealthCheck()
+	require.NoError(err)
+	details := detailsIntf.(map[string]interface{})
+	require.Equal(4.0, details["cpuUsage"])
+	require.Equal(1.0, details["cpuSoftLimit"])
+
+	// The handler stops waiting for the chain's CPU usage to drop once it
+	// starts shutting down.
+	close(handler.closingChan)
+	require.False(handler.throttle())
+	require.Equal(float64(1), testutil.ToFloat64(handler.metrics.throttled))
+	require.Equal(float64(maxThrottleDelay), testutil.ToFloat64(handler.metrics.throttledTime))
+
+	// Without a soft limit, the chain is never throttled.
+	handler.cpuSoftLimit = 0
+	require.True(handler.throttle())
+	require.Equal(float64(1), testutil.ToFloat64(handler.metrics.throttled))
+}
+
+type testTracer struct {
+	oteltrace.Tracer
+}
+
+func (testTracer) Close() error { return nil }
+
+type tracedMsg struct {
+	message.InboundMessage
+
+	ctx context.Context
+}
+
+func (m *tracedMsg) TraceContext() context.Context { return m.ctx }
+
+// Test that the calls made while handling a message are parented to the span
+// of the message
+func TestHandlerTracesSyncMessages(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.Tracer = testTracer{Tracer: tp.Tracer("test")}
+
+	vdrs := validators.NewSet()
+	vdrID := ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddWeight(vdrID, 1))
+
+	mc := message.NewInternalBuilder()
+	metrics := prometheus.NewRegistry()
+	inboundMC, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(err)
+	handler, err := New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		chainTracker,
+		MessageQueueConfig{},
+	)
+	require.NoError(err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	handler.SetBootstrapper(bootstrapper)
+
+	routerCtx, routerSpan := ctx.Tracer.Start(context.Background(), "router")
+	called := make(chan struct{}, 1)
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	engine.GetF = func(ids.NodeID, uint32, ids.ID) error {
+		// Spans started while handling the message are children of the
+		// handler's span.
+		_, span := ctx.Tracer.Start(ctx.TraceScope.Context(), "vm")
+		span.End()
+		called <- struct{}{}
+		return nil
+	}
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	bootstrapper.StartF = func(startReqID uint32) error { return nil }
+
+	handler.Start(false)
+	handler.Push(&tracedMsg{
+		InboundMessage: inboundMC.InboundGet(ids.Empty, 1, time.Second, ids.GenerateTestID(), vdrID),
+		ctx:            routerCtx,
+	})
+
+	select {
+	case <-time.After(time.Second):
+		t.Fatalf("should have called get")
+	case <-called:
+	}
+	routerSpan.End()
+	handler.Stop()
+	<-handler.Stopped()
+
+	var handlerSpan, vmSpan sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "handler.get":
+			handlerSpan = span
+		case "vm":
+			vmSpan = span
+		}
+	}
+	require.NotNil(handlerSpan)
+	require.NotNil(vmSpan)
+	require.Equal(routerSpan.SpanContext().SpanID(), handlerSpan.Parent().SpanID())
+	require.Equal(handlerSpan.SpanContext().SpanID(), vmSpan.Parent().SpanID())
+
+	// The scope is cleared once the message is handled.
+	require.Equal(context.Background(), ctx.TraceScope.Context())
+}
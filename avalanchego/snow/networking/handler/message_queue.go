@@ -53,19 +53,27 @@ type messageQueue struct {
 	vdrs validators.Set
 	// Tracks CPU utilization of each node
 	cpuTracker tracker.Tracker
+	// Bounds the queue and decides what happens to non-essential messages
+	// once it is full
+	config MessageQueueConfig
 
 	cond   *sync.Cond
 	closed bool
-	// Node ID --> Messages this node has in [msgs]
+	// Node ID --> Messages this node has in [msgs] and [lowPriorityMsgs]
 	nodeToUnprocessedMsgs map[ids.NodeID]int
 	// Unprocessed messages
 	msgs []message.InboundMessage
+	// Unprocessed non-essential messages that were pushed while the queue was
+	// full. These are only handled once [msgs] is empty. Holds at most
+	// [config.MaxSize] messages.
+	lowPriorityMsgs []message.InboundMessage
 }
 
 func NewMessageQueue(
 	log logging.Logger,
 	vdrs validators.Set,
 	cpuTracker tracker.Tracker,
+	config MessageQueueConfig,
 	metricsNamespace string,
 	metricsRegisterer prometheus.Registerer,
 	ops []message.Op,
@@ -74,6 +82,7 @@ func NewMessageQueue(
 		log:                   log,
 		vdrs:                  vdrs,
 		cpuTracker:            cpuTracker,
+		config:                config,
 		cond:                  sync.NewCond(&sync.Mutex{}),
 		nodeToUnprocessedMsgs: make(map[ids.NodeID]int),
 	}
@@ -90,7 +99,24 @@ func (m *messageQueue) Push(msg message.InboundMessage) {
 	}
 
 	// Add the message to the queue
-	m.msgs = append(m.msgs, msg)
+	if m.isFull() && !isEssential(msg) {
+		op := msg.Op()
+		switch {
+		case m.config.DropPolicy == DropPolicyDeprioritize && len(m.lowPriorityMsgs) < m.config.MaxSize:
+			m.lowPriorityMsgs = append(m.lowPriorityMsgs, msg)
+			m.metrics.deprioritized[op].Inc()
+		default:
+			m.log.Verbo("dropping message from full queue",
+				zap.Stringer("nodeID", msg.NodeID()),
+				zap.Stringer("messageOp", op),
+			)
+			m.metrics.dropped[op].Inc()
+			msg.OnFinishedHandling()
+			return
+		}
+	} else {
+		m.msgs = append(m.msgs, msg)
+	}
 	m.nodeToUnprocessedMsgs[msg.NodeID()]++
 
 	// Update metrics
@@ -103,7 +129,8 @@ func (m *messageQueue) Push(msg message.InboundMessage) {
 }
 
 // FIFO, but skip over messages whose senders whose messages have caused us to
-// use excessive CPU recently.
+// use excessive CPU recently. Deprioritized messages are only returned once no
+// other messages are queued.
 func (m *messageQueue) Pop() (message.InboundMessage, bool) {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
@@ -113,12 +140,20 @@ func (m *messageQueue) Pop() (message.InboundMessage, bool) {
 			return nil, false
 		}
 		if len(m.msgs) != 0 {
-			break
+			return m.pop(&m.msgs), true
+		}
+		if len(m.lowPriorityMsgs) != 0 {
+			return m.pop(&m.lowPriorityMsgs), true
 		}
 		m.cond.Wait()
 	}
+}
 
-	n := len(m.msgs)
+// pop removes and returns the first message of [queue] that [canPop] allows
+// to be handled next.
+// Assumes [m.cond.L] is held and [queue] isn't empty.
+func (m *messageQueue) pop(queue *[]message.InboundMessage) message.InboundMessage {
+	n := len(*queue)
 	i := 0
 	for {
 		if i == n {
@@ -126,29 +161,22 @@ func (m *messageQueue) Pop() (message.InboundMessage, bool) {
 				zap.Int("numMessages", n),
 			)
 		}
-		msg := m.msgs[0]
-		m.msgs[0] = nil
-		nodeID := msg.NodeID()
+		msg := (*queue)[0]
+		(*queue)[0] = nil
 		// See if it's OK to process [msg] next
 		if m.canPop(msg) || i == n { // i should never == n but handle anyway as a fail-safe
-			if cap(m.msgs) == 1 {
-				m.msgs = nil // Give back memory if possible
+			if cap(*queue) == 1 {
+				*queue = nil // Give back memory if possible
 			} else {
-				m.msgs = m.msgs[1:]
-			}
-			m.nodeToUnprocessedMsgs[nodeID]--
-			if m.nodeToUnprocessedMsgs[nodeID] == 0 {
-				delete(m.nodeToUnprocessedMsgs, nodeID)
+				*queue = (*queue)[1:]
 			}
-			m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
-			m.metrics.len.Dec()
-			m.metrics.ops[msg.Op()].Dec()
-			return msg, true
+			m.markPopped(msg)
+			return msg
 		}
 		// [msg.nodeID] is causing excessive CPU usage.
-		// Push [msg] to back of [m.msgs] and handle it later.
-		m.msgs = append(m.msgs, msg)
-		m.msgs = m.msgs[1:]
+		// Push [msg] to back of [queue] and handle it later.
+		*queue = append(*queue, msg)
+		*queue = (*queue)[1:]
 		i++
 		m.metrics.numExcessiveCPU.Inc()
 	}
@@ -158,7 +186,7 @@ func (m *messageQueue) Len() int {
 	m.cond.L.Lock()
 	defer m.cond.L.Unlock()
 
-	return len(m.msgs)
+	return len(m.msgs) + len(m.lowPriorityMsgs)
 }
 
 func (m *messageQueue) Shutdown() {
@@ -169,7 +197,11 @@ func (m *messageQueue) Shutdown() {
 	for _, msg := range m.msgs {
 		msg.OnFinishedHandling()
 	}
+	for _, msg := range m.lowPriorityMsgs {
+		msg.OnFinishedHandling()
+	}
 	m.msgs = nil
+	m.lowPriorityMsgs = nil
 	m.nodeToUnprocessedMsgs = nil
 
 	// Update metrics
@@ -181,7 +213,26 @@ func (m *messageQueue) Shutdown() {
 	m.cond.Broadcast()
 }
 
-// canPop will return true for at least one message in [m.msgs]
+// isFull returns true if [m] is bounded and holds at least [MaxSize] messages.
+// Assumes [m.cond.L] is held.
+func (m *messageQueue) isFull() bool {
+	return m.config.MaxSize > 0 && len(m.msgs)+len(m.lowPriorityMsgs) >= m.config.MaxSize
+}
+
+// markPopped updates the bookkeeping after [msg] was removed from the queue.
+// Assumes [m.cond.L] is held.
+func (m *messageQueue) markPopped(msg message.InboundMessage) {
+	nodeID := msg.NodeID()
+	m.nodeToUnprocessedMsgs[nodeID]--
+	if m.nodeToUnprocessedMsgs[nodeID] == 0 {
+		delete(m.nodeToUnprocessedMsgs, nodeID)
+	}
+	m.metrics.nodesWithMessages.Set(float64(len(m.nodeToUnprocessedMsgs)))
+	m.metrics.len.Dec()
+	m.metrics.ops[msg.Op()].Dec()
+}
+
+// canPop will return true for at least one message of a queue
 func (m *messageQueue) canPop(msg message.InboundMessage) bool {
 	// Always pop connected and disconnected messages.
 	if op := msg.Op(); op == message.Connected || op == message.Disconnected {
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+const (
+	// DropPolicyDrop drops non-essential messages that arrive while the queue
+	// is full.
+	DropPolicyDrop DropPolicy = iota
+	// DropPolicyDeprioritize keeps non-essential messages that arrive while
+	// the queue is full, but only handles them once no other messages are
+	// queued. At most [MessageQueueConfig.MaxSize] messages are kept this way,
+	// further ones are dropped.
+	DropPolicyDeprioritize
+)
+
+var (
+	errUnknownDropPolicy = errors.New("unknown drop policy")
+
+	// nonEssentialOps are the ops that may carry messages subject to the
+	// queue's [DropPolicy].
+	nonEssentialOps = map[message.Op]struct{}{
+		message.Put:       {},
+		message.AppGossip: {},
+	}
+)
+
+// DropPolicy describes what a full message queue does with non-essential
+// messages.
+type DropPolicy byte
+
+func (p DropPolicy) String() string {
+	switch p {
+	case DropPolicyDrop:
+		return "drop"
+	case DropPolicyDeprioritize:
+		return "deprioritize"
+	default:
+		return "unknown"
+	}
+}
+
+// DropPolicyFromString returns the DropPolicy named [s].
+func DropPolicyFromString(s string) (DropPolicy, error) {
+	switch s {
+	case DropPolicyDrop.String():
+		return DropPolicyDrop, nil
+	case DropPolicyDeprioritize.String():
+		return DropPolicyDeprioritize, nil
+	default:
+		return DropPolicyDrop, fmt.Errorf("%w: %q", errUnknownDropPolicy, s)
+	}
+}
+
+func (p DropPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *DropPolicy) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	policy, err := DropPolicyFromString(s)
+	if err != nil {
+		return err
+	}
+	*p = policy
+	return nil
+}
+
+// MessageQueueConfig bounds the number of messages a chain's inbound queue
+// holds before non-essential messages are dropped or deprioritized, and the CPU
+// usage above which the chain's queue is drained more slowly.
+type MessageQueueConfig struct {
+	// MaxSize is the number of queued messages after which the queue is
+	// considered full. 0 means the queue is unbounded.
+	MaxSize int `json:"maxSize"`
+	// DropPolicy is applied to non-essential messages pushed onto a full
+	// queue. Essential messages are always queued.
+	DropPolicy DropPolicy `json:"dropPolicy"`
+	// CPUSoftLimit is the CPU usage, in cores, attributed to the chain above
+	// which handling its messages is delayed. 0 means the chain's message
+	// handling is never delayed.
+	CPUSoftLimit float64 `json:"cpuSoftLimit"`
+}
+
+// isEssential returns false for messages that the node can afford to lose
+// under load: gossiped containers and application gossip.
+func isEssential(msg message.InboundMessage) bool {
+	switch msg.Op() {
+	case message.AppGossip:
+		return false
+	case message.Put:
+		requestIDIntf, err := msg.Get(message.RequestID)
+		if err != nil {
+			return true
+		}
+		requestID, ok := requestIDIntf.(uint32)
+		return !ok || requestID != constants.GossipMsgRequestID
+	default:
+		return true
+	}
+}
@@ -17,6 +17,8 @@ type messageQueueMetrics struct {
 	len               prometheus.Gauge
 	nodesWithMessages prometheus.Gauge
 	numExcessiveCPU   prometheus.Counter
+	dropped           map[message.Op]prometheus.Counter
+	deprioritized     map[message.Op]prometheus.Counter
 }
 
 func (m *messageQueueMetrics) initialize(
@@ -43,6 +45,8 @@ func (m *messageQueueMetrics) initialize(
 
 	errs := wrappers.Errs{}
 	m.ops = make(map[message.Op]prometheus.Gauge, len(ops))
+	m.dropped = make(map[message.Op]prometheus.Counter, len(nonEssentialOps))
+	m.deprioritized = make(map[message.Op]prometheus.Counter, len(nonEssentialOps))
 
 	for _, op := range ops {
 		opStr := op.String()
@@ -53,6 +57,26 @@ func (m *messageQueueMetrics) initialize(
 		})
 		m.ops[op] = opMetric
 		errs.Add(metricsRegisterer.Register(opMetric))
+
+		if _, ok := nonEssentialOps[op]; !ok {
+			continue
+		}
+		droppedMetric := prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_dropped", opStr),
+			Help:      fmt.Sprintf("Number of %s messages dropped because the message queue was full.", opStr),
+		})
+		deprioritizedMetric := prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_deprioritized", opStr),
+			Help:      fmt.Sprintf("Number of %s messages deprioritized because the message queue was full.", opStr),
+		})
+		m.dropped[op] = droppedMetric
+		m.deprioritized[op] = deprioritizedMetric
+		errs.Add(
+			metricsRegisterer.Register(droppedMetric),
+			metricsRegisterer.Register(deprioritizedMetric),
+		)
 	}
 
 	errs.Add(
@@ -18,6 +18,8 @@ import (
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/logging"
 )
 
@@ -33,7 +35,7 @@ func TestQueue(t *testing.T) {
 			vdr1ID, vdr2ID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
 			require.NoError(vdrs.AddWeight(vdr1ID, 1))
 			require.NoError(vdrs.AddWeight(vdr2ID, 1))
-			mIntf, err := NewMessageQueue(logging.NoLog{}, vdrs, cpuTracker, "", prometheus.NewRegistry(), message.SynchronousOps)
+			mIntf, err := NewMessageQueue(logging.NoLog{}, vdrs, cpuTracker, MessageQueueConfig{}, "", prometheus.NewRegistry(), message.SynchronousOps)
 			require.NoError(err)
 			u := mIntf.(*messageQueue)
 			currentTime := time.Now()
@@ -44,7 +46,7 @@ func TestQueue(t *testing.T) {
 			if !useProto {
 				mc, err = message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
 			} else {
-				mc, err = message.NewCreatorWithProto(metrics, "dummyNamespace", true, 10*time.Second)
+				mc, err = message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 			}
 			require.NoError(err)
 
@@ -156,3 +158,119 @@ func TestQueue(t *testing.T) {
 		})
 	}
 }
+
+func TestQueueDropPolicy(t *testing.T) {
+	tests := []struct {
+		policy       DropPolicy
+		expectedPops int
+	}{
+		{
+			policy:       DropPolicyDrop,
+			expectedPops: 2,
+		},
+		{
+			policy:       DropPolicyDeprioritize,
+			expectedPops: 3,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.policy.String(), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			require := require.New(t)
+			cpuTracker := tracker.NewMockTracker(ctrl)
+			cpuTracker.EXPECT().Usage(gomock.Any(), gomock.Any()).Return(0.0).AnyTimes()
+			vdrs := validators.NewSet()
+			vdrID := ids.GenerateTestNodeID()
+			require.NoError(vdrs.AddWeight(vdrID, 1))
+			config := MessageQueueConfig{
+				MaxSize:    1,
+				DropPolicy: test.policy,
+			}
+			mIntf, err := NewMessageQueue(logging.NoLog{}, vdrs, cpuTracker, config, "", prometheus.NewRegistry(), message.SynchronousOps)
+			require.NoError(err)
+			u := mIntf.(*messageQueue)
+
+			mc, err := message.NewCreatorWithProto(prometheus.NewRegistry(), "dummyNamespace", compression.TypeGzip, 10*time.Second)
+			require.NoError(err)
+
+			essentialMsg1 := mc.InboundPut(ids.Empty, 1, nil, vdrID)
+			gossipMsg := mc.InboundPut(ids.Empty, constants.GossipMsgRequestID, nil, vdrID)
+			essentialMsg2 := mc.InboundPut(ids.Empty, 2, nil, vdrID)
+
+			// The queue is full after the first message, so only the gossip
+			// message is subject to the drop policy.
+			u.Push(essentialMsg1)
+			u.Push(gossipMsg)
+			u.Push(essentialMsg2)
+			require.Equal(test.expectedPops, u.Len())
+
+			expectedMsgs := []message.InboundMessage{essentialMsg1, essentialMsg2}
+			if test.policy == DropPolicyDeprioritize {
+				expectedMsgs = append(expectedMsgs, gossipMsg)
+			}
+			for _, expectedMsg := range expectedMsgs {
+				msg, ok := u.Pop()
+				require.True(ok)
+				require.Equal(expectedMsg, msg)
+			}
+			require.Zero(u.Len())
+			require.Empty(u.nodeToUnprocessedMsgs)
+		})
+	}
+}
+
+func TestQueueDeprioritizedBounded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	require := require.New(t)
+	cpuTracker := tracker.NewMockTracker(ctrl)
+	vdrs := validators.NewSet()
+	vdr1ID, vdr2ID := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	require.NoError(vdrs.AddWeight(vdr1ID, 1))
+	require.NoError(vdrs.AddWeight(vdr2ID, 1))
+	config := MessageQueueConfig{
+		MaxSize:    2,
+		DropPolicy: DropPolicyDeprioritize,
+	}
+	mIntf, err := NewMessageQueue(logging.NoLog{}, vdrs, cpuTracker, config, "", prometheus.NewRegistry(), message.SynchronousOps)
+	require.NoError(err)
+	u := mIntf.(*messageQueue)
+
+	mc, err := message.NewCreatorWithProto(prometheus.NewRegistry(), "dummyNamespace", compression.TypeGzip, 10*time.Second)
+	require.NoError(err)
+
+	// Fill the queue, then push more gossip than can be deprioritized
+	u.Push(mc.InboundPut(ids.Empty, 1, nil, vdr1ID))
+	u.Push(mc.InboundPut(ids.Empty, 2, nil, vdr1ID))
+	gossipMsg1 := mc.InboundPut(ids.Empty, constants.GossipMsgRequestID, nil, vdr1ID)
+	gossipMsg2 := mc.InboundPut(ids.Empty, constants.GossipMsgRequestID, nil, vdr2ID)
+	u.Push(gossipMsg1)
+	u.Push(gossipMsg2)
+	for i := 0; i < 10; i++ {
+		u.Push(mc.InboundPut(ids.Empty, constants.GossipMsgRequestID, nil, vdr1ID))
+	}
+	require.Len(u.lowPriorityMsgs, config.MaxSize)
+	require.Equal(2*config.MaxSize, u.Len())
+
+	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(0.0).Times(2)
+	for i := 0; i < 2; i++ {
+		_, ok := u.Pop()
+		require.True(ok)
+	}
+
+	// vdr1 exceeded its portion of CPU time, so the deprioritized message of
+	// vdr2 is handled first
+	cpuTracker.EXPECT().Usage(vdr1ID, gomock.Any()).Return(.99).Times(2)
+	cpuTracker.EXPECT().Usage(vdr2ID, gomock.Any()).Return(.01).Times(1)
+	msg, ok := u.Pop()
+	require.True(ok)
+	require.Equal(gossipMsg2, msg)
+	msg, ok = u.Pop()
+	require.True(ok)
+	require.Equal(gossipMsg1, msg)
+	require.Zero(u.Len())
+	require.Empty(u.nodeToUnprocessedMsgs)
+}
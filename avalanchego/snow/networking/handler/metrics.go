@@ -14,9 +14,12 @@ import (
 )
 
 type metrics struct {
-	expired      prometheus.Counter
-	asyncExpired prometheus.Counter
-	messages     map[message.Op]metric.Averager
+	expired       prometheus.Counter
+	asyncExpired  prometheus.Counter
+	cpuUsage      prometheus.Gauge
+	throttled     prometheus.Counter
+	throttledTime prometheus.Counter
+	messages      map[message.Op]metric.Averager
 }
 
 func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
@@ -32,9 +35,27 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 		Name:      "async_expired",
 		Help:      "Incoming async messages dropped because the message deadline expired",
 	})
+	cpuUsage := prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "cpu_usage",
+		Help:      "CPU usage attributed to the chain. Value should be in [0, number of CPU cores]",
+	})
+	throttled := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "throttled",
+		Help:      "Number of times message handling was delayed because the chain exceeded its CPU soft limit",
+	})
+	throttledTime := prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "throttled_time",
+		Help:      "Time (in ns) message handling was delayed because the chain exceeded its CPU soft limit",
+	})
 	errs.Add(
 		reg.Register(expired),
 		reg.Register(asyncExpired),
+		reg.Register(cpuUsage),
+		reg.Register(throttled),
+		reg.Register(throttledTime),
 	)
 
 	messages := make(map[message.Op]metric.Averager, len(message.ConsensusOps))
@@ -50,8 +71,11 @@ func newMetrics(namespace string, reg prometheus.Registerer) (*metrics, error) {
 	}
 
 	return &metrics{
-		expired:      expired,
-		asyncExpired: asyncExpired,
-		messages:     messages,
+		expired:       expired,
+		asyncExpired:  asyncExpired,
+		cpuUsage:      cpuUsage,
+		throttled:     throttled,
+		throttledTime: throttledTime,
+		messages:      messages,
 	}, errs.Err
 }
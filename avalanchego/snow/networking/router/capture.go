@@ -0,0 +1,164 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ Router = &capturingRouter{}
+
+// CaptureConfig configures the capture of the inbound consensus messages
+type CaptureConfig struct {
+	// Number of most recent messages that are kept. 0 disables the capture.
+	Size int `json:"size"`
+	// If true, the container and application bytes of the messages are kept
+	Payloads bool `json:"payloads"`
+}
+
+// CapturedMessage is an inbound consensus message recorded by a
+// [MessageCapture]
+type CapturedMessage struct {
+	Op           string     `json:"op"`
+	ChainID      ids.ID     `json:"chainID"`
+	NodeID       ids.NodeID `json:"nodeID"`
+	RequestID    uint32     `json:"requestID"`
+	Received     time.Time  `json:"received"`
+	Expiration   time.Time  `json:"expiration"`
+	ContainerIDs []ids.ID   `json:"containerIDs,omitempty"`
+	Payloads     [][]byte   `json:"payloads,omitempty"`
+}
+
+// MessageCapture keeps the most recent inbound consensus messages in a ring
+// buffer so that the events leading up to a failure can be reconstructed.
+type MessageCapture struct {
+	clock    mockable.Clock
+	payloads bool
+
+	lock     sync.Mutex
+	messages []CapturedMessage
+	// Index in [messages] that the next message is written to
+	next int
+	// Whether [messages] has wrapped around
+	full bool
+}
+
+func NewMessageCapture(config CaptureConfig) *MessageCapture {
+	return &MessageCapture{
+		payloads: config.Payloads,
+		messages: make([]CapturedMessage, config.Size),
+	}
+}
+
+// Record adds [msg] to the capture, evicting the oldest message if the
+// capture is full.
+func (c *MessageCapture) Record(msg message.InboundMessage) {
+	if len(c.messages) == 0 {
+		return
+	}
+
+	captured := CapturedMessage{
+		Op:         msg.Op().String(),
+		NodeID:     msg.NodeID(),
+		Received:   c.clock.Time(),
+		Expiration: msg.ExpirationTime(),
+	}
+	if chainIDBytes, ok := getField(msg, message.ChainID).([]byte); ok {
+		captured.ChainID, _ = ids.ToID(chainIDBytes)
+	}
+	if requestID, ok := getField(msg, message.RequestID).(uint32); ok {
+		captured.RequestID = requestID
+	}
+	if containerIDBytes, ok := getField(msg, message.ContainerID).([]byte); ok {
+		if containerID, err := ids.ToID(containerIDBytes); err == nil {
+			captured.ContainerIDs = []ids.ID{containerID}
+		}
+	}
+	if containerIDsBytes, ok := getField(msg, message.ContainerIDs).([][]byte); ok {
+		for _, containerIDBytes := range containerIDsBytes {
+			if containerID, err := ids.ToID(containerIDBytes); err == nil {
+				captured.ContainerIDs = append(captured.ContainerIDs, containerID)
+			}
+		}
+	}
+	if c.payloads {
+		for _, field := range []message.Field{message.ContainerBytes, message.AppBytes, message.SummaryBytes} {
+			if payload, ok := getField(msg, field).([]byte); ok {
+				captured.Payloads = append(captured.Payloads, payload)
+			}
+		}
+		if payloads, ok := getField(msg, message.MultiContainerBytes).([][]byte); ok {
+			captured.Payloads = append(captured.Payloads, payloads...)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.messages[c.next] = captured
+	c.next++
+	if c.next == len(c.messages) {
+		c.next = 0
+		c.full = true
+	}
+}
+
+// Messages returns the captured messages, oldest first. If [chainID] isn't
+// empty, only the messages of that chain are returned.
+func (c *MessageCapture) Messages(chainID ids.ID) []CapturedMessage {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	ordered := c.messages[:c.next]
+	if c.full {
+		ordered = append(
+			append([]CapturedMessage{}, c.messages[c.next:]...),
+			c.messages[:c.next]...,
+		)
+	}
+
+	messages := make([]CapturedMessage, 0, len(ordered))
+	for _, msg := range ordered {
+		if chainID == ids.Empty || msg.ChainID == chainID {
+			messages = append(messages, msg)
+		}
+	}
+	return messages
+}
+
+// getField returns the value of [field] in [msg], or nil if [msg] doesn't have
+// the field
+func getField(msg message.InboundMessage, field message.Field) interface{} {
+	value, err := msg.Get(field)
+	if err != nil {
+		return nil
+	}
+	return value
+}
+
+// capturingRouter records the inbound messages it is given before routing them
+type capturingRouter struct {
+	Router
+	capture *MessageCapture
+}
+
+// NewCapturingRouter returns a router that records the inbound messages into
+// [capture] before passing them to [router]. Messages that [router] creates
+// internally, such as request timeouts, aren't recorded.
+func NewCapturingRouter(router Router, capture *MessageCapture) Router {
+	return &capturingRouter{
+		Router:  router,
+		capture: capture,
+	}
+}
+
+func (r *capturingRouter) HandleInbound(msg message.InboundMessage) {
+	r.capture.Record(msg)
+	r.Router.HandleInbound(msg)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestMessageCapture(t *testing.T) {
+	require := require.New(t)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+
+	chainID0 := ids.GenerateTestID()
+	chainID1 := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	containerID := ids.GenerateTestID()
+	container := []byte{1, 2, 3}
+
+	capture := NewMessageCapture(CaptureConfig{Size: 2})
+	capture.Record(mc.InboundPut(chainID0, 1, container, nodeID))
+	capture.Record(mc.InboundPullQuery(chainID1, 2, time.Second, containerID, nodeID))
+
+	messages := capture.Messages(ids.Empty)
+	require.Len(messages, 2)
+	require.Equal(message.Put.String(), messages[0].Op)
+	require.Equal(chainID0, messages[0].ChainID)
+	require.Equal(nodeID, messages[0].NodeID)
+	require.EqualValues(1, messages[0].RequestID)
+	require.Empty(messages[0].Payloads)
+	require.Equal(message.PullQuery.String(), messages[1].Op)
+	require.Equal([]ids.ID{containerID}, messages[1].ContainerIDs)
+
+	// The oldest message is evicted once the capture is full
+	capture.Record(mc.InboundChits(chainID0, 3, []ids.ID{containerID}, nodeID))
+	messages = capture.Messages(ids.Empty)
+	require.Len(messages, 2)
+	require.Equal(message.PullQuery.String(), messages[0].Op)
+	require.Equal(message.Chits.String(), messages[1].Op)
+
+	messages = capture.Messages(chainID0)
+	require.Len(messages, 1)
+	require.Equal(message.Chits.String(), messages[0].Op)
+	require.Equal([]ids.ID{containerID}, messages[0].ContainerIDs)
+
+	// Payloads are only kept if requested
+	capture = NewMessageCapture(CaptureConfig{Size: 1, Payloads: true})
+	capture.Record(mc.InboundPut(chainID0, 1, container, nodeID))
+	messages = capture.Messages(ids.Empty)
+	require.Len(messages, 1)
+	require.Equal([][]byte{container}, messages[0].Payloads)
+}
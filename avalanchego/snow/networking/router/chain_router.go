@@ -15,6 +15,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
@@ -29,9 +30,28 @@ import (
 	"github.com/ava-labs/avalanchego/version"
 )
 
+const (
+	// A request that repeats one answered within this window is answered with
+	// the same response, without reaching the engine
+	duplicateRequestWindow = time.Second
+	// Number of requests passed to the engines that are remembered until they
+	// are answered
+	pendingResponseCacheSize = 4096
+	// Number of recent responses remembered to answer repeated requests. A
+	// response to a Get holds the container, so this bounds their memory.
+	recentResponseCacheSize = 256
+)
+
 var (
 	errUnknownChain = errors.New("received message for unknown chain")
 
+	// deduplicatedOps are the requests that are answered from the recent
+	// responses when they are repeated
+	deduplicatedOps = map[message.Op]struct{}{
+		message.Get:       {},
+		message.PullQuery: {},
+	}
+
 	_ Router              = &ChainRouter{}
 	_ benchlist.Benchable = &ChainRouter{}
 )
@@ -43,6 +63,27 @@ type requestEntry struct {
 	op message.Op
 }
 
+type peerChain struct {
+	nodeID  ids.NodeID
+	chainID ids.ID
+}
+
+// recentRequestKey identifies the requests for a container that share a
+// response
+type recentRequestKey struct {
+	nodeID      ids.NodeID
+	chainID     ids.ID
+	op          message.Op
+	containerID ids.ID
+}
+
+type recentResponse struct {
+	// When the response was sent
+	time time.Time
+	// Sends the response again, to the request with the given ID
+	respond func(requestID uint32)
+}
+
 type peer struct {
 	version        *version.Application
 	trackedSubnets ids.Set
@@ -76,6 +117,14 @@ type ChainRouter struct {
 	healthConfig HealthConfig
 	// aggregator of requests based on their time
 	timedRequests linkedhashmap.LinkedHashmap[ids.ID, requestEntry]
+	// (node ID, chain ID) --> number of requests in [timedRequests] sent to
+	// that node on that chain
+	peerRequests map[peerChain]int
+	// unique request ID --> recentRequestKey of a request passed to its chain
+	// that hasn't been answered yet
+	pendingResponses cache.LRU
+	// recentRequestKey --> *recentResponse sent to the last such request
+	recentResponses cache.LRU
 	// Must only be accessed in method [createRequestID].
 	// [lock] must be held when [requestIDBytes] is accessed.
 	requestIDBytes []byte
@@ -108,6 +157,9 @@ func (cr *ChainRouter) Initialize(
 	cr.criticalChains = criticalChains
 	cr.onFatal = onFatal
 	cr.timedRequests = linkedhashmap.New[ids.ID, requestEntry]()
+	cr.peerRequests = make(map[peerChain]int)
+	cr.pendingResponses = cache.LRU{Size: pendingResponseCacheSize}
+	cr.recentResponses = cache.LRU{Size: recentResponseCacheSize}
 	cr.peers = make(map[ids.NodeID]*peer)
 	cr.healthConfig = healthConfig
 	cr.requestIDBytes = make([]byte, hashing.AddrLen+hashing.HashLen+wrappers.IntLen+wrappers.ByteLen) // Validator ID, Chain ID, Request ID, Msg Type
@@ -148,6 +200,9 @@ func (cr *ChainRouter) RegisterRequest(
 	// we validate that we actually sent the corresponding request.
 	// Give this request a unique ID so we can do that validation.
 	uniqueRequestID := cr.createRequestID(nodeID, chainID, requestID, op)
+	if _, exists := cr.timedRequests.Get(uniqueRequestID); !exists {
+		cr.peerRequests[peerChain{nodeID: nodeID, chainID: chainID}]++
+	}
 	// Add to the set of unfulfilled requests
 	cr.timedRequests.Put(uniqueRequestID, requestEntry{
 		time: cr.clock.Time(),
@@ -172,6 +227,40 @@ func (cr *ChainRouter) RegisterRequest(
 	})
 }
 
+// CacheResponse records that the response to the [op] request [requestID] from
+// [nodeID] on [chainID] was sent. Repeats of the request received within
+// [duplicateRequestWindow] are answered by calling [respond] with their request
+// ID rather than by the engine. [respond] is called with the router lock held,
+// so it must not call into the router.
+func (cr *ChainRouter) CacheResponse(
+	nodeID ids.NodeID,
+	chainID ids.ID,
+	requestID uint32,
+	op message.Op,
+	respond func(requestID uint32),
+) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	uniqueRequestID := cr.createRequestID(nodeID, chainID, requestID, op)
+	keyIntf, ok := cr.pendingResponses.Get(uniqueRequestID)
+	if !ok {
+		return
+	}
+	cr.pendingResponses.Evict(uniqueRequestID)
+	cr.recentResponses.Put(keyIntf, &recentResponse{
+		time:    cr.clock.Time(),
+		respond: respond,
+	})
+}
+
+func (cr *ChainRouter) OutstandingRequests(nodeID ids.NodeID, chainID ids.ID) int {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	return cr.peerRequests[peerChain{nodeID: nodeID, chainID: chainID}]
+}
+
 func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 	nodeID := msg.NodeID()
 	op := msg.Op()
@@ -259,7 +348,19 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 			msg.OnFinishedHandling()
 			return
 		}
-		chain.Push(msg)
+		if cr.serveRecentResponse(msg, nodeID, chainID, requestID) {
+			cr.log.Debug("answered duplicate request with a recent response",
+				zap.Stringer("nodeID", nodeID),
+				zap.Stringer("messageOp", op),
+				zap.Stringer("chainID", chainID),
+				zap.Uint32("requestID", requestID),
+			)
+			cr.metrics.duplicateRequests.Inc()
+
+			msg.OnFinishedHandling()
+			return
+		}
+		chain.Push(traceMessage(ctx.Tracer, msg, chainID, requestID))
 		return
 	}
 
@@ -274,10 +375,12 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 		}
 
 		// Tell the timeout manager we are no longer expecting a response
-		cr.timeoutManager.RemoveRequest(uniqueRequestID)
+		cr.timeoutManager.RemoveRequest(chainID, uniqueRequestID)
 
-		// Pass the failure to the chain
-		chain.Push(msg)
+		// Pass the failure to the chain, recording how long it took from
+		// sending the request until the failure was handled
+		timed := cr.timeResponse(msg, op, chainID, req.time)
+		chain.Push(traceMessage(ctx.Tracer, timed, chainID, requestID))
 		return
 	}
 
@@ -292,7 +395,14 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 		return
 	}
 
-	uniqueRequestID, req := cr.clearRequest(op, nodeID, chainID, requestID)
+	// An error sent in place of a response is matched against the request of
+	// the response it replaces
+	responseOp := op
+	if expectedResponse, isError := message.ErrorToResponseOps[op]; isError {
+		responseOp = expectedResponse
+	}
+
+	uniqueRequestID, req := cr.clearRequest(responseOp, nodeID, chainID, requestID)
 	if req == nil {
 		// We didn't request this message.
 		msg.OnFinishedHandling()
@@ -305,8 +415,21 @@ func (cr *ChainRouter) HandleInbound(msg message.InboundMessage) {
 	// Tell the timeout manager we got a response
 	cr.timeoutManager.RegisterResponse(nodeID, chainID, uniqueRequestID, req.op, latency)
 
-	// Pass the response to the chain
-	chain.Push(msg)
+	// Pass the response to the chain, recording how long it took from sending
+	// the request until the response was handled
+	timed := cr.timeResponse(msg, op, chainID, req.time)
+	chain.Push(traceMessage(ctx.Tracer, timed, chainID, requestID))
+}
+
+// timeResponse wraps [msg] so that, once it has been handled, the time since
+// [requestTime] is recorded as the latency of [op] for [chainID].
+func (cr *ChainRouter) timeResponse(msg message.InboundMessage, op message.Op, chainID ids.ID, requestTime time.Time) message.InboundMessage {
+	return &timedResponse{
+		InboundMessage: msg,
+		onFinishedHandling: func() {
+			cr.metrics.observeResponse(op, chainID, cr.clock.Time().Sub(requestTime))
+		},
+	}
 }
 
 // Shutdown shuts down this router
@@ -549,9 +672,59 @@ func (cr *ChainRouter) clearRequest(
 
 	cr.timedRequests.Delete(uniqueRequestID)
 	cr.metrics.outstandingRequests.Set(float64(cr.timedRequests.Len()))
+
+	key := peerChain{nodeID: nodeID, chainID: chainID}
+	if cr.peerRequests[key] <= 1 {
+		delete(cr.peerRequests, key)
+	} else {
+		cr.peerRequests[key]--
+	}
 	return uniqueRequestID, &request
 }
 
+// serveRecentResponse answers [msg] with the response sent to the same request
+// from [nodeID] if that response was sent within [duplicateRequestWindow], and
+// returns true. Otherwise, [msg] is remembered so that its response can be
+// recorded by [CacheResponse], and false is returned.
+// Assumes [cr.lock] is held.
+func (cr *ChainRouter) serveRecentResponse(
+	msg message.InboundMessage,
+	nodeID ids.NodeID,
+	chainID ids.ID,
+	requestID uint32,
+) bool {
+	op := msg.Op()
+	if _, ok := deduplicatedOps[op]; !ok {
+		return false
+	}
+	// Invalid container IDs are reported by the handler
+	containerIDIntf, err := msg.Get(message.ContainerID)
+	if err != nil {
+		return false
+	}
+	containerID, err := ids.ToID(containerIDIntf.([]byte))
+	if err != nil {
+		return false
+	}
+
+	key := recentRequestKey{
+		nodeID:      nodeID,
+		chainID:     chainID,
+		op:          op,
+		containerID: containerID,
+	}
+	if responseIntf, ok := cr.recentResponses.Get(key); ok {
+		response := responseIntf.(*recentResponse)
+		if cr.clock.Time().Sub(response.time) < duplicateRequestWindow {
+			response.respond(requestID)
+			return true
+		}
+		cr.recentResponses.Evict(key)
+	}
+	cr.pendingResponses.Put(cr.createRequestID(nodeID, chainID, requestID, op), key)
+	return false
+}
+
 // Assumes [cr.lock] is held.
 // Assumes [message.Op] is an alias of byte.
 func (cr *ChainRouter) createRequestID(nodeID ids.NodeID, chainID ids.ID, requestID uint32, op message.Op) ids.ID {
@@ -4,16 +4,33 @@
 package router
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 )
 
+const (
+	opLabel    = "op"
+	chainLabel = "chain"
+)
+
+// responseLatencyBuckets are the upper bounds, in ns, of the response latency
+// histograms. They range from 10ms to ~20s.
+var responseLatencyBuckets = prometheus.ExponentialBuckets(float64(10*time.Millisecond), 2, 12)
+
 // routerMetrics about router messages
 type routerMetrics struct {
 	outstandingRequests   prometheus.Gauge
 	longestRunningRequest prometheus.Gauge
 	droppedRequests       prometheus.Counter
+	duplicateRequests     prometheus.Counter
+	// Time from a request being registered until its response, or the
+	// notification that it failed, was handled by the chain
+	responseLatencies *prometheus.HistogramVec
 }
 
 func newRouterMetrics(namespace string, registerer prometheus.Registerer) (*routerMetrics, error) {
@@ -39,12 +56,53 @@ func newRouterMetrics(namespace string, registerer prometheus.Registerer) (*rout
 			Help:      "Number of dropped requests (all types)",
 		},
 	)
+	rMetrics.duplicateRequests = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "duplicate_requests",
+			Help:      "Number of requests answered with the response to a recent identical request",
+		},
+	)
+
+	rMetrics.responseLatencies = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "response_handled_latency",
+			Help:      "Time (in ns) from a request being registered until its response, or the notification that it failed, was handled",
+			Buckets:   responseLatencyBuckets,
+		},
+		[]string{opLabel, chainLabel},
+	)
 
 	errs := wrappers.Errs{}
 	errs.Add(
 		registerer.Register(rMetrics.outstandingRequests),
 		registerer.Register(rMetrics.longestRunningRequest),
 		registerer.Register(rMetrics.droppedRequests),
+		registerer.Register(rMetrics.duplicateRequests),
+		registerer.Register(rMetrics.responseLatencies),
 	)
 	return rMetrics, errs.Err
 }
+
+// observeResponse records that a response, or failure notification, of type
+// [op] for [chainID] was handled [latency] after the request was registered.
+func (m *routerMetrics) observeResponse(op message.Op, chainID ids.ID, latency time.Duration) {
+	m.responseLatencies.With(prometheus.Labels{
+		opLabel:    op.String(),
+		chainLabel: chainID.String(),
+	}).Observe(float64(latency))
+}
+
+// timedResponse reports the end-to-end latency of a response, or of the
+// notification that a request failed, once the chain has finished handling it.
+type timedResponse struct {
+	message.InboundMessage
+
+	onFinishedHandling func()
+}
+
+func (r *timedResponse) OnFinishedHandling() {
+	r.InboundMessage.OnFinishedHandling()
+	r.onFinishedHandling()
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -41,6 +42,7 @@ func TestShutdown(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -59,6 +61,8 @@ func TestShutdown(t *testing.T) {
 	ctx := snow.DefaultConsensusContextTest()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -67,6 +71,8 @@ func TestShutdown(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -133,6 +139,7 @@ func TestShutdownTimesOut(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist,
 		"",
 		metrics,
@@ -162,6 +169,8 @@ func TestShutdownTimesOut(t *testing.T) {
 	ctx := snow.DefaultConsensusContextTest()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -170,6 +179,8 @@ func TestShutdownTimesOut(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -240,6 +251,7 @@ func TestRouterTimeout(t *testing.T) {
 			TimeoutCoefficient: 1,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist.NewNoBenchlist(),
 		"",
 		prometheus.NewRegistry(),
@@ -270,6 +282,8 @@ func TestRouterTimeout(t *testing.T) {
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -278,6 +292,8 @@ func TestRouterTimeout(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -360,6 +376,7 @@ func TestRouterClearTimeouts(t *testing.T) {
 			TimeoutCoefficient: 1,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist.NewNoBenchlist(),
 		"",
 		prometheus.NewRegistry(),
@@ -385,6 +402,8 @@ func TestRouterClearTimeouts(t *testing.T) {
 
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -393,6 +412,8 @@ func TestRouterClearTimeouts(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -460,6 +481,303 @@ func TestRouterClearTimeouts(t *testing.T) {
 	require.Equal(t, chainRouter.timedRequests.Len(), 0)
 }
 
+func TestRouterAppErrorClearsRequest(t *testing.T) {
+	// Create a timeout manager
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		nil,
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	go tm.Dispatch()
+
+	// Create a router
+	chainRouter := ChainRouter{}
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(t, err)
+
+	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// Create bootstrapper, engine and handler
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	err = vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
+	require.NoError(t, err)
+
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	handler, err := handler.New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
+	)
+	require.NoError(t, err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetBootstrapper(bootstrapper)
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	chainRouter.AddChain(handler)
+
+	bootstrapper.StartF = func(startReqID uint32) error { return nil }
+	handler.Start(false)
+
+	// An app error answers an outstanding app request
+	vID := ids.GenerateTestNodeID()
+	chainRouter.RegisterRequest(vID, ctx.ChainID, 1, message.AppResponse)
+	require.Equal(t, 1, chainRouter.timedRequests.Len())
+
+	inMsg := mc.InboundAppError(ctx.ChainID, 1, 1, "refused", vID)
+	chainRouter.HandleInbound(inMsg)
+
+	require.Equal(t, 0, chainRouter.timedRequests.Len())
+}
+
+func TestRouterOutstandingRequests(t *testing.T) {
+	// Create a timeout manager
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		nil,
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	go tm.Dispatch()
+
+	// Create a router
+	chainRouter := ChainRouter{}
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(t, err)
+
+	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// Create bootstrapper, engine and handler
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	err = vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
+	require.NoError(t, err)
+
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	handler, err := handler.New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
+	)
+	require.NoError(t, err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetBootstrapper(bootstrapper)
+
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	chainRouter.AddChain(handler)
+
+	bootstrapper.StartF = func(startReqID uint32) error { return nil }
+	handler.Start(false)
+
+	vID := ids.GenerateTestNodeID()
+	chainRouter.RegisterRequest(vID, ctx.ChainID, 1, message.AppResponse)
+	chainRouter.RegisterRequest(vID, ctx.ChainID, 2, message.Chits)
+	// Registering the same request again doesn't count it twice
+	chainRouter.RegisterRequest(vID, ctx.ChainID, 2, message.Chits)
+	require.Equal(t, 2, chainRouter.OutstandingRequests(vID, ctx.ChainID))
+	require.Zero(t, chainRouter.OutstandingRequests(vID, ids.GenerateTestID()))
+	require.Zero(t, chainRouter.OutstandingRequests(ids.GenerateTestNodeID(), ctx.ChainID))
+
+	// Responses and failures both end a request
+	chainRouter.HandleInbound(mc.InboundAppError(ctx.ChainID, 1, 1, "refused", vID))
+	require.Equal(t, 1, chainRouter.OutstandingRequests(vID, ctx.ChainID))
+
+	chainRouter.HandleInbound(mc.InternalFailedRequest(message.QueryFailed, vID, ctx.ChainID, 2))
+	require.Zero(t, chainRouter.OutstandingRequests(vID, ctx.ChainID))
+	require.Empty(t, chainRouter.peerRequests)
+
+	// The latencies of both are recorded once the chain has handled them
+	require.Eventually(t, func() bool {
+		return testutil.CollectAndCount(chainRouter.metrics.responseLatencies) == 2
+	}, time.Second, time.Millisecond)
+}
+
+func TestRouterServesDuplicateRequests(t *testing.T) {
+	// Create a timeout manager
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     3 * time.Second,
+			MinimumTimeout:     3 * time.Second,
+			MaximumTimeout:     5 * time.Minute,
+			TimeoutCoefficient: 1,
+			TimeoutHalflife:    5 * time.Minute,
+		},
+		nil,
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(t, err)
+	go tm.Dispatch()
+
+	// Create a router
+	chainRouter := ChainRouter{}
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(t, err)
+
+	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Millisecond, ids.Set{}, ids.Set{}, nil, HealthConfig{}, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	// Create bootstrapper, engine and handler
+	ctx := snow.DefaultConsensusContextTest()
+	vdrs := validators.NewSet()
+	err = vdrs.AddWeight(ids.GenerateTestNodeID(), 1)
+	require.NoError(t, err)
+
+	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
+	handler, err := handler.New(
+		mc,
+		ctx,
+		vdrs,
+		nil,
+		nil,
+		time.Second,
+		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
+	)
+	require.NoError(t, err)
+
+	bootstrapper := &common.BootstrapperTest{
+		BootstrapableTest: common.BootstrapableTest{
+			T: t,
+		},
+		EngineTest: common.EngineTest{
+			T: t,
+		},
+	}
+	bootstrapper.Default(false)
+	bootstrapper.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetBootstrapper(bootstrapper)
+
+	handled := make(chan uint32, 4)
+	engine := &common.EngineTest{T: t}
+	engine.Default(false)
+	engine.GetF = func(_ ids.NodeID, requestID uint32, _ ids.ID) error {
+		handled <- requestID
+		return nil
+	}
+	engine.ContextF = func() *snow.ConsensusContext { return ctx }
+	handler.SetConsensus(engine)
+	ctx.SetState(snow.NormalOp) // assumed bootstrapping is done
+
+	chainRouter.AddChain(handler)
+
+	bootstrapper.StartF = func(startReqID uint32) error { return nil }
+	handler.Start(false)
+
+	vID := ids.GenerateTestNodeID()
+	containerID := ids.GenerateTestID()
+	now := time.Now()
+	chainRouter.clock.Set(now)
+
+	// The first request is handled
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 1, time.Hour, containerID, vID))
+	require.EqualValues(t, 1, <-handled)
+
+	// Until it is answered, another request for the same container is handled
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 2, time.Hour, containerID, vID))
+	require.EqualValues(t, 2, <-handled)
+
+	served := make(chan uint32, 1)
+	chainRouter.CacheResponse(vID, ctx.ChainID, 1, message.Get, func(requestID uint32) {
+		served <- requestID
+	})
+
+	// Once it is answered, a repeat is answered with the same response
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 3, time.Hour, containerID, vID))
+	require.EqualValues(t, 3, <-served)
+	require.Equal(t, float64(1), testutil.ToFloat64(chainRouter.metrics.duplicateRequests))
+
+	// Requests for other containers, or from other nodes, are handled
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 4, time.Hour, ids.GenerateTestID(), vID))
+	require.EqualValues(t, 4, <-handled)
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 5, time.Hour, containerID, ids.GenerateTestNodeID()))
+	require.EqualValues(t, 5, <-handled)
+
+	// Once the window has passed, the repeat is handled
+	chainRouter.clock.Set(now.Add(duplicateRequestWindow))
+	chainRouter.HandleInbound(mc.InboundGet(ctx.ChainID, 6, time.Hour, containerID, vID))
+	require.EqualValues(t, 6, <-handled)
+	require.Equal(t, float64(1), testutil.ToFloat64(chainRouter.metrics.duplicateRequests))
+}
+
 func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// Create a timeout manager
 	maxTimeout := 25 * time.Millisecond
@@ -471,6 +789,7 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 			TimeoutCoefficient: 1,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist.NewNoBenchlist(),
 		"",
 		prometheus.NewRegistry(),
@@ -494,12 +813,16 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 
 	ctx := snow.DefaultConsensusContextTest()
 	ctx.SetValidatorOnly()
+	allowedID := ids.GenerateTestNodeID()
+	ctx.AllowNodes(allowedID)
 	vdrs := validators.NewSet()
 	vID := ids.GenerateTestNodeID()
 	err = vdrs.AddWeight(vID, 1)
 	require.NoError(t, err)
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -508,6 +831,8 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -566,6 +891,18 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	wg.Wait()
 	require.True(t, calledF) // should be called since this is a validator request
 
+	// Allowed non-validator case
+	calledF = false
+	reqID++
+	inMsg = mc.InboundPullQuery(ctx.ChainID, reqID, time.Hour, dummyContainerID,
+		allowedID,
+	)
+	wg.Add(1)
+	chainRouter.HandleInbound(inMsg)
+
+	wg.Wait()
+	require.True(t, calledF) // should be called since this node is allowed
+
 	// register a validator request
 	reqID++
 	chainRouter.RegisterRequest(vID, ctx.ChainID, reqID, message.Get)
@@ -582,3 +919,49 @@ func TestValidatorOnlyMessageDrops(t *testing.T) {
 	// the GetFailed message is sent
 	require.Equal(t, 1, chainRouter.timedRequests.Len())
 }
+
+func TestTimedResponseObservesLatency(t *testing.T) {
+	require := require.New(t)
+
+	registry := prometheus.NewRegistry()
+	rMetrics, err := newRouterMetrics("router", registry)
+	require.NoError(err)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+
+	finished := false
+	chainID := ids.GenerateTestID()
+	msg := mc.InboundPut(chainID, 1, nil, ids.GenerateTestNodeID())
+	msg = &timedResponse{
+		InboundMessage: msg,
+		onFinishedHandling: func() {
+			finished = true
+			rMetrics.observeResponse(message.Put, chainID, time.Second)
+		},
+	}
+	msg.OnFinishedHandling()
+	require.True(finished)
+
+	families, err := registry.Gather()
+	require.NoError(err)
+	for _, family := range families {
+		if family.GetName() != "router_response_handled_latency" {
+			continue
+		}
+		require.Len(family.GetMetric(), 1)
+		metric := family.GetMetric()[0]
+		labels := map[string]string{}
+		for _, label := range metric.GetLabel() {
+			labels[label.GetName()] = label.GetValue()
+		}
+		require.Equal(map[string]string{
+			opLabel:    message.Put.String(),
+			chainLabel: chainID.String(),
+		}, labels)
+		require.EqualValues(1, metric.GetHistogram().GetSampleCount())
+		require.Equal(float64(time.Second), metric.GetHistogram().GetSampleSum())
+		return
+	}
+	require.FailNow("response latency histogram wasn't registered")
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+var _ Router = &queryTrackingRouter{}
+
+// QueryTracker records the last time each connected peer queried this node.
+// Peers don't tell a node that they benched it, but they stop querying it, so
+// a connected validator that hasn't queried this node in a while has likely
+// benched it.
+type QueryTracker struct {
+	clock mockable.Clock
+
+	lock sync.Mutex
+	// peer --> last time the peer sent a PullQuery or PushQuery
+	lastQueried map[ids.NodeID]time.Time
+}
+
+func NewQueryTracker() *QueryTracker {
+	return &QueryTracker{
+		lastQueried: make(map[ids.NodeID]time.Time),
+	}
+}
+
+// Record notes that [msg] was received, if it's a query.
+func (q *QueryTracker) Record(msg message.InboundMessage) {
+	switch msg.Op() {
+	case message.PullQuery, message.PushQuery:
+	default:
+		return
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.lastQueried[msg.NodeID()] = q.clock.Time()
+}
+
+// LastQueried returns the last time [nodeID] queried this node, or false if it
+// hasn't since it connected.
+func (q *QueryTracker) LastQueried(nodeID ids.NodeID) (time.Time, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	lastQueried, ok := q.lastQueried[nodeID]
+	return lastQueried, ok
+}
+
+// Forget removes what is known about [nodeID].
+func (q *QueryTracker) Forget(nodeID ids.NodeID) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	delete(q.lastQueried, nodeID)
+}
+
+// queryTrackingRouter records the queries it is given before routing them
+type queryTrackingRouter struct {
+	Router
+	tracker *QueryTracker
+}
+
+// NewQueryTrackingRouter returns a router that records the inbound queries
+// into [tracker] before passing them to [router].
+func NewQueryTrackingRouter(router Router, tracker *QueryTracker) Router {
+	return &queryTrackingRouter{
+		Router:  router,
+		tracker: tracker,
+	}
+}
+
+func (r *queryTrackingRouter) HandleInbound(msg message.InboundMessage) {
+	r.tracker.Record(msg)
+	r.Router.HandleInbound(msg)
+}
+
+func (r *queryTrackingRouter) Disconnected(nodeID ids.NodeID) {
+	r.tracker.Forget(nodeID)
+	r.Router.Disconnected(nodeID)
+}
@@ -0,0 +1,51 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func TestQueryTracker(t *testing.T) {
+	require := require.New(t)
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	containerID := ids.GenerateTestID()
+
+	tracker := NewQueryTracker()
+	now := time.Unix(1000, 0)
+	tracker.clock.Set(now)
+
+	// Messages other than queries aren't recorded.
+	tracker.Record(mc.InboundPut(chainID, 1, []byte{1}, nodeID))
+	_, ok := tracker.LastQueried(nodeID)
+	require.False(ok)
+
+	tracker.Record(mc.InboundPullQuery(chainID, 2, time.Second, containerID, nodeID))
+	lastQueried, ok := tracker.LastQueried(nodeID)
+	require.True(ok)
+	require.Equal(now, lastQueried)
+
+	tracker.clock.Set(now.Add(time.Minute))
+	tracker.Record(mc.InboundPushQuery(chainID, 3, time.Second, []byte{1}, nodeID))
+	lastQueried, ok = tracker.LastQueried(nodeID)
+	require.True(ok)
+	require.Equal(now.Add(time.Minute), lastQueried)
+
+	tracker.Forget(nodeID)
+	_, ok = tracker.LastQueried(nodeID)
+	require.False(ok)
+}
@@ -51,4 +51,17 @@ type InternalHandler interface {
 		requestID uint32,
 		op message.Op,
 	)
+	// CacheResponse records that the response to the [op] request [requestID]
+	// from [nodeID] on [chainID] was sent. [respond] sends the same response to
+	// another request ID, and must not call into the router.
+	CacheResponse(
+		nodeID ids.NodeID,
+		chainID ids.ID,
+		requestID uint32,
+		op message.Op,
+		respond func(requestID uint32),
+	)
+	// OutstandingRequests returns the number of requests sent to [nodeID] on
+	// [chainID] that are waiting for a response or a timeout
+	OutstandingRequests(nodeID ids.NodeID, chainID ids.ID) int
 }
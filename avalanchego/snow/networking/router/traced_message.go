@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/trace"
+)
+
+var _ trace.Traced = &tracedMessage{}
+
+// tracedMessage ends the span of a message once the chain finished handling
+// it. The spans started while handling the message are parented to it.
+type tracedMessage struct {
+	message.InboundMessage
+
+	ctx  context.Context
+	span oteltrace.Span
+}
+
+// traceMessage starts the span of [msg], which is about to be handled by chain
+// [chainID]. Must wrap any other wrapper of [msg], so the handler can find the
+// span of the message.
+func traceMessage(
+	tracer trace.Tracer,
+	msg message.InboundMessage,
+	chainID ids.ID,
+	requestID uint32,
+) message.InboundMessage {
+	op := msg.Op()
+	ctx, span := tracer.Start(
+		context.Background(),
+		fmt.Sprintf("router.%s", op),
+		oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+		oteltrace.WithAttributes(
+			attribute.Stringer("op", op),
+			attribute.Stringer("chainID", chainID),
+			attribute.Stringer("nodeID", msg.NodeID()),
+			attribute.Int64("requestID", int64(requestID)),
+		),
+	)
+	return &tracedMessage{
+		InboundMessage: msg,
+		ctx:            ctx,
+		span:           span,
+	}
+}
+
+func (m *tracedMessage) OnFinishedHandling() {
+	m.InboundMessage.OnFinishedHandling()
+	m.span.End()
+}
+
+func (m *tracedMessage) TraceContext() context.Context { return m.ctx }
@@ -0,0 +1,75 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package router
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/trace"
+)
+
+type testTracer struct {
+	oteltrace.Tracer
+}
+
+func (testTracer) Close() error { return nil }
+
+func TestTracedMessageEndsSpan(t *testing.T) {
+	require := require.New(t)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := testTracer{Tracer: tp.Tracer("test")}
+
+	mc, err := message.NewCreator(prometheus.NewRegistry(), "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+
+	finished := false
+	chainID := ids.GenerateTestID()
+	nodeID := ids.GenerateTestNodeID()
+	msg := traceMessage(
+		tracer,
+		&timedResponse{
+			InboundMessage: mc.InboundPut(chainID, 1, nil, nodeID),
+			onFinishedHandling: func() {
+				finished = true
+			},
+		},
+		chainID,
+		1,
+	)
+
+	// The handler parents its spans to the span of the message.
+	spanCtx := oteltrace.SpanContextFromContext(trace.ContextOf(msg))
+	require.True(spanCtx.IsValid())
+	require.Empty(recorder.Ended())
+
+	msg.OnFinishedHandling()
+	require.True(finished)
+
+	spans := recorder.Ended()
+	require.Len(spans, 1)
+	span := spans[0]
+	require.Equal("router.put", span.Name())
+	require.Equal(spanCtx.SpanID(), span.SpanContext().SpanID())
+	require.ElementsMatch([]attribute.KeyValue{
+		attribute.String("op", message.Put.String()),
+		attribute.String("chainID", chainID.String()),
+		attribute.String("nodeID", nodeID.String()),
+		attribute.Int64("requestID", 1),
+	}, span.Attributes())
+}
@@ -29,4 +29,16 @@ type ExternalSender interface {
 		numNonValidatorsToSend int,
 		numPeersToSend int,
 	) ids.NodeIDSet
+
+	// Send a message to a random group of nodes in a subnet.
+	// Validators are sampled with probability proportional to their stake.
+	// Non-validators and peers are sampled as in Gossip.
+	StakeWeightedGossip(
+		msg message.OutboundMessage,
+		subnetID ids.ID,
+		validatorOnly bool,
+		numValidatorsToSend int,
+		numNonValidatorsToSend int,
+		numPeersToSend int,
+	) ids.NodeIDSet
 }
@@ -0,0 +1,46 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package sender
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MaxGossipSize is the largest number of nodes of any kind that a single
+// message may be gossiped to when the gossip config is changed at runtime.
+const MaxGossipSize = 1024
+
+var errGossipSizeTooLarge = errors.New("gossip size too large")
+
+// GossipConfigurer allows the gossip config of a chain to be inspected and
+// changed while the chain is running.
+type GossipConfigurer interface {
+	GossipConfig() GossipConfig
+	SetGossipConfig(GossipConfig) error
+}
+
+// Verify returns an error if any of the sizes in [c] exceeds [MaxGossipSize].
+func (c *GossipConfig) Verify() error {
+	sizes := []struct {
+		name string
+		size uint
+	}{
+		{name: "acceptedFrontierValidatorSize", size: c.AcceptedFrontierValidatorSize},
+		{name: "acceptedFrontierNonValidatorSize", size: c.AcceptedFrontierNonValidatorSize},
+		{name: "acceptedFrontierPeerSize", size: c.AcceptedFrontierPeerSize},
+		{name: "onAcceptValidatorSize", size: c.OnAcceptValidatorSize},
+		{name: "onAcceptNonValidatorSize", size: c.OnAcceptNonValidatorSize},
+		{name: "onAcceptPeerSize", size: c.OnAcceptPeerSize},
+		{name: "appGossipValidatorSize", size: c.AppGossipValidatorSize},
+		{name: "appGossipNonValidatorSize", size: c.AppGossipNonValidatorSize},
+		{name: "appGossipPeerSize", size: c.AppGossipPeerSize},
+	}
+	for _, s := range sizes {
+		if s.size > MaxGossipSize {
+			return fmt.Errorf("%w: %s is %d but must be <= %d", errGossipSizeTooLarge, s.name, s.size, MaxGossipSize)
+		}
+	}
+	return nil
+}
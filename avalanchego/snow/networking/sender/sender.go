@@ -5,12 +5,15 @@ package sender
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
 
+	oteltrace "go.opentelemetry.io/otel/trace"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
 	"github.com/ava-labs/avalanchego/snow"
@@ -21,7 +24,11 @@ import (
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 )
 
-var _ common.Sender = &sender{}
+var (
+	_ common.Sender         = &sender{}
+	_ common.AppErrorSender = &sender{}
+	_ GossipConfigurer      = &sender{}
+)
 
 type GossipConfig struct {
 	AcceptedFrontierValidatorSize    uint `json:"gossipAcceptedFrontierValidatorSize" yaml:"gossipAcceptedFrontierValidatorSize"`
@@ -33,6 +40,10 @@ type GossipConfig struct {
 	AppGossipValidatorSize           uint `json:"appGossipValidatorSize" yaml:"appGossipValidatorSize"`
 	AppGossipNonValidatorSize        uint `json:"appGossipNonValidatorSize" yaml:"appGossipNonValidatorSize"`
 	AppGossipPeerSize                uint `json:"appGossipPeerSize" yaml:"appGossipPeerSize"`
+	// AppGossipStakeWeighted samples the validators that AppGossip messages
+	// are sent to with probability proportional to their stake, rather than
+	// uniformly.
+	AppGossipStakeWeighted bool `json:"appGossipStakeWeighted" yaml:"appGossipStakeWeighted"`
 }
 
 // sender is a wrapper around an ExternalSender.
@@ -54,11 +65,19 @@ type sender struct {
 	router   router.Router
 	timeouts timeout.Manager
 
-	gossipConfig GossipConfig
+	gossipConfigLock sync.RWMutex
+	gossipConfig     GossipConfig
 
 	// Request message type --> Counts how many of that request
 	// have failed because the node was benched
 	failedDueToBench map[message.Op]prometheus.Counter
+
+	// Maximum number of requests that may be outstanding to a single node on
+	// this chain. If 0, the number isn't limited.
+	maxOutstandingRequestsPerPeer int
+	// Request message type --> Counts how many of that request
+	// have failed because the node had too many outstanding requests
+	failedDueToOverload map[message.Op]prometheus.Counter
 }
 
 func New(
@@ -70,6 +89,7 @@ func New(
 	router router.Router,
 	timeouts timeout.Manager,
 	gossipConfig GossipConfig,
+	maxOutstandingRequestsPerPeer int,
 ) (common.Sender, error) {
 	s := &sender{
 		ctx:                 ctx,
@@ -81,6 +101,9 @@ func New(
 		timeouts:            timeouts,
 		gossipConfig:        gossipConfig,
 		failedDueToBench:    make(map[message.Op]prometheus.Counter, len(message.ConsensusRequestOps)),
+
+		maxOutstandingRequestsPerPeer: maxOutstandingRequestsPerPeer,
+		failedDueToOverload:           make(map[message.Op]prometheus.Counter, len(message.ConsensusRequestOps)),
 	}
 
 	for _, op := range message.ConsensusRequestOps {
@@ -94,10 +117,58 @@ func New(
 			return nil, fmt.Errorf("couldn't register metric for %s: %w", op, err)
 		}
 		s.failedDueToBench[op] = counter
+
+		overloadCounter := prometheus.NewCounter(
+			prometheus.CounterOpts{
+				Name: fmt.Sprintf("%s_failed_overloaded", op),
+				Help: fmt.Sprintf("# of times a %s request was not sent because the node had too many outstanding requests", op),
+			},
+		)
+		if err := ctx.Registerer.Register(overloadCounter); err != nil {
+			return nil, fmt.Errorf("couldn't register metric for %s: %w", op, err)
+		}
+		s.failedDueToOverload[op] = overloadCounter
 	}
 	return s, nil
 }
 
+func (s *sender) GossipConfig() GossipConfig {
+	s.gossipConfigLock.RLock()
+	defer s.gossipConfigLock.RUnlock()
+
+	return s.gossipConfig
+}
+
+func (s *sender) SetGossipConfig(config GossipConfig) error {
+	if err := config.Verify(); err != nil {
+		return err
+	}
+
+	s.gossipConfigLock.Lock()
+	defer s.gossipConfigLock.Unlock()
+
+	s.gossipConfig = config
+	return nil
+}
+
+// isOverloaded returns true if [nodeID] has more outstanding requests on this
+// chain than allowed. Assumes the request being sent is already registered.
+func (s *sender) isOverloaded(nodeID ids.NodeID) bool {
+	return s.maxOutstandingRequestsPerPeer > 0 &&
+		s.router.OutstandingRequests(nodeID, s.ctx.ChainID) > s.maxOutstandingRequestsPerPeer
+}
+
+// startSpan starts the span of sending [op], parented to the message the
+// chain is handling.
+func (s *sender) startSpan(op message.Op) oteltrace.Span {
+	_, span := s.ctx.Tracer.Start(
+		s.ctx.TraceScope.Context(),
+		fmt.Sprintf("sender.%s", op),
+		oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+	)
+	return span
+}
+
 func (s *sender) getMsgCreator() message.Creator {
 	now := s.clock.Time()
 	if now.Before(s.banffTime) {
@@ -107,9 +178,11 @@ func (s *sender) getMsgCreator() message.Creator {
 }
 
 func (s *sender) SendGetStateSummaryFrontier(nodeIDs ids.NodeIDSet, requestID uint32) {
+	defer s.startSpan(message.GetStateSummaryFrontier).End()
+
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -160,6 +233,8 @@ func (s *sender) SendGetStateSummaryFrontier(nodeIDs ids.NodeIDSet, requestID ui
 }
 
 func (s *sender) SendStateSummaryFrontier(nodeID ids.NodeID, requestID uint32, summary []byte) {
+	defer s.startSpan(message.StateSummaryFrontier).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Sending this message to myself.
@@ -203,9 +278,11 @@ func (s *sender) SendStateSummaryFrontier(nodeID ids.NodeID, requestID uint32, s
 }
 
 func (s *sender) SendGetAcceptedStateSummary(nodeIDs ids.NodeIDSet, requestID uint32, heights []uint64) {
+	defer s.startSpan(message.GetAcceptedStateSummary).End()
+
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -257,6 +334,8 @@ func (s *sender) SendGetAcceptedStateSummary(nodeIDs ids.NodeIDSet, requestID ui
 }
 
 func (s *sender) SendAcceptedStateSummary(nodeID ids.NodeID, requestID uint32, summaryIDs []ids.ID) {
+	defer s.startSpan(message.AcceptedStateSummary).End()
+
 	msgCreator := s.getMsgCreator()
 
 	if nodeID == s.ctx.NodeID {
@@ -293,9 +372,11 @@ func (s *sender) SendAcceptedStateSummary(nodeID ids.NodeID, requestID uint32, s
 }
 
 func (s *sender) SendGetAcceptedFrontier(nodeIDs ids.NodeIDSet, requestID uint32) {
+	defer s.startSpan(message.GetAcceptedFrontier).End()
+
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -346,6 +427,8 @@ func (s *sender) SendGetAcceptedFrontier(nodeIDs ids.NodeIDSet, requestID uint32
 }
 
 func (s *sender) SendAcceptedFrontier(nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
+	defer s.startSpan(message.AcceptedFrontier).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Sending this message to myself.
@@ -383,9 +466,11 @@ func (s *sender) SendAcceptedFrontier(nodeID ids.NodeID, requestID uint32, conta
 }
 
 func (s *sender) SendGetAccepted(nodeIDs ids.NodeIDSet, requestID uint32, containerIDs []ids.ID) {
+	defer s.startSpan(message.GetAccepted).End()
+
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
@@ -437,6 +522,8 @@ func (s *sender) SendGetAccepted(nodeIDs ids.NodeIDSet, requestID uint32, contai
 }
 
 func (s *sender) SendAccepted(nodeID ids.NodeID, requestID uint32, containerIDs []ids.ID) {
+	defer s.startSpan(message.Accepted).End()
+
 	msgCreator := s.getMsgCreator()
 
 	if nodeID == s.ctx.NodeID {
@@ -473,6 +560,8 @@ func (s *sender) SendAccepted(nodeID ids.NodeID, requestID uint32, containerIDs
 }
 
 func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	defer s.startSpan(message.GetAncestors).End()
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from this node.
 	s.router.RegisterRequest(nodeID, s.ctx.ChainID, requestID, message.Ancestors)
@@ -490,7 +579,17 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 	// so we don't even bother sending requests to them. We just have them immediately fail.
 	if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
 		s.failedDueToBench[message.GetAncestors].Inc() // update metric
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
+		inMsg := msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
+		go s.router.HandleInbound(inMsg)
+		return
+	}
+
+	// [nodeID] may already have too many outstanding requests on this chain.
+	// The request fails immediately instead of adding to the requests that
+	// may time out, so that the engine can send it to another peer.
+	if s.isOverloaded(nodeID) {
+		s.failedDueToOverload[message.GetAncestors].Inc() // update metric
 		inMsg := msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
 		go s.router.HandleInbound(inMsg)
 		return
@@ -498,7 +597,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 	// Create the outbound message.
 	outMsg, err := msgCreator.GetAncestors(s.ctx.ChainID, requestID, deadline, containerID)
 	if err != nil {
@@ -527,7 +626,7 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 			zap.Stringer("containerID", containerID),
 		)
 
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
 		inMsg := msgCreator.InternalFailedRequest(message.GetAncestorsFailed, nodeID, s.ctx.ChainID, requestID)
 		go s.router.HandleInbound(inMsg)
 	}
@@ -537,6 +636,8 @@ func (s *sender) SendGetAncestors(nodeID ids.NodeID, requestID uint32, container
 // on the specified node.
 // The Ancestors message gives the recipient the contents of several containers.
 func (s *sender) SendAncestors(nodeID ids.NodeID, requestID uint32, containers [][]byte) {
+	defer s.startSpan(message.Ancestors).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Create the outbound message.
@@ -571,6 +672,8 @@ func (s *sender) SendAncestors(nodeID ids.NodeID, requestID uint32, containers [
 // consensus engine would like the recipient to send this consensus engine the
 // specified container.
 func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID) {
+	defer s.startSpan(message.Get).End()
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from this node.
 	s.router.RegisterRequest(nodeID, s.ctx.ChainID, requestID, message.Put)
@@ -588,7 +691,17 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 	// so we don't even bother sending requests to them. We just have them immediately fail.
 	if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
 		s.failedDueToBench[message.Get].Inc() // update metric
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
+		inMsg := msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
+		go s.router.HandleInbound(inMsg)
+		return
+	}
+
+	// [nodeID] may already have too many outstanding requests on this chain.
+	// The request fails immediately instead of adding to the requests that
+	// may time out, so that the engine can send it to another peer.
+	if s.isOverloaded(nodeID) {
+		s.failedDueToOverload[message.Get].Inc() // update metric
 		inMsg := msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
 		go s.router.HandleInbound(inMsg)
 		return
@@ -596,7 +709,7 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 	// Create the outbound message.
 	outMsg, err := msgCreator.Get(s.ctx.ChainID, requestID, deadline, containerID)
 
@@ -626,7 +739,7 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 			zap.Stringer("containerID", containerID),
 		)
 
-		s.timeouts.RegisterRequestToUnreachableValidator()
+		s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
 		inMsg := msgCreator.InternalFailedRequest(message.GetFailed, nodeID, s.ctx.ChainID, requestID)
 		go s.router.HandleInbound(inMsg)
 	}
@@ -637,6 +750,15 @@ func (s *sender) SendGet(nodeID ids.NodeID, requestID uint32, containerID ids.ID
 // The Put message signifies that this consensus engine is giving to the recipient
 // the contents of the specified container.
 func (s *sender) SendPut(nodeID ids.NodeID, requestID uint32, container []byte) {
+	defer s.startSpan(message.Put).End()
+
+	s.sendPut(nodeID, requestID, container)
+	s.router.CacheResponse(nodeID, s.ctx.ChainID, requestID, message.Get, func(requestID uint32) {
+		s.sendPut(nodeID, requestID, container)
+	})
+}
+
+func (s *sender) sendPut(nodeID ids.NodeID, requestID uint32, container []byte) {
 	msgCreator := s.getMsgCreator()
 
 	// Create the outbound message.
@@ -677,6 +799,8 @@ func (s *sender) SendPut(nodeID ids.NodeID, requestID uint32, container []byte)
 // The PushQuery message signifies that this consensus engine would like each node to send
 // their preferred frontier given the existence of the specified container.
 func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, container []byte) {
+	defer s.startSpan(message.PushQuery).End()
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
 	// We register timeouts for all nodes, regardless of whether we fail
@@ -688,7 +812,7 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	msgCreator := s.getMsgCreator()
 
@@ -706,7 +830,21 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 		if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
 			s.failedDueToBench[message.PushQuery].Inc() // update metric
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
+
+			// Immediately register a failure. Do so asynchronously to avoid deadlock.
+			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
+			go s.router.HandleInbound(inMsg)
+		}
+	}
+
+	// Some of [nodeIDs] may already have too many outstanding requests on this
+	// chain. Their requests fail immediately instead of adding to the requests
+	// that may time out.
+	for nodeID := range nodeIDs {
+		if s.isOverloaded(nodeID) {
+			s.failedDueToOverload[message.PushQuery].Inc() // update metric
+			nodeIDs.Remove(nodeID)
 
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
@@ -749,7 +887,7 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 			)
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
 			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
 			go s.router.HandleInbound(inMsg)
 		}
@@ -761,6 +899,8 @@ func (s *sender) SendPushQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 // The PullQuery message signifies that this consensus engine would like each node to send
 // their preferred frontier.
 func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containerID ids.ID) {
+	defer s.startSpan(message.PullQuery).End()
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
 	// We register timeouts for all nodes, regardless of whether we fail
@@ -772,7 +912,7 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	msgCreator := s.getMsgCreator()
 
@@ -790,7 +930,21 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 		if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
 			s.failedDueToBench[message.PullQuery].Inc() // update metric
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
+			// Immediately register a failure. Do so asynchronously to avoid deadlock.
+			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
+			go s.router.HandleInbound(inMsg)
+		}
+	}
+
+	// Some of [nodeIDs] may already have too many outstanding requests on this
+	// chain. Their requests fail immediately instead of adding to the requests
+	// that may time out.
+	for nodeID := range nodeIDs {
+		if s.isOverloaded(nodeID) {
+			s.failedDueToOverload[message.PullQuery].Inc() // update metric
+			nodeIDs.Remove(nodeID)
+
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
 			go s.router.HandleInbound(inMsg)
@@ -826,7 +980,7 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 			)
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
 			inMsg := msgCreator.InternalFailedRequest(message.QueryFailed, nodeID, s.ctx.ChainID, requestID)
 			go s.router.HandleInbound(inMsg)
 		}
@@ -835,6 +989,15 @@ func (s *sender) SendPullQuery(nodeIDs ids.NodeIDSet, requestID uint32, containe
 
 // SendChits sends chits
 func (s *sender) SendChits(nodeID ids.NodeID, requestID uint32, votes []ids.ID) {
+	defer s.startSpan(message.Chits).End()
+
+	s.sendChits(nodeID, requestID, votes)
+	s.router.CacheResponse(nodeID, s.ctx.ChainID, requestID, message.PullQuery, func(requestID uint32) {
+		s.sendChits(nodeID, requestID, votes)
+	})
+}
+
+func (s *sender) sendChits(nodeID ids.NodeID, requestID uint32, votes []ids.ID) {
 	msgCreator := s.getMsgCreator()
 
 	// If [nodeID] is myself, send this message directly
@@ -875,6 +1038,8 @@ func (s *sender) SendChits(nodeID ids.NodeID, requestID uint32, votes []ids.ID)
 // SendAppRequest sends an application-level request to the given nodes.
 // The meaning of this request, and how it should be handled, is defined by the VM.
 func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequestBytes []byte) error {
+	defer s.startSpan(message.AppRequest).End()
+
 	// Tell the router to expect a response message or a message notifying
 	// that we won't get a response from each of these nodes.
 	// We register timeouts for all nodes, regardless of whether we fail
@@ -886,7 +1051,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 
 	// Note that this timeout duration won't exactly match the one that gets
 	// registered. That's OK.
-	deadline := s.timeouts.TimeoutDuration()
+	deadline := s.timeouts.TimeoutDuration(s.ctx.ChainID)
 
 	msgCreator := s.getMsgCreator()
 
@@ -904,7 +1069,21 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 		if s.timeouts.IsBenched(nodeID, s.ctx.ChainID) {
 			s.failedDueToBench[message.AppRequest].Inc() // update metric
 			nodeIDs.Remove(nodeID)
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
+
+			// Immediately register a failure. Do so asynchronously to avoid deadlock.
+			inMsg := msgCreator.InternalFailedRequest(message.AppRequestFailed, nodeID, s.ctx.ChainID, requestID)
+			go s.router.HandleInbound(inMsg)
+		}
+	}
+
+	// Some of [nodeIDs] may already have too many outstanding requests on this
+	// chain. Their requests fail immediately instead of adding to the requests
+	// that may time out.
+	for nodeID := range nodeIDs {
+		if s.isOverloaded(nodeID) {
+			s.failedDueToOverload[message.AppRequest].Inc() // update metric
+			nodeIDs.Remove(nodeID)
 
 			// Immediately register a failure. Do so asynchronously to avoid deadlock.
 			inMsg := msgCreator.InternalFailedRequest(message.AppRequestFailed, nodeID, s.ctx.ChainID, requestID)
@@ -947,7 +1126,7 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 			)
 
 			// Register failures for nodes we didn't send a request to.
-			s.timeouts.RegisterRequestToUnreachableValidator()
+			s.timeouts.RegisterRequestToUnreachableValidator(s.ctx.ChainID)
 			inMsg := msgCreator.InternalFailedRequest(message.AppRequestFailed, nodeID, s.ctx.ChainID, requestID)
 			go s.router.HandleInbound(inMsg)
 		}
@@ -958,6 +1137,8 @@ func (s *sender) SendAppRequest(nodeIDs ids.NodeIDSet, requestID uint32, appRequ
 // SendAppResponse sends a response to an application-level request from the
 // given node
 func (s *sender) SendAppResponse(nodeID ids.NodeID, requestID uint32, appResponseBytes []byte) error {
+	defer s.startSpan(message.AppResponse).End()
+
 	msgCreator := s.getMsgCreator()
 
 	if nodeID == s.ctx.NodeID {
@@ -1000,7 +1181,50 @@ func (s *sender) SendAppResponse(nodeID ids.NodeID, requestID uint32, appRespons
 	return nil
 }
 
+// SendAppError refuses an application-level request from the given node
+func (s *sender) SendAppError(nodeID ids.NodeID, requestID uint32, errorCode int32, errorMessage string) error {
+	defer s.startSpan(message.AppError).End()
+
+	msgCreator := s.getMsgCreator()
+
+	if nodeID == s.ctx.NodeID {
+		inMsg := msgCreator.InboundAppError(s.ctx.ChainID, requestID, errorCode, errorMessage, nodeID)
+		go s.router.HandleInbound(inMsg)
+		return nil
+	}
+
+	// Create the outbound message.
+	outMsg, err := msgCreator.AppError(s.ctx.ChainID, requestID, errorCode, errorMessage)
+	if err != nil {
+		s.ctx.Log.Error("failed to build message",
+			zap.Stringer("messageOp", message.AppError),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Int32("errorCode", errorCode),
+			zap.String("errorMessage", errorMessage),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	// Send the message over the network.
+	nodeIDs := ids.NewNodeIDSet(1)
+	nodeIDs.Add(nodeID)
+	if sentTo := s.sender.Send(outMsg, nodeIDs, s.ctx.SubnetID, s.ctx.IsValidatorOnly()); sentTo.Len() == 0 {
+		s.ctx.Log.Debug("failed to send message",
+			zap.Stringer("messageOp", message.AppError),
+			zap.Stringer("nodeID", nodeID),
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.Uint32("requestID", requestID),
+			zap.Int32("errorCode", errorCode),
+		)
+	}
+	return nil
+}
+
 func (s *sender) SendAppGossipSpecific(nodeIDs ids.NodeIDSet, appGossipBytes []byte) error {
+	defer s.startSpan(message.AppGossip).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Create the outbound message.
@@ -1038,6 +1262,8 @@ func (s *sender) SendAppGossipSpecific(nodeIDs ids.NodeIDSet, appGossipBytes []b
 
 // SendAppGossip sends an application-level gossip message.
 func (s *sender) SendAppGossip(appGossipBytes []byte) error {
+	defer s.startSpan(message.AppGossip).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Create the outbound message.
@@ -1052,11 +1278,17 @@ func (s *sender) SendAppGossip(appGossipBytes []byte) error {
 		return nil
 	}
 
-	validatorSize := int(s.gossipConfig.AppGossipValidatorSize)
-	nonValidatorSize := int(s.gossipConfig.AppGossipNonValidatorSize)
-	peerSize := int(s.gossipConfig.AppGossipPeerSize)
+	gossipConfig := s.GossipConfig()
+	validatorSize := int(gossipConfig.AppGossipValidatorSize)
+	nonValidatorSize := int(gossipConfig.AppGossipNonValidatorSize)
+	peerSize := int(gossipConfig.AppGossipPeerSize)
 
-	sentTo := s.sender.Gossip(outMsg, s.ctx.SubnetID, s.ctx.IsValidatorOnly(), validatorSize, nonValidatorSize, peerSize)
+	var sentTo ids.NodeIDSet
+	if gossipConfig.AppGossipStakeWeighted {
+		sentTo = s.sender.StakeWeightedGossip(outMsg, s.ctx.SubnetID, s.ctx.IsValidatorOnly(), validatorSize, nonValidatorSize, peerSize)
+	} else {
+		sentTo = s.sender.Gossip(outMsg, s.ctx.SubnetID, s.ctx.IsValidatorOnly(), validatorSize, nonValidatorSize, peerSize)
+	}
 	if sentTo.Len() == 0 {
 		s.ctx.Log.Debug("failed to send message",
 			zap.Stringer("messageOp", message.AppGossip),
@@ -1073,6 +1305,8 @@ func (s *sender) SendAppGossip(appGossipBytes []byte) error {
 
 // SendGossip gossips the provided container
 func (s *sender) SendGossip(container []byte) {
+	defer s.startSpan(message.Put).End()
+
 	msgCreator := s.getMsgCreator()
 
 	// Create the outbound message.
@@ -1087,13 +1321,14 @@ func (s *sender) SendGossip(container []byte) {
 		return
 	}
 
+	gossipConfig := s.GossipConfig()
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
 		s.ctx.IsValidatorOnly(),
-		int(s.gossipConfig.AcceptedFrontierValidatorSize),
-		int(s.gossipConfig.AcceptedFrontierNonValidatorSize),
-		int(s.gossipConfig.AcceptedFrontierPeerSize),
+		int(gossipConfig.AcceptedFrontierValidatorSize),
+		int(gossipConfig.AcceptedFrontierNonValidatorSize),
+		int(gossipConfig.AcceptedFrontierPeerSize),
 	)
 	if sentTo.Len() == 0 {
 		s.ctx.Log.Debug("failed to send message",
@@ -1114,6 +1349,14 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 		// don't gossip during bootstrapping
 		return nil
 	}
+	if ctx.GossipedContainers.Contains(container) {
+		// don't re-gossip containers that were received via gossip
+		s.ctx.Log.Verbo("skipping gossip of accepted container",
+			zap.Stringer("chainID", s.ctx.ChainID),
+			zap.String("reason", "received via gossip"),
+		)
+		return nil
+	}
 
 	msgCreator := s.getMsgCreator()
 
@@ -1129,13 +1372,14 @@ func (s *sender) Accept(ctx *snow.ConsensusContext, _ ids.ID, container []byte)
 		return nil
 	}
 
+	gossipConfig := s.GossipConfig()
 	sentTo := s.sender.Gossip(
 		outMsg,
 		s.ctx.SubnetID,
 		s.ctx.IsValidatorOnly(),
-		int(s.gossipConfig.OnAcceptValidatorSize),
-		int(s.gossipConfig.OnAcceptNonValidatorSize),
-		int(s.gossipConfig.OnAcceptPeerSize),
+		int(gossipConfig.OnAcceptValidatorSize),
+		int(gossipConfig.OnAcceptNonValidatorSize),
+		int(gossipConfig.OnAcceptPeerSize),
 	)
 	if sentTo.Len() == 0 {
 		s.ctx.Log.Debug("failed to send message",
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 
 	"github.com/stretchr/testify/require"
 
@@ -23,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/networking/tracker"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math/meter"
 	"github.com/ava-labs/avalanchego/utils/resource"
@@ -50,6 +52,7 @@ func TestTimeout(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 			TimeoutCoefficient: 1.25,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -62,7 +65,7 @@ func TestTimeout(t *testing.T) {
 	metrics := prometheus.NewRegistry()
 	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
 	require.NoError(t, err)
-	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", true, 10*time.Second)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
@@ -72,7 +75,7 @@ func TestTimeout(t *testing.T) {
 	externalSender := &ExternalSenderTest{TB: t}
 	externalSender.Default(false)
 
-	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig)
+	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig, 0)
 	require.NoError(t, err)
 
 	wg := sync.WaitGroup{}
@@ -81,6 +84,8 @@ func TestTimeout(t *testing.T) {
 	ctx := snow.DefaultConsensusContextTest()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -89,6 +94,8 @@ func TestTimeout(t *testing.T) {
 		nil,
 		time.Hour,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -143,6 +150,7 @@ func TestReliableMessages(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 			TimeoutCoefficient: 1.25,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -156,7 +164,7 @@ func TestReliableMessages(t *testing.T) {
 	metrics := prometheus.NewRegistry()
 	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
 	require.NoError(t, err)
-	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", true, 10*time.Second)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
@@ -167,12 +175,14 @@ func TestReliableMessages(t *testing.T) {
 	externalSender := &ExternalSenderTest{TB: t}
 	externalSender.Default(false)
 
-	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig)
+	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig, 0)
 	require.NoError(t, err)
 
 	ctx := snow.DefaultConsensusContextTest()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -181,6 +191,8 @@ func TestReliableMessages(t *testing.T) {
 		nil,
 		1,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -242,6 +254,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 			TimeoutHalflife:    5 * time.Minute,
 			TimeoutCoefficient: 1.25,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -255,7 +268,7 @@ func TestReliableMessagesToMyself(t *testing.T) {
 	metrics := prometheus.NewRegistry()
 	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
 	require.NoError(t, err)
-	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", true, 10*time.Second)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 	require.NoError(t, err)
 
 	err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, tm, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
@@ -266,12 +279,14 @@ func TestReliableMessagesToMyself(t *testing.T) {
 	externalSender := &ExternalSenderTest{TB: t}
 	externalSender.Default(false)
 
-	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig)
+	sender, err := New(context, mc, mcProto, time.Now().Add(time.Hour) /* TODO: test with banff accepted */, externalSender, &chainRouter, tm, defaultGossipConfig, 0)
 	require.NoError(t, err)
 
 	ctx := snow.DefaultConsensusContextTest()
 	resourceTracker, err := tracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 	require.NoError(t, err)
+	chainTracker, err := tracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+	require.NoError(t, err)
 	handler, err := handler.New(
 		mc,
 		ctx,
@@ -280,6 +295,8 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		nil,
 		time.Second,
 		resourceTracker,
+		chainTracker,
+		handler.MessageQueueConfig{},
 	)
 	require.NoError(t, err)
 
@@ -327,3 +344,218 @@ func TestReliableMessagesToMyself(t *testing.T) {
 		<-await
 	}
 }
+
+func TestSetGossipConfig(t *testing.T) {
+	require := require.New(t)
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.SetState(snow.NormalOp)
+	externalSender := &ExternalSenderTest{TB: t}
+	externalSender.Default(false)
+
+	gossipedToPeers := 0
+	externalSender.GossipF = func(_ message.OutboundMessage, _ ids.ID, _ bool, _, _, numPeersToSend int) ids.NodeIDSet {
+		gossipedToPeers = numPeersToSend
+		return nil
+	}
+
+	s, err := New(ctx, mc, mcProto, time.Now().Add(time.Hour), externalSender, nil, nil, defaultGossipConfig, 0)
+	require.NoError(err)
+	configurer := s.(GossipConfigurer)
+	require.Equal(defaultGossipConfig, configurer.GossipConfig())
+
+	require.NoError(s.Accept(ctx, ids.Empty, []byte{1}))
+	require.Equal(int(defaultGossipConfig.OnAcceptPeerSize), gossipedToPeers)
+
+	newConfig := defaultGossipConfig
+	newConfig.OnAcceptPeerSize = 5
+	require.NoError(configurer.SetGossipConfig(newConfig))
+	require.Equal(newConfig, configurer.GossipConfig())
+
+	require.NoError(s.Accept(ctx, ids.Empty, []byte{1}))
+	require.Equal(5, gossipedToPeers)
+
+	// Sizes above the bound are refused and the previous config is kept.
+	newConfig.AppGossipPeerSize = MaxGossipSize + 1
+	err = configurer.SetGossipConfig(newConfig)
+	require.ErrorIs(err, errGossipSizeTooLarge)
+	require.Zero(configurer.GossipConfig().AppGossipPeerSize)
+}
+
+func TestAcceptSkipsGossipedContainers(t *testing.T) {
+	require := require.New(t)
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.SetState(snow.NormalOp)
+	ctx.GossipedContainers = snow.NewGossipedContainers(time.Minute)
+	externalSender := &ExternalSenderTest{TB: t}
+	externalSender.Default(false)
+
+	numGossiped := 0
+	externalSender.GossipF = func(message.OutboundMessage, ids.ID, bool, int, int, int) ids.NodeIDSet {
+		numGossiped++
+		return nil
+	}
+
+	s, err := New(ctx, mc, mcProto, time.Now().Add(time.Hour), externalSender, nil, nil, defaultGossipConfig, 0)
+	require.NoError(err)
+
+	// Containers that weren't received via gossip are gossiped.
+	require.NoError(s.Accept(ctx, ids.Empty, []byte{1}))
+	require.Equal(1, numGossiped)
+
+	// Containers that were received via gossip aren't.
+	ctx.GossipedContainers.Add([]byte{2})
+	require.NoError(s.Accept(ctx, ids.Empty, []byte{2}))
+	require.Equal(1, numGossiped)
+}
+
+func TestSendAppGossipStakeWeighted(t *testing.T) {
+	require := require.New(t)
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.SetState(snow.NormalOp)
+	externalSender := &ExternalSenderTest{TB: t}
+	externalSender.Default(true)
+
+	gossiped, stakeWeightedGossiped := 0, 0
+	externalSender.GossipF = func(_ message.OutboundMessage, _ ids.ID, _ bool, numValidatorsToSend, _, _ int) ids.NodeIDSet {
+		gossiped++
+		require.Equal(int(defaultGossipConfig.AppGossipValidatorSize), numValidatorsToSend)
+		return nil
+	}
+	externalSender.StakeWeightedGossipF = func(_ message.OutboundMessage, _ ids.ID, _ bool, numValidatorsToSend, _, _ int) ids.NodeIDSet {
+		stakeWeightedGossiped++
+		require.Equal(int(defaultGossipConfig.AppGossipValidatorSize), numValidatorsToSend)
+		return nil
+	}
+
+	s, err := New(ctx, mc, mcProto, time.Now().Add(time.Hour), externalSender, nil, nil, defaultGossipConfig, 0)
+	require.NoError(err)
+
+	require.NoError(s.SendAppGossip([]byte{1}))
+	require.Equal(1, gossiped)
+	require.Zero(stakeWeightedGossiped)
+
+	newConfig := defaultGossipConfig
+	newConfig.AppGossipStakeWeighted = true
+	require.NoError(s.(GossipConfigurer).SetGossipConfig(newConfig))
+
+	require.NoError(s.SendAppGossip([]byte{1}))
+	require.Equal(1, gossiped)
+	require.Equal(1, stakeWeightedGossiped)
+}
+
+// outstandingRequestsRouter counts the registered requests and reports the
+// failures the sender registers
+type outstandingRequestsRouter struct {
+	router.Router
+
+	lock        sync.Mutex
+	outstanding map[ids.NodeID]int
+	failed      chan message.InboundMessage
+}
+
+func (r *outstandingRequestsRouter) RegisterRequest(nodeID ids.NodeID, _ ids.ID, _ uint32, _ message.Op) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.outstanding[nodeID]++
+}
+
+func (r *outstandingRequestsRouter) OutstandingRequests(nodeID ids.NodeID, _ ids.ID) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.outstanding[nodeID]
+}
+
+func (r *outstandingRequestsRouter) HandleInbound(msg message.InboundMessage) {
+	r.failed <- msg
+}
+
+func TestMaxOutstandingRequestsPerPeer(t *testing.T) {
+	require := require.New(t)
+
+	tm, err := timeout.NewManager(
+		&timer.AdaptiveTimeoutConfig{
+			InitialTimeout:     10 * time.Second,
+			MinimumTimeout:     10 * time.Second,
+			MaximumTimeout:     10 * time.Second,
+			TimeoutHalflife:    5 * time.Minute,
+			TimeoutCoefficient: 1.25,
+		},
+		nil,
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+
+	metrics := prometheus.NewRegistry()
+	mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
+	require.NoError(err)
+	mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
+	require.NoError(err)
+
+	ctx := snow.DefaultConsensusContextTest()
+	externalSender := &ExternalSenderTest{TB: t}
+	externalSender.Default(true)
+	var sentTo []ids.NodeIDSet
+	externalSender.SendF = func(_ message.OutboundMessage, nodeIDs ids.NodeIDSet, _ ids.ID, _ bool) ids.NodeIDSet {
+		sentTo = append(sentTo, nodeIDs)
+		return nodeIDs
+	}
+
+	r := &outstandingRequestsRouter{
+		outstanding: make(map[ids.NodeID]int),
+		failed:      make(chan message.InboundMessage, 1),
+	}
+	sIntf, err := New(ctx, mc, mcProto, time.Now().Add(time.Hour), externalSender, r, tm, defaultGossipConfig, 1)
+	require.NoError(err)
+	s := sIntf.(*sender)
+
+	slow, fast := ids.GenerateTestNodeID(), ids.GenerateTestNodeID()
+	s.SendGet(slow, 1, ids.Empty)
+	require.Len(sentTo, 1)
+	require.True(sentTo[0].Contains(slow))
+
+	// [slow] already has an outstanding request, so the next one fails
+	// immediately
+	s.SendGet(slow, 2, ids.Empty)
+	require.Len(sentTo, 1)
+	failed := <-r.failed
+	require.Equal(message.GetFailed, failed.Op())
+	require.Equal(slow, failed.NodeID())
+	require.Equal(1.0, testutil.ToFloat64(s.failedDueToOverload[message.Get]))
+
+	// Only the overloaded node is dropped from a query
+	nodeIDs := ids.NewNodeIDSet(2)
+	nodeIDs.Add(slow, fast)
+	s.SendPullQuery(nodeIDs, 3, ids.Empty)
+	failed = <-r.failed
+	require.Equal(message.QueryFailed, failed.Op())
+	require.Equal(slow, failed.NodeID())
+	require.Len(sentTo, 2)
+	require.Equal(1, sentTo[1].Len())
+	require.True(sentTo[1].Contains(fast))
+	require.Equal(1.0, testutil.ToFloat64(s.failedDueToOverload[message.PullQuery]))
+}
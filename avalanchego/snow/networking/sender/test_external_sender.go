@@ -12,24 +12,27 @@ import (
 )
 
 var (
-	errSend   = errors.New("unexpectedly called Send")
-	errGossip = errors.New("unexpectedly called Gossip")
+	errSend                = errors.New("unexpectedly called Send")
+	errGossip              = errors.New("unexpectedly called Gossip")
+	errStakeWeightedGossip = errors.New("unexpectedly called StakeWeightedGossip")
 )
 
 // ExternalSenderTest is a test sender
 type ExternalSenderTest struct {
 	TB testing.TB
 
-	CantSend, CantGossip bool
+	CantSend, CantGossip, CantStakeWeightedGossip bool
 
-	SendF   func(msg message.OutboundMessage, nodeIDs ids.NodeIDSet, subnetID ids.ID, validatorOnly bool) ids.NodeIDSet
-	GossipF func(msg message.OutboundMessage, subnetID ids.ID, validatorOnly bool, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend int) ids.NodeIDSet
+	SendF                func(msg message.OutboundMessage, nodeIDs ids.NodeIDSet, subnetID ids.ID, validatorOnly bool) ids.NodeIDSet
+	GossipF              func(msg message.OutboundMessage, subnetID ids.ID, validatorOnly bool, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend int) ids.NodeIDSet
+	StakeWeightedGossipF func(msg message.OutboundMessage, subnetID ids.ID, validatorOnly bool, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend int) ids.NodeIDSet
 }
 
 // Default set the default callable value to [cant]
 func (s *ExternalSenderTest) Default(cant bool) {
 	s.CantSend = cant
 	s.CantGossip = cant
+	s.CantStakeWeightedGossip = cant
 }
 
 func (s *ExternalSenderTest) Send(
@@ -72,3 +75,23 @@ func (s *ExternalSenderTest) Gossip(
 	}
 	return nil
 }
+
+func (s *ExternalSenderTest) StakeWeightedGossip(
+	msg message.OutboundMessage,
+	subnetID ids.ID,
+	validatorOnly bool,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+) ids.NodeIDSet {
+	if s.StakeWeightedGossipF != nil {
+		return s.StakeWeightedGossipF(msg, subnetID, validatorOnly, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend)
+	}
+	if s.CantStakeWeightedGossip {
+		if s.TB != nil {
+			s.TB.Helper()
+			s.TB.Fatal(errStakeWeightedGossip)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// dispatch notifies [handler] of [msg], like the chain's handler notifies its
+// engine. [deadline] is the deadline given to AppRequests.
+//
+// Unlike the chain's handler, messages with invalid fields are reported as
+// errors rather than dropped: the messages were built by the harness or the
+// test, so an invalid field is a bug in the test.
+func dispatch(handler common.AppHandler, msg message.InboundMessage, deadline time.Time) error {
+	var (
+		nodeID = msg.NodeID()
+		op     = msg.Op()
+	)
+	if op == message.AppGossip {
+		appBytes, err := msg.Get(message.AppBytes)
+		if err != nil {
+			return err
+		}
+		return handler.AppGossip(nodeID, appBytes.([]byte))
+	}
+
+	requestID, err := getRequestID(msg)
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case message.AppRequest:
+		appBytes, err := msg.Get(message.AppBytes)
+		if err != nil {
+			return err
+		}
+		return handler.AppRequest(nodeID, requestID, deadline, appBytes.([]byte))
+	case message.AppResponse:
+		appBytes, err := msg.Get(message.AppBytes)
+		if err != nil {
+			return err
+		}
+		return handler.AppResponse(nodeID, requestID, appBytes.([]byte))
+	case message.AppError:
+		errorCode, err := msg.Get(message.ErrorCode)
+		if err != nil {
+			return err
+		}
+		errorMessage, err := msg.Get(message.ErrorMessage)
+		if err != nil {
+			return err
+		}
+		return common.NotifyAppError(handler, nodeID, requestID, &common.AppError{
+			Code:    errorCode.(int32),
+			Message: errorMessage.(string),
+		})
+	case message.AppRequestFailed:
+		return handler.AppRequestFailed(nodeID, requestID)
+	}
+
+	if h, ok := handler.(common.GetStateSummaryFrontierHandler); ok && op == message.GetStateSummaryFrontier {
+		return h.GetStateSummaryFrontier(nodeID, requestID)
+	}
+	if h, ok := handler.(common.StateSummaryFrontierHandler); ok {
+		switch op {
+		case message.StateSummaryFrontier:
+			summary, err := msg.Get(message.SummaryBytes)
+			if err != nil {
+				return err
+			}
+			return h.StateSummaryFrontier(nodeID, requestID, summary.([]byte))
+		case message.GetStateSummaryFrontierFailed:
+			return h.GetStateSummaryFrontierFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.GetAcceptedStateSummaryHandler); ok && op == message.GetAcceptedStateSummary {
+		heights, err := msg.Get(message.SummaryHeights)
+		if err != nil {
+			return err
+		}
+		return h.GetAcceptedStateSummary(nodeID, requestID, heights.([]uint64))
+	}
+	if h, ok := handler.(common.AcceptedStateSummaryHandler); ok {
+		switch op {
+		case message.AcceptedStateSummary:
+			summaryIDs, err := getIDs(msg, message.SummaryIDs)
+			if err != nil {
+				return err
+			}
+			return h.AcceptedStateSummary(nodeID, requestID, summaryIDs)
+		case message.GetAcceptedStateSummaryFailed:
+			return h.GetAcceptedStateSummaryFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.GetAcceptedFrontierHandler); ok && op == message.GetAcceptedFrontier {
+		return h.GetAcceptedFrontier(nodeID, requestID)
+	}
+	if h, ok := handler.(common.AcceptedFrontierHandler); ok {
+		switch op {
+		case message.AcceptedFrontier:
+			containerIDs, err := getIDs(msg, message.ContainerIDs)
+			if err != nil {
+				return err
+			}
+			return h.AcceptedFrontier(nodeID, requestID, containerIDs)
+		case message.GetAcceptedFrontierFailed:
+			return h.GetAcceptedFrontierFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.GetAcceptedHandler); ok && op == message.GetAccepted {
+		containerIDs, err := getIDs(msg, message.ContainerIDs)
+		if err != nil {
+			return err
+		}
+		return h.GetAccepted(nodeID, requestID, containerIDs)
+	}
+	if h, ok := handler.(common.AcceptedHandler); ok {
+		switch op {
+		case message.Accepted:
+			containerIDs, err := getIDs(msg, message.ContainerIDs)
+			if err != nil {
+				return err
+			}
+			return h.Accepted(nodeID, requestID, containerIDs)
+		case message.GetAcceptedFailed:
+			return h.GetAcceptedFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.GetAncestorsHandler); ok && op == message.GetAncestors {
+		containerID, err := getID(msg, message.ContainerID)
+		if err != nil {
+			return err
+		}
+		return h.GetAncestors(nodeID, requestID, containerID)
+	}
+	if h, ok := handler.(common.AncestorsHandler); ok {
+		switch op {
+		case message.Ancestors:
+			containers, err := msg.Get(message.MultiContainerBytes)
+			if err != nil {
+				return err
+			}
+			return h.Ancestors(nodeID, requestID, containers.([][]byte))
+		case message.GetAncestorsFailed:
+			return h.GetAncestorsFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.GetHandler); ok && op == message.Get {
+		containerID, err := getID(msg, message.ContainerID)
+		if err != nil {
+			return err
+		}
+		return h.Get(nodeID, requestID, containerID)
+	}
+	if h, ok := handler.(common.PutHandler); ok {
+		switch op {
+		case message.Put:
+			container, err := msg.Get(message.ContainerBytes)
+			if err != nil {
+				return err
+			}
+			return h.Put(nodeID, requestID, container.([]byte))
+		case message.GetFailed:
+			return h.GetFailed(nodeID, requestID)
+		}
+	}
+	if h, ok := handler.(common.QueryHandler); ok {
+		switch op {
+		case message.PushQuery:
+			container, err := msg.Get(message.ContainerBytes)
+			if err != nil {
+				return err
+			}
+			return h.PushQuery(nodeID, requestID, container.([]byte))
+		case message.PullQuery:
+			containerID, err := getID(msg, message.ContainerID)
+			if err != nil {
+				return err
+			}
+			return h.PullQuery(nodeID, requestID, containerID)
+		}
+	}
+	if h, ok := handler.(common.ChitsHandler); ok {
+		switch op {
+		case message.Chits:
+			votes, err := getIDs(msg, message.ContainerIDs)
+			if err != nil {
+				return err
+			}
+			return h.Chits(nodeID, requestID, votes)
+		case message.QueryFailed:
+			return h.QueryFailed(nodeID, requestID)
+		}
+	}
+	return fmt.Errorf("%w: %s from %s", errUnknownMsg, op, nodeID)
+}
+
+func getRequestID(msg message.InboundMessage) (uint32, error) {
+	requestID, err := msg.Get(message.RequestID)
+	if err != nil {
+		return 0, err
+	}
+	return requestID.(uint32), nil
+}
+
+func getID(msg message.InboundMessage, field message.Field) (ids.ID, error) {
+	idBytes, err := msg.Get(field)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return ids.ToID(idBytes.([]byte))
+}
+
+func getIDs(msg message.InboundMessage, field message.Field) ([]ids.ID, error) {
+	idsBytes, err := msg.Get(field)
+	if err != nil {
+		return nil, err
+	}
+	idsList := make([]ids.ID, len(idsBytes.([][]byte)))
+	for i, idBytes := range idsBytes.([][]byte) {
+		id, err := ids.ToID(idBytes)
+		if err != nil {
+			return nil, err
+		}
+		idsList[i] = id
+	}
+	return idsList, nil
+}
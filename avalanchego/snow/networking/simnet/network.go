@@ -0,0 +1,511 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package simnet runs the real sender of a chain against an in-memory network
+// of scripted peers and a deterministic clock. It lets engine and VM
+// developers test their request, response and timeout flows without sockets,
+// goroutine races or real time passing.
+//
+// The node under test sends messages through [Network.Sender]. Every message
+// is delivered to the scripted peers, whose answers reach the node after the
+// peer's latency. Requests that aren't answered before the timeout fail, as
+// they would with the real router. Time only moves when [Network.Advance] is
+// called, so every test runs the same way on every machine.
+//
+// Messages the node sends to itself are handed to the harness by the sender on
+// other goroutines, so their order relative to each other isn't fixed. Tests
+// that need a deterministic order shouldn't include the node in the set of
+// nodes it sends requests to.
+package simnet
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+)
+
+// settleTimeout bounds the real time spent waiting for the messages that the
+// sender hands to the router on other goroutines.
+const settleTimeout = 10 * time.Second
+
+var (
+	errNoHandler  = errors.New("no handler registered")
+	errUnsettled  = errors.New("sender didn't deliver its messages in time")
+	errKnownPeer  = errors.New("peer already added")
+	errNoTimeout  = errors.New("timeout must be positive")
+	errUnknownMsg = errors.New("message isn't handled by the node")
+)
+
+// Config describes the node under test.
+type Config struct {
+	// Context of the chain under test. The node under test is Ctx.NodeID.
+	Ctx *snow.ConsensusContext
+	// Time the clock starts at
+	StartTime time.Time
+	// Timeout of every request sent by the node
+	Timeout time.Duration
+	// Gossip configuration of the sender
+	GossipConfig sender.GossipConfig
+	// Maximum number of outstanding requests the node may have with a peer.
+	// 0 means no limit.
+	MaxOutstandingRequestsPerPeer int
+}
+
+// requestKey identifies a request sent by the node under test.
+type requestKey struct {
+	nodeID    ids.NodeID
+	requestID uint32
+}
+
+// pendingRequest is a request that hasn't been answered, failed or timed out
+// yet.
+type pendingRequest struct {
+	// op of the expected response
+	op message.Op
+	// seq of the event that times the request out
+	timeoutSeq uint64
+}
+
+// Network is an in-memory network of scripted peers around the node under
+// test. It is safe for the node to use its sender concurrently, but a test
+// must only call the methods of Network from one goroutine.
+type Network struct {
+	ctx        *snow.ConsensusContext
+	timeout    time.Duration
+	msgCreator message.Creator
+	sender     common.Sender
+
+	lock sync.Mutex
+	// handler of the node under test
+	handler common.AppHandler
+	clock   mockable.Clock
+	events  eventHeap
+	nextSeq uint64
+	peers   map[ids.NodeID]*Peer
+	// peers that the node can't send messages to
+	disconnected ids.NodeIDSet
+	// requests of the node that are waiting for an answer
+	pending map[requestKey]pendingRequest
+	// requests registered by the sender that haven't been sent or failed yet
+	unsent map[requestKey]struct{}
+}
+
+// New returns a network around the node described by [config]. The node's
+// handler must be set with [Network.SetHandler] before time is advanced.
+func New(config Config) (*Network, error) {
+	if config.Timeout <= 0 {
+		return nil, errNoTimeout
+	}
+	n := &Network{
+		ctx:          config.Ctx,
+		timeout:      config.Timeout,
+		peers:        make(map[ids.NodeID]*Peer),
+		disconnected: ids.NewNodeIDSet(0),
+		pending:      make(map[requestKey]pendingRequest),
+		unsent:       make(map[requestKey]struct{}),
+	}
+	n.clock.Set(config.StartTime)
+
+	// The messages never leave the process, so compression would only slow
+	// the tests down.
+	msgCreator, err := message.NewCreator(prometheus.NewRegistry(), "simnet", false, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	msgCreatorWithProto, err := message.NewCreatorWithProto(prometheus.NewRegistry(), "simnet", 0, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	n.msgCreator = msgCreator
+
+	n.sender, err = sender.New(
+		config.Ctx,
+		msgCreator,
+		msgCreatorWithProto,
+		mockable.MaxTime, // only use [msgCreator]
+		&externalSender{n: n},
+		&simRouter{n: n},
+		&simTimeouts{timeout: config.Timeout},
+		config.GossipConfig,
+		config.MaxOutstandingRequestsPerPeer,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create sender: %w", err)
+	}
+	return n, nil
+}
+
+// Sender returns the sender the node under test should use.
+func (n *Network) Sender() common.Sender { return n.sender }
+
+// MsgCreator returns the creator used to build messages for [Network.Deliver].
+func (n *Network) MsgCreator() message.Creator { return n.msgCreator }
+
+// SetHandler sets the handler of the node under test. Engines are notified of
+// every message type they implement a handler for.
+func (n *Network) SetHandler(handler common.AppHandler) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.handler = handler
+}
+
+// Now returns the current simulated time.
+func (n *Network) Now() time.Time {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.clock.Time()
+}
+
+// AddPeer connects the scripted [peer] to the node as [nodeID].
+func (n *Network) AddPeer(nodeID ids.NodeID, peer *Peer) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if _, ok := n.peers[nodeID]; ok {
+		return fmt.Errorf("%w: %s", errKnownPeer, nodeID)
+	}
+	n.peers[nodeID] = peer
+	return nil
+}
+
+// Disconnect makes messages sent to [nodeID] fail immediately. Answers the
+// peer has already sent are still delivered.
+func (n *Network) Disconnect(nodeID ids.NodeID) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.disconnected.Add(nodeID)
+}
+
+// Connect undoes [Network.Disconnect].
+func (n *Network) Connect(nodeID ids.NodeID) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.disconnected.Remove(nodeID)
+}
+
+// Outstanding returns the number of requests the node sent to [nodeID] that
+// are still waiting for an answer.
+func (n *Network) Outstanding(nodeID ids.NodeID) int {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.outstanding(nodeID)
+}
+
+// Deliver schedules [msg] to reach the node at the current time. It is used
+// to send requests and gossip from a peer to the node; [msg] is built with
+// [Network.MsgCreator].
+func (n *Network) Deliver(msg message.InboundMessage) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.schedule(n.clock.Time(), func() error {
+		return n.receive(msg)
+	})
+}
+
+// Advance moves time forward by [d], delivering every message and firing
+// every timeout that is due in order. It returns the first error returned by
+// the node's handler.
+func (n *Network) Advance(d time.Duration) error {
+	n.lock.Lock()
+	end := n.clock.Time().Add(d)
+	n.lock.Unlock()
+
+	for {
+		if err := n.settle(); err != nil {
+			return err
+		}
+
+		n.lock.Lock()
+		if n.events.Len() == 0 || n.events[0].time.After(end) {
+			n.clock.Set(end)
+			n.lock.Unlock()
+			return nil
+		}
+		e := heap.Pop(&n.events).(*event)
+		n.clock.Set(e.time)
+		n.lock.Unlock()
+
+		if err := e.fire(); err != nil {
+			return err
+		}
+	}
+}
+
+// settle waits for the messages the sender delivers to the router on other
+// goroutines, so that they are ordered like every other message.
+func (n *Network) settle() error {
+	start := time.Now()
+	for {
+		n.lock.Lock()
+		unsent := len(n.unsent)
+		n.lock.Unlock()
+
+		if unsent == 0 {
+			return nil
+		}
+		if time.Since(start) > settleTimeout {
+			return errUnsettled
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// schedule runs [fire] at [t]. Events due at the same time run in the order
+// they were scheduled. Assumes [n.lock] is held.
+func (n *Network) schedule(t time.Time, fire func() error) {
+	heap.Push(&n.events, &event{
+		time: t,
+		seq:  n.nextSeq,
+		fire: fire,
+	})
+	n.nextSeq++
+}
+
+// Assumes [n.lock] is held.
+func (n *Network) outstanding(nodeID ids.NodeID) int {
+	count := 0
+	for key := range n.pending {
+		if key.nodeID == nodeID {
+			count++
+		}
+	}
+	return count
+}
+
+// registerRequest notes that the node expects a response of type [op] to a
+// request and times the request out if it isn't answered in time.
+func (n *Network) registerRequest(nodeID ids.NodeID, requestID uint32, op message.Op) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	key := requestKey{
+		nodeID:    nodeID,
+		requestID: requestID,
+	}
+	seq := n.nextSeq
+	n.unsent[key] = struct{}{}
+	n.pending[key] = pendingRequest{
+		op:         op,
+		timeoutSeq: seq,
+	}
+	n.schedule(n.clock.Time().Add(n.timeout), func() error {
+		return n.timeoutRequest(key, seq)
+	})
+}
+
+// timeoutRequest fails the request [key] unless it was answered, or its
+// requestID was reused, before timeout event [seq] fired.
+func (n *Network) timeoutRequest(key requestKey, seq uint64) error {
+	n.lock.Lock()
+	request, ok := n.pending[key]
+	if !ok || request.timeoutSeq != seq {
+		n.lock.Unlock()
+		return nil
+	}
+	delete(n.pending, key)
+	n.lock.Unlock()
+
+	msg := n.msgCreator.InternalFailedRequest(
+		message.ResponseToFailedOps[request.op],
+		key.nodeID,
+		n.ctx.ChainID,
+		key.requestID,
+	)
+	return n.dispatch(msg)
+}
+
+// handleInbound is called by the sender on other goroutines with the
+// requests the node sends to itself and the requests that failed without
+// being sent.
+func (n *Network) handleInbound(msg message.InboundMessage) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if requestIDIntf, err := msg.Get(message.RequestID); err == nil {
+		delete(n.unsent, requestKey{
+			nodeID:    msg.NodeID(),
+			requestID: requestIDIntf.(uint32),
+		})
+	}
+	n.schedule(n.clock.Time(), func() error {
+		return n.receive(msg)
+	})
+}
+
+// receive delivers [msg] to the node. Responses and failures to requests that
+// are no longer pending are dropped, like the router does.
+func (n *Network) receive(msg message.InboundMessage) error {
+	op := msg.Op()
+	responseOp, isResponse := op, false
+	if _, ok := message.ResponseToFailedOps[op]; ok {
+		isResponse = true
+	} else if responseOp, ok = message.FailedToResponseOps[op]; ok {
+		isResponse = true
+	} else if responseOp, ok = message.ErrorToResponseOps[op]; ok {
+		isResponse = true
+	}
+	if !isResponse {
+		return n.dispatch(msg)
+	}
+
+	requestID, err := getRequestID(msg)
+	if err != nil {
+		return err
+	}
+	key := requestKey{
+		nodeID:    msg.NodeID(),
+		requestID: requestID,
+	}
+
+	n.lock.Lock()
+	request, ok := n.pending[key]
+	if !ok || request.op != responseOp {
+		n.lock.Unlock()
+		return nil
+	}
+	delete(n.pending, key)
+	n.lock.Unlock()
+
+	return n.dispatch(msg)
+}
+
+// send hands [msg] to every connected peer in [nodeIDs] and returns the peers
+// it was sent to.
+func (n *Network) send(msg message.OutboundMessage, nodeIDs []ids.NodeID) ids.NodeIDSet {
+	inMsg, err := n.msgCreator.Parse(msg.Bytes(), n.ctx.NodeID, func() {})
+	if err != nil {
+		n.ctx.Log.Error("couldn't parse outbound message",
+			zap.Stringer("messageOp", msg.Op()),
+			zap.Error(err),
+		)
+		return nil
+	}
+	_, isRequest := message.RequestToResponseOps[inMsg.Op()]
+	var requestID uint32
+	if isRequest {
+		requestID, err = getRequestID(inMsg)
+		if err != nil {
+			n.ctx.Log.Error("outbound request without a requestID",
+				zap.Stringer("messageOp", msg.Op()),
+				zap.Error(err),
+			)
+			return nil
+		}
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	sentTo := ids.NewNodeIDSet(len(nodeIDs))
+	for _, nodeID := range nodeIDs {
+		peer, ok := n.peers[nodeID]
+		if !ok || n.disconnected.Contains(nodeID) {
+			continue
+		}
+		sentTo.Add(nodeID)
+		if isRequest {
+			delete(n.unsent, requestKey{
+				nodeID:    nodeID,
+				requestID: requestID,
+			})
+		}
+
+		nodeID := nodeID
+		n.schedule(n.clock.Time(), func() error {
+			n.answer(nodeID, peer, inMsg)
+			return nil
+		})
+	}
+	return sentTo
+}
+
+// answer has [peer] handle [msg] and schedules its answer, if any.
+func (n *Network) answer(nodeID ids.NodeID, peer *Peer, msg message.InboundMessage) {
+	response := peer.handle(n.msgCreator, n.ctx.ChainID, nodeID, msg)
+	if response == nil {
+		return
+	}
+
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.schedule(n.clock.Time().Add(peer.Latency), func() error {
+		return n.receive(response)
+	})
+}
+
+// connectedPeers returns the connected peers, sorted by nodeID.
+func (n *Network) connectedPeers() []ids.NodeID {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	nodeIDs := make([]ids.NodeID, 0, len(n.peers))
+	for nodeID := range n.peers {
+		if !n.disconnected.Contains(nodeID) {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	ids.SortNodeIDs(nodeIDs)
+	return nodeIDs
+}
+
+func (n *Network) dispatch(msg message.InboundMessage) error {
+	n.lock.Lock()
+	handler := n.handler
+	n.lock.Unlock()
+
+	if handler == nil {
+		return errNoHandler
+	}
+	return dispatch(handler, msg, n.Now().Add(n.timeout))
+}
+
+type event struct {
+	time time.Time
+	seq  uint64
+	fire func() error
+}
+
+// eventHeap orders events by time, then by the order they were scheduled.
+type eventHeap []*event
+
+func (h eventHeap) Len() int { return len(h) }
+
+func (h eventHeap) Less(i, j int) bool {
+	if h[i].time.Equal(h[j].time) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].time.Before(h[j].time)
+}
+
+func (h eventHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *eventHeap) Push(x interface{}) { *h = append(*h, x.(*event)) }
+
+func (h *eventHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
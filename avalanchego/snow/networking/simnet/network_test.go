@@ -0,0 +1,255 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+var errDropped = errors.New("dropped")
+
+// testHandler records the messages the node under test is notified of.
+type testHandler struct {
+	n       *Network
+	answers map[string][]byte
+
+	requestDeadlines []time.Time
+	responses        map[uint32][]byte
+	failed           []uint32
+	appErrs          map[uint32]*common.AppError
+	chits            map[uint32][]ids.ID
+	queriesFailed    []uint32
+}
+
+func (h *testHandler) AppRequest(nodeID ids.NodeID, requestID uint32, deadline time.Time, request []byte) error {
+	h.requestDeadlines = append(h.requestDeadlines, deadline)
+	return h.n.Sender().SendAppResponse(nodeID, requestID, h.answers[string(request)])
+}
+
+func (h *testHandler) AppRequestFailed(_ ids.NodeID, requestID uint32) error {
+	h.failed = append(h.failed, requestID)
+	return nil
+}
+
+func (h *testHandler) AppResponse(_ ids.NodeID, requestID uint32, response []byte) error {
+	h.responses[requestID] = response
+	return nil
+}
+
+func (*testHandler) AppGossip(ids.NodeID, []byte) error { return nil }
+
+func (h *testHandler) AppError(_ ids.NodeID, requestID uint32, appErr *common.AppError) error {
+	h.appErrs[requestID] = appErr
+	return nil
+}
+
+func (h *testHandler) Chits(_ ids.NodeID, requestID uint32, votes []ids.ID) error {
+	h.chits[requestID] = votes
+	return nil
+}
+
+func (h *testHandler) QueryFailed(_ ids.NodeID, requestID uint32) error {
+	h.queriesFailed = append(h.queriesFailed, requestID)
+	return nil
+}
+
+func newTestNetwork(t *testing.T) (*Network, *testHandler) {
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.NodeID = ids.GenerateTestNodeID()
+	n, err := New(Config{
+		Ctx:       ctx,
+		StartTime: time.Unix(1000, 0),
+		Timeout:   time.Second,
+	})
+	require.NoError(t, err)
+
+	h := &testHandler{
+		n:         n,
+		answers:   make(map[string][]byte),
+		responses: make(map[uint32][]byte),
+		appErrs:   make(map[uint32]*common.AppError),
+		chits:     make(map[uint32][]ids.ID),
+	}
+	n.SetHandler(h)
+	return n, h
+}
+
+func echo(request []byte) ([]byte, error) { return request, nil }
+
+func TestAppRequestResponse(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	peerID := ids.GenerateTestNodeID()
+	require.NoError(n.AddPeer(peerID, &Peer{
+		Latency:     100 * time.Millisecond,
+		AppRequestF: echo,
+	}))
+
+	require.NoError(n.Sender().SendAppRequest(ids.NodeIDSet{peerID: struct{}{}}, 1, []byte("ping")))
+	require.Equal(1, n.Outstanding(peerID))
+
+	// The response takes the peer's latency to arrive.
+	require.NoError(n.Advance(99 * time.Millisecond))
+	require.Empty(h.responses)
+
+	require.NoError(n.Advance(time.Millisecond))
+	require.Equal([]byte("ping"), h.responses[1])
+	require.Zero(n.Outstanding(peerID))
+
+	// The request was answered, so it never times out.
+	require.NoError(n.Advance(time.Hour))
+	require.Empty(h.failed)
+}
+
+func TestAppRequestTimeout(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	silentID := ids.GenerateTestNodeID()
+	slowID := ids.GenerateTestNodeID()
+	require.NoError(n.AddPeer(silentID, &Peer{}))
+	require.NoError(n.AddPeer(slowID, &Peer{
+		Latency:     2 * time.Second,
+		AppRequestF: echo,
+	}))
+
+	require.NoError(n.Sender().SendAppRequest(ids.NodeIDSet{silentID: struct{}{}}, 1, []byte("ping")))
+	require.NoError(n.Sender().SendAppRequest(ids.NodeIDSet{slowID: struct{}{}}, 2, []byte("ping")))
+
+	require.NoError(n.Advance(time.Second - 1))
+	require.Empty(h.failed)
+
+	require.NoError(n.Advance(1))
+	require.Equal([]uint32{1, 2}, h.failed)
+
+	// The response of the slow peer arrives after the request timed out, so
+	// it is dropped.
+	require.NoError(n.Advance(time.Second))
+	require.Empty(h.responses)
+}
+
+func TestAppRequestError(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	peerID := ids.GenerateTestNodeID()
+	appErr := &common.AppError{
+		Code:    1,
+		Message: "busy",
+	}
+	require.NoError(n.AddPeer(peerID, &Peer{
+		AppRequestF: func(request []byte) ([]byte, error) {
+			switch string(request) {
+			case "refused":
+				return nil, appErr
+			case "dropped":
+				return nil, errDropped
+			default:
+				return request, nil
+			}
+		},
+	}))
+
+	nodeIDs := ids.NodeIDSet{peerID: struct{}{}}
+	require.NoError(n.Sender().SendAppRequest(nodeIDs, 1, []byte("refused")))
+	require.NoError(n.Sender().SendAppRequest(nodeIDs, 2, []byte("dropped")))
+
+	require.NoError(n.Advance(0))
+	require.Equal(appErr, h.appErrs[1])
+	require.Empty(h.failed)
+
+	require.NoError(n.Advance(time.Second))
+	require.Equal([]uint32{2}, h.failed)
+}
+
+func TestDisconnectedPeer(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	peerID := ids.GenerateTestNodeID()
+	require.NoError(n.AddPeer(peerID, &Peer{AppRequestF: echo}))
+	n.Disconnect(peerID)
+
+	// Requests to disconnected peers fail without waiting for the timeout.
+	require.NoError(n.Sender().SendAppRequest(ids.NodeIDSet{peerID: struct{}{}}, 1, []byte("ping")))
+	require.NoError(n.Advance(0))
+	require.Equal([]uint32{1}, h.failed)
+
+	n.Connect(peerID)
+	require.NoError(n.Sender().SendAppRequest(ids.NodeIDSet{peerID: struct{}{}}, 2, []byte("ping")))
+	require.NoError(n.Advance(0))
+	require.Equal([]byte("ping"), h.responses[2])
+}
+
+func TestQuery(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	containerID := ids.GenerateTestID()
+	voterID := ids.GenerateTestNodeID()
+	silentID := ids.GenerateTestNodeID()
+	require.NoError(n.AddPeer(voterID, &Peer{
+		Latency: 10 * time.Millisecond,
+		PullQueryF: func(queried ids.ID) []ids.ID {
+			return []ids.ID{queried}
+		},
+	}))
+	require.NoError(n.AddPeer(silentID, &Peer{}))
+
+	n.Sender().SendPullQuery(ids.NodeIDSet{voterID: struct{}{}, silentID: struct{}{}}, 1, containerID)
+
+	require.NoError(n.Advance(10 * time.Millisecond))
+	require.Equal([]ids.ID{containerID}, h.chits[1])
+	require.Equal(1, n.Outstanding(silentID))
+
+	require.NoError(n.Advance(time.Second))
+	require.Equal([]uint32{1}, h.queriesFailed)
+}
+
+func TestDeliverRequestToNode(t *testing.T) {
+	require := require.New(t)
+
+	n, h := newTestNetwork(t)
+	h.answers["ping"] = []byte("pong")
+
+	peerID := ids.GenerateTestNodeID()
+	var received []message.InboundMessage
+	require.NoError(n.AddPeer(peerID, &Peer{
+		ReceiveF: func(msg message.InboundMessage) {
+			received = append(received, msg)
+		},
+	}))
+
+	n.Deliver(n.MsgCreator().InboundAppRequest(n.ctx.ChainID, 7, time.Second, []byte("ping"), peerID))
+	require.NoError(n.Advance(0))
+
+	require.Equal([]time.Time{n.Now().Add(time.Second)}, h.requestDeadlines)
+	require.Len(received, 1)
+	require.Equal(message.AppResponse, received[0].Op())
+	requestID, err := getRequestID(received[0])
+	require.NoError(err)
+	require.Equal(uint32(7), requestID)
+	response, err := received[0].Get(message.AppBytes)
+	require.NoError(err)
+	require.Equal([]byte("pong"), response)
+}
+
+func TestUnhandledMessage(t *testing.T) {
+	n, _ := newTestNetwork(t)
+	peerID := ids.GenerateTestNodeID()
+
+	// The test handler doesn't serve Get requests.
+	n.Deliver(n.MsgCreator().InboundGet(n.ctx.ChainID, 1, time.Second, ids.GenerateTestID(), peerID))
+	require.ErrorIs(t, n.Advance(0), errUnknownMsg)
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+)
+
+// Peer is a scripted remote node. Each F field answers one type of request
+// from the node under test. If the field is nil, requests of that type are
+// never answered and time out.
+//
+// The F fields are called from [Network.Advance] in simulated time order, so
+// they don't need to be safe for concurrent use.
+type Peer struct {
+	// Time between the peer receiving a message and the node receiving the
+	// answer
+	Latency time.Duration
+
+	// ReceiveF, if set, is called with every message the peer receives,
+	// before the message is answered. It is used to observe the responses
+	// and gossip sent by the node.
+	ReceiveF func(msg message.InboundMessage)
+
+	GetStateSummaryFrontierF func() []byte
+	GetAcceptedStateSummaryF func(heights []uint64) []ids.ID
+	GetAcceptedFrontierF     func() []ids.ID
+	GetAcceptedF             func(containerIDs []ids.ID) []ids.ID
+	GetAncestorsF            func(containerID ids.ID) [][]byte
+	GetF                     func(containerID ids.ID) []byte
+	PushQueryF               func(container []byte) []ids.ID
+	PullQueryF               func(containerID ids.ID) []ids.ID
+	// AppRequestF answers an AppRequest. If it returns a *common.AppError, the
+	// request is refused with that error. Any other error leaves the request
+	// unanswered.
+	AppRequestF func(request []byte) ([]byte, error)
+}
+
+// handle returns the answer of the peer [nodeID] to [msg], or nil if the
+// peer doesn't answer it.
+func (p *Peer) handle(
+	msgCreator message.Creator,
+	chainID ids.ID,
+	nodeID ids.NodeID,
+	msg message.InboundMessage,
+) message.InboundMessage {
+	if p.ReceiveF != nil {
+		p.ReceiveF(msg)
+	}
+
+	// The messages were built by the sender of the node, so their fields are
+	// known to be valid.
+	requestID, err := getRequestID(msg)
+	if err != nil {
+		return nil
+	}
+
+	switch msg.Op() {
+	case message.GetStateSummaryFrontier:
+		if p.GetStateSummaryFrontierF == nil {
+			return nil
+		}
+		summary := p.GetStateSummaryFrontierF()
+		return msgCreator.InboundStateSummaryFrontier(chainID, requestID, summary, nodeID)
+	case message.GetAcceptedStateSummary:
+		if p.GetAcceptedStateSummaryF == nil {
+			return nil
+		}
+		heights, _ := msg.Get(message.SummaryHeights)
+		summaryIDs := p.GetAcceptedStateSummaryF(heights.([]uint64))
+		return msgCreator.InboundAcceptedStateSummary(chainID, requestID, summaryIDs, nodeID)
+	case message.GetAcceptedFrontier:
+		if p.GetAcceptedFrontierF == nil {
+			return nil
+		}
+		containerIDs := p.GetAcceptedFrontierF()
+		return msgCreator.InboundAcceptedFrontier(chainID, requestID, containerIDs, nodeID)
+	case message.GetAccepted:
+		if p.GetAcceptedF == nil {
+			return nil
+		}
+		containerIDs, _ := getIDs(msg, message.ContainerIDs)
+		acceptedIDs := p.GetAcceptedF(containerIDs)
+		return msgCreator.InboundAccepted(chainID, requestID, acceptedIDs, nodeID)
+	case message.GetAncestors:
+		if p.GetAncestorsF == nil {
+			return nil
+		}
+		containerID, _ := getID(msg, message.ContainerID)
+		containers := p.GetAncestorsF(containerID)
+		return msgCreator.InboundAncestors(chainID, requestID, containers, nodeID)
+	case message.Get:
+		if p.GetF == nil {
+			return nil
+		}
+		containerID, _ := getID(msg, message.ContainerID)
+		container := p.GetF(containerID)
+		return msgCreator.InboundPut(chainID, requestID, container, nodeID)
+	case message.PushQuery:
+		if p.PushQueryF == nil {
+			return nil
+		}
+		container, _ := msg.Get(message.ContainerBytes)
+		votes := p.PushQueryF(container.([]byte))
+		return msgCreator.InboundChits(chainID, requestID, votes, nodeID)
+	case message.PullQuery:
+		if p.PullQueryF == nil {
+			return nil
+		}
+		containerID, _ := getID(msg, message.ContainerID)
+		votes := p.PullQueryF(containerID)
+		return msgCreator.InboundChits(chainID, requestID, votes, nodeID)
+	case message.AppRequest:
+		if p.AppRequestF == nil {
+			return nil
+		}
+		request, _ := msg.Get(message.AppBytes)
+		response, err := p.AppRequestF(request.([]byte))
+		if err == nil {
+			return msgCreator.InboundAppResponse(chainID, requestID, response, nodeID)
+		}
+		var appErr *common.AppError
+		if !errors.As(err, &appErr) {
+			return nil
+		}
+		return msgCreator.InboundAppError(chainID, requestID, appErr.Code, appErr.Message, nodeID)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package simnet
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/networking/handler"
+	"github.com/ava-labs/avalanchego/snow/networking/router"
+	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/timeout"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+var (
+	_ sender.ExternalSender = (*externalSender)(nil)
+	_ router.Router         = (*simRouter)(nil)
+	_ timeout.Manager       = (*simTimeouts)(nil)
+)
+
+// externalSender hands the messages of the node to the scripted peers.
+type externalSender struct {
+	n *Network
+}
+
+func (s *externalSender) Send(
+	msg message.OutboundMessage,
+	nodeIDs ids.NodeIDSet,
+	_ ids.ID,
+	_ bool,
+) ids.NodeIDSet {
+	return s.n.send(msg, nodeIDs.SortedList())
+}
+
+// Gossip sends [msg] to the first connected peers in nodeID order, so the
+// peers that are gossiped to don't change between runs.
+func (s *externalSender) Gossip(
+	msg message.OutboundMessage,
+	_ ids.ID,
+	_ bool,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+) ids.NodeIDSet {
+	nodeIDs := s.n.connectedPeers()
+	if numPeers := numValidatorsToSend + numNonValidatorsToSend + numPeersToSend; numPeers < len(nodeIDs) {
+		nodeIDs = nodeIDs[:numPeers]
+	}
+	return s.n.send(msg, nodeIDs)
+}
+
+// StakeWeightedGossip ignores stake; see [externalSender.Gossip].
+func (s *externalSender) StakeWeightedGossip(
+	msg message.OutboundMessage,
+	subnetID ids.ID,
+	validatorOnly bool,
+	numValidatorsToSend int,
+	numNonValidatorsToSend int,
+	numPeersToSend int,
+) ids.NodeIDSet {
+	return s.Gossip(msg, subnetID, validatorOnly, numValidatorsToSend, numNonValidatorsToSend, numPeersToSend)
+}
+
+// simRouter tracks the requests of the node in simulated time. Only the
+// methods used by the sender do anything.
+type simRouter struct {
+	n *Network
+}
+
+func (r *simRouter) RegisterRequest(nodeID ids.NodeID, _ ids.ID, requestID uint32, op message.Op) {
+	r.n.registerRequest(nodeID, requestID, op)
+}
+
+func (*simRouter) CacheResponse(ids.NodeID, ids.ID, uint32, message.Op, func(uint32)) {}
+
+func (r *simRouter) OutstandingRequests(nodeID ids.NodeID, _ ids.ID) int {
+	r.n.lock.Lock()
+	defer r.n.lock.Unlock()
+
+	return r.n.outstanding(nodeID)
+}
+
+func (r *simRouter) HandleInbound(msg message.InboundMessage) { r.n.handleInbound(msg) }
+
+func (*simRouter) Initialize(
+	ids.NodeID,
+	logging.Logger,
+	message.InternalMsgBuilder,
+	timeout.Manager,
+	time.Duration,
+	ids.Set,
+	ids.Set,
+	func(int),
+	router.HealthConfig,
+	string,
+	prometheus.Registerer,
+) error {
+	return nil
+}
+
+func (*simRouter) Shutdown()                                          {}
+func (*simRouter) AddChain(handler.Handler)                           {}
+func (*simRouter) HealthCheck() (interface{}, error)                  { return nil, nil }
+func (*simRouter) Connected(ids.NodeID, *version.Application, ids.ID) {}
+func (*simRouter) Disconnected(ids.NodeID)                            {}
+func (*simRouter) Benched(ids.ID, ids.NodeID)                         {}
+func (*simRouter) Unbenched(ids.ID, ids.NodeID)                       {}
+
+// simTimeouts gives every request the same timeout and never benches a peer.
+// The requests are timed out by [simRouter].
+type simTimeouts struct {
+	timeout time.Duration
+}
+
+func (t *simTimeouts) TimeoutDuration(ids.ID) time.Duration { return t.timeout }
+
+func (*simTimeouts) Dispatch()                                                              {}
+func (*simTimeouts) IsBenched(ids.NodeID, ids.ID) bool                                      { return false }
+func (*simTimeouts) RegisterChain(*snow.ConsensusContext) error                             { return nil }
+func (*simTimeouts) UnregisterChain(ids.ID)                                                 {}
+func (*simTimeouts) RegisterRequest(ids.NodeID, ids.ID, message.Op, ids.ID, func())         {}
+func (*simTimeouts) RegisterRequestToUnreachableValidator(ids.ID)                           {}
+func (*simTimeouts) RegisterResponse(ids.NodeID, ids.ID, ids.ID, message.Op, time.Duration) {}
+func (*simTimeouts) RemoveRequest(ids.ID, ids.ID)                                           {}
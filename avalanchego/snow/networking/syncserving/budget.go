@@ -0,0 +1,227 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package syncserving
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const opLabel = "op"
+
+var (
+	errInvalidConcurrency = errors.New("max concurrent requests per peer must be positive")
+	errInvalidBandwidth   = errors.New("bandwidth per peer and its burst must be positive")
+
+	_ Budget = &budget{}
+	_ Budget = NoBudget{}
+)
+
+// Budget limits the resources spent serving the state summaries and blocks
+// that peers request while they sync.
+type Budget interface {
+	// Acquire reserves a slot to serve a request of type [op] from [nodeID].
+	// Returns false if the request should be dropped. If true is returned,
+	// Release must be called once the request has been served.
+	Acquire(nodeID ids.NodeID, op message.Op) bool
+
+	// Release frees the slot reserved for [nodeID] and charges [bytes], the
+	// size of the response that was sent, to its bandwidth budget.
+	Release(nodeID ids.NodeID, op message.Op, bytes int)
+}
+
+type Config struct {
+	// Enabled enforces the per peer budgets. If false, every request is
+	// served, but the bytes served are still reported.
+	Enabled bool `json:"enabled"`
+
+	// Max number of requests from a peer that are served at once
+	MaxConcurrentPerPeer int `json:"maxConcurrentPerPeer"`
+
+	// Bytes per second that each peer's bandwidth budget refills at
+	BandwidthPerPeer float64 `json:"bandwidthPerPeer"`
+
+	// Max number of bytes each peer's bandwidth budget holds
+	BandwidthBurstPerPeer float64 `json:"bandwidthBurstPerPeer"`
+}
+
+func (c *Config) Verify() error {
+	if !c.Enabled {
+		return nil
+	}
+	switch {
+	case c.MaxConcurrentPerPeer <= 0:
+		return fmt.Errorf("%w: %d", errInvalidConcurrency, c.MaxConcurrentPerPeer)
+	case c.BandwidthPerPeer <= 0 || c.BandwidthBurstPerPeer <= 0:
+		return fmt.Errorf("%w: bandwidth %f, burst %f", errInvalidBandwidth, c.BandwidthPerPeer, c.BandwidthBurstPerPeer)
+	default:
+		return nil
+	}
+}
+
+type budget struct {
+	config Config
+	clock  mockable.Clock
+
+	bytesServed     *prometheus.CounterVec
+	requestsServed  *prometheus.CounterVec
+	requestsLimited *prometheus.CounterVec
+
+	lock  sync.Mutex
+	peers map[ids.NodeID]*peer
+	// a peer that is idle for [staleAfter] has a full bandwidth budget, so its
+	// state can be dropped.
+	staleAfter time.Duration
+	lastPrune  time.Time
+}
+
+type peer struct {
+	// number of requests currently being served
+	active int
+	// remaining bandwidth budget as of [lastUpdate]. May be negative if the
+	// last responses were larger than the remaining budget.
+	bytes      float64
+	lastUpdate time.Time
+}
+
+// NewBudget returns a Budget that enforces [config], if it is enabled, and
+// registers its metrics with [registerer].
+func NewBudget(config Config, namespace string, registerer prometheus.Registerer) (Budget, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+
+	b := &budget{
+		config: config,
+		bytesServed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "bytes_served",
+				Help:      "Number of bytes sent in response to sync requests",
+			},
+			[]string{opLabel},
+		),
+		requestsServed: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_served",
+				Help:      "Number of sync requests served",
+			},
+			[]string{opLabel},
+		),
+		requestsLimited: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "requests_limited",
+				Help:      "Number of sync requests dropped for exceeding a peer's budget",
+			},
+			[]string{opLabel},
+		),
+		peers: make(map[ids.NodeID]*peer),
+	}
+	if config.Enabled {
+		b.staleAfter = time.Duration(config.BandwidthBurstPerPeer / config.BandwidthPerPeer * float64(time.Second))
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(b.bytesServed),
+		registerer.Register(b.requestsServed),
+		registerer.Register(b.requestsLimited),
+	)
+	return b, errs.Err
+}
+
+func (b *budget) Acquire(nodeID ids.NodeID, op message.Op) bool {
+	if !b.config.Enabled {
+		return true
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	now := b.clock.Time()
+	b.prune(now)
+
+	p := b.refill(nodeID, now)
+	if p.active >= b.config.MaxConcurrentPerPeer || p.bytes <= 0 {
+		b.requestsLimited.WithLabelValues(op.String()).Inc()
+		return false
+	}
+	p.active++
+	return true
+}
+
+func (b *budget) Release(nodeID ids.NodeID, op message.Op, bytes int) {
+	opStr := op.String()
+	b.bytesServed.WithLabelValues(opStr).Add(float64(bytes))
+	b.requestsServed.WithLabelValues(opStr).Inc()
+	if !b.config.Enabled {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	p := b.refill(nodeID, b.clock.Time())
+	if p.active > 0 {
+		p.active--
+	}
+	p.bytes -= float64(bytes)
+}
+
+// refill returns the state of [nodeID] with its bandwidth budget refilled up
+// to [now].
+// Assumes [b.lock] is held.
+func (b *budget) refill(nodeID ids.NodeID, now time.Time) *peer {
+	p, ok := b.peers[nodeID]
+	if !ok {
+		p = &peer{
+			bytes:      b.config.BandwidthBurstPerPeer,
+			lastUpdate: now,
+		}
+		b.peers[nodeID] = p
+		return p
+	}
+
+	if elapsed := now.Sub(p.lastUpdate); elapsed > 0 {
+		p.bytes += elapsed.Seconds() * b.config.BandwidthPerPeer
+		if p.bytes > b.config.BandwidthBurstPerPeer {
+			p.bytes = b.config.BandwidthBurstPerPeer
+		}
+	}
+	p.lastUpdate = now
+	return p
+}
+
+// prune drops the state of peers that aren't being served and have been idle
+// long enough for their bandwidth budget to refill.
+// Assumes [b.lock] is held.
+func (b *budget) prune(now time.Time) {
+	if now.Sub(b.lastPrune) < b.staleAfter {
+		return
+	}
+	b.lastPrune = now
+	for nodeID, p := range b.peers {
+		if p.active == 0 && now.Sub(p.lastUpdate) >= b.staleAfter {
+			delete(b.peers, nodeID)
+		}
+	}
+}
+
+// NoBudget serves every request and doesn't report any metrics
+type NoBudget struct{}
+
+func (NoBudget) Acquire(ids.NodeID, message.Op) bool { return true }
+
+func (NoBudget) Release(ids.NodeID, message.Op, int) {}
@@ -0,0 +1,113 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package syncserving
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/message"
+)
+
+func newTestBudget(t *testing.T, config Config) *budget {
+	b, err := NewBudget(config, "", prometheus.NewRegistry())
+	require.NoError(t, err)
+	return b.(*budget)
+}
+
+func TestBudgetConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	b := newTestBudget(t, Config{
+		Enabled:               true,
+		MaxConcurrentPerPeer:  2,
+		BandwidthPerPeer:      100,
+		BandwidthBurstPerPeer: 1000,
+	})
+	b.clock.Set(time.Now())
+
+	nodeID0 := ids.GenerateTestNodeID()
+	nodeID1 := ids.GenerateTestNodeID()
+	require.True(b.Acquire(nodeID0, message.GetAncestors))
+	require.True(b.Acquire(nodeID0, message.GetAncestors))
+	require.False(b.Acquire(nodeID0, message.GetAncestors))
+
+	// Other peers have their own budget.
+	require.True(b.Acquire(nodeID1, message.GetAncestors))
+
+	b.Release(nodeID0, message.GetAncestors, 10)
+	require.True(b.Acquire(nodeID0, message.GetStateSummaryFrontier))
+
+	require.Equal(1.0, testutil.ToFloat64(b.requestsLimited.WithLabelValues(message.GetAncestors.String())))
+	require.Equal(10.0, testutil.ToFloat64(b.bytesServed.WithLabelValues(message.GetAncestors.String())))
+	require.Equal(1.0, testutil.ToFloat64(b.requestsServed.WithLabelValues(message.GetAncestors.String())))
+}
+
+func TestBudgetBandwidth(t *testing.T) {
+	require := require.New(t)
+
+	b := newTestBudget(t, Config{
+		Enabled:               true,
+		MaxConcurrentPerPeer:  1,
+		BandwidthPerPeer:      100,
+		BandwidthBurstPerPeer: 1000,
+	})
+	now := time.Now()
+	b.clock.Set(now)
+
+	nodeID := ids.GenerateTestNodeID()
+	require.True(b.Acquire(nodeID, message.GetAncestors))
+	// A response may exceed the remaining budget, which puts the peer in debt.
+	b.Release(nodeID, message.GetAncestors, 1500)
+	require.False(b.Acquire(nodeID, message.GetAncestors))
+
+	// The debt is paid off over time.
+	b.clock.Set(now.Add(5 * time.Second))
+	require.False(b.Acquire(nodeID, message.GetAncestors))
+	b.clock.Set(now.Add(6 * time.Second))
+	require.True(b.Acquire(nodeID, message.GetAncestors))
+	b.Release(nodeID, message.GetAncestors, 0)
+
+	// Idle peers are dropped once their budget is full.
+	b.clock.Set(now.Add(time.Minute))
+	require.True(b.Acquire(ids.GenerateTestNodeID(), message.GetAncestors))
+	require.Len(b.peers, 1)
+	require.NotContains(b.peers, nodeID)
+}
+
+func TestBudgetDisabled(t *testing.T) {
+	require := require.New(t)
+
+	b := newTestBudget(t, Config{})
+
+	nodeID := ids.GenerateTestNodeID()
+	for i := 0; i < 10; i++ {
+		require.True(b.Acquire(nodeID, message.GetAncestors))
+	}
+	b.Release(nodeID, message.GetAncestors, 100)
+	require.Empty(b.peers)
+
+	// Bytes served are reported even if the budget isn't enforced.
+	require.Equal(100.0, testutil.ToFloat64(b.bytesServed.WithLabelValues(message.GetAncestors.String())))
+}
+
+func TestBudgetInvalidConfig(t *testing.T) {
+	_, err := NewBudget(Config{
+		Enabled:          true,
+		BandwidthPerPeer: 1,
+	}, "", prometheus.NewRegistry())
+	require.ErrorIs(t, err, errInvalidConcurrency)
+
+	_, err = NewBudget(Config{
+		Enabled:              true,
+		MaxConcurrentPerPeer: 1,
+	}, "", prometheus.NewRegistry())
+	require.ErrorIs(t, err, errInvalidBandwidth)
+}
@@ -5,6 +5,7 @@ package timeout
 
 import (
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -23,8 +24,9 @@ type Manager interface {
 	// Start the manager. Must be called before any other method.
 	// Should be called in a goroutine.
 	Dispatch()
-	// TimeoutDuration returns the current timeout duration.
-	TimeoutDuration() time.Duration
+	// TimeoutDuration returns the current timeout duration of requests
+	// regarding chain [chainID].
+	TimeoutDuration(chainID ids.ID) time.Duration
 	// IsBenched returns true if messages to [nodeID] regarding [chainID]
 	// should not be sent over the network and should immediately fail.
 	IsBenched(nodeID ids.NodeID, chainID ids.ID) bool
@@ -32,6 +34,10 @@ type Manager interface {
 	// Must be called before any method calls that use the
 	// ID of the chain.
 	RegisterChain(ctx *snow.ConsensusContext) error
+	// UnregisterChain stops the timeouts of the chain [chainID] and
+	// unregisters its metrics. Pending requests regarding the chain never time
+	// out. Should be called once the chain has stopped.
+	UnregisterChain(chainID ids.ID)
 	// RegisterRequest notes that we expect a response of type [op] from
 	// [nodeID] for chain [chainID]. If we don't receive a response in
 	// time, [timeoutHandler] is executed.
@@ -45,9 +51,10 @@ type Manager interface {
 	// Registers that we would have sent a request to a validator but they
 	// are unreachable because they are benched or because of network conditions
 	// (e.g. we're not connected), so we didn't send the query. For the sake
-	// of calculating the average latency and network timeout, we act as
-	// though we sent the validator a request and it timed out.
-	RegisterRequestToUnreachableValidator()
+	// of calculating the average latency and network timeout of chain
+	// [chainID], we act as though we sent the validator a request and it timed
+	// out.
+	RegisterRequestToUnreachableValidator(chainID ids.ID)
 	// Registers that [nodeID] sent us a response of type [op]
 	// for the given chain. The response corresponds to the given
 	// requestID we sent them. [latency] is the time between us
@@ -59,13 +66,18 @@ type Manager interface {
 		op message.Op,
 		latency time.Duration,
 	)
-	// Mark that we no longer expect a response to this request we sent.
+	// Mark that we no longer expect a response to this request we sent
+	// regarding chain [chainID].
 	// Does not modify the timeout.
-	RemoveRequest(requestID ids.ID)
+	RemoveRequest(chainID ids.ID, requestID ids.ID)
 }
 
+// NewManager returns a timeout manager that keeps a separate adaptive timeout
+// for every registered chain. Chains use [timeoutConfig] unless they have an
+// entry in [chainTimeoutConfigs], which is keyed by chainID or chain alias.
 func NewManager(
 	timeoutConfig *timer.AdaptiveTimeoutConfig,
+	chainTimeoutConfigs map[string]timer.AdaptiveTimeoutConfig,
 	benchlistMgr benchlist.Manager,
 	metricsNamespace string,
 	metricsRegister prometheus.Registerer,
@@ -79,23 +91,72 @@ func NewManager(
 		return nil, fmt.Errorf("couldn't create timeout manager: %w", err)
 	}
 	return &manager{
-		benchlistMgr: benchlistMgr,
-		tm:           tm,
+		benchlistMgr:        benchlistMgr,
+		tm:                  tm,
+		timeoutConfig:       *timeoutConfig,
+		chainTimeoutConfigs: chainTimeoutConfigs,
+		chainTMs:            make(map[ids.ID]*chainTimeoutManager),
 	}, nil
 }
 
+// chainTimeoutManager is the adaptive timeout manager of a registered chain.
+type chainTimeoutManager struct {
+	timer.AdaptiveTimeoutManager
+	// registerer unregisters the metrics of the timeout manager once the chain
+	// is unregistered.
+	registerer *registerer
+}
+
 type manager struct {
+	// tm is used for requests regarding chains that weren't registered.
 	tm           timer.AdaptiveTimeoutManager
 	benchlistMgr benchlist.Manager
 	metrics      metrics
+
+	timeoutConfig       timer.AdaptiveTimeoutConfig
+	chainTimeoutConfigs map[string]timer.AdaptiveTimeoutConfig
+
+	chainTMsLock sync.RWMutex
+	// chainID -> adaptive timeout manager of the chain
+	chainTMs map[ids.ID]*chainTimeoutManager
 }
 
 func (m *manager) Dispatch() {
 	m.tm.Dispatch()
 }
 
-func (m *manager) TimeoutDuration() time.Duration {
-	return m.tm.TimeoutDuration()
+func (m *manager) TimeoutDuration(chainID ids.ID) time.Duration {
+	return m.getTM(chainID).TimeoutDuration()
+}
+
+// getTM returns the adaptive timeout manager of [chainID], or the default
+// adaptive timeout manager if [chainID] wasn't registered.
+func (m *manager) getTM(chainID ids.ID) timer.AdaptiveTimeoutManager {
+	m.chainTMsLock.RLock()
+	defer m.chainTMsLock.RUnlock()
+
+	if tm, ok := m.chainTMs[chainID]; ok {
+		return tm
+	}
+	return m.tm
+}
+
+// getTimeoutConfig returns the adaptive timeout config of the chain described
+// by [ctx]. The config is looked up by chainID first, then by chain alias.
+func (m *manager) getTimeoutConfig(ctx *snow.ConsensusContext) timer.AdaptiveTimeoutConfig {
+	if config, ok := m.chainTimeoutConfigs[ctx.ChainID.String()]; ok {
+		return config
+	}
+	aliases, err := ctx.BCLookup.Aliases(ctx.ChainID)
+	if err != nil {
+		return m.timeoutConfig
+	}
+	for _, alias := range aliases {
+		if config, ok := m.chainTimeoutConfigs[alias]; ok {
+			return config
+		}
+	}
+	return m.timeoutConfig
 }
 
 // IsBenched returns true if messages to [nodeID] regarding [chainID]
@@ -108,12 +169,48 @@ func (m *manager) RegisterChain(ctx *snow.ConsensusContext) error {
 	if err := m.metrics.RegisterChain(ctx); err != nil {
 		return fmt.Errorf("couldn't register timeout metrics for chain %s: %w", ctx.ChainID, err)
 	}
+
+	timeoutConfig := m.getTimeoutConfig(ctx)
+	registerer := &registerer{Registerer: ctx.Registerer}
+	tm, err := timer.NewAdaptiveTimeoutManager(
+		&timeoutConfig,
+		"timeout",
+		registerer,
+	)
+	if err != nil {
+		registerer.unregisterAll()
+		m.metrics.UnregisterChain(ctx.ChainID)
+		return fmt.Errorf("couldn't create timeout manager for chain %s: %w", ctx.ChainID, err)
+	}
+	go ctx.Log.RecoverAndPanic(tm.Dispatch)
+
+	m.chainTMsLock.Lock()
+	m.chainTMs[ctx.ChainID] = &chainTimeoutManager{
+		AdaptiveTimeoutManager: tm,
+		registerer:             registerer,
+	}
+	m.chainTMsLock.Unlock()
+
 	if err := m.benchlistMgr.RegisterChain(ctx); err != nil {
+		m.UnregisterChain(ctx.ChainID)
 		return fmt.Errorf("couldn't register chain %s with benchlist manager: %w", ctx.ChainID, err)
 	}
 	return nil
 }
 
+func (m *manager) UnregisterChain(chainID ids.ID) {
+	m.chainTMsLock.Lock()
+	tm, ok := m.chainTMs[chainID]
+	delete(m.chainTMs, chainID)
+	m.chainTMsLock.Unlock()
+
+	if ok {
+		tm.Stop()
+		tm.registerer.unregisterAll()
+	}
+	m.metrics.UnregisterChain(chainID)
+}
+
 // RegisterRequest notes that we expect a response of type [op] from
 // [nodeID] regarding chain [chainID]. If we don't receive a response in
 // time, [timeoutHandler]  is executed.
@@ -129,7 +226,7 @@ func (m *manager) RegisterRequest(
 		m.benchlistMgr.RegisterFailure(chainID, nodeID)
 		timeoutHandler()
 	}
-	m.tm.Put(requestID, op, newTimeoutHandler)
+	m.getTM(chainID).Put(requestID, op, newTimeoutHandler)
 }
 
 // RegisterResponse registers that we received a response from [nodeID]
@@ -143,13 +240,14 @@ func (m *manager) RegisterResponse(
 ) {
 	m.metrics.Observe(nodeID, chainID, op, latency)
 	m.benchlistMgr.RegisterResponse(chainID, nodeID)
-	m.tm.Remove(requestID)
+	m.getTM(chainID).Remove(requestID)
 }
 
-func (m *manager) RemoveRequest(requestID ids.ID) {
-	m.tm.Remove(requestID)
+func (m *manager) RemoveRequest(chainID ids.ID, requestID ids.ID) {
+	m.getTM(chainID).Remove(requestID)
 }
 
-func (m *manager) RegisterRequestToUnreachableValidator() {
-	m.tm.ObserveLatency(m.TimeoutDuration())
+func (m *manager) RegisterRequestToUnreachableValidator(chainID ids.ID) {
+	tm := m.getTM(chainID)
+	tm.ObserveLatency(tm.TimeoutDuration())
 }
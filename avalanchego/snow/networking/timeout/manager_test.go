@@ -10,8 +10,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/message"
+	"github.com/ava-labs/avalanchego/snow"
 	"github.com/ava-labs/avalanchego/snow/networking/benchlist"
 	"github.com/ava-labs/avalanchego/utils/timer"
 )
@@ -26,6 +29,7 @@ func TestManagerFire(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -53,6 +57,7 @@ func TestManagerCancel(t *testing.T) {
 			TimeoutCoefficient: 1.25,
 			TimeoutHalflife:    5 * time.Minute,
 		},
+		nil,
 		benchlist,
 		"",
 		prometheus.NewRegistry(),
@@ -80,3 +85,105 @@ func TestManagerCancel(t *testing.T) {
 		t.Fatalf("Should have cancelled the function")
 	}
 }
+
+func TestManagerChainTimeoutConfig(t *testing.T) {
+	require := require.New(t)
+
+	defaultConfig := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     5 * time.Second,
+		MinimumTimeout:     2 * time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+	}
+	chainConfig := defaultConfig
+	chainConfig.InitialTimeout = time.Second
+	chainConfig.MinimumTimeout = time.Second
+
+	manager, err := NewManager(
+		&defaultConfig,
+		map[string]timer.AdaptiveTimeoutConfig{
+			"C": chainConfig,
+		},
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go manager.Dispatch()
+
+	aliaser := ids.NewAliaser()
+	chainID := ids.GenerateTestID()
+	require.NoError(aliaser.Alias(chainID, "C"))
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = chainID
+	ctx.BCLookup = aliaser
+	require.NoError(manager.RegisterChain(ctx))
+
+	otherCtx := snow.DefaultConsensusContextTest()
+	otherCtx.ChainID = ids.GenerateTestID()
+	otherCtx.BCLookup = aliaser
+	require.NoError(manager.RegisterChain(otherCtx))
+
+	// The aliased chain uses its own config while the other chains use the
+	// default config.
+	require.Equal(chainConfig.InitialTimeout, manager.TimeoutDuration(chainID))
+	require.Equal(defaultConfig.InitialTimeout, manager.TimeoutDuration(otherCtx.ChainID))
+	require.Equal(defaultConfig.InitialTimeout, manager.TimeoutDuration(ids.GenerateTestID()))
+
+	// Unreachable validators only affect the timeout of their chain.
+	manager.RegisterRequestToUnreachableValidator(otherCtx.ChainID)
+	require.Greater(manager.TimeoutDuration(otherCtx.ChainID), defaultConfig.InitialTimeout)
+	require.Equal(chainConfig.InitialTimeout, manager.TimeoutDuration(chainID))
+	require.Equal(defaultConfig.InitialTimeout, manager.TimeoutDuration(ids.GenerateTestID()))
+}
+
+func TestManagerUnregisterChain(t *testing.T) {
+	require := require.New(t)
+
+	config := timer.AdaptiveTimeoutConfig{
+		InitialTimeout:     time.Second,
+		MinimumTimeout:     time.Second,
+		MaximumTimeout:     10 * time.Second,
+		TimeoutCoefficient: 1.25,
+		TimeoutHalflife:    5 * time.Minute,
+	}
+	manager, err := NewManager(
+		&config,
+		nil,
+		benchlist.NewNoBenchlist(),
+		"",
+		prometheus.NewRegistry(),
+	)
+	require.NoError(err)
+	go manager.Dispatch()
+
+	registry := prometheus.NewRegistry()
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.Registerer = registry
+	require.NoError(manager.RegisterChain(ctx))
+
+	metrics, err := registry.Gather()
+	require.NoError(err)
+	require.NotEmpty(metrics)
+
+	manager.RegisterRequestToUnreachableValidator(ctx.ChainID)
+	require.Greater(manager.TimeoutDuration(ctx.ChainID), config.InitialTimeout)
+
+	manager.UnregisterChain(ctx.ChainID)
+
+	// The chain's metrics are unregistered and its requests fall back to the
+	// default timeout.
+	metrics, err = registry.Gather()
+	require.NoError(err)
+	require.Empty(metrics)
+	require.Equal(config.InitialTimeout, manager.TimeoutDuration(ctx.ChainID))
+
+	// The chain may be registered again.
+	require.NoError(manager.RegisterChain(ctx))
+	manager.UnregisterChain(ctx.ChainID)
+
+	// Unregistering an unknown chain is a no-op.
+	manager.UnregisterChain(ids.GenerateTestID())
+}
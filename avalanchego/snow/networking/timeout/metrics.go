@@ -24,6 +24,10 @@ const (
 	validatorIDLabel      = "validatorID"
 )
 
+// latencyBuckets are the upper bounds, in ns, of the response latency
+// histograms. They range from 10ms to ~20s.
+var latencyBuckets = prometheus.ExponentialBuckets(float64(10*time.Millisecond), 2, 12)
+
 type metrics struct {
 	lock           sync.Mutex
 	chainToMetrics map[ids.ID]*chainMetrics
@@ -41,12 +45,27 @@ func (m *metrics) RegisterChain(ctx *snow.ConsensusContext) error {
 	}
 	cm, err := newChainMetrics(ctx, false)
 	if err != nil {
+		cm.registerer.unregisterAll()
 		return fmt.Errorf("couldn't create metrics for chain %s: %w", ctx.ChainID, err)
 	}
 	m.chainToMetrics[ctx.ChainID] = cm
 	return nil
 }
 
+// UnregisterChain unregisters the metrics of chain [chainID], if it was
+// registered.
+func (m *metrics) UnregisterChain(chainID ids.ID) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	cm, exists := m.chainToMetrics[chainID]
+	if !exists {
+		return
+	}
+	delete(m.chainToMetrics, chainID)
+	cm.registerer.unregisterAll()
+}
+
 // Record that a response of type [op] took [latency]
 func (m *metrics) Observe(nodeID ids.NodeID, chainID ids.ID, op message.Op, latency time.Duration) {
 	m.lock.Lock()
@@ -62,9 +81,11 @@ func (m *metrics) Observe(nodeID ids.NodeID, chainID ids.ID, op message.Op, late
 
 // chainMetrics contains message response time metrics for a chain
 type chainMetrics struct {
-	ctx *snow.ConsensusContext
+	ctx        *snow.ConsensusContext
+	registerer *registerer
 
-	messageLatencies map[message.Op]metric.Averager
+	messageLatencies  map[message.Op]metric.Averager
+	messageHistograms map[message.Op]prometheus.Histogram
 
 	summaryEnabled   bool
 	messageSummaries map[message.Op]*prometheus.SummaryVec
@@ -72,9 +93,11 @@ type chainMetrics struct {
 
 func newChainMetrics(ctx *snow.ConsensusContext, summaryEnabled bool) (*chainMetrics, error) {
 	cm := &chainMetrics{
-		ctx: ctx,
+		ctx:        ctx,
+		registerer: &registerer{Registerer: ctx.Registerer},
 
-		messageLatencies: make(map[message.Op]metric.Averager, len(message.ConsensusResponseOps)),
+		messageLatencies:  make(map[message.Op]metric.Averager, len(message.ConsensusResponseOps)),
+		messageHistograms: make(map[message.Op]prometheus.Histogram, len(message.ConsensusResponseOps)),
 
 		summaryEnabled:   summaryEnabled,
 		messageSummaries: make(map[message.Op]*prometheus.SummaryVec, len(message.ConsensusResponseOps)),
@@ -86,10 +109,23 @@ func newChainMetrics(ctx *snow.ConsensusContext, summaryEnabled bool) (*chainMet
 			"lat",
 			op.String(),
 			defaultRequestHelpMsg,
-			ctx.Registerer,
+			cm.registerer,
 			&errs,
 		)
 
+		histogramName := fmt.Sprintf("%s_histogram", op)
+		histogram := prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "lat",
+			Name:      histogramName,
+			Help:      defaultRequestHelpMsg,
+			Buckets:   latencyBuckets,
+		})
+		cm.messageHistograms[op] = histogram
+
+		if err := cm.registerer.Register(histogram); err != nil {
+			errs.Add(fmt.Errorf("failed to register %s histogram: %w", histogramName, err))
+		}
+
 		if !summaryEnabled {
 			continue
 		}
@@ -105,7 +141,7 @@ func newChainMetrics(ctx *snow.ConsensusContext, summaryEnabled bool) (*chainMet
 		)
 		cm.messageSummaries[op] = summary
 
-		if err := ctx.Registerer.Register(summary); err != nil {
+		if err := cm.registerer.Register(summary); err != nil {
 			errs.Add(fmt.Errorf("failed to register %s statistics: %w", summaryName, err))
 		}
 	}
@@ -117,6 +153,9 @@ func (cm *chainMetrics) observe(nodeID ids.NodeID, op message.Op, latency time.D
 	if msg, exists := cm.messageLatencies[op]; exists {
 		msg.Observe(lat)
 	}
+	if histogram, exists := cm.messageHistograms[op]; exists {
+		histogram.Observe(lat)
+	}
 
 	if !cm.summaryEnabled {
 		return
@@ -140,3 +179,33 @@ func (cm *chainMetrics) observe(nodeID ids.NodeID, op message.Op, latency time.D
 	}
 	observer.Observe(lat)
 }
+
+// registerer remembers the collectors that were registered through it, so
+// that they can be unregistered once their chain is unregistered.
+type registerer struct {
+	prometheus.Registerer
+	collectors []prometheus.Collector
+}
+
+func (r *registerer) Register(c prometheus.Collector) error {
+	if err := r.Registerer.Register(c); err != nil {
+		return err
+	}
+	r.collectors = append(r.collectors, c)
+	return nil
+}
+
+func (r *registerer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		if err := r.Register(c); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func (r *registerer) unregisterAll() {
+	for _, c := range r.collectors {
+		r.Registerer.Unregister(c)
+	}
+	r.collectors = nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/resource"
+)
+
+var _ ChainTracker = &chainTracker{}
+
+// ChainTracker is an interface for tracking chains' usage of the CPU.
+//
+// The CPU usage of the node is attributed to the chains in proportion to the
+// time each chain spent handling messages. Memory isn't attributed to chains,
+// as all chains running in this process share a single heap.
+type ChainTracker interface {
+	// Registers that the given chain started processing at the given time.
+	StartProcessing(chainID ids.ID, now time.Time)
+	// Registers that the given chain stopped processing at the given time.
+	StopProcessing(chainID ids.ID, now time.Time)
+	// Returns the current CPU usage of the given chain.
+	CPUUsage(chainID ids.ID, now time.Time) float64
+	// Returns the duration between [now] and when the CPU usage of [chainID]
+	// reaches [value], assuming that the chain uses no more CPU.
+	// If the chain's usage isn't known, or is already <= [value], returns the
+	// zero duration.
+	TimeUntilCPUUsage(chainID ids.ID, now time.Time, value float64) time.Duration
+}
+
+type chainTracker struct {
+	lock sync.Mutex
+
+	resources resource.User
+	factory   meter.Factory
+	halflife  time.Duration
+	// Tracks total number of current processing messages of all chains.
+	processingMeter meter.Meter
+	// Tracks the number of current processing messages of each chain. Chains
+	// are never removed, as the number of chains a node runs is small.
+	meters map[ids.ID]meter.Meter
+
+	processingTimeMetric prometheus.Gauge
+}
+
+func NewChainTracker(
+	reg prometheus.Registerer,
+	resources resource.User,
+	factory meter.Factory,
+	halflife time.Duration,
+) (ChainTracker, error) {
+	t := &chainTracker{
+		resources:       resources,
+		factory:         factory,
+		halflife:        halflife,
+		processingMeter: factory.New(halflife),
+		meters:          make(map[ids.ID]meter.Meter),
+		processingTimeMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "chain_tracker",
+			Name:      "processing_time",
+			Help:      "Tracked processing time over all chains. Value expected to be in [0, number of CPU cores], but can go higher due to IO bound processes and thread multiplexing",
+		}),
+	}
+	if err := reg.Register(t.processingTimeMetric); err != nil {
+		return nil, fmt.Errorf("initializing chainTracker metrics errored with: %w", err)
+	}
+	return t, nil
+}
+
+func (t *chainTracker) StartProcessing(chainID ids.ID, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.getMeter(chainID).Inc(now, 1)
+	t.processingMeter.Inc(now, 1)
+}
+
+func (t *chainTracker) StopProcessing(chainID ids.ID, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	t.getMeter(chainID).Dec(now, 1)
+	t.processingMeter.Dec(now, 1)
+}
+
+func (t *chainTracker) CPUUsage(chainID ids.ID, now time.Time) float64 {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	m, exists := t.meters[chainID]
+	if !exists {
+		return 0
+	}
+
+	measuredProcessingTime := t.processingMeter.Read(now)
+	t.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 {
+		return 0
+	}
+
+	portionUsageByChain := m.Read(now) / measuredProcessingTime
+	return t.resources.CPUUsage() * portionUsageByChain
+}
+
+func (t *chainTracker) TimeUntilCPUUsage(chainID ids.ID, now time.Time, value float64) time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	m, exists := t.meters[chainID]
+	if !exists {
+		return 0
+	}
+
+	measuredProcessingTime := t.processingMeter.Read(now)
+	t.processingTimeMetric.Set(measuredProcessingTime)
+
+	if measuredProcessingTime == 0 {
+		return 0
+	}
+
+	realCPUUsage := t.resources.CPUUsage()
+	if realCPUUsage == 0 {
+		return 0
+	}
+
+	scale := realCPUUsage / measuredProcessingTime
+	return m.TimeUntil(now, value/scale)
+}
+
+// getMeter returns the meter used to measure CPU time spent processing
+// messages of [chainID].
+// assumes [t.lock] is held.
+func (t *chainTracker) getMeter(chainID ids.ID) meter.Meter {
+	m, exists := t.meters[chainID]
+	if exists {
+		return m
+	}
+
+	newMeter := t.factory.New(t.halflife)
+	t.meters[chainID] = newMeter
+	return newMeter
+}
@@ -0,0 +1,94 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/math/meter"
+	"github.com/ava-labs/avalanchego/utils/resource"
+)
+
+func TestChainTrackerCPUUsage(t *testing.T) {
+	require := require.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resource.NewMockUser(ctrl)
+	mockUser.EXPECT().CPUUsage().Return(1.0).AnyTimes()
+
+	tracker, err := NewChainTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	require.NoError(err)
+
+	chain1 := ids.GenerateTestID()
+	chain2 := ids.GenerateTestID()
+
+	startTime1 := time.Now()
+	endTime1 := startTime1.Add(halflife)
+	tracker.StartProcessing(chain1, startTime1)
+	tracker.StopProcessing(chain1, endTime1)
+
+	startTime2 := endTime1
+	endTime2 := startTime2.Add(halflife)
+	tracker.StartProcessing(chain2, startTime2)
+	tracker.StopProcessing(chain2, endTime2)
+
+	chain1Usage := tracker.CPUUsage(chain1, endTime2)
+	chain2Usage := tracker.CPUUsage(chain2, endTime2)
+	require.Less(chain1Usage, chain2Usage)
+	require.InDelta(1.0, chain1Usage+chain2Usage, .00001)
+
+	// A chain that never processed a message doesn't use the CPU.
+	require.Zero(tracker.CPUUsage(ids.GenerateTestID(), endTime2))
+}
+
+func TestChainTrackerTimeUntilCPUUsage(t *testing.T) {
+	require := require.New(t)
+
+	halflife := 5 * time.Second
+
+	ctrl := gomock.NewController(t)
+	mockUser := resource.NewMockUser(ctrl)
+	mockUser.EXPECT().CPUUsage().Return(2.0).AnyTimes()
+
+	tracker, err := NewChainTracker(prometheus.NewRegistry(), mockUser, meter.ContinuousFactory{}, halflife)
+	require.NoError(err)
+
+	chainID := ids.GenerateTestID()
+	otherChainID := ids.GenerateTestID()
+
+	now := time.Now()
+	tracker.StartProcessing(chainID, now)
+	tracker.StartProcessing(otherChainID, now)
+	now = now.Add(halflife)
+	tracker.StopProcessing(chainID, now)
+	tracker.StopProcessing(otherChainID, now)
+
+	// The chains processed for the same amount of time, so each is attributed
+	// half of the node's CPU usage.
+	currentVal := tracker.CPUUsage(chainID, now)
+	require.InDelta(1.0, currentVal, .00001)
+
+	// Lower values take longer to be reached.
+	timeUntilHalf := tracker.TimeUntilCPUUsage(chainID, now, currentVal/2)
+	timeUntilThird := tracker.TimeUntilCPUUsage(chainID, now, currentVal/3)
+	require.Positive(timeUntilHalf)
+	require.Greater(timeUntilThird, timeUntilHalf)
+
+	// Make sure TimeUntilCPUUsage returns the zero duration if the value
+	// provided >= the current value
+	require.Zero(tracker.TimeUntilCPUUsage(chainID, now, currentVal))
+	require.Zero(tracker.TimeUntilCPUUsage(chainID, now, currentVal+.1))
+	// Make sure it returns the zero duration if the chain isn't known
+	require.Zero(tracker.TimeUntilCPUUsage(ids.GenerateTestID(), now, 0.0001))
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package snow
+
+import (
+	"github.com/ava-labs/avalanchego/utils"
+)
+
+// QueryGate is shared by the chains of a node. While it is closed, the
+// consensus engines don't start new polls, so the node stops querying its
+// peers. Queries from peers are still answered.
+type QueryGate struct {
+	closed utils.AtomicBool
+}
+
+// SetClosed sets whether the consensus engines may start new polls.
+func (g *QueryGate) SetClosed(closed bool) {
+	g.closed.SetValue(closed)
+}
+
+// Closed returns true if the consensus engines may not start new polls. A nil
+// gate is never closed.
+func (g *QueryGate) Closed() bool {
+	return g != nil && g.closed.GetValue()
+}
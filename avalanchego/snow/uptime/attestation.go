@@ -0,0 +1,71 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// Record is the uptime of a validator as tracked by a node
+type Record struct {
+	NodeID      ids.NodeID    `json:"nodeID"`
+	UpDuration  time.Duration `json:"upDuration"`
+	LastUpdated time.Time     `json:"lastUpdated"`
+}
+
+// Attestation is a set of uptime records signed with the staking key of the
+// node that tracked them. It allows a validator that migrates to a new host to
+// carry over the uptimes it tracked of its peers.
+type Attestation struct {
+	Records []Record `json:"records"`
+	// DER encoded staking certificate of the node that signed the records
+	Certificate []byte `json:"certificate"`
+	Signature   []byte `json:"signature"`
+}
+
+// NewAttestation signs [records] with [signer], the key of the staking
+// certificate [cert].
+func NewAttestation(records []Record, cert *x509.Certificate, signer crypto.Signer) (*Attestation, error) {
+	recordsBytes, err := json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(
+		rand.Reader,
+		hashing.ComputeHash256(recordsBytes),
+		crypto.SHA256,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &Attestation{
+		Records:     records,
+		Certificate: cert.Raw,
+		Signature:   sig,
+	}, nil
+}
+
+// Verify checks the signature of the attestation and returns the ID of the
+// node that signed it.
+func (a *Attestation) Verify() (ids.NodeID, error) {
+	cert, err := x509.ParseCertificate(a.Certificate)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	recordsBytes, err := json.Marshal(a.Records)
+	if err != nil {
+		return ids.EmptyNodeID, err
+	}
+	if err := cert.CheckSignature(cert.SignatureAlgorithm, recordsBytes, a.Signature); err != nil {
+		return ids.EmptyNodeID, err
+	}
+	return ids.NodeIDFromCert(cert), nil
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package uptime
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/staking"
+)
+
+func TestAttestation(t *testing.T) {
+	require := require.New(t)
+
+	tlsCert, err := staking.NewTLSCert()
+	require.NoError(err)
+
+	records := []Record{
+		{
+			NodeID:      ids.GenerateTestNodeID(),
+			UpDuration:  time.Hour,
+			LastUpdated: time.Unix(1_000_000, 0).UTC(),
+		},
+	}
+	attestation, err := NewAttestation(records, tlsCert.Leaf, tlsCert.PrivateKey.(crypto.Signer))
+	require.NoError(err)
+
+	nodeID, err := attestation.Verify()
+	require.NoError(err)
+	require.Equal(ids.NodeIDFromCert(tlsCert.Leaf), nodeID)
+
+	// Modifying a record invalidates the signature
+	attestation.Records[0].UpDuration = 2 * time.Hour
+	_, err = attestation.Verify()
+	require.Error(err)
+}
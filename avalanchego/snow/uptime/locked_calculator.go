@@ -13,13 +13,15 @@ import (
 )
 
 var (
-	errNotReady = errors.New("should not be called")
+	errNotReady          = errors.New("should not be called")
+	errImportUnsupported = errors.New("uptime calculator doesn't support imports")
 
 	_ LockedCalculator = &lockedCalculator{}
 )
 
 type LockedCalculator interface {
 	Calculator
+	Importer
 
 	SetCalculator(isBootstrapped *utils.AtomicBool, lock sync.Locker, newC Calculator)
 }
@@ -77,6 +79,25 @@ func (c *lockedCalculator) CalculateUptimePercentFrom(nodeID ids.NodeID, startTi
 	return c.c.CalculateUptimePercentFrom(nodeID, startTime)
 }
 
+func (c *lockedCalculator) ImportUptime(nodeID ids.NodeID, upDuration time.Duration, lastUpdated time.Time) (bool, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	if c.isBootstrapped == nil || !c.isBootstrapped.GetValue() {
+		return false, errNotReady
+	}
+
+	importer, ok := c.c.(Importer)
+	if !ok {
+		return false, errImportUnsupported
+	}
+
+	c.calculatorLock.Lock()
+	defer c.calculatorLock.Unlock()
+
+	return importer.ImportUptime(nodeID, upDuration, lastUpdated)
+}
+
 func (c *lockedCalculator) SetCalculator(isBootstrapped *utils.AtomicBool, lock sync.Locker, newC Calculator) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
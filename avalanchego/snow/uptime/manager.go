@@ -16,6 +16,7 @@ var _ TestManager = &manager{}
 type Manager interface {
 	Tracker
 	Calculator
+	Importer
 }
 
 type Tracker interface {
@@ -37,6 +38,14 @@ type Calculator interface {
 	CalculateUptimePercentFrom(nodeID ids.NodeID, startTime time.Time) (float64, error)
 }
 
+type Importer interface {
+	// ImportUptime merges an uptime record of [nodeID] that was tracked
+	// elsewhere, such as by this validator on a previous host. The local
+	// record is only ever increased. Returns true if the local record was
+	// modified.
+	ImportUptime(nodeID ids.NodeID, upDuration time.Duration, lastUpdated time.Time) (bool, error)
+}
+
 type TestManager interface {
 	Manager
 	SetTime(time.Time)
@@ -196,6 +205,36 @@ func (m *manager) CalculateUptimePercentFrom(nodeID ids.NodeID, startTime time.T
 	return uptime, nil
 }
 
+func (m *manager) ImportUptime(nodeID ids.NodeID, importedUpDuration time.Duration, importedLastUpdated time.Time) (bool, error) {
+	startTime, err := m.state.GetStartTime(nodeID)
+	if err != nil {
+		return false, err
+	}
+	upDuration, lastUpdated, err := m.state.GetUptime(nodeID)
+	if err != nil {
+		return false, err
+	}
+
+	// Records that claim to be from the future or from before the validator
+	// started can't be compared against the local record.
+	now := m.clock.UnixTime()
+	if importedLastUpdated.After(now) || importedLastUpdated.Before(startTime) {
+		return false, nil
+	}
+
+	// Both records are compared by the amount of time the validator was
+	// considered offline. If the imported record saw less downtime, the
+	// missing uptime is credited to the local record without moving its last
+	// updated time, so that no period is double counted.
+	importedDownDuration := importedLastUpdated.Sub(startTime) - importedUpDuration
+	downDuration := lastUpdated.Sub(startTime) - upDuration
+	if importedDownDuration < 0 || importedDownDuration >= downDuration {
+		return false, nil
+	}
+	newUpDuration := upDuration + downDuration - importedDownDuration
+	return true, m.state.SetUptime(nodeID, newUpDuration, lastUpdated)
+}
+
 func (m *manager) SetTime(newTime time.Time) {
 	m.clock.Set(newTime)
 }
@@ -566,3 +566,45 @@ func TestShutdownUnixTimeRegression(t *testing.T) {
 	require.NoError(err)
 	require.GreaterOrEqual(float64(1), perc)
 }
+
+func TestImportUptime(t *testing.T) {
+	require := require.New(t)
+
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime := time.Unix(time.Now().Unix(), 0)
+
+	s := NewTestState()
+	s.AddNode(nodeID0, startTime)
+
+	up := NewManager(s).(*manager)
+
+	// The local record was reset, so the validator was considered offline for
+	// its first 10 seconds.
+	currentTime := startTime.Add(10 * time.Second)
+	up.clock.Set(currentTime)
+	require.NoError(s.SetUptime(nodeID0, 0, currentTime))
+
+	// The imported record saw the validator online for 8 of its first 9
+	// seconds.
+	imported, err := up.ImportUptime(nodeID0, 8*time.Second, startTime.Add(9*time.Second))
+	require.NoError(err)
+	require.True(imported)
+
+	duration, lastUpdated, err := s.GetUptime(nodeID0)
+	require.NoError(err)
+	require.Equal(9*time.Second, duration)
+	require.Equal(up.clock.UnixTime(), lastUpdated)
+
+	// Records that saw more downtime are ignored
+	imported, err = up.ImportUptime(nodeID0, 7*time.Second, startTime.Add(9*time.Second))
+	require.NoError(err)
+	require.False(imported)
+
+	// Records from the future are ignored
+	imported, err = up.ImportUptime(nodeID0, 11*time.Second, startTime.Add(11*time.Second))
+	require.NoError(err)
+	require.False(imported)
+
+	_, err = up.ImportUptime(ids.GenerateTestNodeID(), time.Second, startTime)
+	require.Error(err)
+}
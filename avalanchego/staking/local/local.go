@@ -0,0 +1,32 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package local embeds the staking keys of the stakers of the local networks,
+// so that local networks can be started without a checkout of this
+// repository.
+package local
+
+import (
+	"embed"
+	"fmt"
+)
+
+// NumStakers is the number of stakers whose keys are embedded
+const NumStakers = 5
+
+//go:embed *.crt *.key
+var files embed.FS
+
+// StakerKeys returns the PEM encoded TLS key and certificate of the staker with
+// index [i], which ranges from 1 to [NumStakers].
+func StakerKeys(i int) ([]byte, []byte, error) {
+	key, err := files.ReadFile(fmt.Sprintf("staker%d.key", i))
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := files.ReadFile(fmt.Sprintf("staker%d.crt", i))
+	if err != nil {
+		return nil, nil, err
+	}
+	return key, cert, nil
+}
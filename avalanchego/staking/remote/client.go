@@ -0,0 +1,148 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var (
+	_ crypto.Signer = &tlsSigner{}
+	_ bls.Signer    = &blsSigner{}
+)
+
+// Client fetches the staking keys of a node from a remote signer. The secret
+// keys never leave the signer.
+type Client struct {
+	client signerpb.SignerClient
+	// Maximum duration of a request to the signer
+	timeout time.Duration
+}
+
+// NewClient returns a client of the remote signer served by [client]. Requests
+// fail if the signer doesn't answer within [timeout].
+func NewClient(client signerpb.SignerClient, timeout time.Duration) *Client {
+	return &Client{
+		client:  client,
+		timeout: timeout,
+	}
+}
+
+// TLSCertificate returns the staking certificate of the signer. Its private
+// key signs with the signer.
+func (c *Client) TLSCertificate() (*tls.Certificate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.TLSCertificate(ctx, &signerpb.TLSCertificateRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch staking certificate: %w", err)
+	}
+	leaf, err := x509.ParseCertificate(resp.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse staking certificate: %w", err)
+	}
+	return &tls.Certificate{
+		Certificate: [][]byte{resp.Certificate},
+		PrivateKey: &tlsSigner{
+			client:    c,
+			publicKey: leaf.PublicKey,
+		},
+		Leaf: leaf,
+	}, nil
+}
+
+// BLSSigner returns a signer using the BLS key of the signer.
+func (c *Client) BLSSigner() (bls.Signer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	resp, err := c.client.BLSPublicKey(ctx, &signerpb.BLSPublicKeyRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("couldn't fetch BLS public key: %w", err)
+	}
+	pk, err := bls.PublicKeyFromBytes(resp.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't parse BLS public key: %w", err)
+	}
+	return &blsSigner{
+		client:    c,
+		publicKey: pk,
+	}, nil
+}
+
+type tlsSigner struct {
+	client    *Client
+	publicKey crypto.PublicKey
+}
+
+func (s *tlsSigner) Public() crypto.PublicKey {
+	return s.publicKey
+}
+
+// Sign ignores [rand] as the signer uses its own source of randomness.
+func (s *tlsSigner) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.timeout)
+	defer cancel()
+
+	req := &signerpb.TLSSignRequest{
+		Digest: digest,
+		Hash:   uint32(opts.HashFunc()),
+	}
+	if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+		req.Pss = true
+		req.PssSaltLength = int32(pssOpts.SaltLength)
+	}
+	resp, err := s.client.client.TLSSign(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Signature, nil
+}
+
+type blsSigner struct {
+	client    *Client
+	publicKey *bls.PublicKey
+}
+
+func (s *blsSigner) PublicKey() *bls.PublicKey {
+	return s.publicKey
+}
+
+func (s *blsSigner) Sign(msg []byte) (*bls.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.timeout)
+	defer cancel()
+
+	resp, err := s.client.client.BLSSign(ctx, &signerpb.BLSSignRequest{
+		Message: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(resp.Signature)
+}
+
+func (s *blsSigner) SignProofOfPossession(msg []byte) (*bls.Signature, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.client.timeout)
+	defer cancel()
+
+	resp, err := s.client.client.BLSSignProofOfPossession(ctx, &signerpb.BLSSignProofOfPossessionRequest{
+		Message: msg,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureFromBytes(resp.Signature)
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/ava-labs/avalanchego/network/peer"
+	"github.com/ava-labs/avalanchego/staking"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/rpcchainvm/grpcutils"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+// newTestClient serves [cert] and [sk] with a remote signer and returns a
+// client of that signer.
+func newTestClient(t *testing.T, cert *tls.Certificate, sk *bls.SecretKey) *Client {
+	require := require.New(t)
+
+	server, err := NewServer(cert, bls.NewSigner(sk))
+	require.NoError(err)
+
+	listener, err := grpcutils.NewListener()
+	require.NoError(err)
+	grpcServer := grpc.NewServer()
+	signerpb.RegisterSignerServer(grpcServer, server)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return NewClient(signerpb.NewSignerClient(conn), time.Minute)
+}
+
+func TestTLSCertificate(t *testing.T) {
+	require := require.New(t)
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(err)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	client := newTestClient(t, cert, sk)
+
+	remoteCert, err := client.TLSCertificate()
+	require.NoError(err)
+	require.Equal(cert.Leaf.Raw, remoteCert.Leaf.Raw)
+
+	// A peer handshake must succeed with the remote key
+	peerCert, err := staking.NewTLSCert()
+	require.NoError(err)
+	serverConn, clientConn := net.Pipe()
+	tlsServer := tls.Server(serverConn, peer.TLSConfig(*remoteCert, nil, peer.CertPolicy{}))
+	tlsClient := tls.Client(clientConn, peer.TLSConfig(*peerCert, nil, peer.CertPolicy{}))
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- tlsServer.Handshake()
+	}()
+	require.NoError(tlsClient.Handshake())
+	require.NoError(<-serverErr)
+	require.Equal(cert.Leaf.Raw, tlsClient.ConnectionState().PeerCertificates[0].Raw)
+}
+
+func TestBLSSigner(t *testing.T) {
+	require := require.New(t)
+
+	cert, err := staking.NewTLSCert()
+	require.NoError(err)
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	client := newTestClient(t, cert, sk)
+
+	blsSigner, err := client.BLSSigner()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+	require.Equal(bls.PublicKeyToBytes(pk), bls.PublicKeyToBytes(blsSigner.PublicKey()))
+
+	msg := []byte("message")
+	sig, err := blsSigner.Sign(msg)
+	require.NoError(err)
+	require.True(bls.Verify(pk, sig, msg))
+
+	pop, err := signer.NewProofOfPossessionFromSigner(blsSigner)
+	require.NoError(err)
+	require.NoError(pop.Verify())
+	require.Equal(signer.NewProofOfPossession(sk), pop)
+}
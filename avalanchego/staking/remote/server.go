@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package remote
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"errors"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	signerpb "github.com/ava-labs/avalanchego/proto/pb/signer"
+)
+
+var (
+	_ signerpb.SignerServer = &Server{}
+
+	errInvalidTLSKey     = errors.New("invalid TLS key")
+	errUnavailableHash   = errors.New("unavailable hash function")
+	errMissingTLSLeaf    = errors.New("missing parsed TLS certificate")
+	errUnexpectedPSSHash = errors.New("PSS signatures require a hash function")
+)
+
+// Server is a signer serving keys held in memory. An HSM-backed signer
+// implements the same service.
+type Server struct {
+	signerpb.UnsafeSignerServer
+
+	cert      []byte
+	tlsSigner crypto.Signer
+	blsSigner bls.Signer
+}
+
+// NewServer returns a signer that signs with the private key of [cert] and
+// with [blsSigner]. [cert.Leaf] must be set.
+func NewServer(cert *tls.Certificate, blsSigner bls.Signer) (*Server, error) {
+	if cert.Leaf == nil {
+		return nil, errMissingTLSLeaf
+	}
+	tlsSigner, ok := cert.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, errInvalidTLSKey
+	}
+	return &Server{
+		cert:      cert.Leaf.Raw,
+		tlsSigner: tlsSigner,
+		blsSigner: blsSigner,
+	}, nil
+}
+
+func (s *Server) TLSCertificate(context.Context, *signerpb.TLSCertificateRequest) (*signerpb.TLSCertificateResponse, error) {
+	return &signerpb.TLSCertificateResponse{
+		Certificate: s.cert,
+	}, nil
+}
+
+func (s *Server) TLSSign(_ context.Context, req *signerpb.TLSSignRequest) (*signerpb.TLSSignResponse, error) {
+	hash := crypto.Hash(req.Hash)
+	if hash != 0 && !hash.Available() {
+		return nil, errUnavailableHash
+	}
+
+	var opts crypto.SignerOpts = hash
+	if req.Pss {
+		if hash == 0 {
+			return nil, errUnexpectedPSSHash
+		}
+		opts = &rsa.PSSOptions{
+			SaltLength: int(req.PssSaltLength),
+			Hash:       hash,
+		}
+	}
+	sig, err := s.tlsSigner.Sign(rand.Reader, req.Digest, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.TLSSignResponse{
+		Signature: sig,
+	}, nil
+}
+
+func (s *Server) BLSPublicKey(context.Context, *signerpb.BLSPublicKeyRequest) (*signerpb.BLSPublicKeyResponse, error) {
+	return &signerpb.BLSPublicKeyResponse{
+		PublicKey: bls.PublicKeyToBytes(s.blsSigner.PublicKey()),
+	}, nil
+}
+
+func (s *Server) BLSSign(_ context.Context, req *signerpb.BLSSignRequest) (*signerpb.BLSSignResponse, error) {
+	sig, err := s.blsSigner.Sign(req.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.BLSSignResponse{
+		Signature: bls.SignatureToBytes(sig),
+	}, nil
+}
+
+func (s *Server) BLSSignProofOfPossession(_ context.Context, req *signerpb.BLSSignProofOfPossessionRequest) (*signerpb.BLSSignProofOfPossessionResponse, error) {
+	sig, err := s.blsSigner.SignProofOfPossession(req.Message)
+	if err != nil {
+		return nil, err
+	}
+	return &signerpb.BLSSignProofOfPossessionResponse{
+		Signature: bls.SignatureToBytes(sig),
+	}, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// genesisaudit recomputes the funds allocated by a genesis, such as the initial
+// supply, the allocations of each address, the locked amounts and the unlock
+// schedule, and prints them as JSON.
+//
+// Audit the genesis embedded in this binary for a network:
+//
+//	genesisaudit -network flare
+//
+// Audit a genesis file:
+//
+//	genesisaudit -genesis-file genesis.json -out audit.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/genesis"
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func main() {
+	networkName := flag.String("network", constants.FlareName, "Name of the network whose embedded genesis is audited. Ignored if -genesis-file is provided")
+	genesisFile := flag.String("genesis-file", "", "Genesis file to audit")
+	out := flag.String("out", "", "File to write the audit report to. If empty, it is written to stdout")
+	flag.Parse()
+
+	config, err := loadConfig(*networkName, *genesisFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load genesis: %s\n", err)
+		os.Exit(1)
+	}
+
+	audit, err := genesis.NewAudit(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to audit genesis: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeAudit(*out, audit); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write audit report: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadConfig reads the genesis config from [genesisFile] if provided, or
+// returns the config embedded for [networkName] otherwise.
+func loadConfig(networkName, genesisFile string) (*genesis.Config, error) {
+	if genesisFile != "" {
+		return genesis.GetConfigFile(genesisFile)
+	}
+
+	networkID, err := constants.NetworkID(networkName)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := constants.NetworkIDToNetworkName[networkID]; !ok {
+		return nil, fmt.Errorf("no genesis is embedded for network %q", networkName)
+	}
+	return genesis.GetConfig(networkID), nil
+}
+
+func writeAudit(out string, audit *genesis.Audit) error {
+	auditBytes, err := json.MarshalIndent(audit, "", "\t")
+	if err != nil {
+		return err
+	}
+	auditBytes = append(auditBytes, '\n')
+	if out == "" {
+		_, err := os.Stdout.Write(auditBytes)
+		return err
+	}
+	return os.WriteFile(out, auditBytes, 0o644)
+}
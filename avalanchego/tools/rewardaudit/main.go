@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// rewardaudit exports the inputs of the reward calculations of staking
+// transactions from a node and verifies them by recalculating the rewards.
+//
+// Export and verify the reward contexts of staking transactions:
+//
+//	rewardaudit -uri http://127.0.0.1:9650 -tx-ids <txID>,<txID> -out contexts.json
+//
+// Verify a previous export without connecting to a node:
+//
+//	rewardaudit -in contexts.json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+)
+
+const requestTimeout = 10 * time.Second
+
+var errNoTxIDs = errors.New("either -tx-ids or -in must be provided")
+
+// exportedContext is the reward context of the staker added by [TxID].
+type exportedContext struct {
+	TxID    ids.ID          `json:"txID"`
+	Context *reward.Context `json:"context"`
+}
+
+func main() {
+	uri := flag.String("uri", "http://127.0.0.1:9650", "URI of the node to export the reward contexts from")
+	txIDsStr := flag.String("tx-ids", "", "Comma separated list of staking transaction IDs, or of the IDs of the RewardContinuousValidatorTxs that rewarded the periods of continuous validators, to export the reward contexts of")
+	in := flag.String("in", "", "File of previously exported reward contexts to verify offline")
+	out := flag.String("out", "", "File to write the exported reward contexts to. If empty, they are written to stdout")
+	flag.Parse()
+
+	contexts, err := loadContexts(*uri, *txIDsStr, *in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load reward contexts: %s\n", err)
+		os.Exit(1)
+	}
+
+	if *in == "" {
+		if err := writeContexts(*out, contexts); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write reward contexts: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if !verifyContexts(contexts) {
+		os.Exit(1)
+	}
+}
+
+// loadContexts reads the reward contexts from [in] if provided, or fetches the
+// contexts of [txIDsStr] from the node at [uri] otherwise.
+func loadContexts(uri, txIDsStr, in string) ([]exportedContext, error) {
+	var contexts []exportedContext
+	if in != "" {
+		contextsBytes, err := os.ReadFile(in)
+		if err != nil {
+			return nil, err
+		}
+		return contexts, json.Unmarshal(contextsBytes, &contexts)
+	}
+	if txIDsStr == "" {
+		return nil, errNoTxIDs
+	}
+
+	client := platformvm.NewClient(uri)
+	for _, txIDStr := range strings.Split(txIDsStr, ",") {
+		txID, err := ids.FromString(strings.TrimSpace(txIDStr))
+		if err != nil {
+			return nil, fmt.Errorf("couldn't parse txID %q: %w", txIDStr, err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+		rewardContext, err := client.GetRewardContext(ctx, txID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("couldn't get reward context of %s: %w", txID, err)
+		}
+		contexts = append(contexts, exportedContext{
+			TxID:    txID,
+			Context: rewardContext,
+		})
+	}
+	return contexts, nil
+}
+
+func writeContexts(out string, contexts []exportedContext) error {
+	contextsBytes, err := json.MarshalIndent(contexts, "", "\t")
+	if err != nil {
+		return err
+	}
+	contextsBytes = append(contextsBytes, '\n')
+	if out == "" {
+		_, err := os.Stdout.Write(contextsBytes)
+		return err
+	}
+	return os.WriteFile(out, contextsBytes, 0o644)
+}
+
+// verifyContexts reports whether the reward of each context can be
+// recalculated from its inputs. Returns true if all of them can.
+func verifyContexts(contexts []exportedContext) bool {
+	verified := true
+	for _, exported := range contexts {
+		if exported.Context == nil {
+			fmt.Fprintf(os.Stderr, "%s: missing reward context\n", exported.TxID)
+			verified = false
+			continue
+		}
+		if err := exported.Context.Verify(); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", exported.TxID, err)
+			verified = false
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: verified\n", exported.TxID)
+	}
+	return verified
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+var errUnknownExporterType = errors.New("unknown exporter type")
+
+// ExporterType is the protocol used to send spans to the OTLP collector.
+type ExporterType byte
+
+const (
+	GRPC ExporterType = iota + 1
+	HTTP
+)
+
+func (t ExporterType) String() string {
+	switch t {
+	case GRPC:
+		return "grpc"
+	case HTTP:
+		return "http"
+	default:
+		return "unknown"
+	}
+}
+
+// ExporterTypeFromString returns the exporter type named [s].
+func ExporterTypeFromString(s string) (ExporterType, error) {
+	switch s {
+	case GRPC.String():
+		return GRPC, nil
+	case HTTP.String():
+		return HTTP, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", errUnknownExporterType, s)
+	}
+}
+
+func (t ExporterType) MarshalJSON() ([]byte, error) {
+	return []byte(fmt.Sprintf("%q", t)), nil
+}
+
+type ExporterConfig struct {
+	Type ExporterType `json:"type"`
+
+	// Endpoint to send spans to. If empty, the exporter's default is used.
+	Endpoint string `json:"endpoint"`
+
+	// Headers to send with the spans. Not marshalled, as they usually hold
+	// credentials.
+	Headers map[string]string `json:"-"`
+
+	// If true, don't use TLS
+	Insecure bool `json:"insecure"`
+}
+
+func newExporter(config ExporterConfig) (sdktrace.SpanExporter, error) {
+	var client otlptrace.Client
+	switch config.Type {
+	case GRPC:
+		opts := []otlptracegrpc.Option{
+			otlptracegrpc.WithHeaders(config.Headers),
+			otlptracegrpc.WithTimeout(tracerExportTimeout),
+		}
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		client = otlptracegrpc.NewClient(opts...)
+	case HTTP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithHeaders(config.Headers),
+			otlptracehttp.WithTimeout(tracerExportTimeout),
+		}
+		if config.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(config.Endpoint))
+		}
+		if config.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		client = otlptracehttp.NewClient(opts...)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnknownExporterType, config.Type)
+	}
+	return otlptrace.New(context.Background(), client)
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporterTypeFromString(t *testing.T) {
+	require := require.New(t)
+
+	for _, exporterType := range []ExporterType{GRPC, HTTP} {
+		parsed, err := ExporterTypeFromString(exporterType.String())
+		require.NoError(err)
+		require.Equal(exporterType, parsed)
+	}
+
+	_, err := ExporterTypeFromString("zipkin")
+	require.ErrorIs(err, errUnknownExporterType)
+}
+
+func TestExporterConfigDoesNotMarshalHeaders(t *testing.T) {
+	require := require.New(t)
+
+	configJSON, err := json.Marshal(ExporterConfig{
+		Type:    HTTP,
+		Headers: map[string]string{"authorization": "secret"},
+	})
+	require.NoError(err)
+	require.JSONEq(`{"type":"http","endpoint":"","insecure":false}`, string(configJSON))
+}
+
+func TestNewDisabled(t *testing.T) {
+	tracer, err := New(Config{})
+	require.NoError(t, err)
+	require.Equal(t, Noop, tracer)
+}
@@ -0,0 +1,21 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// Noop is a tracer whose spans are never recorded.
+var Noop Tracer = noOpTracer{
+	Tracer: trace.NewNoopTracerProvider().Tracer(constants.AppName),
+}
+
+type noOpTracer struct {
+	trace.Tracer
+}
+
+func (noOpTracer) Close() error { return nil }
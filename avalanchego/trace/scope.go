@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"sync"
+)
+
+// Scope holds the context of the message a chain is currently handling, so
+// the spans of the VM calls and the messages sent while handling it are
+// parented to the span of the message.
+//
+// The scope is set while a message is handled under the chain's lock. Calls
+// made without holding the lock, like the handling of app messages, may be
+// attributed to the message that is being handled under the lock.
+type Scope struct {
+	lock sync.RWMutex
+	ctx  context.Context
+}
+
+// Set sets the context of the message being handled. Setting a nil context
+// clears the scope.
+func (s *Scope) Set(ctx context.Context) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ctx = ctx
+}
+
+// Context returns the context of the message being handled, or an empty
+// context if no message is being handled.
+func (s *Scope) Context() context.Context {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+// Traced is implemented by the messages whose handling is traced.
+type Traced interface {
+	// TraceContext returns the context of the span of the message.
+	TraceContext() context.Context
+}
+
+// ContextOf returns the context of the span of [msg], or an empty context if
+// [msg] isn't traced.
+func ContextOf(msg interface{}) context.Context {
+	if traced, ok := msg.(Traced); ok {
+		return traced.TraceContext()
+	}
+	return context.Background()
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package trace
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	tracerExportTimeout = 10 * time.Second
+	// [tracerProviderShutdownTimeout] is longer than [tracerExportTimeout] so
+	// in-flight exports can finish before the tracer provider shuts down.
+	tracerProviderShutdownTimeout = 15 * time.Second
+)
+
+type Config struct {
+	ExporterConfig `json:"exporterConfig"`
+
+	// Used to flag if tracing should be performed
+	Enabled bool `json:"enabled"`
+
+	// The fraction of traces to sample.
+	// If >= 1 always samples.
+	// If <= 0 never samples.
+	TraceSampleRate float64 `json:"traceSampleRate"`
+}
+
+// Tracer starts the spans of this node and exports them when it is closed.
+type Tracer interface {
+	trace.Tracer
+	io.Closer
+}
+
+type tracer struct {
+	trace.Tracer
+
+	tp *sdktrace.TracerProvider
+}
+
+func (t *tracer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), tracerProviderShutdownTimeout)
+	defer cancel()
+	return t.tp.Shutdown(ctx)
+}
+
+// New returns a tracer that exports the spans it samples as described by
+// [config], or a tracer that does nothing if tracing isn't enabled.
+func New(config Config) (Tracer, error) {
+	if !config.Enabled {
+		return Noop, nil
+	}
+
+	exporter, err := newExporter(config.ExporterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter, sdktrace.WithExportTimeout(tracerExportTimeout)),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			attribute.String("version", version.Current.String()),
+			semconv.ServiceNameKey.String(constants.AppName),
+		)),
+		// Messages are traced from the router onwards, so the spans started
+		// while handling a message follow the sampling decision of the
+		// message.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.TraceSampleRate))),
+	)
+	return &tracer{
+		Tracer: tp.Tracer(constants.AppName),
+		tp:     tp,
+	}, nil
+}
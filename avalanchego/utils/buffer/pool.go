@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package buffer
+
+import (
+	"math/bits"
+	"sync"
+)
+
+// Pool is a pool of byte slices. The slices are split into tiers of power of
+// two capacities, so that a small slice is never served by a much larger one.
+// It's safe for multiple goroutines to use a Pool concurrently.
+type Pool struct {
+	minBits int
+	// tiers[i] contains *[]byte with a capacity of at least 1 << (minBits+i)
+	tiers []sync.Pool
+}
+
+// NewPool returns a pool of byte slices with capacities between [minSize] and
+// [maxSize], rounded up to powers of two.
+func NewPool(minSize, maxSize int) *Pool {
+	minBits := ceilLog2(minSize)
+	maxBits := ceilLog2(maxSize)
+	if maxBits < minBits {
+		maxBits = minBits
+	}
+	return &Pool{
+		minBits: minBits,
+		tiers:   make([]sync.Pool, maxBits-minBits+1),
+	}
+}
+
+// Get returns a byte slice of length [size]. Its contents are undefined.
+// Slices larger than the maximum size of the pool are always allocated.
+func (p *Pool) Get(size int) []byte {
+	tier := p.getTier(size)
+	if tier >= len(p.tiers) {
+		return make([]byte, size)
+	}
+	if b, ok := p.tiers[tier].Get().(*[]byte); ok {
+		return (*b)[:size]
+	}
+	return make([]byte, size, 1<<(p.minBits+tier))
+}
+
+// Put returns [b] to the pool, which now owns it. The caller must not use [b]
+// afterwards. Slices smaller than the minimum size or larger than the maximum
+// size of the pool are dropped.
+func (p *Pool) Put(b []byte) {
+	tier := p.putTier(cap(b))
+	if tier < 0 || tier >= len(p.tiers) {
+		return
+	}
+	b = b[:0]
+	p.tiers[tier].Put(&b)
+}
+
+// getTier returns the smallest tier whose slices can hold [size] bytes.
+func (p *Pool) getTier(size int) int {
+	tier := ceilLog2(size) - p.minBits
+	if tier < 0 {
+		return 0
+	}
+	return tier
+}
+
+// putTier returns the largest tier whose sizes can all be served by a slice of
+// capacity [capacity].
+func (p *Pool) putTier(capacity int) int {
+	return bits.Len(uint(capacity)) - 1 - p.minBits
+}
+
+// ceilLog2 returns the smallest n such that [size] <= 1 << n.
+func ceilLog2(size int) int {
+	if size <= 1 {
+		return 0
+	}
+	return bits.Len(uint(size - 1))
+}
@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package buffer
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolGet(t *testing.T) {
+	require := require.New(t)
+
+	p := NewPool(128, 1024)
+
+	// Sizes are rounded up to the smallest tier that can serve them
+	b := p.Get(0)
+	require.Len(b, 0)
+	require.Equal(128, cap(b))
+
+	b = p.Get(100)
+	require.Len(b, 100)
+	require.Equal(128, cap(b))
+
+	b = p.Get(129)
+	require.Len(b, 129)
+	require.Equal(256, cap(b))
+
+	b = p.Get(1024)
+	require.Len(b, 1024)
+	require.Equal(1024, cap(b))
+
+	// Sizes larger than the maximum size are allocated exactly
+	b = p.Get(1025)
+	require.Len(b, 1025)
+	require.Equal(1025, cap(b))
+}
+
+func TestPoolTiers(t *testing.T) {
+	require := require.New(t)
+
+	p := NewPool(100, 1000)
+	require.Len(p.tiers, 4) // 128, 256, 512 and 1024
+
+	// A size is served by the smallest tier that can hold it
+	require.Equal(0, p.getTier(0))
+	require.Equal(0, p.getTier(128))
+	require.Equal(1, p.getTier(129))
+	require.Equal(3, p.getTier(1024))
+	require.Equal(4, p.getTier(1025))
+
+	// A slice is put in the largest tier it can serve
+	require.Equal(-1, p.putTier(127))
+	require.Equal(0, p.putTier(128))
+	require.Equal(0, p.putTier(255))
+	require.Equal(1, p.putTier(256))
+	require.Equal(1, p.putTier(300))
+	require.Equal(4, p.putTier(2048))
+}
+
+func TestPoolPut(t *testing.T) {
+	require := require.New(t)
+
+	p := NewPool(128, 1024)
+
+	b := p.Get(200)
+	b[0] = 1
+	p.Put(b)
+
+	// Reuse isn't guaranteed by [sync.Pool], but the slice always has the
+	// capacity of its tier
+	b = p.Get(250)
+	require.Len(b, 250)
+	require.Equal(256, cap(b))
+
+	// Slices outside of the pool's sizes are dropped
+	p.Put(make([]byte, 127))
+	p.Put(make([]byte, 2048))
+}
+
+func BenchmarkPool(b *testing.B) {
+	p := NewPool(128, 2*1024*1024)
+	for _, size := range []int{128, 4 * 1024, 256 * 1024, 2 * 1024 * 1024} {
+		b.Run(fmt.Sprintf("make_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf := make([]byte, size)
+				buf[size-1] = 1
+			}
+		})
+		b.Run(fmt.Sprintf("pool_%d", size), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				buf := p.Get(size)
+				buf[size-1] = 1
+				p.Put(buf)
+			}
+		})
+	}
+}
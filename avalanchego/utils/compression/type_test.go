@@ -0,0 +1,31 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package compression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeString(t *testing.T) {
+	require := require.New(t)
+
+	for _, compressionType := range []Type{TypeNone, TypeGzip, TypeZstd} {
+		parsedType, err := TypeFromString(compressionType.String())
+		require.NoError(err)
+		require.Equal(compressionType, parsedType)
+	}
+
+	_, err := TypeFromString("unknown")
+	require.ErrorIs(err, errUnknownCompressionType)
+}
+
+func TestTypeMarshalJSON(t *testing.T) {
+	require := require.New(t)
+
+	b, err := TypeZstd.MarshalJSON()
+	require.NoError(err)
+	require.Equal(`"zstd"`, string(b))
+}
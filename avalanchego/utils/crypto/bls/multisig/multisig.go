@@ -0,0 +1,174 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+// Package multisig aggregates BLS signatures produced by members of a
+// validator set and verifies that an aggregate signature was produced by a
+// sufficient portion of the set's weight.
+package multisig
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+
+	safemath "github.com/ava-labs/avalanchego/utils/math"
+)
+
+var (
+	ErrNoSignatures       = errors.New("no signatures")
+	ErrDuplicateSigner    = errors.New("duplicate signer")
+	ErrUnknownSigner      = errors.New("signer isn't in the validator set")
+	ErrMissingPublicKey   = errors.New("signer has no public key")
+	ErrInvalidQuorum      = errors.New("invalid quorum")
+	ErrInsufficientWeight = errors.New("signature weight is insufficient")
+	ErrInvalidSignature   = errors.New("invalid aggregate signature")
+	errWeightOverflowed   = errors.New("validator weight overflowed")
+	errNoValidators       = errors.New("validator set is empty")
+)
+
+// Validator is a member of the set whose signatures are aggregated.
+type Validator struct {
+	// PublicKey may be nil if the validator didn't register a BLS key, in
+	// which case the validator can't sign.
+	PublicKey *bls.PublicKey
+	Weight    uint64
+}
+
+// SortValidators puts [vdrs] into the canonical order that signer indices
+// refer to. Validators are ordered by their public key. Validators without a
+// public key are placed last.
+func SortValidators(vdrs []Validator) {
+	sort.SliceStable(vdrs, func(i, j int) bool {
+		pkI, pkJ := vdrs[i].PublicKey, vdrs[j].PublicKey
+		switch {
+		case pkI == nil:
+			return false
+		case pkJ == nil:
+			return true
+		default:
+			return bytes.Compare(bls.PublicKeyToBytes(pkI), bls.PublicKeyToBytes(pkJ)) < 0
+		}
+	})
+}
+
+// IndexedSignature is a signature produced by the validator at [Index] in the
+// canonically ordered validator set.
+type IndexedSignature struct {
+	Index     int
+	Signature *bls.Signature
+}
+
+// Signature is an aggregate signature along with the validators that
+// contributed to it.
+type Signature struct {
+	Signers   Signers
+	Signature *bls.Signature
+}
+
+// Aggregate combines [sigs] into a single signature. Each validator may only
+// contribute one signature.
+// Invariant: all [sigs] have been validated.
+func Aggregate(sigs []IndexedSignature) (*Signature, error) {
+	if len(sigs) == 0 {
+		return nil, ErrNoSignatures
+	}
+
+	signers := NewSigners()
+	blsSigs := make([]*bls.Signature, len(sigs))
+	for i, sig := range sigs {
+		if sig.Index < 0 {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownSigner, sig.Index)
+		}
+		if signers.Contains(sig.Index) {
+			return nil, fmt.Errorf("%w: %d", ErrDuplicateSigner, sig.Index)
+		}
+		signers.Add(sig.Index)
+		blsSigs[i] = sig.Signature
+	}
+
+	aggSig, err := bls.AggregateSignatures(blsSigs)
+	if err != nil {
+		return nil, err
+	}
+	return &Signature{
+		Signers:   signers,
+		Signature: aggSig,
+	}, nil
+}
+
+// SignedWeight returns the total weight of the validators in [vdrs] that
+// contributed to [s], along with their aggregated public key.
+func (s *Signature) SignedWeight(vdrs []Validator) (uint64, *bls.PublicKey, error) {
+	if s.Signers.BitLen() > len(vdrs) {
+		return 0, nil, fmt.Errorf("%w: %d", ErrUnknownSigner, s.Signers.BitLen()-1)
+	}
+
+	var (
+		weight uint64
+		pks    = make([]*bls.PublicKey, 0, s.Signers.Len())
+		err    error
+	)
+	for i, vdr := range vdrs {
+		if !s.Signers.Contains(i) {
+			continue
+		}
+		if vdr.PublicKey == nil {
+			return 0, nil, fmt.Errorf("%w: %d", ErrMissingPublicKey, i)
+		}
+		weight, err = safemath.Add64(weight, vdr.Weight)
+		if err != nil {
+			return 0, nil, errWeightOverflowed
+		}
+		pks = append(pks, vdr.PublicKey)
+	}
+
+	aggPK, err := bls.AggregatePublicKeys(pks)
+	if err != nil {
+		return 0, nil, err
+	}
+	return weight, aggPK, nil
+}
+
+// Verify checks that [sig] is a valid signature of [msg] by validators in
+// [vdrs] holding at least [quorumNum]/[quorumDen] of the total weight of
+// [vdrs].
+func Verify(vdrs []Validator, sig *Signature, msg []byte, quorumNum, quorumDen uint64) error {
+	if quorumDen == 0 || quorumNum > quorumDen {
+		return fmt.Errorf("%w: %d/%d", ErrInvalidQuorum, quorumNum, quorumDen)
+	}
+	if len(vdrs) == 0 {
+		return errNoValidators
+	}
+
+	var (
+		totalWeight uint64
+		err         error
+	)
+	for _, vdr := range vdrs {
+		totalWeight, err = safemath.Add64(totalWeight, vdr.Weight)
+		if err != nil {
+			return errWeightOverflowed
+		}
+	}
+
+	signedWeight, aggPK, err := sig.SignedWeight(vdrs)
+	if err != nil {
+		return err
+	}
+
+	// signedWeight * quorumDen >= totalWeight * quorumNum
+	lhs := new(big.Int).Mul(new(big.Int).SetUint64(signedWeight), new(big.Int).SetUint64(quorumDen))
+	rhs := new(big.Int).Mul(new(big.Int).SetUint64(totalWeight), new(big.Int).SetUint64(quorumNum))
+	if lhs.Cmp(rhs) < 0 {
+		return fmt.Errorf("%w: signed %d of %d", ErrInsufficientWeight, signedWeight, totalWeight)
+	}
+
+	if !bls.Verify(aggPK, sig.Signature, msg) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func newValidators(t *testing.T, weights ...uint64) ([]*bls.SecretKey, []Validator) {
+	sks := make([]*bls.SecretKey, len(weights))
+	vdrs := make([]Validator, len(weights))
+	for i, weight := range weights {
+		sk, err := bls.NewSecretKey()
+		require.NoError(t, err)
+		sks[i] = sk
+		vdrs[i] = Validator{
+			PublicKey: bls.PublicFromSecretKey(sk),
+			Weight:    weight,
+		}
+	}
+	return sks, vdrs
+}
+
+func TestAggregateAndVerify(t *testing.T) {
+	type test struct {
+		name        string
+		signers     []int
+		quorumNum   uint64
+		quorumDen   uint64
+		modifyMsg   bool
+		expectedErr error
+	}
+
+	tests := []test{
+		{
+			name:      "sufficient weight",
+			signers:   []int{0, 2},
+			quorumNum: 2,
+			quorumDen: 3,
+		},
+		{
+			name:        "insufficient weight",
+			signers:     []int{1, 2},
+			quorumNum:   2,
+			quorumDen:   3,
+			expectedErr: ErrInsufficientWeight,
+		},
+		{
+			name:        "wrong message",
+			signers:     []int{0, 1, 2},
+			quorumNum:   2,
+			quorumDen:   3,
+			modifyMsg:   true,
+			expectedErr: ErrInvalidSignature,
+		},
+		{
+			name:        "invalid quorum",
+			signers:     []int{0},
+			quorumNum:   4,
+			quorumDen:   3,
+			expectedErr: ErrInvalidQuorum,
+		},
+		{
+			name:        "unknown signer",
+			signers:     []int{0, 3},
+			quorumNum:   2,
+			quorumDen:   3,
+			expectedErr: ErrUnknownSigner,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+
+			// Validator 3 signs but isn't part of the set being verified
+			// against.
+			sks, vdrs := newValidators(t, 60, 10, 30, 50)
+			vdrs = vdrs[:3]
+
+			msg := utils.RandomBytes(1234)
+			sigs := make([]IndexedSignature, len(tt.signers))
+			for i, index := range tt.signers {
+				sigs[i] = IndexedSignature{
+					Index:     index,
+					Signature: bls.Sign(sks[index], msg),
+				}
+			}
+
+			sig, err := Aggregate(sigs)
+			require.NoError(err)
+			for _, index := range tt.signers {
+				require.True(sig.Signers.Contains(index))
+			}
+
+			if tt.modifyMsg {
+				msg[0]++
+			}
+			err = Verify(vdrs, sig, msg, tt.quorumNum, tt.quorumDen)
+			require.ErrorIs(err, tt.expectedErr)
+		})
+	}
+}
+
+func TestAggregateErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, err := Aggregate(nil)
+	require.ErrorIs(err, ErrNoSignatures)
+
+	sks, _ := newValidators(t, 1)
+	sig := bls.Sign(sks[0], utils.RandomBytes(32))
+	_, err = Aggregate([]IndexedSignature{
+		{Index: 0, Signature: sig},
+		{Index: 0, Signature: sig},
+	})
+	require.ErrorIs(err, ErrDuplicateSigner)
+}
+
+func TestSignedWeightMissingPublicKey(t *testing.T) {
+	require := require.New(t)
+
+	sks, vdrs := newValidators(t, 1, 1)
+	vdrs[1].PublicKey = nil
+
+	msg := utils.RandomBytes(32)
+	sig, err := Aggregate([]IndexedSignature{
+		{Index: 0, Signature: bls.Sign(sks[0], msg)},
+		{Index: 1, Signature: bls.Sign(sks[1], msg)},
+	})
+	require.NoError(err)
+
+	_, _, err = sig.SignedWeight(vdrs)
+	require.ErrorIs(err, ErrMissingPublicKey)
+}
+
+func TestSortValidators(t *testing.T) {
+	require := require.New(t)
+
+	_, vdrs := newValidators(t, 1, 2, 3)
+	vdrs = append([]Validator{{Weight: 4}}, vdrs...)
+	SortValidators(vdrs)
+
+	require.Nil(vdrs[3].PublicKey)
+	for i := 1; i < 3; i++ {
+		prev := bls.PublicKeyToBytes(vdrs[i-1].PublicKey)
+		next := bls.PublicKeyToBytes(vdrs[i].PublicKey)
+		require.Negative(bytes.Compare(prev, next))
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"errors"
+	"math/big"
+)
+
+var ErrNonCanonicalSigners = errors.New("signers bitset isn't canonically encoded")
+
+// Signers is the bitset of the indices, into a canonically ordered validator
+// set, of the validators that contributed to a multi-signature. The zero value
+// isn't usable, use NewSigners or SignersFromBytes.
+type Signers struct {
+	bits *big.Int
+}
+
+// NewSigners returns a bitset containing [indices].
+func NewSigners(indices ...int) Signers {
+	s := Signers{bits: new(big.Int)}
+	for _, index := range indices {
+		s.Add(index)
+	}
+	return s
+}
+
+// SignersFromBytes parses the big-endian encoding returned by Bytes.
+func SignersFromBytes(b []byte) (Signers, error) {
+	// Leading zero bytes would allow the same set of signers to be encoded in
+	// multiple ways.
+	if len(b) > 0 && b[0] == 0 {
+		return Signers{}, ErrNonCanonicalSigners
+	}
+	return Signers{bits: new(big.Int).SetBytes(b)}, nil
+}
+
+// Add marks validator [index] as a signer.
+func (s Signers) Add(index int) {
+	s.bits.SetBit(s.bits, index, 1)
+}
+
+// Contains returns true if validator [index] is a signer.
+func (s Signers) Contains(index int) bool {
+	return s.bits.Bit(index) == 1
+}
+
+// Len returns the number of signers.
+func (s Signers) Len() int {
+	count := 0
+	for _, word := range s.bits.Bits() {
+		for ; word != 0; word &= word - 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// BitLen returns one more than the largest signer index, or 0 if there are no
+// signers.
+func (s Signers) BitLen() int {
+	return s.bits.BitLen()
+}
+
+// Bytes returns the canonical big-endian encoding of the bitset.
+func (s Signers) Bytes() []byte {
+	return s.bits.Bytes()
+}
@@ -0,0 +1,38 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigners(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSigners(0, 9)
+	require.True(s.Contains(0))
+	require.False(s.Contains(1))
+	require.True(s.Contains(9))
+	require.Equal(2, s.Len())
+	require.Equal(10, s.BitLen())
+
+	s.Add(1)
+	require.True(s.Contains(1))
+	require.Equal(3, s.Len())
+
+	parsed, err := SignersFromBytes(s.Bytes())
+	require.NoError(err)
+	require.Equal(s.Bytes(), parsed.Bytes())
+	require.Equal(3, parsed.Len())
+
+	empty, err := SignersFromBytes(nil)
+	require.NoError(err)
+	require.Zero(empty.Len())
+	require.Zero(empty.BitLen())
+
+	_, err = SignersFromBytes([]byte{0, 1})
+	require.ErrorIs(err, ErrNonCanonicalSigners)
+}
@@ -0,0 +1,45 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package bls
+
+var _ Signer = &localSigner{}
+
+// Signer signs messages with a secret key that may not be held in memory, such
+// as a key held by a remote signer.
+type Signer interface {
+	// PublicKey returns the public key of the secret key used to sign.
+	PublicKey() *PublicKey
+
+	// Sign [msg] to authorize this message.
+	Sign(msg []byte) (*Signature, error)
+
+	// SignProofOfPossession signs [msg] to prove the ownership of the secret
+	// key.
+	SignProofOfPossession(msg []byte) (*Signature, error)
+}
+
+type localSigner struct {
+	sk *SecretKey
+	pk *PublicKey
+}
+
+// NewSigner returns a Signer that signs with [sk].
+func NewSigner(sk *SecretKey) Signer {
+	return &localSigner{
+		sk: sk,
+		pk: PublicFromSecretKey(sk),
+	}
+}
+
+func (s *localSigner) PublicKey() *PublicKey {
+	return s.pk
+}
+
+func (s *localSigner) Sign(msg []byte) (*Signature, error) {
+	return Sign(s.sk, msg), nil
+}
+
+func (s *localSigner) SignProofOfPossession(msg []byte) (*Signature, error) {
+	return SignProofOfPossession(s.sk, msg), nil
+}
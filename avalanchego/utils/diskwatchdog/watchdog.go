@@ -0,0 +1,298 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package diskwatchdog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// PauseIndexing stops indexing newly accepted containers.
+	PauseIndexing Action = "pauseIndexing"
+	// RejectAPITxs stops accepting txs submitted through the APIs. Txs gossiped
+	// by peers are still accepted.
+	RejectAPITxs Action = "rejectAPITxs"
+	// Halt gracefully shuts down the node.
+	Halt Action = "halt"
+
+	// OverrideAuto takes the action while the available disk space is below
+	// the action's threshold.
+	OverrideAuto Override = "auto"
+	// OverrideForce takes the action regardless of the available disk space.
+	OverrideForce Override = "force"
+	// OverrideSuppress never takes the action.
+	OverrideSuppress Override = "suppress"
+)
+
+var (
+	// allActions are ordered by the available disk space at which they are taken,
+	// from most to least available.
+	allActions = []Action{PauseIndexing, RejectAPITxs, Halt}
+
+	errUnknownAction   = errors.New("unknown action")
+	errUnknownOverride = errors.New("unknown override")
+	errCantForceHalt   = errors.New("halting can't be forced")
+	errHalted          = errors.New("node is already halting")
+
+	_ health.Checker = &Watchdog{}
+)
+
+// Action is a measure taken by the watchdog to preserve disk space.
+type Action string
+
+// Override allows an operator to take or suppress an action regardless of
+// the available disk space.
+type Override string
+
+func (o Override) verify() error {
+	switch o {
+	case OverrideAuto, OverrideForce, OverrideSuppress:
+		return nil
+	default:
+		return fmt.Errorf("%w %q", errUnknownOverride, o)
+	}
+}
+
+// Config of the available disk space, in bytes, under which the watchdog
+// acts. A threshold of 0 disables the action.
+type Config struct {
+	// Available disk space under which the node stops indexing
+	IndexingPauseThreshold uint64 `json:"indexingPauseThreshold"`
+	// Available disk space under which the node rejects txs submitted through
+	// the APIs
+	APITxsRejectThreshold uint64 `json:"apiTxsRejectThreshold"`
+	// Available disk space under which the node shuts down
+	RequiredAvailableDiskSpace uint64 `json:"requiredAvailableDiskSpace"`
+	// Available disk space under which the node reports unhealthy
+	WarningThresholdAvailableDiskSpace uint64 `json:"warningThresholdAvailableDiskSpace"`
+}
+
+func (c *Config) threshold(action Action) uint64 {
+	switch action {
+	case PauseIndexing:
+		return c.IndexingPauseThreshold
+	case RejectAPITxs:
+		return c.APITxsRejectThreshold
+	default:
+		return c.RequiredAvailableDiskSpace
+	}
+}
+
+// Actions are called when the watchdog starts or stops taking an action.
+type Actions struct {
+	PauseIndexing func(paused bool) error
+	RejectAPITxs  func(reject bool)
+	Halt          func()
+}
+
+// ActionStatus describes the state of one of the watchdog's actions.
+type ActionStatus struct {
+	Action    Action   `json:"action"`
+	Threshold uint64   `json:"threshold"`
+	Override  Override `json:"override"`
+	Active    bool     `json:"active"`
+}
+
+// Status describes the state of the watchdog.
+type Status struct {
+	AvailableDiskBytes uint64         `json:"availableDiskBytes"`
+	Actions            []ActionStatus `json:"actions"`
+}
+
+// Watchdog progressively restricts what the node does as the available disk
+// space shrinks, so that the node halts before the database runs out of space.
+// The available disk space is checked each time the watchdog's health is
+// checked.
+type Watchdog struct {
+	log                logging.Logger
+	config             Config
+	availableDiskBytes func() uint64
+	actions            Actions
+
+	lock      sync.Mutex
+	overrides map[Action]Override
+	active    map[Action]bool
+}
+
+func New(
+	log logging.Logger,
+	config Config,
+	availableDiskBytes func() uint64,
+	actions Actions,
+) *Watchdog {
+	w := &Watchdog{
+		log:                log,
+		config:             config,
+		availableDiskBytes: availableDiskBytes,
+		actions:            actions,
+		overrides:          make(map[Action]Override, len(allActions)),
+		active:             make(map[Action]bool, len(allActions)),
+	}
+	for _, action := range allActions {
+		w.overrides[action] = OverrideAuto
+	}
+	return w
+}
+
+func (w *Watchdog) HealthCheck() (interface{}, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	availableDiskBytes := w.availableDiskBytes()
+	err := w.update(availableDiskBytes)
+
+	details := map[string]interface{}{
+		"availableDiskBytes": availableDiskBytes,
+	}
+	var activeActions []Action
+	for _, action := range allActions {
+		if w.active[action] {
+			activeActions = append(activeActions, action)
+		}
+	}
+	if len(activeActions) > 0 {
+		details["activeActions"] = activeActions
+	}
+	if err != nil {
+		return details, err
+	}
+
+	switch {
+	case w.belowThreshold(Halt, availableDiskBytes):
+		err = fmt.Errorf("remaining available disk space (%d) is below minimum required available space (%d)", availableDiskBytes, w.config.RequiredAvailableDiskSpace)
+	case w.belowThreshold(RejectAPITxs, availableDiskBytes):
+		err = fmt.Errorf("remaining available disk space (%d) is below the threshold to reject API txs (%d)", availableDiskBytes, w.config.APITxsRejectThreshold)
+	case w.belowThreshold(PauseIndexing, availableDiskBytes):
+		err = fmt.Errorf("remaining available disk space (%d) is below the threshold to pause indexing (%d)", availableDiskBytes, w.config.IndexingPauseThreshold)
+	case availableDiskBytes < w.config.WarningThresholdAvailableDiskSpace:
+		err = fmt.Errorf("remaining available disk space (%d) is below the warning threshold of disk space (%d)", availableDiskBytes, w.config.WarningThresholdAvailableDiskSpace)
+	}
+	return details, err
+}
+
+// Status returns the state of the watchdog.
+func (w *Watchdog) Status() Status {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return w.status(w.availableDiskBytes())
+}
+
+// SetOverride sets how the watchdog decides whether to take [action] and
+// immediately applies the decision.
+func (w *Watchdog) SetOverride(action Action, override Override) (Status, error) {
+	if err := override.verify(); err != nil {
+		return Status{}, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.overrides[action]; !ok {
+		return Status{}, fmt.Errorf("%w %q", errUnknownAction, action)
+	}
+	if action == Halt {
+		switch {
+		case override == OverrideForce:
+			return Status{}, errCantForceHalt
+		case w.active[Halt]:
+			return Status{}, errHalted
+		}
+	}
+
+	w.log.Info("overriding disk space watchdog action",
+		zap.String("action", string(action)),
+		zap.String("override", string(override)),
+	)
+	w.overrides[action] = override
+
+	availableDiskBytes := w.availableDiskBytes()
+	err := w.update(availableDiskBytes)
+	return w.status(availableDiskBytes), err
+}
+
+// update starts and stops the actions as dictated by [availableDiskBytes] and
+// the overrides.
+// Assumes [w.lock] is held.
+func (w *Watchdog) update(availableDiskBytes uint64) error {
+	for _, action := range allActions {
+		var shouldBeActive bool
+		switch w.overrides[action] {
+		case OverrideForce:
+			shouldBeActive = true
+		case OverrideAuto:
+			shouldBeActive = w.belowThreshold(action, availableDiskBytes)
+		}
+		if shouldBeActive == w.active[action] {
+			continue
+		}
+		if action == Halt && !shouldBeActive {
+			// The node can't be brought back once it started halting.
+			continue
+		}
+
+		switch {
+		case action == Halt:
+			// Halting is logged as fatal below
+		case shouldBeActive:
+			w.log.Warn("low on disk space. Taking action...",
+				zap.String("action", string(action)),
+				zap.Uint64("remainingDiskBytes", availableDiskBytes),
+			)
+		default:
+			w.log.Info("stopping disk space watchdog action",
+				zap.String("action", string(action)),
+				zap.Uint64("remainingDiskBytes", availableDiskBytes),
+			)
+		}
+
+		switch action {
+		case PauseIndexing:
+			if err := w.actions.PauseIndexing(shouldBeActive); err != nil {
+				return fmt.Errorf("couldn't change whether indexing is paused: %w", err)
+			}
+		case RejectAPITxs:
+			w.actions.RejectAPITxs(shouldBeActive)
+		case Halt:
+			w.log.Fatal("low on disk space. Shutting down...",
+				zap.Uint64("remainingDiskBytes", availableDiskBytes),
+			)
+			go w.actions.Halt()
+		}
+		w.active[action] = shouldBeActive
+	}
+	return nil
+}
+
+// belowThreshold returns true if [availableDiskBytes] is below the enabled
+// threshold of [action].
+// Assumes [w.lock] is held.
+func (w *Watchdog) belowThreshold(action Action, availableDiskBytes uint64) bool {
+	threshold := w.config.threshold(action)
+	return threshold > 0 && availableDiskBytes < threshold
+}
+
+// Assumes [w.lock] is held.
+func (w *Watchdog) status(availableDiskBytes uint64) Status {
+	status := Status{
+		AvailableDiskBytes: availableDiskBytes,
+		Actions:            make([]ActionStatus, len(allActions)),
+	}
+	for i, action := range allActions {
+		status.Actions[i] = ActionStatus{
+			Action:    action,
+			Threshold: w.config.threshold(action),
+			Override:  w.overrides[action],
+			Active:    w.active[action],
+		}
+	}
+	return status
+}
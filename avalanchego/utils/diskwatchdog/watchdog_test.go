@@ -0,0 +1,146 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package diskwatchdog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+type testActions struct {
+	indexingPaused bool
+	rejectingTxs   bool
+	halted         chan struct{}
+}
+
+func newTestWatchdog(availableDiskBytes *uint64) (*Watchdog, *testActions) {
+	a := &testActions{
+		halted: make(chan struct{}, 1),
+	}
+	w := New(
+		logging.NoLog{},
+		Config{
+			IndexingPauseThreshold:             300,
+			APITxsRejectThreshold:              200,
+			RequiredAvailableDiskSpace:         100,
+			WarningThresholdAvailableDiskSpace: 400,
+		},
+		func() uint64 { return *availableDiskBytes },
+		Actions{
+			PauseIndexing: func(paused bool) error {
+				a.indexingPaused = paused
+				return nil
+			},
+			RejectAPITxs: func(reject bool) {
+				a.rejectingTxs = reject
+			},
+			Halt: func() {
+				a.halted <- struct{}{}
+			},
+		},
+	)
+	return w, a
+}
+
+func TestWatchdogThresholds(t *testing.T) {
+	require := require.New(t)
+
+	availableDiskBytes := uint64(500)
+	w, a := newTestWatchdog(&availableDiskBytes)
+
+	_, err := w.HealthCheck()
+	require.NoError(err)
+	require.False(a.indexingPaused)
+	require.False(a.rejectingTxs)
+
+	availableDiskBytes = 350
+	_, err = w.HealthCheck()
+	require.Error(err)
+	require.False(a.indexingPaused)
+
+	availableDiskBytes = 250
+	_, err = w.HealthCheck()
+	require.Error(err)
+	require.True(a.indexingPaused)
+	require.False(a.rejectingTxs)
+
+	availableDiskBytes = 150
+	details, err := w.HealthCheck()
+	require.Error(err)
+	require.True(a.indexingPaused)
+	require.True(a.rejectingTxs)
+	require.Equal([]Action{PauseIndexing, RejectAPITxs}, details.(map[string]interface{})["activeActions"])
+
+	// Actions are stopped once space is freed up.
+	availableDiskBytes = 500
+	_, err = w.HealthCheck()
+	require.NoError(err)
+	require.False(a.indexingPaused)
+	require.False(a.rejectingTxs)
+
+	availableDiskBytes = 50
+	_, err = w.HealthCheck()
+	require.Error(err)
+	<-a.halted
+
+	// The node keeps halting once space is freed up.
+	availableDiskBytes = 500
+	_, err = w.HealthCheck()
+	require.NoError(err)
+	require.True(w.Status().Actions[2].Active)
+
+	_, err = w.SetOverride(Halt, OverrideSuppress)
+	require.ErrorIs(err, errHalted)
+}
+
+func TestWatchdogOverrides(t *testing.T) {
+	require := require.New(t)
+
+	availableDiskBytes := uint64(500)
+	w, a := newTestWatchdog(&availableDiskBytes)
+
+	status, err := w.SetOverride(RejectAPITxs, OverrideForce)
+	require.NoError(err)
+	require.True(a.rejectingTxs)
+	require.Equal(ActionStatus{
+		Action:    RejectAPITxs,
+		Threshold: 200,
+		Override:  OverrideForce,
+		Active:    true,
+	}, status.Actions[1])
+
+	// Forced actions don't make the node unhealthy.
+	_, err = w.HealthCheck()
+	require.NoError(err)
+
+	_, err = w.SetOverride(RejectAPITxs, OverrideAuto)
+	require.NoError(err)
+	require.False(a.rejectingTxs)
+
+	_, err = w.SetOverride(PauseIndexing, OverrideSuppress)
+	require.NoError(err)
+	_, err = w.SetOverride(Halt, OverrideSuppress)
+	require.NoError(err)
+
+	availableDiskBytes = 50
+	_, err = w.HealthCheck()
+	require.Error(err)
+	require.False(a.indexingPaused)
+	require.True(a.rejectingTxs)
+	select {
+	case <-a.halted:
+		require.FailNow("suppressed halt was taken")
+	default:
+	}
+
+	_, err = w.SetOverride(Halt, OverrideForce)
+	require.ErrorIs(err, errCantForceHalt)
+	_, err = w.SetOverride("compact", OverrideForce)
+	require.ErrorIs(err, errUnknownAction)
+	_, err = w.SetOverride(PauseIndexing, "sometimes")
+	require.ErrorIs(err, errUnknownOverride)
+}
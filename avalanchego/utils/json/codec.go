@@ -31,6 +31,13 @@ func NewCodec() rpc.Codec {
 	return lowercase{json2.NewCodec()}
 }
 
+// NewCodecWithErrorMapper returns a new json codec that will convert the first
+// character of the method to uppercase and that will report the errors
+// returned by the methods as the result of [mapper].
+func NewCodecWithErrorMapper(mapper func(error) error) rpc.Codec {
+	return lowercase{json2.NewCustomCodecWithErrorMapper(rpc.DefaultEncoderSelector, mapper)}
+}
+
 type lowercase struct{ *json2.Codec }
 
 func (lc lowercase) NewRequest(r *http.Request) rpc.CodecRequest {
@@ -3,12 +3,27 @@
 
 package logging
 
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+)
+
 type RotatingWriterConfig struct {
 	MaxSize   int    `json:"maxSize"` // in megabytes
 	MaxFiles  int    `json:"maxFiles"`
 	MaxAge    int    `json:"maxAge"` // in days
 	Directory string `json:"directory"`
 	Compress  bool   `json:"compress"`
+	// Compression of the rotated files if [Compress] is set. zstd is applied
+	// by the factory's janitor; anything else means gzip.
+	CompressionType compression.Type `json:"compressionType"`
+	// Rotate a file once it has been written to for this long, even if it is
+	// smaller than [MaxSize]. 0 means files are only rotated by size.
+	RotationInterval time.Duration `json:"rotationInterval"`
+	// Size budget of the whole log directory, in megabytes. The oldest
+	// rotated files are removed to stay under it. 0 means no budget.
+	DirectoryMaxSize int `json:"directoryMaxSize"`
 }
 
 // Config defines the configuration of a logger
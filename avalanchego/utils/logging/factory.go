@@ -8,11 +8,14 @@ import (
 	"os"
 	"path"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 
 	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
 )
 
 var _ Factory = &factory{}
@@ -57,15 +60,23 @@ type factory struct {
 	// For each logger created by this factory:
 	// Logger name --> the logger.
 	loggers map[string]logWrapper
+
+	// nil if the config doesn't need one
+	janitor *janitor
 }
 
 // NewFactory returns a new instance of a Factory producing loggers configured with
 // the values set in the [config] parameter
 func NewFactory(config Config) Factory {
-	return &factory{
+	f := &factory{
 		config:  config,
 		loggers: make(map[string]logWrapper),
+		janitor: newJanitor(config.RotatingWriterConfig),
+	}
+	if f.janitor != nil {
+		f.janitor.start()
 	}
+	return f
 }
 
 // Assumes [f.lock] is held
@@ -84,7 +95,10 @@ func (f *factory) makeLogger(config Config) (Logger, error) {
 		MaxSize:    config.MaxSize,  // megabytes
 		MaxAge:     config.MaxAge,   // days
 		MaxBackups: config.MaxFiles, // files
-		Compress:   config.Compress,
+		Compress:   config.Compress && config.CompressionType != compression.TypeZstd,
+	}
+	if f.janitor != nil {
+		f.janitor.add(config.LoggerName, rw, time.Now())
 	}
 	fileCore := NewWrappedCore(config.LogLevel, rw, fileEnc)
 	prefix := config.LogFormat.WrapPrefix(config.MsgPrefix)
@@ -178,6 +192,10 @@ func (f *factory) Close() {
 	f.lock.Lock()
 	defer f.lock.Unlock()
 
+	if f.janitor != nil {
+		f.janitor.stop()
+		f.janitor = nil
+	}
 	for _, lw := range f.loggers {
 		lw.logger.Stop()
 	}
@@ -0,0 +1,326 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+const (
+	// janitorFrequency is how often the janitor checks the log directory
+	janitorFrequency = time.Minute
+
+	logExt  = ".log"
+	gzipExt = ".gz"
+	zstdExt = ".zst"
+
+	// backupTimeFormat is the format lumberjack uses for the timestamp in the
+	// name of rotated files
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// backup is a rotated log file
+type backup struct {
+	path      string
+	size      int64
+	timestamp time.Time
+}
+
+// janitor does the work on the log directory that lumberjack can't do itself:
+// rotating files by age, compressing rotated files with zstd and keeping the
+// whole directory under a size budget.
+type janitor struct {
+	config RotatingWriterConfig
+
+	lock sync.Mutex
+	// logger name --> the writer of the logger's file
+	writers map[string]*lumberjack.Logger
+	// logger name --> last time the janitor rotated the logger's file
+	rotatedAt map[string]time.Time
+
+	closer chan struct{}
+	wg     sync.WaitGroup
+}
+
+// newJanitor returns a janitor for [config], or nil if the config doesn't
+// need one.
+func newJanitor(config RotatingWriterConfig) *janitor {
+	zstdEnabled := config.Compress && config.CompressionType == compression.TypeZstd
+	if config.RotationInterval <= 0 && !zstdEnabled && config.DirectoryMaxSize <= 0 {
+		return nil
+	}
+	return &janitor{
+		config:    config,
+		writers:   make(map[string]*lumberjack.Logger),
+		rotatedAt: make(map[string]time.Time),
+		closer:    make(chan struct{}),
+	}
+}
+
+func (j *janitor) add(name string, writer *lumberjack.Logger, now time.Time) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	j.writers[name] = writer
+	j.rotatedAt[name] = now
+}
+
+func (j *janitor) start() {
+	j.wg.Add(1)
+	go func() {
+		defer j.wg.Done()
+
+		ticker := time.NewTicker(janitorFrequency)
+		defer ticker.Stop()
+
+		for {
+			// The janitor has no logger of its own to report errors to. A
+			// failed run is retried on the next tick.
+			_ = j.run(time.Now())
+
+			select {
+			case <-ticker.C:
+			case <-j.closer:
+				return
+			}
+		}
+	}()
+}
+
+func (j *janitor) stop() {
+	close(j.closer)
+	j.wg.Wait()
+}
+
+// run does one pass over the log directory.
+func (j *janitor) run(now time.Time) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	errs := wrappers.Errs{}
+	if j.config.RotationInterval > 0 {
+		errs.Add(j.rotate(now))
+	}
+	if j.config.Compress && j.config.CompressionType == compression.TypeZstd {
+		errs.Add(j.compress(now))
+	}
+	if j.config.DirectoryMaxSize > 0 {
+		errs.Add(j.enforceBudget())
+	}
+	return errs.Err
+}
+
+// rotate rotates the files that have been written to for longer than the
+// rotation interval. Assumes [j.lock] is held.
+func (j *janitor) rotate(now time.Time) error {
+	errs := wrappers.Errs{}
+	for name, writer := range j.writers {
+		if now.Sub(j.rotatedAt[name]) < j.config.RotationInterval {
+			continue
+		}
+		// Don't fill the directory with empty files for loggers that are
+		// rarely written to.
+		info, err := os.Stat(writer.Filename)
+		if err != nil || info.Size() == 0 {
+			continue
+		}
+		if err := writer.Rotate(); err != nil {
+			errs.Add(fmt.Errorf("couldn't rotate %s: %w", writer.Filename, err))
+			continue
+		}
+		j.rotatedAt[name] = now
+	}
+	return errs.Err
+}
+
+// compress compresses the rotated files with zstd and removes the ones that
+// are too old or too many. lumberjack only knows about gzip, so it doesn't
+// see the zstd files when enforcing MaxFiles and MaxAge. Assumes [j.lock] is
+// held.
+func (j *janitor) compress(now time.Time) error {
+	errs := wrappers.Errs{}
+	for name := range j.writers {
+		backups, err := j.backups(name)
+		if err != nil {
+			errs.Add(err)
+			continue
+		}
+
+		for i, b := range backups {
+			if !strings.HasSuffix(b.path, logExt) {
+				continue
+			}
+			if err := compressFile(b.path, b.path+zstdExt); err != nil {
+				errs.Add(err)
+				continue
+			}
+			backups[i].path += zstdExt
+		}
+
+		// [backups] is sorted from newest to oldest.
+		for i, b := range backups {
+			tooMany := j.config.MaxFiles > 0 && i >= j.config.MaxFiles
+			tooOld := j.config.MaxAge > 0 && now.Sub(b.timestamp) > time.Duration(j.config.MaxAge)*24*time.Hour
+			if !tooMany && !tooOld {
+				continue
+			}
+			if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+				errs.Add(err)
+			}
+		}
+	}
+	return errs.Err
+}
+
+// backups returns the rotated files of logger [name], newest first. Assumes
+// [j.lock] is held.
+func (j *janitor) backups(name string) ([]backup, error) {
+	entries, err := os.ReadDir(j.config.Directory)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := name + "-"
+	backups := []backup(nil)
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(fileName, prefix) {
+			continue
+		}
+		timestamp := strings.TrimPrefix(fileName, prefix)
+		timestamp = strings.TrimSuffix(timestamp, zstdExt)
+		timestamp = strings.TrimSuffix(timestamp, gzipExt)
+		if !strings.HasSuffix(timestamp, logExt) {
+			continue
+		}
+		t, err := time.Parse(backupTimeFormat, strings.TrimSuffix(timestamp, logExt))
+		if err != nil {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		backups = append(backups, backup{
+			path:      filepath.Join(j.config.Directory, fileName),
+			size:      info.Size(),
+			timestamp: t,
+		})
+	}
+	sort.Slice(backups, func(i, k int) bool {
+		return backups[i].timestamp.After(backups[k].timestamp)
+	})
+	return backups, nil
+}
+
+// enforceBudget removes the oldest log files until the log directory fits in
+// its size budget. The files that are being written to are never removed.
+// Assumes [j.lock] is held.
+func (j *janitor) enforceBudget() error {
+	entries, err := os.ReadDir(j.config.Directory)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string]struct{}, len(j.writers))
+	for _, writer := range j.writers {
+		active[filepath.Base(writer.Filename)] = struct{}{}
+	}
+
+	var (
+		size      int64
+		removable []backup
+	)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		size += info.Size()
+
+		fileName := entry.Name()
+		if _, ok := active[fileName]; ok {
+			continue
+		}
+		if !strings.HasSuffix(fileName, logExt) &&
+			!strings.HasSuffix(fileName, logExt+gzipExt) &&
+			!strings.HasSuffix(fileName, logExt+zstdExt) {
+			continue
+		}
+		removable = append(removable, backup{
+			path:      filepath.Join(j.config.Directory, fileName),
+			size:      info.Size(),
+			timestamp: info.ModTime(),
+		})
+	}
+
+	sort.Slice(removable, func(i, k int) bool {
+		return removable[i].timestamp.Before(removable[k].timestamp)
+	})
+
+	budget := int64(j.config.DirectoryMaxSize) * units.MiB
+	errs := wrappers.Errs{}
+	for _, b := range removable {
+		if size <= budget {
+			break
+		}
+		if err := os.Remove(b.path); err != nil && !os.IsNotExist(err) {
+			errs.Add(err)
+			continue
+		}
+		size -= b.size
+	}
+	return errs.Err
+}
+
+// compressFile compresses [src] into [dst] with zstd and removes [src].
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return err
+	}
+
+	errs := wrappers.Errs{}
+	encoder, err := zstd.NewWriter(out)
+	if err == nil {
+		_, err = io.Copy(encoder, in)
+		errs.Add(err, encoder.Close())
+	} else {
+		errs.Add(err)
+	}
+	errs.Add(out.Close())
+	if errs.Errored() {
+		_ = os.Remove(dst)
+		return fmt.Errorf("couldn't compress %s: %w", src, errs.Err)
+	}
+	return os.Remove(src)
+}
@@ -0,0 +1,160 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/stretchr/testify/require"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/ava-labs/avalanchego/utils/compression"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func writeBackup(t *testing.T, dir string, name string, timestamp time.Time, size int) string {
+	path := filepath.Join(dir, name+"-"+timestamp.UTC().Format(backupTimeFormat)+logExt)
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0o600))
+	require.NoError(t, os.Chtimes(path, timestamp, timestamp))
+	return path
+}
+
+func TestJanitorDisabled(t *testing.T) {
+	require.Nil(t, newJanitor(RotatingWriterConfig{Compress: true}))
+	require.Nil(t, newJanitor(RotatingWriterConfig{
+		Compress:        true,
+		CompressionType: compression.TypeGzip,
+	}))
+}
+
+func TestJanitorRotate(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	j := newJanitor(RotatingWriterConfig{
+		Directory:        dir,
+		RotationInterval: time.Hour,
+	})
+	require.NotNil(j)
+
+	now := time.Now()
+	writer := &lumberjack.Logger{Filename: filepath.Join(dir, "C.log")}
+	defer writer.Close()
+	j.add("C", writer, now)
+	idle := &lumberjack.Logger{Filename: filepath.Join(dir, "X.log")}
+	defer idle.Close()
+	j.add("X", idle, now)
+
+	_, err := writer.Write([]byte("log line\n"))
+	require.NoError(err)
+
+	// The file isn't old enough to be rotated yet.
+	require.NoError(j.run(now.Add(time.Hour - 1)))
+	backups, err := j.backups("C")
+	require.NoError(err)
+	require.Empty(backups)
+
+	require.NoError(j.run(now.Add(time.Hour)))
+	backups, err = j.backups("C")
+	require.NoError(err)
+	require.Len(backups, 1)
+
+	// Files that were never written to aren't rotated.
+	backups, err = j.backups("X")
+	require.NoError(err)
+	require.Empty(backups)
+}
+
+func TestJanitorCompress(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	j := newJanitor(RotatingWriterConfig{
+		Directory:       dir,
+		MaxFiles:        2,
+		MaxAge:          1,
+		Compress:        true,
+		CompressionType: compression.TypeZstd,
+	})
+	require.NotNil(j)
+	j.add("C", &lumberjack.Logger{Filename: filepath.Join(dir, "C.log")}, time.Now())
+
+	now := time.Now()
+	newest := writeBackup(t, dir, "C", now.Add(-time.Minute), 100)
+	second := writeBackup(t, dir, "C", now.Add(-2*time.Minute), 100)
+	third := writeBackup(t, dir, "C", now.Add(-3*time.Minute), 100)
+	// Backups of other loggers are left alone.
+	other := writeBackup(t, dir, "P", now.Add(-48*time.Hour), 100)
+
+	require.NoError(j.run(now))
+
+	for _, path := range []string{newest, second, third} {
+		require.NoFileExists(path)
+	}
+	require.FileExists(other)
+
+	// Only the [MaxFiles] newest backups are kept.
+	require.FileExists(newest + zstdExt)
+	require.FileExists(second + zstdExt)
+	require.NoFileExists(third + zstdExt)
+
+	compressed, err := os.ReadFile(newest + zstdExt)
+	require.NoError(err)
+	decoder, err := zstd.NewReader(nil)
+	require.NoError(err)
+	defer decoder.Close()
+	decompressed, err := decoder.DecodeAll(compressed, nil)
+	require.NoError(err)
+	require.Equal(make([]byte, 100), decompressed)
+
+	// Backups older than [MaxAge] are removed.
+	require.NoError(j.run(now.Add(25 * time.Hour)))
+	require.NoFileExists(newest + zstdExt)
+	require.NoFileExists(second + zstdExt)
+}
+
+func TestJanitorDirectoryBudget(t *testing.T) {
+	require := require.New(t)
+
+	dir := t.TempDir()
+	j := newJanitor(RotatingWriterConfig{
+		Directory:        dir,
+		DirectoryMaxSize: 2,
+	})
+	require.NotNil(j)
+
+	active := filepath.Join(dir, "C.log")
+	require.NoError(os.WriteFile(active, make([]byte, units.MiB), 0o600))
+	j.add("C", &lumberjack.Logger{Filename: active}, time.Now())
+
+	now := time.Now()
+	oldest := writeBackup(t, dir, "C", now.Add(-3*time.Hour), units.MiB/2)
+	// Logs of chains that are no longer run count against the budget too.
+	stale := writeBackup(t, dir, "old-chain", now.Add(-2*time.Hour), units.MiB/2)
+	newest := writeBackup(t, dir, "C", now.Add(-time.Hour), units.MiB/2)
+	notALog := filepath.Join(dir, "notes.txt")
+	require.NoError(os.WriteFile(notALog, make([]byte, units.MiB), 0o600))
+	require.NoError(os.Chtimes(notALog, now.Add(-4*time.Hour), now.Add(-4*time.Hour)))
+
+	require.NoError(j.run(now))
+
+	// The oldest log files are removed until the directory fits in 2 MiB.
+	// Files that aren't logs and the active log file are never removed.
+	require.NoFileExists(oldest)
+	require.NoFileExists(stale)
+	require.NoFileExists(newest)
+	require.FileExists(notALog)
+	require.FileExists(active)
+
+	require.NoError(os.Remove(notALog))
+	newest = writeBackup(t, dir, "C", now, units.MiB/2)
+	require.NoError(j.run(now))
+	require.FileExists(newest)
+}
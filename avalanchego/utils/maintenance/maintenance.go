@@ -0,0 +1,234 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package maintenance
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+const (
+	// Active is the state of a node that isn't in maintenance.
+	Active State = "active"
+	// Draining is the state of a node that stopped starting consensus polls
+	// and waits for its outstanding requests to finish.
+	Draining State = "draining"
+	// Drained is the state of a node whose outstanding requests finished, or
+	// timed out, and whose databases were flushed. The node can be stopped.
+	Drained State = "drained"
+
+	// drainCheckFrequency is how often the node checks whether its
+	// outstanding requests finished
+	drainCheckFrequency = 100 * time.Millisecond
+)
+
+var (
+	errDraining = errors.New("node is draining for maintenance")
+	errDrained  = errors.New("node is in maintenance")
+
+	_ health.Checker = &Mode{}
+)
+
+// State of the node's maintenance mode.
+type State string
+
+// Actions are called when the node enters and leaves maintenance.
+type Actions struct {
+	// PauseQueries stops or resumes the consensus polls of the node's chains
+	PauseQueries func(paused bool)
+	// InFlightAPICalls returns the number of API calls being served
+	InFlightAPICalls func() int
+	// FlushDatabases writes the writes buffered by the databases to disk
+	FlushDatabases func() error
+}
+
+// Status describes the node's maintenance mode.
+type Status struct {
+	State State `json:"state"`
+	// Time the node entered maintenance. Zero if the node is active.
+	EnteredAt        time.Time `json:"enteredAt"`
+	InFlightAPICalls int       `json:"inFlightAPICalls"`
+	// True if the drain timeout expired before the outstanding requests
+	// finished
+	DrainTimedOut bool `json:"drainTimedOut"`
+	// Error returned when the databases were flushed, if any
+	FlushError string `json:"flushError,omitempty"`
+}
+
+// Mode takes the node out of consensus so that it can be stopped without
+// leaving its peers' queries or the API calls being served unanswered.
+//
+// While draining, the node answers its peers' queries but doesn't start new
+// polls. Once the polls it already started and the API calls being served
+// finished, or the drain timeout expired, the databases are flushed and the
+// node is drained.
+type Mode struct {
+	log     logging.Logger
+	actions Actions
+	// Time after which the polls started before the node entered maintenance
+	// have finished, either with a response or a timeout
+	queryDrainTime time.Duration
+
+	lock          sync.Mutex
+	state         State
+	enteredAt     time.Time
+	drainTimedOut bool
+	flushErr      error
+	// Closed when the node leaves maintenance
+	closer chan struct{}
+}
+
+func New(log logging.Logger, queryDrainTime time.Duration, actions Actions) *Mode {
+	return &Mode{
+		log:            log,
+		actions:        actions,
+		queryDrainTime: queryDrainTime,
+		state:          Active,
+	}
+}
+
+// Enter puts the node in maintenance. The node is drained in the background;
+// [drainTimeout] bounds how long it waits for the outstanding requests.
+// Entering maintenance while in maintenance does nothing.
+func (m *Mode) Enter(drainTimeout time.Duration) Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.state != Active {
+		return m.status()
+	}
+
+	m.log.Info("entering maintenance",
+		zap.Duration("drainTimeout", drainTimeout),
+	)
+	m.state = Draining
+	m.enteredAt = time.Now()
+	m.drainTimedOut = false
+	m.flushErr = nil
+	m.closer = make(chan struct{})
+	m.actions.PauseQueries(true)
+
+	go m.drain(m.closer, m.enteredAt, drainTimeout)
+	return m.status()
+}
+
+// Exit takes the node out of maintenance. Exiting maintenance while active
+// does nothing.
+func (m *Mode) Exit() Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.state == Active {
+		return m.status()
+	}
+
+	m.log.Info("exiting maintenance",
+		zap.String("state", string(m.state)),
+	)
+	close(m.closer)
+	m.state = Active
+	m.enteredAt = time.Time{}
+	m.drainTimedOut = false
+	m.flushErr = nil
+	m.actions.PauseQueries(false)
+	return m.status()
+}
+
+// Status returns the state of the node's maintenance mode.
+func (m *Mode) Status() Status {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.status()
+}
+
+func (m *Mode) HealthCheck() (interface{}, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	status := m.status()
+	switch status.State {
+	case Draining:
+		return status, errDraining
+	case Drained:
+		return status, errDrained
+	default:
+		return status, nil
+	}
+}
+
+// drain waits for the outstanding requests to finish and then flushes the
+// databases, unless [closer] is closed first.
+func (m *Mode) drain(closer chan struct{}, enteredAt time.Time, drainTimeout time.Duration) {
+	ticker := time.NewTicker(drainCheckFrequency)
+	defer ticker.Stop()
+
+	timedOut := false
+	for {
+		select {
+		case <-ticker.C:
+		case <-closer:
+			return
+		}
+
+		elapsed := time.Since(enteredAt)
+		if elapsed >= m.queryDrainTime && m.actions.InFlightAPICalls() == 0 {
+			break
+		}
+		if elapsed >= drainTimeout {
+			timedOut = true
+			break
+		}
+	}
+
+	err := m.actions.FlushDatabases()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	select {
+	case <-closer:
+		// The node left maintenance while the databases were flushed.
+		return
+	default:
+	}
+
+	if timedOut {
+		m.log.Warn("drain timeout expired before the outstanding requests finished",
+			zap.Duration("drainTimeout", drainTimeout),
+			zap.Int("inFlightAPICalls", m.actions.InFlightAPICalls()),
+		)
+	}
+	if err != nil {
+		m.log.Error("failed to flush the databases",
+			zap.Error(err),
+		)
+	}
+	m.log.Info("node is drained and can be stopped")
+	m.state = Drained
+	m.drainTimedOut = timedOut
+	m.flushErr = err
+}
+
+// Assumes [m.lock] is held.
+func (m *Mode) status() Status {
+	status := Status{
+		State:         m.state,
+		EnteredAt:     m.enteredAt,
+		DrainTimedOut: m.drainTimedOut,
+	}
+	if m.state != Active {
+		status.InFlightAPICalls = m.actions.InFlightAPICalls()
+	}
+	if m.flushErr != nil {
+		status.FlushError = m.flushErr.Error()
+	}
+	return status
+}
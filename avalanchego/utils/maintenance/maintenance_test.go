@@ -0,0 +1,133 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package maintenance
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+)
+
+var errFlush = errors.New("flush failed")
+
+type testActions struct {
+	lock             sync.Mutex
+	queriesPaused    bool
+	inFlightAPICalls int
+	flushes          int
+	flushErr         error
+}
+
+func newTestMode(queryDrainTime time.Duration) (*Mode, *testActions) {
+	a := &testActions{}
+	m := New(
+		logging.NoLog{},
+		queryDrainTime,
+		Actions{
+			PauseQueries: func(paused bool) {
+				a.lock.Lock()
+				defer a.lock.Unlock()
+				a.queriesPaused = paused
+			},
+			InFlightAPICalls: func() int {
+				a.lock.Lock()
+				defer a.lock.Unlock()
+				return a.inFlightAPICalls
+			},
+			FlushDatabases: func() error {
+				a.lock.Lock()
+				defer a.lock.Unlock()
+				a.flushes++
+				return a.flushErr
+			},
+		},
+	)
+	return m, a
+}
+
+func (a *testActions) setInFlightAPICalls(n int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.inFlightAPICalls = n
+}
+
+func waitForState(t *testing.T, m *Mode, state State) {
+	require.Eventually(t, func() bool {
+		return m.Status().State == state
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestMaintenanceDrain(t *testing.T) {
+	require := require.New(t)
+
+	m, a := newTestMode(0)
+	_, err := m.HealthCheck()
+	require.NoError(err)
+
+	a.setInFlightAPICalls(1)
+	status := m.Enter(time.Hour)
+	require.Equal(Draining, status.State)
+	require.Equal(1, status.InFlightAPICalls)
+	require.True(a.queriesPaused)
+	_, err = m.HealthCheck()
+	require.ErrorIs(err, errDraining)
+
+	// The node keeps draining while API calls are being served.
+	time.Sleep(3 * drainCheckFrequency)
+	require.Equal(Draining, m.Status().State)
+
+	a.setInFlightAPICalls(0)
+	waitForState(t, m, Drained)
+	status = m.Status()
+	require.False(status.DrainTimedOut)
+	require.Empty(status.FlushError)
+	require.Equal(1, a.flushes)
+	_, err = m.HealthCheck()
+	require.ErrorIs(err, errDrained)
+
+	// Entering maintenance again does nothing.
+	require.Equal(Drained, m.Enter(time.Hour).State)
+
+	status = m.Exit()
+	require.Equal(Active, status.State)
+	require.Zero(status.EnteredAt)
+	require.False(a.queriesPaused)
+	_, err = m.HealthCheck()
+	require.NoError(err)
+}
+
+func TestMaintenanceDrainTimeout(t *testing.T) {
+	require := require.New(t)
+
+	m, a := newTestMode(0)
+	a.setInFlightAPICalls(1)
+	a.flushErr = errFlush
+
+	m.Enter(drainCheckFrequency)
+	waitForState(t, m, Drained)
+
+	status := m.Status()
+	require.True(status.DrainTimedOut)
+	require.Equal(errFlush.Error(), status.FlushError)
+	require.Equal(1, status.InFlightAPICalls)
+}
+
+func TestMaintenanceExitWhileDraining(t *testing.T) {
+	require := require.New(t)
+
+	// The polls started before entering maintenance take an hour to finish.
+	m, a := newTestMode(time.Hour)
+	m.Enter(time.Hour)
+	require.Equal(Active, m.Exit().State)
+	require.False(a.queriesPaused)
+
+	time.Sleep(3 * drainCheckFrequency)
+	require.Equal(Active, m.Status().State)
+	require.Zero(a.flushes)
+}
@@ -0,0 +1,53 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notifier
+
+import (
+	"time"
+)
+
+const (
+	// Unhealthy is sent when the node's health checks start failing after the
+	// node was healthy.
+	Unhealthy Event = "unhealthy"
+	// Benched is sent when a large share of the stake of the connected
+	// validators stopped querying the node.
+	Benched Event = "benched"
+	// FellBehind is sent when a chain's last accepted block is too far below
+	// the blocks sent by its peers.
+	FellBehind Event = "fellBehind"
+	// ChainStopped is sent when a chain stops while the node keeps running.
+	ChainStopped Event = "chainStopped"
+	// UpgradeActivated is sent when a network upgrade activates while the node
+	// is running.
+	UpgradeActivated Event = "upgradeActivated"
+)
+
+// Event is a significant change in the state of the node.
+type Event string
+
+// Config of the notifier. The notifier is disabled if neither [WebhookURL] nor
+// [SocketPath] is set.
+type Config struct {
+	// URL the notifications are POSTed to. Not marshalled, as webhook URLs
+	// usually embed a secret.
+	WebhookURL string `json:"-"`
+	// Path of the Unix socket the notifications are written to
+	SocketPath string `json:"socketPath"`
+	// How often the state of the node is checked
+	CheckFrequency time.Duration `json:"checkFrequency"`
+	// Number of blocks a chain must be behind its peers to send FellBehind
+	BehindThreshold uint64 `json:"behindThreshold"`
+	// Share of the stake of the connected validators that must have stopped
+	// querying the node to send Benched
+	BenchedThreshold float64 `json:"benchedThreshold"`
+	// Time a connected validator must not have queried the node for to count
+	// towards [BenchedThreshold]
+	BenchedWindow time.Duration `json:"benchedWindow"`
+}
+
+// Enabled returns true if the notifications are sent anywhere.
+func (c *Config) Enabled() bool {
+	return c.WebhookURL != "" || c.SocketPath != ""
+}
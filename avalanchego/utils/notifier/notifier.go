@@ -0,0 +1,354 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+const (
+	// queueSize is the number of notifications that can wait to be sent.
+	// Notifications are dropped while the queue is full.
+	queueSize = 64
+
+	// sendTimeout bounds how long sending a notification to a sink takes
+	sendTimeout = 10 * time.Second
+)
+
+// Notification is the JSON document sent for an event
+type Notification struct {
+	Event     Event       `json:"event"`
+	NodeID    ids.NodeID  `json:"nodeID"`
+	NetworkID uint32      `json:"networkID"`
+	Time      time.Time   `json:"time"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// Sources report the state of the node to the notifier.
+type Sources struct {
+	// Health returns the results of the node's health checks and whether the
+	// node is healthy
+	Health func() (map[string]health.Result, bool)
+	// SilentStake returns the share of the stake of the connected validators
+	// that haven't queried the node within [window], or false if it can't be
+	// told, e.g. because the node isn't a validator or no validator queried it
+	SilentStake func(window time.Duration) (float64, bool)
+}
+
+// chain is a chain registered with the notifier
+type chain struct {
+	name   string
+	engine common.Engine
+	// true while the chain is at least [BehindThreshold] blocks behind
+	behind bool
+}
+
+// Notifier sends notifications about significant changes in the state of the
+// node to a webhook and a Unix socket, so that operators don't have to scrape
+// the logs to be alerted.
+type Notifier struct {
+	log       logging.Logger
+	config    Config
+	nodeID    ids.NodeID
+	networkID uint32
+	sources   Sources
+	sinks     []sink
+
+	chainsLock sync.Mutex
+	chains     map[ids.ID]*chain
+
+	// The state below is only accessed by the goroutine that checks the node.
+	startTime time.Time
+	healthy   bool
+	benched   bool
+	// Upgrades that haven't activated yet, in activation order
+	upgrades []version.Upgrade
+
+	lock    sync.Mutex
+	stopped bool
+	queue   chan *Notification
+
+	closer chan struct{}
+	wg     sync.WaitGroup
+}
+
+func New(
+	log logging.Logger,
+	config Config,
+	nodeID ids.NodeID,
+	networkID uint32,
+	sources Sources,
+) *Notifier {
+	n := &Notifier{
+		log:       log,
+		config:    config,
+		nodeID:    nodeID,
+		networkID: networkID,
+		sources:   sources,
+		chains:    make(map[ids.ID]*chain),
+		queue:     make(chan *Notification, queueSize),
+		closer:    make(chan struct{}),
+	}
+	if config.WebhookURL != "" {
+		n.sinks = append(n.sinks, &webhookSink{url: config.WebhookURL})
+	}
+	if config.SocketPath != "" {
+		n.sinks = append(n.sinks, &socketSink{path: config.SocketPath})
+	}
+	return n
+}
+
+// RegisterChain implements chains.Registrant. The chains with linear engines
+// are checked for falling behind their peers.
+func (n *Notifier) RegisterChain(name string, engine common.Engine) {
+	if _, ok := engine.(common.HeightInspector); !ok {
+		return
+	}
+
+	n.chainsLock.Lock()
+	defer n.chainsLock.Unlock()
+
+	n.chains[engine.Context().ChainID] = &chain{
+		name:   name,
+		engine: engine,
+	}
+}
+
+// ChainStopped sends ChainStopped for the chain [chainID].
+func (n *Notifier) ChainStopped(chainAlias string, chainID ids.ID) {
+	n.chainsLock.Lock()
+	delete(n.chains, chainID)
+	n.chainsLock.Unlock()
+
+	n.notify(ChainStopped, time.Now(), map[string]interface{}{
+		"chain":   chainAlias,
+		"chainID": chainID,
+	})
+}
+
+// Start checks the node every [CheckFrequency] until Stop is called.
+func (n *Notifier) Start() {
+	n.start(time.Now())
+
+	n.wg.Add(2)
+	go n.sendNotifications()
+	go func() {
+		defer n.wg.Done()
+
+		ticker := time.NewTicker(n.config.CheckFrequency)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				n.check(now)
+			case <-n.closer:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops checking the node and sends the notifications that are already
+// queued. Events that happen after Stop is called, such as the chains stopping
+// as the node shuts down, aren't sent.
+func (n *Notifier) Stop() {
+	n.lock.Lock()
+	if n.stopped {
+		n.lock.Unlock()
+		return
+	}
+	n.stopped = true
+	close(n.queue)
+	n.lock.Unlock()
+
+	close(n.closer)
+	n.wg.Wait()
+}
+
+// start records the upgrades that haven't activated by [now].
+func (n *Notifier) start(now time.Time) {
+	n.startTime = now
+	for _, upgrade := range version.GetUpgradeSchedule(n.networkID) {
+		if upgrade.Time.After(now) {
+			n.upgrades = append(n.upgrades, upgrade)
+		}
+	}
+}
+
+// check sends the notifications for the changes in the state of the node.
+func (n *Notifier) check(now time.Time) {
+	n.checkHealth(now)
+	n.checkBenched(now)
+	n.checkBehind(now)
+	n.checkUpgrades(now)
+}
+
+// checkHealth sends Unhealthy when the node stops being healthy. The node is
+// unhealthy while it starts, which isn't reported.
+func (n *Notifier) checkHealth(now time.Time) {
+	results, healthy := n.sources.Health()
+	if n.healthy && !healthy {
+		failing := []string{}
+		for name, result := range results {
+			if result.Error != nil {
+				failing = append(failing, name)
+			}
+		}
+		sort.Strings(failing)
+		n.notify(Unhealthy, now, map[string]interface{}{
+			"failingChecks": failing,
+		})
+	}
+	n.healthy = healthy
+}
+
+// checkBenched sends Benched when the share of the connected stake that
+// stopped querying the node goes over [BenchedThreshold].
+func (n *Notifier) checkBenched(now time.Time) {
+	// Right after the node starts, its peers haven't had time to query it.
+	if now.Sub(n.startTime) < n.config.BenchedWindow {
+		return
+	}
+
+	silentStake, ok := n.sources.SilentStake(n.config.BenchedWindow)
+	benched := ok && silentStake >= n.config.BenchedThreshold
+	if benched && !n.benched {
+		n.notify(Benched, now, map[string]interface{}{
+			"silentStake": silentStake,
+			"window":      n.config.BenchedWindow.String(),
+		})
+	}
+	n.benched = benched
+}
+
+// checkBehind sends FellBehind when a chain falls [BehindThreshold] blocks
+// behind the blocks sent by its peers.
+func (n *Notifier) checkBehind(now time.Time) {
+	n.chainsLock.Lock()
+	chains := make(map[ids.ID]*chain, len(n.chains))
+	for chainID, c := range n.chains {
+		chains[chainID] = c
+	}
+	n.chainsLock.Unlock()
+
+	for chainID, c := range chains {
+		ctx := c.engine.Context()
+		lastAccepted, highestObserved, ok := heights(ctx, c.engine.(common.HeightInspector))
+		if !ok {
+			continue
+		}
+
+		behind := highestObserved >= lastAccepted+n.config.BehindThreshold
+		if behind && !c.behind {
+			n.notify(FellBehind, now, map[string]interface{}{
+				"chain":                 c.name,
+				"chainID":               chainID,
+				"lastAcceptedHeight":    lastAccepted,
+				"highestObservedHeight": highestObserved,
+			})
+		}
+		c.behind = behind
+	}
+}
+
+// heights returns the heights reported by [inspector], or false if the chain
+// isn't in normal operation. Chains are expected to be behind while they
+// bootstrap.
+func heights(ctx *snow.ConsensusContext, inspector common.HeightInspector) (uint64, uint64, bool) {
+	ctx.Lock.Lock()
+	defer ctx.Lock.Unlock()
+
+	if ctx.GetState() != snow.NormalOp {
+		return 0, 0, false
+	}
+	lastAccepted, highestObserved, err := inspector.Heights()
+	if err != nil {
+		ctx.Log.Debug("couldn't get the heights of the chain",
+			zap.Error(err),
+		)
+		return 0, 0, false
+	}
+	return lastAccepted, highestObserved, true
+}
+
+// checkUpgrades sends UpgradeActivated for each upgrade that activated by
+// [now].
+func (n *Notifier) checkUpgrades(now time.Time) {
+	for len(n.upgrades) > 0 && !n.upgrades[0].Time.After(now) {
+		n.notify(UpgradeActivated, now, n.upgrades[0])
+		n.upgrades = n.upgrades[1:]
+	}
+}
+
+// notify queues a notification of [event].
+func (n *Notifier) notify(event Event, now time.Time, details interface{}) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.stopped {
+		return
+	}
+
+	n.log.Info("sending notification",
+		zap.String("event", string(event)),
+	)
+	select {
+	case n.queue <- &Notification{
+		Event:     event,
+		NodeID:    n.nodeID,
+		NetworkID: n.networkID,
+		Time:      now.UTC(),
+		Details:   details,
+	}:
+	default:
+		n.log.Warn("dropping notification",
+			zap.String("reason", "too many notifications are waiting to be sent"),
+			zap.String("event", string(event)),
+		)
+	}
+}
+
+// sendNotifications sends the queued notifications to the sinks until the
+// queue is closed.
+func (n *Notifier) sendNotifications() {
+	defer n.wg.Done()
+
+	for notification := range n.queue {
+		notificationBytes, err := json.Marshal(notification)
+		if err != nil {
+			n.log.Error("couldn't encode notification",
+				zap.String("event", string(notification.Event)),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for _, s := range n.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+			err := s.send(ctx, notificationBytes)
+			cancel()
+			if err != nil {
+				n.log.Warn("failed to send notification",
+					zap.String("event", string(notification.Event)),
+					zap.Stringer("sink", s),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notifier
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/api/health"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/engine/common"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/version"
+)
+
+type testSources struct {
+	healthy     bool
+	silentStake float64
+}
+
+type testEngine struct {
+	*common.EngineTest
+	lastAccepted, highestObserved uint64
+}
+
+func (e *testEngine) Heights() (uint64, uint64, error) {
+	return e.lastAccepted, e.highestObserved, nil
+}
+
+// newTestNotifier returns a notifier that POSTs to a test server. The
+// notifications the server receives are sent on the returned channel.
+func newTestNotifier(t *testing.T, config Config) (*Notifier, *testSources, chan Notification) {
+	received := make(chan Notification, queueSize)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		notification := Notification{}
+		require.NoError(t, json.Unmarshal(body, &notification))
+		received <- notification
+	}))
+	t.Cleanup(server.Close)
+
+	config.WebhookURL = server.URL
+	config.CheckFrequency = time.Hour
+	sources := &testSources{}
+	n := New(
+		logging.NoLog{},
+		config,
+		ids.GenerateTestNodeID(),
+		constants.LocalID,
+		Sources{
+			Health: func() (map[string]health.Result, bool) {
+				if sources.healthy {
+					return map[string]health.Result{"network": {}}, true
+				}
+				err := "not connected"
+				return map[string]health.Result{
+					"network":   {Error: &err},
+					"bootstrap": {},
+				}, false
+			},
+			SilentStake: func(time.Duration) (float64, bool) {
+				return sources.silentStake, sources.silentStake > 0
+			},
+		},
+	)
+	n.Start()
+	t.Cleanup(n.Stop)
+	return n, sources, received
+}
+
+func receive(t *testing.T, received chan Notification) Notification {
+	select {
+	case notification := <-received:
+		return notification
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "notification wasn't received")
+		return Notification{}
+	}
+}
+
+func TestNotifyUnhealthy(t *testing.T) {
+	require := require.New(t)
+
+	n, sources, received := newTestNotifier(t, Config{})
+	now := time.Now()
+
+	// The node is unhealthy while it starts.
+	n.check(now)
+	sources.healthy = true
+	n.check(now)
+	sources.healthy = false
+	n.check(now)
+	n.check(now)
+
+	notification := receive(t, received)
+	require.Equal(Unhealthy, notification.Event)
+	require.Equal(n.nodeID, notification.NodeID)
+	require.Equal(map[string]interface{}{
+		"failingChecks": []interface{}{"network"},
+	}, notification.Details)
+	require.Empty(received)
+}
+
+func TestNotifyBenched(t *testing.T) {
+	require := require.New(t)
+
+	n, sources, received := newTestNotifier(t, Config{
+		BenchedThreshold: 0.5,
+		BenchedWindow:    time.Minute,
+	})
+	sources.healthy = true
+	sources.silentStake = 0.8
+
+	// Peers haven't had time to query the node yet.
+	n.check(n.startTime)
+	n.check(n.startTime.Add(time.Minute))
+	n.check(n.startTime.Add(2 * time.Minute))
+	notification := receive(t, received)
+	require.Equal(Benched, notification.Event)
+	require.Equal(0.8, notification.Details.(map[string]interface{})["silentStake"])
+
+	sources.silentStake = 0.2
+	n.check(n.startTime.Add(3 * time.Minute))
+	sources.silentStake = 0.6
+	n.check(n.startTime.Add(4 * time.Minute))
+	require.Equal(Benched, receive(t, received).Event)
+	require.Empty(received)
+}
+
+func TestNotifyFellBehind(t *testing.T) {
+	require := require.New(t)
+
+	n, sources, received := newTestNotifier(t, Config{
+		BehindThreshold: 10,
+	})
+	sources.healthy = true
+
+	ctx := snow.DefaultConsensusContextTest()
+	ctx.ChainID = ids.GenerateTestID()
+	engine := &testEngine{
+		EngineTest: &common.EngineTest{
+			T:        t,
+			ContextF: func() *snow.ConsensusContext { return ctx },
+		},
+		lastAccepted:    5,
+		highestObserved: 20,
+	}
+	n.RegisterChain("C", engine)
+
+	// Chains are expected to be behind while they bootstrap.
+	ctx.SetState(snow.Bootstrapping)
+	n.check(time.Now())
+
+	ctx.SetState(snow.NormalOp)
+	n.check(time.Now())
+	n.check(time.Now())
+	notification := receive(t, received)
+	require.Equal(FellBehind, notification.Event)
+	require.Equal("C", notification.Details.(map[string]interface{})["chain"])
+	require.Empty(received)
+
+	engine.lastAccepted = 15
+	n.check(time.Now())
+	engine.highestObserved = 25
+	n.check(time.Now())
+	require.Equal(FellBehind, receive(t, received).Event)
+}
+
+func TestNotifyUpgradeActivated(t *testing.T) {
+	require := require.New(t)
+
+	n, sources, received := newTestNotifier(t, Config{})
+	sources.healthy = true
+
+	var upgrade version.Upgrade
+	for _, u := range version.GetUpgradeSchedule(constants.LocalID) {
+		if u.Name == "banff" {
+			upgrade = u
+		}
+	}
+	n.upgrades = nil
+	n.start(upgrade.Time.Add(-time.Second))
+	require.Equal(upgrade, n.upgrades[0])
+
+	n.check(upgrade.Time.Add(-time.Second))
+	n.check(upgrade.Time)
+	n.check(upgrade.Time.Add(time.Second))
+
+	notification := receive(t, received)
+	require.Equal(UpgradeActivated, notification.Event)
+	require.Equal(upgrade.Name, notification.Details.(map[string]interface{})["name"])
+	require.Empty(received)
+}
+
+func TestNotifyChainStoppedOnSocket(t *testing.T) {
+	require := require.New(t)
+
+	path := filepath.Join(t.TempDir(), "notifier.sock")
+	listener, err := net.Listen("unix", path)
+	require.NoError(err)
+	defer listener.Close()
+
+	n := New(
+		logging.NoLog{},
+		Config{
+			SocketPath:     path,
+			CheckFrequency: time.Hour,
+		},
+		ids.GenerateTestNodeID(),
+		constants.LocalID,
+		Sources{},
+	)
+	n.Start()
+
+	chainID := ids.GenerateTestID()
+	n.ChainStopped("X", chainID)
+
+	conn, err := listener.Accept()
+	require.NoError(err)
+	defer conn.Close()
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	require.NoError(err)
+
+	notification := Notification{}
+	require.NoError(json.Unmarshal(line, &notification))
+	require.Equal(ChainStopped, notification.Event)
+	require.Equal(map[string]interface{}{
+		"chain":   "X",
+		"chainID": chainID.String(),
+	}, notification.Details)
+
+	// Events after the notifier is stopped aren't sent.
+	n.Stop()
+	n.ChainStopped("P", ids.GenerateTestID())
+	require.Empty(n.queue)
+}
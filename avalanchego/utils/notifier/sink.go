@@ -0,0 +1,81 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+)
+
+var (
+	_ sink = &webhookSink{}
+	_ sink = &socketSink{}
+)
+
+// sink delivers encoded notifications
+type sink interface {
+	send(ctx context.Context, notification []byte) error
+	fmt.Stringer
+}
+
+// webhookSink POSTs each notification to a URL
+type webhookSink struct {
+	url    string
+	client http.Client
+}
+
+func (s *webhookSink) send(ctx context.Context, notification []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(notification))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *webhookSink) String() string {
+	return "webhook"
+}
+
+// socketSink writes each notification, followed by a newline, to a new
+// connection to a Unix socket
+type socketSink struct {
+	path string
+}
+
+func (s *socketSink) send(ctx context.Context, notification []byte) error {
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "unix", s.path)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetWriteDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	_, err = conn.Write(append(notification, '\n'))
+	return err
+}
+
+func (s *socketSink) String() string {
+	return "socket"
+}
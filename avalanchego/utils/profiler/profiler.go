@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/pprof"
+	"runtime/trace"
 
 	"github.com/ava-labs/avalanchego/utils/perms"
 )
@@ -20,11 +21,15 @@ const (
 	memProfileFile = "mem.profile"
 	// Name of file that lock profile is written to
 	lockProfileFile = "lock.profile"
+	// Name of file that execution trace is written to when StartTrace called
+	traceFile = "trace.out"
 )
 
 var (
 	errCPUProfilerRunning    = errors.New("cpu profiler already running")
 	errCPUProfilerNotRunning = errors.New("cpu profiler doesn't exist")
+	errTraceRunning          = errors.New("execution trace already running")
+	errTraceNotRunning       = errors.New("execution trace doesn't exist")
 )
 
 // Profiler provides helper methods for measuring the current performance of
@@ -41,15 +46,23 @@ type Profiler interface {
 
 	// LockProfile dumps the current lock statistics of this process
 	LockProfile() error
+
+	// StartTrace starts recording an execution trace of this process
+	StartTrace() error
+
+	// StopTrace stops recording the execution trace of this process
+	StopTrace() error
 }
 
 type profiler struct {
 	dir,
 	cpuProfileName,
 	memProfileName,
-	lockProfileName string
+	lockProfileName,
+	traceName string
 
 	cpuProfileFile *os.File
+	traceFile      *os.File
 }
 
 func New(dir string) Profiler { return new(dir) }
@@ -60,6 +73,7 @@ func new(dir string) *profiler {
 		cpuProfileName:  filepath.Join(dir, cpuProfileFile),
 		memProfileName:  filepath.Join(dir, memProfileFile),
 		lockProfileName: filepath.Join(dir, lockProfileFile),
+		traceName:       filepath.Join(dir, traceFile),
 	}
 }
 
@@ -128,3 +142,35 @@ func (p *profiler) LockProfile() error {
 	}
 	return file.Close()
 }
+
+func (p *profiler) StartTrace() error {
+	if p.traceFile != nil {
+		return errTraceRunning
+	}
+
+	if err := os.MkdirAll(p.dir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	file, err := perms.Create(p.traceName, perms.ReadWrite)
+	if err != nil {
+		return err
+	}
+	if err := trace.Start(file); err != nil {
+		_ = file.Close() // Return the original error
+		return err
+	}
+
+	p.traceFile = file
+	return nil
+}
+
+func (p *profiler) StopTrace() error {
+	if p.traceFile == nil {
+		return errTraceNotRunning
+	}
+
+	trace.Stop()
+	err := p.traceFile.Close()
+	p.traceFile = nil
+	return err
+}
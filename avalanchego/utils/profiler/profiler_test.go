@@ -43,4 +43,18 @@ func TestProfiler(t *testing.T) {
 
 	_, err = os.Stat(filepath.Join(dir, lockProfileFile))
 	require.NoError(t, err)
+
+	// Test Start and Stop Trace
+	err = p.StartTrace()
+	require.NoError(t, err)
+
+	err = p.StopTrace()
+	require.NoError(t, err)
+
+	_, err = os.Stat(filepath.Join(dir, traceFile))
+	require.NoError(t, err)
+
+	// Test Stop Trace without it running
+	err = p.StopTrace()
+	require.Error(t, err)
 }
@@ -141,6 +141,44 @@ var (
 		constants.LocalID:      time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
 	}
 	XChainMigrationDefaultTime = time.Date(2020, time.December, 5, 5, 0, 0, 0, time.UTC)
+
+	// Continuous staking isn't scheduled on any network, including custom
+	// networks, until it is approved by governance.
+	ContinuousStakingTimes = map[uint32]time.Time{
+		constants.MainnetID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FlareID:      time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.CostwoID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.StagingID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.LocalFlareID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.CostonID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.SongbirdID:   time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.LocalID:      time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	ContinuousStakingDefaultTime = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	// Batch delegation isn't scheduled on any network, including custom
+	// networks, until it is approved by governance.
+	BatchDelegationTimes = map[uint32]time.Time{
+		constants.MainnetID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.FlareID:      time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.CostwoID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.StagingID:    time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.LocalFlareID: time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.CostonID:     time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.SongbirdID:   time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+		constants.LocalID:      time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC),
+	}
+	BatchDelegationDefaultTime = time.Date(10000, time.December, 1, 0, 0, 0, 0, time.UTC)
+
+	// UpgradeVersions maps network upgrades to the first release that
+	// implements them. Upgrades that aren't listed are implemented by every
+	// release. SgbUpgradeVersions lists the releases of the Songbird networks.
+	UpgradeVersions = map[string]*Application{
+		"banff": {Major: 1, Minor: 9, Patch: 0},
+	}
+	SgbUpgradeVersions = map[string]*Application{
+		"banff": {Major: 0, Minor: 7, Patch: 0},
+	}
 )
 
 func GetApricotPhase3Time(networkID uint32) time.Time {
@@ -192,6 +230,50 @@ func GetXChainMigrationTime(networkID uint32) time.Time {
 	return XChainMigrationDefaultTime
 }
 
+func GetContinuousStakingTime(networkID uint32) time.Time {
+	if upgradeTime, exists := ContinuousStakingTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return ContinuousStakingDefaultTime
+}
+
+func GetBatchDelegationTime(networkID uint32) time.Time {
+	if upgradeTime, exists := BatchDelegationTimes[networkID]; exists {
+		return upgradeTime
+	}
+	return BatchDelegationDefaultTime
+}
+
+// Upgrade is a network upgrade and the time it activates at
+type Upgrade struct {
+	Name string    `json:"name"`
+	Time time.Time `json:"time"`
+}
+
+// GetUpgradeSchedule returns the network upgrades of [networkID] in the order
+// that they activate in
+func GetUpgradeSchedule(networkID uint32) []Upgrade {
+	return []Upgrade{
+		{Name: "apricotPhase3", Time: GetApricotPhase3Time(networkID)},
+		{Name: "apricotPhase4", Time: GetApricotPhase4Time(networkID)},
+		{Name: "apricotPhase5", Time: GetApricotPhase5Time(networkID)},
+		{Name: "apricotPhase6", Time: GetApricotPhase6Time(networkID)},
+		{Name: "banff", Time: GetBanffTime(networkID)},
+		{Name: "xChainMigration", Time: GetXChainMigrationTime(networkID)},
+		{Name: "continuousStaking", Time: GetContinuousStakingTime(networkID)},
+		{Name: "batchDelegation", Time: GetBatchDelegationTime(networkID)},
+	}
+}
+
+// GetUpgradeVersion returns the first release that implements [upgrade] on
+// [networkID], or nil if every release implements it
+func GetUpgradeVersion(networkID uint32, upgrade string) *Application {
+	if networkID == constants.SongbirdID || networkID == constants.CostonID || networkID == constants.LocalID {
+		return SgbUpgradeVersions[upgrade]
+	}
+	return UpgradeVersions[upgrade]
+}
+
 func GetCompatibility(networkID uint32) Compatibility {
 	if networkID == constants.SongbirdID || networkID == constants.CostonID || networkID == constants.LocalID {
 		return NewCompatibility(
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrUnsupportedUpgradeActive  = errors.New("an active network upgrade isn't implemented by this release")
+	ErrUnsupportedUpgradePending = errors.New("an upcoming network upgrade isn't implemented by this release")
+)
+
+// PendingUpgrade is a network upgrade that a release doesn't implement
+type PendingUpgrade struct {
+	Upgrade
+	RequiredVersion string `json:"requiredVersion"`
+	ActivatesIn     string `json:"activatesIn"`
+}
+
+// UnsupportedUpgrades returns the upgrades of [networkID] that [current]
+// doesn't implement
+func UnsupportedUpgrades(networkID uint32, current *Application) []PendingUpgrade {
+	var unsupported []PendingUpgrade
+	for _, upgrade := range GetUpgradeSchedule(networkID) {
+		required := GetUpgradeVersion(networkID, upgrade.Name)
+		if required == nil || !current.Before(required) {
+			continue
+		}
+		unsupported = append(unsupported, PendingUpgrade{
+			Upgrade:         upgrade,
+			RequiredVersion: required.String(),
+		})
+	}
+	return unsupported
+}
+
+// CheckUpgradeReadiness returns an error if one of the [unsupported] upgrades is
+// active at [now]. Otherwise, it returns the time left until each of them
+// activates, along with an error if there are any, so that the node is
+// reported unhealthy until it is updated.
+func CheckUpgradeReadiness(unsupported []PendingUpgrade, now time.Time) ([]PendingUpgrade, error) {
+	pending := make([]PendingUpgrade, 0, len(unsupported))
+	for _, upgrade := range unsupported {
+		if !now.Before(upgrade.Time) {
+			return nil, fmt.Errorf("%w: %s activated at %s and requires %s, update the node before restarting it",
+				ErrUnsupportedUpgradeActive,
+				upgrade.Name,
+				upgrade.Time,
+				upgrade.RequiredVersion,
+			)
+		}
+		upgrade.ActivatesIn = upgrade.Time.Sub(now).Truncate(time.Second).String()
+		pending = append(pending, upgrade)
+	}
+	if len(pending) == 0 {
+		return pending, nil
+	}
+	next := pending[0]
+	return pending, fmt.Errorf("%w: %s activates in %s and requires %s",
+		ErrUnsupportedUpgradePending,
+		next.Name,
+		next.ActivatesIn,
+		next.RequiredVersion,
+	)
+}
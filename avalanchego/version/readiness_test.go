@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package version
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+func TestUnsupportedUpgrades(t *testing.T) {
+	require := require.New(t)
+
+	// Every upgrade of the built in networks is implemented by this release
+	require.Empty(UnsupportedUpgrades(constants.FlareID, CurrentApp))
+	require.Empty(UnsupportedUpgrades(constants.SongbirdID, CurrentSgbApp))
+
+	oldApp := &Application{Major: 1, Minor: 8, Patch: 0}
+	unsupported := UnsupportedUpgrades(constants.FlareID, oldApp)
+	require.Len(unsupported, 1)
+	require.Equal("banff", unsupported[0].Name)
+	require.Equal(GetBanffTime(constants.FlareID), unsupported[0].Time)
+	require.Equal(UpgradeVersions["banff"].String(), unsupported[0].RequiredVersion)
+}
+
+func TestCheckUpgradeReadiness(t *testing.T) {
+	require := require.New(t)
+
+	activation := time.Date(2024, time.December, 17, 15, 0, 0, 0, time.UTC)
+	unsupported := []PendingUpgrade{{
+		Upgrade:         Upgrade{Name: "banff", Time: activation},
+		RequiredVersion: "avalanche/1.9.0",
+	}}
+
+	pending, err := CheckUpgradeReadiness(nil, activation)
+	require.NoError(err)
+	require.Empty(pending)
+
+	// Before the activation, the time left is counted down
+	pending, err = CheckUpgradeReadiness(unsupported, activation.Add(-90*time.Minute))
+	require.ErrorIs(err, ErrUnsupportedUpgradePending)
+	require.Len(pending, 1)
+	require.Equal("1h30m0s", pending[0].ActivatesIn)
+
+	// Once the upgrade is active, the node must not keep running
+	_, err = CheckUpgradeReadiness(unsupported, activation)
+	require.ErrorIs(err, ErrUnsupportedUpgradeActive)
+}
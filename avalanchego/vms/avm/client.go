@@ -25,6 +25,9 @@ var _ Client = &client{}
 // Client for interacting with an AVM (X-Chain) instance
 type Client interface {
 	WalletClient
+	// IssueTxs issues [txs] in order and returns the result of issuing each of
+	// them. An error is only returned if the request itself failed.
+	IssueTxs(ctx context.Context, txs [][]byte, options ...rpc.Option) ([]IssueTxResult, error)
 	// GetTxStatus returns the status of [txID]
 	GetTxStatus(ctx context.Context, txID ids.ID, options ...rpc.Option) (choices.Status, error)
 	// ConfirmTx attempts to confirm [txID] by repeatedly checking its status.
@@ -44,6 +47,12 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetAllUTXOs returns the byte representation of all the UTXOs controlled
+	// by [addrs], fetched in pages of at most [limit] UTXOs.
+	GetAllUTXOs(ctx context.Context, addrs []ids.ShortID, limit uint32, options ...rpc.Option) ([][]byte, error)
+	// StreamUTXOs fetches the UTXOs controlled by [addrs] in pages of at most
+	// [limit] UTXOs and sends each page on the returned channel.
+	StreamUTXOs(ctx context.Context, addrs []ids.ShortID, limit uint32, options ...rpc.Option) <-chan api.UTXOBatch
 	// GetAtomicUTXOs returns the byte representation of the atomic UTXOs controlled by [addrs]
 	// from [sourceChain]
 	GetAtomicUTXOs(
@@ -118,6 +127,22 @@ type Client interface {
 	ExportKey(ctx context.Context, user api.UserPass, addr ids.ShortID, options ...rpc.Option) (*crypto.PrivateKeySECP256K1R, error)
 	// ImportKey imports [privateKey] to [user]
 	ImportKey(ctx context.Context, user api.UserPass, privateKey *crypto.PrivateKeySECP256K1R, options ...rpc.Option) (ids.ShortID, error)
+	// ConsolidateUTXOs merges the UTXOs of [assetID] controlled by [user] into
+	// outputs owned by [to], consuming at most [maxInputsPerTx] UTXOs per tx.
+	// If [to] is empty, the outputs are sent to the change address.
+	// If [maxTxs] is 0, all spendable UTXOs are merged.
+	// Returns the IDs of the issued transactions.
+	ConsolidateUTXOs(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		assetID string,
+		to ids.ShortID,
+		maxInputsPerTx uint32,
+		maxTxs uint32,
+		options ...rpc.Option,
+	) ([]ids.ID, error)
 	// Mint [amount] of [assetID] to be owned by [to]
 	Mint(
 		ctx context.Context,
@@ -183,7 +208,7 @@ func NewClient(uri, chain string) Client {
 		chain,
 	)
 	return &client{
-		requester: rpc.NewEndpointRequester(path, "avm"),
+		requester: &errorMappingRequester{rpc.NewEndpointRequester(path, "avm")},
 	}
 }
 
@@ -200,6 +225,23 @@ func (c *client) IssueTx(ctx context.Context, txBytes []byte, options ...rpc.Opt
 	return res.TxID, err
 }
 
+func (c *client) IssueTxs(ctx context.Context, txs [][]byte, options ...rpc.Option) ([]IssueTxResult, error) {
+	txStrs := make([]string, len(txs))
+	for i, txBytes := range txs {
+		txStr, err := formatting.Encode(formatting.Hex, txBytes)
+		if err != nil {
+			return nil, err
+		}
+		txStrs[i] = txStr
+	}
+	res := &IssueTxsReply{}
+	err := c.requester.SendRequest(ctx, "issueTxs", &IssueTxsArgs{
+		Txs:      txStrs,
+		Encoding: formatting.Hex,
+	}, res, options...)
+	return res.Results, err
+}
+
 func (c *client) IssueStopVertex(ctx context.Context, options ...rpc.Option) error {
 	return c.requester.SendRequest(ctx, "issueStopVertex", &struct{}{}, &struct{}{}, options...)
 }
@@ -260,6 +302,35 @@ func (c *client) GetUTXOs(
 	return c.GetAtomicUTXOs(ctx, addrs, "", limit, startAddress, startUTXOID, options...)
 }
 
+func (c *client) GetAllUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	limit uint32,
+	options ...rpc.Option,
+) ([][]byte, error) {
+	return api.GetAllUTXOs(ctx, c.utxoPageFetcher(addrs, options), limit)
+}
+
+func (c *client) StreamUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	limit uint32,
+	options ...rpc.Option,
+) <-chan api.UTXOBatch {
+	return api.StreamUTXOs(ctx, c.utxoPageFetcher(addrs, options), limit)
+}
+
+func (c *client) utxoPageFetcher(addrs []ids.ShortID, options []rpc.Option) api.UTXOPageFetcher {
+	return func(
+		ctx context.Context,
+		limit uint32,
+		startAddress ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		return c.GetUTXOs(ctx, addrs, limit, startAddress, startUTXOID, options...)
+	}
+}
+
 func (c *client) GetAtomicUTXOs(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -586,6 +657,36 @@ func (c *client) SendMultiple(
 	return res.TxID, err
 }
 
+func (c *client) ConsolidateUTXOs(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	assetID string,
+	to ids.ShortID,
+	maxInputsPerTx uint32,
+	maxTxs uint32,
+	options ...rpc.Option,
+) ([]ids.ID, error) {
+	toStr := ""
+	if to != ids.ShortEmpty {
+		toStr = to.String()
+	}
+	res := &ConsolidateUTXOsReply{}
+	err := c.requester.SendRequest(ctx, "consolidateUTXOs", &ConsolidateUTXOsArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		AssetID:        assetID,
+		To:             toStr,
+		MaxInputsPerTx: cjson.Uint32(maxInputsPerTx),
+		MaxTxs:         cjson.Uint32(maxTxs),
+	}, res, options...)
+	return res.TxIDs, err
+}
+
 func (c *client) Mint(
 	ctx context.Context,
 	user api.UserPass,
@@ -0,0 +1,134 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+// Error codes reported by the API of the AVM, in the range reserved by JSON-RPC
+// for server errors. Errors without a more specific code are reported with
+// json2.E_SERVER.
+const (
+	ErrCodeInsufficientFunds json2.ErrorCode = -32010
+	ErrCodeUnknownAsset      json2.ErrorCode = -32011
+	ErrCodeConflictingTx     json2.ErrorCode = -32012
+	ErrCodeNotFound          json2.ErrorCode = -32013
+)
+
+var (
+	// ErrInsufficientFunds is reported when the spent addresses don't hold
+	// enough of an asset.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrUnknownAsset is reported when an asset ID or alias doesn't reference
+	// an asset.
+	ErrUnknownAsset = errors.New("unknown asset")
+	// ErrConflictingTx is reported when a transaction consumes UTXOs that were
+	// already consumed.
+	ErrConflictingTx = errors.New("conflicting transaction")
+	// ErrNotFound is reported when a transaction isn't known to the node.
+	ErrNotFound = errors.New("not found")
+
+	_ rpc.EndpointRequester = &errorMappingRequester{}
+
+	errorCodes = []struct {
+		code json2.ErrorCode
+		errs []error
+	}{
+		{code: ErrCodeInsufficientFunds, errs: []error{errInsufficientFunds, avax.ErrInsufficientFunds}},
+		{code: ErrCodeUnknownAsset, errs: []error{errUnknownAssetID}},
+		{code: ErrCodeConflictingTx, errs: []error{errMissingUTXO}},
+		{code: ErrCodeNotFound, errs: []error{errUnknownTx}},
+	}
+
+	clientErrors = map[json2.ErrorCode]error{
+		ErrCodeInsufficientFunds: ErrInsufficientFunds,
+		ErrCodeUnknownAsset:      ErrUnknownAsset,
+		ErrCodeConflictingTx:     ErrConflictingTx,
+		ErrCodeNotFound:          ErrNotFound,
+	}
+)
+
+// mapServiceError reports [err] with the error code of its cause, if it has
+// one. The message of the error is unchanged.
+func mapServiceError(err error) error {
+	for _, errorCode := range errorCodes {
+		for _, cause := range errorCode.errs {
+			if errors.Is(err, cause) {
+				return &json2.Error{
+					Code:    errorCode.code,
+					Message: err.Error(),
+				}
+			}
+		}
+	}
+	return err
+}
+
+// IsRetryable returns true if the request that failed with [err] may succeed
+// if it is sent again. Requests the API rejected are only retryable if they
+// referenced a transaction the node doesn't know about yet, as it may still be
+// propagating. Requests that failed before getting a response from the API are
+// retryable unless their context was done.
+func IsRetryable(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, ErrNotFound):
+		return true
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return false
+	}
+	var apiErr *json2.Error
+	return !errors.As(err, &apiErr)
+}
+
+// apiError is an error reported by the API with a known error code. It is
+// both its sentinel error and the original error.
+type apiError struct {
+	sentinel error
+	err      error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func (e *apiError) Unwrap() []error {
+	return []error{e.sentinel, e.err}
+}
+
+// errorMappingRequester wraps the errors reported by the API with the sentinel
+// errors of their error codes.
+type errorMappingRequester struct {
+	rpc.EndpointRequester
+}
+
+func (r *errorMappingRequester) SendRequest(
+	ctx context.Context,
+	method string,
+	params interface{},
+	reply interface{},
+	options ...rpc.Option,
+) error {
+	err := r.EndpointRequester.SendRequest(ctx, method, params, reply, options...)
+	var apiErr *json2.Error
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	sentinel, ok := clientErrors[apiErr.Code]
+	if !ok {
+		return err
+	}
+	return &apiError{
+		sentinel: sentinel,
+		err:      err,
+	}
+}
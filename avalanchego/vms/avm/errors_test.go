@@ -0,0 +1,96 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package avm
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/rpc/v2/json2"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+)
+
+func TestMapServiceError(t *testing.T) {
+	tests := []struct {
+		err          error
+		expectedCode json2.ErrorCode
+	}{
+		{
+			err:          fmt.Errorf("%w: want to spend 2 of asset X but only have 1", errInsufficientFunds),
+			expectedCode: ErrCodeInsufficientFunds,
+		},
+		{
+			err:          avax.ErrInsufficientFunds,
+			expectedCode: ErrCodeInsufficientFunds,
+		},
+		{
+			err:          fmt.Errorf("couldn't find asset X: %w", errUnknownAssetID),
+			expectedCode: ErrCodeUnknownAsset,
+		},
+		{
+			err:          errMissingUTXO,
+			expectedCode: ErrCodeConflictingTx,
+		},
+		{
+			err:          errUnknownTx,
+			expectedCode: ErrCodeNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.err.Error(), func(t *testing.T) {
+			require := require.New(t)
+
+			mappedErr, ok := mapServiceError(test.err).(*json2.Error)
+			require.True(ok)
+			require.Equal(test.expectedCode, mappedErr.Code)
+			require.Equal(test.err.Error(), mappedErr.Message)
+		})
+	}
+
+	require.Equal(t, errNoKeys, mapServiceError(errNoKeys))
+}
+
+func TestClientErrors(t *testing.T) {
+	require := require.New(t)
+
+	_, vm, _, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	handlers, err := vm.CreateHandlers()
+	require.NoError(err)
+	server := httptest.NewServer(handlers[""].Handler)
+	c := &client{
+		requester: &errorMappingRequester{rpc.NewEndpointRequester(server.URL, "avm")},
+	}
+
+	_, err = c.GetTx(context.Background(), ids.GenerateTestID())
+	require.ErrorIs(err, ErrNotFound)
+	require.Contains(err.Error(), errUnknownTx.Error())
+	require.True(IsRetryable(err))
+
+	_, err = c.GetAssetDescription(context.Background(), "unknown")
+	require.ErrorIs(err, ErrUnknownAsset)
+	require.False(IsRetryable(err))
+
+	// Errors without an error code aren't mapped.
+	_, err = c.GetTx(context.Background(), ids.Empty)
+	require.Error(err)
+	require.NotErrorIs(err, ErrNotFound)
+	require.False(IsRetryable(err))
+
+	server.Close()
+	_, err = c.GetTx(context.Background(), ids.GenerateTestID())
+	require.Error(err)
+	require.True(IsRetryable(err))
+}
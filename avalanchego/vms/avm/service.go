@@ -16,6 +16,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/vms/avm/txs"
@@ -34,6 +35,15 @@ const (
 
 	// Max number of items allowed in a page
 	maxPageSize uint64 = 1024
+
+	// Default number of inputs consumed by each consolidation tx
+	defaultConsolidateInputsPerTx = 256
+
+	// Max number of inputs that can be consumed by each consolidation tx
+	maxConsolidateInputsPerTx = 1024
+
+	// Max number of txs that can be issued in one call to IssueTxs
+	maxIssueTxsSize = 256
 )
 
 var (
@@ -51,6 +61,10 @@ var (
 	errNoAddresses            = errors.New("no addresses provided")
 	errNoKeys                 = errors.New("from addresses have no keys or funds")
 	errMissingPrivateKey      = errors.New("argument 'privateKey' not given")
+	errNothingToConsolidate   = errors.New("fewer than two spendable UTXOs of the asset to consolidate")
+	errTooFewInputsPerTx      = errors.New("maxInputsPerTx must be at least 2")
+	errUnknownInputUTXO       = errors.New("unknown UTXO consumed by input")
+	errWalletUnsigned         = errors.New("the wallet can't return unsigned txs, use the avm API instead")
 )
 
 // Service defines the base service for the asset vm
@@ -80,6 +94,54 @@ func (service *Service) IssueTx(r *http.Request, args *api.FormattedTx, reply *a
 	return nil
 }
 
+// IssueTxsArgs are arguments for passing into IssueTxs requests
+type IssueTxsArgs struct {
+	Txs      []string            `json:"txs"`
+	Encoding formatting.Encoding `json:"encoding"`
+}
+
+// IssueTxResult is the outcome of issuing a single tx of an IssueTxs request
+type IssueTxResult struct {
+	TxID ids.ID `json:"txID"`
+	// Error is empty if the tx was issued
+	Error string `json:"error,omitempty"`
+}
+
+// IssueTxsReply defines the IssueTxs replies returned from the API
+type IssueTxsReply struct {
+	// Results[i] is the result of issuing Txs[i]
+	Results []IssueTxResult `json:"results"`
+}
+
+// IssueTxs verifies and issues [args.Txs] in order. No other tx is issued
+// between them. A tx that fails to be issued doesn't prevent the following txs
+// from being issued, but txs that depend on it will fail verification.
+func (service *Service) IssueTxs(_ *http.Request, args *IssueTxsArgs, reply *IssueTxsReply) error {
+	service.vm.ctx.Log.Debug("AVM: IssueTxs called",
+		zap.Int("numTxs", len(args.Txs)),
+	)
+
+	if len(args.Txs) > maxIssueTxsSize {
+		return fmt.Errorf("number of txs (%d) > maximum allowed (%d)", len(args.Txs), maxIssueTxsSize)
+	}
+
+	reply.Results = make([]IssueTxResult, len(args.Txs))
+	for i, txStr := range args.Txs {
+		txBytes, err := formatting.Decode(args.Encoding, txStr)
+		if err != nil {
+			reply.Results[i].Error = fmt.Sprintf("problem decoding transaction: %s", err)
+			continue
+		}
+		txID, err := service.vm.IssueTx(txBytes)
+		if err != nil {
+			reply.Results[i].Error = err.Error()
+			continue
+		}
+		reply.Results[i].TxID = txID
+	}
+	return nil
+}
+
 func (service *Service) IssueStopVertex(_ *http.Request, _ *struct{}, _ *struct{}) error {
 	return service.vm.issueStopVertex()
 }
@@ -945,6 +1007,10 @@ type SendArgs struct {
 
 	// Memo field
 	Memo string `json:"memo"`
+
+	// If true, the tx is built from the UTXOs of the from addresses and
+	// returned unsigned instead of being signed with the keystore and issued
+	Unsigned bool `json:"unsigned"`
 }
 
 // SendMultipleArgs are arguments for passing into SendMultiple requests
@@ -957,19 +1023,25 @@ type SendMultipleArgs struct {
 
 	// Memo field
 	Memo string `json:"memo"`
+
+	// If true, the tx is built from the UTXOs of the from addresses and
+	// returned unsigned instead of being signed with the keystore and issued
+	Unsigned bool `json:"unsigned"`
 }
 
 // Send returns the ID of the newly created transaction
-func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxIDChangeAddr) error {
+func (service *Service) Send(r *http.Request, args *SendArgs, reply *api.JSONTxIDChangeAddrUnsignedTx) error {
 	return service.SendMultiple(r, &SendMultipleArgs{
 		JSONSpendHeader: args.JSONSpendHeader,
 		Outputs:         []SendOutput{args.SendOutput},
 		Memo:            args.Memo,
+		Unsigned:        args.Unsigned,
 	}, reply)
 }
 
-// SendMultiple sends a transaction with multiple outputs.
-func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddr) error {
+// SendMultiple sends a transaction with multiple outputs. If [args.Unsigned]
+// is set, the tx is returned unsigned instead.
+func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, reply *api.JSONTxIDChangeAddrUnsignedTx) error {
 	service.vm.ctx.Log.Debug("AVM: SendMultiple called",
 		logging.UserString("username", args.Username),
 	)
@@ -988,17 +1060,41 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		return err
 	}
 
-	// Load user's UTXOs/keys
-	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
-	if err != nil {
-		return err
+	var (
+		utxos             []*avax.UTXO
+		kc                spender
+		defaultChangeAddr ids.ShortID
+	)
+	if args.Unsigned {
+		// Without keys, the funds can only be looked up by address
+		if len(args.From) == 0 {
+			return errNoAddresses
+		}
+		utxos, err = avax.GetAllUTXOs(service.vm.state, fromAddrs)
+		if err != nil {
+			return fmt.Errorf("problem retrieving UTXOs: %w", err)
+		}
+		kc = secp256k1fx.NewWatchKeychain(fromAddrs.List()...)
+		defaultChangeAddr, err = avax.ParseServiceAddress(service.vm, args.From[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		// Load user's UTXOs/keys
+		var userKC *secp256k1fx.Keychain
+		utxos, userKC, err = service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+		if err != nil {
+			return err
+		}
+		if len(userKC.Keys) == 0 {
+			return errNoKeys
+		}
+		kc = userKC
+		defaultChangeAddr = userKC.Keys[0].PublicKey().Address()
 	}
 
 	// Parse the change address.
-	if len(kc.Keys) == 0 {
-		return errNoKeys
-	}
-	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	changeAddr, err := service.vm.selectChangeAddr(defaultChangeAddr, args.ChangeAddr)
 	if err != nil {
 		return err
 	}
@@ -1018,7 +1114,7 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		if !ok {
 			assetID, err = service.vm.lookupAssetID(output.AssetID)
 			if err != nil {
-				return fmt.Errorf("couldn't find asset %s", output.AssetID)
+				return fmt.Errorf("couldn't find asset %s: %w", output.AssetID, err)
 			}
 			assetIDs[output.AssetID] = assetID
 		}
@@ -1096,6 +1192,16 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		Ins:          ins,
 		Memo:         memoBytes,
 	}}}
+	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
+	if err != nil {
+		return err
+	}
+
+	if args.Unsigned {
+		reply.JSONUnsignedTx, err = service.newUnsignedTx(&tx, ins, utxos)
+		return err
+	}
+
 	if err := tx.SignSECP256K1Fx(service.vm.parser.Codec(), keys); err != nil {
 		return err
 	}
@@ -1106,10 +1212,329 @@ func (service *Service) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 	}
 
 	reply.TxID = txID
+	return nil
+}
+
+// newUnsignedTx returns the unsigned bytes of [tx] along with the signatures
+// required to spend [ins], which consume [utxos].
+func (service *Service) newUnsignedTx(tx *txs.Tx, ins []*avax.TransferableInput, utxos []*avax.UTXO) (api.JSONUnsignedTx, error) {
+	unsignedBytes, err := service.vm.parser.Codec().Marshal(txs.CodecVersion, &tx.Unsigned)
+	if err != nil {
+		return api.JSONUnsignedTx{}, fmt.Errorf("problem marshalling unsigned tx: %w", err)
+	}
+	unsignedTx, err := formatting.Encode(formatting.Hex, unsignedBytes)
+	if err != nil {
+		return api.JSONUnsignedTx{}, err
+	}
+	sigHash, err := formatting.Encode(formatting.Hex, hashing.ComputeHash256(unsignedBytes))
+	if err != nil {
+		return api.JSONUnsignedTx{}, err
+	}
+
+	utxoMap := make(map[ids.ID]*avax.UTXO, len(utxos))
+	for _, utxo := range utxos {
+		utxoMap[utxo.InputID()] = utxo
+	}
+
+	signers := make([]api.SigningDescriptor, len(ins))
+	for i, input := range ins {
+		utxoID := input.InputID()
+		utxo, ok := utxoMap[utxoID]
+		if !ok {
+			return api.JSONUnsignedTx{}, fmt.Errorf("%w: %s", errUnknownInputUTXO, utxoID)
+		}
+		out, ok := utxo.Out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return api.JSONUnsignedTx{}, fmt.Errorf("can't describe the signers of UTXO %s of type %T", utxoID, utxo.Out)
+		}
+		in, ok := input.In.(*secp256k1fx.TransferInput)
+		if !ok {
+			return api.JSONUnsignedTx{}, fmt.Errorf("can't describe the signers of input %d of type %T", i, input.In)
+		}
+		addrs, err := secp256k1fx.SigningAddresses(&in.Input, &out.OutputOwners)
+		if err != nil {
+			return api.JSONUnsignedTx{}, err
+		}
+
+		signers[i] = api.SigningDescriptor{
+			InputIndex: json.Uint32(i),
+			UTXOID:     input.UTXOID.String(),
+			Addresses:  make([]string, len(addrs)),
+			SigHash:    sigHash,
+		}
+		for j, addr := range addrs {
+			signers[i].Addresses[j], err = service.vm.FormatLocalAddress(addr)
+			if err != nil {
+				return api.JSONUnsignedTx{}, err
+			}
+		}
+	}
+	return api.JSONUnsignedTx{
+		UnsignedTx: unsignedTx,
+		Signers:    signers,
+	}, nil
+}
+
+// ConsolidateUTXOsArgs are arguments for passing into ConsolidateUTXOs requests
+type ConsolidateUTXOsArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+
+	// ID of the asset whose UTXOs are merged
+	AssetID string `json:"assetID"`
+
+	// Address receiving the merged outputs. Defaults to the change address.
+	To string `json:"to"`
+
+	// Max number of UTXOs of [AssetID] consumed by each issued tx
+	MaxInputsPerTx json.Uint32 `json:"maxInputsPerTx"`
+
+	// Max number of txs to issue. If 0, all spendable UTXOs are merged.
+	MaxTxs json.Uint32 `json:"maxTxs"`
+}
+
+// ConsolidateUTXOsReply defines the ConsolidateUTXOs replies returned from the API
+type ConsolidateUTXOsReply struct {
+	TxIDs []ids.ID `json:"txIDs"`
+	// Number of UTXOs of the asset consumed by the issued txs
+	NumConsumed json.Uint64 `json:"numConsumed"`
+	api.JSONChangeAddr
+}
+
+// ConsolidateUTXOs merges the UTXOs of [AssetID] owned by the user into single
+// outputs. The UTXOs are split into chunks of at most [MaxInputsPerTx] inputs
+// and a tx is issued for each chunk.
+func (service *Service) ConsolidateUTXOs(_ *http.Request, args *ConsolidateUTXOsArgs, reply *ConsolidateUTXOsReply) error {
+	service.vm.ctx.Log.Debug("AVM: ConsolidateUTXOs called",
+		logging.UserString("username", args.Username),
+		logging.UserString("assetID", args.AssetID),
+	)
+
+	maxInputs := int(args.MaxInputsPerTx)
+	switch {
+	case maxInputs == 0:
+		maxInputs = defaultConsolidateInputsPerTx
+	case maxInputs == 1:
+		return errTooFewInputsPerTx
+	case maxInputs > maxConsolidateInputsPerTx:
+		return fmt.Errorf("maxInputsPerTx > maximum allowed (%d)", maxConsolidateInputsPerTx)
+	}
+
+	assetID, err := service.vm.lookupAssetID(args.AssetID)
+	if err != nil {
+		return fmt.Errorf("couldn't find asset %s: %w", args.AssetID, err)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(service.vm, args.From)
+	if err != nil {
+		return err
+	}
+
+	// Load user's UTXOs/keys
+	utxos, kc, err := service.vm.LoadUser(args.Username, args.Password, fromAddrs)
+	if err != nil {
+		return err
+	}
+
+	// Parse the change address.
+	if len(kc.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr, err := service.vm.selectChangeAddr(kc.Keys[0].PublicKey().Address(), args.ChangeAddr)
+	if err != nil {
+		return err
+	}
+	to := changeAddr
+	if args.To != "" {
+		to, err = avax.ParseServiceAddress(service.vm, args.To)
+		if err != nil {
+			return fmt.Errorf("problem parsing to address %q: %w", args.To, err)
+		}
+	}
+
+	// Split the UTXOs into the ones being merged and the ones that can be used
+	// to pay the fee.
+	now := service.vm.clock.Unix()
+	var (
+		toMerge  []*avax.UTXO
+		feeUTXOs []*avax.UTXO
+	)
+	for _, utxo := range utxos {
+		if utxo.AssetID() != assetID {
+			feeUTXOs = append(feeUTXOs, utxo)
+			continue
+		}
+		if _, ok := utxo.Out.(avax.TransferableOut); !ok {
+			continue
+		}
+		if _, _, err := kc.Spend(utxo.Out, now); err != nil {
+			// this utxo can't be spent with the current keys right now
+			continue
+		}
+		toMerge = append(toMerge, utxo)
+	}
+	if len(toMerge) < 2 {
+		return errNothingToConsolidate
+	}
+
+	payFee := assetID != service.vm.feeAssetID
+	for len(toMerge) >= 2 {
+		if args.MaxTxs != 0 && len(reply.TxIDs) >= int(args.MaxTxs) {
+			break
+		}
+
+		numInputs := maxInputs
+		if numInputs > len(toMerge) {
+			numInputs = len(toMerge)
+		}
+		chunk := toMerge[:numInputs]
+		toMerge = toMerge[numInputs:]
+
+		tx, err := service.newConsolidateTx(kc, chunk, feeUTXOs, assetID, to, changeAddr, payFee, now)
+		if err != nil {
+			return partialConsolidationErr(reply, err)
+		}
+
+		txID, err := service.vm.IssueTx(tx.Bytes())
+		if err != nil {
+			return partialConsolidationErr(reply, fmt.Errorf("problem issuing transaction: %w", err))
+		}
+		reply.TxIDs = append(reply.TxIDs, txID)
+		reply.NumConsumed += json.Uint64(len(chunk))
+
+		if payFee {
+			// The fee inputs are now consumed, so subsequent txs must pay
+			// their fee with the change of this tx.
+			feeUTXOs = removeSpentUTXOs(feeUTXOs, tx.Unsigned.(*txs.BaseTx).Ins)
+			for _, utxo := range tx.UTXOs() {
+				if utxo.AssetID() == service.vm.feeAssetID {
+					feeUTXOs = append(feeUTXOs, utxo)
+				}
+			}
+		}
+	}
+
 	reply.ChangeAddr, err = service.vm.FormatLocalAddress(changeAddr)
 	return err
 }
 
+// newConsolidateTx returns a signed tx merging [chunk] into a single output of
+// [assetID] owned by [to]. If [payFee], the fee is paid with [feeUTXOs] and its
+// change is sent to [changeAddr]. Otherwise, it's taken from the merged amount.
+func (service *Service) newConsolidateTx(
+	kc *secp256k1fx.Keychain,
+	chunk []*avax.UTXO,
+	feeUTXOs []*avax.UTXO,
+	assetID ids.ID,
+	to ids.ShortID,
+	changeAddr ids.ShortID,
+	payFee bool,
+	now uint64,
+) (*txs.Tx, error) {
+	var (
+		amount uint64
+		ins    = make([]*avax.TransferableInput, 0, len(chunk))
+		keys   = make([][]*crypto.PrivateKeySECP256K1R, 0, len(chunk))
+	)
+	for _, utxo := range chunk {
+		inputIntf, signers, err := kc.Spend(utxo.Out, now)
+		if err != nil {
+			return nil, err
+		}
+		input := inputIntf.(avax.TransferableIn)
+		amount, err = safemath.Add64(amount, input.Amount())
+		if err != nil {
+			return nil, errSpendOverflow
+		}
+		ins = append(ins, &avax.TransferableInput{
+			UTXOID: utxo.UTXOID,
+			Asset:  avax.Asset{ID: assetID},
+			In:     input,
+		})
+		keys = append(keys, signers)
+	}
+
+	outs := []*avax.TransferableOutput{}
+	if payFee {
+		feeSpent, feeIns, feeKeys, err := service.vm.Spend(
+			feeUTXOs,
+			kc,
+			map[ids.ID]uint64{service.vm.feeAssetID: service.vm.TxFee},
+		)
+		if err != nil {
+			return nil, err
+		}
+		ins = append(ins, feeIns...)
+		keys = append(keys, feeKeys...)
+		if change := feeSpent[service.vm.feeAssetID] - service.vm.TxFee; change > 0 {
+			outs = append(outs, &avax.TransferableOutput{
+				Asset: avax.Asset{ID: service.vm.feeAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt: change,
+					OutputOwners: secp256k1fx.OutputOwners{
+						Locktime:  0,
+						Threshold: 1,
+						Addrs:     []ids.ShortID{changeAddr},
+					},
+				},
+			})
+		}
+	} else {
+		if amount <= service.vm.TxFee {
+			return nil, fmt.Errorf("merged amount %d doesn't cover the tx fee %d", amount, service.vm.TxFee)
+		}
+		amount -= service.vm.TxFee
+	}
+	outs = append(outs, &avax.TransferableOutput{
+		Asset: avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: amount,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Locktime:  0,
+				Threshold: 1,
+				Addrs:     []ids.ShortID{to},
+			},
+		},
+	})
+	codec := service.vm.parser.Codec()
+	avax.SortTransferableInputsWithSigners(ins, keys)
+	avax.SortTransferableOutputs(outs, codec)
+
+	tx := &txs.Tx{Unsigned: &txs.BaseTx{BaseTx: avax.BaseTx{
+		NetworkID:    service.vm.ctx.NetworkID,
+		BlockchainID: service.vm.ctx.ChainID,
+		Outs:         outs,
+		Ins:          ins,
+	}}}
+	return tx, tx.SignSECP256K1Fx(codec, keys)
+}
+
+// partialConsolidationErr returns [err], which stopped a ConsolidateUTXOs call.
+// The txs issued before the failure are left in [reply] and also reported in
+// the error, so that the caller knows which UTXOs were already spent.
+func partialConsolidationErr(reply *ConsolidateUTXOsReply, err error) error {
+	if len(reply.TxIDs) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w (issued txs %v before failing)", err, reply.TxIDs)
+}
+
+// removeSpentUTXOs returns [utxos] without the UTXOs consumed by [ins]
+func removeSpentUTXOs(utxos []*avax.UTXO, ins []*avax.TransferableInput) []*avax.UTXO {
+	spent := ids.NewSet(len(ins))
+	for _, in := range ins {
+		spent.Add(in.InputID())
+	}
+	remaining := make([]*avax.UTXO, 0, len(utxos))
+	for _, utxo := range utxos {
+		if !spent.Contains(utxo.InputID()) {
+			remaining = append(remaining, utxo)
+		}
+	}
+	return remaining
+}
+
 // MintArgs are arguments for passing into Mint requests
 type MintArgs struct {
 	api.JSONSpendHeader             // User, password, from addrs, change addr
@@ -27,6 +27,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/sampler"
 	"github.com/ava-labs/avalanchego/version"
@@ -217,6 +218,37 @@ func TestServiceIssueTx(t *testing.T) {
 	}
 }
 
+func TestServiceIssueTxs(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, vm, s, _, _ := setup(t, true)
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	tx := NewTx(t, genesisBytes, vm)
+	txStr, err := formatting.Encode(formatting.Hex, tx.Bytes())
+	require.NoError(err)
+
+	reply := &IssueTxsReply{}
+	err = s.IssueTxs(nil, &IssueTxsArgs{
+		Txs:      []string{"0xinvalid", txStr},
+		Encoding: formatting.Hex,
+	}, reply)
+	require.NoError(err)
+	require.Len(reply.Results, 2)
+	require.NotEmpty(reply.Results[0].Error)
+	require.Empty(reply.Results[1].Error)
+	require.Equal(tx.ID(), reply.Results[1].TxID)
+
+	err = s.IssueTxs(nil, &IssueTxsArgs{
+		Txs:      make([]string, maxIssueTxsSize+1),
+		Encoding: formatting.Hex,
+	}, &IssueTxsReply{})
+	require.Error(err)
+}
+
 func TestServiceGetTxStatus(t *testing.T) {
 	genesisBytes, vm, s, _, _ := setup(t, true)
 	defer func() {
@@ -2263,7 +2295,7 @@ func TestCreateVariableCapAsset(t *testing.T) {
 					To:      fromAddrsStr[0],
 				},
 			}
-			sendReply := &api.JSONTxIDChangeAddr{}
+			sendReply := &api.JSONTxIDChangeAddrUnsignedTx{}
 			if err := s.Send(nil, sendArgs, sendReply); err != nil {
 				t.Fatalf("Failed to send newly minted variable cap asset due to: %s", err)
 			} else if sendReply.ChangeAddr != changeAddrStr {
@@ -2546,7 +2578,7 @@ func TestSend(t *testing.T) {
 			To:      addrStr,
 		},
 	}
-	reply := &api.JSONTxIDChangeAddr{}
+	reply := &api.JSONTxIDChangeAddrUnsignedTx{}
 	vm.timer.Cancel()
 	if err := s.Send(nil, args, reply); err != nil {
 		t.Fatalf("Failed to send transaction: %s", err)
@@ -2610,7 +2642,7 @@ func TestSendMultiple(t *testing.T) {
 					},
 				},
 			}
-			reply := &api.JSONTxIDChangeAddr{}
+			reply := &api.JSONTxIDChangeAddrUnsignedTx{}
 			vm.timer.Cancel()
 			if err := s.SendMultiple(nil, args, reply); err != nil {
 				t.Fatalf("Failed to send transaction: %s", err)
@@ -2634,6 +2666,199 @@ func TestSendMultiple(t *testing.T) {
 	}
 }
 
+func TestSendMultipleUnsigned(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, vm, s, _, genesisTx := setupWithKeys(t, tc.avaxAsset)
+			defer func() {
+				require.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+
+			assetID := genesisTx.ID()
+			addr := keys[0].PublicKey().Address()
+			addrStr, err := vm.FormatLocalAddress(addr)
+			require.NoError(err)
+
+			// No keystore user is needed to build an unsigned tx
+			args := &SendMultipleArgs{
+				JSONSpendHeader: api.JSONSpendHeader{
+					JSONFromAddrs: api.JSONFromAddrs{From: []string{addrStr}},
+				},
+				Outputs: []SendOutput{
+					{
+						Amount:  500,
+						AssetID: assetID.String(),
+						To:      addrStr,
+					},
+				},
+				Unsigned: true,
+			}
+			reply := &api.JSONTxIDChangeAddrUnsignedTx{}
+			vm.timer.Cancel()
+			require.NoError(s.SendMultiple(nil, args, reply))
+			require.Equal(ids.Empty, reply.TxID)
+			require.Equal(addrStr, reply.ChangeAddr)
+			require.Empty(vm.txs)
+			require.NotEmpty(reply.Signers)
+
+			unsignedBytes, err := formatting.Decode(formatting.Hex, reply.UnsignedTx)
+			require.NoError(err)
+			tx := &txs.Tx{}
+			_, err = vm.parser.Codec().Unmarshal(unsignedBytes, &tx.Unsigned)
+			require.NoError(err)
+
+			// Sign the tx as described by the signers
+			keysByAddr := make(map[string]*crypto.PrivateKeySECP256K1R)
+			for _, key := range keys {
+				keyAddrStr, err := vm.FormatLocalAddress(key.PublicKey().Address())
+				require.NoError(err)
+				keysByAddr[keyAddrStr] = key
+			}
+			sigHash, err := formatting.Encode(formatting.Hex, hashing.ComputeHash256(unsignedBytes))
+			require.NoError(err)
+			signers := make([][]*crypto.PrivateKeySECP256K1R, len(reply.Signers))
+			for i, signer := range reply.Signers {
+				require.Equal(json.Uint32(i), signer.InputIndex)
+				require.Equal(sigHash, signer.SigHash)
+				for _, signerAddr := range signer.Addresses {
+					signers[i] = append(signers[i], keysByAddr[signerAddr])
+				}
+			}
+			require.NoError(tx.SignSECP256K1Fx(vm.parser.Codec(), signers))
+
+			txID, err := vm.IssueTx(tx.Bytes())
+			require.NoError(err)
+			require.Len(vm.txs, 1)
+			require.Equal(txID, vm.txs[0].ID())
+		})
+	}
+}
+
+func TestConsolidateUTXOs(t *testing.T) {
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require := require.New(t)
+
+			_, vm, s, _, genesisTx := setupWithKeys(t, tc.avaxAsset)
+			defer func() {
+				require.NoError(vm.Shutdown())
+				vm.ctx.Lock.Unlock()
+			}()
+
+			assetID := genesisTx.ID()
+			changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+			require.NoError(err)
+
+			fromAddrsStr := make([]string, len(addrs))
+			for i, addr := range addrs {
+				fromAddrsStr[i], err = vm.FormatLocalAddress(addr)
+				require.NoError(err)
+			}
+
+			args := &ConsolidateUTXOsArgs{
+				JSONSpendHeader: api.JSONSpendHeader{
+					UserPass: api.UserPass{
+						Username: username,
+						Password: password,
+					},
+					JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+					JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+				},
+				AssetID:        assetID.String(),
+				MaxInputsPerTx: 2,
+			}
+			reply := &ConsolidateUTXOsReply{}
+			vm.timer.Cancel()
+			require.NoError(s.ConsolidateUTXOs(nil, args, reply))
+			require.Equal(changeAddrStr, reply.ChangeAddr)
+
+			// Each address holds a single UTXO of the asset, so the first two
+			// are merged and the last one is left alone.
+			require.Len(reply.TxIDs, 1)
+			require.EqualValues(2, reply.NumConsumed)
+
+			pendingTxs := vm.txs
+			require.Len(pendingTxs, 1)
+			require.Equal(reply.TxIDs[0], pendingTxs[0].ID())
+
+			tx := pendingTxs[0].(*UniqueTx).Tx
+			require.Len(tx.Unsigned.InputUTXOs(), 2)
+			outs := tx.UTXOs()
+			require.Len(outs, 1)
+			require.Equal(startBalance*2-testTxFee, outs[0].Out.(*secp256k1fx.TransferOutput).Amt)
+
+			args.MaxInputsPerTx = 1
+			err = s.ConsolidateUTXOs(nil, args, &ConsolidateUTXOsReply{})
+			require.ErrorIs(err, errTooFewInputsPerTx)
+		})
+	}
+}
+
+func TestConsolidateUTXOsPartialFailure(t *testing.T) {
+	require := require.New(t)
+
+	genesisBytes, vm, s, _, _ := setupWithKeys(t, false)
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Merge the asset that doesn't pay the fees. Give the first address a
+	// second UTXO of it, so that its 4 UTXOs are merged by 2 txs.
+	assetID := GetCreateTxFromGenesisTest(t, genesisBytes, otherAssetName).ID()
+	require.NoError(vm.state.PutUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: assetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: startBalance,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{addrs[0]},
+			},
+		},
+	}))
+
+	// The UTXOs of the fee asset only cover the fee of the first tx
+	vm.TxFee = 2 * startBalance
+
+	changeAddrStr, err := vm.FormatLocalAddress(testChangeAddr)
+	require.NoError(err)
+	fromAddrsStr := make([]string, len(addrs))
+	for i, addr := range addrs {
+		fromAddrsStr[i], err = vm.FormatLocalAddress(addr)
+		require.NoError(err)
+	}
+
+	args := &ConsolidateUTXOsArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass: api.UserPass{
+				Username: username,
+				Password: password,
+			},
+			JSONFromAddrs:  api.JSONFromAddrs{From: fromAddrsStr},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddrStr},
+		},
+		AssetID:        assetID.String(),
+		MaxInputsPerTx: 2,
+	}
+	reply := &ConsolidateUTXOsReply{}
+	vm.timer.Cancel()
+	err = s.ConsolidateUTXOs(nil, args, reply)
+	require.ErrorIs(err, errInsufficientFunds)
+
+	// The tx issued before the failure is reported
+	require.Len(reply.TxIDs, 1)
+	require.EqualValues(2, reply.NumConsumed)
+	require.Contains(err.Error(), reply.TxIDs[0].String())
+
+	pendingTxs := vm.txs
+	require.Len(pendingTxs, 1)
+	require.Equal(reply.TxIDs[0], pendingTxs[0].ID())
+}
+
 func TestCreateAndListAddresses(t *testing.T) {
 	_, vm, s, _, _ := setup(t, true)
 	defer func() {
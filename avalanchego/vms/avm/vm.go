@@ -296,7 +296,7 @@ func (vm *VM) Version() (string, error) {
 }
 
 func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
-	codec := json.NewCodec()
+	codec := json.NewCodecWithErrorMapper(mapServiceError)
 
 	rpcServer := rpc.NewServer()
 	rpcServer.RegisterCodec(codec, "application/json")
@@ -688,9 +688,15 @@ func (vm *VM) LoadUser(
 	return utxos, kc, user.Close()
 }
 
+// spender creates inputs consuming outputs along with the keys that must sign
+// them
+type spender interface {
+	Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error)
+}
+
 func (vm *VM) Spend(
 	utxos []*avax.UTXO,
-	kc *secp256k1fx.Keychain,
+	kc spender,
 	amounts map[ids.ID]uint64,
 ) (
 	map[ids.ID]uint64,
@@ -742,7 +748,8 @@ func (vm *VM) Spend(
 
 	for asset, amount := range amounts {
 		if amountsSpent[asset] < amount {
-			return nil, nil, nil, fmt.Errorf("want to spend %d of asset %s but only have %d",
+			return nil, nil, nil, fmt.Errorf("%w: want to spend %d of asset %s but only have %d",
+				errInsufficientFunds,
 				amount,
 				asset,
 				amountsSpent[asset],
@@ -1043,7 +1050,7 @@ func (vm *VM) lookupAssetID(asset string) (ids.ID, error) {
 	if assetID, err := ids.FromString(asset); err == nil {
 		return assetID, nil
 	}
-	return ids.ID{}, fmt.Errorf("asset '%s' not found", asset)
+	return ids.ID{}, fmt.Errorf("%w: '%s'", errUnknownAssetID, asset)
 }
 
 // This VM doesn't (currently) have any app-specific messages
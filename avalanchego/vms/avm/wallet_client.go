@@ -59,7 +59,7 @@ func NewWalletClient(uri, chain string) WalletClient {
 		chain,
 	)
 	return &walletClient{
-		requester: rpc.NewEndpointRequester(path, "wallet"),
+		requester: &errorMappingRequester{rpc.NewEndpointRequester(path, "wallet")},
 	}
 }
 
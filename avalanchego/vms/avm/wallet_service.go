@@ -124,6 +124,8 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 			l)
 	} else if len(args.Outputs) == 0 {
 		return errNoOutputs
+	} else if args.Unsigned {
+		return errWalletUnsigned
 	}
 
 	// Parse the from addresses
@@ -167,7 +169,7 @@ func (w *WalletService) SendMultiple(r *http.Request, args *SendMultipleArgs, re
 		if !ok {
 			assetID, err = w.vm.lookupAssetID(output.AssetID)
 			if err != nil {
-				return fmt.Errorf("couldn't find asset %s", output.AssetID)
+				return fmt.Errorf("couldn't find asset %s: %w", output.AssetID, err)
 			}
 			assetIDs[output.AssetID] = assetID
 		}
@@ -34,12 +34,12 @@ func buildApricotBlock(
 	}
 
 	// try rewarding stakers whose staking period ends at current chain time.
-	stakerTxID, shouldReward, err := builder.getNextStakerToReward(parentState.GetTimestamp(), parentState)
+	staker, shouldReward, err := builder.getNextStakerToReward(parentState.GetTimestamp(), parentState)
 	if err != nil {
 		return nil, fmt.Errorf("could not find next staker to reward: %w", err)
 	}
 	if shouldReward {
-		rewardValidatorTx, err := builder.txBuilder.NewRewardValidatorTx(stakerTxID)
+		rewardValidatorTx, err := builder.newRewardValidatorTx(staker, parentState)
 		if err != nil {
 			return nil, fmt.Errorf("could not build tx to reward staker: %w", err)
 		}
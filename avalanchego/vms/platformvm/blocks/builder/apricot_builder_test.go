@@ -20,6 +20,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/verify"
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
@@ -147,6 +148,9 @@ func TestBuildApricotBlock(t *testing.T) {
 
 				s.EXPECT().GetCurrentStakerIterator().Return(currentStakerIter, nil)
 
+				// the staker isn't a continuous validator
+				s.EXPECT().GetTx(stakerTxID).Return(&txs.Tx{Unsigned: &txs.AddDelegatorTx{}}, status.Committed, nil)
+
 				return s
 			},
 			expectedBlkF: func(require *require.Assertions) blocks.Block {
@@ -24,12 +24,12 @@ func buildBanffBlock(
 	// Try rewarding stakers whose staking period ends at the new chain time.
 	// This is done first to prioritize advancing the timestamp as quickly as
 	// possible.
-	stakerTxID, shouldReward, err := builder.getNextStakerToReward(timestamp, parentState)
+	staker, shouldReward, err := builder.getNextStakerToReward(timestamp, parentState)
 	if err != nil {
 		return nil, fmt.Errorf("could not find next staker to reward: %w", err)
 	}
 	if shouldReward {
-		rewardValidatorTx, err := builder.txBuilder.NewRewardValidatorTx(stakerTxID)
+		rewardValidatorTx, err := builder.newRewardValidatorTx(staker, parentState)
 		if err != nil {
 			return nil, fmt.Errorf("could not build tx to reward staker: %w", err)
 		}
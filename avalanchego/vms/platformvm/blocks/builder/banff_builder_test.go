@@ -23,6 +23,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/state"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
@@ -213,6 +214,9 @@ func TestBuildBanffBlock(t *testing.T) {
 				currentStakerIter.EXPECT().Release()
 
 				s.EXPECT().GetCurrentStakerIterator().Return(currentStakerIter, nil)
+
+				// the staker isn't a continuous validator
+				s.EXPECT().GetTx(stakerTxID).Return(&txs.Tx{Unsigned: &txs.AddDelegatorTx{}}, status.Committed, nil)
 				return s
 			},
 			expectedBlkF: func(require *require.Assertions) blocks.Block {
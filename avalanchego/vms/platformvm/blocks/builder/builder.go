@@ -10,6 +10,7 @@ import (
 
 	"go.uber.org/zap"
 
+	"github.com/ava-labs/avalanchego/cache"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
 	"github.com/ava-labs/avalanchego/snow/engine/common"
@@ -55,6 +56,10 @@ type Builder interface {
 	// next block
 	BuildBlock() (snowman.Block, error)
 
+	// Diagnose explains which mempool txs would be included in the next
+	// block, without modifying the mempool.
+	Diagnose() (*BlockDiagnostics, error)
+
 	// Shutdown cleanly shuts Builder down
 	Shutdown()
 }
@@ -78,6 +83,10 @@ type builder struct {
 	// the validator set. When it goes off ResetTimer() is called, potentially
 	// triggering creation of a new block.
 	timer *timer.Timer
+
+	// Key: Tx ID
+	// Value: txArrival of a tx recently added to the mempool
+	txArrivals *cache.LRU
 }
 
 func New(
@@ -94,6 +103,7 @@ func New(
 		txExecutorBackend: txExecutorBackend,
 		blkManager:        blkManager,
 		toEngine:          toEngine,
+		txArrivals:        &cache.LRU{Size: txArrivalsCacheSize},
 	}
 
 	builder.timer = timer.NewTimer(builder.setNextBuildBlockTime)
@@ -123,6 +133,12 @@ func (b *builder) Preferred() (snowman.Block, error) {
 
 // AddUnverifiedTx verifies a transaction and attempts to add it to the mempool
 func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
+	return b.addUnverifiedTx(tx, ids.EmptyNodeID)
+}
+
+// addUnverifiedTx verifies a transaction gossiped by [nodeID], or issued to
+// this node if [nodeID] is empty, and attempts to add it to the mempool.
+func (b *builder) addUnverifiedTx(tx *txs.Tx, nodeID ids.NodeID) error {
 	txID := tx.ID()
 	if b.Mempool.Has(txID) {
 		// If the transaction is already in the mempool - then it looks the same
@@ -144,6 +160,10 @@ func (b *builder) AddUnverifiedTx(tx *txs.Tx) error {
 	if err := b.Mempool.Add(tx); err != nil {
 		return err
 	}
+	b.txArrivals.Put(txID, txArrival{
+		receivedAt:   b.txExecutorBackend.Clk.Time(),
+		receivedFrom: nodeID,
+	})
 	return b.GossipTx(tx)
 }
 
@@ -172,16 +192,28 @@ func (b *builder) BuildBlock() (snowman.Block, error) {
 	return b.blkManager.NewBlock(statelessBlk), nil
 }
 
-// Returns the block we want to build and issue.
-// Only modifies state to remove expired proposal txs.
-func (b *builder) buildBlock() (blocks.Block, error) {
+// blockContext is the context of the next block built on top of the preferred
+// block.
+type blockContext struct {
+	parentID    ids.ID
+	height      uint64
+	parentState state.Chain
+	// min(max(now, parent timestamp), next staker change time)
+	timestamp time.Time
+	// timeWasCapped means that [timestamp] was reduced to the next staker
+	// change time.
+	timeWasCapped bool
+}
+
+// nextBlockContext returns the context of the next block built on top of the
+// preferred block.
+func (b *builder) nextBlockContext() (*blockContext, error) {
 	// Get the block to build on top of and retrieve the new block's context.
 	preferred, err := b.Preferred()
 	if err != nil {
 		return nil, err
 	}
 	preferredID := preferred.ID()
-	nextHeight := preferred.Height() + 1
 	preferredState, ok := b.blkManager.GetState(preferredID)
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", state.ErrMissingParentState, preferredID)
@@ -209,25 +241,42 @@ func (b *builder) buildBlock() (blocks.Block, error) {
 	}
 	// [timestamp] = min(max(now, parentTime), nextStakerChangeTime)
 
+	return &blockContext{
+		parentID:      preferredID,
+		height:        preferred.Height() + 1,
+		parentState:   preferredState,
+		timestamp:     timestamp,
+		timeWasCapped: timeWasCapped,
+	}, nil
+}
+
+// Returns the block we want to build and issue.
+// Only modifies state to remove expired proposal txs.
+func (b *builder) buildBlock() (blocks.Block, error) {
+	blkCtx, err := b.nextBlockContext()
+	if err != nil {
+		return nil, err
+	}
+
 	// If the banff timestamp has come, build banff blocks.
-	if b.txExecutorBackend.Config.IsBanffActivated(timestamp) {
+	if b.txExecutorBackend.Config.IsBanffActivated(blkCtx.timestamp) {
 		return buildBanffBlock(
 			b,
-			preferredID,
-			nextHeight,
-			timestamp,
-			timeWasCapped,
-			preferredState,
+			blkCtx.parentID,
+			blkCtx.height,
+			blkCtx.timestamp,
+			blkCtx.timeWasCapped,
+			blkCtx.parentState,
 		)
 	}
 
 	return buildApricotBlock(
 		b,
-		preferredID,
-		nextHeight,
-		timestamp,
-		timeWasCapped,
-		preferredState,
+		blkCtx.parentID,
+		blkCtx.height,
+		blkCtx.timestamp,
+		blkCtx.timeWasCapped,
+		blkCtx.parentState,
 	)
 }
 
@@ -246,25 +295,25 @@ func (b *builder) ResetBlockTimer() {
 	b.timer.SetTimeoutIn(0)
 }
 
-// getNextStakerToReward returns the next staker txID to remove from the staking
-// set with a RewardValidatorTx rather than an AdvanceTimeTx. [chainTimestamp]
-// is the timestamp of the chain at the time this validator would be getting
+// getNextStakerToReward returns the next staker to remove from the staking set
+// with a RewardValidatorTx rather than an AdvanceTimeTx. [chainTimestamp] is
+// the timestamp of the chain at the time this validator would be getting
 // removed and is used to calculate [shouldReward].
 // Returns:
-// - [txID] of the next staker to reward
-// - [shouldReward] if the txID exists and is ready to be rewarded
+// - [staker] the next staker to reward, or nil if there is none
+// - [shouldReward] if the staker exists and is ready to be rewarded
 // - [err] if something bad happened
 func (b *builder) getNextStakerToReward(
 	chainTimestamp time.Time,
 	preferredState state.Chain,
-) (ids.ID, bool, error) {
+) (*state.Staker, bool, error) {
 	if !chainTimestamp.Before(mockable.MaxTime) {
-		return ids.Empty, false, errEndOfTime
+		return nil, false, errEndOfTime
 	}
 
 	currentStakerIterator, err := preferredState.GetCurrentStakerIterator()
 	if err != nil {
-		return ids.Empty, false, err
+		return nil, false, err
 	}
 	defer currentStakerIterator.Release()
 
@@ -275,10 +324,25 @@ func (b *builder) getNextStakerToReward(
 		// validator), it's the next staker we will want to remove with a
 		// RewardValidatorTx rather than an AdvanceTimeTx.
 		if priority != txs.SubnetPermissionedValidatorCurrentPriority {
-			return currentStaker.TxID, chainTimestamp.Equal(currentStaker.EndTime), nil
+			return currentStaker, chainTimestamp.Equal(currentStaker.EndTime), nil
 		}
 	}
-	return ids.Empty, false, nil
+	return nil, false, nil
+}
+
+// newRewardValidatorTx returns the tx that rewards [staker] for its staking
+// period that is ending. Each staking period of a continuous validator is
+// rewarded by its own tx.
+func (b *builder) newRewardValidatorTx(staker *state.Staker, parentState state.Chain) (*txs.Tx, error) {
+	stakerTx, err := state.GetStakerTx(parentState, staker)
+	if err != nil {
+		return nil, err
+	}
+	continuousTx, ok := stakerTx.(*txs.AddContinuousValidatorTx)
+	if !ok {
+		return b.txBuilder.NewRewardValidatorTx(staker.TxID)
+	}
+	return b.txBuilder.NewRewardContinuousValidatorTx(staker.TxID, continuousTx.Period(staker.StartTime))
 }
 
 // dropExpiredStakerTxs drops add validator/delegator transactions in the
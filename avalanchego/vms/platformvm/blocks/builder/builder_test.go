@@ -279,12 +279,16 @@ func TestGetNextStakerToReward(t *testing.T) {
 
 			state := tt.stateF(ctrl)
 			b := builder{}
-			txID, shouldReward, err := b.getNextStakerToReward(tt.timestamp, state)
+			staker, shouldReward, err := b.getNextStakerToReward(tt.timestamp, state)
 			if tt.expectedErr != nil {
 				require.Equal(tt.expectedErr, err)
 				return
 			}
 			require.NoError(err)
+			txID := ids.Empty
+			if staker != nil {
+				txID = staker.TxID
+			}
 			require.Equal(tt.expectedTxID, txID)
 			require.Equal(tt.expectedShouldReward, shouldReward)
 		})
@@ -0,0 +1,259 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+// txArrivalsCacheSize is the number of recently added txs whose arrival is
+// remembered for the diagnostics
+const txArrivalsCacheSize = 4096
+
+// Reasons a mempool tx isn't included in the next block
+const (
+	SkipReasonSyncBound         = "start time is within the synchrony bound of the block timestamp"
+	SkipReasonReceivedLate      = "received through gossip after its start time reached the synchrony bound"
+	SkipReasonBlockFull         = "block size limit reached"
+	SkipReasonRewardStaker      = "block rewards a staker"
+	SkipReasonAdvanceTime       = "block advances the chain time"
+	SkipReasonDecisionTxsFirst  = "decision txs are included before staker txs"
+	SkipReasonOneStakerPerBlock = "only one staker tx is included per proposal block"
+	SkipReasonStartTooFar       = "start time is too far after the chain time, so the block advances the chain time"
+)
+
+// BlockDiagnostics explains which mempool txs would be included in the next
+// block built on top of the preferred block.
+type BlockDiagnostics struct {
+	ParentID  ids.ID
+	Height    uint64
+	Timestamp time.Time
+	// True if the next block is a Banff block
+	Banff bool
+	// ID of the staker rewarded by the next block, if any. Mempool txs aren't
+	// included in a block that rewards a staker.
+	RewardedStakerTxID ids.ID
+	// True if the next block only advances the chain time
+	AdvancesTime bool
+	// The mempool txs, in the order they are considered by the builder:
+	// decision txs by arrival, then staker txs by start time.
+	Txs []TxDiagnostics
+}
+
+// TxDiagnostics explains whether a mempool tx would be included in the next
+// block.
+type TxDiagnostics struct {
+	TxID ids.ID
+	// True if the tx would be included in the next block
+	Selected bool
+	// Why the tx wouldn't be included. Empty if [Selected].
+	SkipReason string
+	// Error returned by verifying the tx on top of the preferred block, such
+	// as a conflict with a processing tx. A selected tx that fails
+	// verification makes the block invalid.
+	VerificationError string
+	// Start time of a staker tx. Zero for decision txs.
+	StartTime time.Time
+	// When the tx was added to the mempool. Zero if unknown.
+	ReceivedAt time.Time
+	// Peer that gossiped the tx. Empty if the tx was issued to this node or
+	// if its arrival is unknown.
+	ReceivedFrom ids.NodeID
+}
+
+type txArrival struct {
+	receivedAt   time.Time
+	receivedFrom ids.NodeID
+}
+
+// Diagnose explains which mempool txs would be included in the next block,
+// without modifying the mempool.
+func (b *builder) Diagnose() (*BlockDiagnostics, error) {
+	blkCtx, err := b.nextBlockContext()
+	if err != nil {
+		return nil, err
+	}
+
+	// The decision txs are listed before the staker txs.
+	decisionTxs := b.Mempool.PeekApricotDecisionTxs(math.MaxInt)
+	allTxs := b.Mempool.PeekTxs(math.MaxInt)
+	stakerTxs := allTxs[len(decisionTxs):]
+
+	d := &BlockDiagnostics{
+		ParentID:  blkCtx.parentID,
+		Height:    blkCtx.height,
+		Timestamp: blkCtx.timestamp,
+		Banff:     b.txExecutorBackend.Config.IsBanffActivated(blkCtx.timestamp),
+		Txs:       make([]TxDiagnostics, len(allTxs)),
+	}
+	for i, tx := range allTxs {
+		d.Txs[i] = b.diagnoseTx(blkCtx, tx)
+	}
+	decisionDiagnostics := d.Txs[:len(decisionTxs)]
+	stakerDiagnostics := d.Txs[len(decisionTxs):]
+
+	if d.Banff {
+		return d, b.diagnoseBanffBlock(d, blkCtx, allTxs)
+	}
+	return d, b.diagnoseApricotBlock(d, blkCtx, decisionTxs, stakerTxs, decisionDiagnostics, stakerDiagnostics)
+}
+
+// Mirrors [buildBanffBlock].
+func (b *builder) diagnoseBanffBlock(d *BlockDiagnostics, blkCtx *blockContext, allTxs []*txs.Tx) error {
+	staker, shouldReward, err := b.getNextStakerToReward(blkCtx.timestamp, blkCtx.parentState)
+	if err != nil {
+		return fmt.Errorf("could not find next staker to reward: %w", err)
+	}
+	if shouldReward {
+		d.RewardedStakerTxID = staker.TxID
+		skipAll(d.Txs, SkipReasonRewardStaker)
+		return nil
+	}
+
+	// Expired staker txs are dropped before the txs are selected.
+	for i := range d.Txs {
+		d.Txs[i].SkipReason = syncBoundSkipReason(blkCtx, &d.Txs[i])
+	}
+
+	size, numSelected := 0, 0
+	for i, tx := range allTxs {
+		txDiagnostics := &d.Txs[i]
+		if txDiagnostics.SkipReason != "" {
+			continue
+		}
+		// Mirrors [PeekTxs], which stops at the first tx that doesn't fit.
+		size += len(tx.Bytes())
+		if size > targetBlockSize {
+			skipAll(d.Txs[i:], SkipReasonBlockFull)
+			break
+		}
+		txDiagnostics.Selected = true
+		numSelected++
+	}
+	d.AdvancesTime = blkCtx.timeWasCapped && numSelected == 0
+	return nil
+}
+
+// Mirrors [buildApricotBlock].
+func (b *builder) diagnoseApricotBlock(
+	d *BlockDiagnostics,
+	blkCtx *blockContext,
+	decisionTxs []*txs.Tx,
+	stakerTxs []*txs.Tx,
+	decisionDiagnostics []TxDiagnostics,
+	stakerDiagnostics []TxDiagnostics,
+) error {
+	if len(decisionTxs) > 0 {
+		size := 0
+		for i, tx := range decisionTxs {
+			size += len(tx.Bytes())
+			if size > targetBlockSize {
+				skipAll(decisionDiagnostics[i:], SkipReasonBlockFull)
+				break
+			}
+			decisionDiagnostics[i].Selected = true
+		}
+		skipAll(stakerDiagnostics, SkipReasonDecisionTxsFirst)
+		return nil
+	}
+
+	staker, shouldReward, err := b.getNextStakerToReward(blkCtx.parentState.GetTimestamp(), blkCtx.parentState)
+	if err != nil {
+		return fmt.Errorf("could not find next staker to reward: %w", err)
+	}
+	if shouldReward {
+		d.RewardedStakerTxID = staker.TxID
+		skipAll(stakerDiagnostics, SkipReasonRewardStaker)
+		return nil
+	}
+	if blkCtx.timeWasCapped {
+		d.AdvancesTime = true
+		skipAll(stakerDiagnostics, SkipReasonAdvanceTime)
+		return nil
+	}
+
+	maxChainStartTime := blkCtx.parentState.GetTimestamp().Add(txexecutor.MaxFutureStartTime)
+	// Expired staker txs are dropped before the tx is selected.
+	for i := range stakerDiagnostics {
+		stakerDiagnostics[i].SkipReason = syncBoundSkipReason(blkCtx, &stakerDiagnostics[i])
+	}
+	for i, tx := range stakerTxs {
+		txDiagnostics := &stakerDiagnostics[i]
+		if txDiagnostics.SkipReason != "" {
+			continue
+		}
+		// Only the first staker tx that wasn't dropped may be issued.
+		if tx.Unsigned.(txs.Staker).StartTime().After(maxChainStartTime) {
+			d.AdvancesTime = true
+			txDiagnostics.SkipReason = SkipReasonStartTooFar
+		} else {
+			txDiagnostics.Selected = true
+		}
+		skipAll(stakerDiagnostics[i+1:], SkipReasonOneStakerPerBlock)
+		break
+	}
+	return nil
+}
+
+// diagnoseTx returns the diagnostics of [tx] that don't depend on the other
+// mempool txs.
+func (b *builder) diagnoseTx(blkCtx *blockContext, tx *txs.Tx) TxDiagnostics {
+	txID := tx.ID()
+	txDiagnostics := TxDiagnostics{
+		TxID: txID,
+	}
+	if arrivalIntf, ok := b.txArrivals.Get(txID); ok {
+		arrival := arrivalIntf.(txArrival)
+		txDiagnostics.ReceivedAt = arrival.receivedAt
+		txDiagnostics.ReceivedFrom = arrival.receivedFrom
+	}
+
+	verifier := txexecutor.MempoolTxVerifier{
+		Backend:       b.txExecutorBackend,
+		ParentID:      blkCtx.parentID,
+		StateVersions: b.blkManager,
+		Tx:            tx,
+	}
+	if err := tx.Unsigned.Visit(&verifier); err != nil {
+		txDiagnostics.VerificationError = err.Error()
+	}
+
+	if staker, ok := tx.Unsigned.(txs.Staker); ok {
+		txDiagnostics.StartTime = staker.StartTime()
+	}
+	return txDiagnostics
+}
+
+// syncBoundSkipReason returns why the staker tx of [txDiagnostics] is dropped
+// by [dropExpiredStakerTxs], or the empty string if it isn't dropped.
+func syncBoundSkipReason(blkCtx *blockContext, txDiagnostics *TxDiagnostics) string {
+	if txDiagnostics.StartTime.IsZero() {
+		return ""
+	}
+	syncBound := txDiagnostics.StartTime.Add(-txexecutor.SyncBound)
+	if !syncBound.Before(blkCtx.timestamp) {
+		return ""
+	}
+	if txDiagnostics.ReceivedFrom != ids.EmptyNodeID && txDiagnostics.ReceivedAt.After(syncBound) {
+		return SkipReasonReceivedLate
+	}
+	return SkipReasonSyncBound
+}
+
+// skipAll marks the txs of [txsDiagnostics] that weren't already skipped as
+// skipped for [reason].
+func skipAll(txsDiagnostics []TxDiagnostics, reason string) {
+	for i := range txsDiagnostics {
+		if txsDiagnostics[i].SkipReason == "" {
+			txsDiagnostics[i].SkipReason = reason
+		}
+	}
+}
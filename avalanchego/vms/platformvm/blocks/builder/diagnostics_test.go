@@ -0,0 +1,107 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks/executor"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+func TestDiagnoseBanffBlock(t *testing.T) {
+	require := require.New(t)
+
+	env := newEnvironment(t)
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+
+	chainTime := env.state.GetTimestamp()
+	env.clk.Set(chainTime)
+	env.config.BanffTime = chainTime
+	env.sender.SendAppGossipF = func([]byte) error { return nil }
+
+	decisionTx := getValidTx(env.txBuilder, t)
+	require.NoError(env.Builder.AddUnverifiedTx(decisionTx))
+
+	validatorTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(chainTime.Add(2*txexecutor.SyncBound).Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[1]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	require.NoError(env.Builder.AddUnverifiedTx(validatorTx))
+
+	// The start time of this validator is already within the synchrony bound
+	// when the tx is gossiped to this node.
+	lateValidatorTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake,
+		uint64(chainTime.Add(txexecutor.SyncBound/2).Unix()),
+		uint64(defaultValidateEndTime.Unix()),
+		ids.GenerateTestNodeID(),
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[2]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	nodeID := ids.GenerateTestNodeID()
+	b := env.Builder.(*builder)
+	require.NoError(env.mempool.Add(lateValidatorTx))
+	b.txArrivals.Put(lateValidatorTx.ID(), txArrival{
+		receivedAt:   chainTime,
+		receivedFrom: nodeID,
+	})
+
+	d, err := env.Builder.Diagnose()
+	require.NoError(err)
+	require.True(d.Banff)
+	require.Equal(chainTime, d.Timestamp)
+	require.Equal(ids.Empty, d.RewardedStakerTxID)
+	require.False(d.AdvancesTime)
+	require.Len(d.Txs, 3)
+
+	// Decision txs are considered before staker txs, which are ordered by
+	// start time.
+	require.Equal(decisionTx.ID(), d.Txs[0].TxID)
+	require.True(d.Txs[0].Selected)
+	require.Empty(d.Txs[0].VerificationError)
+	require.Equal(ids.EmptyNodeID, d.Txs[0].ReceivedFrom)
+	require.Equal(chainTime, d.Txs[0].ReceivedAt)
+
+	require.Equal(lateValidatorTx.ID(), d.Txs[1].TxID)
+	require.False(d.Txs[1].Selected)
+	require.Equal(SkipReasonReceivedLate, d.Txs[1].SkipReason)
+	require.Equal(nodeID, d.Txs[1].ReceivedFrom)
+
+	require.Equal(validatorTx.ID(), d.Txs[2].TxID)
+	require.True(d.Txs[2].Selected)
+	require.Empty(d.Txs[2].SkipReason)
+
+	// The diagnostics don't modify the mempool.
+	require.True(env.mempool.Has(lateValidatorTx.ID()))
+
+	// The built block includes the selected txs.
+	blkIntf, err := env.Builder.BuildBlock()
+	require.NoError(err)
+	blk, ok := blkIntf.(*executor.Block)
+	require.True(ok)
+	blkTxs := blk.Txs()
+	require.Len(blkTxs, 2)
+	require.Equal(decisionTx.ID(), blkTxs[0].ID())
+	require.Equal(validatorTx.ID(), blkTxs[1].ID())
+}
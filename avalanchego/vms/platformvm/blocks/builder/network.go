@@ -36,7 +36,7 @@ type Network interface {
 
 type network struct {
 	ctx        *snow.Context
-	blkBuilder Builder
+	blkBuilder *builder
 
 	// gossip related attributes
 	appSender common.AppSender
@@ -119,7 +119,7 @@ func (n *network) AppGossip(nodeID ids.NodeID, msgBytes []byte) error {
 	}
 
 	// add to mempool
-	if err = n.blkBuilder.AddUnverifiedTx(tx); err != nil {
+	if err = n.blkBuilder.addUnverifiedTx(tx, nodeID); err != nil {
 		n.ctx.Log.Debug("tx failed verification",
 			zap.Stringer("nodeID", nodeID),
 			zap.Error(err),
@@ -36,6 +36,8 @@ func init() {
 			RegisterApricotBlockTypes(c),
 			txs.RegisterUnsignedTxsTypes(c),
 			RegisterBanffBlockTypes(c),
+			txs.RegisterContinuousStakingTypes(c),
+			txs.RegisterBatchDelegationTypes(c),
 		)
 	}
 	errs.Add(
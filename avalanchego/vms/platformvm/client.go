@@ -15,6 +15,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/rpc"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 
 	platformapi "github.com/ava-labs/avalanchego/vms/platformvm/api"
@@ -45,6 +46,12 @@ type Client interface {
 		startUTXOID ids.ID,
 		options ...rpc.Option,
 	) ([][]byte, ids.ShortID, ids.ID, error)
+	// GetAllUTXOs returns the byte representation of all the UTXOs controlled
+	// by [addrs], fetched in pages of at most [limit] UTXOs.
+	GetAllUTXOs(ctx context.Context, addrs []ids.ShortID, limit uint32, options ...rpc.Option) ([][]byte, error)
+	// StreamUTXOs fetches the UTXOs controlled by [addrs] in pages of at most
+	// [limit] UTXOs and sends each page on the returned channel.
+	StreamUTXOs(ctx context.Context, addrs []ids.ShortID, limit uint32, options ...rpc.Option) <-chan api.UTXOBatch
 	// GetAtomicUTXOs returns the byte representation of the atomic UTXOs controlled by [addrs]
 	// from [sourceChain]
 	GetAtomicUTXOs(
@@ -98,6 +105,19 @@ type Client interface {
 		endTime uint64,
 		options ...rpc.Option,
 	) (ids.ID, error)
+	// AddDelegators issues a transaction to add delegators to several validators
+	// of the primary network and returns the txID
+	AddDelegators(
+		ctx context.Context,
+		user api.UserPass,
+		from []ids.ShortID,
+		changeAddr ids.ShortID,
+		rewardAddress ids.ShortID,
+		delegations []APIDelegation,
+		startTime,
+		endTime uint64,
+		options ...rpc.Option,
+	) (ids.ID, error)
 	// AddSubnetValidator issues a transaction to add validator [nodeID] to subnet
 	// with ID [subnetID] and returns the txID
 	AddSubnetValidator(
@@ -181,6 +201,16 @@ type Client interface {
 	// GetStake returns the amount of nAVAX that [addrs] have cumulatively
 	// staked on the Primary Network.
 	GetStake(ctx context.Context, addrs []ids.ShortID, options ...rpc.Option) (map[ids.ID]uint64, [][]byte, error)
+	// GetBalanceAt returns the amount of nAVAX that [addr] owned once the
+	// block at [height] was accepted
+	GetBalanceAt(ctx context.Context, addr ids.ShortID, height uint64, options ...rpc.Option) (uint64, error)
+	// GetStakeAt returns the amount of nAVAX that [addr] had staked once the
+	// block at [height] was accepted
+	GetStakeAt(ctx context.Context, addr ids.ShortID, height uint64, options ...rpc.Option) (uint64, error)
+	// GetStakerHistory returns up to [limit] lifecycle events of the validator
+	// [nodeID] starting at [startHeight], and the height to start the next
+	// page at if more events may be available
+	GetStakerHistory(ctx context.Context, nodeID ids.NodeID, startHeight uint64, limit uint32, options ...rpc.Option) ([]APIStakerEvent, *uint64, error)
 	// GetMinStake returns the minimum staking amount in nAVAX for validators
 	// and delegators respectively
 	GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error)
@@ -198,11 +228,24 @@ type Client interface {
 	) (uint64, error)
 	// GetRewardUTXOs returns the reward UTXOs for a transaction
 	GetRewardUTXOs(context.Context, *api.GetTxArgs, ...rpc.Option) ([][]byte, error)
+	// GetRewardContext returns the inputs the reward of the staker added by
+	// [txID] was calculated from
+	GetRewardContext(ctx context.Context, txID ids.ID, options ...rpc.Option) (*reward.Context, error)
 	// GetTimestamp returns the current chain timestamp
 	GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error)
+	// GetNextBlockDiagnostics explains which mempool txs would be included in
+	// the next block built by the node, and why the other txs would be skipped
+	GetNextBlockDiagnostics(ctx context.Context, options ...rpc.Option) (*GetNextBlockDiagnosticsReply, error)
+	// EstimateFee returns the fee, in nAVAX, that a tx of [txType] must burn if
+	// it is executed at [timestamp]. If [timestamp] is zero, the current chain
+	// timestamp is used. [subnetID] is only used by permissionless staker txs.
+	EstimateFee(ctx context.Context, txType string, subnetID ids.ID, timestamp time.Time, options ...rpc.Option) (uint64, error)
 	// GetValidatorsAt returns the weights of the validator set of a provided subnet
 	// at the specified height.
 	GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error)
+	// GetValidatorSetDiff returns the validators of a provided subnet whose
+	// weight changed between [startHeight] and [endHeight].
+	GetValidatorSetDiff(ctx context.Context, subnetID ids.ID, startHeight, endHeight uint64, options ...rpc.Option) ([]ValidatorWeightChange, error)
 	// GetBlock returns the block with the given id.
 	GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error)
 }
@@ -284,6 +327,35 @@ func (c *client) GetUTXOs(
 	return c.GetAtomicUTXOs(ctx, addrs, "", limit, startAddress, startUTXOID, options...)
 }
 
+func (c *client) GetAllUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	limit uint32,
+	options ...rpc.Option,
+) ([][]byte, error) {
+	return api.GetAllUTXOs(ctx, c.utxoPageFetcher(addrs, options), limit)
+}
+
+func (c *client) StreamUTXOs(
+	ctx context.Context,
+	addrs []ids.ShortID,
+	limit uint32,
+	options ...rpc.Option,
+) <-chan api.UTXOBatch {
+	return api.StreamUTXOs(ctx, c.utxoPageFetcher(addrs, options), limit)
+}
+
+func (c *client) utxoPageFetcher(addrs []ids.ShortID, options []rpc.Option) api.UTXOPageFetcher {
+	return func(
+		ctx context.Context,
+		limit uint32,
+		startAddress ids.ShortID,
+		startUTXOID ids.ID,
+	) ([][]byte, ids.ShortID, ids.ID, error) {
+		return c.GetUTXOs(ctx, addrs, limit, startAddress, startUTXOID, options...)
+	}
+}
+
 func (c *client) GetAtomicUTXOs(
 	ctx context.Context,
 	addrs []ids.ShortID,
@@ -479,6 +551,32 @@ func (c *client) AddDelegator(
 	return res.TxID, err
 }
 
+func (c *client) AddDelegators(
+	ctx context.Context,
+	user api.UserPass,
+	from []ids.ShortID,
+	changeAddr ids.ShortID,
+	rewardAddress ids.ShortID,
+	delegations []APIDelegation,
+	startTime,
+	endTime uint64,
+	options ...rpc.Option,
+) (ids.ID, error) {
+	res := &api.JSONTxID{}
+	err := c.requester.SendRequest(ctx, "addDelegators", &AddDelegatorsArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			UserPass:       user,
+			JSONFromAddrs:  api.JSONFromAddrs{From: ids.ShortIDsToStrings(from)},
+			JSONChangeAddr: api.JSONChangeAddr{ChangeAddr: changeAddr.String()},
+		},
+		StartTime:     json.Uint64(startTime),
+		EndTime:       json.Uint64(endTime),
+		Delegations:   delegations,
+		RewardAddress: rewardAddress.String(),
+	}, res, options...)
+	return res.TxID, err
+}
+
 func (c *client) AddSubnetValidator(
 	ctx context.Context,
 	user api.UserPass,
@@ -733,6 +831,44 @@ func (c *client) GetStake(ctx context.Context, addrs []ids.ShortID, options ...r
 	return staked, outputs, err
 }
 
+func (c *client) GetBalanceAt(ctx context.Context, addr ids.ShortID, height uint64, options ...rpc.Option) (uint64, error) {
+	res := &GetBalanceAtReply{}
+	err := c.requester.SendRequest(ctx, "getBalanceAt", &GetAtHeightArgs{
+		Address: addr.String(),
+		Height:  json.Uint64(height),
+	}, res, options...)
+	return uint64(res.Balance), err
+}
+
+func (c *client) GetStakeAt(ctx context.Context, addr ids.ShortID, height uint64, options ...rpc.Option) (uint64, error) {
+	res := &GetStakeAtReply{}
+	err := c.requester.SendRequest(ctx, "getStakeAt", &GetAtHeightArgs{
+		Address: addr.String(),
+		Height:  json.Uint64(height),
+	}, res, options...)
+	return uint64(res.Staked), err
+}
+
+func (c *client) GetStakerHistory(
+	ctx context.Context,
+	nodeID ids.NodeID,
+	startHeight uint64,
+	limit uint32,
+	options ...rpc.Option,
+) ([]APIStakerEvent, *uint64, error) {
+	res := &GetStakerHistoryReply{}
+	err := c.requester.SendRequest(ctx, "getStakerHistory", &GetStakerHistoryArgs{
+		NodeID:      nodeID,
+		StartHeight: json.Uint64(startHeight),
+		Limit:       json.Uint32(limit),
+	}, res, options...)
+	if err != nil || res.NextHeight == nil {
+		return res.Events, nil, err
+	}
+	nextHeight := uint64(*res.NextHeight)
+	return res.Events, &nextHeight, nil
+}
+
 func (c *client) GetMinStake(ctx context.Context, subnetID ids.ID, options ...rpc.Option) (uint64, uint64, error) {
 	res := new(GetMinStakeReply)
 	err := c.requester.SendRequest(ctx, "getMinStake", &GetMinStakeArgs{
@@ -783,12 +919,56 @@ func (c *client) GetRewardUTXOs(ctx context.Context, args *api.GetTxArgs, option
 	return utxos, err
 }
 
+func (c *client) GetRewardContext(ctx context.Context, txID ids.ID, options ...rpc.Option) (*reward.Context, error) {
+	res := &GetRewardContextReply{}
+	err := c.requester.SendRequest(ctx, "getRewardContext", &GetRewardContextArgs{
+		TxID: txID,
+	}, res, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &reward.Context{
+		SubnetID:       res.SubnetID,
+		StakedAmount:   uint64(res.StakedAmount),
+		StakedDuration: time.Duration(res.StakedDuration),
+		CurrentSupply:  uint64(res.CurrentSupply),
+		Config: reward.Config{
+			MaxConsumptionRate: uint64(res.MaxConsumptionRate),
+			MinConsumptionRate: uint64(res.MinConsumptionRate),
+			MintingPeriod:      time.Duration(res.MintingPeriod),
+			SupplyCap:          uint64(res.SupplyCap),
+		},
+		PotentialReward: uint64(res.PotentialReward),
+		Removed:         res.Removed,
+		Rewarded:        res.Rewarded,
+	}, nil
+}
+
+func (c *client) EstimateFee(ctx context.Context, txType string, subnetID ids.ID, timestamp time.Time, options ...rpc.Option) (uint64, error) {
+	args := &EstimateFeeArgs{
+		TxType:   txType,
+		SubnetID: subnetID,
+	}
+	if !timestamp.IsZero() {
+		args.Timestamp = json.Uint64(timestamp.Unix())
+	}
+	res := &EstimateFeeReply{}
+	err := c.requester.SendRequest(ctx, "estimateFee", args, res, options...)
+	return uint64(res.Fee), err
+}
+
 func (c *client) GetTimestamp(ctx context.Context, options ...rpc.Option) (time.Time, error) {
 	res := &GetTimestampReply{}
 	err := c.requester.SendRequest(ctx, "getTimestamp", struct{}{}, res, options...)
 	return res.Timestamp, err
 }
 
+func (c *client) GetNextBlockDiagnostics(ctx context.Context, options ...rpc.Option) (*GetNextBlockDiagnosticsReply, error) {
+	res := &GetNextBlockDiagnosticsReply{}
+	err := c.requester.SendRequest(ctx, "getNextBlockDiagnostics", struct{}{}, res, options...)
+	return res, err
+}
+
 func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height uint64, options ...rpc.Option) (map[ids.NodeID]uint64, error) {
 	res := &GetValidatorsAtReply{}
 	err := c.requester.SendRequest(ctx, "getValidatorsAt", &GetValidatorsAtArgs{
@@ -798,6 +978,16 @@ func (c *client) GetValidatorsAt(ctx context.Context, subnetID ids.ID, height ui
 	return res.Validators, err
 }
 
+func (c *client) GetValidatorSetDiff(ctx context.Context, subnetID ids.ID, startHeight, endHeight uint64, options ...rpc.Option) ([]ValidatorWeightChange, error) {
+	res := &GetValidatorSetDiffReply{}
+	err := c.requester.SendRequest(ctx, "getValidatorSetDiff", &GetValidatorSetDiffArgs{
+		SubnetID:    subnetID,
+		StartHeight: json.Uint64(startHeight),
+		EndHeight:   json.Uint64(endHeight),
+	}, res, options...)
+	return res.Changes, err
+}
+
 func (c *client) GetBlock(ctx context.Context, blockID ids.ID, options ...rpc.Option) ([]byte, error) {
 	response := &api.FormattedBlock{}
 	if err := c.requester.SendRequest(ctx, "getBlock", &api.GetBlockArgs{
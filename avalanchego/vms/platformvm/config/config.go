@@ -95,6 +95,23 @@ type Config struct {
 
 	// Time of the Banff network upgrade
 	BanffTime time.Time
+
+	// Time continuous validators can be added from
+	ContinuousStakingTime time.Time
+
+	// Time delegations can be batched from
+	BatchDelegationTime time.Time
+
+	// True if the balance and stake of every address should be indexed by
+	// height. Can only be enabled on a database that was initialized with it.
+	HistoricalStateIndexEnabled bool
+
+	// Deletes the old data that isn't needed to verify new blocks
+	Pruning PruningConfig
+
+	// Overrides of the built in network specific parameters, keyed by network
+	// ID
+	NetworkParams map[uint32]NetworkParams
 }
 
 func (c *Config) IsApricotPhase3Activated(timestamp time.Time) bool {
@@ -109,6 +126,14 @@ func (c *Config) IsBanffActivated(timestamp time.Time) bool {
 	return !timestamp.Before(c.BanffTime)
 }
 
+func (c *Config) IsContinuousStakingActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.ContinuousStakingTime)
+}
+
+func (c *Config) IsBatchDelegationActivated(timestamp time.Time) bool {
+	return !timestamp.Before(c.BatchDelegationTime)
+}
+
 func (c *Config) GetCreateBlockchainTxFee(timestamp time.Time) uint64 {
 	if c.IsApricotPhase3Activated(timestamp) {
 		return c.CreateBlockchainTxFee
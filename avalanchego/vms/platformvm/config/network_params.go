@@ -0,0 +1,91 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+)
+
+// InflationSettings are the staking rules of the primary network
+type InflationSettings struct {
+	MinValidatorStake        uint64        `json:"minValidatorStake"`
+	MaxValidatorStake        uint64        `json:"maxValidatorStake"`
+	MinDelegatorStake        uint64        `json:"minDelegatorStake"`
+	MinDelegationFee         uint32        `json:"minDelegationFee"`
+	MinStakeDuration         time.Duration `json:"minStakeDuration"`
+	MinDelegateDuration      time.Duration `json:"minDelegateDuration"`
+	MaxStakeDuration         time.Duration `json:"maxStakeDuration"`
+	MinFutureStartTimeOffset time.Duration `json:"minFutureStartTimeOffset"` // Will not be checked when addPermissionlessValidator tx is used
+	MaxValidatorWeightFactor uint64        `json:"maxValidatorWeightFactor"`
+	MinStakeStartTime        time.Time     `json:"minStakeStartTime"`
+}
+
+// StakingPhase is a set of staking rules that applies from [Start] until the
+// next phase starts
+type StakingPhase struct {
+	Start time.Time `json:"start"`
+	InflationSettings
+}
+
+// NetworkParams are the rules of the P-chain that differ between networks
+type NetworkParams struct {
+	// If true, subnet validators and permissionless stakers can't be added
+	RestrictStakingTxs bool `json:"restrictStakingTxs"`
+	// If [RestrictStakingTxs] is set, primary network validators can still be
+	// added with an AddPermissionlessValidatorTx, which registers their BLS
+	// key, from this time on. The zero time never allows it.
+	BLSValidatorsTime time.Time `json:"blsValidatorsTime"`
+	// Staking rules, ordered by their start time. Before the first phase
+	// starts, the staking rules of the [Config] apply.
+	StakingPhases []StakingPhase `json:"stakingPhases"`
+}
+
+// AllowsBLSValidators returns true if a primary network validator can be added
+// with an AddPermissionlessValidatorTx at [timestamp]
+func (p *NetworkParams) AllowsBLSValidators(timestamp time.Time) bool {
+	if !p.RestrictStakingTxs {
+		return true
+	}
+	return !p.BLSValidatorsTime.IsZero() && !timestamp.Before(p.BLSValidatorsTime)
+}
+
+// InflationSettings returns the staking rules that apply at [timestamp], or
+// [defaults] if no phase has started yet
+func (p *NetworkParams) InflationSettings(timestamp time.Time, defaults InflationSettings) InflationSettings {
+	settings := defaults
+	for _, phase := range p.StakingPhases {
+		if timestamp.Before(phase.Start) {
+			break
+		}
+		settings = phase.InflationSettings
+	}
+	return settings
+}
+
+// ParseNetworkParams parses a JSON object of network ID to [NetworkParams].
+// The parameters of the networks that have built in parameters can't be
+// overridden.
+func ParseNetworkParams(paramsBytes []byte) (map[uint32]NetworkParams, error) {
+	params := make(map[uint32]NetworkParams)
+	if err := json.Unmarshal(paramsBytes, &params); err != nil {
+		return nil, err
+	}
+	for networkID, networkParams := range params {
+		switch networkID {
+		case constants.FlareID, constants.CostwoID, constants.LocalFlareID, constants.StagingID,
+			constants.SongbirdID, constants.CostonID, constants.LocalID:
+			return nil, fmt.Errorf("can't override the parameters of network %q", constants.NetworkName(networkID))
+		}
+		for i := 1; i < len(networkParams.StakingPhases); i++ {
+			if networkParams.StakingPhases[i].Start.Before(networkParams.StakingPhases[i-1].Start) {
+				return nil, fmt.Errorf("staking phases of network %d aren't ordered by start time", networkID)
+			}
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,41 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package config
+
+import (
+	"time"
+)
+
+// PruningConfig configures the deletion of the P-chain data that isn't needed
+// to verify new blocks. Pruning runs when the chain starts.
+//
+// Data is only pruned once it was accepted more than [RetainedBlocks] blocks
+// and [RetainedAge] before the last accepted block.
+type PruningConfig struct {
+	// True if the reward UTXOs of the stakers that were rewarded before the
+	// retention bounds should be deleted. Their reward UTXOs are no longer
+	// returned by the API.
+	RewardUTXOs bool `json:"rewardUTXOs"`
+
+	// True if the txs accepted before the retention bounds should be deleted.
+	// The txs that create subnets and chains, transform subnets and add the
+	// current and pending stakers are always kept. The deleted txs are no
+	// longer returned by the API.
+	Txs bool `json:"txs"`
+
+	// Number of most recently accepted blocks whose data is kept
+	RetainedBlocks uint64 `json:"retainedBlocks"`
+
+	// Data accepted less than [RetainedAge] before the last accepted block is
+	// kept
+	RetainedAge time.Duration `json:"retainedAge"`
+
+	// If true, the data that would be pruned is only reported, not deleted
+	DryRun bool `json:"dryRun"`
+}
+
+// Enabled returns true if any data should be pruned.
+func (c *PruningConfig) Enabled() bool {
+	return c.RewardUTXOs || c.Txs
+}
@@ -27,7 +27,10 @@ type txMetrics struct {
 	numRemoveSubnetValidatorTxs,
 	numTransformSubnetTxs,
 	numAddPermissionlessValidatorTxs,
-	numAddPermissionlessDelegatorTxs prometheus.Counter
+	numAddPermissionlessDelegatorTxs,
+	numAddContinuousValidatorTxs,
+	numRewardContinuousValidatorTxs,
+	numAddDelegatorsTxs prometheus.Counter
 }
 
 func newTxMetrics(
@@ -49,6 +52,9 @@ func newTxMetrics(
 		numTransformSubnetTxs:            newTxMetric(namespace, "transform_subnet", registerer, &errs),
 		numAddPermissionlessValidatorTxs: newTxMetric(namespace, "add_permissionless_validator", registerer, &errs),
 		numAddPermissionlessDelegatorTxs: newTxMetric(namespace, "add_permissionless_delegator", registerer, &errs),
+		numAddContinuousValidatorTxs:     newTxMetric(namespace, "add_continuous_validator", registerer, &errs),
+		numRewardContinuousValidatorTxs:  newTxMetric(namespace, "reward_continuous_validator", registerer, &errs),
+		numAddDelegatorsTxs:              newTxMetric(namespace, "add_delegators", registerer, &errs),
 	}
 	return m, errs.Err
 }
@@ -132,3 +138,18 @@ func (m *txMetrics) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDelegator
 	m.numAddPermissionlessDelegatorTxs.Inc()
 	return nil
 }
+
+func (m *txMetrics) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	m.numAddContinuousValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	m.numRewardContinuousValidatorTxs.Inc()
+	return nil
+}
+
+func (m *txMetrics) AddDelegatorsTx(*txs.AddDelegatorsTx) error {
+	m.numAddDelegatorsTxs.Inc()
+	return nil
+}
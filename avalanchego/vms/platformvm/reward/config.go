@@ -18,17 +18,17 @@ var consumptionRateDenominator = new(big.Int).SetUint64(PercentDenominator)
 type Config struct {
 	// MaxConsumptionRate is the rate to allocate funds if the validator's stake
 	// duration is equal to [MintingPeriod]
-	MaxConsumptionRate uint64 `json:"maxConsumptionRate"`
+	MaxConsumptionRate uint64 `serialize:"true" json:"maxConsumptionRate"`
 
 	// MinConsumptionRate is the rate to allocate funds if the validator's stake
 	// duration is 0.
-	MinConsumptionRate uint64 `json:"minConsumptionRate"`
+	MinConsumptionRate uint64 `serialize:"true" json:"minConsumptionRate"`
 
 	// MintingPeriod is period that the staking calculator runs on. It is
 	// not valid for a validator's stake duration to be larger than this.
-	MintingPeriod time.Duration `json:"mintingPeriod"`
+	MintingPeriod time.Duration `serialize:"true" json:"mintingPeriod"`
 
 	// SupplyCap is the target value that the reward calculation should be
 	// asymptotic to.
-	SupplyCap uint64 `json:"supplyCap"`
+	SupplyCap uint64 `serialize:"true" json:"supplyCap"`
 }
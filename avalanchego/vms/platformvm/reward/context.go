@@ -0,0 +1,57 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var errRewardMismatch = errors.New("recalculated reward doesn't match the potential reward")
+
+// Context is the set of inputs a staker's potential reward was calculated
+// from. It is persisted when the reward is calculated so that reward payouts
+// can be audited.
+type Context struct {
+	// SubnetID is the subnet the staker staked on
+	SubnetID ids.ID `serialize:"true" json:"subnetID"`
+
+	// StakedAmount is the weight of the staker
+	StakedAmount uint64 `serialize:"true" json:"stakedAmount"`
+
+	// StakedDuration is the time between the staker's start and end time
+	StakedDuration time.Duration `serialize:"true" json:"stakedDuration"`
+
+	// CurrentSupply is the supply of the subnet at the time the reward was
+	// calculated
+	CurrentSupply uint64 `serialize:"true" json:"currentSupply"`
+
+	// Config holds the network constants the reward was calculated with
+	Config Config `serialize:"true" json:"config"`
+
+	// PotentialReward is the reward that was calculated
+	PotentialReward uint64 `serialize:"true" json:"potentialReward"`
+
+	// Removed is true once the staker was removed by a RewardValidatorTx, or,
+	// for the context of a period of a continuous validator, once the period
+	// was rewarded by a RewardContinuousValidatorTx
+	Removed bool `serialize:"true" json:"removed"`
+
+	// Rewarded is true if the staker was paid [PotentialReward] when it was
+	// removed
+	Rewarded bool `serialize:"true" json:"rewarded"`
+}
+
+// Verify recalculates the reward from the inputs of [c] and returns an error
+// if it doesn't match [c.PotentialReward].
+func (c *Context) Verify() error {
+	reward := NewCalculator(c.Config).Calculate(c.StakedDuration, c.StakedAmount, c.CurrentSupply)
+	if reward != c.PotentialReward {
+		return fmt.Errorf("%w: recalculated %d, potential %d", errRewardMismatch, reward, c.PotentialReward)
+	}
+	return nil
+}
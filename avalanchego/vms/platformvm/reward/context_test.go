@@ -0,0 +1,28 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package reward
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+func TestContextVerify(t *testing.T) {
+	require := require.New(t)
+
+	c := &Context{
+		StakedAmount:   units.KiloAvax,
+		StakedDuration: defaultMinStakingDuration,
+		CurrentSupply:  359 * units.MegaAvax,
+		Config:         defaultConfig,
+	}
+	c.PotentialReward = NewCalculator(c.Config).Calculate(c.StakedDuration, c.StakedAmount, c.CurrentSupply)
+	require.NoError(c.Verify())
+
+	c.PotentialReward++
+	require.ErrorIs(c.Verify(), errRewardMismatch)
+}
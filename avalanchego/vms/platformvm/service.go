@@ -19,6 +19,7 @@ import (
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
 	"github.com/ava-labs/avalanchego/utils/formatting"
+	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
@@ -27,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/components/keystore"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/builder"
@@ -52,6 +54,7 @@ var (
 	errMissingDecisionBlock     = errors.New("should have a decision block within the past two blocks")
 	errNoSubnetID               = errors.New("argument 'subnetID' not provided")
 	errNoRewardAddress          = errors.New("argument 'rewardAddress' not provided")
+	errNoDelegations            = errors.New("argument 'delegations' not provided")
 	errInvalidDelegationRate    = errors.New("argument 'delegationFeeRate' must be between 0 and 100, inclusive")
 	errNoAddresses              = errors.New("no addresses provided")
 	errNoKeys                   = errors.New("user has no keys or funds")
@@ -67,6 +70,7 @@ var (
 	errMissingPrivateKey        = errors.New("argument 'privateKey' not given")
 	errStartAfterEndTime        = errors.New("start time must be before end time")
 	errStartTimeInThePast       = errors.New("start time in the past")
+	errUnknownTxType            = errors.New("unknown tx type")
 )
 
 // Service defines the API calls that can be made to the platform chain
@@ -699,7 +703,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 			continue
 		}
 
-		tx, _, err := service.vm.state.GetTx(staker.TxID)
+		tx, err := state.GetStakerTx(service.vm.state, staker)
 		if err != nil {
 			return err
 		}
@@ -711,7 +715,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 		endTime := json.Uint64(staker.EndTime.Unix())
 		potentialReward := json.Uint64(staker.PotentialReward)
 
-		switch staker := tx.Unsigned.(type) {
+		switch staker := tx.(type) {
 		case txs.ValidatorTx:
 			shares := staker.Shares()
 			delegationFee := json.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
@@ -823,7 +827,7 @@ func (service *Service) GetCurrentValidators(_ *http.Request, args *GetCurrentVa
 				Connected: connected && tracksSubnet,
 			})
 		default:
-			return fmt.Errorf("expected validator but got %T", tx.Unsigned)
+			return fmt.Errorf("expected validator but got %T", tx)
 		}
 	}
 
@@ -885,7 +889,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 			continue
 		}
 
-		tx, _, err := service.vm.state.GetTx(staker.TxID)
+		tx, err := state.GetStakerTx(service.vm.state, staker)
 		if err != nil {
 			return err
 		}
@@ -896,7 +900,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 		startTime := json.Uint64(staker.StartTime.Unix())
 		endTime := json.Uint64(staker.EndTime.Unix())
 
-		switch staker := tx.Unsigned.(type) {
+		switch staker := tx.(type) {
 		case txs.ValidatorTx:
 			shares := staker.Shares()
 			delegationFee := json.Float32(100 * float32(shares) / float32(reward.PercentDenominator))
@@ -938,7 +942,7 @@ func (service *Service) GetPendingValidators(_ *http.Request, args *GetPendingVa
 				Connected: connected && tracksSubnet,
 			})
 		default:
-			return fmt.Errorf("expected validator but got %T", tx.Unsigned)
+			return fmt.Errorf("expected validator but got %T", tx)
 		}
 	}
 	return nil
@@ -1019,11 +1023,15 @@ type AddValidatorArgs struct {
 	// The address the staking reward, if applicable, will go to
 	RewardAddress     string       `json:"rewardAddress"`
 	DelegationFeeRate json.Float32 `json:"delegationFeeRate"`
+	// If true, the tx is built from the UTXOs of the from addresses and
+	// returned unsigned instead of being signed with the keystore and issued
+	Unsigned bool `json:"unsigned"`
 }
 
 // AddValidator creates and signs and issues a transaction to add a validator to
-// the primary network
-func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, reply *api.JSONTxIDChangeAddr) error {
+// the primary network. If [args.Unsigned] is set, the tx is returned unsigned
+// instead.
+func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, reply *api.JSONTxIDChangeAddrUnsignedTx) error {
 	service.vm.ctx.Log.Debug("Platform: AddValidator called")
 
 	now := service.vm.clock.Time()
@@ -1067,6 +1075,27 @@ func (service *Service) AddValidator(_ *http.Request, args *AddValidatorArgs, re
 		return fmt.Errorf("problem while parsing reward address: %w", err)
 	}
 
+	if args.Unsigned {
+		changeAddr, err := service.unsignedChangeAddr(args.From, args.ChangeAddr)
+		if err != nil {
+			return err
+		}
+		tx, err := service.vm.txBuilder.NewUnsignedAddValidatorTx(
+			args.GetWeight(),                     // Stake amount
+			uint64(args.StartTime),               // Start time
+			uint64(args.EndTime),                 // End time
+			nodeID,                               // Node ID
+			rewardAddress,                        // Reward Address
+			uint32(10000*args.DelegationFeeRate), // Shares
+			fromAddrs,                            // Addresses providing the staked tokens
+			changeAddr,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+		return service.replyUnsignedTx(tx, tx.Unsigned.(*txs.AddValidatorTx).Ins, changeAddr, reply)
+	}
+
 	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
 	if err != nil {
 		return err
@@ -1124,11 +1153,15 @@ type AddDelegatorArgs struct {
 	api.JSONSpendHeader
 	platformapi.Staker
 	RewardAddress string `json:"rewardAddress"`
+	// If true, the tx is built from the UTXOs of the from addresses and
+	// returned unsigned instead of being signed with the keystore and issued
+	Unsigned bool `json:"unsigned"`
 }
 
 // AddDelegator creates and signs and issues a transaction to add a delegator to
-// the primary network
-func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, reply *api.JSONTxIDChangeAddr) error {
+// the primary network. If [args.Unsigned] is set, the tx is returned unsigned
+// instead.
+func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, reply *api.JSONTxIDChangeAddrUnsignedTx) error {
 	service.vm.ctx.Log.Debug("Platform: AddDelegator called")
 
 	now := service.vm.clock.Time()
@@ -1169,6 +1202,26 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 		return err
 	}
 
+	if args.Unsigned {
+		changeAddr, err := service.unsignedChangeAddr(args.From, args.ChangeAddr)
+		if err != nil {
+			return err
+		}
+		tx, err := service.vm.txBuilder.NewUnsignedAddDelegatorTx(
+			args.GetWeight(),       // Stake amount
+			uint64(args.StartTime), // Start time
+			uint64(args.EndTime),   // End time
+			nodeID,                 // Node ID
+			rewardAddress,          // Reward Address
+			fromAddrs,              // Addresses providing the staked tokens
+			changeAddr,             // Change address
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+		return service.replyUnsignedTx(tx, tx.Unsigned.(*txs.AddDelegatorTx).Ins, changeAddr, reply)
+	}
+
 	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
 	if err != nil {
 		return err
@@ -1219,6 +1272,231 @@ func (service *Service) AddDelegator(_ *http.Request, args *AddDelegatorArgs, re
 	return errs.Err
 }
 
+// APIDelegation is a delegation of AddDelegatorsArgs
+type APIDelegation struct {
+	NodeID      ids.NodeID  `json:"nodeID"`
+	StakeAmount json.Uint64 `json:"stakeAmount"`
+}
+
+// AddDelegatorsArgs are the arguments to AddDelegators
+type AddDelegatorsArgs struct {
+	// User, password, from addrs, change addr
+	api.JSONSpendHeader
+	// Unix time the delegations start at
+	StartTime json.Uint64 `json:"startTime"`
+	// Unix time the delegations end at
+	EndTime       json.Uint64     `json:"endTime"`
+	Delegations   []APIDelegation `json:"delegations"`
+	RewardAddress string          `json:"rewardAddress"`
+	// If true, the tx is built from the UTXOs of the from addresses and
+	// returned unsigned instead of being signed with the keystore and issued
+	Unsigned bool `json:"unsigned"`
+}
+
+// AddDelegators creates and signs and issues a transaction to add delegators to
+// several validators of the primary network, over the same period. If
+// [args.Unsigned] is set, the tx is returned unsigned instead.
+func (service *Service) AddDelegators(_ *http.Request, args *AddDelegatorsArgs, reply *api.JSONTxIDChangeAddrUnsignedTx) error {
+	service.vm.ctx.Log.Debug("Platform: AddDelegators called",
+		zap.Int("numDelegations", len(args.Delegations)),
+	)
+
+	now := service.vm.clock.Time()
+	minAddStakerTime := now.Add(minAddStakerDelay)
+	minAddStakerUnix := json.Uint64(minAddStakerTime.Unix())
+	maxAddStakerTime := now.Add(executor.MaxFutureStartTime)
+	maxAddStakerUnix := json.Uint64(maxAddStakerTime.Unix())
+
+	if args.StartTime == 0 {
+		args.StartTime = minAddStakerUnix
+	}
+
+	switch {
+	case args.RewardAddress == "":
+		return errNoRewardAddress
+	case len(args.Delegations) == 0:
+		return errNoDelegations
+	case args.StartTime < minAddStakerUnix:
+		return errStartTimeTooSoon
+	case args.StartTime > maxAddStakerUnix:
+		return errStartTimeTooLate
+	}
+
+	delegations := make([]*txs.Delegation, len(args.Delegations))
+	for i, delegation := range args.Delegations {
+		delegations[i] = &txs.Delegation{
+			NodeID: delegation.NodeID,
+			Wght:   uint64(delegation.StakeAmount),
+		}
+	}
+
+	// Parse the reward address
+	rewardAddress, err := avax.ParseServiceAddress(service.addrManager, args.RewardAddress)
+	if err != nil {
+		return fmt.Errorf("problem parsing 'rewardAddress': %w", err)
+	}
+
+	// Parse the from addresses
+	fromAddrs, err := avax.ParseServiceAddresses(service.addrManager, args.From)
+	if err != nil {
+		return err
+	}
+
+	if args.Unsigned {
+		changeAddr, err := service.unsignedChangeAddr(args.From, args.ChangeAddr)
+		if err != nil {
+			return err
+		}
+		tx, err := service.vm.txBuilder.NewUnsignedAddDelegatorsTx(
+			uint64(args.StartTime), // Start time
+			uint64(args.EndTime),   // End time
+			delegations,            // Node IDs and stake amounts
+			rewardAddress,          // Reward Address
+			fromAddrs,              // Addresses providing the staked tokens
+			changeAddr,             // Change address
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't create tx: %w", err)
+		}
+		return service.replyUnsignedTx(tx, tx.Unsigned.(*txs.AddDelegatorsTx).Ins, changeAddr, reply)
+	}
+
+	user, err := keystore.NewUserFromKeystore(service.vm.ctx.Keystore, args.Username, args.Password)
+	if err != nil {
+		return err
+	}
+	defer user.Close()
+
+	privKeys, err := keystore.GetKeychain(user, fromAddrs)
+	if err != nil {
+		return fmt.Errorf("couldn't get addresses controlled by the user: %w", err)
+	}
+
+	// Parse the change address. Assumes that if the user has no keys,
+	// this operation will fail so the change address can be anything.
+	if len(privKeys.Keys) == 0 {
+		return errNoKeys
+	}
+	changeAddr := privKeys.Keys[0].PublicKey().Address() // By default, use a key controlled by the user
+	if args.ChangeAddr != "" {
+		changeAddr, err = avax.ParseServiceAddress(service.addrManager, args.ChangeAddr)
+		if err != nil {
+			return fmt.Errorf("couldn't parse changeAddr: %w", err)
+		}
+	}
+
+	// Create the transaction
+	tx, err := service.vm.txBuilder.NewAddDelegatorsTx(
+		uint64(args.StartTime), // Start time
+		uint64(args.EndTime),   // End time
+		delegations,            // Node IDs and stake amounts
+		rewardAddress,          // Reward Address
+		privKeys.Keys,          // Private keys
+		changeAddr,             // Change address
+	)
+	if err != nil {
+		return fmt.Errorf("couldn't create tx: %w", err)
+	}
+
+	reply.TxID = tx.ID()
+	reply.ChangeAddr, err = service.addrManager.FormatLocalAddress(changeAddr)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		err,
+		service.vm.Builder.AddUnverifiedTx(tx),
+		user.Close(),
+	)
+	return errs.Err
+}
+
+// unsignedChangeAddr returns the parsed [changeAddr], defaulting to the first
+// of [from]. Without keys, the funds of an unsigned tx can only be looked up by
+// address so [from] must not be empty.
+func (service *Service) unsignedChangeAddr(from []string, changeAddr string) (ids.ShortID, error) {
+	if len(from) == 0 {
+		return ids.ShortEmpty, errNoAddresses
+	}
+	if changeAddr == "" {
+		changeAddr = from[0]
+	}
+	addr, err := avax.ParseServiceAddress(service.addrManager, changeAddr)
+	if err != nil {
+		return ids.ShortEmpty, fmt.Errorf("couldn't parse changeAddr: %w", err)
+	}
+	return addr, nil
+}
+
+// replyUnsignedTx fills [reply] with the unsigned bytes of [tx] along with the
+// signatures required to spend [ins].
+func (service *Service) replyUnsignedTx(
+	tx *txs.Tx,
+	ins []*avax.TransferableInput,
+	changeAddr ids.ShortID,
+	reply *api.JSONTxIDChangeAddrUnsignedTx,
+) error {
+	var err error
+	reply.ChangeAddr, err = service.addrManager.FormatLocalAddress(changeAddr)
+	if err != nil {
+		return err
+	}
+
+	unsignedBytes := tx.Unsigned.Bytes()
+	reply.UnsignedTx, err = formatting.Encode(formatting.Hex, unsignedBytes)
+	if err != nil {
+		return err
+	}
+	sigHash, err := formatting.Encode(formatting.Hex, hashing.ComputeHash256(unsignedBytes))
+	if err != nil {
+		return err
+	}
+
+	reply.Signers = make([]api.SigningDescriptor, len(ins))
+	for i, input := range ins {
+		utxoID := input.InputID()
+		utxo, err := service.vm.state.GetUTXO(utxoID)
+		if err != nil {
+			return fmt.Errorf("couldn't get UTXO %s: %w", utxoID, err)
+		}
+
+		out := utxo.Out
+		if lockedOut, ok := out.(*stakeable.LockOut); ok {
+			out = lockedOut.TransferableOut
+		}
+		transferOut, ok := out.(*secp256k1fx.TransferOutput)
+		if !ok {
+			return fmt.Errorf("can't describe the signers of UTXO %s of type %T", utxoID, out)
+		}
+
+		in := input.In
+		if lockedIn, ok := in.(*stakeable.LockIn); ok {
+			in = lockedIn.TransferableIn
+		}
+		transferIn, ok := in.(*secp256k1fx.TransferInput)
+		if !ok {
+			return fmt.Errorf("can't describe the signers of input %d of type %T", i, in)
+		}
+
+		addrs, err := secp256k1fx.SigningAddresses(&transferIn.Input, &transferOut.OutputOwners)
+		if err != nil {
+			return err
+		}
+		reply.Signers[i] = api.SigningDescriptor{
+			InputIndex: json.Uint32(i),
+			UTXOID:     input.UTXOID.String(),
+			Addresses:  make([]string, len(addrs)),
+			SigHash:    sigHash,
+		}
+		for j, addr := range addrs {
+			reply.Signers[i].Addresses[j], err = service.addrManager.FormatLocalAddress(addr)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // AddSubnetValidatorArgs are the arguments to AddSubnetValidator
 type AddSubnetValidatorArgs struct {
 	// User, password, from addrs, change addr
@@ -2087,8 +2365,8 @@ type GetStakeReply struct {
 // Returns:
 // 1) The total amount staked by addresses in [addrs]
 // 2) The staked outputs
-func (service *Service) getStakeHelper(tx *txs.Tx, addrs ids.ShortSet, totalAmountStaked map[ids.ID]uint64) []avax.TransferableOutput {
-	staker, ok := tx.Unsigned.(txs.PermissionlessStaker)
+func (service *Service) getStakeHelper(tx txs.UnsignedTx, addrs ids.ShortSet, totalAmountStaked map[ids.ID]uint64) []avax.TransferableOutput {
+	staker, ok := tx.(txs.PermissionlessStaker)
 	if !ok {
 		return nil
 	}
@@ -2168,7 +2446,7 @@ func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *
 	for currentStakerIterator.Next() { // Iterates over current stakers
 		staker := currentStakerIterator.Value()
 
-		tx, _, err := service.vm.state.GetTx(staker.TxID)
+		tx, err := state.GetStakerTx(service.vm.state, staker)
 		if err != nil {
 			return err
 		}
@@ -2185,7 +2463,7 @@ func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *
 	for pendingStakerIterator.Next() { // Iterates over pending stakers
 		staker := pendingStakerIterator.Value()
 
-		tx, _, err := service.vm.state.GetTx(staker.TxID)
+		tx, err := state.GetStakerTx(service.vm.state, staker)
 		if err != nil {
 			return err
 		}
@@ -2211,6 +2489,130 @@ func (service *Service) GetStake(_ *http.Request, args *GetStakeArgs, response *
 	return nil
 }
 
+// GetAtHeightArgs are the arguments for calling GetBalanceAt and GetStakeAt.
+type GetAtHeightArgs struct {
+	Address string      `json:"address"`
+	Height  json.Uint64 `json:"height"`
+}
+
+// GetBalanceAtReply is the response from calling GetBalanceAt.
+type GetBalanceAtReply struct {
+	// Balance, in nAVAX, owned by the address once the block at the
+	// requested height was accepted
+	Balance json.Uint64 `json:"balance"`
+}
+
+// GetBalanceAt returns the amount of nAVAX that [args.Address] owned once the
+// block at [args.Height] was accepted. Requires the historical state index.
+func (service *Service) GetBalanceAt(_ *http.Request, args *GetAtHeightArgs, reply *GetBalanceAtReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetBalanceAt called",
+		logging.UserString("address", args.Address),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	addr, err := avax.ParseServiceAddress(service.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	balance, err := service.vm.state.GetBalanceAt(addr, uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	reply.Balance = json.Uint64(balance)
+	return nil
+}
+
+// GetStakeAtReply is the response from calling GetStakeAt.
+type GetStakeAtReply struct {
+	// Amount, in nAVAX, staked by the address once the block at the requested
+	// height was accepted
+	Staked json.Uint64 `json:"staked"`
+}
+
+// GetStakeAt returns the amount of nAVAX that [args.Address] had staked once
+// the block at [args.Height] was accepted. Requires the historical state
+// index.
+func (service *Service) GetStakeAt(_ *http.Request, args *GetAtHeightArgs, reply *GetStakeAtReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetStakeAt called",
+		logging.UserString("address", args.Address),
+		zap.Uint64("height", uint64(args.Height)),
+	)
+
+	addr, err := avax.ParseServiceAddress(service.addrManager, args.Address)
+	if err != nil {
+		return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+	}
+
+	staked, err := service.vm.state.GetStakeAt(addr, uint64(args.Height))
+	if err != nil {
+		return err
+	}
+	reply.Staked = json.Uint64(staked)
+	return nil
+}
+
+// GetStakerHistoryArgs are the arguments for calling GetStakerHistory.
+type GetStakerHistoryArgs struct {
+	NodeID      ids.NodeID  `json:"nodeID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	Limit       json.Uint32 `json:"limit"`
+}
+
+// APIStakerEvent is the API representation of a validator lifecycle event.
+type APIStakerEvent struct {
+	Type     state.StakerEventType `json:"type"`
+	Height   json.Uint64           `json:"height"`
+	SubnetID ids.ID                `json:"subnetID"`
+	TxID     ids.ID                `json:"txID"`
+	Weight   json.Uint64           `json:"weight"`
+	Reward   json.Uint64           `json:"reward"`
+}
+
+// GetStakerHistoryReply is the response from calling GetStakerHistory.
+type GetStakerHistoryReply struct {
+	Events []APIStakerEvent `json:"events"`
+	// Height to start the next page at. Only set if more events may be
+	// available.
+	NextHeight *json.Uint64 `json:"nextHeight,omitempty"`
+}
+
+// GetStakerHistory returns the lifecycle events of the validator
+// [args.NodeID], starting at [args.StartHeight].
+func (service *Service) GetStakerHistory(_ *http.Request, args *GetStakerHistoryArgs, reply *GetStakerHistoryReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetStakerHistory called",
+		zap.Stringer("nodeID", args.NodeID),
+		zap.Uint64("startHeight", uint64(args.StartHeight)),
+	)
+
+	limit := int(args.Limit)
+	if limit <= 0 || builder.MaxPageSize < limit {
+		limit = builder.MaxPageSize
+	}
+
+	events, err := service.vm.state.GetStakerEvents(args.NodeID, uint64(args.StartHeight), limit)
+	if err != nil {
+		return fmt.Errorf("couldn't get staker events: %w", err)
+	}
+
+	reply.Events = make([]APIStakerEvent, len(events))
+	for i, event := range events {
+		reply.Events[i] = APIStakerEvent{
+			Type:     event.Type,
+			Height:   json.Uint64(event.Height),
+			SubnetID: event.SubnetID,
+			TxID:     event.TxID,
+			Weight:   json.Uint64(event.Weight),
+			Reward:   json.Uint64(event.Reward),
+		}
+	}
+	if numEvents := len(events); numEvents >= limit {
+		nextHeight := json.Uint64(events[numEvents-1].Height + 1)
+		reply.NextHeight = &nextHeight
+	}
+	return nil
+}
+
 // GetMinStakeArgs are the arguments for calling GetMinStake.
 type GetMinStakeArgs struct {
 	SubnetID ids.ID `json:"subnetID"`
@@ -2367,6 +2769,140 @@ func (service *Service) GetRewardUTXOs(_ *http.Request, args *api.GetTxArgs, rep
 	return nil
 }
 
+// GetRewardContextArgs are the arguments for GetRewardContext
+type GetRewardContextArgs struct {
+	// ID of the staking transaction, or of the RewardContinuousValidatorTx
+	// that rewarded a staking period of a continuous validator
+	TxID ids.ID `json:"txID"`
+}
+
+// GetRewardContextReply is the response from GetRewardContext
+type GetRewardContextReply struct {
+	// Subnet the staker staked on
+	SubnetID ids.ID `json:"subnetID"`
+	// Weight of the staker
+	StakedAmount json.Uint64 `json:"stakedAmount"`
+	// Time, in nanoseconds, between the staker's start and end time
+	StakedDuration json.Uint64 `json:"stakedDuration"`
+	// Supply of the subnet when the reward was calculated
+	CurrentSupply json.Uint64 `json:"currentSupply"`
+	// Network constants the reward was calculated with
+	MaxConsumptionRate json.Uint64 `json:"maxConsumptionRate"`
+	MinConsumptionRate json.Uint64 `json:"minConsumptionRate"`
+	// Minting period, in nanoseconds
+	MintingPeriod json.Uint64 `json:"mintingPeriod"`
+	SupplyCap     json.Uint64 `json:"supplyCap"`
+	// Reward that was calculated
+	PotentialReward json.Uint64 `json:"potentialReward"`
+	// True once the staker was removed
+	Removed bool `json:"removed"`
+	// True if the staker was paid [PotentialReward] when it was removed
+	Rewarded bool `json:"rewarded"`
+}
+
+// GetRewardContext returns the inputs the reward of the staker added by the
+// provided transaction was calculated from. The reward of each staking period
+// of a continuous validator is looked up by the transaction that rewarded the
+// period.
+func (service *Service) GetRewardContext(_ *http.Request, args *GetRewardContextArgs, reply *GetRewardContextReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetRewardContext called")
+
+	rewardContext, err := service.vm.state.GetRewardContext(args.TxID)
+	if err != nil {
+		return fmt.Errorf("couldn't get reward context: %w", err)
+	}
+
+	reply.SubnetID = rewardContext.SubnetID
+	reply.StakedAmount = json.Uint64(rewardContext.StakedAmount)
+	reply.StakedDuration = json.Uint64(rewardContext.StakedDuration)
+	reply.CurrentSupply = json.Uint64(rewardContext.CurrentSupply)
+	reply.MaxConsumptionRate = json.Uint64(rewardContext.Config.MaxConsumptionRate)
+	reply.MinConsumptionRate = json.Uint64(rewardContext.Config.MinConsumptionRate)
+	reply.MintingPeriod = json.Uint64(rewardContext.Config.MintingPeriod)
+	reply.SupplyCap = json.Uint64(rewardContext.Config.SupplyCap)
+	reply.PotentialReward = json.Uint64(rewardContext.PotentialReward)
+	reply.Removed = rewardContext.Removed
+	reply.Rewarded = rewardContext.Rewarded
+	return nil
+}
+
+// EstimateFeeArgs are the arguments for calling EstimateFee
+type EstimateFeeArgs struct {
+	// TxType is one of "addValidator", "addContinuousValidator",
+	// "addDelegator", "addDelegators", "addSubnetValidator",
+	// "removeSubnetValidator", "createChain", "createSubnet", "import",
+	// "export", "transformSubnet", "addPermissionlessValidator" or
+	// "addPermissionlessDelegator"
+	TxType string `json:"txType"`
+	// SubnetID is the subnet staked on by permissionless stakers. Defaults to
+	// the primary network.
+	SubnetID ids.ID `json:"subnetID"`
+	// Timestamp, in Unix seconds, of the chain to estimate the fee at. Defaults
+	// to the current chain timestamp.
+	Timestamp json.Uint64 `json:"timestamp"`
+}
+
+// EstimateFeeReply is the response from EstimateFee
+type EstimateFeeReply struct {
+	// Fee, in nAVAX, that the tx must burn
+	Fee json.Uint64 `json:"fee"`
+	// Chain timestamp the fee was calculated at
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// EstimateFee returns the fee a tx of type [args.TxType] must burn to be
+// accepted. The fee is calculated the same way the tx executors do.
+func (service *Service) EstimateFee(_ *http.Request, args *EstimateFeeArgs, reply *EstimateFeeReply) error {
+	service.vm.ctx.Log.Debug("Platform: EstimateFee called",
+		zap.String("txType", args.TxType),
+	)
+
+	var tx txs.UnsignedTx
+	switch args.TxType {
+	case "addValidator":
+		tx = &txs.AddValidatorTx{}
+	case "addContinuousValidator":
+		tx = &txs.AddContinuousValidatorTx{}
+	case "addDelegator":
+		tx = &txs.AddDelegatorTx{}
+	case "addDelegators":
+		tx = &txs.AddDelegatorsTx{}
+	case "addSubnetValidator":
+		tx = &txs.AddSubnetValidatorTx{}
+	case "removeSubnetValidator":
+		tx = &txs.RemoveSubnetValidatorTx{}
+	case "createChain":
+		tx = &txs.CreateChainTx{}
+	case "createSubnet":
+		tx = &txs.CreateSubnetTx{}
+	case "import":
+		tx = &txs.ImportTx{}
+	case "export":
+		tx = &txs.ExportTx{}
+	case "transformSubnet":
+		tx = &txs.TransformSubnetTx{}
+	case "addPermissionlessValidator":
+		tx = &txs.AddPermissionlessValidatorTx{Subnet: args.SubnetID}
+	case "addPermissionlessDelegator":
+		tx = &txs.AddPermissionlessDelegatorTx{Subnet: args.SubnetID}
+	default:
+		return fmt.Errorf("%w: %q", errUnknownTxType, args.TxType)
+	}
+
+	timestamp := service.vm.state.GetTimestamp()
+	if args.Timestamp != 0 {
+		timestamp = time.Unix(int64(args.Timestamp), 0)
+	}
+
+	fee, err := executor.TxFee(&service.vm.Config, timestamp, tx)
+	if err != nil {
+		return err
+	}
+	reply.Fee = json.Uint64(fee)
+	reply.Timestamp = timestamp
+	return nil
+}
+
 // GetTimestampReply is the response from GetTimestamp
 type GetTimestampReply struct {
 	// Current timestamp
@@ -2381,6 +2917,78 @@ func (service *Service) GetTimestamp(_ *http.Request, args *struct{}, reply *Get
 	return nil
 }
 
+// TxDiagnostics explains whether a mempool tx would be included in the next
+// block
+type TxDiagnostics struct {
+	TxID     ids.ID `json:"txID"`
+	Selected bool   `json:"selected"`
+	// Why the tx wouldn't be included. Empty if the tx is selected.
+	SkipReason string `json:"skipReason,omitempty"`
+	// Error returned by verifying the tx on top of the preferred block, such
+	// as a conflict with a processing tx
+	VerificationError string `json:"verificationError,omitempty"`
+	// Start time of a staker tx
+	StartTime *time.Time `json:"startTime,omitempty"`
+	// When the tx was added to the mempool, if known
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+	// Peer that gossiped the tx, if known. Empty if the tx was issued to this
+	// node.
+	ReceivedFrom *ids.NodeID `json:"receivedFrom,omitempty"`
+}
+
+// GetNextBlockDiagnosticsReply is the response from GetNextBlockDiagnostics
+type GetNextBlockDiagnosticsReply struct {
+	ParentID  ids.ID      `json:"parentID"`
+	Height    json.Uint64 `json:"height"`
+	Timestamp time.Time   `json:"timestamp"`
+	Banff     bool        `json:"banff"`
+	// ID of the staker rewarded by the next block, if any
+	RewardedStakerTxID ids.ID `json:"rewardedStakerTxID"`
+	// True if the next block only advances the chain time
+	AdvancesTime bool `json:"advancesTime"`
+	// The mempool txs, in the order they are considered by the block builder
+	Txs []TxDiagnostics `json:"txs"`
+}
+
+// GetNextBlockDiagnostics explains which mempool txs would be included in the
+// next block built by this node, and why the other txs would be skipped.
+func (service *Service) GetNextBlockDiagnostics(_ *http.Request, _ *struct{}, reply *GetNextBlockDiagnosticsReply) error {
+	service.vm.ctx.Log.Debug("Platform: GetNextBlockDiagnostics called")
+
+	diagnostics, err := service.vm.Builder.Diagnose()
+	if err != nil {
+		return fmt.Errorf("couldn't diagnose the next block: %w", err)
+	}
+
+	reply.ParentID = diagnostics.ParentID
+	reply.Height = json.Uint64(diagnostics.Height)
+	reply.Timestamp = diagnostics.Timestamp
+	reply.Banff = diagnostics.Banff
+	reply.RewardedStakerTxID = diagnostics.RewardedStakerTxID
+	reply.AdvancesTime = diagnostics.AdvancesTime
+	reply.Txs = make([]TxDiagnostics, len(diagnostics.Txs))
+	for i, tx := range diagnostics.Txs {
+		tx := tx
+		apiTx := TxDiagnostics{
+			TxID:              tx.TxID,
+			Selected:          tx.Selected,
+			SkipReason:        tx.SkipReason,
+			VerificationError: tx.VerificationError,
+		}
+		if !tx.StartTime.IsZero() {
+			apiTx.StartTime = &tx.StartTime
+		}
+		if !tx.ReceivedAt.IsZero() {
+			apiTx.ReceivedAt = &tx.ReceivedAt
+		}
+		if tx.ReceivedFrom != ids.EmptyNodeID {
+			apiTx.ReceivedFrom = &tx.ReceivedFrom
+		}
+		reply.Txs[i] = apiTx
+	}
+	return nil
+}
+
 // GetValidatorsAtArgs is the response from GetValidatorsAt
 type GetValidatorsAtArgs struct {
 	Height   json.Uint64 `json:"height"`
@@ -2409,6 +3017,41 @@ func (service *Service) GetValidatorsAt(_ *http.Request, args *GetValidatorsAtAr
 	return nil
 }
 
+// GetValidatorSetDiffArgs are the arguments for GetValidatorSetDiff
+type GetValidatorSetDiffArgs struct {
+	SubnetID    ids.ID      `json:"subnetID"`
+	StartHeight json.Uint64 `json:"startHeight"`
+	EndHeight   json.Uint64 `json:"endHeight"`
+}
+
+// GetValidatorSetDiffReply is the response from GetValidatorSetDiff
+type GetValidatorSetDiffReply struct {
+	StartHeight json.Uint64             `json:"startHeight"`
+	EndHeight   json.Uint64             `json:"endHeight"`
+	Changes     []ValidatorWeightChange `json:"changes"`
+}
+
+// GetValidatorSetDiff returns the validators of a provided subnet whose weight
+// changed between the specified heights.
+func (service *Service) GetValidatorSetDiff(_ *http.Request, args *GetValidatorSetDiffArgs, reply *GetValidatorSetDiffReply) error {
+	startHeight := uint64(args.StartHeight)
+	endHeight := uint64(args.EndHeight)
+	service.vm.ctx.Log.Debug("Platform: GetValidatorSetDiff called",
+		zap.Uint64("startHeight", startHeight),
+		zap.Uint64("endHeight", endHeight),
+		zap.Stringer("subnetID", args.SubnetID),
+	)
+
+	changes, err := service.vm.GetValidatorSetDiff(startHeight, endHeight, args.SubnetID)
+	if err != nil {
+		return fmt.Errorf("couldn't get validator set diff: %w", err)
+	}
+	reply.StartHeight = args.StartHeight
+	reply.EndHeight = args.EndHeight
+	reply.Changes = changes
+	return nil
+}
+
 func (service *Service) GetBlock(_ *http.Request, args *api.GetBlockArgs, response *api.GetBlockResponse) error {
 	service.vm.ctx.Log.Debug("Platform: GetBlock called",
 		zap.Stringer("blkID", args.BlockID),
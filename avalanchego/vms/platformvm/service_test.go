@@ -99,7 +99,7 @@ func defaultAddress(t *testing.T, service *Service) {
 }
 
 func TestAddValidator(t *testing.T) {
-	expectedJSONString := `{"username":"","password":"","from":null,"changeAddr":"","txID":"11111111111111111111111111111111LpoYY","startTime":"0","endTime":"0","nodeID":"NodeID-111111111111111111116DBWJs","rewardAddress":"","delegationFeeRate":"0.0000"}`
+	expectedJSONString := `{"username":"","password":"","from":null,"changeAddr":"","txID":"11111111111111111111111111111111LpoYY","startTime":"0","endTime":"0","nodeID":"NodeID-111111111111111111116DBWJs","rewardAddress":"","delegationFeeRate":"0.0000","unsigned":false}`
 	args := AddValidatorArgs{}
 	bytes, err := stdjson.Marshal(&args)
 	if err != nil {
@@ -111,6 +111,60 @@ func TestAddValidator(t *testing.T) {
 	}
 }
 
+func TestAddValidatorUnsigned(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	fromAddr, err := service.addrManager.FormatLocalAddress(keys[0].PublicKey().Address())
+	require.NoError(err)
+
+	startTime := service.vm.clock.Time().Add(minAddStakerDelay).Add(time.Second)
+	args := AddValidatorArgs{
+		JSONSpendHeader: api.JSONSpendHeader{
+			JSONFromAddrs: api.JSONFromAddrs{From: []string{fromAddr}},
+		},
+		Staker: pchainapi.Staker{
+			NodeID:      ids.GenerateTestNodeID(),
+			StartTime:   json.Uint64(startTime.Unix()),
+			EndTime:     json.Uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+			StakeAmount: (*json.Uint64)(&service.vm.MinValidatorStake),
+		},
+		RewardAddress: fromAddr,
+		Unsigned:      true,
+	}
+	reply := api.JSONTxIDChangeAddrUnsignedTx{}
+	require.NoError(service.AddValidator(nil, &args, &reply))
+	require.Equal(ids.Empty, reply.TxID)
+	require.Equal(fromAddr, reply.ChangeAddr)
+	require.NotEmpty(reply.Signers)
+
+	// Nothing was issued
+	require.False(service.vm.Builder.HasTxs())
+
+	// Sign the tx as an external wallet would
+	unsignedBytes, err := formatting.Decode(formatting.Hex, reply.UnsignedTx)
+	require.NoError(err)
+	var utx txs.UnsignedTx
+	_, err = txs.Codec.Unmarshal(unsignedBytes, &utx)
+	require.NoError(err)
+
+	signers := make([][]*crypto.PrivateKeySECP256K1R, len(reply.Signers))
+	for i, signer := range reply.Signers {
+		require.EqualValues(i, signer.InputIndex)
+		require.Equal([]string{fromAddr}, signer.Addresses)
+		signers[i] = []*crypto.PrivateKeySECP256K1R{keys[0]}
+	}
+	tx := &txs.Tx{Unsigned: utx}
+	require.NoError(tx.Sign(txs.Codec, signers))
+	require.NoError(service.vm.Builder.AddUnverifiedTx(tx))
+	require.True(service.vm.Builder.Has(tx.ID()))
+}
+
 func TestCreateBlockchainArgsParsing(t *testing.T) {
 	jsonString := `{"vmID":"lol","fxIDs":["secp256k1"], "name":"awesome", "username":"bob loblaw", "password":"yeet", "genesisData":"SkB92YpWm4Q2iPnLGCuDPZPgUQMxajqQQuz91oi3xD984f8r"}`
 	args := CreateBlockchainArgs{}
@@ -768,6 +822,37 @@ func TestGetTimestamp(t *testing.T) {
 	require.Equal(newTimestamp, reply.Timestamp)
 }
 
+func TestEstimateFee(t *testing.T) {
+	require := require.New(t)
+	service, _ := defaultService(t)
+	service.vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(service.vm.Shutdown())
+		service.vm.ctx.Lock.Unlock()
+	}()
+
+	// Before ApricotPhase3 the create asset fee is charged
+	reply := EstimateFeeReply{}
+	require.NoError(service.EstimateFee(nil, &EstimateFeeArgs{TxType: "createSubnet"}, &reply))
+	require.EqualValues(service.vm.Config.CreateAssetTxFee, reply.Fee)
+	require.Equal(service.vm.state.GetTimestamp(), reply.Timestamp)
+
+	require.NoError(service.EstimateFee(nil, &EstimateFeeArgs{
+		TxType:    "createSubnet",
+		Timestamp: json.Uint64(service.vm.Config.ApricotPhase3Time.Unix()),
+	}, &reply))
+	require.EqualValues(service.vm.Config.CreateSubnetTxFee, reply.Fee)
+
+	require.NoError(service.EstimateFee(nil, &EstimateFeeArgs{
+		TxType:   "addPermissionlessDelegator",
+		SubnetID: ids.GenerateTestID(),
+	}, &reply))
+	require.EqualValues(service.vm.Config.AddSubnetDelegatorFee, reply.Fee)
+
+	err := service.EstimateFee(nil, &EstimateFeeArgs{TxType: "advanceTime"}, &reply)
+	require.ErrorIs(err, errUnknownTxType)
+}
+
 func TestGetBlock(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -11,6 +11,7 @@ import (
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
@@ -52,6 +53,8 @@ type diff struct {
 	// map of txID -> []*UTXO
 	addedRewardUTXOs map[ids.ID][]*avax.UTXO
 
+	modifiedRewardContexts map[ids.ID]*reward.Context
+
 	// map of txID -> {*txs.Tx, Status}
 	addedTxs map[ids.ID]*txAndStatus
 
@@ -143,6 +146,10 @@ func (d *diff) DeleteCurrentValidator(staker *Staker) {
 	d.currentStakerDiffs.DeleteValidator(staker)
 }
 
+func (d *diff) RestakeCurrentValidator(staker *Staker) {
+	d.currentStakerDiffs.RestakeValidator(staker)
+}
+
 func (d *diff) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	parentState, ok := d.stateVersions.GetState(d.parentID)
 	if !ok {
@@ -409,6 +416,25 @@ func (d *diff) AddRewardUTXO(txID ids.ID, utxo *avax.UTXO) {
 	d.addedRewardUTXOs[txID] = append(d.addedRewardUTXOs[txID], utxo)
 }
 
+func (d *diff) GetRewardContext(txID ids.ID) (*reward.Context, error) {
+	if rewardContext, exists := d.modifiedRewardContexts[txID]; exists {
+		return rewardContext, nil
+	}
+
+	parentState, ok := d.stateVersions.GetState(d.parentID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrMissingParentState, d.parentID)
+	}
+	return parentState.GetRewardContext(txID)
+}
+
+func (d *diff) SetRewardContext(txID ids.ID, rewardContext *reward.Context) {
+	if d.modifiedRewardContexts == nil {
+		d.modifiedRewardContexts = make(map[ids.ID]*reward.Context)
+	}
+	d.modifiedRewardContexts[txID] = rewardContext
+}
+
 func (d *diff) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
 	utxo, modified := d.modifiedUTXOs[utxoID]
 	if !modified {
@@ -459,9 +485,12 @@ func (d *diff) Apply(baseState State) {
 	for _, subnetValidatorDiffs := range d.currentStakerDiffs.validatorDiffs {
 		for _, validatorDiff := range subnetValidatorDiffs {
 			if validatorDiff.validatorModified {
-				if validatorDiff.validatorDeleted {
+				switch {
+				case validatorDiff.validatorDeleted:
 					baseState.DeleteCurrentValidator(validatorDiff.validator)
-				} else {
+				case validatorDiff.validatorRestaked:
+					baseState.RestakeCurrentValidator(validatorDiff.validator)
+				default:
 					baseState.PutCurrentValidator(validatorDiff.validator)
 				}
 			}
@@ -517,6 +546,9 @@ func (d *diff) Apply(baseState State) {
 			baseState.AddRewardUTXO(txID, utxo)
 		}
 	}
+	for txID, rewardContext := range d.modifiedRewardContexts {
+		baseState.SetRewardContext(txID, rewardContext)
+	}
 	for _, utxo := range d.modifiedUTXOs {
 		if utxo.utxo != nil {
 			baseState.AddUTXO(utxo.utxo)
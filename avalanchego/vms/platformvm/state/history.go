@@ -0,0 +1,249 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errHistoricalStateIndexDisabled   = errors.New("historical state index is disabled")
+	errHistoricalStateIndexIncomplete = errors.New("historical state index can only be enabled on a database that was initialized with it")
+	errHeightNotAccepted              = errors.New("height hasn't been accepted")
+)
+
+// historyKey returns the key of the snapshot of [addr] at [height]. The height
+// is inverted so that iterating forward from [height] yields the most recent
+// snapshot at or below it first.
+func historyKey(addr ids.ShortID, height uint64) []byte {
+	key := make([]byte, hashing.AddrLen+wrappers.LongLen)
+	copy(key, addr[:])
+	binary.BigEndian.PutUint64(key[hashing.AddrLen:], ^height)
+	return key
+}
+
+// getHistoricalAmount returns the most recent snapshot of [addr] in [db] at or
+// below [height]. If [addr] has no snapshot at or below [height], 0 is
+// returned.
+func getHistoricalAmount(db database.Iteratee, addr ids.ShortID, height uint64) (uint64, error) {
+	it := db.NewIteratorWithStartAndPrefix(historyKey(addr, height), addr[:])
+	defer it.Release()
+
+	if !it.Next() {
+		return 0, it.Error()
+	}
+	return database.ParseUInt64(it.Value())
+}
+
+func (s *state) GetBalanceAt(addr ids.ShortID, height uint64) (uint64, error) {
+	if err := s.verifyHistoricalHeight(height); err != nil {
+		return 0, err
+	}
+	return getHistoricalAmount(s.balanceHistoryDB, addr, height)
+}
+
+func (s *state) GetStakeAt(addr ids.ShortID, height uint64) (uint64, error) {
+	if err := s.verifyHistoricalHeight(height); err != nil {
+		return 0, err
+	}
+	return getHistoricalAmount(s.stakeHistoryDB, addr, height)
+}
+
+func (s *state) verifyHistoricalHeight(height uint64) error {
+	if !s.cfg.HistoricalStateIndexEnabled {
+		return errHistoricalStateIndexDisabled
+	}
+	lastAccepted, _, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return err
+	}
+	if lastAcceptedHeight := lastAccepted.Height(); height > lastAcceptedHeight {
+		return fmt.Errorf("%w: %d > last accepted height %d",
+			errHeightNotAccepted,
+			height,
+			lastAcceptedHeight,
+		)
+	}
+	return nil
+}
+
+// syncHistory makes sure that the historical state index is only ever served
+// if it has been maintained since genesis.
+func (s *state) syncHistory(shouldInit bool) error {
+	indexed, err := s.singletonDB.Has(historyIndexedKey)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case s.cfg.HistoricalStateIndexEnabled && shouldInit:
+		return s.singletonDB.Put(historyIndexedKey, nil)
+	case s.cfg.HistoricalStateIndexEnabled && !indexed:
+		return errHistoricalStateIndexIncomplete
+	case !s.cfg.HistoricalStateIndexEnabled && indexed:
+		// The index is about to miss state changes, so it must never be
+		// served again.
+		s.ctx.Log.Warn("historical state index is disabled and can only be re-enabled after resyncing the database")
+		return s.singletonDB.Delete(historyIndexedKey)
+	default:
+		return nil
+	}
+}
+
+// writeHistory snapshots the AVAX balance and stake of every address affected
+// by the pending changes at [height]. It must be called before the pending
+// UTXOs and stakers are written.
+//
+// Outputs with multiple owners are accounted in full to each of their owners.
+func (s *state) writeHistory(height uint64) error {
+	if !s.cfg.HistoricalStateIndexEnabled {
+		return nil
+	}
+
+	balanceDiffs := make(map[ids.ShortID]*ValidatorWeightDiff)
+	for utxoID, utxo := range s.modifiedUTXOs {
+		if utxo != nil {
+			if err := s.addHistoryDiff(balanceDiffs, utxo.AssetID(), utxo.Out, false); err != nil {
+				return err
+			}
+		}
+
+		// Any previously persisted UTXO is being removed.
+		persistedUTXO, err := s.utxoState.GetUTXO(utxoID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get UTXO: %w", err)
+		}
+		if err := s.addHistoryDiff(balanceDiffs, persistedUTXO.AssetID(), persistedUTXO.Out, true); err != nil {
+			return err
+		}
+	}
+
+	stakeDiffs := make(map[ids.ShortID]*ValidatorWeightDiff)
+	for _, stakers := range []*baseStakers{s.currentStakers, s.pendingStakers} {
+		for _, subnetValidatorDiffs := range stakers.validatorDiffs {
+			for _, validatorDiff := range subnetValidatorDiffs {
+				// Restaking a validator doesn't change its stake.
+				if validatorDiff.validatorModified && !validatorDiff.validatorRestaked {
+					if err := s.addStakeHistoryDiff(stakeDiffs, validatorDiff.validator, validatorDiff.validatorDeleted); err != nil {
+						return err
+					}
+				}
+
+				addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+				for addedDelegatorIterator.Next() {
+					if err := s.addStakeHistoryDiff(stakeDiffs, addedDelegatorIterator.Value(), false); err != nil {
+						addedDelegatorIterator.Release()
+						return err
+					}
+				}
+				addedDelegatorIterator.Release()
+
+				for _, staker := range validatorDiff.deletedDelegators {
+					if err := s.addStakeHistoryDiff(stakeDiffs, staker, true); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	if err := writeHistoryDiffs(s.balanceHistoryDB, balanceDiffs, height); err != nil {
+		return fmt.Errorf("failed to write balance history: %w", err)
+	}
+	if err := writeHistoryDiffs(s.stakeHistoryDB, stakeDiffs, height); err != nil {
+		return fmt.Errorf("failed to write stake history: %w", err)
+	}
+	return nil
+}
+
+func (s *state) addStakeHistoryDiff(diffs map[ids.ShortID]*ValidatorWeightDiff, staker *Staker, negative bool) error {
+	tx, err := GetStakerTx(s, staker)
+	if err != nil {
+		return fmt.Errorf("failed to get staker tx %s: %w", staker.TxID, err)
+	}
+	stakerTx, ok := tx.(txs.PermissionlessStaker)
+	if !ok {
+		// Permissioned subnet validators don't lock any stake.
+		return nil
+	}
+	for _, out := range stakerTx.Stake() {
+		if err := s.addHistoryDiff(diffs, out.AssetID(), out.Out, negative); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *state) addHistoryDiff(diffs map[ids.ShortID]*ValidatorWeightDiff, assetID ids.ID, out interface{}, negative bool) error {
+	if assetID != s.ctx.AVAXAssetID {
+		return nil
+	}
+	if lockedOut, ok := out.(*stakeable.LockOut); ok {
+		out = lockedOut.TransferableOut
+	}
+	transferableOut, ok := out.(avax.TransferableOut)
+	if !ok {
+		return nil
+	}
+	addressable, ok := out.(avax.Addressable)
+	if !ok {
+		return nil
+	}
+
+	amount := transferableOut.Amount()
+	for _, addrBytes := range addressable.Addresses() {
+		addr, err := ids.ToShortID(addrBytes)
+		if err != nil {
+			return err
+		}
+		diff, ok := diffs[addr]
+		if !ok {
+			diff = &ValidatorWeightDiff{}
+			diffs[addr] = diff
+		}
+		if err := diff.Add(negative, amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeHistoryDiffs(db database.Database, diffs map[ids.ShortID]*ValidatorWeightDiff, height uint64) error {
+	for addr, diff := range diffs {
+		if diff.Amount == 0 {
+			continue
+		}
+
+		amount, err := getHistoricalAmount(db, addr, height)
+		if err != nil {
+			return err
+		}
+		if diff.Decrease {
+			amount, err = math.Sub64(amount, diff.Amount)
+		} else {
+			amount, err = math.Add64(amount, diff.Amount)
+		}
+		if err != nil {
+			return err
+		}
+		if err := database.PutUInt64(db, historyKey(addr, height), amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
+	reward "github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	status "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -290,6 +291,21 @@ func (mr *MockChainMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockChain)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardContext mocks base method.
+func (m *MockChain) GetRewardContext(arg0 ids.ID) (*reward.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardContext", arg0)
+	ret0, _ := ret[0].(*reward.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardContext indicates an expected call of GetRewardContext.
+func (mr *MockChainMockRecorder) GetRewardContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardContext", reflect.TypeOf((*MockChain)(nil).GetRewardContext), arg0)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockChain) GetRewardUTXOs(arg0 ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -428,6 +444,18 @@ func (mr *MockChainMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockChain)(nil).PutPendingValidator), arg0)
 }
 
+// RestakeCurrentValidator mocks base method.
+func (m *MockChain) RestakeCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestakeCurrentValidator", arg0)
+}
+
+// RestakeCurrentValidator indicates an expected call of RestakeCurrentValidator.
+func (mr *MockChainMockRecorder) RestakeCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestakeCurrentValidator", reflect.TypeOf((*MockChain)(nil).RestakeCurrentValidator), arg0)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockChain) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -440,6 +468,18 @@ func (mr *MockChainMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockChain)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetRewardContext mocks base method.
+func (m *MockChain) SetRewardContext(arg0 ids.ID, arg1 *reward.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardContext", arg0, arg1)
+}
+
+// SetRewardContext indicates an expected call of SetRewardContext.
+func (mr *MockChainMockRecorder) SetRewardContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardContext", reflect.TypeOf((*MockChain)(nil).SetRewardContext), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockChain) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
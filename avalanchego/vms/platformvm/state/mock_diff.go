@@ -10,6 +10,7 @@ import (
 
 	ids "github.com/ava-labs/avalanchego/ids"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
+	reward "github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	status "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -306,6 +307,21 @@ func (mr *MockDiffMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockDiff)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardContext mocks base method.
+func (m *MockDiff) GetRewardContext(arg0 ids.ID) (*reward.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardContext", arg0)
+	ret0, _ := ret[0].(*reward.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardContext indicates an expected call of GetRewardContext.
+func (mr *MockDiffMockRecorder) GetRewardContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardContext", reflect.TypeOf((*MockDiff)(nil).GetRewardContext), arg0)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockDiff) GetRewardUTXOs(arg0 ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -444,6 +460,18 @@ func (mr *MockDiffMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockDiff)(nil).PutPendingValidator), arg0)
 }
 
+// RestakeCurrentValidator mocks base method.
+func (m *MockDiff) RestakeCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestakeCurrentValidator", arg0)
+}
+
+// RestakeCurrentValidator indicates an expected call of RestakeCurrentValidator.
+func (mr *MockDiffMockRecorder) RestakeCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestakeCurrentValidator", reflect.TypeOf((*MockDiff)(nil).RestakeCurrentValidator), arg0)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockDiff) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -456,6 +484,18 @@ func (mr *MockDiffMockRecorder) SetCurrentSupply(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCurrentSupply", reflect.TypeOf((*MockDiff)(nil).SetCurrentSupply), arg0, arg1)
 }
 
+// SetRewardContext mocks base method.
+func (m *MockDiff) SetRewardContext(arg0 ids.ID, arg1 *reward.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardContext", arg0, arg1)
+}
+
+// SetRewardContext indicates an expected call of SetRewardContext.
+func (mr *MockDiffMockRecorder) SetRewardContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardContext", reflect.TypeOf((*MockDiff)(nil).SetRewardContext), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockDiff) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
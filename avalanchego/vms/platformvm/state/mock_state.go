@@ -12,8 +12,10 @@ import (
 	ids "github.com/ava-labs/avalanchego/ids"
 	choices "github.com/ava-labs/avalanchego/snow/choices"
 	validators "github.com/ava-labs/avalanchego/snow/validators"
+	bls "github.com/ava-labs/avalanchego/utils/crypto/bls"
 	avax "github.com/ava-labs/avalanchego/vms/components/avax"
 	blocks "github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	reward "github.com/ava-labs/avalanchego/vms/platformvm/reward"
 	status "github.com/ava-labs/avalanchego/vms/platformvm/status"
 	txs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	gomock "github.com/golang/mock/gomock"
@@ -245,6 +247,21 @@ func (mr *MockStateMockRecorder) DeleteUTXO(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUTXO", reflect.TypeOf((*MockState)(nil).DeleteUTXO), arg0)
 }
 
+// GetBalanceAt mocks base method.
+func (m *MockState) GetBalanceAt(arg0 ids.ShortID, arg1 uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalanceAt", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalanceAt indicates an expected call of GetBalanceAt.
+func (mr *MockStateMockRecorder) GetBalanceAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalanceAt", reflect.TypeOf((*MockState)(nil).GetBalanceAt), arg0, arg1)
+}
+
 // GetChains mocks base method.
 func (m *MockState) GetChains(arg0 ids.ID) ([]*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -393,6 +410,21 @@ func (mr *MockStateMockRecorder) GetPendingValidator(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingValidator", reflect.TypeOf((*MockState)(nil).GetPendingValidator), arg0, arg1)
 }
 
+// GetRewardContext mocks base method.
+func (m *MockState) GetRewardContext(arg0 ids.ID) (*reward.Context, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRewardContext", arg0)
+	ret0, _ := ret[0].(*reward.Context)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRewardContext indicates an expected call of GetRewardContext.
+func (mr *MockStateMockRecorder) GetRewardContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardContext", reflect.TypeOf((*MockState)(nil).GetRewardContext), arg0)
+}
+
 // GetRewardUTXOs mocks base method.
 func (m *MockState) GetRewardUTXOs(arg0 ids.ID) ([]*avax.UTXO, error) {
 	m.ctrl.T.Helper()
@@ -408,6 +440,36 @@ func (mr *MockStateMockRecorder) GetRewardUTXOs(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRewardUTXOs", reflect.TypeOf((*MockState)(nil).GetRewardUTXOs), arg0)
 }
 
+// GetStakeAt mocks base method.
+func (m *MockState) GetStakeAt(arg0 ids.ShortID, arg1 uint64) (uint64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakeAt", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakeAt indicates an expected call of GetStakeAt.
+func (mr *MockStateMockRecorder) GetStakeAt(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakeAt", reflect.TypeOf((*MockState)(nil).GetStakeAt), arg0, arg1)
+}
+
+// GetStakerEvents mocks base method.
+func (m *MockState) GetStakerEvents(arg0 ids.NodeID, arg1 uint64, arg2 int) ([]*StakerEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStakerEvents", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*StakerEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStakerEvents indicates an expected call of GetStakerEvents.
+func (mr *MockStateMockRecorder) GetStakerEvents(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStakerEvents", reflect.TypeOf((*MockState)(nil).GetStakerEvents), arg0, arg1, arg2)
+}
+
 // GetStartTime mocks base method.
 func (m *MockState) GetStartTime(arg0 ids.NodeID) (time.Time, error) {
 	m.ctrl.T.Helper()
@@ -530,6 +592,21 @@ func (mr *MockStateMockRecorder) GetUptime(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUptime", reflect.TypeOf((*MockState)(nil).GetUptime), arg0)
 }
 
+// GetValidatorPublicKeyDiffs mocks base method.
+func (m *MockState) GetValidatorPublicKeyDiffs(arg0 uint64) (map[ids.NodeID]*bls.PublicKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetValidatorPublicKeyDiffs", arg0)
+	ret0, _ := ret[0].(map[ids.NodeID]*bls.PublicKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetValidatorPublicKeyDiffs indicates an expected call of GetValidatorPublicKeyDiffs.
+func (mr *MockStateMockRecorder) GetValidatorPublicKeyDiffs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetValidatorPublicKeyDiffs", reflect.TypeOf((*MockState)(nil).GetValidatorPublicKeyDiffs), arg0)
+}
+
 // GetValidatorWeightDiffs mocks base method.
 func (m *MockState) GetValidatorWeightDiffs(arg0 uint64, arg1 ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error) {
 	m.ctrl.T.Helper()
@@ -593,6 +670,18 @@ func (mr *MockStateMockRecorder) PutPendingValidator(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutPendingValidator", reflect.TypeOf((*MockState)(nil).PutPendingValidator), arg0)
 }
 
+// RestakeCurrentValidator mocks base method.
+func (m *MockState) RestakeCurrentValidator(arg0 *Staker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestakeCurrentValidator", arg0)
+}
+
+// RestakeCurrentValidator indicates an expected call of RestakeCurrentValidator.
+func (mr *MockStateMockRecorder) RestakeCurrentValidator(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestakeCurrentValidator", reflect.TypeOf((*MockState)(nil).RestakeCurrentValidator), arg0)
+}
+
 // SetCurrentSupply mocks base method.
 func (m *MockState) SetCurrentSupply(arg0 ids.ID, arg1 uint64) {
 	m.ctrl.T.Helper()
@@ -629,6 +718,18 @@ func (mr *MockStateMockRecorder) SetLastAccepted(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLastAccepted", reflect.TypeOf((*MockState)(nil).SetLastAccepted), arg0)
 }
 
+// SetRewardContext mocks base method.
+func (m *MockState) SetRewardContext(arg0 ids.ID, arg1 *reward.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRewardContext", arg0, arg1)
+}
+
+// SetRewardContext indicates an expected call of SetRewardContext.
+func (mr *MockStateMockRecorder) SetRewardContext(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRewardContext", reflect.TypeOf((*MockState)(nil).SetRewardContext), arg0, arg1)
+}
+
 // SetTimestamp mocks base method.
 func (m *MockState) SetTimestamp(arg0 time.Time) {
 	m.ctrl.T.Helper()
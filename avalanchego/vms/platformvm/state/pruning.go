@@ -0,0 +1,290 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// pruneReport is the data deleted by prune, or that would be deleted in a dry
+// run. The sizes are the lengths of the keys and values, without the database
+// overhead.
+type pruneReport struct {
+	txs             int
+	txBytes         int
+	rewardedStakers int
+	rewardUTXOBytes int
+}
+
+// prune deletes the data configured by [s.cfg.Pruning] that was accepted
+// before the retention bounds. The state must not have uncommitted changes.
+func (s *state) prune() error {
+	cfg := s.cfg.Pruning
+	if !cfg.Enabled() {
+		return nil
+	}
+
+	startTime := time.Now()
+	lastAccepted, _, err := s.GetStatelessBlock(s.lastAccepted)
+	if err != nil {
+		return fmt.Errorf("failed to get last accepted block: %w", err)
+	}
+	lastAcceptedHeight := lastAccepted.Height()
+	if lastAcceptedHeight < cfg.RetainedBlocks {
+		return nil
+	}
+
+	prunedTxIDs, rewardedTxIDs, err := s.prunableTxs(
+		lastAcceptedHeight-cfg.RetainedBlocks,
+		s.GetTimestamp().Add(-cfg.RetainedAge),
+	)
+	if err != nil {
+		return err
+	}
+
+	report := pruneReport{}
+	for _, txID := range prunedTxIDs {
+		if err := s.pruneTx(txID, &report); err != nil {
+			return fmt.Errorf("failed to prune tx %s: %w", txID, err)
+		}
+	}
+	for _, txID := range rewardedTxIDs {
+		if err := s.pruneRewardUTXOs(txID, &report); err != nil {
+			return fmt.Errorf("failed to prune the reward UTXOs of %s: %w", txID, err)
+		}
+	}
+
+	msg := "P-chain state can be pruned"
+	if !cfg.DryRun {
+		if err := s.baseDB.Commit(); err != nil {
+			return fmt.Errorf("failed to commit pruned state: %w", err)
+		}
+		msg = "pruned P-chain state"
+	}
+	s.ctx.Log.Info(msg,
+		zap.Int("numTxs", report.txs),
+		zap.Int("txBytes", report.txBytes),
+		zap.Int("numRewardedStakers", report.rewardedStakers),
+		zap.Int("rewardUTXOBytes", report.rewardUTXOBytes),
+		zap.Duration("duration", time.Since(startTime)),
+	)
+	return nil
+}
+
+// prunableTxs returns the txs accepted at or below [maxHeight] and [maxTime]
+// that aren't needed to verify new blocks, and the staker txs whose rewards
+// were accepted at or below them.
+func (s *state) prunableTxs(maxHeight uint64, maxTime time.Time) ([]ids.ID, []ids.ID, error) {
+	stakerTxIDs, err := s.stakerTxIDs()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		prunedTxIDs   []ids.ID
+		rewardedTxIDs []ids.ID
+		// The reward UTXOs of all the staking periods of a continuous
+		// validator are pruned together, once all of them can be pruned.
+		continuousTxIDs         = ids.Set{}
+		retainedContinuousTxIDs = ids.Set{}
+		// The reward UTXOs of the delegations of an AddDelegatorsTx belong to
+		// that tx, so they're also pruned together.
+		batchTxIDs          = make(map[ids.ID]ids.ID) // delegation ID -> batch tx ID
+		rewardedBatchTxIDs  = ids.Set{}
+		retainedRewardTxIDs = ids.Set{}
+	)
+	it := s.blockDB.NewIterator()
+	defer it.Release()
+
+	for it.Next() {
+		blkState := stateBlk{}
+		if _, err := blocks.GenesisCodec.Unmarshal(it.Value(), &blkState); err != nil {
+			return nil, nil, err
+		}
+		if blkState.Status != choices.Accepted {
+			continue
+		}
+		blk, err := blocks.Parse(blocks.GenesisCodec, blkState.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, tx := range blk.Txs() {
+			if batchTx, ok := tx.Unsigned.(*txs.AddDelegatorsTx); ok {
+				txID := tx.ID()
+				for i := range batchTx.Delegations {
+					batchTxIDs[txs.DelegationID(txID, i)] = txID
+				}
+			}
+		}
+		if blk.Height() > maxHeight || s.acceptanceTime(blk).After(maxTime) {
+			for _, tx := range blk.Txs() {
+				switch rewardTx := tx.Unsigned.(type) {
+				case *txs.RewardValidatorTx:
+					retainedRewardTxIDs.Add(rewardTx.TxID)
+				case *txs.RewardContinuousValidatorTx:
+					retainedContinuousTxIDs.Add(rewardTx.TxID)
+				}
+			}
+			continue
+		}
+
+		for _, tx := range blk.Txs() {
+			if s.cfg.Pruning.Txs && !isRetainedTx(tx, stakerTxIDs) {
+				prunedTxIDs = append(prunedTxIDs, tx.ID())
+			}
+			if !s.cfg.Pruning.RewardUTXOs {
+				continue
+			}
+			switch rewardTx := tx.Unsigned.(type) {
+			case *txs.RewardValidatorTx:
+				rewardedTxIDs = append(rewardedTxIDs, rewardTx.TxID)
+			case *txs.RewardContinuousValidatorTx:
+				if !stakerTxIDs.Contains(rewardTx.TxID) {
+					continuousTxIDs.Add(rewardTx.TxID)
+				}
+			}
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	for txID := range continuousTxIDs {
+		if !retainedContinuousTxIDs.Contains(txID) {
+			rewardedTxIDs = append(rewardedTxIDs, txID)
+		}
+	}
+
+	// A batch is pruned once none of its delegations is left and none of them
+	// was rewarded in a retained block.
+	prunableTxIDs := rewardedTxIDs[:0]
+	for _, txID := range rewardedTxIDs {
+		batchTxID, ok := batchTxIDs[txID]
+		if !ok {
+			prunableTxIDs = append(prunableTxIDs, txID)
+			continue
+		}
+		if !stakerTxIDs.Contains(batchTxID) {
+			rewardedBatchTxIDs.Add(batchTxID)
+		}
+	}
+	for txID := range retainedRewardTxIDs {
+		if batchTxID, ok := batchTxIDs[txID]; ok {
+			rewardedBatchTxIDs.Remove(batchTxID)
+		}
+	}
+	for batchTxID := range rewardedBatchTxIDs {
+		prunableTxIDs = append(prunableTxIDs, batchTxID)
+	}
+	return prunedTxIDs, prunableTxIDs, nil
+}
+
+// acceptanceTime returns the time [blk] was accepted at the latest. Apricot
+// blocks don't have a timestamp, but they were all accepted before Banff
+// activated.
+func (s *state) acceptanceTime(blk blocks.Block) time.Time {
+	if banffBlk, ok := blk.(blocks.BanffBlock); ok {
+		return banffBlk.Timestamp()
+	}
+	return s.cfg.BanffTime
+}
+
+// stakerTxIDs returns the IDs of the txs that added the current and pending
+// stakers. The stakers added by an AddDelegatorsTx are included along with the
+// ID of that tx.
+func (s *state) stakerTxIDs() (ids.Set, error) {
+	stakerTxIDs := ids.Set{}
+	for _, getIterator := range []func() (StakerIterator, error){
+		s.GetCurrentStakerIterator,
+		s.GetPendingStakerIterator,
+	} {
+		it, err := getIterator()
+		if err != nil {
+			return nil, err
+		}
+		for it.Next() {
+			staker := it.Value()
+			stakerTxIDs.Add(staker.TxID)
+			if staker.BatchTxID != ids.Empty {
+				stakerTxIDs.Add(staker.BatchTxID)
+			}
+		}
+		it.Release()
+	}
+	return stakerTxIDs, nil
+}
+
+// isRetainedTx returns true if [tx] is needed to verify new blocks.
+func isRetainedTx(tx *txs.Tx, stakerTxIDs ids.Set) bool {
+	switch tx.Unsigned.(type) {
+	case *txs.CreateSubnetTx, *txs.CreateChainTx, *txs.TransformSubnetTx:
+		return true
+	case txs.StakerTx, *txs.AddDelegatorsTx:
+		return stakerTxIDs.Contains(tx.ID())
+	default:
+		return false
+	}
+}
+
+func (s *state) pruneTx(txID ids.ID, report *pruneReport) error {
+	txBytes, err := s.txDB.Get(txID[:])
+	if err == database.ErrNotFound {
+		// Pruned by a previous run
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	report.txs++
+	report.txBytes += len(txID) + len(txBytes)
+	if s.cfg.Pruning.DryRun {
+		return nil
+	}
+	s.txCache.Evict(txID)
+	return s.txDB.Delete(txID[:])
+}
+
+func (s *state) pruneRewardUTXOs(txID ids.ID, report *pruneReport) error {
+	rawTxDB := prefixdb.New(txID[:], s.rewardUTXODB)
+	it := rawTxDB.NewIterator()
+	defer it.Release()
+
+	var keys [][]byte
+	for it.Next() {
+		report.rewardUTXOBytes += len(it.Key()) + len(it.Value())
+		keys = append(keys, utils.CopyBytes(it.Key()))
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		// Pruned by a previous run
+		return nil
+	}
+
+	report.rewardedStakers++
+	if s.cfg.Pruning.DryRun {
+		return nil
+	}
+
+	s.rewardUTXOsCache.Evict(txID)
+	for _, key := range keys {
+		if err := rawTxDB.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
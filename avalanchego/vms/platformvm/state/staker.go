@@ -5,11 +5,14 @@ package state
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 
 	"github.com/google/btree"
 
 	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 )
 
@@ -41,6 +44,10 @@ type Staker struct {
 	EndTime         time.Time
 	PotentialReward uint64
 
+	// PublicKey is the BLS key registered by a primary network validator, if
+	// any.
+	PublicKey *bls.PublicKey
+
 	// NextTime is the next time this staker will be moved from a validator set.
 	// If the staker is in the pending validator set, NextTime will equal
 	// StartTime. If the staker is in the current validator set, NextTime will
@@ -53,6 +60,12 @@ type Staker struct {
 	// [priorities.go] and depends on if the stakers are in the pending or
 	// current validator set.
 	Priority txs.Priority
+
+	// BatchTxID is the ID of the AddDelegatorsTx that added this staker, if it
+	// is one of its delegations, and BatchIndex is the index of the delegation.
+	// Such a staker has no tx of its own: its TxID is derived from them.
+	BatchTxID  ids.ID
+	BatchIndex uint32
 }
 
 // A *Staker is considered to be less than another *Staker when:
@@ -93,6 +106,7 @@ func NewCurrentStaker(txID ids.ID, staker txs.Staker, potentialReward uint64) *S
 		StartTime:       staker.StartTime(),
 		EndTime:         endTime,
 		PotentialReward: potentialReward,
+		PublicKey:       stakerPublicKey(staker),
 		NextTime:        endTime,
 		Priority:        staker.CurrentPriority(),
 	}
@@ -107,7 +121,68 @@ func NewPendingStaker(txID ids.ID, staker txs.Staker) *Staker {
 		Weight:    staker.Weight(),
 		StartTime: startTime,
 		EndTime:   staker.EndTime(),
+		PublicKey: stakerPublicKey(staker),
 		NextTime:  startTime,
 		Priority:  staker.PendingPriority(),
 	}
 }
+
+// stakerPublicKey returns the BLS key registered by [staker], if it is a
+// primary network validator that registered one.
+func stakerPublicKey(staker txs.Staker) *bls.PublicKey {
+	tx, ok := staker.(*txs.AddPermissionlessValidatorTx)
+	if !ok || tx.Subnet != constants.PrimaryNetworkID {
+		return nil
+	}
+	return tx.Signer.Key()
+}
+
+// NewPendingBatchDelegator returns the pending staker of the [i]-th delegation
+// of the AddDelegatorsTx [txID].
+func NewPendingBatchDelegator(txID ids.ID, tx *txs.AddDelegatorsTx, i int) (*Staker, error) {
+	delegatorTx, err := tx.DelegatorTx(i)
+	if err != nil {
+		return nil, err
+	}
+	staker := NewPendingStaker(txs.DelegationID(txID, i), delegatorTx)
+	staker.BatchTxID = txID
+	staker.BatchIndex = uint32(i)
+	return staker, nil
+}
+
+// GetStakerTx returns the unsigned tx that added [staker]. The delegations of
+// an AddDelegatorsTx are returned as the AddDelegatorTx they're staked as.
+func GetStakerTx(chain Chain, staker *Staker) (txs.UnsignedTx, error) {
+	if staker.BatchTxID == ids.Empty {
+		tx, _, err := chain.GetTx(staker.TxID)
+		if err != nil {
+			return nil, err
+		}
+		return tx.Unsigned, nil
+	}
+	return getBatchDelegatorTx(chain, staker.BatchTxID, staker.BatchIndex)
+}
+
+func getBatchDelegatorTx(chain Chain, txID ids.ID, index uint32) (*txs.AddDelegatorTx, error) {
+	tx, _, err := chain.GetTx(txID)
+	if err != nil {
+		return nil, err
+	}
+	batchTx, ok := tx.Unsigned.(*txs.AddDelegatorsTx)
+	if !ok {
+		return nil, fmt.Errorf("expected tx type *txs.AddDelegatorsTx but got %T", tx.Unsigned)
+	}
+	return batchTx.DelegatorTx(int(index))
+}
+
+// NewRestakedStaker returns the staker of the staking period that follows the
+// one of [staker]. The new period starts when the one of [staker] ends and has
+// the same duration.
+func NewRestakedStaker(staker *Staker, potentialReward uint64) *Staker {
+	restaked := *staker
+	restaked.StartTime = staker.EndTime
+	restaked.EndTime = staker.EndTime.Add(staker.EndTime.Sub(staker.StartTime))
+	restaked.NextTime = restaked.EndTime
+	restaked.PotentialReward = potentialReward
+	return &restaked
+}
@@ -0,0 +1,300 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package state
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
+)
+
+// List of possible staker event types:
+// - [StakerAdded] The validator entered the current validator set
+// - [DelegationAdded] A delegation to the validator started
+// - [DelegationRemoved] A delegation to the validator ended
+// - [WeightChanged] The total weight of the validator changed
+// - [StakerRewarded] The validator was rewarded when leaving the validator set
+// - [StakerRemoved] The validator left the current validator set
+// - [StakerRestaked] The validator was restaked for another staking period
+const (
+	StakerAdded StakerEventType = iota
+	DelegationAdded
+	DelegationRemoved
+	WeightChanged
+	StakerRewarded
+	StakerRemoved
+	StakerRestaked
+)
+
+var (
+	errUnknownStakerEventType = errors.New("unknown staker event type")
+
+	_ json.Marshaler = StakerEventType(0)
+	_ fmt.Stringer   = StakerEventType(0)
+)
+
+type StakerEventType uint8
+
+func (t StakerEventType) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + t.String() + `"`), t.Verify()
+}
+
+func (t *StakerEventType) UnmarshalJSON(b []byte) error {
+	switch string(b) {
+	case `"Added"`:
+		*t = StakerAdded
+	case `"DelegationAdded"`:
+		*t = DelegationAdded
+	case `"DelegationRemoved"`:
+		*t = DelegationRemoved
+	case `"WeightChanged"`:
+		*t = WeightChanged
+	case `"Rewarded"`:
+		*t = StakerRewarded
+	case `"Removed"`:
+		*t = StakerRemoved
+	case `"Restaked"`:
+		*t = StakerRestaked
+	default:
+		return errUnknownStakerEventType
+	}
+	return nil
+}
+
+// Verify that this is a valid staker event type.
+func (t StakerEventType) Verify() error {
+	switch t {
+	case StakerAdded, DelegationAdded, DelegationRemoved, WeightChanged, StakerRewarded, StakerRemoved, StakerRestaked:
+		return nil
+	default:
+		return errUnknownStakerEventType
+	}
+}
+
+func (t StakerEventType) String() string {
+	switch t {
+	case StakerAdded:
+		return "Added"
+	case DelegationAdded:
+		return "DelegationAdded"
+	case DelegationRemoved:
+		return "DelegationRemoved"
+	case WeightChanged:
+		return "WeightChanged"
+	case StakerRewarded:
+		return "Rewarded"
+	case StakerRemoved:
+		return "Removed"
+	case StakerRestaked:
+		return "Restaked"
+	default:
+		return "Unknown"
+	}
+}
+
+// StakerEvent is an entry of the lifecycle of a validator.
+type StakerEvent struct {
+	Type StakerEventType `serialize:"true"`
+	// Height of the block that caused the event
+	Height   uint64 `serialize:"true"`
+	SubnetID ids.ID `serialize:"true"`
+	// ID of the tx that added the validator or the delegator the event is
+	// about. Empty for [WeightChanged] events.
+	TxID ids.ID `serialize:"true"`
+	// Weight of the validator or delegator the event is about. For
+	// [WeightChanged] events, the total weight of the validator after the
+	// change.
+	Weight uint64 `serialize:"true"`
+	// Reward minted when the validator or delegator was removed
+	Reward uint64 `serialize:"true"`
+}
+
+// stakerEventKey returns the key of the [index]th event of [nodeID] at
+// [height].
+func stakerEventKey(nodeID ids.NodeID, height uint64, index uint16) []byte {
+	key := make([]byte, hashing.AddrLen+wrappers.LongLen+wrappers.ShortLen)
+	copy(key, nodeID[:])
+	binary.BigEndian.PutUint64(key[hashing.AddrLen:], height)
+	binary.BigEndian.PutUint16(key[hashing.AddrLen+wrappers.LongLen:], index)
+	return key
+}
+
+func (s *state) GetStakerEvents(nodeID ids.NodeID, startHeight uint64, limit int) ([]*StakerEvent, error) {
+	it := s.stakerEventDB.NewIteratorWithStartAndPrefix(
+		stakerEventKey(nodeID, startHeight, 0),
+		nodeID[:],
+	)
+	defer it.Release()
+
+	var events []*StakerEvent
+	for it.Next() {
+		event := &StakerEvent{}
+		if _, err := blocks.GenesisCodec.Unmarshal(it.Value(), event); err != nil {
+			return nil, err
+		}
+
+		// Never split the events of a height so that the next page can start
+		// at the following height.
+		if numEvents := len(events); numEvents > 0 && numEvents >= limit && events[numEvents-1].Height != event.Height {
+			break
+		}
+		events = append(events, event)
+	}
+	return events, it.Error()
+}
+
+// writeStakerEvents records the lifecycle events caused by the pending changes
+// to the current validator set at [height]. It must be called before the
+// pending stakers and reward UTXOs are written.
+func (s *state) writeStakerEvents(height uint64) error {
+	subnetIDs := make([]ids.ID, 0, len(s.currentStakers.validatorDiffs))
+	for subnetID := range s.currentStakers.validatorDiffs {
+		subnetIDs = append(subnetIDs, subnetID)
+	}
+	ids.SortIDs(subnetIDs)
+
+	nextIndex := make(map[ids.NodeID]uint16)
+	for _, subnetID := range subnetIDs {
+		for nodeID, validatorDiff := range s.currentStakers.validatorDiffs[subnetID] {
+			events, err := s.stakerEvents(height, subnetID, nodeID, validatorDiff)
+			if err != nil {
+				return err
+			}
+
+			for _, event := range events {
+				eventBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, event)
+				if err != nil {
+					return fmt.Errorf("failed to serialize staker event: %w", err)
+				}
+
+				index := nextIndex[nodeID]
+				nextIndex[nodeID]++
+				if err := s.stakerEventDB.Put(stakerEventKey(nodeID, height, index), eventBytes); err != nil {
+					return fmt.Errorf("failed to write staker event: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *state) stakerEvents(
+	height uint64,
+	subnetID ids.ID,
+	nodeID ids.NodeID,
+	validatorDiff *diffValidator,
+) ([]*StakerEvent, error) {
+	newEvent := func(eventType StakerEventType, staker *Staker) *StakerEvent {
+		return &StakerEvent{
+			Type:     eventType,
+			Height:   height,
+			SubnetID: subnetID,
+			TxID:     staker.TxID,
+			Weight:   staker.Weight,
+		}
+	}
+
+	var events []*StakerEvent
+	if validatorDiff.validatorModified && !validatorDiff.validatorDeleted && !validatorDiff.validatorRestaked {
+		events = append(events, newEvent(StakerAdded, validatorDiff.validator))
+	}
+
+	delegationsModified := false
+	addedDelegatorIterator := NewTreeIterator(validatorDiff.addedDelegators)
+	for addedDelegatorIterator.Next() {
+		events = append(events, newEvent(DelegationAdded, addedDelegatorIterator.Value()))
+		delegationsModified = true
+	}
+	addedDelegatorIterator.Release()
+
+	for _, staker := range validatorDiff.deletedDelegators {
+		event := newEvent(DelegationRemoved, staker)
+		reward, err := s.addedReward(staker.TxID)
+		if err != nil {
+			return nil, err
+		}
+		event.Reward = reward
+		events = append(events, event)
+		delegationsModified = true
+	}
+
+	if validatorDiff.validatorDeleted || validatorDiff.validatorRestaked {
+		staker := validatorDiff.validator
+		reward, err := s.addedReward(staker.TxID)
+		if err != nil {
+			return nil, err
+		}
+		if reward > 0 {
+			event := newEvent(StakerRewarded, staker)
+			event.Reward = reward
+			events = append(events, event)
+		}
+		if validatorDiff.validatorDeleted {
+			return append(events, newEvent(StakerRemoved, staker)), nil
+		}
+		events = append(events, newEvent(StakerRestaked, staker))
+	}
+
+	if delegationsModified {
+		weight, err := s.currentValidatorWeight(subnetID, nodeID)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, &StakerEvent{
+			Type:     WeightChanged,
+			Height:   height,
+			SubnetID: subnetID,
+			Weight:   weight,
+		})
+	}
+	return events, nil
+}
+
+// addedReward returns the amount rewarded by the reward UTXOs of [txID] that
+// are pending to be written.
+func (s *state) addedReward(txID ids.ID) (uint64, error) {
+	var reward uint64
+	for _, utxo := range s.addedRewardUTXOs[txID] {
+		out, ok := utxo.Out.(avax.TransferableOut)
+		if !ok {
+			continue
+		}
+		var err error
+		reward, err = math.Add64(reward, out.Amount())
+		if err != nil {
+			return 0, err
+		}
+	}
+	return reward, nil
+}
+
+// currentValidatorWeight returns the weight of the validator, including its
+// delegations, in the current validator set.
+func (s *state) currentValidatorWeight(subnetID ids.ID, nodeID ids.NodeID) (uint64, error) {
+	validator, ok := s.currentStakers.validators[subnetID][nodeID]
+	if !ok || validator.validator == nil {
+		return 0, database.ErrNotFound
+	}
+
+	weight := validator.validator.Weight
+	delegatorIterator := NewTreeIterator(validator.delegators)
+	defer delegatorIterator.Release()
+	for delegatorIterator.Next() {
+		var err error
+		weight, err = math.Add64(weight, delegatorIterator.Value().Weight)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return weight, nil
+}
@@ -33,6 +33,13 @@ type CurrentStakers interface {
 	// Invariant: [staker] is currently a CurrentValidator
 	DeleteCurrentValidator(staker *Staker)
 
+	// RestakeCurrentValidator replaces the current validator with the TxID of
+	// [staker] by [staker], which describes its next staking period.
+	//
+	// Invariant: A primary network validator with the TxID and weight of
+	//            [staker] is currently a CurrentValidator
+	RestakeCurrentValidator(staker *Staker)
+
 	// GetCurrentDelegatorIterator returns the delegators associated with the
 	// validator on [subnetID] with [nodeID]. Delegators are sorted by their
 	// removal from current staker set.
@@ -130,6 +137,22 @@ func (v *baseStakers) PutValidator(staker *Staker) {
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	validatorDiff.validatorModified = true
 	validatorDiff.validatorDeleted = false
+	validatorDiff.validatorRestaked = false
+	validatorDiff.validator = staker
+
+	v.stakers.ReplaceOrInsert(staker)
+}
+
+func (v *baseStakers) RestakeValidator(staker *Staker) {
+	validator := v.getOrCreateValidator(staker.SubnetID, staker.NodeID)
+	v.stakers.Delete(validator.validator)
+	validator.validator = staker
+
+	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
+	// A validator added since the last write is still written as added.
+	validatorDiff.validatorRestaked = !validatorDiff.validatorModified || validatorDiff.validatorRestaked
+	validatorDiff.validatorModified = true
+	validatorDiff.validatorDeleted = false
 	validatorDiff.validator = staker
 
 	v.stakers.ReplaceOrInsert(staker)
@@ -143,6 +166,7 @@ func (v *baseStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := v.getOrCreateValidatorDiff(staker.SubnetID, staker.NodeID)
 	validatorDiff.validatorModified = true
 	validatorDiff.validatorDeleted = true
+	validatorDiff.validatorRestaked = false
 	validatorDiff.validator = staker
 
 	v.stakers.Delete(staker)
@@ -246,13 +270,18 @@ type diffStakers struct {
 	validatorDiffs map[ids.ID]map[ids.NodeID]*diffValidator
 	addedStakers   *btree.BTree
 	deletedStakers map[ids.ID]*Staker
+	// Stakers that replace the stakers with the same TxID in [deletedStakers]
+	restakedStakers *btree.BTree
 }
 
 type diffValidator struct {
 	validatorModified bool
 	// [validatorDeleted] implies [validatorModified]
 	validatorDeleted bool
-	validator        *Staker
+	// [validatorRestaked] implies [validatorModified] and not
+	// [validatorDeleted]
+	validatorRestaked bool
+	validator         *Staker
 
 	addedDelegators   *btree.BTree
 	deletedDelegators map[ids.ID]*Staker
@@ -299,10 +328,43 @@ func (s *diffStakers) PutValidator(staker *Staker) {
 	s.addedStakers.ReplaceOrInsert(staker)
 }
 
+func (s *diffStakers) RestakeValidator(staker *Staker) {
+	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorModified && !validatorDiff.validatorRestaked {
+		// The validator was added by this diff, so it is replaced in place.
+		s.addedStakers.Delete(validatorDiff.validator)
+		s.addedStakers.ReplaceOrInsert(staker)
+		validatorDiff.validator = staker
+		return
+	}
+
+	if validatorDiff.validatorRestaked {
+		s.restakedStakers.Delete(validatorDiff.validator)
+	}
+	validatorDiff.validatorModified = true
+	validatorDiff.validatorRestaked = true
+	validatorDiff.validator = staker
+
+	// The previous period of the validator is masked by its TxID.
+	if s.deletedStakers == nil {
+		s.deletedStakers = make(map[ids.ID]*Staker)
+	}
+	s.deletedStakers[staker.TxID] = staker
+
+	if s.restakedStakers == nil {
+		s.restakedStakers = btree.New(defaultTreeDegree)
+	}
+	s.restakedStakers.ReplaceOrInsert(staker)
+}
+
 func (s *diffStakers) DeleteValidator(staker *Staker) {
 	validatorDiff := s.getOrCreateDiff(staker.SubnetID, staker.NodeID)
+	if validatorDiff.validatorRestaked {
+		s.restakedStakers.Delete(validatorDiff.validator)
+	}
 	validatorDiff.validatorModified = true
 	validatorDiff.validatorDeleted = true
+	validatorDiff.validatorRestaked = false
 	validatorDiff.validator = staker
 
 	if s.deletedStakers == nil {
@@ -363,12 +425,15 @@ func (s *diffStakers) DeleteDelegator(staker *Staker) {
 }
 
 func (s *diffStakers) GetStakerIterator(parentIterator StakerIterator) StakerIterator {
-	return NewMaskedIterator(
-		NewMergedIterator(
-			parentIterator,
-			NewTreeIterator(s.addedStakers),
+	return NewMergedIterator(
+		NewMaskedIterator(
+			NewMergedIterator(
+				parentIterator,
+				NewTreeIterator(s.addedStakers),
+			),
+			s.deletedStakers,
 		),
-		s.deletedStakers,
+		NewTreeIterator(s.restakedStakers),
 	)
 }
 
@@ -174,6 +174,33 @@ func TestDiffStakersValidator(t *testing.T) {
 	assertIteratorsEqual(t, NewSliceIterator(delegator), stakerIterator)
 }
 
+func TestDiffStakersRestakeValidator(t *testing.T) {
+	require := require.New(t)
+	staker := newTestStaker()
+	restakedStaker := NewRestakedStaker(staker, 2)
+
+	v := diffStakers{}
+
+	v.RestakeValidator(restakedStaker)
+
+	returnedStaker, ok := v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.True(ok)
+	require.Equal(restakedStaker, returnedStaker)
+
+	// The staker of the previous period is replaced by the restaked one
+	stakerIterator := v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, NewSliceIterator(restakedStaker), stakerIterator)
+
+	v.DeleteValidator(restakedStaker)
+
+	returnedStaker, ok = v.GetValidator(staker.SubnetID, staker.NodeID)
+	require.True(ok)
+	require.Nil(returnedStaker)
+
+	stakerIterator = v.GetStakerIterator(NewSliceIterator(staker))
+	assertIteratorsEqual(t, EmptyIterator, stakerIterator)
+}
+
 func TestDiffStakersDelegator(t *testing.T) {
 	staker := newTestStaker()
 	delegator := newTestStaker()
@@ -24,6 +24,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/hashing"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
@@ -42,6 +43,7 @@ const (
 	blockCacheSize          = 2048
 	txCacheSize             = 2048
 	rewardUTXOsCacheSize    = 2048
+	rewardContextCacheSize  = 2048
 	chainCacheSize          = 2048
 	chainDBCacheSize        = 2048
 )
@@ -59,20 +61,29 @@ var (
 	delegatorPrefix         = []byte("delegator")
 	subnetValidatorPrefix   = []byte("subnetValidator")
 	subnetDelegatorPrefix   = []byte("subnetDelegator")
+	restakedValidatorPrefix = []byte("restakedValidator")
+	batchDelegatorPrefix    = []byte("batchDelegator")
 	validatorDiffsPrefix    = []byte("validatorDiffs")
+	publicKeyDiffsPrefix    = []byte("publicKeyDiffs")
 	txPrefix                = []byte("tx")
 	rewardUTXOsPrefix       = []byte("rewardUTXOs")
+	rewardContextPrefix     = []byte("rewardContext")
 	utxoPrefix              = []byte("utxo")
 	subnetPrefix            = []byte("subnet")
 	transformedSubnetPrefix = []byte("transformedSubnet")
 	supplyPrefix            = []byte("supply")
 	chainPrefix             = []byte("chain")
 	singletonPrefix         = []byte("singleton")
-
-	timestampKey     = []byte("timestamp")
-	currentSupplyKey = []byte("current supply")
-	lastAcceptedKey  = []byte("last accepted")
-	initializedKey   = []byte("initialized")
+	historyPrefix           = []byte("history")
+	balancePrefix           = []byte("balance")
+	stakePrefix             = []byte("stake")
+	stakerEventPrefix       = []byte("stakerEvent")
+
+	timestampKey      = []byte("timestamp")
+	currentSupplyKey  = []byte("current supply")
+	lastAcceptedKey   = []byte("last accepted")
+	initializedKey    = []byte("initialized")
+	historyIndexedKey = []byte("history indexed")
 )
 
 // Chain collects all methods to manage the state of the chain for block
@@ -94,6 +105,11 @@ type Chain interface {
 	GetRewardUTXOs(txID ids.ID) ([]*avax.UTXO, error)
 	AddRewardUTXO(txID ids.ID, utxo *avax.UTXO)
 
+	// GetRewardContext returns the inputs of the reward calculation of the
+	// staker added by [txID].
+	GetRewardContext(txID ids.ID) (*reward.Context, error)
+	SetRewardContext(txID ids.ID, rewardContext *reward.Context)
+
 	GetSubnets() ([]*txs.Tx, error)
 	AddSubnet(createSubnetTx *txs.Tx)
 
@@ -126,6 +142,24 @@ type State interface {
 
 	GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids.NodeID]*ValidatorWeightDiff, error)
 
+	// GetValidatorPublicKeyDiffs returns the BLS public keys that the primary
+	// network validators removed at [height] had before [height]. A nil key
+	// means that the validator had no key.
+	GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error)
+
+	// GetBalanceAt returns the AVAX owned by [addr] once the block at [height]
+	// was accepted. Requires the historical state index to be enabled.
+	GetBalanceAt(addr ids.ShortID, height uint64) (uint64, error)
+
+	// GetStakeAt returns the AVAX staked by [addr] once the block at [height]
+	// was accepted. Requires the historical state index to be enabled.
+	GetStakeAt(addr ids.ShortID, height uint64) (uint64, error)
+
+	// GetStakerEvents returns the lifecycle events of the validator [nodeID],
+	// starting at [startHeight]. At least [limit] events are returned if
+	// available, the events of a height are never split.
+	GetStakerEvents(nodeID ids.NodeID, startHeight uint64, limit int) ([]*StakerEvent, error)
+
 	// Return the current validator set of [subnetID].
 	ValidatorSet(subnetID ids.ID) (validators.Set, error)
 
@@ -191,6 +225,8 @@ type stateBlk struct {
  * | '-. txID
  * |   '-. list
  * |     '-- utxoID -> utxo bytes
+ * |-. rewardContexts
+ * | '-- txID -> reward context bytes
  * |- utxos
  * | '-- utxoDB
  * |-. subnets
@@ -200,8 +236,16 @@ type stateBlk struct {
  * | '-. subnetID
  * |   '-. list
  * |     '-- txID -> nil
+ * |-. stakerEvents
+ * | '-- nodeID + height + index -> staker event
+ * |-. history
+ * | |-. balance
+ * | | '-- addr + ^height -> balance
+ * | '-. stake
+ * |   '-- addr + ^height -> stake
  * '-. singletons
  *   |-- initializedKey -> nil
+ *   |-- historyIndexedKey -> nil
  *   |-- timestampKey -> timestamp
  *   |-- currentSupplyKey -> currentSupply
  *   '-- lastAcceptedKey -> lastAccepted
@@ -236,6 +280,12 @@ type state struct {
 	currentSubnetValidatorList   linkeddb.LinkedDB
 	currentSubnetDelegatorBaseDB database.Database
 	currentSubnetDelegatorList   linkeddb.LinkedDB
+	// txID -> start time of the current staking period of a restaked
+	// validator, in unix seconds
+	restakedValidatorDB database.Database
+	// staker ID -> the AddDelegatorsTx and index of the delegation of a
+	// pending or current delegator added by an AddDelegatorsTx
+	batchDelegatorDB             database.Database
 	pendingValidatorsDB          database.Database
 	pendingValidatorBaseDB       database.Database
 	pendingValidatorList         linkeddb.LinkedDB
@@ -248,6 +298,9 @@ type state struct {
 
 	validatorDiffsCache cache.Cacher // cache of heightWithSubnet -> map[ids.ShortID]*ValidatorWeightDiff
 	validatorDiffsDB    database.Database
+	// height -> nodeID -> BLS public key of a primary network validator
+	// removed at that height, empty if it had none
+	publicKeyDiffsDB database.Database
 
 	addedTxs map[ids.ID]*txAndStatus // map of txID -> {*txs.Tx, Status}
 	txCache  cache.Cacher            // cache of txID -> {*txs.Tx, Status} if the entry is nil, it is not in the database
@@ -257,6 +310,10 @@ type state struct {
 	rewardUTXOsCache cache.Cacher            // cache of txID -> []*UTXO
 	rewardUTXODB     database.Database
 
+	modifiedRewardContexts map[ids.ID]*reward.Context // map of txID -> reward context
+	rewardContextCache     cache.Cacher               // cache of txID -> reward context if the entry is nil, it is not in the database
+	rewardContextDB        database.Database
+
 	modifiedUTXOs map[ids.ID]*avax.UTXO // map of modified UTXOID -> *UTXO if the UTXO is nil, it has been removed
 	utxoDB        database.Database
 	utxoState     avax.UTXOState
@@ -285,6 +342,12 @@ type state struct {
 	// [lastAccepted] is the most recently accepted block.
 	lastAccepted, persistedLastAccepted ids.ID
 	singletonDB                         database.Database
+
+	stakerEventDB database.Database
+
+	historyDB        database.Database
+	balanceHistoryDB database.Database
+	stakeHistoryDB   database.Database
 }
 
 type ValidatorWeightDiff struct {
@@ -323,6 +386,11 @@ type txAndStatus struct {
 	status status.Status
 }
 
+type batchDelegation struct {
+	TxID  ids.ID `serialize:"true"`
+	Index uint32 `serialize:"true"`
+}
+
 type uptimeAndReward struct {
 	txID        ids.ID
 	lastUpdated time.Time
@@ -360,6 +428,13 @@ func New(
 		return nil, err
 	}
 
+	if err := s.prune(); err != nil {
+		// Drop any errors on close to return the first error
+		_ = s.Close()
+
+		return nil, fmt.Errorf("failed to prune the database: %w", err)
+	}
+
 	for _, vdr := range validators.DefaultValidatorList() {
 		s.uptimes[vdr.ID()] = &uptimeAndReward{
 			txID:        ids.Empty,
@@ -396,6 +471,8 @@ func new(
 	currentDelegatorBaseDB := prefixdb.New(delegatorPrefix, currentValidatorsDB)
 	currentSubnetValidatorBaseDB := prefixdb.New(subnetValidatorPrefix, currentValidatorsDB)
 	currentSubnetDelegatorBaseDB := prefixdb.New(subnetDelegatorPrefix, currentValidatorsDB)
+	restakedValidatorDB := prefixdb.New(restakedValidatorPrefix, currentValidatorsDB)
+	batchDelegatorDB := prefixdb.New(batchDelegatorPrefix, validatorsDB)
 
 	pendingValidatorsDB := prefixdb.New(pendingPrefix, validatorsDB)
 	pendingValidatorBaseDB := prefixdb.New(validatorPrefix, pendingValidatorsDB)
@@ -439,6 +516,15 @@ func new(
 		return nil, err
 	}
 
+	rewardContextCache, err := metercacher.New(
+		"reward_context_cache",
+		metricsReg,
+		&cache.LRU{Size: rewardContextCacheSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	subnetBaseDB := prefixdb.New(subnetPrefix, baseDB)
 
 	transformedSubnetCache, err := metercacher.New(
@@ -477,6 +563,8 @@ func new(
 		return nil, err
 	}
 
+	historyDB := prefixdb.New(historyPrefix, baseDB)
+
 	return &state{
 		cfg:     cfg,
 		ctx:     ctx,
@@ -504,6 +592,8 @@ func new(
 		currentSubnetValidatorList:   linkeddb.NewDefault(currentSubnetValidatorBaseDB),
 		currentSubnetDelegatorBaseDB: currentSubnetDelegatorBaseDB,
 		currentSubnetDelegatorList:   linkeddb.NewDefault(currentSubnetDelegatorBaseDB),
+		restakedValidatorDB:          restakedValidatorDB,
+		batchDelegatorDB:             batchDelegatorDB,
 		pendingValidatorsDB:          pendingValidatorsDB,
 		pendingValidatorBaseDB:       pendingValidatorBaseDB,
 		pendingValidatorList:         linkeddb.NewDefault(pendingValidatorBaseDB),
@@ -515,6 +605,7 @@ func new(
 		pendingSubnetDelegatorList:   linkeddb.NewDefault(pendingSubnetDelegatorBaseDB),
 		validatorDiffsDB:             validatorDiffsDB,
 		validatorDiffsCache:          validatorDiffsCache,
+		publicKeyDiffsDB:             prefixdb.New(publicKeyDiffsPrefix, validatorsDB),
 
 		addedTxs: make(map[ids.ID]*txAndStatus),
 		txDB:     prefixdb.New(txPrefix, baseDB),
@@ -524,6 +615,10 @@ func new(
 		rewardUTXODB:     rewardUTXODB,
 		rewardUTXOsCache: rewardUTXOsCache,
 
+		modifiedRewardContexts: make(map[ids.ID]*reward.Context),
+		rewardContextCache:     rewardContextCache,
+		rewardContextDB:        prefixdb.New(rewardContextPrefix, baseDB),
+
 		modifiedUTXOs: make(map[ids.ID]*avax.UTXO),
 		utxoDB:        utxoDB,
 		utxoState:     utxoState,
@@ -545,6 +640,12 @@ func new(
 		chainDBCache: chainDBCache,
 
 		singletonDB: prefixdb.New(singletonPrefix, baseDB),
+
+		stakerEventDB: prefixdb.New(stakerEventPrefix, baseDB),
+
+		historyDB:        historyDB,
+		balanceHistoryDB: prefixdb.New(balancePrefix, historyDB),
+		stakeHistoryDB:   prefixdb.New(stakePrefix, historyDB),
 	}, nil
 }
 
@@ -564,6 +665,10 @@ func (s *state) DeleteCurrentValidator(staker *Staker) {
 	s.currentStakers.DeleteValidator(staker)
 }
 
+func (s *state) RestakeCurrentValidator(staker *Staker) {
+	s.currentStakers.RestakeValidator(staker)
+}
+
 func (s *state) GetCurrentDelegatorIterator(subnetID ids.ID, nodeID ids.NodeID) (StakerIterator, error) {
 	return s.currentStakers.GetDelegatorIterator(subnetID, nodeID), nil
 }
@@ -815,6 +920,38 @@ func (s *state) AddRewardUTXO(txID ids.ID, utxo *avax.UTXO) {
 	s.addedRewardUTXOs[txID] = append(s.addedRewardUTXOs[txID], utxo)
 }
 
+func (s *state) GetRewardContext(txID ids.ID) (*reward.Context, error) {
+	if rewardContext, exists := s.modifiedRewardContexts[txID]; exists {
+		return rewardContext, nil
+	}
+	if rewardContextIntf, exists := s.rewardContextCache.Get(txID); exists {
+		if rewardContextIntf == nil {
+			return nil, database.ErrNotFound
+		}
+		return rewardContextIntf.(*reward.Context), nil
+	}
+
+	rewardContextBytes, err := s.rewardContextDB.Get(txID[:])
+	if err == database.ErrNotFound {
+		s.rewardContextCache.Put(txID, nil)
+		return nil, database.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rewardContext := &reward.Context{}
+	if _, err := txs.GenesisCodec.Unmarshal(rewardContextBytes, rewardContext); err != nil {
+		return nil, err
+	}
+	s.rewardContextCache.Put(txID, rewardContext)
+	return rewardContext, nil
+}
+
+func (s *state) SetRewardContext(txID ids.ID, rewardContext *reward.Context) {
+	s.modifiedRewardContexts[txID] = rewardContext
+}
+
 func (s *state) GetUTXO(utxoID ids.ID) (*avax.UTXO, error) {
 	if utxo, exists := s.modifiedUTXOs[utxoID]; exists {
 		if utxo == nil {
@@ -950,6 +1087,30 @@ func (s *state) GetValidatorWeightDiffs(height uint64, subnetID ids.ID) (map[ids
 	return weightDiffs, diffIter.Error()
 }
 
+func (s *state) GetValidatorPublicKeyDiffs(height uint64) (map[ids.NodeID]*bls.PublicKey, error) {
+	diffDB := prefixdb.New(database.PackUInt64(height), s.publicKeyDiffsDB)
+	diffIter := diffDB.NewIterator()
+	defer diffIter.Release()
+
+	pkDiffs := make(map[ids.NodeID]*bls.PublicKey)
+	for diffIter.Next() {
+		nodeID, err := ids.ToNodeID(diffIter.Key())
+		if err != nil {
+			return nil, err
+		}
+
+		var pk *bls.PublicKey
+		if pkBytes := diffIter.Value(); len(pkBytes) != 0 {
+			pk, err = bls.PublicKeyFromBytes(pkBytes)
+			if err != nil {
+				return nil, err
+			}
+		}
+		pkDiffs[nodeID] = pk
+	}
+	return pkDiffs, diffIter.Error()
+}
+
 func (s *state) ValidatorSet(subnetID ids.ID) (validators.Set, error) {
 	vdrs := validators.NewSet()
 	for nodeID, validator := range s.currentStakers.validators[subnetID] {
@@ -1013,6 +1174,14 @@ func (s *state) syncGenesis(genesisBlk blocks.Block, genesis *genesis.State) err
 		s.PutCurrentValidator(staker)
 		s.AddTx(vdrTx, status.Committed)
 		s.SetCurrentSupply(constants.PrimaryNetworkID, newCurrentSupply)
+		s.SetRewardContext(vdrTx.ID(), &reward.Context{
+			SubnetID:        constants.PrimaryNetworkID,
+			StakedAmount:    stakeAmount,
+			StakedDuration:  stakeDuration,
+			CurrentSupply:   currentSupply,
+			Config:          s.cfg.RewardConfig,
+			PotentialReward: potentialReward,
+		})
 	}
 
 	for _, chain := range genesis.Chains {
@@ -1074,6 +1243,53 @@ func (s *state) loadMetadata() error {
 	return nil
 }
 
+// loadRestakedPeriod moves [staker] to the staking period it was last restaked
+// for, if it was ever restaked.
+func (s *state) loadRestakedPeriod(staker *Staker) error {
+	startTime, err := database.GetUInt64(s.restakedValidatorDB, staker.TxID[:])
+	if err == database.ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	duration := staker.EndTime.Sub(staker.StartTime)
+	staker.StartTime = time.Unix(int64(startTime), 0)
+	staker.EndTime = staker.StartTime.Add(duration)
+	staker.NextTime = staker.EndTime
+	return nil
+}
+
+// loadDelegatorTx returns the tx of the delegator [txID]. The delegations of an
+// AddDelegatorsTx have no tx of their own, so they're loaded from the
+// AddDelegatorsTx, which is returned along with the index of the delegation.
+func (s *state) loadDelegatorTx(txID ids.ID) (txs.Staker, batchDelegation, error) {
+	batch := batchDelegation{}
+	batchBytes, err := s.batchDelegatorDB.Get(txID[:])
+	switch err {
+	case nil:
+		if _, err := blocks.GenesisCodec.Unmarshal(batchBytes, &batch); err != nil {
+			return nil, batch, err
+		}
+		delegatorTx, err := getBatchDelegatorTx(s, batch.TxID, batch.Index)
+		return delegatorTx, batch, err
+	case database.ErrNotFound:
+	default:
+		return nil, batch, err
+	}
+
+	tx, _, err := s.GetTx(txID)
+	if err != nil {
+		return nil, batch, err
+	}
+	stakerTx, ok := tx.Unsigned.(txs.Staker)
+	if !ok {
+		return nil, batch, fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
+	}
+	return stakerTx, batch, nil
+}
+
 func (s *state) loadCurrentValidators() error {
 	s.currentStakers = newBaseStakers()
 
@@ -1105,6 +1321,9 @@ func (s *state) loadCurrentValidators() error {
 		}
 
 		staker := NewCurrentStaker(txID, stakerTx, uptime.PotentialReward)
+		if err := s.loadRestakedPeriod(staker); err != nil {
+			return err
+		}
 		validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 		validator.validator = staker
 
@@ -1162,7 +1381,7 @@ func (s *state) loadCurrentValidators() error {
 			if err != nil {
 				return err
 			}
-			tx, _, err := s.GetTx(txID)
+			stakerTx, batch, err := s.loadDelegatorTx(txID)
 			if err != nil {
 				return err
 			}
@@ -1173,12 +1392,9 @@ func (s *state) loadCurrentValidators() error {
 				return err
 			}
 
-			stakerTx, ok := tx.Unsigned.(txs.Staker)
-			if !ok {
-				return fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
-			}
-
 			staker := NewCurrentStaker(txID, stakerTx, potentialReward)
+			staker.BatchTxID = batch.TxID
+			staker.BatchIndex = batch.Index
 			validator := s.currentStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			if validator.delegators == nil {
 				validator.delegators = btree.New(defaultTreeDegree)
@@ -1246,17 +1462,14 @@ func (s *state) loadPendingValidators() error {
 			if err != nil {
 				return err
 			}
-			tx, _, err := s.GetTx(txID)
+			stakerTx, batch, err := s.loadDelegatorTx(txID)
 			if err != nil {
 				return err
 			}
 
-			stakerTx, ok := tx.Unsigned.(txs.Staker)
-			if !ok {
-				return fmt.Errorf("expected tx type txs.Staker but got %T", tx.Unsigned)
-			}
-
 			staker := NewPendingStaker(txID, stakerTx)
+			staker.BatchTxID = batch.TxID
+			staker.BatchIndex = batch.Index
 			validator := s.pendingStakers.getOrCreateValidator(staker.SubnetID, staker.NodeID)
 			if validator.delegators == nil {
 				validator.delegators = btree.New(defaultTreeDegree)
@@ -1280,6 +1493,8 @@ func (s *state) loadPendingValidators() error {
 func (s *state) write(height uint64) error {
 	errs := wrappers.Errs{}
 	errs.Add(
+		s.writeHistory(height),
+		s.writeStakerEvents(height),
 		s.writeBlocks(),
 		s.writeCurrentPrimaryNetworkStakers(height),
 		s.writeCurrentSubnetStakers(height),
@@ -1288,6 +1503,7 @@ func (s *state) write(height uint64) error {
 		s.writeUptimes(),
 		s.writeTXs(),
 		s.writeRewardUTXOs(),
+		s.writeRewardContexts(),
 		s.writeUTXOs(),
 		s.writeSubnets(),
 		s.writeTransformedSubnets(),
@@ -1308,12 +1524,16 @@ func (s *state) Close() error {
 		s.pendingValidatorsDB.Close(),
 		s.currentSubnetValidatorBaseDB.Close(),
 		s.currentSubnetDelegatorBaseDB.Close(),
+		s.restakedValidatorDB.Close(),
+		s.batchDelegatorDB.Close(),
+		s.publicKeyDiffsDB.Close(),
 		s.currentDelegatorBaseDB.Close(),
 		s.currentValidatorBaseDB.Close(),
 		s.currentValidatorsDB.Close(),
 		s.validatorsDB.Close(),
 		s.txDB.Close(),
 		s.rewardUTXODB.Close(),
+		s.rewardContextDB.Close(),
 		s.utxoDB.Close(),
 		s.subnetBaseDB.Close(),
 		s.transformedSubnetDB.Close(),
@@ -1321,6 +1541,10 @@ func (s *state) Close() error {
 		s.chainDB.Close(),
 		s.singletonDB.Close(),
 		s.blockDB.Close(),
+		s.stakerEventDB.Close(),
+		s.balanceHistoryDB.Close(),
+		s.stakeHistoryDB.Close(),
+		s.historyDB.Close(),
 	)
 	return errs.Err
 }
@@ -1334,6 +1558,13 @@ func (s *state) sync(genesis []byte) error {
 		)
 	}
 
+	if err := s.syncHistory(shouldInit); err != nil {
+		return fmt.Errorf(
+			"failed to sync the historical state index: %w",
+			err,
+		)
+	}
+
 	// If the database is empty, create the platform chain anew using the
 	// provided genesis state
 	if shouldInit {
@@ -1486,6 +1717,7 @@ func (s *state) writeCurrentPrimaryNetworkStakers(height uint64) error {
 	}
 	rawDiffDB := prefixdb.New(prefixBytes, s.validatorDiffsDB)
 	diffDB := linkeddb.NewDefault(rawDiffDB)
+	pkDiffDB := prefixdb.New(database.PackUInt64(height), s.publicKeyDiffsDB)
 
 	weightDiffs := make(map[ids.NodeID]*ValidatorWeightDiff)
 	for nodeID, validatorDiff := range validatorDiffs {
@@ -1493,13 +1725,29 @@ func (s *state) writeCurrentPrimaryNetworkStakers(height uint64) error {
 		if validatorDiff.validatorModified {
 			staker := validatorDiff.validator
 
-			weightDiff.Decrease = validatorDiff.validatorDeleted
-			weightDiff.Amount = staker.Weight
+			// Restaking a validator doesn't change its weight.
+			if !validatorDiff.validatorRestaked {
+				weightDiff.Decrease = validatorDiff.validatorDeleted
+				weightDiff.Amount = staker.Weight
+			}
 
 			if validatorDiff.validatorDeleted {
 				if err := s.currentValidatorList.Delete(staker.TxID[:]); err != nil {
 					return fmt.Errorf("failed to delete current staker: %w", err)
 				}
+				if err := s.restakedValidatorDB.Delete(staker.TxID[:]); err != nil {
+					return fmt.Errorf("failed to delete restaked staker: %w", err)
+				}
+
+				// Record the key the validator had, so that the keys of past
+				// validator sets can be rebuilt.
+				var pkBytes []byte
+				if staker.PublicKey != nil {
+					pkBytes = bls.PublicKeyToBytes(staker.PublicKey)
+				}
+				if err := pkDiffDB.Put(nodeID[:], pkBytes); err != nil {
+					return fmt.Errorf("failed to write public key diff: %w", err)
+				}
 
 				delete(s.uptimes, nodeID)
 				delete(s.updatedUptimes, nodeID)
@@ -1522,12 +1770,21 @@ func (s *state) writeCurrentPrimaryNetworkStakers(height uint64) error {
 					return fmt.Errorf("failed to write current validator to list: %w", err)
 				}
 
+				// The uptime of a restaked validator is reset, so that it is
+				// only measured over its new staking period.
+				if validatorDiff.validatorRestaked {
+					if err := database.PutUInt64(s.restakedValidatorDB, staker.TxID[:], uint64(staker.StartTime.Unix())); err != nil {
+						return fmt.Errorf("failed to write restaked staker: %w", err)
+					}
+				}
+
 				s.uptimes[nodeID] = vdr
 			}
 		}
 
 		err := writeCurrentDelegatorDiff(
 			s.currentDelegatorList,
+			s.batchDelegatorDB,
 			weightDiff,
 			validatorDiff,
 		)
@@ -1627,6 +1884,7 @@ func (s *state) writeCurrentSubnetStakers(height uint64) error {
 
 			err := writeCurrentDelegatorDiff(
 				s.currentSubnetDelegatorList,
+				s.batchDelegatorDB,
 				weightDiff,
 				validatorDiff,
 			)
@@ -1670,6 +1928,7 @@ func (s *state) writeCurrentSubnetStakers(height uint64) error {
 
 func writeCurrentDelegatorDiff(
 	currentDelegatorList linkeddb.LinkedDB,
+	batchDelegatorDB database.KeyValueDeleter,
 	weightDiff *ValidatorWeightDiff,
 	validatorDiff *diffValidator,
 ) error {
@@ -1695,6 +1954,13 @@ func writeCurrentDelegatorDiff(
 		if err := currentDelegatorList.Delete(staker.TxID[:]); err != nil {
 			return fmt.Errorf("failed to delete current staker: %w", err)
 		}
+
+		if staker.BatchTxID == ids.Empty {
+			continue
+		}
+		if err := batchDelegatorDB.Delete(staker.TxID[:]); err != nil {
+			return fmt.Errorf("failed to delete batch delegator: %w", err)
+		}
 	}
 	return nil
 }
@@ -1704,6 +1970,7 @@ func (s *state) writePendingPrimaryNetworkStakers() error {
 		err := writePendingDiff(
 			s.pendingValidatorList,
 			s.pendingDelegatorList,
+			s.batchDelegatorDB,
 			validatorDiff,
 		)
 		if err != nil {
@@ -1727,6 +1994,7 @@ func (s *state) writePendingSubnetStakers() error {
 			err := writePendingDiff(
 				s.pendingSubnetValidatorList,
 				s.pendingSubnetDelegatorList,
+				s.batchDelegatorDB,
 				validatorDiff,
 			)
 			if err != nil {
@@ -1740,6 +2008,7 @@ func (s *state) writePendingSubnetStakers() error {
 func writePendingDiff(
 	pendingValidatorList linkeddb.LinkedDB,
 	pendingDelegatorList linkeddb.LinkedDB,
+	batchDelegatorDB database.KeyValueWriter,
 	validatorDiff *diffValidator,
 ) error {
 	if validatorDiff.validatorModified {
@@ -1764,6 +2033,20 @@ func writePendingDiff(
 		if err := pendingDelegatorList.Put(staker.TxID[:], nil); err != nil {
 			return fmt.Errorf("failed to write pending delegator to list: %w", err)
 		}
+
+		if staker.BatchTxID == ids.Empty {
+			continue
+		}
+		batchBytes, err := blocks.GenesisCodec.Marshal(blocks.Version, &batchDelegation{
+			TxID:  staker.BatchTxID,
+			Index: staker.BatchIndex,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to serialize batch delegator: %w", err)
+		}
+		if err := batchDelegatorDB.Put(staker.TxID[:], batchBytes); err != nil {
+			return fmt.Errorf("failed to write batch delegator: %w", err)
+		}
 	}
 
 	for _, staker := range validatorDiff.deletedDelegators {
@@ -1826,7 +2109,9 @@ func (s *state) writeTXs() error {
 func (s *state) writeRewardUTXOs() error {
 	for txID, utxos := range s.addedRewardUTXOs {
 		delete(s.addedRewardUTXOs, txID)
-		s.rewardUTXOsCache.Put(txID, utxos)
+		// The rewards of an AddDelegatorsTx are added over multiple blocks, so
+		// [utxos] may not be all of the reward UTXOs of [txID].
+		s.rewardUTXOsCache.Evict(txID)
 		rawTxDB := prefixdb.New(txID[:], s.rewardUTXODB)
 		txDB := linkeddb.NewDefault(rawTxDB)
 
@@ -1844,6 +2129,22 @@ func (s *state) writeRewardUTXOs() error {
 	return nil
 }
 
+func (s *state) writeRewardContexts() error {
+	for txID, rewardContext := range s.modifiedRewardContexts {
+		delete(s.modifiedRewardContexts, txID)
+		s.rewardContextCache.Put(txID, rewardContext)
+
+		rewardContextBytes, err := txs.GenesisCodec.Marshal(txs.Version, rewardContext)
+		if err != nil {
+			return fmt.Errorf("failed to serialize reward context: %w", err)
+		}
+		if err := s.rewardContextDB.Put(txID[:], rewardContextBytes); err != nil {
+			return fmt.Errorf("failed to write reward context: %w", err)
+		}
+	}
+	return nil
+}
+
 func (s *state) writeUTXOs() error {
 	for utxoID, utxo := range s.modifiedUTXOs {
 		delete(s.modifiedUTXOs, utxoID)
@@ -16,8 +16,10 @@ import (
 	"github.com/ava-labs/avalanchego/database/memdb"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/snow/choices"
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/components/avax"
@@ -26,6 +28,8 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/genesis"
 	"github.com/ava-labs/avalanchego/vms/platformvm/metrics"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
@@ -81,6 +85,49 @@ func TestStateSyncGenesis(t *testing.T) {
 	assertIteratorsEqual(t, EmptyIterator, delegatorIterator)
 }
 
+func TestRewardContext(t *testing.T) {
+	require := require.New(t)
+	state, db := newInitializedState(require)
+
+	// The reward context of genesis validators is recorded at genesis.
+	staker, err := state.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	genesisContext, err := state.GetRewardContext(staker.TxID)
+	require.NoError(err)
+	require.Equal(constants.PrimaryNetworkID, genesisContext.SubnetID)
+	require.Equal(staker.Weight, genesisContext.StakedAmount)
+	require.Equal(staker.EndTime.Sub(staker.StartTime), genesisContext.StakedDuration)
+	require.Equal(staker.PotentialReward, genesisContext.PotentialReward)
+	require.False(genesisContext.Removed)
+
+	txID := ids.GenerateTestID()
+	_, err = state.GetRewardContext(txID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	rewardContext := &reward.Context{
+		SubnetID:       ids.GenerateTestID(),
+		StakedAmount:   units.Avax,
+		StakedDuration: 24 * time.Hour,
+		CurrentSupply:  units.MegaAvax,
+		Config: reward.Config{
+			MaxConsumptionRate: .12 * reward.PercentDenominator,
+			MinConsumptionRate: .1 * reward.PercentDenominator,
+			MintingPeriod:      365 * 24 * time.Hour,
+			SupplyCap:          720 * units.MegaAvax,
+		},
+		Removed:  true,
+		Rewarded: true,
+	}
+	state.SetRewardContext(txID, rewardContext)
+	state.SetHeight(0)
+	require.NoError(state.Commit())
+
+	state = newStateFromDB(require, db)
+	gotContext, err := state.GetRewardContext(txID)
+	require.NoError(err)
+	require.Equal(rewardContext, gotContext)
+}
+
 func TestGetValidatorWeightDiffs(t *testing.T) {
 	require := require.New(t)
 	stateIntf, _ := newInitializedState(require)
@@ -486,3 +533,569 @@ func TestValidatorWeightDiff(t *testing.T) {
 		})
 	}
 }
+
+func TestHistoricalStateIndex(t *testing.T) {
+	require := require.New(t)
+
+	avaxAssetID := ids.GenerateTestID()
+	addr := ids.GenerateTestShortID()
+	otherAddr := ids.GenerateTestShortID()
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{addr},
+	}
+
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.Set(constants.PrimaryNetworkID, validators.NewSet()))
+	s, err := new(
+		memdb.New(),
+		metrics.Noop,
+		&config.Config{
+			Validators:                  vdrs,
+			HistoricalStateIndexEnabled: true,
+		},
+		&snow.Context{
+			AVAXAssetID: avaxAssetID,
+			Log:         logging.NoLog{},
+		},
+		prometheus.NewRegistry(),
+		reward.NewCalculator(reward.Config{}),
+	)
+	require.NoError(err)
+	require.NoError(s.syncHistory(true))
+	validators.InitializeDefaultValidators(constants.UnitTestID, initialTime)
+
+	newUTXO := func(amount uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          amount,
+				OutputOwners: owners,
+			},
+		}
+	}
+	accept := func(height uint64) {
+		blk, err := blocks.NewApricotCommitBlock(ids.GenerateTestID(), height)
+		require.NoError(err)
+		s.AddStatelessBlock(blk, choices.Accepted)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		require.NoError(s.Commit())
+	}
+
+	// Height 0: [addr] receives 10.
+	utxo := newUTXO(10)
+	s.AddUTXO(utxo)
+	accept(0)
+
+	// Height 1: [addr] stakes 5 out of [utxo] and gets 4 back as change.
+	validatorTx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+		Validator: validator.Validator{
+			NodeID: ids.GenerateTestNodeID(),
+			Start:  uint64(initialTime.Unix()),
+			End:    uint64(initialValidatorEndTime.Unix()),
+			Wght:   5,
+		},
+		StakeOuts: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: avaxAssetID},
+			Out: &stakeable.LockOut{
+				Locktime: uint64(initialValidatorEndTime.Unix()),
+				TransferableOut: &secp256k1fx.TransferOutput{
+					Amt:          5,
+					OutputOwners: owners,
+				},
+			},
+		}},
+		RewardsOwner: &owners,
+	}}
+	require.NoError(validatorTx.Sign(txs.Codec, nil))
+	staker := NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(*txs.AddValidatorTx), 0)
+	s.AddTx(validatorTx, status.Committed)
+	s.PutCurrentValidator(staker)
+	s.DeleteUTXO(utxo.InputID())
+	s.AddUTXO(newUTXO(4))
+	accept(1)
+
+	// Height 2: the stake is returned to [addr].
+	s.DeleteCurrentValidator(staker)
+	s.AddUTXO(newUTXO(5))
+	accept(2)
+
+	expectedBalances := []uint64{10, 4, 9}
+	expectedStakes := []uint64{0, 5, 0}
+	for height := range expectedBalances {
+		balance, err := s.GetBalanceAt(addr, uint64(height))
+		require.NoError(err)
+		require.Equal(expectedBalances[height], balance, "height %d", height)
+
+		stake, err := s.GetStakeAt(addr, uint64(height))
+		require.NoError(err)
+		require.Equal(expectedStakes[height], stake, "height %d", height)
+
+		balance, err = s.GetBalanceAt(otherAddr, uint64(height))
+		require.NoError(err)
+		require.Zero(balance)
+	}
+
+	_, err = s.GetBalanceAt(addr, 3)
+	require.ErrorIs(err, errHeightNotAccepted)
+}
+
+func TestHistoricalStateIndexRequiresGenesis(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+	_, err := s.GetBalanceAt(ids.GenerateTestShortID(), 0)
+	require.ErrorIs(err, errHistoricalStateIndexDisabled)
+	require.NoError(s.Commit())
+
+	indexedState := newStateFromDB(require, db).(*state)
+	indexedState.cfg.HistoricalStateIndexEnabled = true
+	require.ErrorIs(indexedState.syncHistory(false), errHistoricalStateIndexIncomplete)
+}
+
+func TestStakerEvents(t *testing.T) {
+	require := require.New(t)
+
+	stateIntf, _ := newInitializedState(require)
+	s := stateIntf.(*state)
+	validators.InitializeDefaultValidators(constants.UnitTestID, initialTime)
+
+	s.SetHeight(0)
+	require.NoError(s.Commit())
+
+	nodeID := ids.GenerateTestNodeID()
+	validator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		NodeID:   nodeID,
+		SubnetID: constants.PrimaryNetworkID,
+		Weight:   10,
+	}
+	delegator := &Staker{
+		TxID:     ids.GenerateTestID(),
+		NodeID:   nodeID,
+		SubnetID: constants.PrimaryNetworkID,
+		Weight:   5,
+	}
+	rewardUTXO := func(txID ids.ID, amount uint64) *avax.UTXO {
+		return &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: txID},
+			Out:    &secp256k1fx.TransferOutput{Amt: amount},
+		}
+	}
+
+	s.PutCurrentValidator(validator)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	s.PutCurrentDelegator(delegator)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	s.DeleteCurrentDelegator(delegator)
+	s.AddRewardUTXO(delegator.TxID, rewardUTXO(delegator.TxID, 2))
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	s.DeleteCurrentValidator(validator)
+	s.AddRewardUTXO(validator.TxID, rewardUTXO(validator.TxID, 3))
+	s.SetHeight(4)
+	require.NoError(s.Commit())
+
+	expectedEvents := []*StakerEvent{
+		{Type: StakerAdded, Height: 1, SubnetID: constants.PrimaryNetworkID, TxID: validator.TxID, Weight: 10},
+		{Type: DelegationAdded, Height: 2, SubnetID: constants.PrimaryNetworkID, TxID: delegator.TxID, Weight: 5},
+		{Type: WeightChanged, Height: 2, SubnetID: constants.PrimaryNetworkID, Weight: 15},
+		{Type: DelegationRemoved, Height: 3, SubnetID: constants.PrimaryNetworkID, TxID: delegator.TxID, Weight: 5, Reward: 2},
+		{Type: WeightChanged, Height: 3, SubnetID: constants.PrimaryNetworkID, Weight: 10},
+		{Type: StakerRewarded, Height: 4, SubnetID: constants.PrimaryNetworkID, TxID: validator.TxID, Weight: 10, Reward: 3},
+		{Type: StakerRemoved, Height: 4, SubnetID: constants.PrimaryNetworkID, TxID: validator.TxID, Weight: 10},
+	}
+	events, err := s.GetStakerEvents(nodeID, 0, 100)
+	require.NoError(err)
+	require.Equal(expectedEvents, events)
+
+	// Pages never split the events of a height.
+	events, err = s.GetStakerEvents(nodeID, 2, 1)
+	require.NoError(err)
+	require.Equal(expectedEvents[1:3], events)
+
+	events, err = s.GetStakerEvents(initialNodeID, 0, 100)
+	require.NoError(err)
+	require.Len(events, 1)
+	require.Equal(StakerAdded, events[0].Type)
+}
+
+func TestRestakeCurrentValidator(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	staker, err := s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	restakedStaker := NewRestakedStaker(staker, 2)
+	require.Equal(staker.EndTime, restakedStaker.StartTime)
+	require.Equal(staker.EndTime.Sub(staker.StartTime), restakedStaker.EndTime.Sub(restakedStaker.StartTime))
+
+	s.RestakeCurrentValidator(restakedStaker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	events, err := s.GetStakerEvents(initialNodeID, 1, 100)
+	require.NoError(err)
+	require.Len(events, 1)
+	require.Equal(StakerRestaked, events[0].Type)
+
+	// The period of a restaked validator is restored on restart
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).loadCurrentValidators())
+
+	staker, err = s.GetCurrentValidator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	require.Equal(restakedStaker.StartTime, staker.StartTime)
+	require.Equal(restakedStaker.EndTime, staker.EndTime)
+	require.Equal(restakedStaker.NextTime, staker.NextTime)
+}
+
+func TestBatchDelegatorRestored(t *testing.T) {
+	require := require.New(t)
+
+	s, db := newInitializedState(require)
+
+	owners := &secp256k1fx.OutputOwners{}
+	batchTx := &txs.Tx{Unsigned: &txs.AddDelegatorsTx{
+		Start: uint64(initialTime.Unix()),
+		End:   uint64(initialValidatorEndTime.Unix()),
+		Delegations: []*txs.Delegation{{
+			NodeID: initialNodeID,
+			Wght:   units.Avax,
+		}},
+		StakeOuts: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: initialTxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          units.Avax,
+				OutputOwners: *owners,
+			},
+		}},
+		DelegationRewardsOwner: owners,
+	}}
+	require.NoError(batchTx.Sign(txs.Codec, nil))
+	batchTxID := batchTx.ID()
+
+	pendingStaker, err := NewPendingBatchDelegator(batchTxID, batchTx.Unsigned.(*txs.AddDelegatorsTx), 0)
+	require.NoError(err)
+	require.Equal(txs.DelegationID(batchTxID, 0), pendingStaker.TxID)
+
+	s.AddTx(batchTx, status.Committed)
+	s.PutPendingDelegator(pendingStaker)
+	s.SetHeight(1)
+	require.NoError(s.Commit())
+
+	// Only the AddDelegatorsTx is stored
+	_, _, err = s.GetTx(pendingStaker.TxID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	// The pending delegator is restored from the AddDelegatorsTx on restart
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+	delegatorIterator, err := s.GetPendingDelegatorIterator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	require.Equal(pendingStaker, delegatorIterator.Value())
+	delegatorIterator.Release()
+
+	currentStaker := *pendingStaker
+	currentStaker.NextTime = currentStaker.EndTime
+	currentStaker.Priority = txs.PrimaryNetworkDelegatorCurrentPriority
+	currentStaker.PotentialReward = 1
+	s.DeletePendingDelegator(pendingStaker)
+	s.PutCurrentDelegator(&currentStaker)
+	s.SetHeight(2)
+	require.NoError(s.Commit())
+
+	// So is the current delegator
+	s = newStateFromDB(require, db)
+	require.NoError(s.(*state).load())
+	delegatorIterator, err = s.GetCurrentDelegatorIterator(constants.PrimaryNetworkID, initialNodeID)
+	require.NoError(err)
+	require.True(delegatorIterator.Next())
+	require.Equal(&currentStaker, delegatorIterator.Value())
+	delegatorIterator.Release()
+
+	stakerTx, err := GetStakerTx(s, &currentStaker)
+	require.NoError(err)
+	require.IsType(&txs.AddDelegatorTx{}, stakerTx)
+
+	// The delegation is forgotten once the delegator is removed
+	s.DeleteCurrentDelegator(&currentStaker)
+	s.SetHeight(3)
+	require.NoError(s.Commit())
+
+	has, err := s.(*state).batchDelegatorDB.Has(currentStaker.TxID[:])
+	require.NoError(err)
+	require.False(has)
+}
+
+func TestPrune(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.Set(constants.PrimaryNetworkID, validators.NewSet()))
+	s, err := new(
+		memdb.New(),
+		metrics.Noop,
+		&config.Config{
+			Validators: vdrs,
+			Pruning: config.PruningConfig{
+				RewardUTXOs:    true,
+				Txs:            true,
+				RetainedBlocks: 1,
+				RetainedAge:    time.Hour,
+				DryRun:         true,
+			},
+		},
+		&snow.Context{
+			Log: logging.NoLog{},
+		},
+		prometheus.NewRegistry(),
+		reward.NewCalculator(reward.Config{}),
+	)
+	require.NoError(err)
+	validators.InitializeDefaultValidators(constants.UnitTestID, initialTime)
+
+	newTx := func(utx txs.UnsignedTx) *txs.Tx {
+		tx := &txs.Tx{Unsigned: utx}
+		require.NoError(tx.Sign(txs.Codec, nil))
+		return tx
+	}
+	newValidatorTx := func() *txs.Tx {
+		return newTx(&txs.AddValidatorTx{
+			Validator: validator.Validator{
+				NodeID: ids.GenerateTestNodeID(),
+				Start:  uint64(initialTime.Unix()),
+				End:    uint64(initialValidatorEndTime.Unix()),
+				Wght:   units.Avax,
+			},
+			RewardsOwner: &secp256k1fx.OutputOwners{},
+		})
+	}
+	parentID := ids.GenerateTestID()
+	accept := func(blk blocks.Block) {
+		for _, tx := range blk.Txs() {
+			s.AddTx(tx, status.Committed)
+		}
+		s.AddStatelessBlock(blk, choices.Accepted)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(blk.Height())
+		require.NoError(s.Commit())
+		parentID = blk.ID()
+	}
+
+	// Height 1: a subnet is created, a validator that is still staking and a
+	// validator that is rewarded at height 2 are added and a tx that isn't
+	// needed for verification is accepted.
+	subnetTx := newTx(&txs.CreateSubnetTx{
+		Owner: &secp256k1fx.OutputOwners{},
+	})
+	stakingTx := newValidatorTx()
+	rewardedTx := newValidatorTx()
+	importTx := newTx(&txs.ImportTx{
+		SourceChain: ids.GenerateTestID(),
+	})
+	blk, err := blocks.NewBanffStandardBlock(
+		initialTime,
+		parentID,
+		1,
+		[]*txs.Tx{subnetTx, stakingTx, rewardedTx, importTx},
+	)
+	require.NoError(err)
+	rewardedStaker := NewCurrentStaker(rewardedTx.ID(), rewardedTx.Unsigned.(*txs.AddValidatorTx), 0)
+	s.PutCurrentValidator(NewCurrentStaker(stakingTx.ID(), stakingTx.Unsigned.(*txs.AddValidatorTx), 0))
+	s.PutCurrentValidator(rewardedStaker)
+	accept(blk)
+
+	// Height 2: the validator added by [rewardedTx] is rewarded.
+	rewardTx := newTx(&txs.RewardValidatorTx{TxID: rewardedTx.ID()})
+	proposalBlk, err := blocks.NewBanffProposalBlock(initialTime.Add(90*time.Minute), parentID, 2, rewardTx)
+	require.NoError(err)
+	s.DeleteCurrentValidator(rewardedStaker)
+	s.AddRewardUTXO(rewardedTx.ID(), &avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: rewardTx.ID()},
+		Asset:  avax.Asset{ID: ids.GenerateTestID()},
+		Out:    &secp256k1fx.TransferOutput{Amt: units.Avax},
+	})
+	accept(proposalBlk)
+
+	// Height 3: a tx is accepted within the retained blocks.
+	recentTx := newTx(&txs.ImportTx{
+		SourceChain: ids.GenerateTestID(),
+	})
+	blk, err = blocks.NewBanffStandardBlock(initialTime.Add(2*time.Hour), parentID, 3, []*txs.Tx{recentTx})
+	require.NoError(err)
+	s.SetTimestamp(blk.Timestamp())
+	accept(blk)
+
+	allTxs := []*txs.Tx{subnetTx, stakingTx, rewardedTx, importTx, rewardTx, recentTx}
+	requireState := func(rewardUTXOsPruned bool, prunedTxs ...*txs.Tx) {
+		pruned := ids.Set{}
+		for _, tx := range prunedTxs {
+			pruned.Add(tx.ID())
+		}
+		for _, tx := range allTxs {
+			_, _, err := s.GetTx(tx.ID())
+			if pruned.Contains(tx.ID()) {
+				require.ErrorIs(err, database.ErrNotFound)
+			} else {
+				require.NoError(err)
+			}
+		}
+
+		utxos, err := s.GetRewardUTXOs(rewardedTx.ID())
+		require.NoError(err)
+		if rewardUTXOsPruned {
+			require.Empty(utxos)
+		} else {
+			require.Len(utxos, 1)
+		}
+	}
+
+	// Nothing is deleted in a dry run.
+	require.NoError(s.prune())
+	requireState(false)
+
+	// Only the block at height 1 is more than an hour older than the last
+	// accepted block.
+	s.cfg.Pruning.DryRun = false
+	require.NoError(s.prune())
+	requireState(false, rewardedTx, importTx)
+
+	// The block at height 3 is retained.
+	s.cfg.Pruning.RetainedAge = 0
+	require.NoError(s.prune())
+	requireState(true, rewardedTx, importTx, rewardTx)
+}
+
+func TestPruneBatchDelegators(t *testing.T) {
+	require := require.New(t)
+
+	vdrs := validators.NewManager()
+	require.NoError(vdrs.Set(constants.PrimaryNetworkID, validators.NewSet()))
+	s, err := new(
+		memdb.New(),
+		metrics.Noop,
+		&config.Config{
+			Validators: vdrs,
+			Pruning: config.PruningConfig{
+				RewardUTXOs:    true,
+				Txs:            true,
+				RetainedBlocks: 1,
+			},
+		},
+		&snow.Context{
+			Log: logging.NoLog{},
+		},
+		prometheus.NewRegistry(),
+		reward.NewCalculator(reward.Config{}),
+	)
+	require.NoError(err)
+	validators.InitializeDefaultValidators(constants.UnitTestID, initialTime)
+
+	parentID := ids.GenerateTestID()
+	accept := func(height uint64, timestamp time.Time, tx *txs.Tx) {
+		require.NoError(tx.Sign(txs.Codec, nil))
+		blk, err := blocks.NewBanffStandardBlock(timestamp, parentID, height, []*txs.Tx{tx})
+		require.NoError(err)
+		s.AddTx(tx, status.Committed)
+		s.AddStatelessBlock(blk, choices.Accepted)
+		s.SetLastAccepted(blk.ID())
+		s.SetHeight(height)
+		s.SetTimestamp(timestamp)
+		require.NoError(s.Commit())
+		parentID = blk.ID()
+	}
+
+	// Height 1: an AddDelegatorsTx with two delegations is accepted.
+	owners := &secp256k1fx.OutputOwners{}
+	batchTx := &txs.Tx{Unsigned: &txs.AddDelegatorsTx{
+		Start: uint64(initialTime.Unix()),
+		End:   uint64(initialValidatorEndTime.Unix()),
+		Delegations: []*txs.Delegation{
+			{NodeID: ids.GenerateTestNodeID(), Wght: units.Avax},
+			{NodeID: ids.GenerateTestNodeID(), Wght: units.Avax},
+		},
+		StakeOuts: []*avax.TransferableOutput{{
+			Asset: avax.Asset{ID: initialTxID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt:          2 * units.Avax,
+				OutputOwners: *owners,
+			},
+		}},
+		DelegationRewardsOwner: owners,
+	}}
+	require.NoError(batchTx.Sign(txs.Codec, nil))
+	batchTxID := batchTx.ID()
+	delegators := make([]*Staker, 2)
+	for i, delegation := range batchTx.Unsigned.(*txs.AddDelegatorsTx).Delegations {
+		validatorTx := &txs.Tx{Unsigned: &txs.AddValidatorTx{
+			Validator: validator.Validator{
+				NodeID: delegation.NodeID,
+				Start:  uint64(initialTime.Unix()),
+				End:    uint64(initialValidatorEndTime.Unix()),
+				Wght:   units.Avax,
+			},
+			RewardsOwner: owners,
+		}}
+		require.NoError(validatorTx.Sign(txs.Codec, nil))
+		s.AddTx(validatorTx, status.Committed)
+		s.PutCurrentValidator(NewCurrentStaker(validatorTx.ID(), validatorTx.Unsigned.(*txs.AddValidatorTx), 0))
+
+		delegator, err := NewPendingBatchDelegator(batchTxID, batchTx.Unsigned.(*txs.AddDelegatorsTx), i)
+		require.NoError(err)
+		delegator.NextTime = delegator.EndTime
+		delegator.Priority = txs.PrimaryNetworkDelegatorCurrentPriority
+		s.PutCurrentDelegator(delegator)
+		delegators[i] = delegator
+	}
+	accept(1, initialTime, batchTx)
+
+	// Heights 2 and 3: the delegations are rewarded one at a time.
+	rewardDelegator := func(height uint64, delegator *Staker) *txs.Tx {
+		rewardTx := &txs.Tx{Unsigned: &txs.RewardValidatorTx{TxID: delegator.TxID}}
+		s.DeleteCurrentDelegator(delegator)
+		s.AddRewardUTXO(batchTxID, &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: batchTxID, OutputIndex: uint32(height)},
+			Asset:  avax.Asset{ID: ids.GenerateTestID()},
+			Out:    &secp256k1fx.TransferOutput{Amt: units.Avax},
+		})
+		accept(height, initialTime.Add(time.Duration(height)*time.Hour), rewardTx)
+		return rewardTx
+	}
+	requireRewardUTXOs := func(expected int) {
+		utxos, err := s.GetRewardUTXOs(batchTxID)
+		require.NoError(err)
+		require.Len(utxos, expected)
+	}
+
+	// The AddDelegatorsTx is needed while one of its delegations is staking.
+	rewardDelegator(2, delegators[0])
+	require.NoError(s.prune())
+	_, _, err = s.GetTx(batchTxID)
+	require.NoError(err)
+	requireRewardUTXOs(1)
+
+	// The rewards of the batch are retained while one of them is in a
+	// retained block.
+	rewardDelegator(3, delegators[1])
+	require.NoError(s.prune())
+	_, _, err = s.GetTx(batchTxID)
+	require.ErrorIs(err, database.ErrNotFound)
+	requireRewardUTXOs(2)
+
+	// Height 4: the rewards of the batch are pruned together.
+	accept(4, initialTime.Add(4*time.Hour), &txs.Tx{Unsigned: &txs.ImportTx{
+		SourceChain: ids.GenerateTestID(),
+	}})
+	require.NoError(s.prune())
+	requireRewardUTXOs(0)
+}
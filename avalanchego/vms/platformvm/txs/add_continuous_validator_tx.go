@@ -0,0 +1,63 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+)
+
+// MaxRestakePeriods is the maximum number of times a continuous validator can
+// be restaked
+const MaxRestakePeriods = 100
+
+var (
+	_ ValidatorTx = &AddContinuousValidatorTx{}
+
+	errNoRestakePeriods      = errors.New("continuous validator must be restaked at least once")
+	errTooManyRestakePeriods = fmt.Errorf("continuous validator can be restaked at most %d times", MaxRestakePeriods)
+)
+
+// AddContinuousValidatorTx is an unsigned addContinuousValidatorTx. It adds a
+// primary network validator that, when its staking period ends, is rewarded
+// and automatically restaked for another period of the same duration, until it
+// has been restaked [RestakePeriods] times. Its stake is only returned once the
+// last period ends.
+type AddContinuousValidatorTx struct {
+	// Describes the validator over its first staking period
+	AddValidatorTx `serialize:"true"`
+	// Number of times the validator is restaked once a staking period ends
+	RestakePeriods uint32 `serialize:"true" json:"restakePeriods"`
+}
+
+// NumPeriods returns the number of staking periods of the validator
+func (tx *AddContinuousValidatorTx) NumPeriods() uint64 {
+	return uint64(tx.RestakePeriods) + 1
+}
+
+// Period returns the index of the staking period of the validator that starts
+// at [startTime]. The first period is 0.
+func (tx *AddContinuousValidatorTx) Period(startTime time.Time) uint32 {
+	return uint32(startTime.Sub(tx.StartTime()) / tx.Validator.Duration())
+}
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddContinuousValidatorTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.RestakePeriods == 0:
+		return errNoRestakePeriods
+	case tx.RestakePeriods > MaxRestakePeriods:
+		return errTooManyRestakePeriods
+	}
+	return tx.AddValidatorTx.SyntacticVerify(ctx)
+}
+
+func (tx *AddContinuousValidatorTx) Visit(visitor Visitor) error {
+	return visitor.AddContinuousValidatorTx(tx)
+}
@@ -0,0 +1,104 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func TestAddContinuousValidatorTxSyntacticVerify(t *testing.T) {
+	require := require.New(t)
+	clk := mockable.Clock{}
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	var (
+		stx                      *Tx
+		addContinuousValidatorTx *AddContinuousValidatorTx
+		err                      error
+	)
+
+	// Case : unsigned tx is nil
+	require.ErrorIs(addContinuousValidatorTx.SyntacticVerify(ctx), ErrNilTx)
+
+	validatorWeight := uint64(2022)
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+	}
+	addContinuousValidatorTx = &AddContinuousValidatorTx{
+		AddValidatorTx: AddValidatorTx{
+			BaseTx: BaseTx{BaseTx: avax.BaseTx{
+				NetworkID:    ctx.NetworkID,
+				BlockchainID: ctx.ChainID,
+				Ins: []*avax.TransferableInput{{
+					UTXOID: avax.UTXOID{
+						TxID:        ids.ID{'t', 'x', 'I', 'D'},
+						OutputIndex: 2,
+					},
+					Asset: avax.Asset{ID: ctx.AVAXAssetID},
+					In: &secp256k1fx.TransferInput{
+						Amt:   uint64(5678),
+						Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+					},
+				}},
+			}},
+			Validator: validator.Validator{
+				NodeID: ctx.NodeID,
+				Start:  uint64(clk.Time().Unix()),
+				End:    uint64(clk.Time().Add(time.Hour).Unix()),
+				Wght:   validatorWeight,
+			},
+			StakeOuts: []*avax.TransferableOutput{{
+				Asset: avax.Asset{ID: ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          validatorWeight,
+					OutputOwners: owners,
+				},
+			}},
+			RewardsOwner:     &owners,
+			DelegationShares: reward.PercentDenominator,
+		},
+		RestakePeriods: 1,
+	}
+
+	// Case: valid tx
+	stx, err = NewSigned(addContinuousValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+	require.Equal(uint64(2), addContinuousValidatorTx.NumPeriods())
+
+	// Case: never restaked
+	addContinuousValidatorTx.SyntacticallyVerified = false
+	addContinuousValidatorTx.RestakePeriods = 0
+	stx, err = NewSigned(addContinuousValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.ErrorIs(stx.SyntacticVerify(ctx), errNoRestakePeriods)
+
+	// Case: restaked too many times
+	addContinuousValidatorTx.RestakePeriods = MaxRestakePeriods + 1
+	stx, err = NewSigned(addContinuousValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.ErrorIs(stx.SyntacticVerify(ctx), errTooManyRestakePeriods)
+
+	// Case: invalid validator
+	addContinuousValidatorTx.RestakePeriods = MaxRestakePeriods
+	addContinuousValidatorTx.DelegationShares++ // 1 more than max amount
+	stx, err = NewSigned(addContinuousValidatorTx, Codec, signers)
+	require.NoError(err)
+	require.Error(stx.SyntacticVerify(ctx))
+}
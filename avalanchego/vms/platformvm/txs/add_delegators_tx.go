@@ -0,0 +1,275 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/math"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/fx"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+// MaxDelegations is the maximum number of delegations of an AddDelegatorsTx
+const MaxDelegations = 64
+
+var (
+	_ UnsignedTx = &AddDelegatorsTx{}
+
+	errNoDelegations           = errors.New("no delegations")
+	errTooManyDelegations      = fmt.Errorf("more than %d delegations", MaxDelegations)
+	errDuplicateDelegation     = errors.New("validator is delegated to more than once")
+	errInvalidDelegationPeriod = errors.New("delegation end time must be after its start time")
+	errUnsupportedStakeOutput  = errors.New("unsupported stake output")
+	errDelegationOutOfRange    = errors.New("delegation index out of range")
+)
+
+// Delegation is the stake delegated to a validator by an AddDelegatorsTx
+type Delegation struct {
+	// Node ID of the validator delegated to
+	NodeID ids.NodeID `serialize:"true" json:"nodeID"`
+	// Amount delegated to the validator
+	Wght uint64 `serialize:"true" json:"weight"`
+}
+
+// AddDelegatorsTx is an unsigned addDelegatorsTx. It delegates to several
+// primary network validators over the same period, with a single fee. Each
+// delegation is then staked, rewarded and refunded as the AddDelegatorTx
+// returned by [DelegatorTx], to the outputs of this tx that start at
+// [OutputIndex].
+type AddDelegatorsTx struct {
+	// Metadata, inputs and outputs
+	BaseTx `serialize:"true"`
+	// Unix time the delegations start
+	Start uint64 `serialize:"true" json:"startTime"`
+	// Unix time the delegations end
+	End uint64 `serialize:"true" json:"endTime"`
+	// Validators delegated to, with the amounts delegated to them
+	Delegations []*Delegation `serialize:"true" json:"delegations"`
+	// Where to send staked tokens when done validating. The stake is split
+	// between the delegations in order.
+	StakeOuts []*avax.TransferableOutput `serialize:"true" json:"stake"`
+	// Where to send staking rewards when done validating
+	DelegationRewardsOwner fx.Owner `serialize:"true" json:"rewardsOwner"`
+}
+
+// InitCtx sets the FxID fields in the inputs and outputs of this
+// [AddDelegatorsTx]. Also sets the [ctx] to the given [vm.ctx] so that
+// the addresses can be json marshalled into human readable format
+func (tx *AddDelegatorsTx) InitCtx(ctx *snow.Context) {
+	tx.BaseTx.InitCtx(ctx)
+	for _, out := range tx.StakeOuts {
+		out.FxID = secp256k1fx.ID
+		out.InitCtx(ctx)
+	}
+	tx.DelegationRewardsOwner.InitCtx(ctx)
+}
+
+func (tx *AddDelegatorsTx) StartTime() time.Time { return time.Unix(int64(tx.Start), 0) }
+func (tx *AddDelegatorsTx) EndTime() time.Time   { return time.Unix(int64(tx.End), 0) }
+
+// SyntacticVerify returns nil iff [tx] is valid
+func (tx *AddDelegatorsTx) SyntacticVerify(ctx *snow.Context) error {
+	switch {
+	case tx == nil:
+		return ErrNilTx
+	case tx.SyntacticallyVerified: // already passed syntactic verification
+		return nil
+	case len(tx.Delegations) == 0:
+		return errNoDelegations
+	case len(tx.Delegations) > MaxDelegations:
+		return errTooManyDelegations
+	case tx.End <= tx.Start:
+		return errInvalidDelegationPeriod
+	}
+
+	if err := tx.BaseTx.SyntacticVerify(ctx); err != nil {
+		return err
+	}
+	if err := tx.DelegationRewardsOwner.Verify(); err != nil {
+		return fmt.Errorf("failed to verify rewards owner: %w", err)
+	}
+
+	nodeIDs := ids.NodeIDSet{}
+	totalWeight := uint64(0)
+	for _, delegation := range tx.Delegations {
+		if nodeIDs.Contains(delegation.NodeID) {
+			return fmt.Errorf("%w: %s", errDuplicateDelegation, delegation.NodeID)
+		}
+		nodeIDs.Add(delegation.NodeID)
+
+		if delegation.Wght == 0 {
+			return validator.ErrWeightTooSmall
+		}
+		newWeight, err := math.Add64(totalWeight, delegation.Wght)
+		if err != nil {
+			return err
+		}
+		totalWeight = newWeight
+	}
+
+	totalStakeWeight := uint64(0)
+	for _, out := range tx.StakeOuts {
+		if err := out.Verify(); err != nil {
+			return fmt.Errorf("output verification failed: %w", err)
+		}
+		// The stake must be splittable between the delegations
+		if _, err := withAmount(out.Out, out.Output().Amount()); err != nil {
+			return err
+		}
+		newWeight, err := math.Add64(totalStakeWeight, out.Output().Amount())
+		if err != nil {
+			return err
+		}
+		totalStakeWeight = newWeight
+
+		assetID := out.AssetID()
+		if assetID != ctx.AVAXAssetID {
+			return fmt.Errorf("stake output must be AVAX but is %q", assetID)
+		}
+	}
+
+	switch {
+	case !avax.IsSortedTransferableOutputs(tx.StakeOuts, Codec):
+		return errOutputsNotSorted
+	case totalStakeWeight != totalWeight:
+		return fmt.Errorf("%w, delegator weight %d total stake weight %d",
+			errDelegatorWeightMismatch,
+			totalWeight,
+			totalStakeWeight,
+		)
+	}
+
+	// cache that this is valid
+	tx.SyntacticallyVerified = true
+	return nil
+}
+
+// DelegationID returns the ID of the staker of the [i]-th delegation of the
+// AddDelegatorsTx [txID].
+func DelegationID(txID ids.ID, i int) ids.ID {
+	return txID.Prefix(uint64(i))
+}
+
+// DelegatorTx returns the [i]-th delegation of [tx] as an AddDelegatorTx. Its
+// stake is the part of [tx.StakeOuts] that is delegated to the validator. It
+// is never issued: it only describes the delegation, which is staked, rewarded
+// and refunded like the delegator of an AddDelegatorTx.
+//
+// Invariant: [tx] is syntactically valid.
+func (tx *AddDelegatorsTx) DelegatorTx(i int) (*AddDelegatorTx, error) {
+	if i < 0 || i >= len(tx.Delegations) {
+		return nil, fmt.Errorf("%w: %d", errDelegationOutOfRange, i)
+	}
+
+	stake, err := tx.delegationStake(i)
+	if err != nil {
+		return nil, err
+	}
+	delegation := tx.Delegations[i]
+	return &AddDelegatorTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    tx.NetworkID,
+			BlockchainID: tx.BlockchainID,
+		}},
+		Validator: validator.Validator{
+			NodeID: delegation.NodeID,
+			Start:  tx.Start,
+			End:    tx.End,
+			Wght:   delegation.Wght,
+		},
+		StakeOuts:              stake,
+		DelegationRewardsOwner: tx.DelegationRewardsOwner,
+	}, nil
+}
+
+// OutputIndex returns the index of the first output of [tx] that belongs to
+// its [i]-th delegation. The outputs of the delegations follow [tx.Outs]. Each
+// delegation has an output per output of its stake, to refund it, followed by
+// two outputs to reward the delegator and the validator.
+//
+// Invariant: [tx] is syntactically valid.
+func (tx *AddDelegatorsTx) OutputIndex(i int) (uint32, error) {
+	if i < 0 || i >= len(tx.Delegations) {
+		return 0, fmt.Errorf("%w: %d", errDelegationOutOfRange, i)
+	}
+
+	index := len(tx.Outs)
+	for j := 0; j < i; j++ {
+		stake, err := tx.delegationStake(j)
+		if err != nil {
+			return 0, err
+		}
+		index += len(stake) + 2
+	}
+	return uint32(index), nil
+}
+
+// delegationStake returns the stake outputs of the [i]-th delegation of [tx].
+// The delegation is staked by the range of [tx.StakeOuts] that follows the
+// stake of the previous delegations.
+func (tx *AddDelegatorsTx) delegationStake(i int) ([]*avax.TransferableOutput, error) {
+	start := uint64(0)
+	for _, delegation := range tx.Delegations[:i] {
+		start += delegation.Wght
+	}
+	end := start + tx.Delegations[i].Wght
+
+	var (
+		stake  []*avax.TransferableOutput
+		offset uint64
+	)
+	for _, out := range tx.StakeOuts {
+		amount := out.Output().Amount()
+		outStart, outEnd := offset, offset+amount
+		offset = outEnd
+		if outEnd <= start || outStart >= end {
+			continue
+		}
+
+		splitOut, err := withAmount(out.Out, math.Min64(outEnd, end)-math.Max64(outStart, start))
+		if err != nil {
+			return nil, err
+		}
+		stake = append(stake, &avax.TransferableOutput{
+			Asset: out.Asset,
+			FxID:  out.FxID,
+			Out:   splitOut,
+		})
+	}
+	return stake, nil
+}
+
+func (tx *AddDelegatorsTx) Visit(visitor Visitor) error {
+	return visitor.AddDelegatorsTx(tx)
+}
+
+// withAmount returns a copy of the stake output [out] of [amount].
+func withAmount(out avax.TransferableOut, amount uint64) (avax.TransferableOut, error) {
+	switch out := out.(type) {
+	case *secp256k1fx.TransferOutput:
+		return &secp256k1fx.TransferOutput{
+			Amt:          amount,
+			OutputOwners: out.OutputOwners,
+		}, nil
+	case *stakeable.LockOut:
+		innerOut, err := withAmount(out.TransferableOut, amount)
+		if err != nil {
+			return nil, err
+		}
+		return &stakeable.LockOut{
+			Locktime:        out.Locktime,
+			TransferableOut: innerOut,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: %T", errUnsupportedStakeOutput, out)
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/stakeable"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+func newTestAddDelegatorsTx(ctx *snow.Context) *AddDelegatorsTx {
+	clk := mockable.Clock{}
+	owners := secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+	}
+	return &AddDelegatorsTx{
+		BaseTx: BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    ctx.NetworkID,
+			BlockchainID: ctx.ChainID,
+			Ins: []*avax.TransferableInput{{
+				UTXOID: avax.UTXOID{
+					TxID:        ids.ID{'t', 'x', 'I', 'D'},
+					OutputIndex: 2,
+				},
+				Asset: avax.Asset{ID: ctx.AVAXAssetID},
+				In: &secp256k1fx.TransferInput{
+					Amt:   uint64(5678),
+					Input: secp256k1fx.Input{SigIndices: []uint32{0}},
+				},
+			}},
+		}},
+		Start: uint64(clk.Time().Unix()),
+		End:   uint64(clk.Time().Add(time.Hour).Unix()),
+		Delegations: []*Delegation{
+			{NodeID: ids.GenerateTestNodeID(), Wght: 4},
+			{NodeID: ids.GenerateTestNodeID(), Wght: 4},
+		},
+		StakeOuts: []*avax.TransferableOutput{
+			{
+				Asset: avax.Asset{ID: ctx.AVAXAssetID},
+				Out: &secp256k1fx.TransferOutput{
+					Amt:          5,
+					OutputOwners: owners,
+				},
+			},
+			{
+				Asset: avax.Asset{ID: ctx.AVAXAssetID},
+				Out: &stakeable.LockOut{
+					Locktime: uint64(clk.Time().Add(time.Second).Unix()),
+					TransferableOut: &secp256k1fx.TransferOutput{
+						Amt:          3,
+						OutputOwners: owners,
+					},
+				},
+			},
+		},
+		DelegationRewardsOwner: &owners,
+	}
+}
+
+func TestAddDelegatorsTxSyntacticVerify(t *testing.T) {
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+	signers := [][]*crypto.PrivateKeySECP256K1R{preFundedKeys}
+
+	// Case : unsigned tx is nil
+	var addDelegatorsTx *AddDelegatorsTx
+	require.ErrorIs(t, addDelegatorsTx.SyntacticVerify(ctx), ErrNilTx)
+
+	tests := []struct {
+		name          string
+		modify        func(*AddDelegatorsTx)
+		expectedError error
+	}{
+		{
+			name:          "valid",
+			modify:        func(*AddDelegatorsTx) {},
+			expectedError: nil,
+		},
+		{
+			name: "no delegations",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.Delegations = nil
+			},
+			expectedError: errNoDelegations,
+		},
+		{
+			name: "too many delegations",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.Delegations = make([]*Delegation, MaxDelegations+1)
+				for i := range tx.Delegations {
+					tx.Delegations[i] = &Delegation{NodeID: ids.GenerateTestNodeID(), Wght: 1}
+				}
+			},
+			expectedError: errTooManyDelegations,
+		},
+		{
+			name: "empty period",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.End = tx.Start
+			},
+			expectedError: errInvalidDelegationPeriod,
+		},
+		{
+			name: "duplicate validator",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.Delegations[1].NodeID = tx.Delegations[0].NodeID
+			},
+			expectedError: errDuplicateDelegation,
+		},
+		{
+			name: "empty delegation",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.Delegations[0].Wght = 0
+				tx.Delegations[1].Wght = 8
+			},
+			expectedError: validator.ErrWeightTooSmall,
+		},
+		{
+			name: "stake mismatch",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.Delegations[1].Wght++
+			},
+			expectedError: errDelegatorWeightMismatch,
+		},
+		{
+			name: "outputs not sorted",
+			modify: func(tx *AddDelegatorsTx) {
+				tx.StakeOuts[0], tx.StakeOuts[1] = tx.StakeOuts[1], tx.StakeOuts[0]
+			},
+			expectedError: errOutputsNotSorted,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			tx := newTestAddDelegatorsTx(ctx)
+			test.modify(tx)
+			stx, err := NewSigned(tx, Codec, signers)
+			require.NoError(err)
+			require.ErrorIs(stx.SyntacticVerify(ctx), test.expectedError)
+		})
+	}
+}
+
+func TestAddDelegatorsTxDelegatorTx(t *testing.T) {
+	require := require.New(t)
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	addDelegatorsTx := newTestAddDelegatorsTx(ctx)
+	stx, err := NewSigned(addDelegatorsTx, Codec, [][]*crypto.PrivateKeySECP256K1R{preFundedKeys})
+	require.NoError(err)
+	require.NoError(stx.SyntacticVerify(ctx))
+
+	_, err = addDelegatorsTx.DelegatorTx(len(addDelegatorsTx.Delegations))
+	require.ErrorIs(err, errDelegationOutOfRange)
+
+	// The first delegation is staked by part of the first stake output
+	delegator0, err := addDelegatorsTx.DelegatorTx(0)
+	require.NoError(err)
+	require.Equal(addDelegatorsTx.Delegations[0].NodeID, delegator0.Validator.NodeID)
+	require.Equal(addDelegatorsTx.Start, delegator0.Validator.Start)
+	require.Equal(addDelegatorsTx.End, delegator0.Validator.End)
+	require.Equal(uint64(4), delegator0.Validator.Wght)
+	require.Equal(addDelegatorsTx.DelegationRewardsOwner, delegator0.DelegationRewardsOwner)
+	require.Len(delegator0.StakeOuts, 1)
+	require.Equal(uint64(4), delegator0.StakeOuts[0].Out.(*secp256k1fx.TransferOutput).Amt)
+
+	// The second delegation is staked by the rest of the first stake output
+	// and by the locked stake output
+	delegator1, err := addDelegatorsTx.DelegatorTx(1)
+	require.NoError(err)
+	require.Len(delegator1.StakeOuts, 2)
+	require.Equal(uint64(1), delegator1.StakeOuts[0].Out.(*secp256k1fx.TransferOutput).Amt)
+	lockOut := delegator1.StakeOuts[1].Out.(*stakeable.LockOut)
+	require.Equal(addDelegatorsTx.StakeOuts[1].Out.(*stakeable.LockOut).Locktime, lockOut.Locktime)
+	require.Equal(uint64(3), lockOut.Amount())
+
+	// The stake of the batch isn't modified
+	require.Equal(uint64(5), addDelegatorsTx.StakeOuts[0].Out.Amount())
+
+	txID := stx.ID()
+	require.NotEqual(DelegationID(txID, 0), DelegationID(txID, 1))
+	require.NotEqual(txID, DelegationID(txID, 0))
+}
+
+func TestAddDelegatorsTxOutputIndex(t *testing.T) {
+	require := require.New(t)
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	addDelegatorsTx := newTestAddDelegatorsTx(ctx)
+	addDelegatorsTx.Outs = []*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt:          1,
+			OutputOwners: *addDelegatorsTx.DelegationRewardsOwner.(*secp256k1fx.OutputOwners),
+		},
+	}}
+
+	_, err := addDelegatorsTx.OutputIndex(len(addDelegatorsTx.Delegations))
+	require.ErrorIs(err, errDelegationOutOfRange)
+
+	// The outputs of the first delegation follow the outputs of the tx
+	index, err := addDelegatorsTx.OutputIndex(0)
+	require.NoError(err)
+	require.Equal(uint32(1), index)
+
+	// The first delegation has one stake output and two reward outputs
+	index, err = addDelegatorsTx.OutputIndex(1)
+	require.NoError(err)
+	require.Equal(uint32(4), index)
+}
@@ -114,6 +114,19 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// NewUnsignedAddValidatorTx is NewAddValidatorTx, but the staked tokens
+	// are provided by [fromAddrs] and the returned tx isn't signed.
+	NewUnsignedAddValidatorTx(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		rewardAddress ids.ShortID,
+		shares uint32,
+		fromAddrs ids.ShortSet,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// stakeAmount: amount the delegator stakes
 	// startTime: unix time they start delegating
 	// endTime: unix time they stop delegating
@@ -131,6 +144,45 @@ type ProposalTxBuilder interface {
 		changeAddr ids.ShortID,
 	) (*txs.Tx, error)
 
+	// NewUnsignedAddDelegatorTx is NewAddDelegatorTx, but the staked tokens
+	// are provided by [fromAddrs] and the returned tx isn't signed.
+	NewUnsignedAddDelegatorTx(
+		stakeAmount,
+		startTime,
+		endTime uint64,
+		nodeID ids.NodeID,
+		rewardAddress ids.ShortID,
+		fromAddrs ids.ShortSet,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// startTime: unix time they start delegating
+	// endTime: unix time they stop delegating
+	// delegations: IDs of the nodes we are delegating to, with the amounts
+	//              delegated to them
+	// rewardAddress: address to send rewards to, if applicable
+	// keys: keys providing the staked tokens
+	// changeAddr: address to send change to, if there is any
+	NewAddDelegatorsTx(
+		startTime,
+		endTime uint64,
+		delegations []*txs.Delegation,
+		rewardAddress ids.ShortID,
+		keys []*crypto.PrivateKeySECP256K1R,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
+	// NewUnsignedAddDelegatorsTx is NewAddDelegatorsTx, but the staked tokens
+	// are provided by [fromAddrs] and the returned tx isn't signed.
+	NewUnsignedAddDelegatorsTx(
+		startTime,
+		endTime uint64,
+		delegations []*txs.Delegation,
+		rewardAddress ids.ShortID,
+		fromAddrs ids.ShortSet,
+		changeAddr ids.ShortID,
+	) (*txs.Tx, error)
+
 	// weight: sampling weight of the new validator
 	// startTime: unix time they start delegating
 	// endTime:  unix time they top delegating
@@ -166,6 +218,11 @@ type ProposalTxBuilder interface {
 	// RewardStakerTx creates a new transaction that proposes to remove the staker
 	// [validatorID] from the default validator set.
 	NewRewardValidatorTx(txID ids.ID) (*txs.Tx, error)
+
+	// NewRewardContinuousValidatorTx creates a new transaction that proposes
+	// to reward the staking period [period] of the continuous validator added
+	// by [txID].
+	NewRewardContinuousValidatorTx(txID ids.ID, period uint32) (*txs.Tx, error)
 }
 
 func New(
@@ -439,8 +496,48 @@ func (b *builder) NewAddValidatorTx(
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-	// Create the tx
-	utx := &txs.AddValidatorTx{
+	utx := b.newAddValidatorTx(ins, unstakedOuts, stakedOuts, stakeAmount, startTime, endTime, nodeID, rewardAddress, shares)
+	tx, err := txs.NewSigned(utx, txs.Codec, signers)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) NewUnsignedAddValidatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	shares uint32,
+	fromAddrs ids.ShortSet,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, unstakedOuts, stakedOuts, err := b.SpendFromAddrs(fromAddrs, stakeAmount, b.cfg.AddPrimaryNetworkValidatorFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	utx := b.newAddValidatorTx(ins, unstakedOuts, stakedOuts, stakeAmount, startTime, endTime, nodeID, rewardAddress, shares)
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) newAddValidatorTx(
+	ins []*avax.TransferableInput,
+	unstakedOuts []*avax.TransferableOutput,
+	stakedOuts []*avax.TransferableOutput,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	shares uint32,
+) *txs.AddValidatorTx {
+	return &txs.AddValidatorTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    b.ctx.NetworkID,
 			BlockchainID: b.ctx.ChainID,
@@ -461,6 +558,22 @@ func (b *builder) NewAddValidatorTx(
 		},
 		DelegationShares: shares,
 	}
+}
+
+func (b *builder) NewAddDelegatorTx(
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	utx := b.newAddDelegatorTx(ins, unlockedOuts, lockedOuts, stakeAmount, startTime, endTime, nodeID, rewardAddress)
 	tx, err := txs.NewSigned(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
@@ -468,21 +581,38 @@ func (b *builder) NewAddValidatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
-func (b *builder) NewAddDelegatorTx(
+func (b *builder) NewUnsignedAddDelegatorTx(
 	stakeAmount,
 	startTime,
 	endTime uint64,
 	nodeID ids.NodeID,
 	rewardAddress ids.ShortID,
-	keys []*crypto.PrivateKeySECP256K1R,
+	fromAddrs ids.ShortSet,
 	changeAddr ids.ShortID,
 ) (*txs.Tx, error) {
-	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	ins, unlockedOuts, lockedOuts, err := b.SpendFromAddrs(fromAddrs, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
 	if err != nil {
 		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
 	}
-	// Create the tx
-	utx := &txs.AddDelegatorTx{
+	utx := b.newAddDelegatorTx(ins, unlockedOuts, lockedOuts, stakeAmount, startTime, endTime, nodeID, rewardAddress)
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) newAddDelegatorTx(
+	ins []*avax.TransferableInput,
+	unlockedOuts []*avax.TransferableOutput,
+	lockedOuts []*avax.TransferableOutput,
+	stakeAmount,
+	startTime,
+	endTime uint64,
+	nodeID ids.NodeID,
+	rewardAddress ids.ShortID,
+) *txs.AddDelegatorTx {
+	return &txs.AddDelegatorTx{
 		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
 			NetworkID:    b.ctx.NetworkID,
 			BlockchainID: b.ctx.ChainID,
@@ -502,6 +632,25 @@ func (b *builder) NewAddDelegatorTx(
 			Addrs:     []ids.ShortID{rewardAddress},
 		},
 	}
+}
+
+func (b *builder) NewAddDelegatorsTx(
+	startTime,
+	endTime uint64,
+	delegations []*txs.Delegation,
+	rewardAddress ids.ShortID,
+	keys []*crypto.PrivateKeySECP256K1R,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	stakeAmount, err := delegatedAmount(delegations)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, signers, err := b.Spend(keys, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	utx := b.newAddDelegatorsTx(ins, unlockedOuts, lockedOuts, startTime, endTime, delegations, rewardAddress)
 	tx, err := txs.NewSigned(utx, txs.Codec, signers)
 	if err != nil {
 		return nil, err
@@ -509,6 +658,71 @@ func (b *builder) NewAddDelegatorTx(
 	return tx, tx.SyntacticVerify(b.ctx)
 }
 
+func (b *builder) NewUnsignedAddDelegatorsTx(
+	startTime,
+	endTime uint64,
+	delegations []*txs.Delegation,
+	rewardAddress ids.ShortID,
+	fromAddrs ids.ShortSet,
+	changeAddr ids.ShortID,
+) (*txs.Tx, error) {
+	stakeAmount, err := delegatedAmount(delegations)
+	if err != nil {
+		return nil, err
+	}
+	ins, unlockedOuts, lockedOuts, err := b.SpendFromAddrs(fromAddrs, stakeAmount, b.cfg.AddPrimaryNetworkDelegatorFee, changeAddr)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't generate tx inputs/outputs: %w", err)
+	}
+	utx := b.newAddDelegatorsTx(ins, unlockedOuts, lockedOuts, startTime, endTime, delegations, rewardAddress)
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	if err != nil {
+		return nil, err
+	}
+	return tx, tx.SyntacticVerify(b.ctx)
+}
+
+func (b *builder) newAddDelegatorsTx(
+	ins []*avax.TransferableInput,
+	unlockedOuts []*avax.TransferableOutput,
+	lockedOuts []*avax.TransferableOutput,
+	startTime,
+	endTime uint64,
+	delegations []*txs.Delegation,
+	rewardAddress ids.ShortID,
+) *txs.AddDelegatorsTx {
+	return &txs.AddDelegatorsTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.ctx.NetworkID,
+			BlockchainID: b.ctx.ChainID,
+			Ins:          ins,
+			Outs:         unlockedOuts,
+		}},
+		Start:       startTime,
+		End:         endTime,
+		Delegations: delegations,
+		StakeOuts:   lockedOuts,
+		DelegationRewardsOwner: &secp256k1fx.OutputOwners{
+			Locktime:  0,
+			Threshold: 1,
+			Addrs:     []ids.ShortID{rewardAddress},
+		},
+	}
+}
+
+// delegatedAmount returns the total amount staked by [delegations]
+func delegatedAmount(delegations []*txs.Delegation) (uint64, error) {
+	amount := uint64(0)
+	for _, delegation := range delegations {
+		newAmount, err := math.Add64(amount, delegation.Wght)
+		if err != nil {
+			return 0, err
+		}
+		amount = newAmount
+	}
+	return amount, nil
+}
+
 func (b *builder) NewAddSubnetValidatorTx(
 	weight,
 	startTime,
@@ -609,3 +823,16 @@ func (b *builder) NewRewardValidatorTx(txID ids.ID) (*txs.Tx, error) {
 
 	return tx, tx.SyntacticVerify(b.ctx)
 }
+
+func (b *builder) NewRewardContinuousValidatorTx(txID ids.ID, period uint32) (*txs.Tx, error) {
+	utx := &txs.RewardContinuousValidatorTx{
+		RewardValidatorTx: txs.RewardValidatorTx{TxID: txID},
+		Period:            period,
+	}
+	tx, err := txs.NewSigned(utx, txs.Codec, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return tx, tx.SyntacticVerify(b.ctx)
+}
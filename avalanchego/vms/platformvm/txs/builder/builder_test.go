@@ -0,0 +1,191 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package builder
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/timer/mockable"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+)
+
+const (
+	testFunds = 10 * units.Avax
+	testFee   = units.MilliAvax
+)
+
+var preFundedKeys = crypto.BuildTestKeys()
+
+// newTestBuilder returns a builder that spends a single UTXO of [testFunds]
+// owned by preFundedKeys[0].
+func newTestBuilder(require *require.Assertions) *builder {
+	ctx := snow.DefaultContextTest()
+	ctx.AVAXAssetID = ids.GenerateTestID()
+
+	fx := &secp256k1fx.Fx{}
+	require.NoError(fx.InitializeVM(&secp256k1fx.TestVM{}))
+	require.NoError(fx.Bootstrapped())
+
+	utxos := avax.NewUTXOState(memdb.New(), txs.Codec)
+	require.NoError(utxos.PutUTXO(&avax.UTXO{
+		UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+		Asset:  avax.Asset{ID: ctx.AVAXAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: testFunds,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{preFundedKeys[0].PublicKey().Address()},
+			},
+		},
+	}))
+
+	clk := &mockable.Clock{}
+	return &builder{
+		Spender: utxo.NewHandler(ctx, clk, utxos, fx),
+		cfg: &config.Config{
+			AddPrimaryNetworkDelegatorFee: testFee,
+		},
+		ctx: ctx,
+		clk: clk,
+	}
+}
+
+func TestNewAddDelegatorsTx(t *testing.T) {
+	require := require.New(t)
+	b := newTestBuilder(require)
+
+	startTime := uint64(time.Now().Unix())
+	endTime := startTime + uint64(time.Hour/time.Second)
+	delegations := []*txs.Delegation{
+		{NodeID: ids.GenerateTestNodeID(), Wght: units.Avax},
+		{NodeID: ids.GenerateTestNodeID(), Wght: 2 * units.Avax},
+	}
+	rewardAddress := ids.GenerateTestShortID()
+	changeAddress := ids.GenerateTestShortID()
+
+	tx, err := b.NewAddDelegatorsTx(
+		startTime,
+		endTime,
+		delegations,
+		rewardAddress,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		changeAddress,
+	)
+	require.NoError(err)
+	require.Len(tx.Creds, 1)
+
+	utx, ok := tx.Unsigned.(*txs.AddDelegatorsTx)
+	require.True(ok)
+	require.Equal(startTime, utx.Start)
+	require.Equal(endTime, utx.End)
+	require.Equal(delegations, utx.Delegations)
+	require.Equal(&secp256k1fx.OutputOwners{
+		Threshold: 1,
+		Addrs:     []ids.ShortID{rewardAddress},
+	}, utx.DelegationRewardsOwner)
+
+	// The delegated amount is staked, the fee is burned and the rest is
+	// returned to the change address
+	staked := uint64(0)
+	for _, out := range utx.StakeOuts {
+		staked += out.Output().Amount()
+	}
+	require.Equal(3*units.Avax, staked)
+
+	require.Len(utx.Outs, 1)
+	change := utx.Outs[0].Out.(*secp256k1fx.TransferOutput)
+	require.Equal(testFunds-staked-testFee, change.Amt)
+	require.Equal([]ids.ShortID{changeAddress}, change.Addrs)
+}
+
+func TestNewUnsignedAddDelegatorsTx(t *testing.T) {
+	require := require.New(t)
+	b := newTestBuilder(require)
+
+	startTime := uint64(time.Now().Unix())
+	delegations := []*txs.Delegation{
+		{NodeID: ids.GenerateTestNodeID(), Wght: units.Avax},
+	}
+	fromAddrs := ids.ShortSet{}
+	fromAddrs.Add(preFundedKeys[0].PublicKey().Address())
+
+	tx, err := b.NewUnsignedAddDelegatorsTx(
+		startTime,
+		startTime+1,
+		delegations,
+		ids.GenerateTestShortID(),
+		fromAddrs,
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	// The inputs are left to be signed by the owner of the funds
+	require.Empty(tx.Creds)
+	utx := tx.Unsigned.(*txs.AddDelegatorsTx)
+	require.Len(utx.Ins, 1)
+	require.Len(utx.StakeOuts, 1)
+	require.Equal(units.Avax, utx.StakeOuts[0].Output().Amount())
+}
+
+func TestNewAddDelegatorsTxErrors(t *testing.T) {
+	startTime := uint64(time.Now().Unix())
+
+	tests := []struct {
+		name        string
+		delegations []*txs.Delegation
+		keys        []*crypto.PrivateKeySECP256K1R
+	}{
+		{
+			name: "delegated amount overflows",
+			delegations: []*txs.Delegation{
+				{NodeID: ids.GenerateTestNodeID(), Wght: math.MaxUint64},
+				{NodeID: ids.GenerateTestNodeID(), Wght: 1},
+			},
+			keys: []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		},
+		{
+			name: "insufficient funds",
+			delegations: []*txs.Delegation{
+				{NodeID: ids.GenerateTestNodeID(), Wght: testFunds},
+			},
+			keys: []*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		},
+		{
+			name: "no funds",
+			delegations: []*txs.Delegation{
+				{NodeID: ids.GenerateTestNodeID(), Wght: units.Avax},
+			},
+			keys: []*crypto.PrivateKeySECP256K1R{preFundedKeys[1]},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			b := newTestBuilder(require)
+
+			_, err := b.NewAddDelegatorsTx(
+				startTime,
+				startTime+1,
+				test.delegations,
+				ids.GenerateTestShortID(),
+				test.keys,
+				ids.ShortEmpty,
+			)
+			require.Error(err)
+		})
+	}
+}
@@ -52,6 +52,21 @@ func (mr *MockBuilderMockRecorder) NewAddDelegatorTx(arg0, arg1, arg2, arg3, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddDelegatorTx", reflect.TypeOf((*MockBuilder)(nil).NewAddDelegatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
 }
 
+// NewAddDelegatorsTx mocks base method.
+func (m *MockBuilder) NewAddDelegatorsTx(arg0, arg1 uint64, arg2 []*txs.Delegation, arg3 ids.ShortID, arg4 []*crypto.PrivateKeySECP256K1R, arg5 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewAddDelegatorsTx", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewAddDelegatorsTx indicates an expected call of NewAddDelegatorsTx.
+func (mr *MockBuilderMockRecorder) NewAddDelegatorsTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewAddDelegatorsTx", reflect.TypeOf((*MockBuilder)(nil).NewAddDelegatorsTx), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // NewAddSubnetValidatorTx mocks base method.
 func (m *MockBuilder) NewAddSubnetValidatorTx(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ID, arg5 []*crypto.PrivateKeySECP256K1R, arg6 ids.ShortID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +187,21 @@ func (mr *MockBuilderMockRecorder) NewRemoveSubnetValidatorTx(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRemoveSubnetValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRemoveSubnetValidatorTx), arg0, arg1, arg2, arg3)
 }
 
+// NewRewardContinuousValidatorTx mocks base method.
+func (m *MockBuilder) NewRewardContinuousValidatorTx(arg0 ids.ID, arg1 uint32) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewRewardContinuousValidatorTx", arg0, arg1)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewRewardContinuousValidatorTx indicates an expected call of NewRewardContinuousValidatorTx.
+func (mr *MockBuilderMockRecorder) NewRewardContinuousValidatorTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRewardContinuousValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRewardContinuousValidatorTx), arg0, arg1)
+}
+
 // NewRewardValidatorTx mocks base method.
 func (m *MockBuilder) NewRewardValidatorTx(arg0 ids.ID) (*txs.Tx, error) {
 	m.ctrl.T.Helper()
@@ -186,3 +216,48 @@ func (mr *MockBuilderMockRecorder) NewRewardValidatorTx(arg0 interface{}) *gomoc
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewRewardValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewRewardValidatorTx), arg0)
 }
+
+// NewUnsignedAddDelegatorTx mocks base method.
+func (m *MockBuilder) NewUnsignedAddDelegatorTx(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ShortID, arg5 ids.ShortSet, arg6 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUnsignedAddDelegatorTx", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUnsignedAddDelegatorTx indicates an expected call of NewUnsignedAddDelegatorTx.
+func (mr *MockBuilderMockRecorder) NewUnsignedAddDelegatorTx(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUnsignedAddDelegatorTx", reflect.TypeOf((*MockBuilder)(nil).NewUnsignedAddDelegatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
+// NewUnsignedAddDelegatorsTx mocks base method.
+func (m *MockBuilder) NewUnsignedAddDelegatorsTx(arg0, arg1 uint64, arg2 []*txs.Delegation, arg3 ids.ShortID, arg4 ids.ShortSet, arg5 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUnsignedAddDelegatorsTx", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUnsignedAddDelegatorsTx indicates an expected call of NewUnsignedAddDelegatorsTx.
+func (mr *MockBuilderMockRecorder) NewUnsignedAddDelegatorsTx(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUnsignedAddDelegatorsTx", reflect.TypeOf((*MockBuilder)(nil).NewUnsignedAddDelegatorsTx), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// NewUnsignedAddValidatorTx mocks base method.
+func (m *MockBuilder) NewUnsignedAddValidatorTx(arg0, arg1, arg2 uint64, arg3 ids.NodeID, arg4 ids.ShortID, arg5 uint32, arg6 ids.ShortSet, arg7 ids.ShortID) (*txs.Tx, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewUnsignedAddValidatorTx", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	ret0, _ := ret[0].(*txs.Tx)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NewUnsignedAddValidatorTx indicates an expected call of NewUnsignedAddValidatorTx.
+func (mr *MockBuilderMockRecorder) NewUnsignedAddValidatorTx(arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewUnsignedAddValidatorTx", reflect.TypeOf((*MockBuilder)(nil).NewUnsignedAddValidatorTx), arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+}
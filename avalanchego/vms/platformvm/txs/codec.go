@@ -41,6 +41,14 @@ func init() {
 		c.SkipRegistrations(5)
 
 		errs.Add(RegisterUnsignedTxsTypes(c))
+
+		// Skip the positions of the Banff blocks.
+		c.SkipRegistrations(4)
+
+		errs.Add(
+			RegisterContinuousStakingTypes(c),
+			RegisterBatchDelegationTypes(c),
+		)
 	}
 	errs.Add(
 		Codec.RegisterCodec(Version, c),
@@ -94,3 +102,21 @@ func RegisterUnsignedTxsTypes(targetCodec codec.Registry) error {
 	)
 	return errs.Err
 }
+
+// RegisterContinuousStakingTypes registers the types added by the continuous
+// staking upgrade. They are registered after the Banff blocks, so that the
+// typeIDs of the previously registered types don't change.
+func RegisterContinuousStakingTypes(targetCodec codec.Registry) error {
+	errs := wrappers.Errs{}
+	errs.Add(
+		targetCodec.RegisterType(&AddContinuousValidatorTx{}),
+		targetCodec.RegisterType(&RewardContinuousValidatorTx{}),
+	)
+	return errs.Err
+}
+
+// RegisterBatchDelegationTypes registers the types added by the batch
+// delegation upgrade, after the continuous staking types.
+func RegisterBatchDelegationTypes(targetCodec codec.Registry) error {
+	return targetCodec.RegisterType(&AddDelegatorsTx{})
+}
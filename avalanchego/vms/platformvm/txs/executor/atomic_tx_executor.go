@@ -47,6 +47,18 @@ func (*AtomicTxExecutor) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDele
 	return errWrongTxType
 }
 
+func (*AtomicTxExecutor) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*AtomicTxExecutor) AddDelegatorsTx(*txs.AddDelegatorsTx) error {
+	return errWrongTxType
+}
+
 func (e *AtomicTxExecutor) ImportTx(tx *txs.ImportTx) error {
 	return e.atomicTx(tx)
 }
@@ -0,0 +1,115 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"time"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var _ txs.Visitor = &feeCalculator{}
+
+// feeCalculator computes the amount of AVAX a tx must burn
+type feeCalculator struct {
+	// inputs, to be filled before visitor methods are called
+	config    *config.Config
+	timestamp time.Time
+
+	// outputs of visitor execution
+	fee uint64
+}
+
+// TxFee returns the amount of AVAX that [tx] must burn to be executed on a
+// chain whose timestamp is [timestamp].
+func TxFee(cfg *config.Config, timestamp time.Time, tx txs.UnsignedTx) (uint64, error) {
+	calculator := feeCalculator{
+		config:    cfg,
+		timestamp: timestamp,
+	}
+	err := tx.Visit(&calculator)
+	return calculator.fee, err
+}
+
+func (*feeCalculator) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errWrongTxType }
+func (*feeCalculator) RewardValidatorTx(*txs.RewardValidatorTx) error { return errWrongTxType }
+
+func (c *feeCalculator) AddValidatorTx(*txs.AddValidatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (c *feeCalculator) AddSubnetValidatorTx(*txs.AddSubnetValidatorTx) error {
+	c.fee = c.config.AddSubnetValidatorFee
+	return nil
+}
+
+func (c *feeCalculator) AddDelegatorTx(*txs.AddDelegatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	return nil
+}
+
+func (c *feeCalculator) CreateChainTx(*txs.CreateChainTx) error {
+	c.fee = c.config.GetCreateBlockchainTxFee(c.timestamp)
+	return nil
+}
+
+func (c *feeCalculator) CreateSubnetTx(*txs.CreateSubnetTx) error {
+	c.fee = c.config.GetCreateSubnetTxFee(c.timestamp)
+	return nil
+}
+
+func (c *feeCalculator) ImportTx(*txs.ImportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *feeCalculator) ExportTx(*txs.ExportTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *feeCalculator) RemoveSubnetValidatorTx(*txs.RemoveSubnetValidatorTx) error {
+	c.fee = c.config.TxFee
+	return nil
+}
+
+func (c *feeCalculator) TransformSubnetTx(*txs.TransformSubnetTx) error {
+	c.fee = c.config.TransformSubnetTxFee
+	return nil
+}
+
+func (c *feeCalculator) AddPermissionlessValidatorTx(tx *txs.AddPermissionlessValidatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetValidatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkValidatorFee
+	}
+	return nil
+}
+
+func (c *feeCalculator) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	c.fee = c.config.AddPrimaryNetworkValidatorFee
+	return nil
+}
+
+func (*feeCalculator) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errWrongTxType
+}
+
+func (c *feeCalculator) AddDelegatorsTx(*txs.AddDelegatorsTx) error {
+	c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	return nil
+}
+
+func (c *feeCalculator) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegatorTx) error {
+	if tx.Subnet != constants.PrimaryNetworkID {
+		c.fee = c.config.AddSubnetDelegatorFee
+	} else {
+		c.fee = c.config.AddPrimaryNetworkDelegatorFee
+	}
+	return nil
+}
@@ -304,6 +304,9 @@ func defaultConfig() config.Config {
 		ApricotPhase3Time: defaultValidateEndTime,
 		ApricotPhase5Time: defaultValidateEndTime,
 		BanffTime:         mockable.MaxTime,
+
+		ContinuousStakingTime: mockable.MaxTime,
+		BatchDelegationTime:   mockable.MaxTime,
 	}
 }
 
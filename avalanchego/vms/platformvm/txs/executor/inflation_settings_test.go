@@ -0,0 +1,102 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package executor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/units"
+	"github.com/ava-labs/avalanchego/vms/platformvm/config"
+)
+
+func TestGetInflationSettings(t *testing.T) {
+	require := require.New(t)
+
+	cfg := defaultConfig()
+
+	// Flare switches to its second staking phase on October 1st, 2023
+	settings := getInflationSettings(time.Date(2023, time.September, 1, 0, 0, 0, 0, time.UTC), constants.FlareID, &cfg)
+	require.EqualValues(10*units.MegaAvax, settings.MinValidatorStake)
+	settings = getInflationSettings(time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC), constants.FlareID, &cfg)
+	require.EqualValues(1*units.MegaAvax, settings.MinValidatorStake)
+
+	// Unknown networks use the staking rules of the config
+	settings = getInflationSettings(time.Now(), testNetworkID, &cfg)
+	require.Equal(getDefaultInflationSettings(&cfg), settings)
+	require.False(GetNetworkParams(testNetworkID, &cfg).RestrictStakingTxs)
+}
+
+func TestGetInflationSettingsOverride(t *testing.T) {
+	require := require.New(t)
+
+	paramsJSON := `{
+		"10": {
+			"restrictStakingTxs": true,
+			"stakingPhases": [
+				{
+					"start": "2024-01-01T00:00:00Z",
+					"minValidatorStake": 1000,
+					"maxValidatorStake": 2000,
+					"maxValidatorWeightFactor": 3
+				}
+			]
+		}
+	}`
+	params, err := config.ParseNetworkParams([]byte(paramsJSON))
+	require.NoError(err)
+
+	cfg := defaultConfig()
+	cfg.NetworkParams = params
+	require.True(GetNetworkParams(testNetworkID, &cfg).RestrictStakingTxs)
+
+	// The config applies until the first phase starts
+	settings := getInflationSettings(time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC), testNetworkID, &cfg)
+	require.Equal(getDefaultInflationSettings(&cfg), settings)
+
+	settings = getInflationSettings(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), testNetworkID, &cfg)
+	require.EqualValues(1000, settings.MinValidatorStake)
+	require.EqualValues(2000, settings.MaxValidatorStake)
+	require.EqualValues(3, settings.MaxValidatorWeightFactor)
+
+}
+
+func TestParseNetworkParamsBuiltIn(t *testing.T) {
+	// Networks with built in parameters can't be overridden
+	for networkID := range networkParams {
+		t.Run(constants.NetworkName(networkID), func(t *testing.T) {
+			_, err := config.ParseNetworkParams([]byte(fmt.Sprintf(`{"%d": {}}`, networkID)))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestAllowsBLSValidators(t *testing.T) {
+	require := require.New(t)
+
+	cfg := defaultConfig()
+	testParams := GetNetworkParams(testNetworkID, &cfg)
+	require.True(testParams.AllowsBLSValidators(time.Now()))
+
+	// Built in networks that restrict staking txs don't allow them yet
+	flareParams := GetNetworkParams(constants.FlareID, &cfg)
+	require.False(flareParams.AllowsBLSValidators(time.Now()))
+
+	params, err := config.ParseNetworkParams([]byte(`{
+		"10": {
+			"restrictStakingTxs": true,
+			"blsValidatorsTime": "2024-01-01T00:00:00Z"
+		}
+	}`))
+	require.NoError(err)
+	cfg.NetworkParams = params
+
+	networkParams := GetNetworkParams(testNetworkID, &cfg)
+	require.False(networkParams.AllowsBLSValidators(time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC)))
+	require.True(networkParams.AllowsBLSValidators(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)))
+}
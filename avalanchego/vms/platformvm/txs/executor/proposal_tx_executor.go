@@ -37,6 +37,8 @@ var (
 	errInvalidState                  = errors.New("generated output isn't valid state")
 	errShouldBePermissionlessStaker  = errors.New("expected permissionless staker")
 	errWrongTxType                   = errors.New("wrong transaction type")
+	errWrongRewardTxType             = errors.New("staker must be rewarded by another transaction type")
+	errWrongStakingPeriod            = errors.New("attempting to reward the wrong staking period")
 	errInvalidID                     = errors.New("invalid ID")
 	errProposedAddStakerTxAfterBanff = errors.New("staker transaction proposed after Banff")
 	errAdvanceTimeTxIssuedAfterBanff = errors.New("AdvanceTimeTx issued after Banff")
@@ -84,6 +86,14 @@ func (*ProposalTxExecutor) AddPermissionlessDelegatorTx(*txs.AddPermissionlessDe
 	return errWrongTxType
 }
 
+func (*ProposalTxExecutor) AddContinuousValidatorTx(*txs.AddContinuousValidatorTx) error {
+	return errWrongTxType
+}
+
+func (*ProposalTxExecutor) AddDelegatorsTx(*txs.AddDelegatorsTx) error {
+	return errWrongTxType
+}
+
 func (e *ProposalTxExecutor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	// AddValidatorTx is a proposal transaction until the Banff fork
 	// activation. Following the activation, AddValidatorTxs must be issued into
@@ -279,9 +289,25 @@ func (e *ProposalTxExecutor) AdvanceTimeTx(tx *txs.AdvanceTimeTx) error {
 }
 
 func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error {
-	switch {
-	case tx == nil:
+	if tx == nil {
 		return txs.ErrNilTx
+	}
+	return e.rewardStaker(tx, false, 0)
+}
+
+func (e *ProposalTxExecutor) RewardContinuousValidatorTx(tx *txs.RewardContinuousValidatorTx) error {
+	if tx == nil {
+		return txs.ErrNilTx
+	}
+	return e.rewardStaker(&tx.RewardValidatorTx, true, tx.Period)
+}
+
+// rewardStaker removes or restakes the staker whose staking period ends at the
+// current chain time, which must be the staker added by [tx.TxID]. Continuous
+// validators must be rewarded by a RewardContinuousValidatorTx, with
+// [continuous] set and the index of the period ending as [period].
+func (e *ProposalTxExecutor) rewardStaker(tx *txs.RewardValidatorTx, continuous bool, period uint32) error {
+	switch {
 	case tx.TxID == ids.Empty:
 		return errInvalidID
 	case len(e.Tx.Creds) != 0:
@@ -326,33 +352,55 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		return err
 	}
 
-	stakerTx, _, err := e.OnCommitState.GetTx(stakerToRemove.TxID)
+	stakerTx, err := state.GetStakerTx(e.OnCommitState, stakerToRemove)
 	if err != nil {
 		return fmt.Errorf("failed to get next removed staker tx: %w", err)
 	}
 
-	switch uStakerTx := stakerTx.Unsigned.(type) {
-	case txs.ValidatorTx:
-		e.OnCommitState.DeleteCurrentValidator(stakerToRemove)
-		e.OnAbortState.DeleteCurrentValidator(stakerToRemove)
+	// A continuous validator that has staking periods left is restaked rather
+	// than removed. Each of its periods is rewarded to a different output.
+	continuousTx, isContinuous := stakerTx.(*txs.AddContinuousValidatorTx)
+	if isContinuous != continuous {
+		return fmt.Errorf("%w: %s", errWrongRewardTxType, tx.TxID)
+	}
+	restake := false
+	if isContinuous {
+		if expectedPeriod := continuousTx.Period(stakerToRemove.StartTime); period != expectedPeriod {
+			return fmt.Errorf(
+				"%w: period %d of %s is ending, not period %d",
+				errWrongStakingPeriod,
+				expectedPeriod,
+				tx.TxID,
+				period,
+			)
+		}
+		restake = uint64(period)+1 < continuousTx.NumPeriods()
+	}
 
+	switch uStakerTx := stakerTx.(type) {
+	case txs.ValidatorTx:
 		stake := uStakerTx.Stake()
 		outputs := uStakerTx.Outputs()
 		// Invariant: The staked asset must be equal to the reward asset.
 		stakeAsset := stake[0].Asset
 
-		// Refund the stake here
-		for i, out := range stake {
-			utxo := &avax.UTXO{
-				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + i),
-				},
-				Asset: out.Asset,
-				Out:   out.Output(),
+		if !restake {
+			e.OnCommitState.DeleteCurrentValidator(stakerToRemove)
+			e.OnAbortState.DeleteCurrentValidator(stakerToRemove)
+
+			// Refund the stake here
+			for i, out := range stake {
+				utxo := &avax.UTXO{
+					UTXOID: avax.UTXOID{
+						TxID:        tx.TxID,
+						OutputIndex: uint32(len(outputs) + i),
+					},
+					Asset: out.Asset,
+					Out:   out.Output(),
+				}
+				e.OnCommitState.AddUTXO(utxo)
+				e.OnAbortState.AddUTXO(utxo)
 			}
-			e.OnCommitState.AddUTXO(utxo)
-			e.OnAbortState.AddUTXO(utxo)
 		}
 
 		// Provide the reward here
@@ -370,7 +418,7 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
 					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + len(stake)),
+					OutputIndex: uint32(len(outputs)+len(stake)) + period,
 				},
 				Asset: stakeAsset,
 				Out:   out,
@@ -390,12 +438,24 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		outputs := uStakerTx.Outputs()
 		stakeAsset := stake[0].Asset
 
+		// The delegations of an AddDelegatorsTx are refunded and rewarded to
+		// the outputs of that tx that belong to them.
+		utxoTxID := tx.TxID
+		firstOutputIndex := uint32(len(outputs))
+		if stakerToRemove.BatchTxID != ids.Empty {
+			utxoTxID = stakerToRemove.BatchTxID
+			firstOutputIndex, err = batchDelegatorOutputIndex(e.OnCommitState, stakerToRemove)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Refund the stake here
 		for i, out := range stake {
 			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + i),
+					TxID:        utxoTxID,
+					OutputIndex: firstOutputIndex + uint32(i),
 				},
 				Asset: out.Asset,
 				Out:   out.Output(),
@@ -462,15 +522,15 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			}
 			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + len(stake)),
+					TxID:        utxoTxID,
+					OutputIndex: firstOutputIndex + uint32(len(stake)),
 				},
 				Asset: stakeAsset,
 				Out:   out,
 			}
 
 			e.OnCommitState.AddUTXO(utxo)
-			e.OnCommitState.AddRewardUTXO(tx.TxID, utxo)
+			e.OnCommitState.AddRewardUTXO(utxoTxID, utxo)
 
 			offset++
 		}
@@ -488,15 +548,15 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 			}
 			utxo := &avax.UTXO{
 				UTXOID: avax.UTXOID{
-					TxID:        tx.TxID,
-					OutputIndex: uint32(len(outputs) + len(stake) + offset),
+					TxID:        utxoTxID,
+					OutputIndex: firstOutputIndex + uint32(len(stake)+offset),
 				},
 				Asset: stakeAsset,
 				Out:   out,
 			}
 
 			e.OnCommitState.AddUTXO(utxo)
-			e.OnCommitState.AddRewardUTXO(tx.TxID, utxo)
+			e.OnCommitState.AddRewardUTXO(utxoTxID, utxo)
 		}
 	default:
 		// Invariant: Permissioned stakers are removed by the advancement of
@@ -506,6 +566,25 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 		return errShouldBePermissionlessStaker
 	}
 
+	// Record whether the staker was rewarded. Stakers whose reward was
+	// calculated before reward contexts were persisted don't have one. The
+	// context of a restaked staker is replaced by the one of its next period,
+	// so the outcome of each period of a continuous validator is also recorded
+	// under the tx that rewarded the period.
+	rewardContext, err := e.OnCommitState.GetRewardContext(stakerToRemove.TxID)
+	switch {
+	case err == nil:
+		if isContinuous {
+			e.recordRewardOutcome(e.Tx.ID(), rewardContext)
+		}
+		if !restake {
+			e.recordRewardOutcome(stakerToRemove.TxID, rewardContext)
+		}
+	case err == database.ErrNotFound:
+	default:
+		return fmt.Errorf("failed to get reward context: %w", err)
+	}
+
 	// If the reward is aborted, then the current supply should be decreased.
 	currentSupply, err := e.OnAbortState.GetCurrentSupply(stakerToRemove.SubnetID)
 	if err != nil {
@@ -517,6 +596,15 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 	}
 	e.OnAbortState.SetCurrentSupply(stakerToRemove.SubnetID, newSupply)
 
+	if restake {
+		if err := restakeValidator(e.Backend, e.OnCommitState, stakerToRemove); err != nil {
+			return err
+		}
+		if err := restakeValidator(e.Backend, e.OnAbortState, stakerToRemove); err != nil {
+			return err
+		}
+	}
+
 	var expectedUptimePercentage float64
 	if stakerToRemove.SubnetID != constants.PrimaryNetworkID {
 		transformSubnetIntf, err := e.OnCommitState.GetSubnetTransformation(stakerToRemove.SubnetID)
@@ -546,6 +634,69 @@ func (e *ProposalTxExecutor) RewardValidatorTx(tx *txs.RewardValidatorTx) error
 	return nil
 }
 
+// recordRewardOutcome stores [rewardContext] under [txID], marked as rewarded
+// on commit and as not rewarded on abort.
+func (e *ProposalTxExecutor) recordRewardOutcome(txID ids.ID, rewardContext *reward.Context) {
+	committedContext := *rewardContext
+	committedContext.Removed = true
+	committedContext.Rewarded = true
+	e.OnCommitState.SetRewardContext(txID, &committedContext)
+
+	abortedContext := *rewardContext
+	abortedContext.Removed = true
+	abortedContext.Rewarded = false
+	e.OnAbortState.SetRewardContext(txID, &abortedContext)
+}
+
+// batchDelegatorOutputIndex returns the index of the first output of the
+// AddDelegatorsTx of [staker] that belongs to its delegation.
+func batchDelegatorOutputIndex(chainState state.Chain, staker *state.Staker) (uint32, error) {
+	tx, _, err := chainState.GetTx(staker.BatchTxID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get batch delegator tx: %w", err)
+	}
+	batchTx, ok := tx.Unsigned.(*txs.AddDelegatorsTx)
+	if !ok {
+		return 0, errWrongTxType
+	}
+	return batchTx.OutputIndex(int(staker.BatchIndex))
+}
+
+// restakeValidator replaces [staker] in [chainState] by the staker of its next
+// staking period, whose potential reward is calculated from the current supply
+// of [chainState].
+func restakeValidator(backend *Backend, chainState state.Diff, staker *state.Staker) error {
+	supply, err := chainState.GetCurrentSupply(staker.SubnetID)
+	if err != nil {
+		return err
+	}
+	rewards, err := GetRewardsCalculator(backend, chainState, staker.SubnetID)
+	if err != nil {
+		return err
+	}
+	rewardConfig, err := GetRewardsConfig(backend, chainState, staker.SubnetID)
+	if err != nil {
+		return err
+	}
+
+	stakedDuration := staker.EndTime.Sub(staker.StartTime)
+	potentialReward := rewards.Calculate(stakedDuration, staker.Weight, supply)
+	chainState.SetRewardContext(staker.TxID, &reward.Context{
+		SubnetID:        staker.SubnetID,
+		StakedAmount:    staker.Weight,
+		StakedDuration:  stakedDuration,
+		CurrentSupply:   supply,
+		Config:          rewardConfig,
+		PotentialReward: potentialReward,
+	})
+
+	// Invariant: [rewards.Calculate] can never return a [potentialReward]
+	//            such that [supply + potentialReward > maximumSupply].
+	chainState.SetCurrentSupply(staker.SubnetID, supply+potentialReward)
+	chainState.RestakeCurrentValidator(state.NewRestakedStaker(staker, potentialReward))
+	return nil
+}
+
 // GetNextStakerChangeTime returns the next time a staker will be either added
 // or removed to/from the current validator set.
 func GetNextStakerChangeTime(state state.Chain) (time.Time, error) {
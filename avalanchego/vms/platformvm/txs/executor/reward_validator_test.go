@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
@@ -117,6 +118,20 @@ func TestRewardValidatorTxExecuteOnCommit(t *testing.T) {
 	onCommitStakerIterator.Release()
 	require.NotEqual(stakerToRemove.TxID, nextToRemove.TxID)
 
+	// check that the outcome is recorded in the reward context
+	onCommitRewardContext, err := txExecutor.OnCommitState.GetRewardContext(stakerToRemove.TxID)
+	require.NoError(err)
+	require.True(onCommitRewardContext.Removed)
+	require.True(onCommitRewardContext.Rewarded)
+	require.Equal(stakerToRemove.Weight, onCommitRewardContext.StakedAmount)
+	require.Equal(stakerToRemove.PotentialReward, onCommitRewardContext.PotentialReward)
+	require.NoError(onCommitRewardContext.Verify())
+
+	onAbortRewardContext, err := txExecutor.OnAbortState.GetRewardContext(stakerToRemove.TxID)
+	require.NoError(err)
+	require.True(onAbortRewardContext.Removed)
+	require.False(onAbortRewardContext.Rewarded)
+
 	// check that stake/reward is given back
 	stakeOwners := stakerToRemoveTx.StakeOuts[0].Out.(*secp256k1fx.TransferOutput).AddressesSet()
 
@@ -355,3 +370,406 @@ func TestRewardDelegatorTxExecuteOnAbort(t *testing.T) {
 	require.NoError(err)
 	require.Equal(initialSupply-expectedReward, newSupply, "should have removed un-rewarded tokens from the potential supply")
 }
+
+func TestRewardBatchDelegatorTx(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	dummyHeight := uint64(1)
+
+	delRewardAddress := ids.GenerateTestShortID()
+
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(2 * defaultMinStakingDuration).Unix())
+
+	delegations := make([]*txs.Delegation, 2)
+	for i := range delegations {
+		vdrNodeID := ids.GenerateTestNodeID()
+		vdrTx, err := env.txBuilder.NewAddValidatorTx(
+			env.config.MinValidatorStake, // stakeAmt
+			vdrStartTime,
+			vdrEndTime,
+			vdrNodeID,                 // node ID
+			ids.GenerateTestShortID(), // reward address
+			reward.PercentDenominator/4,
+			[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+			ids.ShortEmpty,
+		)
+		require.NoError(err)
+
+		vdrStaker := state.NewCurrentStaker(
+			vdrTx.ID(),
+			vdrTx.Unsigned.(*txs.AddValidatorTx),
+			0,
+		)
+		env.state.PutCurrentValidator(vdrStaker)
+		env.state.AddTx(vdrTx, status.Committed)
+
+		delegations[i] = &txs.Delegation{
+			NodeID: vdrNodeID,
+			Wght:   env.config.MinDelegatorStake,
+		}
+	}
+
+	batchTx, err := env.txBuilder.NewAddDelegatorsTx(
+		vdrStartTime,
+		vdrEndTime,
+		delegations,
+		delRewardAddress,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+	batchTxID := batchTx.ID()
+	utx := batchTx.Unsigned.(*txs.AddDelegatorsTx)
+
+	for i := range delegations {
+		delStaker, err := state.NewPendingBatchDelegator(batchTxID, utx, i)
+		require.NoError(err)
+		delStaker.NextTime = delStaker.EndTime
+		delStaker.Priority = txs.PrimaryNetworkDelegatorCurrentPriority
+		delStaker.PotentialReward = 1000000
+		env.state.PutCurrentDelegator(delStaker)
+	}
+	env.state.AddTx(batchTx, status.Committed)
+	env.state.SetTimestamp(time.Unix(int64(vdrEndTime), 0))
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	// The delegations are rewarded one at a time, in the staker order
+	currentStakerIterator, err := env.state.GetCurrentStakerIterator()
+	require.NoError(err)
+	require.True(currentStakerIterator.Next())
+	delStaker := currentStakerIterator.Value()
+	currentStakerIterator.Release()
+	require.Equal(batchTxID, delStaker.BatchTxID)
+	require.Equal(txs.DelegationID(batchTxID, int(delStaker.BatchIndex)), delStaker.TxID)
+
+	tx, err := env.txBuilder.NewRewardValidatorTx(delStaker.TxID)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	onCommitState.Apply(env.state)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	// The stake and the rewards are sent to the outputs of the AddDelegatorsTx
+	// that belong to the delegation
+	delegatorTx, err := utx.DelegatorTx(int(delStaker.BatchIndex))
+	require.NoError(err)
+	firstOutputIndex, err := utx.OutputIndex(int(delStaker.BatchIndex))
+	require.NoError(err)
+	for i, out := range delegatorTx.StakeOuts {
+		stakeUTXOID := avax.UTXOID{
+			TxID:        batchTxID,
+			OutputIndex: firstOutputIndex + uint32(i),
+		}
+		stakeUTXO, err := env.state.GetUTXO(stakeUTXOID.InputID())
+		require.NoError(err)
+		require.Equal(out.Output().Amount(), stakeUTXO.Out.(avax.Amounter).Amount())
+	}
+
+	rewardUTXOs, err := env.state.GetRewardUTXOs(batchTxID)
+	require.NoError(err)
+	rewardOutputIndices := make([]uint32, 0, len(rewardUTXOs))
+	for _, rewardUTXO := range rewardUTXOs {
+		require.Equal(batchTxID, rewardUTXO.TxID)
+		rewardOutputIndices = append(rewardOutputIndices, rewardUTXO.OutputIndex)
+	}
+	rewardOutputIndex := firstOutputIndex + uint32(len(delegatorTx.StakeOuts))
+	require.ElementsMatch([]uint32{rewardOutputIndex, rewardOutputIndex + 1}, rewardOutputIndices)
+
+	// No tx is stored for the delegation
+	_, _, err = env.state.GetTx(delStaker.TxID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+func TestRewardContinuousValidatorTxRestake(t *testing.T) {
+	require := require.New(t)
+	env := newEnvironment()
+	defer func() {
+		require.NoError(shutdownEnvironment(env))
+	}()
+	dummyHeight := uint64(1)
+
+	vdrRewardAddress := ids.GenerateTestShortID()
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(2 * defaultMinStakingDuration).Unix())
+	vdrNodeID := ids.GenerateTestNodeID()
+
+	addValidatorTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake, // stakeAmt
+		vdrStartTime,
+		vdrEndTime,
+		vdrNodeID,        // node ID
+		vdrRewardAddress, // reward address
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(err)
+
+	utx := &txs.AddContinuousValidatorTx{
+		AddValidatorTx: *addValidatorTx.Unsigned.(*txs.AddValidatorTx),
+		RestakePeriods: 1,
+	}
+	vdrTx, err := txs.NewSigned(utx, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}})
+	require.NoError(err)
+	vdrTxID := vdrTx.ID()
+
+	potentialReward := uint64(1000000)
+	vdrStaker := state.NewCurrentStaker(vdrTxID, utx, potentialReward)
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.SetRewardContext(vdrTxID, &reward.Context{
+		SubnetID:        constants.PrimaryNetworkID,
+		StakedAmount:    vdrStaker.Weight,
+		StakedDuration:  vdrStaker.EndTime.Sub(vdrStaker.StartTime),
+		PotentialReward: potentialReward,
+	})
+	env.state.SetTimestamp(vdrStaker.EndTime)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	rewardOwners := ids.ShortSet{}
+	rewardOwners.Add(vdrRewardAddress)
+	rewardIndex := uint32(len(utx.Outs) + len(utx.StakeOuts))
+
+	// The first period ends: the validator is rewarded and restaked
+	tx, err := env.txBuilder.NewRewardContinuousValidatorTx(vdrTxID, 0)
+	require.NoError(err)
+
+	onCommitState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err := state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor := ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	duration := vdrStaker.EndTime.Sub(vdrStaker.StartTime)
+	for _, chainState := range []state.Chain{onCommitState, onAbortState} {
+		restakedStaker, err := chainState.GetCurrentValidator(constants.PrimaryNetworkID, vdrNodeID)
+		require.NoError(err)
+		require.Equal(vdrTxID, restakedStaker.TxID)
+		require.Equal(vdrStaker.Weight, restakedStaker.Weight)
+		require.Equal(vdrStaker.EndTime, restakedStaker.StartTime)
+		require.Equal(vdrStaker.EndTime.Add(duration), restakedStaker.EndTime)
+		require.Equal(restakedStaker.EndTime, restakedStaker.NextTime)
+
+		rewardContext, err := chainState.GetRewardContext(vdrTxID)
+		require.NoError(err)
+		require.Equal(restakedStaker.PotentialReward, rewardContext.PotentialReward)
+		require.Equal(duration, rewardContext.StakedDuration)
+		require.False(rewardContext.Removed)
+	}
+
+	// The outcome of the first period is recorded under the tx that rewarded it
+	firstTxID := tx.ID()
+	onCommitRewardContext, err := onCommitState.GetRewardContext(firstTxID)
+	require.NoError(err)
+	require.True(onCommitRewardContext.Removed)
+	require.True(onCommitRewardContext.Rewarded)
+	require.Equal(potentialReward, onCommitRewardContext.PotentialReward)
+
+	onAbortRewardContext, err := onAbortState.GetRewardContext(firstTxID)
+	require.NoError(err)
+	require.True(onAbortRewardContext.Removed)
+	require.False(onAbortRewardContext.Rewarded)
+	require.Equal(potentialReward, onAbortRewardContext.PotentialReward)
+
+	onCommitState.Apply(env.state)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	// The reward of the first period is paid but the stake is still locked
+	rewardUTXOID := avax.UTXOID{
+		TxID:        vdrTxID,
+		OutputIndex: rewardIndex,
+	}
+	rewardUTXO, err := env.state.GetUTXO(rewardUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(potentialReward, rewardUTXO.Out.(*secp256k1fx.TransferOutput).Amt)
+
+	stakeUTXOID := avax.UTXOID{
+		TxID:        vdrTxID,
+		OutputIndex: uint32(len(utx.Outs)),
+	}
+	_, err = env.state.GetUTXO(stakeUTXOID.InputID())
+	require.ErrorIs(err, database.ErrNotFound)
+
+	restakedStaker, err := env.state.GetCurrentValidator(constants.PrimaryNetworkID, vdrNodeID)
+	require.NoError(err)
+
+	// Give the last period a reward so that its reward UTXO is created
+	env.state.DeleteCurrentValidator(restakedStaker)
+	require.NoError(env.state.Commit())
+	restakedStaker = &state.Staker{}
+	*restakedStaker = *vdrStaker
+	restakedStaker.StartTime = vdrStaker.EndTime
+	restakedStaker.EndTime = vdrStaker.EndTime.Add(duration)
+	restakedStaker.NextTime = restakedStaker.EndTime
+	restakedStaker.PotentialReward = potentialReward / 2
+	env.state.PutCurrentValidator(restakedStaker)
+
+	// The last period ends: the validator is rewarded and removed
+	env.state.SetTimestamp(restakedStaker.EndTime)
+	require.NoError(env.state.Commit())
+
+	lastTx, err := env.txBuilder.NewRewardContinuousValidatorTx(vdrTxID, 1)
+	require.NoError(err)
+
+	// Each period is rewarded by a distinct tx
+	require.NotEqual(tx.ID(), lastTx.ID())
+	tx = lastTx
+
+	onCommitState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+	onAbortState, err = state.NewDiff(lastAcceptedID, env)
+	require.NoError(err)
+
+	txExecutor = ProposalTxExecutor{
+		OnCommitState: onCommitState,
+		OnAbortState:  onAbortState,
+		Backend:       &env.backend,
+		Tx:            tx,
+	}
+	require.NoError(tx.Unsigned.Visit(&txExecutor))
+
+	_, err = onCommitState.GetCurrentValidator(constants.PrimaryNetworkID, vdrNodeID)
+	require.ErrorIs(err, database.ErrNotFound)
+
+	onCommitState.Apply(env.state)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(env.state.Commit())
+
+	// The stake is returned once the last period ends
+	stakeUTXO, err := env.state.GetUTXO(stakeUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(vdrStaker.Weight, stakeUTXO.Out.(*secp256k1fx.TransferOutput).Amt)
+
+	// The reward of the last period doesn't overwrite the first one
+	lastRewardUTXOID := avax.UTXOID{
+		TxID:        vdrTxID,
+		OutputIndex: rewardIndex + 1,
+	}
+	lastRewardUTXO, err := env.state.GetUTXO(lastRewardUTXOID.InputID())
+	require.NoError(err)
+	require.Equal(restakedStaker.PotentialReward, lastRewardUTXO.Out.(*secp256k1fx.TransferOutput).Amt)
+	_, err = env.state.GetUTXO(rewardUTXOID.InputID())
+	require.NoError(err)
+
+	// Each period keeps its own outcome, and the staker's context holds the
+	// outcome of its last period
+	firstRewardContext, err := env.state.GetRewardContext(firstTxID)
+	require.NoError(err)
+	require.True(firstRewardContext.Rewarded)
+	require.Equal(potentialReward, firstRewardContext.PotentialReward)
+
+	lastRewardContext, err := env.state.GetRewardContext(lastTx.ID())
+	require.NoError(err)
+	require.True(lastRewardContext.Removed)
+	require.True(lastRewardContext.Rewarded)
+
+	stakerRewardContext, err := env.state.GetRewardContext(vdrTxID)
+	require.NoError(err)
+	require.Equal(lastRewardContext, stakerRewardContext)
+}
+
+func TestRewardContinuousValidatorTxWrongTx(t *testing.T) {
+	env := newEnvironment()
+	defer func() {
+		require.NoError(t, shutdownEnvironment(env))
+	}()
+	dummyHeight := uint64(1)
+
+	vdrStartTime := uint64(defaultValidateStartTime.Unix()) + 1
+	vdrEndTime := uint64(defaultValidateStartTime.Add(2 * defaultMinStakingDuration).Unix())
+	vdrNodeID := ids.GenerateTestNodeID()
+
+	addValidatorTx, err := env.txBuilder.NewAddValidatorTx(
+		env.config.MinValidatorStake, // stakeAmt
+		vdrStartTime,
+		vdrEndTime,
+		vdrNodeID,                 // node ID
+		ids.GenerateTestShortID(), // reward address
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+		ids.ShortEmpty,
+	)
+	require.NoError(t, err)
+
+	utx := &txs.AddContinuousValidatorTx{
+		AddValidatorTx: *addValidatorTx.Unsigned.(*txs.AddValidatorTx),
+		RestakePeriods: 1,
+	}
+	vdrTx, err := txs.NewSigned(utx, txs.Codec, [][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}})
+	require.NoError(t, err)
+	vdrTxID := vdrTx.ID()
+
+	vdrStaker := state.NewCurrentStaker(vdrTxID, utx, 1000000)
+	env.state.PutCurrentValidator(vdrStaker)
+	env.state.AddTx(vdrTx, status.Committed)
+	env.state.SetTimestamp(vdrStaker.EndTime)
+	env.state.SetHeight(dummyHeight)
+	require.NoError(t, env.state.Commit())
+
+	rewardValidatorTx, err := env.txBuilder.NewRewardValidatorTx(vdrTxID)
+	require.NoError(t, err)
+	wrongPeriodTx, err := env.txBuilder.NewRewardContinuousValidatorTx(vdrTxID, 1)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		tx          *txs.Tx
+		expectedErr error
+	}{
+		{
+			name:        "not continuous reward tx",
+			tx:          rewardValidatorTx,
+			expectedErr: errWrongRewardTxType,
+		},
+		{
+			name:        "wrong period",
+			tx:          wrongPeriodTx,
+			expectedErr: errWrongStakingPeriod,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			onCommitState, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+			onAbortState, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			txExecutor := ProposalTxExecutor{
+				OnCommitState: onCommitState,
+				OnAbortState:  onAbortState,
+				Backend:       &env.backend,
+				Tx:            test.tx,
+			}
+			require.ErrorIs(test.tx.Unsigned.Visit(&txExecutor), test.expectedErr)
+		})
+	}
+}
@@ -169,7 +169,7 @@ func verifyAddSubnetValidatorTx(
 	}
 
 	// Flare does not allow creation of subnets
-	if constants.IsFlareNetworkID(backend.Ctx.NetworkID) || constants.IsSgbNetworkID(backend.Ctx.NetworkID) {
+	if GetNetworkParams(backend.Ctx.NetworkID, backend.Config).RestrictStakingTxs {
 		return errWrongTxType
 	}
 
@@ -441,6 +441,131 @@ func verifyAddDelegatorTx(
 	return outs, nil
 }
 
+// verifyAddDelegatorsTx carries out the validation for an AddDelegatorsTx.
+// Either all of its delegations are valid, or the tx is invalid. It returns
+// the pending staker of each of the delegations.
+func verifyAddDelegatorsTx(
+	backend *Backend,
+	chainState state.Chain,
+	sTx *txs.Tx,
+	tx *txs.AddDelegatorsTx,
+) (
+	[]*state.Staker,
+	error,
+) {
+	// Verify the tx is well-formed
+	if err := sTx.SyntacticVerify(backend.Ctx); err != nil {
+		return nil, err
+	}
+
+	currentTimestamp := chainState.GetTimestamp()
+	_, maxValidatorStake, minDelegatorStake, _, _, minStakeDuration, maxStakeDuration, minFutureStartTimeOffset, maxValidatorWeightFactor, _ := GetCurrentInflationSettings(currentTimestamp, backend.Ctx.NetworkID, backend.Config)
+
+	startTime := tx.StartTime()
+	duration := tx.EndTime().Sub(startTime)
+	switch {
+	case duration < minStakeDuration:
+		// Ensure staking length is not too short
+		return nil, errStakeTooShort
+
+	case duration > maxStakeDuration:
+		// Ensure staking length is not too long
+		return nil, errStakeTooLong
+	}
+
+	txID := sTx.ID()
+	delegators := make([]*state.Staker, len(tx.Delegations))
+	for i, delegation := range tx.Delegations {
+		// Ensure each delegation is at least the minimum amount
+		if delegation.Wght < minDelegatorStake {
+			return nil, fmt.Errorf("%w: delegation to %s", errWeightTooSmall, delegation.NodeID)
+		}
+
+		delegator, err := state.NewPendingBatchDelegator(txID, tx, i)
+		if err != nil {
+			return nil, err
+		}
+		delegators[i] = delegator
+	}
+
+	if !backend.Bootstrapped.GetValue() {
+		return delegators, nil
+	}
+
+	// Ensure the proposed delegations start after the current timestamp
+	if !currentTimestamp.Before(startTime) {
+		return nil, fmt.Errorf(
+			"%w: %s >= %s",
+			errTimestampNotBeforeStartTime,
+			currentTimestamp,
+			startTime,
+		)
+	}
+
+	for _, delegator := range delegators {
+		nodeID := delegator.NodeID
+		primaryNetworkValidator, err := GetValidator(chainState, constants.PrimaryNetworkID, nodeID)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to fetch the primary network validator for %s: %w",
+				nodeID,
+				err,
+			)
+		}
+
+		maximumWeight, err := math.Mul64(maxValidatorWeightFactor, primaryNetworkValidator.Weight)
+		if err != nil {
+			return nil, errStakeOverflow
+		}
+
+		if backend.Config.IsApricotPhase3Activated(currentTimestamp) {
+			maximumWeight = math.Min64(maximumWeight, maxValidatorStake)
+		}
+
+		canDelegate, err := canDelegate(chainState, primaryNetworkValidator, maximumWeight, delegator)
+		if err != nil {
+			return nil, err
+		}
+		if !canDelegate {
+			return nil, fmt.Errorf("%w: %s", errOverDelegated, nodeID)
+		}
+	}
+
+	outs := make([]*avax.TransferableOutput, len(tx.Outs)+len(tx.StakeOuts))
+	copy(outs, tx.Outs)
+	copy(outs[len(tx.Outs):], tx.StakeOuts)
+
+	// Verify the flowcheck
+	if err := backend.FlowChecker.VerifySpend(
+		tx,
+		chainState,
+		tx.Ins,
+		outs,
+		sTx.Creds,
+		map[ids.ID]uint64{
+			backend.Ctx.AVAXAssetID: backend.Config.AddPrimaryNetworkDelegatorFee,
+		},
+	); err != nil {
+		return nil, fmt.Errorf("%w: %s", errFlowCheckFailed, err)
+	}
+
+	// Make sure the tx doesn't start too far in the future. This is done last
+	// to allow the verifier visitor to explicitly check for this error.
+	maxStartTime := currentTimestamp.Add(MaxFutureStartTime)
+	if startTime.After(maxStartTime) {
+		return nil, errFutureStakeTime
+	}
+	minStartTime := maxStartTime.Add(-minFutureStartTimeOffset)
+	if startTime.Before(minStartTime) {
+		return nil, fmt.Errorf(
+			"delegators' start time (%s) at or before minStartTime (%s)",
+			startTime,
+			minStartTime,
+		)
+	}
+	return delegators, nil
+}
+
 // verifyAddPermissionlessValidatorTx carries out the validation for an
 // AddPermissionlessValidatorTx.
 func verifyAddPermissionlessValidatorTx(
@@ -454,8 +579,11 @@ func verifyAddPermissionlessValidatorTx(
 		return err
 	}
 
-	// Flare does not (yet) allow adding permissionless validator tx
-	if constants.IsFlareNetworkID(backend.Ctx.NetworkID) || constants.IsSgbNetworkID(backend.Ctx.NetworkID) {
+	// Flare does not (yet) allow adding permissionless validator tx, except
+	// for primary network validators that register a BLS key once enabled
+	networkParams := GetNetworkParams(backend.Ctx.NetworkID, backend.Config)
+	if networkParams.RestrictStakingTxs &&
+		(tx.Subnet != constants.PrimaryNetworkID || !networkParams.AllowsBLSValidators(chainState.GetTimestamp())) {
 		return errWrongTxType
 	}
 
@@ -641,7 +769,7 @@ func verifyAddPermissionlessDelegatorTx(
 	}
 
 	// Flare does not (yet) allow adding permissionless delegator tx
-	if constants.IsFlareNetworkID(backend.Ctx.NetworkID) || constants.IsSgbNetworkID(backend.Ctx.NetworkID) {
+	if GetNetworkParams(backend.Ctx.NetworkID, backend.Config).RestrictStakingTxs {
 		return errWrongTxType
 	}
 
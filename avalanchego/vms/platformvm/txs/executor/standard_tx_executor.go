@@ -26,6 +26,9 @@ var (
 	errRemoveSubnetValidatorTxBeforeBanff = errors.New("RemoveSubnetValidatorTx issued before Banff")
 	errTransformSubnetTxBeforeBanff       = errors.New("TransformSubnetTx issued before Banff")
 	errMaxStakeDurationTooLarge           = errors.New("max stake duration must be less than or equal to the global max stake duration")
+	errContinuousStakingNotActivated      = errors.New("continuous staking isn't activated")
+	errContinuousStakeTooLong             = errors.New("continuous staking periods are too long in total")
+	errBatchDelegationNotActivated        = errors.New("batch delegation isn't activated")
 )
 
 type StandardTxExecutor struct {
@@ -42,6 +45,9 @@ type StandardTxExecutor struct {
 
 func (*StandardTxExecutor) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errWrongTxType }
 func (*StandardTxExecutor) RewardValidatorTx(*txs.RewardValidatorTx) error { return errWrongTxType }
+func (*StandardTxExecutor) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errWrongTxType
+}
 
 func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	if err := e.Tx.SyntacticVerify(e.Ctx); err != nil {
@@ -54,8 +60,10 @@ func (e *StandardTxExecutor) CreateChainTx(tx *txs.CreateChainTx) error {
 	}
 
 	// Verify the flowcheck
-	timestamp := e.State.GetTimestamp()
-	createBlockchainTxFee := e.Config.GetCreateBlockchainTxFee(timestamp)
+	createBlockchainTxFee, err := TxFee(e.Config, e.State.GetTimestamp(), tx)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -91,8 +99,10 @@ func (e *StandardTxExecutor) CreateSubnetTx(tx *txs.CreateSubnetTx) error {
 	}
 
 	// Verify the flowcheck
-	timestamp := e.State.GetTimestamp()
-	createSubnetTxFee := e.Config.GetCreateSubnetTxFee(timestamp)
+	createSubnetTxFee, err := TxFee(e.Config, e.State.GetTimestamp(), tx)
+	if err != nil {
+		return err
+	}
 	if err := e.FlowChecker.VerifySpend(
 		tx,
 		e.State,
@@ -538,3 +548,85 @@ func (e *StandardTxExecutor) AddPermissionlessDelegatorTx(tx *txs.AddPermissionl
 
 	return nil
 }
+
+func (e *StandardTxExecutor) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsContinuousStakingActivated(currentTimestamp) {
+		return fmt.Errorf(
+			"%w: timestamp (%s) < continuous staking time (%s)",
+			errContinuousStakingNotActivated,
+			currentTimestamp,
+			e.Config.ContinuousStakingTime,
+		)
+	}
+
+	if tx.Validator.NodeID == ids.EmptyNodeID {
+		return errEmptyNodeID
+	}
+
+	if _, err := verifyAddValidatorTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		&tx.AddValidatorTx,
+	); err != nil {
+		return err
+	}
+
+	// The stake is locked until the last period ends, so all the periods
+	// together are bound by the maximum staking duration. A period is at most
+	// [MaxStakeDuration] long, so this can't overflow.
+	totalDuration := tx.Validator.Duration() * time.Duration(tx.NumPeriods())
+	if totalDuration > e.Config.MaxStakeDuration {
+		return fmt.Errorf(
+			"%w: %d periods of %s > %s",
+			errContinuousStakeTooLong,
+			tx.NumPeriods(),
+			tx.Validator.Duration(),
+			e.Config.MaxStakeDuration,
+		)
+	}
+
+	txID := e.Tx.ID()
+
+	newStaker := state.NewPendingStaker(txID, tx)
+	e.State.PutPendingValidator(newStaker)
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
+
+func (e *StandardTxExecutor) AddDelegatorsTx(tx *txs.AddDelegatorsTx) error {
+	currentTimestamp := e.State.GetTimestamp()
+	if !e.Config.IsBatchDelegationActivated(currentTimestamp) {
+		return fmt.Errorf(
+			"%w: timestamp (%s) < batch delegation time (%s)",
+			errBatchDelegationNotActivated,
+			currentTimestamp,
+			e.Config.BatchDelegationTime,
+		)
+	}
+
+	delegators, err := verifyAddDelegatorsTx(
+		e.Backend,
+		e.State,
+		e.Tx,
+		tx,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Each delegation is added as a delegator of its own, so that it is
+	// rewarded and refunded like any other delegator.
+	for _, delegator := range delegators {
+		e.State.PutPendingDelegator(delegator)
+	}
+
+	txID := e.Tx.ID()
+	utxo.Consume(e.State, tx.Ins)
+	utxo.Produce(e.State, txID, tx.Outs)
+
+	return nil
+}
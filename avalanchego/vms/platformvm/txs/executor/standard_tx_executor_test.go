@@ -99,6 +99,271 @@ func TestStandardTxExecutorAddValidatorTxEmptyID(t *testing.T) {
 	}
 }
 
+func TestStandardTxExecutorAddContinuousValidatorTx(t *testing.T) {
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownEnvironment(env))
+	}()
+
+	chainTime := env.state.GetTimestamp()
+	startTime := defaultGenesisTime.Add(1 * time.Second)
+	env.config.BanffTime = chainTime
+
+	tests := []struct {
+		continuousStakingTime time.Time
+		expectedError         error
+	}{
+		{ // Case: Before continuous staking
+			continuousStakingTime: chainTime.Add(1),
+			expectedError:         errContinuousStakingNotActivated,
+		},
+		{ // Case: At continuous staking
+			continuousStakingTime: chainTime,
+			expectedError:         errEmptyNodeID,
+		},
+		{ // Case: After continuous staking
+			continuousStakingTime: chainTime.Add(-1),
+			expectedError:         errEmptyNodeID,
+		},
+	}
+	for _, test := range tests {
+		// Case: Empty validator node ID after continuous staking
+		env.config.ContinuousStakingTime = test.continuousStakingTime
+
+		addValidatorTx, err := env.txBuilder.NewAddValidatorTx( // create the tx
+			env.config.MinValidatorStake,
+			uint64(startTime.Unix()),
+			uint64(defaultValidateEndTime.Unix()),
+			ids.EmptyNodeID,
+			ids.GenerateTestShortID(),
+			reward.PercentDenominator,
+			[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+			ids.ShortEmpty, // change addr
+		)
+		require.NoError(t, err)
+
+		tx, err := txs.NewSigned(
+			&txs.AddContinuousValidatorTx{
+				AddValidatorTx: *addValidatorTx.Unsigned.(*txs.AddValidatorTx),
+				RestakePeriods: 1,
+			},
+			txs.Codec,
+			[][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}},
+		)
+		require.NoError(t, err)
+
+		stateDiff, err := state.NewDiff(lastAcceptedID, env)
+		require.NoError(t, err)
+
+		executor := StandardTxExecutor{
+			Backend: &env.backend,
+			State:   stateDiff,
+			Tx:      tx,
+		}
+		err = tx.Unsigned.Visit(&executor)
+		require.ErrorIs(t, err, test.expectedError)
+	}
+}
+
+func TestStandardTxExecutorAddContinuousValidatorTxTooLong(t *testing.T) {
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownEnvironment(env))
+	}()
+
+	chainTime := env.state.GetTimestamp()
+	env.config.BanffTime = chainTime
+	env.config.ContinuousStakingTime = chainTime
+
+	startTime := chainTime.Add(time.Second)
+
+	// Both cases are restaked once, so the stake is locked for two periods
+	tests := []struct {
+		name          string
+		period        time.Duration
+		expectedError error
+	}{
+		{
+			name:          "periods as long as max stake duration",
+			period:        env.config.MaxStakeDuration / 2,
+			expectedError: nil,
+		},
+		{
+			name:          "periods longer than max stake duration",
+			period:        env.config.MaxStakeDuration/2 + time.Second,
+			expectedError: errContinuousStakeTooLong,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+
+			addValidatorTx, err := env.txBuilder.NewAddValidatorTx(
+				env.config.MinValidatorStake,
+				uint64(startTime.Unix()),
+				uint64(startTime.Add(test.period).Unix()),
+				ids.GenerateTestNodeID(),
+				ids.GenerateTestShortID(),
+				reward.PercentDenominator,
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				ids.ShortEmpty, // change addr
+			)
+			require.NoError(err)
+
+			tx, err := txs.NewSigned(
+				&txs.AddContinuousValidatorTx{
+					AddValidatorTx: *addValidatorTx.Unsigned.(*txs.AddValidatorTx),
+					RestakePeriods: 1,
+				},
+				txs.Codec,
+				[][]*crypto.PrivateKeySECP256K1R{{preFundedKeys[0]}},
+			)
+			require.NoError(err)
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedError)
+		})
+	}
+}
+
+func TestStandardTxExecutorAddDelegatorsTx(t *testing.T) {
+	env := newEnvironment()
+	env.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(t, shutdownEnvironment(env))
+	}()
+
+	chainTime := env.state.GetTimestamp()
+	env.config.BanffTime = chainTime
+
+	rewardAddress := preFundedKeys[0].PublicKey().Address()
+	genesisValidatorID := ids.NodeID(rewardAddress)
+	validatorStartTime := uint64(defaultValidateStartTime.Add(5 * time.Second).Unix())
+	validatorEndTime := uint64(defaultValidateEndTime.Add(-5 * time.Second).Unix())
+	delegationStartTime := uint64(defaultValidateStartTime.Add(10 * time.Second).Unix())
+	delegationEndTime := delegationStartTime + uint64(defaultMinStakingDuration/time.Second)
+
+	// Add two validators that can be delegated to
+	validatorIDs := []ids.NodeID{ids.GenerateTestNodeID(), ids.GenerateTestNodeID()}
+	for _, nodeID := range validatorIDs {
+		tx, err := env.txBuilder.NewAddValidatorTx(
+			env.config.MaxValidatorStake,
+			validatorStartTime,
+			validatorEndTime,
+			nodeID,
+			rewardAddress,
+			reward.PercentDenominator,
+			[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+			ids.ShortEmpty,
+		)
+		require.NoError(t, err)
+
+		staker := state.NewCurrentStaker(
+			tx.ID(),
+			tx.Unsigned.(*txs.AddValidatorTx),
+			0,
+		)
+		env.state.PutCurrentValidator(staker)
+		env.state.AddTx(tx, status.Committed)
+	}
+	env.state.SetHeight(1)
+	require.NoError(t, env.state.Commit())
+
+	tests := []struct {
+		name                string
+		batchDelegationTime time.Time
+		delegatedTo         []ids.NodeID
+		expectedError       error
+	}{
+		{
+			name:                "before batch delegation",
+			batchDelegationTime: chainTime.Add(1),
+			delegatedTo:         validatorIDs,
+			expectedError:       errBatchDelegationNotActivated,
+		},
+		{
+			name:                "over delegated",
+			batchDelegationTime: chainTime,
+			delegatedTo:         []ids.NodeID{validatorIDs[0], genesisValidatorID},
+			expectedError:       errOverDelegated,
+		},
+		{
+			name:                "valid",
+			batchDelegationTime: chainTime,
+			delegatedTo:         validatorIDs,
+			expectedError:       nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require := require.New(t)
+			env.config.BatchDelegationTime = test.batchDelegationTime
+
+			delegations := make([]*txs.Delegation, len(test.delegatedTo))
+			for i, nodeID := range test.delegatedTo {
+				delegations[i] = &txs.Delegation{
+					NodeID: nodeID,
+					Wght:   env.config.MinDelegatorStake,
+				}
+			}
+			tx, err := env.txBuilder.NewAddDelegatorsTx(
+				delegationStartTime,
+				delegationEndTime,
+				delegations,
+				rewardAddress,
+				[]*crypto.PrivateKeySECP256K1R{preFundedKeys[0]},
+				ids.ShortEmpty,
+			)
+			require.NoError(err)
+
+			stateDiff, err := state.NewDiff(lastAcceptedID, env)
+			require.NoError(err)
+
+			executor := StandardTxExecutor{
+				Backend: &env.backend,
+				State:   stateDiff,
+				Tx:      tx,
+			}
+			err = tx.Unsigned.Visit(&executor)
+			require.ErrorIs(err, test.expectedError)
+			if test.expectedError != nil {
+				return
+			}
+
+			// Each delegation is a pending delegator of its own, which isn't
+			// added by a tx of its own
+			txID := tx.ID()
+			utx := tx.Unsigned.(*txs.AddDelegatorsTx)
+			for i, delegation := range utx.Delegations {
+				delegationID := txs.DelegationID(txID, i)
+				_, _, err := stateDiff.GetTx(delegationID)
+				require.ErrorIs(err, database.ErrNotFound)
+
+				delegatorIterator, err := stateDiff.GetPendingDelegatorIterator(constants.PrimaryNetworkID, delegation.NodeID)
+				require.NoError(err)
+				require.True(delegatorIterator.Next())
+				delegator := delegatorIterator.Value()
+				require.Equal(delegationID, delegator.TxID)
+				require.Equal(txID, delegator.BatchTxID)
+				require.Equal(uint32(i), delegator.BatchIndex)
+				require.Equal(delegation.Wght, delegator.Weight)
+				require.False(delegatorIterator.Next())
+				delegatorIterator.Release()
+			}
+		})
+	}
+}
+
 func TestStandardTxExecutorAddDelegator(t *testing.T) {
 	dummyHeight := uint64(1)
 	rewardAddress := preFundedKeys[0].PublicKey().Address()
@@ -64,6 +64,7 @@ type StateChanges interface {
 
 type stateChanges struct {
 	updatedSupplies           map[ids.ID]uint64
+	rewardContexts            map[ids.ID]*reward.Context
 	currentValidatorsToAdd    []*state.Staker
 	currentDelegatorsToAdd    []*state.Staker
 	pendingValidatorsToRemove []*state.Staker
@@ -75,6 +76,9 @@ func (s *stateChanges) Apply(stateDiff state.Diff) {
 	for subnetID, supply := range s.updatedSupplies {
 		stateDiff.SetCurrentSupply(subnetID, supply)
 	}
+	for txID, rewardContext := range s.rewardContexts {
+		stateDiff.SetRewardContext(txID, rewardContext)
+	}
 
 	for _, currentValidatorToAdd := range s.currentValidatorsToAdd {
 		stateDiff.PutCurrentValidator(currentValidatorToAdd)
@@ -115,6 +119,7 @@ func AdvanceTimeTo(
 
 	changes := &stateChanges{
 		updatedSupplies: make(map[ids.ID]uint64),
+		rewardContexts:  make(map[ids.ID]*reward.Context),
 	}
 
 	// Add to the staker set any pending stakers whose start time is at or
@@ -153,13 +158,26 @@ func AdvanceTimeTo(
 		if err != nil {
 			return nil, err
 		}
+		rewardConfig, err := GetRewardsConfig(backend, parentState, stakerToRemove.SubnetID)
+		if err != nil {
+			return nil, err
+		}
 
+		stakedDuration := stakerToRemove.EndTime.Sub(stakerToRemove.StartTime)
 		potentialReward := rewards.Calculate(
-			stakerToRemove.EndTime.Sub(stakerToRemove.StartTime),
+			stakedDuration,
 			stakerToRemove.Weight,
 			supply,
 		)
 		stakerToAdd.PotentialReward = potentialReward
+		changes.rewardContexts[stakerToRemove.TxID] = &reward.Context{
+			SubnetID:        stakerToRemove.SubnetID,
+			StakedAmount:    stakerToRemove.Weight,
+			StakedDuration:  stakedDuration,
+			CurrentSupply:   supply,
+			Config:          rewardConfig,
+			PotentialReward: potentialReward,
+		}
 
 		// Invariant: [rewards.Calculate] can never return a [potentialReward]
 		//            such that [supply + potentialReward > maximumSupply].
@@ -213,19 +231,37 @@ func GetRewardsCalculator(
 		return backend.Rewards, nil
 	}
 
-	transformSubnetIntf, err := parentState.GetSubnetTransformation(subnetID)
+	rewardConfig, err := GetRewardsConfig(backend, parentState, subnetID)
 	if err != nil {
 		return nil, err
 	}
+	return reward.NewCalculator(rewardConfig), nil
+}
+
+// GetRewardsConfig returns the constants rewards of stakers of [subnetID] are
+// calculated with.
+func GetRewardsConfig(
+	backend *Backend,
+	parentState state.Chain,
+	subnetID ids.ID,
+) (reward.Config, error) {
+	if subnetID == constants.PrimaryNetworkID {
+		return backend.Config.RewardConfig, nil
+	}
+
+	transformSubnetIntf, err := parentState.GetSubnetTransformation(subnetID)
+	if err != nil {
+		return reward.Config{}, err
+	}
 	transformSubnet, ok := transformSubnetIntf.Unsigned.(*txs.TransformSubnetTx)
 	if !ok {
-		return nil, errIsNotTransformSubnetTx
+		return reward.Config{}, errIsNotTransformSubnetTx
 	}
 
-	return reward.NewCalculator(reward.Config{
+	return reward.Config{
 		MaxConsumptionRate: transformSubnet.MaxConsumptionRate,
 		MinConsumptionRate: transformSubnet.MinConsumptionRate,
 		MintingPeriod:      backend.Config.RewardConfig.MintingPeriod,
 		SupplyCap:          transformSubnet.MaximumSupply,
-	}), nil
+	}, nil
 }
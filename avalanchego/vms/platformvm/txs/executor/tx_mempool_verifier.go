@@ -67,6 +67,18 @@ func (v *MempoolTxVerifier) AddPermissionlessDelegatorTx(tx *txs.AddPermissionle
 	return v.standardTx(tx)
 }
 
+func (v *MempoolTxVerifier) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	return v.standardTx(tx)
+}
+
+func (*MempoolTxVerifier) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errWrongTxType
+}
+
+func (v *MempoolTxVerifier) AddDelegatorsTx(tx *txs.AddDelegatorsTx) error {
+	return v.standardTx(tx)
+}
+
 // TODO: simplify this function after Banff is activated.
 func (v *MempoolTxVerifier) proposalTx(tx txs.StakerTx) error {
 	startTime := tx.StartTime()
@@ -83,3 +83,17 @@ func (i *issuer) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegator
 	i.m.addStakerTx(i.tx)
 	return nil
 }
+
+func (i *issuer) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	i.m.addStakerTx(i.tx)
+	return nil
+}
+
+func (i *issuer) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errCantIssueRewardValidatorTx
+}
+
+func (i *issuer) AddDelegatorsTx(tx *txs.AddDelegatorsTx) error {
+	i.m.addDecisionTx(i.tx)
+	return nil
+}
@@ -67,6 +67,16 @@ func (r *remover) AddPermissionlessDelegatorTx(tx *txs.AddPermissionlessDelegato
 	return nil
 }
 
+func (r *remover) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	r.m.removeStakerTx(r.tx)
+	return nil
+}
+
+func (r *remover) AddDelegatorsTx(tx *txs.AddDelegatorsTx) error {
+	r.m.removeDecisionTxs([]*txs.Tx{r.tx})
+	return nil
+}
+
 func (r *remover) AdvanceTimeTx(*txs.AdvanceTimeTx) error {
 	// this tx is never in mempool
 	return nil
@@ -76,3 +86,8 @@ func (r *remover) RewardValidatorTx(*txs.RewardValidatorTx) error {
 	// this tx is never in mempool
 	return nil
 }
+
+func (r *remover) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	// this tx is never in mempool
+	return nil
+}
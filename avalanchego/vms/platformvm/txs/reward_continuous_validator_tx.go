@@ -0,0 +1,22 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package txs
+
+var _ UnsignedTx = &RewardContinuousValidatorTx{}
+
+// RewardContinuousValidatorTx is a transaction that represents a proposal to
+// reward a staking period of a continuous validator. The validator is restaked
+// for its next period, or removed if this was its last period.
+//
+// Each period is rewarded by a tx with a different [Period], so that the txs
+// rewarding the periods of a validator have distinct IDs.
+type RewardContinuousValidatorTx struct {
+	RewardValidatorTx `serialize:"true"`
+	// Index of the staking period being rewarded. The first period is 0.
+	Period uint32 `serialize:"true" json:"period"`
+}
+
+func (tx *RewardContinuousValidatorTx) Visit(visitor Visitor) error {
+	return visitor.RewardContinuousValidatorTx(tx)
+}
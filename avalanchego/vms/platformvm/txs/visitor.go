@@ -18,4 +18,7 @@ type Visitor interface {
 	TransformSubnetTx(*TransformSubnetTx) error
 	AddPermissionlessValidatorTx(*AddPermissionlessValidatorTx) error
 	AddPermissionlessDelegatorTx(*AddPermissionlessDelegatorTx) error
+	AddContinuousValidatorTx(*AddContinuousValidatorTx) error
+	RewardContinuousValidatorTx(*RewardContinuousValidatorTx) error
+	AddDelegatorsTx(*AddDelegatorsTx) error
 }
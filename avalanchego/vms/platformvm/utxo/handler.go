@@ -86,6 +86,20 @@ type Spender interface {
 		error,
 	)
 
+	// SpendFromAddrs is Spend, but the funds are owned by [addrs] and no
+	// signers are returned. The inputs must be signed externally.
+	SpendFromAddrs(
+		addrs ids.ShortSet,
+		amount uint64,
+		fee uint64,
+		changeAddr ids.ShortID,
+	) (
+		[]*avax.TransferableInput, // inputs
+		[]*avax.TransferableOutput, // returnedOutputs
+		[]*avax.TransferableOutput, // stakedOutputs
+		error,
+	)
+
 	// Authorize an operation on behalf of the named subnet with the provided
 	// keys.
 	Authorize(
@@ -99,6 +113,12 @@ type Spender interface {
 	)
 }
 
+// keychain creates inputs consuming outputs along with the keys that must sign
+// them
+type keychain interface {
+	Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error)
+}
+
 type Verifier interface {
 	// Verify that [tx] is semantically valid.
 	// [ins] and [outs] are the inputs and outputs of [tx].
@@ -182,13 +202,45 @@ func (h *handler) Spend(
 	for _, key := range keys {
 		addrs.Add(key.PublicKey().Address())
 	}
-	utxos, err := avax.GetAllUTXOs(h.utxosReader, addrs) // The UTXOs controlled by [keys]
+	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
+	return h.spend(addrs, kc, amount, fee, changeAddr)
+}
+
+func (h *handler) SpendFromAddrs(
+	addrs ids.ShortSet,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	error,
+) {
+	kc := secp256k1fx.NewWatchKeychain(addrs.List()...)
+	ins, returnedOuts, stakedOuts, _, err := h.spend(addrs, kc, amount, fee, changeAddr)
+	return ins, returnedOuts, stakedOuts, err
+}
+
+// spend consumes the UTXOs of [addrs] using [kc].
+func (h *handler) spend(
+	addrs ids.ShortSet,
+	kc keychain,
+	amount uint64,
+	fee uint64,
+	changeAddr ids.ShortID,
+) (
+	[]*avax.TransferableInput, // inputs
+	[]*avax.TransferableOutput, // returnedOutputs
+	[]*avax.TransferableOutput, // stakedOutputs
+	[][]*crypto.PrivateKeySECP256K1R, // signers
+	error,
+) {
+	utxos, err := avax.GetAllUTXOs(h.utxosReader, addrs) // The UTXOs controlled by [addrs]
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("couldn't get UTXOs: %w", err)
 	}
 
-	kc := secp256k1fx.NewKeychain(keys...) // Keychain consumes UTXOs and creates new ones
-
 	// Minimum time this transaction will be issued at
 	now := uint64(h.clk.Time().Unix())
 
@@ -0,0 +1,385 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"go.uber.org/zap"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/units"
+)
+
+const (
+	validatorStreamEndpoint = "/validators"
+
+	// Query parameters of a stream request
+	subnetIDParam    = "subnetID"
+	startHeightParam = "startHeight"
+
+	// Size of the ws read buffer
+	validatorStreamReadBufferSize = units.KiB
+
+	// Size of the ws write buffer
+	validatorStreamWriteBufferSize = units.KiB
+
+	// Time allowed to write a message to the subscriber.
+	validatorStreamWriteWait = 10 * time.Second
+
+	// Time allowed to read the next pong message from the subscriber.
+	validatorStreamPongWait = 60 * time.Second
+
+	// Send pings to the subscriber with this period. Must be less than
+	// [validatorStreamPongWait].
+	validatorStreamPingPeriod = (validatorStreamPongWait * 9) / 10
+
+	// Maximum size of a message read from a subscriber. Subscribers aren't
+	// expected to send anything other than control messages.
+	validatorStreamMaxMessageSize = units.KiB
+)
+
+var (
+	validatorStreamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  validatorStreamReadBufferSize,
+		WriteBufferSize: validatorStreamWriteBufferSize,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
+
+	_ validators.SetCallbackListener = &validatorStreamListener{}
+)
+
+// validatorStream pushes the changes of the validator set of a subnet to its
+// websocket subscribers, as they are accepted.
+//
+// Each message is a [GetValidatorSetDiffReply] holding the validators whose
+// weight changed between the last height sent to the subscriber and the
+// current height. Messages are only sent when a weight changed, so the heights
+// of consecutive messages are contiguous. A subscriber that reconnects with the
+// end height of the last message it received as [startHeightParam] doesn't
+// miss any change.
+type validatorStream struct {
+	vm *VM
+
+	lock        sync.Mutex
+	closed      bool
+	subscribers map[*validatorSubscriber]struct{}
+
+	// Subnets whose validator sets are listened to. Listeners can't be
+	// removed, so each subnet is only listened to once.
+	listeningLock sync.Mutex
+	listening     ids.Set
+}
+
+type validatorSubscriber struct {
+	s        *validatorStream
+	conn     *websocket.Conn
+	subnetID ids.ID
+
+	// Signaled when the validator set of [subnetID] changes. Closed when the
+	// subscriber is removed from the stream.
+	notify chan struct{}
+
+	// Height that the next diff starts at. Only accessed by the write pump.
+	height uint64
+}
+
+func newValidatorStream(vm *VM) *validatorStream {
+	return &validatorStream{
+		vm:          vm,
+		subscribers: make(map[*validatorSubscriber]struct{}),
+		listening:   ids.Set{},
+	}
+}
+
+// ServeHTTP upgrades the request to a websocket connection that the changes
+// of the validator set of [subnetIDParam], which defaults to the primary
+// network, are pushed to. The changes are sent from [startHeightParam] if it's
+// set, or else from the last accepted height.
+func (s *validatorStream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	subnetID := constants.PrimaryNetworkID
+	if subnetIDStr := query.Get(subnetIDParam); subnetIDStr != "" {
+		var err error
+		subnetID, err = ids.FromString(subnetIDStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", subnetIDParam, subnetIDStr, err), http.StatusBadRequest)
+			return
+		}
+	}
+	vdrs, ok := s.vm.Validators.GetValidators(subnetID)
+	if !ok {
+		http.Error(w, fmt.Sprintf("validator set of subnet %s isn't tracked", subnetID), http.StatusNotFound)
+		return
+	}
+
+	s.vm.ctx.Lock.Lock()
+	currentHeight, err := s.vm.GetCurrentHeight()
+	s.vm.ctx.Lock.Unlock()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("couldn't get current height: %s", err), http.StatusInternalServerError)
+		return
+	}
+
+	startHeight := currentHeight
+	if startHeightStr := query.Get(startHeightParam); startHeightStr != "" {
+		startHeight, err = strconv.ParseUint(startHeightStr, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid %s %q: %s", startHeightParam, startHeightStr, err), http.StatusBadRequest)
+			return
+		}
+		if startHeight > currentHeight {
+			http.Error(w, fmt.Sprintf("%s (%d) > current height (%d)", startHeightParam, startHeight, currentHeight), http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.listen(subnetID, vdrs)
+
+	conn, err := validatorStreamUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.vm.ctx.Log.Debug("failed to upgrade",
+			zap.Error(err),
+		)
+		return
+	}
+
+	sub := &validatorSubscriber{
+		s:        s,
+		conn:     conn,
+		subnetID: subnetID,
+		notify:   make(chan struct{}, 1),
+		height:   startHeight,
+	}
+	// Send the changes since [startHeight], if any.
+	sub.notify <- struct{}{}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		_ = conn.Close()
+		return
+	}
+	s.subscribers[sub] = struct{}{}
+	go sub.writePump()
+	go sub.readPump()
+}
+
+// listen registers a listener to the changes of [vdrs], the validator set of
+// [subnetID], if there isn't one already.
+func (s *validatorStream) listen(subnetID ids.ID, vdrs validators.Set) {
+	s.listeningLock.Lock()
+	defer s.listeningLock.Unlock()
+
+	if s.listening.Contains(subnetID) {
+		return
+	}
+	s.listening.Add(subnetID)
+	vdrs.RegisterCallbackListener(&validatorStreamListener{
+		s:        s,
+		subnetID: subnetID,
+	})
+}
+
+// publish notifies the subscribers to [subnetID] that its validator set
+// changed. publish never blocks on a subscriber.
+func (s *validatorStream) publish(subnetID ids.ID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for sub := range s.subscribers {
+		if sub.subnetID != subnetID {
+			continue
+		}
+		select {
+		case sub.notify <- struct{}{}:
+		default:
+			// The subscriber was already notified.
+		}
+	}
+}
+
+// Close disconnects all subscribers.
+func (s *validatorStream) Close() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.closed = true
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+func (s *validatorStream) isClosed() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.closed
+}
+
+// removeSubscriber removes [sub] from the stream if it hasn't been already.
+func (s *validatorStream) removeSubscriber(sub *validatorSubscriber) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.remove(sub)
+}
+
+// remove closes [sub.notify], which causes the write pump to close the
+// connection.
+// Assumes [s.lock] is held.
+func (s *validatorStream) remove(sub *validatorSubscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	close(sub.notify)
+}
+
+// validatorStreamListener notifies the stream of the changes of the validator
+// set of [subnetID]. The changes are made while the block that caused them is
+// accepted, so the subscribers read them once the block is committed.
+type validatorStreamListener struct {
+	s        *validatorStream
+	subnetID ids.ID
+}
+
+func (l *validatorStreamListener) OnValidatorAdded(ids.NodeID, uint64) {
+	l.s.publish(l.subnetID)
+}
+
+func (l *validatorStreamListener) OnValidatorRemoved(ids.NodeID, uint64) {
+	l.s.publish(l.subnetID)
+}
+
+func (l *validatorStreamListener) OnValidatorWeightChanged(ids.NodeID, uint64, uint64) {
+	l.s.publish(l.subnetID)
+}
+
+// diff returns the changes of the validator set since [sub.height], or nil if
+// the stream was closed.
+func (sub *validatorSubscriber) diff() (*GetValidatorSetDiffReply, error) {
+	vm := sub.s.vm
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	// The VM's state can't be read once it's shut down.
+	if sub.s.isClosed() {
+		return nil, nil
+	}
+
+	currentHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := vm.GetValidatorSetDiff(sub.height, currentHeight, sub.subnetID)
+	if err != nil {
+		return nil, err
+	}
+	return &GetValidatorSetDiffReply{
+		StartHeight: json.Uint64(sub.height),
+		EndHeight:   json.Uint64(currentHeight),
+		Changes:     changes,
+	}, nil
+}
+
+// readPump processes the control messages sent by the subscriber until the
+// connection is closed. Other messages are discarded.
+func (sub *validatorSubscriber) readPump() {
+	defer func() {
+		sub.s.removeSubscriber(sub)
+		_ = sub.conn.Close()
+	}()
+
+	sub.conn.SetReadLimit(validatorStreamMaxMessageSize)
+	// SetReadDeadline returns an error if the connection is corrupted
+	if err := sub.conn.SetReadDeadline(time.Now().Add(validatorStreamPongWait)); err != nil {
+		return
+	}
+	sub.conn.SetPongHandler(func(string) error {
+		return sub.conn.SetReadDeadline(time.Now().Add(validatorStreamPongWait))
+	})
+
+	for {
+		if _, _, err := sub.conn.ReadMessage(); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
+				sub.s.vm.ctx.Log.Debug("unexpected close in websockets",
+					zap.Error(err),
+				)
+			}
+			return
+		}
+	}
+}
+
+// writePump sends the changes of the validator set to the subscriber when
+// notified, and pings it periodically, until the subscriber is removed or the
+// connection fails.
+func (sub *validatorSubscriber) writePump() {
+	ticker := time.NewTicker(validatorStreamPingPeriod)
+	defer func() {
+		ticker.Stop()
+		sub.s.removeSubscriber(sub)
+		_ = sub.conn.Close()
+	}()
+
+	for {
+		select {
+		case _, ok := <-sub.notify:
+			if !ok {
+				// The subscriber was removed.
+				sub.closeGracefully()
+				return
+			}
+
+			diff, err := sub.diff()
+			if err != nil {
+				sub.s.vm.ctx.Log.Debug("dropping subscriber",
+					zap.String("reason", "failed to get validator set diff"),
+					zap.Error(err),
+				)
+				return
+			}
+			if diff == nil {
+				sub.closeGracefully()
+				return
+			}
+			if len(diff.Changes) == 0 {
+				continue
+			}
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(validatorStreamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteJSON(diff); err != nil {
+				return
+			}
+			sub.height = uint64(diff.EndHeight)
+		case <-ticker.C:
+			if err := sub.conn.SetWriteDeadline(time.Now().Add(validatorStreamWriteWait)); err != nil {
+				return
+			}
+			if err := sub.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// closeGracefully attempts to close the connection with a close message.
+func (sub *validatorSubscriber) closeGracefully() {
+	if err := sub.conn.SetWriteDeadline(time.Now().Add(validatorStreamWriteWait)); err != nil {
+		return
+	}
+	_ = sub.conn.WriteMessage(websocket.CloseMessage, []byte{})
+}
@@ -0,0 +1,120 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package platformvm
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+
+	txexecutor "github.com/ava-labs/avalanchego/vms/platformvm/txs/executor"
+)
+
+// subscribeValidators connects to [vm]'s validator stream and waits until the
+// subscription is registered. Assumes [vm.ctx.Lock] isn't held.
+func subscribeValidators(t *testing.T, vm *VM, query string) *websocket.Conn {
+	srv := httptest.NewServer(vm.validatorStream)
+	t.Cleanup(srv.Close)
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "?" + query
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	require.Eventually(t, func() bool {
+		vm.validatorStream.lock.Lock()
+		defer vm.validatorStream.lock.Unlock()
+		return len(vm.validatorStream.subscribers) == 1
+	}, time.Second, 10*time.Millisecond)
+	return conn
+}
+
+func TestValidatorStream(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Changes accepted before subscribing are sent from the start height.
+	nodeID0 := ids.GenerateTestNodeID()
+	startTime0 := defaultGenesisTime.Add(txexecutor.SyncBound).Add(time.Second)
+	vm.ctx.Lock.Lock()
+	addPrimaryValidator(require, vm, nodeID0, startTime0)
+	vm.ctx.Lock.Unlock()
+
+	conn := subscribeValidators(t, vm, "startHeight=1")
+
+	var diff GetValidatorSetDiffReply
+	require.NoError(conn.ReadJSON(&diff))
+	require.EqualValues(1, diff.StartHeight)
+	require.EqualValues(5, diff.EndHeight)
+	require.Equal([]ValidatorWeightChange{{
+		NodeID:         nodeID0,
+		PreviousWeight: 0,
+		Weight:         json.Uint64(vm.MinValidatorStake),
+	}}, diff.Changes)
+
+	// Changes accepted after subscribing are sent once they're accepted.
+	nodeID1 := ids.GenerateTestNodeID()
+	startTime1 := startTime0.Add(txexecutor.SyncBound).Add(time.Second)
+	vm.ctx.Lock.Lock()
+	addPrimaryValidator(require, vm, nodeID1, startTime1)
+	vm.ctx.Lock.Unlock()
+
+	diff = GetValidatorSetDiffReply{}
+	require.NoError(conn.ReadJSON(&diff))
+	require.EqualValues(5, diff.StartHeight)
+	require.EqualValues(9, diff.EndHeight)
+	require.Equal([]ValidatorWeightChange{{
+		NodeID:         nodeID1,
+		PreviousWeight: 0,
+		Weight:         json.Uint64(vm.MinValidatorStake),
+	}}, diff.Changes)
+
+	// Subscribers are disconnected on shutdown.
+	vm.validatorStream.Close()
+	_, _, err := conn.ReadMessage()
+	require.True(websocket.IsCloseError(err, websocket.CloseNoStatusReceived))
+}
+
+func TestValidatorStreamInvalidRequest(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	defer func() {
+		vm.ctx.Lock.Lock()
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	srv := httptest.NewServer(vm.validatorStream)
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	tests := map[string]int{
+		"subnetID=invalid":                          http.StatusBadRequest,
+		"subnetID=" + ids.GenerateTestID().String(): http.StatusNotFound,
+		"startHeight=invalid":                       http.StatusBadRequest,
+		"startHeight=2":                             http.StatusBadRequest,
+	}
+	for query, expectedStatus := range tests {
+		_, resp, err := websocket.DefaultDialer.Dial(url+"?"+query, nil)
+		require.ErrorIs(err, websocket.ErrBadHandshake, query)
+		require.Equal(expectedStatus, resp.StatusCode, query)
+		_ = resp.Body.Close()
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/validators"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/math"
@@ -45,6 +46,7 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs/mempool"
 	"github.com/ava-labs/avalanchego/vms/platformvm/utxo"
+	"github.com/ava-labs/avalanchego/vms/platformvm/warp"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	blockbuilder "github.com/ava-labs/avalanchego/vms/platformvm/blocks/builder"
@@ -60,14 +62,24 @@ const (
 )
 
 var (
-	_ block.ChainVM    = &VM{}
-	_ secp256k1fx.VM   = &VM{}
-	_ validators.State = &VM{}
+	_ block.ChainVM       = &VM{}
+	_ secp256k1fx.VM      = &VM{}
+	_ validators.State    = &VM{}
+	_ warp.ValidatorState = &VM{}
 
 	errWrongCacheType      = errors.New("unexpectedly cached type")
 	errMissingValidatorSet = errors.New("missing validator set")
+	errInvalidHeightRange  = errors.New("start height is after end height")
 )
 
+// ValidatorWeightChange is the change of the weight of a validator between two
+// heights. A weight of 0 means that the node wasn't a validator.
+type ValidatorWeightChange struct {
+	NodeID         ids.NodeID  `json:"nodeID"`
+	PreviousWeight json.Uint64 `json:"previousWeight"`
+	Weight         json.Uint64 `json:"weight"`
+}
+
 type VM struct {
 	Factory
 	blockbuilder.Builder
@@ -103,6 +115,9 @@ type VM struct {
 	txBuilder         txbuilder.Builder
 	txExecutorBackend *txexecutor.Backend
 	manager           blockexecutor.Manager
+
+	// Pushes validator set changes to websocket subscribers
+	validatorStream *validatorStream
 }
 
 // Initialize this blockchain.
@@ -124,6 +139,12 @@ func (vm *VM) Initialize(
 		return err
 	}
 
+	// The whitelisted subnets can grow at runtime, so the VM keeps its own copy
+	// rather than sharing the node's.
+	whitelistedSubnets := ids.NewSet(vm.WhitelistedSubnets.Len())
+	whitelistedSubnets.Union(vm.WhitelistedSubnets)
+	vm.WhitelistedSubnets = whitelistedSubnets
+
 	// Initialize metrics as soon as possible
 	var err error
 	vm.metrics, err = metrics.New("", registerer, vm.WhitelistedSubnets)
@@ -132,6 +153,9 @@ func (vm *VM) Initialize(
 	}
 
 	vm.ctx = ctx
+	// The stream is closed on shutdown, which is possible once [vm.dbManager]
+	// is set.
+	vm.validatorStream = newValidatorStream(vm)
 	vm.dbManager = dbManager
 
 	vm.codecRegistry = linearcodec.NewDefault()
@@ -223,6 +247,7 @@ func (vm *VM) Initialize(
 			err,
 		)
 	}
+	vm.Chains.AddSubnetRegistrant(vm)
 
 	lastAcceptedID := vm.state.GetLastAccepted()
 	ctx.Log.Info("initializing last accepted",
@@ -273,6 +298,27 @@ func (vm *VM) createSubnet(subnetID ids.ID) error {
 	return nil
 }
 
+// RegisterSubnet starts validating [subnetID] after the VM was initialized. It
+// populates the subnet's validator set and creates the subnet's chains.
+func (vm *VM) RegisterSubnet(subnetID ids.ID) error {
+	vm.ctx.Lock.Lock()
+	defer vm.ctx.Lock.Unlock()
+
+	if vm.WhitelistedSubnets.Contains(subnetID) {
+		return nil
+	}
+	vm.WhitelistedSubnets.Add(subnetID)
+
+	subnetValidators, err := vm.state.ValidatorSet(subnetID)
+	if err != nil {
+		return err
+	}
+	if err := vm.Validators.Set(subnetID, subnetValidators); err != nil {
+		return err
+	}
+	return vm.createSubnet(subnetID)
+}
+
 // onBootstrapStarted marks this VM as bootstrapping
 func (vm *VM) onBootstrapStarted() error {
 	vm.bootstrapped.SetValue(false)
@@ -331,6 +377,7 @@ func (vm *VM) Shutdown() error {
 	}
 
 	vm.Builder.Shutdown()
+	vm.validatorStream.Close()
 
 	if vm.bootstrapped.GetValue() {
 		primaryValidatorSet, exist := vm.Validators.GetValidators(constants.PrimaryNetworkID)
@@ -412,6 +459,10 @@ func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		"": {
 			Handler: server,
 		},
+		validatorStreamEndpoint: {
+			LockOptions: common.NoLock,
+			Handler:     vm.validatorStream,
+		},
 	}, nil
 }
 
@@ -528,6 +579,116 @@ func (vm *VM) GetValidatorSet(height uint64, subnetID ids.ID) (map[ids.NodeID]ui
 	return vdrSet, nil
 }
 
+// GetValidatorSetDiff returns the validators of [subnetID] whose weight at
+// [endHeight] differs from their weight at [startHeight], sorted by node ID.
+func (vm *VM) GetValidatorSetDiff(startHeight, endHeight uint64, subnetID ids.ID) ([]ValidatorWeightChange, error) {
+	if startHeight > endHeight {
+		return nil, errInvalidHeightRange
+	}
+
+	endSet, err := vm.GetValidatorSet(endHeight, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Roll back the weights of the validators that changed after
+	// [startHeight].
+	startWeights := make(map[ids.NodeID]uint64)
+	for i := endHeight; i > startHeight; i-- {
+		diffs, err := vm.state.GetValidatorWeightDiffs(i, subnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		for nodeID, diff := range diffs {
+			weight, ok := startWeights[nodeID]
+			if !ok {
+				weight = endSet[nodeID]
+			}
+
+			var op func(uint64, uint64) (uint64, error)
+			if diff.Decrease {
+				op = math.Add64
+			} else {
+				op = math.Sub64
+			}
+			startWeights[nodeID], err = op(weight, diff.Amount)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	nodeIDs := make([]ids.NodeID, 0, len(startWeights))
+	for nodeID, startWeight := range startWeights {
+		if startWeight != endSet[nodeID] {
+			nodeIDs = append(nodeIDs, nodeID)
+		}
+	}
+	ids.SortNodeIDs(nodeIDs)
+
+	changes := make([]ValidatorWeightChange, len(nodeIDs))
+	for i, nodeID := range nodeIDs {
+		changes[i] = ValidatorWeightChange{
+			NodeID:         nodeID,
+			PreviousWeight: json.Uint64(startWeights[nodeID]),
+			Weight:         json.Uint64(endSet[nodeID]),
+		}
+	}
+	return changes, nil
+}
+
+// GetValidatorPublicKeys returns the BLS public keys of the validators of
+// [subnetID] at the specified height.
+//
+// A validator's key is the one registered by the AddPermissionlessValidatorTx
+// that added it to the primary network. Validators that never registered a
+// key are omitted.
+func (vm *VM) GetValidatorPublicKeys(height uint64, subnetID ids.ID) (map[ids.NodeID]*bls.PublicKey, error) {
+	vdrSet, err := vm.GetValidatorSet(height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastAcceptedHeight, err := vm.GetCurrentHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the keys of the current primary network validators.
+	pks := make(map[ids.NodeID]*bls.PublicKey, len(vdrSet))
+	for nodeID := range vdrSet {
+		staker, err := vm.state.GetCurrentValidator(constants.PrimaryNetworkID, nodeID)
+		if err == database.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		pks[nodeID] = staker.PublicKey
+	}
+
+	// Restore the keys of the validators that were removed after [height].
+	for i := lastAcceptedHeight; i > height; i-- {
+		pkDiffs, err := vm.state.GetValidatorPublicKeyDiffs(i)
+		if err != nil {
+			return nil, err
+		}
+		for nodeID, pk := range pkDiffs {
+			if _, ok := vdrSet[nodeID]; ok {
+				pks[nodeID] = pk
+			}
+		}
+	}
+
+	for nodeID, pk := range pks {
+		if pk == nil {
+			delete(pks, nodeID)
+		}
+	}
+	return pks, nil
+}
+
 // GetMinimumHeight returns the height of the most recent block beyond the
 // horizon of our recentlyAccepted window.
 //
@@ -32,11 +32,14 @@ import (
 	"github.com/ava-labs/avalanchego/snow/networking/handler"
 	"github.com/ava-labs/avalanchego/snow/networking/router"
 	"github.com/ava-labs/avalanchego/snow/networking/sender"
+	"github.com/ava-labs/avalanchego/snow/networking/syncserving"
 	"github.com/ava-labs/avalanchego/snow/networking/timeout"
 	"github.com/ava-labs/avalanchego/snow/uptime"
 	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/compression"
 	"github.com/ava-labs/avalanchego/utils/constants"
 	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
 	"github.com/ava-labs/avalanchego/utils/formatting"
 	"github.com/ava-labs/avalanchego/utils/formatting/address"
 	"github.com/ava-labs/avalanchego/utils/json"
@@ -53,8 +56,11 @@ import (
 	"github.com/ava-labs/avalanchego/vms/platformvm/blocks"
 	"github.com/ava-labs/avalanchego/vms/platformvm/config"
 	"github.com/ava-labs/avalanchego/vms/platformvm/reward"
+	"github.com/ava-labs/avalanchego/vms/platformvm/signer"
+	"github.com/ava-labs/avalanchego/vms/platformvm/state"
 	"github.com/ava-labs/avalanchego/vms/platformvm/status"
 	"github.com/ava-labs/avalanchego/vms/platformvm/txs"
+	"github.com/ava-labs/avalanchego/vms/platformvm/validator"
 	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
 
 	smcon "github.com/ava-labs/avalanchego/snow/consensus/snowman"
@@ -1980,6 +1986,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 					TimeoutHalflife:    5 * time.Minute,
 					TimeoutCoefficient: 1.25,
 				},
+				nil,
 				benchlist,
 				"",
 				prometheus.NewRegistry(),
@@ -1993,7 +2000,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			metrics := prometheus.NewRegistry()
 			mc, err := message.NewCreator(metrics, "dummyNamespace", true, 10*time.Second)
 			require.NoError(err)
-			mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", true, 10*time.Second)
+			mcProto, err := message.NewCreatorWithProto(metrics, "dummyNamespace", compression.TypeGzip, 10*time.Second)
 			require.NoError(err)
 
 			err = chainRouter.Initialize(ids.EmptyNodeID, logging.NoLog{}, mc, timeoutManager, time.Second, ids.Set{}, ids.Set{}, nil, router.HealthConfig{}, "", prometheus.NewRegistry())
@@ -2017,6 +2024,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 					AppGossipValidatorSize:    1,
 					AppGossipNonValidatorSize: 1,
 				},
+				0,
 			)
 			require.NoError(err)
 
@@ -2067,7 +2075,7 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 				SharedCfg:                      &common.SharedConfig{},
 			}
 
-			snowGetHandler, err := snowgetter.New(vm, commonCfg)
+			snowGetHandler, err := snowgetter.New(vm, commonCfg, syncserving.NoBudget{})
 			require.NoError(err)
 
 			bootstrapConfig := bootstrap.Config{
@@ -2080,6 +2088,8 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 			// Asynchronously passes messages from the network to the consensus engine
 			cpuTracker, err := timetracker.NewResourceTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
 			require.NoError(err)
+			chainTracker, err := timetracker.NewChainTracker(prometheus.NewRegistry(), resource.NoUsage, meter.ContinuousFactory{}, time.Second)
+			require.NoError(err)
 
 			handler, err := handler.New(
 				mc,
@@ -2089,6 +2099,8 @@ func TestBootstrapPartiallyAccepted(t *testing.T) {
 				nil,
 				time.Hour,
 				cpuTracker,
+				chainTracker,
+				handler.MessageQueueConfig{},
 			)
 			require.NoError(err)
 
@@ -2607,3 +2619,177 @@ func TestUptimeDisallowedAfterNeverConnecting(t *testing.T) {
 	)
 	require.ErrorIs(err, database.ErrNotFound)
 }
+
+func TestGetValidatorPublicKeys(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	height, err := vm.GetCurrentHeight()
+	require.NoError(err)
+	vdrSet, err := vm.GetValidatorSet(height, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.NotEmpty(vdrSet)
+
+	// The genesis validators didn't register BLS keys.
+	pks, err := vm.GetValidatorPublicKeys(height, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Empty(pks)
+
+	_, err = vm.GetValidatorPublicKeys(height+1, constants.PrimaryNetworkID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
+
+// addPrimaryValidator accepts the blocks that add [nodeID] to the current
+// primary network validator set with a weight of [vm.MinValidatorStake],
+// starting at [startTime].
+func addPrimaryValidator(require *require.Assertions, vm *VM, nodeID ids.NodeID, startTime time.Time) {
+	addValidatorTx, err := vm.txBuilder.NewAddValidatorTx(
+		vm.MinValidatorStake,
+		uint64(startTime.Unix()),
+		uint64(startTime.Add(defaultMinStakingDuration).Unix()),
+		nodeID,
+		ids.GenerateTestShortID(),
+		reward.PercentDenominator,
+		[]*crypto.PrivateKeySECP256K1R{keys[0]},
+		keys[0].PublicKey().Address(), // change addr
+	)
+	require.NoError(err)
+
+	preferred, err := vm.Builder.Preferred()
+	require.NoError(err)
+	statelessBlk, err := blocks.NewApricotProposalBlock(
+		preferred.ID(),
+		preferred.Height()+1,
+		addValidatorTx,
+	)
+	require.NoError(err)
+	verifyAndAcceptProposalCommitment(require, vm, vm.manager.NewBlock(statelessBlk))
+
+	vm.clock.Set(startTime)
+	advanceTimeTx, err := vm.txBuilder.NewAdvanceTimeTx(startTime)
+	require.NoError(err)
+
+	preferred, err = vm.Builder.Preferred()
+	require.NoError(err)
+	statelessBlk, err = blocks.NewApricotProposalBlock(
+		preferred.ID(),
+		preferred.Height()+1,
+		advanceTimeTx,
+	)
+	require.NoError(err)
+	verifyAndAcceptProposalCommitment(require, vm, vm.manager.NewBlock(statelessBlk))
+}
+
+func TestGetValidatorPublicKeysOfRemovedValidator(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	initialHeight, err := vm.GetCurrentHeight()
+	require.NoError(err)
+
+	// accept commits the staker changes of [vm.state] in a block on top of
+	// the last accepted one.
+	accept := func() uint64 {
+		parentID := vm.state.GetLastAccepted()
+		parent, _, err := vm.state.GetStatelessBlock(parentID)
+		require.NoError(err)
+
+		blk, err := blocks.NewBanffStandardBlock(vm.state.GetTimestamp(), parentID, parent.Height()+1, nil)
+		require.NoError(err)
+		vm.state.AddStatelessBlock(blk, choices.Accepted)
+		vm.state.SetLastAccepted(blk.ID())
+		vm.state.SetHeight(blk.Height())
+		require.NoError(vm.state.Commit())
+		return blk.Height()
+	}
+
+	sk, err := bls.NewSecretKey()
+	require.NoError(err)
+	pk := bls.PublicFromSecretKey(sk)
+
+	nodeID := ids.GenerateTestNodeID()
+	validatorTx := &txs.AddPermissionlessValidatorTx{
+		Validator: validator.Validator{
+			NodeID: nodeID,
+			Start:  uint64(defaultValidateStartTime.Unix()),
+			End:    uint64(defaultValidateEndTime.Unix()),
+			Wght:   vm.MinValidatorStake,
+		},
+		Subnet: constants.PrimaryNetworkID,
+		Signer: signer.NewProofOfPossession(sk),
+	}
+	staker := state.NewCurrentStaker(ids.GenerateTestID(), validatorTx, 0)
+	require.Equal(pk, staker.PublicKey)
+
+	vm.state.PutCurrentValidator(staker)
+	addedHeight := accept()
+
+	vm.state.DeleteCurrentValidator(staker)
+	removedHeight := accept()
+
+	// The key is known for as long as the validator was in the set.
+	pks, err := vm.GetValidatorPublicKeys(addedHeight, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal(map[ids.NodeID]*bls.PublicKey{nodeID: pk}, pks)
+
+	pks, err = vm.GetValidatorPublicKeys(initialHeight, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Empty(pks)
+
+	pks, err = vm.GetValidatorPublicKeys(removedHeight, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Empty(pks)
+}
+
+func TestGetValidatorSetDiff(t *testing.T) {
+	require := require.New(t)
+
+	vm, _, _ := defaultVM()
+	vm.ctx.Lock.Lock()
+	defer func() {
+		require.NoError(vm.Shutdown())
+		vm.ctx.Lock.Unlock()
+	}()
+
+	// Heights 2 and 3 add the validator as pending, heights 4 and 5 move it
+	// into the current validator set.
+	nodeID := ids.GenerateTestNodeID()
+	addPrimaryValidator(require, vm, nodeID, defaultGenesisTime.Add(txexecutor.SyncBound).Add(time.Second))
+	height, err := vm.GetCurrentHeight()
+	require.NoError(err)
+	require.EqualValues(5, height)
+
+	changes, err := vm.GetValidatorSetDiff(1, 5, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Equal([]ValidatorWeightChange{{
+		NodeID:         nodeID,
+		PreviousWeight: 0,
+		Weight:         json.Uint64(vm.MinValidatorStake),
+	}}, changes)
+
+	changes, err = vm.GetValidatorSetDiff(1, 4, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Empty(changes)
+
+	changes, err = vm.GetValidatorSetDiff(5, 5, constants.PrimaryNetworkID)
+	require.NoError(err)
+	require.Empty(changes)
+
+	_, err = vm.GetValidatorSetDiff(5, 1, constants.PrimaryNetworkID)
+	require.ErrorIs(err, errInvalidHeightRange)
+
+	_, err = vm.GetValidatorSetDiff(1, 6, constants.PrimaryNetworkID)
+	require.ErrorIs(err, database.ErrNotFound)
+}
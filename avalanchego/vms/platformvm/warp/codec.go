@@ -0,0 +1,24 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"math"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/codec/linearcodec"
+)
+
+// Version is the current default codec version
+const Version = 0
+
+// Codec does serialization and deserialization of warp messages
+var Codec codec.Manager
+
+func init() {
+	Codec = codec.NewManager(math.MaxInt)
+	if err := Codec.RegisterCodec(Version, linearcodec.NewDefault()); err != nil {
+		panic(err)
+	}
+}
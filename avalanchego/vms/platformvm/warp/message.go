@@ -0,0 +1,98 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+)
+
+// UnsignedMessage is a message sent by [SourceChainID] to be verified by other
+// chains.
+type UnsignedMessage struct {
+	SourceChainID ids.ID `serialize:"true"`
+	Payload       []byte `serialize:"true"`
+
+	bytes []byte
+	id    ids.ID
+}
+
+// NewUnsignedMessage returns a new unsigned message sent by [sourceChainID].
+func NewUnsignedMessage(sourceChainID ids.ID, payload []byte) (*UnsignedMessage, error) {
+	msg := &UnsignedMessage{
+		SourceChainID: sourceChainID,
+		Payload:       payload,
+	}
+	return msg, msg.initialize()
+}
+
+// ParseUnsignedMessage parses [b] into an unsigned message.
+func ParseUnsignedMessage(b []byte) (*UnsignedMessage, error) {
+	msg := &UnsignedMessage{}
+	if _, err := Codec.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	msg.bytes = b
+	msg.id = hashing.ComputeHash256Array(b)
+	return msg, nil
+}
+
+func (m *UnsignedMessage) initialize() error {
+	bytes, err := Codec.Marshal(Version, m)
+	if err != nil {
+		return err
+	}
+	m.bytes = bytes
+	m.id = hashing.ComputeHash256Array(bytes)
+	return nil
+}
+
+// ID returns the hash of the message's bytes.
+func (m *UnsignedMessage) ID() ids.ID {
+	return m.id
+}
+
+// Bytes returns the binary representation of the message. These are the bytes
+// validators sign.
+func (m *UnsignedMessage) Bytes() []byte {
+	return m.bytes
+}
+
+// Message is an unsigned message along with the aggregate signature of the
+// source chain's validators.
+type Message struct {
+	UnsignedMessage `serialize:"true"`
+	Signature       BitSetSignature `serialize:"true"`
+
+	bytes []byte
+}
+
+// NewMessage returns a new message with the provided signature.
+func NewMessage(unsignedMsg *UnsignedMessage, signature BitSetSignature) (*Message, error) {
+	msg := &Message{
+		UnsignedMessage: *unsignedMsg,
+		Signature:       signature,
+	}
+	bytes, err := Codec.Marshal(Version, msg)
+	if err != nil {
+		return nil, err
+	}
+	msg.bytes = bytes
+	return msg, nil
+}
+
+// ParseMessage parses [b] into a signed message.
+func ParseMessage(b []byte) (*Message, error) {
+	msg := &Message{}
+	if _, err := Codec.Unmarshal(b, msg); err != nil {
+		return nil, err
+	}
+	msg.bytes = b
+	return msg, msg.UnsignedMessage.initialize()
+}
+
+// Bytes returns the binary representation of the signed message.
+func (m *Message) Bytes() []byte {
+	return m.bytes
+}
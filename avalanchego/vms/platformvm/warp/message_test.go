@@ -0,0 +1,39 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+func TestMessage(t *testing.T) {
+	require := require.New(t)
+
+	unsignedMsg, err := NewUnsignedMessage(ids.GenerateTestID(), []byte("payload"))
+	require.NoError(err)
+
+	parsedUnsignedMsg, err := ParseUnsignedMessage(unsignedMsg.Bytes())
+	require.NoError(err)
+	require.Equal(unsignedMsg.ID(), parsedUnsignedMsg.ID())
+	require.Equal(unsignedMsg.SourceChainID, parsedUnsignedMsg.SourceChainID)
+	require.Equal(unsignedMsg.Payload, parsedUnsignedMsg.Payload)
+
+	msg, err := NewMessage(unsignedMsg, BitSetSignature{
+		Signers:   []byte{1},
+		Signature: [bls.SignatureLen]byte{2},
+	})
+	require.NoError(err)
+
+	parsedMsg, err := ParseMessage(msg.Bytes())
+	require.NoError(err)
+	require.Equal(msg.Bytes(), parsedMsg.Bytes())
+	require.Equal(unsignedMsg.ID(), parsedMsg.ID())
+	require.Equal(unsignedMsg.Bytes(), parsedMsg.UnsignedMessage.Bytes())
+	require.Equal(msg.Signature, parsedMsg.Signature)
+}
@@ -0,0 +1,62 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/multisig"
+)
+
+// BitSetSignature is an aggregate signature along with the bitset of the
+// validators, in canonical order, that contributed to it.
+type BitSetSignature struct {
+	// Signers is the big-endian encoding of the bitset of signers
+	Signers   []byte                 `serialize:"true"`
+	Signature [bls.SignatureLen]byte `serialize:"true"`
+}
+
+// NewBitSetSignature returns the serializable form of [sig].
+func NewBitSetSignature(sig *multisig.Signature) BitSetSignature {
+	bitSetSig := BitSetSignature{
+		Signers: sig.Signers.Bytes(),
+	}
+	copy(bitSetSig.Signature[:], bls.SignatureToBytes(sig.Signature))
+	return bitSetSig
+}
+
+// Verify returns nil if [s] is a signature of [msg] by validators of
+// [subnetID] holding at least [quorumNum]/[quorumDen] of the subnet's weight at
+// P-chain height [pChainHeight].
+func (s *BitSetSignature) Verify(
+	msg *UnsignedMessage,
+	state ValidatorState,
+	pChainHeight uint64,
+	subnetID ids.ID,
+	quorumNum uint64,
+	quorumDen uint64,
+) error {
+	vdrs, err := GetCanonicalValidatorSet(state, pChainHeight, subnetID)
+	if err != nil {
+		return err
+	}
+	signers, err := multisig.SignersFromBytes(s.Signers)
+	if err != nil {
+		return err
+	}
+	sig, err := bls.SignatureFromBytes(s.Signature[:])
+	if err != nil {
+		return err
+	}
+	return multisig.Verify(
+		vdrs,
+		&multisig.Signature{
+			Signers:   signers,
+			Signature: sig,
+		},
+		msg.Bytes(),
+		quorumNum,
+		quorumDen,
+	)
+}
@@ -0,0 +1,49 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+)
+
+var (
+	_ Signer = &signer{}
+
+	errWrongSourceChainID = errors.New("wrong source chain ID")
+)
+
+// Signer signs warp messages on behalf of this node.
+type Signer interface {
+	// Sign returns this node's BLS signature of [msg]. Only messages sent by
+	// the chain the signer was created for may be signed.
+	Sign(msg *UnsignedMessage) ([]byte, error)
+}
+
+type signer struct {
+	sk      bls.Signer
+	chainID ids.ID
+}
+
+// NewSigner returns a Signer that signs the messages of [chainID] with [sk].
+func NewSigner(sk bls.Signer, chainID ids.ID) Signer {
+	return &signer{
+		sk:      sk,
+		chainID: chainID,
+	}
+}
+
+func (s *signer) Sign(msg *UnsignedMessage) ([]byte, error) {
+	if msg.SourceChainID != s.chainID {
+		return nil, fmt.Errorf("%w: expected %s but got %s", errWrongSourceChainID, s.chainID, msg.SourceChainID)
+	}
+	sig, err := s.sk.Sign(msg.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return bls.SignatureToBytes(sig), nil
+}
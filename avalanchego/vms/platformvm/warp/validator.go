@@ -0,0 +1,74 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"sync"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/validators"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/multisig"
+)
+
+var _ ValidatorState = &lockedValidatorState{}
+
+// ValidatorState is the P-chain state that warp messages are verified against.
+type ValidatorState interface {
+	validators.State
+
+	// GetValidatorPublicKeys returns the BLS public keys of the validators of
+	// [subnetID] at P-chain [height]. Validators without a known key are
+	// omitted.
+	GetValidatorPublicKeys(height uint64, subnetID ids.ID) (map[ids.NodeID]*bls.PublicKey, error)
+}
+
+type lockedValidatorState struct {
+	validators.State
+
+	lock sync.Locker
+	s    ValidatorState
+}
+
+// NewLockedValidatorState returns a ValidatorState that holds [lock] while
+// accessing [s].
+func NewLockedValidatorState(lock sync.Locker, s ValidatorState) ValidatorState {
+	return &lockedValidatorState{
+		State: validators.NewLockedState(lock, s),
+		lock:  lock,
+		s:     s,
+	}
+}
+
+func (s *lockedValidatorState) GetValidatorPublicKeys(height uint64, subnetID ids.ID) (map[ids.NodeID]*bls.PublicKey, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.s.GetValidatorPublicKeys(height, subnetID)
+}
+
+// GetCanonicalValidatorSet returns the validators of [subnetID] at P-chain
+// [height] in the order that signer bitsets refer to. Validators without a
+// known public key are included so that their weight counts towards the total,
+// but they can't sign.
+func GetCanonicalValidatorSet(state ValidatorState, height uint64, subnetID ids.ID) ([]multisig.Validator, error) {
+	weights, err := state.GetValidatorSet(height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	pks, err := state.GetValidatorPublicKeys(height, subnetID)
+	if err != nil {
+		return nil, err
+	}
+
+	vdrs := make([]multisig.Validator, 0, len(weights))
+	for nodeID, weight := range weights {
+		vdrs = append(vdrs, multisig.Validator{
+			PublicKey: pks[nodeID],
+			Weight:    weight,
+		})
+	}
+	multisig.SortValidators(vdrs)
+	return vdrs, nil
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+var _ Verifier = &verifier{}
+
+// SubnetLookup returns the subnet that validates a chain.
+type SubnetLookup interface {
+	SubnetID(chainID ids.ID) (ids.ID, error)
+}
+
+// Verifier verifies warp messages sent by other chains.
+type Verifier interface {
+	// Verify returns nil if [msg] was signed by validators holding at least
+	// [quorumNum]/[quorumDen] of the weight of the source chain's subnet at
+	// P-chain height [pChainHeight].
+	Verify(msg *Message, pChainHeight uint64, quorumNum, quorumDen uint64) error
+}
+
+type verifier struct {
+	state   ValidatorState
+	subnets SubnetLookup
+}
+
+// NewVerifier returns a Verifier that looks up the validators of the source
+// chain in [state]. Only messages sent by chains known to [subnets] can be
+// verified.
+func NewVerifier(state ValidatorState, subnets SubnetLookup) Verifier {
+	return &verifier{
+		state:   state,
+		subnets: subnets,
+	}
+}
+
+func (v *verifier) Verify(msg *Message, pChainHeight uint64, quorumNum, quorumDen uint64) error {
+	subnetID, err := v.subnets.SubnetID(msg.SourceChainID)
+	if err != nil {
+		return fmt.Errorf("couldn't find subnet of chain %s: %w", msg.SourceChainID, err)
+	}
+	return msg.Signature.Verify(
+		&msg.UnsignedMessage,
+		v.state,
+		pChainHeight,
+		subnetID,
+		quorumNum,
+		quorumDen,
+	)
+}
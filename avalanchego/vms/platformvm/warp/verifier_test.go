@@ -0,0 +1,110 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package warp
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls"
+	"github.com/ava-labs/avalanchego/utils/crypto/bls/multisig"
+)
+
+var errUnknownChain = errors.New("unknown chain")
+
+type testValidatorState struct {
+	height  uint64
+	weights map[ids.NodeID]uint64
+	pks     map[ids.NodeID]*bls.PublicKey
+}
+
+func (s *testValidatorState) GetMinimumHeight() (uint64, error) { return s.height, nil }
+func (s *testValidatorState) GetCurrentHeight() (uint64, error) { return s.height, nil }
+
+func (s *testValidatorState) GetValidatorSet(uint64, ids.ID) (map[ids.NodeID]uint64, error) {
+	return s.weights, nil
+}
+
+func (s *testValidatorState) GetValidatorPublicKeys(uint64, ids.ID) (map[ids.NodeID]*bls.PublicKey, error) {
+	return s.pks, nil
+}
+
+type testSubnetLookup map[ids.ID]ids.ID
+
+func (l testSubnetLookup) SubnetID(chainID ids.ID) (ids.ID, error) {
+	subnetID, ok := l[chainID]
+	if !ok {
+		return ids.Empty, errUnknownChain
+	}
+	return subnetID, nil
+}
+
+func TestVerifier(t *testing.T) {
+	require := require.New(t)
+
+	var (
+		chainID  = ids.GenerateTestID()
+		subnetID = ids.GenerateTestID()
+		state    = &testValidatorState{
+			weights: make(map[ids.NodeID]uint64),
+			pks:     make(map[ids.NodeID]*bls.PublicKey),
+		}
+		signers = make(map[string]Signer)
+	)
+	for i, weight := range []uint64{40, 30, 20, 10} {
+		nodeID := ids.GenerateTestNodeID()
+		state.weights[nodeID] = weight
+		// The last validator didn't register a key.
+		if i == 3 {
+			continue
+		}
+		sk, err := bls.NewSecretKey()
+		require.NoError(err)
+		pk := bls.PublicFromSecretKey(sk)
+		state.pks[nodeID] = pk
+		signers[string(bls.PublicKeyToBytes(pk))] = NewSigner(bls.NewSigner(sk), chainID)
+	}
+
+	unsignedMsg, err := NewUnsignedMessage(chainID, []byte("payload"))
+	require.NoError(err)
+
+	_, err = NewSigner(nil, ids.GenerateTestID()).Sign(unsignedMsg)
+	require.ErrorIs(err, errWrongSourceChainID)
+
+	// Every validator with a key signs the message.
+	vdrs, err := GetCanonicalValidatorSet(state, 0, subnetID)
+	require.NoError(err)
+	require.Len(vdrs, 4)
+	require.Nil(vdrs[3].PublicKey)
+
+	var sigs []multisig.IndexedSignature
+	for i, vdr := range vdrs[:3] {
+		sigBytes, err := signers[string(bls.PublicKeyToBytes(vdr.PublicKey))].Sign(unsignedMsg)
+		require.NoError(err)
+		sig, err := bls.SignatureFromBytes(sigBytes)
+		require.NoError(err)
+		sigs = append(sigs, multisig.IndexedSignature{
+			Index:     i,
+			Signature: sig,
+		})
+	}
+	aggSig, err := multisig.Aggregate(sigs)
+	require.NoError(err)
+
+	msg, err := NewMessage(unsignedMsg, NewBitSetSignature(aggSig))
+	require.NoError(err)
+	parsedMsg, err := ParseMessage(msg.Bytes())
+	require.NoError(err)
+
+	verifier := NewVerifier(state, testSubnetLookup{chainID: subnetID})
+	require.NoError(verifier.Verify(parsedMsg, 0, 9, 10))
+	require.ErrorIs(verifier.Verify(parsedMsg, 0, 10, 10), multisig.ErrInsufficientWeight)
+
+	// Messages from unknown chains can't be verified.
+	verifier = NewVerifier(state, testSubnetLookup{})
+	require.ErrorIs(verifier.Verify(parsedMsg, 0, 1, 2), errUnknownChain)
+}
@@ -202,12 +202,14 @@ func (p *postForkCommonComponents) buildChild(
 			// should attempt to re-handle that once it is our turn to build the
 			// block.
 			p.vm.notifyInnerBlockReady()
+			p.vm.markBuildSkipped(buildSkippedNotOurTurn)
 			return nil, errProposerWindowNotStarted
 		}
 	}
 
 	innerBlock, err := p.vm.ChainVM.BuildBlock()
 	if err != nil {
+		p.vm.markBuildSkipped(buildSkippedVMNotReady)
 		return nil, err
 	}
 
@@ -270,6 +272,7 @@ func (p *postForkCommonComponents) buildChild(
 		},
 	}
 
+	p.vm.metrics.numBuilt.Inc()
 	p.vm.ctx.Log.Info("built block",
 		zap.Stringer("blkID", child.ID()),
 		zap.Stringer("innerBlkID", innerBlock.ID()),
@@ -0,0 +1,58 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+)
+
+// List of reasons a block wasn't proposed when the engine asked for one:
+// - [buildSkippedNotOurTurn] This node's proposer window hadn't started yet
+// - [buildSkippedVMNotReady] The inner VM failed to build a block
+const (
+	buildSkippedNotOurTurn = "not_our_turn"
+	buildSkippedVMNotReady = "vm_not_ready"
+)
+
+type buildMetrics struct {
+	numBuilt           prometheus.Counter
+	numSkipped         *prometheus.CounterVec
+	proposerSlot       prometheus.Gauge
+	parentPChainHeight prometheus.Gauge
+}
+
+func newBuildMetrics(registerer prometheus.Registerer) (*buildMetrics, error) {
+	m := &buildMetrics{
+		numBuilt: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "blks_built",
+			Help: "Number of blocks that have been built locally",
+		}),
+		numSkipped: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Name: "blk_builds_skipped",
+				Help: "Number of block builds that didn't produce a block, by reason",
+			},
+			[]string{"reason"},
+		),
+		proposerSlot: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "proposer_slot",
+			Help: "Position of this node in the proposer list of the child of the preferred block. Equal to the number of windows if this node isn't in the list",
+		}),
+		parentPChainHeight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "parent_p_chain_height",
+			Help: "P-chain height of the preferred block, which is used to sample the proposers of its child",
+		}),
+	}
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(m.numBuilt),
+		registerer.Register(m.numSkipped),
+		registerer.Register(m.proposerSlot),
+		registerer.Register(m.parentPChainHeight),
+	)
+	return m, errs.Err
+}
@@ -0,0 +1,26 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+// HealthCheck returns the health of the inner VM. While the height index is
+// being repaired, the inner VM's details are reported under "vm" along with
+// the progress of the repair under "heightIndexRepair".
+func (vm *VM) HealthCheck() (interface{}, error) {
+	innerDetails, err := vm.ChainVM.HealthCheck()
+	if vm.hVM == nil || vm.hIndexer.IsRepaired() {
+		return innerDetails, err
+	}
+
+	progress := vm.hIndexer.Progress()
+	return map[string]interface{}{
+		"vm": innerDetails,
+		"heightIndexRepair": map[string]interface{}{
+			"repairing":          progress.Repairing,
+			"indexedBlocks":      progress.IndexedBlocks,
+			"nextHeight":         progress.NextHeight,
+			"maxRemainingBlocks": progress.MaxRemainingBlocks,
+			"eta":                progress.ETA.String(),
+		},
+	}, err
+}
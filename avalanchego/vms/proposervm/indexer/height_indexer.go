@@ -6,23 +6,28 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"go.uber.org/zap"
 
 	"github.com/ava-labs/avalanchego/database"
 	"github.com/ava-labs/avalanchego/ids"
 	"github.com/ava-labs/avalanchego/utils"
 	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/vms/proposervm/state"
 )
 
 // default number of heights to index before committing
 const (
 	defaultCommitFrequency = 1024
-	// Sleep [sleepDurationMultiplier]x (5x) the amount of time we spend processing the block
-	// to ensure the async indexing does not bottleneck the node.
-	sleepDurationMultiplier = 5
+	// DefaultSleepDurationMultiplier makes the indexer sleep 5x the amount of
+	// time spent processing a block to ensure the async indexing does not
+	// bottleneck the node.
+	DefaultSleepDurationMultiplier = 5
 )
 
 var _ HeightIndexer = &heightIndexer{}
@@ -36,14 +41,46 @@ type HeightIndexer interface {
 
 	// Resumes repairing of the height index from the checkpoint.
 	RepairHeightIndex(context.Context) error
+
+	// Progress returns how far the repair of the height index has gotten.
+	Progress() Progress
+}
+
+// Progress of the repair of the height index
+type Progress struct {
+	// Whether the repair is currently walking the chain
+	Repairing bool
+	// Number of heights indexed since the repair was resumed
+	IndexedBlocks uint64
+	// Height of the next block to index. The repair walks down the chain
+	// towards the fork height.
+	NextHeight uint64
+	// Upper bound on the number of heights that are left to index. The fork
+	// height is only known once the repair reaches it.
+	MaxRemainingBlocks uint64
+	// Estimated upper bound on the time left to finish the repair
+	ETA time.Duration
 }
 
+// NewHeightIndexer returns a new height indexer. While repairing, the indexer
+// sleeps [sleepDurationMultiplier] times the amount of time spent processing
+// each block. A multiplier of 0 disables the throttling.
 func NewHeightIndexer(
 	server BlockServer,
 	log logging.Logger,
 	indexState state.State,
-) HeightIndexer {
-	return newHeightIndexer(server, log, indexState)
+	registerer prometheus.Registerer,
+	sleepDurationMultiplier uint64,
+) (HeightIndexer, error) {
+	hi := newHeightIndexer(server, log, indexState)
+	hi.sleepDurationMultiplier = time.Duration(sleepDurationMultiplier)
+
+	errs := wrappers.Errs{}
+	errs.Add(
+		registerer.Register(hi.indexedBlocksMetric),
+		registerer.Register(hi.remainingBlocksMetric),
+	)
+	return hi, errs.Err
 }
 
 func newHeightIndexer(
@@ -52,10 +89,19 @@ func newHeightIndexer(
 	indexState state.State,
 ) *heightIndexer {
 	return &heightIndexer{
-		server:          server,
-		log:             log,
-		state:           indexState,
-		commitFrequency: defaultCommitFrequency,
+		server:                  server,
+		log:                     log,
+		state:                   indexState,
+		commitFrequency:         defaultCommitFrequency,
+		sleepDurationMultiplier: DefaultSleepDurationMultiplier,
+		indexedBlocksMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "height_index_repair_indexed_blocks",
+			Help: "Number of heights indexed since the repair of the height index was resumed",
+		}),
+		remainingBlocksMetric: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "height_index_repair_max_remaining_blocks",
+			Help: "Upper bound on the number of heights left to index by the repair of the height index",
+		}),
 	}
 }
 
@@ -66,7 +112,13 @@ type heightIndexer struct {
 	jobDone utils.AtomicBool
 	state   state.State
 
-	commitFrequency int
+	commitFrequency         int
+	sleepDurationMultiplier time.Duration
+
+	progressLock          sync.RWMutex
+	progress              Progress
+	indexedBlocksMetric   prometheus.Gauge
+	remainingBlocksMetric prometheus.Gauge
 }
 
 func (hi *heightIndexer) IsRepaired() bool {
@@ -77,6 +129,38 @@ func (hi *heightIndexer) MarkRepaired(repaired bool) {
 	hi.jobDone.SetValue(repaired)
 }
 
+func (hi *heightIndexer) Progress() Progress {
+	hi.progressLock.RLock()
+	defer hi.progressLock.RUnlock()
+
+	return hi.progress
+}
+
+// setProgress records that [indexedBlks] heights were indexed in [elapsed] and
+// that [nextHeight] is the next height to index.
+func (hi *heightIndexer) setProgress(repairing bool, indexedBlks int, nextHeight uint64, elapsed time.Duration) {
+	// Every height above the fork height must be indexed, and the fork height
+	// is at least 1.
+	maxRemaining := nextHeight
+	var eta time.Duration
+	if indexedBlks > 0 && repairing {
+		eta = time.Duration(float64(elapsed) / float64(indexedBlks) * float64(maxRemaining))
+	}
+
+	hi.progressLock.Lock()
+	hi.progress = Progress{
+		Repairing:          repairing,
+		IndexedBlocks:      uint64(indexedBlks),
+		NextHeight:         nextHeight,
+		MaxRemainingBlocks: maxRemaining,
+		ETA:                eta,
+	}
+	hi.progressLock.Unlock()
+
+	hi.indexedBlocksMetric.Set(float64(indexedBlks))
+	hi.remainingBlocksMetric.Set(float64(maxRemaining))
+}
+
 // RepairHeightIndex ensures the height -> proBlkID height block index is well formed.
 // Starting from the checkpoint, it will go back to snowman++ activation fork
 // or genesis. PreFork blocks will be handled by innerVM height index.
@@ -120,6 +204,7 @@ func (hi *heightIndexer) doRepair(ctx context.Context, currentProBlkID ids.ID, l
 		indexedBlks     int
 		lastIndexedBlks int
 	)
+	hi.setProgress(true, 0, lastIndexedHeight, 0)
 	for {
 		if err := ctx.Err(); err != nil {
 			return err
@@ -140,6 +225,7 @@ func (hi *heightIndexer) doRepair(ctx context.Context, currentProBlkID ids.ID, l
 				return err
 			}
 			hi.MarkRepaired(true)
+			hi.setProgress(false, indexedBlks, 0, time.Since(start))
 
 			// it will commit on exit
 			hi.log.Info("indexing finished",
@@ -190,11 +276,12 @@ func (hi *heightIndexer) doRepair(ctx context.Context, currentProBlkID ids.ID, l
 		// keep checking the parent
 		currentProBlkID = currentAcceptedBlk.ParentID()
 		lastIndexedHeight--
+		hi.setProgress(true, indexedBlks, lastIndexedHeight, time.Since(start))
 
 		processingDuration := time.Since(processingStart)
-		// Sleep [sleepDurationMultiplier]x (5x) the amount of time we spend processing the block
+		// Sleep [sleepDurationMultiplier]x the amount of time we spend processing the block
 		// to ensure the indexing does not bottleneck the node.
-		time.Sleep(processingDuration * sleepDurationMultiplier)
+		time.Sleep(processingDuration * hi.sleepDurationMultiplier)
 	}
 }
 
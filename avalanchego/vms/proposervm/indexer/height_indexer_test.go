@@ -89,6 +89,11 @@ func TestHeightBlockIndexPostFork(t *testing.T) {
 	require.NoError(hIndex.RepairHeightIndex(context.Background()))
 	require.True(hIndex.IsRepaired())
 
+	progress := hIndex.Progress()
+	require.False(progress.Repairing)
+	require.EqualValues(blkNumber, progress.IndexedBlocks)
+	require.Zero(progress.MaxRemainingBlocks)
+
 	// check that height index is fully built
 	loadedForkHeight, err := storedState.GetForkHeight()
 	require.NoError(err)
@@ -165,9 +165,11 @@ func (b *preForkBlock) buildChild() (Block, error) {
 		// The chain hasn't forked yet
 		innerBlock, err := b.vm.ChainVM.BuildBlock()
 		if err != nil {
+			b.vm.markBuildSkipped(buildSkippedVMNotReady)
 			return nil, err
 		}
 
+		b.vm.metrics.numBuilt.Inc()
 		b.vm.ctx.Log.Info("built block",
 			zap.Stringer("blkID", innerBlock.ID()),
 			zap.Uint64("height", innerBlock.Height()),
@@ -197,6 +199,7 @@ func (b *preForkBlock) buildChild() (Block, error) {
 
 	innerBlock, err := b.vm.ChainVM.BuildBlock()
 	if err != nil {
+		b.vm.markBuildSkipped(buildSkippedVMNotReady)
 		return nil, err
 	}
 
@@ -219,6 +222,7 @@ func (b *preForkBlock) buildChild() (Block, error) {
 		},
 	}
 
+	b.vm.metrics.numBuilt.Inc()
 	b.vm.ctx.Log.Info("built block",
 		zap.Stringer("blkID", blk.ID()),
 		zap.Stringer("innerBlkID", innerBlock.ID()),
@@ -24,6 +24,14 @@ const (
 var _ Windower = &windower{}
 
 type Windower interface {
+	// Proposers returns the ordered list of validators that are given a
+	// submission window for the block at [chainHeight].
+	Proposers(
+		chainHeight,
+		pChainHeight uint64,
+	) ([]ids.NodeID, error)
+	// Delay returns the amount of time [validatorID] must wait after the
+	// parent's timestamp before proposing the block at [chainHeight].
 	Delay(
 		chainHeight,
 		pChainHeight uint64,
@@ -50,15 +58,11 @@ func New(state validators.State, subnetID, chainID ids.ID) Windower {
 	}
 }
 
-func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.NodeID) (time.Duration, error) {
-	if validatorID == ids.EmptyNodeID {
-		return MaxDelay, nil
-	}
-
+func (w *windower) Proposers(chainHeight, pChainHeight uint64) ([]ids.NodeID, error) {
 	// get the validator set by the p-chain height
 	validatorsMap, err := w.state.GetValidatorSet(pChainHeight, w.subnetID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	// convert the map of validators to a slice
@@ -71,7 +75,7 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.NodeI
 		})
 		newWeight, err := math.Add64(weight, v)
 		if err != nil {
-			return 0, err
+			return nil, err
 		}
 		weight = newWeight
 	}
@@ -88,7 +92,7 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.NodeI
 	}
 
 	if err := w.sampler.Initialize(validatorWeights); err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	numToSample := MaxWindows
@@ -100,13 +104,29 @@ func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.NodeI
 	w.sampler.Seed(int64(seed))
 
 	indices, err := w.sampler.Sample(numToSample)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeIDs := make([]ids.NodeID, numToSample)
+	for i, index := range indices {
+		nodeIDs[i] = validators[index].id
+	}
+	return nodeIDs, nil
+}
+
+func (w *windower) Delay(chainHeight, pChainHeight uint64, validatorID ids.NodeID) (time.Duration, error) {
+	if validatorID == ids.EmptyNodeID {
+		return MaxDelay, nil
+	}
+
+	proposers, err := w.Proposers(chainHeight, pChainHeight)
 	if err != nil {
 		return 0, err
 	}
 
 	delay := time.Duration(0)
-	for _, index := range indices {
-		nodeID := validators[index].id
+	for _, nodeID := range proposers {
 		if nodeID == validatorID {
 			return delay, nil
 		}
@@ -114,6 +114,43 @@ func TestWindowerChangeByHeight(t *testing.T) {
 	}
 }
 
+func TestWindowerProposers(t *testing.T) {
+	require := require.New(t)
+
+	subnetID := ids.ID{0, 1}
+	chainID := ids.ID{0, 2}
+	validatorIDs := make([]ids.NodeID, MaxWindows)
+	for i := range validatorIDs {
+		validatorIDs[i] = ids.NodeID{byte(i + 1)}
+	}
+	vdrState := &validators.TestState{
+		T: t,
+		GetValidatorSetF: func(height uint64, subnetID ids.ID) (map[ids.NodeID]uint64, error) {
+			validators := make(map[ids.NodeID]uint64, MaxWindows)
+			for _, id := range validatorIDs {
+				validators[id] = 1
+			}
+			return validators, nil
+		},
+	}
+
+	w := New(vdrState, subnetID, chainID)
+
+	proposers, err := w.Proposers(1, 0)
+	require.NoError(err)
+	require.Equal(
+		[]ids.NodeID{
+			validatorIDs[4],
+			validatorIDs[5],
+			validatorIDs[0],
+			validatorIDs[2],
+			validatorIDs[3],
+			validatorIDs[1],
+		},
+		proposers,
+	)
+}
+
 func TestWindowerChangeByChain(t *testing.T) {
 	require := require.New(t)
 
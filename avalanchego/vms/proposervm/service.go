@@ -0,0 +1,106 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+// Service defines the API calls that expose the block production decisions of
+// the proposervm
+type Service struct {
+	vm *VM
+}
+
+// BuildSkipped describes a block build that didn't produce a block
+type BuildSkipped struct {
+	Time time.Time `json:"time"`
+	// Either "not_our_turn" or "vm_not_ready"
+	Reason string `json:"reason"`
+}
+
+// GetProposerWindowReply is the response from GetProposerWindow
+type GetProposerWindowReply struct {
+	// The preferred block, which is the parent of the next block
+	PreferredBlockID   ids.ID      `json:"preferredBlockID"`
+	PreferredHeight    json.Uint64 `json:"preferredHeight"`
+	PreferredTimestamp time.Time   `json:"preferredTimestamp"`
+	// P-chain height of the preferred block. The proposers of the next block
+	// are sampled from the validator set at this height.
+	PChainHeight json.Uint64 `json:"pChainHeight"`
+	// P-chain height currently observed by this node
+	CurrentPChainHeight json.Uint64 `json:"currentPChainHeight"`
+	// Proposers of the next block, in the order of their windows
+	Proposers []ids.NodeID `json:"proposers"`
+	// Position of this node in [Proposers]. If this node isn't a proposer, the
+	// slot is the number of proposers and this node can only propose once
+	// every window has passed.
+	Slot json.Uint32 `json:"slot"`
+	// Earliest time at which this node may propose the next block
+	WindowStart time.Time `json:"windowStart"`
+	// Window that is currently open
+	CurrentWindow json.Uint32 `json:"currentWindow"`
+	// Most recent block build of this node that didn't produce a block
+	LastBuildSkipped *BuildSkipped `json:"lastBuildSkipped,omitempty"`
+}
+
+// GetProposerWindow returns the proposer window of this node for the child of
+// the preferred block, along with the reason its last block build was skipped.
+func (s *Service) GetProposerWindow(_ *http.Request, _ *struct{}, reply *GetProposerWindowReply) error {
+	s.vm.ctx.Log.Debug("ProposerVM: GetProposerWindow called")
+
+	blk, err := s.vm.getPostForkBlock(s.vm.preferred)
+	if err != nil {
+		return errProposersNotActivated
+	}
+	pChainHeight, err := blk.pChainHeight()
+	if err != nil {
+		return err
+	}
+	currentPChainHeight, err := s.vm.ctx.ValidatorState.GetCurrentHeight()
+	if err != nil {
+		return err
+	}
+	proposers, err := s.vm.Windower.Proposers(blk.Height()+1, pChainHeight)
+	if err != nil {
+		return err
+	}
+
+	slot := len(proposers)
+	for i, nodeID := range proposers {
+		if nodeID == s.vm.ctx.NodeID {
+			slot = i
+			break
+		}
+	}
+
+	preferredTime := blk.Timestamp()
+	currentWindow := s.vm.Time().Sub(preferredTime) / proposer.WindowDuration
+	switch {
+	case currentWindow < 0:
+		currentWindow = 0
+	case currentWindow > proposer.MaxWindows:
+		currentWindow = proposer.MaxWindows
+	}
+
+	reply.PreferredBlockID = blk.ID()
+	reply.PreferredHeight = json.Uint64(blk.Height())
+	reply.PreferredTimestamp = preferredTime
+	reply.PChainHeight = json.Uint64(pChainHeight)
+	reply.CurrentPChainHeight = json.Uint64(currentPChainHeight)
+	reply.Proposers = proposers
+	reply.Slot = json.Uint32(slot)
+	reply.WindowStart = preferredTime.Add(time.Duration(slot) * proposer.WindowDuration)
+	reply.CurrentWindow = json.Uint32(currentWindow)
+	if s.vm.lastBuildSkipped.Reason != "" {
+		lastBuildSkipped := s.vm.lastBuildSkipped
+		reply.LastBuildSkipped = &lastBuildSkipped
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package proposervm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/vms/proposervm/proposer"
+)
+
+func TestGetProposerWindow(t *testing.T) {
+	require := require.New(t)
+
+	coreVM, _, proVM, coreGenBlk, _ := initTestProposerVM(t, time.Time{}, 0) // enable ProBlks
+	service := &Service{vm: proVM}
+
+	// The genesis block isn't wrapped with a proposer header
+	reply := GetProposerWindowReply{}
+	err := service.GetProposerWindow(nil, nil, &reply)
+	require.ErrorIs(err, errProposersNotActivated)
+
+	coreBlk := &snowman.TestBlock{
+		TestDecidable: choices.TestDecidable{
+			IDV:     ids.GenerateTestID(),
+			StatusV: choices.Processing,
+		},
+		BytesV:     []byte{1},
+		ParentV:    coreGenBlk.ID(),
+		HeightV:    coreGenBlk.Height() + 1,
+		TimestampV: coreGenBlk.Timestamp(),
+	}
+	coreVM.BuildBlockF = func() (snowman.Block, error) { return coreBlk, nil }
+
+	proVM.Set(coreGenBlk.Timestamp().Add(proposer.MaxDelay))
+	blk, err := proVM.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk.Verify())
+	require.NoError(proVM.SetPreference(blk.ID()))
+
+	proVM.Set(blk.Timestamp())
+	reply = GetProposerWindowReply{}
+	require.NoError(service.GetProposerWindow(nil, nil, &reply))
+
+	postForkBlk := blk.(*postForkBlock)
+	expectedProposers, err := proVM.Windower.Proposers(blk.Height()+1, postForkBlk.PChainHeight())
+	require.NoError(err)
+	expectedDelay, err := proVM.Windower.Delay(blk.Height()+1, postForkBlk.PChainHeight(), proVM.ctx.NodeID)
+	require.NoError(err)
+
+	require.Equal(blk.ID(), reply.PreferredBlockID)
+	require.EqualValues(blk.Height(), reply.PreferredHeight)
+	require.Equal(blk.Timestamp(), reply.PreferredTimestamp)
+	require.EqualValues(postForkBlk.PChainHeight(), reply.PChainHeight)
+	require.EqualValues(defaultPChainHeight, reply.CurrentPChainHeight)
+	require.Equal(expectedProposers, reply.Proposers)
+	require.EqualValues(expectedDelay/proposer.WindowDuration, reply.Slot)
+	require.Equal(blk.Timestamp().Add(expectedDelay), reply.WindowStart)
+	require.EqualValues(0, reply.CurrentWindow)
+	require.Nil(reply.LastBuildSkipped)
+
+	// Once every window has passed, any node may propose, but the inner VM
+	// has nothing to build.
+	coreVM.BuildBlockF = func() (snowman.Block, error) { return nil, errors.New("no pending txs") }
+	proVM.Set(blk.Timestamp().Add(2 * proposer.MaxDelay))
+	_, err = proVM.BuildBlock()
+	require.Error(err)
+
+	reply = GetProposerWindowReply{}
+	require.NoError(service.GetProposerWindow(nil, nil, &reply))
+	require.EqualValues(proposer.MaxWindows, reply.CurrentWindow)
+	require.NotNil(reply.LastBuildSkipped)
+	require.Equal(buildSkippedVMNotReady, reply.LastBuildSkipped.Reason)
+	require.Equal(blk.Timestamp().Add(2*proposer.MaxDelay), reply.LastBuildSkipped.Time)
+}
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/gorilla/rpc/v2"
+
 	"github.com/prometheus/client_golang/prometheus"
 
 	"go.uber.org/zap"
@@ -27,6 +29,7 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
 	"github.com/ava-labs/avalanchego/utils"
+	"github.com/ava-labs/avalanchego/utils/json"
 	"github.com/ava-labs/avalanchego/utils/math"
 	"github.com/ava-labs/avalanchego/utils/timer/mockable"
 	"github.com/ava-labs/avalanchego/vms/proposervm/indexer"
@@ -44,6 +47,8 @@ const (
 	minBlockDelay         = time.Second
 	checkIndexedFrequency = 10 * time.Second
 	innerBlkCacheSize     = 512
+
+	apiExtension = "/proposervm"
 )
 
 var (
@@ -68,6 +73,10 @@ type VM struct {
 
 	banffActivationTime time.Time
 
+	// heightIndexRepairThrottle is the number of times the time spent
+	// repairing each height of the height index that the repair sleeps for.
+	heightIndexRepairThrottle uint64
+
 	state.State
 	hIndexer                indexer.HeightIndexer
 	resetHeightIndexOngoing utils.AtomicBool
@@ -80,6 +89,11 @@ type VM struct {
 	ctx         *snow.Context
 	db          *versiondb.Database
 	toScheduler chan<- common.Message
+	metrics     *buildMetrics
+
+	// lastBuildSkipped is the most recent block build that didn't produce a
+	// block.
+	lastBuildSkipped BuildSkipped
 
 	// Block ID --> Block
 	// Each element is a block that passed verification but
@@ -111,6 +125,7 @@ func New(
 	activationTime time.Time,
 	minimumPChainHeight uint64,
 	banffActivationTime time.Time,
+	heightIndexRepairThrottle uint64,
 ) *VM {
 	bVM, _ := vm.(block.BatchedChainVM)
 	hVM, _ := vm.(block.HeightIndexedChainVM)
@@ -125,6 +140,8 @@ func New(
 		minimumPChainHeight: minimumPChainHeight,
 
 		banffActivationTime: banffActivationTime,
+
+		heightIndexRepairThrottle: heightIndexRepairThrottle,
 	}
 }
 
@@ -172,10 +189,24 @@ func (vm *VM) Initialize(
 	}
 	vm.innerBlkCache = innerBlkCache
 
+	vm.metrics, err = newBuildMetrics(registerer)
+	if err != nil {
+		return err
+	}
+
 	indexerDB := versiondb.New(vm.db)
 	// TODO: Use [state.NewMetered] here to populate additional metrics.
 	indexerState := state.New(indexerDB)
-	vm.hIndexer = indexer.NewHeightIndexer(vm, vm.ctx.Log, indexerState)
+	vm.hIndexer, err = indexer.NewHeightIndexer(
+		vm,
+		vm.ctx.Log,
+		indexerState,
+		registerer,
+		vm.heightIndexRepairThrottle,
+	)
+	if err != nil {
+		return err
+	}
 
 	scheduler, vmToEngine := scheduler.New(vm.ctx.Log, toEngine)
 	vm.Scheduler = scheduler
@@ -251,6 +282,29 @@ func (vm *VM) BuildBlock() (snowman.Block, error) {
 	return preferredBlock.buildChild()
 }
 
+// CreateHandlers returns the handlers of the inner VM along with the proposervm
+// API, which is served under the "/proposervm" extension.
+func (vm *VM) CreateHandlers() (map[string]*common.HTTPHandler, error) {
+	handlers, err := vm.ChainVM.CreateHandlers()
+	if err != nil {
+		return nil, err
+	}
+	if handlers == nil {
+		handlers = make(map[string]*common.HTTPHandler, 1)
+	}
+
+	server := rpc.NewServer()
+	server.RegisterCodec(json.NewCodec(), "application/json")
+	server.RegisterCodec(json.NewCodec(), "application/json;charset=UTF-8")
+	if err := server.RegisterService(&Service{vm: vm}, "proposervm"); err != nil {
+		return nil, err
+	}
+	handlers[apiExtension] = &common.HTTPHandler{
+		Handler: server,
+	}
+	return handlers, nil
+}
+
 func (vm *VM) ParseBlock(b []byte) (snowman.Block, error) {
 	if blk, err := vm.parsePostForkBlock(b); err == nil {
 		return blk, nil
@@ -294,6 +348,8 @@ func (vm *VM) SetPreference(preferred ids.ID) error {
 		// until the P-chain's height has advanced.
 		return nil
 	}
+	vm.metrics.proposerSlot.Set(float64(minDelay / proposer.WindowDuration))
+	vm.metrics.parentPChainHeight.Set(float64(pChainHeight))
 	if minDelay < minBlockDelay {
 		minDelay = minBlockDelay
 	}
@@ -770,6 +826,16 @@ func (vm *VM) notifyInnerBlockReady() {
 	}
 }
 
+// markBuildSkipped records that a block build requested by the engine didn't
+// produce a block because of [reason].
+func (vm *VM) markBuildSkipped(reason string) {
+	vm.metrics.numSkipped.WithLabelValues(reason).Inc()
+	vm.lastBuildSkipped = BuildSkipped{
+		Time:   vm.Time(),
+		Reason: reason,
+	}
+}
+
 func (vm *VM) optimalPChainHeight(minPChainHeight uint64) (uint64, error) {
 	minimumHeight, err := vm.ctx.ValidatorState.GetMinimumHeight()
 	if err != nil {
@@ -126,7 +126,7 @@ func initTestProposerVM(
 		}
 	}
 
-	proVM := New(coreVM, proBlkStartTime, minPChainHeight, time.Time{})
+	proVM := New(coreVM, proBlkStartTime, minPChainHeight, time.Time{}, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -865,7 +865,7 @@ func TestExpiredBuildBlock(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0, time.Time{})
+	proVM := New(coreVM, time.Time{}, 0, time.Time{}, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -1195,7 +1195,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	dbManager := manager.NewMemDB(version.Semantic1_0_0)
 
-	proVM := New(coreVM, time.Time{}, 0, time.Time{})
+	proVM := New(coreVM, time.Time{}, 0, time.Time{}, 0)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
@@ -1286,7 +1286,7 @@ func TestInnerVMRollback(t *testing.T) {
 
 	coreBlk.StatusV = choices.Processing
 
-	proVM = New(coreVM, time.Time{}, 0, time.Time{})
+	proVM = New(coreVM, time.Time{}, 0, time.Time{}, 0)
 
 	if err := proVM.Initialize(ctx, dbManager, nil, nil, nil, nil, nil, nil); err != nil {
 		t.Fatalf("failed to initialize proposerVM with %s", err)
@@ -1812,7 +1812,7 @@ func TestRejectedHeightNotIndexed(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0, time.Time{})
+	proVM := New(coreVM, time.Time{}, 0, time.Time{}, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -1990,7 +1990,7 @@ func TestRejectedOptionHeightNotIndexed(t *testing.T) {
 		}
 	}
 
-	proVM := New(coreVM, time.Time{}, 0, time.Time{})
+	proVM := New(coreVM, time.Time{}, 0, time.Time{}, 0)
 
 	valState := &validators.TestState{
 		T: t,
@@ -2128,6 +2128,7 @@ func TestVMInnerBlkCache(t *testing.T) {
 		time.Time{}, // fork is active
 		0,           // minimum P-Chain height
 		time.Time{}, // fork is active
+		0,           // height index repair throttle
 	)
 
 	dummyDBManager := manager.NewMemDB(version.Semantic1_0_0)
@@ -38,6 +38,8 @@ type VMGetterConfig struct {
 	Manager         vms.Manager
 	PluginDirectory string
 	CPUTracker      resource.ProcessTracker
+	// Number of times a plugin process that exits unexpectedly is restarted
+	MaxPluginRestarts int
 }
 
 type vmGetter struct {
@@ -100,6 +102,7 @@ func (getter *vmGetter) Get() (map[ids.ID]vms.Factory, map[ids.ID]vms.Factory, e
 		unregisteredVMs[vmID] = rpcchainvm.NewFactory(
 			filepath.Join(getter.config.PluginDirectory, file.Name()),
 			getter.config.CPUTracker,
+			getter.config.MaxPluginRestarts,
 		)
 	}
 	return registeredVMs, unregisteredVMs, nil
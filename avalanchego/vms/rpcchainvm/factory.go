@@ -13,7 +13,10 @@ import (
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-plugin"
 
+	"google.golang.org/grpc"
+
 	"github.com/ava-labs/avalanchego/snow"
+	"github.com/ava-labs/avalanchego/utils/logging"
 	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/subprocess"
 	"github.com/ava-labs/avalanchego/vms"
@@ -29,16 +32,46 @@ var (
 type factory struct {
 	path           string
 	processTracker resource.ProcessTracker
+	maxRestarts    int
 }
 
-func NewFactory(path string, processTracker resource.ProcessTracker) vms.Factory {
+// NewFactory returns a factory of VMs served by the plugin at [path]. A plugin
+// process that exits unexpectedly is restarted up to [maxRestarts] times.
+func NewFactory(path string, processTracker resource.ProcessTracker, maxRestarts int) vms.Factory {
 	return &factory{
 		path:           path,
 		processTracker: processTracker,
+		maxRestarts:    maxRestarts,
 	}
 }
 
 func (f *factory) New(ctx *snow.Context) (interface{}, error) {
+	client, conn, err := f.start(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var log logging.Logger = logging.NoLog{}
+	if ctx != nil {
+		log = ctx.Log
+	}
+	vm := NewClient(nil)
+	vm.setProcess(ctx, newProcess(log, client, conn, f.processTracker))
+	if f.maxRestarts > 0 {
+		vm.process.enableRestarts(
+			f.maxRestarts,
+			func() (*plugin.Client, *grpc.ClientConn, error) {
+				return f.start(ctx)
+			},
+			vm.replay,
+		)
+	}
+	return vm, nil
+}
+
+// start launches a new instance of the plugin and returns the connection to
+// it.
+func (f *factory) start(ctx *snow.Context) (*plugin.Client, *grpc.ClientConn, error) {
 	config := &plugin.ClientConfig{
 		HandshakeConfig: Handshake,
 		Plugins:         PluginMap,
@@ -83,21 +116,24 @@ func (f *factory) New(ctx *snow.Context) (interface{}, error) {
 	rpcClient, err := client.Client()
 	if err != nil {
 		client.Kill()
-		return nil, pluginErr(err)
+		return nil, nil, pluginErr(err)
 	}
 
 	raw, err := rpcClient.Dispense("vm")
 	if err != nil {
 		client.Kill()
-		return nil, pluginErr(err)
+		return nil, nil, pluginErr(err)
 	}
 
-	vm, ok := raw.(*VMClient)
-	if !ok {
+	if _, ok := raw.(*VMClient); !ok {
 		client.Kill()
-		return nil, pluginErr(errWrongVM)
+		return nil, nil, pluginErr(errWrongVM)
 	}
 
-	vm.SetProcess(ctx, client, f.processTracker)
-	return vm, nil
+	grpcClient, ok := rpcClient.(*plugin.GRPCClient)
+	if !ok {
+		client.Kill()
+		return nil, nil, pluginErr(errWrongVM)
+	}
+	return client, grpcClient.Conn, nil
 }
@@ -0,0 +1,235 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-plugin"
+
+	"go.uber.org/zap"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/resource"
+
+	vmpb "github.com/ava-labs/avalanchego/proto/pb/vm"
+)
+
+const (
+	// exitPollFrequency is how often a request that failed because the plugin
+	// became unavailable checks if the plugin process exited.
+	exitPollFrequency = 10 * time.Millisecond
+	// exitTimeout is how long a request that failed because the plugin became
+	// unavailable waits for the plugin process to exit before giving up on
+	// restarting it.
+	exitTimeout = time.Second
+)
+
+var (
+	errProcessExited   = errors.New("plugin process exited")
+	errTooManyRestarts = errors.New("plugin process exceeded its restart limit")
+
+	_ grpc.ClientConnInterface = &process{}
+	_ http.Handler             = &processHandler{}
+)
+
+// process is the plugin process serving a VMClient.
+//
+// If restarts are enabled, a request that fails because the process exited
+// restarts it. The VM's state is replayed into the new process before the
+// request is retried. Requests are never retried against a process that is
+// still running.
+type process struct {
+	log            logging.Logger
+	processTracker resource.ProcessTracker
+
+	// Starts a new instance of the plugin. Nil if restarts are disabled.
+	start func() (*plugin.Client, *grpc.ClientConn, error)
+	// Maximum number of times the process is restarted.
+	maxRestarts int
+	// Brings a newly started plugin up to the state of the one it replaces.
+	replay func(vmpb.VMClient) error
+
+	lock     sync.RWMutex
+	proc     *plugin.Client
+	pid      int
+	conn     *grpc.ClientConn
+	restarts int
+	// closed is true once the VM started shutting down. A closed process is
+	// never restarted.
+	closed bool
+}
+
+func newProcess(
+	log logging.Logger,
+	proc *plugin.Client,
+	conn *grpc.ClientConn,
+	processTracker resource.ProcessTracker,
+) *process {
+	p := &process{
+		log:            log,
+		processTracker: processTracker,
+		proc:           proc,
+		pid:            proc.ReattachConfig().Pid,
+		conn:           conn,
+	}
+	processTracker.TrackProcess(p.pid)
+	return p
+}
+
+// enableRestarts allows the process to be restarted up to [maxRestarts] times
+// using [start] to launch the new process and [replay] to restore its state.
+func (p *process) enableRestarts(
+	maxRestarts int,
+	start func() (*plugin.Client, *grpc.ClientConn, error),
+	replay func(vmpb.VMClient) error,
+) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.maxRestarts = maxRestarts
+	p.start = start
+	p.replay = replay
+}
+
+func (p *process) Invoke(ctx context.Context, method string, args interface{}, reply interface{}, opts ...grpc.CallOption) error {
+	proc, conn := p.current()
+	err := conn.Invoke(ctx, method, args, reply, opts...)
+	if err == nil || status.Code(err) != codes.Unavailable || !p.waitExited(proc) {
+		return err
+	}
+
+	if err := p.restart(proc); err != nil {
+		return err
+	}
+
+	_, conn = p.current()
+	return conn.Invoke(ctx, method, args, reply, opts...)
+}
+
+func (p *process) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	_, conn := p.current()
+	return conn.NewStream(ctx, desc, method, opts...)
+}
+
+// exited returns true if the current plugin process exited.
+func (p *process) exited() bool {
+	proc, _ := p.current()
+	return proc.Exited()
+}
+
+// close prevents the process from being restarted. It should be called before
+// the plugin is asked to shut down.
+func (p *process) close() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.closed = true
+}
+
+// kill stops the current plugin process.
+func (p *process) kill() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.closed = true
+	p.proc.Kill()
+	p.processTracker.UntrackProcess(p.pid)
+}
+
+func (p *process) current() (*plugin.Client, *grpc.ClientConn) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	return p.proc, p.conn
+}
+
+// waitExited returns true if [proc] exits within [exitTimeout]. The connection
+// to the plugin can break slightly before the exit of the process is noticed.
+func (p *process) waitExited(proc *plugin.Client) bool {
+	ticker := time.NewTicker(exitPollFrequency)
+	defer ticker.Stop()
+
+	deadline := time.Now().Add(exitTimeout)
+	for !proc.Exited() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		<-ticker.C
+	}
+	return true
+}
+
+// restart replaces [exited] with a new plugin process. If [exited] was already
+// replaced, this is a no-op.
+func (p *process) restart(exited *plugin.Client) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	switch {
+	case p.proc != exited:
+		return nil
+	case p.closed, p.start == nil:
+		return errProcessExited
+	case p.restarts >= p.maxRestarts:
+		return fmt.Errorf("%w of %d", errTooManyRestarts, p.maxRestarts)
+	}
+
+	p.restarts++
+	p.log.Warn("restarting plugin process",
+		zap.Int("pid", p.pid),
+		zap.Int("restarts", p.restarts),
+	)
+
+	proc, conn, err := p.start()
+	if err != nil {
+		return fmt.Errorf("couldn't restart plugin process: %w", err)
+	}
+	if err := p.replay(vmpb.NewVMClient(conn)); err != nil {
+		proc.Kill()
+		return fmt.Errorf("couldn't restore state of restarted plugin process: %w", err)
+	}
+
+	p.processTracker.UntrackProcess(p.pid)
+	p.proc = proc
+	p.pid = proc.ReattachConfig().Pid
+	p.conn = conn
+	p.processTracker.TrackProcess(p.pid)
+
+	p.log.Info("restarted plugin process",
+		zap.Int("pid", p.pid),
+	)
+	return nil
+}
+
+// processHandler serves an HTTP handler of the plugin. The handler is replaced
+// when the plugin process is restarted.
+type processHandler struct {
+	lock    sync.RWMutex
+	handler http.Handler
+}
+
+func (h *processHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.lock.RLock()
+	handler := h.handler
+	h.lock.RUnlock()
+
+	handler.ServeHTTP(w, r)
+}
+
+func (h *processHandler) set(handler http.Handler) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	h.handler = handler
+}
@@ -0,0 +1,125 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package rpcchainvm
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/hashicorp/go-plugin"
+
+	"github.com/stretchr/testify/require"
+
+	"google.golang.org/grpc"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block/mocks"
+	"github.com/ava-labs/avalanchego/utils/logging"
+	"github.com/ava-labs/avalanchego/utils/resource"
+)
+
+func restartTestPlugin(t *testing.T, loadExpectations bool) (plugin.Plugin, *gomock.Controller) {
+	// test key is "restartTestKey"
+
+	// create mock
+	ctrl := gomock.NewController(t)
+	ssVM := StateSyncEnabledMock{
+		MockChainVM:         mocks.NewMockChainVM(ctrl),
+		MockStateSyncableVM: mocks.NewMockStateSyncableVM(ctrl),
+	}
+
+	if loadExpectations {
+		ssVM.MockStateSyncableVM.EXPECT().StateSyncEnabled().Return(true, nil).AnyTimes()
+	}
+
+	return New(ssVM), ctrl
+}
+
+// startRestartTestPlugin starts a new instance of the restart test plugin.
+func startRestartTestPlugin() (*plugin.Client, *grpc.ClientConn, error) {
+	c := plugin.NewClient(&plugin.ClientConfig{
+		Cmd:             helperProcess(restartTestKey),
+		HandshakeConfig: TestHandshake,
+		// The VM is only used by the plugin process
+		Plugins:          plugin.PluginSet{restartTestKey: New(nil)},
+		AllowedProtocols: []plugin.Protocol{plugin.ProtocolGRPC},
+	})
+	rpcClient, err := c.Client()
+	if err != nil {
+		c.Kill()
+		return nil, nil, err
+	}
+	return c, rpcClient.(*plugin.GRPCClient).Conn, nil
+}
+
+// crash kills the current plugin process of [p] without going through the
+// plugin client, like an unexpected exit would.
+func crash(require *require.Assertions, p *process) {
+	proc, err := os.FindProcess(p.pid)
+	require.NoError(err)
+	require.NoError(proc.Kill())
+	require.Eventually(p.exited, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessRestart(t *testing.T) {
+	require := require.New(t)
+
+	c, conn, err := startRestartTestPlugin()
+	require.NoError(err)
+
+	vm := NewClient(nil)
+	vm.setProcess(nil, newProcess(
+		logging.NoLog{},
+		c,
+		conn,
+		resource.NewManager("", time.Hour, time.Hour, time.Hour),
+	))
+	vm.process.enableRestarts(1, startRestartTestPlugin, vm.replay)
+	defer vm.process.kill()
+
+	enabled, err := vm.StateSyncEnabled()
+	require.NoError(err)
+	require.True(enabled)
+
+	// The first crash is recovered from by restarting the plugin
+	pid := vm.process.pid
+	crash(require, vm.process)
+
+	enabled, err = vm.StateSyncEnabled()
+	require.NoError(err)
+	require.True(enabled)
+	require.NotEqual(pid, vm.process.pid)
+	require.False(vm.process.exited())
+
+	// The second crash exceeds the restart limit
+	crash(require, vm.process)
+
+	_, err = vm.StateSyncEnabled()
+	require.ErrorIs(err, errTooManyRestarts)
+}
+
+func TestProcessNotRestartedAfterClose(t *testing.T) {
+	require := require.New(t)
+
+	c, conn, err := startRestartTestPlugin()
+	require.NoError(err)
+
+	vm := NewClient(nil)
+	vm.setProcess(nil, newProcess(
+		logging.NoLog{},
+		c,
+		conn,
+		resource.NewManager("", time.Hour, time.Hour, time.Hour),
+	))
+	vm.process.enableRestarts(1, startRestartTestPlugin, vm.replay)
+	defer vm.process.kill()
+
+	vm.process.close()
+	crash(require, vm.process)
+
+	_, err = vm.StateSyncEnabled()
+	require.ErrorIs(err, errProcessExited)
+}
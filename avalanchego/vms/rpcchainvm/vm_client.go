@@ -8,12 +8,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 
-	"github.com/hashicorp/go-plugin"
-
 	"github.com/prometheus/client_golang/prometheus"
 
 	dto "github.com/prometheus/client_model/go"
@@ -39,7 +39,6 @@ import (
 	"github.com/ava-labs/avalanchego/snow/engine/common"
 	"github.com/ava-labs/avalanchego/snow/engine/common/appsender"
 	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
-	"github.com/ava-labs/avalanchego/utils/resource"
 	"github.com/ava-labs/avalanchego/utils/wrappers"
 	"github.com/ava-labs/avalanchego/version"
 	"github.com/ava-labs/avalanchego/vms/components/chain"
@@ -84,10 +83,8 @@ var (
 // VMClient is an implementation of a VM that talks over RPC.
 type VMClient struct {
 	*chain.State
-	client         vmpb.VMClient
-	proc           *plugin.Client
-	pid            int
-	processTracker resource.ProcessTracker
+	client  vmpb.VMClient
+	process *process
 
 	messenger    *messenger.Server
 	keystore     *gkeystore.Server
@@ -102,22 +99,150 @@ type VMClient struct {
 	grpcServerMetrics *grpc_prometheus.ServerMetrics
 
 	ctx *snow.Context
+
+	// The following are replayed into the plugin if its process is restarted.
+	// [replayLock] must be held while accessing them or [conns].
+	replayLock     sync.Mutex
+	initRequest    *vmpb.InitializeRequest
+	state          *snow.State
+	preferredID    ids.ID
+	connected      map[ids.NodeID]*version.Application
+	verifiedBlocks map[ids.ID]*blockClient
+	handlers       map[string]*processHandler
+	staticHandlers map[string]*processHandler
 }
 
 // NewClient returns a VM connected to a remote VM
 func NewClient(client vmpb.VMClient) *VMClient {
 	return &VMClient{
-		client: client,
+		client:         client,
+		connected:      make(map[ids.NodeID]*version.Application),
+		verifiedBlocks: make(map[ids.ID]*blockClient),
 	}
 }
 
-// SetProcess gives ownership of the server process to the client.
-func (vm *VMClient) SetProcess(ctx *snow.Context, proc *plugin.Client, processTracker resource.ProcessTracker) {
+// setProcess gives ownership of the server process to the client. Requests to
+// the VM are sent through [proc].
+func (vm *VMClient) setProcess(ctx *snow.Context, proc *process) {
 	vm.ctx = ctx
-	vm.proc = proc
-	vm.processTracker = processTracker
-	vm.pid = proc.ReattachConfig().Pid
-	processTracker.TrackProcess(vm.pid)
+	vm.process = proc
+	vm.client = vmpb.NewVMClient(proc)
+}
+
+// replay brings [client], which is served by a newly started plugin process, up
+// to the state of the plugin it replaces.
+func (vm *VMClient) replay(client vmpb.VMClient) error {
+	vm.replayLock.Lock()
+	defer vm.replayLock.Unlock()
+
+	if vm.initRequest != nil {
+		if _, err := client.Initialize(context.Background(), vm.initRequest); err != nil {
+			return err
+		}
+	}
+	if vm.state != nil {
+		if _, err := client.SetState(context.Background(), &vmpb.SetStateRequest{
+			State: uint32(*vm.state),
+		}); err != nil {
+			return err
+		}
+	}
+	for nodeID, nodeVersion := range vm.connected {
+		if _, err := client.Connected(context.Background(), &vmpb.ConnectedRequest{
+			NodeId:  nodeID[:],
+			Version: nodeVersion.String(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Blocks must be verified after their parents.
+	verifiedBlocks := make([]*blockClient, 0, len(vm.verifiedBlocks))
+	for _, blk := range vm.verifiedBlocks {
+		verifiedBlocks = append(verifiedBlocks, blk)
+	}
+	sort.Slice(verifiedBlocks, func(i, j int) bool {
+		return verifiedBlocks[i].height < verifiedBlocks[j].height
+	})
+	for _, blk := range verifiedBlocks {
+		if _, err := client.ParseBlock(context.Background(), &vmpb.ParseBlockRequest{
+			Bytes: blk.bytes,
+		}); err != nil {
+			return err
+		}
+		if _, err := client.BlockVerify(context.Background(), &vmpb.BlockVerifyRequest{
+			Bytes: blk.bytes,
+		}); err != nil {
+			return fmt.Errorf("couldn't verify block %s: %w", blk.id, err)
+		}
+	}
+	if vm.preferredID != ids.Empty {
+		if _, err := client.SetPreference(context.Background(), &vmpb.SetPreferenceRequest{
+			Id: vm.preferredID[:],
+		}); err != nil {
+			return err
+		}
+	}
+
+	if vm.handlers != nil {
+		resp, err := client.CreateHandlers(context.Background(), &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		if err := vm.redialHandlers(resp.Handlers, vm.handlers); err != nil {
+			return err
+		}
+	}
+	if vm.staticHandlers != nil {
+		resp, err := client.CreateStaticHandlers(context.Background(), &emptypb.Empty{})
+		if err != nil {
+			return err
+		}
+		if err := vm.redialHandlers(resp.Handlers, vm.staticHandlers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redialHandlers points [processHandlers] to the HTTP servers of a restarted
+// plugin. The set of handlers is fixed once they are registered, so handlers
+// that the restarted plugin added are ignored.
+//
+// Assumes [vm.replayLock] is held.
+func (vm *VMClient) redialHandlers(handlers []*vmpb.Handler, processHandlers map[string]*processHandler) error {
+	for _, handler := range handlers {
+		processHandler, ok := processHandlers[handler.Prefix]
+		if !ok {
+			vm.process.log.Warn("dropping HTTP handler added by restarted plugin",
+				zap.String("prefix", handler.Prefix),
+			)
+			continue
+		}
+
+		clientConn, err := grpcutils.Dial(handler.ServerAddr)
+		if err != nil {
+			return err
+		}
+
+		vm.conns = append(vm.conns, clientConn)
+		processHandler.set(ghttp.NewClient(httppb.NewHTTPClient(clientConn)))
+	}
+	return nil
+}
+
+func (vm *VMClient) trackVerified(blk *blockClient) {
+	vm.replayLock.Lock()
+	defer vm.replayLock.Unlock()
+
+	vm.verifiedBlocks[blk.id] = blk
+}
+
+func (vm *VMClient) untrackVerified(blkID ids.ID) {
+	vm.replayLock.Lock()
+	defer vm.replayLock.Unlock()
+
+	delete(vm.verifiedBlocks, blkID)
 }
 
 func (vm *VMClient) Initialize(
@@ -193,7 +318,7 @@ func (vm *VMClient) Initialize(
 		zap.String("address", serverAddr),
 	)
 
-	resp, err := vm.client.Initialize(context.Background(), &vmpb.InitializeRequest{
+	initRequest := &vmpb.InitializeRequest{
 		NetworkId:    ctx.NetworkID,
 		SubnetId:     ctx.SubnetID[:],
 		ChainId:      ctx.ChainID[:],
@@ -205,11 +330,16 @@ func (vm *VMClient) Initialize(
 		ConfigBytes:  configBytes,
 		DbServers:    versionedDBServers,
 		ServerAddr:   serverAddr,
-	})
+	}
+	resp, err := vm.client.Initialize(context.Background(), initRequest)
 	if err != nil {
 		return err
 	}
 
+	vm.replayLock.Lock()
+	vm.initRequest = initRequest
+	vm.replayLock.Unlock()
+
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
 		return err
@@ -335,6 +465,10 @@ func (vm *VMClient) SetState(state snow.State) error {
 		return err
 	}
 
+	vm.replayLock.Lock()
+	vm.state = &state
+	vm.replayLock.Unlock()
+
 	id, err := ids.ToID(resp.LastAcceptedId)
 	if err != nil {
 		return err
@@ -362,17 +496,21 @@ func (vm *VMClient) SetState(state snow.State) error {
 }
 
 func (vm *VMClient) Shutdown() error {
+	// A plugin that exits while shutting down shouldn't be restarted.
+	vm.process.close()
+
 	errs := wrappers.Errs{}
 	_, err := vm.client.Shutdown(context.Background(), &emptypb.Empty{})
 	errs.Add(err)
 
 	vm.serverCloser.Stop()
+	vm.replayLock.Lock()
 	for _, conn := range vm.conns {
 		errs.Add(conn.Close())
 	}
+	vm.replayLock.Unlock()
 
-	vm.proc.Kill()
-	vm.processTracker.UntrackProcess(vm.pid)
+	vm.process.kill()
 	return errs.Err
 }
 
@@ -382,20 +520,14 @@ func (vm *VMClient) CreateHandlers() (map[string]*common.HTTPHandler, error) {
 		return nil, err
 	}
 
-	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
-	for _, handler := range resp.Handlers {
-		clientConn, err := grpcutils.Dial(handler.ServerAddr)
-		if err != nil {
-			return nil, err
-		}
+	vm.replayLock.Lock()
+	defer vm.replayLock.Unlock()
 
-		vm.conns = append(vm.conns, clientConn)
-		handlers[handler.Prefix] = &common.HTTPHandler{
-			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
-		}
+	vm.handlers, err = vm.dialHandlers(resp.Handlers)
+	if err != nil {
+		return nil, err
 	}
-	return handlers, nil
+	return newHTTPHandlers(resp.Handlers, vm.handlers), nil
 }
 
 func (vm *VMClient) CreateStaticHandlers() (map[string]*common.HTTPHandler, error) {
@@ -404,20 +536,44 @@ func (vm *VMClient) CreateStaticHandlers() (map[string]*common.HTTPHandler, erro
 		return nil, err
 	}
 
-	handlers := make(map[string]*common.HTTPHandler, len(resp.Handlers))
-	for _, handler := range resp.Handlers {
+	vm.replayLock.Lock()
+	defer vm.replayLock.Unlock()
+
+	vm.staticHandlers, err = vm.dialHandlers(resp.Handlers)
+	if err != nil {
+		return nil, err
+	}
+	return newHTTPHandlers(resp.Handlers, vm.staticHandlers), nil
+}
+
+// dialHandlers connects to the HTTP servers of the plugin.
+//
+// Assumes [vm.replayLock] is held.
+func (vm *VMClient) dialHandlers(handlers []*vmpb.Handler) (map[string]*processHandler, error) {
+	processHandlers := make(map[string]*processHandler, len(handlers))
+	for _, handler := range handlers {
 		clientConn, err := grpcutils.Dial(handler.ServerAddr)
 		if err != nil {
 			return nil, err
 		}
 
 		vm.conns = append(vm.conns, clientConn)
-		handlers[handler.Prefix] = &common.HTTPHandler{
+		processHandlers[handler.Prefix] = &processHandler{
+			handler: ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+		}
+	}
+	return processHandlers, nil
+}
+
+func newHTTPHandlers(handlers []*vmpb.Handler, processHandlers map[string]*processHandler) map[string]*common.HTTPHandler {
+	httpHandlers := make(map[string]*common.HTTPHandler, len(handlers))
+	for _, handler := range handlers {
+		httpHandlers[handler.Prefix] = &common.HTTPHandler{
 			LockOptions: common.LockOption(handler.LockOptions),
-			Handler:     ghttp.NewClient(httppb.NewHTTPClient(clientConn)),
+			Handler:     processHandlers[handler.Prefix],
 		}
 	}
-	return handlers, nil
+	return httpHandlers
 }
 
 func (vm *VMClient) Connected(nodeID ids.NodeID, nodeVersion *version.Application) error {
@@ -425,14 +581,28 @@ func (vm *VMClient) Connected(nodeID ids.NodeID, nodeVersion *version.Applicatio
 		NodeId:  nodeID[:],
 		Version: nodeVersion.String(),
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	vm.replayLock.Lock()
+	vm.connected[nodeID] = nodeVersion
+	vm.replayLock.Unlock()
+	return nil
 }
 
 func (vm *VMClient) Disconnected(nodeID ids.NodeID) error {
 	_, err := vm.client.Disconnected(context.Background(), &vmpb.DisconnectedRequest{
 		NodeId: nodeID[:],
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	vm.replayLock.Lock()
+	delete(vm.connected, nodeID)
+	vm.replayLock.Unlock()
+	return nil
 }
 
 func (vm *VMClient) buildBlock() (snowman.Block, error) {
@@ -535,12 +705,24 @@ func (vm *VMClient) SetPreference(id ids.ID) error {
 	_, err := vm.client.SetPreference(context.Background(), &vmpb.SetPreferenceRequest{
 		Id: id[:],
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	vm.replayLock.Lock()
+	vm.preferredID = id
+	vm.replayLock.Unlock()
+	return nil
 }
 
+// HealthCheck reports the health of the plugin. If the plugin process exited,
+// the health check restarts it if possible and fails otherwise.
 func (vm *VMClient) HealthCheck() (interface{}, error) {
 	health, err := vm.client.Health(context.Background(), &emptypb.Empty{})
 	if err != nil {
+		if vm.process.exited() {
+			return nil, fmt.Errorf("health check failed: %w: %s", errProcessExited, err)
+		}
 		return nil, fmt.Errorf("health check failed: %w", err)
 	}
 
@@ -815,6 +997,7 @@ func (b *blockClient) ID() ids.ID { return b.id }
 
 func (b *blockClient) Accept() error {
 	b.status = choices.Accepted
+	b.vm.untrackVerified(b.id)
 	_, err := b.vm.client.BlockAccept(context.Background(), &vmpb.BlockAcceptRequest{
 		Id: b.id[:],
 	})
@@ -823,6 +1006,7 @@ func (b *blockClient) Accept() error {
 
 func (b *blockClient) Reject() error {
 	b.status = choices.Rejected
+	b.vm.untrackVerified(b.id)
 	_, err := b.vm.client.BlockReject(context.Background(), &vmpb.BlockRejectRequest{
 		Id: b.id[:],
 	})
@@ -842,7 +1026,12 @@ func (b *blockClient) Verify() error {
 	}
 
 	b.time, err = grpcutils.TimestampAsTime(resp.Timestamp)
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.vm.trackVerified(b)
+	return nil
 }
 
 func (b *blockClient) Bytes() []byte        { return b.bytes }
@@ -0,0 +1,80 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+)
+
+var errSigIndexOutOfBounds = errors.New("signature index out of bounds")
+
+// WatchKeychain is a collection of addresses whose outputs can be consumed
+// without holding their keys. The inputs it creates must be signed
+// externally.
+type WatchKeychain struct {
+	Addrs ids.ShortSet
+}
+
+// NewWatchKeychain returns a new watch-only keychain containing [addrs]
+func NewWatchKeychain(addrs ...ids.ShortID) *WatchKeychain {
+	kc := &WatchKeychain{}
+	kc.Addrs.Add(addrs...)
+	return kc
+}
+
+// Spend attempts to create an input. No signers are ever returned.
+func (kc *WatchKeychain) Spend(out verify.Verifiable, time uint64) (verify.Verifiable, []*crypto.PrivateKeySECP256K1R, error) {
+	switch out := out.(type) {
+	case *MintOutput:
+		if sigIndices, able := kc.Match(&out.OutputOwners, time); able {
+			return &Input{
+				SigIndices: sigIndices,
+			}, nil, nil
+		}
+		return nil, nil, errCantSpend
+	case *TransferOutput:
+		if sigIndices, able := kc.Match(&out.OutputOwners, time); able {
+			return &TransferInput{
+				Amt: out.Amt,
+				Input: Input{
+					SigIndices: sigIndices,
+				},
+			}, nil, nil
+		}
+		return nil, nil, errCantSpend
+	}
+	return nil, nil, fmt.Errorf("can't spend UTXO because it is unexpected type %T", out)
+}
+
+// Match attempts to match a list of addresses up to the provided threshold
+func (kc *WatchKeychain) Match(owners *OutputOwners, time uint64) ([]uint32, bool) {
+	if time < owners.Locktime {
+		return nil, false
+	}
+	sigs := make([]uint32, 0, owners.Threshold)
+	for i := uint32(0); i < uint32(len(owners.Addrs)) && uint32(len(sigs)) < owners.Threshold; i++ {
+		if kc.Addrs.Contains(owners.Addrs[i]) {
+			sigs = append(sigs, i)
+		}
+	}
+	return sigs, uint32(len(sigs)) == owners.Threshold
+}
+
+// SigningAddresses returns the addresses that must sign [in] to spend an output
+// owned by [owners], in the order of the signatures.
+func SigningAddresses(in *Input, owners *OutputOwners) ([]ids.ShortID, error) {
+	addrs := make([]ids.ShortID, len(in.SigIndices))
+	for i, sigIndex := range in.SigIndices {
+		if sigIndex >= uint32(len(owners.Addrs)) {
+			return nil, errSigIndexOutOfBounds
+		}
+		addrs[i] = owners.Addrs[sigIndex]
+	}
+	return addrs, nil
+}
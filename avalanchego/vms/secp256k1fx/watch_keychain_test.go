@@ -0,0 +1,52 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package secp256k1fx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+)
+
+func TestWatchKeychainSpend(t *testing.T) {
+	require := require.New(t)
+
+	addr0 := ids.GenerateTestShortID()
+	addr1 := ids.GenerateTestShortID()
+	unknownAddr := ids.GenerateTestShortID()
+	kc := NewWatchKeychain(addr0, addr1)
+
+	out := &TransferOutput{
+		Amt: 12345,
+		OutputOwners: OutputOwners{
+			Locktime:  10,
+			Threshold: 2,
+			Addrs:     []ids.ShortID{addr1, unknownAddr, addr0},
+		},
+	}
+
+	_, _, err := kc.Spend(out, 9)
+	require.ErrorIs(err, errCantSpend)
+
+	inIntf, signers, err := kc.Spend(out, 10)
+	require.NoError(err)
+	require.Empty(signers)
+	in, ok := inIntf.(*TransferInput)
+	require.True(ok)
+	require.Equal(uint64(12345), in.Amt)
+	require.Equal([]uint32{0, 2}, in.SigIndices)
+
+	signingAddrs, err := SigningAddresses(&in.Input, &out.OutputOwners)
+	require.NoError(err)
+	require.Equal([]ids.ShortID{addr1, addr0}, signingAddrs)
+
+	_, err = SigningAddresses(&Input{SigIndices: []uint32{3}}, &out.OutputOwners)
+	require.ErrorIs(err, errSigIndexOutOfBounds)
+
+	out.Threshold = 3
+	_, _, err = kc.Spend(out, 10)
+	require.ErrorIs(err, errCantSpend)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) GetAncestors(
+	blkID ids.ID,
+	maxBlocksNum int,
+	maxBlocksSize int,
+	maxBlocksRetrivalTime time.Duration,
+) ([][]byte, error) {
+	if vm.bVM == nil {
+		return nil, block.ErrRemoteVMNotImplemented
+	}
+
+	span := vm.start("GetAncestors",
+		attribute.Stringer("blkID", blkID),
+		attribute.Int("maxBlocksNum", maxBlocksNum),
+	)
+	defer span.End()
+
+	ancestors, err := vm.bVM.GetAncestors(
+		blkID,
+		maxBlocksNum,
+		maxBlocksSize,
+		maxBlocksRetrivalTime,
+	)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return ancestors, err
+}
+
+func (vm *blockVM) BatchedParseBlock(blks [][]byte) ([]snowman.Block, error) {
+	if vm.bVM == nil {
+		return nil, block.ErrRemoteVMNotImplemented
+	}
+
+	span := vm.start("BatchedParseBlock", attribute.Int("numBlocks", len(blks)))
+	defer span.End()
+
+	blocks, err := vm.bVM.BatchedParseBlock(blks)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	wrappedBlocks := make([]snowman.Block, len(blocks))
+	for i, block := range blocks {
+		wrappedBlocks[i] = &tracedBlock{
+			Block: block,
+			vm:    vm,
+		}
+	}
+	return wrappedBlocks, err
+}
@@ -0,0 +1,85 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+)
+
+var (
+	_ snowman.Block       = &tracedBlock{}
+	_ snowman.OracleBlock = &tracedBlock{}
+)
+
+type tracedBlock struct {
+	snowman.Block
+
+	vm *blockVM
+}
+
+func (tb *tracedBlock) Verify() error {
+	span := tb.vm.start("Verify",
+		attribute.Stringer("blkID", tb.ID()),
+		attribute.Int64("height", int64(tb.Height())),
+	)
+	defer span.End()
+
+	err := tb.Block.Verify()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+func (tb *tracedBlock) Accept() error {
+	span := tb.vm.start("Accept",
+		attribute.Stringer("blkID", tb.ID()),
+		attribute.Int64("height", int64(tb.Height())),
+	)
+	defer span.End()
+
+	err := tb.Block.Accept()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+func (tb *tracedBlock) Reject() error {
+	span := tb.vm.start("Reject",
+		attribute.Stringer("blkID", tb.ID()),
+		attribute.Int64("height", int64(tb.Height())),
+	)
+	defer span.End()
+
+	err := tb.Block.Reject()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
+
+func (tb *tracedBlock) Options() ([2]snowman.Block, error) {
+	oracleBlock, ok := tb.Block.(snowman.OracleBlock)
+	if !ok {
+		return [2]snowman.Block{}, snowman.ErrNotOracle
+	}
+
+	blks, err := oracleBlock.Options()
+	if err != nil {
+		return [2]snowman.Block{}, err
+	}
+	return [2]snowman.Block{
+		&tracedBlock{
+			Block: blks[0],
+			vm:    tb.vm,
+		},
+		&tracedBlock{
+			Block: blks[1],
+			vm:    tb.vm,
+		},
+	}, nil
+}
@@ -0,0 +1,119 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/consensus/snowman"
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+	"github.com/ava-labs/avalanchego/trace"
+)
+
+var (
+	_ block.ChainVM              = &blockVM{}
+	_ block.BatchedChainVM       = &blockVM{}
+	_ block.HeightIndexedChainVM = &blockVM{}
+	_ block.StateSyncableVM      = &blockVM{}
+)
+
+type blockVM struct {
+	block.ChainVM
+	bVM  block.BatchedChainVM
+	hVM  block.HeightIndexedChainVM
+	ssVM block.StateSyncableVM
+
+	tracer trace.Tracer
+	// The context of the message the chain is handling, which the spans of
+	// the VM calls are parented to
+	scope *trace.Scope
+}
+
+func NewBlockVM(vm block.ChainVM, tracer trace.Tracer, scope *trace.Scope) block.ChainVM {
+	bVM, _ := vm.(block.BatchedChainVM)
+	hVM, _ := vm.(block.HeightIndexedChainVM)
+	ssVM, _ := vm.(block.StateSyncableVM)
+	return &blockVM{
+		ChainVM: vm,
+		bVM:     bVM,
+		hVM:     hVM,
+		ssVM:    ssVM,
+		tracer:  tracer,
+		scope:   scope,
+	}
+}
+
+// start starts the span of the VM call [name].
+func (vm *blockVM) start(name string, attrs ...attribute.KeyValue) oteltrace.Span {
+	_, span := vm.tracer.Start(
+		vm.scope.Context(),
+		fmt.Sprintf("vm.%s", name),
+		oteltrace.WithAttributes(attrs...),
+	)
+	return span
+}
+
+func (vm *blockVM) BuildBlock() (snowman.Block, error) {
+	span := vm.start("BuildBlock")
+	defer span.End()
+
+	blk, err := vm.ChainVM.BuildBlock()
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Stringer("blkID", blk.ID()))
+	return &tracedBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
+
+func (vm *blockVM) ParseBlock(b []byte) (snowman.Block, error) {
+	span := vm.start("ParseBlock", attribute.Int("size", len(b)))
+	defer span.End()
+
+	blk, err := vm.ChainVM.ParseBlock(b)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Stringer("blkID", blk.ID()))
+	return &tracedBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
+
+func (vm *blockVM) GetBlock(id ids.ID) (snowman.Block, error) {
+	span := vm.start("GetBlock", attribute.Stringer("blkID", id))
+	defer span.End()
+
+	blk, err := vm.ChainVM.GetBlock(id)
+	if err != nil {
+		// Looking up unknown blocks is expected, so it isn't recorded as an
+		// error.
+		return nil, err
+	}
+	return &tracedBlock{
+		Block: blk,
+		vm:    vm,
+	}, nil
+}
+
+func (vm *blockVM) SetPreference(id ids.ID) error {
+	span := vm.start("SetPreference", attribute.Stringer("blkID", id))
+	defer span.End()
+
+	err := vm.ChainVM.SetPreference(id)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package tracedvm
+
+import (
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ava-labs/avalanchego/snow/engine/snowman/block"
+)
+
+func (vm *blockVM) StateSyncEnabled() (bool, error) {
+	if vm.ssVM == nil {
+		return false, nil
+	}
+	return vm.ssVM.StateSyncEnabled()
+}
+
+func (vm *blockVM) GetOngoingSyncStateSummary() (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+	return vm.ssVM.GetOngoingSyncStateSummary()
+}
+
+func (vm *blockVM) GetLastStateSummary() (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
+	span := vm.start("GetLastStateSummary")
+	defer span.End()
+
+	summary, err := vm.ssVM.GetLastStateSummary()
+	if err != nil {
+		span.RecordError(err)
+	}
+	return summary, err
+}
+
+func (vm *blockVM) ParseStateSummary(summaryBytes []byte) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
+	span := vm.start("ParseStateSummary", attribute.Int("size", len(summaryBytes)))
+	defer span.End()
+
+	summary, err := vm.ssVM.ParseStateSummary(summaryBytes)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return summary, err
+}
+
+func (vm *blockVM) GetStateSummary(height uint64) (block.StateSummary, error) {
+	if vm.ssVM == nil {
+		return nil, block.ErrStateSyncableVMNotImplemented
+	}
+
+	span := vm.start("GetStateSummary", attribute.Int64("height", int64(height)))
+	defer span.End()
+
+	summary, err := vm.ssVM.GetStateSummary(height)
+	if err != nil {
+		span.RecordError(err)
+	}
+	return summary, err
+}
@@ -23,11 +23,22 @@ type backendVisitor struct {
 
 func (*backendVisitor) AdvanceTimeTx(*txs.AdvanceTimeTx) error         { return errUnsupportedTxType }
 func (*backendVisitor) RewardValidatorTx(*txs.RewardValidatorTx) error { return errUnsupportedTxType }
+func (*backendVisitor) RewardContinuousValidatorTx(*txs.RewardContinuousValidatorTx) error {
+	return errUnsupportedTxType
+}
 
 func (b *backendVisitor) AddValidatorTx(tx *txs.AddValidatorTx) error {
 	return b.baseTx(&tx.BaseTx)
 }
 
+func (b *backendVisitor) AddContinuousValidatorTx(tx *txs.AddContinuousValidatorTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
+func (b *backendVisitor) AddDelegatorsTx(tx *txs.AddDelegatorsTx) error {
+	return b.baseTx(&tx.BaseTx)
+}
+
 func (b *backendVisitor) AddSubnetValidatorTx(tx *txs.AddSubnetValidatorTx) error {
 	return b.baseTx(&tx.BaseTx)
 }
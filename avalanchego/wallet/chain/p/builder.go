@@ -77,6 +77,26 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.AddValidatorTx, error)
 
+	// NewAddContinuousValidatorTx creates a new validator of the primary
+	// network that is automatically restaked when its validation period ends.
+	//
+	// - [vdr] specifies all the details of the first validation period such as
+	//   the startTime, endTime, stake weight, and nodeID.
+	// - [rewardsOwner] specifies the owner of all the rewards this validator
+	//   may accrue during its validation periods.
+	// - [shares] specifies the fraction (out of 1,000,000) that this validator
+	//   will take from delegation rewards. If 1,000,000 is provided, 100% of
+	//   the delegation reward will be sent to the validator's [rewardsOwner].
+	// - [restakePeriods] specifies the number of times the validator is
+	//   restaked for a period of the same duration.
+	NewAddContinuousValidatorTx(
+		vdr *validator.Validator,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		shares uint32,
+		restakePeriods uint32,
+		options ...common.Option,
+	) (*txs.AddContinuousValidatorTx, error)
+
 	// NewAddSubnetValidatorTx creates a new validator of a subnet.
 	//
 	// - [vdr] specifies all the details of the validation period such as the
@@ -107,6 +127,22 @@ type Builder interface {
 		options ...common.Option,
 	) (*txs.AddDelegatorTx, error)
 
+	// NewAddDelegatorsTx creates new delegators to several validators on the
+	// primary network, over the same delegation period.
+	//
+	// - [start] and [end] specify the delegation period, in unix seconds.
+	// - [delegations] specifies the nodeIDs of the validators and the stake
+	//   weight delegated to each of them.
+	// - [rewardsOwner] specifies the owner of all the rewards these delegators
+	//   may accrue at the end of the delegation period.
+	NewAddDelegatorsTx(
+		start uint64,
+		end uint64,
+		delegations []*txs.Delegation,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (*txs.AddDelegatorsTx, error)
+
 	// NewCreateChainTx creates a new chain in the named subnet.
 	//
 	// - [subnetID] specifies the subnet to launch the chain in.
@@ -357,6 +393,23 @@ func (b *builder) NewAddValidatorTx(
 	}, nil
 }
 
+func (b *builder) NewAddContinuousValidatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	restakePeriods uint32,
+	options ...common.Option,
+) (*txs.AddContinuousValidatorTx, error) {
+	utx, err := b.NewAddValidatorTx(vdr, rewardsOwner, shares, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &txs.AddContinuousValidatorTx{
+		AddValidatorTx: *utx,
+		RestakePeriods: restakePeriods,
+	}, nil
+}
+
 func (b *builder) NewAddSubnetValidatorTx(
 	vdr *validator.SubnetValidator,
 	options ...common.Option,
@@ -456,6 +509,52 @@ func (b *builder) NewAddDelegatorTx(
 	}, nil
 }
 
+func (b *builder) NewAddDelegatorsTx(
+	start uint64,
+	end uint64,
+	delegations []*txs.Delegation,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddDelegatorsTx, error) {
+	totalWeight := uint64(0)
+	for _, delegation := range delegations {
+		newWeight, err := math.Add64(totalWeight, delegation.Wght)
+		if err != nil {
+			return nil, err
+		}
+		totalWeight = newWeight
+	}
+
+	avaxAssetID := b.backend.AVAXAssetID()
+	toBurn := map[ids.ID]uint64{
+		avaxAssetID: b.backend.AddPrimaryNetworkDelegatorFee(),
+	}
+	toStake := map[ids.ID]uint64{
+		avaxAssetID: totalWeight,
+	}
+	ops := common.NewOptions(options)
+	inputs, baseOutputs, stakeOutputs, err := b.spend(toBurn, toStake, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	ids.SortShortIDs(rewardsOwner.Addrs)
+	return &txs.AddDelegatorsTx{
+		BaseTx: txs.BaseTx{BaseTx: avax.BaseTx{
+			NetworkID:    b.backend.NetworkID(),
+			BlockchainID: constants.PlatformChainID,
+			Ins:          inputs,
+			Outs:         baseOutputs,
+			Memo:         ops.Memo(),
+		}},
+		Start:                  start,
+		End:                    end,
+		Delegations:            delegations,
+		StakeOuts:              stakeOutputs,
+		DelegationRewardsOwner: rewardsOwner,
+	}, nil
+}
+
 func (b *builder) NewCreateChainTx(
 	subnetID ids.ID,
 	genesis []byte,
@@ -68,6 +68,22 @@ func (b *builderWithOptions) NewAddValidatorTx(
 	)
 }
 
+func (b *builderWithOptions) NewAddContinuousValidatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	restakePeriods uint32,
+	options ...common.Option,
+) (*txs.AddContinuousValidatorTx, error) {
+	return b.Builder.NewAddContinuousValidatorTx(
+		vdr,
+		rewardsOwner,
+		shares,
+		restakePeriods,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewAddSubnetValidatorTx(
 	vdr *validator.SubnetValidator,
 	options ...common.Option,
@@ -102,6 +118,22 @@ func (b *builderWithOptions) NewAddDelegatorTx(
 	)
 }
 
+func (b *builderWithOptions) NewAddDelegatorsTx(
+	start uint64,
+	end uint64,
+	delegations []*txs.Delegation,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (*txs.AddDelegatorsTx, error) {
+	return b.Builder.NewAddDelegatorsTx(
+		start,
+		end,
+		delegations,
+		rewardsOwner,
+		common.UnionOptions(b.options, options)...,
+	)
+}
+
 func (b *builderWithOptions) NewCreateChainTx(
 	subnetID ids.ID,
 	genesis []byte,
@@ -61,6 +61,27 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueAddContinuousValidatorTx creates, signs, and issues a new validator
+	// of the primary network that is automatically restaked when its
+	// validation period ends.
+	//
+	// - [vdr] specifies all the details of the first validation period such as
+	//   the startTime, endTime, stake weight, and nodeID.
+	// - [rewardsOwner] specifies the owner of all the rewards this validator
+	//   may accrue during its validation periods.
+	// - [shares] specifies the fraction (out of 1,000,000) that this validator
+	//   will take from delegation rewards. If 1,000,000 is provided, 100% of
+	//   the delegation reward will be sent to the validator's [rewardsOwner].
+	// - [restakePeriods] specifies the number of times the validator is
+	//   restaked for a period of the same duration.
+	IssueAddContinuousValidatorTx(
+		vdr *validator.Validator,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		shares uint32,
+		restakePeriods uint32,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueAddSubnetValidatorTx creates, signs, and issues a new validator of a
 	// subnet.
 	//
@@ -94,6 +115,22 @@ type Wallet interface {
 		options ...common.Option,
 	) (ids.ID, error)
 
+	// IssueAddDelegatorsTx creates, signs, and issues new delegators to several
+	// validators on the primary network, over the same delegation period.
+	//
+	// - [start] and [end] specify the delegation period, in unix seconds.
+	// - [delegations] specifies the nodeIDs of the validators and the stake
+	//   weight delegated to each of them.
+	// - [rewardsOwner] specifies the owner of all the rewards these delegators
+	//   may accrue at the end of the delegation period.
+	IssueAddDelegatorsTx(
+		start uint64,
+		end uint64,
+		delegations []*txs.Delegation,
+		rewardsOwner *secp256k1fx.OutputOwners,
+		options ...common.Option,
+	) (ids.ID, error)
+
 	// IssueCreateChainTx creates, signs, and issues a new chain in the named
 	// subnet.
 	//
@@ -294,6 +331,20 @@ func (w *wallet) IssueAddValidatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueAddContinuousValidatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	restakePeriods uint32,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewAddContinuousValidatorTx(vdr, rewardsOwner, shares, restakePeriods, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueAddSubnetValidatorTx(
 	vdr *validator.SubnetValidator,
 	options ...common.Option,
@@ -329,6 +380,20 @@ func (w *wallet) IssueAddDelegatorTx(
 	return w.IssueUnsignedTx(utx, options...)
 }
 
+func (w *wallet) IssueAddDelegatorsTx(
+	start uint64,
+	end uint64,
+	delegations []*txs.Delegation,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	utx, err := w.builder.NewAddDelegatorsTx(start, end, delegations, rewardsOwner, options...)
+	if err != nil {
+		return ids.Empty, err
+	}
+	return w.IssueUnsignedTx(utx, options...)
+}
+
 func (w *wallet) IssueCreateChainTx(
 	subnetID ids.ID,
 	genesis []byte,
@@ -63,6 +63,22 @@ func (w *walletWithOptions) IssueAddValidatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueAddContinuousValidatorTx(
+	vdr *validator.Validator,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	shares uint32,
+	restakePeriods uint32,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueAddContinuousValidatorTx(
+		vdr,
+		rewardsOwner,
+		shares,
+		restakePeriods,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueAddSubnetValidatorTx(
 	vdr *validator.SubnetValidator,
 	options ...common.Option,
@@ -97,6 +113,22 @@ func (w *walletWithOptions) IssueAddDelegatorTx(
 	)
 }
 
+func (w *walletWithOptions) IssueAddDelegatorsTx(
+	start uint64,
+	end uint64,
+	delegations []*txs.Delegation,
+	rewardsOwner *secp256k1fx.OutputOwners,
+	options ...common.Option,
+) (ids.ID, error) {
+	return w.Wallet.IssueAddDelegatorsTx(
+		start,
+		end,
+		delegations,
+		rewardsOwner,
+		common.UnionOptions(w.options, options)...,
+	)
+}
+
 func (w *walletWithOptions) IssueCreateChainTx(
 	subnetID ids.ID,
 	genesis []byte,
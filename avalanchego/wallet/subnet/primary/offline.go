@@ -0,0 +1,248 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/avm/fxs"
+	"github.com/ava-labs/avalanchego/vms/components/verify"
+	"github.com/ava-labs/avalanchego/vms/nftfx"
+	"github.com/ava-labs/avalanchego/vms/propertyfx"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	ptxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errMissingSignature      = errors.New("missing signature")
+	errUnknownCredentialType = errors.New("unknown credential type")
+	errUTXONotSpendable      = errors.New("UTXO isn't spendable")
+
+	emptySig [crypto.SECP256K1RSigLen]byte
+
+	_ OfflineWallet = &offlineWallet{}
+	_ Broadcaster   = &broadcaster{}
+)
+
+// OfflineWallet builds and signs txs against the UTXOs recorded in a
+// [Snapshot], without connecting to a node. The txs are later issued through a
+// [Broadcaster].
+type OfflineWallet interface {
+	P() OfflinePWallet
+	X() OfflineXWallet
+}
+
+// OfflinePWallet builds and signs P-chain txs. Accepting a tx built by the
+// wallet marks the UTXOs it consumes as spent, so that they aren't spent again
+// by the next tx.
+type OfflinePWallet interface {
+	p.Backend
+
+	Builder() p.Builder
+	Signer() p.Signer
+}
+
+// OfflineXWallet builds and signs X-chain txs. Accepting a tx built by the
+// wallet marks the UTXOs it consumes as spent, so that they aren't spent again
+// by the next tx.
+type OfflineXWallet interface {
+	x.Backend
+
+	Builder() x.Builder
+	Signer() x.Signer
+}
+
+type offlineWallet struct {
+	p *offlinePWallet
+	x *offlineXWallet
+}
+
+func (w *offlineWallet) P() OfflinePWallet { return w.p }
+func (w *offlineWallet) X() OfflineXWallet { return w.x }
+
+type offlinePWallet struct {
+	p.Backend
+	builder p.Builder
+	signer  p.Signer
+}
+
+func (w *offlinePWallet) Builder() p.Builder { return w.builder }
+func (w *offlinePWallet) Signer() p.Signer   { return w.signer }
+
+type offlineXWallet struct {
+	x.Backend
+	builder x.Builder
+	signer  x.Signer
+}
+
+func (w *offlineXWallet) Builder() x.Builder { return w.builder }
+func (w *offlineXWallet) Signer() x.Signer   { return w.signer }
+
+// NewOfflineWallet returns a wallet that builds txs spending the UTXOs of
+// [snapshot].
+//
+// The txs are signed with the keys of [kc], which may be nil. If [kc] doesn't
+// hold all the keys needed to spend a UTXO, the signatures of the missing keys
+// are left empty. The partially signed tx can then be serialized and signed by
+// an offline wallet holding the missing keys, using Signer().Sign. P-chain txs
+// authorized by a subnet owner aren't supported, as the snapshot doesn't
+// include the subnet txs.
+func NewOfflineWallet(snapshot *Snapshot, kc *secp256k1fx.Keychain) (OfflineWallet, error) {
+	pCTX, xCTX, utxos, err := snapshot.State()
+	if err != nil {
+		return nil, err
+	}
+	if kc == nil {
+		kc = secp256k1fx.NewKeychain()
+	}
+	addrs := ids.NewShortSet(len(snapshot.Addrs))
+	addrs.Add(snapshot.Addrs...)
+
+	pUTXOs := NewChainUTXOs(constants.PlatformChainID, utxos)
+	pBackend := p.NewBackend(pCTX, pUTXOs, make(map[ids.ID]*ptxs.Tx))
+
+	xChainID := xCTX.BlockchainID()
+	xUTXOs := NewChainUTXOs(xChainID, utxos)
+	xBackend := x.NewBackend(xCTX, xChainID, xUTXOs)
+
+	return &offlineWallet{
+		p: &offlinePWallet{
+			Backend: pBackend,
+			builder: p.NewBuilder(addrs, pBackend),
+			signer:  p.NewSigner(kc, pBackend),
+		},
+		x: &offlineXWallet{
+			Backend: xBackend,
+			builder: x.NewBuilder(addrs, xBackend),
+			signer:  x.NewSigner(kc, xBackend),
+		},
+	}, nil
+}
+
+// Broadcaster issues txs built by an [OfflineWallet] through a connected
+// node. Before a tx is issued, it is verified to be fully signed and to only
+// consume UTXOs that are still spendable, so that txs built against a stale
+// snapshot are reported without being issued.
+type Broadcaster interface {
+	IssuePTx(tx *ptxs.Tx, options ...common.Option) (ids.ID, error)
+	IssueXTx(tx *avmtxs.Tx, options ...common.Option) (ids.ID, error)
+}
+
+type broadcaster struct {
+	wallet   Wallet
+	utxos    UTXOs
+	xChainID ids.ID
+}
+
+// NewBroadcaster returns a broadcaster that issues txs to [uri]. The txs may
+// only consume UTXOs referenced by [addrs], which are fetched from [uri].
+func NewBroadcaster(ctx context.Context, uri string, addrs ids.ShortSet) (Broadcaster, error) {
+	pCTX, xCTX, utxos, err := FetchState(ctx, uri, addrs)
+	if err != nil {
+		return nil, err
+	}
+	return &broadcaster{
+		wallet:   NewWalletWithState(uri, pCTX, xCTX, utxos, secp256k1fx.NewKeychain()),
+		utxos:    utxos,
+		xChainID: xCTX.BlockchainID(),
+	}, nil
+}
+
+func (b *broadcaster) IssuePTx(tx *ptxs.Tx, options ...common.Option) (ids.ID, error) {
+	if err := b.verifyPTx(common.NewOptions(options).Context(), tx); err != nil {
+		return ids.Empty, err
+	}
+	return b.wallet.P().IssueTx(tx, options...)
+}
+
+func (b *broadcaster) IssueXTx(tx *avmtxs.Tx, options ...common.Option) (ids.ID, error) {
+	if err := b.verifyXTx(common.NewOptions(options).Context(), tx); err != nil {
+		return ids.Empty, err
+	}
+	return b.wallet.X().IssueTx(tx, options...)
+}
+
+func (b *broadcaster) verifyPTx(ctx context.Context, tx *ptxs.Tx) error {
+	if err := verifySignatures(tx.Creds); err != nil {
+		return err
+	}
+	for utxoID := range tx.Unsigned.InputIDs() {
+		if err := b.verifySpendable(ctx, constants.PlatformChainID, utxoID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *broadcaster) verifyXTx(ctx context.Context, tx *avmtxs.Tx) error {
+	if err := verifySignatures(credentials(tx.Creds)); err != nil {
+		return err
+	}
+	for _, utxoID := range tx.Unsigned.InputUTXOs() {
+		if err := b.verifySpendable(ctx, b.xChainID, utxoID.InputID()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifySpendable returns nil if [utxoID] is a UTXO of [chainID] that is
+// spendable by the broadcaster's addresses, whichever chain it was sent from.
+func (b *broadcaster) verifySpendable(ctx context.Context, chainID, utxoID ids.ID) error {
+	for _, sourceChainID := range []ids.ID{constants.PlatformChainID, b.xChainID} {
+		_, err := b.utxos.GetUTXO(ctx, sourceChainID, chainID, utxoID)
+		if err == nil {
+			return nil
+		}
+		if err != database.ErrNotFound {
+			return err
+		}
+	}
+	return fmt.Errorf("%w: %s", errUTXONotSpendable, utxoID)
+}
+
+// credentials returns the credentials of an X-chain tx.
+func credentials(fxCreds []*fxs.FxCredential) []verify.Verifiable {
+	creds := make([]verify.Verifiable, len(fxCreds))
+	for i, fxCred := range fxCreds {
+		creds[i] = fxCred.Verifiable
+	}
+	return creds
+}
+
+// verifySignatures returns an error if a signature of [creds] wasn't
+// populated.
+func verifySignatures(creds []verify.Verifiable) error {
+	for credIndex, credIntf := range creds {
+		var cred *secp256k1fx.Credential
+		switch credImpl := credIntf.(type) {
+		case *secp256k1fx.Credential:
+			cred = credImpl
+		case *nftfx.Credential:
+			cred = &credImpl.Credential
+		case *propertyfx.Credential:
+			cred = &credImpl.Credential
+		default:
+			return fmt.Errorf("%w: %T", errUnknownCredentialType, credIntf)
+		}
+
+		for sigIndex, sig := range cred.Sigs {
+			if sig == emptySig {
+				return fmt.Errorf("%w: credential %d signature %d", errMissingSignature, credIndex, sigIndex)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+)
+
+func TestOfflineWallet(t *testing.T) {
+	require := require.New(t)
+	ctx := context.Background()
+
+	factory := crypto.FactorySECP256K1R{}
+	skIntf, err := factory.NewPrivateKey()
+	require.NoError(err)
+	sk := skIntf.(*crypto.PrivateKeySECP256K1R)
+	kc := secp256k1fx.NewKeychain(sk)
+
+	var (
+		avaxAssetID = ids.GenerateTestID()
+		xChainID    = ids.GenerateTestID()
+		pCTX        = p.NewContext(constants.UnitTestID, avaxAssetID, 1, 2, 3, 4, 5, 6, 7, 8)
+		xCTX        = x.NewContext(constants.UnitTestID, xChainID, avaxAssetID, 100, 200)
+		utxo        = &avax.UTXO{
+			UTXOID: avax.UTXOID{TxID: ids.GenerateTestID()},
+			Asset:  avax.Asset{ID: avaxAssetID},
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 10_000,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{sk.PublicKey().Address()},
+				},
+			},
+		}
+	)
+	utxos := NewUTXOs()
+	require.NoError(utxos.AddUTXO(ctx, xChainID, xChainID, utxo))
+
+	// Export the snapshot on the connected machine.
+	snapshot, err := NewSnapshot(ctx, kc.Addrs, pCTX, xCTX, utxos)
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(WriteSnapshot(path, snapshot))
+
+	// Build the tx without the keys.
+	snapshot, err = ReadSnapshot(path)
+	require.NoError(err)
+	require.Equal(kc.Addrs.List(), snapshot.Addrs)
+	watchOnly, err := NewOfflineWallet(snapshot, nil)
+	require.NoError(err)
+	require.Equal(xChainID, watchOnly.X().BlockchainID())
+	require.Equal(uint64(8), watchOnly.P().AddSubnetDelegatorFee())
+
+	utx, err := watchOnly.X().Builder().NewBaseTx([]*avax.TransferableOutput{{
+		Asset: avax.Asset{ID: avaxAssetID},
+		Out: &secp256k1fx.TransferOutput{
+			Amt: 1_000,
+			OutputOwners: secp256k1fx.OutputOwners{
+				Threshold: 1,
+				Addrs:     []ids.ShortID{ids.GenerateTestShortID()},
+			},
+		},
+	}})
+	require.NoError(err)
+	unsignedTx, err := watchOnly.X().Signer().SignUnsigned(ctx, utx)
+	require.NoError(err)
+	require.ErrorIs(verifySignatures(credentials(unsignedTx.Creds)), errMissingSignature)
+
+	// Sign the tx on the air-gapped machine.
+	tx, err := x.Parser.Parse(unsignedTx.Bytes())
+	require.NoError(err)
+	airGapped, err := NewOfflineWallet(snapshot, kc)
+	require.NoError(err)
+	require.NoError(airGapped.X().Signer().Sign(ctx, tx))
+	require.NoError(verifySignatures(credentials(tx.Creds)))
+
+	// The UTXOs consumed by the tx must still be spendable when it is
+	// broadcast.
+	b := &broadcaster{
+		utxos:    utxos,
+		xChainID: xChainID,
+	}
+	require.NoError(b.verifyXTx(ctx, tx))
+
+	require.NoError(utxos.RemoveUTXO(ctx, xChainID, xChainID, utxo.InputID()))
+	require.ErrorIs(b.verifyXTx(ctx, tx), errUTXONotSpendable)
+}
@@ -0,0 +1,223 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/ava-labs/avalanchego/codec"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/perms"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	ptxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+// Snapshot is the state fetched from a node that is needed to build txs
+// without connecting to a node. It can be written to a file to move it to an
+// offline machine.
+type Snapshot struct {
+	// Addrs are the addresses whose UTXOs were exported.
+	Addrs []ids.ShortID `json:"addrs"`
+
+	PContext SnapshotPContext `json:"pContext"`
+	XContext SnapshotXContext `json:"xContext"`
+
+	UTXOs []SnapshotUTXOs `json:"utxos"`
+}
+
+type SnapshotPContext struct {
+	NetworkID                     uint32 `json:"networkID"`
+	AVAXAssetID                   ids.ID `json:"avaxAssetID"`
+	BaseTxFee                     uint64 `json:"baseTxFee"`
+	CreateSubnetTxFee             uint64 `json:"createSubnetTxFee"`
+	TransformSubnetTxFee          uint64 `json:"transformSubnetTxFee"`
+	CreateBlockchainTxFee         uint64 `json:"createBlockchainTxFee"`
+	AddPrimaryNetworkValidatorFee uint64 `json:"addPrimaryNetworkValidatorFee"`
+	AddPrimaryNetworkDelegatorFee uint64 `json:"addPrimaryNetworkDelegatorFee"`
+	AddSubnetValidatorFee         uint64 `json:"addSubnetValidatorFee"`
+	AddSubnetDelegatorFee         uint64 `json:"addSubnetDelegatorFee"`
+}
+
+type SnapshotXContext struct {
+	NetworkID        uint32 `json:"networkID"`
+	BlockchainID     ids.ID `json:"blockchainID"`
+	AVAXAssetID      ids.ID `json:"avaxAssetID"`
+	BaseTxFee        uint64 `json:"baseTxFee"`
+	CreateAssetTxFee uint64 `json:"createAssetTxFee"`
+}
+
+// SnapshotUTXOs are the UTXOs sent from [SourceChainID] to
+// [DestinationChainID], serialized with the codec of [DestinationChainID].
+type SnapshotUTXOs struct {
+	SourceChainID      ids.ID   `json:"sourceChainID"`
+	DestinationChainID ids.ID   `json:"destinationChainID"`
+	UTXOs              [][]byte `json:"utxos"`
+}
+
+// ExportSnapshot fetches from [uri] the state needed to build txs spending the
+// UTXOs referenced by [addrs].
+func ExportSnapshot(ctx context.Context, uri string, addrs ids.ShortSet) (*Snapshot, error) {
+	pCTX, xCTX, utxos, err := FetchState(ctx, uri, addrs)
+	if err != nil {
+		return nil, err
+	}
+	return NewSnapshot(ctx, addrs, pCTX, xCTX, utxos)
+}
+
+// NewSnapshot returns a snapshot of the provided state.
+func NewSnapshot(
+	ctx context.Context,
+	addrs ids.ShortSet,
+	pCTX p.Context,
+	xCTX x.Context,
+	utxos UTXOs,
+) (*Snapshot, error) {
+	s := &Snapshot{
+		Addrs: addrs.List(),
+		PContext: SnapshotPContext{
+			NetworkID:                     pCTX.NetworkID(),
+			AVAXAssetID:                   pCTX.AVAXAssetID(),
+			BaseTxFee:                     pCTX.BaseTxFee(),
+			CreateSubnetTxFee:             pCTX.CreateSubnetTxFee(),
+			TransformSubnetTxFee:          pCTX.TransformSubnetTxFee(),
+			CreateBlockchainTxFee:         pCTX.CreateBlockchainTxFee(),
+			AddPrimaryNetworkValidatorFee: pCTX.AddPrimaryNetworkValidatorFee(),
+			AddPrimaryNetworkDelegatorFee: pCTX.AddPrimaryNetworkDelegatorFee(),
+			AddSubnetValidatorFee:         pCTX.AddSubnetValidatorFee(),
+			AddSubnetDelegatorFee:         pCTX.AddSubnetDelegatorFee(),
+		},
+		XContext: SnapshotXContext{
+			NetworkID:        xCTX.NetworkID(),
+			BlockchainID:     xCTX.BlockchainID(),
+			AVAXAssetID:      xCTX.AVAXAssetID(),
+			BaseTxFee:        xCTX.BaseTxFee(),
+			CreateAssetTxFee: xCTX.CreateAssetTxFee(),
+		},
+	}
+
+	chains := s.chains()
+	for _, destinationChain := range chains {
+		for _, sourceChain := range chains {
+			chainUTXOs, err := utxos.UTXOs(ctx, sourceChain.id, destinationChain.id)
+			if err != nil {
+				return nil, err
+			}
+			if len(chainUTXOs) == 0 {
+				continue
+			}
+
+			utxosBytes := make([][]byte, len(chainUTXOs))
+			for i, utxo := range chainUTXOs {
+				utxosBytes[i], err = destinationChain.codec.Marshal(destinationChain.codecVersion, utxo)
+				if err != nil {
+					return nil, fmt.Errorf("couldn't marshal UTXO %s: %w", utxo.InputID(), err)
+				}
+			}
+			s.UTXOs = append(s.UTXOs, SnapshotUTXOs{
+				SourceChainID:      sourceChain.id,
+				DestinationChainID: destinationChain.id,
+				UTXOs:              utxosBytes,
+			})
+		}
+	}
+	return s, nil
+}
+
+// ReadSnapshot reads the snapshot written to [path] by [WriteSnapshot].
+func ReadSnapshot(path string) (*Snapshot, error) {
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &Snapshot{}
+	if err := json.Unmarshal(snapshotBytes, s); err != nil {
+		return nil, fmt.Errorf("couldn't parse snapshot %s: %w", path, err)
+	}
+	return s, nil
+}
+
+// WriteSnapshot writes [s] to [path].
+func WriteSnapshot(path string, s *Snapshot) error {
+	snapshotBytes, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return perms.WriteFile(path, snapshotBytes, perms.ReadWrite)
+}
+
+// State returns the contexts and the UTXOs recorded in the snapshot.
+func (s *Snapshot) State() (p.Context, x.Context, UTXOs, error) {
+	pCTX := p.NewContext(
+		s.PContext.NetworkID,
+		s.PContext.AVAXAssetID,
+		s.PContext.BaseTxFee,
+		s.PContext.CreateSubnetTxFee,
+		s.PContext.TransformSubnetTxFee,
+		s.PContext.CreateBlockchainTxFee,
+		s.PContext.AddPrimaryNetworkValidatorFee,
+		s.PContext.AddPrimaryNetworkDelegatorFee,
+		s.PContext.AddSubnetValidatorFee,
+		s.PContext.AddSubnetDelegatorFee,
+	)
+	xCTX := x.NewContext(
+		s.XContext.NetworkID,
+		s.XContext.BlockchainID,
+		s.XContext.AVAXAssetID,
+		s.XContext.BaseTxFee,
+		s.XContext.CreateAssetTxFee,
+	)
+
+	chains := s.chains()
+	utxos := NewUTXOs()
+	for _, chainUTXOs := range s.UTXOs {
+		destinationChain, ok := chains[chainUTXOs.DestinationChainID]
+		if !ok {
+			return nil, nil, nil, fmt.Errorf("%w: %s", errUnsupportedChain, chainUTXOs.DestinationChainID)
+		}
+		for _, utxoBytes := range chainUTXOs.UTXOs {
+			var utxo avax.UTXO
+			if _, err := destinationChain.codec.Unmarshal(utxoBytes, &utxo); err != nil {
+				return nil, nil, nil, err
+			}
+			// The in-memory UTXO set doesn't use the context, so it can't be
+			// cancelled.
+			err := utxos.AddUTXO(context.Background(), chainUTXOs.SourceChainID, chainUTXOs.DestinationChainID, &utxo)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+	return pCTX, xCTX, utxos, nil
+}
+
+type snapshotChain struct {
+	id           ids.ID
+	codec        codec.Manager
+	codecVersion uint16
+}
+
+// chains returns the chains whose UTXOs are recorded in the snapshot, indexed
+// by their IDs.
+func (s *Snapshot) chains() map[ids.ID]snapshotChain {
+	return map[ids.ID]snapshotChain{
+		constants.PlatformChainID: {
+			id:           constants.PlatformChainID,
+			codec:        ptxs.Codec,
+			codecVersion: ptxs.Version,
+		},
+		s.XContext.BlockchainID: {
+			id:           s.XContext.BlockchainID,
+			codec:        x.Parser.Codec(),
+			codecVersion: avmtxs.CodecVersion,
+		},
+	}
+}
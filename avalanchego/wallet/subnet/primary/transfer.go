@@ -0,0 +1,381 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/constants"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/avm"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/platformvm"
+	"github.com/ava-labs/avalanchego/vms/platformvm/status"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/chain/p"
+	"github.com/ava-labs/avalanchego/wallet/chain/x"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+
+	avmtxs "github.com/ava-labs/avalanchego/vms/avm/txs"
+	ptxs "github.com/ava-labs/avalanchego/vms/platformvm/txs"
+)
+
+var (
+	errUnsupportedChain = errors.New("unsupported chain")
+	errSameChain        = errors.New("source and destination chains must differ")
+	errTxRejected       = errors.New("tx was rejected")
+)
+
+// TransferState records the progress of a cross-chain transfer. Signed txs are
+// recorded before they are issued so that a resumed transfer re-issues the
+// same txs, rather than exporting the funds a second time.
+type TransferState struct {
+	SourceChainID      ids.ID `json:"sourceChainID"`
+	DestinationChainID ids.ID `json:"destinationChainID"`
+
+	ExportTxID     ids.ID `json:"exportTxID"`
+	ExportTx       []byte `json:"exportTx"`
+	ExportAccepted bool   `json:"exportAccepted"`
+
+	ImportTxID     ids.ID `json:"importTxID"`
+	ImportTx       []byte `json:"importTx"`
+	ImportAccepted bool   `json:"importAccepted"`
+}
+
+// Done returns true once the funds have been imported into the destination
+// chain.
+func (s *TransferState) Done() bool {
+	return s.ImportAccepted
+}
+
+// TransferCheckpointer persists [state] so that an interrupted transfer can be
+// resumed. If it returns an error, the transfer is aborted.
+type TransferCheckpointer func(state *TransferState) error
+
+// Transfer moves funds from [state.SourceChainID] to
+// [state.DestinationChainID] by issuing an export tx on the source chain,
+// waiting for it to be accepted, and then issuing an import tx on the
+// destination chain.
+//
+//   - [outputs] specifies the outputs to export. They must be spendable by
+//     [kc] for the funds to be imported. They are ignored if the export tx was
+//     already recorded in [state].
+//   - [to] specifies where to send the imported funds to.
+//   - [checkpoint] is called every time [state] is modified.
+//
+// If Transfer returns an error, calling it again with the last checkpointed
+// [state] resumes the transfer from where it stopped. The P-chain and the
+// X-chain are supported.
+func Transfer(
+	ctx context.Context,
+	uri string,
+	kc *secp256k1fx.Keychain,
+	state *TransferState,
+	outputs []*avax.TransferableOutput,
+	to *secp256k1fx.OutputOwners,
+	checkpoint TransferCheckpointer,
+	options ...common.Option,
+) error {
+	pCTX, xCTX, utxos, err := FetchState(ctx, uri, kc.Addrs)
+	if err != nil {
+		return err
+	}
+
+	pUTXOs := NewChainUTXOs(constants.PlatformChainID, utxos)
+	pBackend := p.NewBackend(pCTX, pUTXOs, make(map[ids.ID]*ptxs.Tx))
+	pClient := platformvm.NewClient(uri)
+	pWallet := p.NewWallet(
+		p.NewBuilder(kc.Addrs, pBackend),
+		p.NewSigner(kc, pBackend),
+		pClient,
+		pBackend,
+	)
+
+	xChainID := xCTX.BlockchainID()
+	xUTXOs := NewChainUTXOs(xChainID, utxos)
+	xBackend := x.NewBackend(xCTX, xChainID, xUTXOs)
+	xClient := avm.NewClient(uri, "X")
+	xWallet := x.NewWallet(
+		x.NewBuilder(kc.Addrs, xBackend),
+		x.NewSigner(kc, xBackend),
+		xClient,
+		xBackend,
+	)
+
+	chains := map[ids.ID]transferChain{
+		constants.PlatformChainID: &pTransferChain{
+			wallet:  pWallet,
+			backend: pBackend,
+			client:  pClient,
+		},
+		xChainID: &xTransferChain{
+			wallet:  xWallet,
+			backend: xBackend,
+			client:  xClient,
+		},
+	}
+	source, ok := chains[state.SourceChainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnsupportedChain, state.SourceChainID)
+	}
+	destination, ok := chains[state.DestinationChainID]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnsupportedChain, state.DestinationChainID)
+	}
+
+	options = append([]common.Option{common.WithContext(ctx)}, options...)
+	return transfer(source, destination, state, outputs, to, checkpoint, options)
+}
+
+func transfer(
+	source transferChain,
+	destination transferChain,
+	state *TransferState,
+	outputs []*avax.TransferableOutput,
+	to *secp256k1fx.OutputOwners,
+	checkpoint TransferCheckpointer,
+	options []common.Option,
+) error {
+	if state.SourceChainID == state.DestinationChainID {
+		return errSameChain
+	}
+
+	if !state.ExportAccepted {
+		if state.ExportTx == nil {
+			txBytes, err := source.newExportTx(state.DestinationChainID, outputs, options)
+			if err != nil {
+				return fmt.Errorf("couldn't create export tx: %w", err)
+			}
+			state.ExportTx = txBytes
+			state.ExportTxID = ids.ID(hashing.ComputeHash256Array(txBytes))
+			if err := checkpoint(state); err != nil {
+				return err
+			}
+		}
+
+		if err := accept(source, state.ExportTx, options); err != nil {
+			return fmt.Errorf("couldn't accept export tx %s: %w", state.ExportTxID, err)
+		}
+		state.ExportAccepted = true
+		if err := checkpoint(state); err != nil {
+			return err
+		}
+	}
+
+	if !state.ImportAccepted {
+		if state.ImportTx == nil {
+			txBytes, err := destination.newImportTx(state.SourceChainID, to, options)
+			if err != nil {
+				return fmt.Errorf("couldn't create import tx: %w", err)
+			}
+			state.ImportTx = txBytes
+			state.ImportTxID = ids.ID(hashing.ComputeHash256Array(txBytes))
+			if err := checkpoint(state); err != nil {
+				return err
+			}
+		}
+
+		if err := accept(destination, state.ImportTx, options); err != nil {
+			return fmt.Errorf("couldn't accept import tx %s: %w", state.ImportTxID, err)
+		}
+		state.ImportAccepted = true
+		if err := checkpoint(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// accept waits for [txBytes] to be accepted, only issuing it if the chain
+// doesn't already know about it.
+func accept(chain transferChain, txBytes []byte, options []common.Option) error {
+	ops := common.NewOptions(options)
+	txID := ids.ID(hashing.ComputeHash256Array(txBytes))
+	txStatus, err := chain.status(ops.Context(), txID)
+	if err != nil {
+		return err
+	}
+
+	switch txStatus {
+	case choices.Accepted:
+		return chain.acceptTx(ops.Context(), txBytes)
+	case choices.Rejected:
+		return errTxRejected
+	case choices.Processing:
+		return chain.confirmTx(txBytes, options)
+	default:
+		return chain.issueTx(txBytes, options)
+	}
+}
+
+// transferChain abstracts the chain specific parts of a transfer.
+type transferChain interface {
+	// newExportTx returns a signed export tx.
+	newExportTx(chainID ids.ID, outputs []*avax.TransferableOutput, options []common.Option) ([]byte, error)
+	// newImportTx returns a signed import tx.
+	newImportTx(chainID ids.ID, to *secp256k1fx.OutputOwners, options []common.Option) ([]byte, error)
+
+	status(ctx context.Context, txID ids.ID) (choices.Status, error)
+	// issueTx issues the tx and waits for it to be accepted.
+	issueTx(txBytes []byte, options []common.Option) error
+	// confirmTx waits for an already issued tx to be accepted.
+	confirmTx(txBytes []byte, options []common.Option) error
+	// acceptTx updates the local UTXO set with an accepted tx.
+	acceptTx(ctx context.Context, txBytes []byte) error
+}
+
+type pTransferChain struct {
+	wallet  p.Wallet
+	backend p.Backend
+	client  platformvm.Client
+}
+
+func (c *pTransferChain) newExportTx(chainID ids.ID, outputs []*avax.TransferableOutput, options []common.Option) ([]byte, error) {
+	utx, err := c.wallet.Builder().NewExportTx(chainID, outputs, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.sign(utx, options)
+}
+
+func (c *pTransferChain) newImportTx(chainID ids.ID, to *secp256k1fx.OutputOwners, options []common.Option) ([]byte, error) {
+	utx, err := c.wallet.Builder().NewImportTx(chainID, to, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.sign(utx, options)
+}
+
+func (c *pTransferChain) sign(utx ptxs.UnsignedTx, options []common.Option) ([]byte, error) {
+	ops := common.NewOptions(options)
+	tx, err := c.wallet.Signer().SignUnsigned(ops.Context(), utx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Bytes(), nil
+}
+
+func (c *pTransferChain) status(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	res, err := c.client.GetTxStatus(ctx, txID)
+	if err != nil {
+		return choices.Unknown, err
+	}
+	return toChoicesStatus(res.Status), nil
+}
+
+func (c *pTransferChain) issueTx(txBytes []byte, options []common.Option) error {
+	tx, err := ptxs.Parse(ptxs.Codec, txBytes)
+	if err != nil {
+		return err
+	}
+	_, err = c.wallet.IssueTx(tx, options...)
+	return err
+}
+
+func (c *pTransferChain) confirmTx(txBytes []byte, options []common.Option) error {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	txID := ids.ID(hashing.ComputeHash256Array(txBytes))
+	res, err := c.client.AwaitTxDecided(ctx, txID, ops.PollFrequency())
+	if err != nil {
+		return err
+	}
+	if toChoicesStatus(res.Status) != choices.Accepted {
+		return errTxRejected
+	}
+	return c.acceptTx(ctx, txBytes)
+}
+
+func (c *pTransferChain) acceptTx(ctx context.Context, txBytes []byte) error {
+	tx, err := ptxs.Parse(ptxs.Codec, txBytes)
+	if err != nil {
+		return err
+	}
+	return c.backend.AcceptTx(ctx, tx)
+}
+
+// toChoicesStatus maps a P-chain tx status onto the statuses used by the other
+// chains. Dropped txs are reported as unknown so that they are re-issued.
+func toChoicesStatus(s status.Status) choices.Status {
+	switch s {
+	case status.Committed:
+		return choices.Accepted
+	case status.Aborted:
+		return choices.Rejected
+	case status.Processing:
+		return choices.Processing
+	default:
+		return choices.Unknown
+	}
+}
+
+type xTransferChain struct {
+	wallet  x.Wallet
+	backend x.Backend
+	client  avm.Client
+}
+
+func (c *xTransferChain) newExportTx(chainID ids.ID, outputs []*avax.TransferableOutput, options []common.Option) ([]byte, error) {
+	utx, err := c.wallet.Builder().NewExportTx(chainID, outputs, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.sign(utx, options)
+}
+
+func (c *xTransferChain) newImportTx(chainID ids.ID, to *secp256k1fx.OutputOwners, options []common.Option) ([]byte, error) {
+	utx, err := c.wallet.Builder().NewImportTx(chainID, to, options...)
+	if err != nil {
+		return nil, err
+	}
+	return c.sign(utx, options)
+}
+
+func (c *xTransferChain) sign(utx avmtxs.UnsignedTx, options []common.Option) ([]byte, error) {
+	ops := common.NewOptions(options)
+	tx, err := c.wallet.Signer().SignUnsigned(ops.Context(), utx)
+	if err != nil {
+		return nil, err
+	}
+	return tx.Bytes(), nil
+}
+
+func (c *xTransferChain) status(ctx context.Context, txID ids.ID) (choices.Status, error) {
+	return c.client.GetTxStatus(ctx, txID)
+}
+
+func (c *xTransferChain) issueTx(txBytes []byte, options []common.Option) error {
+	tx, err := x.Parser.Parse(txBytes)
+	if err != nil {
+		return err
+	}
+	_, err = c.wallet.IssueTx(tx, options...)
+	return err
+}
+
+func (c *xTransferChain) confirmTx(txBytes []byte, options []common.Option) error {
+	ops := common.NewOptions(options)
+	ctx := ops.Context()
+	txID := ids.ID(hashing.ComputeHash256Array(txBytes))
+	txStatus, err := c.client.ConfirmTx(ctx, txID, ops.PollFrequency())
+	if err != nil {
+		return err
+	}
+	if txStatus != choices.Accepted {
+		return errTxRejected
+	}
+	return c.acceptTx(ctx, txBytes)
+}
+
+func (c *xTransferChain) acceptTx(ctx context.Context, txBytes []byte) error {
+	tx, err := x.Parser.Parse(txBytes)
+	if err != nil {
+		return err
+	}
+	return c.backend.AcceptTx(ctx, tx)
+}
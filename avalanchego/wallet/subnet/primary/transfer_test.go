@@ -0,0 +1,157 @@
+// Copyright (C) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package primary
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/snow/choices"
+	"github.com/ava-labs/avalanchego/utils/hashing"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ava-labs/avalanchego/wallet/subnet/primary/common"
+)
+
+var errTestCrash = errors.New("crash")
+
+type testTransferChain struct {
+	txBytes  []byte
+	statuses map[ids.ID]choices.Status
+
+	built, issued, confirmed, accepted int
+}
+
+func (c *testTransferChain) newExportTx(ids.ID, []*avax.TransferableOutput, []common.Option) ([]byte, error) {
+	c.built++
+	return c.txBytes, nil
+}
+
+func (c *testTransferChain) newImportTx(ids.ID, *secp256k1fx.OutputOwners, []common.Option) ([]byte, error) {
+	c.built++
+	return c.txBytes, nil
+}
+
+func (c *testTransferChain) status(_ context.Context, txID ids.ID) (choices.Status, error) {
+	return c.statuses[txID], nil
+}
+
+func (c *testTransferChain) issueTx(txBytes []byte, _ []common.Option) error {
+	c.issued++
+	c.statuses[hashing.ComputeHash256Array(txBytes)] = choices.Accepted
+	return nil
+}
+
+func (c *testTransferChain) confirmTx(txBytes []byte, _ []common.Option) error {
+	c.confirmed++
+	c.statuses[hashing.ComputeHash256Array(txBytes)] = choices.Accepted
+	return nil
+}
+
+func (c *testTransferChain) acceptTx(context.Context, []byte) error {
+	c.accepted++
+	return nil
+}
+
+func TestTransfer(t *testing.T) {
+	require := require.New(t)
+
+	source := &testTransferChain{
+		txBytes:  []byte{1},
+		statuses: make(map[ids.ID]choices.Status),
+	}
+	destination := &testTransferChain{
+		txBytes:  []byte{2},
+		statuses: make(map[ids.ID]choices.Status),
+	}
+	state := &TransferState{
+		SourceChainID:      ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+	}
+	checkpoints := 0
+	err := transfer(source, destination, state, nil, nil, func(*TransferState) error {
+		checkpoints++
+		return nil
+	}, nil)
+	require.NoError(err)
+	require.True(state.Done())
+	require.Equal(ids.ID(hashing.ComputeHash256Array(source.txBytes)), state.ExportTxID)
+	require.Equal(ids.ID(hashing.ComputeHash256Array(destination.txBytes)), state.ImportTxID)
+	require.Equal(4, checkpoints)
+	require.Equal(1, source.built)
+	require.Equal(1, source.issued)
+	require.Equal(1, destination.built)
+	require.Equal(1, destination.issued)
+}
+
+func TestTransferResume(t *testing.T) {
+	require := require.New(t)
+
+	source := &testTransferChain{
+		txBytes:  []byte{1},
+		statuses: make(map[ids.ID]choices.Status),
+	}
+	destination := &testTransferChain{
+		txBytes:  []byte{2},
+		statuses: make(map[ids.ID]choices.Status),
+	}
+	state := &TransferState{
+		SourceChainID:      ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+	}
+
+	// Stop the transfer right after the export tx was recorded.
+	err := transfer(source, destination, state, nil, nil, func(*TransferState) error {
+		return errTestCrash
+	}, nil)
+	require.ErrorIs(err, errTestCrash)
+	require.NotNil(state.ExportTx)
+	require.False(state.ExportAccepted)
+
+	// The export tx was issued by another process before it died.
+	source.statuses[state.ExportTxID] = choices.Processing
+
+	err = transfer(source, destination, state, nil, nil, func(*TransferState) error {
+		return nil
+	}, nil)
+	require.NoError(err)
+	require.True(state.Done())
+	require.Equal(1, source.built)
+	require.Zero(source.issued)
+	require.Equal(1, source.confirmed)
+
+	// Resuming a finished transfer doesn't issue anything.
+	err = transfer(source, destination, state, nil, nil, func(*TransferState) error {
+		return nil
+	}, nil)
+	require.NoError(err)
+	require.Equal(1, destination.built)
+	require.Equal(1, destination.issued)
+}
+
+func TestTransferRejected(t *testing.T) {
+	require := require.New(t)
+
+	source := &testTransferChain{
+		txBytes:  []byte{1},
+		statuses: make(map[ids.ID]choices.Status),
+	}
+	state := &TransferState{
+		SourceChainID:      ids.GenerateTestID(),
+		DestinationChainID: ids.GenerateTestID(),
+		ExportTx:           source.txBytes,
+		ExportTxID:         hashing.ComputeHash256Array(source.txBytes),
+	}
+	source.statuses[state.ExportTxID] = choices.Rejected
+
+	err := transfer(source, source, state, nil, nil, func(*TransferState) error {
+		return nil
+	}, nil)
+	require.ErrorIs(err, errTxRejected)
+	require.False(state.ExportAccepted)
+}
@@ -944,6 +944,28 @@ func (pool *TxPool) AddLocal(tx *types.Transaction) error {
 	return errs[0]
 }
 
+// ValidateLocal checks whether [tx] would be accepted by AddLocal against the
+// current state, without adding it to the pool.
+func (pool *TxPool) ValidateLocal(tx *types.Transaction) error {
+	if pool.all.Get(tx.Hash()) != nil {
+		knownTxMeter.Mark(1)
+		return ErrAlreadyKnown
+	}
+	if _, err := types.Sender(pool.signer, tx); err != nil {
+		invalidTxMeter.Mark(1)
+		return ErrInvalidSender
+	}
+
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	if err := pool.validateTx(tx, !pool.config.NoLocals); err != nil {
+		invalidTxMeter.Mark(1)
+		return err
+	}
+	return nil
+}
+
 // AddRemotes enqueues a batch of transactions into the pool if they are valid. If the
 // senders are not among the locally tracked ones, full pricing constraints will apply.
 //
@@ -299,6 +299,9 @@ func (b *EthAPIBackend) SendTx(ctx context.Context, signedTx *types.Transaction)
 	if err := ctx.Err(); err != nil {
 		return err
 	}
+	if submit := b.eth.LocalTxSubmitter(); submit != nil {
+		return submit(signedTx)
+	}
 	return b.eth.txPool.AddLocal(signedTx)
 }
 
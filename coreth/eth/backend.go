@@ -94,6 +94,10 @@ type Ethereum struct {
 	miner     *miner.Miner
 	etherbase common.Address
 
+	// localTxSubmitter, if set, is handed the txs submitted through the APIs
+	// instead of the tx pool.
+	localTxSubmitter func(*types.Transaction) error
+
 	networkID     uint64
 	netRPCService *ethapi.NetAPI
 
@@ -348,6 +352,24 @@ func (s *Ethereum) SetEtherbase(etherbase common.Address) {
 	s.miner.SetEtherbase(etherbase)
 }
 
+// SetLocalTxSubmitter sets the function that is handed the txs submitted
+// through the APIs instead of the tx pool. If [submitter] is nil, the txs are
+// added to the tx pool.
+func (s *Ethereum) SetLocalTxSubmitter(submitter func(*types.Transaction) error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.localTxSubmitter = submitter
+}
+
+// LocalTxSubmitter returns the function set by SetLocalTxSubmitter.
+func (s *Ethereum) LocalTxSubmitter() func(*types.Transaction) error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.localTxSubmitter
+}
+
 func (s *Ethereum) Miner() *miner.Miner { return s.miner }
 
 func (s *Ethereum) AccountManager() *accounts.Manager { return s.accountManager }
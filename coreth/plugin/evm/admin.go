@@ -4,10 +4,15 @@
 package evm
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/ava-labs/avalanchego/api"
+	avalancheJSON "github.com/ava-labs/avalanchego/utils/json"
+	"github.com/ava-labs/avalanchego/utils/perms"
 	"github.com/ava-labs/avalanchego/utils/profiler"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -16,12 +21,14 @@ import (
 type Admin struct {
 	vm       *VM
 	profiler profiler.Profiler
+	traceDir string
 }
 
-func NewAdminService(vm *VM, performanceDir string) *Admin {
+func NewAdminService(vm *VM, performanceDir string, traceDir string) *Admin {
 	return &Admin{
 		vm:       vm,
 		profiler: profiler.New(performanceDir),
+		traceDir: traceDir,
 	}
 }
 
@@ -73,3 +80,43 @@ func (p *Admin) GetVMConfig(_ *http.Request, _ *struct{}, reply *ConfigReply) er
 	reply.Config = &p.vm.config
 	return nil
 }
+
+type ExportBlockTraceArgs struct {
+	BlockHeight avalancheJSON.Uint64 `json:"blockHeight"`
+}
+
+type ExportBlockTraceReply struct {
+	// File the trace was written to
+	File string `json:"file"`
+	// StateRootMatches is false if re-executing the block didn't produce the
+	// state root of the block
+	StateRootMatches bool `json:"stateRootMatches"`
+}
+
+// ExportBlockTrace re-executes the accepted block at the given height on the
+// state of its parent and writes a deterministic trace of the execution to a
+// file. Comparing the traces of the same block exported by different nodes
+// shows where their execution diverged.
+func (p *Admin) ExportBlockTrace(_ *http.Request, args *ExportBlockTraceArgs, reply *ExportBlockTraceReply) error {
+	log.Info("Admin: ExportBlockTrace called", "blockHeight", args.BlockHeight)
+
+	trace, err := p.vm.traceAcceptedBlock(uint64(args.BlockHeight))
+	if err != nil {
+		return fmt.Errorf("failed to trace block: %w", err)
+	}
+	traceBytes, err := json.MarshalIndent(trace, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(p.traceDir, perms.ReadWriteExecute); err != nil {
+		return err
+	}
+	file := filepath.Join(p.traceDir, fmt.Sprintf("block_%d_%s.json", trace.Number, trace.Hash.Hex()))
+	if err := os.WriteFile(file, traceBytes, perms.ReadWrite); err != nil {
+		return fmt.Errorf("failed to write trace: %w", err)
+	}
+
+	reply.File = file
+	reply.StateRootMatches = trace.StateRoot == trace.ExecutedStateRoot
+	return nil
+}
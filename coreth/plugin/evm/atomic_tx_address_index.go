@@ -0,0 +1,118 @@
+// (c) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ava-labs/avalanchego/database"
+	"github.com/ava-labs/avalanchego/database/prefixdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/wrappers"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const atomicTxAddressKeyLen = common.AddressLength + wrappers.LongLen + common.HashLength
+
+var atomicTxAddressDBPrefix = []byte("atomicTxAddressDB")
+
+// atomicTxAddressIndex maintains an index of
+// [address]+[height]+[txID] => nil for accepted atomic txs.
+//
+// EVM addresses and the addresses owning exported outputs are both 20 bytes,
+// so they share the same key space.
+type atomicTxAddressIndex struct {
+	db database.Database
+}
+
+func newAtomicTxAddressIndex(db database.Database) *atomicTxAddressIndex {
+	return &atomicTxAddressIndex{
+		db: prefixdb.New(atomicTxAddressDBPrefix, db),
+	}
+}
+
+// atomicTxAddressEntry is a single atomic tx found by address.
+type atomicTxAddressEntry struct {
+	txID   ids.ID
+	height uint64
+}
+
+// Index adds [txs], accepted at [height], to the index.
+func (i *atomicTxAddressIndex) Index(height uint64, txs []*Tx) error {
+	for _, tx := range txs {
+		txID := tx.ID()
+		for addr := range atomicTxAddresses(tx) {
+			if err := i.db.Put(atomicTxAddressKey(addr, height, txID), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Get returns the atomic txs touching [addr] accepted at or after
+// [startHeight], ordered by height. Txs accepted at the same height are never
+// split across calls, so more than [limit] txs may be returned.
+func (i *atomicTxAddressIndex) Get(addr ids.ShortID, startHeight uint64, limit int) ([]atomicTxAddressEntry, error) {
+	it := i.db.NewIteratorWithStartAndPrefix(
+		atomicTxAddressKey(addr, startHeight, ids.Empty),
+		addr[:],
+	)
+	defer it.Release()
+
+	var entries []atomicTxAddressEntry
+	for it.Next() {
+		key := it.Key()
+		if len(key) != atomicTxAddressKeyLen {
+			return nil, fmt.Errorf("unexpected atomic tx address key length %d", len(key))
+		}
+		height := binary.BigEndian.Uint64(key[common.AddressLength:])
+		if len(entries) >= limit && entries[len(entries)-1].height != height {
+			break
+		}
+		txID, err := ids.ToID(key[common.AddressLength+wrappers.LongLen:])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, atomicTxAddressEntry{
+			txID:   txID,
+			height: height,
+		})
+	}
+	return entries, it.Error()
+}
+
+func atomicTxAddressKey(addr ids.ShortID, height uint64, txID ids.ID) []byte {
+	key := make([]byte, atomicTxAddressKeyLen)
+	copy(key, addr[:])
+	binary.BigEndian.PutUint64(key[common.AddressLength:], height)
+	copy(key[common.AddressLength+wrappers.LongLen:], txID[:])
+	return key
+}
+
+// atomicTxAddresses returns the addresses [tx] sends funds from or to, as
+// recorded in the tx itself. For import txs these are the EVM addresses being
+// credited, for export txs the EVM addresses being debited and the owners of
+// the exported outputs.
+func atomicTxAddresses(tx *Tx) ids.ShortSet {
+	addrs := ids.ShortSet{}
+	switch utx := tx.UnsignedAtomicTx.(type) {
+	case *UnsignedImportTx:
+		for _, out := range utx.Outs {
+			addrs.Add(ids.ShortID(out.Address))
+		}
+	case *UnsignedExportTx:
+		for _, in := range utx.Ins {
+			addrs.Add(ids.ShortID(in.Address))
+		}
+		for _, out := range utx.ExportedOutputs {
+			if owned, ok := out.Out.(*secp256k1fx.TransferOutput); ok {
+				addrs.Add(owned.Addrs...)
+			}
+		}
+	}
+	return addrs
+}
@@ -0,0 +1,74 @@
+// (c) 2019-2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"testing"
+
+	"github.com/ava-labs/avalanchego/database/memdb"
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/vms/components/avax"
+	"github.com/ava-labs/avalanchego/vms/secp256k1fx"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicTxAddressIndex(t *testing.T) {
+	ethAddr := common.Address{1}
+	shortAddr := ids.GenerateTestShortID()
+
+	importTx := &Tx{UnsignedAtomicTx: &UnsignedImportTx{
+		SourceChain: ids.GenerateTestID(),
+		Outs: []EVMOutput{{
+			Address: ethAddr,
+			Amount:  1,
+		}},
+	}}
+	assert.NoError(t, importTx.Sign(Codec, nil))
+	exportTx := &Tx{UnsignedAtomicTx: &UnsignedExportTx{
+		DestinationChain: ids.GenerateTestID(),
+		Ins: []EVMInput{{
+			Address: ethAddr,
+			Amount:  1,
+		}},
+		ExportedOutputs: []*avax.TransferableOutput{{
+			Out: &secp256k1fx.TransferOutput{
+				Amt: 1,
+				OutputOwners: secp256k1fx.OutputOwners{
+					Threshold: 1,
+					Addrs:     []ids.ShortID{shortAddr},
+				},
+			},
+		}},
+	}}
+	assert.NoError(t, exportTx.Sign(Codec, nil))
+
+	index := newAtomicTxAddressIndex(memdb.New())
+	assert.NoError(t, index.Index(5, []*Tx{importTx}))
+	assert.NoError(t, index.Index(7, []*Tx{exportTx}))
+
+	entries, err := index.Get(ids.ShortID(ethAddr), 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []atomicTxAddressEntry{
+		{txID: importTx.ID(), height: 5},
+		{txID: exportTx.ID(), height: 7},
+	}, entries)
+
+	// Only the export tx sends funds to [shortAddr].
+	entries, err = index.Get(shortAddr, 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []atomicTxAddressEntry{{txID: exportTx.ID(), height: 7}}, entries)
+
+	// Paging continues from [startHeight].
+	entries, err = index.Get(ids.ShortID(ethAddr), 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []atomicTxAddressEntry{{txID: importTx.ID(), height: 5}}, entries)
+	entries, err = index.Get(ids.ShortID(ethAddr), 6, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []atomicTxAddressEntry{{txID: exportTx.ID(), height: 7}}, entries)
+
+	entries, err = index.Get(ids.GenerateTestShortID(), 0, 10)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -150,6 +150,12 @@ func (b *Block) Accept() error {
 		// Remove the accepted transaction from the mempool
 		vm.mempool.RemoveTx(tx)
 	}
+	// Bonus blocks re-accept txs that were already indexed at another height.
+	if vm.atomicTxAddressIndex != nil && !vm.atomicBackend.IsBonus(b.Height(), common.Hash(b.ID())) {
+		if err := vm.atomicTxAddressIndex.Index(b.Height(), b.atomicTxs); err != nil {
+			return fmt.Errorf("failed to index atomic txs of %s by address: %w", b.ID(), err)
+		}
+	}
 
 	// Update VM state for atomic txs in this block. This includes updating the
 	// atomic tx repo, atomic trie, and shared memory.
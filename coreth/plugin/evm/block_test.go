@@ -0,0 +1,81 @@
+// (c) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+)
+
+// BenchmarkBlockVerify compares the syntactic checks of a block with its full
+// verification, which also executes the block and checks its state root.
+func BenchmarkBlockVerify(b *testing.B) {
+	const numTxs = 100
+
+	require := require.New(b)
+
+	importAmount := uint64(50000000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(b, true, genesisJSONApricotPhase2, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown())
+	}()
+
+	newTxPoolHeadChan := make(chan core.NewTxPoolReorgEvent, 1)
+	vm.txPool.SubscribeNewReorgEvent(newTxPoolHeadChan)
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*crypto.PrivateKeySECP256K1R{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.issueTx(importTx, true /*=local*/))
+	<-issuer
+
+	blk1, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk1.Verify())
+	require.NoError(vm.SetPreference(blk1.ID()))
+	require.NoError(blk1.Accept())
+	<-newTxPoolHeadChan
+
+	txs := make([]*types.Transaction, numTxs)
+	for i := range txs {
+		tx := types.NewTransaction(uint64(i), testEthAddrs[1], big.NewInt(10), 21000, big.NewInt(params.LaunchMinGasPrice), nil)
+		txs[i], err = types.SignTx(tx, types.NewEIP155Signer(vm.chainID), testKeys[0].ToECDSA())
+		require.NoError(err)
+	}
+	for _, err := range vm.txPool.AddRemotesSync(txs) {
+		require.NoError(err)
+	}
+	<-issuer
+
+	blk2, err := vm.BuildBlock()
+	require.NoError(err)
+	blk, err := vm.parseBlock(blk2.Bytes())
+	require.NoError(err)
+	evmBlk := blk.(*Block)
+	require.Len(evmBlk.ethBlock.Transactions(), numTxs)
+
+	b.Run("syntactic", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := evmBlk.syntacticVerify(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := evmBlk.verify(false /*=writes*/); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,373 @@
+// (c) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ava-labs/avalanchego/snow"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/state"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/core/vm"
+	"github.com/ava-labs/coreth/params"
+)
+
+// blockTraceReexec is the number of blocks that are re-executed to rebuild the
+// state a block is executed on if that state was pruned.
+const blockTraceReexec = 128
+
+var (
+	errGenesisNotTraceable = errors.New("genesis is not traceable")
+	errBlockNotAccepted    = errors.New("block is not accepted")
+
+	_ vm.EVMLogger = &blockTracer{}
+)
+
+// BlockTrace is the deterministic trace of the re-execution of a block. Two
+// nodes that agree on the execution of a block produce identical traces.
+type BlockTrace struct {
+	Number     uint64      `json:"number"`
+	Hash       common.Hash `json:"hash"`
+	ParentHash common.Hash `json:"parentHash"`
+	// StateRoot is the state root of the block.
+	StateRoot common.Hash `json:"stateRoot"`
+	// ExecutedStateRoot is the state root the re-execution produced.
+	ExecutedStateRoot common.Hash    `json:"executedStateRoot"`
+	GasUsed           uint64         `json:"gasUsed"`
+	Txs               []*TxTrace     `json:"txs"`
+	AtomicTxs         []string       `json:"atomicTxs"`
+	AtomicWrites      []AccountTrace `json:"atomicWrites"`
+}
+
+// TxTrace is the trace of the execution of a tx.
+type TxTrace struct {
+	Hash    common.Hash `json:"hash"`
+	Status  uint64      `json:"status"`
+	GasUsed uint64      `json:"gasUsed"`
+	// Error is the error the top level call failed with, if any.
+	Error string `json:"error,omitempty"`
+	// Accounts are the accounts read or written by the tx, sorted by address.
+	Accounts []AccountTrace `json:"accounts"`
+}
+
+// AccountTrace is the state of an account that was accessed. Reads are the
+// values before the tx, and writes are the values after the tx that differ.
+type AccountTrace struct {
+	Address      common.Address `json:"address"`
+	Read         AccountState   `json:"read"`
+	Written      *AccountState  `json:"written,omitempty"`
+	StorageRead  []StorageSlot  `json:"storageRead,omitempty"`
+	StorageWrite []StorageSlot  `json:"storageWritten,omitempty"`
+}
+
+type AccountState struct {
+	Balance  *hexutil.Big `json:"balance"`
+	Nonce    uint64       `json:"nonce"`
+	CodeHash common.Hash  `json:"codeHash"`
+}
+
+type StorageSlot struct {
+	Key   common.Hash `json:"key"`
+	Value common.Hash `json:"value"`
+}
+
+// blockTracer records the state accessed by the txs of a block.
+type blockTracer struct {
+	state *state.StateDB
+
+	// State accessed by the current tx
+	accounts map[common.Address]*AccountState
+	storage  map[common.Address]map[common.Hash]common.Hash
+	written  map[common.Address]map[common.Hash]struct{}
+	err      error
+}
+
+func newBlockTracer(statedb *state.StateDB) *blockTracer {
+	return &blockTracer{state: statedb}
+}
+
+// startTx must be called before each tx is applied with the accounts that are
+// modified before the EVM runs.
+func (t *blockTracer) startTx(addrs ...common.Address) {
+	t.accounts = make(map[common.Address]*AccountState)
+	t.storage = make(map[common.Address]map[common.Hash]common.Hash)
+	t.written = make(map[common.Address]map[common.Hash]struct{})
+	t.err = nil
+	for _, addr := range addrs {
+		t.readAccount(addr)
+	}
+}
+
+// endTx returns the accounts accessed since [startTx], sorted by address.
+func (t *blockTracer) endTx() []AccountTrace {
+	addrs := make([]common.Address, 0, len(t.accounts))
+	for addr := range t.accounts {
+		addrs = append(addrs, addr)
+	}
+	sortAddresses(addrs)
+
+	traces := make([]AccountTrace, len(addrs))
+	for i, addr := range addrs {
+		read := t.accounts[addr]
+		trace := AccountTrace{
+			Address:     addr,
+			Read:        *read,
+			StorageRead: sortedSlots(t.storage[addr]),
+		}
+		if written := t.accountState(addr); !accountStatesEqual(read, written) {
+			trace.Written = written
+		}
+		if keys := t.written[addr]; len(keys) > 0 {
+			values := make(map[common.Hash]common.Hash, len(keys))
+			for key := range keys {
+				values[key] = t.state.GetState(addr, key)
+			}
+			trace.StorageWrite = sortedSlots(values)
+		}
+		traces[i] = trace
+	}
+	return traces
+}
+
+func (t *blockTracer) CaptureTxStart(uint64) {}
+
+func (t *blockTracer) CaptureTxEnd(uint64) {}
+
+func (t *blockTracer) CaptureStart(_ *vm.EVM, _ common.Address, to common.Address, _ bool, _ []byte, _ uint64, value *big.Int) {
+	// [to] was already credited with [value] if it wasn't read before the tx.
+	t.readAccountBefore(to, value)
+}
+
+func (t *blockTracer) CaptureEnd(_ []byte, _ uint64, _ time.Duration, err error) {
+	if err != nil {
+		t.err = err
+	}
+}
+
+func (t *blockTracer) CaptureEnter(_ vm.OpCode, _ common.Address, to common.Address, _ []byte, _ uint64, value *big.Int) {
+	t.readAccountBefore(to, value)
+}
+
+func (t *blockTracer) CaptureExit([]byte, uint64, error) {}
+
+func (t *blockTracer) CaptureState(_ uint64, op vm.OpCode, _, _ uint64, scope *vm.ScopeContext, _ []byte, _ int, _ error) {
+	stackData := scope.Stack.Data()
+	stackLen := len(stackData)
+	switch {
+	case stackLen >= 1 && op == vm.SLOAD:
+		t.readStorage(scope.Contract.Address(), common.Hash(stackData[stackLen-1].Bytes32()))
+	case stackLen >= 1 && op == vm.SSTORE:
+		addr := scope.Contract.Address()
+		key := common.Hash(stackData[stackLen-1].Bytes32())
+		t.readStorage(addr, key)
+		if _, ok := t.written[addr]; !ok {
+			t.written[addr] = make(map[common.Hash]struct{})
+		}
+		t.written[addr][key] = struct{}{}
+	case stackLen >= 1 && (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE || op == vm.SELFDESTRUCT):
+		t.readAccount(common.Address(stackData[stackLen-1].Bytes20()))
+	case stackLen >= 5 && (op == vm.DELEGATECALL || op == vm.CALL || op == vm.STATICCALL || op == vm.CALLCODE):
+		t.readAccount(common.Address(stackData[stackLen-2].Bytes20()))
+	case op == vm.CREATE:
+		addr := scope.Contract.Address()
+		t.readAccount(crypto.CreateAddress(addr, t.state.GetNonce(addr)))
+	case stackLen >= 4 && op == vm.CREATE2:
+		offset := stackData[stackLen-2]
+		size := stackData[stackLen-3]
+		init := scope.Memory.GetCopy(int64(offset.Uint64()), int64(size.Uint64()))
+		salt := stackData[stackLen-4]
+		t.readAccount(crypto.CreateAddress2(scope.Contract.Address(), salt.Bytes32(), crypto.Keccak256(init)))
+	}
+}
+
+func (t *blockTracer) CaptureFault(uint64, vm.OpCode, uint64, uint64, *vm.ScopeContext, int, error) {}
+
+func (t *blockTracer) readAccount(addr common.Address) {
+	t.readAccountBefore(addr, nil)
+}
+
+// readAccountBefore records the state of [addr] before [value] was transferred
+// to it, unless it was already recorded.
+func (t *blockTracer) readAccountBefore(addr common.Address, value *big.Int) {
+	if _, ok := t.accounts[addr]; ok {
+		return
+	}
+	account := t.accountState(addr)
+	if value != nil {
+		account.Balance = (*hexutil.Big)(new(big.Int).Sub(account.Balance.ToInt(), value))
+	}
+	t.accounts[addr] = account
+}
+
+func (t *blockTracer) readStorage(addr common.Address, key common.Hash) {
+	t.readAccount(addr)
+	slots, ok := t.storage[addr]
+	if !ok {
+		slots = make(map[common.Hash]common.Hash)
+		t.storage[addr] = slots
+	}
+	if _, ok := slots[key]; ok {
+		return
+	}
+	if _, ok := t.written[addr][key]; ok {
+		// The value before the tx was overwritten.
+		return
+	}
+	slots[key] = t.state.GetState(addr, key)
+}
+
+func (t *blockTracer) accountState(addr common.Address) *AccountState {
+	return &AccountState{
+		Balance:  (*hexutil.Big)(new(big.Int).Set(t.state.GetBalance(addr))),
+		Nonce:    t.state.GetNonce(addr),
+		CodeHash: t.state.GetCodeHash(addr),
+	}
+}
+
+func accountStatesEqual(a, b *AccountState) bool {
+	return a.Balance.ToInt().Cmp(b.Balance.ToInt()) == 0 && a.Nonce == b.Nonce && a.CodeHash == b.CodeHash
+}
+
+// traceAcceptedBlock re-executes the accepted block at [height] on the state of
+// its parent. The VM's state, including the atomic trie, isn't modified.
+func (vm *VM) traceAcceptedBlock(height uint64) (*BlockTrace, error) {
+	lastAccepted := vm.blockChain.LastAcceptedBlock().NumberU64()
+	switch {
+	case height == 0:
+		return nil, errGenesisNotTraceable
+	case height > lastAccepted:
+		return nil, fmt.Errorf("%w: height %d > last accepted height %d", errBlockNotAccepted, height, lastAccepted)
+	}
+	block := vm.blockChain.GetBlockByNumber(height)
+	if block == nil {
+		return nil, fmt.Errorf("couldn't find block at height %d", height)
+	}
+	parent := vm.blockChain.GetBlock(block.ParentHash(), height-1)
+	if parent == nil {
+		return nil, fmt.Errorf("couldn't find parent %s of block %s", block.ParentHash(), block.Hash())
+	}
+	statedb, err := vm.eth.StateAtBlock(parent, blockTraceReexec, nil, true, false)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get state of parent %s: %w", parent.Hash(), err)
+	}
+	rules := vm.chainConfig.AvalancheRules(block.Number(), new(big.Int).SetUint64(block.Time()))
+	atomicTxs, err := ExtractAtomicTxs(block.ExtData(), rules.IsApricotPhase5, vm.codec)
+	if err != nil {
+		return nil, err
+	}
+	return traceBlock(vm.ctx, vm.chainConfig, vm.blockChain, block, parent.Header(), atomicTxs, statedb)
+}
+
+// traceBlock re-executes [block], with the [atomicTxs] it contains, on
+// [statedb], the state of its parent, and returns the trace of the execution.
+// [statedb] is modified but never committed.
+func traceBlock(
+	ctx *snow.Context,
+	config *params.ChainConfig,
+	chain core.ChainContext,
+	block *types.Block,
+	parent *types.Header,
+	atomicTxs []*Tx,
+	statedb *state.StateDB,
+) (*BlockTrace, error) {
+	var (
+		header    = block.Header()
+		tracer    = newBlockTracer(statedb)
+		vmConfig  = vm.Config{Debug: true, Tracer: tracer}
+		gp        = new(core.GasPool).AddGas(block.GasLimit())
+		usedGas   = new(uint64)
+		timestamp = new(big.Int).SetUint64(header.Time)
+		signer    = types.MakeSigner(config, header.Number, timestamp)
+		trace     = &BlockTrace{
+			Number:     block.NumberU64(),
+			Hash:       block.Hash(),
+			ParentHash: block.ParentHash(),
+			StateRoot:  block.Root(),
+			Txs:        make([]*TxTrace, 0, len(block.Transactions())),
+			AtomicTxs:  make([]string, 0, len(atomicTxs)),
+		}
+	)
+
+	// Mirrors [core.StateProcessor.Process], which can't be used as the
+	// consensus engine would insert the atomic txs of the block into the
+	// atomic trie again.
+	config.CheckConfigurePrecompiles(new(big.Int).SetUint64(parent.Time), block, statedb)
+	for i, tx := range block.Transactions() {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not recover sender of tx %d [%s]: %w", i, tx.Hash().Hex(), err)
+		}
+		// The sender pays for gas and the coinbase is paid outside of the EVM.
+		tracer.startTx(from, header.Coinbase)
+		statedb.Prepare(tx.Hash(), i)
+		receipt, err := core.ApplyTransaction(config, chain, nil, gp, statedb, header, tx, usedGas, vmConfig)
+		if err != nil {
+			return nil, fmt.Errorf("could not apply tx %d [%s]: %w", i, tx.Hash().Hex(), err)
+		}
+		txTrace := &TxTrace{
+			Hash:     tx.Hash(),
+			Status:   receipt.Status,
+			GasUsed:  receipt.GasUsed,
+			Accounts: tracer.endTx(),
+		}
+		if tracer.err != nil {
+			txTrace.Error = tracer.err.Error()
+		}
+		trace.Txs = append(trace.Txs, txTrace)
+	}
+	trace.GasUsed = *usedGas
+
+	// Atomic txs change the state outside of the EVM, so the accounts they
+	// change are read up front.
+	tracer.startTx()
+	for _, tx := range atomicTxs {
+		switch utx := tx.UnsignedAtomicTx.(type) {
+		case *UnsignedImportTx:
+			for _, out := range utx.Outs {
+				tracer.readAccount(out.Address)
+			}
+		case *UnsignedExportTx:
+			for _, in := range utx.Ins {
+				tracer.readAccount(in.Address)
+			}
+		}
+		trace.AtomicTxs = append(trace.AtomicTxs, tx.ID().String())
+		if err := tx.UnsignedAtomicTx.EVMStateTransfer(ctx, statedb); err != nil {
+			return nil, fmt.Errorf("could not apply atomic tx %s: %w", tx.ID(), err)
+		}
+	}
+	trace.AtomicWrites = tracer.endTx()
+
+	trace.ExecutedStateRoot = statedb.IntermediateRoot(config.IsEIP158(header.Number))
+	return trace, nil
+}
+
+func sortAddresses(addrs []common.Address) {
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i][:], addrs[j][:]) < 0
+	})
+}
+
+// sortedSlots returns [slots] sorted by key.
+func sortedSlots(slots map[common.Hash]common.Hash) []StorageSlot {
+	sorted := make([]StorageSlot, 0, len(slots))
+	for key, value := range slots {
+		sorted = append(sorted, StorageSlot{Key: key, Value: value})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].Key[:], sorted[j].Key[:]) < 0
+	})
+	return sorted
+}
@@ -0,0 +1,109 @@
+// (c) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ava-labs/avalanchego/ids"
+	"github.com/ava-labs/avalanchego/utils/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ava-labs/coreth/core"
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/params"
+)
+
+func TestTraceAcceptedBlock(t *testing.T) {
+	require := require.New(t)
+
+	importAmount := uint64(20000000)
+	issuer, vm, _, _, _ := GenesisVMWithUTXOs(t, true, genesisJSONApricotPhase2, "", "", map[ids.ShortID]uint64{
+		testShortIDAddrs[0]: importAmount,
+	})
+	defer func() {
+		require.NoError(vm.Shutdown())
+	}()
+
+	newTxPoolHeadChan := make(chan core.NewTxPoolReorgEvent, 1)
+	vm.txPool.SubscribeNewReorgEvent(newTxPoolHeadChan)
+
+	importTx, err := vm.newImportTx(vm.ctx.XChainID, testEthAddrs[0], initialBaseFee, []*crypto.PrivateKeySECP256K1R{testKeys[0]})
+	require.NoError(err)
+	require.NoError(vm.issueTx(importTx, true /*=local*/))
+	<-issuer
+
+	blk1, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk1.Verify())
+	require.NoError(vm.SetPreference(blk1.ID()))
+	require.NoError(blk1.Accept())
+	<-newTxPoolHeadChan
+
+	tx := types.NewTransaction(0, testEthAddrs[1], big.NewInt(10), 21000, big.NewInt(params.LaunchMinGasPrice), nil)
+	signedTx, err := types.SignTx(tx, types.NewEIP155Signer(vm.chainID), testKeys[0].ToECDSA())
+	require.NoError(err)
+	for _, err := range vm.txPool.AddRemotesSync([]*types.Transaction{signedTx}) {
+		require.NoError(err)
+	}
+	<-issuer
+
+	blk2, err := vm.BuildBlock()
+	require.NoError(err)
+	require.NoError(blk2.Verify())
+	require.NoError(vm.SetPreference(blk2.ID()))
+	require.NoError(blk2.Accept())
+	vm.blockChain.DrainAcceptorQueue()
+
+	// The atomic tx of the first block credits the imported funds.
+	trace, err := vm.traceAcceptedBlock(1)
+	require.NoError(err)
+	require.Equal(trace.StateRoot, trace.ExecutedStateRoot)
+	require.Empty(trace.Txs)
+	require.Equal([]string{importTx.ID().String()}, trace.AtomicTxs)
+	require.Len(trace.AtomicWrites, 1)
+	require.Equal(testEthAddrs[0], trace.AtomicWrites[0].Address)
+	require.Zero(trace.AtomicWrites[0].Read.Balance.ToInt().Sign())
+	require.NotNil(trace.AtomicWrites[0].Written)
+
+	trace, err = vm.traceAcceptedBlock(2)
+	require.NoError(err)
+	require.Equal(common.Hash(blk2.ID()), trace.Hash)
+	require.Equal(trace.StateRoot, trace.ExecutedStateRoot)
+	require.Len(trace.Txs, 1)
+	txTrace := trace.Txs[0]
+	require.Equal(signedTx.Hash(), txTrace.Hash)
+	require.Equal(types.ReceiptStatusSuccessful, txTrace.Status)
+	require.Equal(uint64(21000), txTrace.GasUsed)
+
+	accounts := make(map[common.Address]AccountTrace)
+	for _, account := range txTrace.Accounts {
+		accounts[account.Address] = account
+	}
+	sender := accounts[testEthAddrs[0]]
+	require.Zero(sender.Read.Nonce)
+	require.NotNil(sender.Written)
+	require.Equal(uint64(1), sender.Written.Nonce)
+	recipient := accounts[testEthAddrs[1]]
+	require.Zero(recipient.Read.Balance.ToInt().Sign())
+	require.NotNil(recipient.Written)
+	require.Equal(big.NewInt(10), recipient.Written.Balance.ToInt())
+
+	// Re-executing the block again produces an identical trace.
+	traceBytes, err := json.Marshal(trace)
+	require.NoError(err)
+	trace, err = vm.traceAcceptedBlock(2)
+	require.NoError(err)
+	retraceBytes, err := json.Marshal(trace)
+	require.NoError(err)
+	require.Equal(traceBytes, retraceBytes)
+
+	_, err = vm.traceAcceptedBlock(0)
+	require.ErrorIs(err, errGenesisNotTraceable)
+	_, err = vm.traceAcceptedBlock(3)
+	require.ErrorIs(err, errBlockNotAccepted)
+}
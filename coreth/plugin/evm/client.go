@@ -27,6 +27,7 @@ type Client interface {
 	GetAtomicTxStatus(ctx context.Context, txID ids.ID) (Status, error)
 	GetAtomicTx(ctx context.Context, txID ids.ID) ([]byte, error)
 	GetAtomicUTXOs(ctx context.Context, addrs []string, sourceChain string, limit uint32, startAddress, startUTXOID string) ([][]byte, api.Index, error)
+	GetAtomicTxsByAddress(ctx context.Context, addr string, startHeight uint64, limit uint32) ([][]byte, uint64, error)
 	ListAddresses(ctx context.Context, userPass api.UserPass) ([]string, error)
 	ExportKey(ctx context.Context, userPass api.UserPass, addr string) (*crypto.PrivateKeySECP256K1R, string, error)
 	ImportKey(ctx context.Context, userPass api.UserPass, privateKey *crypto.PrivateKeySECP256K1R) (string, error)
@@ -39,6 +40,7 @@ type Client interface {
 	LockProfile(ctx context.Context) error
 	SetLogLevel(ctx context.Context, level log.Lvl) error
 	GetVMConfig(ctx context.Context) (*Config, error)
+	ExportBlockTrace(ctx context.Context, blockHeight uint64) (*ExportBlockTraceReply, error)
 }
 
 // Client implementation for interacting with EVM [chain]
@@ -126,6 +128,32 @@ func (c *client) GetAtomicUTXOs(ctx context.Context, addrs []string, sourceChain
 	return utxos, res.EndIndex, nil
 }
 
+// GetAtomicTxsByAddress returns the byte representation of the accepted atomic
+// txs touching [addr] from [startHeight] onwards, and the height to continue
+// fetching from
+func (c *client) GetAtomicTxsByAddress(ctx context.Context, addr string, startHeight uint64, limit uint32) ([][]byte, uint64, error) {
+	res := &GetAtomicTxsByAddressReply{}
+	err := c.requester.SendRequest(ctx, "getAtomicTxsByAddress", &GetAtomicTxsByAddressArgs{
+		Address:     addr,
+		StartHeight: cjson.Uint64(startHeight),
+		Limit:       cjson.Uint32(limit),
+		Encoding:    formatting.Hex,
+	}, res)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	txs := make([][]byte, len(res.Txs))
+	for i, tx := range res.Txs {
+		b, err := formatting.Decode(formatting.Hex, tx.Tx)
+		if err != nil {
+			return nil, 0, err
+		}
+		txs[i] = b
+	}
+	return txs, uint64(res.NextHeight), nil
+}
+
 // ListAddresses returns all addresses on this chain controlled by [user]
 func (c *client) ListAddresses(ctx context.Context, user api.UserPass) ([]string, error) {
 	res := &api.JSONAddresses{}
@@ -228,3 +256,13 @@ func (c *client) GetVMConfig(ctx context.Context) (*Config, error) {
 	err := c.adminRequester.SendRequest(ctx, "getVMConfig", struct{}{}, res)
 	return res.Config, err
 }
+
+// ExportBlockTrace re-executes the accepted block at [blockHeight] and writes
+// the trace of the execution to a file on the node
+func (c *client) ExportBlockTrace(ctx context.Context, blockHeight uint64) (*ExportBlockTraceReply, error) {
+	res := &ExportBlockTraceReply{}
+	err := c.adminRequester.SendRequest(ctx, "exportBlockTrace", &ExportBlockTraceArgs{
+		BlockHeight: cjson.Uint64(blockHeight),
+	}, res)
+	return res, err
+}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/server"
+	"github.com/ava-labs/avalanchego/utils/units"
 	"github.com/ava-labs/coreth/eth"
 	"github.com/spf13/cast"
 )
@@ -35,6 +37,7 @@ const (
 	defaultPopulateMissingTriesParallelism        = 1024
 	defaultMaxOutboundActiveRequests              = 16
 	defaultStateSyncServerTrieCache               = 64 // MB
+	defaultArchiveMinDiskSpace             uint64 = 1024 * units.GiB
 
 	// defaultStateSyncMinBlocks is the minimum number of blocks the blockchain
 	// should be ahead of local last accepted to perform state sync.
@@ -90,6 +93,7 @@ type Config struct {
 	AcceptorQueueLimit              int     `json:"accepted-queue-limit"`               // Maximum blocks to queue before blocking during acceptance
 	CommitInterval                  uint64  `json:"commit-interval"`                    // Specifies the commit interval at which to persist EVM and atomic tries.
 	AllowMissingTries               bool    `json:"allow-missing-tries"`                // If enabled, warnings preventing an incomplete trie index are suppressed
+	ArchiveMinDiskSpace             uint64  `json:"archive-min-disk-space"`             // Disk space (bytes) an archival node should have available at startup. Disables the check if 0.
 	PopulateMissingTries            *uint64 `json:"populate-missing-tries,omitempty"`   // Sets the starting point for re-populating missing tries. Disables re-generation if nil.
 	PopulateMissingTriesParallelism int     `json:"populate-missing-tries-parallelism"` // Number of concurrent readers to use when re-populating missing tries on startup.
 
@@ -105,6 +109,16 @@ type Config struct {
 	AllowUnfinalizedQueries bool     `json:"allow-unfinalized-queries"`
 	AllowUnprotectedTxs     bool     `json:"allow-unprotected-txs"`
 
+	// APIRateLimits limits the calls each client IP may make to this chain's
+	// RPC API, in addition to the node's HTTP rate limits. On the websocket
+	// API, only opening a connection counts against the per-IP limit; the
+	// calls made over it are limited by ws-cpu-refill-rate and ws-cpu-max-stored.
+	APIRateLimits server.RateLimiterConfig `json:"api-rate-limits"`
+
+	// AtomicTxAddressIndexEnabled indexes atomic txs accepted from now on by
+	// the addresses they touch, serving avax.getAtomicTxsByAddress
+	AtomicTxAddressIndexEnabled bool `json:"atomic-tx-address-index-enabled"`
+
 	// Keystore Settings
 	KeystoreDirectory             string `json:"keystore-directory"` // both absolute and relative supported
 	KeystoreExternalSigner        string `json:"keystore-external-signer"`
@@ -114,6 +128,9 @@ type Config struct {
 	RemoteTxGossipOnlyEnabled bool     `json:"remote-tx-gossip-only-enabled"`
 	TxRegossipFrequency       Duration `json:"tx-regossip-frequency"`
 	TxRegossipMaxSize         int      `json:"tx-regossip-max-size"`
+	// TxBroadcastOnlyEnabled gossips the txs submitted through the APIs
+	// without adding them to the tx pool, for nodes that never build blocks.
+	TxBroadcastOnlyEnabled bool `json:"tx-broadcast-only-enabled"`
 
 	// Log
 	LogLevel      string `json:"log-level"`
@@ -146,7 +163,8 @@ func (c Config) EthBackendSettings() eth.Settings {
 }
 
 func (c *Config) SetDefaults() {
-	c.EnabledEthAPIs = defaultEnabledAPIs
+	// Copy the defaults, as unmarshalling a config reuses the slice.
+	c.EnabledEthAPIs = append([]string(nil), defaultEnabledAPIs...)
 	c.RPCGasCap = defaultRpcGasCap
 	c.RPCTxFeeCap = defaultRpcTxFeeCap
 	c.MetricsExpensiveEnabled = defaultMetricsExpensiveEnabled
@@ -170,6 +188,7 @@ func (c *Config) SetDefaults() {
 	c.CommitInterval = defaultCommitInterval
 	c.StateSyncCommitInterval = defaultSyncableCommitInterval
 	c.StateSyncMinBlocks = defaultStateSyncMinBlocks
+	c.ArchiveMinDiskSpace = defaultArchiveMinDiskSpace
 }
 
 func (d *Duration) UnmarshalJSON(data []byte) (err error) {
@@ -208,5 +227,70 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("cannot use commit interval of 0 with pruning enabled")
 	}
 
+	if c.TxBroadcastOnlyEnabled && c.RemoteTxGossipOnlyEnabled {
+		return fmt.Errorf("cannot enable tx broadcast only mode while only gossiping remote txs")
+	}
+
+	if err := c.APIRateLimits.Verify(); err != nil {
+		return fmt.Errorf("invalid api rate limits: %w", err)
+	}
+
 	return nil
 }
+
+// CheckCompatibility cross-checks the options of a valid config for
+// combinations that would otherwise only fail, or misbehave, long after the
+// chain started. Combinations that can't work return an error. Otherwise,
+// options that can't take effect are downgraded and a warning is returned for
+// each downgrade and each combination with a surprising effect.
+// [availableDiskSpace] is the space available to the database, or 0 if
+// unknown.
+func (c *Config) CheckCompatibility(availableDiskSpace uint64) ([]string, error) {
+	if c.OfflinePruning && len(c.OfflinePruningDataDirectory) == 0 {
+		return nil, fmt.Errorf("cannot run offline pruning without an offline pruning data directory: set offline-pruning-data-directory")
+	}
+
+	var warnings []string
+	if !c.Pruning {
+		if c.StateSyncEnabled {
+			c.StateSyncEnabled = false
+			warnings = append(warnings, "disabled state sync as pruning is disabled: a state synced node doesn't have the historical state an archival node serves")
+		}
+		if c.AllowMissingTries {
+			warnings = append(warnings, "allowing missing tries while pruning is disabled: historical state queries may fail with missing trie node errors, set populate-missing-tries to regenerate them")
+		}
+		if c.ArchiveMinDiskSpace != 0 && availableDiskSpace != 0 && availableDiskSpace < c.ArchiveMinDiskSpace {
+			warnings = append(warnings, fmt.Sprintf("only %d bytes of disk space are available while pruning is disabled: an archival node is expected to need at least %d bytes, free up disk space or set pruning-enabled to true", availableDiskSpace, c.ArchiveMinDiskSpace))
+		}
+	}
+	if c.Pruning && c.ethAPIEnabled("debug-tracer") {
+		warnings = append(warnings, fmt.Sprintf("debug-tracer is enabled while pruning is enabled: state is only kept every %d blocks, so tracing older blocks may fail to regenerate the state, set pruning-enabled to false to trace the full history", c.CommitInterval))
+	}
+	return warnings, nil
+}
+
+func (c *Config) ethAPIEnabled(name string) bool {
+	for _, api := range c.EnabledEthAPIs {
+		if api == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckConfig parses [configBytes] as a C-chain config and checks it the way
+// the VM does at startup. It allows the node to report a misconfigured C-chain
+// before the chain is created.
+func CheckConfig(configBytes []byte, availableDiskSpace uint64) ([]string, error) {
+	var config Config
+	config.SetDefaults()
+	if len(configBytes) > 0 {
+		if err := json.Unmarshal(configBytes, &config); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal config %s: %w", string(configBytes), err)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config.CheckCompatibility(availableDiskSpace)
+}
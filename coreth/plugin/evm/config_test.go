@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ava-labs/avalanchego/api/server"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,6 +57,15 @@ func TestUnmarshalConfig(t *testing.T) {
 			Config{StateSyncIDs: "NodeID-CaBYJ9kzHvrQFiYWowMkJGAQKGMJqZoat"},
 			false,
 		},
+		{
+			"api rate limits",
+			[]byte(`{"api-rate-limits": {"perIP": {"rate": 10, "burst": 20}, "methods": {"eth_getLogs": {"rate": 0.5, "burst": 1}}}}`),
+			Config{APIRateLimits: server.RateLimiterConfig{
+				PerIP:   server.RateLimit{Rate: 10, Burst: 20},
+				Methods: map[string]server.RateLimit{"eth_getLogs": {Rate: 0.5, Burst: 1}},
+			}},
+			false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,3 +81,109 @@ func TestUnmarshalConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckCompatibility(t *testing.T) {
+	tests := []struct {
+		name               string
+		givenJSON          string
+		availableDiskSpace uint64
+		expectedWarnings   int
+		expectedErr        bool
+		check              func(*testing.T, Config)
+	}{
+		{
+			name:      "defaults",
+			givenJSON: `{}`,
+		},
+		{
+			name:             "state sync on archival node",
+			givenJSON:        `{"pruning-enabled":false, "state-sync-enabled":true}`,
+			expectedWarnings: 1,
+			check: func(t *testing.T, c Config) {
+				assert.False(t, c.StateSyncEnabled)
+			},
+		},
+		{
+			name:      "state sync on pruning node",
+			givenJSON: `{"state-sync-enabled":true}`,
+			check: func(t *testing.T, c Config) {
+				assert.True(t, c.StateSyncEnabled)
+			},
+		},
+		{
+			name:             "missing tries on archival node",
+			givenJSON:        `{"pruning-enabled":false, "allow-missing-tries":true}`,
+			expectedWarnings: 1,
+		},
+		{
+			name:               "archival node low on disk space",
+			givenJSON:          `{"pruning-enabled":false, "archive-min-disk-space":1000}`,
+			availableDiskSpace: 999,
+			expectedWarnings:   1,
+		},
+		{
+			name:               "archival node with enough disk space",
+			givenJSON:          `{"pruning-enabled":false, "archive-min-disk-space":1000}`,
+			availableDiskSpace: 1000,
+		},
+		{
+			name:      "archival node with unknown disk space",
+			givenJSON: `{"pruning-enabled":false}`,
+		},
+		{
+			name:               "disk space check disabled",
+			givenJSON:          `{"pruning-enabled":false, "archive-min-disk-space":0}`,
+			availableDiskSpace: 1,
+		},
+		{
+			name:             "tracer on pruning node",
+			givenJSON:        `{"eth-apis":["eth","debug-tracer"]}`,
+			expectedWarnings: 1,
+		},
+		{
+			name:      "tracer on archival node",
+			givenJSON: `{"pruning-enabled":false, "eth-apis":["eth","debug-tracer"]}`,
+		},
+		{
+			name:        "offline pruning without data directory",
+			givenJSON:   `{"offline-pruning-enabled":true}`,
+			expectedErr: true,
+		},
+		{
+			name:      "offline pruning with data directory",
+			givenJSON: `{"offline-pruning-enabled":true, "offline-pruning-data-directory":"/tmp/pruning"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config Config
+			config.SetDefaults()
+			assert.NoError(t, json.Unmarshal([]byte(tt.givenJSON), &config))
+			assert.NoError(t, config.Validate())
+
+			warnings, err := config.CheckCompatibility(tt.availableDiskSpace)
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, warnings, tt.expectedWarnings)
+			if tt.check != nil {
+				tt.check(t, config)
+			}
+		})
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	_, err := CheckConfig([]byte(`{"pruning-enabled":false, "offline-pruning-enabled":true}`), 0)
+	assert.Error(t, err)
+
+	warnings, err := CheckConfig([]byte(`{"pruning-enabled":false}`), 1)
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+
+	warnings, err = CheckConfig(nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+}
@@ -44,6 +44,9 @@ type Gossiper interface {
 	GossipAtomicTxs(txs []*Tx) error
 	// GossipEthTxs sends AppGossip message containing the given [txs]
 	GossipEthTxs(txs []*types.Transaction) error
+	// BroadcastEthTxs immediately sends AppGossip message containing the given
+	// [txs], which aren't in the tx pool
+	BroadcastEthTxs(txs []*types.Transaction) error
 }
 
 // pushGossiper is used to gossip transactions to the network
@@ -371,18 +374,20 @@ func (n *pushGossiper) gossipEthTxs(force bool) (int, error) {
 		selectedTxs = append(selectedTxs, tx)
 	}
 
-	if len(selectedTxs) == 0 {
-		return 0, nil
-	}
-
 	// Attempt to gossip [selectedTxs]
+	return len(selectedTxs), n.sendEthTxsBatched(selectedTxs)
+}
+
+// sendEthTxsBatched gossips [txs] in as few messages as allowed by
+// [message.EthMsgSoftCapSize].
+func (n *pushGossiper) sendEthTxsBatched(txs []*types.Transaction) error {
 	msgTxs := make([]*types.Transaction, 0)
 	msgTxsSize := common.StorageSize(0)
-	for _, tx := range selectedTxs {
+	for _, tx := range txs {
 		size := tx.Size()
 		if msgTxsSize+size > message.EthMsgSoftCapSize {
 			if err := n.sendEthTxs(msgTxs); err != nil {
-				return len(selectedTxs), err
+				return err
 			}
 			msgTxs = msgTxs[:0]
 			msgTxsSize = 0
@@ -392,7 +397,7 @@ func (n *pushGossiper) gossipEthTxs(force bool) (int, error) {
 	}
 
 	// Send any remaining [msgTxs]
-	return len(selectedTxs), n.sendEthTxs(msgTxs)
+	return n.sendEthTxs(msgTxs)
 }
 
 // GossipEthTxs enqueues the provided [txs] for gossiping. At some point, the
@@ -417,6 +422,25 @@ func (n *pushGossiper) GossipEthTxs(txs []*types.Transaction) error {
 	return nil
 }
 
+// BroadcastEthTxs gossips [txs] right away. Unlike [GossipEthTxs], [txs] are
+// not expected to be in the tx pool, so they are never regossiped.
+func (n *pushGossiper) BroadcastEthTxs(txs []*types.Transaction) error {
+	if time.Now().Before(n.gossipActivationTime) {
+		return errEthTxGossipInactive
+	}
+
+	selectedTxs := make([]*types.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		txHash := tx.Hash()
+		if _, has := n.recentEthTxs.Get(txHash); has {
+			continue
+		}
+		n.recentEthTxs.Put(txHash, nil)
+		selectedTxs = append(selectedTxs, tx)
+	}
+	return n.sendEthTxsBatched(selectedTxs)
+}
+
 // GossipHandler handles incoming gossip messages
 type GossipHandler struct {
 	vm            *VM
@@ -545,3 +569,6 @@ func (n *noopGossiper) GossipAtomicTxs([]*Tx) error {
 func (n *noopGossiper) GossipEthTxs([]*types.Transaction) error {
 	return nil
 }
+func (n *noopGossiper) BroadcastEthTxs([]*types.Transaction) error {
+	return errEthTxGossipInactive
+}
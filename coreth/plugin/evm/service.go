@@ -28,6 +28,8 @@ const (
 
 	// Max number of addresses that can be passed in as argument to GetUTXOs
 	maxGetUTXOsAddrs = 1024
+	// Max number of txs returned by GetAtomicTxsByAddress
+	maxGetAtomicTxsByAddressLimit = 1024
 )
 
 var (
@@ -35,6 +37,7 @@ var (
 	errNoSourceChain     = errors.New("no source chain provided")
 	errNilTxID           = errors.New("nil transaction ID")
 	errMissingPrivateKey = errors.New("argument 'privateKey' not given")
+	errAddressIndexOff   = errors.New("atomic tx address index is not enabled")
 
 	initialBaseFee = big.NewInt(params.ApricotPhase3InitialBaseFee)
 )
@@ -484,3 +487,84 @@ func (service *AvaxAPI) GetAtomicTx(r *http.Request, args *api.GetTxArgs, reply
 	}
 	return nil
 }
+
+// GetAtomicTxsByAddressArgs are the arguments for GetAtomicTxsByAddress
+type GetAtomicTxsByAddressArgs struct {
+	// Address is either a hex encoded EVM address or a bech32 address of an
+	// exported output's owner
+	Address     string              `json:"address"`
+	StartHeight json.Uint64         `json:"startHeight"`
+	Limit       json.Uint32         `json:"limit"`
+	Encoding    formatting.Encoding `json:"encoding"`
+}
+
+// AddressAtomicTx is an atomic tx returned by GetAtomicTxsByAddress
+type AddressAtomicTx struct {
+	TxID ids.ID `json:"txID"`
+	FormattedTx
+}
+
+// GetAtomicTxsByAddressReply is the response from GetAtomicTxsByAddress
+type GetAtomicTxsByAddressReply struct {
+	Txs []AddressAtomicTx `json:"txs"`
+	// NextHeight is the height to continue fetching from
+	NextHeight json.Uint64 `json:"nextHeight"`
+}
+
+// GetAtomicTxsByAddress returns the accepted atomic txs that send funds from
+// or to [args.Address], starting at [args.StartHeight]. Only txs accepted
+// while the atomic tx address index was enabled are returned.
+func (service *AvaxAPI) GetAtomicTxsByAddress(r *http.Request, args *GetAtomicTxsByAddressArgs, reply *GetAtomicTxsByAddressReply) error {
+	log.Info("EVM: GetAtomicTxsByAddress called", "address", args.Address, "startHeight", args.StartHeight)
+
+	if service.vm.atomicTxAddressIndex == nil {
+		return errAddressIndexOff
+	}
+
+	var addr ids.ShortID
+	if common.IsHexAddress(args.Address) {
+		addr = ids.ShortID(common.HexToAddress(args.Address))
+	} else {
+		var err error
+		_, addr, err = service.vm.ParseAddress(args.Address)
+		if err != nil {
+			return fmt.Errorf("couldn't parse address %q: %w", args.Address, err)
+		}
+	}
+
+	limit := int(args.Limit)
+	if limit <= 0 || limit > maxGetAtomicTxsByAddressLimit {
+		limit = maxGetAtomicTxsByAddressLimit
+	}
+
+	entries, err := service.vm.atomicTxAddressIndex.Get(addr, uint64(args.StartHeight), limit)
+	if err != nil {
+		return err
+	}
+
+	reply.Txs = make([]AddressAtomicTx, len(entries))
+	reply.NextHeight = args.StartHeight
+	for i, entry := range entries {
+		tx, _, err := service.vm.atomicTxRepository.GetByTxID(entry.txID)
+		if err != nil {
+			return fmt.Errorf("couldn't get atomic tx %s: %w", entry.txID, err)
+		}
+		txBytes, err := formatting.Encode(args.Encoding, tx.SignedBytes())
+		if err != nil {
+			return err
+		}
+		height := json.Uint64(entry.height)
+		reply.Txs[i] = AddressAtomicTx{
+			TxID: entry.txID,
+			FormattedTx: FormattedTx{
+				FormattedTx: api.FormattedTx{
+					Tx:       txBytes,
+					Encoding: args.Encoding,
+				},
+				BlockHeight: &height,
+			},
+		}
+		reply.NextHeight = height + 1
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// (c) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ava-labs/coreth/core/types"
+	"github.com/ava-labs/coreth/metrics"
+)
+
+// localTxValidator checks txs submitted through the APIs against the current
+// state.
+type localTxValidator interface {
+	ValidateLocal(tx *types.Transaction) error
+}
+
+// txBroadcaster gossips the txs submitted through the APIs without adding them
+// to the tx pool. Nodes that never build blocks use it to avoid holding the
+// txs they serve in memory. As the txs aren't kept, they are never regossiped.
+type txBroadcaster struct {
+	validator localTxValidator
+	gossiper  Gossiper
+
+	sent    metrics.Counter
+	invalid metrics.Counter
+	failed  metrics.Counter
+}
+
+func newTxBroadcaster(validator localTxValidator, gossiper Gossiper) *txBroadcaster {
+	return &txBroadcaster{
+		validator: validator,
+		gossiper:  gossiper,
+		sent:      metrics.GetOrRegisterCounter("tx_broadcast_only_sent", nil),
+		invalid:   metrics.GetOrRegisterCounter("tx_broadcast_only_invalid", nil),
+		failed:    metrics.GetOrRegisterCounter("tx_broadcast_only_failed", nil),
+	}
+}
+
+// broadcast validates [tx] and gossips it.
+func (b *txBroadcaster) broadcast(tx *types.Transaction) error {
+	if err := b.validator.ValidateLocal(tx); err != nil {
+		b.invalid.Inc(1)
+		return err
+	}
+	if err := b.gossiper.BroadcastEthTxs([]*types.Transaction{tx}); err != nil {
+		b.failed.Inc(1)
+		log.Debug("failed to broadcast eth tx", "txHash", tx.Hash(), "err", err)
+		return err
+	}
+	b.sent.Inc(1)
+	return nil
+}
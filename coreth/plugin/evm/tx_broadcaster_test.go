@@ -0,0 +1,62 @@
+// (c) 2022, Ava Labs, Inc. All rights reserved.
+// See the file LICENSE for licensing terms.
+
+package evm
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ava-labs/coreth/core/types"
+)
+
+type testLocalTxValidator struct {
+	err error
+}
+
+func (v *testLocalTxValidator) ValidateLocal(*types.Transaction) error { return v.err }
+
+type testBroadcastGossiper struct {
+	noopGossiper
+
+	err         error
+	broadcasted []*types.Transaction
+}
+
+func (g *testBroadcastGossiper) BroadcastEthTxs(txs []*types.Transaction) error {
+	if g.err != nil {
+		return g.err
+	}
+	g.broadcasted = append(g.broadcasted, txs...)
+	return nil
+}
+
+func TestTxBroadcaster(t *testing.T) {
+	assert := assert.New(t)
+
+	validator := &testLocalTxValidator{}
+	gossiper := &testBroadcastGossiper{}
+	b := newTxBroadcaster(validator, gossiper)
+	sent, invalid, failed := b.sent.Count(), b.invalid.Count(), b.failed.Count()
+
+	tx := types.NewTransaction(0, common.Address{1}, big.NewInt(1), 21000, big.NewInt(1), nil)
+	assert.NoError(b.broadcast(tx))
+	assert.Equal([]*types.Transaction{tx}, gossiper.broadcasted)
+	assert.Equal(sent+1, b.sent.Count())
+
+	// Invalid txs aren't gossiped.
+	validator.err = errors.New("invalid tx")
+	assert.ErrorIs(b.broadcast(tx), validator.err)
+	assert.Len(gossiper.broadcasted, 1)
+	assert.Equal(invalid+1, b.invalid.Count())
+
+	// Gossip failures are reported to the client.
+	validator.err = nil
+	gossiper.err = errEthTxGossipInactive
+	assert.ErrorIs(b.broadcast(tx), errEthTxGossipInactive)
+	assert.Equal(failed+1, b.failed.Count())
+}
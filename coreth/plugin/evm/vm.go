@@ -18,6 +18,7 @@ import (
 	"time"
 
 	avalanchegoMetrics "github.com/ava-labs/avalanchego/api/metrics"
+	"github.com/ava-labs/avalanchego/api/server"
 
 	"github.com/ava-labs/coreth/consensus/dummy"
 	corethConstants "github.com/ava-labs/coreth/constants"
@@ -91,8 +92,9 @@ const (
 	x2cRateMinus1Int64 int64 = x2cRateInt64 - 1
 
 	// Prefixes for metrics gatherers
-	ethMetricsPrefix        = "eth"
-	chainStateMetricsPrefix = "chain_state"
+	ethMetricsPrefix         = "eth"
+	chainStateMetricsPrefix  = "chain_state"
+	rateLimiterMetricsPrefix = "api_rate_limiter"
 )
 
 var (
@@ -177,6 +179,8 @@ var (
 	errInvalidExtraStateRoot          = errors.New("invalid ExtraStateRoot")
 	errImportTxsDisabled              = errors.New("import transactions are disabled")
 	errExportTxsDisabled              = errors.New("export transactions are disabled")
+	errEthTxGossipInactive            = errors.New("eth tx gossip isn't activated")
+	errNotBootstrapped                = errors.New("node is not bootstrapped")
 )
 
 var originalStderr *os.File
@@ -253,6 +257,9 @@ type VM struct {
 	atomicTrie AtomicTrie
 	// [atomicBackend] abstracts verification and processing of atomic transactions
 	atomicBackend AtomicBackend
+	// [atomicTxAddressIndex] maintains an index of address to accepted atomic
+	// txs. It is nil unless enabled in the config.
+	atomicTxAddressIndex *atomicTxAddressIndex
 
 	builder *blockBuilder
 
@@ -351,6 +358,14 @@ func (vm *VM) Initialize(
 	}
 	vm.logger = corethLogger
 
+	warnings, err := vm.config.CheckCompatibility(0)
+	if err != nil {
+		return err
+	}
+	for _, warning := range warnings {
+		log.Warn("incompatible config options", "warning", warning)
+	}
+
 	log.Info("Initializing Coreth VM", "Version", Version, "Config", vm.config)
 
 	if len(fxs) > 0 {
@@ -510,6 +525,9 @@ func (vm *VM) Initialize(
 		return fmt.Errorf("failed to create atomic backend: %w", err)
 	}
 	vm.atomicTrie = vm.atomicBackend.AtomicTrie()
+	if vm.config.AtomicTxAddressIndexEnabled {
+		vm.atomicTxAddressIndex = newAtomicTxAddressIndex(vm.db)
+	}
 
 	go vm.ctx.Log.RecoverAndPanic(vm.startContinuousProfiler)
 
@@ -567,6 +585,12 @@ func (vm *VM) initializeChain(lastAcceptedHash common.Hash) error {
 		return err
 	}
 	vm.eth.SetEtherbase(corethConstants.BlackholeAddr)
+	if vm.config.TxBroadcastOnlyEnabled {
+		// Txs can't be gossiped until the node is bootstrapped.
+		vm.eth.SetLocalTxSubmitter(func(*types.Transaction) error {
+			return errNotBootstrapped
+		})
+	}
 	vm.txPool = vm.eth.TxPool()
 	vm.blockChain = vm.eth.BlockChain()
 	vm.miner = vm.eth.Miner()
@@ -959,6 +983,9 @@ func (vm *VM) initBlockBuilding() {
 	// NOTE: gossip network must be initialized first otherwise ETH tx gossip will not work.
 	gossipStats := NewGossipStats()
 	vm.gossiper = vm.createGossiper(gossipStats)
+	if vm.config.TxBroadcastOnlyEnabled {
+		vm.eth.SetLocalTxSubmitter(newTxBroadcaster(vm.txPool, vm.gossiper).broadcast)
+	}
 	vm.builder = vm.NewBlockBuilder(vm.toEngine)
 	vm.builder.awaitSubmittedTxs()
 	vm.Network.SetGossipHandler(NewGossipHandler(vm, gossipStats))
@@ -1169,7 +1196,12 @@ func (vm *VM) CreateHandlers() (map[string]*commonEng.HTTPHandler, error) {
 	apis[avaxEndpoint] = avaxAPI
 
 	if vm.config.CorethAdminAPIEnabled {
-		adminAPI, err := newHandler("admin", NewAdminService(vm, os.ExpandEnv(fmt.Sprintf("%s_coreth_performance_%s", vm.config.CorethAdminAPIDir, primaryAlias))))
+		adminService := NewAdminService(
+			vm,
+			os.ExpandEnv(fmt.Sprintf("%s_coreth_performance_%s", vm.config.CorethAdminAPIDir, primaryAlias)),
+			os.ExpandEnv(fmt.Sprintf("%s_coreth_block_traces_%s", vm.config.CorethAdminAPIDir, primaryAlias)),
+		)
+		adminAPI, err := newHandler("admin", adminService)
 		if err != nil {
 			return nil, fmt.Errorf("failed to register service for admin API due to %w", err)
 		}
@@ -1184,19 +1216,31 @@ func (vm *VM) CreateHandlers() (map[string]*commonEng.HTTPHandler, error) {
 		enabledAPIs = append(enabledAPIs, "snowman")
 	}
 
+	rateLimiterRegisterer := prometheus.NewRegistry()
+	rateLimiter, err := server.NewRateLimiter(vm.config.APIRateLimits, "", rateLimiterRegisterer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API rate limiter due to %w", err)
+	}
+	if err := vm.multiGatherer.Register(rateLimiterMetricsPrefix, rateLimiterRegisterer); err != nil {
+		return nil, err
+	}
+
 	log.Info(fmt.Sprintf("Enabled APIs: %s", strings.Join(enabledAPIs, ", ")))
 	apis[ethRPCEndpoint] = &commonEng.HTTPHandler{
 		LockOptions: commonEng.NoLock,
-		Handler:     handler,
+		Handler:     rateLimiter.WrapHandler(handler),
 	}
+	// The rate limiter only sees the request that opens a websocket, so it
+	// limits the connections each client IP may open. The calls made over an
+	// open connection are limited by the websocket CPU limits instead.
 	apis[ethWSEndpoint] = &commonEng.HTTPHandler{
 		LockOptions: commonEng.NoLock,
-		Handler: handler.WebsocketHandlerWithDuration(
+		Handler: rateLimiter.WrapHandler(handler.WebsocketHandlerWithDuration(
 			[]string{"*"},
 			vm.config.APIMaxDuration.Duration,
 			vm.config.WSCPURefillRate.Duration,
 			vm.config.WSCPUMaxStored.Duration,
-		),
+		)),
 	}
 
 	return apis, nil